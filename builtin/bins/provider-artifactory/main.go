@@ -0,0 +1,14 @@
+package main
+
+import (
+	"github.com/hashicorp/terraform/builtin/providers/artifactory"
+	"github.com/hashicorp/terraform/plugin"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func main() {
+	plugin.Serve(&plugin.ServeOpts{
+		ProviderFunc: artifactory.Provider,
+		Version:      terraform.VersionString(),
+	})
+}
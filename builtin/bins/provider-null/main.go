@@ -11,5 +11,6 @@ func main() {
 		ProviderFunc: func() terraform.ResourceProvider {
 			return null.Provider()
 		},
+		Version: terraform.VersionString(),
 	})
 }
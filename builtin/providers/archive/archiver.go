@@ -0,0 +1,49 @@
+package archive
+
+import (
+	"fmt"
+	"os"
+)
+
+// Archiver writes an archive of a file, a directory, or some content to
+// its output path.
+type Archiver interface {
+	ArchiveContent(content []byte, name string) error
+	ArchiveFile(path string) error
+	ArchiveDir(path string) error
+}
+
+// archivers are the factories of the archivers of each archive type.
+var archivers = map[string]func(outputPath string) Archiver{
+	"zip": func(outputPath string) Archiver {
+		return &ZipArchiver{OutputPath: outputPath}
+	},
+}
+
+// getArchiver returns the archiver of the given type, which writes to
+// the given path.
+func getArchiver(archiveType, outputPath string) (Archiver, error) {
+	f, ok := archivers[archiveType]
+	if !ok {
+		return nil, fmt.Errorf("unsupported archive type %q: must be zip", archiveType)
+	}
+
+	return f(outputPath), nil
+}
+
+// checkSource returns an error if the file or directory doesn't exist or
+// isn't of the expected kind.
+func checkSource(path string, dir bool) (os.FileInfo, error) {
+	fi, err := os.Stat(path)
+	if err != nil {
+		return nil, fmt.Errorf("could not archive missing source %s: %s", path, err)
+	}
+	if fi.IsDir() != dir {
+		if dir {
+			return nil, fmt.Errorf("source %s is not a directory", path)
+		}
+		return nil, fmt.Errorf("source %s is a directory", path)
+	}
+
+	return fi, nil
+}
@@ -0,0 +1,133 @@
+package archive
+
+import (
+	"crypto/sha1"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"io/ioutil"
+	"os"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func resourceFile() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceFileCreate,
+		Read:   resourceFileRead,
+		Delete: resourceFileDelete,
+
+		Schema: map[string]*schema.Schema{
+			"type": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"source_content": &schema.Schema{
+				Type:          schema.TypeString,
+				Optional:      true,
+				ForceNew:      true,
+				ConflictsWith: []string{"source_file", "source_dir"},
+			},
+
+			"source_content_filename": &schema.Schema{
+				Type:          schema.TypeString,
+				Optional:      true,
+				ForceNew:      true,
+				ConflictsWith: []string{"source_file", "source_dir"},
+			},
+
+			"source_file": &schema.Schema{
+				Type:          schema.TypeString,
+				Optional:      true,
+				ForceNew:      true,
+				ConflictsWith: []string{"source_content", "source_dir"},
+			},
+
+			"source_dir": &schema.Schema{
+				Type:          schema.TypeString,
+				Optional:      true,
+				ForceNew:      true,
+				ConflictsWith: []string{"source_content", "source_file"},
+			},
+
+			"output_path": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"output_size": &schema.Schema{
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+
+			"output_sha": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"output_base64sha256": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceFileCreate(d *schema.ResourceData, meta interface{}) error {
+	return resourceFileRead(d, meta)
+}
+
+// resourceFileRead writes the archive again, since the sources may have
+// changed since it was last written. Reading happens on every refresh,
+// so a plan has the hashes of the current sources, and the resources
+// that use them are updated when the sources change.
+func resourceFileRead(d *schema.ResourceData, meta interface{}) error {
+	outputPath := d.Get("output_path").(string)
+	archiver, err := getArchiver(d.Get("type").(string), outputPath)
+	if err != nil {
+		return err
+	}
+
+	if dir, ok := d.GetOk("source_dir"); ok {
+		err = archiver.ArchiveDir(dir.(string))
+	} else if file, ok := d.GetOk("source_file"); ok {
+		err = archiver.ArchiveFile(file.(string))
+	} else if name, ok := d.GetOk("source_content_filename"); ok {
+		content := d.Get("source_content").(string)
+		err = archiver.ArchiveContent([]byte(content), name.(string))
+	} else {
+		return fmt.Errorf(
+			"one of source_dir, source_file, or source_content and " +
+				"source_content_filename must be set")
+	}
+	if err != nil {
+		return fmt.Errorf("error archiving to %s: %s", outputPath, err)
+	}
+
+	data, err := ioutil.ReadFile(outputPath)
+	if err != nil {
+		return fmt.Errorf("error reading %s: %s", outputPath, err)
+	}
+	sha1Sum := sha1.Sum(data)
+	sha256Sum := sha256.Sum256(data)
+
+	d.SetId(hex.EncodeToString(sha1Sum[:]))
+	d.Set("output_size", len(data))
+	d.Set("output_sha", hex.EncodeToString(sha1Sum[:]))
+	d.Set("output_base64sha256", base64.StdEncoding.EncodeToString(sha256Sum[:]))
+	return nil
+}
+
+func resourceFileDelete(d *schema.ResourceData, meta interface{}) error {
+	outputPath := d.Get("output_path").(string)
+	if err := os.Remove(outputPath); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("error removing %s: %s", outputPath, err)
+	}
+
+	d.SetId("")
+	return nil
+}
@@ -0,0 +1,100 @@
+package archive
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestResourceFile(t *testing.T) {
+	td := tempDir(t)
+	defer os.RemoveAll(td)
+
+	source := filepath.Join(td, "src")
+	testWriteFile(t, filepath.Join(source, "index.js"), "v1")
+
+	output := filepath.Join(td, "lambda.zip")
+	r := resourceFile()
+	s, err := schema.TestResourceApply(r, map[string]interface{}{
+		"type":        "zip",
+		"source_dir":  source,
+		"output_path": output,
+	})
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	testZipContents(t, output, map[string]string{"index.js": "v1"})
+	if s.ID == "" || s.ID != s.Attributes["output_sha"] {
+		t.Fatalf("bad: %#v", s)
+	}
+	if s.Attributes["output_base64sha256"] == "" {
+		t.Fatalf("bad: %#v", s.Attributes)
+	}
+
+	// Refreshing without changes keeps the hashes
+	refreshed, err := r.Refresh(s, nil)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if refreshed.Attributes["output_sha"] != s.Attributes["output_sha"] {
+		t.Fatalf("bad: %#v", refreshed.Attributes)
+	}
+
+	// Refreshing after the sources changed archives them again
+	testWriteFile(t, filepath.Join(source, "index.js"), "v2")
+	refreshed, err = r.Refresh(s, nil)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if refreshed.Attributes["output_base64sha256"] == s.Attributes["output_base64sha256"] {
+		t.Fatalf("bad: %#v", refreshed.Attributes)
+	}
+	testZipContents(t, output, map[string]string{"index.js": "v2"})
+
+	if _, err := r.Apply(refreshed, &terraform.InstanceDiff{Destroy: true}, nil); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if _, err := os.Stat(output); !os.IsNotExist(err) {
+		t.Fatalf("archive should be removed: %s", err)
+	}
+}
+
+func TestResourceFile_content(t *testing.T) {
+	td := tempDir(t)
+	defer os.RemoveAll(td)
+
+	output := filepath.Join(td, "config.zip")
+	_, err := schema.TestResourceApply(resourceFile(), map[string]interface{}{
+		"type":                    "zip",
+		"source_content":          "{}",
+		"source_content_filename": "config.json",
+		"output_path":             output,
+	})
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	testZipContents(t, output, map[string]string{"config.json": "{}"})
+}
+
+func TestResourceFile_invalid(t *testing.T) {
+	td := tempDir(t)
+	defer os.RemoveAll(td)
+
+	output := filepath.Join(td, "out.zip")
+	cases := []map[string]interface{}{
+		{"type": "tar", "source_dir": td, "output_path": output},
+		{"type": "zip", "output_path": output},
+		{"type": "zip", "source_file": filepath.Join(td, "nope"), "output_path": output},
+	}
+
+	for i, raw := range cases {
+		if _, err := schema.TestResourceApply(resourceFile(), raw); err == nil {
+			t.Fatalf("%d: should error", i)
+		}
+	}
+}
@@ -0,0 +1,160 @@
+package archive
+
+import (
+	"archive/zip"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// zipModTime is the time of every file in the archives. The times of the
+// source files aren't kept, so that the same content always gives the
+// same archive and the same hash, however the files were checked out.
+var zipModTime = time.Date(1980, 1, 1, 0, 0, 0, 0, time.UTC)
+
+// ZipArchiver is an Archiver that writes zip files.
+type ZipArchiver struct {
+	OutputPath string
+
+	file   *os.File
+	writer *zip.Writer
+}
+
+func (a *ZipArchiver) ArchiveContent(content []byte, name string) error {
+	if err := a.open(); err != nil {
+		return err
+	}
+
+	w, err := a.create(name, 0644)
+	if err == nil {
+		_, err = w.Write(content)
+	}
+
+	return a.close(err)
+}
+
+func (a *ZipArchiver) ArchiveFile(path string) error {
+	fi, err := checkSource(path, false)
+	if err != nil {
+		return err
+	}
+
+	if err := a.open(); err != nil {
+		return err
+	}
+
+	return a.close(a.copy(path, fi.Name(), fi.Mode()))
+}
+
+func (a *ZipArchiver) ArchiveDir(path string) error {
+	if _, err := checkSource(path, true); err != nil {
+		return err
+	}
+
+	if err := a.open(); err != nil {
+		return err
+	}
+
+	// The archive may be written to the directory it archives, but it
+	// doesn't archive itself.
+	output, err := filepath.Abs(a.OutputPath)
+	if err != nil {
+		return a.close(err)
+	}
+
+	// Walk visits the files in lexical order, so the archive is the same
+	// for the same files.
+	err = filepath.Walk(path, func(p string, fi os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if fi.IsDir() {
+			return nil
+		}
+		if abs, err := filepath.Abs(p); err == nil && abs == output {
+			return nil
+		}
+
+		rel, err := filepath.Rel(path, p)
+		if err != nil {
+			return err
+		}
+
+		return a.copy(p, filepath.ToSlash(rel), fi.Mode())
+	})
+
+	return a.close(err)
+}
+
+// open creates the output file, and the directory it is in.
+func (a *ZipArchiver) open() error {
+	if dir := filepath.Dir(a.OutputPath); dir != "" {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return fmt.Errorf("error creating directory for %s: %s", a.OutputPath, err)
+		}
+	}
+
+	f, err := os.Create(a.OutputPath)
+	if err != nil {
+		return fmt.Errorf("error creating %s: %s", a.OutputPath, err)
+	}
+
+	a.file = f
+	a.writer = zip.NewWriter(f)
+	return nil
+}
+
+// close finishes the archive and closes the output file, returning the
+// given error of writing the archive, if any, or else the error of
+// closing it.
+func (a *ZipArchiver) close(err error) error {
+	if cerr := a.writer.Close(); err == nil && cerr != nil {
+		err = fmt.Errorf("error writing %s: %s", a.OutputPath, cerr)
+	}
+	if cerr := a.file.Close(); err == nil && cerr != nil {
+		err = fmt.Errorf("error writing %s: %s", a.OutputPath, cerr)
+	}
+
+	a.writer = nil
+	a.file = nil
+	return err
+}
+
+// create adds a file to the archive, returning the writer of its content.
+func (a *ZipArchiver) create(name string, mode os.FileMode) (io.Writer, error) {
+	fh := &zip.FileHeader{
+		Name:   name,
+		Method: zip.Deflate,
+	}
+	fh.SetModTime(zipModTime)
+	fh.SetMode(mode)
+
+	w, err := a.writer.CreateHeader(fh)
+	if err != nil {
+		return nil, fmt.Errorf("error adding %s to archive: %s", name, err)
+	}
+
+	return w, nil
+}
+
+// copy adds the file at the given path to the archive with the given name.
+func (a *ZipArchiver) copy(path, name string, mode os.FileMode) error {
+	w, err := a.create(name, mode)
+	if err != nil {
+		return err
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("error reading %s: %s", path, err)
+	}
+	defer f.Close()
+
+	if _, err := io.Copy(w, f); err != nil {
+		return fmt.Errorf("error adding %s to archive: %s", path, err)
+	}
+
+	return nil
+}
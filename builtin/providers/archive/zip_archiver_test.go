@@ -0,0 +1,152 @@
+package archive
+
+import (
+	"archive/zip"
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestZipArchiver_content(t *testing.T) {
+	td := tempDir(t)
+	defer os.RemoveAll(td)
+
+	output := filepath.Join(td, "out", "content.zip")
+	archiver := &ZipArchiver{OutputPath: output}
+	if err := archiver.ArchiveContent([]byte("hello"), "hello.txt"); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	testZipContents(t, output, map[string]string{
+		"hello.txt": "hello",
+	})
+}
+
+func TestZipArchiver_file(t *testing.T) {
+	td := tempDir(t)
+	defer os.RemoveAll(td)
+
+	source := filepath.Join(td, "index.js")
+	testWriteFile(t, source, "exports.handler = function() {}")
+
+	output := filepath.Join(td, "file.zip")
+	archiver := &ZipArchiver{OutputPath: output}
+	if err := archiver.ArchiveFile(source); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	testZipContents(t, output, map[string]string{
+		"index.js": "exports.handler = function() {}",
+	})
+
+	if err := archiver.ArchiveFile(td); err == nil {
+		t.Fatal("should error for a directory")
+	}
+	if err := archiver.ArchiveFile(filepath.Join(td, "nope")); err == nil {
+		t.Fatal("should error for a missing file")
+	}
+}
+
+func TestZipArchiver_dir(t *testing.T) {
+	td := tempDir(t)
+	defer os.RemoveAll(td)
+
+	testWriteFile(t, filepath.Join(td, "a.txt"), "a")
+	testWriteFile(t, filepath.Join(td, "lib", "b.txt"), "b")
+
+	// The archive is in the directory it archives
+	output := filepath.Join(td, "dir.zip")
+	archiver := &ZipArchiver{OutputPath: output}
+	if err := archiver.ArchiveDir(td); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	testZipContents(t, output, map[string]string{
+		"a.txt":     "a",
+		"lib/b.txt": "b",
+	})
+
+	if err := archiver.ArchiveDir(filepath.Join(td, "a.txt")); err == nil {
+		t.Fatal("should error for a file")
+	}
+}
+
+func TestZipArchiver_stable(t *testing.T) {
+	td := tempDir(t)
+	defer os.RemoveAll(td)
+
+	source := filepath.Join(td, "src")
+	testWriteFile(t, filepath.Join(source, "a.txt"), "a")
+
+	output := filepath.Join(td, "out.zip")
+	archiver := &ZipArchiver{OutputPath: output}
+	if err := archiver.ArchiveDir(source); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	first, err := ioutil.ReadFile(output)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	// Only the content of the files matters, not their times
+	testWriteFile(t, filepath.Join(source, "a.txt"), "a")
+	if err := archiver.ArchiveDir(source); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	second, err := ioutil.ReadFile(output)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	if !bytes.Equal(first, second) {
+		t.Fatal("archives of the same content should be the same")
+	}
+}
+
+func tempDir(t *testing.T) string {
+	dir, err := ioutil.TempDir("", "tf")
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	return dir
+}
+
+func testWriteFile(t *testing.T, path, content string) {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if err := ioutil.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+}
+
+func testZipContents(t *testing.T, path string, expected map[string]string) {
+	r, err := zip.OpenReader(path)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	defer r.Close()
+
+	actual := make(map[string]string)
+	for _, f := range r.File {
+		rc, err := f.Open()
+		if err != nil {
+			t.Fatalf("err: %s", err)
+		}
+		data, err := ioutil.ReadAll(rc)
+		rc.Close()
+		if err != nil {
+			t.Fatalf("err: %s", err)
+		}
+
+		actual[f.Name] = string(data)
+	}
+
+	if !reflect.DeepEqual(actual, expected) {
+		t.Fatalf("bad: %#v", actual)
+	}
+}
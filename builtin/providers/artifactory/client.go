@@ -0,0 +1,142 @@
+package artifactory
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/httpapi"
+)
+
+// Client is a client for the REST API of Artifactory.
+type Client struct {
+	api *httpapi.Client
+}
+
+// isNotFound returns whether the error is the API's answer for an object
+// that doesn't exist. Some versions of Artifactory answer a request for
+// a repository that doesn't exist with a bad request.
+func isNotFound(err error) bool {
+	se, ok := err.(*httpapi.StatusError)
+	if !ok {
+		return false
+	}
+
+	return se.Code == http.StatusNotFound ||
+		(se.Code == http.StatusBadRequest && strings.Contains(se.Message, "not exist"))
+}
+
+// Repository is the configuration of a local, remote or virtual
+// repository, as accepted and returned by the API. The fields that only
+// apply to some classes of repositories are left out when they aren't
+// set.
+type Repository struct {
+	Key             string `json:"key"`
+	RClass          string `json:"rclass"`
+	PackageType     string `json:"packageType"`
+	Description     string `json:"description"`
+	Notes           string `json:"notes"`
+	IncludesPattern string `json:"includesPattern"`
+	ExcludesPattern string `json:"excludesPattern"`
+
+	// Local and remote repositories
+	HandleReleases     *bool `json:"handleReleases,omitempty"`
+	HandleSnapshots    *bool `json:"handleSnapshots,omitempty"`
+	MaxUniqueSnapshots *int  `json:"maxUniqueSnapshots,omitempty"`
+
+	// Remote repositories
+	URL                   string `json:"url,omitempty"`
+	Username              string `json:"username,omitempty"`
+	Password              string `json:"password,omitempty"`
+	Offline               *bool  `json:"offline,omitempty"`
+	StoreArtifactsLocally *bool  `json:"storeArtifactsLocally,omitempty"`
+
+	// Virtual repositories
+	Repositories          []string `json:"repositories,omitempty"`
+	DefaultDeploymentRepo string   `json:"defaultDeploymentRepo,omitempty"`
+}
+
+// PermissionTarget is a permission target, which grants permissions on
+// the paths of repositories that match its patterns to users and groups.
+type PermissionTarget struct {
+	Name            string     `json:"name"`
+	IncludesPattern string     `json:"includesPattern"`
+	ExcludesPattern string     `json:"excludesPattern"`
+	Repositories    []string   `json:"repositories"`
+	Principals      Principals `json:"principals"`
+}
+
+// Principals maps the names of users and groups to their permissions,
+// such as "r" for read and "w" for deploy.
+type Principals struct {
+	Users  map[string][]string `json:"users,omitempty"`
+	Groups map[string][]string `json:"groups,omitempty"`
+}
+
+// Repository returns the repository with the given key.
+func (c *Client) Repository(key string) (*Repository, error) {
+	var result Repository
+	if err := c.do("GET", "/api/repositories/"+key, nil, &result); err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// CreateRepository creates the repository.
+func (c *Client) CreateRepository(repo *Repository) error {
+	return c.do("PUT", "/api/repositories/"+repo.Key, repo, nil)
+}
+
+// UpdateRepository changes the configuration of the repository.
+func (c *Client) UpdateRepository(repo *Repository) error {
+	return c.do("POST", "/api/repositories/"+repo.Key, repo, nil)
+}
+
+// DeleteRepository deletes the repository with the given key, along with
+// its artifacts.
+func (c *Client) DeleteRepository(key string) error {
+	return c.do("DELETE", "/api/repositories/"+key, nil, nil)
+}
+
+// PermissionTarget returns the permission target with the given name.
+func (c *Client) PermissionTarget(name string) (*PermissionTarget, error) {
+	var result PermissionTarget
+	if err := c.do("GET", "/api/security/permissions/"+name, nil, &result); err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// SavePermissionTarget creates the permission target, or replaces the
+// one with the same name.
+func (c *Client) SavePermissionTarget(pt *PermissionTarget) error {
+	return c.do("PUT", "/api/security/permissions/"+pt.Name, pt, nil)
+}
+
+// DeletePermissionTarget deletes the permission target with the given
+// name.
+func (c *Client) DeletePermissionTarget(name string) error {
+	return c.do("DELETE", "/api/security/permissions/"+name, nil, nil)
+}
+
+func (c *Client) do(method, path string, in, out interface{}) error {
+	// Some successful requests answer with plain text, which is ignored
+	return c.api.Do(method, path, in, out)
+}
+
+// errorMessage returns the message of the first error in the body of a
+// failed answer.
+func errorMessage(body []byte) string {
+	var status struct {
+		Errors []struct {
+			Message string `json:"message"`
+		} `json:"errors"`
+	}
+	if err := json.Unmarshal(body, &status); err != nil || len(status.Errors) == 0 {
+		return ""
+	}
+
+	return status.Errors[0].Message
+}
@@ -0,0 +1,44 @@
+package artifactory
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/httpapi"
+)
+
+type Config struct {
+	URL      string
+	Username string
+	Password string
+	APIKey   string
+}
+
+// Client returns a new client for the Artifactory REST API.
+func (c *Config) Client() (*Client, error) {
+	u, err := url.Parse(c.URL)
+	if err != nil || u.Scheme == "" || u.Host == "" {
+		return nil, fmt.Errorf("Invalid Artifactory URL %q", c.URL)
+	}
+	if c.APIKey == "" && c.Username == "" {
+		return nil, fmt.Errorf(
+			"Either api_key, or username and password must be set for Artifactory")
+	}
+
+	log.Printf("[INFO] Artifactory client configured for %s", c.URL)
+	api := &httpapi.Client{
+		BaseURL:      strings.TrimSuffix(c.URL, "/"),
+		Name:         "Artifactory",
+		ErrorMessage: errorMessage,
+	}
+	if c.APIKey != "" {
+		api.Header = http.Header{"X-Jfrog-Art-Api": []string{c.APIKey}}
+	} else {
+		api.Username, api.Password = c.Username, c.Password
+	}
+
+	return &Client{api: api}, nil
+}
@@ -0,0 +1,64 @@
+package artifactory
+
+import (
+	"log"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+// Provider returns a terraform.ResourceProvider.
+func Provider() terraform.ResourceProvider {
+	return &schema.Provider{
+		Schema: map[string]*schema.Schema{
+			"url": &schema.Schema{
+				Type:        schema.TypeString,
+				Required:    true,
+				DefaultFunc: schema.EnvDefaultFunc("ARTIFACTORY_URL", nil),
+				Description: "The URL of Artifactory, such as https://example.com/artifactory.",
+			},
+
+			"username": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("ARTIFACTORY_USERNAME", ""),
+				Description: "The username of an admin user.",
+			},
+
+			"password": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("ARTIFACTORY_PASSWORD", ""),
+				Description: "The password of the admin user.",
+			},
+
+			"api_key": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("ARTIFACTORY_API_KEY", ""),
+				Description: "The API key of an admin user, instead of a username and password.",
+			},
+		},
+
+		ResourcesMap: map[string]*schema.Resource{
+			"artifactory_local_repository":   resourceArtifactoryLocalRepository(),
+			"artifactory_permission_target":  resourceArtifactoryPermissionTarget(),
+			"artifactory_remote_repository":  resourceArtifactoryRemoteRepository(),
+			"artifactory_virtual_repository": resourceArtifactoryVirtualRepository(),
+		},
+
+		ConfigureFunc: providerConfigure,
+	}
+}
+
+func providerConfigure(d *schema.ResourceData) (interface{}, error) {
+	config := Config{
+		URL:      d.Get("url").(string),
+		Username: d.Get("username").(string),
+		Password: d.Get("password").(string),
+		APIKey:   d.Get("api_key").(string),
+	}
+
+	log.Println("[INFO] Initializing Artifactory client")
+	return config.Client()
+}
@@ -0,0 +1,139 @@
+package artifactory
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+// repositorySchema returns the schema of the settings that all classes of
+// repositories have, along with the given fields of the resource.
+func repositorySchema(fields map[string]*schema.Schema) map[string]*schema.Schema {
+	fields["key"] = &schema.Schema{
+		Type:     schema.TypeString,
+		Required: true,
+		ForceNew: true,
+	}
+	fields["package_type"] = &schema.Schema{
+		Type:     schema.TypeString,
+		Optional: true,
+		ForceNew: true,
+		Default:  "generic",
+	}
+	fields["description"] = &schema.Schema{
+		Type:     schema.TypeString,
+		Optional: true,
+	}
+	fields["notes"] = &schema.Schema{
+		Type:     schema.TypeString,
+		Optional: true,
+	}
+	fields["includes_pattern"] = &schema.Schema{
+		Type:     schema.TypeString,
+		Optional: true,
+		Default:  "**/*",
+	}
+	fields["excludes_pattern"] = &schema.Schema{
+		Type:     schema.TypeString,
+		Optional: true,
+	}
+
+	return fields
+}
+
+// expandRepository returns the repository of the resource, with the
+// settings that all classes of repositories have.
+func expandRepository(d *schema.ResourceData, rclass string) *Repository {
+	return &Repository{
+		Key:             d.Get("key").(string),
+		RClass:          rclass,
+		PackageType:     d.Get("package_type").(string),
+		Description:     d.Get("description").(string),
+		Notes:           d.Get("notes").(string),
+		IncludesPattern: d.Get("includes_pattern").(string),
+		ExcludesPattern: d.Get("excludes_pattern").(string),
+	}
+}
+
+// createRepository creates the repository of the resource, whose ID is its
+// key.
+func createRepository(d *schema.ResourceData, meta interface{}, repo *Repository) error {
+	client := meta.(*Client)
+
+	log.Printf("[DEBUG] Creating Artifactory %s repository: %s", repo.RClass, repo.Key)
+	if err := client.CreateRepository(repo); err != nil {
+		return fmt.Errorf("Error creating Artifactory repository %s: %s", repo.Key, err)
+	}
+
+	d.SetId(repo.Key)
+	log.Printf("[INFO] Artifactory repository created: %s", d.Id())
+	return nil
+}
+
+// readRepository reads the repository of the resource, and sets the
+// settings that all classes of repositories have. It returns nil if the
+// repository is gone.
+func readRepository(d *schema.ResourceData, meta interface{}) (*Repository, error) {
+	client := meta.(*Client)
+
+	repo, err := client.Repository(d.Id())
+	if err != nil {
+		if isNotFound(err) {
+			d.SetId("")
+			return nil, nil
+		}
+		return nil, fmt.Errorf("Error reading Artifactory repository %s: %s", d.Id(), err)
+	}
+
+	d.Set("key", repo.Key)
+	d.Set("package_type", repo.PackageType)
+	d.Set("description", repo.Description)
+	d.Set("notes", repo.Notes)
+	d.Set("includes_pattern", repo.IncludesPattern)
+	d.Set("excludes_pattern", repo.ExcludesPattern)
+
+	return repo, nil
+}
+
+// updateRepository changes the configuration of the repository of the
+// resource.
+func updateRepository(d *schema.ResourceData, meta interface{}, repo *Repository) error {
+	client := meta.(*Client)
+
+	log.Printf("[DEBUG] Updating Artifactory repository: %s", d.Id())
+	if err := client.UpdateRepository(repo); err != nil {
+		return fmt.Errorf("Error updating Artifactory repository %s: %s", d.Id(), err)
+	}
+
+	return nil
+}
+
+func resourceArtifactoryRepositoryDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Client)
+
+	log.Printf("[INFO] Deleting Artifactory repository: %s", d.Id())
+	if err := client.DeleteRepository(d.Id()); err != nil {
+		if isNotFound(err) {
+			return nil
+		}
+		return fmt.Errorf("Error deleting Artifactory repository %s: %s", d.Id(), err)
+	}
+
+	d.SetId("")
+	return nil
+}
+
+func expandStringList(v interface{}) []string {
+	l, ok := v.([]interface{})
+	if !ok || len(l) == 0 {
+		return nil
+	}
+
+	result := make([]string, len(l))
+	for i, v := range l {
+		result[i] = v.(string)
+	}
+
+	return result
+}
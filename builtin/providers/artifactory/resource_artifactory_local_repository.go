@@ -0,0 +1,82 @@
+package artifactory
+
+import (
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func resourceArtifactoryLocalRepository() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceArtifactoryLocalRepositoryCreate,
+		Read:   resourceArtifactoryLocalRepositoryRead,
+		Update: resourceArtifactoryLocalRepositoryUpdate,
+		Delete: resourceArtifactoryRepositoryDelete,
+
+		Schema: repositorySchema(map[string]*schema.Schema{
+			"handle_releases": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+
+			"handle_snapshots": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+
+			"max_unique_snapshots": &schema.Schema{
+				Type:     schema.TypeInt,
+				Optional: true,
+				Default:  0,
+			},
+		}),
+	}
+}
+
+func resourceArtifactoryLocalRepositoryCreate(d *schema.ResourceData, meta interface{}) error {
+	if err := createRepository(d, meta, expandLocalRepository(d)); err != nil {
+		return err
+	}
+
+	return resourceArtifactoryLocalRepositoryRead(d, meta)
+}
+
+func resourceArtifactoryLocalRepositoryRead(d *schema.ResourceData, meta interface{}) error {
+	repo, err := readRepository(d, meta)
+	if err != nil || repo == nil {
+		return err
+	}
+
+	if repo.HandleReleases != nil {
+		d.Set("handle_releases", *repo.HandleReleases)
+	}
+	if repo.HandleSnapshots != nil {
+		d.Set("handle_snapshots", *repo.HandleSnapshots)
+	}
+	if repo.MaxUniqueSnapshots != nil {
+		d.Set("max_unique_snapshots", *repo.MaxUniqueSnapshots)
+	}
+
+	return nil
+}
+
+func resourceArtifactoryLocalRepositoryUpdate(d *schema.ResourceData, meta interface{}) error {
+	if err := updateRepository(d, meta, expandLocalRepository(d)); err != nil {
+		return err
+	}
+
+	return resourceArtifactoryLocalRepositoryRead(d, meta)
+}
+
+func expandLocalRepository(d *schema.ResourceData) *Repository {
+	repo := expandRepository(d, "local")
+
+	handleReleases := d.Get("handle_releases").(bool)
+	handleSnapshots := d.Get("handle_snapshots").(bool)
+	maxUniqueSnapshots := d.Get("max_unique_snapshots").(int)
+	repo.HandleReleases = &handleReleases
+	repo.HandleSnapshots = &handleSnapshots
+	repo.MaxUniqueSnapshots = &maxUniqueSnapshots
+
+	return repo
+}
@@ -0,0 +1,115 @@
+package artifactory
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestAccArtifactoryLocalRepository_basic(t *testing.T) {
+	var repo Repository
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckArtifactoryRepositoryDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccArtifactoryLocalRepositoryConfig_basic,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckArtifactoryRepositoryExists(
+						"artifactory_local_repository.test", &repo),
+					testAccCheckArtifactoryRepositoryClass(&repo, "local"),
+					resource.TestCheckResourceAttr(
+						"artifactory_local_repository.test", "package_type", "maven"),
+					resource.TestCheckResourceAttr(
+						"artifactory_local_repository.test", "handle_snapshots", "false"),
+				),
+			},
+			resource.TestStep{
+				Config: testAccArtifactoryLocalRepositoryConfig_update,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckArtifactoryRepositoryExists(
+						"artifactory_local_repository.test", &repo),
+					resource.TestCheckResourceAttr(
+						"artifactory_local_repository.test", "description", "Updated"),
+					resource.TestCheckResourceAttr(
+						"artifactory_local_repository.test", "handle_snapshots", "true"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckArtifactoryRepositoryExists(n string, repo *Repository) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No repository key is set")
+		}
+
+		client := testAccProvider.Meta().(*Client)
+		result, err := client.Repository(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+
+		*repo = *result
+		return nil
+	}
+}
+
+func testAccCheckArtifactoryRepositoryClass(repo *Repository, rclass string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		if repo.RClass != rclass {
+			return fmt.Errorf("bad rclass: %s", repo.RClass)
+		}
+
+		return nil
+	}
+}
+
+func testAccCheckArtifactoryRepositoryDestroy(s *terraform.State) error {
+	client := testAccProvider.Meta().(*Client)
+
+	for _, rs := range s.RootModule().Resources {
+		switch rs.Type {
+		case "artifactory_local_repository", "artifactory_remote_repository",
+			"artifactory_virtual_repository":
+		default:
+			continue
+		}
+
+		_, err := client.Repository(rs.Primary.ID)
+		if err == nil {
+			return fmt.Errorf("Repository still exists: %s", rs.Primary.ID)
+		}
+		if !isNotFound(err) {
+			return err
+		}
+	}
+
+	return nil
+}
+
+const testAccArtifactoryLocalRepositoryConfig_basic = `
+resource "artifactory_local_repository" "test" {
+	key = "tf-acc-test-local"
+	package_type = "maven"
+	handle_snapshots = false
+}
+`
+
+const testAccArtifactoryLocalRepositoryConfig_update = `
+resource "artifactory_local_repository" "test" {
+	key = "tf-acc-test-local"
+	package_type = "maven"
+	description = "Updated"
+}
+`
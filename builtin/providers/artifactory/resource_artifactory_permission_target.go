@@ -0,0 +1,223 @@
+package artifactory
+
+import (
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/hashcode"
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func resourceArtifactoryPermissionTarget() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceArtifactoryPermissionTargetCreate,
+		Read:   resourceArtifactoryPermissionTargetRead,
+		Update: resourceArtifactoryPermissionTargetUpdate,
+		Delete: resourceArtifactoryPermissionTargetDelete,
+
+		Schema: map[string]*schema.Schema{
+			"name": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"repositories": &schema.Schema{
+				Type:     schema.TypeSet,
+				Required: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Set:      schema.HashString,
+			},
+
+			"includes_pattern": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  "**",
+			},
+
+			"excludes_pattern": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"user":  principalSchema(),
+			"group": principalSchema(),
+		},
+	}
+}
+
+// principalSchema returns the schema of the user or group blocks, which
+// grant permissions to a user or group by name.
+func principalSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeSet,
+		Optional: true,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"name": &schema.Schema{
+					Type:     schema.TypeString,
+					Required: true,
+				},
+
+				"permissions": &schema.Schema{
+					Type:     schema.TypeSet,
+					Required: true,
+					Elem:     &schema.Schema{Type: schema.TypeString},
+					Set:      schema.HashString,
+				},
+			},
+		},
+		Set: resourceArtifactoryPrincipalHash,
+	}
+}
+
+func resourceArtifactoryPermissionTargetCreate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Client)
+
+	pt := expandPermissionTarget(d)
+	log.Printf("[DEBUG] Creating Artifactory permission target: %s", pt.Name)
+	if err := client.SavePermissionTarget(pt); err != nil {
+		return fmt.Errorf("Error creating Artifactory permission target %s: %s", pt.Name, err)
+	}
+
+	d.SetId(pt.Name)
+	log.Printf("[INFO] Artifactory permission target created: %s", d.Id())
+
+	return resourceArtifactoryPermissionTargetRead(d, meta)
+}
+
+func resourceArtifactoryPermissionTargetRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Client)
+
+	pt, err := client.PermissionTarget(d.Id())
+	if err != nil {
+		if isNotFound(err) {
+			d.SetId("")
+			return nil
+		}
+		return fmt.Errorf("Error reading Artifactory permission target %s: %s", d.Id(), err)
+	}
+
+	d.Set("name", pt.Name)
+	d.Set("repositories", pt.Repositories)
+	d.Set("includes_pattern", pt.IncludesPattern)
+	d.Set("excludes_pattern", pt.ExcludesPattern)
+	if err := d.Set("user", flattenPrincipals(pt.Principals.Users)); err != nil {
+		return err
+	}
+	if err := d.Set("group", flattenPrincipals(pt.Principals.Groups)); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func resourceArtifactoryPermissionTargetUpdate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Client)
+
+	log.Printf("[DEBUG] Updating Artifactory permission target: %s", d.Id())
+	if err := client.SavePermissionTarget(expandPermissionTarget(d)); err != nil {
+		return fmt.Errorf("Error updating Artifactory permission target %s: %s", d.Id(), err)
+	}
+
+	return resourceArtifactoryPermissionTargetRead(d, meta)
+}
+
+func resourceArtifactoryPermissionTargetDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Client)
+
+	log.Printf("[INFO] Deleting Artifactory permission target: %s", d.Id())
+	if err := client.DeletePermissionTarget(d.Id()); err != nil {
+		if isNotFound(err) {
+			return nil
+		}
+		return fmt.Errorf("Error deleting Artifactory permission target %s: %s", d.Id(), err)
+	}
+
+	d.SetId("")
+	return nil
+}
+
+// expandPermissionTarget returns the permission target of the resource.
+func expandPermissionTarget(d *schema.ResourceData) *PermissionTarget {
+	pt := &PermissionTarget{
+		Name:            d.Get("name").(string),
+		IncludesPattern: d.Get("includes_pattern").(string),
+		ExcludesPattern: d.Get("excludes_pattern").(string),
+		Principals: Principals{
+			Users:  expandPrincipals(d.Get("user").(*schema.Set).List()),
+			Groups: expandPrincipals(d.Get("group").(*schema.Set).List()),
+		},
+	}
+
+	for _, v := range d.Get("repositories").(*schema.Set).List() {
+		pt.Repositories = append(pt.Repositories, v.(string))
+	}
+	sort.Strings(pt.Repositories)
+
+	return pt
+}
+
+// expandPrincipals returns the permissions of the user or group blocks,
+// by name.
+func expandPrincipals(configured []interface{}) map[string][]string {
+	if len(configured) == 0 {
+		return nil
+	}
+
+	result := make(map[string][]string, len(configured))
+	for _, raw := range configured {
+		m := raw.(map[string]interface{})
+		var permissions []string
+		for _, p := range m["permissions"].(*schema.Set).List() {
+			permissions = append(permissions, p.(string))
+		}
+		sort.Strings(permissions)
+
+		result[m["name"].(string)] = permissions
+	}
+
+	return result
+}
+
+func flattenPrincipals(principals map[string][]string) []map[string]interface{} {
+	names := make([]string, 0, len(principals))
+	for name := range principals {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	result := make([]map[string]interface{}, 0, len(principals))
+	for _, name := range names {
+		result = append(result, map[string]interface{}{
+			"name":        name,
+			"permissions": principals[name],
+		})
+	}
+
+	return result
+}
+
+func resourceArtifactoryPrincipalHash(v interface{}) int {
+	m := v.(map[string]interface{})
+
+	var permissions []string
+	switch p := m["permissions"].(type) {
+	case *schema.Set:
+		for _, v := range p.List() {
+			permissions = append(permissions, v.(string))
+		}
+	case []string:
+		permissions = append(permissions, p...)
+	case []interface{}:
+		for _, v := range p {
+			permissions = append(permissions, v.(string))
+		}
+	}
+	sort.Strings(permissions)
+
+	return hashcode.String(fmt.Sprintf(
+		"%s-%s", m["name"].(string), strings.Join(permissions, ",")))
+}
@@ -0,0 +1,138 @@
+package artifactory
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestAccArtifactoryPermissionTarget_basic(t *testing.T) {
+	var pt PermissionTarget
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckArtifactoryPermissionTargetDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccArtifactoryPermissionTargetConfig,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckArtifactoryPermissionTargetExists(
+						"artifactory_permission_target.test", &pt),
+					func(*terraform.State) error {
+						expected := map[string][]string{"readers": []string{"r"}}
+						if !reflect.DeepEqual(pt.Principals.Groups, expected) {
+							return fmt.Errorf("bad groups: %#v", pt.Principals.Groups)
+						}
+						return nil
+					},
+					resource.TestCheckResourceAttr(
+						"artifactory_permission_target.test", "user.#", "1"),
+				),
+			},
+		},
+	})
+}
+
+func TestExpandPrincipals(t *testing.T) {
+	configured := []interface{}{
+		map[string]interface{}{
+			"name": "deployer",
+			"permissions": schema.NewSet(schema.HashString, []interface{}{
+				"w", "r",
+			}),
+		},
+	}
+
+	expected := map[string][]string{"deployer": []string{"r", "w"}}
+	if actual := expandPrincipals(configured); !reflect.DeepEqual(actual, expected) {
+		t.Fatalf("bad: %#v", actual)
+	}
+
+	if actual := expandPrincipals(nil); actual != nil {
+		t.Fatalf("bad: %#v", actual)
+	}
+}
+
+func TestResourceArtifactoryPrincipalHash(t *testing.T) {
+	a := resourceArtifactoryPrincipalHash(map[string]interface{}{
+		"name": "deployer",
+		"permissions": schema.NewSet(schema.HashString, []interface{}{
+			"w", "r",
+		}),
+	})
+	b := resourceArtifactoryPrincipalHash(map[string]interface{}{
+		"name":        "deployer",
+		"permissions": []string{"r", "w"},
+	})
+	if a != b {
+		t.Fatalf("bad: %d != %d", a, b)
+	}
+}
+
+func testAccCheckArtifactoryPermissionTargetExists(n string, pt *PermissionTarget) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No permission target name is set")
+		}
+
+		client := testAccProvider.Meta().(*Client)
+		result, err := client.PermissionTarget(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+
+		*pt = *result
+		return nil
+	}
+}
+
+func testAccCheckArtifactoryPermissionTargetDestroy(s *terraform.State) error {
+	client := testAccProvider.Meta().(*Client)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "artifactory_permission_target" {
+			continue
+		}
+
+		_, err := client.PermissionTarget(rs.Primary.ID)
+		if err == nil {
+			return fmt.Errorf("Permission target still exists: %s", rs.Primary.ID)
+		}
+		if !isNotFound(err) {
+			return err
+		}
+	}
+
+	return testAccCheckArtifactoryRepositoryDestroy(s)
+}
+
+const testAccArtifactoryPermissionTargetConfig = `
+resource "artifactory_local_repository" "test" {
+	key = "tf-acc-test-local"
+}
+
+resource "artifactory_permission_target" "test" {
+	name = "tf-acc-test"
+	repositories = ["${artifactory_local_repository.test.key}"]
+
+	user {
+		name = "admin"
+		permissions = ["r", "w", "d"]
+	}
+
+	group {
+		name = "readers"
+		permissions = ["r"]
+	}
+}
+`
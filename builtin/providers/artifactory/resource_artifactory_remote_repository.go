@@ -0,0 +1,114 @@
+package artifactory
+
+import (
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func resourceArtifactoryRemoteRepository() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceArtifactoryRemoteRepositoryCreate,
+		Read:   resourceArtifactoryRemoteRepositoryRead,
+		Update: resourceArtifactoryRemoteRepositoryUpdate,
+		Delete: resourceArtifactoryRepositoryDelete,
+
+		Schema: repositorySchema(map[string]*schema.Schema{
+			"url": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+			},
+
+			"username": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			// The password isn't returned by the API
+			"password": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"handle_releases": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+
+			"handle_snapshots": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+
+			"offline": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+
+			"store_artifacts_locally": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+		}),
+	}
+}
+
+func resourceArtifactoryRemoteRepositoryCreate(d *schema.ResourceData, meta interface{}) error {
+	if err := createRepository(d, meta, expandRemoteRepository(d)); err != nil {
+		return err
+	}
+
+	return resourceArtifactoryRemoteRepositoryRead(d, meta)
+}
+
+func resourceArtifactoryRemoteRepositoryRead(d *schema.ResourceData, meta interface{}) error {
+	repo, err := readRepository(d, meta)
+	if err != nil || repo == nil {
+		return err
+	}
+
+	d.Set("url", repo.URL)
+	d.Set("username", repo.Username)
+	if repo.HandleReleases != nil {
+		d.Set("handle_releases", *repo.HandleReleases)
+	}
+	if repo.HandleSnapshots != nil {
+		d.Set("handle_snapshots", *repo.HandleSnapshots)
+	}
+	if repo.Offline != nil {
+		d.Set("offline", *repo.Offline)
+	}
+	if repo.StoreArtifactsLocally != nil {
+		d.Set("store_artifacts_locally", *repo.StoreArtifactsLocally)
+	}
+
+	return nil
+}
+
+func resourceArtifactoryRemoteRepositoryUpdate(d *schema.ResourceData, meta interface{}) error {
+	if err := updateRepository(d, meta, expandRemoteRepository(d)); err != nil {
+		return err
+	}
+
+	return resourceArtifactoryRemoteRepositoryRead(d, meta)
+}
+
+func expandRemoteRepository(d *schema.ResourceData) *Repository {
+	repo := expandRepository(d, "remote")
+	repo.URL = d.Get("url").(string)
+	repo.Username = d.Get("username").(string)
+	repo.Password = d.Get("password").(string)
+
+	handleReleases := d.Get("handle_releases").(bool)
+	handleSnapshots := d.Get("handle_snapshots").(bool)
+	offline := d.Get("offline").(bool)
+	storeArtifactsLocally := d.Get("store_artifacts_locally").(bool)
+	repo.HandleReleases = &handleReleases
+	repo.HandleSnapshots = &handleSnapshots
+	repo.Offline = &offline
+	repo.StoreArtifactsLocally = &storeArtifactsLocally
+
+	return repo
+}
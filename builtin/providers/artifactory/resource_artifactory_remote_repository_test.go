@@ -0,0 +1,38 @@
+package artifactory
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+)
+
+func TestAccArtifactoryRemoteRepository_basic(t *testing.T) {
+	var repo Repository
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckArtifactoryRepositoryDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccArtifactoryRemoteRepositoryConfig,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckArtifactoryRepositoryExists(
+						"artifactory_remote_repository.test", &repo),
+					testAccCheckArtifactoryRepositoryClass(&repo, "remote"),
+					resource.TestCheckResourceAttr(
+						"artifactory_remote_repository.test", "url",
+						"https://repo1.maven.org/maven2/"),
+				),
+			},
+		},
+	})
+}
+
+const testAccArtifactoryRemoteRepositoryConfig = `
+resource "artifactory_remote_repository" "test" {
+	key = "tf-acc-test-remote"
+	package_type = "maven"
+	url = "https://repo1.maven.org/maven2/"
+}
+`
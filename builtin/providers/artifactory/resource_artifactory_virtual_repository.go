@@ -0,0 +1,65 @@
+package artifactory
+
+import (
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func resourceArtifactoryVirtualRepository() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceArtifactoryVirtualRepositoryCreate,
+		Read:   resourceArtifactoryVirtualRepositoryRead,
+		Update: resourceArtifactoryVirtualRepositoryUpdate,
+		Delete: resourceArtifactoryRepositoryDelete,
+
+		Schema: repositorySchema(map[string]*schema.Schema{
+			// The order of the repositories is the order in which they
+			// are searched for artifacts.
+			"repositories": &schema.Schema{
+				Type:     schema.TypeList,
+				Required: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+
+			"default_deployment_repo": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+		}),
+	}
+}
+
+func resourceArtifactoryVirtualRepositoryCreate(d *schema.ResourceData, meta interface{}) error {
+	if err := createRepository(d, meta, expandVirtualRepository(d)); err != nil {
+		return err
+	}
+
+	return resourceArtifactoryVirtualRepositoryRead(d, meta)
+}
+
+func resourceArtifactoryVirtualRepositoryRead(d *schema.ResourceData, meta interface{}) error {
+	repo, err := readRepository(d, meta)
+	if err != nil || repo == nil {
+		return err
+	}
+
+	d.Set("repositories", repo.Repositories)
+	d.Set("default_deployment_repo", repo.DefaultDeploymentRepo)
+
+	return nil
+}
+
+func resourceArtifactoryVirtualRepositoryUpdate(d *schema.ResourceData, meta interface{}) error {
+	if err := updateRepository(d, meta, expandVirtualRepository(d)); err != nil {
+		return err
+	}
+
+	return resourceArtifactoryVirtualRepositoryRead(d, meta)
+}
+
+func expandVirtualRepository(d *schema.ResourceData) *Repository {
+	repo := expandRepository(d, "virtual")
+	repo.Repositories = expandStringList(d.Get("repositories"))
+	repo.DefaultDeploymentRepo = d.Get("default_deployment_repo").(string)
+
+	return repo
+}
@@ -0,0 +1,55 @@
+package artifactory
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+)
+
+func TestAccArtifactoryVirtualRepository_basic(t *testing.T) {
+	var repo Repository
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckArtifactoryRepositoryDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccArtifactoryVirtualRepositoryConfig,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckArtifactoryRepositoryExists(
+						"artifactory_virtual_repository.test", &repo),
+					testAccCheckArtifactoryRepositoryClass(&repo, "virtual"),
+					resource.TestCheckResourceAttr(
+						"artifactory_virtual_repository.test", "repositories.#", "2"),
+					resource.TestCheckResourceAttr(
+						"artifactory_virtual_repository.test", "repositories.0",
+						"tf-acc-test-local"),
+				),
+			},
+		},
+	})
+}
+
+const testAccArtifactoryVirtualRepositoryConfig = `
+resource "artifactory_local_repository" "local" {
+	key = "tf-acc-test-local"
+	package_type = "maven"
+}
+
+resource "artifactory_remote_repository" "remote" {
+	key = "tf-acc-test-remote"
+	package_type = "maven"
+	url = "https://repo1.maven.org/maven2/"
+}
+
+resource "artifactory_virtual_repository" "test" {
+	key = "tf-acc-test-virtual"
+	package_type = "maven"
+	repositories = [
+		"${artifactory_local_repository.local.key}",
+		"${artifactory_remote_repository.remote.key}",
+	]
+	default_deployment_repo = "${artifactory_local_repository.local.key}"
+}
+`
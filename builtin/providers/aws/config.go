@@ -3,7 +3,9 @@ package aws
 import (
 	"fmt"
 	"log"
+	"net/http"
 	"strings"
+	"sync"
 
 	"github.com/hashicorp/terraform/helper/multierror"
 
@@ -13,10 +15,12 @@ import (
 	"github.com/awslabs/aws-sdk-go/service/ec2"
 	"github.com/awslabs/aws-sdk-go/service/elasticache"
 	"github.com/awslabs/aws-sdk-go/service/elb"
+	"github.com/awslabs/aws-sdk-go/service/elbv2"
 	"github.com/awslabs/aws-sdk-go/service/iam"
 	"github.com/awslabs/aws-sdk-go/service/rds"
 	"github.com/awslabs/aws-sdk-go/service/route53"
 	"github.com/awslabs/aws-sdk-go/service/s3"
+	"github.com/awslabs/aws-sdk-go/service/sts"
 )
 
 type Config struct {
@@ -28,11 +32,30 @@ type Config struct {
 
 	AllowedAccountIds   []interface{}
 	ForbiddenAccountIds []interface{}
+
+	DefaultTags map[string]interface{}
+
+	AssumeRoleARN         string
+	AssumeRoleSessionName string
+	AssumeRoleExternalID  string
+
+	Profile       string
+	CredsFilename string
+	MFASerial     string
+	MFAToken      string
+
+	// Endpoints are the custom endpoints of the services, by the name
+	// of the service.
+	Endpoints map[string]string
+
+	// LogRequests enables logging every API request at the TRACE level.
+	LogRequests bool
 }
 
 type AWSClient struct {
 	ec2conn         *ec2.EC2
 	elbconn         *elb.ELB
+	elbv2conn       *elbv2.ELBV2
 	autoscalingconn *autoscaling.AutoScaling
 	s3conn          *s3.S3
 	r53conn         *route53.Route53
@@ -40,6 +63,7 @@ type AWSClient struct {
 	rdsconn         *rds.RDS
 	iamconn         *iam.IAM
 	elasticacheconn *elasticache.ElastiCache
+	defaultTags     map[string]interface{}
 }
 
 // Client configures and returns a fully initailized AWSClient
@@ -60,59 +84,50 @@ func (c *Config) Client() (interface{}, error) {
 		// store AWS region in client struct, for region specific operations such as
 		// bucket storage in S3
 		client.region = c.Region
+		client.defaultTags = c.DefaultTags
 
 		log.Println("[INFO] Building AWS auth structure")
-		creds := credentials.NewChainCredentials([]credentials.Provider{
-			&credentials.StaticProvider{Value: credentials.Value{
-				AccessKeyID:     c.AccessKey,
-				SecretAccessKey: c.SecretKey,
-				SessionToken:    c.Token,
-			}},
-			&credentials.EnvProvider{},
-			&credentials.SharedCredentialsProvider{Filename: "", Profile: ""},
-			&credentials.EC2RoleProvider{},
-		})
-		awsConfig := &aws.Config{
-			Credentials: creds,
-			Region:      c.Region,
-			MaxRetries:  c.MaxRetries,
+		creds, err := c.credentials()
+		if err != nil {
+			return nil, &multierror.Error{Errors: []error{err}}
 		}
 
 		log.Println("[INFO] Initializing ELB connection")
-		client.elbconn = elb.New(awsConfig)
+		client.elbconn = elb.New(c.awsConfig(creds, "elb"))
+
+		log.Println("[INFO] Initializing ELBv2 connection")
+		client.elbv2conn = elbv2.New(c.awsConfig(creds, "elbv2"))
 
 		log.Println("[INFO] Initializing S3 connection")
-		client.s3conn = s3.New(awsConfig)
+		client.s3conn = s3.New(c.awsConfig(creds, "s3"))
 
 		log.Println("[INFO] Initializing RDS Connection")
-		client.rdsconn = rds.New(awsConfig)
+		client.rdsconn = rds.New(c.awsConfig(creds, "rds"))
 
 		log.Println("[INFO] Initializing IAM Connection")
-		client.iamconn = iam.New(awsConfig)
+		client.iamconn = iam.New(c.awsConfig(creds, "iam"))
 
-		err := c.ValidateAccountId(client.iamconn)
+		err = c.ValidateAccountId(client.iamconn)
 		if err != nil {
 			errs = append(errs, err)
 		}
 
 		log.Println("[INFO] Initializing AutoScaling connection")
-		client.autoscalingconn = autoscaling.New(awsConfig)
+		client.autoscalingconn = autoscaling.New(c.awsConfig(creds, "autoscaling"))
 
 		log.Println("[INFO] Initializing EC2 Connection")
-		client.ec2conn = ec2.New(awsConfig)
+		client.ec2conn = ec2.New(c.awsConfig(creds, "ec2"))
 
 		// aws-sdk-go uses v4 for signing requests, which requires all global
 		// endpoints to use 'us-east-1'.
 		// See http://docs.aws.amazon.com/general/latest/gr/sigv4_changes.html
 		log.Println("[INFO] Initializing Route 53 connection")
-		client.r53conn = route53.New(&aws.Config{
-			Credentials: creds,
-			Region:      "us-east-1",
-			MaxRetries:  c.MaxRetries,
-		})
+		r53Config := c.awsConfig(creds, "route53")
+		r53Config.Region = "us-east-1"
+		client.r53conn = route53.New(r53Config)
 
 		log.Println("[INFO] Initializing Elasticache Connection")
-		client.elasticacheconn = elasticache.New(awsConfig)
+		client.elasticacheconn = elasticache.New(c.awsConfig(creds, "elasticache"))
 	}
 
 	if len(errs) > 0 {
@@ -122,6 +137,105 @@ func (c *Config) Client() (interface{}, error) {
 	return &client, nil
 }
 
+// mfaCreds are the credentials of the MFA sessions of this process, by
+// the role, device and token code they were gotten with.
+var mfaCreds = make(map[string]*credentials.Credentials)
+var mfaCredsLock sync.Mutex
+
+// credentials returns the credentials to use for AWS operations: the
+// configured keys, the keys from the environment, a profile of the shared
+// credentials file or the role of the EC2 instance, in that order. With an
+// MFA device or a role to assume, these are then used to get temporary
+// credentials from STS.
+func (c *Config) credentials() (*credentials.Credentials, error) {
+	creds := credentials.NewChainCredentials([]credentials.Provider{
+		&credentials.StaticProvider{Value: credentials.Value{
+			AccessKeyID:     c.AccessKey,
+			SecretAccessKey: c.SecretKey,
+			SessionToken:    c.Token,
+		}},
+		&credentials.EnvProvider{},
+		&credentials.SharedCredentialsProvider{
+			Filename: c.CredsFilename,
+			Profile:  c.Profile,
+		},
+		&credentials.EC2RoleProvider{},
+	})
+
+	if c.AssumeRoleARN == "" && c.MFASerial == "" {
+		return creds, nil
+	}
+
+	if c.MFASerial != "" && c.MFAToken == "" {
+		return nil, fmt.Errorf(
+			"mfa_token must be set when mfa_serial is set, or be input when asked for")
+	}
+
+	// A token code can only be used once, but the provider is configured
+	// again for each walk of the graph, so the MFA sessions are reused.
+	cacheKey := strings.Join([]string{
+		c.AssumeRoleARN, c.AssumeRoleSessionName, c.AssumeRoleExternalID,
+		c.MFASerial, c.MFAToken}, "|")
+	if c.MFAToken != "" {
+		mfaCredsLock.Lock()
+		defer mfaCredsLock.Unlock()
+		if creds, ok := mfaCreds[cacheKey]; ok {
+			return creds, nil
+		}
+	}
+
+	stsconn := sts.New(c.awsConfig(creds, "sts"))
+	if c.AssumeRoleARN != "" {
+		log.Printf("[INFO] Building credentials for role %s", c.AssumeRoleARN)
+		creds = credentials.NewCredentials(&assumeRoleProvider{
+			Client:       stsconn,
+			RoleARN:      c.AssumeRoleARN,
+			SessionName:  c.AssumeRoleSessionName,
+			ExternalID:   c.AssumeRoleExternalID,
+			SerialNumber: c.MFASerial,
+			TokenCode:    c.MFAToken,
+		})
+	} else {
+		log.Printf("[INFO] Building credentials for MFA device %s", c.MFASerial)
+		creds = credentials.NewCredentials(&sessionTokenProvider{
+			Client:       stsconn,
+			SerialNumber: c.MFASerial,
+			TokenCode:    c.MFAToken,
+		})
+	}
+
+	// Get the temporary credentials now, so that a role that can't be
+	// assumed or a wrong token fails the configuration of the provider
+	if _, err := creds.Get(); err != nil {
+		return nil, err
+	}
+
+	if c.MFAToken != "" {
+		mfaCreds[cacheKey] = creds
+	}
+
+	return creds, nil
+}
+
+// awsConfig returns the configuration of the client for a service, which
+// uses the custom endpoint for the service if one is configured.
+func (c *Config) awsConfig(
+	creds *credentials.Credentials, service string) *aws.Config {
+	result := &aws.Config{
+		Credentials: creds,
+		Region:      c.Region,
+		MaxRetries:  c.MaxRetries,
+		Endpoint:    c.Endpoints[service],
+	}
+	if c.LogRequests {
+		result.HTTPClient = &http.Client{
+			Transport: &requestLogTransport{Transport: http.DefaultTransport},
+		}
+	}
+
+	return result
+}
+
 // ValidateRegion returns an error if the configured region is not a
 // valid aws region and nil otherwise.
 func (c *Config) ValidateRegion() error {
@@ -0,0 +1,33 @@
+package aws
+
+import (
+	"testing"
+)
+
+func TestConfigAwsConfig(t *testing.T) {
+	c := &Config{
+		Region:     "us-west-2",
+		MaxRetries: 3,
+		Endpoints: map[string]string{
+			"ec2": "http://localhost:4597",
+		},
+	}
+
+	cases := []struct {
+		Service  string
+		Endpoint string
+	}{
+		{"ec2", "http://localhost:4597"},
+		{"s3", ""},
+	}
+
+	for i, tc := range cases {
+		actual := c.awsConfig(nil, tc.Service)
+		if actual.Endpoint != tc.Endpoint {
+			t.Fatalf("%d: bad: %#v", i, actual)
+		}
+		if actual.Region != "us-west-2" || actual.MaxRetries != 3 {
+			t.Fatalf("%d: bad: %#v", i, actual)
+		}
+	}
+}
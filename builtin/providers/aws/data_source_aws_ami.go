@@ -0,0 +1,241 @@
+package aws
+
+import (
+	"bytes"
+	"fmt"
+	"log"
+	"regexp"
+	"sort"
+
+	"github.com/awslabs/aws-sdk-go/aws"
+	"github.com/awslabs/aws-sdk-go/service/ec2"
+	"github.com/hashicorp/terraform/helper/hashcode"
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+// dataSourceAwsAmi is the data source that looks up an existing AMI, so
+// that configurations don't have to hardcode AMI IDs per region. It is
+// read at plan time, so the AMI can be used by resources that are planned
+// along with it.
+func dataSourceAwsAmi() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceAwsAmiRead,
+
+		Schema: map[string]*schema.Schema{
+			"owners": &schema.Schema{
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+
+			"name_regex": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"tags": &schema.Schema{
+				Type:     schema.TypeMap,
+				Optional: true,
+			},
+
+			"filter": &schema.Schema{
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": &schema.Schema{
+							Type:     schema.TypeString,
+							Required: true,
+						},
+
+						"values": &schema.Schema{
+							Type:     schema.TypeList,
+							Required: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+					},
+				},
+				Set: dataSourceAwsAmiFilterHash,
+			},
+
+			"most_recent": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+
+			"image_id": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"name": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"description": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"owner_id": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"creation_date": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"architecture": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"virtualization_type": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"root_device_type": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"root_device_name": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func dataSourceAwsAmiRead(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).ec2conn
+
+	req := &ec2.DescribeImagesInput{}
+	for _, v := range d.Get("owners").([]interface{}) {
+		req.Owners = append(req.Owners, aws.String(v.(string)))
+	}
+	for k, v := range d.Get("tags").(map[string]interface{}) {
+		req.Filters = append(req.Filters, &ec2.Filter{
+			Name:   aws.String("tag:" + k),
+			Values: []*string{aws.String(v.(string))},
+		})
+	}
+	if v, ok := d.GetOk("filter"); ok {
+		for _, raw := range v.(*schema.Set).List() {
+			m := raw.(map[string]interface{})
+			filter := &ec2.Filter{Name: aws.String(m["name"].(string))}
+			for _, value := range m["values"].([]interface{}) {
+				filter.Values = append(filter.Values, aws.String(value.(string)))
+			}
+			req.Filters = append(req.Filters, filter)
+		}
+	}
+	if len(req.Owners) == 0 && len(req.Filters) == 0 {
+		return fmt.Errorf(
+			"At least one of owners, tags or filter must be set to look up an AMI")
+	}
+
+	log.Printf("[DEBUG] Looking up AMI: %#v", req)
+	resp, err := conn.DescribeImages(req)
+	if err != nil {
+		return fmt.Errorf("Error looking up AMI: %s", err)
+	}
+
+	images := resp.Images
+	if v, ok := d.GetOk("name_regex"); ok {
+		images, err = amiFilterByName(images, v.(string))
+		if err != nil {
+			return err
+		}
+	}
+
+	image, err := amiSelect(images, d.Get("most_recent").(bool))
+	if err != nil {
+		return err
+	}
+
+	log.Printf("[DEBUG] Found AMI: %s", *image.ImageID)
+	d.SetId(*image.ImageID)
+	d.Set("image_id", image.ImageID)
+	d.Set("name", image.Name)
+	d.Set("description", image.Description)
+	d.Set("owner_id", image.OwnerID)
+	d.Set("creation_date", image.CreationDate)
+	d.Set("architecture", image.Architecture)
+	d.Set("virtualization_type", image.VirtualizationType)
+	d.Set("root_device_type", image.RootDeviceType)
+	d.Set("root_device_name", image.RootDeviceName)
+	return nil
+}
+
+// amiFilterByName returns the images with a name matching the regular
+// expression.
+func amiFilterByName(images []*ec2.Image, expr string) ([]*ec2.Image, error) {
+	re, err := regexp.Compile(expr)
+	if err != nil {
+		return nil, fmt.Errorf("Error parsing name_regex: %s", err)
+	}
+
+	var result []*ec2.Image
+	for _, image := range images {
+		if image.Name != nil && re.MatchString(*image.Name) {
+			result = append(result, image)
+		}
+	}
+
+	return result, nil
+}
+
+// amiSelect returns the single image of a lookup. If more than one image
+// was found, the most recently created one is returned if mostRecent is
+// set, otherwise it is an error.
+func amiSelect(images []*ec2.Image, mostRecent bool) (*ec2.Image, error) {
+	if len(images) == 0 {
+		return nil, fmt.Errorf(
+			"No AMI matched the lookup. Please change the search criteria.")
+	}
+	if len(images) > 1 && !mostRecent {
+		return nil, fmt.Errorf(
+			"%d AMIs matched the lookup. Please use a more specific search, "+
+				"or set most_recent to use the newest one.", len(images))
+	}
+
+	sorted := make([]*ec2.Image, len(images))
+	copy(sorted, images)
+	sort.Sort(amiByCreationDate(sorted))
+	return sorted[len(sorted)-1], nil
+}
+
+// amiByCreationDate sorts images from the oldest to the newest. The
+// creation dates are in ISO 8601 format, so they sort as strings.
+type amiByCreationDate []*ec2.Image
+
+func (s amiByCreationDate) Len() int      { return len(s) }
+func (s amiByCreationDate) Swap(i, j int) { s[i], s[j] = s[j], s[i] }
+func (s amiByCreationDate) Less(i, j int) bool {
+	var a, b string
+	if s[i].CreationDate != nil {
+		a = *s[i].CreationDate
+	}
+	if s[j].CreationDate != nil {
+		b = *s[j].CreationDate
+	}
+
+	return a < b
+}
+
+func dataSourceAwsAmiFilterHash(v interface{}) int {
+	var buf bytes.Buffer
+	m := v.(map[string]interface{})
+	buf.WriteString(fmt.Sprintf("%s-", m["name"].(string)))
+	for _, value := range m["values"].([]interface{}) {
+		buf.WriteString(fmt.Sprintf("%s-", value.(string)))
+	}
+
+	return hashcode.String(buf.String())
+}
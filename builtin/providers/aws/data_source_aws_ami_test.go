@@ -0,0 +1,163 @@
+package aws
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/awslabs/aws-sdk-go/aws"
+	"github.com/awslabs/aws-sdk-go/service/ec2"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestAccAWSAmiDataSource_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccAWSAmiDataSourceConfig,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSAmiID("data.aws_ami.ubuntu"),
+					resource.TestCheckResourceAttr(
+						"data.aws_ami.ubuntu", "owner_id", "099720109477"),
+					resource.TestCheckResourceAttr(
+						"data.aws_ami.ubuntu", "virtualization_type", "hvm"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccAWSAmiDataSource_instance(t *testing.T) {
+	var v ec2.Instance
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckInstanceDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccAWSAmiDataSourceConfigInstance,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckInstanceExists("aws_instance.foo", &v),
+					func(s *terraform.State) error {
+						ami := s.RootModule().Resources["data.aws_ami.ubuntu"].Primary.ID
+						if *v.ImageID != ami {
+							return fmt.Errorf("bad: %s != %s", *v.ImageID, ami)
+						}
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
+
+func TestAmiFilterByName(t *testing.T) {
+	images := []*ec2.Image{
+		&ec2.Image{Name: aws.String("ubuntu-trusty-14.04-amd64-server-20150325")},
+		&ec2.Image{Name: aws.String("ubuntu-precise-12.04-amd64-server-20150401")},
+		&ec2.Image{},
+	}
+
+	result, err := amiFilterByName(images, "^ubuntu-trusty-")
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if len(result) != 1 || result[0] != images[0] {
+		t.Fatalf("bad: %#v", result)
+	}
+
+	if _, err := amiFilterByName(images, "("); err == nil {
+		t.Fatal("should error")
+	}
+}
+
+func TestAmiSelect(t *testing.T) {
+	old := &ec2.Image{
+		ImageID:      aws.String("ami-old"),
+		CreationDate: aws.String("2015-03-25T19:30:40.000Z"),
+	}
+	recent := &ec2.Image{
+		ImageID:      aws.String("ami-recent"),
+		CreationDate: aws.String("2015-04-01T08:12:03.000Z"),
+	}
+
+	cases := []struct {
+		Images     []*ec2.Image
+		MostRecent bool
+		Result     string
+		Err        bool
+	}{
+		{nil, true, "", true},
+		{[]*ec2.Image{old}, false, "ami-old", false},
+		{[]*ec2.Image{recent, old}, false, "", true},
+		{[]*ec2.Image{recent, old}, true, "ami-recent", false},
+		{[]*ec2.Image{old, recent}, true, "ami-recent", false},
+	}
+
+	for i, tc := range cases {
+		image, err := amiSelect(tc.Images, tc.MostRecent)
+		if (err != nil) != tc.Err {
+			t.Fatalf("%d: err: %s", i, err)
+		}
+		if err != nil {
+			continue
+		}
+		if *image.ImageID != tc.Result {
+			t.Fatalf("%d: bad: %s", i, *image.ImageID)
+		}
+	}
+}
+
+func testAccCheckAWSAmiID(n string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		if !regexp.MustCompile(`^ami-[0-9a-f]+$`).MatchString(rs.Primary.ID) {
+			return fmt.Errorf("Bad AMI ID: %s", rs.Primary.ID)
+		}
+		if rs.Primary.Attributes["image_id"] != rs.Primary.ID {
+			return fmt.Errorf(
+				"Bad image_id: %s", rs.Primary.Attributes["image_id"])
+		}
+
+		return nil
+	}
+}
+
+const testAccAWSAmiDataSourceConfig = `
+data "aws_ami" "ubuntu" {
+	owners = ["099720109477"]
+	name_regex = "^ubuntu/images/hvm-ssd/ubuntu-trusty-14.04-amd64-server-"
+	most_recent = true
+
+	filter {
+		name = "virtualization-type"
+		values = ["hvm"]
+	}
+}
+`
+
+const testAccAWSAmiDataSourceConfigInstance = `
+data "aws_ami" "ubuntu" {
+	owners = ["099720109477"]
+	name_regex = "^ubuntu/images/ebs/ubuntu-trusty-14.04-amd64-server-"
+	most_recent = true
+
+	filter {
+		name = "virtualization-type"
+		values = ["paravirtual"]
+	}
+}
+
+resource "aws_instance" "foo" {
+	ami = "${data.aws_ami.ubuntu.id}"
+	instance_type = "m1.small"
+}
+`
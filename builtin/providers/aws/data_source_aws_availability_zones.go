@@ -0,0 +1,65 @@
+package aws
+
+import (
+	"fmt"
+	"log"
+	"sort"
+
+	"github.com/awslabs/aws-sdk-go/aws"
+	"github.com/awslabs/aws-sdk-go/service/ec2"
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+// dataSourceAwsAvailabilityZones is the data source that looks up the
+// availability zones of the configured region. It is read before the
+// resources that reference it are planned, so the zones can be counted.
+func dataSourceAwsAvailabilityZones() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceAwsAvailabilityZonesRead,
+
+		Schema: map[string]*schema.Schema{
+			"state": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"names": &schema.Schema{
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+		},
+	}
+}
+
+func dataSourceAwsAvailabilityZonesRead(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).ec2conn
+
+	req := &ec2.DescribeAvailabilityZonesInput{}
+	if v, ok := d.GetOk("state"); ok {
+		req.Filters = []*ec2.Filter{
+			&ec2.Filter{
+				Name:   aws.String("state"),
+				Values: []*string{aws.String(v.(string))},
+			},
+		}
+	}
+
+	log.Printf("[DEBUG] Looking up availability zones: %#v", req)
+	resp, err := conn.DescribeAvailabilityZones(req)
+	if err != nil {
+		return fmt.Errorf("Error looking up availability zones: %s", err)
+	}
+
+	// Sort the zones so that their order, and with that the resources
+	// that are spread over them, is stable.
+	names := make([]string, 0, len(resp.AvailabilityZones))
+	for _, az := range resp.AvailabilityZones {
+		names = append(names, *az.ZoneName)
+	}
+	sort.Strings(names)
+
+	d.SetId(meta.(*AWSClient).region)
+	d.Set("names", names)
+	return nil
+}
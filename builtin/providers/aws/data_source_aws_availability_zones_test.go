@@ -0,0 +1,58 @@
+package aws
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestAccAWSAvailabilityZonesDataSource_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccAWSAvailabilityZonesDataSourceConfig,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSAvailabilityZones("data.aws_availability_zones.available"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckAWSAvailabilityZones(n string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		count, err := strconv.Atoi(rs.Primary.Attributes["names.#"])
+		if err != nil {
+			return err
+		}
+		if count == 0 {
+			return fmt.Errorf("No availability zones found")
+		}
+
+		for i := 0; i < count; i++ {
+			name := rs.Primary.Attributes[fmt.Sprintf("names.%d", i)]
+			if !strings.HasPrefix(name, rs.Primary.ID) {
+				return fmt.Errorf("Bad availability zone %s in %s", name, rs.Primary.ID)
+			}
+		}
+
+		return nil
+	}
+}
+
+const testAccAWSAvailabilityZonesDataSourceConfig = `
+data "aws_availability_zones" "available" {
+	state = "available"
+}
+`
@@ -0,0 +1,72 @@
+package aws
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+// dataSourceAwsCallerIdentity is the data source that looks up the account
+// that Terraform is running as, so that account IDs don't have to be
+// hardcoded.
+func dataSourceAwsCallerIdentity() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceAwsCallerIdentityRead,
+
+		Schema: map[string]*schema.Schema{
+			"account_id": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"arn": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"user_id": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func dataSourceAwsCallerIdentityRead(d *schema.ResourceData, meta interface{}) error {
+	iamconn := meta.(*AWSClient).iamconn
+
+	// The account ID is part of the ARN of the IAM user. Credentials that
+	// don't belong to an IAM user, such as those of an instance profile,
+	// can't get the user, and the account can't be looked up without it.
+	log.Printf("[DEBUG] Looking up the caller identity")
+	out, err := iamconn.GetUser(nil)
+	if err != nil {
+		return fmt.Errorf(
+			"Error looking up the caller identity, which needs the "+
+				"credentials of an IAM user: %s", err)
+	}
+
+	accountId, err := accountIdFromArn(*out.User.ARN)
+	if err != nil {
+		return err
+	}
+
+	d.SetId(accountId)
+	d.Set("account_id", accountId)
+	d.Set("arn", out.User.ARN)
+	d.Set("user_id", out.User.UserID)
+	return nil
+}
+
+// accountIdFromArn returns the account ID of an ARN, such as
+// "arn:aws:iam::123456789012:user/foo".
+func accountIdFromArn(arn string) (string, error) {
+	parts := strings.Split(arn, ":")
+	if len(parts) < 6 || parts[0] != "arn" || parts[4] == "" {
+		return "", fmt.Errorf("Invalid ARN: %s", arn)
+	}
+
+	return parts[4], nil
+}
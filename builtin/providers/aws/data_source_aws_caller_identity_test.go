@@ -0,0 +1,71 @@
+package aws
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestAccAWSCallerIdentityDataSource_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccAWSCallerIdentityDataSourceConfig,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSCallerIdentity("data.aws_caller_identity.current"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccountIdFromArn(t *testing.T) {
+	cases := []struct {
+		Arn    string
+		Result string
+		Err    bool
+	}{
+		{"arn:aws:iam::123456789012:user/foo", "123456789012", false},
+		{"arn:aws:iam::123456789012:root", "123456789012", false},
+		{"arn:aws:s3:::bucket", "", true},
+		{"foo", "", true},
+	}
+
+	for i, tc := range cases {
+		actual, err := accountIdFromArn(tc.Arn)
+		if (err != nil) != tc.Err {
+			t.Fatalf("%d: err: %s", i, err)
+		}
+		if actual != tc.Result {
+			t.Fatalf("%d: bad: %s", i, actual)
+		}
+	}
+}
+
+func testAccCheckAWSCallerIdentity(n string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		accountId := rs.Primary.Attributes["account_id"]
+		if !regexp.MustCompile(`^\d{12}$`).MatchString(accountId) {
+			return fmt.Errorf("Bad account ID: %s", accountId)
+		}
+		if rs.Primary.ID != accountId {
+			return fmt.Errorf("Bad ID: %s", rs.Primary.ID)
+		}
+
+		return nil
+	}
+}
+
+const testAccAWSCallerIdentityDataSourceConfig = `
+data "aws_caller_identity" "current" {}
+`
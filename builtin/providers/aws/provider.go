@@ -1,6 +1,9 @@
 package aws
 
 import (
+	"fmt"
+	"os"
+
 	"github.com/hashicorp/terraform/helper/hashcode"
 	"github.com/hashicorp/terraform/helper/schema"
 	"github.com/hashicorp/terraform/terraform"
@@ -11,25 +14,25 @@ func Provider() terraform.ResourceProvider {
 	// TODO: Move the validation to this, requires conditional schemas
 	// TODO: Move the configuration to this, requires validation
 
-	return &schema.Provider{
+	p := &schema.Provider{
 		Schema: map[string]*schema.Schema{
 			"access_key": &schema.Schema{
 				Type:     schema.TypeString,
-				Required: true,
+				Optional: true,
 				DefaultFunc: schema.MultiEnvDefaultFunc([]string{
 					"AWS_ACCESS_KEY",
 					"AWS_ACCESS_KEY_ID",
-				}, nil),
+				}, ""),
 				Description: descriptions["access_key"],
 			},
 
 			"secret_key": &schema.Schema{
 				Type:     schema.TypeString,
-				Required: true,
+				Optional: true,
 				DefaultFunc: schema.MultiEnvDefaultFunc([]string{
 					"AWS_SECRET_KEY",
 					"AWS_SECRET_ACCESS_KEY",
-				}, nil),
+				}, ""),
 				Description: descriptions["secret_key"],
 			},
 
@@ -43,6 +46,34 @@ func Provider() terraform.ResourceProvider {
 				Description: descriptions["token"],
 			},
 
+			"profile": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("AWS_PROFILE", ""),
+				Description: descriptions["profile"],
+			},
+
+			"shared_credentials_file": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("AWS_SHARED_CREDENTIALS_FILE", ""),
+				Description: descriptions["shared_credentials_file"],
+			},
+
+			"mfa_serial": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("AWS_MFA_SERIAL", ""),
+				Description: descriptions["mfa_serial"],
+			},
+
+			"mfa_token": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("AWS_MFA_TOKEN", ""),
+				Description: descriptions["mfa_token"],
+			},
+
 			"region": &schema.Schema{
 				Type:     schema.TypeString,
 				Required: true,
@@ -61,6 +92,13 @@ func Provider() terraform.ResourceProvider {
 				Description: descriptions["max_retries"],
 			},
 
+			"log_requests": &schema.Schema{
+				Type:        schema.TypeBool,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("TF_AWS_LOG_REQUESTS", false),
+				Description: descriptions["log_requests"],
+			},
+
 			"allowed_account_ids": &schema.Schema{
 				Type:          schema.TypeSet,
 				Elem:          &schema.Schema{Type: schema.TypeString},
@@ -80,16 +118,74 @@ func Provider() terraform.ResourceProvider {
 					return hashcode.String(v.(string))
 				},
 			},
+
+			"assume_role": &schema.Schema{
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: descriptions["assume_role"],
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"role_arn": &schema.Schema{
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: descriptions["assume_role_role_arn"],
+						},
+
+						"session_name": &schema.Schema{
+							Type:        schema.TypeString,
+							Optional:    true,
+							Default:     "terraform",
+							Description: descriptions["assume_role_session_name"],
+						},
+
+						"external_id": &schema.Schema{
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: descriptions["assume_role_external_id"],
+						},
+					},
+				},
+			},
+
+			"endpoints": &schema.Schema{
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: descriptions["endpoints"],
+				Elem: &schema.Resource{
+					Schema: endpointsSchema(),
+				},
+			},
+
+			"default_tags": &schema.Schema{
+				Type:        schema.TypeMap,
+				Optional:    true,
+				Description: descriptions["default_tags"],
+			},
+		},
+
+		DataSourcesMap: map[string]*schema.Resource{
+			"aws_ami":                dataSourceAwsAmi(),
+			"aws_availability_zones": dataSourceAwsAvailabilityZones(),
+			"aws_caller_identity":    dataSourceAwsCallerIdentity(),
 		},
 
 		ResourcesMap: map[string]*schema.Resource{
+			"aws_alb":                          resourceAwsAlb(),
+			"aws_alb_listener":                 resourceAwsAlbListener(),
+			"aws_alb_listener_rule":            resourceAwsAlbListenerRule(),
+			"aws_alb_target_group":             resourceAwsAlbTargetGroup(),
+			"aws_alb_target_group_attachment":  resourceAwsAlbTargetGroupAttachment(),
 			"aws_app_cookie_stickiness_policy": resourceAwsAppCookieStickinessPolicy(),
+			"aws_autoscaling_attachment":       resourceAwsAutoscalingAttachment(),
 			"aws_autoscaling_group":            resourceAwsAutoscalingGroup(),
+			"aws_autoscaling_lifecycle_hook":   resourceAwsAutoscalingLifecycleHook(),
+			"aws_autoscaling_policy":           resourceAwsAutoscalingPolicy(),
 			"aws_customer_gateway":             resourceAwsCustomerGateway(),
 			"aws_db_instance":                  resourceAwsDbInstance(),
 			"aws_db_parameter_group":           resourceAwsDbParameterGroup(),
 			"aws_db_security_group":            resourceAwsDbSecurityGroup(),
 			"aws_db_subnet_group":              resourceAwsDbSubnetGroup(),
+			"aws_ebs_snapshot":                 resourceAwsEbsSnapshot(),
 			"aws_ebs_volume":                   resourceAwsEbsVolume(),
 			"aws_eip":                          resourceAwsEip(),
 			"aws_elasticache_cluster":          resourceAwsElasticacheCluster(),
@@ -109,6 +205,7 @@ func Provider() terraform.ResourceProvider {
 			"aws_internet_gateway":             resourceAwsInternetGateway(),
 			"aws_key_pair":                     resourceAwsKeyPair(),
 			"aws_launch_configuration":         resourceAwsLaunchConfiguration(),
+			"aws_lb":                           resourceAwsAlb(),
 			"aws_lb_cookie_stickiness_policy":  resourceAwsLBCookieStickinessPolicy(),
 			"aws_main_route_table_association": resourceAwsMainRouteTableAssociation(),
 			"aws_network_acl":                  resourceAwsNetworkAcl(),
@@ -116,6 +213,7 @@ func Provider() terraform.ResourceProvider {
 			"aws_proxy_protocol_policy":        resourceAwsProxyProtocolPolicy(),
 			"aws_route53_record":               resourceAwsRoute53Record(),
 			"aws_route53_zone":                 resourceAwsRoute53Zone(),
+			"aws_route53_zone_association":     resourceAwsRoute53ZoneAssociation(),
 			"aws_route_table_association":      resourceAwsRouteTableAssociation(),
 			"aws_route_table":                  resourceAwsRouteTable(),
 			"aws_s3_bucket":                    resourceAwsS3Bucket(),
@@ -132,7 +230,17 @@ func Provider() terraform.ResourceProvider {
 		},
 
 		ConfigureFunc: providerConfigure,
+		InputFunc:     providerInput,
 	}
+
+	// The default tags go to every resource that can update its tags
+	for _, r := range p.ResourcesMap {
+		if tags, ok := r.Schema["tags"]; ok && !tags.ForceNew && r.Update != nil {
+			r.DiffConfig = defaultTagsDiffConfig
+		}
+	}
+
+	return p
 }
 
 var descriptions map[string]string
@@ -151,9 +259,42 @@ func init() {
 		"token": "session token. A session token is only required if you are\n" +
 			"using temporary security credentials.",
 
+		"profile": "The profile of the shared credentials file to use, if\n" +
+			"no access keys are configured.",
+
+		"shared_credentials_file": "The path to the shared credentials file.\n" +
+			"Defaults to ~/.aws/credentials.",
+
+		"mfa_serial": "The serial number or ARN of an MFA device. Temporary\n" +
+			"credentials for an MFA session are used when it is set.",
+
+		"mfa_token": "The current token code of the MFA device.",
+
 		"max_retries": "The maximum number of times an AWS API request is\n" +
 			"being executed. If the API request still fails, an error is\n" +
 			"thrown.",
+
+		"assume_role": "An IAM role to assume with the configured credentials.\n" +
+			"All AWS operations then use the temporary credentials of the\n" +
+			"role.",
+
+		"assume_role_role_arn": "The ARN of the IAM role to assume.",
+
+		"assume_role_session_name": "The name of the session of the assumed\n" +
+			"role, which shows up in CloudTrail logs.",
+
+		"assume_role_external_id": "The external ID that the trust policy\n" +
+			"of the role requires, if any.",
+
+		"log_requests": "Log every AWS API request and its response at the\n" +
+			"TRACE level, with secrets redacted.",
+
+		"endpoints": "Custom endpoints of the AWS services, such as for\n" +
+			"local emulators or for regions with endpoints of their own.",
+
+		"default_tags": "Tags to set on every resource that supports tags.\n" +
+			"Tags set on a resource override the default tags with the\n" +
+			"same key.",
 	}
 }
 
@@ -164,6 +305,13 @@ func providerConfigure(d *schema.ResourceData) (interface{}, error) {
 		Token:      d.Get("token").(string),
 		Region:     d.Get("region").(string),
 		MaxRetries: d.Get("max_retries").(int),
+
+		Profile:       d.Get("profile").(string),
+		CredsFilename: d.Get("shared_credentials_file").(string),
+		MFASerial:     d.Get("mfa_serial").(string),
+		MFAToken:      d.Get("mfa_token").(string),
+
+		LogRequests: d.Get("log_requests").(bool),
 	}
 
 	if v, ok := d.GetOk("allowed_account_ids"); ok {
@@ -174,5 +322,93 @@ func providerConfigure(d *schema.ResourceData) (interface{}, error) {
 		config.ForbiddenAccountIds = v.(*schema.Set).List()
 	}
 
+	if v := d.Get("assume_role").([]interface{}); len(v) > 0 {
+		if len(v) > 1 {
+			return nil, fmt.Errorf("Only one assume_role block can be set")
+		}
+
+		role := v[0].(map[string]interface{})
+		config.AssumeRoleARN = role["role_arn"].(string)
+		config.AssumeRoleSessionName = role["session_name"].(string)
+		config.AssumeRoleExternalID = role["external_id"].(string)
+	}
+
+	if v := d.Get("endpoints").([]interface{}); len(v) > 0 {
+		if len(v) > 1 {
+			return nil, fmt.Errorf("Only one endpoints block can be set")
+		}
+
+		config.Endpoints = make(map[string]string)
+		for k, endpoint := range v[0].(map[string]interface{}) {
+			if endpoint.(string) != "" {
+				config.Endpoints[k] = endpoint.(string)
+			}
+		}
+	}
+
+	if v, ok := d.GetOk("default_tags"); ok {
+		config.DefaultTags = v.(map[string]interface{})
+	}
+
 	return config.Client()
 }
+
+// endpointServices are the services whose endpoints can be set in the
+// endpoints block of the provider.
+var endpointServices = []string{
+	"autoscaling",
+	"ec2",
+	"elasticache",
+	"elb",
+	"elbv2",
+	"iam",
+	"rds",
+	"route53",
+	"s3",
+	"sts",
+}
+
+func endpointsSchema() map[string]*schema.Schema {
+	result := make(map[string]*schema.Schema, len(endpointServices))
+	for _, service := range endpointServices {
+		result[service] = &schema.Schema{
+			Type:     schema.TypeString,
+			Optional: true,
+			Default:  "",
+			Description: fmt.Sprintf(
+				"The URL to use for the %s API instead of the default.", service),
+		}
+	}
+
+	return result
+}
+
+// providerInput asks for the token code of the MFA device, which changes
+// every time Terraform runs, when an MFA device is configured.
+func providerInput(
+	input terraform.UIInput,
+	c *terraform.ResourceConfig) (*terraform.ResourceConfig, error) {
+	serial, ok := c.Get("mfa_serial")
+	if !ok {
+		serial = os.Getenv("AWS_MFA_SERIAL")
+	}
+	if serial == "" {
+		return c, nil
+	}
+
+	if _, ok := c.Raw["mfa_token"]; ok || os.Getenv("AWS_MFA_TOKEN") != "" {
+		return c, nil
+	}
+
+	token, err := input.Input(&terraform.InputOpts{
+		Id:          "mfa_token",
+		Query:       fmt.Sprintf("MFA token code for %s", serial),
+		Description: descriptions["mfa_token"],
+	})
+	if err != nil {
+		return nil, fmt.Errorf("mfa_token: %s", err)
+	}
+
+	c.Config["mfa_token"] = token
+	return c, nil
+}
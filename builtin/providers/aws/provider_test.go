@@ -1,10 +1,15 @@
 package aws
 
 import (
+	"fmt"
 	"log"
 	"os"
+	"strings"
 	"testing"
 
+	"github.com/awslabs/aws-sdk-go/aws"
+	"github.com/awslabs/aws-sdk-go/service/ec2"
+	"github.com/hashicorp/terraform/helper/resource"
 	"github.com/hashicorp/terraform/helper/schema"
 	"github.com/hashicorp/terraform/terraform"
 )
@@ -19,6 +24,66 @@ func init() {
 	}
 }
 
+// testSweepNamePrefixes are the prefixes of the names given to resources
+// by the acceptance tests. Sweepers only remove resources with these
+// names, so nothing else in the account is touched.
+var testSweepNamePrefixes = []string{
+	"tf-",
+	"tf_",
+	"terraform-",
+	"foobar-terraform-test",
+}
+
+func TestMain(m *testing.M) {
+	resource.TestMain(m)
+}
+
+// sharedClientForRegion returns a client for sweepers to use in a region,
+// using the credentials from the environment.
+func sharedClientForRegion(region string) (*AWSClient, error) {
+	if os.Getenv("AWS_ACCESS_KEY_ID") == "" || os.Getenv("AWS_SECRET_ACCESS_KEY") == "" {
+		return nil, fmt.Errorf(
+			"AWS_ACCESS_KEY_ID and AWS_SECRET_ACCESS_KEY must be set for sweepers")
+	}
+
+	config := &Config{
+		Region:     region,
+		MaxRetries: 3,
+	}
+	client, err := config.Client()
+	if err != nil {
+		return nil, err
+	}
+
+	return client.(*AWSClient), nil
+}
+
+// testSweepName returns whether a resource with the given name was
+// created by the acceptance tests.
+func testSweepName(name string) bool {
+	for _, prefix := range testSweepNamePrefixes {
+		if strings.HasPrefix(name, prefix) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// testSweepNameFilter returns an EC2 filter that matches the Name tag of
+// resources created by the acceptance tests.
+func testSweepNameFilter() *ec2.Filter {
+	values := make([]*string, len(testSweepNamePrefixes))
+	for i, prefix := range testSweepNamePrefixes {
+		values[i] = aws.String(prefix + "*")
+	}
+
+	return &ec2.Filter{
+		Name:   aws.String("tag:Name"),
+		Values: values,
+	}
+}
+
 func TestProvider(t *testing.T) {
 	if err := Provider().(*schema.Provider).InternalValidate(); err != nil {
 		t.Fatalf("err: %s", err)
@@ -0,0 +1,118 @@
+package aws
+
+import (
+	"bytes"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+)
+
+// requestLogBodyLimit is how much of the body of an error response is
+// logged.
+const requestLogBodyLimit = 1024
+
+// requestLogRedacted are parts of the names of parameters whose values
+// are never logged.
+var requestLogRedacted = []string{
+	"credential",
+	"password",
+	"privatekey",
+	"secret",
+	"signature",
+	"token",
+	"userdata",
+}
+
+// requestLogTransport is an http.RoundTripper that logs every AWS API
+// request and its response at the TRACE level: the operation and its
+// parameters, with secrets redacted, how long it took, the status and the
+// request ID to look the request up with AWS support. The body of error
+// responses is logged too, since it has the error code and message of
+// throttling and permission failures.
+type requestLogTransport struct {
+	Transport http.RoundTripper
+}
+
+func (t *requestLogTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	params := requestLogParams(req.URL.Query())
+	if req.Body != nil && strings.HasPrefix(
+		req.Header.Get("Content-Type"), "application/x-www-form-urlencoded") {
+		body, err := ioutil.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		req.Body = ioutil.NopCloser(bytes.NewReader(body))
+
+		form, err := url.ParseQuery(string(body))
+		if err == nil {
+			params = requestLogParams(form)
+		}
+	}
+
+	log.Printf("[TRACE] AWS request: %s %s%s %s",
+		req.Method, req.URL.Host, req.URL.Path, params)
+
+	start := time.Now()
+	resp, err := t.Transport.RoundTrip(req)
+	latency := time.Now().Sub(start)
+	if err != nil {
+		log.Printf("[TRACE] AWS request to %s failed after %s: %s",
+			req.URL.Host, latency, err)
+		return resp, err
+	}
+
+	requestId := resp.Header.Get("X-Amzn-Requestid")
+	if requestId == "" {
+		requestId = resp.Header.Get("X-Amz-Request-Id")
+	}
+
+	var body []byte
+	if resp.StatusCode >= 400 {
+		body, err = ioutil.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		resp.Body = ioutil.NopCloser(bytes.NewReader(body))
+
+		if len(body) > requestLogBodyLimit {
+			body = append(body[:requestLogBodyLimit:requestLogBodyLimit], "..."...)
+		}
+	}
+
+	log.Printf("[TRACE] AWS response from %s: %s in %s, request ID %q %s",
+		req.URL.Host, resp.Status, latency, requestId, body)
+
+	return resp, nil
+}
+
+// requestLogParams returns the parameters of a request to log, sorted by
+// name and with the values of secret parameters redacted.
+func requestLogParams(values url.Values) string {
+	keys := make([]string, 0, len(values))
+	for k, _ := range values {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	parts := make([]string, 0, len(keys))
+	for _, k := range keys {
+		v := strings.Join(values[k], ",")
+		lower := strings.ToLower(k)
+		for _, r := range requestLogRedacted {
+			if strings.Contains(lower, r) {
+				v = "<redacted>"
+				break
+			}
+		}
+
+		parts = append(parts, k+"="+v)
+	}
+
+	return strings.Join(parts, " ")
+}
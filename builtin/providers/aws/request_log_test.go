@@ -0,0 +1,101 @@
+package aws
+
+import (
+	"bytes"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestRequestLogParams(t *testing.T) {
+	cases := []struct {
+		Input  url.Values
+		Output string
+	}{
+		{
+			url.Values{
+				"Action":  []string{"DescribeInstances"},
+				"Version": []string{"2014-10-01"},
+			},
+			"Action=DescribeInstances Version=2014-10-01",
+		},
+
+		{
+			url.Values{
+				"Action":               []string{"CreateDBInstance"},
+				"MasterUserPassword":   []string{"hunter2"},
+				"X-Amz-Security-Token": []string{"abc"},
+			},
+			"Action=CreateDBInstance MasterUserPassword=<redacted> " +
+				"X-Amz-Security-Token=<redacted>",
+		},
+
+		{
+			url.Values{},
+			"",
+		},
+	}
+
+	for i, tc := range cases {
+		actual := requestLogParams(tc.Input)
+		if actual != tc.Output {
+			t.Fatalf("%d: bad: %s", i, actual)
+		}
+	}
+}
+
+func TestRequestLogTransport(t *testing.T) {
+	var received string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		received = string(body)
+
+		w.Header().Set("X-Amzn-Requestid", "req-123")
+		w.WriteHeader(403)
+		w.Write([]byte("<Code>UnauthorizedOperation</Code>"))
+	}))
+	defer ts.Close()
+
+	var buf bytes.Buffer
+	log.SetOutput(&buf)
+	defer log.SetOutput(os.Stderr)
+
+	client := &http.Client{
+		Transport: &requestLogTransport{Transport: http.DefaultTransport},
+	}
+	resp, err := client.Post(ts.URL, "application/x-www-form-urlencoded",
+		strings.NewReader("Action=RunInstances&UserData=c2VjcmV0"))
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	defer resp.Body.Close()
+
+	// The request and the response are passed on unchanged
+	if received != "Action=RunInstances&UserData=c2VjcmV0" {
+		t.Fatalf("bad: %s", received)
+	}
+	body, _ := ioutil.ReadAll(resp.Body)
+	if string(body) != "<Code>UnauthorizedOperation</Code>" {
+		t.Fatalf("bad: %s", body)
+	}
+
+	logged := buf.String()
+	for _, s := range []string{
+		"Action=RunInstances UserData=<redacted>",
+		"403 Forbidden",
+		`request ID "req-123"`,
+		"<Code>UnauthorizedOperation</Code>",
+	} {
+		if !strings.Contains(logged, s) {
+			t.Fatalf("%q not logged:\n\n%s", s, logged)
+		}
+	}
+	if strings.Contains(logged, "c2VjcmV0") {
+		t.Fatalf("secret logged:\n\n%s", logged)
+	}
+}
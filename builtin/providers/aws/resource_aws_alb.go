@@ -0,0 +1,323 @@
+package aws
+
+import (
+	"fmt"
+	"log"
+	"strconv"
+	"time"
+
+	"github.com/awslabs/aws-sdk-go/aws"
+	"github.com/awslabs/aws-sdk-go/service/elbv2"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+// resourceAwsAlb is an Application Load Balancer. Unlike a classic ELB, it
+// has no listeners or instances of its own: those are the aws_alb_listener,
+// aws_alb_target_group and aws_alb_target_group_attachment resources, which
+// refer to it by its ARN, which is also its ID.
+func resourceAwsAlb() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceAwsAlbCreate,
+		Read:   resourceAwsAlbRead,
+		Update: resourceAwsAlbUpdate,
+		Delete: resourceAwsAlbDelete,
+
+		Schema: map[string]*schema.Schema{
+			"name": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"internal": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				ForceNew: true,
+				Computed: true,
+			},
+
+			"security_groups": &schema.Schema{
+				Type:     schema.TypeSet,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Optional: true,
+				Computed: true,
+				Set:      schema.HashString,
+			},
+
+			"subnets": &schema.Schema{
+				Type:     schema.TypeSet,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Required: true,
+				Set:      schema.HashString,
+			},
+
+			"idle_timeout": &schema.Schema{
+				Type:     schema.TypeInt,
+				Optional: true,
+				Default:  60,
+			},
+
+			"enable_deletion_protection": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+
+			"arn": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"dns_name": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"zone_id": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"vpc_id": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"tags": tagsSchema(),
+		},
+	}
+}
+
+func resourceAwsAlbCreate(d *schema.ResourceData, meta interface{}) error {
+	elbconn := meta.(*AWSClient).elbv2conn
+
+	tags := tagsFromMapELBv2(d.Get("tags").(map[string]interface{}))
+	albOpts := &elbv2.CreateLoadBalancerInput{
+		Name:    aws.String(d.Get("name").(string)),
+		Subnets: expandStringList(d.Get("subnets").(*schema.Set).List()),
+		Tags:    tags,
+	}
+
+	if scheme, ok := d.GetOk("internal"); ok && scheme.(bool) {
+		albOpts.Scheme = aws.String("internal")
+	}
+
+	if v, ok := d.GetOk("security_groups"); ok {
+		albOpts.SecurityGroups = expandStringList(v.(*schema.Set).List())
+	}
+
+	log.Printf("[DEBUG] ALB create configuration: %#v", albOpts)
+	resp, err := elbconn.CreateLoadBalancer(albOpts)
+	if err != nil {
+		return fmt.Errorf("Error creating ALB: %s", err)
+	}
+	if len(resp.LoadBalancers) != 1 {
+		return fmt.Errorf("Unexpected ALBs in create response: %#v", resp.LoadBalancers)
+	}
+
+	d.SetId(*resp.LoadBalancers[0].LoadBalancerARN)
+	log.Printf("[INFO] ALB ID: %s", d.Id())
+
+	stateConf := &resource.StateChangeConf{
+		Pending:    []string{"provisioning"},
+		Target:     "active",
+		Refresh:    albStateRefreshFunc(elbconn, d.Id()),
+		Timeout:    10 * time.Minute,
+		Delay:      10 * time.Second,
+		MinTimeout: 3 * time.Second,
+		StopCh:     d.StopCh(),
+	}
+
+	log.Printf("[DEBUG] Waiting for ALB (%s) to become active", d.Id())
+	if _, err := stateConf.WaitForState(); err != nil {
+		return fmt.Errorf("Error waiting for ALB (%s) to become active: %s", d.Id(), err)
+	}
+
+	// Enable partial mode and record what we set
+	d.Partial(true)
+	d.SetPartial("name")
+	d.SetPartial("internal")
+	d.SetPartial("security_groups")
+	d.SetPartial("subnets")
+
+	d.Set("tags", tagsToMapELBv2(tags))
+
+	return resourceAwsAlbUpdate(d, meta)
+}
+
+func resourceAwsAlbRead(d *schema.ResourceData, meta interface{}) error {
+	elbconn := meta.(*AWSClient).elbv2conn
+
+	resp, err := elbconn.DescribeLoadBalancers(&elbv2.DescribeLoadBalancersInput{
+		LoadBalancerARNs: []*string{aws.String(d.Id())},
+	})
+	if err != nil {
+		if isLoadBalancerNotFound(err) {
+			// The ALB is gone now, so just remove it from the state
+			d.SetId("")
+			return nil
+		}
+
+		return fmt.Errorf("Error retrieving ALB: %s", err)
+	}
+	if len(resp.LoadBalancers) != 1 {
+		return fmt.Errorf("Unable to find ALB: %#v", resp.LoadBalancers)
+	}
+
+	attrsResp, err := elbconn.DescribeLoadBalancerAttributes(&elbv2.DescribeLoadBalancerAttributesInput{
+		LoadBalancerARN: aws.String(d.Id()),
+	})
+	if err != nil {
+		return fmt.Errorf("Error retrieving ALB attributes: %s", err)
+	}
+
+	lb := resp.LoadBalancers[0]
+
+	d.Set("arn", lb.LoadBalancerARN)
+	d.Set("name", lb.LoadBalancerName)
+	d.Set("internal", *lb.Scheme == "internal")
+	d.Set("security_groups", lb.SecurityGroups)
+	d.Set("dns_name", lb.DNSName)
+	d.Set("zone_id", lb.CanonicalHostedZoneID)
+	d.Set("vpc_id", lb.VPCID)
+
+	subnets := make([]string, 0, len(lb.AvailabilityZones))
+	for _, az := range lb.AvailabilityZones {
+		subnets = append(subnets, *az.SubnetID)
+	}
+	d.Set("subnets", subnets)
+
+	for _, attr := range attrsResp.Attributes {
+		switch *attr.Key {
+		case "idle_timeout.timeout_seconds":
+			timeout, err := strconv.Atoi(*attr.Value)
+			if err != nil {
+				return fmt.Errorf("Error parsing ALB idle timeout %q: %s", *attr.Value, err)
+			}
+			d.Set("idle_timeout", timeout)
+		case "deletion_protection.enabled":
+			d.Set("enable_deletion_protection", *attr.Value == "true")
+		}
+	}
+
+	tags, err := readTagsELBv2(elbconn, d.Id())
+	if err != nil {
+		return fmt.Errorf("Error retrieving ALB tags: %s", err)
+	}
+	d.Set("tags", tagsToMapELBv2(tags))
+
+	return nil
+}
+
+func resourceAwsAlbUpdate(d *schema.ResourceData, meta interface{}) error {
+	elbconn := meta.(*AWSClient).elbv2conn
+
+	d.Partial(true)
+
+	var attrs []*elbv2.LoadBalancerAttribute
+	if d.HasChange("idle_timeout") {
+		attrs = append(attrs, &elbv2.LoadBalancerAttribute{
+			Key:   aws.String("idle_timeout.timeout_seconds"),
+			Value: aws.String(strconv.Itoa(d.Get("idle_timeout").(int))),
+		})
+	}
+	if d.HasChange("enable_deletion_protection") {
+		attrs = append(attrs, &elbv2.LoadBalancerAttribute{
+			Key:   aws.String("deletion_protection.enabled"),
+			Value: aws.String(strconv.FormatBool(d.Get("enable_deletion_protection").(bool))),
+		})
+	}
+
+	if len(attrs) > 0 {
+		_, err := elbconn.ModifyLoadBalancerAttributes(&elbv2.ModifyLoadBalancerAttributesInput{
+			LoadBalancerARN: aws.String(d.Id()),
+			Attributes:      attrs,
+		})
+		if err != nil {
+			return fmt.Errorf("Failure configuring ALB attributes: %s", err)
+		}
+
+		d.SetPartial("idle_timeout")
+		d.SetPartial("enable_deletion_protection")
+	}
+
+	if d.HasChange("security_groups") {
+		groups := d.Get("security_groups").(*schema.Set).List()
+
+		_, err := elbconn.SetSecurityGroups(&elbv2.SetSecurityGroupsInput{
+			LoadBalancerARN: aws.String(d.Id()),
+			SecurityGroups:  expandStringList(groups),
+		})
+		if err != nil {
+			return fmt.Errorf("Failure applying ALB security groups: %s", err)
+		}
+
+		d.SetPartial("security_groups")
+	}
+
+	// Unlike a classic ELB, the subnets of an ALB are replaced as a whole,
+	// so moving it to another subnet of the same zone is a single call.
+	if d.HasChange("subnets") {
+		subnets := d.Get("subnets").(*schema.Set).List()
+
+		_, err := elbconn.SetSubnets(&elbv2.SetSubnetsInput{
+			LoadBalancerARN: aws.String(d.Id()),
+			Subnets:         expandStringList(subnets),
+		})
+		if err != nil {
+			return fmt.Errorf("Failure setting ALB subnets: %s", err)
+		}
+
+		d.SetPartial("subnets")
+	}
+
+	if err := setTagsELBv2(elbconn, d); err != nil {
+		return err
+	}
+
+	d.SetPartial("tags")
+	d.Partial(false)
+
+	return resourceAwsAlbRead(d, meta)
+}
+
+func resourceAwsAlbDelete(d *schema.ResourceData, meta interface{}) error {
+	elbconn := meta.(*AWSClient).elbv2conn
+
+	log.Printf("[INFO] Deleting ALB: %s", d.Id())
+	_, err := elbconn.DeleteLoadBalancer(&elbv2.DeleteLoadBalancerInput{
+		LoadBalancerARN: aws.String(d.Id()),
+	})
+	if err != nil {
+		return fmt.Errorf("Error deleting ALB: %s", err)
+	}
+
+	return nil
+}
+
+// albStateRefreshFunc returns a resource.StateRefreshFunc that is used to
+// watch the state of an ALB while it is provisioned.
+func albStateRefreshFunc(conn *elbv2.ELBV2, arn string) resource.StateRefreshFunc {
+	return func() (interface{}, string, error) {
+		resp, err := conn.DescribeLoadBalancers(&elbv2.DescribeLoadBalancersInput{
+			LoadBalancerARNs: []*string{aws.String(arn)},
+		})
+		if err != nil {
+			return nil, "", err
+		}
+		if len(resp.LoadBalancers) != 1 {
+			return nil, "", fmt.Errorf("Unable to find ALB: %#v", resp.LoadBalancers)
+		}
+
+		lb := resp.LoadBalancers[0]
+		return lb, *lb.State.Code, nil
+	}
+}
+
+// isElbv2Error returns whether err is an error of the ELBv2 API with
+// the given code.
+func isElbv2Error(err error, code string) bool {
+	elberr, ok := err.(aws.APIError)
+	return ok && elberr.Code == code
+}
@@ -0,0 +1,235 @@
+package aws
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/awslabs/aws-sdk-go/aws"
+	"github.com/awslabs/aws-sdk-go/service/elbv2"
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func resourceAwsAlbListener() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceAwsAlbListenerCreate,
+		Read:   resourceAwsAlbListenerRead,
+		Update: resourceAwsAlbListenerUpdate,
+		Delete: resourceAwsAlbListenerDelete,
+
+		Schema: map[string]*schema.Schema{
+			"load_balancer_arn": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"port": &schema.Schema{
+				Type:     schema.TypeInt,
+				Required: true,
+			},
+
+			"protocol": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "HTTP",
+				ValidateFunc: validateAlbProtocol,
+			},
+
+			"ssl_policy": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+
+			"certificate_arn": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"default_action": albActionSchema(),
+
+			"arn": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+// albActionSchema is the schema of the actions of listeners and listener
+// rules. Forwarding to a target group is the only type of action.
+func albActionSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeList,
+		Required: true,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"target_group_arn": &schema.Schema{
+					Type:     schema.TypeString,
+					Required: true,
+				},
+
+				"type": &schema.Schema{
+					Type:         schema.TypeString,
+					Required:     true,
+					ValidateFunc: validateAlbActionType,
+				},
+			},
+		},
+	}
+}
+
+func resourceAwsAlbListenerCreate(d *schema.ResourceData, meta interface{}) error {
+	elbconn := meta.(*AWSClient).elbv2conn
+
+	opts := &elbv2.CreateListenerInput{
+		LoadBalancerARN: aws.String(d.Get("load_balancer_arn").(string)),
+		Port:            aws.Long(int64(d.Get("port").(int))),
+		Protocol:        aws.String(d.Get("protocol").(string)),
+		DefaultActions:  expandAlbActions(d.Get("default_action").([]interface{})),
+	}
+
+	if v, ok := d.GetOk("ssl_policy"); ok {
+		opts.SSLPolicy = aws.String(v.(string))
+	}
+
+	if v, ok := d.GetOk("certificate_arn"); ok {
+		opts.Certificates = []*elbv2.Certificate{
+			&elbv2.Certificate{
+				CertificateARN: aws.String(v.(string)),
+			},
+		}
+	}
+
+	log.Printf("[DEBUG] ALB listener create configuration: %#v", opts)
+	resp, err := elbconn.CreateListener(opts)
+	if err != nil {
+		return fmt.Errorf("Error creating ALB listener: %s", err)
+	}
+	if len(resp.Listeners) != 1 {
+		return fmt.Errorf("Unexpected listeners in create response: %#v", resp.Listeners)
+	}
+
+	d.SetId(*resp.Listeners[0].ListenerARN)
+	log.Printf("[INFO] ALB listener ID: %s", d.Id())
+
+	return resourceAwsAlbListenerRead(d, meta)
+}
+
+func resourceAwsAlbListenerRead(d *schema.ResourceData, meta interface{}) error {
+	elbconn := meta.(*AWSClient).elbv2conn
+
+	resp, err := elbconn.DescribeListeners(&elbv2.DescribeListenersInput{
+		ListenerARNs: []*string{aws.String(d.Id())},
+	})
+	if err != nil {
+		if isElbv2Error(err, "ListenerNotFound") {
+			// The listener is gone now, so just remove it from the state
+			d.SetId("")
+			return nil
+		}
+
+		return fmt.Errorf("Error retrieving ALB listener: %s", err)
+	}
+	if len(resp.Listeners) != 1 {
+		return fmt.Errorf("Unable to find ALB listener: %#v", resp.Listeners)
+	}
+
+	listener := resp.Listeners[0]
+
+	d.Set("arn", listener.ListenerARN)
+	d.Set("load_balancer_arn", listener.LoadBalancerARN)
+	d.Set("port", listener.Port)
+	d.Set("protocol", listener.Protocol)
+	if listener.SSLPolicy != nil {
+		d.Set("ssl_policy", listener.SSLPolicy)
+	}
+	if len(listener.Certificates) > 0 {
+		d.Set("certificate_arn", listener.Certificates[0].CertificateARN)
+	}
+	d.Set("default_action", flattenAlbActions(listener.DefaultActions))
+
+	return nil
+}
+
+func resourceAwsAlbListenerUpdate(d *schema.ResourceData, meta interface{}) error {
+	elbconn := meta.(*AWSClient).elbv2conn
+
+	opts := &elbv2.ModifyListenerInput{
+		ListenerARN:    aws.String(d.Id()),
+		Port:           aws.Long(int64(d.Get("port").(int))),
+		Protocol:       aws.String(d.Get("protocol").(string)),
+		DefaultActions: expandAlbActions(d.Get("default_action").([]interface{})),
+	}
+
+	if v, ok := d.GetOk("ssl_policy"); ok {
+		opts.SSLPolicy = aws.String(v.(string))
+	}
+
+	if v, ok := d.GetOk("certificate_arn"); ok {
+		opts.Certificates = []*elbv2.Certificate{
+			&elbv2.Certificate{
+				CertificateARN: aws.String(v.(string)),
+			},
+		}
+	}
+
+	log.Printf("[DEBUG] ALB listener update configuration: %#v", opts)
+	if _, err := elbconn.ModifyListener(opts); err != nil {
+		return fmt.Errorf("Error updating ALB listener: %s", err)
+	}
+
+	return resourceAwsAlbListenerRead(d, meta)
+}
+
+func resourceAwsAlbListenerDelete(d *schema.ResourceData, meta interface{}) error {
+	elbconn := meta.(*AWSClient).elbv2conn
+
+	log.Printf("[INFO] Deleting ALB listener: %s", d.Id())
+	_, err := elbconn.DeleteListener(&elbv2.DeleteListenerInput{
+		ListenerARN: aws.String(d.Id()),
+	})
+	if err != nil {
+		return fmt.Errorf("Error deleting ALB listener: %s", err)
+	}
+
+	return nil
+}
+
+// expandAlbActions turns the actions of a listener or listener rule into
+// the actions of the API.
+func expandAlbActions(configured []interface{}) []*elbv2.Action {
+	actions := make([]*elbv2.Action, 0, len(configured))
+	for _, raw := range configured {
+		data := raw.(map[string]interface{})
+		actions = append(actions, &elbv2.Action{
+			TargetGroupARN: aws.String(data["target_group_arn"].(string)),
+			Type:           aws.String(data["type"].(string)),
+		})
+	}
+
+	return actions
+}
+
+// flattenAlbActions turns the actions of the API into the actions of a
+// listener or listener rule.
+func flattenAlbActions(list []*elbv2.Action) []map[string]interface{} {
+	result := make([]map[string]interface{}, 0, len(list))
+	for _, action := range list {
+		result = append(result, map[string]interface{}{
+			"target_group_arn": *action.TargetGroupARN,
+			"type":             *action.Type,
+		})
+	}
+
+	return result
+}
+
+func validateAlbActionType(v interface{}, k string) (ws []string, errors []error) {
+	if value := v.(string); value != "forward" {
+		errors = append(errors, fmt.Errorf(
+			"%q must be forward, got %q", k, value))
+	}
+
+	return
+}
@@ -0,0 +1,206 @@
+package aws
+
+import (
+	"fmt"
+	"log"
+	"strconv"
+
+	"github.com/awslabs/aws-sdk-go/aws"
+	"github.com/awslabs/aws-sdk-go/service/elbv2"
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+// resourceAwsAlbListenerRule routes the requests of a listener whose path
+// or host match its conditions to the target group of its action, instead
+// of the default action of the listener.
+func resourceAwsAlbListenerRule() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceAwsAlbListenerRuleCreate,
+		Read:   resourceAwsAlbListenerRuleRead,
+		Update: resourceAwsAlbListenerRuleUpdate,
+		Delete: resourceAwsAlbListenerRuleDelete,
+
+		Schema: map[string]*schema.Schema{
+			"listener_arn": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"priority": &schema.Schema{
+				Type:     schema.TypeInt,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"action": albActionSchema(),
+
+			"condition": &schema.Schema{
+				Type:     schema.TypeList,
+				Required: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"field": &schema.Schema{
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validateAlbListenerRuleField,
+						},
+
+						"values": &schema.Schema{
+							Type:     schema.TypeList,
+							Required: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+					},
+				},
+			},
+
+			"arn": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceAwsAlbListenerRuleCreate(d *schema.ResourceData, meta interface{}) error {
+	elbconn := meta.(*AWSClient).elbv2conn
+
+	opts := &elbv2.CreateRuleInput{
+		ListenerARN: aws.String(d.Get("listener_arn").(string)),
+		Priority:    aws.Long(int64(d.Get("priority").(int))),
+		Actions:     expandAlbActions(d.Get("action").([]interface{})),
+		Conditions:  expandAlbRuleConditions(d.Get("condition").([]interface{})),
+	}
+
+	log.Printf("[DEBUG] ALB listener rule create configuration: %#v", opts)
+	resp, err := elbconn.CreateRule(opts)
+	if err != nil {
+		return fmt.Errorf("Error creating ALB listener rule: %s", err)
+	}
+	if len(resp.Rules) != 1 {
+		return fmt.Errorf("Unexpected rules in create response: %#v", resp.Rules)
+	}
+
+	d.SetId(*resp.Rules[0].RuleARN)
+	log.Printf("[INFO] ALB listener rule ID: %s", d.Id())
+
+	return resourceAwsAlbListenerRuleRead(d, meta)
+}
+
+func resourceAwsAlbListenerRuleRead(d *schema.ResourceData, meta interface{}) error {
+	elbconn := meta.(*AWSClient).elbv2conn
+
+	resp, err := elbconn.DescribeRules(&elbv2.DescribeRulesInput{
+		RuleARNs: []*string{aws.String(d.Id())},
+	})
+	if err != nil {
+		if isElbv2Error(err, "RuleNotFound") {
+			// The rule is gone now, so just remove it from the state
+			d.SetId("")
+			return nil
+		}
+
+		return fmt.Errorf("Error retrieving ALB listener rule: %s", err)
+	}
+	if len(resp.Rules) != 1 {
+		return fmt.Errorf("Unable to find ALB listener rule: %#v", resp.Rules)
+	}
+
+	rule := resp.Rules[0]
+
+	// The priority is a string in the API, since the default rule of a
+	// listener has the priority "default". That one is never managed here.
+	priority, err := strconv.Atoi(*rule.Priority)
+	if err != nil {
+		return fmt.Errorf("Error parsing ALB listener rule priority %q: %s", *rule.Priority, err)
+	}
+
+	d.Set("arn", rule.RuleARN)
+	d.Set("priority", priority)
+	d.Set("action", flattenAlbActions(rule.Actions))
+	d.Set("condition", flattenAlbRuleConditions(rule.Conditions))
+
+	return nil
+}
+
+func resourceAwsAlbListenerRuleUpdate(d *schema.ResourceData, meta interface{}) error {
+	elbconn := meta.(*AWSClient).elbv2conn
+
+	opts := &elbv2.ModifyRuleInput{
+		RuleARN: aws.String(d.Id()),
+	}
+
+	if d.HasChange("action") {
+		opts.Actions = expandAlbActions(d.Get("action").([]interface{}))
+	}
+
+	if d.HasChange("condition") {
+		opts.Conditions = expandAlbRuleConditions(d.Get("condition").([]interface{}))
+	}
+
+	log.Printf("[DEBUG] ALB listener rule update configuration: %#v", opts)
+	if _, err := elbconn.ModifyRule(opts); err != nil {
+		return fmt.Errorf("Error updating ALB listener rule: %s", err)
+	}
+
+	return resourceAwsAlbListenerRuleRead(d, meta)
+}
+
+func resourceAwsAlbListenerRuleDelete(d *schema.ResourceData, meta interface{}) error {
+	elbconn := meta.(*AWSClient).elbv2conn
+
+	log.Printf("[INFO] Deleting ALB listener rule: %s", d.Id())
+	_, err := elbconn.DeleteRule(&elbv2.DeleteRuleInput{
+		RuleARN: aws.String(d.Id()),
+	})
+	if err != nil {
+		return fmt.Errorf("Error deleting ALB listener rule: %s", err)
+	}
+
+	return nil
+}
+
+// expandAlbRuleConditions turns the conditions of a listener rule into the
+// conditions of the API.
+func expandAlbRuleConditions(configured []interface{}) []*elbv2.RuleCondition {
+	conditions := make([]*elbv2.RuleCondition, 0, len(configured))
+	for _, raw := range configured {
+		data := raw.(map[string]interface{})
+		conditions = append(conditions, &elbv2.RuleCondition{
+			Field:  aws.String(data["field"].(string)),
+			Values: expandStringList(data["values"].([]interface{})),
+		})
+	}
+
+	return conditions
+}
+
+// flattenAlbRuleConditions turns the conditions of the API into the
+// conditions of a listener rule.
+func flattenAlbRuleConditions(list []*elbv2.RuleCondition) []map[string]interface{} {
+	result := make([]map[string]interface{}, 0, len(list))
+	for _, condition := range list {
+		values := make([]string, 0, len(condition.Values))
+		for _, v := range condition.Values {
+			values = append(values, *v)
+		}
+
+		result = append(result, map[string]interface{}{
+			"field":  *condition.Field,
+			"values": values,
+		})
+	}
+
+	return result
+}
+
+func validateAlbListenerRuleField(v interface{}, k string) (ws []string, errors []error) {
+	value := v.(string)
+	if value != "path-pattern" && value != "host-header" {
+		errors = append(errors, fmt.Errorf(
+			"%q must be path-pattern or host-header, got %q", k, value))
+	}
+
+	return
+}
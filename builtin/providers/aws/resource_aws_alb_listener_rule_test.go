@@ -0,0 +1,173 @@
+package aws
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/awslabs/aws-sdk-go/aws"
+	"github.com/awslabs/aws-sdk-go/service/elbv2"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestAccAWSAlbListenerRule_basic(t *testing.T) {
+	var conf elbv2.Rule
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAWSAlbListenerRuleDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccAWSAlbListenerRuleConfig,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSAlbListenerRuleExists("aws_alb_listener_rule.api", &conf),
+					resource.TestCheckResourceAttr(
+						"aws_alb_listener_rule.api", "priority", "100"),
+					resource.TestCheckResourceAttr(
+						"aws_alb_listener_rule.api", "condition.#", "1"),
+					resource.TestCheckResourceAttr(
+						"aws_alb_listener_rule.api", "condition.0.field", "path-pattern"),
+					resource.TestCheckResourceAttr(
+						"aws_alb_listener_rule.api", "condition.0.values.0", "/api/*"),
+					testAccCheckAWSAlbListenerRuleExists("aws_alb_listener_rule.host", &conf),
+					resource.TestCheckResourceAttr(
+						"aws_alb_listener_rule.host", "condition.0.field", "host-header"),
+					resource.TestCheckResourceAttr(
+						"aws_alb_listener_rule.host", "condition.0.values.0", "api.example.com"),
+				),
+			},
+
+			resource.TestStep{
+				Config: testAccAWSAlbListenerRuleConfig_update,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSAlbListenerRuleExists("aws_alb_listener_rule.api", &conf),
+					resource.TestCheckResourceAttr(
+						"aws_alb_listener_rule.api", "condition.0.values.0", "/v2/*"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckAWSAlbListenerRuleDestroy(s *terraform.State) error {
+	conn := testAccProvider.Meta().(*AWSClient).elbv2conn
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "aws_alb_listener_rule" {
+			continue
+		}
+
+		describe, err := conn.DescribeRules(&elbv2.DescribeRulesInput{
+			RuleARNs: []*string{aws.String(rs.Primary.ID)},
+		})
+
+		if err == nil {
+			if len(describe.Rules) != 0 {
+				return fmt.Errorf("ALB listener rule still exists")
+			}
+			continue
+		}
+
+		if !isElbv2Error(err, "RuleNotFound") {
+			return fmt.Errorf("Unexpected error: %s", err)
+		}
+	}
+
+	return nil
+}
+
+func testAccCheckAWSAlbListenerRuleExists(n string, res *elbv2.Rule) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No ALB listener rule ID is set")
+		}
+
+		conn := testAccProvider.Meta().(*AWSClient).elbv2conn
+
+		describe, err := conn.DescribeRules(&elbv2.DescribeRulesInput{
+			RuleARNs: []*string{aws.String(rs.Primary.ID)},
+		})
+		if err != nil {
+			return err
+		}
+
+		if len(describe.Rules) != 1 ||
+			*describe.Rules[0].RuleARN != rs.Primary.ID {
+			return fmt.Errorf("ALB listener rule not found")
+		}
+
+		*res = *describe.Rules[0]
+
+		return nil
+	}
+}
+
+const testAccAWSAlbListenerRuleConfig = testAccAWSAlbListenerConfig + `
+resource "aws_alb_listener_rule" "api" {
+  listener_arn = "${aws_alb_listener.front.id}"
+  priority = 100
+
+  action {
+    target_group_arn = "${aws_alb_target_group.api.id}"
+    type = "forward"
+  }
+
+  condition {
+    field = "path-pattern"
+    values = ["/api/*"]
+  }
+}
+
+resource "aws_alb_listener_rule" "host" {
+  listener_arn = "${aws_alb_listener.front.id}"
+  priority = 200
+
+  action {
+    target_group_arn = "${aws_alb_target_group.api.id}"
+    type = "forward"
+  }
+
+  condition {
+    field = "host-header"
+    values = ["api.example.com"]
+  }
+}
+`
+
+const testAccAWSAlbListenerRuleConfig_update = testAccAWSAlbListenerConfig + `
+resource "aws_alb_listener_rule" "api" {
+  listener_arn = "${aws_alb_listener.front.id}"
+  priority = 100
+
+  action {
+    target_group_arn = "${aws_alb_target_group.api.id}"
+    type = "forward"
+  }
+
+  condition {
+    field = "path-pattern"
+    values = ["/v2/*"]
+  }
+}
+
+resource "aws_alb_listener_rule" "host" {
+  listener_arn = "${aws_alb_listener.front.id}"
+  priority = 200
+
+  action {
+    target_group_arn = "${aws_alb_target_group.api.id}"
+    type = "forward"
+  }
+
+  condition {
+    field = "host-header"
+    values = ["api.example.com"]
+  }
+}
+`
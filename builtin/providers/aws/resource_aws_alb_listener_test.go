@@ -0,0 +1,171 @@
+package aws
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/awslabs/aws-sdk-go/aws"
+	"github.com/awslabs/aws-sdk-go/service/elbv2"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestAccAWSAlbListener_basic(t *testing.T) {
+	var conf elbv2.Listener
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAWSAlbListenerDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccAWSAlbListenerConfig,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSAlbListenerExists("aws_alb_listener.front", &conf),
+					resource.TestCheckResourceAttr(
+						"aws_alb_listener.front", "port", "80"),
+					resource.TestCheckResourceAttr(
+						"aws_alb_listener.front", "protocol", "HTTP"),
+					resource.TestCheckResourceAttr(
+						"aws_alb_listener.front", "default_action.#", "1"),
+					resource.TestCheckResourceAttr(
+						"aws_alb_listener.front", "default_action.0.type", "forward"),
+					testAccCheckAWSAlbListenerTargetGroup(&conf, "aws_alb_target_group.app"),
+				),
+			},
+
+			resource.TestStep{
+				Config: testAccAWSAlbListenerConfig_update,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSAlbListenerExists("aws_alb_listener.front", &conf),
+					resource.TestCheckResourceAttr(
+						"aws_alb_listener.front", "port", "8080"),
+					testAccCheckAWSAlbListenerTargetGroup(&conf, "aws_alb_target_group.api"),
+				),
+			},
+		},
+	})
+}
+
+// testAccCheckAWSAlbListenerTargetGroup checks that the listener forwards
+// to the given target group by default.
+func testAccCheckAWSAlbListenerTargetGroup(conf *elbv2.Listener, n string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		if len(conf.DefaultActions) != 1 {
+			return fmt.Errorf("Bad default actions: %#v", conf.DefaultActions)
+		}
+
+		if arn := *conf.DefaultActions[0].TargetGroupARN; arn != rs.Primary.ID {
+			return fmt.Errorf("Bad target group: %s, expected %s", arn, rs.Primary.ID)
+		}
+
+		return nil
+	}
+}
+
+func testAccCheckAWSAlbListenerDestroy(s *terraform.State) error {
+	conn := testAccProvider.Meta().(*AWSClient).elbv2conn
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "aws_alb_listener" {
+			continue
+		}
+
+		describe, err := conn.DescribeListeners(&elbv2.DescribeListenersInput{
+			ListenerARNs: []*string{aws.String(rs.Primary.ID)},
+		})
+
+		if err == nil {
+			if len(describe.Listeners) != 0 {
+				return fmt.Errorf("ALB listener still exists")
+			}
+			continue
+		}
+
+		if !isElbv2Error(err, "ListenerNotFound") {
+			return fmt.Errorf("Unexpected error: %s", err)
+		}
+	}
+
+	return nil
+}
+
+func testAccCheckAWSAlbListenerExists(n string, res *elbv2.Listener) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No ALB listener ID is set")
+		}
+
+		conn := testAccProvider.Meta().(*AWSClient).elbv2conn
+
+		describe, err := conn.DescribeListeners(&elbv2.DescribeListenersInput{
+			ListenerARNs: []*string{aws.String(rs.Primary.ID)},
+		})
+		if err != nil {
+			return err
+		}
+
+		if len(describe.Listeners) != 1 ||
+			*describe.Listeners[0].ListenerARN != rs.Primary.ID {
+			return fmt.Errorf("ALB listener not found")
+		}
+
+		*res = *describe.Listeners[0]
+
+		return nil
+	}
+}
+
+// testAccAWSAlbListenerConfigBase is an ALB with two target groups, for
+// the listeners and listener rules of the acceptance tests.
+const testAccAWSAlbListenerConfigBase = testAccAWSAlbConfig + `
+resource "aws_alb_target_group" "app" {
+  name = "tf-alb-test-app"
+  port = 8080
+  protocol = "HTTP"
+  vpc_id = "${aws_vpc.alb.id}"
+}
+
+resource "aws_alb_target_group" "api" {
+  name = "tf-alb-test-api"
+  port = 8081
+  protocol = "HTTP"
+  vpc_id = "${aws_vpc.alb.id}"
+}
+`
+
+const testAccAWSAlbListenerConfig = testAccAWSAlbListenerConfigBase + `
+resource "aws_alb_listener" "front" {
+  load_balancer_arn = "${aws_alb.bar.id}"
+  port = 80
+  protocol = "HTTP"
+
+  default_action {
+    target_group_arn = "${aws_alb_target_group.app.id}"
+    type = "forward"
+  }
+}
+`
+
+const testAccAWSAlbListenerConfig_update = testAccAWSAlbListenerConfigBase + `
+resource "aws_alb_listener" "front" {
+  load_balancer_arn = "${aws_alb.bar.id}"
+  port = 8080
+  protocol = "HTTP"
+
+  default_action {
+    target_group_arn = "${aws_alb_target_group.api.id}"
+    type = "forward"
+  }
+}
+`
@@ -0,0 +1,316 @@
+package aws
+
+import (
+	"fmt"
+	"log"
+	"strconv"
+
+	"github.com/awslabs/aws-sdk-go/aws"
+	"github.com/awslabs/aws-sdk-go/service/elbv2"
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func resourceAwsAlbTargetGroup() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceAwsAlbTargetGroupCreate,
+		Read:   resourceAwsAlbTargetGroupRead,
+		Update: resourceAwsAlbTargetGroupUpdate,
+		Delete: resourceAwsAlbTargetGroupDelete,
+
+		Schema: map[string]*schema.Schema{
+			"name": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"port": &schema.Schema{
+				Type:     schema.TypeInt,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"protocol": &schema.Schema{
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validateAlbProtocol,
+			},
+
+			"vpc_id": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"deregistration_delay": &schema.Schema{
+				Type:     schema.TypeInt,
+				Optional: true,
+				Default:  300,
+			},
+
+			"health_check": &schema.Schema{
+				Type:     schema.TypeList,
+				Optional: true,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"interval": &schema.Schema{
+							Type:     schema.TypeInt,
+							Optional: true,
+							Default:  30,
+						},
+
+						"path": &schema.Schema{
+							Type:     schema.TypeString,
+							Optional: true,
+							Default:  "/",
+						},
+
+						"port": &schema.Schema{
+							Type:     schema.TypeString,
+							Optional: true,
+							Default:  "traffic-port",
+						},
+
+						"protocol": &schema.Schema{
+							Type:         schema.TypeString,
+							Optional:     true,
+							Default:      "HTTP",
+							ValidateFunc: validateAlbProtocol,
+						},
+
+						"timeout": &schema.Schema{
+							Type:     schema.TypeInt,
+							Optional: true,
+							Default:  5,
+						},
+
+						"healthy_threshold": &schema.Schema{
+							Type:     schema.TypeInt,
+							Optional: true,
+							Default:  5,
+						},
+
+						"unhealthy_threshold": &schema.Schema{
+							Type:     schema.TypeInt,
+							Optional: true,
+							Default:  2,
+						},
+
+						"matcher": &schema.Schema{
+							Type:     schema.TypeString,
+							Optional: true,
+							Default:  "200",
+						},
+					},
+				},
+			},
+
+			"arn": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"tags": tagsSchema(),
+		},
+	}
+}
+
+func resourceAwsAlbTargetGroupCreate(d *schema.ResourceData, meta interface{}) error {
+	elbconn := meta.(*AWSClient).elbv2conn
+
+	opts := &elbv2.CreateTargetGroupInput{
+		Name:     aws.String(d.Get("name").(string)),
+		Port:     aws.Long(int64(d.Get("port").(int))),
+		Protocol: aws.String(d.Get("protocol").(string)),
+		VPCID:    aws.String(d.Get("vpc_id").(string)),
+	}
+
+	if v := d.Get("health_check").([]interface{}); len(v) > 0 {
+		check := v[0].(map[string]interface{})
+
+		opts.HealthCheckIntervalSeconds = aws.Long(int64(check["interval"].(int)))
+		opts.HealthCheckPath = aws.String(check["path"].(string))
+		opts.HealthCheckPort = aws.String(check["port"].(string))
+		opts.HealthCheckProtocol = aws.String(check["protocol"].(string))
+		opts.HealthCheckTimeoutSeconds = aws.Long(int64(check["timeout"].(int)))
+		opts.HealthyThresholdCount = aws.Long(int64(check["healthy_threshold"].(int)))
+		opts.UnhealthyThresholdCount = aws.Long(int64(check["unhealthy_threshold"].(int)))
+		opts.Matcher = &elbv2.Matcher{
+			HTTPCode: aws.String(check["matcher"].(string)),
+		}
+	}
+
+	log.Printf("[DEBUG] ALB target group create configuration: %#v", opts)
+	resp, err := elbconn.CreateTargetGroup(opts)
+	if err != nil {
+		return fmt.Errorf("Error creating ALB target group: %s", err)
+	}
+	if len(resp.TargetGroups) != 1 {
+		return fmt.Errorf("Unexpected target groups in create response: %#v", resp.TargetGroups)
+	}
+
+	d.SetId(*resp.TargetGroups[0].TargetGroupARN)
+	log.Printf("[INFO] ALB target group ID: %s", d.Id())
+
+	// The health check was set on creation, the deregistration delay and
+	// the tags are set by the update.
+	d.Partial(true)
+	d.SetPartial("name")
+	d.SetPartial("port")
+	d.SetPartial("protocol")
+	d.SetPartial("vpc_id")
+	d.SetPartial("health_check")
+
+	return resourceAwsAlbTargetGroupUpdate(d, meta)
+}
+
+func resourceAwsAlbTargetGroupRead(d *schema.ResourceData, meta interface{}) error {
+	elbconn := meta.(*AWSClient).elbv2conn
+
+	resp, err := elbconn.DescribeTargetGroups(&elbv2.DescribeTargetGroupsInput{
+		TargetGroupARNs: []*string{aws.String(d.Id())},
+	})
+	if err != nil {
+		if isElbv2Error(err, "TargetGroupNotFound") {
+			// The target group is gone now, so just remove it from the state
+			d.SetId("")
+			return nil
+		}
+
+		return fmt.Errorf("Error retrieving ALB target group: %s", err)
+	}
+	if len(resp.TargetGroups) != 1 {
+		return fmt.Errorf("Unable to find ALB target group: %#v", resp.TargetGroups)
+	}
+
+	tg := resp.TargetGroups[0]
+
+	d.Set("arn", tg.TargetGroupARN)
+	d.Set("name", tg.TargetGroupName)
+	d.Set("port", tg.Port)
+	d.Set("protocol", tg.Protocol)
+	d.Set("vpc_id", tg.VPCID)
+	d.Set("health_check", []map[string]interface{}{
+		map[string]interface{}{
+			"interval":            *tg.HealthCheckIntervalSeconds,
+			"path":                *tg.HealthCheckPath,
+			"port":                *tg.HealthCheckPort,
+			"protocol":            *tg.HealthCheckProtocol,
+			"timeout":             *tg.HealthCheckTimeoutSeconds,
+			"healthy_threshold":   *tg.HealthyThresholdCount,
+			"unhealthy_threshold": *tg.UnhealthyThresholdCount,
+			"matcher":             *tg.Matcher.HTTPCode,
+		},
+	})
+
+	attrsResp, err := elbconn.DescribeTargetGroupAttributes(&elbv2.DescribeTargetGroupAttributesInput{
+		TargetGroupARN: aws.String(d.Id()),
+	})
+	if err != nil {
+		return fmt.Errorf("Error retrieving ALB target group attributes: %s", err)
+	}
+
+	for _, attr := range attrsResp.Attributes {
+		if *attr.Key == "deregistration_delay.timeout_seconds" {
+			delay, err := strconv.Atoi(*attr.Value)
+			if err != nil {
+				return fmt.Errorf(
+					"Error parsing ALB target group deregistration delay %q: %s", *attr.Value, err)
+			}
+			d.Set("deregistration_delay", delay)
+		}
+	}
+
+	tags, err := readTagsELBv2(elbconn, d.Id())
+	if err != nil {
+		return fmt.Errorf("Error retrieving ALB target group tags: %s", err)
+	}
+	d.Set("tags", tagsToMapELBv2(tags))
+
+	return nil
+}
+
+func resourceAwsAlbTargetGroupUpdate(d *schema.ResourceData, meta interface{}) error {
+	elbconn := meta.(*AWSClient).elbv2conn
+
+	d.Partial(true)
+
+	if d.HasChange("health_check") {
+		// A health check taken out of the configuration keeps the last one
+		// that was set, since the target group always has one.
+		if v := d.Get("health_check").([]interface{}); len(v) > 0 {
+			check := v[0].(map[string]interface{})
+
+			_, err := elbconn.ModifyTargetGroup(&elbv2.ModifyTargetGroupInput{
+				TargetGroupARN:             aws.String(d.Id()),
+				HealthCheckIntervalSeconds: aws.Long(int64(check["interval"].(int))),
+				HealthCheckPath:            aws.String(check["path"].(string)),
+				HealthCheckPort:            aws.String(check["port"].(string)),
+				HealthCheckProtocol:        aws.String(check["protocol"].(string)),
+				HealthCheckTimeoutSeconds:  aws.Long(int64(check["timeout"].(int))),
+				HealthyThresholdCount:      aws.Long(int64(check["healthy_threshold"].(int))),
+				UnhealthyThresholdCount:    aws.Long(int64(check["unhealthy_threshold"].(int))),
+				Matcher: &elbv2.Matcher{
+					HTTPCode: aws.String(check["matcher"].(string)),
+				},
+			})
+			if err != nil {
+				return fmt.Errorf("Failure configuring ALB target group health check: %s", err)
+			}
+		}
+
+		d.SetPartial("health_check")
+	}
+
+	if d.HasChange("deregistration_delay") {
+		_, err := elbconn.ModifyTargetGroupAttributes(&elbv2.ModifyTargetGroupAttributesInput{
+			TargetGroupARN: aws.String(d.Id()),
+			Attributes: []*elbv2.TargetGroupAttribute{
+				&elbv2.TargetGroupAttribute{
+					Key:   aws.String("deregistration_delay.timeout_seconds"),
+					Value: aws.String(strconv.Itoa(d.Get("deregistration_delay").(int))),
+				},
+			},
+		})
+		if err != nil {
+			return fmt.Errorf("Failure configuring ALB target group attributes: %s", err)
+		}
+
+		d.SetPartial("deregistration_delay")
+	}
+
+	if err := setTagsELBv2(elbconn, d); err != nil {
+		return err
+	}
+
+	d.SetPartial("tags")
+	d.Partial(false)
+
+	return resourceAwsAlbTargetGroupRead(d, meta)
+}
+
+func resourceAwsAlbTargetGroupDelete(d *schema.ResourceData, meta interface{}) error {
+	elbconn := meta.(*AWSClient).elbv2conn
+
+	log.Printf("[INFO] Deleting ALB target group: %s", d.Id())
+	_, err := elbconn.DeleteTargetGroup(&elbv2.DeleteTargetGroupInput{
+		TargetGroupARN: aws.String(d.Id()),
+	})
+	if err != nil {
+		return fmt.Errorf("Error deleting ALB target group: %s", err)
+	}
+
+	return nil
+}
+
+func validateAlbProtocol(v interface{}, k string) (ws []string, errors []error) {
+	value := v.(string)
+	if value != "HTTP" && value != "HTTPS" {
+		errors = append(errors, fmt.Errorf(
+			"%q must be HTTP or HTTPS, got %q", k, value))
+	}
+
+	return
+}
@@ -0,0 +1,131 @@
+package aws
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/awslabs/aws-sdk-go/aws"
+	"github.com/awslabs/aws-sdk-go/service/elbv2"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+// resourceAwsAlbTargetGroupAttachment registers a single target, such as
+// an instance, with an ALB target group.
+func resourceAwsAlbTargetGroupAttachment() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceAwsAlbTargetGroupAttachmentCreate,
+		Read:   resourceAwsAlbTargetGroupAttachmentRead,
+		Delete: resourceAwsAlbTargetGroupAttachmentDelete,
+
+		Schema: map[string]*schema.Schema{
+			"target_group_arn": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"target_id": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"port": &schema.Schema{
+				Type:     schema.TypeInt,
+				Optional: true,
+				ForceNew: true,
+			},
+		},
+	}
+}
+
+func resourceAwsAlbTargetGroupAttachmentCreate(d *schema.ResourceData, meta interface{}) error {
+	elbconn := meta.(*AWSClient).elbv2conn
+	tgArn := d.Get("target_group_arn").(string)
+
+	log.Printf("[INFO] Registering target %s with ALB target group %s", d.Get("target_id"), tgArn)
+	_, err := elbconn.RegisterTargets(&elbv2.RegisterTargetsInput{
+		TargetGroupARN: aws.String(tgArn),
+		Targets:        []*elbv2.TargetDescription{albTargetDescription(d)},
+	})
+	if err != nil {
+		return fmt.Errorf(
+			"Error registering target %s with ALB target group %s: %s",
+			d.Get("target_id"), tgArn, err)
+	}
+
+	d.SetId(resource.UniqueId())
+
+	return resourceAwsAlbTargetGroupAttachmentRead(d, meta)
+}
+
+func resourceAwsAlbTargetGroupAttachmentRead(d *schema.ResourceData, meta interface{}) error {
+	elbconn := meta.(*AWSClient).elbv2conn
+	tgArn := d.Get("target_group_arn").(string)
+
+	resp, err := elbconn.DescribeTargetHealth(&elbv2.DescribeTargetHealthInput{
+		TargetGroupARN: aws.String(tgArn),
+		Targets:        []*elbv2.TargetDescription{albTargetDescription(d)},
+	})
+	if err != nil {
+		if isElbv2Error(err, "TargetGroupNotFound") || isElbv2Error(err, "InvalidTarget") {
+			// The target group or the target is gone, and the
+			// attachment with it
+			d.SetId("")
+			return nil
+		}
+
+		return fmt.Errorf("Error retrieving targets of ALB target group %s: %s", tgArn, err)
+	}
+
+	// Targets that aren't registered are still described, with the
+	// "unused" state and the "Target.NotRegistered" reason.
+	for _, th := range resp.TargetHealthDescriptions {
+		if th.TargetHealth != nil && th.TargetHealth.Reason != nil &&
+			*th.TargetHealth.Reason == "Target.NotRegistered" {
+			log.Printf("[WARN] Target %s is no longer registered with ALB target group %s",
+				d.Get("target_id"), tgArn)
+			d.SetId("")
+			return nil
+		}
+	}
+
+	if len(resp.TargetHealthDescriptions) == 0 {
+		d.SetId("")
+	}
+
+	return nil
+}
+
+func resourceAwsAlbTargetGroupAttachmentDelete(d *schema.ResourceData, meta interface{}) error {
+	elbconn := meta.(*AWSClient).elbv2conn
+	tgArn := d.Get("target_group_arn").(string)
+
+	log.Printf("[INFO] Deregistering target %s from ALB target group %s", d.Get("target_id"), tgArn)
+	_, err := elbconn.DeregisterTargets(&elbv2.DeregisterTargetsInput{
+		TargetGroupARN: aws.String(tgArn),
+		Targets:        []*elbv2.TargetDescription{albTargetDescription(d)},
+	})
+	if err != nil && !isElbv2Error(err, "TargetGroupNotFound") {
+		return fmt.Errorf(
+			"Error deregistering target %s from ALB target group %s: %s",
+			d.Get("target_id"), tgArn, err)
+	}
+
+	return nil
+}
+
+// albTargetDescription returns the target of an attachment. Without a port,
+// the target is registered on the port of the target group.
+func albTargetDescription(d *schema.ResourceData) *elbv2.TargetDescription {
+	target := &elbv2.TargetDescription{
+		ID: aws.String(d.Get("target_id").(string)),
+	}
+
+	if v, ok := d.GetOk("port"); ok {
+		target.Port = aws.Long(int64(v.(int)))
+	}
+
+	return target
+}
@@ -0,0 +1,134 @@
+package aws
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/awslabs/aws-sdk-go/aws"
+	"github.com/awslabs/aws-sdk-go/service/elbv2"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestAccAWSAlbTargetGroupAttachment_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAWSAlbTargetGroupAttachmentDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccAWSAlbTargetGroupAttachmentConfig,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSAlbTargetGroupAttachmentExists("aws_alb_target_group_attachment.foo"),
+					testAccCheckAWSAlbTargetGroupAttachmentExists("aws_alb_target_group_attachment.bar"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckAWSAlbTargetGroupAttachmentExists(n string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No ALB target group attachment ID is set")
+		}
+
+		registered, err := testAccAWSAlbTargetRegistered(rs)
+		if err != nil {
+			return err
+		}
+		if !registered {
+			return fmt.Errorf("Target %s is not registered", rs.Primary.Attributes["target_id"])
+		}
+
+		return nil
+	}
+}
+
+func testAccCheckAWSAlbTargetGroupAttachmentDestroy(s *terraform.State) error {
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "aws_alb_target_group_attachment" {
+			continue
+		}
+
+		registered, err := testAccAWSAlbTargetRegistered(rs)
+		if err != nil {
+			if isElbv2Error(err, "TargetGroupNotFound") || isElbv2Error(err, "InvalidTarget") {
+				continue
+			}
+
+			return err
+		}
+		if registered {
+			return fmt.Errorf("Target %s is still registered", rs.Primary.Attributes["target_id"])
+		}
+	}
+
+	return nil
+}
+
+// testAccAWSAlbTargetRegistered returns whether the target of an attachment
+// is registered with its target group.
+func testAccAWSAlbTargetRegistered(rs *terraform.ResourceState) (bool, error) {
+	conn := testAccProvider.Meta().(*AWSClient).elbv2conn
+
+	target := &elbv2.TargetDescription{
+		ID: aws.String(rs.Primary.Attributes["target_id"]),
+	}
+	if port := rs.Primary.Attributes["port"]; port != "" && port != "0" {
+		var p int64
+		if _, err := fmt.Sscan(port, &p); err != nil {
+			return false, err
+		}
+		target.Port = aws.Long(p)
+	}
+
+	resp, err := conn.DescribeTargetHealth(&elbv2.DescribeTargetHealthInput{
+		TargetGroupARN: aws.String(rs.Primary.Attributes["target_group_arn"]),
+		Targets:        []*elbv2.TargetDescription{target},
+	})
+	if err != nil {
+		return false, err
+	}
+
+	for _, th := range resp.TargetHealthDescriptions {
+		if th.TargetHealth != nil && th.TargetHealth.Reason != nil &&
+			*th.TargetHealth.Reason == "Target.NotRegistered" {
+			return false, nil
+		}
+	}
+
+	return len(resp.TargetHealthDescriptions) > 0, nil
+}
+
+const testAccAWSAlbTargetGroupAttachmentConfig = testAccAWSAlbConfigVpc + `
+resource "aws_alb_target_group" "test" {
+  name = "tf-alb-test"
+  port = 8080
+  protocol = "HTTP"
+  vpc_id = "${aws_vpc.alb.id}"
+}
+
+resource "aws_instance" "foo" {
+  # us-west-2
+  ami = "ami-4fccb37f"
+  instance_type = "m1.small"
+  subnet_id = "${aws_subnet.a.id}"
+}
+
+resource "aws_alb_target_group_attachment" "foo" {
+  target_group_arn = "${aws_alb_target_group.test.id}"
+  target_id = "${aws_instance.foo.id}"
+}
+
+resource "aws_alb_target_group_attachment" "bar" {
+  target_group_arn = "${aws_alb_target_group.test.id}"
+  target_id = "${aws_instance.foo.id}"
+  port = 9090
+}
+`
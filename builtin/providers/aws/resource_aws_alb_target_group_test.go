@@ -0,0 +1,204 @@
+package aws
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/awslabs/aws-sdk-go/aws"
+	"github.com/awslabs/aws-sdk-go/service/elbv2"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestAccAWSAlbTargetGroup_basic(t *testing.T) {
+	var conf elbv2.TargetGroup
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAWSAlbTargetGroupDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccAWSAlbTargetGroupConfig,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSAlbTargetGroupExists("aws_alb_target_group.test", &conf),
+					resource.TestCheckResourceAttr(
+						"aws_alb_target_group.test", "name", "tf-alb-test"),
+					resource.TestCheckResourceAttr(
+						"aws_alb_target_group.test", "port", "8080"),
+					resource.TestCheckResourceAttr(
+						"aws_alb_target_group.test", "protocol", "HTTP"),
+					resource.TestCheckResourceAttr(
+						"aws_alb_target_group.test", "deregistration_delay", "200"),
+					resource.TestCheckResourceAttr(
+						"aws_alb_target_group.test", "health_check.#", "1"),
+					resource.TestCheckResourceAttr(
+						"aws_alb_target_group.test", "health_check.0.path", "/health"),
+					resource.TestCheckResourceAttr(
+						"aws_alb_target_group.test", "health_check.0.interval", "60"),
+					resource.TestCheckResourceAttr(
+						"aws_alb_target_group.test", "health_check.0.port", "8081"),
+					resource.TestCheckResourceAttr(
+						"aws_alb_target_group.test", "health_check.0.matcher", "200-299"),
+					resource.TestCheckResourceAttr(
+						"aws_alb_target_group.test", "tags.Name", "tf-alb-test"),
+				),
+			},
+
+			resource.TestStep{
+				Config: testAccAWSAlbTargetGroupConfig_update,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSAlbTargetGroupExists("aws_alb_target_group.test", &conf),
+					resource.TestCheckResourceAttr(
+						"aws_alb_target_group.test", "deregistration_delay", "30"),
+					resource.TestCheckResourceAttr(
+						"aws_alb_target_group.test", "health_check.0.path", "/status"),
+					resource.TestCheckResourceAttr(
+						"aws_alb_target_group.test", "health_check.0.interval", "10"),
+					resource.TestCheckResourceAttr(
+						"aws_alb_target_group.test", "health_check.0.port", "traffic-port"),
+					resource.TestCheckResourceAttr(
+						"aws_alb_target_group.test", "health_check.0.healthy_threshold", "3"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccAWSAlbTargetGroup_defaultHealthCheck(t *testing.T) {
+	var conf elbv2.TargetGroup
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAWSAlbTargetGroupDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccAWSAlbTargetGroupConfig_defaultHealthCheck,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSAlbTargetGroupExists("aws_alb_target_group.test", &conf),
+					resource.TestCheckResourceAttr(
+						"aws_alb_target_group.test", "health_check.0.path", "/"),
+					resource.TestCheckResourceAttr(
+						"aws_alb_target_group.test", "health_check.0.port", "traffic-port"),
+					resource.TestCheckResourceAttr(
+						"aws_alb_target_group.test", "health_check.0.protocol", "HTTP"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckAWSAlbTargetGroupDestroy(s *terraform.State) error {
+	conn := testAccProvider.Meta().(*AWSClient).elbv2conn
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "aws_alb_target_group" {
+			continue
+		}
+
+		describe, err := conn.DescribeTargetGroups(&elbv2.DescribeTargetGroupsInput{
+			TargetGroupARNs: []*string{aws.String(rs.Primary.ID)},
+		})
+
+		if err == nil {
+			if len(describe.TargetGroups) != 0 {
+				return fmt.Errorf("ALB target group still exists")
+			}
+			continue
+		}
+
+		if !isElbv2Error(err, "TargetGroupNotFound") {
+			return fmt.Errorf("Unexpected error: %s", err)
+		}
+	}
+
+	return nil
+}
+
+func testAccCheckAWSAlbTargetGroupExists(n string, res *elbv2.TargetGroup) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No ALB target group ID is set")
+		}
+
+		conn := testAccProvider.Meta().(*AWSClient).elbv2conn
+
+		describe, err := conn.DescribeTargetGroups(&elbv2.DescribeTargetGroupsInput{
+			TargetGroupARNs: []*string{aws.String(rs.Primary.ID)},
+		})
+		if err != nil {
+			return err
+		}
+
+		if len(describe.TargetGroups) != 1 ||
+			*describe.TargetGroups[0].TargetGroupARN != rs.Primary.ID {
+			return fmt.Errorf("ALB target group not found")
+		}
+
+		*res = *describe.TargetGroups[0]
+
+		return nil
+	}
+}
+
+const testAccAWSAlbTargetGroupConfig = testAccAWSAlbConfigVpc + `
+resource "aws_alb_target_group" "test" {
+  name = "tf-alb-test"
+  port = 8080
+  protocol = "HTTP"
+  vpc_id = "${aws_vpc.alb.id}"
+  deregistration_delay = 200
+
+  health_check {
+    path = "/health"
+    interval = 60
+    port = 8081
+    protocol = "HTTP"
+    timeout = 3
+    healthy_threshold = 3
+    unhealthy_threshold = 3
+    matcher = "200-299"
+  }
+
+  tags {
+    Name = "tf-alb-test"
+  }
+}
+`
+
+const testAccAWSAlbTargetGroupConfig_update = testAccAWSAlbConfigVpc + `
+resource "aws_alb_target_group" "test" {
+  name = "tf-alb-test"
+  port = 8080
+  protocol = "HTTP"
+  vpc_id = "${aws_vpc.alb.id}"
+  deregistration_delay = 30
+
+  health_check {
+    path = "/status"
+    interval = 10
+    timeout = 4
+    healthy_threshold = 3
+    unhealthy_threshold = 3
+  }
+
+  tags {
+    Name = "tf-alb-test"
+  }
+}
+`
+
+const testAccAWSAlbTargetGroupConfig_defaultHealthCheck = testAccAWSAlbConfigVpc + `
+resource "aws_alb_target_group" "test" {
+  name = "tf-alb-test"
+  port = 80
+  protocol = "HTTP"
+  vpc_id = "${aws_vpc.alb.id}"
+}
+`
@@ -0,0 +1,265 @@
+package aws
+
+import (
+	"fmt"
+	"log"
+	"testing"
+
+	"github.com/awslabs/aws-sdk-go/aws"
+	"github.com/awslabs/aws-sdk-go/service/elbv2"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func init() {
+	resource.AddTestSweepers("aws_alb", &resource.Sweeper{
+		F: testSweepAlbs,
+	})
+}
+
+func testSweepAlbs(region string) error {
+	client, err := sharedClientForRegion(region)
+	if err != nil {
+		return err
+	}
+	conn := client.elbv2conn
+
+	resp, err := conn.DescribeLoadBalancers(&elbv2.DescribeLoadBalancersInput{})
+	if err != nil {
+		return fmt.Errorf("Error describing ALBs: %s", err)
+	}
+
+	for _, lb := range resp.LoadBalancers {
+		if !testSweepName(*lb.LoadBalancerName) {
+			continue
+		}
+
+		log.Printf("[INFO] Deleting ALB: %s", *lb.LoadBalancerName)
+		_, err := conn.DeleteLoadBalancer(&elbv2.DeleteLoadBalancerInput{
+			LoadBalancerARN: lb.LoadBalancerARN,
+		})
+		if err != nil {
+			return fmt.Errorf(
+				"Error deleting ALB %s: %s", *lb.LoadBalancerName, err)
+		}
+	}
+
+	return nil
+}
+
+func TestAccAWSAlb_basic(t *testing.T) {
+	var conf elbv2.LoadBalancer
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAWSAlbDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccAWSAlbConfig,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSAlbExists("aws_alb.bar", &conf),
+					resource.TestCheckResourceAttr(
+						"aws_alb.bar", "name", "tf-alb-test"),
+					resource.TestCheckResourceAttr(
+						"aws_alb.bar", "internal", "false"),
+					resource.TestCheckResourceAttr(
+						"aws_alb.bar", "subnets.#", "2"),
+					resource.TestCheckResourceAttr(
+						"aws_alb.bar", "security_groups.#", "1"),
+					resource.TestCheckResourceAttr(
+						"aws_alb.bar", "idle_timeout", "30"),
+					resource.TestCheckResourceAttr(
+						"aws_alb.bar", "enable_deletion_protection", "false"),
+					resource.TestCheckResourceAttr(
+						"aws_alb.bar", "tags.Name", "tf-alb-test"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccAWSAlb_update(t *testing.T) {
+	var before, after elbv2.LoadBalancer
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAWSAlbDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccAWSAlbConfig,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSAlbExists("aws_alb.bar", &before),
+				),
+			},
+
+			resource.TestStep{
+				Config: testAccAWSAlbConfig_update,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSAlbExists("aws_alb.bar", &after),
+					testAccCheckAWSAlbNotRecreated(&before, &after),
+					resource.TestCheckResourceAttr(
+						"aws_alb.bar", "subnets.#", "2"),
+					resource.TestCheckResourceAttr(
+						"aws_alb.bar", "idle_timeout", "120"),
+					resource.TestCheckResourceAttr(
+						"aws_alb.bar", "tags.Name", "tf-alb-test"),
+					resource.TestCheckResourceAttr(
+						"aws_alb.bar", "tags.Env", "test"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckAWSAlbNotRecreated(before, after *elbv2.LoadBalancer) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		if *before.LoadBalancerARN != *after.LoadBalancerARN {
+			return fmt.Errorf("ALB was recreated: %s -> %s",
+				*before.LoadBalancerARN, *after.LoadBalancerARN)
+		}
+
+		return nil
+	}
+}
+
+func testAccCheckAWSAlbDestroy(s *terraform.State) error {
+	conn := testAccProvider.Meta().(*AWSClient).elbv2conn
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "aws_alb" {
+			continue
+		}
+
+		describe, err := conn.DescribeLoadBalancers(&elbv2.DescribeLoadBalancersInput{
+			LoadBalancerARNs: []*string{aws.String(rs.Primary.ID)},
+		})
+
+		if err == nil {
+			if len(describe.LoadBalancers) != 0 {
+				return fmt.Errorf("ALB still exists")
+			}
+			continue
+		}
+
+		if !isLoadBalancerNotFound(err) {
+			return fmt.Errorf("Unexpected error: %s", err)
+		}
+	}
+
+	return nil
+}
+
+func testAccCheckAWSAlbExists(n string, res *elbv2.LoadBalancer) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No ALB ID is set")
+		}
+
+		conn := testAccProvider.Meta().(*AWSClient).elbv2conn
+
+		describe, err := conn.DescribeLoadBalancers(&elbv2.DescribeLoadBalancersInput{
+			LoadBalancerARNs: []*string{aws.String(rs.Primary.ID)},
+		})
+		if err != nil {
+			return err
+		}
+
+		if len(describe.LoadBalancers) != 1 ||
+			*describe.LoadBalancers[0].LoadBalancerARN != rs.Primary.ID {
+			return fmt.Errorf("ALB not found")
+		}
+
+		if *describe.LoadBalancers[0].DNSName == "" {
+			return fmt.Errorf("empty dns_name")
+		}
+
+		*res = *describe.LoadBalancers[0]
+
+		return nil
+	}
+}
+
+// testAccAWSAlbConfigVpc is the VPC the ALBs of the acceptance tests are
+// in. An ALB needs subnets in at least two availability zones.
+const testAccAWSAlbConfigVpc = `
+resource "aws_vpc" "alb" {
+  cidr_block = "10.10.0.0/16"
+
+  tags {
+    Name = "tf-alb-test"
+  }
+}
+
+resource "aws_internet_gateway" "alb" {
+  vpc_id = "${aws_vpc.alb.id}"
+}
+
+resource "aws_subnet" "a" {
+  vpc_id = "${aws_vpc.alb.id}"
+  availability_zone = "us-west-2a"
+  cidr_block = "10.10.1.0/24"
+}
+
+resource "aws_subnet" "b" {
+  vpc_id = "${aws_vpc.alb.id}"
+  availability_zone = "us-west-2b"
+  cidr_block = "10.10.2.0/24"
+}
+
+resource "aws_subnet" "c" {
+  vpc_id = "${aws_vpc.alb.id}"
+  availability_zone = "us-west-2c"
+  cidr_block = "10.10.3.0/24"
+}
+
+resource "aws_security_group" "alb" {
+  name = "tf-alb-test"
+  description = "ALB acceptance tests"
+  vpc_id = "${aws_vpc.alb.id}"
+
+  ingress {
+    protocol = "tcp"
+    from_port = 80
+    to_port = 80
+    cidr_blocks = ["0.0.0.0/0"]
+  }
+}
+`
+
+const testAccAWSAlbConfig = testAccAWSAlbConfigVpc + `
+resource "aws_alb" "bar" {
+  name = "tf-alb-test"
+  subnets = ["${aws_subnet.a.id}", "${aws_subnet.b.id}"]
+  security_groups = ["${aws_security_group.alb.id}"]
+  idle_timeout = 30
+
+  tags {
+    Name = "tf-alb-test"
+  }
+
+  depends_on = ["aws_internet_gateway.alb"]
+}
+`
+
+const testAccAWSAlbConfig_update = testAccAWSAlbConfigVpc + `
+resource "aws_alb" "bar" {
+  name = "tf-alb-test"
+  subnets = ["${aws_subnet.a.id}", "${aws_subnet.c.id}"]
+  security_groups = ["${aws_security_group.alb.id}"]
+  idle_timeout = 120
+
+  tags {
+    Name = "tf-alb-test"
+    Env = "test"
+  }
+
+  depends_on = ["aws_internet_gateway.alb"]
+}
+`
@@ -0,0 +1,151 @@
+package aws
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/awslabs/aws-sdk-go/aws"
+	"github.com/awslabs/aws-sdk-go/service/autoscaling"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+// resourceAwsAutoscalingAttachment attaches a single ELB or ALB target
+// group to an autoscaling group. Unlike the load_balancers and
+// target_group_arns of the group, it only manages its own attachment, so
+// that load balancers defined elsewhere, such as in another module, can be
+// attached to the group.
+func resourceAwsAutoscalingAttachment() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceAwsAutoscalingAttachmentCreate,
+		Read:   resourceAwsAutoscalingAttachmentRead,
+		Delete: resourceAwsAutoscalingAttachmentDelete,
+
+		Schema: map[string]*schema.Schema{
+			"autoscaling_group_name": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"elb": &schema.Schema{
+				Type:          schema.TypeString,
+				Optional:      true,
+				ForceNew:      true,
+				ConflictsWith: []string{"alb_target_group_arn"},
+			},
+
+			"alb_target_group_arn": &schema.Schema{
+				Type:          schema.TypeString,
+				Optional:      true,
+				ForceNew:      true,
+				ConflictsWith: []string{"elb"},
+			},
+		},
+	}
+}
+
+func resourceAwsAutoscalingAttachmentCreate(d *schema.ResourceData, meta interface{}) error {
+	autoscalingconn := meta.(*AWSClient).autoscalingconn
+	asgName := d.Get("autoscaling_group_name").(string)
+
+	if elb, ok := d.GetOk("elb"); ok {
+		log.Printf("[INFO] Attaching ELB %s to autoscaling group %s", elb, asgName)
+		_, err := autoscalingconn.AttachLoadBalancers(&autoscaling.AttachLoadBalancersInput{
+			AutoScalingGroupName: aws.String(asgName),
+			LoadBalancerNames:    []*string{aws.String(elb.(string))},
+		})
+		if err != nil {
+			return fmt.Errorf(
+				"Error attaching ELB %s to autoscaling group %s: %s", elb, asgName, err)
+		}
+	} else if tgArn, ok := d.GetOk("alb_target_group_arn"); ok {
+		log.Printf("[INFO] Attaching ALB target group %s to autoscaling group %s", tgArn, asgName)
+		_, err := autoscalingconn.AttachLoadBalancerTargetGroups(&autoscaling.AttachLoadBalancerTargetGroupsInput{
+			AutoScalingGroupName: aws.String(asgName),
+			TargetGroupARNs:      []*string{aws.String(tgArn.(string))},
+		})
+		if err != nil {
+			return fmt.Errorf(
+				"Error attaching ALB target group %s to autoscaling group %s: %s", tgArn, asgName, err)
+		}
+	} else {
+		return fmt.Errorf("One of elb or alb_target_group_arn must be set")
+	}
+
+	d.SetId(resource.UniqueId())
+
+	return resourceAwsAutoscalingAttachmentRead(d, meta)
+}
+
+func resourceAwsAutoscalingAttachmentRead(d *schema.ResourceData, meta interface{}) error {
+	autoscalingconn := meta.(*AWSClient).autoscalingconn
+	asgName := d.Get("autoscaling_group_name").(string)
+
+	resp, err := autoscalingconn.DescribeAutoScalingGroups(&autoscaling.DescribeAutoScalingGroupsInput{
+		AutoScalingGroupNames: []*string{aws.String(asgName)},
+	})
+	if err != nil {
+		return fmt.Errorf("Error retrieving autoscaling group %s: %s", asgName, err)
+	}
+	if len(resp.AutoScalingGroups) == 0 {
+		// The group is gone, and the attachment with it
+		d.SetId("")
+		return nil
+	}
+
+	g := resp.AutoScalingGroups[0]
+	if elb, ok := d.GetOk("elb"); ok {
+		for _, name := range g.LoadBalancerNames {
+			if *name == elb.(string) {
+				return nil
+			}
+		}
+
+		log.Printf("[WARN] ELB %s is no longer attached to autoscaling group %s", elb, asgName)
+	} else {
+		tgArn := d.Get("alb_target_group_arn").(string)
+		for _, arn := range g.TargetGroupARNs {
+			if *arn == tgArn {
+				return nil
+			}
+		}
+
+		log.Printf("[WARN] ALB target group %s is no longer attached to autoscaling group %s", tgArn, asgName)
+	}
+
+	d.SetId("")
+	return nil
+}
+
+func resourceAwsAutoscalingAttachmentDelete(d *schema.ResourceData, meta interface{}) error {
+	autoscalingconn := meta.(*AWSClient).autoscalingconn
+	asgName := d.Get("autoscaling_group_name").(string)
+
+	if elb, ok := d.GetOk("elb"); ok {
+		log.Printf("[INFO] Detaching ELB %s from autoscaling group %s", elb, asgName)
+		_, err := autoscalingconn.DetachLoadBalancers(&autoscaling.DetachLoadBalancersInput{
+			AutoScalingGroupName: aws.String(asgName),
+			LoadBalancerNames:    []*string{aws.String(elb.(string))},
+		})
+		if err != nil {
+			return fmt.Errorf(
+				"Error detaching ELB %s from autoscaling group %s: %s", elb, asgName, err)
+		}
+
+		return nil
+	}
+
+	tgArn := d.Get("alb_target_group_arn").(string)
+	log.Printf("[INFO] Detaching ALB target group %s from autoscaling group %s", tgArn, asgName)
+	_, err := autoscalingconn.DetachLoadBalancerTargetGroups(&autoscaling.DetachLoadBalancerTargetGroupsInput{
+		AutoScalingGroupName: aws.String(asgName),
+		TargetGroupARNs:      []*string{aws.String(tgArn)},
+	})
+	if err != nil {
+		return fmt.Errorf(
+			"Error detaching ALB target group %s from autoscaling group %s: %s", tgArn, asgName, err)
+	}
+
+	return nil
+}
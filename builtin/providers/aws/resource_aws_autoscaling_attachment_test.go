@@ -0,0 +1,265 @@
+package aws
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/awslabs/aws-sdk-go/aws"
+	"github.com/awslabs/aws-sdk-go/service/autoscaling"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestAccAWSAutoscalingAttachment_elb(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAWSAutoscalingAttachmentDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccAWSAutoscalingAttachmentConfigGroup,
+				Check: testAccCheckAWSAutoscalingAttachmentCount(
+					"aws_autoscaling_group.asg", 0),
+			},
+
+			resource.TestStep{
+				Config: testAccAWSAutoscalingAttachmentConfigELB,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSAutoscalingAttachmentExists(
+						"aws_autoscaling_attachment.foo"),
+					testAccCheckAWSAutoscalingAttachmentCount(
+						"aws_autoscaling_group.asg", 1),
+				),
+			},
+		},
+	})
+}
+
+func TestAccAWSAutoscalingAttachment_albTargetGroup(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAWSAutoscalingAttachmentDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccAWSAutoscalingAttachmentConfigVpcGroup,
+				Check: testAccCheckAWSAutoscalingAttachmentTargetGroupCount(
+					"aws_autoscaling_group.asg", 0),
+			},
+
+			resource.TestStep{
+				Config: testAccAWSAutoscalingAttachmentConfigTargetGroup,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSAutoscalingAttachmentExists(
+						"aws_autoscaling_attachment.foo"),
+					testAccCheckAWSAutoscalingAttachmentTargetGroupCount(
+						"aws_autoscaling_group.asg", 1),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckAWSAutoscalingAttachmentExists(n string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		attached, err := testAccAWSAutoscalingAttached(rs)
+		if err != nil {
+			return err
+		}
+		if !attached {
+			return fmt.Errorf("Load balancer is not attached: %s", rs.Primary.ID)
+		}
+
+		return nil
+	}
+}
+
+func testAccCheckAWSAutoscalingAttachmentDestroy(s *terraform.State) error {
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "aws_autoscaling_attachment" {
+			continue
+		}
+
+		attached, err := testAccAWSAutoscalingAttached(rs)
+		if err != nil {
+			if ae, ok := err.(aws.APIError); ok && ae.Code == "ValidationError" {
+				continue
+			}
+			return err
+		}
+		if attached {
+			return fmt.Errorf("Load balancer is still attached: %s", rs.Primary.ID)
+		}
+	}
+
+	return nil
+}
+
+// testAccCheckAWSAutoscalingAttachmentCount checks the number of ELBs
+// attached to the group, which shouldn't be changed by the group itself
+// when it doesn't set load_balancers.
+func testAccCheckAWSAutoscalingAttachmentCount(n string, count int) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		g, err := testAccAWSAutoscalingAttachmentGroup(s, n)
+		if err != nil {
+			return err
+		}
+
+		actual := len(g.LoadBalancerNames)
+		if actual != count {
+			return fmt.Errorf("Expected %d load balancers, got %d", count, actual)
+		}
+
+		return nil
+	}
+}
+
+// testAccCheckAWSAutoscalingAttachmentTargetGroupCount checks the number of
+// ALB target groups attached to the group, which shouldn't be changed by
+// the group itself when it doesn't set target_group_arns.
+func testAccCheckAWSAutoscalingAttachmentTargetGroupCount(n string, count int) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		g, err := testAccAWSAutoscalingAttachmentGroup(s, n)
+		if err != nil {
+			return err
+		}
+
+		actual := len(g.TargetGroupARNs)
+		if actual != count {
+			return fmt.Errorf("Expected %d target groups, got %d", count, actual)
+		}
+
+		return nil
+	}
+}
+
+func testAccAWSAutoscalingAttachmentGroup(s *terraform.State, n string) (*autoscaling.AutoScalingGroup, error) {
+	rs, ok := s.RootModule().Resources[n]
+	if !ok {
+		return nil, fmt.Errorf("Not found: %s", n)
+	}
+
+	conn := testAccProvider.Meta().(*AWSClient).autoscalingconn
+	resp, err := conn.DescribeAutoScalingGroups(&autoscaling.DescribeAutoScalingGroupsInput{
+		AutoScalingGroupNames: []*string{aws.String(rs.Primary.ID)},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(resp.AutoScalingGroups) != 1 {
+		return nil, fmt.Errorf("Autoscaling group not found: %s", rs.Primary.ID)
+	}
+
+	return resp.AutoScalingGroups[0], nil
+}
+
+func testAccAWSAutoscalingAttached(rs *terraform.ResourceState) (bool, error) {
+	conn := testAccProvider.Meta().(*AWSClient).autoscalingconn
+	asgName := rs.Primary.Attributes["autoscaling_group_name"]
+
+	elb := rs.Primary.Attributes["elb"]
+	tgArn := rs.Primary.Attributes["alb_target_group_arn"]
+
+	resp, err := conn.DescribeAutoScalingGroups(&autoscaling.DescribeAutoScalingGroupsInput{
+		AutoScalingGroupNames: []*string{aws.String(asgName)},
+	})
+	if err != nil {
+		return false, err
+	}
+	if len(resp.AutoScalingGroups) == 0 {
+		return false, nil
+	}
+
+	if elb != "" {
+		for _, name := range resp.AutoScalingGroups[0].LoadBalancerNames {
+			if *name == elb {
+				return true, nil
+			}
+		}
+
+		return false, nil
+	}
+
+	for _, arn := range resp.AutoScalingGroups[0].TargetGroupARNs {
+		if *arn == tgArn {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+const testAccAWSAutoscalingAttachmentConfigGroup = `
+resource "aws_elb" "foo" {
+  name = "tf-test-asg-attachment"
+  availability_zones = ["us-west-2a"]
+
+  listener {
+    instance_port = 8000
+    instance_protocol = "http"
+    lb_port = 80
+    lb_protocol = "http"
+  }
+}
+
+resource "aws_launch_configuration" "foo" {
+  name = "tf-test-asg-attachment"
+  image_id = "ami-21f78e11"
+  instance_type = "t1.micro"
+}
+
+resource "aws_autoscaling_group" "asg" {
+  availability_zones = ["us-west-2a"]
+  name = "tf-test-asg-attachment"
+  max_size = 1
+  min_size = 0
+  desired_capacity = 0
+  force_delete = true
+  launch_configuration = "${aws_launch_configuration.foo.name}"
+}
+`
+
+const testAccAWSAutoscalingAttachmentConfigELB = testAccAWSAutoscalingAttachmentConfigGroup + `
+resource "aws_autoscaling_attachment" "foo" {
+  autoscaling_group_name = "${aws_autoscaling_group.asg.id}"
+  elb = "${aws_elb.foo.id}"
+}
+`
+
+const testAccAWSAutoscalingAttachmentConfigVpcGroup = testAccAWSAlbConfigVpc + `
+resource "aws_alb_target_group" "foo" {
+  name = "tf-test-asg-attachment"
+  port = 8080
+  protocol = "HTTP"
+  vpc_id = "${aws_vpc.alb.id}"
+}
+
+resource "aws_launch_configuration" "foo" {
+  name = "tf-test-asg-attachment"
+  image_id = "ami-4fccb37f"
+  instance_type = "m1.small"
+}
+
+resource "aws_autoscaling_group" "asg" {
+  availability_zones = ["us-west-2a"]
+  vpc_zone_identifier = ["${aws_subnet.a.id}"]
+  name = "tf-test-asg-attachment"
+  max_size = 1
+  min_size = 0
+  desired_capacity = 0
+  force_delete = true
+  launch_configuration = "${aws_launch_configuration.foo.name}"
+}
+`
+
+const testAccAWSAutoscalingAttachmentConfigTargetGroup = testAccAWSAutoscalingAttachmentConfigVpcGroup + `
+resource "aws_autoscaling_attachment" "foo" {
+  autoscaling_group_name = "${aws_autoscaling_group.asg.id}"
+  alb_target_group_arn = "${aws_alb_target_group.foo.id}"
+}
+`
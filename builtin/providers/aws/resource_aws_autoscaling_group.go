@@ -11,6 +11,7 @@ import (
 
 	"github.com/awslabs/aws-sdk-go/aws"
 	"github.com/awslabs/aws-sdk-go/service/autoscaling"
+	"github.com/awslabs/aws-sdk-go/service/elb"
 )
 
 func resourceAwsAutoscalingGroup() *schema.Resource {
@@ -62,6 +63,21 @@ func resourceAwsAutoscalingGroup() *schema.Resource {
 				ForceNew: true,
 			},
 
+			// wait_for_elb_drain detaches the load balancers from the group
+			// before it's drained on destroy, and waits for the ELBs to
+			// drain the connections of its instances.
+			"wait_for_elb_drain": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+
+			"drain_timeout": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  "10m",
+			},
+
 			"health_check_grace_period": &schema.Schema{
 				Type:     schema.TypeInt,
 				Optional: true,
@@ -86,7 +102,13 @@ func resourceAwsAutoscalingGroup() *schema.Resource {
 			"load_balancers": &schema.Schema{
 				Type:     schema.TypeSet,
 				Optional: true,
-				ForceNew: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Set:      schema.HashString,
+			},
+
+			"target_group_arns": &schema.Schema{
+				Type:     schema.TypeSet,
+				Optional: true,
 				Elem:     &schema.Schema{Type: schema.TypeString},
 				Set:      schema.HashString,
 			},
@@ -110,6 +132,35 @@ func resourceAwsAutoscalingGroup() *schema.Resource {
 			},
 
 			"tag": autoscalingTagsSchema(),
+
+			// rolling_update opts in to replacing the instances of the group
+			// when its launch configuration changes. Otherwise only the
+			// instances launched afterwards use the new launch configuration.
+			"rolling_update": &schema.Schema{
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"batch_size": &schema.Schema{
+							Type:     schema.TypeInt,
+							Optional: true,
+							Default:  1,
+						},
+
+						"pause_time": &schema.Schema{
+							Type:     schema.TypeInt,
+							Optional: true,
+							Default:  0,
+						},
+
+						"timeout": &schema.Schema{
+							Type:     schema.TypeString,
+							Optional: true,
+							Default:  "10m",
+						},
+					},
+				},
+			},
 		},
 	}
 }
@@ -151,6 +202,11 @@ func resourceAwsAutoscalingGroupCreate(d *schema.ResourceData, meta interface{})
 			v.(*schema.Set).List())
 	}
 
+	if v, ok := d.GetOk("target_group_arns"); ok && v.(*schema.Set).Len() > 0 {
+		autoScalingGroupOpts.TargetGroupARNs = expandStringList(
+			v.(*schema.Set).List())
+	}
+
 	if v, ok := d.GetOk("vpc_zone_identifier"); ok && v.(*schema.Set).Len() > 0 {
 		exp := expandStringList(v.(*schema.Set).List())
 		strs := make([]string, len(exp))
@@ -192,7 +248,6 @@ func resourceAwsAutoscalingGroupRead(d *schema.ResourceData, meta interface{}) e
 	d.Set("health_check_grace_period", g.HealthCheckGracePeriod)
 	d.Set("health_check_type", g.HealthCheckType)
 	d.Set("launch_configuration", g.LaunchConfigurationName)
-	d.Set("load_balancers", g.LoadBalancerNames)
 	d.Set("min_size", g.MinSize)
 	d.Set("max_size", g.MaxSize)
 	d.Set("name", g.AutoScalingGroupName)
@@ -200,6 +255,17 @@ func resourceAwsAutoscalingGroupRead(d *schema.ResourceData, meta interface{}) e
 	d.Set("vpc_zone_identifier", strings.Split(*g.VPCZoneIdentifier, ","))
 	d.Set("termination_policies", g.TerminationPolicies)
 
+	// Load balancers and target groups can also be attached with an
+	// aws_autoscaling_attachment. Those must not show up as a diff on a
+	// group that manages none itself, so the attached ones are only read
+	// back while the group has some in its state.
+	if v, ok := d.GetOk("load_balancers"); ok && v.(*schema.Set).Len() > 0 {
+		d.Set("load_balancers", g.LoadBalancerNames)
+	}
+	if v, ok := d.GetOk("target_group_arns"); ok && v.(*schema.Set).Len() > 0 {
+		d.Set("target_group_arns", g.TargetGroupARNs)
+	}
+
 	return nil
 }
 
@@ -230,6 +296,58 @@ func resourceAwsAutoscalingGroupUpdate(d *schema.ResourceData, meta interface{})
                 opts.HealthCheckGracePeriod = aws.Long(int64(d.Get("health_check_grace_period").(int)))
         }
 
+	if d.HasChange("load_balancers") {
+		o, n := d.GetChange("load_balancers")
+		os := o.(*schema.Set)
+		ns := n.(*schema.Set)
+
+		if remove := expandStringList(os.Difference(ns).List()); len(remove) > 0 {
+			_, err := autoscalingconn.DetachLoadBalancers(&autoscaling.DetachLoadBalancersInput{
+				AutoScalingGroupName: aws.String(d.Id()),
+				LoadBalancerNames:    remove,
+			})
+			if err != nil {
+				return fmt.Errorf("Error detaching load balancers: %s", err)
+			}
+		}
+
+		if add := expandStringList(ns.Difference(os).List()); len(add) > 0 {
+			_, err := autoscalingconn.AttachLoadBalancers(&autoscaling.AttachLoadBalancersInput{
+				AutoScalingGroupName: aws.String(d.Id()),
+				LoadBalancerNames:    add,
+			})
+			if err != nil {
+				return fmt.Errorf("Error attaching load balancers: %s", err)
+			}
+		}
+	}
+
+	if d.HasChange("target_group_arns") {
+		o, n := d.GetChange("target_group_arns")
+		os := o.(*schema.Set)
+		ns := n.(*schema.Set)
+
+		if remove := expandStringList(os.Difference(ns).List()); len(remove) > 0 {
+			_, err := autoscalingconn.DetachLoadBalancerTargetGroups(&autoscaling.DetachLoadBalancerTargetGroupsInput{
+				AutoScalingGroupName: aws.String(d.Id()),
+				TargetGroupARNs:      remove,
+			})
+			if err != nil {
+				return fmt.Errorf("Error detaching target groups: %s", err)
+			}
+		}
+
+		if add := expandStringList(ns.Difference(os).List()); len(add) > 0 {
+			_, err := autoscalingconn.AttachLoadBalancerTargetGroups(&autoscaling.AttachLoadBalancerTargetGroupsInput{
+				AutoScalingGroupName: aws.String(d.Id()),
+				TargetGroupARNs:      add,
+			})
+			if err != nil {
+				return fmt.Errorf("Error attaching target groups: %s", err)
+			}
+		}
+	}
+
 	if err := setAutoscalingTags(autoscalingconn, d); err != nil {
 		return err
 	} else {
@@ -243,6 +361,24 @@ func resourceAwsAutoscalingGroupUpdate(d *schema.ResourceData, meta interface{})
 		return fmt.Errorf("Error updating Autoscaling group: %s", err)
 	}
 
+	if d.HasChange("launch_configuration") {
+		if v, ok := d.GetOk("rolling_update"); ok {
+			if err := resourceAwsAutoscalingGroupRoll(d, meta, v.([]interface{})); err != nil {
+				// Keep the old launch configuration in the state, so the
+				// next apply picks up the rolling update where it stopped.
+				d.Partial(true)
+				for _, k := range []string{
+					"desired_capacity", "min_size", "max_size",
+					"health_check_grace_period", "load_balancers",
+					"target_group_arns",
+				} {
+					d.SetPartial(k)
+				}
+				return err
+			}
+		}
+	}
+
 	return resourceAwsAutoscalingGroupRead(d, meta)
 }
 
@@ -260,7 +396,18 @@ func resourceAwsAutoscalingGroupDelete(d *schema.ResourceData, meta interface{})
 		return nil
 	}
 	if len(g.Instances) > 0 || *g.DesiredCapacity > 0 {
-		if err := resourceAwsAutoscalingGroupDrain(d, meta); err != nil {
+		timeout, err := time.ParseDuration(d.Get("drain_timeout").(string))
+		if err != nil {
+			return fmt.Errorf("Error parsing drain_timeout: %s", err)
+		}
+
+		if d.Get("wait_for_elb_drain").(bool) {
+			if err := resourceAwsAutoscalingGroupDrainLoadBalancers(d, meta, g, timeout); err != nil {
+				return err
+			}
+		}
+
+		if err := resourceAwsAutoscalingGroupDrain(d, meta, timeout); err != nil {
 			return err
 		}
 	}
@@ -326,7 +473,8 @@ func getAwsAutoscalingGroup(
 	return nil, nil
 }
 
-func resourceAwsAutoscalingGroupDrain(d *schema.ResourceData, meta interface{}) error {
+func resourceAwsAutoscalingGroupDrain(
+	d *schema.ResourceData, meta interface{}, timeout time.Duration) error {
 	autoscalingconn := meta.(*AWSClient).autoscalingconn
 
 	// First, set the capacity to zero so the group will drain
@@ -343,7 +491,7 @@ func resourceAwsAutoscalingGroupDrain(d *schema.ResourceData, meta interface{})
 
 	// Next, wait for the autoscale group to drain
 	log.Printf("[DEBUG] Waiting for group to have zero instances")
-	return resource.Retry(10*time.Minute, func() error {
+	return resource.Retry(timeout, func() error {
 		g, err := getAwsAutoscalingGroup(d, meta)
 		if err != nil {
 			return resource.RetryError{Err: err}
@@ -359,3 +507,188 @@ func resourceAwsAutoscalingGroupDrain(d *schema.ResourceData, meta interface{})
 		return fmt.Errorf("group still has %d instances", len(g.Instances))
 	})
 }
+
+// resourceAwsAutoscalingGroupDrainLoadBalancers detaches the load balancers
+// from the group, which deregisters its instances from them, and waits for
+// the ELBs to finish draining their connections, so that the instances
+// aren't terminated while they're still serving traffic.
+func resourceAwsAutoscalingGroupDrainLoadBalancers(
+	d *schema.ResourceData, meta interface{},
+	g *autoscaling.AutoScalingGroup, timeout time.Duration) error {
+	autoscalingconn := meta.(*AWSClient).autoscalingconn
+	elbconn := meta.(*AWSClient).elbconn
+
+	if len(g.LoadBalancerNames) == 0 {
+		return nil
+	}
+
+	log.Printf("[DEBUG] Detaching load balancers from autoscaling group %s", d.Id())
+	_, err := autoscalingconn.DetachLoadBalancers(&autoscaling.DetachLoadBalancersInput{
+		AutoScalingGroupName: aws.String(d.Id()),
+		LoadBalancerNames:    g.LoadBalancerNames,
+	})
+	if err != nil {
+		return fmt.Errorf("Error detaching load balancers to drain: %s", err)
+	}
+
+	instances := make(map[string]struct{}, len(g.Instances))
+	for _, i := range g.Instances {
+		instances[*i.InstanceID] = struct{}{}
+	}
+
+	log.Printf("[DEBUG] Waiting for load balancers to drain the group's instances")
+	return resource.Retry(timeout, func() error {
+		for _, name := range g.LoadBalancerNames {
+			resp, err := elbconn.DescribeInstanceHealth(&elb.DescribeInstanceHealthInput{
+				LoadBalancerName: name,
+			})
+			if err != nil {
+				if isLoadBalancerNotFound(err) {
+					continue
+				}
+				return resource.RetryError{Err: err}
+			}
+
+			for _, state := range resp.InstanceStates {
+				if _, ok := instances[*state.InstanceID]; ok {
+					return fmt.Errorf("ELB %s is still draining instance %s",
+						*name, *state.InstanceID)
+				}
+			}
+		}
+
+		return nil
+	})
+}
+
+// resourceAwsAutoscalingGroupRoll replaces the instances of the group that
+// don't use its launch configuration, a batch at a time. Each batch is
+// terminated without decrementing the desired capacity, so the group
+// launches replacements from the new launch configuration, and the next
+// batch waits until the group is healthy at its desired capacity again.
+func resourceAwsAutoscalingGroupRoll(
+	d *schema.ResourceData, meta interface{}, configs []interface{}) error {
+	autoscalingconn := meta.(*AWSClient).autoscalingconn
+
+	if len(configs) > 1 {
+		return fmt.Errorf("Only one rolling_update can be given")
+	}
+	config := configs[0].(map[string]interface{})
+
+	batchSize := config["batch_size"].(int)
+	if batchSize < 1 {
+		return fmt.Errorf("rolling_update batch_size must be at least 1")
+	}
+	pause := time.Duration(config["pause_time"].(int)) * time.Second
+	timeout, err := time.ParseDuration(config["timeout"].(string))
+	if err != nil {
+		return fmt.Errorf("Error parsing rolling_update timeout: %s", err)
+	}
+
+	lc := d.Get("launch_configuration").(string)
+	for {
+		select {
+		case <-d.StopCh():
+			return fmt.Errorf("Rolling update of autoscaling group %s interrupted", d.Id())
+		default:
+		}
+
+		g, err := getAwsAutoscalingGroup(d, meta)
+		if err != nil {
+			return err
+		}
+		if g == nil {
+			return fmt.Errorf("Autoscaling group %s disappeared during rolling update", d.Id())
+		}
+
+		outdated := autoscalingOutdatedInstances(g, lc)
+		if len(outdated) == 0 {
+			log.Printf("[INFO] Rolling update of autoscaling group %s complete", d.Id())
+			return nil
+		}
+		if len(outdated) > batchSize {
+			outdated = outdated[:batchSize]
+		}
+
+		for _, id := range outdated {
+			log.Printf("[INFO] Rolling update: replacing instance %s", id)
+			_, err := autoscalingconn.TerminateInstanceInAutoScalingGroup(
+				&autoscaling.TerminateInstanceInAutoScalingGroupInput{
+					InstanceID:                     aws.String(id),
+					ShouldDecrementDesiredCapacity: aws.Boolean(false),
+				})
+			if err != nil {
+				return fmt.Errorf("Error terminating instance %s: %s", id, err)
+			}
+		}
+
+		log.Printf("[DEBUG] Rolling update: waiting for autoscaling group %s to be healthy", d.Id())
+		err = resource.Retry(timeout, func() error {
+			g, err := getAwsAutoscalingGroup(d, meta)
+			if err != nil {
+				return resource.RetryError{Err: err}
+			}
+			if g == nil {
+				return resource.RetryError{Err: fmt.Errorf(
+					"Autoscaling group %s disappeared during rolling update", d.Id())}
+			}
+
+			return autoscalingGroupSettled(g, outdated)
+		})
+		if err != nil {
+			return fmt.Errorf("Error waiting for rolling update of autoscaling group %s: %s",
+				d.Id(), err)
+		}
+
+		if pause > 0 {
+			time.Sleep(pause)
+		}
+	}
+}
+
+// autoscalingOutdatedInstances returns the IDs of the instances of the
+// group that weren't launched from the given launch configuration.
+func autoscalingOutdatedInstances(g *autoscaling.AutoScalingGroup, lc string) []string {
+	var ids []string
+	for _, i := range g.Instances {
+		if i.LaunchConfigurationName != nil && *i.LaunchConfigurationName == lc {
+			continue
+		}
+		if i.LifecycleState != nil && strings.HasPrefix(*i.LifecycleState, "Terminating") {
+			continue
+		}
+
+		ids = append(ids, *i.InstanceID)
+	}
+
+	return ids
+}
+
+// autoscalingGroupSettled returns an error until the terminated instances
+// are gone from the group, and it has as many healthy instances in service
+// as its desired capacity.
+func autoscalingGroupSettled(g *autoscaling.AutoScalingGroup, terminated []string) error {
+	healthy := 0
+	for _, i := range g.Instances {
+		for _, id := range terminated {
+			if *i.InstanceID == id {
+				return fmt.Errorf("instance %s is still in the group", id)
+			}
+		}
+
+		if i.LifecycleState != nil && *i.LifecycleState == "InService" &&
+			i.HealthStatus != nil && *i.HealthStatus == "Healthy" {
+			healthy++
+		}
+	}
+
+	desired := 0
+	if g.DesiredCapacity != nil {
+		desired = int(*g.DesiredCapacity)
+	}
+	if healthy < desired {
+		return fmt.Errorf("%d of %d instances are healthy", healthy, desired)
+	}
+
+	return nil
+}
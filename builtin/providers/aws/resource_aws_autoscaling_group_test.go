@@ -113,9 +113,204 @@ func TestAccAWSAutoScalingGroup_WithLoadBalancer(t *testing.T) {
 					testAccCheckAWSAutoScalingGroupAttributesLoadBalancer(&group),
 				),
 			},
+
+			resource.TestStep{
+				Config: testAccAWSAutoScalingGroupConfigWithoutLoadBalancer,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSAutoScalingGroupExists("aws_autoscaling_group.bar", &group),
+					testAccCheckAWSAutoScalingGroupNoLoadBalancers(&group),
+					resource.TestCheckResourceAttr(
+						"aws_autoscaling_group.bar", "load_balancers.#", "0"),
+				),
+			},
 		},
 	})
 }
+func TestAccAWSAutoScalingGroup_ALBTargetGroups(t *testing.T) {
+	var group autoscaling.AutoScalingGroup
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAWSAutoScalingGroupDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccAWSAutoScalingGroupConfigTargetGroups(
+					`target_group_arns = ["${aws_alb_target_group.app.id}"]`),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSAutoScalingGroupExists("aws_autoscaling_group.bar", &group),
+					testAccCheckAWSAutoScalingGroupTargetGroups(&group, "aws_alb_target_group.app"),
+					resource.TestCheckResourceAttr(
+						"aws_autoscaling_group.bar", "target_group_arns.#", "1"),
+				),
+			},
+
+			resource.TestStep{
+				Config: testAccAWSAutoScalingGroupConfigTargetGroups(
+					`target_group_arns = ["${aws_alb_target_group.api.id}"]`),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSAutoScalingGroupExists("aws_autoscaling_group.bar", &group),
+					testAccCheckAWSAutoScalingGroupTargetGroups(&group, "aws_alb_target_group.api"),
+				),
+			},
+
+			resource.TestStep{
+				Config: testAccAWSAutoScalingGroupConfigTargetGroups(""),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSAutoScalingGroupExists("aws_autoscaling_group.bar", &group),
+					testAccCheckAWSAutoScalingGroupTargetGroups(&group),
+					resource.TestCheckResourceAttr(
+						"aws_autoscaling_group.bar", "target_group_arns.#", "0"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccAWSAutoScalingGroup_drainLoadBalancers(t *testing.T) {
+	var group autoscaling.AutoScalingGroup
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAWSAutoScalingGroupDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccAWSAutoScalingGroupConfigDrainLoadBalancers,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSAutoScalingGroupExists("aws_autoscaling_group.bar", &group),
+					resource.TestCheckResourceAttr(
+						"aws_autoscaling_group.bar", "wait_for_elb_drain", "true"),
+					resource.TestCheckResourceAttr(
+						"aws_autoscaling_group.bar", "drain_timeout", "15m"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccAWSAutoScalingGroup_rollingUpdate(t *testing.T) {
+	var group autoscaling.AutoScalingGroup
+	var lc autoscaling.LaunchConfiguration
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAWSAutoScalingGroupDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: fmt.Sprintf(testAccAWSAutoScalingGroupConfigRollingUpdate, "t1.micro"),
+				Check:  testAccCheckAWSAutoScalingGroupExists("aws_autoscaling_group.bar", &group),
+			},
+
+			resource.TestStep{
+				Config: fmt.Sprintf(testAccAWSAutoScalingGroupConfigRollingUpdate, "m1.small"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSAutoScalingGroupExists("aws_autoscaling_group.bar", &group),
+					testAccCheckAWSLaunchConfigurationExists("aws_launch_configuration.foobar", &lc),
+					testLaunchConfigurationName("aws_autoscaling_group.bar", &lc),
+					func(*terraform.State) error {
+						outdated := autoscalingOutdatedInstances(&group, *lc.LaunchConfigurationName)
+						if len(outdated) > 0 {
+							return fmt.Errorf("instances weren't replaced: %v", outdated)
+						}
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
+
+func TestAutoscalingOutdatedInstances(t *testing.T) {
+	g := &autoscaling.AutoScalingGroup{
+		Instances: []*autoscaling.Instance{
+			&autoscaling.Instance{
+				InstanceID:              aws.String("i-1"),
+				LaunchConfigurationName: aws.String("new"),
+				LifecycleState:          aws.String("InService"),
+			},
+			&autoscaling.Instance{
+				InstanceID:              aws.String("i-2"),
+				LaunchConfigurationName: aws.String("old"),
+				LifecycleState:          aws.String("InService"),
+			},
+			&autoscaling.Instance{
+				InstanceID:              aws.String("i-3"),
+				LaunchConfigurationName: aws.String("old"),
+				LifecycleState:          aws.String("Terminating:Wait"),
+			},
+			&autoscaling.Instance{
+				InstanceID:     aws.String("i-4"),
+				LifecycleState: aws.String("Pending"),
+			},
+		},
+	}
+
+	actual := autoscalingOutdatedInstances(g, "new")
+	expected := []string{"i-2", "i-4"}
+	if !reflect.DeepEqual(actual, expected) {
+		t.Fatalf("bad: %#v", actual)
+	}
+}
+
+func TestAutoscalingGroupSettled(t *testing.T) {
+	instance := func(id, state, health string) *autoscaling.Instance {
+		return &autoscaling.Instance{
+			InstanceID:     aws.String(id),
+			LifecycleState: aws.String(state),
+			HealthStatus:   aws.String(health),
+		}
+	}
+
+	cases := []struct {
+		Instances []*autoscaling.Instance
+		Settled   bool
+	}{
+		{
+			[]*autoscaling.Instance{
+				instance("i-1", "InService", "Healthy"),
+				instance("i-2", "InService", "Healthy"),
+			},
+			true,
+		},
+		{
+			[]*autoscaling.Instance{
+				instance("i-1", "InService", "Healthy"),
+				instance("i-2", "Pending", "Healthy"),
+			},
+			false,
+		},
+		{
+			[]*autoscaling.Instance{
+				instance("i-1", "InService", "Healthy"),
+				instance("i-2", "InService", "Unhealthy"),
+			},
+			false,
+		},
+		{
+			[]*autoscaling.Instance{
+				instance("i-1", "InService", "Healthy"),
+				instance("i-2", "InService", "Healthy"),
+				instance("i-old", "Terminating", "Healthy"),
+			},
+			false,
+		},
+	}
+
+	for i, tc := range cases {
+		g := &autoscaling.AutoScalingGroup{
+			DesiredCapacity: aws.Long(2),
+			Instances:       tc.Instances,
+		}
+
+		err := autoscalingGroupSettled(g, []string{"i-old"})
+		if (err == nil) != tc.Settled {
+			t.Fatalf("%d: bad: %s", i, err)
+		}
+	}
+}
+
 func testAccCheckAWSAutoScalingGroupDestroy(s *terraform.State) error {
 	conn := testAccProvider.Meta().(*AWSClient).autoscalingconn
 
@@ -213,6 +408,42 @@ func testAccCheckAWSAutoScalingGroupAttributesLoadBalancer(group *autoscaling.Au
 	}
 }
 
+func testAccCheckAWSAutoScalingGroupNoLoadBalancers(group *autoscaling.AutoScalingGroup) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		if len(group.LoadBalancerNames) != 0 {
+			return fmt.Errorf("Bad load_balancers: %#v", group.LoadBalancerNames)
+		}
+
+		return nil
+	}
+}
+
+// testAccCheckAWSAutoScalingGroupTargetGroups checks that exactly the
+// given target groups are attached to the group.
+func testAccCheckAWSAutoScalingGroupTargetGroups(group *autoscaling.AutoScalingGroup, names ...string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		expected := make([]string, 0, len(names))
+		for _, n := range names {
+			rs, ok := s.RootModule().Resources[n]
+			if !ok {
+				return fmt.Errorf("Not found: %s", n)
+			}
+			expected = append(expected, rs.Primary.ID)
+		}
+
+		actual := make([]string, 0, len(group.TargetGroupARNs))
+		for _, arn := range group.TargetGroupARNs {
+			actual = append(actual, *arn)
+		}
+
+		if !reflect.DeepEqual(actual, expected) {
+			return fmt.Errorf("Bad target_group_arns: %#v, expected %#v", actual, expected)
+		}
+
+		return nil
+	}
+}
+
 func testAccCheckAWSAutoScalingGroupExists(n string, group *autoscaling.AutoScalingGroup) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		rs, ok := s.RootModule().Resources[n]
@@ -355,3 +586,137 @@ resource "aws_autoscaling_group" "bar" {
   load_balancers = ["${aws_elb.bar.name}"]
 }
 `
+
+const testAccAWSAutoScalingGroupConfigWithoutLoadBalancer = `
+resource "aws_elb" "bar" {
+  name = "foobar-terraform-test"
+  availability_zones = ["us-west-2a"]
+
+  listener {
+    instance_port = 8000
+    instance_protocol = "http"
+    lb_port = 80
+    lb_protocol = "http"
+  }
+}
+
+resource "aws_launch_configuration" "foobar" {
+  name = "foobarautoscaling-terraform-test"
+  image_id = "ami-21f78e11"
+  instance_type = "t1.micro"
+}
+
+resource "aws_autoscaling_group" "bar" {
+  availability_zones = ["us-west-2a"]
+  name = "foobar3-terraform-test"
+  max_size = 5
+  min_size = 2
+  health_check_grace_period = 300
+  health_check_type = "ELB"
+  desired_capacity = 4
+  force_delete = true
+
+  launch_configuration = "${aws_launch_configuration.foobar.name}"
+}
+`
+
+const testAccAWSAutoScalingGroupConfigRollingUpdate = `
+resource "aws_launch_configuration" "foobar" {
+  image_id = "ami-21f78e11"
+  instance_type = "%s"
+
+  lifecycle {
+    create_before_destroy = true
+  }
+}
+
+resource "aws_autoscaling_group" "bar" {
+  availability_zones = ["us-west-2a"]
+  name = "foobar3-terraform-test"
+  max_size = 3
+  min_size = 2
+  desired_capacity = 2
+  force_delete = true
+
+  launch_configuration = "${aws_launch_configuration.foobar.name}"
+
+  rolling_update {
+    batch_size = 1
+    timeout = "15m"
+  }
+}
+`
+
+const testAccAWSAutoScalingGroupConfigDrainLoadBalancers = `
+resource "aws_elb" "bar" {
+  name = "foobar-terraform-test"
+  availability_zones = ["us-west-2a"]
+  connection_draining = true
+  connection_draining_timeout = 60
+
+  listener {
+    instance_port = 8000
+    instance_protocol = "http"
+    lb_port = 80
+    lb_protocol = "http"
+  }
+}
+
+resource "aws_launch_configuration" "foobar" {
+  name = "foobarautoscaling-terraform-test"
+  image_id = "ami-21f78e11"
+  instance_type = "t1.micro"
+}
+
+resource "aws_autoscaling_group" "bar" {
+  availability_zones = ["us-west-2a"]
+  name = "foobar3-terraform-test"
+  max_size = 2
+  min_size = 1
+  desired_capacity = 1
+  wait_for_elb_drain = true
+  drain_timeout = "15m"
+
+  launch_configuration = "${aws_launch_configuration.foobar.name}"
+  load_balancers = ["${aws_elb.bar.name}"]
+}
+`
+
+// testAccAWSAutoScalingGroupConfigTargetGroups is a group in a VPC with the
+// given target_group_arns argument, which can be left out.
+func testAccAWSAutoScalingGroupConfigTargetGroups(arns string) string {
+	return testAccAWSAlbConfigVpc + fmt.Sprintf(`
+resource "aws_alb_target_group" "app" {
+  name = "tf-asg-test-app"
+  port = 8080
+  protocol = "HTTP"
+  vpc_id = "${aws_vpc.alb.id}"
+}
+
+resource "aws_alb_target_group" "api" {
+  name = "tf-asg-test-api"
+  port = 8081
+  protocol = "HTTP"
+  vpc_id = "${aws_vpc.alb.id}"
+}
+
+resource "aws_launch_configuration" "foobar" {
+  name = "foobarautoscaling-terraform-test"
+  image_id = "ami-4fccb37f"
+  instance_type = "m1.small"
+}
+
+resource "aws_autoscaling_group" "bar" {
+  availability_zones = ["us-west-2a"]
+  vpc_zone_identifier = ["${aws_subnet.a.id}"]
+  name = "foobar3-terraform-test"
+  max_size = 1
+  min_size = 0
+  desired_capacity = 0
+  force_delete = true
+
+  launch_configuration = "${aws_launch_configuration.foobar.name}"
+  %s
+}
+`, arns)
+}
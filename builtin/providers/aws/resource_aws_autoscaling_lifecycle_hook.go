@@ -0,0 +1,192 @@
+package aws
+
+import (
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/awslabs/aws-sdk-go/aws"
+	"github.com/awslabs/aws-sdk-go/service/autoscaling"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+// resourceAwsAutoscalingLifecycleHook is a lifecycle hook of an
+// autoscaling group, which pauses instances that are launched or
+// terminated until the hook is completed or times out, such as to drain
+// them gracefully.
+func resourceAwsAutoscalingLifecycleHook() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceAwsAutoscalingLifecycleHookCreate,
+		Read:   resourceAwsAutoscalingLifecycleHookRead,
+		Update: resourceAwsAutoscalingLifecycleHookUpdate,
+		Delete: resourceAwsAutoscalingLifecycleHookDelete,
+
+		Schema: map[string]*schema.Schema{
+			"name": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"autoscaling_group_name": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"lifecycle_transition": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+			},
+
+			"default_result": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+
+			"heartbeat_timeout": &schema.Schema{
+				Type:     schema.TypeInt,
+				Optional: true,
+				Computed: true,
+			},
+
+			"notification_metadata": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"notification_target_arn": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"role_arn": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+		},
+	}
+}
+
+func resourceAwsAutoscalingLifecycleHookCreate(d *schema.ResourceData, meta interface{}) error {
+	d.SetId(d.Get("name").(string))
+	if err := resourceAwsAutoscalingLifecycleHookPut(d, meta); err != nil {
+		d.SetId("")
+		return err
+	}
+
+	return resourceAwsAutoscalingLifecycleHookRead(d, meta)
+}
+
+func resourceAwsAutoscalingLifecycleHookRead(d *schema.ResourceData, meta interface{}) error {
+	autoscalingconn := meta.(*AWSClient).autoscalingconn
+
+	resp, err := autoscalingconn.DescribeLifecycleHooks(&autoscaling.DescribeLifecycleHooksInput{
+		AutoScalingGroupName: aws.String(d.Get("autoscaling_group_name").(string)),
+		LifecycleHookNames:   []*string{aws.String(d.Id())},
+	})
+	if err != nil {
+		autoscalingerr, ok := err.(aws.APIError)
+		if ok && autoscalingerr.Code == "ValidationError" {
+			// The autoscaling group is gone, and the hook with it
+			d.SetId("")
+			return nil
+		}
+
+		return fmt.Errorf("Error retrieving lifecycle hook: %s", err)
+	}
+	if len(resp.LifecycleHooks) == 0 {
+		d.SetId("")
+		return nil
+	}
+
+	h := resp.LifecycleHooks[0]
+	d.Set("lifecycle_transition", h.LifecycleTransition)
+	d.Set("default_result", h.DefaultResult)
+	d.Set("heartbeat_timeout", h.HeartbeatTimeout)
+	d.Set("notification_metadata", h.NotificationMetadata)
+	d.Set("notification_target_arn", h.NotificationTargetARN)
+	d.Set("role_arn", h.RoleARN)
+
+	return nil
+}
+
+func resourceAwsAutoscalingLifecycleHookUpdate(d *schema.ResourceData, meta interface{}) error {
+	if err := resourceAwsAutoscalingLifecycleHookPut(d, meta); err != nil {
+		return err
+	}
+
+	return resourceAwsAutoscalingLifecycleHookRead(d, meta)
+}
+
+func resourceAwsAutoscalingLifecycleHookDelete(d *schema.ResourceData, meta interface{}) error {
+	autoscalingconn := meta.(*AWSClient).autoscalingconn
+
+	log.Printf("[INFO] Deleting lifecycle hook: %s", d.Id())
+	_, err := autoscalingconn.DeleteLifecycleHook(&autoscaling.DeleteLifecycleHookInput{
+		AutoScalingGroupName: aws.String(d.Get("autoscaling_group_name").(string)),
+		LifecycleHookName:    aws.String(d.Id()),
+	})
+	if err != nil {
+		return fmt.Errorf("Error deleting lifecycle hook: %s", err)
+	}
+
+	return nil
+}
+
+// resourceAwsAutoscalingLifecycleHookPut creates or replaces the hook,
+// since the API doesn't distinguish the two.
+func resourceAwsAutoscalingLifecycleHookPut(d *schema.ResourceData, meta interface{}) error {
+	autoscalingconn := meta.(*AWSClient).autoscalingconn
+
+	opts := getAwsAutoscalingPutLifecycleHookInput(d)
+	log.Printf("[DEBUG] Lifecycle hook configuration: %#v", opts)
+
+	// A test message is published to the notification target with the
+	// role, which fails until a new role has propagated.
+	return resource.Retry(2*time.Minute, func() error {
+		_, err := autoscalingconn.PutLifecycleHook(opts)
+		if err != nil {
+			autoscalingerr, ok := err.(aws.APIError)
+			if ok && autoscalingerr.Code == "ValidationError" &&
+				strings.Contains(autoscalingerr.Message, "Unable to publish test message") {
+				return err
+			}
+
+			return resource.RetryError{
+				Err: fmt.Errorf("Error putting lifecycle hook: %s", err),
+			}
+		}
+
+		return nil
+	})
+}
+
+func getAwsAutoscalingPutLifecycleHookInput(d *schema.ResourceData) *autoscaling.PutLifecycleHookInput {
+	opts := &autoscaling.PutLifecycleHookInput{
+		AutoScalingGroupName: aws.String(d.Get("autoscaling_group_name").(string)),
+		LifecycleHookName:    aws.String(d.Id()),
+		LifecycleTransition:  aws.String(d.Get("lifecycle_transition").(string)),
+	}
+
+	if v, ok := d.GetOk("default_result"); ok {
+		opts.DefaultResult = aws.String(v.(string))
+	}
+	if v, ok := d.GetOk("heartbeat_timeout"); ok {
+		opts.HeartbeatTimeout = aws.Long(int64(v.(int)))
+	}
+	if v, ok := d.GetOk("notification_metadata"); ok {
+		opts.NotificationMetadata = aws.String(v.(string))
+	}
+	if v, ok := d.GetOk("notification_target_arn"); ok {
+		opts.NotificationTargetARN = aws.String(v.(string))
+	}
+	if v, ok := d.GetOk("role_arn"); ok {
+		opts.RoleARN = aws.String(v.(string))
+	}
+
+	return opts
+}
@@ -0,0 +1,130 @@
+package aws
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/awslabs/aws-sdk-go/aws"
+	"github.com/awslabs/aws-sdk-go/service/autoscaling"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestAccAWSAutoscalingLifecycleHook_basic(t *testing.T) {
+	var hook autoscaling.LifecycleHook
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAWSAutoscalingLifecycleHookDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccAWSAutoscalingLifecycleHookConfig,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSAutoscalingLifecycleHookExists("aws_autoscaling_lifecycle_hook.foobar", &hook),
+					resource.TestCheckResourceAttr(
+						"aws_autoscaling_lifecycle_hook.foobar", "lifecycle_transition", "autoscaling:EC2_INSTANCE_TERMINATING"),
+					resource.TestCheckResourceAttr(
+						"aws_autoscaling_lifecycle_hook.foobar", "heartbeat_timeout", "600"),
+					resource.TestCheckResourceAttr(
+						"aws_autoscaling_lifecycle_hook.foobar", "default_result", "CONTINUE"),
+				),
+			},
+
+			resource.TestStep{
+				Config: testAccAWSAutoscalingLifecycleHookConfigUpdate,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSAutoscalingLifecycleHookExists("aws_autoscaling_lifecycle_hook.foobar", &hook),
+					resource.TestCheckResourceAttr(
+						"aws_autoscaling_lifecycle_hook.foobar", "heartbeat_timeout", "300"),
+					resource.TestCheckResourceAttr(
+						"aws_autoscaling_lifecycle_hook.foobar", "default_result", "ABANDON"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckAWSAutoscalingLifecycleHookExists(n string, hook *autoscaling.LifecycleHook) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		conn := testAccProvider.Meta().(*AWSClient).autoscalingconn
+		resp, err := conn.DescribeLifecycleHooks(&autoscaling.DescribeLifecycleHooksInput{
+			AutoScalingGroupName: aws.String(rs.Primary.Attributes["autoscaling_group_name"]),
+			LifecycleHookNames:   []*string{aws.String(rs.Primary.ID)},
+		})
+		if err != nil {
+			return err
+		}
+
+		if len(resp.LifecycleHooks) == 0 {
+			return fmt.Errorf("Lifecycle hook not found: %s", rs.Primary.ID)
+		}
+
+		*hook = *resp.LifecycleHooks[0]
+		return nil
+	}
+}
+
+func testAccCheckAWSAutoscalingLifecycleHookDestroy(s *terraform.State) error {
+	conn := testAccProvider.Meta().(*AWSClient).autoscalingconn
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "aws_autoscaling_lifecycle_hook" {
+			continue
+		}
+
+		resp, err := conn.DescribeLifecycleHooks(&autoscaling.DescribeLifecycleHooksInput{
+			AutoScalingGroupName: aws.String(rs.Primary.Attributes["autoscaling_group_name"]),
+			LifecycleHookNames:   []*string{aws.String(rs.Primary.ID)},
+		})
+		if err == nil && len(resp.LifecycleHooks) != 0 {
+			return fmt.Errorf("Lifecycle hook still exists: %s", rs.Primary.ID)
+		}
+	}
+
+	return nil
+}
+
+const testAccAWSAutoscalingLifecycleHookConfigGroup = `
+resource "aws_launch_configuration" "foobar" {
+  name = "tf-test-autoscaling-lifecycle-hook"
+  image_id = "ami-21f78e11"
+  instance_type = "t1.micro"
+}
+
+resource "aws_autoscaling_group" "bar" {
+  availability_zones = ["us-west-2a"]
+  name = "tf-test-autoscaling-lifecycle-hook"
+  max_size = 5
+  min_size = 0
+  force_delete = true
+  launch_configuration = "${aws_launch_configuration.foobar.name}"
+}
+`
+
+const testAccAWSAutoscalingLifecycleHookConfig = testAccAWSAutoscalingLifecycleHookConfigGroup + `
+resource "aws_autoscaling_lifecycle_hook" "foobar" {
+  name = "tf-test-drain"
+  autoscaling_group_name = "${aws_autoscaling_group.bar.name}"
+  lifecycle_transition = "autoscaling:EC2_INSTANCE_TERMINATING"
+  default_result = "CONTINUE"
+  heartbeat_timeout = 600
+  notification_metadata = "foo"
+}
+`
+
+const testAccAWSAutoscalingLifecycleHookConfigUpdate = testAccAWSAutoscalingLifecycleHookConfigGroup + `
+resource "aws_autoscaling_lifecycle_hook" "foobar" {
+  name = "tf-test-drain"
+  autoscaling_group_name = "${aws_autoscaling_group.bar.name}"
+  lifecycle_transition = "autoscaling:EC2_INSTANCE_TERMINATING"
+  default_result = "ABANDON"
+  heartbeat_timeout = 300
+  notification_metadata = "foo"
+}
+`
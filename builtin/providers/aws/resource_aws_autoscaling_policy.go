@@ -0,0 +1,337 @@
+package aws
+
+import (
+	"bytes"
+	"fmt"
+	"log"
+	"strconv"
+
+	"github.com/awslabs/aws-sdk-go/aws"
+	"github.com/awslabs/aws-sdk-go/service/autoscaling"
+	"github.com/hashicorp/terraform/helper/hashcode"
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+// resourceAwsAutoscalingPolicy is a simple or step scaling policy of an
+// autoscaling group, run by the CloudWatch alarms that have its ARN as an
+// alarm action.
+func resourceAwsAutoscalingPolicy() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceAwsAutoscalingPolicyCreate,
+		Read:   resourceAwsAutoscalingPolicyRead,
+		Update: resourceAwsAutoscalingPolicyUpdate,
+		Delete: resourceAwsAutoscalingPolicyDelete,
+
+		Schema: map[string]*schema.Schema{
+			"name": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"autoscaling_group_name": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"policy_type": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "SimpleScaling",
+				ValidateFunc: validateAutoscalingPolicyType,
+			},
+
+			"adjustment_type": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+			},
+
+			// scaling_adjustment is required by simple scaling policies,
+			// and step_adjustment by step scaling policies.
+			"scaling_adjustment": &schema.Schema{
+				Type:          schema.TypeInt,
+				Optional:      true,
+				ConflictsWith: []string{"step_adjustment"},
+			},
+
+			"cooldown": &schema.Schema{
+				Type:     schema.TypeInt,
+				Optional: true,
+			},
+
+			"min_adjustment_magnitude": &schema.Schema{
+				Type:     schema.TypeInt,
+				Optional: true,
+			},
+
+			"estimated_instance_warmup": &schema.Schema{
+				Type:     schema.TypeInt,
+				Optional: true,
+			},
+
+			"metric_aggregation_type": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+
+			"step_adjustment": &schema.Schema{
+				Type:          schema.TypeSet,
+				Optional:      true,
+				ConflictsWith: []string{"scaling_adjustment"},
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						// The bounds are strings, since a bound of 0 is
+						// different from no bound.
+						"metric_interval_lower_bound": &schema.Schema{
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+
+						"metric_interval_upper_bound": &schema.Schema{
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+
+						"scaling_adjustment": &schema.Schema{
+							Type:     schema.TypeInt,
+							Required: true,
+						},
+					},
+				},
+				Set: resourceAwsAutoscalingPolicyStepAdjustmentHash,
+			},
+
+			"arn": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"alarm_arns": &schema.Schema{
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+		},
+	}
+}
+
+func resourceAwsAutoscalingPolicyCreate(d *schema.ResourceData, meta interface{}) error {
+	d.SetId(d.Get("name").(string))
+	if err := resourceAwsAutoscalingPolicyPut(d, meta); err != nil {
+		d.SetId("")
+		return err
+	}
+
+	return resourceAwsAutoscalingPolicyRead(d, meta)
+}
+
+func resourceAwsAutoscalingPolicyRead(d *schema.ResourceData, meta interface{}) error {
+	autoscalingconn := meta.(*AWSClient).autoscalingconn
+
+	resp, err := autoscalingconn.DescribePolicies(&autoscaling.DescribePoliciesInput{
+		AutoScalingGroupName: aws.String(d.Get("autoscaling_group_name").(string)),
+		PolicyNames:          []*string{aws.String(d.Id())},
+	})
+	if err != nil {
+		autoscalingerr, ok := err.(aws.APIError)
+		if ok && autoscalingerr.Code == "ValidationError" {
+			// The autoscaling group is gone, and the policy with it
+			d.SetId("")
+			return nil
+		}
+
+		return fmt.Errorf("Error retrieving scaling policy: %s", err)
+	}
+	if len(resp.ScalingPolicies) == 0 {
+		d.SetId("")
+		return nil
+	}
+
+	p := resp.ScalingPolicies[0]
+	d.Set("arn", p.PolicyARN)
+	d.Set("policy_type", p.PolicyType)
+	d.Set("adjustment_type", p.AdjustmentType)
+	d.Set("scaling_adjustment", p.ScalingAdjustment)
+	d.Set("cooldown", p.Cooldown)
+	d.Set("min_adjustment_magnitude", p.MinAdjustmentMagnitude)
+	d.Set("estimated_instance_warmup", p.EstimatedInstanceWarmup)
+	d.Set("metric_aggregation_type", p.MetricAggregationType)
+	if err := d.Set("step_adjustment", flattenStepAdjustments(p.StepAdjustments)); err != nil {
+		return err
+	}
+
+	alarms := make([]string, 0, len(p.Alarms))
+	for _, a := range p.Alarms {
+		alarms = append(alarms, *a.AlarmARN)
+	}
+	d.Set("alarm_arns", alarms)
+
+	return nil
+}
+
+func resourceAwsAutoscalingPolicyUpdate(d *schema.ResourceData, meta interface{}) error {
+	if err := resourceAwsAutoscalingPolicyPut(d, meta); err != nil {
+		return err
+	}
+
+	return resourceAwsAutoscalingPolicyRead(d, meta)
+}
+
+func resourceAwsAutoscalingPolicyDelete(d *schema.ResourceData, meta interface{}) error {
+	autoscalingconn := meta.(*AWSClient).autoscalingconn
+
+	log.Printf("[INFO] Deleting scaling policy: %s", d.Id())
+	_, err := autoscalingconn.DeletePolicy(&autoscaling.DeletePolicyInput{
+		AutoScalingGroupName: aws.String(d.Get("autoscaling_group_name").(string)),
+		PolicyName:           aws.String(d.Id()),
+	})
+	if err != nil {
+		return fmt.Errorf("Error deleting scaling policy: %s", err)
+	}
+
+	return nil
+}
+
+// resourceAwsAutoscalingPolicyPut creates or replaces the policy, since
+// the API doesn't distinguish the two.
+func resourceAwsAutoscalingPolicyPut(d *schema.ResourceData, meta interface{}) error {
+	autoscalingconn := meta.(*AWSClient).autoscalingconn
+
+	opts, err := getAwsAutoscalingPutScalingPolicyInput(d)
+	if err != nil {
+		return err
+	}
+
+	log.Printf("[DEBUG] Scaling policy configuration: %#v", opts)
+	if _, err := autoscalingconn.PutScalingPolicy(opts); err != nil {
+		return fmt.Errorf("Error putting scaling policy: %s", err)
+	}
+
+	return nil
+}
+
+func getAwsAutoscalingPutScalingPolicyInput(d *schema.ResourceData) (*autoscaling.PutScalingPolicyInput, error) {
+	policyType := d.Get("policy_type").(string)
+	opts := &autoscaling.PutScalingPolicyInput{
+		AutoScalingGroupName: aws.String(d.Get("autoscaling_group_name").(string)),
+		PolicyName:           aws.String(d.Id()),
+		PolicyType:           aws.String(policyType),
+		AdjustmentType:       aws.String(d.Get("adjustment_type").(string)),
+	}
+
+	if v, ok := d.GetOk("min_adjustment_magnitude"); ok {
+		opts.MinAdjustmentMagnitude = aws.Long(int64(v.(int)))
+	}
+
+	// The other arguments each only apply to one type of policy, and the
+	// API rejects them for the other type, so they are only sent for the
+	// type they apply to.
+	switch policyType {
+	case "SimpleScaling":
+		v, ok := d.GetOk("scaling_adjustment")
+		if !ok {
+			return nil, fmt.Errorf("scaling_adjustment must be set for a simple scaling policy")
+		}
+		opts.ScalingAdjustment = aws.Long(int64(v.(int)))
+
+		if v, ok := d.GetOk("cooldown"); ok {
+			opts.Cooldown = aws.Long(int64(v.(int)))
+		}
+	case "StepScaling":
+		steps, err := expandStepAdjustments(d.Get("step_adjustment").(*schema.Set).List())
+		if err != nil {
+			return nil, err
+		}
+		if len(steps) == 0 {
+			return nil, fmt.Errorf("step_adjustment must be set for a step scaling policy")
+		}
+		opts.StepAdjustments = steps
+
+		if v, ok := d.GetOk("estimated_instance_warmup"); ok {
+			opts.EstimatedInstanceWarmup = aws.Long(int64(v.(int)))
+		}
+		if v, ok := d.GetOk("metric_aggregation_type"); ok {
+			opts.MetricAggregationType = aws.String(v.(string))
+		}
+	}
+
+	return opts, nil
+}
+
+func validateAutoscalingPolicyType(v interface{}, k string) ([]string, []error) {
+	switch v.(string) {
+	case "SimpleScaling", "StepScaling":
+		return nil, nil
+	default:
+		return nil, []error{fmt.Errorf(
+			"%s must be SimpleScaling or StepScaling, got: %s", k, v)}
+	}
+}
+
+// expandStepAdjustments returns the step adjustments of the
+// step_adjustment blocks of a policy.
+func expandStepAdjustments(configured []interface{}) ([]*autoscaling.StepAdjustment, error) {
+	steps := make([]*autoscaling.StepAdjustment, 0, len(configured))
+	for _, raw := range configured {
+		m := raw.(map[string]interface{})
+		step := &autoscaling.StepAdjustment{
+			ScalingAdjustment: aws.Long(int64(m["scaling_adjustment"].(int))),
+		}
+
+		if v := m["metric_interval_lower_bound"].(string); v != "" {
+			bound, err := strconv.ParseFloat(v, 64)
+			if err != nil {
+				return nil, fmt.Errorf("Error parsing metric_interval_lower_bound: %s", err)
+			}
+			step.MetricIntervalLowerBound = aws.Double(bound)
+		}
+		if v := m["metric_interval_upper_bound"].(string); v != "" {
+			bound, err := strconv.ParseFloat(v, 64)
+			if err != nil {
+				return nil, fmt.Errorf("Error parsing metric_interval_upper_bound: %s", err)
+			}
+			step.MetricIntervalUpperBound = aws.Double(bound)
+		}
+
+		steps = append(steps, step)
+	}
+
+	return steps, nil
+}
+
+// flattenStepAdjustments returns the step_adjustment blocks of the step
+// adjustments of a policy.
+func flattenStepAdjustments(steps []*autoscaling.StepAdjustment) []map[string]interface{} {
+	result := make([]map[string]interface{}, 0, len(steps))
+	for _, step := range steps {
+		m := map[string]interface{}{
+			"scaling_adjustment": *step.ScalingAdjustment,
+		}
+		if v := step.MetricIntervalLowerBound; v != nil {
+			m["metric_interval_lower_bound"] = strconv.FormatFloat(*v, 'f', -1, 64)
+		}
+		if v := step.MetricIntervalUpperBound; v != nil {
+			m["metric_interval_upper_bound"] = strconv.FormatFloat(*v, 'f', -1, 64)
+		}
+
+		result = append(result, m)
+	}
+
+	return result
+}
+
+func resourceAwsAutoscalingPolicyStepAdjustmentHash(v interface{}) int {
+	var buf bytes.Buffer
+	m := v.(map[string]interface{})
+	if v, ok := m["metric_interval_lower_bound"]; ok {
+		buf.WriteString(fmt.Sprintf("%s-", v.(string)))
+	}
+	if v, ok := m["metric_interval_upper_bound"]; ok {
+		buf.WriteString(fmt.Sprintf("%s-", v.(string)))
+	}
+	buf.WriteString(fmt.Sprintf("%d-", m["scaling_adjustment"].(int)))
+
+	return hashcode.String(buf.String())
+}
@@ -0,0 +1,283 @@
+package aws
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/awslabs/aws-sdk-go/aws"
+	"github.com/awslabs/aws-sdk-go/service/autoscaling"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestAccAWSAutoscalingPolicy_basic(t *testing.T) {
+	var policy autoscaling.ScalingPolicy
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAWSAutoscalingPolicyDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccAWSAutoscalingPolicyConfig,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSAutoscalingPolicyExists("aws_autoscaling_policy.simple", &policy),
+					resource.TestCheckResourceAttr(
+						"aws_autoscaling_policy.simple", "scaling_adjustment", "2"),
+					testAccCheckAWSAutoscalingPolicyExists("aws_autoscaling_policy.percent", &policy),
+					resource.TestCheckResourceAttr(
+						"aws_autoscaling_policy.percent", "adjustment_type", "PercentChangeInCapacity"),
+				),
+			},
+
+			resource.TestStep{
+				Config: testAccAWSAutoscalingPolicyConfigUpdate,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSAutoscalingPolicyExists("aws_autoscaling_policy.simple", &policy),
+					resource.TestCheckResourceAttr(
+						"aws_autoscaling_policy.simple", "scaling_adjustment", "3"),
+					resource.TestCheckResourceAttr(
+						"aws_autoscaling_policy.simple", "cooldown", "600"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccAWSAutoscalingPolicy_step(t *testing.T) {
+	var policy autoscaling.ScalingPolicy
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAWSAutoscalingPolicyDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccAWSAutoscalingPolicyConfigStep,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSAutoscalingPolicyExists("aws_autoscaling_policy.step", &policy),
+					resource.TestCheckResourceAttr(
+						"aws_autoscaling_policy.step", "policy_type", "StepScaling"),
+					resource.TestCheckResourceAttr(
+						"aws_autoscaling_policy.step", "estimated_instance_warmup", "120"),
+					resource.TestCheckResourceAttr(
+						"aws_autoscaling_policy.step", "step_adjustment.#", "2"),
+					testAccCheckAWSAutoscalingPolicyStepCount(&policy, 2),
+				),
+			},
+
+			resource.TestStep{
+				Config: testAccAWSAutoscalingPolicyConfigStepUpdate,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSAutoscalingPolicyExists("aws_autoscaling_policy.step", &policy),
+					resource.TestCheckResourceAttr(
+						"aws_autoscaling_policy.step", "estimated_instance_warmup", "300"),
+					resource.TestCheckResourceAttr(
+						"aws_autoscaling_policy.step", "step_adjustment.#", "3"),
+					testAccCheckAWSAutoscalingPolicyStepCount(&policy, 3),
+				),
+			},
+		},
+	})
+}
+
+func TestExpandStepAdjustments(t *testing.T) {
+	steps, err := expandStepAdjustments([]interface{}{
+		map[string]interface{}{
+			"metric_interval_lower_bound": "0",
+			"metric_interval_upper_bound": "10.5",
+			"scaling_adjustment":          1,
+		},
+		map[string]interface{}{
+			"metric_interval_lower_bound": "10.5",
+			"metric_interval_upper_bound": "",
+			"scaling_adjustment":          2,
+		},
+	})
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	if len(steps) != 2 {
+		t.Fatalf("bad: %#v", steps)
+	}
+	if *steps[0].MetricIntervalLowerBound != 0 || *steps[0].MetricIntervalUpperBound != 10.5 {
+		t.Fatalf("bad: %#v", steps[0])
+	}
+	if *steps[1].MetricIntervalLowerBound != 10.5 || steps[1].MetricIntervalUpperBound != nil {
+		t.Fatalf("bad: %#v", steps[1])
+	}
+
+	flattened := flattenStepAdjustments(steps)
+	if flattened[0]["metric_interval_upper_bound"] != "10.5" {
+		t.Fatalf("bad: %#v", flattened[0])
+	}
+	if _, ok := flattened[1]["metric_interval_upper_bound"]; ok {
+		t.Fatalf("bad: %#v", flattened[1])
+	}
+
+	_, err = expandStepAdjustments([]interface{}{
+		map[string]interface{}{
+			"metric_interval_lower_bound": "foo",
+			"metric_interval_upper_bound": "",
+			"scaling_adjustment":          1,
+		},
+	})
+	if err == nil {
+		t.Fatal("should error")
+	}
+}
+
+func testAccCheckAWSAutoscalingPolicyStepCount(policy *autoscaling.ScalingPolicy, n int) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		if len(policy.StepAdjustments) != n {
+			return fmt.Errorf("Bad step adjustments: %#v", policy.StepAdjustments)
+		}
+
+		return nil
+	}
+}
+
+func testAccCheckAWSAutoscalingPolicyExists(n string, policy *autoscaling.ScalingPolicy) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		conn := testAccProvider.Meta().(*AWSClient).autoscalingconn
+		resp, err := conn.DescribePolicies(&autoscaling.DescribePoliciesInput{
+			AutoScalingGroupName: aws.String(rs.Primary.Attributes["autoscaling_group_name"]),
+			PolicyNames:          []*string{aws.String(rs.Primary.ID)},
+		})
+		if err != nil {
+			return err
+		}
+
+		if len(resp.ScalingPolicies) == 0 {
+			return fmt.Errorf("Scaling policy not found: %s", rs.Primary.ID)
+		}
+
+		*policy = *resp.ScalingPolicies[0]
+		return nil
+	}
+}
+
+func testAccCheckAWSAutoscalingPolicyDestroy(s *terraform.State) error {
+	conn := testAccProvider.Meta().(*AWSClient).autoscalingconn
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "aws_autoscaling_policy" {
+			continue
+		}
+
+		resp, err := conn.DescribePolicies(&autoscaling.DescribePoliciesInput{
+			AutoScalingGroupName: aws.String(rs.Primary.Attributes["autoscaling_group_name"]),
+			PolicyNames:          []*string{aws.String(rs.Primary.ID)},
+		})
+		if err == nil && len(resp.ScalingPolicies) != 0 {
+			return fmt.Errorf("Scaling policy still exists: %s", rs.Primary.ID)
+		}
+	}
+
+	return nil
+}
+
+const testAccAWSAutoscalingPolicyConfigGroup = `
+resource "aws_launch_configuration" "foobar" {
+  name = "tf-test-autoscaling-policy"
+  image_id = "ami-21f78e11"
+  instance_type = "t1.micro"
+}
+
+resource "aws_autoscaling_group" "bar" {
+  availability_zones = ["us-west-2a"]
+  name = "tf-test-autoscaling-policy"
+  max_size = 5
+  min_size = 0
+  force_delete = true
+  launch_configuration = "${aws_launch_configuration.foobar.name}"
+}
+`
+
+const testAccAWSAutoscalingPolicyConfig = testAccAWSAutoscalingPolicyConfigGroup + `
+resource "aws_autoscaling_policy" "simple" {
+  name = "tf-test-simple"
+  autoscaling_group_name = "${aws_autoscaling_group.bar.name}"
+  adjustment_type = "ChangeInCapacity"
+  scaling_adjustment = 2
+  cooldown = 300
+}
+
+resource "aws_autoscaling_policy" "percent" {
+  name = "tf-test-percent"
+  autoscaling_group_name = "${aws_autoscaling_group.bar.name}"
+  adjustment_type = "PercentChangeInCapacity"
+  scaling_adjustment = -20
+}
+`
+
+const testAccAWSAutoscalingPolicyConfigUpdate = testAccAWSAutoscalingPolicyConfigGroup + `
+resource "aws_autoscaling_policy" "simple" {
+  name = "tf-test-simple"
+  autoscaling_group_name = "${aws_autoscaling_group.bar.name}"
+  adjustment_type = "ChangeInCapacity"
+  scaling_adjustment = 3
+  cooldown = 600
+}
+
+resource "aws_autoscaling_policy" "percent" {
+  name = "tf-test-percent"
+  autoscaling_group_name = "${aws_autoscaling_group.bar.name}"
+  adjustment_type = "PercentChangeInCapacity"
+  scaling_adjustment = -20
+}
+`
+
+const testAccAWSAutoscalingPolicyConfigStep = testAccAWSAutoscalingPolicyConfigGroup + `
+resource "aws_autoscaling_policy" "step" {
+  name = "tf-test-step"
+  autoscaling_group_name = "${aws_autoscaling_group.bar.name}"
+  policy_type = "StepScaling"
+  adjustment_type = "ChangeInCapacity"
+  estimated_instance_warmup = 120
+
+  step_adjustment {
+    metric_interval_lower_bound = "0"
+    metric_interval_upper_bound = "10"
+    scaling_adjustment = 1
+  }
+
+  step_adjustment {
+    metric_interval_lower_bound = "10"
+    scaling_adjustment = 2
+  }
+}
+`
+
+const testAccAWSAutoscalingPolicyConfigStepUpdate = testAccAWSAutoscalingPolicyConfigGroup + `
+resource "aws_autoscaling_policy" "step" {
+  name = "tf-test-step"
+  autoscaling_group_name = "${aws_autoscaling_group.bar.name}"
+  policy_type = "StepScaling"
+  adjustment_type = "ChangeInCapacity"
+  estimated_instance_warmup = 300
+
+  step_adjustment {
+    metric_interval_lower_bound = "0"
+    metric_interval_upper_bound = "10"
+    scaling_adjustment = 1
+  }
+
+  step_adjustment {
+    metric_interval_lower_bound = "10"
+    metric_interval_upper_bound = "20"
+    scaling_adjustment = 2
+  }
+
+  step_adjustment {
+    metric_interval_lower_bound = "20"
+    scaling_adjustment = 3
+  }
+}
+`
@@ -72,6 +72,7 @@ func resourceAwsCustomerGatewayCreate(d *schema.ResourceData, meta interface{})
 		Timeout:    10 * time.Minute,
 		Delay:      10 * time.Second,
 		MinTimeout: 3 * time.Second,
+		StopCh:     d.StopCh(),
 	}
 
 	_, stateErr := stateConf.WaitForState()
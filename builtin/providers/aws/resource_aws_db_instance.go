@@ -146,6 +146,18 @@ func resourceAwsDbInstance() *schema.Resource {
 				Optional: true,
 			},
 
+			"skip_final_snapshot": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+
+			"snapshot_identifier": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+
 			"db_subnet_group_name": &schema.Schema{
 				Type:     schema.TypeString,
 				Optional: true,
@@ -191,6 +203,11 @@ func resourceAwsDbInstance() *schema.Resource {
 func resourceAwsDbInstanceCreate(d *schema.ResourceData, meta interface{}) error {
 	conn := meta.(*AWSClient).rdsconn
 	tags := tagsFromMapRDS(d.Get("tags").(map[string]interface{}))
+
+	if _, ok := d.GetOk("snapshot_identifier"); ok {
+		return resourceAwsDbInstanceRestore(d, meta)
+	}
+
 	opts := rds.CreateDBInstanceInput{
 		AllocatedStorage:     aws.Long(int64(d.Get("allocated_storage").(int))),
 		DBInstanceClass:      aws.String(d.Get("instance_class").(string)),
@@ -283,6 +300,7 @@ func resourceAwsDbInstanceCreate(d *schema.ResourceData, meta interface{}) error
 		Timeout:    40 * time.Minute,
 		MinTimeout: 10 * time.Second,
 		Delay:      30 * time.Second, // Wait 30 secs before starting
+		StopCh:     d.StopCh(),
 	}
 
 	// Wait, catching any errors
@@ -294,6 +312,125 @@ func resourceAwsDbInstanceCreate(d *schema.ResourceData, meta interface{}) error
 	return resourceAwsDbInstanceRead(d, meta)
 }
 
+// resourceAwsDbInstanceRestore creates the DB Instance from the DB
+// snapshot given by snapshot_identifier. The settings that can't be given
+// when restoring, such as the security groups and the parameter group, are
+// modified once the restored instance is available.
+func resourceAwsDbInstanceRestore(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).rdsconn
+	opts := rds.RestoreDBInstanceFromDBSnapshotInput{
+		DBInstanceClass:      aws.String(d.Get("instance_class").(string)),
+		DBInstanceIdentifier: aws.String(d.Get("identifier").(string)),
+		DBSnapshotIdentifier: aws.String(d.Get("snapshot_identifier").(string)),
+		Tags:                 tagsFromMapRDS(d.Get("tags").(map[string]interface{})),
+	}
+
+	if attr, ok := d.GetOk("storage_type"); ok {
+		opts.StorageType = aws.String(attr.(string))
+	}
+
+	if attr, ok := d.GetOk("iops"); ok {
+		opts.IOPS = aws.Long(int64(attr.(int)))
+	}
+
+	if attr, ok := d.GetOk("port"); ok {
+		opts.Port = aws.Long(int64(attr.(int)))
+	}
+
+	if attr, ok := d.GetOk("multi_az"); ok {
+		opts.MultiAZ = aws.Boolean(attr.(bool))
+	}
+
+	if attr, ok := d.GetOk("availability_zone"); ok {
+		opts.AvailabilityZone = aws.String(attr.(string))
+	}
+
+	if attr, ok := d.GetOk("publicly_accessible"); ok {
+		opts.PubliclyAccessible = aws.Boolean(attr.(bool))
+	}
+
+	if attr, ok := d.GetOk("db_subnet_group_name"); ok {
+		opts.DBSubnetGroupName = aws.String(attr.(string))
+	}
+
+	log.Printf("[DEBUG] DB Instance restore configuration: %#v", opts)
+	if _, err := conn.RestoreDBInstanceFromDBSnapshot(&opts); err != nil {
+		return fmt.Errorf("Error restoring DB Instance from snapshot: %s", err)
+	}
+
+	d.SetId(d.Get("identifier").(string))
+
+	log.Printf("[INFO] DB Instance ID: %s", d.Id())
+
+	log.Println(
+		"[INFO] Waiting for DB Instance to be restored")
+
+	stateConf := &resource.StateChangeConf{
+		Pending:    []string{"creating", "backing-up", "modifying"},
+		Target:     "available",
+		Refresh:    resourceAwsDbInstanceStateRefreshFunc(d, meta),
+		Timeout:    40 * time.Minute,
+		MinTimeout: 10 * time.Second,
+		Delay:      30 * time.Second, // Wait 30 secs before starting
+		StopCh:     d.StopCh(),
+	}
+	if _, err := stateConf.WaitForState(); err != nil {
+		return fmt.Errorf(
+			"Error waiting for DB Instance %s to be restored: %s", d.Id(), err)
+	}
+
+	// The restored instance has the settings of the snapshot, and the
+	// rest of the configuration is applied as a modification.
+	req := &rds.ModifyDBInstanceInput{
+		ApplyImmediately:      aws.Boolean(true),
+		DBInstanceIdentifier:  aws.String(d.Id()),
+		BackupRetentionPeriod: aws.Long(int64(d.Get("backup_retention_period").(int))),
+		MasterUserPassword:    aws.String(d.Get("password").(string)),
+	}
+
+	if attr, ok := d.GetOk("maintenance_window"); ok {
+		req.PreferredMaintenanceWindow = aws.String(attr.(string))
+	}
+
+	if attr, ok := d.GetOk("backup_window"); ok {
+		req.PreferredBackupWindow = aws.String(attr.(string))
+	}
+
+	if attr, ok := d.GetOk("parameter_group_name"); ok {
+		req.DBParameterGroupName = aws.String(attr.(string))
+	}
+
+	if attr := d.Get("vpc_security_group_ids").(*schema.Set); attr.Len() > 0 {
+		var s []*string
+		for _, v := range attr.List() {
+			s = append(s, aws.String(v.(string)))
+		}
+		req.VPCSecurityGroupIDs = s
+	}
+
+	if attr := d.Get("security_group_names").(*schema.Set); attr.Len() > 0 {
+		var s []*string
+		for _, v := range attr.List() {
+			s = append(s, aws.String(v.(string)))
+		}
+		req.DBSecurityGroups = s
+	}
+
+	log.Printf("[DEBUG] DB Instance Modification request: %#v", req)
+	if _, err := conn.ModifyDBInstance(req); err != nil {
+		return fmt.Errorf("Error modifying restored DB Instance %s: %s", d.Id(), err)
+	}
+
+	stateConf.Pending = []string{"modifying", "backing-up",
+		"resetting-master-credentials"}
+	if _, err := stateConf.WaitForState(); err != nil {
+		return fmt.Errorf(
+			"Error waiting for DB Instance %s to be modified: %s", d.Id(), err)
+	}
+
+	return resourceAwsDbInstanceRead(d, meta)
+}
+
 func resourceAwsDbInstanceRead(d *schema.ResourceData, meta interface{}) error {
 	v, err := resourceAwsBbInstanceRetrieve(d, meta)
 
@@ -317,6 +454,35 @@ func resourceAwsDbInstanceRead(d *schema.ResourceData, meta interface{}) error {
 	d.Set("backup_window", v.PreferredBackupWindow)
 	d.Set("maintenance_window", v.PreferredMaintenanceWindow)
 	d.Set("multi_az", v.MultiAZ)
+	d.Set("iops", v.IOPS)
+
+	// Modifications that aren't applied immediately are pending until
+	// the next maintenance window. They are what the instance is going to
+	// be, so they are what is stored, instead of planning them again.
+	if p := v.PendingModifiedValues; p != nil {
+		if p.AllocatedStorage != nil {
+			d.Set("allocated_storage", p.AllocatedStorage)
+		}
+		if p.BackupRetentionPeriod != nil {
+			d.Set("backup_retention_period", p.BackupRetentionPeriod)
+		}
+		if p.DBInstanceClass != nil {
+			d.Set("instance_class", p.DBInstanceClass)
+		}
+		if p.EngineVersion != nil {
+			d.Set("engine_version", p.EngineVersion)
+		}
+		if p.IOPS != nil {
+			d.Set("iops", p.IOPS)
+		}
+		if p.MultiAZ != nil {
+			d.Set("multi_az", p.MultiAZ)
+		}
+		if p.StorageType != nil {
+			d.Set("storage_type", p.StorageType)
+		}
+	}
+
 	if v.DBSubnetGroup != nil {
 		d.Set("db_subnet_group_name", v.DBSubnetGroup.DBSubnetGroupName)
 	}
@@ -393,11 +559,19 @@ func resourceAwsDbInstanceDelete(d *schema.ResourceData, meta interface{}) error
 
 	opts := rds.DeleteDBInstanceInput{DBInstanceIdentifier: aws.String(d.Id())}
 
+	// A final snapshot is taken unless it is explicitly skipped, so that
+	// a database isn't lost by accident.
 	finalSnapshot := d.Get("final_snapshot_identifier").(string)
-	if finalSnapshot == "" {
+	if d.Get("skip_final_snapshot").(bool) {
 		opts.SkipFinalSnapshot = aws.Boolean(true)
-	} else {
+	} else if finalSnapshot != "" {
+		opts.SkipFinalSnapshot = aws.Boolean(false)
 		opts.FinalDBSnapshotIdentifier = aws.String(finalSnapshot)
+	} else {
+		return fmt.Errorf(
+			"DB Instance %s can't be deleted without a final snapshot: "+
+				"final_snapshot_identifier must be set, or "+
+				"skip_final_snapshot must be true", d.Id())
 	}
 
 	log.Printf("[DEBUG] DB Instance destroy configuration: %v", opts)
@@ -415,6 +589,7 @@ func resourceAwsDbInstanceDelete(d *schema.ResourceData, meta interface{}) error
 		Timeout:    40 * time.Minute,
 		MinTimeout: 10 * time.Second,
 		Delay:      30 * time.Second, // Wait 30 secs before starting
+		StopCh:     d.StopCh(),
 	}
 	if _, err := stateConf.WaitForState(); err != nil {
 		return err
@@ -434,52 +609,56 @@ func resourceAwsDbInstanceUpdate(d *schema.ResourceData, meta interface{}) error
 	}
 	d.SetPartial("apply_immediately")
 
+	// The changed attributes, which are all modified by a single request
+	var changed []string
+
 	if d.HasChange("allocated_storage") {
-		d.SetPartial("allocated_storage")
+		changed = append(changed, "allocated_storage")
 		req.AllocatedStorage = aws.Long(int64(d.Get("allocated_storage").(int)))
 	}
 	if d.HasChange("backup_retention_period") {
-		d.SetPartial("backup_retention_period")
+		changed = append(changed, "backup_retention_period")
 		req.BackupRetentionPeriod = aws.Long(int64(d.Get("backup_retention_period").(int)))
 	}
 	if d.HasChange("instance_class") {
-		d.SetPartial("instance_class")
+		changed = append(changed, "instance_class")
 		req.DBInstanceClass = aws.String(d.Get("instance_class").(string))
 	}
 	if d.HasChange("parameter_group_name") {
-		d.SetPartial("parameter_group_name")
+		changed = append(changed, "parameter_group_name")
 		req.DBParameterGroupName = aws.String(d.Get("parameter_group_name").(string))
 	}
 	if d.HasChange("engine_version") {
-		d.SetPartial("engine_version")
+		changed = append(changed, "engine_version")
 		req.EngineVersion = aws.String(d.Get("engine_version").(string))
 	}
 	if d.HasChange("iops") {
-		d.SetPartial("iops")
+		changed = append(changed, "iops")
 		req.IOPS = aws.Long(int64(d.Get("iops").(int)))
 	}
 	if d.HasChange("backup_window") {
-		d.SetPartial("backup_window")
+		changed = append(changed, "backup_window")
 		req.PreferredBackupWindow = aws.String(d.Get("backup_window").(string))
 	}
 	if d.HasChange("maintenance_window") {
-		d.SetPartial("maintenance_window")
+		changed = append(changed, "maintenance_window")
 		req.PreferredMaintenanceWindow = aws.String(d.Get("maintenance_window").(string))
 	}
 	if d.HasChange("password") {
-		d.SetPartial("password")
+		changed = append(changed, "password")
 		req.MasterUserPassword = aws.String(d.Get("password").(string))
 	}
 	if d.HasChange("multi_az") {
-		d.SetPartial("multi_az")
+		changed = append(changed, "multi_az")
 		req.MultiAZ = aws.Boolean(d.Get("multi_az").(bool))
 	}
 	if d.HasChange("storage_type") {
-		d.SetPartial("storage_type")
+		changed = append(changed, "storage_type")
 		req.StorageType = aws.String(d.Get("storage_type").(string))
 	}
 
 	if d.HasChange("vpc_security_group_ids") {
+		changed = append(changed, "vpc_security_group_ids")
 		if attr := d.Get("vpc_security_group_ids").(*schema.Set); attr.Len() > 0 {
 			var s []*string
 			for _, v := range attr.List() {
@@ -489,7 +668,8 @@ func resourceAwsDbInstanceUpdate(d *schema.ResourceData, meta interface{}) error
 		}
 	}
 
-	if d.HasChange("vpc_security_group_ids") {
+	if d.HasChange("security_group_names") {
+		changed = append(changed, "security_group_names")
 		if attr := d.Get("security_group_names").(*schema.Set); attr.Len() > 0 {
 			var s []*string
 			for _, v := range attr.List() {
@@ -499,10 +679,37 @@ func resourceAwsDbInstanceUpdate(d *schema.ResourceData, meta interface{}) error
 		}
 	}
 
-	log.Printf("[DEBUG] DB Instance Modification request: %#v", req)
-	_, err := conn.ModifyDBInstance(req)
-	if err != nil {
-		return fmt.Errorf("Error modifying DB Instance %s: %s", d.Id(), err)
+	if len(changed) > 0 {
+		log.Printf("[DEBUG] DB Instance Modification request: %#v", req)
+		_, err := conn.ModifyDBInstance(req)
+		if err != nil {
+			return fmt.Errorf("Error modifying DB Instance %s: %s", d.Id(), err)
+		}
+
+		// Some modifications, such as of the backup retention period
+		// and the password, are applied right away even when they aren't
+		// requested to be, so the instance is waited for either way.
+		log.Println(
+			"[INFO] Waiting for DB Instance modifications to be applied")
+		stateConf := &resource.StateChangeConf{
+			Pending: []string{"modifying", "backing-up", "upgrading",
+				"rebooting", "resetting-master-credentials",
+				"storage-optimization"},
+			Target:     "available",
+			Refresh:    resourceAwsDbInstanceStateRefreshFunc(d, meta),
+			Timeout:    80 * time.Minute,
+			MinTimeout: 10 * time.Second,
+			Delay:      30 * time.Second, // Wait 30 secs before starting
+			StopCh:     d.StopCh(),
+		}
+		if _, err := stateConf.WaitForState(); err != nil {
+			return fmt.Errorf(
+				"Error waiting for DB Instance %s to be modified: %s", d.Id(), err)
+		}
+
+		for _, k := range changed {
+			d.SetPartial(k)
+		}
 	}
 
 	if arn, err := buildRDSARN(d, meta); err == nil {
@@ -42,6 +42,40 @@ func TestAccAWSDBInstance(t *testing.T) {
 						"aws_db_instance.bar", "parameter_group_name", "default.mysql5.6"),
 				),
 			},
+
+			resource.TestStep{
+				Config: testAccAWSDBInstanceConfigModified,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSDBInstanceExists("aws_db_instance.bar", &v),
+					testAccCheckAWSDBInstanceModified(&v),
+					resource.TestCheckResourceAttr(
+						"aws_db_instance.bar", "allocated_storage", "20"),
+					resource.TestCheckResourceAttr(
+						"aws_db_instance.bar", "instance_class", "db.m1.small"),
+					resource.TestCheckResourceAttr(
+						"aws_db_instance.bar", "status", "available"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccAWSDBInstance_finalSnapshot(t *testing.T) {
+	var v rds.DBInstance
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAWSDBInstanceFinalSnapshot,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccAWSDBInstanceConfigFinalSnapshot,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSDBInstanceExists("aws_db_instance.snapshot", &v),
+					resource.TestCheckResourceAttr(
+						"aws_db_instance.snapshot", "skip_final_snapshot", "false"),
+				),
+			},
 		},
 	})
 }
@@ -80,6 +114,42 @@ func testAccCheckAWSDBInstanceDestroy(s *terraform.State) error {
 	return nil
 }
 
+// testAccCheckAWSDBInstanceFinalSnapshot checks that the final snapshot
+// was taken when the instance was destroyed, and deletes it.
+func testAccCheckAWSDBInstanceFinalSnapshot(s *terraform.State) error {
+	if err := testAccCheckAWSDBInstanceDestroy(s); err != nil {
+		return err
+	}
+
+	conn := testAccProvider.Meta().(*AWSClient).rdsconn
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "aws_db_instance" {
+			continue
+		}
+
+		id := rs.Primary.Attributes["final_snapshot_identifier"]
+		resp, err := conn.DescribeDBSnapshots(&rds.DescribeDBSnapshotsInput{
+			DBSnapshotIdentifier: aws.String(id),
+		})
+		if err != nil {
+			return fmt.Errorf("Error finding final snapshot %s: %s", id, err)
+		}
+		if len(resp.DBSnapshots) != 1 {
+			return fmt.Errorf("Final snapshot %s not found", id)
+		}
+
+		_, err = conn.DeleteDBSnapshot(&rds.DeleteDBSnapshotInput{
+			DBSnapshotIdentifier: aws.String(id),
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 func testAccCheckAWSDBInstanceAttributes(v *rds.DBInstance) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 
@@ -99,6 +169,20 @@ func testAccCheckAWSDBInstanceAttributes(v *rds.DBInstance) resource.TestCheckFu
 	}
 }
 
+func testAccCheckAWSDBInstanceModified(v *rds.DBInstance) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		if *v.AllocatedStorage != 20 {
+			return fmt.Errorf("bad allocated_storage: %#v", *v.AllocatedStorage)
+		}
+
+		if *v.DBInstanceClass != "db.m1.small" {
+			return fmt.Errorf("bad instance_class: %#v", *v.DBInstanceClass)
+		}
+
+		return nil
+	}
+}
+
 func testAccCheckAWSDBInstanceExists(n string, v *rds.DBInstance) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		rs, ok := s.RootModule().Resources[n]
@@ -136,6 +220,8 @@ func testAccCheckAWSDBInstanceExists(n string, v *rds.DBInstance) resource.TestC
 // Database names cannot collide, and deletion takes so long, that making the
 // name a bit random helps so able we can kill a test that's just waiting for a
 // delete and not be blocked on kicking off another one.
+var testAccAWSDBInstanceID = rand.New(rand.NewSource(time.Now().UnixNano())).Int()
+
 var testAccAWSDBInstanceConfig = fmt.Sprintf(`
 resource "aws_db_instance" "bar" {
 	identifier = "foobarbaz-test-terraform-%d"
@@ -149,6 +235,44 @@ resource "aws_db_instance" "bar" {
 	username = "foo"
 
 	backup_retention_period = 0
+	skip_final_snapshot = true
+
+	parameter_group_name = "default.mysql5.6"
+}`, testAccAWSDBInstanceID)
+
+var testAccAWSDBInstanceConfigModified = fmt.Sprintf(`
+resource "aws_db_instance" "bar" {
+	identifier = "foobarbaz-test-terraform-%d"
+
+	allocated_storage = 20
+	engine = "mysql"
+	engine_version = "5.6.21"
+	instance_class = "db.m1.small"
+	name = "baz"
+	password = "barbarbarbar"
+	username = "foo"
+
+	backup_retention_period = 0
+	skip_final_snapshot = true
 
 	parameter_group_name = "default.mysql5.6"
-}`, rand.New(rand.NewSource(time.Now().UnixNano())).Int())
+
+	apply_immediately = true
+}`, testAccAWSDBInstanceID)
+
+var testAccAWSDBInstanceConfigFinalSnapshot = fmt.Sprintf(`
+resource "aws_db_instance" "snapshot" {
+	identifier = "foobarbaz-test-terraform-snapshot-%d"
+
+	allocated_storage = 10
+	engine = "mysql"
+	engine_version = "5.6.21"
+	instance_class = "db.t1.micro"
+	name = "baz"
+	password = "barbarbarbar"
+	username = "foo"
+
+	backup_retention_period = 0
+
+	final_snapshot_identifier = "foobarbaz-test-terraform-final-%d"
+}`, testAccAWSDBInstanceID, testAccAWSDBInstanceID)
@@ -186,6 +186,7 @@ func resourceAwsDbParameterGroupDelete(d *schema.ResourceData, meta interface{})
 		Refresh:    resourceAwsDbParameterGroupDeleteRefreshFunc(d, meta),
 		Timeout:    3 * time.Minute,
 		MinTimeout: 1 * time.Second,
+		StopCh:     d.StopCh(),
 	}
 	_, err := stateConf.WaitForState()
 	return err
@@ -115,6 +115,7 @@ func resourceAwsDbSecurityGroupCreate(d *schema.ResourceData, meta interface{})
 		Target:  "authorized",
 		Refresh: resourceAwsDbSecurityGroupStateRefreshFunc(d, meta),
 		Timeout: 10 * time.Minute,
+		StopCh:  d.StopCh(),
 	}
 
 	// Wait, catching any errors
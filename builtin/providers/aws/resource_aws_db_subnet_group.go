@@ -122,6 +122,7 @@ func resourceAwsDbSubnetGroupDelete(d *schema.ResourceData, meta interface{}) er
 		Refresh:    resourceAwsDbSubnetGroupDeleteRefreshFunc(d, meta),
 		Timeout:    3 * time.Minute,
 		MinTimeout: 1 * time.Second,
+		StopCh:     d.StopCh(),
 	}
 	_, err := stateConf.WaitForState()
 	return err
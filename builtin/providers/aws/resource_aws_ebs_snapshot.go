@@ -0,0 +1,189 @@
+package aws
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/awslabs/aws-sdk-go/aws"
+	"github.com/awslabs/aws-sdk-go/service/ec2"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func resourceAwsEbsSnapshot() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceAwsEbsSnapshotCreate,
+		Read:   resourceAwsEbsSnapshotRead,
+		Update: resourceAwsEbsSnapshotUpdate,
+		Delete: resourceAwsEbsSnapshotDelete,
+
+		Schema: map[string]*schema.Schema{
+			"volume_id": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"description": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+			"encrypted": &schema.Schema{
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+			"kms_key_id": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"owner_id": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"volume_size": &schema.Schema{
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"tags": tagsSchema(),
+		},
+	}
+}
+
+func resourceAwsEbsSnapshotCreate(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).ec2conn
+
+	request := &ec2.CreateSnapshotInput{
+		VolumeID: aws.String(d.Get("volume_id").(string)),
+	}
+	if v, ok := d.GetOk("description"); ok {
+		request.Description = aws.String(v.(string))
+	}
+
+	log.Printf("[DEBUG] EBS snapshot create opts: %#v", request)
+	snapshot, err := conn.CreateSnapshot(request)
+	if err != nil {
+		return fmt.Errorf("Error creating EBS snapshot: %s", err)
+	}
+
+	d.SetId(*snapshot.SnapshotID)
+	log.Printf("[INFO] EBS snapshot ID: %s", d.Id())
+
+	if err := waitForEbsSnapshot(conn, d.Id(), d.StopCh()); err != nil {
+		return err
+	}
+
+	return resourceAwsEbsSnapshotUpdate(d, meta)
+}
+
+func resourceAwsEbsSnapshotRead(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).ec2conn
+
+	raw, _, err := EbsSnapshotStateRefreshFunc(conn, d.Id())()
+	if err != nil {
+		return err
+	}
+	if raw == nil {
+		d.SetId("")
+		return nil
+	}
+
+	snapshot := raw.(*ec2.Snapshot)
+	d.Set("volume_id", *snapshot.VolumeID)
+	if snapshot.Description != nil {
+		d.Set("description", *snapshot.Description)
+	}
+	if snapshot.Encrypted != nil {
+		d.Set("encrypted", *snapshot.Encrypted)
+	}
+	if snapshot.KMSKeyID != nil {
+		d.Set("kms_key_id", *snapshot.KMSKeyID)
+	}
+	d.Set("owner_id", *snapshot.OwnerID)
+	if snapshot.VolumeSize != nil {
+		d.Set("volume_size", *snapshot.VolumeSize)
+	}
+	d.Set("tags", tagsToMapSDK(snapshot.Tags))
+
+	return nil
+}
+
+func resourceAwsEbsSnapshotUpdate(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).ec2conn
+
+	d.Partial(true)
+	if err := setTagsSDK(conn, d); err != nil {
+		return err
+	}
+	d.SetPartial("tags")
+	d.Partial(false)
+
+	return resourceAwsEbsSnapshotRead(d, meta)
+}
+
+func resourceAwsEbsSnapshotDelete(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).ec2conn
+
+	log.Printf("[INFO] Deleting EBS snapshot: %s", d.Id())
+	_, err := conn.DeleteSnapshot(&ec2.DeleteSnapshotInput{
+		SnapshotID: aws.String(d.Id()),
+	})
+	if err != nil {
+		ec2err, ok := err.(aws.APIError)
+		if ok && ec2err.Code == "InvalidSnapshot.NotFound" {
+			return nil
+		}
+		return fmt.Errorf("Error deleting EBS snapshot %s: %s", d.Id(), err)
+	}
+
+	return nil
+}
+
+// waitForEbsSnapshot waits for the snapshot with the given ID to be
+// completed, which can take a while, as it copies the whole volume.
+func waitForEbsSnapshot(conn *ec2.EC2, id string, stopCh <-chan struct{}) error {
+	log.Printf("[DEBUG] Waiting for EBS snapshot (%s) to be completed", id)
+	stateConf := &resource.StateChangeConf{
+		Pending:    []string{"pending"},
+		Target:     "completed",
+		Refresh:    EbsSnapshotStateRefreshFunc(conn, id),
+		Timeout:    60 * time.Minute,
+		Delay:      10 * time.Second,
+		MinTimeout: 5 * time.Second,
+		StopCh:     stopCh,
+	}
+	if _, err := stateConf.WaitForState(); err != nil {
+		return fmt.Errorf(
+			"Error waiting for EBS snapshot (%s) to be completed: %s", id, err)
+	}
+
+	return nil
+}
+
+// EbsSnapshotStateRefreshFunc returns a resource.StateRefreshFunc that is
+// used to watch an EBS snapshot.
+func EbsSnapshotStateRefreshFunc(conn *ec2.EC2, id string) resource.StateRefreshFunc {
+	return func() (interface{}, string, error) {
+		resp, err := conn.DescribeSnapshots(&ec2.DescribeSnapshotsInput{
+			SnapshotIDs: []*string{aws.String(id)},
+		})
+		if err != nil {
+			ec2err, ok := err.(aws.APIError)
+			if ok && ec2err.Code == "InvalidSnapshot.NotFound" {
+				return nil, "", nil
+			}
+			return nil, "", fmt.Errorf("Error reading EBS snapshot %s: %s", id, err)
+		}
+		if len(resp.Snapshots) == 0 {
+			return nil, "", nil
+		}
+
+		snapshot := resp.Snapshots[0]
+		if *snapshot.State == "error" {
+			return snapshot, "error", fmt.Errorf("EBS snapshot %s failed", id)
+		}
+
+		return snapshot, *snapshot.State, nil
+	}
+}
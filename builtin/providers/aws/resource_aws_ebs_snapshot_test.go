@@ -0,0 +1,110 @@
+package aws
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/awslabs/aws-sdk-go/aws"
+	"github.com/awslabs/aws-sdk-go/service/ec2"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestAccAWSEBSSnapshot_basic(t *testing.T) {
+	var v ec2.Snapshot
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAWSEBSSnapshotDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccAwsEbsSnapshotConfig,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSEBSSnapshotExists("aws_ebs_snapshot.test", &v),
+					resource.TestCheckResourceAttr(
+						"aws_ebs_snapshot.test", "volume_size", "1"),
+					resource.TestCheckResourceAttr(
+						"aws_ebs_snapshot.test", "tags.Name", "tf-acc-test"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckAWSEBSSnapshotExists(n string, v *ec2.Snapshot) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No snapshot ID is set")
+		}
+
+		conn := testAccProvider.Meta().(*AWSClient).ec2conn
+		resp, err := conn.DescribeSnapshots(&ec2.DescribeSnapshotsInput{
+			SnapshotIDs: []*string{aws.String(rs.Primary.ID)},
+		})
+		if err != nil {
+			return err
+		}
+		if len(resp.Snapshots) != 1 {
+			return fmt.Errorf("Snapshot not found")
+		}
+		if *resp.Snapshots[0].State != "completed" {
+			return fmt.Errorf("bad state: %s", *resp.Snapshots[0].State)
+		}
+
+		*v = *resp.Snapshots[0]
+
+		return nil
+	}
+}
+
+func testAccCheckAWSEBSSnapshotDestroy(s *terraform.State) error {
+	conn := testAccProvider.Meta().(*AWSClient).ec2conn
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "aws_ebs_snapshot" {
+			continue
+		}
+
+		resp, err := conn.DescribeSnapshots(&ec2.DescribeSnapshotsInput{
+			SnapshotIDs: []*string{aws.String(rs.Primary.ID)},
+		})
+		if err == nil {
+			if len(resp.Snapshots) > 0 {
+				return fmt.Errorf("still exist.")
+			}
+			return nil
+		}
+
+		ec2err, ok := err.(aws.APIError)
+		if !ok {
+			return err
+		}
+		if ec2err.Code != "InvalidSnapshot.NotFound" {
+			return err
+		}
+	}
+
+	return nil
+}
+
+const testAccAwsEbsSnapshotConfig = `
+resource "aws_ebs_volume" "test" {
+	availability_zone = "us-west-2a"
+	size = 1
+}
+
+resource "aws_ebs_snapshot" "test" {
+	volume_id = "${aws_ebs_volume.test.id}"
+	description = "tf-acc-test"
+
+	tags {
+		Name = "tf-acc-test"
+	}
+}
+`
@@ -37,6 +37,11 @@ func resourceAwsEip() *schema.Resource {
 				ConflictsWith: []string{"instance"},
 			},
 
+			"associate_with_private_ip": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
 			"allocation_id": &schema.Schema{
 				Type:     schema.TypeString,
 				Computed: true,
@@ -74,6 +79,10 @@ func resourceAwsEipCreate(d *schema.ResourceData, meta interface{}) error {
 		domainOpt = "vpc"
 	}
 
+	if _, ok := d.GetOk("associate_with_private_ip"); ok && domainOpt != "vpc" {
+		return fmt.Errorf("associate_with_private_ip can only be set for EIPs in a VPC")
+	}
+
 	allocOpts := &ec2.AllocateAddressInput{
 		Domain: aws.String(domainOpt),
 	}
@@ -142,7 +151,11 @@ func resourceAwsEipRead(d *schema.ResourceData, meta interface{}) error {
 	address := describeAddresses.Addresses[0]
 
 	d.Set("association_id", address.AssociationID)
-	if address.InstanceID != nil {
+
+	// An EIP associated with a network interface that is attached to an
+	// instance is also associated with the instance, which is only
+	// tracked when the EIP is associated with the instance itself.
+	if address.InstanceID != nil && d.Get("network_interface").(string) == "" {
 		d.Set("instance", address.InstanceID)
 	}
 	if address.NetworkInterfaceID != nil {
@@ -178,6 +191,14 @@ func resourceAwsEipUpdate(d *schema.ResourceData, meta interface{}) error {
 				NetworkInterfaceID: aws.String(networkInterfaceId),
 				InstanceID:         aws.String(instanceId),
 				AllocationID:       aws.String(d.Id()),
+
+				// Allow moving the EIP from one instance or network
+				// interface to another, such as when failing over.
+				AllowReassociation: aws.Boolean(true),
+			}
+
+			if v, ok := d.GetOk("associate_with_private_ip"); ok {
+				assocOpts.PrivateIPAddress = aws.String(v.(string))
 			}
 		}
 
@@ -77,6 +77,39 @@ func TestAccAWSEIP_network_interface(t *testing.T) {
 	})
 }
 
+func TestAccAWSEIP_associateWithPrivateIP(t *testing.T) {
+	var conf ec2.Address
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAWSEIPDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccAWSEIPAssociateWithPrivateIPConfig,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSEIPExists("aws_eip.bar", &conf),
+					testAccCheckAWSEIPAssociated(&conf),
+					testAccCheckAWSEIPPrivateIP(&conf, "10.0.0.11"),
+					resource.TestCheckResourceAttr(
+						"aws_eip.bar", "private_ip", "10.0.0.11"),
+				),
+			},
+
+			resource.TestStep{
+				Config: testAccAWSEIPAssociateWithPrivateIPConfigUpdate,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSEIPExists("aws_eip.bar", &conf),
+					testAccCheckAWSEIPAssociated(&conf),
+					testAccCheckAWSEIPPrivateIP(&conf, "10.0.0.20"),
+					resource.TestCheckResourceAttr(
+						"aws_eip.bar", "private_ip", "10.0.0.20"),
+				),
+			},
+		},
+	})
+}
+
 func testAccCheckAWSEIPDestroy(s *terraform.State) error {
 	conn := testAccProvider.Meta().(*AWSClient).ec2conn
 
@@ -131,6 +164,16 @@ func testAccCheckAWSEIPAssociated(conf *ec2.Address) resource.TestCheckFunc {
 	}
 }
 
+func testAccCheckAWSEIPPrivateIP(conf *ec2.Address, ip string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		if conf.PrivateIPAddress == nil || *conf.PrivateIPAddress != ip {
+			return fmt.Errorf("bad private_ip: %#v", conf.PrivateIPAddress)
+		}
+
+		return nil
+	}
+}
+
 func testAccCheckAWSEIPExists(n string, res *ec2.Address) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		rs, ok := s.RootModule().Resources[n]
@@ -229,3 +272,61 @@ resource "aws_eip" "bar" {
 	network_interface = "${aws_network_interface.bar.id}"
 }
 `
+
+const testAccAWSEIPAssociateWithPrivateIPConfig = `
+resource "aws_vpc" "bar" {
+	cidr_block = "10.0.0.0/24"
+}
+resource "aws_internet_gateway" "bar" {
+	vpc_id = "${aws_vpc.bar.id}"
+}
+resource "aws_subnet" "bar" {
+	vpc_id = "${aws_vpc.bar.id}"
+	availability_zone = "us-west-2a"
+	cidr_block = "10.0.0.0/24"
+}
+resource "aws_network_interface" "bar" {
+	subnet_id = "${aws_subnet.bar.id}"
+	private_ips = ["10.0.0.10", "10.0.0.11"]
+	security_groups = [ "${aws_vpc.bar.default_security_group_id}" ]
+}
+resource "aws_network_interface" "baz" {
+	subnet_id = "${aws_subnet.bar.id}"
+	private_ips = ["10.0.0.20"]
+	security_groups = [ "${aws_vpc.bar.default_security_group_id}" ]
+}
+resource "aws_eip" "bar" {
+	vpc = "true"
+	network_interface = "${aws_network_interface.bar.id}"
+	associate_with_private_ip = "10.0.0.11"
+}
+`
+
+const testAccAWSEIPAssociateWithPrivateIPConfigUpdate = `
+resource "aws_vpc" "bar" {
+	cidr_block = "10.0.0.0/24"
+}
+resource "aws_internet_gateway" "bar" {
+	vpc_id = "${aws_vpc.bar.id}"
+}
+resource "aws_subnet" "bar" {
+	vpc_id = "${aws_vpc.bar.id}"
+	availability_zone = "us-west-2a"
+	cidr_block = "10.0.0.0/24"
+}
+resource "aws_network_interface" "bar" {
+	subnet_id = "${aws_subnet.bar.id}"
+	private_ips = ["10.0.0.10", "10.0.0.11"]
+	security_groups = [ "${aws_vpc.bar.default_security_group_id}" ]
+}
+resource "aws_network_interface" "baz" {
+	subnet_id = "${aws_subnet.bar.id}"
+	private_ips = ["10.0.0.20"]
+	security_groups = [ "${aws_vpc.bar.default_security_group_id}" ]
+}
+resource "aws_eip" "bar" {
+	vpc = "true"
+	network_interface = "${aws_network_interface.baz.id}"
+	associate_with_private_ip = "10.0.0.20"
+}
+`
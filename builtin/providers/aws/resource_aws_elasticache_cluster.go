@@ -16,6 +16,7 @@ func resourceAwsElasticacheCluster() *schema.Resource {
 	return &schema.Resource{
 		Create: resourceAwsElasticacheClusterCreate,
 		Read:   resourceAwsElasticacheClusterRead,
+		Update: resourceAwsElasticacheClusterUpdate,
 		Delete: resourceAwsElasticacheClusterDelete,
 
 		Schema: map[string]*schema.Schema{
@@ -32,17 +33,15 @@ func resourceAwsElasticacheCluster() *schema.Resource {
 			"node_type": &schema.Schema{
 				Type:     schema.TypeString,
 				Required: true,
-				ForceNew: true,
 			},
 			"num_cache_nodes": &schema.Schema{
 				Type:     schema.TypeInt,
 				Required: true,
-				ForceNew: true,
 			},
 			"parameter_group_name": &schema.Schema{
 				Type:     schema.TypeString,
 				Optional: true,
-				ForceNew: true,
+				Computed: true,
 			},
 			"port": &schema.Schema{
 				Type:     schema.TypeInt,
@@ -80,6 +79,14 @@ func resourceAwsElasticacheCluster() *schema.Resource {
 					return hashcode.String(v.(string))
 				},
 			},
+
+			// apply_immediately is used to determine when the update
+			// modifications take place, as with aws_db_instance.
+			"apply_immediately": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				Computed: true,
+			},
 		},
 	}
 }
@@ -119,6 +126,9 @@ func resourceAwsElasticacheClusterCreate(d *schema.ResourceData, meta interface{
 		return fmt.Errorf("Error creating Elasticache: %s", err)
 	}
 
+	// Store the ID, so that the cluster can be waited for
+	d.SetId(clusterId)
+
 	pending := []string{"creating"}
 	stateConf := &resource.StateChangeConf{
 		Pending:    pending,
@@ -127,6 +137,7 @@ func resourceAwsElasticacheClusterCreate(d *schema.ResourceData, meta interface{
 		Timeout:    10 * time.Minute,
 		Delay:      10 * time.Second,
 		MinTimeout: 3 * time.Second,
+		StopCh:     d.StopCh(),
 	}
 
 	log.Printf("[DEBUG] Waiting for state to become available: %v", d.Id())
@@ -135,8 +146,6 @@ func resourceAwsElasticacheClusterCreate(d *schema.ResourceData, meta interface{
 		return fmt.Errorf("Error waiting for elasticache (%s) to be created: %s", d.Id(), sterr)
 	}
 
-	d.SetId(clusterId)
-
 	return nil
 }
 
@@ -164,12 +173,86 @@ func resourceAwsElasticacheClusterRead(d *schema.ResourceData, meta interface{})
 		d.Set("subnet_group_name", c.CacheSubnetGroupName)
 		d.Set("security_group_names", c.CacheSecurityGroups)
 		d.Set("security_group_ids", c.SecurityGroups)
-		d.Set("parameter_group_name", c.CacheParameterGroup)
+		if c.CacheParameterGroup != nil {
+			d.Set("parameter_group_name", c.CacheParameterGroup.CacheParameterGroupName)
+		}
+
+		// Modifications that aren't applied immediately are pending
+		// until the next maintenance window, and stored as if they were
+		// applied, so that they aren't planned again.
+		if c.PendingModifiedValues != nil && c.PendingModifiedValues.NumCacheNodes != nil {
+			d.Set("num_cache_nodes", c.PendingModifiedValues.NumCacheNodes)
+		}
 	}
 
 	return nil
 }
 
+func resourceAwsElasticacheClusterUpdate(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).elasticacheconn
+
+	req := &elasticache.ModifyCacheClusterInput{
+		CacheClusterID:   aws.String(d.Id()),
+		ApplyImmediately: aws.Boolean(d.Get("apply_immediately").(bool)),
+	}
+
+	requestUpdate := false
+	if d.HasChange("parameter_group_name") {
+		req.CacheParameterGroupName = aws.String(d.Get("parameter_group_name").(string))
+		requestUpdate = true
+	}
+
+	if d.HasChange("node_type") {
+		req.CacheNodeType = aws.String(d.Get("node_type").(string))
+		requestUpdate = true
+	}
+
+	if d.HasChange("num_cache_nodes") {
+		o, n := d.GetChange("num_cache_nodes")
+		oldNum, newNum := o.(int), n.(int)
+		req.NumCacheNodes = aws.Long(int64(newNum))
+
+		// Nodes are removed by their IDs, which are their numbers,
+		// such as 0001, so the most recently added ones are removed.
+		if newNum < oldNum {
+			var remove []*string
+			for i := newNum + 1; i <= oldNum; i++ {
+				remove = append(remove, aws.String(fmt.Sprintf("%04d", i)))
+			}
+			req.CacheNodeIDsToRemove = remove
+		}
+
+		requestUpdate = true
+	}
+
+	if requestUpdate {
+		log.Printf("[DEBUG] Modifying Elasticache Cluster (%s): %#v", d.Id(), req)
+		_, err := conn.ModifyCacheCluster(req)
+		if err != nil {
+			return fmt.Errorf("Error updating Elasticache cluster (%s): %s", d.Id(), err)
+		}
+
+		log.Printf("[DEBUG] Waiting for update: %s", d.Id())
+		pending := []string{"modifying", "rebooting cache cluster nodes", "snapshotting"}
+		stateConf := &resource.StateChangeConf{
+			Pending:    pending,
+			Target:     "available",
+			Refresh:    CacheClusterStateRefreshFunc(conn, d.Id(), "available", pending),
+			Timeout:    20 * time.Minute,
+			Delay:      10 * time.Second,
+			MinTimeout: 3 * time.Second,
+			StopCh:     d.StopCh(),
+		}
+
+		_, sterr := stateConf.WaitForState()
+		if sterr != nil {
+			return fmt.Errorf("Error waiting for elasticache (%s) to update: %s", d.Id(), sterr)
+		}
+	}
+
+	return resourceAwsElasticacheClusterRead(d, meta)
+}
+
 func resourceAwsElasticacheClusterDelete(d *schema.ResourceData, meta interface{}) error {
 	conn := meta.(*AWSClient).elasticacheconn
 
@@ -189,6 +272,7 @@ func resourceAwsElasticacheClusterDelete(d *schema.ResourceData, meta interface{
 		Timeout:    10 * time.Minute,
 		Delay:      10 * time.Second,
 		MinTimeout: 3 * time.Second,
+		StopCh:     d.StopCh(),
 	}
 
 	_, sterr := stateConf.WaitForState()
@@ -2,6 +2,7 @@ package aws
 
 import (
 	"fmt"
+	"log"
 	"os"
 	"reflect"
 	"sort"
@@ -13,6 +14,42 @@ import (
 	"github.com/hashicorp/terraform/terraform"
 )
 
+func init() {
+	resource.AddTestSweepers("aws_elb", &resource.Sweeper{
+		F: testSweepElbs,
+	})
+}
+
+func testSweepElbs(region string) error {
+	client, err := sharedClientForRegion(region)
+	if err != nil {
+		return err
+	}
+	conn := client.elbconn
+
+	resp, err := conn.DescribeLoadBalancers(&elb.DescribeLoadBalancersInput{})
+	if err != nil {
+		return fmt.Errorf("Error describing ELBs: %s", err)
+	}
+
+	for _, lb := range resp.LoadBalancerDescriptions {
+		if !testSweepName(*lb.LoadBalancerName) {
+			continue
+		}
+
+		log.Printf("[INFO] Deleting ELB: %s", *lb.LoadBalancerName)
+		_, err := conn.DeleteLoadBalancer(&elb.DeleteLoadBalancerInput{
+			LoadBalancerName: lb.LoadBalancerName,
+		})
+		if err != nil {
+			return fmt.Errorf(
+				"Error deleting ELB %s: %s", *lb.LoadBalancerName, err)
+		}
+	}
+
+	return nil
+}
+
 func TestAccAWSELB_basic(t *testing.T) {
 	var conf elb.LoadBalancerDescription
 	ssl_certificate_id := os.Getenv("AWS_SSL_CERTIFICATE_ID")
@@ -174,6 +211,50 @@ func TestAccAWSELBUpdate_Listener(t *testing.T) {
 	})
 }
 
+func TestAccAWSELBUpdate_AvailabilityZones(t *testing.T) {
+	var conf elb.LoadBalancerDescription
+	var dnsName string
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAWSELBDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccAWSELBConfig,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSELBExists("aws_elb.bar", &conf),
+					resource.TestCheckResourceAttr(
+						"aws_elb.bar", "availability_zones.#", "3"),
+					func(*terraform.State) error {
+						dnsName = *conf.DNSName
+						return nil
+					},
+				),
+			},
+
+			resource.TestStep{
+				Config: testAccAWSELBConfigAvailabilityZones_update,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSELBExists("aws_elb.bar", &conf),
+					resource.TestCheckResourceAttr(
+						"aws_elb.bar", "availability_zones.#", "2"),
+					resource.TestCheckResourceAttr(
+						"aws_elb.bar", "availability_zones.2487133097", "us-west-2a"),
+					resource.TestCheckResourceAttr(
+						"aws_elb.bar", "availability_zones.221770259", "us-west-2b"),
+					func(*terraform.State) error {
+						if *conf.DNSName != dnsName {
+							return fmt.Errorf("ELB was replaced: %s", *conf.DNSName)
+						}
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
+
 func TestAccAWSELB_HealthCheck(t *testing.T) {
 	var conf elb.LoadBalancerDescription
 
@@ -639,6 +720,26 @@ resource "aws_elb" "bar" {
 }
 `
 
+const testAccAWSELBConfigAvailabilityZones_update = `
+resource "aws_elb" "bar" {
+  name = "foobar-terraform-test"
+  availability_zones = ["us-west-2a", "us-west-2b"]
+
+  listener {
+    instance_port = 8000
+    instance_protocol = "http"
+    lb_port = 80
+    lb_protocol = "http"
+  }
+
+	tags {
+		bar = "baz"
+	}
+
+  cross_zone_load_balancing = true
+}
+`
+
 const testAccAWSELBConfigIdleTimeout = `
 resource "aws_elb" "bar" {
 	name = "foobar-terraform-test"
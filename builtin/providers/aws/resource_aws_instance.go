@@ -7,6 +7,7 @@ import (
 	"encoding/hex"
 	"fmt"
 	"log"
+	"strconv"
 	"strings"
 	"time"
 
@@ -23,6 +24,9 @@ func resourceAwsInstance() *schema.Resource {
 		Read:   resourceAwsInstanceRead,
 		Update: resourceAwsInstanceUpdate,
 		Delete: resourceAwsInstanceDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
 
 		SchemaVersion: 1,
 		MigrateState:  resourceAwsInstanceMigrateState,
@@ -54,10 +58,20 @@ func resourceAwsInstance() *schema.Resource {
 				ForceNew: true,
 			},
 
+			// The instance type is changed by stopping the instance, unless
+			// replacing the instance is asked for.
 			"instance_type": &schema.Schema{
 				Type:     schema.TypeString,
 				Required: true,
-				ForceNew: true,
+				ForceNewIf: func(d *schema.ResourceData) bool {
+					return d.Get("replace_on_instance_type_change").(bool)
+				},
+			},
+
+			"replace_on_instance_type_change": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
 			},
 
 			"key_name": &schema.Schema{
@@ -81,6 +95,25 @@ func resourceAwsInstance() *schema.Resource {
 				Computed: true,
 			},
 
+			"instance_state": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+
+			"snapshot_root_volume_on_destroy": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+
+			"secondary_private_ips": &schema.Schema{
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Set:      schema.HashString,
+			},
+
 			"source_dest_check": &schema.Schema{
 				Type:     schema.TypeBool,
 				Optional: true,
@@ -153,6 +186,35 @@ func resourceAwsInstance() *schema.Resource {
 				ForceNew: true,
 			},
 
+			"spot_price": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+
+			"spot_type": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+				Default:  "one-time",
+			},
+
+			"wait_for_fulfillment": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+
+			"spot_request_id": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"spot_request_state": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
 			"tags": tagsSchema(),
 
 			"block_device": &schema.Schema{
@@ -279,18 +341,19 @@ func resourceAwsInstance() *schema.Resource {
 							ForceNew: true,
 						},
 
+						// The root volume can be grown and have its type
+						// changed in place, but can't be shrunk.
 						"volume_size": &schema.Schema{
-							Type:     schema.TypeInt,
-							Optional: true,
-							Computed: true,
-							ForceNew: true,
+							Type:         schema.TypeInt,
+							Optional:     true,
+							Computed:     true,
+							ForceNewFunc: rootVolumeSizeForceNew,
 						},
 
 						"volume_type": &schema.Schema{
 							Type:     schema.TypeString,
 							Optional: true,
 							Computed: true,
-							ForceNew: true,
 						},
 					},
 				},
@@ -366,6 +429,15 @@ func resourceAwsInstanceCreate(d *schema.ResourceData, meta interface{}) error {
 		}
 	}
 
+	if err := validateInstanceState(d.Get("instance_state").(string)); err != nil {
+		return err
+	}
+
+	if !hasSubnet && d.Get("secondary_private_ips").(*schema.Set).Len() > 0 {
+		return fmt.Errorf(
+			"secondary_private_ips can only be set for instances in a VPC, with subnet_id")
+	}
+
 	if hasSubnet && associatePublicIPAddress {
 		// If we have a non-default VPC / Subnet specified, we can flag
 		// AssociatePublicIpAddress to get a Public IP assigned. By default these are not provided.
@@ -501,42 +573,74 @@ func resourceAwsInstanceCreate(d *schema.ResourceData, meta interface{}) error {
 		runOpts.BlockDeviceMappings = blockDevices
 	}
 
-	// Create the instance
-	log.Printf("[DEBUG] Run configuration: %#v", runOpts)
-	runResp, err := conn.RunInstances(runOpts)
-	if err != nil {
-		return fmt.Errorf("Error launching source instance: %s", err)
+	// Create the instance, or request a spot instance
+	var instanceID string
+	if v, ok := d.GetOk("spot_price"); ok {
+		id, err := resourceAwsInstanceRequestSpot(conn, d, runOpts, v.(string))
+		if err != nil {
+			return err
+		}
+		if id == "" {
+			// The spot request isn't fulfilled yet, and isn't waited for.
+			// The instance is picked up by a later refresh.
+			return resourceAwsInstanceRead(d, meta)
+		}
+		instanceID = id
+	} else {
+		log.Printf("[DEBUG] Run configuration: %#v", runOpts)
+		runResp, err := conn.RunInstances(runOpts)
+		if err != nil {
+			return fmt.Errorf("Error launching source instance: %s", err)
+		}
+		instanceID = *runResp.Instances[0].InstanceID
 	}
 
-	instance := runResp.Instances[0]
-	log.Printf("[INFO] Instance ID: %s", *instance.InstanceID)
+	log.Printf("[INFO] Instance ID: %s", instanceID)
 
 	// Store the resulting ID so we can look this up later
-	d.SetId(*instance.InstanceID)
+	d.SetId(instanceID)
 
 	// Wait for the instance to become running so we can get some attributes
 	// that aren't available until later.
 	log.Printf(
 		"[DEBUG] Waiting for instance (%s) to become running",
-		*instance.InstanceID)
+		instanceID)
 
 	stateConf := &resource.StateChangeConf{
 		Pending:    []string{"pending"},
 		Target:     "running",
-		Refresh:    InstanceStateRefreshFunc(conn, *instance.InstanceID),
+		Refresh:    InstanceStateRefreshFunc(conn, instanceID),
 		Timeout:    10 * time.Minute,
 		Delay:      10 * time.Second,
 		MinTimeout: 3 * time.Second,
+		StopCh:     d.StopCh(),
 	}
 
 	instanceRaw, err := stateConf.WaitForState()
 	if err != nil {
 		return fmt.Errorf(
 			"Error waiting for instance (%s) to become ready: %s",
-			*instance.InstanceID, err)
+			instanceID, err)
+	}
+
+	instance := instanceRaw.(*ec2.Instance)
+
+	// Secondary private IPs can only be assigned to the network
+	// interface of the instance once it is running.
+	if v := d.Get("secondary_private_ips").(*schema.Set); v.Len() > 0 {
+		if err := resourceAwsInstanceAssignPrivateIPs(
+			conn, instance, v.List(), nil); err != nil {
+			return err
+		}
 	}
 
-	instance = instanceRaw.(*ec2.Instance)
+	// Instances that are kept stopped until needed are stopped once
+	// they are ready, after the secondary private IPs are assigned.
+	if d.Get("instance_state").(string) == "stopped" {
+		if err := resourceAwsInstanceSetState(conn, d, "stopped"); err != nil {
+			return err
+		}
+	}
 
 	// Initialize the connection info
 	if instance.PublicIPAddress != nil {
@@ -563,6 +667,14 @@ func resourceAwsInstanceCreate(d *schema.ResourceData, meta interface{}) error {
 func resourceAwsInstanceRead(d *schema.ResourceData, meta interface{}) error {
 	conn := meta.(*AWSClient).ec2conn
 
+	// Until a spot request is fulfilled, the ID is the ID of the request
+	if isSpotRequestID(d.Id()) {
+		fulfilled, err := resourceAwsInstanceReadSpotRequest(conn, d)
+		if err != nil || !fulfilled {
+			return err
+		}
+	}
+
 	resp, err := conn.DescribeInstances(&ec2.DescribeInstancesInput{
 		InstanceIDs: []*string{aws.String(d.Id())},
 	})
@@ -599,6 +711,7 @@ func resourceAwsInstanceRead(d *schema.ResourceData, meta interface{}) error {
 		d.Set("tenancy", instance.Placement.Tenancy)
 	}
 
+	d.Set("instance_state", instance.State.Name)
 	d.Set("key_name", instance.KeyName)
 	d.Set("public_dns", instance.PublicDNSName)
 	d.Set("public_ip", instance.PublicIPAddress)
@@ -609,6 +722,9 @@ func resourceAwsInstanceRead(d *schema.ResourceData, meta interface{}) error {
 	} else {
 		d.Set("subnet_id", instance.SubnetID)
 	}
+	if err := d.Set("secondary_private_ips", instanceSecondaryPrivateIPs(instance)); err != nil {
+		return err
+	}
 	d.Set("ebs_optimized", instance.EBSOptimized)
 	d.Set("tags", tagsToMapSDK(instance.Tags))
 
@@ -664,6 +780,11 @@ func resourceAwsInstanceRead(d *schema.ResourceData, meta interface{}) error {
 func resourceAwsInstanceUpdate(d *schema.ResourceData, meta interface{}) error {
 	conn := meta.(*AWSClient).ec2conn
 
+	// Nothing can be changed until the spot request is fulfilled
+	if isSpotRequestID(d.Id()) {
+		return resourceAwsInstanceRead(d, meta)
+	}
+
 	d.Partial(true)
 
 	// SourceDestCheck can only be set on VPC instances
@@ -697,6 +818,58 @@ func resourceAwsInstanceUpdate(d *schema.ResourceData, meta interface{}) error {
 
 	}
 
+	if d.HasChange("instance_type") {
+		if err := resourceAwsInstanceModifyType(conn, d); err != nil {
+			return err
+		}
+		d.SetPartial("instance_type")
+	}
+
+	if d.HasChange("instance_state") {
+		desired := d.Get("instance_state").(string)
+		if err := validateInstanceState(desired); err != nil {
+			return err
+		}
+		if err := resourceAwsInstanceSetState(conn, d, desired); err != nil {
+			return err
+		}
+		d.SetPartial("instance_state")
+	}
+
+	if d.HasChange("secondary_private_ips") {
+		instanceRaw, _, err := InstanceStateRefreshFunc(conn, d.Id())()
+		if err != nil {
+			return err
+		}
+		if instanceRaw == nil {
+			return fmt.Errorf("Error finding instance (%s)", d.Id())
+		}
+		instance := instanceRaw.(*ec2.Instance)
+
+		// Compare with the IPs the instance has, rather than the state,
+		// so that IPs that are already assigned aren't assigned again.
+		current := schema.NewSet(schema.HashString, nil)
+		for _, ip := range instanceSecondaryPrivateIPs(instance) {
+			current.Add(ip)
+		}
+		desired := d.Get("secondary_private_ips").(*schema.Set)
+
+		if err := resourceAwsInstanceAssignPrivateIPs(
+			conn, instance,
+			desired.Difference(current).List(),
+			current.Difference(desired).List()); err != nil {
+			return err
+		}
+		d.SetPartial("secondary_private_ips")
+	}
+
+	if d.HasChange("root_block_device") {
+		if err := resourceAwsInstanceModifyRootVolume(conn, d); err != nil {
+			return err
+		}
+		d.SetPartial("root_block_device")
+	}
+
 	// TODO(mitchellh): wait for the attributes we modified to
 	// persist the change...
 
@@ -713,6 +886,29 @@ func resourceAwsInstanceUpdate(d *schema.ResourceData, meta interface{}) error {
 func resourceAwsInstanceDelete(d *schema.ResourceData, meta interface{}) error {
 	conn := meta.(*AWSClient).ec2conn
 
+	// The spot request is cancelled first, so that a persistent request
+	// doesn't launch a new instance once this one is terminated.
+	if id := d.Get("spot_request_id").(string); id != "" {
+		instanceID, err := resourceAwsInstanceCancelSpotRequest(conn, id)
+		if err != nil {
+			return err
+		}
+
+		if isSpotRequestID(d.Id()) {
+			if instanceID == "" {
+				d.SetId("")
+				return nil
+			}
+			d.SetId(instanceID)
+		}
+	}
+
+	if d.Get("snapshot_root_volume_on_destroy").(bool) {
+		if err := resourceAwsInstanceSnapshotRootVolume(conn, d); err != nil {
+			return err
+		}
+	}
+
 	log.Printf("[INFO] Terminating instance: %s", d.Id())
 	req := &ec2.TerminateInstancesInput{
 		InstanceIDs: []*string{aws.String(d.Id())},
@@ -732,6 +928,7 @@ func resourceAwsInstanceDelete(d *schema.ResourceData, meta interface{}) error {
 		Timeout:    10 * time.Minute,
 		Delay:      10 * time.Second,
 		MinTimeout: 3 * time.Second,
+		StopCh:     d.StopCh(),
 	}
 
 	_, err := stateConf.WaitForState()
@@ -745,6 +942,247 @@ func resourceAwsInstanceDelete(d *schema.ResourceData, meta interface{}) error {
 	return nil
 }
 
+// resourceAwsInstanceRequestSpot requests a spot instance with the given
+// price, launched like RunInstances would with the given options. It
+// returns the ID of the instance once the request is fulfilled, or an
+// empty ID if wait_for_fulfillment is false and the request is left open.
+func resourceAwsInstanceRequestSpot(
+	conn *ec2.EC2, d *schema.ResourceData,
+	runOpts *ec2.RunInstancesInput, price string) (string, error) {
+	if runOpts.Placement.Tenancy != nil && *runOpts.Placement.Tenancy != "default" {
+		return "", fmt.Errorf("Spot instances can't have a tenancy")
+	}
+
+	spec := &ec2.RequestSpotLaunchSpecification{
+		BlockDeviceMappings: runOpts.BlockDeviceMappings,
+		EBSOptimized:        runOpts.EBSOptimized,
+		IAMInstanceProfile:  runOpts.IAMInstanceProfile,
+		ImageID:             runOpts.ImageID,
+		InstanceType:        runOpts.InstanceType,
+		KeyName:             runOpts.KeyName,
+		NetworkInterfaces:   runOpts.NetworkInterfaces,
+		Placement: &ec2.SpotPlacement{
+			AvailabilityZone: runOpts.Placement.AvailabilityZone,
+			GroupName:        runOpts.Placement.GroupName,
+		},
+		SecurityGroupIDs: runOpts.SecurityGroupIDs,
+		SecurityGroups:   runOpts.SecurityGroups,
+		SubnetID:         runOpts.SubnetID,
+		UserData:         runOpts.UserData,
+	}
+
+	// The launch specification of a spot request has no private IP, so
+	// it is set on the primary network interface instead.
+	if runOpts.PrivateIPAddress != nil {
+		spec.NetworkInterfaces = []*ec2.InstanceNetworkInterfaceSpecification{
+			&ec2.InstanceNetworkInterfaceSpecification{
+				DeviceIndex:      aws.Long(int64(0)),
+				SubnetID:         runOpts.SubnetID,
+				PrivateIPAddress: runOpts.PrivateIPAddress,
+				Groups:           runOpts.SecurityGroupIDs,
+			},
+		}
+		spec.SubnetID = nil
+		spec.SecurityGroupIDs = nil
+	}
+
+	req := &ec2.RequestSpotInstancesInput{
+		SpotPrice:           aws.String(price),
+		Type:                aws.String(d.Get("spot_type").(string)),
+		InstanceCount:       aws.Long(int64(1)),
+		LaunchSpecification: spec,
+	}
+
+	log.Printf("[DEBUG] Spot request configuration: %#v", req)
+	resp, err := conn.RequestSpotInstances(req)
+	if err != nil {
+		return "", fmt.Errorf("Error requesting spot instance: %s", err)
+	}
+
+	id := *resp.SpotInstanceRequests[0].SpotInstanceRequestID
+	log.Printf("[INFO] Spot request ID: %s", id)
+	d.SetId(id)
+	d.Set("spot_request_id", id)
+	d.Set("spot_request_state", resp.SpotInstanceRequests[0].State)
+
+	if !d.Get("wait_for_fulfillment").(bool) {
+		return "", nil
+	}
+
+	log.Printf("[DEBUG] Waiting for spot request (%s) to be fulfilled", id)
+	stateConf := &resource.StateChangeConf{
+		Pending:    []string{"open"},
+		Target:     "active",
+		Refresh:    SpotRequestStateRefreshFunc(conn, id),
+		Timeout:    10 * time.Minute,
+		Delay:      10 * time.Second,
+		MinTimeout: 3 * time.Second,
+		StopCh:     d.StopCh(),
+	}
+
+	raw, err := stateConf.WaitForState()
+	if err != nil {
+		return "", fmt.Errorf(
+			"Error waiting for spot request (%s) to be fulfilled: %s", id, err)
+	}
+
+	sir := raw.(*ec2.SpotInstanceRequest)
+	d.Set("spot_request_state", sir.State)
+	return *sir.InstanceID, nil
+}
+
+// resourceAwsInstanceReadSpotRequest refreshes the spot request whose ID
+// is the ID of the resource, and returns whether it is fulfilled, in
+// which case the ID of the resource becomes the ID of the instance.
+func resourceAwsInstanceReadSpotRequest(conn *ec2.EC2, d *schema.ResourceData) (bool, error) {
+	raw, state, err := SpotRequestStateRefreshFunc(conn, d.Id())()
+	if raw == nil {
+		if err == nil {
+			d.SetId("")
+		}
+		return false, err
+	}
+
+	sir := raw.(*ec2.SpotInstanceRequest)
+	d.Set("spot_request_id", sir.SpotInstanceRequestID)
+	d.Set("spot_request_state", state)
+
+	if sir.InstanceID != nil && *sir.InstanceID != "" {
+		log.Printf(
+			"[INFO] Spot request (%s) fulfilled with instance %s",
+			d.Id(), *sir.InstanceID)
+		d.SetId(*sir.InstanceID)
+		return true, nil
+	}
+
+	// A request that is no longer open without an instance is gone
+	if state != "open" {
+		log.Printf("[WARN] Spot request (%s) is %s: %s", d.Id(), state, err)
+		d.SetId("")
+	}
+
+	return false, nil
+}
+
+// resourceAwsInstanceCancelSpotRequest cancels the spot request with the
+// given ID, and returns the ID of its instance, if it has one.
+func resourceAwsInstanceCancelSpotRequest(conn *ec2.EC2, id string) (string, error) {
+	raw, _, _ := SpotRequestStateRefreshFunc(conn, id)()
+	if raw == nil {
+		return "", nil
+	}
+
+	var instanceID string
+	if sir := raw.(*ec2.SpotInstanceRequest); sir.InstanceID != nil {
+		instanceID = *sir.InstanceID
+	}
+
+	log.Printf("[INFO] Cancelling spot request: %s", id)
+	_, err := conn.CancelSpotInstanceRequests(&ec2.CancelSpotInstanceRequestsInput{
+		SpotInstanceRequestIDs: []*string{aws.String(id)},
+	})
+	if err != nil {
+		return "", fmt.Errorf("Error cancelling spot request (%s): %s", id, err)
+	}
+
+	return instanceID, nil
+}
+
+// isSpotRequestID returns whether the ID of an aws_instance is the ID of
+// a spot request that isn't fulfilled yet.
+func isSpotRequestID(id string) bool {
+	return strings.HasPrefix(id, "sir-")
+}
+
+// SpotRequestStateRefreshFunc returns a resource.StateRefreshFunc that is
+// used to watch a spot request. Requests that are closed, cancelled or
+// failed without an instance are reported as errors.
+func SpotRequestStateRefreshFunc(conn *ec2.EC2, id string) resource.StateRefreshFunc {
+	return func() (interface{}, string, error) {
+		resp, err := conn.DescribeSpotInstanceRequests(&ec2.DescribeSpotInstanceRequestsInput{
+			SpotInstanceRequestIDs: []*string{aws.String(id)},
+		})
+		if err != nil {
+			if ec2err, ok := err.(aws.APIError); ok && ec2err.Code == "InvalidSpotInstanceRequestID.NotFound" {
+				return nil, "", nil
+			}
+
+			log.Printf("Error on SpotRequestStateRefresh: %s", err)
+			return nil, "", err
+		}
+		if len(resp.SpotInstanceRequests) == 0 {
+			return nil, "", nil
+		}
+
+		sir := resp.SpotInstanceRequests[0]
+		state := *sir.State
+		if state != "open" && state != "active" {
+			status := ""
+			if sir.Status != nil && sir.Status.Message != nil {
+				status = *sir.Status.Message
+			}
+			return sir, state, fmt.Errorf("spot request is %s: %s", state, status)
+		}
+
+		return sir, state, nil
+	}
+}
+
+// resourceAwsInstanceSnapshotRootVolume creates a snapshot of the root
+// volume of the instance, with the tags of the instance, and waits for it
+// to be completed, so that the root volume can be restored after the
+// instance is terminated.
+func resourceAwsInstanceSnapshotRootVolume(conn *ec2.EC2, d *schema.ResourceData) error {
+	resp, err := conn.DescribeInstances(&ec2.DescribeInstancesInput{
+		InstanceIDs: []*string{aws.String(d.Id())},
+	})
+	if err != nil {
+		return fmt.Errorf("Error retrieving instance %s: %s", d.Id(), err)
+	}
+	if len(resp.Reservations) == 0 || len(resp.Reservations[0].Instances) == 0 {
+		return fmt.Errorf("Instance %s not found", d.Id())
+	}
+	instance := resp.Reservations[0].Instances[0]
+
+	var volumeID string
+	for _, bd := range instance.BlockDeviceMappings {
+		if bd.EBS != nil && blockDeviceIsRoot(bd, instance) {
+			volumeID = *bd.EBS.VolumeID
+		}
+	}
+	if volumeID == "" {
+		return fmt.Errorf(
+			"Error creating a snapshot of the root volume of instance %s: "+
+				"the instance has no EBS root volume", d.Id())
+	}
+
+	log.Printf("[INFO] Creating a snapshot of the root volume %s of instance %s", volumeID, d.Id())
+	snapshot, err := conn.CreateSnapshot(&ec2.CreateSnapshotInput{
+		VolumeID: aws.String(volumeID),
+		Description: aws.String(fmt.Sprintf(
+			"Root volume of %s, before it was destroyed", d.Id())),
+	})
+	if err != nil {
+		return fmt.Errorf(
+			"Error creating a snapshot of the root volume of instance %s: %s",
+			d.Id(), err)
+	}
+	log.Printf("[INFO] Root volume snapshot ID: %s", *snapshot.SnapshotID)
+
+	if len(instance.Tags) > 0 {
+		_, err := conn.CreateTags(&ec2.CreateTagsInput{
+			Resources: []*string{snapshot.SnapshotID},
+			Tags:      instance.Tags,
+		})
+		if err != nil {
+			return fmt.Errorf(
+				"Error tagging the snapshot %s: %s", *snapshot.SnapshotID, err)
+		}
+	}
+
+	return waitForEbsSnapshot(conn, *snapshot.SnapshotID, d.StopCh())
+}
+
 // InstanceStateRefreshFunc returns a resource.StateRefreshFunc that is used to watch
 // an EC2 instance.
 func InstanceStateRefreshFunc(conn *ec2.EC2, instanceID string) resource.StateRefreshFunc {
@@ -773,6 +1211,317 @@ func InstanceStateRefreshFunc(conn *ec2.EC2, instanceID string) resource.StateRe
 	}
 }
 
+// validateInstanceState returns an error if the given instance_state
+// isn't one that instances can be kept in.
+func validateInstanceState(state string) error {
+	switch state {
+	case "", "running", "stopped":
+		return nil
+	default:
+		return fmt.Errorf(
+			"instance_state must be running or stopped, got: %s", state)
+	}
+}
+
+// resourceAwsInstanceSetState starts or stops the instance so that it is
+// in the given state, running or stopped, and waits for it to get there.
+// The instance is left alone if it is already in that state.
+func resourceAwsInstanceSetState(conn *ec2.EC2, d *schema.ResourceData, state string) error {
+	if state == "" {
+		return nil
+	}
+
+	instanceRaw, current, err := InstanceStateRefreshFunc(conn, d.Id())()
+	if err != nil {
+		return err
+	}
+	if instanceRaw == nil {
+		return fmt.Errorf("Error finding instance (%s)", d.Id())
+	}
+
+	// Let the instance finish starting or stopping before changing it
+	switch current {
+	case "pending":
+		current = "running"
+	case "stopping":
+		current = "stopped"
+	}
+	if err := resourceAwsInstanceWaitForState(conn, d, current); err != nil {
+		return err
+	}
+	if current == state {
+		return nil
+	}
+
+	ids := []*string{aws.String(d.Id())}
+	if state == "stopped" {
+		log.Printf("[INFO] Stopping instance: %s", d.Id())
+		if _, err := conn.StopInstances(&ec2.StopInstancesInput{InstanceIDs: ids}); err != nil {
+			return fmt.Errorf("Error stopping instance (%s): %s", d.Id(), err)
+		}
+	} else {
+		log.Printf("[INFO] Starting instance: %s", d.Id())
+		if _, err := conn.StartInstances(&ec2.StartInstancesInput{InstanceIDs: ids}); err != nil {
+			return fmt.Errorf("Error starting instance (%s): %s", d.Id(), err)
+		}
+	}
+
+	return resourceAwsInstanceWaitForState(conn, d, state)
+}
+
+// resourceAwsInstanceModifyType changes the type of the instance. The
+// instance is stopped for the change, and started again if it was
+// running, unless instance_state asks for it to be stopped.
+func resourceAwsInstanceModifyType(conn *ec2.EC2, d *schema.ResourceData) error {
+	_, current, err := InstanceStateRefreshFunc(conn, d.Id())()
+	if err != nil {
+		return err
+	}
+	running := (current == "pending" || current == "running") &&
+		d.Get("instance_state").(string) != "stopped"
+
+	if err := resourceAwsInstanceSetState(conn, d, "stopped"); err != nil {
+		return err
+	}
+
+	instanceType := d.Get("instance_type").(string)
+	log.Printf("[INFO] Changing the type of instance %s to %s", d.Id(), instanceType)
+	_, err = conn.ModifyInstanceAttribute(&ec2.ModifyInstanceAttributeInput{
+		InstanceID: aws.String(d.Id()),
+		InstanceType: &ec2.AttributeValue{
+			Value: aws.String(instanceType),
+		},
+	})
+	if err != nil {
+		return fmt.Errorf(
+			"Error changing the type of instance (%s): %s", d.Id(), err)
+	}
+
+	if !running {
+		return nil
+	}
+	return resourceAwsInstanceSetState(conn, d, "running")
+}
+
+// resourceAwsInstanceWaitForState waits for the instance to be running or
+// stopped, as given by state.
+func resourceAwsInstanceWaitForState(conn *ec2.EC2, d *schema.ResourceData, state string) error {
+	log.Printf("[DEBUG] Waiting for instance (%s) to become %s", d.Id(), state)
+
+	stateConf := &resource.StateChangeConf{
+		Pending:    []string{"pending", "running", "stopping", "stopped"},
+		Target:     state,
+		Refresh:    InstanceStateRefreshFunc(conn, d.Id()),
+		Timeout:    10 * time.Minute,
+		Delay:      10 * time.Second,
+		MinTimeout: 3 * time.Second,
+		StopCh:     d.StopCh(),
+	}
+	if _, err := stateConf.WaitForState(); err != nil {
+		return fmt.Errorf(
+			"Error waiting for instance (%s) to become %s: %s",
+			d.Id(), state, err)
+	}
+
+	return nil
+}
+
+// resourceAwsInstanceModifyRootVolume changes the size and type of the
+// root volume in place, and waits for the modification to get far enough
+// for the volume to be used at its new size.
+func resourceAwsInstanceModifyRootVolume(conn *ec2.EC2, d *schema.ResourceData) error {
+	o, n := d.GetChange("root_block_device")
+	os := o.(*schema.Set).List()
+	ns := n.(*schema.Set).List()
+	if len(os) == 0 || len(ns) == 0 {
+		// The root volume was just created with these settings.
+		return nil
+	}
+	obd := os[0].(map[string]interface{})
+	nbd := ns[0].(map[string]interface{})
+
+	req := &ec2.ModifyVolumeInput{}
+	if v, ok := nbd["volume_size"].(int); ok && v != 0 && v != obd["volume_size"].(int) {
+		req.Size = aws.Long(int64(v))
+	}
+	if v, ok := nbd["volume_type"].(string); ok && v != "" && v != obd["volume_type"].(string) {
+		req.VolumeType = aws.String(v)
+	}
+	if req.Size == nil && req.VolumeType == nil {
+		return nil
+	}
+
+	volumeID, err := instanceRootVolumeID(conn, d.Id())
+	if err != nil {
+		return err
+	}
+	req.VolumeID = aws.String(volumeID)
+
+	log.Printf("[INFO] Modifying root volume %s of instance %s: %#v", volumeID, d.Id(), req)
+	if _, err := conn.ModifyVolume(req); err != nil {
+		return fmt.Errorf("Error modifying root volume %s: %s", volumeID, err)
+	}
+
+	stateConf := &resource.StateChangeConf{
+		Pending:    []string{"modifying"},
+		Target:     "optimizing",
+		Refresh:    VolumeModificationStateRefreshFunc(conn, volumeID),
+		Timeout:    5 * time.Minute,
+		Delay:      10 * time.Second,
+		MinTimeout: 3 * time.Second,
+	}
+	if _, err := stateConf.WaitForState(); err != nil {
+		return fmt.Errorf(
+			"Error waiting for root volume %s to be modified: %s", volumeID, err)
+	}
+
+	return nil
+}
+
+// instancePrimaryNetworkInterface returns the network interface of the
+// instance with the device index 0, or nil if it has none, such as
+// outside of a VPC.
+func instancePrimaryNetworkInterface(instance *ec2.Instance) *ec2.InstanceNetworkInterface {
+	for _, ni := range instance.NetworkInterfaces {
+		if ni.Attachment != nil && ni.Attachment.DeviceIndex != nil &&
+			*ni.Attachment.DeviceIndex == 0 {
+			return ni
+		}
+	}
+
+	return nil
+}
+
+// instanceSecondaryPrivateIPs returns the private IPs of the primary
+// network interface of the instance, other than its primary private IP.
+func instanceSecondaryPrivateIPs(instance *ec2.Instance) []string {
+	ips := make([]string, 0)
+	ni := instancePrimaryNetworkInterface(instance)
+	if ni == nil {
+		return ips
+	}
+
+	for _, ip := range ni.PrivateIPAddresses {
+		if ip.Primary != nil && *ip.Primary {
+			continue
+		}
+		if ip.PrivateIPAddress != nil {
+			ips = append(ips, *ip.PrivateIPAddress)
+		}
+	}
+
+	return ips
+}
+
+// resourceAwsInstanceAssignPrivateIPs unassigns the secondary private IPs
+// to remove from the primary network interface of the instance, and then
+// assigns the ones to add, so that an IP can move between instances.
+func resourceAwsInstanceAssignPrivateIPs(
+	conn *ec2.EC2, instance *ec2.Instance, add, remove []interface{}) error {
+	ni := instancePrimaryNetworkInterface(instance)
+	if ni == nil {
+		return fmt.Errorf(
+			"Instance (%s) has no network interface for secondary private IPs",
+			*instance.InstanceID)
+	}
+
+	if len(remove) > 0 {
+		log.Printf("[INFO] Unassigning private IPs %v from %s", remove, *ni.NetworkInterfaceID)
+		_, err := conn.UnassignPrivateIPAddresses(&ec2.UnassignPrivateIPAddressesInput{
+			NetworkInterfaceID: ni.NetworkInterfaceID,
+			PrivateIPAddresses: expandStringList(remove),
+		})
+		if err != nil {
+			return fmt.Errorf("Error unassigning private IPs: %s", err)
+		}
+	}
+
+	if len(add) > 0 {
+		log.Printf("[INFO] Assigning private IPs %v to %s", add, *ni.NetworkInterfaceID)
+		_, err := conn.AssignPrivateIPAddresses(&ec2.AssignPrivateIPAddressesInput{
+			NetworkInterfaceID: ni.NetworkInterfaceID,
+			PrivateIPAddresses: expandStringList(add),
+		})
+		if err != nil {
+			return fmt.Errorf("Error assigning private IPs: %s", err)
+		}
+	}
+
+	return nil
+}
+
+// VolumeModificationStateRefreshFunc returns a resource.StateRefreshFunc
+// that is used to watch the modification of an EBS volume. A volume can
+// be used at its new size once it is optimizing, so a modification that
+// has already completed is reported as optimizing.
+func VolumeModificationStateRefreshFunc(conn *ec2.EC2, volumeID string) resource.StateRefreshFunc {
+	return func() (interface{}, string, error) {
+		resp, err := conn.DescribeVolumesModifications(&ec2.DescribeVolumesModificationsInput{
+			VolumeIDs: []*string{aws.String(volumeID)},
+		})
+		if err != nil {
+			return nil, "", err
+		}
+
+		if len(resp.VolumesModifications) == 0 {
+			return nil, "", nil
+		}
+
+		m := resp.VolumesModifications[0]
+		state := *m.ModificationState
+		switch state {
+		case "completed":
+			state = "optimizing"
+		case "failed":
+			var msg string
+			if m.StatusMessage != nil {
+				msg = *m.StatusMessage
+			}
+			return nil, "", fmt.Errorf("volume modification failed: %s", msg)
+		}
+
+		return m, state, nil
+	}
+}
+
+// instanceRootVolumeID returns the ID of the EBS root volume of an instance.
+func instanceRootVolumeID(conn *ec2.EC2, instanceID string) (string, error) {
+	resp, err := conn.DescribeInstances(&ec2.DescribeInstancesInput{
+		InstanceIDs: []*string{aws.String(instanceID)},
+	})
+	if err != nil {
+		return "", err
+	}
+	if len(resp.Reservations) == 0 || len(resp.Reservations[0].Instances) == 0 {
+		return "", fmt.Errorf("Instance not found: %s", instanceID)
+	}
+
+	instance := resp.Reservations[0].Instances[0]
+	for _, bd := range instance.BlockDeviceMappings {
+		if bd.EBS != nil && blockDeviceIsRoot(bd, instance) {
+			return *bd.EBS.VolumeID, nil
+		}
+	}
+
+	return "", fmt.Errorf("Instance %s has no EBS root volume", instanceID)
+}
+
+// rootVolumeSizeForceNew is the ForceNewFunc of the root volume size. An
+// EBS volume can be grown in place, but shrinking it requires a new
+// instance.
+func rootVolumeSizeForceNew(o, n string) bool {
+	os, err := strconv.Atoi(o)
+	if err != nil {
+		return false
+	}
+	ns, err := strconv.Atoi(n)
+	if err != nil {
+		return false
+	}
+
+	return ns < os
+}
+
 func readBlockDevices(d *schema.ResourceData, instance *ec2.Instance, conn *ec2.EC2) error {
 	ibds, err := readBlockDevicesFromInstance(instance, conn)
 	if err != nil {
@@ -2,8 +2,11 @@ package aws
 
 import (
 	"fmt"
+	"log"
 	"reflect"
+	"sort"
 	"testing"
+	"time"
 
 	"github.com/awslabs/aws-sdk-go/aws"
 	"github.com/awslabs/aws-sdk-go/service/ec2"
@@ -12,6 +15,75 @@ import (
 	"github.com/hashicorp/terraform/terraform"
 )
 
+func init() {
+	resource.AddTestSweepers("aws_instance", &resource.Sweeper{
+		F: testSweepInstances,
+	})
+}
+
+func testSweepInstances(region string) error {
+	client, err := sharedClientForRegion(region)
+	if err != nil {
+		return err
+	}
+	conn := client.ec2conn
+
+	resp, err := conn.DescribeInstances(&ec2.DescribeInstancesInput{
+		Filters: []*ec2.Filter{
+			testSweepNameFilter(),
+			&ec2.Filter{
+				Name: aws.String("instance-state-name"),
+				Values: []*string{
+					aws.String("pending"),
+					aws.String("running"),
+					aws.String("stopping"),
+					aws.String("stopped"),
+				},
+			},
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("Error describing instances: %s", err)
+	}
+
+	var ids []*string
+	for _, r := range resp.Reservations {
+		for _, i := range r.Instances {
+			ids = append(ids, i.InstanceID)
+		}
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+
+	log.Printf("[INFO] Terminating %d instances", len(ids))
+	_, err = conn.TerminateInstances(&ec2.TerminateInstancesInput{
+		InstanceIDs: ids,
+	})
+	if err != nil {
+		return fmt.Errorf("Error terminating instances: %s", err)
+	}
+
+	// Wait for the instances to be gone, since they keep the VPCs and
+	// security groups they're in from being removed.
+	for _, id := range ids {
+		stateConf := &resource.StateChangeConf{
+			Pending:    []string{"pending", "running", "shutting-down", "stopped", "stopping"},
+			Target:     "terminated",
+			Refresh:    InstanceStateRefreshFunc(conn, *id),
+			Timeout:    10 * time.Minute,
+			Delay:      10 * time.Second,
+			MinTimeout: 3 * time.Second,
+		}
+		if _, err := stateConf.WaitForState(); err != nil {
+			return fmt.Errorf(
+				"Error waiting for instance (%s) to terminate: %s", *id, err)
+		}
+	}
+
+	return nil
+}
+
 func TestAccAWSInstance_normal(t *testing.T) {
 	var v ec2.Instance
 	var vol *ec2.Volume
@@ -184,6 +256,66 @@ func TestAccAWSInstance_blockDevices(t *testing.T) {
 	})
 }
 
+func TestAccAWSInstance_rootBlockDeviceResize(t *testing.T) {
+	var before, after ec2.Instance
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckInstanceDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccInstanceConfigRootBlockDevice,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckInstanceExists("aws_instance.foo", &before),
+					resource.TestCheckResourceAttr(
+						"aws_instance.foo", "root_block_device.0.volume_size", "11"),
+					resource.TestCheckResourceAttr(
+						"aws_instance.foo", "root_block_device.0.volume_type", "standard"),
+				),
+			},
+
+			resource.TestStep{
+				Config: testAccInstanceConfigRootBlockDeviceGrow,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckInstanceExists("aws_instance.foo", &after),
+					resource.TestCheckResourceAttr(
+						"aws_instance.foo", "root_block_device.0.volume_size", "15"),
+					resource.TestCheckResourceAttr(
+						"aws_instance.foo", "root_block_device.0.volume_type", "gp2"),
+					func(*terraform.State) error {
+						if *before.InstanceID != *after.InstanceID {
+							return fmt.Errorf(
+								"instance was replaced: %s != %s",
+								*before.InstanceID, *after.InstanceID)
+						}
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
+
+func TestRootVolumeSizeForceNew(t *testing.T) {
+	cases := []struct {
+		Old, New string
+		Result   bool
+	}{
+		{"8", "8", false},
+		{"8", "10", false},
+		{"10", "8", true},
+		{"", "8", false},
+		{"8", "", false},
+	}
+
+	for i, tc := range cases {
+		if actual := rootVolumeSizeForceNew(tc.Old, tc.New); actual != tc.Result {
+			t.Fatalf("%d: bad: %t", i, actual)
+		}
+	}
+}
+
 func TestAccAWSInstance_sourceDestCheck(t *testing.T) {
 	var v ec2.Instance
 
@@ -248,6 +380,26 @@ func TestAccAWSInstance_vpc(t *testing.T) {
 	})
 }
 
+func TestAccAWSInstance_import(t *testing.T) {
+	var v ec2.Instance
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckInstanceDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccInstanceConfigVPC,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckInstanceExists(
+						"aws_instance.foo", &v),
+					testAccCheckInstanceImport("aws_instance.foo"),
+				),
+			},
+		},
+	})
+}
+
 func TestAccAWSInstance_multipleRegions(t *testing.T) {
 	var v ec2.Instance
 
@@ -381,6 +533,175 @@ func TestAccAWSInstance_privateIP(t *testing.T) {
 	})
 }
 
+func TestAccAWSInstance_secondaryPrivateIPs(t *testing.T) {
+	var v ec2.Instance
+
+	testCheckSecondaryPrivateIPs := func(expected ...string) resource.TestCheckFunc {
+		return func(*terraform.State) error {
+			actual := instanceSecondaryPrivateIPs(&v)
+			sort.Strings(actual)
+			if !reflect.DeepEqual(actual, expected) {
+				return fmt.Errorf("bad secondary private IPs: %#v", actual)
+			}
+
+			return nil
+		}
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckInstanceDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccInstanceConfigSecondaryPrivateIPs,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckInstanceExists("aws_instance.foo", &v),
+					testCheckSecondaryPrivateIPs("10.1.1.43", "10.1.1.44"),
+					resource.TestCheckResourceAttr(
+						"aws_instance.foo", "secondary_private_ips.#", "2"),
+				),
+			},
+
+			resource.TestStep{
+				Config: testAccInstanceConfigSecondaryPrivateIPsUpdate,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckInstanceExists("aws_instance.foo", &v),
+					testCheckSecondaryPrivateIPs("10.1.1.44", "10.1.1.45"),
+					resource.TestCheckResourceAttr(
+						"aws_instance.foo", "secondary_private_ips.#", "2"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccAWSInstance_instanceState(t *testing.T) {
+	var v ec2.Instance
+
+	testCheckState := func(state string) resource.TestCheckFunc {
+		return func(*terraform.State) error {
+			if *v.State.Name != state {
+				return fmt.Errorf("bad instance state: %s", *v.State.Name)
+			}
+
+			return nil
+		}
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckInstanceDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccInstanceConfigInstanceStateStopped,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckInstanceExists(
+						"aws_instance.foo", &v),
+					testCheckState("stopped"),
+					resource.TestCheckResourceAttr(
+						"aws_instance.foo", "instance_state", "stopped"),
+				),
+			},
+
+			resource.TestStep{
+				Config: testAccInstanceConfigInstanceStateRunning,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckInstanceExists(
+						"aws_instance.foo", &v),
+					testCheckState("running"),
+					resource.TestCheckResourceAttr(
+						"aws_instance.foo", "instance_state", "running"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccAWSInstance_changeInstanceType(t *testing.T) {
+	var before, after ec2.Instance
+
+	testCheckType := func(v *ec2.Instance, instanceType string) resource.TestCheckFunc {
+		return func(*terraform.State) error {
+			if *v.InstanceType != instanceType {
+				return fmt.Errorf("bad instance type: %s", *v.InstanceType)
+			}
+			if *v.State.Name != "running" {
+				return fmt.Errorf("bad instance state: %s", *v.State.Name)
+			}
+
+			return nil
+		}
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckInstanceDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccInstanceConfigInstanceType("t2.micro"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckInstanceExists(
+						"aws_instance.foo", &before),
+					testCheckType(&before, "t2.micro"),
+				),
+			},
+
+			resource.TestStep{
+				Config: testAccInstanceConfigInstanceType("t2.small"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckInstanceExists(
+						"aws_instance.foo", &after),
+					testCheckType(&after, "t2.small"),
+					func(*terraform.State) error {
+						if *before.InstanceID != *after.InstanceID {
+							return fmt.Errorf("instance was replaced")
+						}
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
+
+func TestAccAWSInstance_spot(t *testing.T) {
+	var v ec2.Instance
+
+	testCheckSpot := func() resource.TestCheckFunc {
+		return func(*terraform.State) error {
+			if v.SpotInstanceRequestID == nil {
+				return fmt.Errorf("not a spot instance")
+			}
+			if *v.InstanceLifecycle != "spot" {
+				return fmt.Errorf("bad lifecycle: %s", *v.InstanceLifecycle)
+			}
+
+			return nil
+		}
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckInstanceDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccInstanceConfigSpot,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckInstanceExists(
+						"aws_instance.foo", &v),
+					testCheckSpot(),
+					resource.TestCheckResourceAttr(
+						"aws_instance.foo", "spot_request_state", "active"),
+				),
+			},
+		},
+	})
+}
+
 func TestAccAWSInstance_associatePublicIPAndPrivateIP(t *testing.T) {
 	var v ec2.Instance
 
@@ -498,6 +819,36 @@ func testAccCheckInstanceExistsWithProviders(n string, i *ec2.Instance, provider
 	}
 }
 
+// testAccCheckInstanceImport checks that importing the instance by its ID
+// results in the same state as creating it.
+func testAccCheckInstanceImport(n string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		imported, err := testAccProvider.ImportState(
+			&terraform.InstanceInfo{Id: n, Type: rs.Type}, rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+		if imported == nil {
+			return fmt.Errorf("Instance not imported")
+		}
+
+		for _, k := range []string{"ami", "instance_type", "subnet_id", "private_ip"} {
+			if imported.Attributes[k] != rs.Primary.Attributes[k] {
+				return fmt.Errorf(
+					"Imported %s is %q, expected %q",
+					k, imported.Attributes[k], rs.Primary.Attributes[k])
+			}
+		}
+
+		return nil
+	}
+}
+
 func TestInstanceTenancySchema(t *testing.T) {
 	actualSchema := resourceAwsInstance().Schema["tenancy"]
 	expectedSchema := &schema.Schema{
@@ -713,6 +1064,108 @@ resource "aws_instance" "foo" {
 }
 `
 
+const testAccInstanceConfigSecondaryPrivateIPs = `
+resource "aws_vpc" "foo" {
+	cidr_block = "10.1.0.0/16"
+}
+
+resource "aws_subnet" "foo" {
+	cidr_block = "10.1.1.0/24"
+	vpc_id = "${aws_vpc.foo.id}"
+}
+
+resource "aws_instance" "foo" {
+	ami = "ami-c5eabbf5"
+	instance_type = "t2.micro"
+	subnet_id = "${aws_subnet.foo.id}"
+	private_ip = "10.1.1.42"
+	secondary_private_ips = ["10.1.1.43", "10.1.1.44"]
+}
+`
+
+const testAccInstanceConfigSecondaryPrivateIPsUpdate = `
+resource "aws_vpc" "foo" {
+	cidr_block = "10.1.0.0/16"
+}
+
+resource "aws_subnet" "foo" {
+	cidr_block = "10.1.1.0/24"
+	vpc_id = "${aws_vpc.foo.id}"
+}
+
+resource "aws_instance" "foo" {
+	ami = "ami-c5eabbf5"
+	instance_type = "t2.micro"
+	subnet_id = "${aws_subnet.foo.id}"
+	private_ip = "10.1.1.42"
+	secondary_private_ips = ["10.1.1.44", "10.1.1.45"]
+}
+`
+
+const testAccInstanceConfigInstanceStateStopped = `
+resource "aws_vpc" "foo" {
+	cidr_block = "10.1.0.0/16"
+}
+
+resource "aws_subnet" "foo" {
+	cidr_block = "10.1.1.0/24"
+	vpc_id = "${aws_vpc.foo.id}"
+}
+
+resource "aws_instance" "foo" {
+	ami = "ami-c5eabbf5"
+	instance_type = "t2.micro"
+	subnet_id = "${aws_subnet.foo.id}"
+	instance_state = "stopped"
+}
+`
+
+const testAccInstanceConfigInstanceStateRunning = `
+resource "aws_vpc" "foo" {
+	cidr_block = "10.1.0.0/16"
+}
+
+resource "aws_subnet" "foo" {
+	cidr_block = "10.1.1.0/24"
+	vpc_id = "${aws_vpc.foo.id}"
+}
+
+resource "aws_instance" "foo" {
+	ami = "ami-c5eabbf5"
+	instance_type = "t2.micro"
+	subnet_id = "${aws_subnet.foo.id}"
+	instance_state = "running"
+}
+`
+
+func testAccInstanceConfigInstanceType(instanceType string) string {
+	return fmt.Sprintf(`
+resource "aws_vpc" "foo" {
+	cidr_block = "10.1.0.0/16"
+}
+
+resource "aws_subnet" "foo" {
+	cidr_block = "10.1.1.0/24"
+	vpc_id = "${aws_vpc.foo.id}"
+}
+
+resource "aws_instance" "foo" {
+	ami = "ami-c5eabbf5"
+	instance_type = "%s"
+	subnet_id = "${aws_subnet.foo.id}"
+}
+`, instanceType)
+}
+
+const testAccInstanceConfigSpot = `
+resource "aws_instance" "foo" {
+	# us-west-2
+	ami = "ami-4fccb37f"
+	instance_type = "m1.small"
+	spot_price = "0.05"
+}
+`
+
 const testAccInstanceConfigAssociatePublicIPAndPrivateIP = `
 resource "aws_vpc" "foo" {
 	cidr_block = "10.1.0.0/16"
@@ -822,3 +1275,29 @@ resource "aws_eip" "foo_eip" {
 	depends_on = ["aws_internet_gateway.gw"]
 }
 `
+
+const testAccInstanceConfigRootBlockDevice = `
+resource "aws_instance" "foo" {
+	# us-west-2
+	ami = "ami-55a7ea65"
+	instance_type = "m3.medium"
+
+	root_block_device {
+		volume_type = "standard"
+		volume_size = 11
+	}
+}
+`
+
+const testAccInstanceConfigRootBlockDeviceGrow = `
+resource "aws_instance" "foo" {
+	# us-west-2
+	ami = "ami-55a7ea65"
+	instance_type = "m3.medium"
+
+	root_block_device {
+		volume_type = "gp2"
+		volume_size = 15
+	}
+}
+`
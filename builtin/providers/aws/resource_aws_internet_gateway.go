@@ -171,6 +171,7 @@ func resourceAwsInternetGatewayAttach(d *schema.ResourceData, meta interface{})
 		Target:  "available",
 		Refresh: IGAttachStateRefreshFunc(conn, d.Id(), "available"),
 		Timeout: 1 * time.Minute,
+		StopCh:  d.StopCh(),
 	}
 	if _, err := stateConf.WaitForState(); err != nil {
 		return fmt.Errorf(
@@ -207,6 +208,7 @@ func resourceAwsInternetGatewayDetach(d *schema.ResourceData, meta interface{})
 		Refresh: detachIGStateRefreshFunc(conn, d.Id(), vpcID.(string)),
 		Timeout: 2 * time.Minute,
 		Delay:   10 * time.Second,
+		StopCh:  d.StopCh(),
 	}
 	if _, err := stateConf.WaitForState(); err != nil {
 		return fmt.Errorf(
@@ -1,7 +1,15 @@
 package aws
 
 import (
+	"crypto/md5"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/binary"
 	"fmt"
+	"log"
+	"math/big"
+	"strings"
 
 	"github.com/hashicorp/terraform/helper/resource"
 	"github.com/hashicorp/terraform/helper/schema"
@@ -51,10 +59,47 @@ func resourceAwsKeyPairCreate(d *schema.ResourceData, meta interface{}) error {
 	}
 	resp, err := conn.ImportKeyPair(req)
 	if err != nil {
-		return fmt.Errorf("Error import KeyPair: %s", err)
+		awsErr, ok := err.(aws.APIError)
+		if !ok || awsErr.Code != "InvalidKeyPair.Duplicate" {
+			return fmt.Errorf("Error import KeyPair: %s", err)
+		}
+
+		// A key pair with this name already exists. If it holds the same
+		// key, adopt it instead of failing.
+		if err := resourceAwsKeyPairAdopt(conn, keyName, publicKey); err != nil {
+			return err
+		}
+		log.Printf("[INFO] Key pair %s already exists with the same key, adopting it", keyName)
+
+		d.SetId(keyName)
+		return resourceAwsKeyPairRead(d, meta)
 	}
 
 	d.SetId(*resp.KeyName)
+	return resourceAwsKeyPairRead(d, meta)
+}
+
+// resourceAwsKeyPairAdopt returns an error unless the existing key pair
+// with the given name holds the given public key.
+func resourceAwsKeyPairAdopt(conn *ec2.EC2, keyName, publicKey string) error {
+	fingerprint, ok := keyPairFingerprint(publicKey)
+	if !ok {
+		return fmt.Errorf(
+			"Key pair %s already exists, and the fingerprint of public_key "+
+				"can't be computed to check that it holds the same key", keyName)
+	}
+
+	resp, err := conn.DescribeKeyPairs(&ec2.DescribeKeyPairsInput{
+		KeyNames: []*string{aws.String(keyName)},
+	})
+	if err != nil {
+		return fmt.Errorf("Error retrieving KeyPair: %s", err)
+	}
+	if len(resp.KeyPairs) != 1 || *resp.KeyPairs[0].KeyFingerprint != fingerprint {
+		return fmt.Errorf(
+			"Key pair %s already exists with a different public key", keyName)
+	}
+
 	return nil
 }
 
@@ -65,6 +110,11 @@ func resourceAwsKeyPairRead(d *schema.ResourceData, meta interface{}) error {
 	}
 	resp, err := conn.DescribeKeyPairs(req)
 	if err != nil {
+		awsErr, ok := err.(aws.APIError)
+		if ok && awsErr.Code == "InvalidKeyPair.NotFound" {
+			d.SetId("")
+			return nil
+		}
 		return fmt.Errorf("Error retrieving KeyPair: %s", err)
 	}
 
@@ -72,6 +122,18 @@ func resourceAwsKeyPairRead(d *schema.ResourceData, meta interface{}) error {
 		if *keyPair.KeyName == d.Id() {
 			d.Set("key_name", keyPair.KeyName)
 			d.Set("fingerprint", keyPair.KeyFingerprint)
+
+			// AWS doesn't return the public key, so the only way to tell
+			// that the key pair no longer holds the configured key is by
+			// its fingerprint. Clearing public_key forces a new key pair.
+			fingerprint, ok := keyPairFingerprint(d.Get("public_key").(string))
+			if ok && fingerprint != *keyPair.KeyFingerprint {
+				log.Printf(
+					"[WARN] Key pair %s has fingerprint %s, expected %s",
+					d.Id(), *keyPair.KeyFingerprint, fingerprint)
+				d.Set("public_key", "")
+			}
+
 			return nil
 		}
 	}
@@ -87,3 +149,57 @@ func resourceAwsKeyPairDelete(d *schema.ResourceData, meta interface{}) error {
 	})
 	return err
 }
+
+// keyPairFingerprint returns the fingerprint that AWS computes for an
+// imported public key: the MD5 hash of the DER encoded public key. It
+// returns false if the key isn't an RSA key in the OpenSSH format, the
+// only format it is computed for.
+func keyPairFingerprint(publicKey string) (string, bool) {
+	fields := strings.Fields(publicKey)
+	if len(fields) < 2 || fields[0] != "ssh-rsa" {
+		return "", false
+	}
+
+	blob, err := base64.StdEncoding.DecodeString(fields[1])
+	if err != nil {
+		return "", false
+	}
+
+	// The key is the name of its type, the exponent and the modulus, each
+	// prefixed with its length.
+	var parts [][]byte
+	for len(blob) > 0 {
+		if len(blob) < 4 {
+			return "", false
+		}
+		n := binary.BigEndian.Uint32(blob)
+		if uint32(len(blob)-4) < n {
+			return "", false
+		}
+		parts = append(parts, blob[4:4+n])
+		blob = blob[4+n:]
+	}
+	if len(parts) != 3 || string(parts[0]) != "ssh-rsa" {
+		return "", false
+	}
+
+	e := new(big.Int).SetBytes(parts[1])
+	if e.BitLen() > 31 {
+		return "", false
+	}
+	der, err := x509.MarshalPKIXPublicKey(&rsa.PublicKey{
+		E: int(e.Int64()),
+		N: new(big.Int).SetBytes(parts[2]),
+	})
+	if err != nil {
+		return "", false
+	}
+
+	sum := md5.Sum(der)
+	hex := make([]string, len(sum))
+	for i, b := range sum {
+		hex[i] = fmt.Sprintf("%02x", b)
+	}
+
+	return strings.Join(hex, ":"), true
+}
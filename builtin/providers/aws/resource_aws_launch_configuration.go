@@ -25,9 +25,16 @@ func resourceAwsLaunchConfiguration() *schema.Resource {
 
 		Schema: map[string]*schema.Schema{
 			"name": &schema.Schema{
+				Type:          schema.TypeString,
+				Optional:      true,
+				Computed:      true,
+				ForceNew:      true,
+				ConflictsWith: []string{"name_prefix"},
+			},
+
+			"name_prefix": &schema.Schema{
 				Type:     schema.TypeString,
 				Optional: true,
-				Computed: true,
 				ForceNew: true,
 			},
 
@@ -362,9 +369,14 @@ func resourceAwsLaunchConfigurationCreate(d *schema.ResourceData, meta interface
 		createLaunchConfigurationOpts.BlockDeviceMappings = blockDevices
 	}
 
+	// Launch configurations can't be modified, so they are replaced on
+	// every change. Unless a name is given, it is generated, so that the
+	// new one can be created before the old one is destroyed.
 	var lcName string
 	if v, ok := d.GetOk("name"); ok {
 		lcName = v.(string)
+	} else if v, ok := d.GetOk("name_prefix"); ok {
+		lcName = resource.PrefixedUniqueId(v.(string))
 	} else {
 		lcName = resource.UniqueId()
 	}
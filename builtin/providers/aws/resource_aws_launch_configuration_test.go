@@ -80,6 +80,26 @@ func TestAccAWSLaunchConfiguration_withGeneratedName(t *testing.T) {
 	})
 }
 
+func TestAccAWSLaunchConfiguration_withNamePrefix(t *testing.T) {
+	var conf autoscaling.LaunchConfiguration
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAWSLaunchConfigurationDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccAWSLaunchConfigurationNamePrefixConfig,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSLaunchConfigurationExists("aws_launch_configuration.bar", &conf),
+					testAccCheckAWSLaunchConfigurationGeneratedNamePrefix(
+						"aws_launch_configuration.bar", "tf-acc-test-"),
+				),
+			},
+		},
+	})
+}
+
 func testAccCheckAWSLaunchConfigurationGeneratedNamePrefix(
 	resource, prefix string) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
@@ -254,3 +274,17 @@ resource "aws_launch_configuration" "bar" {
    associate_public_ip_address = false
 }
 `
+
+const testAccAWSLaunchConfigurationNamePrefixConfig = `
+resource "aws_launch_configuration" "bar" {
+   name_prefix = "tf-acc-test-"
+   image_id = "ami-21f78e11"
+   instance_type = "t1.micro"
+   user_data = "foobar-user-data-change"
+   associate_public_ip_address = false
+
+   lifecycle {
+      create_before_destroy = true
+   }
+}
+`
@@ -164,6 +164,7 @@ func resourceAwsRoute53RecordCreate(d *schema.ResourceData, meta interface{}) er
 
 			return resp, "accepted", nil
 		},
+		StopCh: d.StopCh(),
 	}
 
 	respRaw, err := wait.WaitForState()
@@ -196,6 +197,7 @@ func resourceAwsRoute53RecordCreate(d *schema.ResourceData, meta interface{}) er
 			}
 			return resourceAwsGoRoute53Wait(conn, changeRequest)
 		},
+		StopCh: d.StopCh(),
 	}
 	_, err = wait.WaitForState()
 	if err != nil {
@@ -325,6 +327,7 @@ func resourceAwsRoute53RecordDelete(d *schema.ResourceData, meta interface{}) er
 
 			return 42, "accepted", nil
 		},
+		StopCh: d.StopCh(),
 	}
 
 	if _, err := wait.WaitForState(); err != nil {
@@ -28,6 +28,19 @@ func resourceAwsRoute53Zone() *schema.Resource {
 				ForceNew: true,
 			},
 
+			"vpc_id": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+
+			"vpc_region": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+				Computed: true,
+			},
+
 			"zone_id": &schema.Schema{
 				Type:     schema.TypeString,
 				Computed: true,
@@ -54,6 +67,22 @@ func resourceAwsRoute53ZoneCreate(d *schema.ResourceData, meta interface{}) erro
 		CallerReference:  aws.String(time.Now().Format(time.RFC3339Nano)),
 	}
 
+	// A zone that is created with a VPC is a private zone, which is only
+	// visible from the VPCs it is associated with.
+	if v := d.Get("vpc_id"); v != "" {
+		region := meta.(*AWSClient).region
+		if v := d.Get("vpc_region"); v != "" {
+			region = v.(string)
+		}
+		d.Set("vpc_region", region)
+
+		req.VPC = &route53.VPC{
+			VPCID:     aws.String(v.(string)),
+			VPCRegion: aws.String(region),
+		}
+		comment.PrivateZone = aws.Boolean(true)
+	}
+
 	log.Printf("[DEBUG] Creating Route53 hosted zone: %s", *req.Name)
 	resp, err := r53.CreateHostedZone(req)
 	if err != nil {
@@ -78,6 +107,7 @@ func resourceAwsRoute53ZoneCreate(d *schema.ResourceData, meta interface{}) erro
 			}
 			return resourceAwsGoRoute53Wait(r53, changeRequest)
 		},
+		StopCh: d.StopCh(),
 	}
 	_, err = wait.WaitForState()
 	if err != nil {
@@ -98,15 +128,43 @@ func resourceAwsRoute53ZoneRead(d *schema.ResourceData, meta interface{}) error
 		return err
 	}
 
-	ns := make([]string, len(zone.DelegationSet.NameServers))
-	for i := range zone.DelegationSet.NameServers {
-		ns[i] = *zone.DelegationSet.NameServers[i]
+	var ns []string
+	if zone.DelegationSet != nil {
+		ns = make([]string, len(zone.DelegationSet.NameServers))
+		for i := range zone.DelegationSet.NameServers {
+			ns[i] = *zone.DelegationSet.NameServers[i]
+		}
+	} else {
+		// Private zones have no delegation set, but they have the name
+		// servers that resolve them in their NS record, as other zones.
+		ns, err = getNameServers(d.Id(), *zone.HostedZone.Name, r53)
+		if err != nil {
+			return err
+		}
 	}
 	sort.Strings(ns)
 	if err := d.Set("name_servers", ns); err != nil {
 		return fmt.Errorf("[DEBUG] Error setting name servers for: %s, error: %#v", d.Id(), err)
 	}
 
+	// If the zone is private, make sure it is still associated with its
+	// VPC, and store another one of its VPCs otherwise.
+	if zone.HostedZone.Config != nil && zone.HostedZone.Config.PrivateZone != nil &&
+		*zone.HostedZone.Config.PrivateZone {
+		var associated bool
+		for _, vpc := range zone.VPCs {
+			if vpc.VPCID != nil && *vpc.VPCID == d.Get("vpc_id").(string) {
+				associated = true
+				d.Set("vpc_region", vpc.VPCRegion)
+				break
+			}
+		}
+		if !associated && len(zone.VPCs) > 0 {
+			d.Set("vpc_id", zone.VPCs[0].VPCID)
+			d.Set("vpc_region", zone.VPCs[0].VPCRegion)
+		}
+	}
+
 	// get tags
 	req := &route53.ListTagsForResourceInput{
 		ResourceID:   aws.String(d.Id()),
@@ -164,6 +222,34 @@ func resourceAwsGoRoute53Wait(r53 *route53.Route53, ref *route53.GetChangeInput)
 	return true, *status.ChangeInfo.Status, nil
 }
 
+// getNameServers returns the name servers of the NS record of the zone
+// with the given ID and name.
+func getNameServers(zoneId, zoneName string, r53 *route53.Route53) ([]string, error) {
+	resp, err := r53.ListResourceRecordSets(&route53.ListResourceRecordSetsInput{
+		HostedZoneID:    aws.String(zoneId),
+		StartRecordName: aws.String(zoneName),
+		StartRecordType: aws.String("NS"),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var ns []string
+	for _, rs := range resp.ResourceRecordSets {
+		if *rs.Type != "NS" ||
+			strings.TrimSuffix(*rs.Name, ".") != strings.TrimSuffix(zoneName, ".") {
+			continue
+		}
+
+		for _, r := range rs.ResourceRecords {
+			ns = append(ns, *r.Value)
+		}
+		break
+	}
+
+	return ns, nil
+}
+
 // cleanChangeID is used to remove the leading /change/
 func cleanChangeID(ID string) string {
 	return cleanPrefix(ID, "/change/")
@@ -0,0 +1,155 @@
+package aws
+
+import (
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
+
+	"github.com/awslabs/aws-sdk-go/aws"
+	"github.com/awslabs/aws-sdk-go/service/route53"
+)
+
+func resourceAwsRoute53ZoneAssociation() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceAwsRoute53ZoneAssociationCreate,
+		Read:   resourceAwsRoute53ZoneAssociationRead,
+		Delete: resourceAwsRoute53ZoneAssociationDelete,
+
+		Schema: map[string]*schema.Schema{
+			"zone_id": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"vpc_id": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"vpc_region": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+		},
+	}
+}
+
+func resourceAwsRoute53ZoneAssociationCreate(d *schema.ResourceData, meta interface{}) error {
+	r53 := meta.(*AWSClient).r53conn
+
+	region := meta.(*AWSClient).region
+	if v, ok := d.GetOk("vpc_region"); ok {
+		region = v.(string)
+	}
+
+	req := &route53.AssociateVPCWithHostedZoneInput{
+		HostedZoneID: aws.String(d.Get("zone_id").(string)),
+		VPC: &route53.VPC{
+			VPCID:     aws.String(d.Get("vpc_id").(string)),
+			VPCRegion: aws.String(region),
+		},
+		Comment: aws.String("Managed by Terraform"),
+	}
+
+	log.Printf("[DEBUG] Associating Route53 Private Zone %s with VPC %s",
+		*req.HostedZoneID, *req.VPC.VPCID)
+	resp, err := r53.AssociateVPCWithHostedZone(req)
+	if err != nil {
+		return fmt.Errorf("Error associating Route53 zone with VPC: %s", err)
+	}
+
+	// Store the association ID
+	d.SetId(fmt.Sprintf("%s:%s", *req.HostedZoneID, *req.VPC.VPCID))
+	d.Set("vpc_region", region)
+
+	// Wait until we are done initializing
+	wait := resource.StateChangeConf{
+		Delay:      30 * time.Second,
+		Pending:    []string{"PENDING"},
+		Target:     "INSYNC",
+		Timeout:    10 * time.Minute,
+		MinTimeout: 2 * time.Second,
+		Refresh: func() (result interface{}, state string, err error) {
+			changeRequest := &route53.GetChangeInput{
+				ID: aws.String(cleanChangeID(*resp.ChangeInfo.ID)),
+			}
+			return resourceAwsGoRoute53Wait(r53, changeRequest)
+		},
+		StopCh: d.StopCh(),
+	}
+	_, err = wait.WaitForState()
+	if err != nil {
+		return err
+	}
+
+	return resourceAwsRoute53ZoneAssociationRead(d, meta)
+}
+
+func resourceAwsRoute53ZoneAssociationRead(d *schema.ResourceData, meta interface{}) error {
+	r53 := meta.(*AWSClient).r53conn
+	zoneId, vpcId := resourceAwsRoute53ZoneAssociationParseId(d.Id())
+
+	zone, err := r53.GetHostedZone(&route53.GetHostedZoneInput{ID: aws.String(zoneId)})
+	if err != nil {
+		// Handle a deleted zone
+		if r53err, ok := err.(aws.APIError); ok && r53err.Code == "NoSuchHostedZone" {
+			d.SetId("")
+			return nil
+		}
+		return err
+	}
+
+	for _, vpc := range zone.VPCs {
+		if vpc.VPCID != nil && *vpc.VPCID == vpcId {
+			d.Set("zone_id", zoneId)
+			d.Set("vpc_id", vpcId)
+			d.Set("vpc_region", vpc.VPCRegion)
+			return nil
+		}
+	}
+
+	// The association is gone
+	d.SetId("")
+	return nil
+}
+
+func resourceAwsRoute53ZoneAssociationDelete(d *schema.ResourceData, meta interface{}) error {
+	r53 := meta.(*AWSClient).r53conn
+	zoneId, vpcId := resourceAwsRoute53ZoneAssociationParseId(d.Id())
+
+	req := &route53.DisassociateVPCFromHostedZoneInput{
+		HostedZoneID: aws.String(zoneId),
+		VPC: &route53.VPC{
+			VPCID:     aws.String(vpcId),
+			VPCRegion: aws.String(d.Get("vpc_region").(string)),
+		},
+		Comment: aws.String("Managed by Terraform"),
+	}
+
+	log.Printf("[DEBUG] Disassociating Route53 Private Zone %s from VPC %s",
+		zoneId, vpcId)
+	if _, err := r53.DisassociateVPCFromHostedZone(req); err != nil {
+		return fmt.Errorf("Error disassociating Route53 zone from VPC: %s", err)
+	}
+
+	return nil
+}
+
+// resourceAwsRoute53ZoneAssociationParseId returns the zone ID and the
+// VPC ID of the ID of an association, which is "ZONE:VPC".
+func resourceAwsRoute53ZoneAssociationParseId(id string) (string, string) {
+	parts := strings.SplitN(id, ":", 2)
+	if len(parts) != 2 {
+		return id, ""
+	}
+
+	return parts[0], parts[1]
+}
@@ -0,0 +1,124 @@
+package aws
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+
+	"github.com/awslabs/aws-sdk-go/aws"
+	"github.com/awslabs/aws-sdk-go/service/route53"
+)
+
+func TestAccRoute53ZoneAssociation(t *testing.T) {
+	var zone route53.HostedZone
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckRoute53ZoneAssociationDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccRoute53ZoneAssociationConfig,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckRoute53ZoneAssociationExists("aws_route53_zone_association.foobar", &zone),
+				),
+			},
+		},
+	})
+}
+
+func TestResourceAwsRoute53ZoneAssociationParseId(t *testing.T) {
+	cases := []struct {
+		Input, Zone, VPC string
+	}{
+		{"Z1:vpc-1", "Z1", "vpc-1"},
+		{"Z1", "Z1", ""},
+	}
+
+	for i, tc := range cases {
+		zone, vpc := resourceAwsRoute53ZoneAssociationParseId(tc.Input)
+		if zone != tc.Zone || vpc != tc.VPC {
+			t.Fatalf("%d: bad: %s, %s", i, zone, vpc)
+		}
+	}
+}
+
+func testAccCheckRoute53ZoneAssociationDestroy(s *terraform.State) error {
+	conn := testAccProvider.Meta().(*AWSClient).r53conn
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "aws_route53_zone_association" {
+			continue
+		}
+
+		zoneId, vpcId := resourceAwsRoute53ZoneAssociationParseId(rs.Primary.ID)
+		resp, err := conn.GetHostedZone(&route53.GetHostedZoneInput{ID: aws.String(zoneId)})
+		if err != nil {
+			if r53err, ok := err.(aws.APIError); ok && r53err.Code == "NoSuchHostedZone" {
+				continue
+			}
+			return err
+		}
+
+		for _, vpc := range resp.VPCs {
+			if *vpc.VPCID == vpcId {
+				return fmt.Errorf("VPC %s is still associated with zone %s", vpcId, zoneId)
+			}
+		}
+	}
+	return nil
+}
+
+func testAccCheckRoute53ZoneAssociationExists(n string, zone *route53.HostedZone) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No zone association ID is set")
+		}
+
+		zoneId, vpcId := resourceAwsRoute53ZoneAssociationParseId(rs.Primary.ID)
+		conn := testAccProvider.Meta().(*AWSClient).r53conn
+		resp, err := conn.GetHostedZone(&route53.GetHostedZoneInput{ID: aws.String(zoneId)})
+		if err != nil {
+			return fmt.Errorf("Hosted zone err: %v", err)
+		}
+
+		for _, vpc := range resp.VPCs {
+			if *vpc.VPCID == vpcId {
+				*zone = *resp.HostedZone
+				return nil
+			}
+		}
+
+		return fmt.Errorf("VPC %s is not associated with zone %s", vpcId, zoneId)
+	}
+}
+
+const testAccRoute53ZoneAssociationConfig = `
+resource "aws_vpc" "foo" {
+	cidr_block = "10.6.0.0/16"
+	enable_dns_hostnames = true
+	enable_dns_support = true
+}
+
+resource "aws_vpc" "bar" {
+	cidr_block = "10.7.0.0/16"
+	enable_dns_hostnames = true
+	enable_dns_support = true
+}
+
+resource "aws_route53_zone" "foo" {
+	name = "foo.com"
+	vpc_id = "${aws_vpc.foo.id}"
+}
+
+resource "aws_route53_zone_association" "foobar" {
+	zone_id = "${aws_route53_zone.foo.id}"
+	vpc_id  = "${aws_vpc.bar.id}"
+}
+`
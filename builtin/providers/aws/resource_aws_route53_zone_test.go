@@ -84,6 +84,27 @@ func TestAccRoute53Zone(t *testing.T) {
 	})
 }
 
+func TestAccRoute53PrivateZone(t *testing.T) {
+	var zone route53.HostedZone
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckRoute53ZoneDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccRoute53PrivateZoneConfig,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckRoute53ZoneExists("aws_route53_zone.main", &zone),
+					testAccCheckRoute53ZoneAssociatesWithVpc("aws_vpc.main", &zone),
+					resource.TestCheckResourceAttr(
+						"aws_route53_zone.main", "vpc_region", "us-west-2"),
+				),
+			},
+		},
+	})
+}
+
 func testAccCheckRoute53ZoneDestroy(s *terraform.State) error {
 	conn := testAccProvider.Meta().(*AWSClient).r53conn
 	for _, rs := range s.RootModule().Resources {
@@ -116,16 +137,19 @@ func testAccCheckRoute53ZoneExists(n string, zone *route53.HostedZone) resource.
 			return fmt.Errorf("Hosted zone err: %v", err)
 		}
 
-		sorted_ns := make([]string, len(resp.DelegationSet.NameServers))
-		for i, ns := range resp.DelegationSet.NameServers {
-			sorted_ns[i] = *ns
-		}
-		sort.Strings(sorted_ns)
-		for idx, ns := range sorted_ns {
-			attribute := fmt.Sprintf("name_servers.%d", idx)
-			dsns := rs.Primary.Attributes[attribute]
-			if dsns != ns {
-				return fmt.Errorf("Got: %v for %v, Expected: %v", dsns, attribute, ns)
+		// Private zones have no delegation set
+		if resp.DelegationSet != nil {
+			sorted_ns := make([]string, len(resp.DelegationSet.NameServers))
+			for i, ns := range resp.DelegationSet.NameServers {
+				sorted_ns[i] = *ns
+			}
+			sort.Strings(sorted_ns)
+			for idx, ns := range sorted_ns {
+				attribute := fmt.Sprintf("name_servers.%d", idx)
+				dsns := rs.Primary.Attributes[attribute]
+				if dsns != ns {
+					return fmt.Errorf("Got: %v for %v, Expected: %v", dsns, attribute, ns)
+				}
 			}
 		}
 
@@ -134,6 +158,29 @@ func testAccCheckRoute53ZoneExists(n string, zone *route53.HostedZone) resource.
 	}
 }
 
+func testAccCheckRoute53ZoneAssociatesWithVpc(n string, zone *route53.HostedZone) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		conn := testAccProvider.Meta().(*AWSClient).r53conn
+		resp, err := conn.GetHostedZone(&route53.GetHostedZoneInput{ID: zone.ID})
+		if err != nil {
+			return fmt.Errorf("Hosted zone err: %v", err)
+		}
+
+		for _, vpc := range resp.VPCs {
+			if *vpc.VPCID == rs.Primary.ID {
+				return nil
+			}
+		}
+
+		return fmt.Errorf("VPC %s is not associated with zone %s", rs.Primary.ID, *zone.ID)
+	}
+}
+
 func testAccLoadTagsR53(zone *route53.HostedZone, td *route53.ResourceTagSet) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		conn := testAccProvider.Meta().(*AWSClient).r53conn
@@ -167,3 +214,15 @@ resource "aws_route53_zone" "main" {
 	}
 }
 `
+
+const testAccRoute53PrivateZoneConfig = `
+resource "aws_vpc" "main" {
+	cidr_block = "172.29.0.0/24"
+	enable_dns_hostnames = true
+}
+
+resource "aws_route53_zone" "main" {
+	name = "hashicorp.com"
+	vpc_id = "${aws_vpc.main.id}"
+}
+`
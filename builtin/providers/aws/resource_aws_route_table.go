@@ -103,6 +103,7 @@ func resourceAwsRouteTableCreate(d *schema.ResourceData, meta interface{}) error
 		Target:  "ready",
 		Refresh: resourceAwsRouteTableStateRefreshFunc(conn, d.Id()),
 		Timeout: 1 * time.Minute,
+		StopCh:  d.StopCh(),
 	}
 	if _, err := stateConf.WaitForState(); err != nil {
 		return fmt.Errorf(
@@ -332,6 +333,7 @@ func resourceAwsRouteTableDelete(d *schema.ResourceData, meta interface{}) error
 		Target:  "",
 		Refresh: resourceAwsRouteTableStateRefreshFunc(conn, d.Id()),
 		Timeout: 1 * time.Minute,
+		StopCh:  d.StopCh(),
 	}
 	if _, err := stateConf.WaitForState(); err != nil {
 		return fmt.Errorf(
@@ -31,6 +31,129 @@ func resourceAwsS3Bucket() *schema.Resource {
 				ForceNew: true,
 			},
 
+			"policy": &schema.Schema{
+				Type:      schema.TypeString,
+				Optional:  true,
+				StateFunc: normalizeJson,
+			},
+
+			"website": &schema.Schema{
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"index_document": &schema.Schema{
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+
+						"error_document": &schema.Schema{
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+
+						"redirect_all_requests_to": &schema.Schema{
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+
+						"routing_rule": &schema.Schema{
+							Type:     schema.TypeList,
+							Optional: true,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"key_prefix_equals": &schema.Schema{
+										Type:     schema.TypeString,
+										Optional: true,
+									},
+
+									"http_error_code_returned_equals": &schema.Schema{
+										Type:     schema.TypeString,
+										Optional: true,
+									},
+
+									"host_name": &schema.Schema{
+										Type:     schema.TypeString,
+										Optional: true,
+									},
+
+									"protocol": &schema.Schema{
+										Type:     schema.TypeString,
+										Optional: true,
+									},
+
+									"http_redirect_code": &schema.Schema{
+										Type:     schema.TypeString,
+										Optional: true,
+									},
+
+									"replace_key_prefix_with": &schema.Schema{
+										Type:     schema.TypeString,
+										Optional: true,
+									},
+
+									"replace_key_with": &schema.Schema{
+										Type:     schema.TypeString,
+										Optional: true,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+
+			"logging": &schema.Schema{
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"target_bucket": &schema.Schema{
+							Type:     schema.TypeString,
+							Required: true,
+						},
+
+						"target_prefix": &schema.Schema{
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+					},
+				},
+			},
+
+			"server_side_encryption_configuration": &schema.Schema{
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"sse_algorithm": &schema.Schema{
+							Type:     schema.TypeString,
+							Required: true,
+						},
+
+						"kms_master_key_id": &schema.Schema{
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+					},
+				},
+			},
+
+			"website_endpoint": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"website_domain": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"hosted_zone_id": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
 			"tags": tagsSchema(),
 		},
 	}
@@ -75,6 +198,31 @@ func resourceAwsS3BucketUpdate(d *schema.ResourceData, meta interface{}) error {
 	if err := setTagsS3(s3conn, d); err != nil {
 		return err
 	}
+
+	if d.HasChange("policy") {
+		if err := resourceAwsS3BucketPolicyUpdate(s3conn, d); err != nil {
+			return err
+		}
+	}
+
+	if d.HasChange("website") {
+		if err := resourceAwsS3BucketWebsiteUpdate(s3conn, d); err != nil {
+			return err
+		}
+	}
+
+	if d.HasChange("logging") {
+		if err := resourceAwsS3BucketLoggingUpdate(s3conn, d); err != nil {
+			return err
+		}
+	}
+
+	if d.HasChange("server_side_encryption_configuration") {
+		if err := resourceAwsS3BucketEncryptionUpdate(s3conn, d); err != nil {
+			return err
+		}
+	}
+
 	return resourceAwsS3BucketRead(d, meta)
 }
 
@@ -103,9 +251,373 @@ func resourceAwsS3BucketRead(d *schema.ResourceData, meta interface{}) error {
 		return err
 	}
 
+	// Read the policy
+	pol, err := s3conn.GetBucketPolicy(&s3.GetBucketPolicyInput{
+		Bucket: aws.String(d.Id()),
+	})
+	log.Printf("[DEBUG] S3 bucket: %s, read policy: %v", d.Id(), pol)
+	if err != nil {
+		if awsError, ok := err.(aws.APIError); !ok || awsError.Code != "NoSuchBucketPolicy" {
+			return fmt.Errorf("error reading S3 bucket \"%s\" policy: %s", d.Id(), err)
+		}
+		d.Set("policy", "")
+	} else if pol.Policy != nil {
+		d.Set("policy", normalizeJson(*pol.Policy))
+	}
+
+	// Read the website configuration
+	ws, err := s3conn.GetBucketWebsite(&s3.GetBucketWebsiteInput{
+		Bucket: aws.String(d.Id()),
+	})
+	var websites []map[string]interface{}
+	if err != nil {
+		if awsError, ok := err.(aws.APIError); !ok || awsError.Code != "NoSuchWebsiteConfiguration" {
+			return fmt.Errorf("error reading S3 bucket \"%s\" website: %s", d.Id(), err)
+		}
+	} else {
+		websites = append(websites, flattenS3Website(ws))
+	}
+	if err := d.Set("website", websites); err != nil {
+		return err
+	}
+
+	// Read the logging configuration
+	logging, err := s3conn.GetBucketLogging(&s3.GetBucketLoggingInput{
+		Bucket: aws.String(d.Id()),
+	})
+	if err != nil {
+		return fmt.Errorf("error reading S3 bucket \"%s\" logging: %s", d.Id(), err)
+	}
+	var lcl []map[string]interface{}
+	if v := logging.LoggingEnabled; v != nil {
+		lc := make(map[string]interface{})
+		if v.TargetBucket != nil {
+			lc["target_bucket"] = *v.TargetBucket
+		}
+		if v.TargetPrefix != nil {
+			lc["target_prefix"] = *v.TargetPrefix
+		}
+		lcl = append(lcl, lc)
+	}
+	if err := d.Set("logging", lcl); err != nil {
+		return err
+	}
+
+	// Read the default encryption
+	encryption, err := s3conn.GetBucketEncryption(&s3.GetBucketEncryptionInput{
+		Bucket: aws.String(d.Id()),
+	})
+	var sses []map[string]interface{}
+	if err != nil {
+		if awsError, ok := err.(aws.APIError); !ok || awsError.Code != "ServerSideEncryptionConfigurationNotFoundError" {
+			return fmt.Errorf("error reading S3 bucket \"%s\" encryption: %s", d.Id(), err)
+		}
+	} else if c := encryption.ServerSideEncryptionConfiguration; c != nil {
+		for _, rule := range c.Rules {
+			v := rule.ApplyServerSideEncryptionByDefault
+			if v == nil {
+				continue
+			}
+
+			sse := make(map[string]interface{})
+			if v.SSEAlgorithm != nil {
+				sse["sse_algorithm"] = *v.SSEAlgorithm
+			}
+			if v.KMSMasterKeyID != nil {
+				sse["kms_master_key_id"] = *v.KMSMasterKeyID
+			}
+			sses = append(sses, sse)
+		}
+	}
+	if err := d.Set("server_side_encryption_configuration", sses); err != nil {
+		return err
+	}
+
+	// Add the website endpoint, for Route53 alias records
+	if len(websites) > 0 {
+		region := meta.(*AWSClient).region
+		domain := websiteDomain(region)
+		d.Set("website_domain", domain)
+		d.Set("website_endpoint", fmt.Sprintf("%s.%s", d.Id(), domain))
+		d.Set("hosted_zone_id", websiteHostedZoneIds[region])
+	} else {
+		d.Set("website_domain", "")
+		d.Set("website_endpoint", "")
+		d.Set("hosted_zone_id", "")
+	}
+
+	return nil
+}
+
+func resourceAwsS3BucketPolicyUpdate(s3conn *s3.S3, d *schema.ResourceData) error {
+	bucket := d.Get("bucket").(string)
+	policy := d.Get("policy").(string)
+
+	if policy == "" {
+		log.Printf("[DEBUG] S3 bucket: %s, delete policy", bucket)
+		_, err := s3conn.DeleteBucketPolicy(&s3.DeleteBucketPolicyInput{
+			Bucket: aws.String(bucket),
+		})
+		if err != nil {
+			return fmt.Errorf("Error deleting S3 policy: %s", err)
+		}
+
+		return nil
+	}
+
+	log.Printf("[DEBUG] S3 bucket: %s, put policy: %s", bucket, policy)
+	_, err := s3conn.PutBucketPolicy(&s3.PutBucketPolicyInput{
+		Bucket: aws.String(bucket),
+		Policy: aws.String(policy),
+	})
+	if err != nil {
+		return fmt.Errorf("Error putting S3 policy: %s", err)
+	}
+
+	return nil
+}
+
+func resourceAwsS3BucketWebsiteUpdate(s3conn *s3.S3, d *schema.ResourceData) error {
+	bucket := d.Get("bucket").(string)
+	ws := d.Get("website").([]interface{})
+
+	switch len(ws) {
+	case 0:
+		log.Printf("[DEBUG] S3 bucket: %s, delete website", bucket)
+		_, err := s3conn.DeleteBucketWebsite(&s3.DeleteBucketWebsiteInput{
+			Bucket: aws.String(bucket),
+		})
+		if err != nil {
+			return fmt.Errorf("Error deleting S3 website: %s", err)
+		}
+
+		return nil
+	case 1:
+	default:
+		return fmt.Errorf("Cannot specify more than one website.")
+	}
+
+	config, err := expandS3Website(ws[0].(map[string]interface{}))
+	if err != nil {
+		return err
+	}
+
+	log.Printf("[DEBUG] S3 bucket: %s, put website: %#v", bucket, config)
+	_, err = s3conn.PutBucketWebsite(&s3.PutBucketWebsiteInput{
+		Bucket:               aws.String(bucket),
+		WebsiteConfiguration: config,
+	})
+	if err != nil {
+		return fmt.Errorf("Error putting S3 website: %s", err)
+	}
+
+	return nil
+}
+
+func resourceAwsS3BucketLoggingUpdate(s3conn *s3.S3, d *schema.ResourceData) error {
+	bucket := d.Get("bucket").(string)
+	logging := d.Get("logging").([]interface{})
+	if len(logging) > 1 {
+		return fmt.Errorf("Cannot specify more than one logging.")
+	}
+
+	// Logging is disabled by an empty logging status
+	status := &s3.BucketLoggingStatus{}
+	if len(logging) == 1 {
+		c := logging[0].(map[string]interface{})
+		status.LoggingEnabled = &s3.LoggingEnabled{
+			TargetBucket: aws.String(c["target_bucket"].(string)),
+			TargetPrefix: aws.String(c["target_prefix"].(string)),
+		}
+	}
+
+	log.Printf("[DEBUG] S3 bucket: %s, put logging: %#v", bucket, status)
+	_, err := s3conn.PutBucketLogging(&s3.PutBucketLoggingInput{
+		Bucket:              aws.String(bucket),
+		BucketLoggingStatus: status,
+	})
+	if err != nil {
+		return fmt.Errorf("Error putting S3 logging: %s", err)
+	}
+
+	return nil
+}
+
+func resourceAwsS3BucketEncryptionUpdate(s3conn *s3.S3, d *schema.ResourceData) error {
+	bucket := d.Get("bucket").(string)
+	sses := d.Get("server_side_encryption_configuration").([]interface{})
+
+	switch len(sses) {
+	case 0:
+		log.Printf("[DEBUG] S3 bucket: %s, delete encryption", bucket)
+		_, err := s3conn.DeleteBucketEncryption(&s3.DeleteBucketEncryptionInput{
+			Bucket: aws.String(bucket),
+		})
+		if err != nil {
+			return fmt.Errorf("Error deleting S3 encryption: %s", err)
+		}
+
+		return nil
+	case 1:
+	default:
+		return fmt.Errorf("Cannot specify more than one server_side_encryption_configuration.")
+	}
+
+	c := sses[0].(map[string]interface{})
+	sse := &s3.ServerSideEncryptionByDefault{
+		SSEAlgorithm: aws.String(c["sse_algorithm"].(string)),
+	}
+	if v := c["kms_master_key_id"].(string); v != "" {
+		sse.KMSMasterKeyID = aws.String(v)
+	}
+
+	config := &s3.ServerSideEncryptionConfiguration{
+		Rules: []*s3.ServerSideEncryptionRule{
+			&s3.ServerSideEncryptionRule{
+				ApplyServerSideEncryptionByDefault: sse,
+			},
+		},
+	}
+
+	log.Printf("[DEBUG] S3 bucket: %s, put encryption: %#v", bucket, config)
+	_, err := s3conn.PutBucketEncryption(&s3.PutBucketEncryptionInput{
+		Bucket:                            aws.String(bucket),
+		ServerSideEncryptionConfiguration: config,
+	})
+	if err != nil {
+		return fmt.Errorf("Error putting S3 encryption: %s", err)
+	}
+
 	return nil
 }
 
+// expandS3Website returns the website configuration of a website block.
+func expandS3Website(m map[string]interface{}) (*s3.WebsiteConfiguration, error) {
+	config := &s3.WebsiteConfiguration{}
+
+	if v := m["redirect_all_requests_to"].(string); v != "" {
+		if m["index_document"].(string) != "" || m["error_document"].(string) != "" ||
+			len(m["routing_rule"].([]interface{})) > 0 {
+			return nil, fmt.Errorf(
+				"website: redirect_all_requests_to can't be used with the " +
+					"other website settings")
+		}
+
+		config.RedirectAllRequestsTo = &s3.RedirectAllRequestsTo{
+			HostName: aws.String(v),
+		}
+		return config, nil
+	}
+
+	if v := m["index_document"].(string); v != "" {
+		config.IndexDocument = &s3.IndexDocument{Suffix: aws.String(v)}
+	} else {
+		return nil, fmt.Errorf(
+			"website: index_document or redirect_all_requests_to must be set")
+	}
+
+	if v := m["error_document"].(string); v != "" {
+		config.ErrorDocument = &s3.ErrorDocument{Key: aws.String(v)}
+	}
+
+	for _, raw := range m["routing_rule"].([]interface{}) {
+		r := raw.(map[string]interface{})
+
+		rule := &s3.RoutingRule{Redirect: &s3.Redirect{}}
+		if v := r["key_prefix_equals"].(string); v != "" {
+			rule.Condition = &s3.Condition{KeyPrefixEquals: aws.String(v)}
+		}
+		if v := r["http_error_code_returned_equals"].(string); v != "" {
+			if rule.Condition == nil {
+				rule.Condition = &s3.Condition{}
+			}
+			rule.Condition.HTTPErrorCodeReturnedEquals = aws.String(v)
+		}
+
+		if v := r["host_name"].(string); v != "" {
+			rule.Redirect.HostName = aws.String(v)
+		}
+		if v := r["protocol"].(string); v != "" {
+			rule.Redirect.Protocol = aws.String(v)
+		}
+		if v := r["http_redirect_code"].(string); v != "" {
+			rule.Redirect.HTTPRedirectCode = aws.String(v)
+		}
+		if v := r["replace_key_prefix_with"].(string); v != "" {
+			rule.Redirect.ReplaceKeyPrefixWith = aws.String(v)
+		}
+		if v := r["replace_key_with"].(string); v != "" {
+			rule.Redirect.ReplaceKeyWith = aws.String(v)
+		}
+
+		config.RoutingRules = append(config.RoutingRules, rule)
+	}
+
+	return config, nil
+}
+
+// flattenS3Website returns the website block of a website configuration.
+func flattenS3Website(ws *s3.GetBucketWebsiteOutput) map[string]interface{} {
+	m := make(map[string]interface{})
+	if v := ws.IndexDocument; v != nil && v.Suffix != nil {
+		m["index_document"] = *v.Suffix
+	}
+	if v := ws.ErrorDocument; v != nil && v.Key != nil {
+		m["error_document"] = *v.Key
+	}
+	if v := ws.RedirectAllRequestsTo; v != nil && v.HostName != nil {
+		m["redirect_all_requests_to"] = *v.HostName
+	}
+
+	rules := make([]map[string]interface{}, 0, len(ws.RoutingRules))
+	for _, rule := range ws.RoutingRules {
+		r := make(map[string]interface{})
+		if c := rule.Condition; c != nil {
+			if c.KeyPrefixEquals != nil {
+				r["key_prefix_equals"] = *c.KeyPrefixEquals
+			}
+			if c.HTTPErrorCodeReturnedEquals != nil {
+				r["http_error_code_returned_equals"] = *c.HTTPErrorCodeReturnedEquals
+			}
+		}
+		if rd := rule.Redirect; rd != nil {
+			if rd.HostName != nil {
+				r["host_name"] = *rd.HostName
+			}
+			if rd.Protocol != nil {
+				r["protocol"] = *rd.Protocol
+			}
+			if rd.HTTPRedirectCode != nil {
+				r["http_redirect_code"] = *rd.HTTPRedirectCode
+			}
+			if rd.ReplaceKeyPrefixWith != nil {
+				r["replace_key_prefix_with"] = *rd.ReplaceKeyPrefixWith
+			}
+			if rd.ReplaceKeyWith != nil {
+				r["replace_key_with"] = *rd.ReplaceKeyWith
+			}
+		}
+		rules = append(rules, r)
+	}
+	if len(rules) > 0 {
+		m["routing_rule"] = rules
+	}
+
+	return m
+}
+
+// websiteDomain returns the domain of the website endpoints of the
+// buckets in the given region, such as "s3-website-us-east-1.amazonaws.com".
+// The newer regions use a dot rather than a dash before the region.
+func websiteDomain(region string) string {
+	switch region {
+	case "eu-central-1":
+		return fmt.Sprintf("s3-website.%s.amazonaws.com", region)
+	default:
+		return fmt.Sprintf("s3-website-%s.amazonaws.com", region)
+	}
+}
+
 func resourceAwsS3BucketDelete(d *schema.ResourceData, meta interface{}) error {
 	s3conn := meta.(*AWSClient).s3conn
 
@@ -118,3 +630,19 @@ func resourceAwsS3BucketDelete(d *schema.ResourceData, meta interface{}) error {
 	}
 	return nil
 }
+
+// websiteHostedZoneIds are the IDs of the Route 53 hosted zones of the
+// website endpoints of the buckets in each region, which alias records to
+// the endpoints are created with.
+var websiteHostedZoneIds = map[string]string{
+	"us-east-1":      "Z3AQBSTGFYJSTF",
+	"us-west-1":      "Z2F56UZL2M1ACD",
+	"us-west-2":      "Z3BJ6K6RIION7M",
+	"eu-west-1":      "Z1BKCTXD74EZPE",
+	"eu-central-1":   "Z21DNDUVLTQW6Q",
+	"ap-southeast-1": "Z3O0J2DXBE1FTB",
+	"ap-southeast-2": "Z1WCIGYICN2BYD",
+	"ap-northeast-1": "Z2M4EHUR26P7ZW",
+	"sa-east-1":      "Z7KQH4QJS55SO",
+	"us-gov-west-1":  "Z31GFT0UA1I2HV",
+}
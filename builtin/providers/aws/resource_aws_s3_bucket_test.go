@@ -30,6 +30,119 @@ func TestAccAWSS3Bucket(t *testing.T) {
 	})
 }
 
+func TestAccAWSS3Bucket_Website(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAWSS3BucketDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccAWSS3BucketWebsiteConfig,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSS3BucketExists("aws_s3_bucket.website"),
+					resource.TestCheckResourceAttr(
+						"aws_s3_bucket.website", "website.0.index_document", "index.html"),
+					resource.TestCheckResourceAttr(
+						"aws_s3_bucket.website", "website.0.error_document", "error.html"),
+					resource.TestCheckResourceAttr(
+						"aws_s3_bucket.website", "website.0.routing_rule.0.key_prefix_equals", "docs/"),
+					resource.TestCheckResourceAttr(
+						"aws_s3_bucket.website", "website_domain",
+						websiteDomain(testAccProvider.Meta().(*AWSClient).region)),
+				),
+			},
+
+			resource.TestStep{
+				Config: testAccAWSS3BucketConfigWebsiteRemoved,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSS3BucketExists("aws_s3_bucket.website"),
+					resource.TestCheckResourceAttr(
+						"aws_s3_bucket.website", "website.#", "0"),
+					resource.TestCheckResourceAttr(
+						"aws_s3_bucket.website", "website_endpoint", ""),
+				),
+			},
+		},
+	})
+}
+
+func TestAccAWSS3Bucket_Policy(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAWSS3BucketDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccAWSS3BucketPolicyConfig,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSS3BucketExists("aws_s3_bucket.policy"),
+					testAccCheckAWSS3BucketPolicy("aws_s3_bucket.policy"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccAWSS3Bucket_Logging(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAWSS3BucketDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccAWSS3BucketLoggingConfig,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSS3BucketExists("aws_s3_bucket.bucket"),
+					resource.TestCheckResourceAttr(
+						"aws_s3_bucket.bucket", "logging.#", "1"),
+					resource.TestCheckResourceAttr(
+						"aws_s3_bucket.bucket", "logging.0.target_prefix", "log/"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccAWSS3Bucket_Encryption(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAWSS3BucketDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccAWSS3BucketEncryptionConfig,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSS3BucketExists("aws_s3_bucket.bucket"),
+					resource.TestCheckResourceAttr(
+						"aws_s3_bucket.bucket", "server_side_encryption_configuration.#", "1"),
+					resource.TestCheckResourceAttr(
+						"aws_s3_bucket.bucket", "server_side_encryption_configuration.0.sse_algorithm", "AES256"),
+				),
+			},
+
+			resource.TestStep{
+				Config: testAccAWSS3BucketEncryptionKMSConfig,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSS3BucketExists("aws_s3_bucket.bucket"),
+					resource.TestCheckResourceAttr(
+						"aws_s3_bucket.bucket", "server_side_encryption_configuration.#", "1"),
+					resource.TestCheckResourceAttr(
+						"aws_s3_bucket.bucket", "server_side_encryption_configuration.0.sse_algorithm", "aws:kms"),
+				),
+			},
+
+			resource.TestStep{
+				Config: testAccAWSS3BucketEncryptionNoneConfig,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSS3BucketExists("aws_s3_bucket.bucket"),
+					resource.TestCheckResourceAttr(
+						"aws_s3_bucket.bucket", "server_side_encryption_configuration.#", "0"),
+				),
+			},
+		},
+	})
+}
+
 func testAccCheckAWSS3BucketDestroy(s *terraform.State) error {
 	conn := testAccProvider.Meta().(*AWSClient).s3conn
 
@@ -70,6 +183,27 @@ func testAccCheckAWSS3BucketExists(n string) resource.TestCheckFunc {
 	}
 }
 
+func testAccCheckAWSS3BucketPolicy(n string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, _ := s.RootModule().Resources[n]
+		conn := testAccProvider.Meta().(*AWSClient).s3conn
+
+		out, err := conn.GetBucketPolicy(&s3.GetBucketPolicyInput{
+			Bucket: aws.String(rs.Primary.ID),
+		})
+		if err != nil {
+			return fmt.Errorf("GetBucketPolicy error: %v", err)
+		}
+
+		expected := normalizeJson(rs.Primary.Attributes["policy"])
+		if actual := normalizeJson(*out.Policy); actual != expected {
+			return fmt.Errorf("bad policy: %s", actual)
+		}
+
+		return nil
+	}
+}
+
 // This needs a bit of randoness as the name can only be
 // used once globally within AWS
 var testAccAWSS3BucketConfig = fmt.Sprintf(`
@@ -78,3 +212,102 @@ resource "aws_s3_bucket" "bar" {
 	acl = "public-read"
 }
 `, rand.New(rand.NewSource(time.Now().UnixNano())).Int())
+
+var testAccAWSS3BucketWebsiteRandInt = rand.New(rand.NewSource(time.Now().UnixNano())).Int()
+
+var testAccAWSS3BucketWebsiteConfig = fmt.Sprintf(`
+resource "aws_s3_bucket" "website" {
+	bucket = "tf-test-bucket-website-%d"
+	acl = "public-read"
+
+	website {
+		index_document = "index.html"
+		error_document = "error.html"
+
+		routing_rule {
+			key_prefix_equals = "docs/"
+			replace_key_prefix_with = "documents/"
+		}
+	}
+}
+`, testAccAWSS3BucketWebsiteRandInt)
+
+var testAccAWSS3BucketConfigWebsiteRemoved = fmt.Sprintf(`
+resource "aws_s3_bucket" "website" {
+	bucket = "tf-test-bucket-website-%d"
+	acl = "public-read"
+}
+`, testAccAWSS3BucketWebsiteRandInt)
+
+var testAccAWSS3BucketPolicyRandInt = rand.New(rand.NewSource(time.Now().UnixNano())).Int()
+
+var testAccAWSS3BucketPolicyConfig = fmt.Sprintf(`
+resource "aws_s3_bucket" "policy" {
+	bucket = "tf-test-bucket-policy-%d"
+	acl = "public-read"
+	policy = <<EOF
+{
+  "Version": "2008-10-17",
+  "Statement": [
+    {
+      "Sid": "PublicRead",
+      "Effect": "Allow",
+      "Principal": {"AWS": "*"},
+      "Action": "s3:GetObject",
+      "Resource": "arn:aws:s3:::tf-test-bucket-policy-%d/*"
+    }
+  ]
+}
+EOF
+}
+`, testAccAWSS3BucketPolicyRandInt, testAccAWSS3BucketPolicyRandInt)
+
+var testAccAWSS3BucketLoggingRandInt = rand.New(rand.NewSource(time.Now().UnixNano())).Int()
+
+var testAccAWSS3BucketLoggingConfig = fmt.Sprintf(`
+resource "aws_s3_bucket" "log_bucket" {
+	bucket = "tf-test-log-bucket-%d"
+	acl = "log-delivery-write"
+}
+
+resource "aws_s3_bucket" "bucket" {
+	bucket = "tf-test-bucket-%d"
+	acl = "private"
+
+	logging {
+		target_bucket = "${aws_s3_bucket.log_bucket.id}"
+		target_prefix = "log/"
+	}
+}
+`, testAccAWSS3BucketLoggingRandInt, testAccAWSS3BucketLoggingRandInt)
+
+var testAccAWSS3BucketEncryptionRandInt = rand.New(rand.NewSource(time.Now().UnixNano())).Int()
+
+var testAccAWSS3BucketEncryptionConfig = fmt.Sprintf(`
+resource "aws_s3_bucket" "bucket" {
+	bucket = "tf-test-bucket-%d"
+	acl = "private"
+
+	server_side_encryption_configuration {
+		sse_algorithm = "AES256"
+	}
+}
+`, testAccAWSS3BucketEncryptionRandInt)
+
+var testAccAWSS3BucketEncryptionKMSConfig = fmt.Sprintf(`
+resource "aws_s3_bucket" "bucket" {
+	bucket = "tf-test-bucket-%d"
+	acl = "private"
+
+	server_side_encryption_configuration {
+		sse_algorithm = "aws:kms"
+	}
+}
+`, testAccAWSS3BucketEncryptionRandInt)
+
+var testAccAWSS3BucketEncryptionNoneConfig = fmt.Sprintf(`
+resource "aws_s3_bucket" "bucket" {
+	bucket = "tf-test-bucket-%d"
+	acl = "private"
+}
+`, testAccAWSS3BucketEncryptionRandInt)
@@ -83,6 +83,11 @@ func resourceAwsSecurityGroup() *schema.Resource {
 							Optional: true,
 							Default:  false,
 						},
+
+						"description": &schema.Schema{
+							Type:     schema.TypeString,
+							Optional: true,
+						},
 					},
 				},
 				Set: resourceAwsSecurityGroupRuleHash,
@@ -129,11 +134,23 @@ func resourceAwsSecurityGroup() *schema.Resource {
 							Optional: true,
 							Default:  false,
 						},
+
+						"description": &schema.Schema{
+							Type:     schema.TypeString,
+							Optional: true,
+						},
 					},
 				},
 				Set: resourceAwsSecurityGroupRuleHash,
 			},
 
+			"revoke_default_egress": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+				ForceNew: true,
+			},
+
 			"owner_id": &schema.Schema{
 				Type:     schema.TypeString,
 				Computed: true,
@@ -185,6 +202,7 @@ func resourceAwsSecurityGroupCreate(d *schema.ResourceData, meta interface{}) er
 		Target:  "exists",
 		Refresh: SGStateRefreshFunc(conn, d.Id()),
 		Timeout: 1 * time.Minute,
+		StopCh:  d.StopCh(),
 	}
 
 	resp, err := stateConf.WaitForState()
@@ -195,9 +213,13 @@ func resourceAwsSecurityGroupCreate(d *schema.ResourceData, meta interface{}) er
 	}
 
 	// AWS defaults all Security Groups to have an ALLOW ALL egress rule. Here we
-	// revoke that rule, so users don't unknowningly have/use it.
+	// revoke that rule, so users don't unknowningly have/use it. It is only
+	// kept if asked for, and if no egress rules are given, since the egress
+	// rules of the group are otherwise exactly those given.
 	group := resp.(*ec2.SecurityGroup)
-	if group.VPCID != nil && *group.VPCID != "" {
+	revoke := d.Get("revoke_default_egress").(bool) ||
+		d.Get("egress").(*schema.Set).Len() > 0
+	if revoke && group.VPCID != nil && *group.VPCID != "" {
 		log.Printf("[DEBUG] Revoking default egress rule for Security Group for %s", d.Id())
 
 		req := &ec2.RevokeSecurityGroupEgressInput{
@@ -327,6 +349,9 @@ func resourceAwsSecurityGroupRuleHash(v interface{}) int {
 	buf.WriteString(fmt.Sprintf("%d-", m["to_port"].(int)))
 	buf.WriteString(fmt.Sprintf("%s-", m["protocol"].(string)))
 	buf.WriteString(fmt.Sprintf("%t-", m["self"].(bool)))
+	if v, ok := m["description"]; ok && v.(string) != "" {
+		buf.WriteString(fmt.Sprintf("%s-", v.(string)))
+	}
 
 	// We need to make sure to sort the strings below so that we always
 	// generate the same hash code no matter what is in the set.
@@ -380,6 +405,14 @@ func resourceAwsSecurityGroupIPPermGather(d *schema.ResourceData, permissions []
 		m["to_port"] = toPort
 		m["protocol"] = *perm.IPProtocol
 
+		// The description of a rule is set on each of its sources, so
+		// any of them has it.
+		if _, ok := m["description"]; !ok {
+			if desc := ipPermDescription(perm); desc != "" {
+				m["description"] = desc
+			}
+		}
+
 		if len(perm.IPRanges) > 0 {
 			raw, ok := m["cidr_blocks"]
 			if !ok {
@@ -439,8 +472,13 @@ func resourceAwsSecurityGroupUpdateRules(
 		os := o.(*schema.Set)
 		ns := n.(*schema.Set)
 
-		remove := expandIPPerms(group, os.Difference(ns).List())
-		add := expandIPPerms(group, ns.Difference(os).List())
+		// Rules that only have their description changed are updated in
+		// place, rather than revoked and authorized again.
+		removeRules, addRules, describeRules := securityGroupRuleChanges(
+			os.Difference(ns).List(), ns.Difference(os).List())
+		remove := expandIPPerms(group, removeRules)
+		add := expandIPPerms(group, addRules)
+		describe := expandIPPerms(group, describeRules)
 
 		// TODO: We need to handle partial state better in the in-between
 		// in this update.
@@ -510,10 +548,103 @@ func resourceAwsSecurityGroupUpdateRules(
 				}
 			}
 		}
+
+		if len(describe) > 0 {
+			conn := meta.(*AWSClient).ec2conn
+
+			log.Printf("[DEBUG] Updating security group %#v %s rule descriptions: %#v",
+				group, ruleset, describe)
+
+			var err error
+			if ruleset == "egress" {
+				req := &ec2.UpdateSecurityGroupRuleDescriptionsEgressInput{
+					GroupID:       group.GroupID,
+					IPPermissions: describe,
+				}
+				_, err = conn.UpdateSecurityGroupRuleDescriptionsEgress(req)
+			} else {
+				req := &ec2.UpdateSecurityGroupRuleDescriptionsIngressInput{
+					GroupID:       group.GroupID,
+					IPPermissions: describe,
+				}
+				if group.VPCID == nil || *group.VPCID == "" {
+					req.GroupID = nil
+					req.GroupName = group.GroupName
+				}
+
+				_, err = conn.UpdateSecurityGroupRuleDescriptionsIngress(req)
+			}
+
+			if err != nil {
+				return fmt.Errorf(
+					"Error updating security group %s rule descriptions: %s",
+					ruleset, err)
+			}
+		}
 	}
 	return nil
 }
 
+// securityGroupRuleChanges splits the rules that were removed from and
+// added to a rule set into the ones to revoke, the ones to authorize, and
+// the ones that only changed their description, which are returned with
+// their new description.
+func securityGroupRuleChanges(removed, added []interface{}) (remove, add, describe []interface{}) {
+	undescribed := make(map[int]int)
+	for i, raw := range removed {
+		undescribed[securityGroupRuleHashWithoutDescription(raw)] = i
+	}
+
+	changed := make(map[int]bool)
+	for _, raw := range added {
+		if i, ok := undescribed[securityGroupRuleHashWithoutDescription(raw)]; ok && !changed[i] {
+			changed[i] = true
+			describe = append(describe, raw)
+			continue
+		}
+
+		add = append(add, raw)
+	}
+
+	for i, raw := range removed {
+		if !changed[i] {
+			remove = append(remove, raw)
+		}
+	}
+
+	return remove, add, describe
+}
+
+// securityGroupRuleHashWithoutDescription hashes a rule like
+// resourceAwsSecurityGroupRuleHash, leaving out its description.
+func securityGroupRuleHashWithoutDescription(v interface{}) int {
+	m := make(map[string]interface{})
+	for k, v := range v.(map[string]interface{}) {
+		if k != "description" {
+			m[k] = v
+		}
+	}
+
+	return resourceAwsSecurityGroupRuleHash(m)
+}
+
+// ipPermDescription returns the description of the first source of a
+// permission that has one.
+func ipPermDescription(perm *ec2.IPPermission) string {
+	for _, ip := range perm.IPRanges {
+		if ip.Description != nil && *ip.Description != "" {
+			return *ip.Description
+		}
+	}
+	for _, pair := range perm.UserIDGroupPairs {
+		if pair.Description != nil && *pair.Description != "" {
+			return *pair.Description
+		}
+	}
+
+	return ""
+}
+
 // SGStateRefreshFunc returns a resource.StateRefreshFunc that is used to watch
 // a security group.
 func SGStateRefreshFunc(conn *ec2.EC2, id string) resource.StateRefreshFunc {
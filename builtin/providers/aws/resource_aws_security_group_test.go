@@ -248,6 +248,82 @@ func TestAccAWSSecurityGroup_DefaultEgress(t *testing.T) {
 	})
 }
 
+func TestAccAWSSecurityGroup_keepDefaultEgress(t *testing.T) {
+	var group ec2.SecurityGroup
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAWSSecurityGroupDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccAWSSecurityGroupConfigKeepDefaultEgress,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSSecurityGroupExists("aws_security_group.worker", &group),
+					resource.TestCheckResourceAttr(
+						"aws_security_group.worker", "egress.#", "1"),
+					resource.TestCheckResourceAttr(
+						"aws_security_group.worker", "revoke_default_egress", "false"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccAWSSecurityGroup_ruleDescription(t *testing.T) {
+	var group ec2.SecurityGroup
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAWSSecurityGroupDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: fmt.Sprintf(testAccAWSSecurityGroupConfigRuleDescription, "foo"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSSecurityGroupExists("aws_security_group.web", &group),
+					testAccCheckAWSSecurityGroupRuleDescription(&group, "foo"),
+				),
+			},
+
+			resource.TestStep{
+				Config: fmt.Sprintf(testAccAWSSecurityGroupConfigRuleDescription, "bar"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSSecurityGroupExists("aws_security_group.web", &group),
+					testAccCheckAWSSecurityGroupRuleDescription(&group, "bar"),
+				),
+			},
+		},
+	})
+}
+
+func TestSecurityGroupRuleChanges(t *testing.T) {
+	rule := func(port int, description string) map[string]interface{} {
+		return map[string]interface{}{
+			"from_port":   port,
+			"to_port":     port,
+			"protocol":    "tcp",
+			"self":        false,
+			"cidr_blocks": []interface{}{"10.0.0.0/8"},
+			"description": description,
+		}
+	}
+
+	removed := []interface{}{rule(80, "foo"), rule(22, "")}
+	added := []interface{}{rule(80, "bar"), rule(443, "")}
+
+	remove, add, describe := securityGroupRuleChanges(removed, added)
+	if !reflect.DeepEqual(remove, []interface{}{rule(22, "")}) {
+		t.Fatalf("bad remove: %#v", remove)
+	}
+	if !reflect.DeepEqual(add, []interface{}{rule(443, "")}) {
+		t.Fatalf("bad add: %#v", add)
+	}
+	if !reflect.DeepEqual(describe, []interface{}{rule(80, "bar")}) {
+		t.Fatalf("bad describe: %#v", describe)
+	}
+}
+
 func testAccCheckAWSSecurityGroupDestroy(s *terraform.State) error {
 	conn := testAccProvider.Meta().(*AWSClient).ec2conn
 
@@ -344,6 +420,23 @@ func testAccCheckAWSSecurityGroupAttributes(group *ec2.SecurityGroup) resource.T
 	}
 }
 
+func testAccCheckAWSSecurityGroupRuleDescription(group *ec2.SecurityGroup, description string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		perms := append(group.IPPermissions, group.IPPermissionsEgress...)
+		if len(perms) != 2 {
+			return fmt.Errorf("Bad permissions: %#v", perms)
+		}
+
+		for _, perm := range perms {
+			if desc := ipPermDescription(perm); desc != description {
+				return fmt.Errorf("Bad rule description: %s", desc)
+			}
+		}
+
+		return nil
+	}
+}
+
 func TestAccAWSSecurityGroup_tags(t *testing.T) {
 	var group ec2.SecurityGroup
 
@@ -708,6 +801,54 @@ resource "aws_security_group" "worker" {
 }
 `
 
+const testAccAWSSecurityGroupConfigKeepDefaultEgress = `
+resource "aws_vpc" "tf_sg_egress_test" {
+  cidr_block = "10.0.0.0/16"
+}
+
+resource "aws_security_group" "worker" {
+  name = "terraform_acceptance_test_example_2"
+  description = "Used in the terraform acceptance tests"
+  vpc_id = "${aws_vpc.tf_sg_egress_test.id}"
+  revoke_default_egress = false
+
+  ingress {
+    protocol = "tcp"
+    from_port = 80
+    to_port = 8000
+    cidr_blocks = ["10.0.0.0/8"]
+  }
+}
+`
+
+const testAccAWSSecurityGroupConfigRuleDescription = `
+resource "aws_vpc" "foo" {
+  cidr_block = "10.1.0.0/16"
+}
+
+resource "aws_security_group" "web" {
+  name = "terraform_acceptance_test_example"
+  description = "Used in the terraform acceptance tests"
+  vpc_id = "${aws_vpc.foo.id}"
+
+  ingress {
+    protocol = "tcp"
+    from_port = 80
+    to_port = 8000
+    cidr_blocks = ["10.0.0.0/8"]
+    description = "%[1]s"
+  }
+
+  egress {
+    protocol = "tcp"
+    from_port = 80
+    to_port = 8000
+    cidr_blocks = ["10.0.0.0/8"]
+    description = "%[1]s"
+  }
+}
+`
+
 const testAccAWSSecurityGroupConfigClassic = `
 provider "aws" {
   region = "us-east-1"
@@ -77,6 +77,7 @@ func resourceAwsSubnetCreate(d *schema.ResourceData, meta interface{}) error {
 		Target:  "available",
 		Refresh: SubnetStateRefreshFunc(conn, *subnet.SubnetID),
 		Timeout: 10 * time.Minute,
+		StopCh:  d.StopCh(),
 	}
 
 	_, err = stateConf.WaitForState()
@@ -188,6 +189,7 @@ func resourceAwsSubnetDelete(d *schema.ResourceData, meta interface{}) error {
 
 			return 42, "destroyed", nil
 		},
+		StopCh: d.StopCh(),
 	}
 
 	if _, err := wait.WaitForState(); err != nil {
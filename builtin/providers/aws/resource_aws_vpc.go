@@ -103,6 +103,7 @@ func resourceAwsVpcCreate(d *schema.ResourceData, meta interface{}) error {
 		Target:  "available",
 		Refresh: VPCStateRefreshFunc(conn, d.Id()),
 		Timeout: 10 * time.Minute,
+		StopCh:  d.StopCh(),
 	}
 	if _, err := stateConf.WaitForState(); err != nil {
 		return fmt.Errorf(
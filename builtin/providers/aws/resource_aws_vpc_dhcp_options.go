@@ -123,6 +123,7 @@ func resourceAwsVpcDhcpOptionsCreate(d *schema.ResourceData, meta interface{}) e
 		Target:  "",
 		Refresh: DHCPOptionsStateRefreshFunc(conn, d.Id()),
 		Timeout: 1 * time.Minute,
+		StopCh:  d.StopCh(),
 	}
 	if _, err := stateConf.WaitForState(); err != nil {
 		return fmt.Errorf(
@@ -77,6 +77,7 @@ func resourceAwsVPCPeeringCreate(d *schema.ResourceData, meta interface{}) error
 		Target:  "pending-acceptance",
 		Refresh: resourceAwsVPCPeeringConnectionStateRefreshFunc(conn, d.Id()),
 		Timeout: 1 * time.Minute,
+		StopCh:  d.StopCh(),
 	}
 	if _, err := stateConf.WaitForState(); err != nil {
 		return fmt.Errorf(
@@ -2,6 +2,8 @@ package aws
 
 import (
 	"fmt"
+	"log"
+	"strings"
 	"testing"
 
 	"github.com/awslabs/aws-sdk-go/aws"
@@ -10,6 +12,45 @@ import (
 	"github.com/hashicorp/terraform/terraform"
 )
 
+func init() {
+	resource.AddTestSweepers("aws_vpc", &resource.Sweeper{
+		Dependencies: []string{"aws_instance", "aws_elb"},
+		F:            testSweepVpcs,
+	})
+}
+
+func testSweepVpcs(region string) error {
+	client, err := sharedClientForRegion(region)
+	if err != nil {
+		return err
+	}
+	conn := client.ec2conn
+
+	resp, err := conn.DescribeVPCs(&ec2.DescribeVPCsInput{
+		Filters: []*ec2.Filter{testSweepNameFilter()},
+	})
+	if err != nil {
+		return fmt.Errorf("Error describing VPCs: %s", err)
+	}
+
+	// A VPC that still has resources in it can't be deleted. Keep going
+	// so that one stuck VPC doesn't keep the others around.
+	var errs []string
+	for _, vpc := range resp.VPCs {
+		log.Printf("[INFO] Deleting VPC: %s", *vpc.VPCID)
+		_, err := conn.DeleteVPC(&ec2.DeleteVPCInput{VPCID: vpc.VPCID})
+		if err != nil {
+			errs = append(errs, fmt.Sprintf("%s: %s", *vpc.VPCID, err))
+		}
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf(
+			"Error deleting VPCs:\n\n%s", strings.Join(errs, "\n"))
+	}
+
+	return nil
+}
+
 func TestAccVpc_basic(t *testing.T) {
 	var vpc ec2.VPC
 
@@ -82,6 +123,39 @@ func TestAccVpc_tags(t *testing.T) {
 	})
 }
 
+func TestAccVpc_defaultTags(t *testing.T) {
+	var vpc ec2.VPC
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckVpcDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccVpcConfigDefaultTags,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckVpcExists("aws_vpc.foo", &vpc),
+					testAccCheckTagsSDK(&vpc.Tags, "team", "ops"),
+					testAccCheckTagsSDK(&vpc.Tags, "env", "dev"),
+					testAccCheckTagsSDK(&vpc.Tags, "foo", "bar"),
+					resource.TestCheckResourceAttr(
+						"aws_vpc.foo", "tags.team", "ops"),
+				),
+			},
+
+			resource.TestStep{
+				Config: testAccVpcConfigDefaultTagsUpdate,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckVpcExists("aws_vpc.foo", &vpc),
+					testAccCheckTagsSDK(&vpc.Tags, "team", "dev"),
+					testAccCheckTagsSDK(&vpc.Tags, "env", ""),
+					testAccCheckTagsSDK(&vpc.Tags, "foo", "bar"),
+				),
+			},
+		},
+	})
+}
+
 func TestAccVpcUpdate(t *testing.T) {
 	var vpc ec2.VPC
 
@@ -237,6 +311,40 @@ resource "aws_vpc" "foo" {
 	}
 }
 `
+const testAccVpcConfigDefaultTags = `
+provider "aws" {
+	default_tags {
+		team = "ops"
+		env = "prod"
+	}
+}
+
+resource "aws_vpc" "foo" {
+	cidr_block = "10.1.0.0/16"
+
+	tags {
+		foo = "bar"
+		env = "dev"
+	}
+}
+`
+
+const testAccVpcConfigDefaultTagsUpdate = `
+provider "aws" {
+	default_tags {
+		team = "dev"
+	}
+}
+
+resource "aws_vpc" "foo" {
+	cidr_block = "10.1.0.0/16"
+
+	tags {
+		foo = "bar"
+	}
+}
+`
+
 const testAccVpcDedicatedConfig = `
 resource "aws_vpc" "bar" {
 	instance_tenancy = "dedicated"
@@ -175,6 +175,7 @@ func resourceAwsVpnConnectionCreate(d *schema.ResourceData, meta interface{}) er
 		Timeout:    30 * time.Minute,
 		Delay:      10 * time.Second,
 		MinTimeout: 10 * time.Second,
+		StopCh:     d.StopCh(),
 	}
 
 	_, stateErr := stateConf.WaitForState()
@@ -307,6 +308,7 @@ func resourceAwsVpnConnectionDelete(d *schema.ResourceData, meta interface{}) er
 		Timeout:    30 * time.Minute,
 		Delay:      10 * time.Second,
 		MinTimeout: 10 * time.Second,
+		StopCh:     d.StopCh(),
 	}
 
 	_, stateErr := stateConf.WaitForState()
@@ -1,6 +1,7 @@
 package aws
 
 import (
+	"encoding/json"
 	"fmt"
 	"sort"
 	"strings"
@@ -57,6 +58,12 @@ func expandIPPerms(
 		perm.ToPort = aws.Long(int64(m["to_port"].(int)))
 		perm.IPProtocol = aws.String(m["protocol"].(string))
 
+		// The description of a rule is set on each of its sources.
+		var description *string
+		if v, ok := m["description"]; ok && v.(string) != "" {
+			description = aws.String(v.(string))
+		}
+
 		var groups []string
 		if raw, ok := m["security_groups"]; ok {
 			list := raw.(*schema.Set).List()
@@ -81,8 +88,9 @@ func expandIPPerms(
 				}
 
 				perm.UserIDGroupPairs[i] = &ec2.UserIDGroupPair{
-					GroupID: aws.String(id),
-					UserID:  aws.String(ownerId),
+					GroupID:     aws.String(id),
+					UserID:      aws.String(ownerId),
+					Description: description,
 				}
 				if !vpc {
 					perm.UserIDGroupPairs[i].GroupID = nil
@@ -95,7 +103,10 @@ func expandIPPerms(
 		if raw, ok := m["cidr_blocks"]; ok {
 			list := raw.([]interface{})
 			for _, v := range list {
-				perm.IPRanges = append(perm.IPRanges, &ec2.IPRange{CIDRIP: aws.String(v.(string))})
+				perm.IPRanges = append(perm.IPRanges, &ec2.IPRange{
+					CIDRIP:      aws.String(v.(string)),
+					Description: description,
+				})
 			}
 		}
 
@@ -293,3 +304,26 @@ func expandResourceRecords(recs []interface{}, typeStr string) []*route53.Resour
 	}
 	return records
 }
+
+// normalizeJson returns the given JSON document in a canonical form, with
+// the keys of objects sorted and without insignificant whitespace, so that
+// documents that only differ in formatting compare as equal. Invalid JSON
+// is returned as is, to be rejected by the API.
+func normalizeJson(v interface{}) string {
+	s, ok := v.(string)
+	if !ok || s == "" {
+		return ""
+	}
+
+	var doc interface{}
+	if err := json.Unmarshal([]byte(s), &doc); err != nil {
+		return s
+	}
+
+	b, err := json.Marshal(doc)
+	if err != nil {
+		return s
+	}
+
+	return string(b)
+}
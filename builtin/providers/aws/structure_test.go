@@ -459,3 +459,38 @@ func TestFlattenResourceRecords(t *testing.T) {
 		t.Fatal("expected result to have value, but got nil")
 	}
 }
+
+func TestNormalizeJson(t *testing.T) {
+	cases := []struct {
+		Input  string
+		Output string
+	}{
+		{
+			"",
+			"",
+		},
+
+		{
+			`{ "b": [1, 2], "a": {"d": "x", "c": true} }`,
+			`{"a":{"c":true,"d":"x"},"b":[1,2]}`,
+		},
+
+		{
+			`{"Version": "2012-10-17",
+			  "Statement": []}`,
+			`{"Statement":[],"Version":"2012-10-17"}`,
+		},
+
+		{
+			`{"invalid"`,
+			`{"invalid"`,
+		},
+	}
+
+	for i, tc := range cases {
+		actual := normalizeJson(tc.Input)
+		if actual != tc.Output {
+			t.Fatalf("%d: bad: %s", i, actual)
+		}
+	}
+}
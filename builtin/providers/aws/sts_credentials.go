@@ -0,0 +1,139 @@
+package aws
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/awslabs/aws-sdk-go/aws"
+	"github.com/awslabs/aws-sdk-go/aws/credentials"
+	"github.com/awslabs/aws-sdk-go/service/sts"
+)
+
+// stsExpiryWindow is how long before they expire temporary credentials
+// from STS are refreshed, so that requests that are signed right before
+// don't fail.
+const stsExpiryWindow = 5 * time.Minute
+
+// assumeRoleAPI is the part of the STS API used to assume a role.
+type assumeRoleAPI interface {
+	AssumeRole(*sts.AssumeRoleInput) (*sts.AssumeRoleOutput, error)
+}
+
+// sessionTokenAPI is the part of the STS API used to get a session token.
+type sessionTokenAPI interface {
+	GetSessionToken(*sts.GetSessionTokenInput) (*sts.GetSessionTokenOutput, error)
+}
+
+// assumeRoleProvider is a credentials.Provider for the temporary
+// credentials of an assumed IAM role. The credentials are requested again
+// when they are about to expire, so long running applies keep working.
+//
+// If the role requires MFA, the SerialNumber of the MFA device and a
+// TokenCode from it are given. A token code can only be used once, so
+// the credentials can't be refreshed then.
+type assumeRoleProvider struct {
+	Client       assumeRoleAPI
+	RoleARN      string
+	SessionName  string
+	ExternalID   string
+	SerialNumber string
+	TokenCode    string
+
+	expiration time.Time
+	retrieved  bool
+}
+
+func (p *assumeRoleProvider) Retrieve() (credentials.Value, error) {
+	if p.retrieved && p.TokenCode != "" {
+		return credentials.Value{}, fmt.Errorf(
+			"The MFA session for role %s expired, run Terraform again "+
+				"with a new token code", p.RoleARN)
+	}
+
+	input := &sts.AssumeRoleInput{
+		RoleARN:         aws.String(p.RoleARN),
+		RoleSessionName: aws.String(p.SessionName),
+	}
+	if p.ExternalID != "" {
+		input.ExternalID = aws.String(p.ExternalID)
+	}
+	if p.SerialNumber != "" {
+		input.SerialNumber = aws.String(p.SerialNumber)
+		input.TokenCode = aws.String(p.TokenCode)
+	}
+
+	log.Printf("[INFO] Assuming role %s", p.RoleARN)
+	resp, err := p.Client.AssumeRole(input)
+	if err != nil {
+		return credentials.Value{}, fmt.Errorf(
+			"Error assuming role %s: %s", p.RoleARN, err)
+	}
+
+	p.retrieved = true
+	v, expiration := stsCredentialsValue(resp.Credentials)
+	p.expiration = expiration
+	return v, nil
+}
+
+func (p *assumeRoleProvider) IsExpired() bool {
+	return p.expiration.IsZero() || time.Now().After(p.expiration)
+}
+
+// sessionTokenProvider is a credentials.Provider for the temporary
+// credentials of an MFA session of the configured credentials, for
+// accounts whose policies require MFA.
+//
+// A token code can only be used once, so the session can't be refreshed
+// when it expires.
+type sessionTokenProvider struct {
+	Client       sessionTokenAPI
+	SerialNumber string
+	TokenCode    string
+
+	expiration time.Time
+	retrieved  bool
+}
+
+func (p *sessionTokenProvider) Retrieve() (credentials.Value, error) {
+	if p.retrieved {
+		return credentials.Value{}, fmt.Errorf(
+			"The MFA session expired, run Terraform again with a new " +
+				"token code")
+	}
+
+	log.Printf("[INFO] Getting a session token for MFA device %s", p.SerialNumber)
+	resp, err := p.Client.GetSessionToken(&sts.GetSessionTokenInput{
+		SerialNumber: aws.String(p.SerialNumber),
+		TokenCode:    aws.String(p.TokenCode),
+	})
+	if err != nil {
+		return credentials.Value{}, fmt.Errorf(
+			"Error getting a session token for MFA device %s: %s",
+			p.SerialNumber, err)
+	}
+
+	p.retrieved = true
+	v, expiration := stsCredentialsValue(resp.Credentials)
+	p.expiration = expiration
+	return v, nil
+}
+
+func (p *sessionTokenProvider) IsExpired() bool {
+	return p.expiration.IsZero() || time.Now().After(p.expiration)
+}
+
+// stsCredentialsValue returns the value of temporary credentials from
+// STS, and when they should be refreshed.
+func stsCredentialsValue(creds *sts.Credentials) (credentials.Value, time.Time) {
+	var expiration time.Time
+	if creds.Expiration != nil {
+		expiration = creds.Expiration.Add(-stsExpiryWindow)
+	}
+
+	return credentials.Value{
+		AccessKeyID:     *creds.AccessKeyID,
+		SecretAccessKey: *creds.SecretAccessKey,
+		SessionToken:    *creds.SessionToken,
+	}, expiration
+}
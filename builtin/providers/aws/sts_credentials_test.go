@@ -0,0 +1,171 @@
+package aws
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/awslabs/aws-sdk-go/aws"
+	"github.com/awslabs/aws-sdk-go/service/sts"
+)
+
+type mockSTS struct {
+	AssumeRoleInput      *sts.AssumeRoleInput
+	GetSessionTokenInput *sts.GetSessionTokenInput
+	Expiration           time.Time
+	Err                  error
+}
+
+func (m *mockSTS) AssumeRole(
+	input *sts.AssumeRoleInput) (*sts.AssumeRoleOutput, error) {
+	m.AssumeRoleInput = input
+	if m.Err != nil {
+		return nil, m.Err
+	}
+
+	return &sts.AssumeRoleOutput{Credentials: m.credentials()}, nil
+}
+
+func (m *mockSTS) GetSessionToken(
+	input *sts.GetSessionTokenInput) (*sts.GetSessionTokenOutput, error) {
+	m.GetSessionTokenInput = input
+	if m.Err != nil {
+		return nil, m.Err
+	}
+
+	return &sts.GetSessionTokenOutput{Credentials: m.credentials()}, nil
+}
+
+func (m *mockSTS) credentials() *sts.Credentials {
+	expiration := m.Expiration
+	return &sts.Credentials{
+		AccessKeyID:     aws.String("id"),
+		SecretAccessKey: aws.String("secret"),
+		SessionToken:    aws.String("token"),
+		Expiration:      &expiration,
+	}
+}
+
+func TestAssumeRoleProvider(t *testing.T) {
+	mock := &mockSTS{Expiration: time.Now().Add(time.Hour)}
+	p := &assumeRoleProvider{
+		Client:      mock,
+		RoleARN:     "arn:aws:iam::123456789012:role/deploy",
+		SessionName: "terraform",
+		ExternalID:  "foo",
+	}
+
+	if !p.IsExpired() {
+		t.Fatal("should be expired before the first retrieve")
+	}
+
+	v, err := p.Retrieve()
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if v.AccessKeyID != "id" || v.SecretAccessKey != "secret" || v.SessionToken != "token" {
+		t.Fatalf("bad: %#v", v)
+	}
+	input := mock.AssumeRoleInput
+	if *input.RoleARN != p.RoleARN ||
+		*input.RoleSessionName != "terraform" ||
+		*input.ExternalID != "foo" ||
+		input.SerialNumber != nil {
+		t.Fatalf("bad: %#v", input)
+	}
+	if p.IsExpired() {
+		t.Fatal("should not be expired")
+	}
+
+	// Credentials are refreshed before they actually expire
+	mock.Expiration = time.Now().Add(stsExpiryWindow / 2)
+	if _, err := p.Retrieve(); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if !p.IsExpired() {
+		t.Fatal("should be expired")
+	}
+}
+
+func TestAssumeRoleProvider_noExternalID(t *testing.T) {
+	mock := &mockSTS{Expiration: time.Now().Add(time.Hour)}
+	p := &assumeRoleProvider{
+		Client:      mock,
+		RoleARN:     "arn:aws:iam::123456789012:role/deploy",
+		SessionName: "terraform",
+	}
+
+	if _, err := p.Retrieve(); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if mock.AssumeRoleInput.ExternalID != nil {
+		t.Fatalf("bad: %#v", mock.AssumeRoleInput)
+	}
+}
+
+func TestAssumeRoleProvider_mfa(t *testing.T) {
+	mock := &mockSTS{Expiration: time.Now().Add(time.Hour)}
+	p := &assumeRoleProvider{
+		Client:       mock,
+		RoleARN:      "arn:aws:iam::123456789012:role/deploy",
+		SessionName:  "terraform",
+		SerialNumber: "arn:aws:iam::123456789012:mfa/user",
+		TokenCode:    "123456",
+	}
+
+	if _, err := p.Retrieve(); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	input := mock.AssumeRoleInput
+	if *input.SerialNumber != p.SerialNumber || *input.TokenCode != "123456" {
+		t.Fatalf("bad: %#v", input)
+	}
+
+	// The token code can't be used again
+	if _, err := p.Retrieve(); err == nil {
+		t.Fatal("should error")
+	}
+}
+
+func TestAssumeRoleProvider_error(t *testing.T) {
+	p := &assumeRoleProvider{
+		Client:  &mockSTS{Err: fmt.Errorf("AccessDenied")},
+		RoleARN: "arn:aws:iam::123456789012:role/deploy",
+	}
+
+	if _, err := p.Retrieve(); err == nil {
+		t.Fatal("should error")
+	}
+	if !p.IsExpired() {
+		t.Fatal("should be expired")
+	}
+}
+
+func TestSessionTokenProvider(t *testing.T) {
+	mock := &mockSTS{Expiration: time.Now().Add(time.Hour)}
+	p := &sessionTokenProvider{
+		Client:       mock,
+		SerialNumber: "arn:aws:iam::123456789012:mfa/user",
+		TokenCode:    "123456",
+	}
+
+	v, err := p.Retrieve()
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if v.AccessKeyID != "id" || v.SessionToken != "token" {
+		t.Fatalf("bad: %#v", v)
+	}
+	input := mock.GetSessionTokenInput
+	if *input.SerialNumber != p.SerialNumber || *input.TokenCode != "123456" {
+		t.Fatalf("bad: %#v", input)
+	}
+	if p.IsExpired() {
+		t.Fatal("should not be expired")
+	}
+
+	// The token code can't be used again
+	if _, err := p.Retrieve(); err == nil {
+		t.Fatal("should error")
+	}
+}
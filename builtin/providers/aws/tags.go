@@ -6,6 +6,7 @@ import (
 	"github.com/awslabs/aws-sdk-go/aws"
 	"github.com/awslabs/aws-sdk-go/service/ec2"
 	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/terraform"
 )
 
 // tagsSchema returns the schema to use for tags.
@@ -17,6 +18,90 @@ func tagsSchema() *schema.Schema {
 	}
 }
 
+// defaultTagsDiffConfig adds the default_tags of the provider that the
+// resource doesn't set itself to the tags in its configuration. The
+// default tags are then diffed like the resource's own tags, so tags
+// that were removed or changed outside of Terraform are set again.
+func defaultTagsDiffConfig(
+	c *terraform.ResourceConfig,
+	meta interface{}) (*terraform.ResourceConfig, error) {
+	client, ok := meta.(*AWSClient)
+	if !ok || len(client.defaultTags) == 0 || c.IsComputed("tags") {
+		return c, nil
+	}
+
+	missing := make(map[string]interface{})
+	for k, v := range client.defaultTags {
+		missing[k] = v
+	}
+	switch raw := c.Raw["tags"].(type) {
+	case map[string]interface{}:
+		for k, _ := range raw {
+			delete(missing, k)
+		}
+	case []map[string]interface{}:
+		for _, m := range raw {
+			for k, _ := range m {
+				delete(missing, k)
+			}
+		}
+	case []interface{}:
+		for _, m := range raw {
+			for k, _ := range m.(map[string]interface{}) {
+				delete(missing, k)
+			}
+		}
+	}
+	if len(missing) == 0 {
+		return c, nil
+	}
+
+	result := &terraform.ResourceConfig{
+		ComputedKeys: c.ComputedKeys,
+		Raw:          make(map[string]interface{}, len(c.Raw)+1),
+		Config:       make(map[string]interface{}, len(c.Config)+1),
+	}
+	for k, v := range c.Raw {
+		result.Raw[k] = v
+	}
+	for k, v := range c.Config {
+		result.Config[k] = v
+	}
+	result.Raw["tags"] = mergeConfigTags(c.Raw["tags"], missing)
+	result.Config["tags"] = mergeConfigTags(c.Config["tags"], missing)
+
+	return result, nil
+}
+
+// mergeConfigTags returns the tags of a configuration with the given tags
+// added. Repeated tags blocks are a list of maps, in which case the tags
+// are added as another element.
+func mergeConfigTags(v interface{}, tags map[string]interface{}) interface{} {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		result := make(map[string]interface{}, len(v)+len(tags))
+		for k, t := range tags {
+			result[k] = t
+		}
+		for k, t := range v {
+			result[k] = t
+		}
+		return result
+	case []map[string]interface{}:
+		result := make([]interface{}, 0, len(v)+1)
+		for _, m := range v {
+			result = append(result, m)
+		}
+		return append(result, tags)
+	case []interface{}:
+		result := make([]interface{}, 0, len(v)+1)
+		result = append(result, v...)
+		return append(result, tags)
+	default:
+		return tags
+	}
+}
+
 // setTags is a helper to set the tags for a resource. It expects the
 // tags field to be named "tags"
 func setTagsSDK(conn *ec2.EC2, d *schema.ResourceData) error {
@@ -0,0 +1,110 @@
+package aws
+
+import (
+	"log"
+
+	"github.com/awslabs/aws-sdk-go/aws"
+	"github.com/awslabs/aws-sdk-go/service/elbv2"
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+// setTagsELBv2 is a helper to set the tags for a resource whose ID is its
+// ARN. It expects the tags field to be named "tags"
+func setTagsELBv2(conn *elbv2.ELBV2, d *schema.ResourceData) error {
+	if d.HasChange("tags") {
+		oraw, nraw := d.GetChange("tags")
+		o := oraw.(map[string]interface{})
+		n := nraw.(map[string]interface{})
+		create, remove := diffTagsELBv2(tagsFromMapELBv2(o), tagsFromMapELBv2(n))
+
+		// Set tags
+		if len(remove) > 0 {
+			log.Printf("[DEBUG] Removing tags: %#v", remove)
+			k := make([]*string, 0, len(remove))
+			for _, t := range remove {
+				k = append(k, t.Key)
+			}
+			_, err := conn.RemoveTags(&elbv2.RemoveTagsInput{
+				ResourceARNs: []*string{aws.String(d.Id())},
+				TagKeys:      k,
+			})
+			if err != nil {
+				return err
+			}
+		}
+		if len(create) > 0 {
+			log.Printf("[DEBUG] Creating tags: %#v", create)
+			_, err := conn.AddTags(&elbv2.AddTagsInput{
+				ResourceARNs: []*string{aws.String(d.Id())},
+				Tags:         create,
+			})
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// diffTagsELBv2 takes our tags locally and the ones remotely and returns
+// the set of tags that must be created, and the set of tags that must
+// be destroyed.
+func diffTagsELBv2(oldTags, newTags []*elbv2.Tag) ([]*elbv2.Tag, []*elbv2.Tag) {
+	// First, we're creating everything we have
+	create := make(map[string]interface{})
+	for _, t := range newTags {
+		create[*t.Key] = *t.Value
+	}
+
+	// Build the list of what to remove
+	var remove []*elbv2.Tag
+	for _, t := range oldTags {
+		old, ok := create[*t.Key]
+		if !ok || old != *t.Value {
+			// Delete it!
+			remove = append(remove, t)
+		}
+	}
+
+	return tagsFromMapELBv2(create), remove
+}
+
+// tagsFromMapELBv2 returns the tags for the given map of data.
+func tagsFromMapELBv2(m map[string]interface{}) []*elbv2.Tag {
+	var result []*elbv2.Tag
+	for k, v := range m {
+		result = append(result, &elbv2.Tag{
+			Key:   aws.String(k),
+			Value: aws.String(v.(string)),
+		})
+	}
+
+	return result
+}
+
+// tagsToMapELBv2 turns the list of tags into a map.
+func tagsToMapELBv2(ts []*elbv2.Tag) map[string]string {
+	result := make(map[string]string)
+	for _, t := range ts {
+		result[*t.Key] = *t.Value
+	}
+
+	return result
+}
+
+// readTagsELBv2 returns the tags of the resource with the given ARN.
+func readTagsELBv2(conn *elbv2.ELBV2, arn string) ([]*elbv2.Tag, error) {
+	resp, err := conn.DescribeTags(&elbv2.DescribeTagsInput{
+		ResourceARNs: []*string{aws.String(arn)},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(resp.TagDescriptions) == 0 {
+		return nil, nil
+	}
+
+	return resp.TagDescriptions[0].Tags, nil
+}
@@ -3,10 +3,13 @@ package aws
 import (
 	"fmt"
 	"reflect"
+	"strings"
 	"testing"
 
 	"github.com/awslabs/aws-sdk-go/service/ec2"
+	"github.com/hashicorp/terraform/config"
 	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
 	"github.com/hashicorp/terraform/terraform"
 )
 
@@ -61,6 +64,113 @@ func TestDiffTagsSDK(t *testing.T) {
 	}
 }
 
+func TestDefaultTagsDiffConfig(t *testing.T) {
+	meta := &AWSClient{
+		defaultTags: map[string]interface{}{
+			"team": "ops",
+			"env":  "prod",
+		},
+	}
+
+	cases := []struct {
+		Raw      map[string]interface{}
+		Meta     interface{}
+		Expected map[string]interface{}
+	}{
+		// No tags on the resource
+		{
+			map[string]interface{}{},
+			meta,
+			map[string]interface{}{
+				"team": "ops",
+				"env":  "prod",
+			},
+		},
+
+		// The resource's own tags win
+		{
+			map[string]interface{}{
+				"tags": []map[string]interface{}{
+					map[string]interface{}{
+						"env":  "dev",
+						"Name": "web",
+					},
+				},
+			},
+			meta,
+			map[string]interface{}{
+				"team": "ops",
+				"env":  "dev",
+				"Name": "web",
+			},
+		},
+
+		// No default tags
+		{
+			map[string]interface{}{
+				"tags": map[string]interface{}{
+					"Name": "web",
+				},
+			},
+			&AWSClient{},
+			map[string]interface{}{
+				"Name": "web",
+			},
+		},
+
+		// Not configured
+		{
+			map[string]interface{}{},
+			nil,
+			nil,
+		},
+	}
+
+	for i, tc := range cases {
+		raw, err := config.NewRawConfig(tc.Raw)
+		if err != nil {
+			t.Fatalf("%d: err: %s", i, err)
+		}
+		rc := terraform.NewResourceConfig(raw)
+
+		c, err := defaultTagsDiffConfig(rc, tc.Meta)
+		if err != nil {
+			t.Fatalf("%d: err: %s", i, err)
+		}
+
+		r := &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"tags": tagsSchema(),
+			},
+		}
+		d, err := r.Diff(nil, c)
+		if err != nil {
+			t.Fatalf("%d: err: %s", i, err)
+		}
+
+		actual := make(map[string]interface{})
+		if d != nil {
+			for k, v := range d.Attributes {
+				if k != "tags.#" {
+					actual[strings.TrimPrefix(k, "tags.")] = v.New
+				}
+			}
+		}
+		if len(actual) == 0 {
+			actual = nil
+		}
+		if !reflect.DeepEqual(actual, tc.Expected) {
+			t.Fatalf("%d: bad: %#v", i, actual)
+		}
+
+		if _, ok := tc.Raw["tags"]; !ok {
+			if _, ok := rc.Raw["tags"]; ok {
+				t.Fatalf("%d: configuration was modified", i)
+			}
+		}
+	}
+}
+
 // testAccCheckTags can be used to check the tags on a resource.
 func testAccCheckTagsSDK(
 	ts *[]*ec2.Tag, key string, value string) resource.TestCheckFunc {
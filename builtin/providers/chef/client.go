@@ -0,0 +1,259 @@
+package chef
+
+import (
+	"crypto"
+	"crypto/rsa"
+	"crypto/sha1"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform/helper/httpapi"
+)
+
+// chefVersion is the version of Chef that the client claims to be, which
+// the server uses to pick the version of its API.
+const chefVersion = "11.12.0"
+
+// Client is a client for the API of a Chef server, for the organization
+// of its URL.
+type Client struct {
+	api        *httpapi.Client
+	clientName string
+	key        *rsa.PrivateKey
+}
+
+// Environment is a Chef environment, which constrains the versions of the
+// cookbooks of its nodes and sets their attributes.
+type Environment struct {
+	Name               string                 `json:"name"`
+	Description        string                 `json:"description"`
+	DefaultAttributes  map[string]interface{} `json:"default_attributes"`
+	OverrideAttributes map[string]interface{} `json:"override_attributes"`
+	CookbookVersions   map[string]string      `json:"cookbook_versions"`
+	JSONClass          string                 `json:"json_class"`
+	ChefType           string                 `json:"chef_type"`
+}
+
+// Role is a Chef role, which is a run list and attributes shared by
+// nodes.
+type Role struct {
+	Name               string                 `json:"name"`
+	Description        string                 `json:"description"`
+	DefaultAttributes  map[string]interface{} `json:"default_attributes"`
+	OverrideAttributes map[string]interface{} `json:"override_attributes"`
+	RunList            []string               `json:"run_list"`
+	JSONClass          string                 `json:"json_class"`
+	ChefType           string                 `json:"chef_type"`
+}
+
+// Node is a Chef node, which is a machine configured by Chef.
+type Node struct {
+	Name                string                 `json:"name"`
+	Environment         string                 `json:"chef_environment"`
+	AutomaticAttributes map[string]interface{} `json:"automatic"`
+	NormalAttributes    map[string]interface{} `json:"normal"`
+	DefaultAttributes   map[string]interface{} `json:"default"`
+	OverrideAttributes  map[string]interface{} `json:"override"`
+	RunList             []string               `json:"run_list"`
+	JSONClass           string                 `json:"json_class"`
+	ChefType            string                 `json:"chef_type"`
+}
+
+// Environment returns the environment with the given name.
+func (c *Client) Environment(name string) (*Environment, error) {
+	var result Environment
+	if err := c.do("GET", "/environments/"+name, nil, &result); err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// CreateEnvironment creates the environment.
+func (c *Client) CreateEnvironment(e *Environment) error {
+	return c.do("POST", "/environments", e, nil)
+}
+
+// UpdateEnvironment replaces the environment with the same name.
+func (c *Client) UpdateEnvironment(e *Environment) error {
+	return c.do("PUT", "/environments/"+e.Name, e, nil)
+}
+
+// DeleteEnvironment deletes the environment with the given name.
+func (c *Client) DeleteEnvironment(name string) error {
+	return c.do("DELETE", "/environments/"+name, nil, nil)
+}
+
+// Role returns the role with the given name.
+func (c *Client) Role(name string) (*Role, error) {
+	var result Role
+	if err := c.do("GET", "/roles/"+name, nil, &result); err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// CreateRole creates the role.
+func (c *Client) CreateRole(r *Role) error {
+	return c.do("POST", "/roles", r, nil)
+}
+
+// UpdateRole replaces the role with the same name.
+func (c *Client) UpdateRole(r *Role) error {
+	return c.do("PUT", "/roles/"+r.Name, r, nil)
+}
+
+// DeleteRole deletes the role with the given name.
+func (c *Client) DeleteRole(name string) error {
+	return c.do("DELETE", "/roles/"+name, nil, nil)
+}
+
+// Node returns the node with the given name.
+func (c *Client) Node(name string) (*Node, error) {
+	var result Node
+	if err := c.do("GET", "/nodes/"+name, nil, &result); err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// CreateNode creates the node.
+func (c *Client) CreateNode(n *Node) error {
+	return c.do("POST", "/nodes", n, nil)
+}
+
+// UpdateNode replaces the node with the same name.
+func (c *Client) UpdateNode(n *Node) error {
+	return c.do("PUT", "/nodes/"+n.Name, n, nil)
+}
+
+// DeleteNode deletes the node with the given name.
+func (c *Client) DeleteNode(name string) error {
+	return c.do("DELETE", "/nodes/"+name, nil, nil)
+}
+
+// DataBag returns the items of the data bag with the given name, as a map
+// of their IDs to their URLs.
+func (c *Client) DataBag(name string) (map[string]string, error) {
+	var result map[string]string
+	if err := c.do("GET", "/data/"+name, nil, &result); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// CreateDataBag creates an empty data bag with the given name.
+func (c *Client) CreateDataBag(name string) error {
+	return c.do("POST", "/data", map[string]string{"name": name}, nil)
+}
+
+// DeleteDataBag deletes the data bag with the given name, along with its
+// items.
+func (c *Client) DeleteDataBag(name string) error {
+	return c.do("DELETE", "/data/"+name, nil, nil)
+}
+
+// DataBagItem returns the content of the item with the given ID in the
+// data bag.
+func (c *Client) DataBagItem(bag, id string) (map[string]interface{}, error) {
+	var result map[string]interface{}
+	if err := c.do("GET", "/data/"+bag+"/"+id, nil, &result); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// CreateDataBagItem creates an item in the data bag. The content of the
+// item must have its ID under the "id" key.
+func (c *Client) CreateDataBagItem(bag string, item map[string]interface{}) error {
+	return c.do("POST", "/data/"+bag, item, nil)
+}
+
+// DeleteDataBagItem deletes the item with the given ID in the data bag.
+func (c *Client) DeleteDataBagItem(bag, id string) error {
+	return c.do("DELETE", "/data/"+bag+"/"+id, nil, nil)
+}
+
+func (c *Client) do(method, path string, in, out interface{}) error {
+	return c.api.Do(method, path, in, out)
+}
+
+// sign adds the headers that authenticate the request to the Chef server,
+// as described by version 1.0 of its signing protocol: the canonical form
+// of the request, with hashes of its path and body, is encrypted with the
+// private key of the client.
+func (c *Client) sign(req *http.Request, body []byte, now time.Time) error {
+	timestamp := now.UTC().Format(time.RFC3339)
+	contentHash := hashBase64(body)
+	canonical := strings.Join([]string{
+		"Method:" + strings.ToUpper(req.Method),
+		"Hashed Path:" + hashBase64([]byte(canonicalPath(req.URL.Path))),
+		"X-Ops-Content-Hash:" + contentHash,
+		"X-Ops-Timestamp:" + timestamp,
+		"X-Ops-UserId:" + c.clientName,
+	}, "\n")
+
+	sig, err := rsa.SignPKCS1v15(nil, c.key, crypto.Hash(0), []byte(canonical))
+	if err != nil {
+		return fmt.Errorf("Error signing the Chef request: %s", err)
+	}
+
+	req.Header.Set("X-Ops-Sign", "algorithm=sha1;version=1.0")
+	req.Header.Set("X-Ops-Userid", c.clientName)
+	req.Header.Set("X-Ops-Timestamp", timestamp)
+	req.Header.Set("X-Ops-Content-Hash", contentHash)
+
+	// The signature is split over as many headers as it takes, in lines
+	// of 60 characters.
+	encoded := base64.StdEncoding.EncodeToString(sig)
+	for i := 1; len(encoded) > 0; i++ {
+		n := 60
+		if len(encoded) < n {
+			n = len(encoded)
+		}
+		req.Header.Set(fmt.Sprintf("X-Ops-Authorization-%d", i), encoded[:n])
+		encoded = encoded[n:]
+	}
+
+	return nil
+}
+
+// errorMessage returns the messages of the errors in the body of a failed
+// answer.
+func errorMessage(body []byte) string {
+	var status struct {
+		Error []string `json:"error"`
+	}
+	if err := json.Unmarshal(body, &status); err != nil {
+		return ""
+	}
+
+	return strings.Join(status.Error, "; ")
+}
+
+// canonicalPath returns the path in the form that is signed, without
+// repeated or trailing slashes.
+func canonicalPath(path string) string {
+	for strings.Contains(path, "//") {
+		path = strings.Replace(path, "//", "/", -1)
+	}
+	if len(path) > 1 {
+		path = strings.TrimSuffix(path, "/")
+	}
+
+	return path
+}
+
+// hashBase64 returns the base64 encoded SHA1 hash of the data.
+func hashBase64(data []byte) string {
+	sum := sha1.Sum(data)
+	return base64.StdEncoding.EncodeToString(sum[:])
+}
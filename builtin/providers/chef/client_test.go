@@ -0,0 +1,85 @@
+package chef
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestClientSign(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	c := &Client{clientName: "terraform", key: key}
+
+	req, err := http.NewRequest(
+		"post", "https://chef.example.com/organizations/acme/roles/", nil)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	body := []byte(`{"name":"web"}`)
+	now := time.Date(2015, 4, 1, 12, 30, 0, 0, time.UTC)
+	if err := c.sign(req, body, now); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	if v := req.Header.Get("X-Ops-Timestamp"); v != "2015-04-01T12:30:00Z" {
+		t.Fatalf("bad timestamp: %s", v)
+	}
+	if v := req.Header.Get("X-Ops-Content-Hash"); v != hashBase64(body) {
+		t.Fatalf("bad content hash: %s", v)
+	}
+
+	var lines []string
+	for i := 1; ; i++ {
+		v := req.Header.Get(fmt.Sprintf("X-Ops-Authorization-%d", i))
+		if v == "" {
+			break
+		}
+		if len(v) > 60 {
+			t.Fatalf("line %d is too long: %s", i, v)
+		}
+		lines = append(lines, v)
+	}
+	if len(lines) < 2 {
+		t.Fatalf("bad authorization headers: %#v", req.Header)
+	}
+
+	sig, err := base64.StdEncoding.DecodeString(strings.Join(lines, ""))
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	canonical := strings.Join([]string{
+		"Method:POST",
+		"Hashed Path:" + hashBase64([]byte("/organizations/acme/roles")),
+		"X-Ops-Content-Hash:" + hashBase64(body),
+		"X-Ops-Timestamp:2015-04-01T12:30:00Z",
+		"X-Ops-UserId:terraform",
+	}, "\n")
+	err = rsa.VerifyPKCS1v15(&key.PublicKey, crypto.Hash(0), []byte(canonical), sig)
+	if err != nil {
+		t.Fatalf("bad signature: %s", err)
+	}
+}
+
+func TestCanonicalPath(t *testing.T) {
+	cases := map[string]string{
+		"/":                            "/",
+		"/roles":                       "/roles",
+		"/roles/":                      "/roles",
+		"/organizations//acme///nodes": "/organizations/acme/nodes",
+	}
+
+	for input, expected := range cases {
+		if actual := canonicalPath(input); actual != expected {
+			t.Fatalf("%s: bad: %s", input, actual)
+		}
+	}
+}
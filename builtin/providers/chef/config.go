@@ -0,0 +1,67 @@
+package chef
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform/helper/httpapi"
+)
+
+type Config struct {
+	ServerURL          string
+	ClientName         string
+	PrivateKeyPEM      string
+	AllowUnverifiedSSL bool
+}
+
+// Client returns a new client for the Chef server API.
+func (c *Config) Client() (*Client, error) {
+	u, err := url.Parse(c.ServerURL)
+	if err != nil || u.Scheme == "" || u.Host == "" {
+		return nil, fmt.Errorf("Invalid Chef server URL %q", c.ServerURL)
+	}
+
+	block, _ := pem.Decode([]byte(c.PrivateKeyPEM))
+	if block == nil {
+		return nil, fmt.Errorf("Error decoding the Chef private key: no PEM data found")
+	}
+	key, err := x509.ParsePKCS1PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("Error parsing the Chef private key: %s", err)
+	}
+
+	httpClient := http.DefaultClient
+	if c.AllowUnverifiedSSL {
+		httpClient = &http.Client{
+			Transport: &http.Transport{
+				Proxy:           http.ProxyFromEnvironment,
+				TLSClientConfig: &tls.Config{InsecureSkipVerify: true},
+			},
+		}
+	}
+
+	log.Printf("[INFO] Chef client configured for %s as %s", c.ServerURL, c.ClientName)
+	client := &Client{
+		clientName: c.ClientName,
+		key:        key,
+	}
+	client.api = &httpapi.Client{
+		BaseURL:      strings.TrimSuffix(c.ServerURL, "/"),
+		Name:         "Chef",
+		HTTP:         httpClient,
+		Header:       http.Header{"X-Chef-Version": []string{chefVersion}},
+		ErrorMessage: errorMessage,
+		Sign: func(req *http.Request, body []byte) error {
+			return client.sign(req, body, time.Now())
+		},
+	}
+
+	return client, nil
+}
@@ -0,0 +1,84 @@
+package chef
+
+import (
+	"fmt"
+	"io/ioutil"
+	"log"
+	"os"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+// Provider returns a terraform.ResourceProvider.
+func Provider() terraform.ResourceProvider {
+	return &schema.Provider{
+		Schema: map[string]*schema.Schema{
+			"server_url": &schema.Schema{
+				Type:        schema.TypeString,
+				Required:    true,
+				DefaultFunc: schema.EnvDefaultFunc("CHEF_SERVER_URL", nil),
+				Description: "The URL of the Chef server, including the organization.",
+			},
+
+			"client_name": &schema.Schema{
+				Type:        schema.TypeString,
+				Required:    true,
+				DefaultFunc: schema.EnvDefaultFunc("CHEF_CLIENT_NAME", nil),
+				Description: "The name of the client or user to authenticate as.",
+			},
+
+			"private_key_pem": &schema.Schema{
+				Type:        schema.TypeString,
+				Required:    true,
+				DefaultFunc: privateKeyFileDefault,
+				Description: "The PEM encoded private key of the client.",
+			},
+
+			"allow_unverified_ssl": &schema.Schema{
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether to skip the verification of the certificate of the Chef server.",
+			},
+		},
+
+		ResourcesMap: map[string]*schema.Resource{
+			"chef_data_bag":      resourceChefDataBag(),
+			"chef_data_bag_item": resourceChefDataBagItem(),
+			"chef_environment":   resourceChefEnvironment(),
+			"chef_node":          resourceChefNode(),
+			"chef_role":          resourceChefRole(),
+		},
+
+		ConfigureFunc: providerConfigure,
+	}
+}
+
+// privateKeyFileDefault is the default of private_key_pem: the contents of
+// the file named by CHEF_PRIVATE_KEY_FILE, if it is set.
+func privateKeyFileDefault() (interface{}, error) {
+	path := os.Getenv("CHEF_PRIVATE_KEY_FILE")
+	if path == "" {
+		return nil, nil
+	}
+
+	raw, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("Error reading CHEF_PRIVATE_KEY_FILE: %s", err)
+	}
+
+	return string(raw), nil
+}
+
+func providerConfigure(d *schema.ResourceData) (interface{}, error) {
+	config := Config{
+		ServerURL:          d.Get("server_url").(string),
+		ClientName:         d.Get("client_name").(string),
+		PrivateKeyPEM:      d.Get("private_key_pem").(string),
+		AllowUnverifiedSSL: d.Get("allow_unverified_ssl").(bool),
+	}
+
+	log.Println("[INFO] Initializing Chef client")
+	return config.Client()
+}
@@ -0,0 +1,74 @@
+package chef
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform/helper/httpapi"
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func resourceChefDataBag() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceChefDataBagCreate,
+		Read:   resourceChefDataBagRead,
+		Delete: resourceChefDataBagDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"name": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+		},
+	}
+}
+
+func resourceChefDataBagCreate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Client)
+
+	name := d.Get("name").(string)
+	log.Printf("[DEBUG] Creating Chef data bag: %s", name)
+	if err := client.CreateDataBag(name); err != nil {
+		return fmt.Errorf("Error creating Chef data bag %s: %s", name, err)
+	}
+
+	d.SetId(name)
+	log.Printf("[INFO] Chef data bag created: %s", d.Id())
+
+	return resourceChefDataBagRead(d, meta)
+}
+
+func resourceChefDataBagRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Client)
+
+	if _, err := client.DataBag(d.Id()); err != nil {
+		if httpapi.IsNotFound(err) {
+			d.SetId("")
+			return nil
+		}
+		return fmt.Errorf("Error reading Chef data bag %s: %s", d.Id(), err)
+	}
+
+	d.Set("name", d.Id())
+
+	return nil
+}
+
+func resourceChefDataBagDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Client)
+
+	log.Printf("[INFO] Deleting Chef data bag: %s", d.Id())
+	if err := client.DeleteDataBag(d.Id()); err != nil {
+		if httpapi.IsNotFound(err) {
+			return nil
+		}
+		return fmt.Errorf("Error deleting Chef data bag %s: %s", d.Id(), err)
+	}
+
+	d.SetId("")
+	return nil
+}
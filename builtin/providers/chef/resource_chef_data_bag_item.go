@@ -0,0 +1,138 @@
+package chef
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/httpapi"
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func resourceChefDataBagItem() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceChefDataBagItemCreate,
+		Read:   resourceChefDataBagItemRead,
+		Delete: resourceChefDataBagItemDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"data_bag_name": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"content_json": &schema.Schema{
+				Type:      schema.TypeString,
+				Required:  true,
+				ForceNew:  true,
+				StateFunc: normalizeJSON,
+			},
+
+			"item_id": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceChefDataBagItemCreate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Client)
+
+	bag := d.Get("data_bag_name").(string)
+	item, err := expandJSONObject(d, "content_json")
+	if err != nil {
+		return err
+	}
+	id, ok := item["id"].(string)
+	if !ok || id == "" {
+		return fmt.Errorf("content_json must have a string \"id\" field")
+	}
+
+	log.Printf("[DEBUG] Creating Chef data bag item: %s/%s", bag, id)
+	if err := client.CreateDataBagItem(bag, item); err != nil {
+		return fmt.Errorf(
+			"Error creating Chef data bag item %s/%s: %s", bag, id, err)
+	}
+
+	d.SetId(itemId(bag, id))
+	log.Printf("[INFO] Chef data bag item created: %s", d.Id())
+
+	return resourceChefDataBagItemRead(d, meta)
+}
+
+func resourceChefDataBagItemRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Client)
+
+	bag, id, err := parseItemId(d.Id())
+	if err != nil {
+		return err
+	}
+
+	item, err := client.DataBagItem(bag, id)
+	if err != nil {
+		if httpapi.IsNotFound(err) {
+			d.SetId("")
+			return nil
+		}
+		return fmt.Errorf("Error reading Chef data bag item %s: %s", d.Id(), err)
+	}
+
+	// The server adds the name of the data bag and the type of the object
+	// to the items it returns, which aren't part of the content.
+	delete(item, "chef_type")
+	delete(item, "data_bag")
+
+	content, err := json.Marshal(item)
+	if err != nil {
+		return err
+	}
+
+	d.Set("data_bag_name", bag)
+	d.Set("item_id", id)
+	d.Set("content_json", string(content))
+
+	return nil
+}
+
+func resourceChefDataBagItemDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Client)
+
+	bag, id, err := parseItemId(d.Id())
+	if err != nil {
+		return err
+	}
+
+	log.Printf("[INFO] Deleting Chef data bag item: %s", d.Id())
+	if err := client.DeleteDataBagItem(bag, id); err != nil {
+		if httpapi.IsNotFound(err) {
+			return nil
+		}
+		return fmt.Errorf("Error deleting Chef data bag item %s: %s", d.Id(), err)
+	}
+
+	d.SetId("")
+	return nil
+}
+
+// itemId returns the ID of the resource for an item of a data bag, which
+// is "BAG/ITEM".
+func itemId(bag, id string) string {
+	return fmt.Sprintf("%s/%s", bag, id)
+}
+
+// parseItemId returns the data bag and the ID of the item in the ID of a
+// resource, as returned by itemId.
+func parseItemId(id string) (string, string, error) {
+	parts := strings.Split(id, "/")
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return "", "", fmt.Errorf("Unexpected ID %q, expected BAG/ITEM", id)
+	}
+
+	return parts[0], parts[1], nil
+}
@@ -0,0 +1,106 @@
+package chef
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/httpapi"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestAccChefDataBagItem_basic(t *testing.T) {
+	var item map[string]interface{}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckChefDataBagItemDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccChefDataBagItemConfig_basic,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckChefDataBagItemExists("chef_data_bag_item.test", &item),
+					resource.TestCheckResourceAttr(
+						"chef_data_bag_item.test", "item_id", "alice"),
+					resource.TestCheckResourceAttr(
+						"chef_data_bag_item.test", "id", "terraform-acc-test/alice"),
+					func(*terraform.State) error {
+						if v := item["shell"]; v != "/bin/zsh" {
+							return fmt.Errorf("bad shell: %#v", v)
+						}
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckChefDataBagItemExists(n string, item *map[string]interface{}) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		bag, id, err := parseItemId(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+
+		client := testAccProvider.Meta().(*Client)
+		result, err := client.DataBagItem(bag, id)
+		if err != nil {
+			return err
+		}
+
+		*item = result
+		return nil
+	}
+}
+
+func testAccCheckChefDataBagItemDestroy(s *terraform.State) error {
+	client := testAccProvider.Meta().(*Client)
+
+	for _, rs := range s.RootModule().Resources {
+		var err error
+		switch rs.Type {
+		case "chef_data_bag":
+			_, err = client.DataBag(rs.Primary.ID)
+		case "chef_data_bag_item":
+			bag, id, perr := parseItemId(rs.Primary.ID)
+			if perr != nil {
+				return perr
+			}
+			_, err = client.DataBagItem(bag, id)
+		default:
+			continue
+		}
+
+		if err == nil {
+			return fmt.Errorf("%s still exists: %s", rs.Type, rs.Primary.ID)
+		}
+		if !httpapi.IsNotFound(err) {
+			return err
+		}
+	}
+
+	return nil
+}
+
+const testAccChefDataBagItemConfig_basic = `
+resource "chef_data_bag" "test" {
+	name = "terraform-acc-test"
+}
+
+resource "chef_data_bag_item" "test" {
+	data_bag_name = "${chef_data_bag.test.name}"
+	content_json = <<EOT
+{
+	"id": "alice",
+	"shell": "/bin/zsh"
+}
+EOT
+}
+`
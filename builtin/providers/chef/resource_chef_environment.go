@@ -0,0 +1,150 @@
+package chef
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform/helper/httpapi"
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func resourceChefEnvironment() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceChefEnvironmentCreate,
+		Read:   resourceChefEnvironmentRead,
+		Update: resourceChefEnvironmentUpdate,
+		Delete: resourceChefEnvironmentDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"name": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"description": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  "Managed by Terraform",
+			},
+
+			"default_attributes_json":  attributesSchema(),
+			"override_attributes_json": attributesSchema(),
+
+			"cookbook_constraints": &schema.Schema{
+				Type:     schema.TypeMap,
+				Optional: true,
+			},
+		},
+	}
+}
+
+func resourceChefEnvironmentCreate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Client)
+
+	e, err := expandEnvironment(d)
+	if err != nil {
+		return err
+	}
+
+	log.Printf("[DEBUG] Creating Chef environment: %s", e.Name)
+	if err := client.CreateEnvironment(e); err != nil {
+		return fmt.Errorf("Error creating Chef environment %s: %s", e.Name, err)
+	}
+
+	d.SetId(e.Name)
+	log.Printf("[INFO] Chef environment created: %s", d.Id())
+
+	return resourceChefEnvironmentRead(d, meta)
+}
+
+func resourceChefEnvironmentRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Client)
+
+	e, err := client.Environment(d.Id())
+	if err != nil {
+		if httpapi.IsNotFound(err) {
+			d.SetId("")
+			return nil
+		}
+		return fmt.Errorf("Error reading Chef environment %s: %s", d.Id(), err)
+	}
+
+	defaults, err := flattenJSONObject(e.DefaultAttributes)
+	if err != nil {
+		return err
+	}
+	overrides, err := flattenJSONObject(e.OverrideAttributes)
+	if err != nil {
+		return err
+	}
+
+	d.Set("name", e.Name)
+	d.Set("description", e.Description)
+	d.Set("default_attributes_json", defaults)
+	d.Set("override_attributes_json", overrides)
+	d.Set("cookbook_constraints", e.CookbookVersions)
+
+	return nil
+}
+
+func resourceChefEnvironmentUpdate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Client)
+
+	e, err := expandEnvironment(d)
+	if err != nil {
+		return err
+	}
+
+	log.Printf("[DEBUG] Updating Chef environment: %s", d.Id())
+	if err := client.UpdateEnvironment(e); err != nil {
+		return fmt.Errorf("Error updating Chef environment %s: %s", d.Id(), err)
+	}
+
+	return resourceChefEnvironmentRead(d, meta)
+}
+
+func resourceChefEnvironmentDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Client)
+
+	log.Printf("[INFO] Deleting Chef environment: %s", d.Id())
+	if err := client.DeleteEnvironment(d.Id()); err != nil {
+		if httpapi.IsNotFound(err) {
+			return nil
+		}
+		return fmt.Errorf("Error deleting Chef environment %s: %s", d.Id(), err)
+	}
+
+	d.SetId("")
+	return nil
+}
+
+// expandEnvironment returns the environment of the resource.
+func expandEnvironment(d *schema.ResourceData) (*Environment, error) {
+	defaults, err := expandJSONObject(d, "default_attributes_json")
+	if err != nil {
+		return nil, err
+	}
+	overrides, err := expandJSONObject(d, "override_attributes_json")
+	if err != nil {
+		return nil, err
+	}
+
+	constraints := make(map[string]string)
+	for k, v := range d.Get("cookbook_constraints").(map[string]interface{}) {
+		constraints[k] = v.(string)
+	}
+
+	return &Environment{
+		Name:               d.Get("name").(string),
+		Description:        d.Get("description").(string),
+		DefaultAttributes:  defaults,
+		OverrideAttributes: overrides,
+		CookbookVersions:   constraints,
+		JSONClass:          "Chef::Environment",
+		ChefType:           "environment",
+	}, nil
+}
@@ -0,0 +1,116 @@
+package chef
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/httpapi"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestAccChefEnvironment_basic(t *testing.T) {
+	var env Environment
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckChefEnvironmentDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccChefEnvironmentConfig_basic,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckChefEnvironmentExists("chef_environment.test", &env),
+					resource.TestCheckResourceAttr(
+						"chef_environment.test", "description", "Terraform acceptance tests"),
+					resource.TestCheckResourceAttr(
+						"chef_environment.test", "default_attributes_json", `{"terraform":{"test":true}}`),
+					resource.TestCheckResourceAttr(
+						"chef_environment.test", "cookbook_constraints.nginx", ">= 2.0.0"),
+				),
+			},
+			resource.TestStep{
+				Config: testAccChefEnvironmentConfig_update,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckChefEnvironmentExists("chef_environment.test", &env),
+					resource.TestCheckResourceAttr(
+						"chef_environment.test", "override_attributes_json", `{"port":8080}`),
+					func(*terraform.State) error {
+						if v := env.CookbookVersions["nginx"]; v != "~> 2.7" {
+							return fmt.Errorf("bad nginx constraint: %s", v)
+						}
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckChefEnvironmentExists(n string, env *Environment) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No environment name is set")
+		}
+
+		client := testAccProvider.Meta().(*Client)
+		result, err := client.Environment(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+
+		*env = *result
+		return nil
+	}
+}
+
+func testAccCheckChefEnvironmentDestroy(s *terraform.State) error {
+	client := testAccProvider.Meta().(*Client)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "chef_environment" {
+			continue
+		}
+
+		_, err := client.Environment(rs.Primary.ID)
+		if err == nil {
+			return fmt.Errorf("Environment still exists: %s", rs.Primary.ID)
+		}
+		if !httpapi.IsNotFound(err) {
+			return err
+		}
+	}
+
+	return nil
+}
+
+const testAccChefEnvironmentConfig_basic = `
+resource "chef_environment" "test" {
+	name = "terraform-acc-test"
+	description = "Terraform acceptance tests"
+	default_attributes_json = <<EOT
+{
+	"terraform": { "test": true }
+}
+EOT
+	cookbook_constraints {
+		nginx = ">= 2.0.0"
+	}
+}
+`
+
+const testAccChefEnvironmentConfig_update = `
+resource "chef_environment" "test" {
+	name = "terraform-acc-test"
+	description = "Terraform acceptance tests"
+	override_attributes_json = "{\"port\": 8080}"
+	cookbook_constraints {
+		nginx = "~> 2.7"
+	}
+}
+`
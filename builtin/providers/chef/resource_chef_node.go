@@ -0,0 +1,165 @@
+package chef
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform/helper/httpapi"
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func resourceChefNode() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceChefNodeCreate,
+		Read:   resourceChefNodeRead,
+		Update: resourceChefNodeUpdate,
+		Delete: resourceChefNodeDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"name": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"environment_name": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  "_default",
+			},
+
+			"normal_attributes_json":   attributesSchema(),
+			"default_attributes_json":  attributesSchema(),
+			"override_attributes_json": attributesSchema(),
+			"run_list":                 runListSchema(),
+		},
+	}
+}
+
+func resourceChefNodeCreate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Client)
+
+	n, err := expandNode(d)
+	if err != nil {
+		return err
+	}
+
+	log.Printf("[DEBUG] Creating Chef node: %s", n.Name)
+	if err := client.CreateNode(n); err != nil {
+		return fmt.Errorf("Error creating Chef node %s: %s", n.Name, err)
+	}
+
+	d.SetId(n.Name)
+	log.Printf("[INFO] Chef node created: %s", d.Id())
+
+	return resourceChefNodeRead(d, meta)
+}
+
+func resourceChefNodeRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Client)
+
+	n, err := client.Node(d.Id())
+	if err != nil {
+		if httpapi.IsNotFound(err) {
+			d.SetId("")
+			return nil
+		}
+		return fmt.Errorf("Error reading Chef node %s: %s", d.Id(), err)
+	}
+
+	normals, err := flattenJSONObject(n.NormalAttributes)
+	if err != nil {
+		return err
+	}
+	defaults, err := flattenJSONObject(n.DefaultAttributes)
+	if err != nil {
+		return err
+	}
+	overrides, err := flattenJSONObject(n.OverrideAttributes)
+	if err != nil {
+		return err
+	}
+
+	d.Set("name", n.Name)
+	d.Set("environment_name", n.Environment)
+	d.Set("normal_attributes_json", normals)
+	d.Set("default_attributes_json", defaults)
+	d.Set("override_attributes_json", overrides)
+	if err := d.Set("run_list", n.RunList); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func resourceChefNodeUpdate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Client)
+
+	n, err := expandNode(d)
+	if err != nil {
+		return err
+	}
+
+	// The automatic attributes are those that chef-client discovers on
+	// the node, such as its IP addresses. They aren't managed by
+	// Terraform, but the server replaces the whole node on update, so
+	// they are sent back as they are.
+	current, err := client.Node(d.Id())
+	if err != nil {
+		return fmt.Errorf("Error reading Chef node %s: %s", d.Id(), err)
+	}
+	n.AutomaticAttributes = current.AutomaticAttributes
+
+	log.Printf("[DEBUG] Updating Chef node: %s", d.Id())
+	if err := client.UpdateNode(n); err != nil {
+		return fmt.Errorf("Error updating Chef node %s: %s", d.Id(), err)
+	}
+
+	return resourceChefNodeRead(d, meta)
+}
+
+func resourceChefNodeDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Client)
+
+	log.Printf("[INFO] Deleting Chef node: %s", d.Id())
+	if err := client.DeleteNode(d.Id()); err != nil {
+		if httpapi.IsNotFound(err) {
+			return nil
+		}
+		return fmt.Errorf("Error deleting Chef node %s: %s", d.Id(), err)
+	}
+
+	d.SetId("")
+	return nil
+}
+
+// expandNode returns the node of the resource.
+func expandNode(d *schema.ResourceData) (*Node, error) {
+	normals, err := expandJSONObject(d, "normal_attributes_json")
+	if err != nil {
+		return nil, err
+	}
+	defaults, err := expandJSONObject(d, "default_attributes_json")
+	if err != nil {
+		return nil, err
+	}
+	overrides, err := expandJSONObject(d, "override_attributes_json")
+	if err != nil {
+		return nil, err
+	}
+
+	return &Node{
+		Name:                d.Get("name").(string),
+		Environment:         d.Get("environment_name").(string),
+		AutomaticAttributes: map[string]interface{}{},
+		NormalAttributes:    normals,
+		DefaultAttributes:   defaults,
+		OverrideAttributes:  overrides,
+		RunList:             expandRunList(d, "run_list"),
+		JSONClass:           "Chef::Node",
+		ChefType:            "node",
+	}, nil
+}
@@ -0,0 +1,108 @@
+package chef
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/httpapi"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestAccChefNode_basic(t *testing.T) {
+	var node Node
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckChefNodeDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccChefNodeConfig_basic,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckChefNodeExists("chef_node.test", &node),
+					resource.TestCheckResourceAttr(
+						"chef_node.test", "environment_name", "_default"),
+					resource.TestCheckResourceAttr(
+						"chef_node.test", "run_list.0", "role[web]"),
+				),
+			},
+			resource.TestStep{
+				Config: testAccChefNodeConfig_update,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckChefNodeExists("chef_node.test", &node),
+					resource.TestCheckResourceAttr(
+						"chef_node.test", "environment_name", "terraform-acc-test"),
+					func(*terraform.State) error {
+						if v := node.NormalAttributes["owner"]; v != "ops" {
+							return fmt.Errorf("bad owner: %#v", v)
+						}
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckChefNodeExists(n string, node *Node) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No node name is set")
+		}
+
+		client := testAccProvider.Meta().(*Client)
+		result, err := client.Node(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+
+		*node = *result
+		return nil
+	}
+}
+
+func testAccCheckChefNodeDestroy(s *terraform.State) error {
+	client := testAccProvider.Meta().(*Client)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "chef_node" {
+			continue
+		}
+
+		_, err := client.Node(rs.Primary.ID)
+		if err == nil {
+			return fmt.Errorf("Node still exists: %s", rs.Primary.ID)
+		}
+		if !httpapi.IsNotFound(err) {
+			return err
+		}
+	}
+
+	return nil
+}
+
+const testAccChefNodeConfig_basic = `
+resource "chef_node" "test" {
+	name = "terraform-acc-test"
+	run_list = ["role[web]"]
+}
+`
+
+const testAccChefNodeConfig_update = `
+resource "chef_environment" "test" {
+	name = "terraform-acc-test"
+}
+
+resource "chef_node" "test" {
+	name = "terraform-acc-test"
+	environment_name = "${chef_environment.test.name}"
+	run_list = ["role[web]"]
+	normal_attributes_json = "{\"owner\": \"ops\"}"
+}
+`
@@ -0,0 +1,143 @@
+package chef
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform/helper/httpapi"
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func resourceChefRole() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceChefRoleCreate,
+		Read:   resourceChefRoleRead,
+		Update: resourceChefRoleUpdate,
+		Delete: resourceChefRoleDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"name": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"description": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  "Managed by Terraform",
+			},
+
+			"default_attributes_json":  attributesSchema(),
+			"override_attributes_json": attributesSchema(),
+			"run_list":                 runListSchema(),
+		},
+	}
+}
+
+func resourceChefRoleCreate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Client)
+
+	r, err := expandRole(d)
+	if err != nil {
+		return err
+	}
+
+	log.Printf("[DEBUG] Creating Chef role: %s", r.Name)
+	if err := client.CreateRole(r); err != nil {
+		return fmt.Errorf("Error creating Chef role %s: %s", r.Name, err)
+	}
+
+	d.SetId(r.Name)
+	log.Printf("[INFO] Chef role created: %s", d.Id())
+
+	return resourceChefRoleRead(d, meta)
+}
+
+func resourceChefRoleRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Client)
+
+	r, err := client.Role(d.Id())
+	if err != nil {
+		if httpapi.IsNotFound(err) {
+			d.SetId("")
+			return nil
+		}
+		return fmt.Errorf("Error reading Chef role %s: %s", d.Id(), err)
+	}
+
+	defaults, err := flattenJSONObject(r.DefaultAttributes)
+	if err != nil {
+		return err
+	}
+	overrides, err := flattenJSONObject(r.OverrideAttributes)
+	if err != nil {
+		return err
+	}
+
+	d.Set("name", r.Name)
+	d.Set("description", r.Description)
+	d.Set("default_attributes_json", defaults)
+	d.Set("override_attributes_json", overrides)
+	if err := d.Set("run_list", r.RunList); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func resourceChefRoleUpdate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Client)
+
+	r, err := expandRole(d)
+	if err != nil {
+		return err
+	}
+
+	log.Printf("[DEBUG] Updating Chef role: %s", d.Id())
+	if err := client.UpdateRole(r); err != nil {
+		return fmt.Errorf("Error updating Chef role %s: %s", d.Id(), err)
+	}
+
+	return resourceChefRoleRead(d, meta)
+}
+
+func resourceChefRoleDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Client)
+
+	log.Printf("[INFO] Deleting Chef role: %s", d.Id())
+	if err := client.DeleteRole(d.Id()); err != nil {
+		if httpapi.IsNotFound(err) {
+			return nil
+		}
+		return fmt.Errorf("Error deleting Chef role %s: %s", d.Id(), err)
+	}
+
+	d.SetId("")
+	return nil
+}
+
+// expandRole returns the role of the resource.
+func expandRole(d *schema.ResourceData) (*Role, error) {
+	defaults, err := expandJSONObject(d, "default_attributes_json")
+	if err != nil {
+		return nil, err
+	}
+	overrides, err := expandJSONObject(d, "override_attributes_json")
+	if err != nil {
+		return nil, err
+	}
+
+	return &Role{
+		Name:               d.Get("name").(string),
+		Description:        d.Get("description").(string),
+		DefaultAttributes:  defaults,
+		OverrideAttributes: overrides,
+		RunList:            expandRunList(d, "run_list"),
+		JSONClass:          "Chef::Role",
+		ChefType:           "role",
+	}, nil
+}
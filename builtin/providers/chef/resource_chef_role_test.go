@@ -0,0 +1,105 @@
+package chef
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/httpapi"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestAccChefRole_basic(t *testing.T) {
+	var role Role
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckChefRoleDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccChefRoleConfig_basic,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckChefRoleExists("chef_role.test", &role),
+					resource.TestCheckResourceAttr(
+						"chef_role.test", "run_list.#", "1"),
+					resource.TestCheckResourceAttr(
+						"chef_role.test", "run_list.0", "recipe[nginx]"),
+				),
+			},
+			resource.TestStep{
+				Config: testAccChefRoleConfig_update,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckChefRoleExists("chef_role.test", &role),
+					resource.TestCheckResourceAttr(
+						"chef_role.test", "run_list.#", "2"),
+					func(*terraform.State) error {
+						expected := []string{"recipe[nginx]", "role[base]"}
+						if !reflect.DeepEqual(role.RunList, expected) {
+							return fmt.Errorf("bad run list: %#v", role.RunList)
+						}
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckChefRoleExists(n string, role *Role) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No role name is set")
+		}
+
+		client := testAccProvider.Meta().(*Client)
+		result, err := client.Role(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+
+		*role = *result
+		return nil
+	}
+}
+
+func testAccCheckChefRoleDestroy(s *terraform.State) error {
+	client := testAccProvider.Meta().(*Client)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "chef_role" {
+			continue
+		}
+
+		_, err := client.Role(rs.Primary.ID)
+		if err == nil {
+			return fmt.Errorf("Role still exists: %s", rs.Primary.ID)
+		}
+		if !httpapi.IsNotFound(err) {
+			return err
+		}
+	}
+
+	return nil
+}
+
+const testAccChefRoleConfig_basic = `
+resource "chef_role" "test" {
+	name = "terraform-acc-test"
+	run_list = ["recipe[nginx]"]
+}
+`
+
+const testAccChefRoleConfig_update = `
+resource "chef_role" "test" {
+	name = "terraform-acc-test"
+	run_list = ["recipe[nginx]", "role[base]"]
+	default_attributes_json = "{\"nginx\": {\"worker_processes\": 4}}"
+}
+`
@@ -0,0 +1,95 @@
+package chef
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+// attributesSchema returns the schema of a JSON object of attributes, such
+// as the default attributes of a role.
+func attributesSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:      schema.TypeString,
+		Optional:  true,
+		Default:   "{}",
+		StateFunc: normalizeJSON,
+	}
+}
+
+// runListSchema returns the schema of a run list, whose entries are
+// recipes and roles such as "recipe[nginx]" and "role[web]".
+func runListSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeList,
+		Optional: true,
+		Elem:     &schema.Schema{Type: schema.TypeString},
+	}
+}
+
+// normalizeJSON returns the given JSON document in a canonical form, with
+// the keys of objects sorted and without insignificant whitespace, so that
+// documents that only differ in formatting compare as equal. Invalid JSON
+// is returned as is, to be rejected when it is expanded.
+func normalizeJSON(v interface{}) string {
+	s, ok := v.(string)
+	if !ok || s == "" {
+		return ""
+	}
+
+	var doc interface{}
+	if err := json.Unmarshal([]byte(s), &doc); err != nil {
+		return s
+	}
+
+	b, err := json.Marshal(doc)
+	if err != nil {
+		return s
+	}
+
+	return string(b)
+}
+
+// expandJSONObject returns the JSON object of the given key of the
+// configuration, such as the attributes of a role.
+func expandJSONObject(d *schema.ResourceData, key string) (map[string]interface{}, error) {
+	result := make(map[string]interface{})
+	s := d.Get(key).(string)
+	if s == "" {
+		return result, nil
+	}
+
+	if err := json.Unmarshal([]byte(s), &result); err != nil {
+		return nil, fmt.Errorf("Error parsing %s: %s", key, err)
+	}
+
+	return result, nil
+}
+
+// flattenJSONObject returns the JSON document of the object for the
+// configuration.
+func flattenJSONObject(m map[string]interface{}) (string, error) {
+	if m == nil {
+		m = make(map[string]interface{})
+	}
+
+	b, err := json.Marshal(m)
+	if err != nil {
+		return "", err
+	}
+
+	return string(b), nil
+}
+
+// expandRunList returns the run list of the given key of the
+// configuration.
+func expandRunList(d *schema.ResourceData, key string) []string {
+	raw := d.Get(key).([]interface{})
+	result := make([]string, len(raw))
+	for i, v := range raw {
+		result[i] = v.(string)
+	}
+
+	return result
+}
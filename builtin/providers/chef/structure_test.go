@@ -0,0 +1,37 @@
+package chef
+
+import (
+	"testing"
+)
+
+func TestNormalizeJSON(t *testing.T) {
+	cases := map[string]string{
+		"":                                  "",
+		"{}":                                "{}",
+		`{ "b": 1,  "a": { "c": [1, 2] } }`: `{"a":{"c":[1,2]},"b":1}`,
+		"{\n\t\"port\": 8080\n}":            `{"port":8080}`,
+		"not json":                          "not json",
+	}
+
+	for input, expected := range cases {
+		if actual := normalizeJSON(input); actual != expected {
+			t.Fatalf("%q: bad: %s", input, actual)
+		}
+	}
+}
+
+func TestParseItemId(t *testing.T) {
+	bag, id, err := parseItemId(itemId("users", "alice"))
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if bag != "users" || id != "alice" {
+		t.Fatalf("bad: %s %s", bag, id)
+	}
+
+	for _, invalid := range []string{"users", "users/", "/alice", "a/b/c"} {
+		if _, _, err := parseItemId(invalid); err == nil {
+			t.Fatalf("%s: should error", invalid)
+		}
+	}
+}
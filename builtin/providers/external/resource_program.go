@@ -0,0 +1,149 @@
+package external
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os/exec"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func resourceProgram() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceProgramCreate,
+		Read:   resourceProgramRead,
+		Delete: resourceProgramDelete,
+
+		Schema: map[string]*schema.Schema{
+			"program": &schema.Schema{
+				Type:     schema.TypeList,
+				Required: true,
+				ForceNew: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+
+			"query": &schema.Schema{
+				Type:     schema.TypeMap,
+				Optional: true,
+				ForceNew: true,
+			},
+
+			"working_dir": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+
+			"result": &schema.Schema{
+				Type:     schema.TypeMap,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceProgramCreate(d *schema.ResourceData, meta interface{}) error {
+	return resourceProgramRead(d, meta)
+}
+
+func resourceProgramRead(d *schema.ResourceData, meta interface{}) error {
+	var program []string
+	for _, v := range d.Get("program").([]interface{}) {
+		program = append(program, v.(string))
+	}
+	query := make(map[string]string)
+	for k, v := range d.Get("query").(map[string]interface{}) {
+		query[k] = v.(string)
+	}
+
+	result, err := runProgram(program, query, d.Get("working_dir").(string))
+	if err != nil {
+		return err
+	}
+
+	d.SetId(programId(program, query))
+	d.Set("result", result)
+	return nil
+}
+
+func resourceProgramDelete(d *schema.ResourceData, meta interface{}) error {
+	d.SetId("")
+	return nil
+}
+
+// runProgram runs the program with the query as a JSON object on stdin,
+// and returns the JSON object the program writes to stdout.
+func runProgram(
+	program []string, query map[string]string, dir string) (map[string]string, error) {
+	if len(program) == 0 || program[0] == "" {
+		return nil, fmt.Errorf("program must not be empty")
+	}
+
+	input, err := json.Marshal(query)
+	if err != nil {
+		return nil, err
+	}
+
+	var stdout, stderr bytes.Buffer
+	cmd := exec.Command(program[0], program[1:]...)
+	cmd.Dir = dir
+	cmd.Stdin = bytes.NewReader(input)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	log.Printf("[DEBUG] Running external program: %v", program)
+	if err := cmd.Run(); err != nil {
+		msg := strings.TrimSpace(stderr.String())
+		if msg == "" {
+			return nil, fmt.Errorf("failed to run %s: %s", program[0], err)
+		}
+
+		return nil, fmt.Errorf("failed to run %s: %s\n\n%s", program[0], err, msg)
+	}
+
+	var raw map[string]interface{}
+	if err := json.Unmarshal(stdout.Bytes(), &raw); err != nil {
+		return nil, fmt.Errorf(
+			"the output of %s must be a JSON object: %s", program[0], err)
+	}
+
+	result := make(map[string]string, len(raw))
+	for k, v := range raw {
+		s, ok := v.(string)
+		if !ok {
+			return nil, fmt.Errorf(
+				"the output of %s must only have string values, but %q is %T",
+				program[0], k, v)
+		}
+
+		result[k] = s
+	}
+
+	return result, nil
+}
+
+// programId returns an ID for the program and query, so that the same
+// program and query always have the same ID.
+func programId(program []string, query map[string]string) string {
+	keys := make([]string, 0, len(query))
+	for k, _ := range query {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	sha := sha256.New()
+	for _, p := range program {
+		fmt.Fprintf(sha, "%q\n", p)
+	}
+	for _, k := range keys {
+		fmt.Fprintf(sha, "%q=%q\n", k, query[k])
+	}
+
+	return hex.EncodeToString(sha.Sum(nil))[:20]
+}
@@ -0,0 +1,132 @@
+package external
+
+import (
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"reflect"
+	"runtime"
+	"testing"
+
+	r "github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestRunProgram(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("test programs require a shell")
+	}
+
+	cases := []struct {
+		Script   string
+		Query    map[string]string
+		Expected map[string]string
+		Err      bool
+	}{
+		{
+			`echo '{"id": "i-123", "zone": "b"}'`,
+			nil,
+			map[string]string{"id": "i-123", "zone": "b"},
+			false,
+		},
+		{
+			// The query is given on stdin
+			`cat`,
+			map[string]string{"name": "web"},
+			map[string]string{"name": "web"},
+			false,
+		},
+		{
+			`echo 'not found' >&2; exit 1`,
+			nil,
+			nil,
+			true,
+		},
+		{
+			`echo 'not json'`,
+			nil,
+			nil,
+			true,
+		},
+		{
+			`echo '{"count": 2}'`,
+			nil,
+			nil,
+			true,
+		},
+	}
+
+	for i, tc := range cases {
+		program := []string{"/bin/sh", "-c", tc.Script}
+		actual, err := runProgram(program, tc.Query, "")
+		if (err != nil) != tc.Err {
+			t.Fatalf("%d: err: %s", i, err)
+		}
+		if !tc.Err && !reflect.DeepEqual(actual, tc.Expected) {
+			t.Fatalf("%d: bad: %#v", i, actual)
+		}
+	}
+}
+
+func TestRunProgram_empty(t *testing.T) {
+	if _, err := runProgram(nil, nil, ""); err == nil {
+		t.Fatal("should error")
+	}
+}
+
+func TestProgramId(t *testing.T) {
+	a := programId([]string{"a", "b"}, map[string]string{"x": "1", "y": "2"})
+	b := programId([]string{"a", "b"}, map[string]string{"y": "2", "x": "1"})
+	c := programId([]string{"a b"}, map[string]string{"x": "1", "y": "2"})
+	if a != b {
+		t.Fatalf("should be equal: %s %s", a, b)
+	}
+	if a == c {
+		t.Fatalf("should not be equal: %s", a)
+	}
+}
+
+func TestProgram(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("test programs require a shell")
+	}
+
+	dir, err := ioutil.TempDir("", "tf")
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	path := filepath.Join(dir, "lookup")
+	script := "#!/bin/sh\nsed 's/\"name\"/\"address\"/'\n"
+	if err := ioutil.WriteFile(path, []byte(script), 0755); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	r.Test(t, r.TestCase{
+		Providers: testProviders,
+		Steps: []r.TestStep{
+			r.TestStep{
+				Config: fmt.Sprintf(testProgramConfig, path),
+				Check: func(s *terraform.State) error {
+					got := s.RootModule().Outputs["address"]
+					if got != "10.0.0.1" {
+						return fmt.Errorf("bad: %s", got)
+					}
+					return nil
+				},
+			},
+		},
+	})
+}
+
+const testProgramConfig = `
+resource "external_program" "lookup" {
+	program = ["%s"]
+	query {
+		name = "10.0.0.1"
+	}
+}
+
+output "address" {
+	value = "${external_program.lookup.result.address}"
+}
+`
@@ -0,0 +1,244 @@
+package gitlab
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+
+	"github.com/hashicorp/terraform/helper/httpapi"
+)
+
+// Client is a client for the v4 GitLab API.
+type Client struct {
+	api *httpapi.Client
+}
+
+// Group is a GitLab group, which is a namespace for projects and other
+// groups.
+type Group struct {
+	ID          int    `json:"id,omitempty"`
+	Name        string `json:"name"`
+	Path        string `json:"path"`
+	Description string `json:"description"`
+	Visibility  string `json:"visibility"`
+	ParentID    int    `json:"parent_id,omitempty"`
+
+	// Set by GitLab
+	FullPath string `json:"full_path,omitempty"`
+	WebURL   string `json:"web_url,omitempty"`
+}
+
+// Project is a GitLab project, which is a repository along with its issues,
+// merge requests and wiki.
+type Project struct {
+	ID                   int    `json:"id,omitempty"`
+	Name                 string `json:"name"`
+	Path                 string `json:"path,omitempty"`
+	NamespaceID          int    `json:"namespace_id,omitempty"`
+	Description          string `json:"description"`
+	DefaultBranch        string `json:"default_branch,omitempty"`
+	Visibility           string `json:"visibility"`
+	IssuesEnabled        bool   `json:"issues_enabled"`
+	MergeRequestsEnabled bool   `json:"merge_requests_enabled"`
+	WikiEnabled          bool   `json:"wiki_enabled"`
+	SnippetsEnabled      bool   `json:"snippets_enabled"`
+
+	// Set by GitLab
+	Namespace         *Namespace `json:"namespace,omitempty"`
+	PathWithNamespace string     `json:"path_with_namespace,omitempty"`
+	SSHURLToRepo      string     `json:"ssh_url_to_repo,omitempty"`
+	HTTPURLToRepo     string     `json:"http_url_to_repo,omitempty"`
+	WebURL            string     `json:"web_url,omitempty"`
+}
+
+// Namespace is the user or group that a project belongs to.
+type Namespace struct {
+	ID       int    `json:"id"`
+	FullPath string `json:"full_path"`
+}
+
+// DeployKey is an SSH key that gives access to the repository of a
+// project, such as for a deployment tool.
+type DeployKey struct {
+	ID      int    `json:"id,omitempty"`
+	Title   string `json:"title"`
+	Key     string `json:"key"`
+	CanPush bool   `json:"can_push"`
+}
+
+// ProtectedBranch is a branch of a project that only the users with the
+// given access levels can push or merge to.
+type ProtectedBranch struct {
+	Name              string        `json:"name"`
+	PushAccessLevels  []AccessLevel `json:"push_access_levels"`
+	MergeAccessLevels []AccessLevel `json:"merge_access_levels"`
+}
+
+// AccessLevel is an access level that is allowed to push or merge to a
+// protected branch.
+type AccessLevel struct {
+	AccessLevel int `json:"access_level"`
+}
+
+// Group returns the group with the given ID or path.
+func (c *Client) Group(id string) (*Group, error) {
+	var result Group
+	if err := c.do("GET", "/groups/"+url.QueryEscape(id), nil, &result); err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// CreateGroup creates the group.
+func (c *Client) CreateGroup(g *Group) (*Group, error) {
+	var result Group
+	if err := c.do("POST", "/groups", g, &result); err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// UpdateGroup changes the settings of the group.
+func (c *Client) UpdateGroup(id string, g *Group) (*Group, error) {
+	var result Group
+	if err := c.do("PUT", "/groups/"+url.QueryEscape(id), g, &result); err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// DeleteGroup deletes the group with the given ID or path, along with its
+// projects.
+func (c *Client) DeleteGroup(id string) error {
+	return c.do("DELETE", "/groups/"+url.QueryEscape(id), nil, nil)
+}
+
+// Project returns the project with the given ID or path.
+func (c *Client) Project(id string) (*Project, error) {
+	var result Project
+	if err := c.do("GET", projectPath(id), nil, &result); err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// CreateProject creates the project.
+func (c *Client) CreateProject(p *Project) (*Project, error) {
+	var result Project
+	if err := c.do("POST", "/projects", p, &result); err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// UpdateProject changes the settings of the project.
+func (c *Client) UpdateProject(id string, p *Project) (*Project, error) {
+	var result Project
+	if err := c.do("PUT", projectPath(id), p, &result); err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// DeleteProject deletes the project with the given ID or path.
+func (c *Client) DeleteProject(id string) error {
+	return c.do("DELETE", projectPath(id), nil, nil)
+}
+
+// DeployKey returns the deploy key with the given ID of the project.
+func (c *Client) DeployKey(project string, id int) (*DeployKey, error) {
+	var result DeployKey
+	path := fmt.Sprintf("%s/deploy_keys/%d", projectPath(project), id)
+	if err := c.do("GET", path, nil, &result); err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// CreateDeployKey adds the deploy key to the project.
+func (c *Client) CreateDeployKey(project string, k *DeployKey) (*DeployKey, error) {
+	var result DeployKey
+	path := projectPath(project) + "/deploy_keys"
+	if err := c.do("POST", path, k, &result); err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// DeleteDeployKey removes the deploy key with the given ID from the
+// project.
+func (c *Client) DeleteDeployKey(project string, id int) error {
+	path := fmt.Sprintf("%s/deploy_keys/%d", projectPath(project), id)
+	return c.do("DELETE", path, nil, nil)
+}
+
+// ProtectedBranch returns the protection of the branch of the project.
+func (c *Client) ProtectedBranch(project, branch string) (*ProtectedBranch, error) {
+	var result ProtectedBranch
+	if err := c.do("GET", protectedBranchPath(project, branch), nil, &result); err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// ProtectBranch protects the branch of the project, allowing the given
+// access levels to push and merge to it.
+func (c *Client) ProtectBranch(project, branch string, push, merge int) (*ProtectedBranch, error) {
+	in := struct {
+		Name             string `json:"name"`
+		PushAccessLevel  int    `json:"push_access_level"`
+		MergeAccessLevel int    `json:"merge_access_level"`
+	}{branch, push, merge}
+
+	var result ProtectedBranch
+	path := projectPath(project) + "/protected_branches"
+	if err := c.do("POST", path, &in, &result); err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// UnprotectBranch removes the protection of the branch of the project.
+func (c *Client) UnprotectBranch(project, branch string) error {
+	return c.do("DELETE", protectedBranchPath(project, branch), nil, nil)
+}
+
+func (c *Client) do(method, path string, in, out interface{}) error {
+	return c.api.Do(method, path, in, out)
+}
+
+// errorMessage returns the message in the body of a failed answer, which
+// is a string, or an object of the errors of each field for validation
+// errors.
+func errorMessage(body []byte) string {
+	var status struct {
+		Message interface{} `json:"message"`
+	}
+	if err := json.Unmarshal(body, &status); err != nil || status.Message == nil {
+		return ""
+	}
+
+	return fmt.Sprint(status.Message)
+}
+
+// projectPath returns the path of the API for the project with the given
+// ID or path, such as "group/project", which is escaped.
+func projectPath(project string) string {
+	return "/projects/" + url.QueryEscape(project)
+}
+
+// protectedBranchPath returns the path of the API for the protection of
+// the branch of the project.
+func protectedBranchPath(project, branch string) string {
+	return projectPath(project) + "/protected_branches/" + url.QueryEscape(branch)
+}
@@ -0,0 +1,34 @@
+package gitlab
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/httpapi"
+)
+
+type Config struct {
+	Token   string
+	BaseURL string
+}
+
+// Client returns a new client for the GitLab API.
+func (c *Config) Client() (*Client, error) {
+	u, err := url.Parse(c.BaseURL)
+	if err != nil || u.Scheme == "" || u.Host == "" {
+		return nil, fmt.Errorf("Invalid GitLab base URL %q", c.BaseURL)
+	}
+
+	log.Printf("[INFO] GitLab client configured for %s", c.BaseURL)
+	return &Client{
+		api: &httpapi.Client{
+			BaseURL:      strings.TrimSuffix(c.BaseURL, "/"),
+			Name:         "GitLab",
+			Header:       http.Header{"Private-Token": []string{c.Token}},
+			ErrorMessage: errorMessage,
+		},
+	}, nil
+}
@@ -0,0 +1,48 @@
+package gitlab
+
+import (
+	"log"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+// Provider returns a terraform.ResourceProvider.
+func Provider() terraform.ResourceProvider {
+	return &schema.Provider{
+		Schema: map[string]*schema.Schema{
+			"token": &schema.Schema{
+				Type:        schema.TypeString,
+				Required:    true,
+				DefaultFunc: schema.EnvDefaultFunc("GITLAB_TOKEN", nil),
+				Description: "The personal access token of the GitLab user.",
+			},
+
+			"base_url": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("GITLAB_BASE_URL", "https://gitlab.com/api/v4"),
+				Description: "The URL of the v4 API of the GitLab server.",
+			},
+		},
+
+		ResourcesMap: map[string]*schema.Resource{
+			"gitlab_branch_protection": resourceGitlabBranchProtection(),
+			"gitlab_deploy_key":        resourceGitlabDeployKey(),
+			"gitlab_group":             resourceGitlabGroup(),
+			"gitlab_project":           resourceGitlabProject(),
+		},
+
+		ConfigureFunc: providerConfigure,
+	}
+}
+
+func providerConfigure(d *schema.ResourceData) (interface{}, error) {
+	config := Config{
+		Token:   d.Get("token").(string),
+		BaseURL: d.Get("base_url").(string),
+	}
+
+	log.Println("[INFO] Initializing GitLab client")
+	return config.Client()
+}
@@ -0,0 +1,119 @@
+package gitlab
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform/helper/httpapi"
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func resourceGitlabBranchProtection() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceGitlabBranchProtectionCreate,
+		Read:   resourceGitlabBranchProtectionRead,
+		Delete: resourceGitlabBranchProtectionDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"project": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"branch": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"push_access_level": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+				Default:  "maintainer",
+			},
+
+			"merge_access_level": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+				Default:  "maintainer",
+			},
+		},
+	}
+}
+
+func resourceGitlabBranchProtectionCreate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Client)
+
+	project := d.Get("project").(string)
+	branch := d.Get("branch").(string)
+	push, err := expandAccessLevel(d.Get("push_access_level").(string))
+	if err != nil {
+		return err
+	}
+	merge, err := expandAccessLevel(d.Get("merge_access_level").(string))
+	if err != nil {
+		return err
+	}
+
+	log.Printf("[DEBUG] Protecting GitLab branch %s of project %s", branch, project)
+	if _, err := client.ProtectBranch(project, branch, push, merge); err != nil {
+		return fmt.Errorf(
+			"Error protecting GitLab branch %s of project %s: %s", branch, project, err)
+	}
+
+	d.SetId(projectResourceId(project, branch))
+	log.Printf("[INFO] GitLab branch protected: %s", d.Id())
+
+	return resourceGitlabBranchProtectionRead(d, meta)
+}
+
+func resourceGitlabBranchProtectionRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Client)
+
+	project, branch, err := parseProjectResourceId(d.Id())
+	if err != nil {
+		return err
+	}
+
+	b, err := client.ProtectedBranch(project, branch)
+	if err != nil {
+		if httpapi.IsNotFound(err) {
+			d.SetId("")
+			return nil
+		}
+		return fmt.Errorf("Error reading GitLab branch protection %s: %s", d.Id(), err)
+	}
+
+	d.Set("project", project)
+	d.Set("branch", b.Name)
+	d.Set("push_access_level", flattenAccessLevel(b.PushAccessLevels))
+	d.Set("merge_access_level", flattenAccessLevel(b.MergeAccessLevels))
+
+	return nil
+}
+
+func resourceGitlabBranchProtectionDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Client)
+
+	project, branch, err := parseProjectResourceId(d.Id())
+	if err != nil {
+		return err
+	}
+
+	log.Printf("[INFO] Unprotecting GitLab branch: %s", d.Id())
+	if err := client.UnprotectBranch(project, branch); err != nil {
+		if httpapi.IsNotFound(err) {
+			return nil
+		}
+		return fmt.Errorf("Error unprotecting GitLab branch %s: %s", d.Id(), err)
+	}
+
+	d.SetId("")
+	return nil
+}
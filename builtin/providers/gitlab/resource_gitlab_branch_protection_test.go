@@ -0,0 +1,93 @@
+package gitlab
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/httpapi"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestAccGitlabBranchProtection_basic(t *testing.T) {
+	var branch ProtectedBranch
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckGitlabBranchProtectionDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccGitlabBranchProtectionConfig_basic,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckGitlabBranchProtectionExists("gitlab_branch_protection.test", &branch),
+					resource.TestCheckResourceAttr(
+						"gitlab_branch_protection.test", "push_access_level", "no one"),
+					resource.TestCheckResourceAttr(
+						"gitlab_branch_protection.test", "merge_access_level", "developer"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckGitlabBranchProtectionExists(n string, branch *ProtectedBranch) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		project, name, err := parseProjectResourceId(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+
+		client := testAccProvider.Meta().(*Client)
+		result, err := client.ProtectedBranch(project, name)
+		if err != nil {
+			return err
+		}
+
+		*branch = *result
+		return nil
+	}
+}
+
+func testAccCheckGitlabBranchProtectionDestroy(s *terraform.State) error {
+	client := testAccProvider.Meta().(*Client)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "gitlab_branch_protection" {
+			continue
+		}
+
+		project, name, err := parseProjectResourceId(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+
+		_, err = client.ProtectedBranch(project, name)
+		if err == nil {
+			return fmt.Errorf("Branch is still protected: %s", rs.Primary.ID)
+		}
+		if !httpapi.IsNotFound(err) {
+			return err
+		}
+	}
+
+	return nil
+}
+
+const testAccGitlabBranchProtectionConfig_basic = `
+resource "gitlab_project" "test" {
+	name = "terraform-acc-test-branch-protection"
+}
+
+resource "gitlab_branch_protection" "test" {
+	project = "${gitlab_project.test.id}"
+	branch = "release/*"
+	push_access_level = "no one"
+	merge_access_level = "developer"
+}
+`
@@ -0,0 +1,139 @@
+package gitlab
+
+import (
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/httpapi"
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func resourceGitlabDeployKey() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceGitlabDeployKeyCreate,
+		Read:   resourceGitlabDeployKeyRead,
+		Delete: resourceGitlabDeployKeyDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"project": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"title": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"key": &schema.Schema{
+				Type:      schema.TypeString,
+				Required:  true,
+				ForceNew:  true,
+				StateFunc: normalizeKey,
+			},
+
+			"can_push": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				ForceNew: true,
+			},
+		},
+	}
+}
+
+func resourceGitlabDeployKeyCreate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Client)
+
+	project := d.Get("project").(string)
+	k := &DeployKey{
+		Title:   d.Get("title").(string),
+		Key:     d.Get("key").(string),
+		CanPush: d.Get("can_push").(bool),
+	}
+
+	log.Printf("[DEBUG] Creating GitLab deploy key %s for project %s", k.Title, project)
+	result, err := client.CreateDeployKey(project, k)
+	if err != nil {
+		return fmt.Errorf(
+			"Error creating GitLab deploy key %s for project %s: %s", k.Title, project, err)
+	}
+
+	d.SetId(projectResourceId(project, strconv.Itoa(result.ID)))
+	log.Printf("[INFO] GitLab deploy key created: %s", d.Id())
+
+	return resourceGitlabDeployKeyRead(d, meta)
+}
+
+func resourceGitlabDeployKeyRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Client)
+
+	project, id, err := parseDeployKeyId(d.Id())
+	if err != nil {
+		return err
+	}
+
+	k, err := client.DeployKey(project, id)
+	if err != nil {
+		if httpapi.IsNotFound(err) {
+			d.SetId("")
+			return nil
+		}
+		return fmt.Errorf("Error reading GitLab deploy key %s: %s", d.Id(), err)
+	}
+
+	d.Set("project", project)
+	d.Set("title", k.Title)
+	d.Set("key", k.Key)
+	d.Set("can_push", k.CanPush)
+
+	return nil
+}
+
+func resourceGitlabDeployKeyDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Client)
+
+	project, id, err := parseDeployKeyId(d.Id())
+	if err != nil {
+		return err
+	}
+
+	log.Printf("[INFO] Deleting GitLab deploy key: %s", d.Id())
+	if err := client.DeleteDeployKey(project, id); err != nil {
+		if httpapi.IsNotFound(err) {
+			return nil
+		}
+		return fmt.Errorf("Error deleting GitLab deploy key %s: %s", d.Id(), err)
+	}
+
+	d.SetId("")
+	return nil
+}
+
+// parseDeployKeyId returns the project and the ID of the deploy key in
+// the ID of a resource.
+func parseDeployKeyId(id string) (string, int, error) {
+	project, key, err := parseProjectResourceId(id)
+	if err != nil {
+		return "", 0, err
+	}
+
+	keyId, err := strconv.Atoi(key)
+	if err != nil {
+		return "", 0, fmt.Errorf("Unexpected ID %q, the key ID isn't a number", id)
+	}
+
+	return project, keyId, nil
+}
+
+// normalizeKey returns the public key without the trailing newline that
+// key files end with, which GitLab removes.
+func normalizeKey(v interface{}) string {
+	return strings.TrimSpace(v.(string))
+}
@@ -0,0 +1,111 @@
+package gitlab
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/httpapi"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+	"golang.org/x/crypto/ssh"
+)
+
+func TestAccGitlabDeployKey_basic(t *testing.T) {
+	var key DeployKey
+
+	// GitLab checks that the key is valid, so a new one is generated
+	privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	publicKey, err := ssh.NewPublicKey(&privateKey.PublicKey)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	authorizedKey := strings.TrimSpace(string(ssh.MarshalAuthorizedKey(publicKey)))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckGitlabDeployKeyDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: fmt.Sprintf(testAccGitlabDeployKeyConfig_basic, authorizedKey),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckGitlabDeployKeyExists("gitlab_deploy_key.test", &key),
+					resource.TestCheckResourceAttr(
+						"gitlab_deploy_key.test", "title", "terraform-acc-test"),
+					func(*terraform.State) error {
+						if key.CanPush {
+							return fmt.Errorf("key shouldn't be able to push")
+						}
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckGitlabDeployKeyExists(n string, key *DeployKey) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		project, id, err := parseDeployKeyId(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+
+		client := testAccProvider.Meta().(*Client)
+		result, err := client.DeployKey(project, id)
+		if err != nil {
+			return err
+		}
+
+		*key = *result
+		return nil
+	}
+}
+
+func testAccCheckGitlabDeployKeyDestroy(s *terraform.State) error {
+	client := testAccProvider.Meta().(*Client)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "gitlab_deploy_key" {
+			continue
+		}
+
+		project, id, err := parseDeployKeyId(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+
+		_, err = client.DeployKey(project, id)
+		if err == nil {
+			return fmt.Errorf("Deploy key still exists: %s", rs.Primary.ID)
+		}
+		if !httpapi.IsNotFound(err) {
+			return err
+		}
+	}
+
+	return nil
+}
+
+const testAccGitlabDeployKeyConfig_basic = `
+resource "gitlab_project" "test" {
+	name = "terraform-acc-test-deploy-key"
+}
+
+resource "gitlab_deploy_key" "test" {
+	project = "${gitlab_project.test.id}"
+	title = "terraform-acc-test"
+	key = "%s"
+}
+`
@@ -0,0 +1,152 @@
+package gitlab
+
+import (
+	"fmt"
+	"log"
+	"strconv"
+
+	"github.com/hashicorp/terraform/helper/httpapi"
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func resourceGitlabGroup() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceGitlabGroupCreate,
+		Read:   resourceGitlabGroupRead,
+		Update: resourceGitlabGroupUpdate,
+		Delete: resourceGitlabGroupDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"name": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+			},
+
+			"path": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+			},
+
+			"description": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"visibility": visibilitySchema(),
+
+			"parent_id": &schema.Schema{
+				Type:     schema.TypeInt,
+				Optional: true,
+				ForceNew: true,
+			},
+
+			"full_path": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"web_url": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceGitlabGroupCreate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Client)
+
+	g, err := expandGroup(d)
+	if err != nil {
+		return err
+	}
+
+	log.Printf("[DEBUG] Creating GitLab group: %s", g.Path)
+	result, err := client.CreateGroup(g)
+	if err != nil {
+		return fmt.Errorf("Error creating GitLab group %s: %s", g.Path, err)
+	}
+
+	d.SetId(strconv.Itoa(result.ID))
+	log.Printf("[INFO] GitLab group created: %s", d.Id())
+
+	return resourceGitlabGroupRead(d, meta)
+}
+
+func resourceGitlabGroupRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Client)
+
+	g, err := client.Group(d.Id())
+	if err != nil {
+		if httpapi.IsNotFound(err) {
+			d.SetId("")
+			return nil
+		}
+		return fmt.Errorf("Error reading GitLab group %s: %s", d.Id(), err)
+	}
+
+	// Groups can be imported by their path, but are known by their ID
+	d.SetId(strconv.Itoa(g.ID))
+	d.Set("name", g.Name)
+	d.Set("path", g.Path)
+	d.Set("description", g.Description)
+	d.Set("visibility", g.Visibility)
+	d.Set("parent_id", g.ParentID)
+	d.Set("full_path", g.FullPath)
+	d.Set("web_url", g.WebURL)
+
+	return nil
+}
+
+func resourceGitlabGroupUpdate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Client)
+
+	g, err := expandGroup(d)
+	if err != nil {
+		return err
+	}
+
+	// The parent can't be changed by an update
+	g.ParentID = 0
+
+	log.Printf("[DEBUG] Updating GitLab group: %s", d.Id())
+	if _, err := client.UpdateGroup(d.Id(), g); err != nil {
+		return fmt.Errorf("Error updating GitLab group %s: %s", d.Id(), err)
+	}
+
+	return resourceGitlabGroupRead(d, meta)
+}
+
+func resourceGitlabGroupDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Client)
+
+	log.Printf("[INFO] Deleting GitLab group: %s", d.Id())
+	if err := client.DeleteGroup(d.Id()); err != nil {
+		if httpapi.IsNotFound(err) {
+			return nil
+		}
+		return fmt.Errorf("Error deleting GitLab group %s: %s", d.Id(), err)
+	}
+
+	d.SetId("")
+	return nil
+}
+
+// expandGroup returns the group of the resource.
+func expandGroup(d *schema.ResourceData) (*Group, error) {
+	visibility := d.Get("visibility").(string)
+	if err := validateVisibility(visibility); err != nil {
+		return nil, err
+	}
+
+	return &Group{
+		Name:        d.Get("name").(string),
+		Path:        d.Get("path").(string),
+		Description: d.Get("description").(string),
+		Visibility:  visibility,
+		ParentID:    d.Get("parent_id").(int),
+	}, nil
+}
@@ -0,0 +1,104 @@
+package gitlab
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/httpapi"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestAccGitlabGroup_basic(t *testing.T) {
+	var group Group
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckGitlabGroupDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccGitlabGroupConfig_basic,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckGitlabGroupExists("gitlab_group.test", &group),
+					resource.TestCheckResourceAttr(
+						"gitlab_group.test", "visibility", "private"),
+					resource.TestCheckResourceAttr(
+						"gitlab_group.test", "full_path", "terraform-acc-test"),
+				),
+			},
+			resource.TestStep{
+				Config: testAccGitlabGroupConfig_update,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckGitlabGroupExists("gitlab_group.test", &group),
+					resource.TestCheckResourceAttr(
+						"gitlab_group.test", "visibility", "internal"),
+					func(*terraform.State) error {
+						if group.Description != "Managed by Terraform" {
+							return fmt.Errorf("bad description: %s", group.Description)
+						}
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckGitlabGroupExists(n string, group *Group) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No group ID is set")
+		}
+
+		client := testAccProvider.Meta().(*Client)
+		result, err := client.Group(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+
+		*group = *result
+		return nil
+	}
+}
+
+func testAccCheckGitlabGroupDestroy(s *terraform.State) error {
+	client := testAccProvider.Meta().(*Client)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "gitlab_group" {
+			continue
+		}
+
+		_, err := client.Group(rs.Primary.ID)
+		if err == nil {
+			return fmt.Errorf("Group still exists: %s", rs.Primary.ID)
+		}
+		if !httpapi.IsNotFound(err) {
+			return err
+		}
+	}
+
+	return nil
+}
+
+const testAccGitlabGroupConfig_basic = `
+resource "gitlab_group" "test" {
+	name = "Terraform acceptance tests"
+	path = "terraform-acc-test"
+}
+`
+
+const testAccGitlabGroupConfig_update = `
+resource "gitlab_group" "test" {
+	name = "Terraform acceptance tests"
+	path = "terraform-acc-test"
+	description = "Managed by Terraform"
+	visibility = "internal"
+}
+`
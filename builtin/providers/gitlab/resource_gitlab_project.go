@@ -0,0 +1,212 @@
+package gitlab
+
+import (
+	"fmt"
+	"log"
+	"strconv"
+
+	"github.com/hashicorp/terraform/helper/httpapi"
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func resourceGitlabProject() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceGitlabProjectCreate,
+		Read:   resourceGitlabProjectRead,
+		Update: resourceGitlabProjectUpdate,
+		Delete: resourceGitlabProjectDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"name": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+			},
+
+			"path": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+
+			"namespace_id": &schema.Schema{
+				Type:     schema.TypeInt,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+
+			"description": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"default_branch": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+
+			"visibility": visibilitySchema(),
+
+			"issues_enabled": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+
+			"merge_requests_enabled": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+
+			"wiki_enabled": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+
+			"snippets_enabled": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+
+			"path_with_namespace": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"ssh_url_to_repo": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"http_url_to_repo": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"web_url": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceGitlabProjectCreate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Client)
+
+	p, err := expandProject(d)
+	if err != nil {
+		return err
+	}
+
+	// The default branch can only be set once the repository has the
+	// branch, so it is left to an update.
+	p.DefaultBranch = ""
+
+	log.Printf("[DEBUG] Creating GitLab project: %s", p.Name)
+	result, err := client.CreateProject(p)
+	if err != nil {
+		return fmt.Errorf("Error creating GitLab project %s: %s", p.Name, err)
+	}
+
+	d.SetId(strconv.Itoa(result.ID))
+	log.Printf("[INFO] GitLab project created: %s", d.Id())
+
+	return resourceGitlabProjectRead(d, meta)
+}
+
+func resourceGitlabProjectRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Client)
+
+	p, err := client.Project(d.Id())
+	if err != nil {
+		if httpapi.IsNotFound(err) {
+			d.SetId("")
+			return nil
+		}
+		return fmt.Errorf("Error reading GitLab project %s: %s", d.Id(), err)
+	}
+
+	// Projects can be imported by their path, but are known by their ID
+	d.SetId(strconv.Itoa(p.ID))
+	d.Set("name", p.Name)
+	d.Set("path", p.Path)
+	if p.Namespace != nil {
+		d.Set("namespace_id", p.Namespace.ID)
+	}
+	d.Set("description", p.Description)
+	d.Set("default_branch", p.DefaultBranch)
+	d.Set("visibility", p.Visibility)
+	d.Set("issues_enabled", p.IssuesEnabled)
+	d.Set("merge_requests_enabled", p.MergeRequestsEnabled)
+	d.Set("wiki_enabled", p.WikiEnabled)
+	d.Set("snippets_enabled", p.SnippetsEnabled)
+	d.Set("path_with_namespace", p.PathWithNamespace)
+	d.Set("ssh_url_to_repo", p.SSHURLToRepo)
+	d.Set("http_url_to_repo", p.HTTPURLToRepo)
+	d.Set("web_url", p.WebURL)
+
+	return nil
+}
+
+func resourceGitlabProjectUpdate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Client)
+
+	p, err := expandProject(d)
+	if err != nil {
+		return err
+	}
+
+	// The namespace can't be changed by an update
+	p.NamespaceID = 0
+
+	log.Printf("[DEBUG] Updating GitLab project: %s", d.Id())
+	if _, err := client.UpdateProject(d.Id(), p); err != nil {
+		return fmt.Errorf("Error updating GitLab project %s: %s", d.Id(), err)
+	}
+
+	return resourceGitlabProjectRead(d, meta)
+}
+
+func resourceGitlabProjectDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Client)
+
+	log.Printf("[INFO] Deleting GitLab project: %s", d.Id())
+	if err := client.DeleteProject(d.Id()); err != nil {
+		if httpapi.IsNotFound(err) {
+			return nil
+		}
+		return fmt.Errorf("Error deleting GitLab project %s: %s", d.Id(), err)
+	}
+
+	d.SetId("")
+	return nil
+}
+
+// expandProject returns the project of the resource.
+func expandProject(d *schema.ResourceData) (*Project, error) {
+	visibility := d.Get("visibility").(string)
+	if err := validateVisibility(visibility); err != nil {
+		return nil, err
+	}
+
+	return &Project{
+		Name:                 d.Get("name").(string),
+		Path:                 d.Get("path").(string),
+		NamespaceID:          d.Get("namespace_id").(int),
+		Description:          d.Get("description").(string),
+		DefaultBranch:        d.Get("default_branch").(string),
+		Visibility:           visibility,
+		IssuesEnabled:        d.Get("issues_enabled").(bool),
+		MergeRequestsEnabled: d.Get("merge_requests_enabled").(bool),
+		WikiEnabled:          d.Get("wiki_enabled").(bool),
+		SnippetsEnabled:      d.Get("snippets_enabled").(bool),
+	}, nil
+}
@@ -0,0 +1,102 @@
+package gitlab
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/httpapi"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestAccGitlabProject_basic(t *testing.T) {
+	var project Project
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckGitlabProjectDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccGitlabProjectConfig_basic,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckGitlabProjectExists("gitlab_project.test", &project),
+					resource.TestCheckResourceAttr(
+						"gitlab_project.test", "path", "terraform-acc-test"),
+					resource.TestCheckResourceAttr(
+						"gitlab_project.test", "wiki_enabled", "true"),
+				),
+			},
+			resource.TestStep{
+				Config: testAccGitlabProjectConfig_update,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckGitlabProjectExists("gitlab_project.test", &project),
+					resource.TestCheckResourceAttr(
+						"gitlab_project.test", "wiki_enabled", "false"),
+					func(*terraform.State) error {
+						if project.Description != "Managed by Terraform" {
+							return fmt.Errorf("bad description: %s", project.Description)
+						}
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckGitlabProjectExists(n string, project *Project) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No project ID is set")
+		}
+
+		client := testAccProvider.Meta().(*Client)
+		result, err := client.Project(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+
+		*project = *result
+		return nil
+	}
+}
+
+func testAccCheckGitlabProjectDestroy(s *terraform.State) error {
+	client := testAccProvider.Meta().(*Client)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "gitlab_project" {
+			continue
+		}
+
+		_, err := client.Project(rs.Primary.ID)
+		if err == nil {
+			return fmt.Errorf("Project still exists: %s", rs.Primary.ID)
+		}
+		if !httpapi.IsNotFound(err) {
+			return err
+		}
+	}
+
+	return nil
+}
+
+const testAccGitlabProjectConfig_basic = `
+resource "gitlab_project" "test" {
+	name = "terraform-acc-test"
+}
+`
+
+const testAccGitlabProjectConfig_update = `
+resource "gitlab_project" "test" {
+	name = "terraform-acc-test"
+	description = "Managed by Terraform"
+	wiki_enabled = false
+}
+`
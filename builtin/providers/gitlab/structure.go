@@ -0,0 +1,85 @@
+package gitlab
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+// visibilitySchema returns the schema of the visibility of a group or
+// project, which is private unless configured otherwise.
+func visibilitySchema() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeString,
+		Optional: true,
+		Default:  "private",
+	}
+}
+
+// validateVisibility returns an error if the visibility isn't one that
+// GitLab knows.
+func validateVisibility(v string) error {
+	switch v {
+	case "private", "internal", "public":
+		return nil
+	default:
+		return fmt.Errorf(
+			"Invalid visibility %q, expected private, internal or public", v)
+	}
+}
+
+// accessLevels are the access levels of the API by the names used in the
+// configuration.
+var accessLevels = map[string]int{
+	"no one":     0,
+	"developer":  30,
+	"maintainer": 40,
+}
+
+// expandAccessLevel returns the access level of the API for the name of
+// the configuration.
+func expandAccessLevel(name string) (int, error) {
+	level, ok := accessLevels[name]
+	if !ok {
+		return 0, fmt.Errorf(
+			"Invalid access level %q, expected \"no one\", developer or maintainer", name)
+	}
+
+	return level, nil
+}
+
+// flattenAccessLevel returns the name of the configuration for the
+// highest access level that is allowed, or "no one" if none is. Levels
+// above maintainer, such as admin, are shown as maintainer.
+func flattenAccessLevel(levels []AccessLevel) string {
+	result := "no one"
+	max := 0
+	for _, l := range levels {
+		for name, level := range accessLevels {
+			if level > max && level <= l.AccessLevel {
+				result, max = name, level
+			}
+		}
+	}
+
+	return result
+}
+
+// projectResourceId returns the ID of a resource that belongs to a
+// project, such as a deploy key, which is "PROJECT:ID". Neither project
+// paths nor branch names can contain colons.
+func projectResourceId(project, id string) string {
+	return fmt.Sprintf("%s:%s", project, id)
+}
+
+// parseProjectResourceId returns the project and the ID in the ID of a
+// resource, as returned by projectResourceId.
+func parseProjectResourceId(id string) (string, string, error) {
+	parts := strings.SplitN(id, ":", 2)
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return "", "", fmt.Errorf("Unexpected ID %q, expected PROJECT:ID", id)
+	}
+
+	return parts[0], parts[1], nil
+}
@@ -0,0 +1,66 @@
+package gitlab
+
+import (
+	"testing"
+)
+
+func TestExpandAccessLevel(t *testing.T) {
+	cases := map[string]int{
+		"no one":     0,
+		"developer":  30,
+		"maintainer": 40,
+	}
+
+	for name, expected := range cases {
+		actual, err := expandAccessLevel(name)
+		if err != nil {
+			t.Fatalf("%s: err: %s", name, err)
+		}
+		if actual != expected {
+			t.Fatalf("%s: bad: %d", name, actual)
+		}
+	}
+
+	if _, err := expandAccessLevel("owner"); err == nil {
+		t.Fatal("should error")
+	}
+}
+
+func TestFlattenAccessLevel(t *testing.T) {
+	cases := []struct {
+		Levels   []AccessLevel
+		Expected string
+	}{
+		{nil, "no one"},
+		{[]AccessLevel{{0}}, "no one"},
+		{[]AccessLevel{{30}}, "developer"},
+		{[]AccessLevel{{40}, {30}}, "maintainer"},
+		{[]AccessLevel{{60}}, "maintainer"},
+	}
+
+	for _, tc := range cases {
+		if actual := flattenAccessLevel(tc.Levels); actual != tc.Expected {
+			t.Fatalf("%#v: bad: %s", tc.Levels, actual)
+		}
+	}
+}
+
+func TestParseProjectResourceId(t *testing.T) {
+	project, id, err := parseProjectResourceId(projectResourceId("ops/infra", "release/1.0"))
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if project != "ops/infra" || id != "release/1.0" {
+		t.Fatalf("bad: %s %s", project, id)
+	}
+
+	for _, invalid := range []string{"ops/infra", "ops/infra:", ":42"} {
+		if _, _, err := parseProjectResourceId(invalid); err == nil {
+			t.Fatalf("%s: should error", invalid)
+		}
+	}
+
+	if _, _, err := parseDeployKeyId("ops/infra:abc"); err == nil {
+		t.Fatal("should error")
+	}
+}
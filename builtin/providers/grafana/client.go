@@ -0,0 +1,110 @@
+package grafana
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform/helper/httpapi"
+)
+
+// Client is a client for the HTTP API of Grafana.
+type Client struct {
+	api *httpapi.Client
+}
+
+// DataSource is a data source, as accepted and returned by the API.
+type DataSource struct {
+	ID                int64  `json:"id,omitempty"`
+	Name              string `json:"name"`
+	Type              string `json:"type"`
+	URL               string `json:"url"`
+	Access            string `json:"access"`
+	Database          string `json:"database,omitempty"`
+	User              string `json:"user,omitempty"`
+	Password          string `json:"password,omitempty"`
+	BasicAuth         bool   `json:"basicAuth"`
+	BasicAuthUser     string `json:"basicAuthUser,omitempty"`
+	BasicAuthPassword string `json:"basicAuthPassword,omitempty"`
+	IsDefault         bool   `json:"isDefault"`
+}
+
+// CreateDataSource creates the data source, and returns its ID.
+func (c *Client) CreateDataSource(ds *DataSource) (int64, error) {
+	var result struct {
+		ID int64 `json:"id"`
+	}
+	if err := c.do("POST", "/api/datasources", ds, &result); err != nil {
+		return 0, err
+	}
+
+	return result.ID, nil
+}
+
+// DataSource returns the data source with the given ID.
+func (c *Client) DataSource(id int64) (*DataSource, error) {
+	var result DataSource
+	if err := c.do("GET", fmt.Sprintf("/api/datasources/%d", id), nil, &result); err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// UpdateDataSource replaces the data source that has the ID of ds.
+func (c *Client) UpdateDataSource(ds *DataSource) error {
+	return c.do("PUT", fmt.Sprintf("/api/datasources/%d", ds.ID), ds, nil)
+}
+
+// DeleteDataSource deletes the data source with the given ID.
+func (c *Client) DeleteDataSource(id int64) error {
+	return c.do("DELETE", fmt.Sprintf("/api/datasources/%d", id), nil, nil)
+}
+
+// Dashboard is a dashboard, as returned by the API.
+type Dashboard struct {
+	Meta struct {
+		Slug string `json:"slug"`
+	} `json:"meta"`
+	Model map[string]interface{} `json:"dashboard"`
+}
+
+// SaveDashboard creates the dashboard with the given model, or replaces
+// the dashboard with the same title, and returns its slug.
+func (c *Client) SaveDashboard(model map[string]interface{}) (string, error) {
+	in := map[string]interface{}{
+		"dashboard": model,
+		"overwrite": true,
+	}
+	var result struct {
+		Slug string `json:"slug"`
+	}
+	if err := c.do("POST", "/api/dashboards/db", in, &result); err != nil {
+		return "", err
+	}
+
+	return result.Slug, nil
+}
+
+// Dashboard returns the dashboard with the given slug.
+func (c *Client) Dashboard(slug string) (*Dashboard, error) {
+	var result Dashboard
+	if err := c.do("GET", dashboardPath(slug), nil, &result); err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// DeleteDashboard deletes the dashboard with the given slug.
+func (c *Client) DeleteDashboard(slug string) error {
+	return c.do("DELETE", dashboardPath(slug), nil, nil)
+}
+
+func (c *Client) do(method, path string, in, out interface{}) error {
+	return c.api.Do(method, path, in, out)
+}
+
+// dashboardPath returns the path of the API for the dashboard with the
+// given slug.
+func dashboardPath(slug string) string {
+	return "/api/dashboards/db/" + slug
+}
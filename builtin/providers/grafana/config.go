@@ -0,0 +1,40 @@
+package grafana
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/httpapi"
+)
+
+type Config struct {
+	URL  string
+	Auth string
+}
+
+// Client returns a new client for the Grafana API.
+func (c *Config) Client() (*Client, error) {
+	u, err := url.Parse(c.URL)
+	if err != nil || u.Scheme == "" || u.Host == "" {
+		return nil, fmt.Errorf("Invalid Grafana URL %q", c.URL)
+	}
+
+	api := &httpapi.Client{
+		BaseURL: strings.TrimSuffix(c.URL, "/"),
+		Name:    "Grafana",
+	}
+
+	// API keys can't contain a colon, so auth with a colon is a username
+	// and a password.
+	if parts := strings.SplitN(c.Auth, ":", 2); len(parts) == 2 {
+		api.Username, api.Password = parts[0], parts[1]
+	} else {
+		api.Header = http.Header{"Authorization": []string{"Bearer " + c.Auth}}
+	}
+
+	log.Printf("[INFO] Grafana client configured for %s", c.URL)
+	return &Client{api: api}, nil
+}
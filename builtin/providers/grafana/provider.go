@@ -0,0 +1,46 @@
+package grafana
+
+import (
+	"log"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+// Provider returns a terraform.ResourceProvider.
+func Provider() terraform.ResourceProvider {
+	return &schema.Provider{
+		Schema: map[string]*schema.Schema{
+			"url": &schema.Schema{
+				Type:        schema.TypeString,
+				Required:    true,
+				DefaultFunc: schema.EnvDefaultFunc("GRAFANA_URL", nil),
+				Description: "The URL of Grafana, such as http://grafana.local:3000.",
+			},
+
+			"auth": &schema.Schema{
+				Type:        schema.TypeString,
+				Required:    true,
+				DefaultFunc: schema.EnvDefaultFunc("GRAFANA_AUTH", nil),
+				Description: "An API key, or a username and password as USERNAME:PASSWORD.",
+			},
+		},
+
+		ResourcesMap: map[string]*schema.Resource{
+			"grafana_dashboard":   resourceGrafanaDashboard(),
+			"grafana_data_source": resourceGrafanaDataSource(),
+		},
+
+		ConfigureFunc: providerConfigure,
+	}
+}
+
+func providerConfigure(d *schema.ResourceData) (interface{}, error) {
+	config := Config{
+		URL:  d.Get("url").(string),
+		Auth: d.Get("auth").(string),
+	}
+
+	log.Println("[INFO] Initializing Grafana client")
+	return config.Client()
+}
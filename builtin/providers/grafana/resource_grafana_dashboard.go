@@ -0,0 +1,153 @@
+package grafana
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform/helper/httpapi"
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func resourceGrafanaDashboard() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceGrafanaDashboardCreate,
+		Read:   resourceGrafanaDashboardRead,
+		Update: resourceGrafanaDashboardUpdate,
+		Delete: resourceGrafanaDashboardDelete,
+
+		Schema: map[string]*schema.Schema{
+			// Only a hash of the JSON is stored, so that large dashboards
+			// don't bloat the state and diffs.
+			"config_json": &schema.Schema{
+				Type:      schema.TypeString,
+				Required:  true,
+				StateFunc: hashDashboardJSON,
+			},
+
+			"slug": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceGrafanaDashboardCreate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Client)
+
+	model, err := expandDashboard(d.Get("config_json").(string))
+	if err != nil {
+		return err
+	}
+
+	// A dashboard with the same title is overwritten, like Grafana does
+	// when a dashboard is imported.
+	log.Printf("[DEBUG] Creating Grafana dashboard: %v", model["title"])
+	slug, err := client.SaveDashboard(model)
+	if err != nil {
+		return fmt.Errorf("Error creating Grafana dashboard: %s", err)
+	}
+
+	d.SetId(slug)
+	log.Printf("[INFO] Grafana dashboard created: %s", d.Id())
+
+	return resourceGrafanaDashboardRead(d, meta)
+}
+
+func resourceGrafanaDashboardRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Client)
+
+	dashboard, err := client.Dashboard(d.Id())
+	if err != nil {
+		if httpapi.IsNotFound(err) {
+			d.SetId("")
+			return nil
+		}
+		return fmt.Errorf("Error reading Grafana dashboard %s: %s", d.Id(), err)
+	}
+
+	// Grafana adds its own defaults to the JSON of a dashboard, so it
+	// can't be compared with the configured one. Changes made in Grafana
+	// aren't detected.
+	d.Set("slug", dashboard.Meta.Slug)
+
+	return nil
+}
+
+func resourceGrafanaDashboardUpdate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Client)
+
+	model, err := expandDashboard(d.Get("config_json").(string))
+	if err != nil {
+		return err
+	}
+
+	log.Printf("[DEBUG] Updating Grafana dashboard: %s", d.Id())
+	slug, err := client.SaveDashboard(model)
+	if err != nil {
+		return fmt.Errorf("Error updating Grafana dashboard %s: %s", d.Id(), err)
+	}
+
+	// The slug is derived from the title, so a new title saves a new
+	// dashboard, and the old one is deleted.
+	if slug != d.Id() {
+		log.Printf("[INFO] Grafana dashboard %s renamed to %s", d.Id(), slug)
+		if err := client.DeleteDashboard(d.Id()); err != nil && !httpapi.IsNotFound(err) {
+			return fmt.Errorf("Error deleting Grafana dashboard %s: %s", d.Id(), err)
+		}
+		d.SetId(slug)
+	}
+
+	return resourceGrafanaDashboardRead(d, meta)
+}
+
+func resourceGrafanaDashboardDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Client)
+
+	log.Printf("[INFO] Deleting Grafana dashboard: %s", d.Id())
+	if err := client.DeleteDashboard(d.Id()); err != nil {
+		if httpapi.IsNotFound(err) {
+			return nil
+		}
+		return fmt.Errorf("Error deleting Grafana dashboard %s: %s", d.Id(), err)
+	}
+
+	d.SetId("")
+	return nil
+}
+
+// expandDashboard returns the model of the dashboard in the JSON,
+// without the ID and version that Grafana assigns, so that the JSON of an
+// exported dashboard can be used as is.
+func expandDashboard(config string) (map[string]interface{}, error) {
+	var model map[string]interface{}
+	if err := json.Unmarshal([]byte(config), &model); err != nil {
+		return nil, fmt.Errorf("Error parsing config_json: %s", err)
+	}
+
+	delete(model, "id")
+	delete(model, "version")
+	return model, nil
+}
+
+// hashDashboardJSON returns the hash of the model of the dashboard in the
+// JSON, so that changes to whitespace and ordering don't cause a diff.
+func hashDashboardJSON(v interface{}) string {
+	config, ok := v.(string)
+	if !ok {
+		return ""
+	}
+
+	normalized := config
+	if model, err := expandDashboard(config); err == nil {
+		if raw, err := json.Marshal(model); err == nil {
+			normalized = string(raw)
+		}
+	}
+
+	hash := sha1.Sum([]byte(normalized))
+	return hex.EncodeToString(hash[:])
+}
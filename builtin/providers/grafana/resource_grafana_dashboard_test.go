@@ -0,0 +1,130 @@
+package grafana
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/httpapi"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestAccGrafanaDashboard_basic(t *testing.T) {
+	var dashboard Dashboard
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckGrafanaDashboardDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccGrafanaDashboardConfig_basic,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckGrafanaDashboardExists("grafana_dashboard.test", &dashboard),
+					resource.TestCheckResourceAttr(
+						"grafana_dashboard.test", "slug", "tf-acc-test"),
+				),
+			},
+			resource.TestStep{
+				Config: testAccGrafanaDashboardConfig_rename,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckGrafanaDashboardExists("grafana_dashboard.test", &dashboard),
+					resource.TestCheckResourceAttr(
+						"grafana_dashboard.test", "slug", "tf-acc-test-renamed"),
+					func(*terraform.State) error {
+						client := testAccProvider.Meta().(*Client)
+						if _, err := client.Dashboard("tf-acc-test"); !httpapi.IsNotFound(err) {
+							return fmt.Errorf("old dashboard still exists: %v", err)
+						}
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
+
+func TestHashDashboardJSON(t *testing.T) {
+	expected := hashDashboardJSON(`{"title": "Test", "rows": []}`)
+
+	cases := []struct {
+		JSON  string
+		Equal bool
+	}{
+		{`{"title":"Test","rows":[]}`, true},
+		{`{"rows": [], "title": "Test"}`, true},
+		{`{"id": 3, "version": 7, "title": "Test", "rows": []}`, true},
+		{`{"title": "Other", "rows": []}`, false},
+		{`{"title": "Test", "rows": [{}]}`, false},
+	}
+
+	for i, tc := range cases {
+		if actual := hashDashboardJSON(tc.JSON); (actual == expected) != tc.Equal {
+			t.Fatalf("%d: bad: %s", i, actual)
+		}
+	}
+}
+
+func testAccCheckGrafanaDashboardExists(n string, dashboard *Dashboard) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No dashboard slug is set")
+		}
+
+		client := testAccProvider.Meta().(*Client)
+		result, err := client.Dashboard(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+
+		*dashboard = *result
+		return nil
+	}
+}
+
+func testAccCheckGrafanaDashboardDestroy(s *terraform.State) error {
+	client := testAccProvider.Meta().(*Client)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "grafana_dashboard" {
+			continue
+		}
+
+		_, err := client.Dashboard(rs.Primary.ID)
+		if err == nil {
+			return fmt.Errorf("Dashboard still exists: %s", rs.Primary.ID)
+		}
+		if !httpapi.IsNotFound(err) {
+			return err
+		}
+	}
+
+	return nil
+}
+
+const testAccGrafanaDashboardConfig_basic = `
+resource "grafana_dashboard" "test" {
+	config_json = <<EOT
+{
+	"title": "tf-acc-test",
+	"rows": []
+}
+EOT
+}
+`
+
+const testAccGrafanaDashboardConfig_rename = `
+resource "grafana_dashboard" "test" {
+	config_json = <<EOT
+{
+	"title": "tf-acc-test-renamed",
+	"rows": []
+}
+EOT
+}
+`
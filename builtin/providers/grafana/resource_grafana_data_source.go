@@ -0,0 +1,184 @@
+package grafana
+
+import (
+	"fmt"
+	"log"
+	"strconv"
+
+	"github.com/hashicorp/terraform/helper/httpapi"
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func resourceGrafanaDataSource() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceGrafanaDataSourceCreate,
+		Read:   resourceGrafanaDataSourceRead,
+		Update: resourceGrafanaDataSourceUpdate,
+		Delete: resourceGrafanaDataSourceDelete,
+
+		Schema: map[string]*schema.Schema{
+			"name": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+			},
+
+			"type": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+			},
+
+			"url": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+			},
+
+			"access_mode": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  "proxy",
+			},
+
+			"database_name": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"username": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"password": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"basic_auth_enabled": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+
+			"basic_auth_username": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"basic_auth_password": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"is_default": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+		},
+	}
+}
+
+func resourceGrafanaDataSourceCreate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Client)
+
+	ds := expandDataSource(d)
+	log.Printf("[DEBUG] Creating Grafana data source: %s", ds.Name)
+	id, err := client.CreateDataSource(ds)
+	if err != nil {
+		return fmt.Errorf("Error creating Grafana data source %s: %s", ds.Name, err)
+	}
+
+	d.SetId(strconv.FormatInt(id, 10))
+	log.Printf("[INFO] Grafana data source created: %s", d.Id())
+
+	return resourceGrafanaDataSourceRead(d, meta)
+}
+
+func resourceGrafanaDataSourceRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Client)
+
+	id, err := strconv.ParseInt(d.Id(), 10, 64)
+	if err != nil {
+		return fmt.Errorf("Invalid Grafana data source ID %q", d.Id())
+	}
+
+	ds, err := client.DataSource(id)
+	if err != nil {
+		if httpapi.IsNotFound(err) {
+			d.SetId("")
+			return nil
+		}
+		return fmt.Errorf("Error reading Grafana data source %s: %s", d.Id(), err)
+	}
+
+	// The passwords are returned as they are stored, so they are read
+	// back as well.
+	d.Set("name", ds.Name)
+	d.Set("type", ds.Type)
+	d.Set("url", ds.URL)
+	d.Set("access_mode", ds.Access)
+	d.Set("database_name", ds.Database)
+	d.Set("username", ds.User)
+	d.Set("password", ds.Password)
+	d.Set("basic_auth_enabled", ds.BasicAuth)
+	d.Set("basic_auth_username", ds.BasicAuthUser)
+	d.Set("basic_auth_password", ds.BasicAuthPassword)
+	d.Set("is_default", ds.IsDefault)
+
+	return nil
+}
+
+func resourceGrafanaDataSourceUpdate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Client)
+
+	id, err := strconv.ParseInt(d.Id(), 10, 64)
+	if err != nil {
+		return fmt.Errorf("Invalid Grafana data source ID %q", d.Id())
+	}
+
+	ds := expandDataSource(d)
+	ds.ID = id
+	log.Printf("[DEBUG] Updating Grafana data source: %s", d.Id())
+	if err := client.UpdateDataSource(ds); err != nil {
+		return fmt.Errorf("Error updating Grafana data source %s: %s", d.Id(), err)
+	}
+
+	return resourceGrafanaDataSourceRead(d, meta)
+}
+
+func resourceGrafanaDataSourceDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Client)
+
+	id, err := strconv.ParseInt(d.Id(), 10, 64)
+	if err != nil {
+		return fmt.Errorf("Invalid Grafana data source ID %q", d.Id())
+	}
+
+	log.Printf("[INFO] Deleting Grafana data source: %s", d.Id())
+	if err := client.DeleteDataSource(id); err != nil {
+		if httpapi.IsNotFound(err) {
+			return nil
+		}
+		return fmt.Errorf("Error deleting Grafana data source %s: %s", d.Id(), err)
+	}
+
+	d.SetId("")
+	return nil
+}
+
+// expandDataSource returns the data source of the resource.
+func expandDataSource(d *schema.ResourceData) *DataSource {
+	return &DataSource{
+		Name:              d.Get("name").(string),
+		Type:              d.Get("type").(string),
+		URL:               d.Get("url").(string),
+		Access:            d.Get("access_mode").(string),
+		Database:          d.Get("database_name").(string),
+		User:              d.Get("username").(string),
+		Password:          d.Get("password").(string),
+		BasicAuth:         d.Get("basic_auth_enabled").(bool),
+		BasicAuthUser:     d.Get("basic_auth_username").(string),
+		BasicAuthPassword: d.Get("basic_auth_password").(string),
+		IsDefault:         d.Get("is_default").(bool),
+	}
+}
@@ -0,0 +1,120 @@
+package grafana
+
+import (
+	"fmt"
+	"strconv"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/httpapi"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestAccGrafanaDataSource_basic(t *testing.T) {
+	var ds DataSource
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckGrafanaDataSourceDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccGrafanaDataSourceConfig_basic,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckGrafanaDataSourceExists("grafana_data_source.test", &ds),
+					resource.TestCheckResourceAttr(
+						"grafana_data_source.test", "type", "influxdb"),
+					resource.TestCheckResourceAttr(
+						"grafana_data_source.test", "access_mode", "proxy"),
+				),
+			},
+			resource.TestStep{
+				Config: testAccGrafanaDataSourceConfig_update,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckGrafanaDataSourceExists("grafana_data_source.test", &ds),
+					resource.TestCheckResourceAttr(
+						"grafana_data_source.test", "database_name", "updated"),
+					func(*terraform.State) error {
+						if ds.Database != "updated" {
+							return fmt.Errorf("bad database: %s", ds.Database)
+						}
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckGrafanaDataSourceExists(n string, ds *DataSource) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No data source ID is set")
+		}
+		id, err := strconv.ParseInt(rs.Primary.ID, 10, 64)
+		if err != nil {
+			return err
+		}
+
+		client := testAccProvider.Meta().(*Client)
+		result, err := client.DataSource(id)
+		if err != nil {
+			return err
+		}
+
+		*ds = *result
+		return nil
+	}
+}
+
+func testAccCheckGrafanaDataSourceDestroy(s *terraform.State) error {
+	client := testAccProvider.Meta().(*Client)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "grafana_data_source" {
+			continue
+		}
+
+		id, err := strconv.ParseInt(rs.Primary.ID, 10, 64)
+		if err != nil {
+			return err
+		}
+
+		_, err = client.DataSource(id)
+		if err == nil {
+			return fmt.Errorf("Data source still exists: %s", rs.Primary.ID)
+		}
+		if !httpapi.IsNotFound(err) {
+			return err
+		}
+	}
+
+	return nil
+}
+
+const testAccGrafanaDataSourceConfig_basic = `
+resource "grafana_data_source" "test" {
+	name = "tf-acc-test"
+	type = "influxdb"
+	url = "http://influxdb.local:8086/"
+	database_name = "metrics"
+	username = "grafana"
+	password = "secret"
+}
+`
+
+const testAccGrafanaDataSourceConfig_update = `
+resource "grafana_data_source" "test" {
+	name = "tf-acc-test"
+	type = "influxdb"
+	url = "http://influxdb.local:8086/"
+	database_name = "updated"
+	username = "grafana"
+	password = "secret"
+}
+`
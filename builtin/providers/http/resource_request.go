@@ -0,0 +1,195 @@
+package http
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func resourceRequest() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceRequestCreate,
+		Read:   resourceRequestRead,
+		Delete: resourceRequestDelete,
+
+		Schema: map[string]*schema.Schema{
+			"url": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"request_headers": &schema.Schema{
+				Type:     schema.TypeMap,
+				Optional: true,
+				ForceNew: true,
+			},
+
+			"username": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+
+			"password": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+
+			"ca_file": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+
+			"insecure": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				ForceNew: true,
+			},
+
+			"timeout": &schema.Schema{
+				Type:     schema.TypeInt,
+				Optional: true,
+				ForceNew: true,
+				Default:  30,
+			},
+
+			"body": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"status_code": &schema.Schema{
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+
+			"response_headers": &schema.Schema{
+				Type:     schema.TypeMap,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceRequestCreate(d *schema.ResourceData, meta interface{}) error {
+	return resourceRequestRead(d, meta)
+}
+
+func resourceRequestRead(d *schema.ResourceData, meta interface{}) error {
+	opts := &requestOpts{
+		URL:      d.Get("url").(string),
+		Headers:  make(map[string]string),
+		Username: d.Get("username").(string),
+		Password: d.Get("password").(string),
+		CAFile:   d.Get("ca_file").(string),
+		Insecure: d.Get("insecure").(bool),
+		Timeout:  time.Duration(d.Get("timeout").(int)) * time.Second,
+	}
+	for k, v := range d.Get("request_headers").(map[string]interface{}) {
+		opts.Headers[k] = v.(string)
+	}
+
+	resp, err := doRequest(opts)
+	if err != nil {
+		return err
+	}
+
+	d.SetId(opts.URL)
+	d.Set("body", resp.Body)
+	d.Set("status_code", resp.StatusCode)
+	d.Set("response_headers", resp.Headers)
+	return nil
+}
+
+func resourceRequestDelete(d *schema.ResourceData, meta interface{}) error {
+	d.SetId("")
+	return nil
+}
+
+// requestOpts are the options of a request.
+type requestOpts struct {
+	URL      string
+	Headers  map[string]string
+	Username string
+	Password string
+	CAFile   string
+	Insecure bool
+	Timeout  time.Duration
+}
+
+// response is the response to a request.
+type response struct {
+	StatusCode int
+	Headers    map[string]string
+	Body       string
+}
+
+// doRequest sends a GET request. Responses with any status are returned,
+// so the status can be checked in the configuration.
+func doRequest(opts *requestOpts) (*response, error) {
+	tlsConfig := &tls.Config{InsecureSkipVerify: opts.Insecure}
+	if opts.CAFile != "" {
+		pem, err := ioutil.ReadFile(opts.CAFile)
+		if err != nil {
+			return nil, fmt.Errorf("Error reading ca_file: %s", err)
+		}
+
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf(
+				"Error reading ca_file: no certificates found in %s", opts.CAFile)
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	client := &http.Client{
+		Timeout: opts.Timeout,
+		Transport: &http.Transport{
+			Proxy:           http.ProxyFromEnvironment,
+			TLSClientConfig: tlsConfig,
+		},
+	}
+
+	req, err := http.NewRequest("GET", opts.URL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("Error creating request: %s", err)
+	}
+	for k, v := range opts.Headers {
+		req.Header.Set(k, v)
+	}
+	if opts.Username != "" || opts.Password != "" {
+		req.SetBasicAuth(opts.Username, opts.Password)
+	}
+
+	log.Printf("[DEBUG] Requesting %s", opts.URL)
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("Error requesting %s: %s", opts.URL, err)
+	}
+	defer resp.Body.Close()
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("Error reading response from %s: %s", opts.URL, err)
+	}
+
+	result := &response{
+		StatusCode: resp.StatusCode,
+		Headers:    make(map[string]string),
+		Body:       string(body),
+	}
+	for k, _ := range resp.Header {
+		result.Headers[k] = resp.Header.Get(k)
+	}
+
+	return result, nil
+}
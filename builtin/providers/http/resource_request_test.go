@@ -0,0 +1,144 @@
+package http
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	r "github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestDoRequest(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(testHandler))
+	defer server.Close()
+
+	cases := []struct {
+		Opts   *requestOpts
+		Status int
+		Body   string
+	}{
+		{
+			&requestOpts{URL: server.URL + "/ips"},
+			200,
+			"10.0.0.0/8",
+		},
+		{
+			&requestOpts{
+				URL:     server.URL + "/header",
+				Headers: map[string]string{"X-Token": "secret"},
+			},
+			200,
+			"secret",
+		},
+		{
+			&requestOpts{
+				URL:      server.URL + "/auth",
+				Username: "foo",
+				Password: "bar",
+			},
+			200,
+			"foo:bar",
+		},
+		{
+			&requestOpts{URL: server.URL + "/auth"},
+			401,
+			"",
+		},
+	}
+
+	for i, tc := range cases {
+		tc.Opts.Timeout = 5 * time.Second
+		resp, err := doRequest(tc.Opts)
+		if err != nil {
+			t.Fatalf("%d: err: %s", i, err)
+		}
+		if resp.StatusCode != tc.Status || resp.Body != tc.Body {
+			t.Fatalf("%d: bad: %#v", i, resp)
+		}
+	}
+}
+
+func TestDoRequest_tls(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(testHandler))
+	defer server.Close()
+
+	opts := &requestOpts{URL: server.URL + "/ips", Timeout: 5 * time.Second}
+	if _, err := doRequest(opts); err == nil {
+		t.Fatal("should error with an unknown certificate")
+	}
+
+	opts.Insecure = true
+	resp, err := doRequest(opts)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if resp.Body != "10.0.0.0/8" {
+		t.Fatalf("bad: %#v", resp)
+	}
+}
+
+func TestDoRequest_badCAFile(t *testing.T) {
+	opts := &requestOpts{URL: "https://127.0.0.1", CAFile: "/nope"}
+	if _, err := doRequest(opts); err == nil {
+		t.Fatal("should error")
+	}
+}
+
+func TestRequest(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(testHandler))
+	defer server.Close()
+
+	r.Test(t, r.TestCase{
+		Providers: testProviders,
+		Steps: []r.TestStep{
+			r.TestStep{
+				Config: fmt.Sprintf(testRequestConfig, server.URL),
+				Check: func(s *terraform.State) error {
+					outputs := s.RootModule().Outputs
+					if outputs["body"] != "10.0.0.0/8" {
+						return fmt.Errorf("bad body: %s", outputs["body"])
+					}
+					if outputs["status"] != "200" {
+						return fmt.Errorf("bad status: %s", outputs["status"])
+					}
+					return nil
+				},
+			},
+		},
+	})
+}
+
+func testHandler(w http.ResponseWriter, r *http.Request) {
+	switch r.URL.Path {
+	case "/ips":
+		w.Write([]byte("10.0.0.0/8"))
+	case "/header":
+		w.Write([]byte(r.Header.Get("X-Token")))
+	case "/auth":
+		u, p, ok := r.BasicAuth()
+		if !ok {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.Write([]byte(u + ":" + p))
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+const testRequestConfig = `
+resource "http_request" "ips" {
+	url = "%s/ips"
+}
+
+output "body" {
+	value = "${http_request.ips.body}"
+}
+
+output "status" {
+	value = "${http_request.ips.status_code}"
+}
+`
@@ -0,0 +1,115 @@
+package influxdb
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/httpapi"
+)
+
+var stringLiteral = regexp.MustCompile(`'(?:[^'\\]|\\.)*'`)
+
+// Client is a client for the query API of InfluxDB 0.9, through which
+// databases, retention policies and users are managed.
+type Client struct {
+	api *httpapi.Client
+}
+
+// Series is a series of the result of a query, such as the databases
+// listed by SHOW DATABASES.
+type Series struct {
+	Name    string          `json:"name"`
+	Columns []string        `json:"columns"`
+	Values  [][]interface{} `json:"values"`
+}
+
+// Rows returns the values of the series as maps of column names to
+// values.
+func (s Series) Rows() []map[string]interface{} {
+	rows := make([]map[string]interface{}, 0, len(s.Values))
+	for _, v := range s.Values {
+		row := make(map[string]interface{}, len(s.Columns))
+		for i, c := range s.Columns {
+			if i < len(v) {
+				row[c] = v[i]
+			}
+		}
+		rows = append(rows, row)
+	}
+
+	return rows
+}
+
+// Query runs a single statement, and returns the series of its result.
+func (c *Client) Query(q string) ([]Series, error) {
+	// String literals are only used for passwords, which aren't logged
+	log.Printf("[DEBUG] InfluxDB query: %s", stringLiteral.ReplaceAllString(q, "'***'"))
+	raw, _, err := c.api.Send("POST", "/query", "application/x-www-form-urlencoded",
+		[]byte(url.Values{"q": {q}}.Encode()))
+	if err != nil {
+		return nil, err
+	}
+
+	var result struct {
+		Results []struct {
+			Series []Series `json:"series"`
+			Err    string   `json:"error"`
+		} `json:"results"`
+		Err string `json:"error"`
+	}
+	if err := json.Unmarshal(raw, &result); err != nil {
+		return nil, fmt.Errorf("Error parsing InfluxDB response: %s", err)
+	}
+	if result.Err != "" {
+		return nil, fmt.Errorf("%s", result.Err)
+	}
+	if len(result.Results) == 0 {
+		return nil, nil
+	}
+	if result.Results[0].Err != "" {
+		return nil, fmt.Errorf("%s", result.Results[0].Err)
+	}
+
+	return result.Results[0].Series, nil
+}
+
+// Exec runs a single statement that has no result, such as CREATE
+// DATABASE.
+func (c *Client) Exec(format string, a ...interface{}) error {
+	_, err := c.Query(fmt.Sprintf(format, a...))
+	return err
+}
+
+// errorMessage returns the error in the body of a failed answer.
+func errorMessage(body []byte) string {
+	var status struct {
+		Err string `json:"error"`
+	}
+	if err := json.Unmarshal(body, &status); err != nil {
+		return ""
+	}
+
+	return status.Err
+}
+
+// quoteIdent quotes the name of a database, retention policy or user for
+// a statement.
+func quoteIdent(s string) string {
+	return `"` + strings.Replace(strings.Replace(s, `\`, `\\`, -1), `"`, `\"`, -1) + `"`
+}
+
+// quoteString quotes a string literal, such as a password, for a
+// statement.
+func quoteString(s string) string {
+	return `'` + strings.Replace(strings.Replace(s, `\`, `\\`, -1), `'`, `\'`, -1) + `'`
+}
+
+// isNotFound returns whether the error is the answer of InfluxDB for a
+// database, retention policy or user that doesn't exist.
+func isNotFound(err error) bool {
+	return err != nil && strings.Contains(err.Error(), "not found")
+}
@@ -0,0 +1,62 @@
+package influxdb
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestQuoteIdent(t *testing.T) {
+	cases := []struct {
+		Input    string
+		Expected string
+	}{
+		{"metrics", `"metrics"`},
+		{"my-db", `"my-db"`},
+		{`a"b`, `"a\"b"`},
+		{`a\b`, `"a\\b"`},
+	}
+
+	for i, tc := range cases {
+		if actual := quoteIdent(tc.Input); actual != tc.Expected {
+			t.Fatalf("%d: bad: %s", i, actual)
+		}
+	}
+}
+
+func TestQuoteString(t *testing.T) {
+	cases := []struct {
+		Input    string
+		Expected string
+	}{
+		{"secret", `'secret'`},
+		{"it's", `'it\'s'`},
+		{`a\'b`, `'a\\\'b'`},
+	}
+
+	for i, tc := range cases {
+		if actual := quoteString(tc.Input); actual != tc.Expected {
+			t.Fatalf("%d: bad: %s", i, actual)
+		}
+		if actual := stringLiteral.ReplaceAllString(quoteString(tc.Input), "'***'"); actual != "'***'" {
+			t.Fatalf("%d: bad redaction: %s", i, actual)
+		}
+	}
+}
+
+func TestSeriesRows(t *testing.T) {
+	s := Series{
+		Columns: []string{"name", "default"},
+		Values: [][]interface{}{
+			{"default", true},
+			{"week", false},
+		},
+	}
+
+	expected := []map[string]interface{}{
+		map[string]interface{}{"name": "default", "default": true},
+		map[string]interface{}{"name": "week", "default": false},
+	}
+	if actual := s.Rows(); !reflect.DeepEqual(actual, expected) {
+		t.Fatalf("bad: %#v", actual)
+	}
+}
@@ -0,0 +1,35 @@
+package influxdb
+
+import (
+	"fmt"
+	"log"
+	"net/url"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/httpapi"
+)
+
+type Config struct {
+	URL      string
+	Username string
+	Password string
+}
+
+// Client returns a new client for the InfluxDB API.
+func (c *Config) Client() (*Client, error) {
+	u, err := url.Parse(c.URL)
+	if err != nil || u.Scheme == "" || u.Host == "" {
+		return nil, fmt.Errorf("Invalid InfluxDB URL %q", c.URL)
+	}
+
+	log.Printf("[INFO] InfluxDB client configured for %s", c.URL)
+	return &Client{
+		api: &httpapi.Client{
+			BaseURL:      strings.TrimSuffix(c.URL, "/"),
+			Name:         "InfluxDB",
+			Username:     c.Username,
+			Password:     c.Password,
+			ErrorMessage: errorMessage,
+		},
+	}, nil
+}
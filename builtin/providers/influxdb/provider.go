@@ -0,0 +1,55 @@
+package influxdb
+
+import (
+	"log"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+// Provider returns a terraform.ResourceProvider.
+func Provider() terraform.ResourceProvider {
+	return &schema.Provider{
+		Schema: map[string]*schema.Schema{
+			"url": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("INFLUXDB_URL", "http://localhost:8086/"),
+				Description: "The URL of the HTTP API of InfluxDB.",
+			},
+
+			"username": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("INFLUXDB_USERNAME", ""),
+				Description: "The username of an admin user.",
+			},
+
+			"password": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("INFLUXDB_PASSWORD", ""),
+				Description: "The password of the admin user.",
+			},
+		},
+
+		ResourcesMap: map[string]*schema.Resource{
+			"influxdb_database":         resourceInfluxDBDatabase(),
+			"influxdb_retention_policy": resourceInfluxDBRetentionPolicy(),
+			"influxdb_user":             resourceInfluxDBUser(),
+		},
+
+		ConfigureFunc: providerConfigure,
+	}
+}
+
+func providerConfigure(d *schema.ResourceData) (interface{}, error) {
+	config := Config{
+		URL:      d.Get("url").(string),
+		Username: d.Get("username").(string),
+		Password: d.Get("password").(string),
+	}
+
+	log.Println("[INFO] Initializing InfluxDB client")
+	return config.Client()
+}
@@ -0,0 +1,66 @@
+package influxdb
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+var testAccProviders map[string]terraform.ResourceProvider
+var testAccProvider *schema.Provider
+
+func init() {
+	testAccProvider = Provider().(*schema.Provider)
+	testAccProviders = map[string]terraform.ResourceProvider{
+		"influxdb": testAccProvider,
+	}
+}
+
+func TestProvider(t *testing.T) {
+	if err := Provider().(*schema.Provider).InternalValidate(); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+}
+
+func TestProvider_impl(t *testing.T) {
+	var _ terraform.ResourceProvider = Provider()
+}
+
+func testAccPreCheck(t *testing.T) {
+	if v := os.Getenv("INFLUXDB_URL"); v == "" {
+		t.Fatal("INFLUXDB_URL must be set for acceptance tests")
+	}
+}
+
+// testAccCheckInfluxDBRow checks whether the result of the query has a
+// row with the given value in the given column.
+func testAccCheckInfluxDBRow(q, column, value string, exists bool) resource.TestCheckFunc {
+	return func(*terraform.State) error {
+		client := testAccProvider.Meta().(*Client)
+		series, err := client.Query(q)
+		if err != nil {
+			if !exists && isNotFound(err) {
+				return nil
+			}
+			return err
+		}
+
+		found := false
+		for _, s := range series {
+			for _, row := range s.Rows() {
+				if row[column] == value {
+					found = true
+				}
+			}
+		}
+		if found != exists {
+			return fmt.Errorf("%s: %s %q exists: %t", q, column, value, found)
+		}
+
+		return nil
+	}
+}
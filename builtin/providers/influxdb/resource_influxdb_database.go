@@ -0,0 +1,76 @@
+package influxdb
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func resourceInfluxDBDatabase() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceInfluxDBDatabaseCreate,
+		Read:   resourceInfluxDBDatabaseRead,
+		Delete: resourceInfluxDBDatabaseDelete,
+
+		Schema: map[string]*schema.Schema{
+			"name": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+		},
+	}
+}
+
+func resourceInfluxDBDatabaseCreate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Client)
+
+	name := d.Get("name").(string)
+	log.Printf("[DEBUG] Creating InfluxDB database: %s", name)
+	if err := client.Exec("CREATE DATABASE %s", quoteIdent(name)); err != nil {
+		return fmt.Errorf("Error creating InfluxDB database %s: %s", name, err)
+	}
+
+	d.SetId(name)
+	log.Printf("[INFO] InfluxDB database created: %s", d.Id())
+
+	return resourceInfluxDBDatabaseRead(d, meta)
+}
+
+func resourceInfluxDBDatabaseRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Client)
+
+	series, err := client.Query("SHOW DATABASES")
+	if err != nil {
+		return fmt.Errorf("Error reading InfluxDB databases: %s", err)
+	}
+
+	for _, s := range series {
+		for _, row := range s.Rows() {
+			if row["name"] == d.Id() {
+				d.Set("name", d.Id())
+				return nil
+			}
+		}
+	}
+
+	log.Printf("[WARN] InfluxDB database %s not found", d.Id())
+	d.SetId("")
+	return nil
+}
+
+func resourceInfluxDBDatabaseDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Client)
+
+	log.Printf("[INFO] Deleting InfluxDB database: %s", d.Id())
+	if err := client.Exec("DROP DATABASE %s", quoteIdent(d.Id())); err != nil {
+		if isNotFound(err) {
+			return nil
+		}
+		return fmt.Errorf("Error deleting InfluxDB database %s: %s", d.Id(), err)
+	}
+
+	d.SetId("")
+	return nil
+}
@@ -0,0 +1,48 @@
+package influxdb
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestAccInfluxDBDatabase_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckInfluxDBDatabaseDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccInfluxDBDatabaseConfig,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckInfluxDBRow(
+						"SHOW DATABASES", "name", "tf_acc_test", true),
+					resource.TestCheckResourceAttr(
+						"influxdb_database.test", "name", "tf_acc_test"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckInfluxDBDatabaseDestroy(s *terraform.State) error {
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "influxdb_database" {
+			continue
+		}
+
+		check := testAccCheckInfluxDBRow("SHOW DATABASES", "name", rs.Primary.ID, false)
+		if err := check(s); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+const testAccInfluxDBDatabaseConfig = `
+resource "influxdb_database" "test" {
+	name = "tf_acc_test"
+}
+`
@@ -0,0 +1,202 @@
+package influxdb
+
+import (
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func resourceInfluxDBRetentionPolicy() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceInfluxDBRetentionPolicyCreate,
+		Read:   resourceInfluxDBRetentionPolicyRead,
+		Update: resourceInfluxDBRetentionPolicyUpdate,
+		Delete: resourceInfluxDBRetentionPolicyDelete,
+
+		Schema: map[string]*schema.Schema{
+			"name": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"database": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"duration": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+			},
+
+			"replication": &schema.Schema{
+				Type:     schema.TypeInt,
+				Optional: true,
+				Default:  1,
+			},
+
+			"default": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+		},
+	}
+}
+
+func resourceInfluxDBRetentionPolicyCreate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Client)
+
+	name := d.Get("name").(string)
+	database := d.Get("database").(string)
+	if _, err := parseDuration(d.Get("duration").(string)); err != nil {
+		return err
+	}
+
+	q := fmt.Sprintf(
+		"CREATE RETENTION POLICY %s ON %s DURATION %s REPLICATION %d",
+		quoteIdent(name), quoteIdent(database),
+		d.Get("duration").(string), d.Get("replication").(int))
+	if d.Get("default").(bool) {
+		q += " DEFAULT"
+	}
+
+	log.Printf("[DEBUG] Creating InfluxDB retention policy %s on %s", name, database)
+	if _, err := client.Query(q); err != nil {
+		return fmt.Errorf(
+			"Error creating InfluxDB retention policy %s on %s: %s",
+			name, database, err)
+	}
+
+	d.SetId(database + "/" + name)
+	log.Printf("[INFO] InfluxDB retention policy created: %s", d.Id())
+
+	return resourceInfluxDBRetentionPolicyRead(d, meta)
+}
+
+func resourceInfluxDBRetentionPolicyRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Client)
+
+	name := d.Get("name").(string)
+	database := d.Get("database").(string)
+	series, err := client.Query(
+		fmt.Sprintf("SHOW RETENTION POLICIES ON %s", quoteIdent(database)))
+	if err != nil {
+		if isNotFound(err) {
+			log.Printf("[WARN] InfluxDB database %s not found", database)
+			d.SetId("")
+			return nil
+		}
+		return fmt.Errorf(
+			"Error reading InfluxDB retention policies on %s: %s", database, err)
+	}
+
+	for _, s := range series {
+		for _, row := range s.Rows() {
+			if row["name"] != name {
+				continue
+			}
+
+			// The duration is returned as a Go duration, such as
+			// "168h0m0s", so the configured one is kept if it's the same.
+			if duration, ok := row["duration"].(string); ok {
+				current, err := parseDuration(d.Get("duration").(string))
+				actual, aerr := parseDuration(duration)
+				if err != nil || aerr != nil || current != actual {
+					d.Set("duration", duration)
+				}
+			}
+			if replication, ok := row["replicaN"].(float64); ok {
+				d.Set("replication", int(replication))
+			}
+			if def, ok := row["default"].(bool); ok {
+				d.Set("default", def)
+			}
+
+			return nil
+		}
+	}
+
+	log.Printf("[WARN] InfluxDB retention policy %s not found", d.Id())
+	d.SetId("")
+	return nil
+}
+
+func resourceInfluxDBRetentionPolicyUpdate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Client)
+
+	if _, err := parseDuration(d.Get("duration").(string)); err != nil {
+		return err
+	}
+
+	q := fmt.Sprintf(
+		"ALTER RETENTION POLICY %s ON %s DURATION %s REPLICATION %d",
+		quoteIdent(d.Get("name").(string)), quoteIdent(d.Get("database").(string)),
+		d.Get("duration").(string), d.Get("replication").(int))
+
+	// A retention policy stops being the default one when another one
+	// becomes the default, so there is no statement to unset it.
+	if d.Get("default").(bool) {
+		q += " DEFAULT"
+	}
+
+	log.Printf("[DEBUG] Updating InfluxDB retention policy: %s", d.Id())
+	if _, err := client.Query(q); err != nil {
+		return fmt.Errorf(
+			"Error updating InfluxDB retention policy %s: %s", d.Id(), err)
+	}
+
+	return resourceInfluxDBRetentionPolicyRead(d, meta)
+}
+
+func resourceInfluxDBRetentionPolicyDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Client)
+
+	log.Printf("[INFO] Deleting InfluxDB retention policy: %s", d.Id())
+	err := client.Exec(
+		"DROP RETENTION POLICY %s ON %s",
+		quoteIdent(d.Get("name").(string)), quoteIdent(d.Get("database").(string)))
+	if err != nil {
+		if isNotFound(err) {
+			return nil
+		}
+		return fmt.Errorf(
+			"Error deleting InfluxDB retention policy %s: %s", d.Id(), err)
+	}
+
+	d.SetId("")
+	return nil
+}
+
+// parseDuration parses the duration of a retention policy, such as "7d"
+// or "INF", which is returned as 0.
+func parseDuration(s string) (time.Duration, error) {
+	if strings.ToUpper(s) == "INF" {
+		return 0, nil
+	}
+
+	for suffix, unit := range map[string]time.Duration{
+		"d": 24 * time.Hour,
+		"w": 7 * 24 * time.Hour,
+	} {
+		if strings.HasSuffix(s, suffix) {
+			n, err := strconv.Atoi(strings.TrimSuffix(s, suffix))
+			if err != nil {
+				return 0, fmt.Errorf("Invalid duration %q", s)
+			}
+			return time.Duration(n) * unit, nil
+		}
+	}
+
+	result, err := time.ParseDuration(s)
+	if err != nil {
+		return 0, fmt.Errorf("Invalid duration %q", s)
+	}
+	return result, nil
+}
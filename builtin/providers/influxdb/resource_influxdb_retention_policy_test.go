@@ -0,0 +1,91 @@
+package influxdb
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform/helper/resource"
+)
+
+func TestAccInfluxDBRetentionPolicy_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckInfluxDBDatabaseDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccInfluxDBRetentionPolicyConfig_basic,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckInfluxDBRow(
+						`SHOW RETENTION POLICIES ON "tf_acc_test"`, "name", "week", true),
+					resource.TestCheckResourceAttr(
+						"influxdb_retention_policy.test", "duration", "7d"),
+					resource.TestCheckResourceAttr(
+						"influxdb_retention_policy.test", "default", "false"),
+				),
+			},
+			resource.TestStep{
+				Config: testAccInfluxDBRetentionPolicyConfig_update,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"influxdb_retention_policy.test", "duration", "14d"),
+					resource.TestCheckResourceAttr(
+						"influxdb_retention_policy.test", "default", "true"),
+				),
+			},
+		},
+	})
+}
+
+func TestParseDuration(t *testing.T) {
+	cases := []struct {
+		Input    string
+		Expected time.Duration
+		Err      bool
+	}{
+		{"INF", 0, false},
+		{"inf", 0, false},
+		{"0", 0, false},
+		{"7d", 7 * 24 * time.Hour, false},
+		{"2w", 14 * 24 * time.Hour, false},
+		{"168h0m0s", 7 * 24 * time.Hour, false},
+		{"90m", 90 * time.Minute, false},
+		{"xd", 0, true},
+		{"week", 0, true},
+	}
+
+	for i, tc := range cases {
+		actual, err := parseDuration(tc.Input)
+		if (err != nil) != tc.Err {
+			t.Fatalf("%d: err: %s", i, err)
+		}
+		if actual != tc.Expected {
+			t.Fatalf("%d: bad: %s", i, actual)
+		}
+	}
+}
+
+const testAccInfluxDBRetentionPolicyConfig_basic = `
+resource "influxdb_database" "test" {
+	name = "tf_acc_test"
+}
+
+resource "influxdb_retention_policy" "test" {
+	name = "week"
+	database = "${influxdb_database.test.name}"
+	duration = "7d"
+}
+`
+
+const testAccInfluxDBRetentionPolicyConfig_update = `
+resource "influxdb_database" "test" {
+	name = "tf_acc_test"
+}
+
+resource "influxdb_retention_policy" "test" {
+	name = "week"
+	database = "${influxdb_database.test.name}"
+	duration = "14d"
+	default = true
+}
+`
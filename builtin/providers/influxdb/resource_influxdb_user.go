@@ -0,0 +1,243 @@
+package influxdb
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/hashcode"
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func resourceInfluxDBUser() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceInfluxDBUserCreate,
+		Read:   resourceInfluxDBUserRead,
+		Update: resourceInfluxDBUserUpdate,
+		Delete: resourceInfluxDBUserDelete,
+
+		Schema: map[string]*schema.Schema{
+			"name": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"password": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+			},
+
+			"admin": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+
+			"grant": &schema.Schema{
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"database": &schema.Schema{
+							Type:     schema.TypeString,
+							Required: true,
+						},
+
+						"privilege": &schema.Schema{
+							Type:     schema.TypeString,
+							Required: true,
+							StateFunc: func(v interface{}) string {
+								return strings.ToUpper(v.(string))
+							},
+						},
+					},
+				},
+				Set: resourceInfluxDBGrantHash,
+			},
+		},
+	}
+}
+
+func resourceInfluxDBUserCreate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Client)
+
+	name := d.Get("name").(string)
+	q := fmt.Sprintf(
+		"CREATE USER %s WITH PASSWORD %s",
+		quoteIdent(name), quoteString(d.Get("password").(string)))
+	if d.Get("admin").(bool) {
+		q += " WITH ALL PRIVILEGES"
+	}
+
+	log.Printf("[DEBUG] Creating InfluxDB user: %s", name)
+	if _, err := client.Query(q); err != nil {
+		return fmt.Errorf("Error creating InfluxDB user %s: %s", name, err)
+	}
+
+	d.SetId(name)
+	log.Printf("[INFO] InfluxDB user created: %s", d.Id())
+
+	for _, g := range d.Get("grant").(*schema.Set).List() {
+		if err := grantPrivilege(client, name, g.(map[string]interface{})); err != nil {
+			return err
+		}
+	}
+
+	return resourceInfluxDBUserRead(d, meta)
+}
+
+func resourceInfluxDBUserRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Client)
+
+	series, err := client.Query("SHOW USERS")
+	if err != nil {
+		return fmt.Errorf("Error reading InfluxDB users: %s", err)
+	}
+
+	found := false
+	for _, s := range series {
+		for _, row := range s.Rows() {
+			if row["user"] == d.Id() {
+				found = true
+				admin, _ := row["admin"].(bool)
+				d.Set("admin", admin)
+			}
+		}
+	}
+	if !found {
+		log.Printf("[WARN] InfluxDB user %s not found", d.Id())
+		d.SetId("")
+		return nil
+	}
+
+	d.Set("name", d.Id())
+
+	series, err = client.Query(fmt.Sprintf("SHOW GRANTS FOR %s", quoteIdent(d.Id())))
+	if err != nil {
+		return fmt.Errorf("Error reading the grants of InfluxDB user %s: %s", d.Id(), err)
+	}
+
+	var grants []map[string]interface{}
+	for _, s := range series {
+		for _, row := range s.Rows() {
+			database, _ := row["database"].(string)
+			privilege, _ := row["privilege"].(string)
+			privilege = strings.TrimSuffix(privilege, " PRIVILEGES")
+			if database == "" || privilege == "" || privilege == "NO" {
+				continue
+			}
+
+			grants = append(grants, map[string]interface{}{
+				"database":  database,
+				"privilege": privilege,
+			})
+		}
+	}
+
+	return d.Set("grant", grants)
+}
+
+func resourceInfluxDBUserUpdate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Client)
+
+	name := quoteIdent(d.Id())
+
+	if d.HasChange("password") {
+		log.Printf("[DEBUG] Changing the password of InfluxDB user: %s", d.Id())
+		err := client.Exec(
+			"SET PASSWORD FOR %s = %s", name, quoteString(d.Get("password").(string)))
+		if err != nil {
+			return fmt.Errorf(
+				"Error changing the password of InfluxDB user %s: %s", d.Id(), err)
+		}
+	}
+
+	if d.HasChange("admin") {
+		var err error
+		if d.Get("admin").(bool) {
+			err = client.Exec("GRANT ALL PRIVILEGES TO %s", name)
+		} else {
+			err = client.Exec("REVOKE ALL PRIVILEGES FROM %s", name)
+		}
+		if err != nil {
+			return fmt.Errorf(
+				"Error changing the admin privileges of InfluxDB user %s: %s",
+				d.Id(), err)
+		}
+	}
+
+	if d.HasChange("grant") {
+		o, n := d.GetChange("grant")
+		os := o.(*schema.Set)
+		ns := n.(*schema.Set)
+
+		// Privileges are revoked first, so that a changed privilege on a
+		// database is revoked before the new one is granted.
+		for _, g := range os.Difference(ns).List() {
+			if err := revokePrivilege(client, d.Id(), g.(map[string]interface{})); err != nil {
+				return err
+			}
+		}
+		for _, g := range ns.Difference(os).List() {
+			if err := grantPrivilege(client, d.Id(), g.(map[string]interface{})); err != nil {
+				return err
+			}
+		}
+	}
+
+	return resourceInfluxDBUserRead(d, meta)
+}
+
+func resourceInfluxDBUserDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Client)
+
+	log.Printf("[INFO] Deleting InfluxDB user: %s", d.Id())
+	if err := client.Exec("DROP USER %s", quoteIdent(d.Id())); err != nil {
+		if isNotFound(err) {
+			return nil
+		}
+		return fmt.Errorf("Error deleting InfluxDB user %s: %s", d.Id(), err)
+	}
+
+	d.SetId("")
+	return nil
+}
+
+func grantPrivilege(client *Client, user string, g map[string]interface{}) error {
+	privilege := strings.ToUpper(g["privilege"].(string))
+	database := g["database"].(string)
+
+	log.Printf("[DEBUG] Granting %s on %s to InfluxDB user %s", privilege, database, user)
+	err := client.Exec(
+		"GRANT %s ON %s TO %s", privilege, quoteIdent(database), quoteIdent(user))
+	if err != nil {
+		return fmt.Errorf(
+			"Error granting %s on %s to InfluxDB user %s: %s",
+			privilege, database, user, err)
+	}
+
+	return nil
+}
+
+func revokePrivilege(client *Client, user string, g map[string]interface{}) error {
+	privilege := strings.ToUpper(g["privilege"].(string))
+	database := g["database"].(string)
+
+	log.Printf("[DEBUG] Revoking %s on %s from InfluxDB user %s", privilege, database, user)
+	err := client.Exec(
+		"REVOKE %s ON %s FROM %s", privilege, quoteIdent(database), quoteIdent(user))
+	if err != nil && !isNotFound(err) {
+		return fmt.Errorf(
+			"Error revoking %s on %s from InfluxDB user %s: %s",
+			privilege, database, user, err)
+	}
+
+	return nil
+}
+
+func resourceInfluxDBGrantHash(v interface{}) int {
+	m := v.(map[string]interface{})
+	return hashcode.String(fmt.Sprintf(
+		"%s-%s", m["database"].(string), strings.ToUpper(m["privilege"].(string))))
+}
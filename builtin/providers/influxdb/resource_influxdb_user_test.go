@@ -0,0 +1,89 @@
+package influxdb
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestAccInfluxDBUser_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckInfluxDBUserDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccInfluxDBUserConfig_basic,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckInfluxDBRow("SHOW USERS", "user", "tf_acc_test", true),
+					testAccCheckInfluxDBRow(
+						`SHOW GRANTS FOR "tf_acc_test"`, "privilege", "READ", true),
+					resource.TestCheckResourceAttr(
+						"influxdb_user.test", "admin", "false"),
+					resource.TestCheckResourceAttr(
+						"influxdb_user.test", "grant.#", "1"),
+				),
+			},
+			resource.TestStep{
+				Config: testAccInfluxDBUserConfig_update,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckInfluxDBRow(
+						`SHOW GRANTS FOR "tf_acc_test"`, "privilege", "READ", false),
+					testAccCheckInfluxDBRow(
+						`SHOW GRANTS FOR "tf_acc_test"`, "privilege", "WRITE", true),
+					resource.TestCheckResourceAttr(
+						"influxdb_user.test", "admin", "true"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckInfluxDBUserDestroy(s *terraform.State) error {
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "influxdb_user" {
+			continue
+		}
+
+		check := testAccCheckInfluxDBRow("SHOW USERS", "user", rs.Primary.ID, false)
+		if err := check(s); err != nil {
+			return err
+		}
+	}
+
+	return testAccCheckInfluxDBDatabaseDestroy(s)
+}
+
+const testAccInfluxDBUserConfig_basic = `
+resource "influxdb_database" "test" {
+	name = "tf_acc_test"
+}
+
+resource "influxdb_user" "test" {
+	name = "tf_acc_test"
+	password = "secret"
+
+	grant {
+		database = "${influxdb_database.test.name}"
+		privilege = "read"
+	}
+}
+`
+
+const testAccInfluxDBUserConfig_update = `
+resource "influxdb_database" "test" {
+	name = "tf_acc_test"
+}
+
+resource "influxdb_user" "test" {
+	name = "tf_acc_test"
+	password = "changed"
+	admin = true
+
+	grant {
+		database = "${influxdb_database.test.name}"
+		privilege = "WRITE"
+	}
+}
+`
@@ -0,0 +1,60 @@
+package kubernetes
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform/helper/httpapi"
+)
+
+// Client is a client for the v1 Kubernetes API and its apps group.
+type Client struct {
+	api *httpapi.Client
+}
+
+// Get reads the object at the given path of the API into out.
+func (c *Client) Get(path string, out interface{}) error {
+	return c.do("GET", path, nil, out)
+}
+
+// Create creates the object under the given path of the API, and
+// reads the created object into out.
+func (c *Client) Create(path string, in, out interface{}) error {
+	return c.do("POST", path, in, out)
+}
+
+// Update replaces the object at the given path of the API with in, and
+// reads the updated object into out. The object must have the resource
+// version of the object it replaces.
+func (c *Client) Update(path string, in, out interface{}) error {
+	return c.do("PUT", path, in, out)
+}
+
+// Delete deletes the object at the given path of the API.
+func (c *Client) Delete(path string) error {
+	return c.do("DELETE", path, nil, nil)
+}
+
+func (c *Client) do(method, path string, in, out interface{}) error {
+	return c.api.Do(method, path, in, out)
+}
+
+// namespacedPath returns the path of the API for the objects of the
+// given kind, such as "services", in the given namespace, or for the
+// object with the given name, if it isn't empty.
+func namespacedPath(kind, namespace, name string) string {
+	return groupPath("/api/v1", kind, namespace, name)
+}
+
+// appsPath returns the path of the API like namespacedPath, for the
+// objects of the apps group of the API, such as "deployments".
+func appsPath(kind, namespace, name string) string {
+	return groupPath("/apis/apps/v1", kind, namespace, name)
+}
+
+func groupPath(prefix, kind, namespace, name string) string {
+	path := fmt.Sprintf("%s/namespaces/%s/%s", prefix, namespace, kind)
+	if name != "" {
+		path += "/" + name
+	}
+	return path
+}
@@ -0,0 +1,234 @@
+package kubernetes
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/httpapi"
+	"github.com/mitchellh/go-homedir"
+	"gopkg.in/yaml.v2"
+)
+
+// Config is the configuration of the Kubernetes provider. The settings
+// that are set override those of the kubeconfig at ConfigPath.
+type Config struct {
+	Host                 string
+	Token                string
+	Username             string
+	Password             string
+	Insecure             bool
+	ClientCertificate    string
+	ClientKey            string
+	ClusterCACertificate string
+	ConfigPath           string
+	ConfigContext        string
+}
+
+// kubeconfig is the part of a kubeconfig file, as written by kubectl,
+// that is used to connect to a cluster.
+type kubeconfig struct {
+	CurrentContext string `yaml:"current-context"`
+
+	Clusters []struct {
+		Name    string `yaml:"name"`
+		Cluster struct {
+			Server                   string `yaml:"server"`
+			InsecureSkipTLSVerify    bool   `yaml:"insecure-skip-tls-verify"`
+			CertificateAuthority     string `yaml:"certificate-authority"`
+			CertificateAuthorityData string `yaml:"certificate-authority-data"`
+		} `yaml:"cluster"`
+	} `yaml:"clusters"`
+
+	Users []struct {
+		Name string `yaml:"name"`
+		User struct {
+			Token                 string `yaml:"token"`
+			Username              string `yaml:"username"`
+			Password              string `yaml:"password"`
+			ClientCertificate     string `yaml:"client-certificate"`
+			ClientCertificateData string `yaml:"client-certificate-data"`
+			ClientKey             string `yaml:"client-key"`
+			ClientKeyData         string `yaml:"client-key-data"`
+		} `yaml:"user"`
+	} `yaml:"users"`
+
+	Contexts []struct {
+		Name    string `yaml:"name"`
+		Context struct {
+			Cluster string `yaml:"cluster"`
+			User    string `yaml:"user"`
+		} `yaml:"context"`
+	} `yaml:"contexts"`
+}
+
+// Client returns a new client for the Kubernetes API.
+func (c *Config) Client() (*Client, error) {
+	if err := c.loadConfigFile(); err != nil {
+		return nil, err
+	}
+
+	if c.Host == "" {
+		return nil, fmt.Errorf(
+			"No Kubernetes API server configured: set host, or a kubeconfig " +
+				"with config_path")
+	}
+
+	tlsConfig := &tls.Config{InsecureSkipVerify: c.Insecure}
+	if c.ClusterCACertificate != "" {
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM([]byte(c.ClusterCACertificate)) {
+			return nil, fmt.Errorf("Error parsing cluster_ca_certificate")
+		}
+		tlsConfig.RootCAs = pool
+	}
+	if c.ClientCertificate != "" || c.ClientKey != "" {
+		cert, err := tls.X509KeyPair(
+			[]byte(c.ClientCertificate), []byte(c.ClientKey))
+		if err != nil {
+			return nil, fmt.Errorf("Error loading the client certificate: %s", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+
+	host := strings.TrimSuffix(c.Host, "/")
+	if !strings.Contains(host, "://") {
+		host = "https://" + host
+	}
+
+	api := &httpapi.Client{
+		BaseURL: host,
+		Name:    "Kubernetes",
+		HTTP: &http.Client{
+			Transport: &http.Transport{TLSClientConfig: tlsConfig},
+		},
+	}
+	if c.Token != "" {
+		api.Header = http.Header{"Authorization": []string{"Bearer " + c.Token}}
+	} else {
+		api.Username, api.Password = c.Username, c.Password
+	}
+
+	log.Printf("[INFO] Kubernetes client configured for %s", host)
+	return &Client{api: api}, nil
+}
+
+// loadConfigFile fills in the settings that aren't set from the context
+// of the kubeconfig at ConfigPath, if there is a kubeconfig.
+func (c *Config) loadConfigFile() error {
+	if c.ConfigPath == "" {
+		return nil
+	}
+
+	path, err := homedir.Expand(c.ConfigPath)
+	if err != nil {
+		return err
+	}
+	raw, err := ioutil.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			log.Printf("[DEBUG] No kubeconfig at %s", path)
+			return nil
+		}
+		return fmt.Errorf("Error reading kubeconfig %s: %s", path, err)
+	}
+
+	var kc kubeconfig
+	if err := yaml.Unmarshal(raw, &kc); err != nil {
+		return fmt.Errorf("Error parsing kubeconfig %s: %s", path, err)
+	}
+
+	name := c.ConfigContext
+	if name == "" {
+		name = kc.CurrentContext
+	}
+	if name == "" {
+		return nil
+	}
+
+	var clusterName, userName string
+	found := false
+	for _, ctx := range kc.Contexts {
+		if ctx.Name == name {
+			clusterName, userName = ctx.Context.Cluster, ctx.Context.User
+			found = true
+		}
+	}
+	if !found {
+		return fmt.Errorf("Context %q not found in kubeconfig %s", name, path)
+	}
+
+	for _, cl := range kc.Clusters {
+		if cl.Name != clusterName {
+			continue
+		}
+
+		setDefault(&c.Host, cl.Cluster.Server)
+		if cl.Cluster.InsecureSkipTLSVerify {
+			c.Insecure = true
+		}
+		ca, err := configData(
+			cl.Cluster.CertificateAuthorityData, cl.Cluster.CertificateAuthority)
+		if err != nil {
+			return err
+		}
+		setDefault(&c.ClusterCACertificate, ca)
+	}
+
+	for _, u := range kc.Users {
+		if u.Name != userName {
+			continue
+		}
+
+		setDefault(&c.Token, u.User.Token)
+		setDefault(&c.Username, u.User.Username)
+		setDefault(&c.Password, u.User.Password)
+		cert, err := configData(
+			u.User.ClientCertificateData, u.User.ClientCertificate)
+		if err != nil {
+			return err
+		}
+		setDefault(&c.ClientCertificate, cert)
+		key, err := configData(u.User.ClientKeyData, u.User.ClientKey)
+		if err != nil {
+			return err
+		}
+		setDefault(&c.ClientKey, key)
+	}
+
+	return nil
+}
+
+// configData returns the contents of a setting of a kubeconfig that is
+// given either as base64 encoded data or as the path to a file.
+func configData(data, path string) (string, error) {
+	if data != "" {
+		raw, err := base64.StdEncoding.DecodeString(data)
+		if err != nil {
+			return "", fmt.Errorf("Error decoding kubeconfig data: %s", err)
+		}
+		return string(raw), nil
+	}
+
+	if path != "" {
+		raw, err := ioutil.ReadFile(path)
+		if err != nil {
+			return "", fmt.Errorf("Error reading %s: %s", path, err)
+		}
+		return string(raw), nil
+	}
+
+	return "", nil
+}
+
+func setDefault(v *string, d string) {
+	if *v == "" {
+		*v = d
+	}
+}
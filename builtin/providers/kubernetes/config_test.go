@@ -0,0 +1,76 @@
+package kubernetes
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestConfigLoadConfigFile(t *testing.T) {
+	cases := []struct {
+		Config   Config
+		Expected Config
+		Err      bool
+	}{
+		// The current context
+		{
+			Config{ConfigPath: "./test-fixtures/kubeconfig"},
+			Config{
+				Host:                 "https://10.0.0.1",
+				Username:             "admin",
+				Password:             "secret",
+				ClusterCACertificate: "CA CERTIFICATE",
+				ConfigPath:           "./test-fixtures/kubeconfig",
+			},
+			false,
+		},
+
+		// Another context, with settings that override the kubeconfig
+		{
+			Config{
+				Host:          "https://10.0.0.2",
+				ConfigPath:    "./test-fixtures/kubeconfig",
+				ConfigContext: "dev",
+			},
+			Config{
+				Host:          "https://10.0.0.2",
+				Token:         "dev-token",
+				Insecure:      true,
+				ConfigPath:    "./test-fixtures/kubeconfig",
+				ConfigContext: "dev",
+			},
+			false,
+		},
+
+		// A context that doesn't exist
+		{
+			Config{
+				ConfigPath:    "./test-fixtures/kubeconfig",
+				ConfigContext: "nope",
+			},
+			Config{},
+			true,
+		},
+
+		// No kubeconfig
+		{
+			Config{Host: "https://10.0.0.1", ConfigPath: "./test-fixtures/nope"},
+			Config{Host: "https://10.0.0.1", ConfigPath: "./test-fixtures/nope"},
+			false,
+		},
+	}
+
+	for i, tc := range cases {
+		c := tc.Config
+		err := c.loadConfigFile()
+		if (err != nil) != tc.Err {
+			t.Fatalf("%d: err: %s", i, err)
+		}
+		if err != nil {
+			continue
+		}
+
+		if !reflect.DeepEqual(c, tc.Expected) {
+			t.Fatalf("%d: bad: %#v", i, c)
+		}
+	}
+}
@@ -0,0 +1,146 @@
+package kubernetes
+
+import (
+	"log"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+// Provider returns a terraform.ResourceProvider.
+func Provider() terraform.ResourceProvider {
+	return &schema.Provider{
+		Schema: map[string]*schema.Schema{
+			"host": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("KUBE_HOST", ""),
+				Description: descriptions["host"],
+			},
+
+			"token": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("KUBE_TOKEN", ""),
+				Description: descriptions["token"],
+			},
+
+			"username": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("KUBE_USER", ""),
+				Description: descriptions["username"],
+			},
+
+			"password": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("KUBE_PASSWORD", ""),
+				Description: descriptions["password"],
+			},
+
+			"insecure": &schema.Schema{
+				Type:        schema.TypeBool,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("KUBE_INSECURE", false),
+				Description: descriptions["insecure"],
+			},
+
+			"client_certificate": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("KUBE_CLIENT_CERT_DATA", ""),
+				Description: descriptions["client_certificate"],
+			},
+
+			"client_key": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("KUBE_CLIENT_KEY_DATA", ""),
+				Description: descriptions["client_key"],
+			},
+
+			"cluster_ca_certificate": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("KUBE_CLUSTER_CA_CERT_DATA", ""),
+				Description: descriptions["cluster_ca_certificate"],
+			},
+
+			"config_path": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("KUBE_CONFIG", "~/.kube/config"),
+				Description: descriptions["config_path"],
+			},
+
+			"config_context": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("KUBE_CTX", ""),
+				Description: descriptions["config_context"],
+			},
+		},
+
+		ResourcesMap: map[string]*schema.Resource{
+			"kubernetes_config_map":             resourceKubernetesConfigMap(),
+			"kubernetes_deployment":             resourceKubernetesDeployment(),
+			"kubernetes_namespace":              resourceKubernetesNamespace(),
+			"kubernetes_replication_controller": resourceKubernetesReplicationController(),
+			"kubernetes_secret":                 resourceKubernetesSecret(),
+			"kubernetes_service":                resourceKubernetesService(),
+		},
+
+		ConfigureFunc: providerConfigure,
+	}
+}
+
+var descriptions map[string]string
+
+func init() {
+	descriptions = map[string]string{
+		"host": "The address of the Kubernetes API server, such as\n" +
+			"https://10.0.0.1. Overrides the server of the kubeconfig.",
+
+		"token": "The bearer token to authenticate with.",
+
+		"username": "The username for basic authentication.",
+
+		"password": "The password for basic authentication.",
+
+		"insecure": "Whether to skip the verification of the certificate\n" +
+			"of the API server.",
+
+		"client_certificate": "The PEM encoded client certificate for TLS\n" +
+			"authentication.",
+
+		"client_key": "The PEM encoded client key for TLS authentication.",
+
+		"cluster_ca_certificate": "The PEM encoded root certificate to\n" +
+			"verify the API server with.",
+
+		"config_path": "The path to the kubeconfig file to load the\n" +
+			"cluster and credentials from, if it exists.",
+
+		"config_context": "The context of the kubeconfig to use. Defaults\n" +
+			"to its current context.",
+	}
+}
+
+func providerConfigure(d *schema.ResourceData) (interface{}, error) {
+	config := Config{
+		Host:                 d.Get("host").(string),
+		Token:                d.Get("token").(string),
+		Username:             d.Get("username").(string),
+		Password:             d.Get("password").(string),
+		Insecure:             d.Get("insecure").(bool),
+		ClientCertificate:    d.Get("client_certificate").(string),
+		ClientKey:            d.Get("client_key").(string),
+		ClusterCACertificate: d.Get("cluster_ca_certificate").(string),
+		ConfigPath:           d.Get("config_path").(string),
+		ConfigContext:        d.Get("config_context").(string),
+	}
+
+	log.Println("[INFO] Initializing Kubernetes client")
+	return config.Client()
+}
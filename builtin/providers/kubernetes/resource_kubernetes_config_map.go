@@ -0,0 +1,117 @@
+package kubernetes
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform/helper/httpapi"
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func resourceKubernetesConfigMap() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceKubernetesConfigMapCreate,
+		Read:   resourceKubernetesConfigMapRead,
+		Update: resourceKubernetesConfigMapUpdate,
+		Delete: resourceKubernetesConfigMapDelete,
+
+		Schema: metadataSchema(true, map[string]*schema.Schema{
+			"data": &schema.Schema{
+				Type:     schema.TypeMap,
+				Optional: true,
+			},
+		}),
+	}
+}
+
+func resourceKubernetesConfigMapCreate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Client)
+
+	cm := &ConfigMap{
+		Kind:       "ConfigMap",
+		APIVersion: "v1",
+		Metadata:   expandMetadata(d),
+		Data:       expandStringMap(d.Get("data")),
+	}
+
+	log.Printf("[DEBUG] Creating config map: %#v", cm)
+	var result ConfigMap
+	err := client.Create(
+		namespacedPath("configmaps", cm.Metadata.Namespace, ""), cm, &result)
+	if err != nil {
+		return fmt.Errorf("Error creating config map: %s", err)
+	}
+
+	d.SetId(namespacedId(result.Metadata))
+	log.Printf("[INFO] Config map created: %s", d.Id())
+
+	return resourceKubernetesConfigMapRead(d, meta)
+}
+
+func resourceKubernetesConfigMapRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Client)
+
+	namespace, name, err := parseNamespacedId(d.Id())
+	if err != nil {
+		return err
+	}
+
+	var cm ConfigMap
+	if err := client.Get(namespacedPath("configmaps", namespace, name), &cm); err != nil {
+		if httpapi.IsNotFound(err) {
+			d.SetId("")
+			return nil
+		}
+		return fmt.Errorf("Error reading config map %s: %s", d.Id(), err)
+	}
+
+	flattenMetadata(d, cm.Metadata)
+	d.Set("data", cm.Data)
+
+	return nil
+}
+
+func resourceKubernetesConfigMapUpdate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Client)
+
+	namespace, name, err := parseNamespacedId(d.Id())
+	if err != nil {
+		return err
+	}
+	path := namespacedPath("configmaps", namespace, name)
+
+	var cm ConfigMap
+	if err := client.Get(path, &cm); err != nil {
+		return fmt.Errorf("Error reading config map %s: %s", d.Id(), err)
+	}
+
+	updateMetadata(d, &cm.Metadata)
+	cm.Data = expandStringMap(d.Get("data"))
+
+	log.Printf("[DEBUG] Updating config map: %#v", cm)
+	if err := client.Update(path, &cm, &cm); err != nil {
+		return fmt.Errorf("Error updating config map %s: %s", d.Id(), err)
+	}
+
+	return resourceKubernetesConfigMapRead(d, meta)
+}
+
+func resourceKubernetesConfigMapDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Client)
+
+	namespace, name, err := parseNamespacedId(d.Id())
+	if err != nil {
+		return err
+	}
+
+	log.Printf("[INFO] Deleting config map: %s", d.Id())
+	if err := client.Delete(namespacedPath("configmaps", namespace, name)); err != nil {
+		if httpapi.IsNotFound(err) {
+			return nil
+		}
+		return fmt.Errorf("Error deleting config map %s: %s", d.Id(), err)
+	}
+
+	d.SetId("")
+	return nil
+}
@@ -0,0 +1,105 @@
+package kubernetes
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/httpapi"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestAccKubernetesConfigMap_basic(t *testing.T) {
+	var v ConfigMap
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckKubernetesConfigMapDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccKubernetesConfigMapConfig_basic,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckKubernetesConfigMapExists("kubernetes_config_map.test", &v),
+					resource.TestCheckResourceAttr(
+						"kubernetes_config_map.test", "data.log_level", "info"),
+				),
+			},
+			resource.TestStep{
+				Config: testAccKubernetesConfigMapConfig_update,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckKubernetesConfigMapExists("kubernetes_config_map.test", &v),
+					resource.TestCheckResourceAttr(
+						"kubernetes_config_map.test", "data.log_level", "debug"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckKubernetesConfigMapExists(n string, v *ConfigMap) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No config map ID is set")
+		}
+
+		client := testAccProvider.Meta().(*Client)
+		namespace, name, err := parseNamespacedId(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+		if err := client.Get(namespacedPath("configmaps", namespace, name), v); err != nil {
+			return err
+		}
+
+		return nil
+	}
+}
+
+func testAccCheckKubernetesConfigMapDestroy(s *terraform.State) error {
+	client := testAccProvider.Meta().(*Client)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "kubernetes_config_map" {
+			continue
+		}
+
+		namespace, name, err := parseNamespacedId(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+		var v ConfigMap
+		err = client.Get(namespacedPath("configmaps", namespace, name), &v)
+		if err == nil {
+			return fmt.Errorf("config map still exists: %s", rs.Primary.ID)
+		}
+		if !httpapi.IsNotFound(err) {
+			return err
+		}
+	}
+
+	return nil
+}
+
+const testAccKubernetesConfigMapConfig_basic = `
+resource "kubernetes_config_map" "test" {
+	name = "tf-acc-test"
+	data {
+		log_level = "info"
+	}
+}
+`
+
+const testAccKubernetesConfigMapConfig_update = `
+resource "kubernetes_config_map" "test" {
+	name = "tf-acc-test"
+	data {
+		log_level = "debug"
+	}
+}
+`
@@ -0,0 +1,231 @@
+package kubernetes
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/hashicorp/terraform/helper/httpapi"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func resourceKubernetesDeployment() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceKubernetesDeploymentCreate,
+		Read:   resourceKubernetesDeploymentRead,
+		Update: resourceKubernetesDeploymentUpdate,
+		Delete: resourceKubernetesDeploymentDelete,
+
+		Schema: metadataSchema(true, podTemplateSchema(map[string]*schema.Schema{
+			"replicas": &schema.Schema{
+				Type:     schema.TypeInt,
+				Optional: true,
+				Default:  1,
+			},
+
+			// The selector of a deployment can't be changed.
+			"selector": &schema.Schema{
+				Type:     schema.TypeMap,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"strategy": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  "RollingUpdate",
+			},
+
+			"max_surge": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+
+			"max_unavailable": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+		})),
+	}
+}
+
+func resourceKubernetesDeploymentCreate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Client)
+
+	deployment := &Deployment{
+		Kind:       "Deployment",
+		APIVersion: "apps/v1",
+		Metadata:   expandMetadata(d),
+		Spec:       expandDeploymentSpec(d),
+	}
+
+	log.Printf("[DEBUG] Creating deployment: %#v", deployment)
+	var result Deployment
+	err := client.Create(
+		appsPath("deployments", deployment.Metadata.Namespace, ""),
+		deployment, &result)
+	if err != nil {
+		return fmt.Errorf("Error creating deployment: %s", err)
+	}
+
+	d.SetId(namespacedId(result.Metadata))
+	log.Printf("[INFO] Deployment created: %s", d.Id())
+
+	if err := waitForRollout(client, d, result.Metadata.Generation); err != nil {
+		return err
+	}
+
+	return resourceKubernetesDeploymentRead(d, meta)
+}
+
+func resourceKubernetesDeploymentRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Client)
+
+	namespace, name, err := parseNamespacedId(d.Id())
+	if err != nil {
+		return err
+	}
+
+	var deployment Deployment
+	err = client.Get(appsPath("deployments", namespace, name), &deployment)
+	if err != nil {
+		if httpapi.IsNotFound(err) {
+			d.SetId("")
+			return nil
+		}
+		return fmt.Errorf("Error reading deployment %s: %s", d.Id(), err)
+	}
+
+	spec := deployment.Spec
+	flattenMetadata(d, deployment.Metadata)
+	d.Set("replicas", spec.Replicas)
+	d.Set("selector", spec.Selector.MatchLabels)
+	d.Set("strategy", spec.Strategy.Type)
+	if ru := spec.Strategy.RollingUpdate; ru != nil {
+		d.Set("max_surge", string(ru.MaxSurge))
+		d.Set("max_unavailable", string(ru.MaxUnavailable))
+	}
+
+	return flattenPodTemplate(d, spec.Template, spec.Selector.MatchLabels)
+}
+
+func resourceKubernetesDeploymentUpdate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Client)
+
+	namespace, name, err := parseNamespacedId(d.Id())
+	if err != nil {
+		return err
+	}
+	path := appsPath("deployments", namespace, name)
+
+	var deployment Deployment
+	if err := client.Get(path, &deployment); err != nil {
+		return fmt.Errorf("Error reading deployment %s: %s", d.Id(), err)
+	}
+
+	updateMetadata(d, &deployment.Metadata)
+	deployment.Spec = expandDeploymentSpec(d)
+
+	log.Printf("[DEBUG] Updating deployment: %#v", deployment)
+	if err := client.Update(path, &deployment, &deployment); err != nil {
+		return fmt.Errorf("Error updating deployment %s: %s", d.Id(), err)
+	}
+
+	if err := waitForRollout(client, d, deployment.Metadata.Generation); err != nil {
+		return err
+	}
+
+	return resourceKubernetesDeploymentRead(d, meta)
+}
+
+func resourceKubernetesDeploymentDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Client)
+
+	namespace, name, err := parseNamespacedId(d.Id())
+	if err != nil {
+		return err
+	}
+
+	// Unlike a replication controller, a deployment of the apps group
+	// deletes its replica sets and their pods along with it.
+	log.Printf("[INFO] Deleting deployment: %s", d.Id())
+	if err := client.Delete(appsPath("deployments", namespace, name)); err != nil {
+		if httpapi.IsNotFound(err) {
+			return nil
+		}
+		return fmt.Errorf("Error deleting deployment %s: %s", d.Id(), err)
+	}
+
+	d.SetId("")
+	return nil
+}
+
+// waitForRollout waits for the deployment of the resource to have rolled
+// out the given generation of its spec: all of its pods are updated and
+// available, and the old ones are gone.
+func waitForRollout(client *Client, d *schema.ResourceData, generation int64) error {
+	namespace, name, err := parseNamespacedId(d.Id())
+	if err != nil {
+		return err
+	}
+	path := appsPath("deployments", namespace, name)
+
+	log.Printf("[DEBUG] Waiting for deployment %s to roll out", d.Id())
+	stateConf := &resource.StateChangeConf{
+		Pending: []string{"rolling out"},
+		Target:  "rolled out",
+		Refresh: func() (interface{}, string, error) {
+			var deployment Deployment
+			if err := client.Get(path, &deployment); err != nil {
+				return nil, "", err
+			}
+
+			replicas := deployment.Spec.Replicas
+			status := deployment.Status
+			if status.ObservedGeneration < generation ||
+				status.UpdatedReplicas != replicas ||
+				status.AvailableReplicas != replicas ||
+				status.Replicas != replicas {
+				return deployment, "rolling out", nil
+			}
+			return deployment, "rolled out", nil
+		},
+		Timeout:    10 * time.Minute,
+		MinTimeout: 3 * time.Second,
+		StopCh:     d.StopCh(),
+	}
+	if _, err := stateConf.WaitForState(); err != nil {
+		return fmt.Errorf(
+			"Error waiting for deployment %s to roll out: %s", d.Id(), err)
+	}
+
+	return nil
+}
+
+// expandDeploymentSpec returns the spec of the deployment of the
+// resource.
+func expandDeploymentSpec(d *schema.ResourceData) DeploymentSpec {
+	selector := expandStringMap(d.Get("selector"))
+	spec := DeploymentSpec{
+		Replicas: d.Get("replicas").(int),
+		Selector: LabelSelector{MatchLabels: selector},
+		Template: expandPodTemplate(d, selector),
+		Strategy: DeploymentStrategy{
+			Type: d.Get("strategy").(string),
+		},
+	}
+
+	// The surge and the number of unavailable pods are only allowed with
+	// rolling updates, and default to 25% of the pods if they aren't set.
+	if spec.Strategy.Type == "RollingUpdate" {
+		spec.Strategy.RollingUpdate = &RollingUpdateDeployment{
+			MaxSurge:       IntOrString(d.Get("max_surge").(string)),
+			MaxUnavailable: IntOrString(d.Get("max_unavailable").(string)),
+		}
+	}
+
+	return spec
+}
@@ -0,0 +1,127 @@
+package kubernetes
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/httpapi"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestAccKubernetesDeployment_basic(t *testing.T) {
+	var v Deployment
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckKubernetesDeploymentDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccKubernetesDeploymentConfig_basic,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckKubernetesDeploymentExists("kubernetes_deployment.test", &v),
+					resource.TestCheckResourceAttr(
+						"kubernetes_deployment.test", "replicas", "1"),
+					resource.TestCheckResourceAttr(
+						"kubernetes_deployment.test", "container.0.image", "nginx:1.9"),
+					resource.TestCheckResourceAttr(
+						"kubernetes_deployment.test", "strategy", "RollingUpdate"),
+				),
+			},
+			resource.TestStep{
+				Config: testAccKubernetesDeploymentConfig_update,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckKubernetesDeploymentExists("kubernetes_deployment.test", &v),
+					resource.TestCheckResourceAttr(
+						"kubernetes_deployment.test", "replicas", "2"),
+					resource.TestCheckResourceAttr(
+						"kubernetes_deployment.test", "container.0.image", "nginx:1.10"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckKubernetesDeploymentExists(n string, v *Deployment) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No deployment ID is set")
+		}
+
+		client := testAccProvider.Meta().(*Client)
+		namespace, name, err := parseNamespacedId(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+		if err := client.Get(appsPath("deployments", namespace, name), v); err != nil {
+			return err
+		}
+
+		return nil
+	}
+}
+
+func testAccCheckKubernetesDeploymentDestroy(s *terraform.State) error {
+	client := testAccProvider.Meta().(*Client)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "kubernetes_deployment" {
+			continue
+		}
+
+		namespace, name, err := parseNamespacedId(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+		var v Deployment
+		err = client.Get(appsPath("deployments", namespace, name), &v)
+		if err == nil {
+			return fmt.Errorf("deployment still exists: %s", rs.Primary.ID)
+		}
+		if !httpapi.IsNotFound(err) {
+			return err
+		}
+	}
+
+	return nil
+}
+
+const testAccKubernetesDeploymentConfig_basic = `
+resource "kubernetes_deployment" "test" {
+	name = "tf-acc-test"
+	replicas = 1
+	selector {
+		app = "tf-acc-test"
+	}
+	container {
+		name = "nginx"
+		image = "nginx:1.9"
+		port {
+			container_port = 80
+		}
+	}
+}
+`
+
+const testAccKubernetesDeploymentConfig_update = `
+resource "kubernetes_deployment" "test" {
+	name = "tf-acc-test"
+	replicas = 2
+	selector {
+		app = "tf-acc-test"
+	}
+	container {
+		name = "nginx"
+		image = "nginx:1.10"
+		port {
+			container_port = 80
+		}
+	}
+}
+`
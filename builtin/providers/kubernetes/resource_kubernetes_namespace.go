@@ -0,0 +1,120 @@
+package kubernetes
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/hashicorp/terraform/helper/httpapi"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func resourceKubernetesNamespace() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceKubernetesNamespaceCreate,
+		Read:   resourceKubernetesNamespaceRead,
+		Update: resourceKubernetesNamespaceUpdate,
+		Delete: resourceKubernetesNamespaceDelete,
+
+		Schema: metadataSchema(false, map[string]*schema.Schema{}),
+	}
+}
+
+func namespacePath(name string) string {
+	return "/api/v1/namespaces/" + name
+}
+
+func resourceKubernetesNamespaceCreate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Client)
+
+	ns := &Namespace{
+		Kind:       "Namespace",
+		APIVersion: "v1",
+		Metadata:   expandMetadata(d),
+	}
+
+	log.Printf("[DEBUG] Creating namespace: %#v", ns)
+	var result Namespace
+	if err := client.Create("/api/v1/namespaces", ns, &result); err != nil {
+		return fmt.Errorf("Error creating namespace: %s", err)
+	}
+
+	d.SetId(result.Metadata.Name)
+	log.Printf("[INFO] Namespace created: %s", d.Id())
+
+	return resourceKubernetesNamespaceRead(d, meta)
+}
+
+func resourceKubernetesNamespaceRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Client)
+
+	var ns Namespace
+	if err := client.Get(namespacePath(d.Id()), &ns); err != nil {
+		if httpapi.IsNotFound(err) {
+			d.SetId("")
+			return nil
+		}
+		return fmt.Errorf("Error reading namespace %s: %s", d.Id(), err)
+	}
+
+	flattenMetadata(d, ns.Metadata)
+	return nil
+}
+
+func resourceKubernetesNamespaceUpdate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Client)
+
+	var ns Namespace
+	if err := client.Get(namespacePath(d.Id()), &ns); err != nil {
+		return fmt.Errorf("Error reading namespace %s: %s", d.Id(), err)
+	}
+
+	updateMetadata(d, &ns.Metadata)
+	log.Printf("[DEBUG] Updating namespace: %#v", ns)
+	if err := client.Update(namespacePath(d.Id()), &ns, &ns); err != nil {
+		return fmt.Errorf("Error updating namespace %s: %s", d.Id(), err)
+	}
+
+	return resourceKubernetesNamespaceRead(d, meta)
+}
+
+func resourceKubernetesNamespaceDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Client)
+
+	log.Printf("[INFO] Deleting namespace: %s", d.Id())
+	if err := client.Delete(namespacePath(d.Id())); err != nil {
+		if httpapi.IsNotFound(err) {
+			return nil
+		}
+		return fmt.Errorf("Error deleting namespace %s: %s", d.Id(), err)
+	}
+
+	// The namespace is terminating until all the objects in it are
+	// deleted. Wait for that, so that a namespace with the same name can
+	// be created right away.
+	stateConf := &resource.StateChangeConf{
+		Pending: []string{"Active", "Terminating"},
+		Target:  "",
+		Refresh: func() (interface{}, string, error) {
+			var ns Namespace
+			if err := client.Get(namespacePath(d.Id()), &ns); err != nil {
+				if httpapi.IsNotFound(err) {
+					return nil, "", nil
+				}
+				return nil, "", err
+			}
+			return ns, ns.Status.Phase, nil
+		},
+		Timeout:    5 * time.Minute,
+		MinTimeout: 3 * time.Second,
+		StopCh:     d.StopCh(),
+	}
+	if _, err := stateConf.WaitForState(); err != nil {
+		return fmt.Errorf(
+			"Error waiting for namespace %s to be deleted: %s", d.Id(), err)
+	}
+
+	d.SetId("")
+	return nil
+}
@@ -0,0 +1,99 @@
+package kubernetes
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/httpapi"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestAccKubernetesNamespace_basic(t *testing.T) {
+	var v Namespace
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckKubernetesNamespaceDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccKubernetesNamespaceConfig_basic,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckKubernetesNamespaceExists("kubernetes_namespace.test", &v),
+					resource.TestCheckResourceAttr(
+						"kubernetes_namespace.test", "name", "tf-acc-test"),
+					resource.TestCheckResourceAttr(
+						"kubernetes_namespace.test", "labels.app", "tf-acc-test"),
+				),
+			},
+			resource.TestStep{
+				Config: testAccKubernetesNamespaceConfig_update,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckKubernetesNamespaceExists("kubernetes_namespace.test", &v),
+					resource.TestCheckResourceAttr(
+						"kubernetes_namespace.test", "labels.app", "tf-acc-test-updated"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckKubernetesNamespaceExists(n string, v *Namespace) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No namespace ID is set")
+		}
+
+		client := testAccProvider.Meta().(*Client)
+		if err := client.Get(namespacePath(rs.Primary.ID), v); err != nil {
+			return err
+		}
+
+		return nil
+	}
+}
+
+func testAccCheckKubernetesNamespaceDestroy(s *terraform.State) error {
+	client := testAccProvider.Meta().(*Client)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "kubernetes_namespace" {
+			continue
+		}
+
+		var v Namespace
+		err := client.Get(namespacePath(rs.Primary.ID), &v)
+		if err == nil {
+			return fmt.Errorf("namespace still exists: %s", rs.Primary.ID)
+		}
+		if !httpapi.IsNotFound(err) {
+			return err
+		}
+	}
+
+	return nil
+}
+
+const testAccKubernetesNamespaceConfig_basic = `
+resource "kubernetes_namespace" "test" {
+	name = "tf-acc-test"
+	labels {
+		app = "tf-acc-test"
+	}
+}
+`
+
+const testAccKubernetesNamespaceConfig_update = `
+resource "kubernetes_namespace" "test" {
+	name = "tf-acc-test"
+	labels {
+		app = "tf-acc-test-updated"
+	}
+}
+`
@@ -0,0 +1,206 @@
+package kubernetes
+
+import (
+	"fmt"
+	"log"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/terraform/helper/httpapi"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func resourceKubernetesReplicationController() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceKubernetesReplicationControllerCreate,
+		Read:   resourceKubernetesReplicationControllerRead,
+		Update: resourceKubernetesReplicationControllerUpdate,
+		Delete: resourceKubernetesReplicationControllerDelete,
+
+		Schema: metadataSchema(true, podTemplateSchema(map[string]*schema.Schema{
+			"replicas": &schema.Schema{
+				Type:     schema.TypeInt,
+				Optional: true,
+				Default:  1,
+			},
+
+			"selector": &schema.Schema{
+				Type:     schema.TypeMap,
+				Required: true,
+			},
+		})),
+	}
+}
+
+func resourceKubernetesReplicationControllerCreate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Client)
+
+	rc := &ReplicationController{
+		Kind:       "ReplicationController",
+		APIVersion: "v1",
+		Metadata:   expandMetadata(d),
+		Spec:       expandReplicationControllerSpec(d),
+	}
+
+	log.Printf("[DEBUG] Creating replication controller: %#v", rc)
+	var result ReplicationController
+	err := client.Create(
+		namespacedPath("replicationcontrollers", rc.Metadata.Namespace, ""),
+		rc, &result)
+	if err != nil {
+		return fmt.Errorf("Error creating replication controller: %s", err)
+	}
+
+	d.SetId(namespacedId(result.Metadata))
+	log.Printf("[INFO] Replication controller created: %s", d.Id())
+
+	if err := waitForReplicas(client, d, rc.Spec.Replicas); err != nil {
+		return err
+	}
+
+	return resourceKubernetesReplicationControllerRead(d, meta)
+}
+
+func resourceKubernetesReplicationControllerRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Client)
+
+	namespace, name, err := parseNamespacedId(d.Id())
+	if err != nil {
+		return err
+	}
+
+	var rc ReplicationController
+	err = client.Get(
+		namespacedPath("replicationcontrollers", namespace, name), &rc)
+	if err != nil {
+		if httpapi.IsNotFound(err) {
+			d.SetId("")
+			return nil
+		}
+		return fmt.Errorf("Error reading replication controller %s: %s", d.Id(), err)
+	}
+
+	flattenMetadata(d, rc.Metadata)
+	d.Set("replicas", rc.Spec.Replicas)
+	d.Set("selector", rc.Spec.Selector)
+	return flattenPodTemplate(d, rc.Spec.Template, rc.Spec.Selector)
+}
+
+func resourceKubernetesReplicationControllerUpdate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Client)
+
+	namespace, name, err := parseNamespacedId(d.Id())
+	if err != nil {
+		return err
+	}
+	path := namespacedPath("replicationcontrollers", namespace, name)
+
+	var rc ReplicationController
+	if err := client.Get(path, &rc); err != nil {
+		return fmt.Errorf("Error reading replication controller %s: %s", d.Id(), err)
+	}
+
+	updateMetadata(d, &rc.Metadata)
+	rc.Spec = expandReplicationControllerSpec(d)
+
+	log.Printf("[DEBUG] Updating replication controller: %#v", rc)
+	if err := client.Update(path, &rc, &rc); err != nil {
+		return fmt.Errorf("Error updating replication controller %s: %s", d.Id(), err)
+	}
+
+	if err := waitForReplicas(client, d, rc.Spec.Replicas); err != nil {
+		return err
+	}
+
+	return resourceKubernetesReplicationControllerRead(d, meta)
+}
+
+func resourceKubernetesReplicationControllerDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Client)
+
+	namespace, name, err := parseNamespacedId(d.Id())
+	if err != nil {
+		return err
+	}
+	path := namespacedPath("replicationcontrollers", namespace, name)
+
+	// Deleting a replication controller leaves its pods running, so it is
+	// scaled down to no pods first, as kubectl does.
+	var rc ReplicationController
+	if err := client.Get(path, &rc); err != nil {
+		if httpapi.IsNotFound(err) {
+			return nil
+		}
+		return fmt.Errorf("Error reading replication controller %s: %s", d.Id(), err)
+	}
+
+	log.Printf("[INFO] Scaling down replication controller: %s", d.Id())
+	rc.Spec.Replicas = 0
+	if err := client.Update(path, &rc, &rc); err != nil {
+		return fmt.Errorf("Error scaling down replication controller %s: %s", d.Id(), err)
+	}
+	if err := waitForReplicas(client, d, 0); err != nil {
+		return err
+	}
+
+	log.Printf("[INFO] Deleting replication controller: %s", d.Id())
+	if err := client.Delete(path); err != nil {
+		if httpapi.IsNotFound(err) {
+			return nil
+		}
+		return fmt.Errorf("Error deleting replication controller %s: %s", d.Id(), err)
+	}
+
+	d.SetId("")
+	return nil
+}
+
+// waitForReplicas waits for the replication controller of the resource
+// to have the given number of pods.
+func waitForReplicas(client *Client, d *schema.ResourceData, replicas int) error {
+	namespace, name, err := parseNamespacedId(d.Id())
+	if err != nil {
+		return err
+	}
+	path := namespacedPath("replicationcontrollers", namespace, name)
+
+	log.Printf(
+		"[DEBUG] Waiting for replication controller %s to have %d pods",
+		d.Id(), replicas)
+	stateConf := &resource.StateChangeConf{
+		Pending: []string{"scaling"},
+		Target:  strconv.Itoa(replicas),
+		Refresh: func() (interface{}, string, error) {
+			var rc ReplicationController
+			if err := client.Get(path, &rc); err != nil {
+				return nil, "", err
+			}
+			if rc.Status.Replicas != replicas {
+				return rc, "scaling", nil
+			}
+			return rc, strconv.Itoa(replicas), nil
+		},
+		Timeout:    10 * time.Minute,
+		MinTimeout: 3 * time.Second,
+		StopCh:     d.StopCh(),
+	}
+	if _, err := stateConf.WaitForState(); err != nil {
+		return fmt.Errorf(
+			"Error waiting for replication controller %s to have %d pods: %s",
+			d.Id(), replicas, err)
+	}
+
+	return nil
+}
+
+// expandReplicationControllerSpec returns the spec of the replication
+// controller of the resource.
+func expandReplicationControllerSpec(d *schema.ResourceData) ReplicationControllerSpec {
+	selector := expandStringMap(d.Get("selector"))
+	return ReplicationControllerSpec{
+		Replicas: d.Get("replicas").(int),
+		Selector: selector,
+		Template: expandPodTemplate(d, selector),
+	}
+}
@@ -0,0 +1,123 @@
+package kubernetes
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/httpapi"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestAccKubernetesReplicationController_basic(t *testing.T) {
+	var v ReplicationController
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckKubernetesReplicationControllerDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccKubernetesReplicationControllerConfig_basic,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckKubernetesReplicationControllerExists("kubernetes_replication_controller.test", &v),
+					resource.TestCheckResourceAttr(
+						"kubernetes_replication_controller.test", "replicas", "1"),
+					resource.TestCheckResourceAttr(
+						"kubernetes_replication_controller.test", "container.0.image", "nginx"),
+				),
+			},
+			resource.TestStep{
+				Config: testAccKubernetesReplicationControllerConfig_update,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckKubernetesReplicationControllerExists("kubernetes_replication_controller.test", &v),
+					resource.TestCheckResourceAttr(
+						"kubernetes_replication_controller.test", "replicas", "2"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckKubernetesReplicationControllerExists(n string, v *ReplicationController) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No replication controller ID is set")
+		}
+
+		client := testAccProvider.Meta().(*Client)
+		namespace, name, err := parseNamespacedId(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+		if err := client.Get(namespacedPath("replicationcontrollers", namespace, name), v); err != nil {
+			return err
+		}
+
+		return nil
+	}
+}
+
+func testAccCheckKubernetesReplicationControllerDestroy(s *terraform.State) error {
+	client := testAccProvider.Meta().(*Client)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "kubernetes_replication_controller" {
+			continue
+		}
+
+		namespace, name, err := parseNamespacedId(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+		var v ReplicationController
+		err = client.Get(namespacedPath("replicationcontrollers", namespace, name), &v)
+		if err == nil {
+			return fmt.Errorf("replication controller still exists: %s", rs.Primary.ID)
+		}
+		if !httpapi.IsNotFound(err) {
+			return err
+		}
+	}
+
+	return nil
+}
+
+const testAccKubernetesReplicationControllerConfig_basic = `
+resource "kubernetes_replication_controller" "test" {
+	name = "tf-acc-test"
+	replicas = 1
+	selector {
+		app = "tf-acc-test"
+	}
+	container {
+		name = "nginx"
+		image = "nginx"
+		port {
+			container_port = 80
+		}
+	}
+}
+`
+
+const testAccKubernetesReplicationControllerConfig_update = `
+resource "kubernetes_replication_controller" "test" {
+	name = "tf-acc-test"
+	replicas = 2
+	selector {
+		app = "tf-acc-test"
+	}
+	container {
+		name = "nginx"
+		image = "nginx"
+		port {
+			container_port = 80
+		}
+	}
+}
+`
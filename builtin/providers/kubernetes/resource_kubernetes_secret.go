@@ -0,0 +1,149 @@
+package kubernetes
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform/helper/httpapi"
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func resourceKubernetesSecret() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceKubernetesSecretCreate,
+		Read:   resourceKubernetesSecretRead,
+		Update: resourceKubernetesSecretUpdate,
+		Delete: resourceKubernetesSecretDelete,
+
+		Schema: metadataSchema(true, map[string]*schema.Schema{
+			"type": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+				Default:  "Opaque",
+			},
+
+			"data": &schema.Schema{
+				Type:     schema.TypeMap,
+				Optional: true,
+			},
+		}),
+	}
+}
+
+func resourceKubernetesSecretCreate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Client)
+
+	secret := &Secret{
+		Kind:       "Secret",
+		APIVersion: "v1",
+		Metadata:   expandMetadata(d),
+		Type:       d.Get("type").(string),
+		Data:       expandSecretData(d.Get("data")),
+	}
+
+	// The data of the secret isn't logged
+	log.Printf("[DEBUG] Creating secret: %s", namespacedId(secret.Metadata))
+	var result Secret
+	err := client.Create(
+		namespacedPath("secrets", secret.Metadata.Namespace, ""), secret, &result)
+	if err != nil {
+		return fmt.Errorf("Error creating secret: %s", err)
+	}
+
+	d.SetId(namespacedId(result.Metadata))
+	log.Printf("[INFO] Secret created: %s", d.Id())
+
+	return resourceKubernetesSecretRead(d, meta)
+}
+
+func resourceKubernetesSecretRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Client)
+
+	namespace, name, err := parseNamespacedId(d.Id())
+	if err != nil {
+		return err
+	}
+
+	var secret Secret
+	if err := client.Get(namespacedPath("secrets", namespace, name), &secret); err != nil {
+		if httpapi.IsNotFound(err) {
+			d.SetId("")
+			return nil
+		}
+		return fmt.Errorf("Error reading secret %s: %s", d.Id(), err)
+	}
+
+	flattenMetadata(d, secret.Metadata)
+	d.Set("type", secret.Type)
+
+	data := make(map[string]string, len(secret.Data))
+	for k, v := range secret.Data {
+		data[k] = string(v)
+	}
+	d.Set("data", data)
+
+	return nil
+}
+
+func resourceKubernetesSecretUpdate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Client)
+
+	namespace, name, err := parseNamespacedId(d.Id())
+	if err != nil {
+		return err
+	}
+	path := namespacedPath("secrets", namespace, name)
+
+	var secret Secret
+	if err := client.Get(path, &secret); err != nil {
+		return fmt.Errorf("Error reading secret %s: %s", d.Id(), err)
+	}
+
+	updateMetadata(d, &secret.Metadata)
+	secret.Data = expandSecretData(d.Get("data"))
+
+	log.Printf("[DEBUG] Updating secret: %s", d.Id())
+	if err := client.Update(path, &secret, &secret); err != nil {
+		return fmt.Errorf("Error updating secret %s: %s", d.Id(), err)
+	}
+
+	return resourceKubernetesSecretRead(d, meta)
+}
+
+func resourceKubernetesSecretDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Client)
+
+	namespace, name, err := parseNamespacedId(d.Id())
+	if err != nil {
+		return err
+	}
+
+	log.Printf("[INFO] Deleting secret: %s", d.Id())
+	if err := client.Delete(namespacedPath("secrets", namespace, name)); err != nil {
+		if httpapi.IsNotFound(err) {
+			return nil
+		}
+		return fmt.Errorf("Error deleting secret %s: %s", d.Id(), err)
+	}
+
+	d.SetId("")
+	return nil
+}
+
+// expandSecretData returns the data of a secret. The values are given
+// as plain text, and are encoded in base64 by the JSON encoding of the
+// API.
+func expandSecretData(v interface{}) map[string][]byte {
+	m := expandStringMap(v)
+	if m == nil {
+		return nil
+	}
+
+	result := make(map[string][]byte, len(m))
+	for k, v := range m {
+		result[k] = []byte(v)
+	}
+
+	return result
+}
@@ -0,0 +1,107 @@
+package kubernetes
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/httpapi"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestAccKubernetesSecret_basic(t *testing.T) {
+	var v Secret
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckKubernetesSecretDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccKubernetesSecretConfig_basic,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckKubernetesSecretExists("kubernetes_secret.test", &v),
+					resource.TestCheckResourceAttr(
+						"kubernetes_secret.test", "type", "Opaque"),
+					resource.TestCheckResourceAttr(
+						"kubernetes_secret.test", "data.password", "tf-acc-test"),
+				),
+			},
+			resource.TestStep{
+				Config: testAccKubernetesSecretConfig_update,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckKubernetesSecretExists("kubernetes_secret.test", &v),
+					resource.TestCheckResourceAttr(
+						"kubernetes_secret.test", "data.password", "tf-acc-test-updated"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckKubernetesSecretExists(n string, v *Secret) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No secret ID is set")
+		}
+
+		client := testAccProvider.Meta().(*Client)
+		namespace, name, err := parseNamespacedId(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+		if err := client.Get(namespacedPath("secrets", namespace, name), v); err != nil {
+			return err
+		}
+
+		return nil
+	}
+}
+
+func testAccCheckKubernetesSecretDestroy(s *terraform.State) error {
+	client := testAccProvider.Meta().(*Client)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "kubernetes_secret" {
+			continue
+		}
+
+		namespace, name, err := parseNamespacedId(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+		var v Secret
+		err = client.Get(namespacedPath("secrets", namespace, name), &v)
+		if err == nil {
+			return fmt.Errorf("secret still exists: %s", rs.Primary.ID)
+		}
+		if !httpapi.IsNotFound(err) {
+			return err
+		}
+	}
+
+	return nil
+}
+
+const testAccKubernetesSecretConfig_basic = `
+resource "kubernetes_secret" "test" {
+	name = "tf-acc-test"
+	data {
+		password = "tf-acc-test"
+	}
+}
+`
+
+const testAccKubernetesSecretConfig_update = `
+resource "kubernetes_secret" "test" {
+	name = "tf-acc-test"
+	data {
+		password = "tf-acc-test-updated"
+	}
+}
+`
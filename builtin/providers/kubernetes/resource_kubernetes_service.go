@@ -0,0 +1,247 @@
+package kubernetes
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform/helper/httpapi"
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func resourceKubernetesService() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceKubernetesServiceCreate,
+		Read:   resourceKubernetesServiceRead,
+		Update: resourceKubernetesServiceUpdate,
+		Delete: resourceKubernetesServiceDelete,
+
+		Schema: metadataSchema(true, map[string]*schema.Schema{
+			"type": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  "ClusterIP",
+			},
+
+			"selector": &schema.Schema{
+				Type:     schema.TypeMap,
+				Optional: true,
+			},
+
+			"port": &schema.Schema{
+				Type:     schema.TypeList,
+				Required: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": &schema.Schema{
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+
+						"protocol": &schema.Schema{
+							Type:     schema.TypeString,
+							Optional: true,
+							Default:  "TCP",
+						},
+
+						"port": &schema.Schema{
+							Type:     schema.TypeInt,
+							Required: true,
+						},
+
+						"target_port": &schema.Schema{
+							Type:     schema.TypeString,
+							Optional: true,
+							Computed: true,
+						},
+
+						"node_port": &schema.Schema{
+							Type:     schema.TypeInt,
+							Optional: true,
+							Computed: true,
+						},
+					},
+				},
+			},
+
+			"cluster_ip": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+
+			"external_ips": &schema.Schema{
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+
+			"load_balancer_ip": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"session_affinity": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  "None",
+			},
+
+			"load_balancer_ingress": &schema.Schema{
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+		}),
+	}
+}
+
+func resourceKubernetesServiceCreate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Client)
+
+	svc := &Service{
+		Kind:       "Service",
+		APIVersion: "v1",
+		Metadata:   expandMetadata(d),
+		Spec:       expandServiceSpec(d),
+	}
+	svc.Spec.ClusterIP = d.Get("cluster_ip").(string)
+
+	log.Printf("[DEBUG] Creating service: %#v", svc)
+	var result Service
+	err := client.Create(
+		namespacedPath("services", svc.Metadata.Namespace, ""), svc, &result)
+	if err != nil {
+		return fmt.Errorf("Error creating service: %s", err)
+	}
+
+	d.SetId(namespacedId(result.Metadata))
+	log.Printf("[INFO] Service created: %s", d.Id())
+
+	return resourceKubernetesServiceRead(d, meta)
+}
+
+func resourceKubernetesServiceRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Client)
+
+	namespace, name, err := parseNamespacedId(d.Id())
+	if err != nil {
+		return err
+	}
+
+	var svc Service
+	if err := client.Get(namespacedPath("services", namespace, name), &svc); err != nil {
+		if httpapi.IsNotFound(err) {
+			d.SetId("")
+			return nil
+		}
+		return fmt.Errorf("Error reading service %s: %s", d.Id(), err)
+	}
+
+	flattenMetadata(d, svc.Metadata)
+	d.Set("type", svc.Spec.Type)
+	d.Set("selector", svc.Spec.Selector)
+	d.Set("cluster_ip", svc.Spec.ClusterIP)
+	d.Set("external_ips", svc.Spec.ExternalIPs)
+	d.Set("load_balancer_ip", svc.Spec.LoadBalancerIP)
+	d.Set("session_affinity", svc.Spec.SessionAffinity)
+
+	ports := make([]map[string]interface{}, len(svc.Spec.Ports))
+	for i, p := range svc.Spec.Ports {
+		ports[i] = map[string]interface{}{
+			"name":        p.Name,
+			"protocol":    p.Protocol,
+			"port":        p.Port,
+			"target_port": string(p.TargetPort),
+			"node_port":   p.NodePort,
+		}
+	}
+	if err := d.Set("port", ports); err != nil {
+		return err
+	}
+
+	ingress := make([]string, 0, len(svc.Status.LoadBalancer.Ingress))
+	for _, i := range svc.Status.LoadBalancer.Ingress {
+		if i.IP != "" {
+			ingress = append(ingress, i.IP)
+		} else {
+			ingress = append(ingress, i.Hostname)
+		}
+	}
+	d.Set("load_balancer_ingress", ingress)
+
+	return nil
+}
+
+func resourceKubernetesServiceUpdate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Client)
+
+	namespace, name, err := parseNamespacedId(d.Id())
+	if err != nil {
+		return err
+	}
+	path := namespacedPath("services", namespace, name)
+
+	var svc Service
+	if err := client.Get(path, &svc); err != nil {
+		return fmt.Errorf("Error reading service %s: %s", d.Id(), err)
+	}
+
+	// The cluster IP of a service can't be changed, so it is kept
+	updateMetadata(d, &svc.Metadata)
+	clusterIP := svc.Spec.ClusterIP
+	svc.Spec = expandServiceSpec(d)
+	svc.Spec.ClusterIP = clusterIP
+
+	log.Printf("[DEBUG] Updating service: %#v", svc)
+	if err := client.Update(path, &svc, &svc); err != nil {
+		return fmt.Errorf("Error updating service %s: %s", d.Id(), err)
+	}
+
+	return resourceKubernetesServiceRead(d, meta)
+}
+
+func resourceKubernetesServiceDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Client)
+
+	namespace, name, err := parseNamespacedId(d.Id())
+	if err != nil {
+		return err
+	}
+
+	log.Printf("[INFO] Deleting service: %s", d.Id())
+	if err := client.Delete(namespacedPath("services", namespace, name)); err != nil {
+		if httpapi.IsNotFound(err) {
+			return nil
+		}
+		return fmt.Errorf("Error deleting service %s: %s", d.Id(), err)
+	}
+
+	d.SetId("")
+	return nil
+}
+
+// expandServiceSpec returns the spec of the service of the resource,
+// without its cluster IP.
+func expandServiceSpec(d *schema.ResourceData) ServiceSpec {
+	spec := ServiceSpec{
+		Type:            d.Get("type").(string),
+		Selector:        expandStringMap(d.Get("selector")),
+		ExternalIPs:     expandStringList(d.Get("external_ips")),
+		LoadBalancerIP:  d.Get("load_balancer_ip").(string),
+		SessionAffinity: d.Get("session_affinity").(string),
+	}
+
+	for _, raw := range d.Get("port").([]interface{}) {
+		p := raw.(map[string]interface{})
+		spec.Ports = append(spec.Ports, ServicePort{
+			Name:       p["name"].(string),
+			Protocol:   p["protocol"].(string),
+			Port:       p["port"].(int),
+			TargetPort: IntOrString(p["target_port"].(string)),
+			NodePort:   p["node_port"].(int),
+		})
+	}
+
+	return spec
+}
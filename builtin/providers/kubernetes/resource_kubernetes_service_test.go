@@ -0,0 +1,119 @@
+package kubernetes
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/httpapi"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestAccKubernetesService_basic(t *testing.T) {
+	var v Service
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckKubernetesServiceDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccKubernetesServiceConfig_basic,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckKubernetesServiceExists("kubernetes_service.test", &v),
+					resource.TestCheckResourceAttr(
+						"kubernetes_service.test", "type", "ClusterIP"),
+					resource.TestCheckResourceAttr(
+						"kubernetes_service.test", "port.0.port", "80"),
+					resource.TestCheckResourceAttr(
+						"kubernetes_service.test", "port.0.target_port", "8080"),
+				),
+			},
+			resource.TestStep{
+				Config: testAccKubernetesServiceConfig_update,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckKubernetesServiceExists("kubernetes_service.test", &v),
+					resource.TestCheckResourceAttr(
+						"kubernetes_service.test", "port.0.port", "8000"),
+					resource.TestCheckResourceAttr(
+						"kubernetes_service.test", "selector.app", "tf-acc-test-updated"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckKubernetesServiceExists(n string, v *Service) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No service ID is set")
+		}
+
+		client := testAccProvider.Meta().(*Client)
+		namespace, name, err := parseNamespacedId(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+		if err := client.Get(namespacedPath("services", namespace, name), v); err != nil {
+			return err
+		}
+
+		return nil
+	}
+}
+
+func testAccCheckKubernetesServiceDestroy(s *terraform.State) error {
+	client := testAccProvider.Meta().(*Client)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "kubernetes_service" {
+			continue
+		}
+
+		namespace, name, err := parseNamespacedId(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+		var v Service
+		err = client.Get(namespacedPath("services", namespace, name), &v)
+		if err == nil {
+			return fmt.Errorf("service still exists: %s", rs.Primary.ID)
+		}
+		if !httpapi.IsNotFound(err) {
+			return err
+		}
+	}
+
+	return nil
+}
+
+const testAccKubernetesServiceConfig_basic = `
+resource "kubernetes_service" "test" {
+	name = "tf-acc-test"
+	selector {
+		app = "tf-acc-test"
+	}
+	port {
+		port = 80
+		target_port = "8080"
+	}
+}
+`
+
+const testAccKubernetesServiceConfig_update = `
+resource "kubernetes_service" "test" {
+	name = "tf-acc-test"
+	selector {
+		app = "tf-acc-test-updated"
+	}
+	port {
+		port = 8000
+		target_port = "8080"
+	}
+}
+`
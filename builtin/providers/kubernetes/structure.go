@@ -0,0 +1,312 @@
+package kubernetes
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+// metadataSchema returns the schema of the metadata of an object, along
+// with the given fields of the resource. Objects other than namespaces
+// are in a namespace.
+func metadataSchema(namespaced bool, fields map[string]*schema.Schema) map[string]*schema.Schema {
+	fields["name"] = &schema.Schema{
+		Type:     schema.TypeString,
+		Required: true,
+		ForceNew: true,
+	}
+	if namespaced {
+		fields["namespace"] = &schema.Schema{
+			Type:     schema.TypeString,
+			Optional: true,
+			ForceNew: true,
+			Default:  "default",
+		}
+	}
+	fields["labels"] = &schema.Schema{
+		Type:     schema.TypeMap,
+		Optional: true,
+	}
+	fields["annotations"] = &schema.Schema{
+		Type:     schema.TypeMap,
+		Optional: true,
+	}
+	fields["uid"] = &schema.Schema{
+		Type:     schema.TypeString,
+		Computed: true,
+	}
+	fields["resource_version"] = &schema.Schema{
+		Type:     schema.TypeString,
+		Computed: true,
+	}
+	fields["self_link"] = &schema.Schema{
+		Type:     schema.TypeString,
+		Computed: true,
+	}
+
+	return fields
+}
+
+// expandMetadata returns the metadata of the object of the resource.
+func expandMetadata(d *schema.ResourceData) ObjectMeta {
+	m := ObjectMeta{
+		Name:        d.Get("name").(string),
+		Labels:      expandStringMap(d.Get("labels")),
+		Annotations: expandStringMap(d.Get("annotations")),
+	}
+	if v, ok := d.GetOk("namespace"); ok {
+		m.Namespace = v.(string)
+	}
+
+	return m
+}
+
+// updateMetadata sets the labels and annotations of the resource on the
+// metadata of an existing object, keeping the rest of it, such as its
+// resource version.
+func updateMetadata(d *schema.ResourceData, m *ObjectMeta) {
+	m.Labels = expandStringMap(d.Get("labels"))
+	m.Annotations = expandStringMap(d.Get("annotations"))
+}
+
+// flattenMetadata sets the fields of the metadata of an object on the
+// resource.
+func flattenMetadata(d *schema.ResourceData, m ObjectMeta) {
+	d.Set("name", m.Name)
+	if m.Namespace != "" {
+		d.Set("namespace", m.Namespace)
+	}
+	d.Set("labels", m.Labels)
+	d.Set("annotations", m.Annotations)
+	d.Set("uid", m.UID)
+	d.Set("resource_version", m.ResourceVersion)
+	d.Set("self_link", m.SelfLink)
+}
+
+// podTemplateSchema returns the schema of the template of the pods of a
+// controller, such as a replication controller or a deployment, along
+// with the given fields of the resource.
+func podTemplateSchema(fields map[string]*schema.Schema) map[string]*schema.Schema {
+	fields["pod_labels"] = &schema.Schema{
+		Type:     schema.TypeMap,
+		Optional: true,
+	}
+	fields["restart_policy"] = &schema.Schema{
+		Type:     schema.TypeString,
+		Optional: true,
+		Default:  "Always",
+	}
+	fields["container"] = &schema.Schema{
+		Type:     schema.TypeList,
+		Required: true,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"name": &schema.Schema{
+					Type:     schema.TypeString,
+					Required: true,
+				},
+
+				"image": &schema.Schema{
+					Type:     schema.TypeString,
+					Required: true,
+				},
+
+				"command": &schema.Schema{
+					Type:     schema.TypeList,
+					Optional: true,
+					Elem:     &schema.Schema{Type: schema.TypeString},
+				},
+
+				"args": &schema.Schema{
+					Type:     schema.TypeList,
+					Optional: true,
+					Elem:     &schema.Schema{Type: schema.TypeString},
+				},
+
+				"env": &schema.Schema{
+					Type:     schema.TypeMap,
+					Optional: true,
+				},
+
+				"image_pull_policy": &schema.Schema{
+					Type:     schema.TypeString,
+					Optional: true,
+					Computed: true,
+				},
+
+				"port": &schema.Schema{
+					Type:     schema.TypeList,
+					Optional: true,
+					Elem: &schema.Resource{
+						Schema: map[string]*schema.Schema{
+							"name": &schema.Schema{
+								Type:     schema.TypeString,
+								Optional: true,
+							},
+
+							"container_port": &schema.Schema{
+								Type:     schema.TypeInt,
+								Required: true,
+							},
+
+							"protocol": &schema.Schema{
+								Type:     schema.TypeString,
+								Optional: true,
+								Default:  "TCP",
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	return fields
+}
+
+// flattenPodTemplate sets the fields of the pod template of a controller
+// with the given selector on the resource.
+func flattenPodTemplate(d *schema.ResourceData, t PodTemplateSpec, selector map[string]string) error {
+	d.Set("restart_policy", t.Spec.RestartPolicy)
+
+	// The pod labels are the labels of the template other than those of
+	// the selector, which the template gets as well.
+	podLabels := make(map[string]string)
+	for k, v := range t.Metadata.Labels {
+		if sv, ok := selector[k]; !ok || sv != v {
+			podLabels[k] = v
+		}
+	}
+	d.Set("pod_labels", podLabels)
+
+	containers := make([]map[string]interface{}, len(t.Spec.Containers))
+	for i, c := range t.Spec.Containers {
+		env := make(map[string]string, len(c.Env))
+		for _, e := range c.Env {
+			env[e.Name] = e.Value
+		}
+
+		ports := make([]map[string]interface{}, len(c.Ports))
+		for j, p := range c.Ports {
+			ports[j] = map[string]interface{}{
+				"name":           p.Name,
+				"container_port": p.ContainerPort,
+				"protocol":       p.Protocol,
+			}
+		}
+
+		containers[i] = map[string]interface{}{
+			"name":              c.Name,
+			"image":             c.Image,
+			"command":           c.Command,
+			"args":              c.Args,
+			"env":               env,
+			"image_pull_policy": c.ImagePullPolicy,
+			"port":              ports,
+		}
+	}
+	return d.Set("container", containers)
+}
+
+// expandPodTemplate returns the pod template of the controller of the
+// resource. The pods get the labels of the selector, along with the
+// pod_labels.
+func expandPodTemplate(d *schema.ResourceData, selector map[string]string) PodTemplateSpec {
+	labels := make(map[string]string)
+	for k, v := range expandStringMap(d.Get("pod_labels")) {
+		labels[k] = v
+	}
+	for k, v := range selector {
+		labels[k] = v
+	}
+
+	t := PodTemplateSpec{
+		Metadata: ObjectMeta{Labels: labels},
+		Spec: PodSpec{
+			RestartPolicy: d.Get("restart_policy").(string),
+		},
+	}
+
+	for _, raw := range d.Get("container").([]interface{}) {
+		c := raw.(map[string]interface{})
+		container := Container{
+			Name:            c["name"].(string),
+			Image:           c["image"].(string),
+			Command:         expandStringList(c["command"]),
+			Args:            expandStringList(c["args"]),
+			ImagePullPolicy: c["image_pull_policy"].(string),
+		}
+
+		for k, v := range expandStringMap(c["env"]) {
+			container.Env = append(container.Env, EnvVar{Name: k, Value: v})
+		}
+		sort.Sort(envVarsByName(container.Env))
+
+		for _, raw := range c["port"].([]interface{}) {
+			p := raw.(map[string]interface{})
+			container.Ports = append(container.Ports, ContainerPort{
+				Name:          p["name"].(string),
+				ContainerPort: p["container_port"].(int),
+				Protocol:      p["protocol"].(string),
+			})
+		}
+
+		t.Spec.Containers = append(t.Spec.Containers, container)
+	}
+
+	return t
+}
+
+type envVarsByName []EnvVar
+
+func (s envVarsByName) Len() int           { return len(s) }
+func (s envVarsByName) Swap(i, j int)      { s[i], s[j] = s[j], s[i] }
+func (s envVarsByName) Less(i, j int) bool { return s[i].Name < s[j].Name }
+
+// namespacedId returns the ID of the resource for an object in a
+// namespace, "NAMESPACE/NAME".
+func namespacedId(m ObjectMeta) string {
+	return m.Namespace + "/" + m.Name
+}
+
+// parseNamespacedId returns the namespace and the name of the object in
+// the ID of a resource, as returned by namespacedId.
+func parseNamespacedId(id string) (string, string, error) {
+	parts := strings.SplitN(id, "/", 2)
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return "", "", fmt.Errorf(
+			"Unexpected ID %q, expected NAMESPACE/NAME", id)
+	}
+
+	return parts[0], parts[1], nil
+}
+
+func expandStringMap(v interface{}) map[string]string {
+	m, ok := v.(map[string]interface{})
+	if !ok || len(m) == 0 {
+		return nil
+	}
+
+	result := make(map[string]string, len(m))
+	for k, v := range m {
+		result[k] = v.(string)
+	}
+
+	return result
+}
+
+func expandStringList(v interface{}) []string {
+	l, ok := v.([]interface{})
+	if !ok || len(l) == 0 {
+		return nil
+	}
+
+	result := make([]string, len(l))
+	for i, v := range l {
+		result[i] = v.(string)
+	}
+
+	return result
+}
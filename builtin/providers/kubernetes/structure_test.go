@@ -0,0 +1,58 @@
+package kubernetes
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestParseNamespacedId(t *testing.T) {
+	cases := []struct {
+		Id        string
+		Namespace string
+		Name      string
+		Err       bool
+	}{
+		{"default/foo", "default", "foo", false},
+		{"foo", "", "", true},
+		{"/foo", "", "", true},
+		{"default/", "", "", true},
+	}
+
+	for i, tc := range cases {
+		namespace, name, err := parseNamespacedId(tc.Id)
+		if (err != nil) != tc.Err {
+			t.Fatalf("%d: err: %s", i, err)
+		}
+		if namespace != tc.Namespace || name != tc.Name {
+			t.Fatalf("%d: bad: %s, %s", i, namespace, name)
+		}
+	}
+}
+
+func TestIntOrString(t *testing.T) {
+	cases := []struct {
+		Value IntOrString
+		JSON  string
+	}{
+		{"8080", `8080`},
+		{"http", `"http"`},
+	}
+
+	for i, tc := range cases {
+		raw, err := json.Marshal(tc.Value)
+		if err != nil {
+			t.Fatalf("%d: err: %s", i, err)
+		}
+		if string(raw) != tc.JSON {
+			t.Fatalf("%d: bad: %s", i, raw)
+		}
+
+		var v IntOrString
+		if err := json.Unmarshal(raw, &v); err != nil {
+			t.Fatalf("%d: err: %s", i, err)
+		}
+		if v != tc.Value {
+			t.Fatalf("%d: bad: %s", i, v)
+		}
+	}
+}
@@ -0,0 +1,191 @@
+package kubernetes
+
+import (
+	"encoding/json"
+	"strconv"
+)
+
+// ObjectMeta is the metadata of an object of the API.
+type ObjectMeta struct {
+	Name            string            `json:"name,omitempty"`
+	Namespace       string            `json:"namespace,omitempty"`
+	Labels          map[string]string `json:"labels,omitempty"`
+	Annotations     map[string]string `json:"annotations,omitempty"`
+	ResourceVersion string            `json:"resourceVersion,omitempty"`
+	Generation      int64             `json:"generation,omitempty"`
+	UID             string            `json:"uid,omitempty"`
+	SelfLink        string            `json:"selfLink,omitempty"`
+}
+
+type Namespace struct {
+	Kind       string          `json:"kind,omitempty"`
+	APIVersion string          `json:"apiVersion,omitempty"`
+	Metadata   ObjectMeta      `json:"metadata"`
+	Status     NamespaceStatus `json:"status,omitempty"`
+}
+
+type NamespaceStatus struct {
+	Phase string `json:"phase,omitempty"`
+}
+
+type Secret struct {
+	Kind       string            `json:"kind,omitempty"`
+	APIVersion string            `json:"apiVersion,omitempty"`
+	Metadata   ObjectMeta        `json:"metadata"`
+	Type       string            `json:"type,omitempty"`
+	Data       map[string][]byte `json:"data,omitempty"`
+}
+
+type ConfigMap struct {
+	Kind       string            `json:"kind,omitempty"`
+	APIVersion string            `json:"apiVersion,omitempty"`
+	Metadata   ObjectMeta        `json:"metadata"`
+	Data       map[string]string `json:"data,omitempty"`
+}
+
+type Service struct {
+	Kind       string        `json:"kind,omitempty"`
+	APIVersion string        `json:"apiVersion,omitempty"`
+	Metadata   ObjectMeta    `json:"metadata"`
+	Spec       ServiceSpec   `json:"spec"`
+	Status     ServiceStatus `json:"status,omitempty"`
+}
+
+type ServiceSpec struct {
+	Type            string            `json:"type,omitempty"`
+	Selector        map[string]string `json:"selector,omitempty"`
+	Ports           []ServicePort     `json:"ports"`
+	ClusterIP       string            `json:"clusterIP,omitempty"`
+	ExternalIPs     []string          `json:"externalIPs,omitempty"`
+	LoadBalancerIP  string            `json:"loadBalancerIP,omitempty"`
+	SessionAffinity string            `json:"sessionAffinity,omitempty"`
+}
+
+type ServicePort struct {
+	Name       string      `json:"name,omitempty"`
+	Protocol   string      `json:"protocol,omitempty"`
+	Port       int         `json:"port"`
+	TargetPort IntOrString `json:"targetPort,omitempty"`
+	NodePort   int         `json:"nodePort,omitempty"`
+}
+
+type ServiceStatus struct {
+	LoadBalancer struct {
+		Ingress []struct {
+			IP       string `json:"ip,omitempty"`
+			Hostname string `json:"hostname,omitempty"`
+		} `json:"ingress,omitempty"`
+	} `json:"loadBalancer,omitempty"`
+}
+
+type ReplicationController struct {
+	Kind       string                      `json:"kind,omitempty"`
+	APIVersion string                      `json:"apiVersion,omitempty"`
+	Metadata   ObjectMeta                  `json:"metadata"`
+	Spec       ReplicationControllerSpec   `json:"spec"`
+	Status     ReplicationControllerStatus `json:"status,omitempty"`
+}
+
+type ReplicationControllerSpec struct {
+	Replicas int               `json:"replicas"`
+	Selector map[string]string `json:"selector,omitempty"`
+	Template PodTemplateSpec   `json:"template"`
+}
+
+type ReplicationControllerStatus struct {
+	Replicas int `json:"replicas"`
+}
+
+type Deployment struct {
+	Kind       string           `json:"kind,omitempty"`
+	APIVersion string           `json:"apiVersion,omitempty"`
+	Metadata   ObjectMeta       `json:"metadata"`
+	Spec       DeploymentSpec   `json:"spec"`
+	Status     DeploymentStatus `json:"status,omitempty"`
+}
+
+type DeploymentSpec struct {
+	Replicas int                `json:"replicas"`
+	Selector LabelSelector      `json:"selector"`
+	Template PodTemplateSpec    `json:"template"`
+	Strategy DeploymentStrategy `json:"strategy,omitempty"`
+}
+
+type LabelSelector struct {
+	MatchLabels map[string]string `json:"matchLabels,omitempty"`
+}
+
+type DeploymentStrategy struct {
+	Type          string                   `json:"type,omitempty"`
+	RollingUpdate *RollingUpdateDeployment `json:"rollingUpdate,omitempty"`
+}
+
+type RollingUpdateDeployment struct {
+	MaxUnavailable IntOrString `json:"maxUnavailable,omitempty"`
+	MaxSurge       IntOrString `json:"maxSurge,omitempty"`
+}
+
+type DeploymentStatus struct {
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+	Replicas           int   `json:"replicas"`
+	UpdatedReplicas    int   `json:"updatedReplicas"`
+	AvailableReplicas  int   `json:"availableReplicas"`
+}
+
+type PodTemplateSpec struct {
+	Metadata ObjectMeta `json:"metadata"`
+	Spec     PodSpec    `json:"spec"`
+}
+
+type PodSpec struct {
+	Containers    []Container `json:"containers"`
+	RestartPolicy string      `json:"restartPolicy,omitempty"`
+}
+
+type Container struct {
+	Name            string          `json:"name"`
+	Image           string          `json:"image"`
+	Command         []string        `json:"command,omitempty"`
+	Args            []string        `json:"args,omitempty"`
+	Env             []EnvVar        `json:"env,omitempty"`
+	Ports           []ContainerPort `json:"ports,omitempty"`
+	ImagePullPolicy string          `json:"imagePullPolicy,omitempty"`
+}
+
+type EnvVar struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+type ContainerPort struct {
+	Name          string `json:"name,omitempty"`
+	ContainerPort int    `json:"containerPort"`
+	Protocol      string `json:"protocol,omitempty"`
+}
+
+// IntOrString is a value of the API that is either a number, such as
+// the number of a port, or a string, such as the name of a port. It is
+// a number if it is a number.
+type IntOrString string
+
+func (v IntOrString) MarshalJSON() ([]byte, error) {
+	if n, err := strconv.Atoi(string(v)); err == nil {
+		return json.Marshal(n)
+	}
+	return json.Marshal(string(v))
+}
+
+func (v *IntOrString) UnmarshalJSON(raw []byte) error {
+	var n int
+	if err := json.Unmarshal(raw, &n); err == nil {
+		*v = IntOrString(strconv.Itoa(n))
+		return nil
+	}
+
+	var s string
+	if err := json.Unmarshal(raw, &s); err != nil {
+		return err
+	}
+	*v = IntOrString(s)
+	return nil
+}
@@ -0,0 +1,185 @@
+package marathon
+
+import (
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform/helper/httpapi"
+	"github.com/hashicorp/terraform/helper/resource"
+)
+
+// Client is a client for the v2 Marathon API.
+type Client struct {
+	api               *httpapi.Client
+	deploymentTimeout time.Duration
+}
+
+// App is the definition of an application, as accepted and returned by
+// the API.
+type App struct {
+	ID           string            `json:"id"`
+	Cmd          string            `json:"cmd,omitempty"`
+	Args         []string          `json:"args,omitempty"`
+	Instances    int               `json:"instances"`
+	CPUs         float64           `json:"cpus"`
+	Mem          float64           `json:"mem"`
+	Disk         float64           `json:"disk,omitempty"`
+	Env          map[string]string `json:"env,omitempty"`
+	Labels       map[string]string `json:"labels,omitempty"`
+	URIs         []string          `json:"uris,omitempty"`
+	Ports        []int             `json:"ports,omitempty"`
+	Constraints  [][]string        `json:"constraints,omitempty"`
+	Container    *Container        `json:"container,omitempty"`
+	HealthChecks []HealthCheck     `json:"healthChecks,omitempty"`
+
+	// Set by Marathon
+	Version      string       `json:"version,omitempty"`
+	Deployments  []Deployment `json:"deployments,omitempty"`
+	TasksRunning int          `json:"tasksRunning,omitempty"`
+}
+
+type Container struct {
+	Type    string    `json:"type"`
+	Docker  *Docker   `json:"docker,omitempty"`
+	Volumes []*Volume `json:"volumes,omitempty"`
+}
+
+type Docker struct {
+	Image          string         `json:"image"`
+	Network        string         `json:"network,omitempty"`
+	Privileged     bool           `json:"privileged"`
+	ForcePullImage bool           `json:"forcePullImage"`
+	PortMappings   []*PortMapping `json:"portMappings,omitempty"`
+}
+
+type PortMapping struct {
+	ContainerPort int    `json:"containerPort"`
+	HostPort      int    `json:"hostPort"`
+	ServicePort   int    `json:"servicePort,omitempty"`
+	Protocol      string `json:"protocol,omitempty"`
+}
+
+type Volume struct {
+	ContainerPath string `json:"containerPath"`
+	HostPath      string `json:"hostPath"`
+	Mode          string `json:"mode"`
+}
+
+type HealthCheck struct {
+	Protocol               string              `json:"protocol"`
+	Path                   string              `json:"path,omitempty"`
+	PortIndex              int                 `json:"portIndex"`
+	Command                *HealthCheckCommand `json:"command,omitempty"`
+	GracePeriodSeconds     int                 `json:"gracePeriodSeconds"`
+	IntervalSeconds        int                 `json:"intervalSeconds"`
+	TimeoutSeconds         int                 `json:"timeoutSeconds"`
+	MaxConsecutiveFailures int                 `json:"maxConsecutiveFailures"`
+}
+
+type HealthCheckCommand struct {
+	Value string `json:"value"`
+}
+
+type Deployment struct {
+	ID string `json:"id"`
+}
+
+// deploymentResult is the answer of the API to a change of an app.
+type deploymentResult struct {
+	DeploymentID string `json:"deploymentId"`
+	Version      string `json:"version"`
+}
+
+// CreateApp creates the app, and returns the ID of the deployment that
+// starts it.
+func (c *Client) CreateApp(app *App) (string, error) {
+	var result App
+	if err := c.do("POST", "/v2/apps", app, &result); err != nil {
+		return "", err
+	}
+
+	if len(result.Deployments) == 0 {
+		return "", nil
+	}
+	return result.Deployments[0].ID, nil
+}
+
+// App returns the app with the given ID.
+func (c *Client) App(id string) (*App, error) {
+	var result struct {
+		App App `json:"app"`
+	}
+	if err := c.do("GET", appPath(id), nil, &result); err != nil {
+		return nil, err
+	}
+
+	return &result.App, nil
+}
+
+// UpdateApp changes the definition of the app, and returns the ID of the
+// deployment that rolls out the change.
+func (c *Client) UpdateApp(app *App) (string, error) {
+	var result deploymentResult
+	if err := c.do("PUT", appPath(app.ID), app, &result); err != nil {
+		return "", err
+	}
+
+	return result.DeploymentID, nil
+}
+
+// DeleteApp deletes the app with the given ID, and returns the ID of the
+// deployment that stops it.
+func (c *Client) DeleteApp(id string) (string, error) {
+	var result deploymentResult
+	if err := c.do("DELETE", appPath(id), nil, &result); err != nil {
+		return "", err
+	}
+
+	return result.DeploymentID, nil
+}
+
+// WaitForDeployment waits for the deployment with the given ID to be
+// finished, which is when Marathon no longer lists it.
+func (c *Client) WaitForDeployment(id string, stopCh <-chan struct{}) error {
+	if id == "" {
+		return nil
+	}
+
+	log.Printf("[DEBUG] Waiting for Marathon deployment %s", id)
+	stateConf := &resource.StateChangeConf{
+		Pending: []string{"running"},
+		Target:  "",
+		Refresh: func() (interface{}, string, error) {
+			var deployments []Deployment
+			if err := c.do("GET", "/v2/deployments", nil, &deployments); err != nil {
+				return nil, "", err
+			}
+			for _, d := range deployments {
+				if d.ID == id {
+					return d, "running", nil
+				}
+			}
+			return nil, "", nil
+		},
+		Timeout:    c.deploymentTimeout,
+		MinTimeout: 2 * time.Second,
+		StopCh:     stopCh,
+	}
+	if _, err := stateConf.WaitForState(); err != nil {
+		return fmt.Errorf("Error waiting for deployment %s: %s", id, err)
+	}
+
+	return nil
+}
+
+func (c *Client) do(method, path string, in, out interface{}) error {
+	return c.api.Do(method, path, in, out)
+}
+
+// appPath returns the path of the API for the app with the given ID,
+// such as "/group/app".
+func appPath(id string) string {
+	return "/v2/apps/" + strings.TrimPrefix(id, "/")
+}
@@ -0,0 +1,37 @@
+package marathon
+
+import (
+	"fmt"
+	"log"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform/helper/httpapi"
+)
+
+type Config struct {
+	URL               string
+	Username          string
+	Password          string
+	DeploymentTimeout int
+}
+
+// Client returns a new client for the Marathon API.
+func (c *Config) Client() (*Client, error) {
+	u, err := url.Parse(c.URL)
+	if err != nil || u.Scheme == "" || u.Host == "" {
+		return nil, fmt.Errorf("Invalid Marathon URL %q", c.URL)
+	}
+
+	log.Printf("[INFO] Marathon client configured for %s", c.URL)
+	return &Client{
+		api: &httpapi.Client{
+			BaseURL:  strings.TrimSuffix(c.URL, "/"),
+			Name:     "Marathon",
+			Username: c.Username,
+			Password: c.Password,
+		},
+		deploymentTimeout: time.Duration(c.DeploymentTimeout) * time.Second,
+	}, nil
+}
@@ -0,0 +1,61 @@
+package marathon
+
+import (
+	"log"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+// Provider returns a terraform.ResourceProvider.
+func Provider() terraform.ResourceProvider {
+	return &schema.Provider{
+		Schema: map[string]*schema.Schema{
+			"url": &schema.Schema{
+				Type:        schema.TypeString,
+				Required:    true,
+				DefaultFunc: schema.EnvDefaultFunc("MARATHON_URL", nil),
+				Description: "The URL of Marathon, such as http://marathon.local:8080.",
+			},
+
+			"username": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("MARATHON_USERNAME", ""),
+				Description: "The username for basic authentication.",
+			},
+
+			"password": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("MARATHON_PASSWORD", ""),
+				Description: "The password for basic authentication.",
+			},
+
+			"deployment_timeout": &schema.Schema{
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Default:     600,
+				Description: "The number of seconds to wait for a deployment to finish.",
+			},
+		},
+
+		ResourcesMap: map[string]*schema.Resource{
+			"marathon_app": resourceMarathonApp(),
+		},
+
+		ConfigureFunc: providerConfigure,
+	}
+}
+
+func providerConfigure(d *schema.ResourceData) (interface{}, error) {
+	config := Config{
+		URL:               d.Get("url").(string),
+		Username:          d.Get("username").(string),
+		Password:          d.Get("password").(string),
+		DeploymentTimeout: d.Get("deployment_timeout").(int),
+	}
+
+	log.Println("[INFO] Initializing Marathon client")
+	return config.Client()
+}
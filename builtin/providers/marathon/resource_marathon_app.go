@@ -0,0 +1,558 @@
+package marathon
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/httpapi"
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func resourceMarathonApp() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceMarathonAppCreate,
+		Read:   resourceMarathonAppRead,
+		Update: resourceMarathonAppUpdate,
+		Delete: resourceMarathonAppDelete,
+
+		Schema: map[string]*schema.Schema{
+			"app_id": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+				StateFunc: func(v interface{}) string {
+					return "/" + strings.TrimPrefix(v.(string), "/")
+				},
+			},
+
+			"cmd": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"args": &schema.Schema{
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+
+			"instances": &schema.Schema{
+				Type:     schema.TypeInt,
+				Optional: true,
+				Default:  1,
+			},
+
+			"cpus": &schema.Schema{
+				Type:     schema.TypeFloat,
+				Optional: true,
+				Default:  1.0,
+			},
+
+			"mem": &schema.Schema{
+				Type:     schema.TypeFloat,
+				Optional: true,
+				Default:  128.0,
+			},
+
+			"disk": &schema.Schema{
+				Type:     schema.TypeFloat,
+				Optional: true,
+				Computed: true,
+			},
+
+			"env": &schema.Schema{
+				Type:     schema.TypeMap,
+				Optional: true,
+			},
+
+			"labels": &schema.Schema{
+				Type:     schema.TypeMap,
+				Optional: true,
+			},
+
+			"uris": &schema.Schema{
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+
+			"ports": &schema.Schema{
+				Type:     schema.TypeList,
+				Optional: true,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeInt},
+			},
+
+			"constraint": &schema.Schema{
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"attribute": &schema.Schema{
+							Type:     schema.TypeString,
+							Required: true,
+						},
+
+						"operation": &schema.Schema{
+							Type:     schema.TypeString,
+							Required: true,
+						},
+
+						"parameter": &schema.Schema{
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+					},
+				},
+			},
+
+			"container": &schema.Schema{
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"type": &schema.Schema{
+							Type:     schema.TypeString,
+							Optional: true,
+							Default:  "DOCKER",
+						},
+
+						"docker": &schema.Schema{
+							Type:     schema.TypeList,
+							Optional: true,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"image": &schema.Schema{
+										Type:     schema.TypeString,
+										Required: true,
+									},
+
+									"network": &schema.Schema{
+										Type:     schema.TypeString,
+										Optional: true,
+										Computed: true,
+									},
+
+									"privileged": &schema.Schema{
+										Type:     schema.TypeBool,
+										Optional: true,
+									},
+
+									"force_pull_image": &schema.Schema{
+										Type:     schema.TypeBool,
+										Optional: true,
+									},
+
+									"port_mapping": &schema.Schema{
+										Type:     schema.TypeList,
+										Optional: true,
+										Elem: &schema.Resource{
+											Schema: map[string]*schema.Schema{
+												"container_port": &schema.Schema{
+													Type:     schema.TypeInt,
+													Required: true,
+												},
+
+												"host_port": &schema.Schema{
+													Type:     schema.TypeInt,
+													Optional: true,
+												},
+
+												"service_port": &schema.Schema{
+													Type:     schema.TypeInt,
+													Optional: true,
+													Computed: true,
+												},
+
+												"protocol": &schema.Schema{
+													Type:     schema.TypeString,
+													Optional: true,
+													Default:  "tcp",
+												},
+											},
+										},
+									},
+								},
+							},
+						},
+
+						"volume": &schema.Schema{
+							Type:     schema.TypeList,
+							Optional: true,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"container_path": &schema.Schema{
+										Type:     schema.TypeString,
+										Required: true,
+									},
+
+									"host_path": &schema.Schema{
+										Type:     schema.TypeString,
+										Required: true,
+									},
+
+									"mode": &schema.Schema{
+										Type:     schema.TypeString,
+										Optional: true,
+										Default:  "RO",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+
+			"health_check": &schema.Schema{
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"protocol": &schema.Schema{
+							Type:     schema.TypeString,
+							Optional: true,
+							Default:  "HTTP",
+						},
+
+						"path": &schema.Schema{
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+
+						"port_index": &schema.Schema{
+							Type:     schema.TypeInt,
+							Optional: true,
+						},
+
+						"command": &schema.Schema{
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+
+						"grace_period_seconds": &schema.Schema{
+							Type:     schema.TypeInt,
+							Optional: true,
+							Default:  300,
+						},
+
+						"interval_seconds": &schema.Schema{
+							Type:     schema.TypeInt,
+							Optional: true,
+							Default:  60,
+						},
+
+						"timeout_seconds": &schema.Schema{
+							Type:     schema.TypeInt,
+							Optional: true,
+							Default:  20,
+						},
+
+						"max_consecutive_failures": &schema.Schema{
+							Type:     schema.TypeInt,
+							Optional: true,
+							Default:  3,
+						},
+					},
+				},
+			},
+
+			"version": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceMarathonAppCreate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Client)
+
+	app := expandApp(d)
+	log.Printf("[DEBUG] Creating Marathon app: %#v", app)
+	deploymentID, err := client.CreateApp(app)
+	if err != nil {
+		return fmt.Errorf("Error creating Marathon app: %s", err)
+	}
+
+	d.SetId(app.ID)
+	log.Printf("[INFO] Marathon app created: %s", d.Id())
+
+	if err := client.WaitForDeployment(deploymentID, d.StopCh()); err != nil {
+		return err
+	}
+
+	return resourceMarathonAppRead(d, meta)
+}
+
+func resourceMarathonAppRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Client)
+
+	app, err := client.App(d.Id())
+	if err != nil {
+		if httpapi.IsNotFound(err) {
+			d.SetId("")
+			return nil
+		}
+		return fmt.Errorf("Error reading Marathon app %s: %s", d.Id(), err)
+	}
+
+	d.Set("app_id", app.ID)
+	d.Set("cmd", app.Cmd)
+	d.Set("args", app.Args)
+	d.Set("instances", app.Instances)
+	d.Set("cpus", app.CPUs)
+	d.Set("mem", app.Mem)
+	d.Set("disk", app.Disk)
+	d.Set("env", app.Env)
+	d.Set("labels", app.Labels)
+	d.Set("uris", app.URIs)
+	d.Set("ports", app.Ports)
+	d.Set("version", app.Version)
+
+	if err := d.Set("constraint", flattenConstraints(app.Constraints)); err != nil {
+		return err
+	}
+	if err := d.Set("container", flattenContainer(app.Container)); err != nil {
+		return err
+	}
+	if err := d.Set("health_check", flattenHealthChecks(app.HealthChecks)); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func resourceMarathonAppUpdate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Client)
+
+	app := expandApp(d)
+	log.Printf("[DEBUG] Updating Marathon app: %#v", app)
+	deploymentID, err := client.UpdateApp(app)
+	if err != nil {
+		return fmt.Errorf("Error updating Marathon app %s: %s", d.Id(), err)
+	}
+
+	if err := client.WaitForDeployment(deploymentID, d.StopCh()); err != nil {
+		return err
+	}
+
+	return resourceMarathonAppRead(d, meta)
+}
+
+func resourceMarathonAppDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Client)
+
+	log.Printf("[INFO] Deleting Marathon app: %s", d.Id())
+	deploymentID, err := client.DeleteApp(d.Id())
+	if err != nil {
+		if httpapi.IsNotFound(err) {
+			return nil
+		}
+		return fmt.Errorf("Error deleting Marathon app %s: %s", d.Id(), err)
+	}
+
+	if err := client.WaitForDeployment(deploymentID, d.StopCh()); err != nil {
+		return err
+	}
+
+	d.SetId("")
+	return nil
+}
+
+// expandApp returns the definition of the app of the resource.
+func expandApp(d *schema.ResourceData) *App {
+	app := &App{
+		ID:        "/" + strings.TrimPrefix(d.Get("app_id").(string), "/"),
+		Cmd:       d.Get("cmd").(string),
+		Args:      expandStringList(d.Get("args")),
+		Instances: d.Get("instances").(int),
+		CPUs:      d.Get("cpus").(float64),
+		Mem:       d.Get("mem").(float64),
+		Disk:      d.Get("disk").(float64),
+		Env:       expandStringMap(d.Get("env")),
+		Labels:    expandStringMap(d.Get("labels")),
+		URIs:      expandStringList(d.Get("uris")),
+	}
+
+	for _, p := range d.Get("ports").([]interface{}) {
+		app.Ports = append(app.Ports, p.(int))
+	}
+
+	for _, raw := range d.Get("constraint").([]interface{}) {
+		c := raw.(map[string]interface{})
+		constraint := []string{c["attribute"].(string), c["operation"].(string)}
+		if p := c["parameter"].(string); p != "" {
+			constraint = append(constraint, p)
+		}
+		app.Constraints = append(app.Constraints, constraint)
+	}
+
+	if l := d.Get("container").([]interface{}); len(l) > 0 {
+		app.Container = expandContainer(l[0].(map[string]interface{}))
+	}
+
+	for _, raw := range d.Get("health_check").([]interface{}) {
+		h := raw.(map[string]interface{})
+		hc := HealthCheck{
+			Protocol:               h["protocol"].(string),
+			Path:                   h["path"].(string),
+			PortIndex:              h["port_index"].(int),
+			GracePeriodSeconds:     h["grace_period_seconds"].(int),
+			IntervalSeconds:        h["interval_seconds"].(int),
+			TimeoutSeconds:         h["timeout_seconds"].(int),
+			MaxConsecutiveFailures: h["max_consecutive_failures"].(int),
+		}
+		if c := h["command"].(string); c != "" {
+			hc.Command = &HealthCheckCommand{Value: c}
+		}
+		app.HealthChecks = append(app.HealthChecks, hc)
+	}
+
+	return app
+}
+
+func expandContainer(m map[string]interface{}) *Container {
+	c := &Container{Type: m["type"].(string)}
+
+	if l := m["docker"].([]interface{}); len(l) > 0 {
+		d := l[0].(map[string]interface{})
+		c.Docker = &Docker{
+			Image:          d["image"].(string),
+			Network:        d["network"].(string),
+			Privileged:     d["privileged"].(bool),
+			ForcePullImage: d["force_pull_image"].(bool),
+		}
+
+		for _, raw := range d["port_mapping"].([]interface{}) {
+			p := raw.(map[string]interface{})
+			c.Docker.PortMappings = append(c.Docker.PortMappings, &PortMapping{
+				ContainerPort: p["container_port"].(int),
+				HostPort:      p["host_port"].(int),
+				ServicePort:   p["service_port"].(int),
+				Protocol:      p["protocol"].(string),
+			})
+		}
+	}
+
+	for _, raw := range m["volume"].([]interface{}) {
+		v := raw.(map[string]interface{})
+		c.Volumes = append(c.Volumes, &Volume{
+			ContainerPath: v["container_path"].(string),
+			HostPath:      v["host_path"].(string),
+			Mode:          v["mode"].(string),
+		})
+	}
+
+	return c
+}
+
+func flattenConstraints(constraints [][]string) []map[string]interface{} {
+	result := make([]map[string]interface{}, 0, len(constraints))
+	for _, c := range constraints {
+		if len(c) < 2 {
+			continue
+		}
+
+		m := map[string]interface{}{
+			"attribute": c[0],
+			"operation": c[1],
+		}
+		if len(c) > 2 {
+			m["parameter"] = c[2]
+		}
+		result = append(result, m)
+	}
+
+	return result
+}
+
+func flattenContainer(c *Container) []map[string]interface{} {
+	if c == nil {
+		return nil
+	}
+
+	m := map[string]interface{}{"type": c.Type}
+
+	if c.Docker != nil {
+		mappings := make([]map[string]interface{}, len(c.Docker.PortMappings))
+		for i, p := range c.Docker.PortMappings {
+			mappings[i] = map[string]interface{}{
+				"container_port": p.ContainerPort,
+				"host_port":      p.HostPort,
+				"service_port":   p.ServicePort,
+				"protocol":       p.Protocol,
+			}
+		}
+
+		m["docker"] = []map[string]interface{}{
+			map[string]interface{}{
+				"image":            c.Docker.Image,
+				"network":          c.Docker.Network,
+				"privileged":       c.Docker.Privileged,
+				"force_pull_image": c.Docker.ForcePullImage,
+				"port_mapping":     mappings,
+			},
+		}
+	}
+
+	volumes := make([]map[string]interface{}, len(c.Volumes))
+	for i, v := range c.Volumes {
+		volumes[i] = map[string]interface{}{
+			"container_path": v.ContainerPath,
+			"host_path":      v.HostPath,
+			"mode":           v.Mode,
+		}
+	}
+	m["volume"] = volumes
+
+	return []map[string]interface{}{m}
+}
+
+func flattenHealthChecks(hcs []HealthCheck) []map[string]interface{} {
+	result := make([]map[string]interface{}, len(hcs))
+	for i, hc := range hcs {
+		result[i] = map[string]interface{}{
+			"protocol":                 hc.Protocol,
+			"path":                     hc.Path,
+			"port_index":               hc.PortIndex,
+			"grace_period_seconds":     hc.GracePeriodSeconds,
+			"interval_seconds":         hc.IntervalSeconds,
+			"timeout_seconds":          hc.TimeoutSeconds,
+			"max_consecutive_failures": hc.MaxConsecutiveFailures,
+		}
+		if hc.Command != nil {
+			result[i]["command"] = hc.Command.Value
+		}
+	}
+
+	return result
+}
+
+func expandStringMap(v interface{}) map[string]string {
+	m, ok := v.(map[string]interface{})
+	if !ok || len(m) == 0 {
+		return nil
+	}
+
+	result := make(map[string]string, len(m))
+	for k, v := range m {
+		result[k] = v.(string)
+	}
+
+	return result
+}
+
+func expandStringList(v interface{}) []string {
+	l, ok := v.([]interface{})
+	if !ok || len(l) == 0 {
+		return nil
+	}
+
+	result := make([]string, len(l))
+	for i, v := range l {
+		result[i] = v.(string)
+	}
+
+	return result
+}
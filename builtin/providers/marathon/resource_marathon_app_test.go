@@ -0,0 +1,199 @@
+package marathon
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/httpapi"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestAccMarathonApp_basic(t *testing.T) {
+	var app App
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckMarathonAppDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccMarathonAppConfig_basic,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckMarathonAppExists("marathon_app.test", &app),
+					testAccCheckMarathonAppInstances(&app, 1),
+					resource.TestCheckResourceAttr(
+						"marathon_app.test", "app_id", "/tf-acc-test/app"),
+					resource.TestCheckResourceAttr(
+						"marathon_app.test", "container.0.docker.0.image", "nginx"),
+					resource.TestCheckResourceAttr(
+						"marathon_app.test", "health_check.0.path", "/"),
+				),
+			},
+			resource.TestStep{
+				Config: testAccMarathonAppConfig_update,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckMarathonAppExists("marathon_app.test", &app),
+					testAccCheckMarathonAppInstances(&app, 2),
+					resource.TestCheckResourceAttr(
+						"marathon_app.test", "instances", "2"),
+					resource.TestCheckResourceAttr(
+						"marathon_app.test", "mem", "64"),
+				),
+			},
+		},
+	})
+}
+
+func TestFlattenConstraints(t *testing.T) {
+	cases := []struct {
+		Constraints [][]string
+		Expected    []map[string]interface{}
+	}{
+		{
+			[][]string{{"hostname", "UNIQUE"}},
+			[]map[string]interface{}{
+				map[string]interface{}{
+					"attribute": "hostname",
+					"operation": "UNIQUE",
+				},
+			},
+		},
+		{
+			[][]string{{"rack_id", "CLUSTER", "rack-1"}},
+			[]map[string]interface{}{
+				map[string]interface{}{
+					"attribute": "rack_id",
+					"operation": "CLUSTER",
+					"parameter": "rack-1",
+				},
+			},
+		},
+	}
+
+	for i, tc := range cases {
+		actual := flattenConstraints(tc.Constraints)
+		if !reflect.DeepEqual(actual, tc.Expected) {
+			t.Fatalf("%d: bad: %#v", i, actual)
+		}
+	}
+}
+
+func TestAppPath(t *testing.T) {
+	cases := []struct {
+		ID       string
+		Expected string
+	}{
+		{"/app", "/v2/apps/app"},
+		{"app", "/v2/apps/app"},
+		{"/group/app", "/v2/apps/group/app"},
+	}
+
+	for i, tc := range cases {
+		if actual := appPath(tc.ID); actual != tc.Expected {
+			t.Fatalf("%d: bad: %s", i, actual)
+		}
+	}
+}
+
+func testAccCheckMarathonAppExists(n string, app *App) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No app ID is set")
+		}
+
+		client := testAccProvider.Meta().(*Client)
+		result, err := client.App(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+
+		*app = *result
+		return nil
+	}
+}
+
+func testAccCheckMarathonAppInstances(app *App, n int) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		if app.Instances != n {
+			return fmt.Errorf("bad instances: %d", app.Instances)
+		}
+		if len(app.Deployments) != 0 {
+			return fmt.Errorf("app still deploying: %#v", app.Deployments)
+		}
+
+		return nil
+	}
+}
+
+func testAccCheckMarathonAppDestroy(s *terraform.State) error {
+	client := testAccProvider.Meta().(*Client)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "marathon_app" {
+			continue
+		}
+
+		_, err := client.App(rs.Primary.ID)
+		if err == nil {
+			return fmt.Errorf("App still exists: %s", rs.Primary.ID)
+		}
+		if !httpapi.IsNotFound(err) {
+			return err
+		}
+	}
+
+	return nil
+}
+
+const testAccMarathonAppConfig_basic = `
+resource "marathon_app" "test" {
+	app_id = "/tf-acc-test/app"
+	instances = 1
+	cpus = 0.1
+	mem = 32
+
+	container {
+		docker {
+			image = "nginx"
+			network = "BRIDGE"
+			port_mapping {
+				container_port = 80
+			}
+		}
+	}
+
+	health_check {
+		path = "/"
+	}
+}
+`
+
+const testAccMarathonAppConfig_update = `
+resource "marathon_app" "test" {
+	app_id = "/tf-acc-test/app"
+	instances = 2
+	cpus = 0.1
+	mem = 64
+
+	container {
+		docker {
+			image = "nginx"
+			network = "BRIDGE"
+			port_mapping {
+				container_port = 80
+			}
+		}
+	}
+
+	health_check {
+		path = "/"
+	}
+}
+`
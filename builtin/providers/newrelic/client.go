@@ -0,0 +1,357 @@
+package newrelic
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/httpapi"
+)
+
+// Client is a client for the v2 REST API of New Relic.
+type Client struct {
+	api *httpapi.Client
+}
+
+// newClient returns a client for the API at the given URL.
+func newClient(baseURL, apiKey string) *Client {
+	return &Client{
+		api: &httpapi.Client{
+			BaseURL:      baseURL,
+			Name:         "New Relic",
+			Header:       http.Header{"X-Api-Key": []string{apiKey}},
+			ErrorMessage: errorMessage,
+		},
+	}
+}
+
+// notFound returns the error for an object that isn't in a list of the
+// API, which has no way to get a single object.
+func notFound(kind string, id int) error {
+	return &httpapi.StatusError{
+		Code:    http.StatusNotFound,
+		Message: fmt.Sprintf("%s %d not found", kind, id),
+	}
+}
+
+// Policy is an alert policy, which is a group of conditions whose
+// violations are reported to the same notification channels.
+type Policy struct {
+	ID                 int    `json:"id,omitempty"`
+	Name               string `json:"name"`
+	IncidentPreference string `json:"incident_preference,omitempty"`
+	CreatedAt          int64  `json:"created_at,omitempty"`
+	UpdatedAt          int64  `json:"updated_at,omitempty"`
+}
+
+// Condition is an alert condition, which is violated when a metric of
+// its entities, such as the APM applications, crosses a threshold.
+type Condition struct {
+	ID             int          `json:"id,omitempty"`
+	Type           string       `json:"type"`
+	Name           string       `json:"name"`
+	Enabled        bool         `json:"enabled"`
+	Entities       []string     `json:"entities"`
+	Metric         string       `json:"metric"`
+	ConditionScope string       `json:"condition_scope,omitempty"`
+	RunbookURL     string       `json:"runbook_url,omitempty"`
+	Terms          []Term       `json:"terms"`
+	UserDefined    *UserDefined `json:"user_defined,omitempty"`
+}
+
+// Term is a threshold of a condition. The API takes and returns all its
+// fields as strings.
+type Term struct {
+	Duration     string `json:"duration"`
+	Operator     string `json:"operator"`
+	Priority     string `json:"priority"`
+	Threshold    string `json:"threshold"`
+	TimeFunction string `json:"time_function"`
+}
+
+// UserDefined is the custom metric of a condition whose metric is
+// "user_defined".
+type UserDefined struct {
+	Metric        string `json:"metric"`
+	ValueFunction string `json:"value_function"`
+}
+
+// Channel is a notification channel, such as an email address or a Slack
+// channel, which is notified of the violations of the policies it is
+// linked to.
+type Channel struct {
+	ID            int                    `json:"id,omitempty"`
+	Name          string                 `json:"name"`
+	Type          string                 `json:"type"`
+	Configuration map[string]interface{} `json:"configuration"`
+	Links         *ChannelLinks          `json:"links,omitempty"`
+}
+
+// ChannelLinks are the objects that a channel is linked to.
+type ChannelLinks struct {
+	PolicyIDs []int `json:"policy_ids"`
+}
+
+// Application is an application monitored by APM.
+type Application struct {
+	ID       int    `json:"id"`
+	Name     string `json:"name"`
+	Language string `json:"language"`
+}
+
+// Policy returns the policy with the given ID.
+func (c *Client) Policy(id int) (*Policy, error) {
+	var result *Policy
+	err := c.list("/alerts_policies.json", nil, func(raw []byte) error {
+		var page struct {
+			Policies []Policy `json:"policies"`
+		}
+		if err := json.Unmarshal(raw, &page); err != nil {
+			return err
+		}
+		for i, p := range page.Policies {
+			if p.ID == id {
+				result = &page.Policies[i]
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if result == nil {
+		return nil, notFound("policy", id)
+	}
+
+	return result, nil
+}
+
+// CreatePolicy creates the policy.
+func (c *Client) CreatePolicy(p *Policy) (*Policy, error) {
+	var result struct {
+		Policy Policy `json:"policy"`
+	}
+	in := map[string]interface{}{"policy": p}
+	if err := c.do("POST", "/alerts_policies.json", in, &result); err != nil {
+		return nil, err
+	}
+
+	return &result.Policy, nil
+}
+
+// UpdatePolicy changes the name and incident preference of the policy.
+func (c *Client) UpdatePolicy(p *Policy) (*Policy, error) {
+	var result struct {
+		Policy Policy `json:"policy"`
+	}
+	in := map[string]interface{}{"policy": p}
+	path := fmt.Sprintf("/alerts_policies/%d.json", p.ID)
+	if err := c.do("PUT", path, in, &result); err != nil {
+		return nil, err
+	}
+
+	return &result.Policy, nil
+}
+
+// DeletePolicy deletes the policy with the given ID, along with its
+// conditions.
+func (c *Client) DeletePolicy(id int) error {
+	return c.do("DELETE", fmt.Sprintf("/alerts_policies/%d.json", id), nil, nil)
+}
+
+// Condition returns the condition with the given ID of the policy.
+func (c *Client) Condition(policyID, id int) (*Condition, error) {
+	var result *Condition
+	params := url.Values{"policy_id": {strconv.Itoa(policyID)}}
+	err := c.list("/alerts_conditions.json", params, func(raw []byte) error {
+		var page struct {
+			Conditions []Condition `json:"conditions"`
+		}
+		if err := json.Unmarshal(raw, &page); err != nil {
+			return err
+		}
+		for i, cond := range page.Conditions {
+			if cond.ID == id {
+				result = &page.Conditions[i]
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if result == nil {
+		return nil, notFound("condition", id)
+	}
+
+	return result, nil
+}
+
+// CreateCondition creates the condition in the policy with the given ID.
+func (c *Client) CreateCondition(policyID int, cond *Condition) (*Condition, error) {
+	var result struct {
+		Condition Condition `json:"condition"`
+	}
+	in := map[string]interface{}{"condition": cond}
+	path := fmt.Sprintf("/alerts_conditions/policies/%d.json", policyID)
+	if err := c.do("POST", path, in, &result); err != nil {
+		return nil, err
+	}
+
+	return &result.Condition, nil
+}
+
+// UpdateCondition replaces the condition with the same ID.
+func (c *Client) UpdateCondition(cond *Condition) (*Condition, error) {
+	var result struct {
+		Condition Condition `json:"condition"`
+	}
+	in := map[string]interface{}{"condition": cond}
+	path := fmt.Sprintf("/alerts_conditions/%d.json", cond.ID)
+	if err := c.do("PUT", path, in, &result); err != nil {
+		return nil, err
+	}
+
+	return &result.Condition, nil
+}
+
+// DeleteCondition deletes the condition with the given ID.
+func (c *Client) DeleteCondition(id int) error {
+	return c.do("DELETE", fmt.Sprintf("/alerts_conditions/%d.json", id), nil, nil)
+}
+
+// Channel returns the channel with the given ID.
+func (c *Client) Channel(id int) (*Channel, error) {
+	var result *Channel
+	err := c.list("/alerts_channels.json", nil, func(raw []byte) error {
+		var page struct {
+			Channels []Channel `json:"channels"`
+		}
+		if err := json.Unmarshal(raw, &page); err != nil {
+			return err
+		}
+		for i, ch := range page.Channels {
+			if ch.ID == id {
+				result = &page.Channels[i]
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if result == nil {
+		return nil, notFound("channel", id)
+	}
+
+	return result, nil
+}
+
+// CreateChannel creates the channel.
+func (c *Client) CreateChannel(ch *Channel) (*Channel, error) {
+	// The created channel is returned in a list
+	var result struct {
+		Channels []Channel `json:"channels"`
+	}
+	in := map[string]interface{}{"channel": ch}
+	if err := c.do("POST", "/alerts_channels.json", in, &result); err != nil {
+		return nil, err
+	}
+	if len(result.Channels) == 0 {
+		return nil, fmt.Errorf("no channel in the answer")
+	}
+
+	return &result.Channels[0], nil
+}
+
+// DeleteChannel deletes the channel with the given ID.
+func (c *Client) DeleteChannel(id int) error {
+	return c.do("DELETE", fmt.Sprintf("/alerts_channels/%d.json", id), nil, nil)
+}
+
+// AddPolicyChannel links the channel to the policy, so that it is
+// notified of its violations.
+func (c *Client) AddPolicyChannel(policyID, channelID int) error {
+	params := url.Values{
+		"policy_id":   {strconv.Itoa(policyID)},
+		"channel_ids": {strconv.Itoa(channelID)},
+	}
+	return c.do("PUT", "/alerts_policy_channels.json?"+params.Encode(), nil, nil)
+}
+
+// RemovePolicyChannel unlinks the channel from the policy.
+func (c *Client) RemovePolicyChannel(policyID, channelID int) error {
+	params := url.Values{
+		"policy_id":  {strconv.Itoa(policyID)},
+		"channel_id": {strconv.Itoa(channelID)},
+	}
+	return c.do("DELETE", "/alerts_policy_channels.json?"+params.Encode(), nil, nil)
+}
+
+// Applications returns the APM applications with the given name, which
+// the API matches as a substring.
+func (c *Client) Applications(name string) ([]Application, error) {
+	var result []Application
+	params := url.Values{"filter[name]": {name}}
+	err := c.list("/applications.json", params, func(raw []byte) error {
+		var page struct {
+			Applications []Application `json:"applications"`
+		}
+		if err := json.Unmarshal(raw, &page); err != nil {
+			return err
+		}
+		result = append(result, page.Applications...)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func (c *Client) do(method, path string, in, out interface{}) error {
+	return c.api.Do(method, path, in, out)
+}
+
+// list gets all the pages of a list of the API, which are linked to each
+// other by the Link header, calling add with the body of each page.
+func (c *Client) list(path string, params url.Values, add func([]byte) error) error {
+	for page := 1; ; page++ {
+		query := url.Values{}
+		for k, v := range params {
+			query[k] = v
+		}
+		query.Set("page", strconv.Itoa(page))
+
+		raw, header, err := c.api.Send("GET", path+"?"+query.Encode(), "", nil)
+		if err != nil {
+			return err
+		}
+		if err := add(raw); err != nil {
+			return err
+		}
+
+		if !strings.Contains(header.Get("Link"), `rel="next"`) {
+			return nil
+		}
+	}
+}
+
+// errorMessage returns the title of the error in the body of a failed
+// answer.
+func errorMessage(body []byte) string {
+	var status struct {
+		Error struct {
+			Title string `json:"title"`
+		} `json:"error"`
+	}
+	if err := json.Unmarshal(body, &status); err != nil {
+		return ""
+	}
+
+	return status.Error.Title
+}
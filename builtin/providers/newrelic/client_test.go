@@ -0,0 +1,93 @@
+package newrelic
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/httpapi"
+)
+
+func testClient(t *testing.T, h http.HandlerFunc) (*Client, func()) {
+	server := httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			if r.Header.Get("X-Api-Key") != "key" {
+				t.Errorf("bad auth header: %#v", r.Header)
+			}
+			h(w, r)
+		}))
+
+	return newClient(server.URL, "key"), server.Close
+}
+
+func TestClientPolicy_pages(t *testing.T) {
+	client, done := testClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/alerts_policies.json" {
+			t.Errorf("bad path: %s", r.URL.Path)
+		}
+		switch r.URL.Query().Get("page") {
+		case "1":
+			w.Header().Set("Link", `<https://api.newrelic.com/v2/alerts_policies.json?page=2>; rel="next"`)
+			fmt.Fprint(w, `{"policies":[{"id":1,"name":"one"}]}`)
+		case "2":
+			fmt.Fprint(w, `{"policies":[{"id":2,"name":"two","incident_preference":"PER_CONDITION"}]}`)
+		default:
+			t.Errorf("bad page: %s", r.URL.RawQuery)
+		}
+	})
+	defer done()
+
+	p, err := client.Policy(2)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if p.Name != "two" || p.IncidentPreference != "PER_CONDITION" {
+		t.Fatalf("bad: %#v", p)
+	}
+
+	if _, err := client.Policy(3); !httpapi.IsNotFound(err) {
+		t.Fatalf("should be not found: %s", err)
+	}
+}
+
+func TestClientCreateChannel(t *testing.T) {
+	client, done := testClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "POST" || r.URL.Path != "/alerts_channels.json" {
+			t.Errorf("bad request: %s %s", r.Method, r.URL.Path)
+		}
+		if v := r.Header.Get("Content-Type"); v != "application/json" {
+			t.Errorf("bad content type: %s", v)
+		}
+		fmt.Fprint(w, `{"channels":[{"id":42,"name":"ops","type":"email"}]}`)
+	})
+	defer done()
+
+	ch, err := client.CreateChannel(&Channel{
+		Name:          "ops",
+		Type:          "email",
+		Configuration: map[string]interface{}{"recipients": "ops@example.com"},
+	})
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if ch.ID != 42 {
+		t.Fatalf("bad: %#v", ch)
+	}
+}
+
+func TestClientDo_error(t *testing.T) {
+	client, done := testClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprint(w, `{"error":{"title":"Not found"}}`)
+	})
+	defer done()
+
+	err := client.DeleteCondition(12)
+	if !httpapi.IsNotFound(err) {
+		t.Fatalf("should be not found: %s", err)
+	}
+	if err.Error() != "404: Not found" {
+		t.Fatalf("bad: %s", err)
+	}
+}
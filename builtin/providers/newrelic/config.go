@@ -0,0 +1,24 @@
+package newrelic
+
+import (
+	"fmt"
+	"log"
+	"net/url"
+	"strings"
+)
+
+type Config struct {
+	APIKey string
+	APIURL string
+}
+
+// Client returns a new client for the New Relic API.
+func (c *Config) Client() (*Client, error) {
+	u, err := url.Parse(c.APIURL)
+	if err != nil || u.Scheme == "" || u.Host == "" {
+		return nil, fmt.Errorf("Invalid New Relic API URL %q", c.APIURL)
+	}
+
+	log.Printf("[INFO] New Relic client configured for %s", c.APIURL)
+	return newClient(strings.TrimSuffix(c.APIURL, "/"), c.APIKey), nil
+}
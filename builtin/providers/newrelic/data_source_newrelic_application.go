@@ -0,0 +1,58 @@
+package newrelic
+
+import (
+	"fmt"
+	"log"
+	"strconv"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+// dataSourceNewRelicApplication is the data source that looks up an APM
+// application by its name, so that the conditions of its alerts can be
+// set up along with the service it monitors.
+func dataSourceNewRelicApplication() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceNewRelicApplicationRead,
+
+		Schema: map[string]*schema.Schema{
+			"name": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+			},
+
+			"language": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func dataSourceNewRelicApplicationRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Client)
+
+	name := d.Get("name").(string)
+	log.Printf("[DEBUG] Reading New Relic applications named %s", name)
+	apps, err := client.Applications(name)
+	if err != nil {
+		return fmt.Errorf("Error reading New Relic applications: %s", err)
+	}
+
+	// The API matches the name as a substring, so only an exact match is
+	// the application.
+	var app *Application
+	for i, a := range apps {
+		if a.Name == name {
+			app = &apps[i]
+		}
+	}
+	if app == nil {
+		return fmt.Errorf("No New Relic application named %q", name)
+	}
+
+	d.SetId(strconv.Itoa(app.ID))
+	d.Set("language", app.Language)
+
+	return nil
+}
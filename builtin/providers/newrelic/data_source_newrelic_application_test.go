@@ -0,0 +1,41 @@
+package newrelic
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestAccNewRelicApplicationDataSource_basic(t *testing.T) {
+	app := os.Getenv("NEWRELIC_APPLICATION")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: fmt.Sprintf(testAccNewRelicApplicationDataSourceConfig, app),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"data.newrelic_application.app", "name", app),
+					func(s *terraform.State) error {
+						rs := s.RootModule().Resources["data.newrelic_application.app"]
+						if rs == nil || rs.Primary.ID == "" {
+							return fmt.Errorf("No application ID is set")
+						}
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
+
+const testAccNewRelicApplicationDataSourceConfig = `
+data "newrelic_application" "app" {
+	name = "%s"
+}
+`
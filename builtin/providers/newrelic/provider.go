@@ -0,0 +1,52 @@
+package newrelic
+
+import (
+	"log"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+// Provider returns a terraform.ResourceProvider.
+func Provider() terraform.ResourceProvider {
+	return &schema.Provider{
+		Schema: map[string]*schema.Schema{
+			"api_key": &schema.Schema{
+				Type:        schema.TypeString,
+				Required:    true,
+				DefaultFunc: schema.EnvDefaultFunc("NEWRELIC_API_KEY", nil),
+				Description: "The REST API key of the New Relic account.",
+			},
+
+			"api_url": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("NEWRELIC_API_URL", "https://api.newrelic.com/v2"),
+				Description: "The URL of the v2 REST API of New Relic.",
+			},
+		},
+
+		DataSourcesMap: map[string]*schema.Resource{
+			"newrelic_application": dataSourceNewRelicApplication(),
+		},
+
+		ResourcesMap: map[string]*schema.Resource{
+			"newrelic_alert_channel":        resourceNewRelicAlertChannel(),
+			"newrelic_alert_condition":      resourceNewRelicAlertCondition(),
+			"newrelic_alert_policy":         resourceNewRelicAlertPolicy(),
+			"newrelic_alert_policy_channel": resourceNewRelicAlertPolicyChannel(),
+		},
+
+		ConfigureFunc: providerConfigure,
+	}
+}
+
+func providerConfigure(d *schema.ResourceData) (interface{}, error) {
+	config := Config{
+		APIKey: d.Get("api_key").(string),
+		APIURL: d.Get("api_url").(string),
+	}
+
+	log.Println("[INFO] Initializing New Relic client")
+	return config.Client()
+}
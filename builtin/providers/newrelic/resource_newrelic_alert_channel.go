@@ -0,0 +1,128 @@
+package newrelic
+
+import (
+	"fmt"
+	"log"
+	"strconv"
+
+	"github.com/hashicorp/terraform/helper/httpapi"
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+// channelTypes are the types of notification channels.
+var channelTypes = []string{
+	"campfire",
+	"email",
+	"hipchat",
+	"opsgenie",
+	"pagerduty",
+	"slack",
+	"user",
+	"victorops",
+	"webhook",
+}
+
+func resourceNewRelicAlertChannel() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceNewRelicAlertChannelCreate,
+		Read:   resourceNewRelicAlertChannelRead,
+		Delete: resourceNewRelicAlertChannelDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"name": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"type": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			// The API has no way to change a channel, and doesn't return
+			// the secrets of its configuration, such as the keys of the
+			// services that it notifies, so it is only set from the
+			// configuration.
+			"configuration": &schema.Schema{
+				Type:     schema.TypeMap,
+				Required: true,
+				ForceNew: true,
+			},
+		},
+	}
+}
+
+func resourceNewRelicAlertChannelCreate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Client)
+
+	ch := &Channel{
+		Name:          d.Get("name").(string),
+		Type:          d.Get("type").(string),
+		Configuration: d.Get("configuration").(map[string]interface{}),
+	}
+	if err := validateOneOf("channel type", ch.Type, channelTypes); err != nil {
+		return err
+	}
+
+	log.Printf("[DEBUG] Creating New Relic alert channel: %s", ch.Name)
+	ch, err := client.CreateChannel(ch)
+	if err != nil {
+		return fmt.Errorf(
+			"Error creating New Relic alert channel %s: %s", d.Get("name"), err)
+	}
+
+	d.SetId(strconv.Itoa(ch.ID))
+	log.Printf("[INFO] New Relic alert channel created: %s", d.Id())
+
+	return resourceNewRelicAlertChannelRead(d, meta)
+}
+
+func resourceNewRelicAlertChannelRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Client)
+
+	id, err := parseId(d.Id())
+	if err != nil {
+		return err
+	}
+
+	ch, err := client.Channel(id)
+	if err != nil {
+		if httpapi.IsNotFound(err) {
+			d.SetId("")
+			return nil
+		}
+		return fmt.Errorf(
+			"Error reading New Relic alert channel %s: %s", d.Id(), err)
+	}
+
+	d.Set("name", ch.Name)
+	d.Set("type", ch.Type)
+
+	return nil
+}
+
+func resourceNewRelicAlertChannelDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Client)
+
+	id, err := parseId(d.Id())
+	if err != nil {
+		return err
+	}
+
+	log.Printf("[INFO] Deleting New Relic alert channel: %s", d.Id())
+	if err := client.DeleteChannel(id); err != nil {
+		if httpapi.IsNotFound(err) {
+			return nil
+		}
+		return fmt.Errorf(
+			"Error deleting New Relic alert channel %s: %s", d.Id(), err)
+	}
+
+	d.SetId("")
+	return nil
+}
@@ -0,0 +1,141 @@
+package newrelic
+
+import (
+	"fmt"
+	"strconv"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/httpapi"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestAccNewRelicAlertChannel_basic(t *testing.T) {
+	var ch Channel
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckNewRelicAlertChannelDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccNewRelicAlertChannelConfig_basic,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckNewRelicAlertChannelExists(
+						"newrelic_alert_channel.test", &ch),
+					resource.TestCheckResourceAttr(
+						"newrelic_alert_channel.test", "type", "email"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccNewRelicAlertPolicyChannel_basic(t *testing.T) {
+	var ch Channel
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckNewRelicAlertChannelDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccNewRelicAlertPolicyChannelConfig_basic,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckNewRelicAlertChannelExists(
+						"newrelic_alert_channel.test", &ch),
+					func(*terraform.State) error {
+						if ch.Links == nil || len(ch.Links.PolicyIDs) != 1 {
+							return fmt.Errorf("bad links: %#v", ch.Links)
+						}
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckNewRelicAlertChannelExists(n string, ch *Channel) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No channel ID is set")
+		}
+
+		id, err := strconv.Atoi(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+
+		client := testAccProvider.Meta().(*Client)
+		result, err := client.Channel(id)
+		if err != nil {
+			return err
+		}
+
+		*ch = *result
+		return nil
+	}
+}
+
+func testAccCheckNewRelicAlertChannelDestroy(s *terraform.State) error {
+	client := testAccProvider.Meta().(*Client)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "newrelic_alert_channel" {
+			continue
+		}
+
+		id, err := strconv.Atoi(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+
+		_, err = client.Channel(id)
+		if err == nil {
+			return fmt.Errorf("Channel still exists: %s", rs.Primary.ID)
+		}
+		if !httpapi.IsNotFound(err) {
+			return err
+		}
+	}
+
+	return nil
+}
+
+const testAccNewRelicAlertChannelConfig_basic = `
+resource "newrelic_alert_channel" "test" {
+	name = "terraform-acc-test"
+	type = "email"
+
+	configuration = {
+		recipients = "terraform-acc-test@example.com"
+		include_json_attachment = "1"
+	}
+}
+`
+
+const testAccNewRelicAlertPolicyChannelConfig_basic = `
+resource "newrelic_alert_policy" "test" {
+	name = "terraform-acc-test"
+}
+
+resource "newrelic_alert_channel" "test" {
+	name = "terraform-acc-test"
+	type = "email"
+
+	configuration = {
+		recipients = "terraform-acc-test@example.com"
+	}
+}
+
+resource "newrelic_alert_policy_channel" "test" {
+	policy_id = "${newrelic_alert_policy.test.id}"
+	channel_id = "${newrelic_alert_channel.test.id}"
+}
+`
@@ -0,0 +1,264 @@
+package newrelic
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform/helper/httpapi"
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func resourceNewRelicAlertCondition() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceNewRelicAlertConditionCreate,
+		Read:   resourceNewRelicAlertConditionRead,
+		Update: resourceNewRelicAlertConditionUpdate,
+		Delete: resourceNewRelicAlertConditionDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"policy_id": &schema.Schema{
+				Type:     schema.TypeInt,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"name": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+			},
+
+			"type": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"entities": &schema.Schema{
+				Type:     schema.TypeList,
+				Required: true,
+				Elem:     &schema.Schema{Type: schema.TypeInt},
+			},
+
+			"metric": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+			},
+
+			"condition_scope": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"runbook_url": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"enabled": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+
+			"term": &schema.Schema{
+				Type:     schema.TypeList,
+				Required: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"duration": &schema.Schema{
+							Type:     schema.TypeInt,
+							Required: true,
+						},
+
+						"operator": &schema.Schema{
+							Type:     schema.TypeString,
+							Optional: true,
+							Default:  "equal",
+						},
+
+						"priority": &schema.Schema{
+							Type:     schema.TypeString,
+							Optional: true,
+							Default:  "critical",
+						},
+
+						"threshold": &schema.Schema{
+							Type:     schema.TypeFloat,
+							Required: true,
+						},
+
+						"time_function": &schema.Schema{
+							Type:     schema.TypeString,
+							Required: true,
+						},
+					},
+				},
+			},
+
+			"user_defined_metric": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"user_defined_value_function": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+		},
+	}
+}
+
+func resourceNewRelicAlertConditionCreate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Client)
+
+	cond, err := expandCondition(d)
+	if err != nil {
+		return err
+	}
+
+	policyID := d.Get("policy_id").(int)
+	log.Printf("[DEBUG] Creating New Relic alert condition: %s", cond.Name)
+	cond, err = client.CreateCondition(policyID, cond)
+	if err != nil {
+		return fmt.Errorf(
+			"Error creating New Relic alert condition %s: %s", d.Get("name"), err)
+	}
+
+	d.SetId(formatIdPair(policyID, cond.ID))
+	log.Printf("[INFO] New Relic alert condition created: %s", d.Id())
+
+	return resourceNewRelicAlertConditionRead(d, meta)
+}
+
+func resourceNewRelicAlertConditionRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Client)
+
+	policyID, id, err := parseIdPair(d.Id())
+	if err != nil {
+		return err
+	}
+
+	cond, err := client.Condition(policyID, id)
+	if err != nil {
+		if httpapi.IsNotFound(err) {
+			d.SetId("")
+			return nil
+		}
+		return fmt.Errorf(
+			"Error reading New Relic alert condition %s: %s", d.Id(), err)
+	}
+
+	entities, err := flattenEntities(cond.Entities)
+	if err != nil {
+		return err
+	}
+	terms, err := flattenTerms(cond.Terms)
+	if err != nil {
+		return err
+	}
+
+	d.Set("policy_id", policyID)
+	d.Set("name", cond.Name)
+	d.Set("type", cond.Type)
+	d.Set("metric", cond.Metric)
+	d.Set("condition_scope", cond.ConditionScope)
+	d.Set("runbook_url", cond.RunbookURL)
+	d.Set("enabled", cond.Enabled)
+	if err := d.Set("entities", entities); err != nil {
+		return err
+	}
+	if err := d.Set("term", terms); err != nil {
+		return err
+	}
+
+	if cond.UserDefined != nil {
+		d.Set("user_defined_metric", cond.UserDefined.Metric)
+		d.Set("user_defined_value_function", cond.UserDefined.ValueFunction)
+	} else {
+		d.Set("user_defined_metric", "")
+		d.Set("user_defined_value_function", "")
+	}
+
+	return nil
+}
+
+func resourceNewRelicAlertConditionUpdate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Client)
+
+	cond, err := expandCondition(d)
+	if err != nil {
+		return err
+	}
+	if _, cond.ID, err = parseIdPair(d.Id()); err != nil {
+		return err
+	}
+
+	log.Printf("[DEBUG] Updating New Relic alert condition: %s", d.Id())
+	if _, err := client.UpdateCondition(cond); err != nil {
+		return fmt.Errorf(
+			"Error updating New Relic alert condition %s: %s", d.Id(), err)
+	}
+
+	return resourceNewRelicAlertConditionRead(d, meta)
+}
+
+func resourceNewRelicAlertConditionDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Client)
+
+	_, id, err := parseIdPair(d.Id())
+	if err != nil {
+		return err
+	}
+
+	log.Printf("[INFO] Deleting New Relic alert condition: %s", d.Id())
+	if err := client.DeleteCondition(id); err != nil {
+		if httpapi.IsNotFound(err) {
+			return nil
+		}
+		return fmt.Errorf(
+			"Error deleting New Relic alert condition %s: %s", d.Id(), err)
+	}
+
+	d.SetId("")
+	return nil
+}
+
+// expandCondition returns the condition of the resource, without its ID.
+func expandCondition(d *schema.ResourceData) (*Condition, error) {
+	cond := &Condition{
+		Type:           d.Get("type").(string),
+		Name:           d.Get("name").(string),
+		Enabled:        d.Get("enabled").(bool),
+		Entities:       expandEntities(d.Get("entities").([]interface{})),
+		Metric:         d.Get("metric").(string),
+		ConditionScope: d.Get("condition_scope").(string),
+		RunbookURL:     d.Get("runbook_url").(string),
+	}
+
+	if err := validateOneOf("condition type", cond.Type, conditionTypes); err != nil {
+		return nil, err
+	}
+
+	terms, err := expandTerms(d.Get("term").([]interface{}))
+	if err != nil {
+		return nil, err
+	}
+	cond.Terms = terms
+
+	if cond.Metric == "user_defined" {
+		cond.UserDefined = &UserDefined{
+			Metric:        d.Get("user_defined_metric").(string),
+			ValueFunction: d.Get("user_defined_value_function").(string),
+		}
+		if cond.UserDefined.Metric == "" || cond.UserDefined.ValueFunction == "" {
+			return nil, fmt.Errorf(
+				"user_defined_metric and user_defined_value_function are " +
+					"required for the user_defined metric")
+		}
+	}
+
+	return cond, nil
+}
@@ -0,0 +1,163 @@
+package newrelic
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/httpapi"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestAccNewRelicAlertCondition_basic(t *testing.T) {
+	var cond Condition
+	app := os.Getenv("NEWRELIC_APPLICATION")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckNewRelicAlertConditionDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: fmt.Sprintf(testAccNewRelicAlertConditionConfig_basic, app),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckNewRelicAlertConditionExists(
+						"newrelic_alert_condition.test", &cond),
+					resource.TestCheckResourceAttr(
+						"newrelic_alert_condition.test", "term.#", "1"),
+					resource.TestCheckResourceAttr(
+						"newrelic_alert_condition.test", "term.0.threshold", "0.75"),
+				),
+			},
+			resource.TestStep{
+				Config: fmt.Sprintf(testAccNewRelicAlertConditionConfig_update, app),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckNewRelicAlertConditionExists(
+						"newrelic_alert_condition.test", &cond),
+					resource.TestCheckResourceAttr(
+						"newrelic_alert_condition.test", "term.#", "2"),
+					func(*terraform.State) error {
+						if cond.Terms[1].Priority != "warning" {
+							return fmt.Errorf("bad terms: %#v", cond.Terms)
+						}
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckNewRelicAlertConditionExists(n string, cond *Condition) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No condition ID is set")
+		}
+
+		policyID, id, err := parseIdPair(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+
+		client := testAccProvider.Meta().(*Client)
+		result, err := client.Condition(policyID, id)
+		if err != nil {
+			return err
+		}
+
+		*cond = *result
+		return nil
+	}
+}
+
+func testAccCheckNewRelicAlertConditionDestroy(s *terraform.State) error {
+	client := testAccProvider.Meta().(*Client)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "newrelic_alert_condition" {
+			continue
+		}
+
+		policyID, id, err := parseIdPair(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+
+		_, err = client.Condition(policyID, id)
+		if err == nil {
+			return fmt.Errorf("Condition still exists: %s", rs.Primary.ID)
+		}
+		if !httpapi.IsNotFound(err) {
+			return err
+		}
+	}
+
+	return nil
+}
+
+const testAccNewRelicAlertConditionConfig_basic = `
+data "newrelic_application" "app" {
+	name = "%s"
+}
+
+resource "newrelic_alert_policy" "test" {
+	name = "terraform-acc-test"
+}
+
+resource "newrelic_alert_condition" "test" {
+	policy_id = "${newrelic_alert_policy.test.id}"
+	name = "terraform-acc-test"
+	type = "apm_app_metric"
+	entities = ["${data.newrelic_application.app.id}"]
+	metric = "apdex"
+	condition_scope = "application"
+
+	term {
+		duration = 5
+		operator = "below"
+		threshold = "0.75"
+		time_function = "all"
+	}
+}
+`
+
+const testAccNewRelicAlertConditionConfig_update = `
+data "newrelic_application" "app" {
+	name = "%s"
+}
+
+resource "newrelic_alert_policy" "test" {
+	name = "terraform-acc-test"
+}
+
+resource "newrelic_alert_condition" "test" {
+	policy_id = "${newrelic_alert_policy.test.id}"
+	name = "terraform-acc-test"
+	type = "apm_app_metric"
+	entities = ["${data.newrelic_application.app.id}"]
+	metric = "apdex"
+	condition_scope = "application"
+	runbook_url = "https://example.com/runbook"
+
+	term {
+		duration = 5
+		operator = "below"
+		threshold = "0.75"
+		time_function = "all"
+	}
+
+	term {
+		duration = 10
+		operator = "below"
+		priority = "warning"
+		threshold = "0.9"
+		time_function = "all"
+	}
+}
+`
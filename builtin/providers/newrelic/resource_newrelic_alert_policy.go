@@ -0,0 +1,148 @@
+package newrelic
+
+import (
+	"fmt"
+	"log"
+	"strconv"
+
+	"github.com/hashicorp/terraform/helper/httpapi"
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func resourceNewRelicAlertPolicy() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceNewRelicAlertPolicyCreate,
+		Read:   resourceNewRelicAlertPolicyRead,
+		Update: resourceNewRelicAlertPolicyUpdate,
+		Delete: resourceNewRelicAlertPolicyDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"name": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+			},
+
+			"incident_preference": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  "PER_POLICY",
+			},
+
+			"created_at": &schema.Schema{
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+
+			"updated_at": &schema.Schema{
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceNewRelicAlertPolicyCreate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Client)
+
+	p, err := expandPolicy(d)
+	if err != nil {
+		return err
+	}
+
+	log.Printf("[DEBUG] Creating New Relic alert policy: %s", p.Name)
+	p, err = client.CreatePolicy(p)
+	if err != nil {
+		return fmt.Errorf(
+			"Error creating New Relic alert policy %s: %s", d.Get("name"), err)
+	}
+
+	d.SetId(strconv.Itoa(p.ID))
+	log.Printf("[INFO] New Relic alert policy created: %s", d.Id())
+
+	return resourceNewRelicAlertPolicyRead(d, meta)
+}
+
+func resourceNewRelicAlertPolicyRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Client)
+
+	id, err := parseId(d.Id())
+	if err != nil {
+		return err
+	}
+
+	p, err := client.Policy(id)
+	if err != nil {
+		if httpapi.IsNotFound(err) {
+			d.SetId("")
+			return nil
+		}
+		return fmt.Errorf(
+			"Error reading New Relic alert policy %s: %s", d.Id(), err)
+	}
+
+	d.Set("name", p.Name)
+	d.Set("incident_preference", p.IncidentPreference)
+	d.Set("created_at", int(p.CreatedAt))
+	d.Set("updated_at", int(p.UpdatedAt))
+
+	return nil
+}
+
+func resourceNewRelicAlertPolicyUpdate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Client)
+
+	p, err := expandPolicy(d)
+	if err != nil {
+		return err
+	}
+	if p.ID, err = parseId(d.Id()); err != nil {
+		return err
+	}
+
+	log.Printf("[DEBUG] Updating New Relic alert policy: %s", d.Id())
+	if _, err := client.UpdatePolicy(p); err != nil {
+		return fmt.Errorf(
+			"Error updating New Relic alert policy %s: %s", d.Id(), err)
+	}
+
+	return resourceNewRelicAlertPolicyRead(d, meta)
+}
+
+func resourceNewRelicAlertPolicyDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Client)
+
+	id, err := parseId(d.Id())
+	if err != nil {
+		return err
+	}
+
+	log.Printf("[INFO] Deleting New Relic alert policy: %s", d.Id())
+	if err := client.DeletePolicy(id); err != nil {
+		if httpapi.IsNotFound(err) {
+			return nil
+		}
+		return fmt.Errorf(
+			"Error deleting New Relic alert policy %s: %s", d.Id(), err)
+	}
+
+	d.SetId("")
+	return nil
+}
+
+// expandPolicy returns the policy of the resource, without its ID.
+func expandPolicy(d *schema.ResourceData) (*Policy, error) {
+	p := &Policy{
+		Name:               d.Get("name").(string),
+		IncidentPreference: d.Get("incident_preference").(string),
+	}
+
+	err := validateOneOf("incident preference", p.IncidentPreference, incidentPreferences)
+	if err != nil {
+		return nil, err
+	}
+
+	return p, nil
+}
@@ -0,0 +1,112 @@
+package newrelic
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform/helper/httpapi"
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func resourceNewRelicAlertPolicyChannel() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceNewRelicAlertPolicyChannelCreate,
+		Read:   resourceNewRelicAlertPolicyChannelRead,
+		Delete: resourceNewRelicAlertPolicyChannelDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"policy_id": &schema.Schema{
+				Type:     schema.TypeInt,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"channel_id": &schema.Schema{
+				Type:     schema.TypeInt,
+				Required: true,
+				ForceNew: true,
+			},
+		},
+	}
+}
+
+func resourceNewRelicAlertPolicyChannelCreate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Client)
+
+	policyID := d.Get("policy_id").(int)
+	channelID := d.Get("channel_id").(int)
+
+	log.Printf("[DEBUG] Adding New Relic alert channel %d to policy %d", channelID, policyID)
+	if err := client.AddPolicyChannel(policyID, channelID); err != nil {
+		return fmt.Errorf(
+			"Error adding New Relic alert channel %d to policy %d: %s",
+			channelID, policyID, err)
+	}
+
+	d.SetId(formatIdPair(policyID, channelID))
+	log.Printf("[INFO] New Relic alert policy channel created: %s", d.Id())
+
+	return resourceNewRelicAlertPolicyChannelRead(d, meta)
+}
+
+func resourceNewRelicAlertPolicyChannelRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Client)
+
+	policyID, channelID, err := parseIdPair(d.Id())
+	if err != nil {
+		return err
+	}
+
+	ch, err := client.Channel(channelID)
+	if err != nil {
+		if httpapi.IsNotFound(err) {
+			d.SetId("")
+			return nil
+		}
+		return fmt.Errorf(
+			"Error reading New Relic alert channel %d: %s", channelID, err)
+	}
+
+	found := false
+	if ch.Links != nil {
+		for _, id := range ch.Links.PolicyIDs {
+			if id == policyID {
+				found = true
+			}
+		}
+	}
+	if !found {
+		d.SetId("")
+		return nil
+	}
+
+	d.Set("policy_id", policyID)
+	d.Set("channel_id", channelID)
+
+	return nil
+}
+
+func resourceNewRelicAlertPolicyChannelDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Client)
+
+	policyID, channelID, err := parseIdPair(d.Id())
+	if err != nil {
+		return err
+	}
+
+	log.Printf("[INFO] Removing New Relic alert channel %d from policy %d", channelID, policyID)
+	if err := client.RemovePolicyChannel(policyID, channelID); err != nil {
+		if httpapi.IsNotFound(err) {
+			return nil
+		}
+		return fmt.Errorf(
+			"Error removing New Relic alert channel %d from policy %d: %s",
+			channelID, policyID, err)
+	}
+
+	d.SetId("")
+	return nil
+}
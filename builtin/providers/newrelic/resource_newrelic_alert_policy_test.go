@@ -0,0 +1,110 @@
+package newrelic
+
+import (
+	"fmt"
+	"strconv"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/httpapi"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestAccNewRelicAlertPolicy_basic(t *testing.T) {
+	var policy Policy
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckNewRelicAlertPolicyDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccNewRelicAlertPolicyConfig_basic,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckNewRelicAlertPolicyExists(
+						"newrelic_alert_policy.test", &policy),
+					resource.TestCheckResourceAttr(
+						"newrelic_alert_policy.test", "incident_preference", "PER_POLICY"),
+				),
+			},
+			resource.TestStep{
+				Config: testAccNewRelicAlertPolicyConfig_update,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckNewRelicAlertPolicyExists(
+						"newrelic_alert_policy.test", &policy),
+					func(*terraform.State) error {
+						if policy.IncidentPreference != "PER_CONDITION" {
+							return fmt.Errorf("bad incident preference: %s", policy.IncidentPreference)
+						}
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckNewRelicAlertPolicyExists(n string, policy *Policy) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No policy ID is set")
+		}
+
+		id, err := strconv.Atoi(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+
+		client := testAccProvider.Meta().(*Client)
+		result, err := client.Policy(id)
+		if err != nil {
+			return err
+		}
+
+		*policy = *result
+		return nil
+	}
+}
+
+func testAccCheckNewRelicAlertPolicyDestroy(s *terraform.State) error {
+	client := testAccProvider.Meta().(*Client)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "newrelic_alert_policy" {
+			continue
+		}
+
+		id, err := strconv.Atoi(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+
+		_, err = client.Policy(id)
+		if err == nil {
+			return fmt.Errorf("Policy still exists: %s", rs.Primary.ID)
+		}
+		if !httpapi.IsNotFound(err) {
+			return err
+		}
+	}
+
+	return nil
+}
+
+const testAccNewRelicAlertPolicyConfig_basic = `
+resource "newrelic_alert_policy" "test" {
+	name = "terraform-acc-test"
+}
+`
+
+const testAccNewRelicAlertPolicyConfig_update = `
+resource "newrelic_alert_policy" "test" {
+	name = "terraform-acc-test"
+	incident_preference = "PER_CONDITION"
+}
+`
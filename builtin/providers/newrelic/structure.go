@@ -0,0 +1,168 @@
+package newrelic
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// incidentPreferences are the ways a policy can group violations into
+// incidents.
+var incidentPreferences = []string{
+	"PER_POLICY",
+	"PER_CONDITION",
+	"PER_CONDITION_AND_TARGET",
+}
+
+// conditionTypes are the types of conditions, which are the kinds of
+// entities whose metrics they watch.
+var conditionTypes = []string{
+	"apm_app_metric",
+	"apm_kt_metric",
+	"browser_metric",
+	"mobile_metric",
+	"servers_metric",
+}
+
+// termOperators are the comparisons of a metric to the threshold of a
+// term.
+var termOperators = []string{"above", "below", "equal"}
+
+// termPriorities are the priorities of the violations of a term.
+var termPriorities = []string{"critical", "warning"}
+
+// termTimeFunctions are the ways a metric is compared to the threshold
+// of a term over its duration.
+var termTimeFunctions = []string{"all", "any"}
+
+// validateOneOf returns an error unless the value is one of the allowed
+// ones.
+func validateOneOf(name, value string, allowed []string) error {
+	for _, a := range allowed {
+		if value == a {
+			return nil
+		}
+	}
+
+	return fmt.Errorf(
+		"Invalid %s %q, expected one of %s",
+		name, value, strings.Join(allowed, ", "))
+}
+
+// expandTerms returns the terms of a condition for the term blocks in the
+// configuration.
+func expandTerms(raw []interface{}) ([]Term, error) {
+	result := make([]Term, 0, len(raw))
+	for _, r := range raw {
+		m := r.(map[string]interface{})
+		t := Term{
+			Duration:     strconv.Itoa(m["duration"].(int)),
+			Operator:     m["operator"].(string),
+			Priority:     m["priority"].(string),
+			Threshold:    strconv.FormatFloat(m["threshold"].(float64), 'f', -1, 64),
+			TimeFunction: m["time_function"].(string),
+		}
+
+		if err := validateOneOf("operator", t.Operator, termOperators); err != nil {
+			return nil, err
+		}
+		if err := validateOneOf("priority", t.Priority, termPriorities); err != nil {
+			return nil, err
+		}
+		if err := validateOneOf("time function", t.TimeFunction, termTimeFunctions); err != nil {
+			return nil, err
+		}
+
+		result = append(result, t)
+	}
+
+	return result, nil
+}
+
+// flattenTerms returns the term blocks of the configuration for the terms
+// of a condition.
+func flattenTerms(terms []Term) ([]map[string]interface{}, error) {
+	result := make([]map[string]interface{}, 0, len(terms))
+	for _, t := range terms {
+		duration, err := strconv.Atoi(t.Duration)
+		if err != nil {
+			return nil, fmt.Errorf("Unexpected term duration %q", t.Duration)
+		}
+		threshold, err := strconv.ParseFloat(t.Threshold, 64)
+		if err != nil {
+			return nil, fmt.Errorf("Unexpected term threshold %q", t.Threshold)
+		}
+
+		result = append(result, map[string]interface{}{
+			"duration":      duration,
+			"operator":      t.Operator,
+			"priority":      t.Priority,
+			"threshold":     threshold,
+			"time_function": t.TimeFunction,
+		})
+	}
+
+	return result, nil
+}
+
+// expandEntities returns the entities of a condition for their IDs in the
+// configuration.
+func expandEntities(raw []interface{}) []string {
+	result := make([]string, 0, len(raw))
+	for _, r := range raw {
+		result = append(result, strconv.Itoa(r.(int)))
+	}
+
+	return result
+}
+
+// flattenEntities returns the IDs of the entities of a condition, as they
+// are in the configuration.
+func flattenEntities(entities []string) ([]int, error) {
+	result := make([]int, 0, len(entities))
+	for _, e := range entities {
+		id, err := strconv.Atoi(e)
+		if err != nil {
+			return nil, fmt.Errorf("Unexpected entity ID %q", e)
+		}
+		result = append(result, id)
+	}
+
+	return result, nil
+}
+
+// parseId returns the numeric ID of a resource.
+func parseId(id string) (int, error) {
+	result, err := strconv.Atoi(id)
+	if err != nil {
+		return 0, fmt.Errorf("Unexpected ID %q, expected a number", id)
+	}
+
+	return result, nil
+}
+
+// parseIdPair returns the two numeric IDs of a resource whose ID is made
+// of the IDs of two objects, such as "POLICY_ID:CONDITION_ID".
+func parseIdPair(id string) (int, int, error) {
+	parts := strings.Split(id, ":")
+	if len(parts) != 2 {
+		return 0, 0, fmt.Errorf("Unexpected ID %q, expected two IDs joined by a colon", id)
+	}
+
+	first, err := parseId(parts[0])
+	if err != nil {
+		return 0, 0, err
+	}
+	second, err := parseId(parts[1])
+	if err != nil {
+		return 0, 0, err
+	}
+
+	return first, second, nil
+}
+
+// formatIdPair returns the ID of a resource made of the IDs of two
+// objects.
+func formatIdPair(first, second int) string {
+	return fmt.Sprintf("%d:%d", first, second)
+}
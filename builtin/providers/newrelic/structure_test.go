@@ -0,0 +1,105 @@
+package newrelic
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestValidateOneOf(t *testing.T) {
+	for _, p := range []string{"PER_POLICY", "PER_CONDITION"} {
+		if err := validateOneOf("preference", p, incidentPreferences); err != nil {
+			t.Fatalf("%s: err: %s", p, err)
+		}
+	}
+
+	for _, p := range []string{"", "per_policy", "PER_TARGET"} {
+		if err := validateOneOf("preference", p, incidentPreferences); err == nil {
+			t.Fatalf("%s: should error", p)
+		}
+	}
+}
+
+func TestExpandTerms(t *testing.T) {
+	raw := []interface{}{
+		map[string]interface{}{
+			"duration":      5,
+			"operator":      "below",
+			"priority":      "critical",
+			"threshold":     0.75,
+			"time_function": "all",
+		},
+	}
+
+	terms, err := expandTerms(raw)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	expected := []Term{
+		{
+			Duration:     "5",
+			Operator:     "below",
+			Priority:     "critical",
+			Threshold:    "0.75",
+			TimeFunction: "all",
+		},
+	}
+	if !reflect.DeepEqual(terms, expected) {
+		t.Fatalf("bad: %#v", terms)
+	}
+
+	flattened, err := flattenTerms(terms)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if !reflect.DeepEqual(flattened[0], raw[0]) {
+		t.Fatalf("bad: %#v", flattened)
+	}
+}
+
+func TestExpandTerms_invalid(t *testing.T) {
+	raw := []interface{}{
+		map[string]interface{}{
+			"duration":      5,
+			"operator":      "over",
+			"priority":      "critical",
+			"threshold":     1.0,
+			"time_function": "all",
+		},
+	}
+
+	if _, err := expandTerms(raw); err == nil {
+		t.Fatal("should error")
+	}
+}
+
+func TestExpandEntities(t *testing.T) {
+	entities := expandEntities([]interface{}{12, 34})
+	if !reflect.DeepEqual(entities, []string{"12", "34"}) {
+		t.Fatalf("bad: %#v", entities)
+	}
+
+	ids, err := flattenEntities(entities)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if !reflect.DeepEqual(ids, []int{12, 34}) {
+		t.Fatalf("bad: %#v", ids)
+	}
+}
+
+func TestParseIdPair(t *testing.T) {
+	first, second, err := parseIdPair(formatIdPair(12, 34))
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if first != 12 || second != 34 {
+		t.Fatalf("bad: %d, %d", first, second)
+	}
+
+	for _, id := range []string{"", "12", "12:34:56", "12:abc"} {
+		if _, _, err := parseIdPair(id); err == nil {
+			t.Fatalf("%q: should error", id)
+		}
+	}
+}
@@ -0,0 +1,156 @@
+package ns1
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform/helper/httpapi"
+)
+
+// Client is a client for the v1 NS1 API.
+type Client struct {
+	api *httpapi.Client
+}
+
+// Zone is a DNS zone, as accepted and returned by the API.
+type Zone struct {
+	Zone      string         `json:"zone"`
+	TTL       int            `json:"ttl,omitempty"`
+	Refresh   int            `json:"refresh,omitempty"`
+	Retry     int            `json:"retry,omitempty"`
+	Expiry    int            `json:"expiry,omitempty"`
+	NxTTL     int            `json:"nx_ttl,omitempty"`
+	Link      string         `json:"link,omitempty"`
+	Secondary *ZoneSecondary `json:"secondary,omitempty"`
+
+	// Set by NS1
+	Hostmaster string   `json:"hostmaster,omitempty"`
+	DNSServers []string `json:"dns_servers,omitempty"`
+}
+
+// ZoneSecondary is the configuration of a zone whose records NS1
+// transfers from a primary name server, instead of serving its own.
+type ZoneSecondary struct {
+	Enabled     bool   `json:"enabled"`
+	PrimaryIP   string `json:"primary_ip,omitempty"`
+	PrimaryPort int    `json:"primary_port,omitempty"`
+}
+
+// Record is a DNS record, along with the configuration of how NS1 picks
+// the answers to give to each query.
+type Record struct {
+	Zone            string            `json:"zone"`
+	Domain          string            `json:"domain"`
+	Type            string            `json:"type"`
+	TTL             int               `json:"ttl,omitempty"`
+	Link            string            `json:"link,omitempty"`
+	UseClientSubnet *bool             `json:"use_client_subnet,omitempty"`
+	Answers         []Answer          `json:"answers"`
+	Filters         []Filter          `json:"filters"`
+	Regions         map[string]Region `json:"regions"`
+}
+
+// Answer is a possible answer of a record. The fields of the answer are
+// numbers or strings, such as [10, "mail.example.com"] for an MX record.
+type Answer struct {
+	Answer []interface{}          `json:"answer"`
+	Region string                 `json:"region,omitempty"`
+	Meta   map[string]interface{} `json:"meta,omitempty"`
+}
+
+// Filter is a step of the filter chain of a record, which picks the
+// answers to a query.
+type Filter struct {
+	Filter   string                 `json:"filter"`
+	Disabled bool                   `json:"disabled,omitempty"`
+	Config   map[string]interface{} `json:"config"`
+}
+
+// Region is a group of answers of a record, with the metadata that the
+// filters use for all its answers.
+type Region struct {
+	Meta map[string]interface{} `json:"meta"`
+}
+
+// Zone returns the zone with the given name.
+func (c *Client) Zone(name string) (*Zone, error) {
+	var result Zone
+	if err := c.do("GET", "/zones/"+name, nil, &result); err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// CreateZone creates the zone.
+func (c *Client) CreateZone(z *Zone) (*Zone, error) {
+	var result Zone
+	if err := c.do("PUT", "/zones/"+z.Zone, z, &result); err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// UpdateZone changes the settings of the zone.
+func (c *Client) UpdateZone(z *Zone) (*Zone, error) {
+	var result Zone
+	if err := c.do("POST", "/zones/"+z.Zone, z, &result); err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// DeleteZone deletes the zone with the given name, along with its
+// records.
+func (c *Client) DeleteZone(name string) error {
+	return c.do("DELETE", "/zones/"+name, nil, nil)
+}
+
+// Record returns the record of the given type for the domain in the zone.
+func (c *Client) Record(zone, domain, typ string) (*Record, error) {
+	var result Record
+	if err := c.do("GET", recordPath(zone, domain, typ), nil, &result); err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// CreateRecord creates the record.
+func (c *Client) CreateRecord(r *Record) (*Record, error) {
+	var result Record
+	path := recordPath(r.Zone, r.Domain, r.Type)
+	if err := c.do("PUT", path, r, &result); err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// UpdateRecord replaces the answers, filters and settings of the record.
+func (c *Client) UpdateRecord(r *Record) (*Record, error) {
+	var result Record
+	path := recordPath(r.Zone, r.Domain, r.Type)
+	if err := c.do("POST", path, r, &result); err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// DeleteRecord deletes the record of the given type for the domain in the
+// zone.
+func (c *Client) DeleteRecord(zone, domain, typ string) error {
+	return c.do("DELETE", recordPath(zone, domain, typ), nil, nil)
+}
+
+func (c *Client) do(method, path string, in, out interface{}) error {
+	return c.api.Do(method, path, in, out)
+}
+
+// recordPath returns the path of the API for the record of the given type
+// for the domain in the zone.
+func recordPath(zone, domain, typ string) string {
+	return fmt.Sprintf("/zones/%s/%s/%s", zone, domain, typ)
+}
@@ -0,0 +1,33 @@
+package ns1
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/httpapi"
+)
+
+type Config struct {
+	APIKey   string
+	Endpoint string
+}
+
+// Client returns a new client for the NS1 API.
+func (c *Config) Client() (*Client, error) {
+	u, err := url.Parse(c.Endpoint)
+	if err != nil || u.Scheme == "" || u.Host == "" {
+		return nil, fmt.Errorf("Invalid NS1 endpoint %q", c.Endpoint)
+	}
+
+	log.Printf("[INFO] NS1 client configured for %s", c.Endpoint)
+	return &Client{
+		api: &httpapi.Client{
+			BaseURL: strings.TrimSuffix(c.Endpoint, "/"),
+			Name:    "NS1",
+			Header:  http.Header{"X-Nsone-Key": []string{c.APIKey}},
+		},
+	}, nil
+}
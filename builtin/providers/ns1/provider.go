@@ -0,0 +1,46 @@
+package ns1
+
+import (
+	"log"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+// Provider returns a terraform.ResourceProvider.
+func Provider() terraform.ResourceProvider {
+	return &schema.Provider{
+		Schema: map[string]*schema.Schema{
+			"apikey": &schema.Schema{
+				Type:        schema.TypeString,
+				Required:    true,
+				DefaultFunc: schema.EnvDefaultFunc("NS1_APIKEY", nil),
+				Description: "The NS1 API key.",
+			},
+
+			"endpoint": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("NS1_ENDPOINT", "https://api.nsone.net/v1"),
+				Description: "The URL of the NS1 API.",
+			},
+		},
+
+		ResourcesMap: map[string]*schema.Resource{
+			"ns1_record": resourceNS1Record(),
+			"ns1_zone":   resourceNS1Zone(),
+		},
+
+		ConfigureFunc: providerConfigure,
+	}
+}
+
+func providerConfigure(d *schema.ResourceData) (interface{}, error) {
+	config := Config{
+		APIKey:   d.Get("apikey").(string),
+		Endpoint: d.Get("endpoint").(string),
+	}
+
+	log.Println("[INFO] Initializing NS1 client")
+	return config.Client()
+}
@@ -0,0 +1,326 @@
+package ns1
+
+import (
+	"bytes"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/hashcode"
+	"github.com/hashicorp/terraform/helper/httpapi"
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func resourceNS1Record() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceNS1RecordCreate,
+		Read:   resourceNS1RecordRead,
+		Update: resourceNS1RecordUpdate,
+		Delete: resourceNS1RecordDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"zone": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"domain": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"type": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"ttl": &schema.Schema{
+				Type:     schema.TypeInt,
+				Optional: true,
+				Computed: true,
+			},
+
+			"link": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"use_client_subnet": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+
+			"answers": &schema.Schema{
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"answer": &schema.Schema{
+							Type:     schema.TypeString,
+							Required: true,
+						},
+
+						"region": &schema.Schema{
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+
+						"meta": &schema.Schema{
+							Type:     schema.TypeMap,
+							Optional: true,
+						},
+					},
+				},
+			},
+
+			"regions": &schema.Schema{
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": &schema.Schema{
+							Type:     schema.TypeString,
+							Required: true,
+						},
+
+						"meta": &schema.Schema{
+							Type:     schema.TypeMap,
+							Optional: true,
+						},
+					},
+				},
+				Set: resourceNS1RegionHash,
+			},
+
+			"filters": &schema.Schema{
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"filter": &schema.Schema{
+							Type:     schema.TypeString,
+							Required: true,
+						},
+
+						"disabled": &schema.Schema{
+							Type:     schema.TypeBool,
+							Optional: true,
+						},
+
+						"config": &schema.Schema{
+							Type:     schema.TypeMap,
+							Optional: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func resourceNS1RecordCreate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Client)
+
+	r := expandRecord(d)
+	log.Printf("[DEBUG] Creating NS1 record: %s %s", r.Domain, r.Type)
+	result, err := client.CreateRecord(r)
+	if err != nil {
+		return fmt.Errorf(
+			"Error creating NS1 record %s %s: %s", r.Domain, r.Type, err)
+	}
+
+	d.SetId(recordId(result))
+	log.Printf("[INFO] NS1 record created: %s", d.Id())
+
+	return resourceNS1RecordRead(d, meta)
+}
+
+func resourceNS1RecordRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Client)
+
+	zone, domain, typ, err := parseRecordId(d.Id())
+	if err != nil {
+		return err
+	}
+
+	r, err := client.Record(zone, domain, typ)
+	if err != nil {
+		if httpapi.IsNotFound(err) {
+			d.SetId("")
+			return nil
+		}
+		return fmt.Errorf("Error reading NS1 record %s: %s", d.Id(), err)
+	}
+
+	d.Set("zone", r.Zone)
+	d.Set("domain", r.Domain)
+	d.Set("type", r.Type)
+	d.Set("ttl", r.TTL)
+	d.Set("link", r.Link)
+	if r.UseClientSubnet != nil {
+		d.Set("use_client_subnet", *r.UseClientSubnet)
+	}
+	if err := d.Set("answers", flattenAnswers(r.Answers)); err != nil {
+		return err
+	}
+	if err := d.Set("regions", flattenRegions(r.Regions)); err != nil {
+		return err
+	}
+	if err := d.Set("filters", flattenFilters(r.Filters)); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func resourceNS1RecordUpdate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Client)
+
+	log.Printf("[DEBUG] Updating NS1 record: %s", d.Id())
+	if _, err := client.UpdateRecord(expandRecord(d)); err != nil {
+		return fmt.Errorf("Error updating NS1 record %s: %s", d.Id(), err)
+	}
+
+	return resourceNS1RecordRead(d, meta)
+}
+
+func resourceNS1RecordDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Client)
+
+	zone, domain, typ, err := parseRecordId(d.Id())
+	if err != nil {
+		return err
+	}
+
+	log.Printf("[INFO] Deleting NS1 record: %s", d.Id())
+	if err := client.DeleteRecord(zone, domain, typ); err != nil {
+		if httpapi.IsNotFound(err) {
+			return nil
+		}
+		return fmt.Errorf("Error deleting NS1 record %s: %s", d.Id(), err)
+	}
+
+	d.SetId("")
+	return nil
+}
+
+// expandRecord returns the record of the resource.
+func expandRecord(d *schema.ResourceData) *Record {
+	useClientSubnet := d.Get("use_client_subnet").(bool)
+	r := &Record{
+		Zone:            d.Get("zone").(string),
+		Domain:          d.Get("domain").(string),
+		Type:            d.Get("type").(string),
+		TTL:             d.Get("ttl").(int),
+		Link:            d.Get("link").(string),
+		UseClientSubnet: &useClientSubnet,
+		Answers:         []Answer{},
+		Filters:         []Filter{},
+		Regions:         map[string]Region{},
+	}
+
+	for _, raw := range d.Get("answers").([]interface{}) {
+		m := raw.(map[string]interface{})
+		r.Answers = append(r.Answers, Answer{
+			Answer: expandRdata(r.Type, m["answer"].(string)),
+			Region: m["region"].(string),
+			Meta:   expandMeta(m["meta"]),
+		})
+	}
+
+	for _, raw := range d.Get("regions").(*schema.Set).List() {
+		m := raw.(map[string]interface{})
+		r.Regions[m["name"].(string)] = Region{Meta: expandMeta(m["meta"])}
+	}
+
+	for _, raw := range d.Get("filters").([]interface{}) {
+		m := raw.(map[string]interface{})
+		config := expandMeta(m["config"])
+		if config == nil {
+			config = map[string]interface{}{}
+		}
+		r.Filters = append(r.Filters, Filter{
+			Filter:   m["filter"].(string),
+			Disabled: m["disabled"].(bool),
+			Config:   config,
+		})
+	}
+
+	return r
+}
+
+func flattenAnswers(answers []Answer) []map[string]interface{} {
+	result := make([]map[string]interface{}, 0, len(answers))
+	for _, a := range answers {
+		result = append(result, map[string]interface{}{
+			"answer": flattenRdata(a.Answer),
+			"region": a.Region,
+			"meta":   flattenMeta(a.Meta),
+		})
+	}
+
+	return result
+}
+
+func flattenRegions(regions map[string]Region) []map[string]interface{} {
+	result := make([]map[string]interface{}, 0, len(regions))
+	for name, r := range regions {
+		result = append(result, map[string]interface{}{
+			"name": name,
+			"meta": flattenMeta(r.Meta),
+		})
+	}
+
+	return result
+}
+
+func flattenFilters(filters []Filter) []map[string]interface{} {
+	result := make([]map[string]interface{}, 0, len(filters))
+	for _, f := range filters {
+		result = append(result, map[string]interface{}{
+			"filter":   f.Filter,
+			"disabled": f.Disabled,
+			"config":   flattenMeta(f.Config),
+		})
+	}
+
+	return result
+}
+
+func resourceNS1RegionHash(v interface{}) int {
+	var buf bytes.Buffer
+	m := v.(map[string]interface{})
+	buf.WriteString(fmt.Sprintf("%s-", m["name"].(string)))
+	if meta, ok := m["meta"].(map[string]interface{}); ok {
+		for _, k := range sortedKeys(meta) {
+			buf.WriteString(fmt.Sprintf("%s=%v-", k, meta[k]))
+		}
+	}
+
+	return hashcode.String(buf.String())
+}
+
+// recordId returns the ID of the resource for a record, which is
+// "ZONE/DOMAIN/TYPE".
+func recordId(r *Record) string {
+	return fmt.Sprintf("%s/%s/%s", r.Zone, r.Domain, r.Type)
+}
+
+// parseRecordId returns the zone, domain and type of the record in the
+// ID of a resource, as returned by recordId.
+func parseRecordId(id string) (string, string, string, error) {
+	parts := strings.Split(id, "/")
+	if len(parts) != 3 || parts[0] == "" || parts[1] == "" || parts[2] == "" {
+		return "", "", "", fmt.Errorf(
+			"Unexpected ID %q, expected ZONE/DOMAIN/TYPE", id)
+	}
+
+	return parts[0], parts[1], parts[2], nil
+}
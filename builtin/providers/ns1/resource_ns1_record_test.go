@@ -0,0 +1,184 @@
+package ns1
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/httpapi"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestAccNS1Record_basic(t *testing.T) {
+	var record Record
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckNS1RecordDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccNS1RecordConfig_basic,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckNS1RecordExists("ns1_record.www", &record),
+					resource.TestCheckResourceAttr(
+						"ns1_record.www", "answers.#", "1"),
+					resource.TestCheckResourceAttr(
+						"ns1_record.www", "answers.0.answer", "10.0.0.1"),
+				),
+			},
+			resource.TestStep{
+				Config: testAccNS1RecordConfig_steering,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckNS1RecordExists("ns1_record.www", &record),
+					resource.TestCheckResourceAttr(
+						"ns1_record.www", "answers.#", "2"),
+					resource.TestCheckResourceAttr(
+						"ns1_record.www", "answers.1.region", "eu"),
+					resource.TestCheckResourceAttr(
+						"ns1_record.www", "regions.#", "2"),
+					resource.TestCheckResourceAttr(
+						"ns1_record.www", "filters.#", "3"),
+					resource.TestCheckResourceAttr(
+						"ns1_record.www", "filters.1.filter", "geotarget_regional"),
+					func(*terraform.State) error {
+						if len(record.Filters) != 3 {
+							return fmt.Errorf("bad filters: %#v", record.Filters)
+						}
+						if up, ok := record.Answers[0].Meta["up"].(bool); !ok || !up {
+							return fmt.Errorf("bad meta: %#v", record.Answers[0].Meta)
+						}
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckNS1RecordExists(n string, record *Record) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No record ID is set")
+		}
+
+		zone, domain, typ, err := parseRecordId(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+
+		client := testAccProvider.Meta().(*Client)
+		result, err := client.Record(zone, domain, typ)
+		if err != nil {
+			return err
+		}
+
+		*record = *result
+		return nil
+	}
+}
+
+func testAccCheckNS1RecordDestroy(s *terraform.State) error {
+	client := testAccProvider.Meta().(*Client)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "ns1_record" {
+			continue
+		}
+
+		zone, domain, typ, err := parseRecordId(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+
+		_, err = client.Record(zone, domain, typ)
+		if err == nil {
+			return fmt.Errorf("Record still exists: %s", rs.Primary.ID)
+		}
+		if !httpapi.IsNotFound(err) {
+			return err
+		}
+	}
+
+	return testAccCheckNS1ZoneDestroy(s)
+}
+
+const testAccNS1RecordConfig_basic = `
+resource "ns1_zone" "test" {
+	zone = "terraform-acc-test.io"
+}
+
+resource "ns1_record" "www" {
+	zone = "${ns1_zone.test.zone}"
+	domain = "www.${ns1_zone.test.zone}"
+	type = "A"
+	ttl = 60
+
+	answers {
+		answer = "10.0.0.1"
+	}
+}
+`
+
+const testAccNS1RecordConfig_steering = `
+resource "ns1_zone" "test" {
+	zone = "terraform-acc-test.io"
+}
+
+resource "ns1_record" "www" {
+	zone = "${ns1_zone.test.zone}"
+	domain = "www.${ns1_zone.test.zone}"
+	type = "A"
+	ttl = 60
+
+	answers {
+		answer = "10.0.0.1"
+		region = "us"
+		meta {
+			up = "true"
+		}
+	}
+
+	answers {
+		answer = "10.0.0.2"
+		region = "eu"
+		meta {
+			up = "true"
+		}
+	}
+
+	regions {
+		name = "us"
+		meta {
+			georegion = "US-EAST,US-WEST"
+		}
+	}
+
+	regions {
+		name = "eu"
+		meta {
+			georegion = "EUROPE"
+		}
+	}
+
+	filters {
+		filter = "up"
+	}
+
+	filters {
+		filter = "geotarget_regional"
+	}
+
+	filters {
+		filter = "select_first_n"
+		config {
+			N = "1"
+		}
+	}
+}
+`
@@ -0,0 +1,178 @@
+package ns1
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform/helper/httpapi"
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func resourceNS1Zone() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceNS1ZoneCreate,
+		Read:   resourceNS1ZoneRead,
+		Update: resourceNS1ZoneUpdate,
+		Delete: resourceNS1ZoneDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"zone": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"ttl": &schema.Schema{
+				Type:     schema.TypeInt,
+				Optional: true,
+				Computed: true,
+			},
+
+			"refresh": &schema.Schema{
+				Type:     schema.TypeInt,
+				Optional: true,
+				Computed: true,
+			},
+
+			"retry": &schema.Schema{
+				Type:     schema.TypeInt,
+				Optional: true,
+				Computed: true,
+			},
+
+			"expiry": &schema.Schema{
+				Type:     schema.TypeInt,
+				Optional: true,
+				Computed: true,
+			},
+
+			"nx_ttl": &schema.Schema{
+				Type:     schema.TypeInt,
+				Optional: true,
+				Computed: true,
+			},
+
+			"link": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+
+			"primary": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+
+			"hostmaster": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"dns_servers": &schema.Schema{
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+		},
+	}
+}
+
+func resourceNS1ZoneCreate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Client)
+
+	z := expandZone(d)
+	log.Printf("[DEBUG] Creating NS1 zone: %s", z.Zone)
+	result, err := client.CreateZone(z)
+	if err != nil {
+		return fmt.Errorf("Error creating NS1 zone %s: %s", z.Zone, err)
+	}
+
+	d.SetId(result.Zone)
+	log.Printf("[INFO] NS1 zone created: %s", d.Id())
+
+	return resourceNS1ZoneRead(d, meta)
+}
+
+func resourceNS1ZoneRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Client)
+
+	z, err := client.Zone(d.Id())
+	if err != nil {
+		if httpapi.IsNotFound(err) {
+			d.SetId("")
+			return nil
+		}
+		return fmt.Errorf("Error reading NS1 zone %s: %s", d.Id(), err)
+	}
+
+	d.Set("zone", z.Zone)
+	d.Set("ttl", z.TTL)
+	d.Set("refresh", z.Refresh)
+	d.Set("retry", z.Retry)
+	d.Set("expiry", z.Expiry)
+	d.Set("nx_ttl", z.NxTTL)
+	d.Set("link", z.Link)
+	if z.Secondary != nil && z.Secondary.Enabled {
+		d.Set("primary", z.Secondary.PrimaryIP)
+	} else {
+		d.Set("primary", "")
+	}
+	d.Set("hostmaster", z.Hostmaster)
+	if err := d.Set("dns_servers", z.DNSServers); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func resourceNS1ZoneUpdate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Client)
+
+	log.Printf("[DEBUG] Updating NS1 zone: %s", d.Id())
+	if _, err := client.UpdateZone(expandZone(d)); err != nil {
+		return fmt.Errorf("Error updating NS1 zone %s: %s", d.Id(), err)
+	}
+
+	return resourceNS1ZoneRead(d, meta)
+}
+
+func resourceNS1ZoneDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Client)
+
+	log.Printf("[INFO] Deleting NS1 zone: %s", d.Id())
+	if err := client.DeleteZone(d.Id()); err != nil {
+		if httpapi.IsNotFound(err) {
+			return nil
+		}
+		return fmt.Errorf("Error deleting NS1 zone %s: %s", d.Id(), err)
+	}
+
+	d.SetId("")
+	return nil
+}
+
+// expandZone returns the zone of the resource. The settings that aren't
+// set are left to the defaults of NS1.
+func expandZone(d *schema.ResourceData) *Zone {
+	z := &Zone{
+		Zone:    d.Get("zone").(string),
+		TTL:     d.Get("ttl").(int),
+		Refresh: d.Get("refresh").(int),
+		Retry:   d.Get("retry").(int),
+		Expiry:  d.Get("expiry").(int),
+		NxTTL:   d.Get("nx_ttl").(int),
+		Link:    d.Get("link").(string),
+	}
+	if v := d.Get("primary").(string); v != "" {
+		z.Secondary = &ZoneSecondary{
+			Enabled:   true,
+			PrimaryIP: v,
+		}
+	}
+
+	return z
+}
@@ -0,0 +1,101 @@
+package ns1
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/httpapi"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestAccNS1Zone_basic(t *testing.T) {
+	var zone Zone
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckNS1ZoneDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccNS1ZoneConfig_basic,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckNS1ZoneExists("ns1_zone.test", &zone),
+					resource.TestCheckResourceAttr(
+						"ns1_zone.test", "ttl", "3600"),
+				),
+			},
+			resource.TestStep{
+				Config: testAccNS1ZoneConfig_update,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckNS1ZoneExists("ns1_zone.test", &zone),
+					resource.TestCheckResourceAttr(
+						"ns1_zone.test", "ttl", "600"),
+					func(*terraform.State) error {
+						if zone.NxTTL != 300 {
+							return fmt.Errorf("bad nx_ttl: %d", zone.NxTTL)
+						}
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckNS1ZoneExists(n string, zone *Zone) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No zone name is set")
+		}
+
+		client := testAccProvider.Meta().(*Client)
+		result, err := client.Zone(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+
+		*zone = *result
+		return nil
+	}
+}
+
+func testAccCheckNS1ZoneDestroy(s *terraform.State) error {
+	client := testAccProvider.Meta().(*Client)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "ns1_zone" {
+			continue
+		}
+
+		_, err := client.Zone(rs.Primary.ID)
+		if err == nil {
+			return fmt.Errorf("Zone still exists: %s", rs.Primary.ID)
+		}
+		if !httpapi.IsNotFound(err) {
+			return err
+		}
+	}
+
+	return nil
+}
+
+const testAccNS1ZoneConfig_basic = `
+resource "ns1_zone" "test" {
+	zone = "terraform-acc-test.io"
+	ttl = 3600
+}
+`
+
+const testAccNS1ZoneConfig_update = `
+resource "ns1_zone" "test" {
+	zone = "terraform-acc-test.io"
+	ttl = 600
+	nx_ttl = 300
+}
+`
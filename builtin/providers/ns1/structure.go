@@ -0,0 +1,140 @@
+package ns1
+
+import (
+	"fmt"
+	"log"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// metaListFields are the fields of metadata whose values are lists, such
+// as the countries of an answer. They are comma separated strings in the
+// configuration.
+var metaListFields = map[string]bool{
+	"asn":         true,
+	"ca_province": true,
+	"country":     true,
+	"georegion":   true,
+	"ip_prefixes": true,
+	"us_state":    true,
+}
+
+// expandMeta returns the metadata of an answer or region, or the config
+// of a filter, from the map in the configuration. The values are strings
+// in the configuration, which are given to the API as booleans or numbers
+// if they look like them, such as "true" for the up field of an answer.
+func expandMeta(v interface{}) map[string]interface{} {
+	m, ok := v.(map[string]interface{})
+	if !ok || len(m) == 0 {
+		return nil
+	}
+
+	result := make(map[string]interface{}, len(m))
+	for k, raw := range m {
+		s := raw.(string)
+		if !metaListFields[k] {
+			result[k] = expandMetaValue(s)
+			continue
+		}
+
+		var values []interface{}
+		for _, e := range strings.Split(s, ",") {
+			if e = strings.TrimSpace(e); e != "" {
+				values = append(values, expandMetaValue(e))
+			}
+		}
+		result[k] = values
+	}
+
+	return result
+}
+
+func expandMetaValue(s string) interface{} {
+	if s == "true" || s == "false" {
+		return s == "true"
+	}
+	if f, err := strconv.ParseFloat(s, 64); err == nil {
+		return f
+	}
+
+	return s
+}
+
+// flattenMeta returns the map of the configuration for the metadata of
+// an answer or region, or the config of a filter. Values that can't be
+// written in the configuration, such as references to data feeds, are
+// left out.
+func flattenMeta(m map[string]interface{}) map[string]interface{} {
+	result := make(map[string]interface{}, len(m))
+	for k, v := range m {
+		if l, ok := v.([]interface{}); ok {
+			values := make([]string, 0, len(l))
+			for _, e := range l {
+				values = append(values, flattenMetaValue(e))
+			}
+			result[k] = strings.Join(values, ",")
+			continue
+		}
+
+		switch v.(type) {
+		case string, bool, float64:
+			result[k] = flattenMetaValue(v)
+		default:
+			log.Printf("[WARN] Ignoring NS1 metadata %s of type %T", k, v)
+		}
+	}
+
+	return result
+}
+
+func flattenMetaValue(v interface{}) string {
+	switch v := v.(type) {
+	case bool:
+		return strconv.FormatBool(v)
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// expandRdata returns the fields of an answer of a record of the given
+// type, which are separated by spaces in the configuration, such as
+// "10 mail.example.com" for an MX record. The text of TXT and SPF records
+// is a single field.
+func expandRdata(typ, answer string) []interface{} {
+	if typ == "TXT" || typ == "SPF" {
+		return []interface{}{answer}
+	}
+
+	fields := strings.Fields(answer)
+	result := make([]interface{}, len(fields))
+	for i, f := range fields {
+		result[i] = f
+	}
+
+	return result
+}
+
+// flattenRdata returns the answer of the configuration for the fields
+// of an answer, as returned by expandRdata.
+func flattenRdata(fields []interface{}) string {
+	values := make([]string, len(fields))
+	for i, f := range fields {
+		values[i] = flattenMetaValue(f)
+	}
+
+	return strings.Join(values, " ")
+}
+
+// sortedKeys returns the keys of the map in order.
+func sortedKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
@@ -0,0 +1,92 @@
+package ns1
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestExpandMeta(t *testing.T) {
+	configured := map[string]interface{}{
+		"up":      "true",
+		"weight":  "10.5",
+		"country": "US, CA",
+		"asn":     "1234",
+		"note":    "primary",
+	}
+
+	expected := map[string]interface{}{
+		"up":      true,
+		"weight":  10.5,
+		"country": []interface{}{"US", "CA"},
+		"asn":     []interface{}{float64(1234)},
+		"note":    "primary",
+	}
+	if actual := expandMeta(configured); !reflect.DeepEqual(actual, expected) {
+		t.Fatalf("bad: %#v", actual)
+	}
+
+	if actual := expandMeta(map[string]interface{}{}); actual != nil {
+		t.Fatalf("bad: %#v", actual)
+	}
+}
+
+func TestFlattenMeta(t *testing.T) {
+	meta := map[string]interface{}{
+		"up":      true,
+		"weight":  float64(10),
+		"country": []interface{}{"US", "CA"},
+		"note":    "primary",
+		"feed":    map[string]interface{}{"feed": "abc123"},
+	}
+
+	expected := map[string]interface{}{
+		"up":      "true",
+		"weight":  "10",
+		"country": "US,CA",
+		"note":    "primary",
+	}
+	if actual := flattenMeta(meta); !reflect.DeepEqual(actual, expected) {
+		t.Fatalf("bad: %#v", actual)
+	}
+}
+
+func TestExpandRdata(t *testing.T) {
+	cases := []struct {
+		Type     string
+		Answer   string
+		Expected []interface{}
+	}{
+		{"A", "1.2.3.4", []interface{}{"1.2.3.4"}},
+		{"MX", "10 mail.example.com", []interface{}{"10", "mail.example.com"}},
+		{"TXT", "v=spf1 -all", []interface{}{"v=spf1 -all"}},
+	}
+
+	for _, tc := range cases {
+		actual := expandRdata(tc.Type, tc.Answer)
+		if !reflect.DeepEqual(actual, tc.Expected) {
+			t.Fatalf("bad: %s %s: %#v", tc.Type, tc.Answer, actual)
+		}
+
+		if answer := flattenRdata(actual); answer != tc.Answer {
+			t.Fatalf("bad: %s %s: %s", tc.Type, tc.Answer, answer)
+		}
+	}
+
+	if answer := flattenRdata([]interface{}{float64(10), "mail.example.com"}); answer != "10 mail.example.com" {
+		t.Fatalf("bad: %s", answer)
+	}
+}
+
+func TestParseRecordId(t *testing.T) {
+	zone, domain, typ, err := parseRecordId("example.com/www.example.com/A")
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if zone != "example.com" || domain != "www.example.com" || typ != "A" {
+		t.Fatalf("bad: %s %s %s", zone, domain, typ)
+	}
+
+	if _, _, _, err := parseRecordId("example.com/A"); err == nil {
+		t.Fatal("should error")
+	}
+}
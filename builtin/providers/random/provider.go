@@ -0,0 +1,58 @@
+package random
+
+import (
+	"hash/crc64"
+	"math/rand"
+	"time"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+// Provider returns a terraform.ResourceProvider.
+func Provider() terraform.ResourceProvider {
+	return &schema.Provider{
+		ResourcesMap: map[string]*schema.Resource{
+			"random_id":      resourceId(),
+			"random_shuffle": resourceShuffle(),
+			"random_pet":     resourcePet(),
+		},
+	}
+}
+
+// keepersSchema is the schema of the keepers of every resource: arbitrary
+// values that generate a new random value when they change.
+func keepersSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeMap,
+		Optional: true,
+		ForceNew: true,
+	}
+}
+
+// newRand returns a source of random numbers for values that don't need
+// to be secure. With a seed, it always returns the same numbers for the
+// same seed.
+func newRand(seed string) *rand.Rand {
+	var n int64
+	if seed == "" {
+		n = time.Now().UnixNano()
+	} else {
+		n = int64(crc64.Checksum([]byte(seed), crc64.MakeTable(crc64.ECMA)))
+	}
+
+	return rand.New(rand.NewSource(n))
+}
+
+// resourceRemove removes the resource from the state. The values only
+// exist in the state, so there is nothing else to delete.
+func resourceRemove(d *schema.ResourceData, meta interface{}) error {
+	d.SetId("")
+	return nil
+}
+
+// resourceNoop is the Read of the resources, since the values only exist
+// in the state and so are always as they were created.
+func resourceNoop(d *schema.ResourceData, meta interface{}) error {
+	return nil
+}
@@ -0,0 +1,23 @@
+package random
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func TestProvider(t *testing.T) {
+	if err := Provider().(*schema.Provider).InternalValidate(); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+}
+
+func TestNewRand(t *testing.T) {
+	a := newRand("foo").Perm(10)
+	b := newRand("foo").Perm(10)
+	for i := range a {
+		if a[i] != b[i] {
+			t.Fatalf("bad: %#v %#v", a, b)
+		}
+	}
+}
@@ -0,0 +1,80 @@
+package random
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func resourceId() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceIdCreate,
+		Read:   resourceNoop,
+		Delete: resourceRemove,
+
+		Schema: map[string]*schema.Schema{
+			"keepers": keepersSchema(),
+
+			"byte_length": &schema.Schema{
+				Type:     schema.TypeInt,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"prefix": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+
+			"b64": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"b64_std": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"hex": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"dec": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceIdCreate(d *schema.ResourceData, meta interface{}) error {
+	length := d.Get("byte_length").(int)
+	if length < 1 {
+		return fmt.Errorf("byte_length must be at least 1")
+	}
+
+	bytes := make([]byte, length)
+	if _, err := rand.Read(bytes); err != nil {
+		return fmt.Errorf("error generating random bytes: %s", err)
+	}
+
+	// The padding of base64 isn't allowed in most names, so it is left
+	// out of the URL-safe encoding, which is also the ID.
+	b64 := strings.TrimRight(base64.URLEncoding.EncodeToString(bytes), "=")
+	prefix := d.Get("prefix").(string)
+
+	d.SetId(b64)
+	d.Set("b64", prefix+b64)
+	d.Set("b64_std", prefix+base64.StdEncoding.EncodeToString(bytes))
+	d.Set("hex", prefix+hex.EncodeToString(bytes))
+	d.Set("dec", prefix+new(big.Int).SetBytes(bytes).String())
+	return nil
+}
@@ -0,0 +1,69 @@
+package random
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func TestResourceId(t *testing.T) {
+	s, err := schema.TestResourceApply(resourceId(), map[string]interface{}{
+		"byte_length": 4,
+	})
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	bytes, err := hex.DecodeString(s.Attributes["hex"])
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if len(bytes) != 4 {
+		t.Fatalf("bad: %#v", s.Attributes)
+	}
+
+	if s.ID != s.Attributes["b64"] || strings.Contains(s.ID, "=") {
+		t.Fatalf("bad: %s", s.ID)
+	}
+	if s.Attributes["b64_std"] != base64.StdEncoding.EncodeToString(bytes) {
+		t.Fatalf("bad: %#v", s.Attributes)
+	}
+	if s.Attributes["dec"] != new(big.Int).SetBytes(bytes).String() {
+		t.Fatalf("bad: %#v", s.Attributes)
+	}
+}
+
+func TestResourceId_prefix(t *testing.T) {
+	s, err := schema.TestResourceApply(resourceId(), map[string]interface{}{
+		"byte_length": 8,
+		"prefix":      "bucket-",
+	})
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	for _, k := range []string{"b64", "b64_std", "hex", "dec"} {
+		if !strings.HasPrefix(s.Attributes[k], "bucket-") {
+			t.Fatalf("bad %s: %#v", k, s.Attributes)
+		}
+	}
+	if strings.HasPrefix(s.ID, "bucket-") {
+		t.Fatalf("bad: %s", s.ID)
+	}
+	if len(s.Attributes["hex"]) != len("bucket-")+16 {
+		t.Fatalf("bad: %#v", s.Attributes)
+	}
+}
+
+func TestResourceId_invalid(t *testing.T) {
+	_, err := schema.TestResourceApply(resourceId(), map[string]interface{}{
+		"byte_length": 0,
+	})
+	if err == nil {
+		t.Fatal("should error")
+	}
+}
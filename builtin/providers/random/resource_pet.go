@@ -0,0 +1,134 @@
+package random
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func resourcePet() *schema.Resource {
+	return &schema.Resource{
+		Create: resourcePetCreate,
+		Read:   resourceNoop,
+		Delete: resourceRemove,
+
+		Schema: map[string]*schema.Schema{
+			"keepers": keepersSchema(),
+
+			"length": &schema.Schema{
+				Type:     schema.TypeInt,
+				Optional: true,
+				ForceNew: true,
+				Default:  2,
+			},
+
+			"prefix": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+
+			"separator": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+				Default:  "-",
+			},
+		},
+	}
+}
+
+func resourcePetCreate(d *schema.ResourceData, meta interface{}) error {
+	length := d.Get("length").(int)
+	if length < 1 {
+		return fmt.Errorf("length must be at least 1")
+	}
+
+	// The name is made of adverbs, an adjective and a name, such as
+	// "quickly-brave-otter", so that it reads as a phrase.
+	rand := newRand("")
+	words := make([]string, 0, length+1)
+	if prefix := d.Get("prefix").(string); prefix != "" {
+		words = append(words, prefix)
+	}
+	for i := 0; i < length-2; i++ {
+		words = append(words, petAdverbs[rand.Intn(len(petAdverbs))])
+	}
+	if length > 1 {
+		words = append(words, petAdjectives[rand.Intn(len(petAdjectives))])
+	}
+	words = append(words, petNames[rand.Intn(len(petNames))])
+
+	d.SetId(strings.Join(words, d.Get("separator").(string)))
+	return nil
+}
+
+var petAdverbs = []string{
+	"abruptly", "actually", "amazingly", "badly", "boldly", "bravely",
+	"briefly", "brightly", "busily", "calmly", "carefully", "cheerfully",
+	"closely", "correctly", "curiously", "daily", "deeply", "eagerly",
+	"easily", "equally", "evenly", "exactly", "fairly", "firmly",
+	"freely", "gently", "gladly", "greatly", "happily", "highly",
+	"honestly", "hugely", "jointly", "kindly", "largely", "lively",
+	"loudly", "merely", "mildly", "mostly", "neatly", "nicely",
+	"openly", "partly", "politely", "possibly", "quickly", "quietly",
+	"rapidly", "rarely", "really", "seemingly", "sharply", "simply",
+	"slowly", "smoothly", "softly", "steadily", "strongly", "surely",
+	"swiftly", "truly", "vastly", "warmly", "wildly", "wisely",
+}
+
+var petAdjectives = []string{
+	"able", "active", "adapted", "amazing", "amused", "big", "bold",
+	"brave", "bright", "busy", "calm", "capital", "careful", "charming",
+	"clever", "cool", "cosmic", "crack", "curious", "driven", "eager",
+	"easy", "enabled", "epic", "exotic", "fair", "famous", "fancy",
+	"fast", "fine", "firm", "fit", "fleet", "fluent", "free", "fresh",
+	"funny", "gentle", "giving", "glad", "golden", "good", "grand",
+	"great", "happy", "hardy", "helpful", "holy", "honest", "humble",
+	"ideal", "intent", "jolly", "keen", "kind", "large", "legal",
+	"liberal", "live", "lucky", "magical", "main", "massive", "merry",
+	"modern", "moving", "natural", "neat", "new", "nice", "noble",
+	"open", "optimal", "perfect", "pleasant", "polite", "precious",
+	"present", "proper", "proud", "quick", "quiet", "rapid", "rare",
+	"ready", "real", "regular", "rich", "right", "robust", "safe",
+	"secure", "sharp", "shining", "smart", "smooth", "solid", "sound",
+	"special", "stable", "steady", "strong", "sunny", "super", "sure",
+	"sweet", "swift", "tender", "tidy", "tight", "touched", "true",
+	"trusty", "united", "up", "usable", "valid", "vast", "verified",
+	"vital", "warm", "welcome", "well", "wise", "witty", "worthy",
+}
+
+var petNames = []string{
+	"albacore", "alpaca", "ant", "antelope", "badger", "bass", "bat",
+	"bear", "beaver", "bee", "bird", "bison", "boar", "bobcat",
+	"buck", "bulldog", "bunny", "buzzard", "calf", "camel", "cardinal",
+	"cat", "cattle", "chamois", "cheetah", "chicken", "chimp", "cicada",
+	"clam", "cobra", "cod", "colt", "condor", "cougar", "cow", "coyote",
+	"crab", "crane", "cricket", "crow", "cub", "deer", "dingo", "dodo",
+	"dog", "dolphin", "donkey", "dory", "dove", "dragon", "duck",
+	"eagle", "eel", "elephant", "elk", "emu", "falcon", "ferret",
+	"finch", "fish", "flamingo", "fly", "foal", "fox", "frog", "gator",
+	"gazelle", "gecko", "gibbon", "giraffe", "gnat", "gnu", "goat",
+	"goose", "gopher", "gorilla", "grizzly", "grouse", "gull", "hare",
+	"hawk", "hen", "heron", "hippo", "horse", "hound", "husky", "ibex",
+	"iguana", "impala", "jackal", "jaguar", "jay", "kid", "kingfish",
+	"kite", "kitten", "koala", "lab", "lamb", "lark", "lemming", "lemur",
+	"leopard", "lion", "lizard", "llama", "lobster", "locust", "lynx",
+	"macaw", "mako", "mallard", "mammal", "mantis", "marlin", "marmot",
+	"martin", "mink", "mole", "molly", "mongoose", "monkey", "moose",
+	"moth", "mouse", "mule", "mustang", "newt", "ocelot", "octopus",
+	"orca", "oriole", "osprey", "ostrich", "otter", "owl", "ox",
+	"oyster", "panda", "panther", "parrot", "peacock", "pegasus",
+	"pelican", "penguin", "pheasant", "pig", "pigeon", "piglet", "pony",
+	"poodle", "possum", "pug", "puma", "pup", "python", "quagga", "quail",
+	"rabbit", "raccoon", "ram", "raptor", "rat", "raven", "reindeer",
+	"robin", "rodent", "rooster", "salmon", "sawfish", "seal", "shark",
+	"sheep", "shrew", "shrimp", "skink", "skunk", "sloth", "slug",
+	"snail", "snake", "snipe", "sparrow", "spider", "squid", "squirrel",
+	"stag", "starling", "stork", "sturgeon", "swan", "swine", "tapir",
+	"teal", "termite", "terrier", "tetra", "tick", "tiger", "toad",
+	"tortoise", "trout", "tuna", "turkey", "turtle", "unicorn", "viper",
+	"vulture", "walrus", "warthog", "wasp", "weasel", "whale", "whippet",
+	"wolf", "wombat", "worm", "wren", "yak", "zebra",
+}
@@ -0,0 +1,36 @@
+package random
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func TestResourcePet(t *testing.T) {
+	cases := []struct {
+		Config map[string]interface{}
+		Sep    string
+		Parts  int
+		Prefix string
+	}{
+		{map[string]interface{}{}, "-", 2, ""},
+		{map[string]interface{}{"length": 1}, "-", 1, ""},
+		{map[string]interface{}{"length": 4, "separator": "_"}, "_", 4, ""},
+		{map[string]interface{}{"prefix": "web"}, "-", 3, "web-"},
+	}
+
+	for i, tc := range cases {
+		s, err := schema.TestResourceApply(resourcePet(), tc.Config)
+		if err != nil {
+			t.Fatalf("%d: err: %s", i, err)
+		}
+
+		if parts := strings.Split(s.ID, tc.Sep); len(parts) != tc.Parts {
+			t.Fatalf("%d: bad: %s", i, s.ID)
+		}
+		if !strings.HasPrefix(s.ID, tc.Prefix) {
+			t.Fatalf("%d: bad: %s", i, s.ID)
+		}
+	}
+}
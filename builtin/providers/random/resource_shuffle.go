@@ -0,0 +1,70 @@
+package random
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func resourceShuffle() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceShuffleCreate,
+		Read:   resourceNoop,
+		Delete: resourceRemove,
+
+		Schema: map[string]*schema.Schema{
+			"keepers": keepersSchema(),
+
+			"input": &schema.Schema{
+				Type:     schema.TypeList,
+				Required: true,
+				ForceNew: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+
+			"result_count": &schema.Schema{
+				Type:     schema.TypeInt,
+				Optional: true,
+				ForceNew: true,
+			},
+
+			"seed": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+
+			"result": &schema.Schema{
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+		},
+	}
+}
+
+func resourceShuffleCreate(d *schema.ResourceData, meta interface{}) error {
+	input := d.Get("input").([]interface{})
+	count := d.Get("result_count").(int)
+	if count < 0 {
+		return fmt.Errorf("result_count can't be negative")
+	}
+	if count == 0 {
+		count = len(input)
+	}
+
+	// More results than inputs repeat the inputs, in the same order,
+	// which keeps them spread as evenly as possible.
+	result := make([]interface{}, 0, count)
+	if len(input) > 0 {
+		rand := newRand(d.Get("seed").(string))
+		perm := rand.Perm(len(input))
+		for i := 0; i < count; i++ {
+			result = append(result, input[perm[i%len(perm)]])
+		}
+	}
+
+	d.SetId("-")
+	d.Set("result", result)
+	return nil
+}
@@ -0,0 +1,87 @@
+package random
+
+import (
+	"reflect"
+	"sort"
+	"strconv"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestResourceShuffle(t *testing.T) {
+	input := []interface{}{"a", "b", "c", "d", "e"}
+	cases := []struct {
+		Count    int
+		Expected []string
+	}{
+		{0, []string{"a", "b", "c", "d", "e"}},
+		{3, nil},
+		{7, nil},
+	}
+
+	for i, tc := range cases {
+		raw := map[string]interface{}{
+			"input": input,
+			"seed":  "us-west-2",
+		}
+		if tc.Count > 0 {
+			raw["result_count"] = tc.Count
+		}
+
+		s, err := schema.TestResourceApply(resourceShuffle(), raw)
+		if err != nil {
+			t.Fatalf("%d: err: %s", i, err)
+		}
+
+		result := testShuffleResult(s)
+		count := tc.Count
+		if count == 0 {
+			count = len(input)
+		}
+		if len(result) != count {
+			t.Fatalf("%d: bad: %#v", i, result)
+		}
+
+		if tc.Expected != nil {
+			sorted := append([]string(nil), result...)
+			sort.Strings(sorted)
+			if !reflect.DeepEqual(sorted, tc.Expected) {
+				t.Fatalf("%d: bad: %#v", i, result)
+			}
+		}
+
+		// The same seed always gives the same result
+		s, err = schema.TestResourceApply(resourceShuffle(), raw)
+		if err != nil {
+			t.Fatalf("%d: err: %s", i, err)
+		}
+		if actual := testShuffleResult(s); !reflect.DeepEqual(actual, result) {
+			t.Fatalf("%d: bad: %#v %#v", i, actual, result)
+		}
+	}
+}
+
+func TestResourceShuffle_empty(t *testing.T) {
+	s, err := schema.TestResourceApply(resourceShuffle(), map[string]interface{}{
+		"input":        []interface{}{},
+		"result_count": 2,
+	})
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if result := testShuffleResult(s); len(result) != 0 {
+		t.Fatalf("bad: %#v", result)
+	}
+}
+
+func testShuffleResult(s *terraform.InstanceState) []string {
+	count, _ := strconv.Atoi(s.Attributes["result.#"])
+	result := make([]string, count)
+	for i := range result {
+		result[i] = s.Attributes["result."+strconv.Itoa(i)]
+	}
+
+	return result
+}
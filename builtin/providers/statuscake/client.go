@@ -0,0 +1,269 @@
+package statuscake
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/httpapi"
+)
+
+// Client is a client for the StatusCake API.
+//
+// The API answers most failed requests with a 200 status and the reason
+// in the body, which are returned as a StatusError with that status. The
+// code is set to 404 for the objects that don't exist, as found by the
+// client.
+type Client struct {
+	api *httpapi.Client
+}
+
+// newClient returns a client for the API at the given endpoint.
+func newClient(endpoint, username, apiKey string) *Client {
+	return &Client{
+		api: &httpapi.Client{
+			BaseURL: endpoint,
+			Name:    "StatusCake",
+			Header: http.Header{
+				"Username": []string{username},
+				"Api":      []string{apiKey},
+			},
+		},
+	}
+}
+
+// Test is an uptime check, which StatusCake calls a test.
+type Test struct {
+	TestID        int                `json:"TestID"`
+	TestType      string             `json:"TestType"`
+	Paused        bool               `json:"Paused"`
+	WebsiteName   string             `json:"WebsiteName"`
+	URI           string             `json:"URI"`
+	CheckRate     int                `json:"CheckRate"`
+	Timeout       int                `json:"Timeout"`
+	TriggerRate   int                `json:"TriggerRate"`
+	Port          int                `json:"Port"`
+	FindString    string             `json:"FindString"`
+	DoNotFind     bool               `json:"DoNotFind"`
+	ContactGroups []TestContactGroup `json:"ContactGroups"`
+}
+
+// TestContactGroup is a contact group that is alerted by a test.
+type TestContactGroup struct {
+	ID   int    `json:"ID"`
+	Name string `json:"Name"`
+}
+
+// ContactGroup is a group of contacts that are alerted together when a
+// test fails.
+type ContactGroup struct {
+	ContactID int      `json:"ContactID"`
+	GroupName string   `json:"GroupName"`
+	Emails    []string `json:"Emails"`
+	Mobiles   []string `json:"Mobiles"`
+	PingURL   string   `json:"PingURL"`
+}
+
+// result is the answer of the API to a change.
+type result struct {
+	Success  bool        `json:"Success"`
+	Message  string      `json:"Message"`
+	Issues   interface{} `json:"Issues"`
+	InsertID int         `json:"InsertID"`
+}
+
+// Test returns the test with the given ID.
+func (c *Client) Test(id int) (*Test, error) {
+	var details struct {
+		Test
+		Error string `json:"Error"`
+	}
+	params := url.Values{"TestID": {strconv.Itoa(id)}}
+	if err := c.do("GET", "/Tests/Details", params, &details); err != nil {
+		return nil, err
+	}
+	if details.Error == "" && details.TestID != 0 {
+		return &details.Test, nil
+	}
+
+	// The details of a test that doesn't exist are an error like any
+	// other, so the list of tests tells whether it is gone.
+	var tests []Test
+	if err := c.do("GET", "/Tests", nil, &tests); err != nil {
+		return nil, err
+	}
+	for _, t := range tests {
+		if t.TestID == id {
+			return nil, &httpapi.StatusError{Code: http.StatusOK, Message: details.Error}
+		}
+	}
+
+	return nil, &httpapi.StatusError{
+		Code:    http.StatusNotFound,
+		Message: fmt.Sprintf("test %d not found", id),
+	}
+}
+
+// SaveTest creates the test, or updates it if it has an ID, and returns
+// its ID.
+func (c *Client) SaveTest(t *Test) (int, error) {
+	params := url.Values{
+		"WebsiteName": {t.WebsiteName},
+		"WebsiteURL":  {t.URI},
+		"TestType":    {t.TestType},
+		"CheckRate":   {strconv.Itoa(t.CheckRate)},
+		"Paused":      {formatFlag(t.Paused)},
+		"FindString":  {t.FindString},
+		"DoNotFind":   {formatFlag(t.DoNotFind)},
+	}
+	if t.TestID != 0 {
+		params.Set("TestID", strconv.Itoa(t.TestID))
+	}
+	if t.Timeout != 0 {
+		params.Set("Timeout", strconv.Itoa(t.Timeout))
+	}
+	if t.TriggerRate != 0 {
+		params.Set("TriggerRate", strconv.Itoa(t.TriggerRate))
+	}
+	if t.Port != 0 {
+		params.Set("Port", strconv.Itoa(t.Port))
+	}
+
+	ids := make([]string, len(t.ContactGroups))
+	for i, g := range t.ContactGroups {
+		ids[i] = strconv.Itoa(g.ID)
+	}
+	params.Set("ContactGroup", strings.Join(ids, ","))
+
+	return c.save("/Tests/Update", t.TestID, params)
+}
+
+// DeleteTest deletes the test with the given ID.
+func (c *Client) DeleteTest(id int) error {
+	var r result
+	params := url.Values{"TestID": {strconv.Itoa(id)}}
+	if err := c.do("DELETE", "/Tests/Details", params, &r); err != nil {
+		return err
+	}
+
+	return r.err()
+}
+
+// ContactGroup returns the contact group with the given ID.
+func (c *Client) ContactGroup(id int) (*ContactGroup, error) {
+	var groups []ContactGroup
+	if err := c.do("GET", "/ContactGroups", nil, &groups); err != nil {
+		return nil, err
+	}
+
+	for _, g := range groups {
+		if g.ContactID == id {
+			return &g, nil
+		}
+	}
+
+	return nil, &httpapi.StatusError{
+		Code:    http.StatusNotFound,
+		Message: fmt.Sprintf("contact group %d not found", id),
+	}
+}
+
+// SaveContactGroup creates the contact group, or updates it if it has an
+// ID, and returns its ID.
+func (c *Client) SaveContactGroup(g *ContactGroup) (int, error) {
+	params := url.Values{
+		"GroupName": {g.GroupName},
+		"Email":     {strings.Join(g.Emails, ",")},
+		"Mobile":    {strings.Join(g.Mobiles, ",")},
+		"PingURL":   {g.PingURL},
+	}
+	if g.ContactID != 0 {
+		params.Set("ContactID", strconv.Itoa(g.ContactID))
+	}
+
+	return c.save("/ContactGroups/Update", g.ContactID, params)
+}
+
+// DeleteContactGroup deletes the contact group with the given ID.
+func (c *Client) DeleteContactGroup(id int) error {
+	var r result
+	params := url.Values{"ContactID": {strconv.Itoa(id)}}
+	if err := c.do("DELETE", "/ContactGroups/Update", params, &r); err != nil {
+		return err
+	}
+
+	return r.err()
+}
+
+// save creates or updates an object with the given parameters, and
+// returns its ID, which is the given one for an update.
+func (c *Client) save(path string, id int, params url.Values) (int, error) {
+	var r result
+	if err := c.do("PUT", path, params, &r); err != nil {
+		return 0, err
+	}
+	if err := r.err(); err != nil {
+		return 0, err
+	}
+
+	if id != 0 {
+		return id, nil
+	}
+	if r.InsertID == 0 {
+		return 0, fmt.Errorf("no ID in the answer: %s", r.Message)
+	}
+
+	return r.InsertID, nil
+}
+
+// err returns the error of a failed change, with the problems of its
+// parameters.
+func (r *result) err() error {
+	if r.Success {
+		return nil
+	}
+
+	message := r.Message
+	if issues := formatIssues(r.Issues); issues != "" {
+		message = fmt.Sprintf("%s (%s)", message, issues)
+	}
+	return &httpapi.StatusError{Code: http.StatusOK, Message: message}
+}
+
+func (c *Client) do(method, path string, params url.Values, out interface{}) error {
+	var contentType string
+	var body []byte
+	if method == "PUT" {
+		contentType = "application/x-www-form-urlencoded"
+		body = []byte(params.Encode())
+	} else if len(params) > 0 {
+		path += "?" + params.Encode()
+	}
+
+	raw, _, err := c.api.Send(method, path, contentType, body)
+	if err != nil {
+		return err
+	}
+
+	// Requests that aren't authenticated are answered with an error
+	// object, whatever the object that was asked for.
+	var status struct {
+		ErrNo *int   `json:"ErrNo"`
+		Error string `json:"Error"`
+	}
+	if err := json.Unmarshal(raw, &status); err == nil && status.ErrNo != nil &&
+		strings.Contains(strings.ToLower(status.Error), "authentication") {
+		return &httpapi.StatusError{Code: http.StatusUnauthorized, Message: status.Error}
+	}
+
+	if out == nil {
+		return nil
+	}
+	if err := json.Unmarshal(raw, out); err != nil {
+		return fmt.Errorf("Unexpected StatusCake answer %q: %s", raw, err)
+	}
+	return nil
+}
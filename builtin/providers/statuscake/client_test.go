@@ -0,0 +1,132 @@
+package statuscake
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/httpapi"
+)
+
+func testClient(t *testing.T, h http.HandlerFunc) (*Client, func()) {
+	server := httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			if r.Header.Get("Username") != "user" || r.Header.Get("API") != "key" {
+				t.Errorf("bad auth headers: %#v", r.Header)
+			}
+			h(w, r)
+		}))
+
+	return newClient(server.URL, "user", "key"), server.Close
+}
+
+func TestClientSaveTest(t *testing.T) {
+	client, done := testClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "PUT" || r.URL.Path != "/Tests/Update" {
+			t.Errorf("bad request: %s %s", r.Method, r.URL.Path)
+		}
+		if err := r.ParseForm(); err != nil {
+			t.Errorf("err: %s", err)
+		}
+		if v := r.PostForm.Get("WebsiteURL"); v != "https://example.com" {
+			t.Errorf("bad WebsiteURL: %s", v)
+		}
+		if v := r.PostForm.Get("ContactGroup"); v != "1,2" {
+			t.Errorf("bad ContactGroup: %s", v)
+		}
+		if v := r.PostForm.Get("Paused"); v != "1" {
+			t.Errorf("bad Paused: %s", v)
+		}
+		if _, ok := r.PostForm["TestID"]; ok {
+			t.Error("TestID should not be set when creating a test")
+		}
+		fmt.Fprint(w, `{"Success":true,"Message":"Test Inserted","InsertID":42}`)
+	})
+	defer done()
+
+	id, err := client.SaveTest(&Test{
+		WebsiteName:   "example",
+		URI:           "https://example.com",
+		TestType:      "HTTP",
+		CheckRate:     300,
+		Paused:        true,
+		ContactGroups: []TestContactGroup{{ID: 1}, {ID: 2}},
+	})
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if id != 42 {
+		t.Fatalf("bad: %d", id)
+	}
+}
+
+func TestClientSaveTest_issues(t *testing.T) {
+	client, done := testClient(t, func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"Success":false,"Message":"Required Data is Missing.","Issues":{"WebsiteURL":"Invalid URL"}}`)
+	})
+	defer done()
+
+	_, err := client.SaveTest(&Test{TestType: "HTTP"})
+	if err == nil {
+		t.Fatal("should error")
+	}
+	expected := "200: Required Data is Missing. (WebsiteURL: Invalid URL)"
+	if err.Error() != expected {
+		t.Fatalf("bad: %s", err)
+	}
+}
+
+func TestClientTest_notFound(t *testing.T) {
+	client, done := testClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/Tests/Details":
+			if v := r.URL.Query().Get("TestID"); v != "42" {
+				t.Errorf("bad TestID: %s", v)
+			}
+			fmt.Fprint(w, `{"ErrNo":1,"Error":"No results found"}`)
+		case "/Tests":
+			fmt.Fprint(w, `[{"TestID":7,"WebsiteName":"other"}]`)
+		default:
+			t.Errorf("bad path: %s", r.URL.Path)
+		}
+	})
+	defer done()
+
+	_, err := client.Test(42)
+	if !httpapi.IsNotFound(err) {
+		t.Fatalf("bad: %v", err)
+	}
+}
+
+func TestClientContactGroup(t *testing.T) {
+	client, done := testClient(t, func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `[{"ContactID":5,"GroupName":"ops","Emails":["ops@example.com"],"Mobiles":[],"PingURL":""}]`)
+	})
+	defer done()
+
+	g, err := client.ContactGroup(5)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if g.GroupName != "ops" || len(g.Emails) != 1 {
+		t.Fatalf("bad: %#v", g)
+	}
+
+	if _, err := client.ContactGroup(6); !httpapi.IsNotFound(err) {
+		t.Fatalf("bad: %v", err)
+	}
+}
+
+func TestClient_authentication(t *testing.T) {
+	client, done := testClient(t, func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"ErrNo":0,"Error":"API Authentication Failed"}`)
+	})
+	defer done()
+
+	_, err := client.ContactGroup(5)
+	se, ok := err.(*httpapi.StatusError)
+	if !ok || se.Code != http.StatusUnauthorized {
+		t.Fatalf("bad: %v", err)
+	}
+}
@@ -0,0 +1,20 @@
+package statuscake
+
+import "fmt"
+
+// DefaultEndpoint is the URL of the StatusCake API.
+const DefaultEndpoint = "https://app.statuscake.com/API"
+
+type Config struct {
+	Username string
+	APIKey   string
+}
+
+// Client returns a new client for the StatusCake API.
+func (c *Config) Client() (*Client, error) {
+	if c.Username == "" || c.APIKey == "" {
+		return nil, fmt.Errorf("StatusCake username and API key must be set")
+	}
+
+	return newClient(DefaultEndpoint, c.Username, c.APIKey), nil
+}
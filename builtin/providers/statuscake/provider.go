@@ -0,0 +1,46 @@
+package statuscake
+
+import (
+	"log"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+// Provider returns a terraform.ResourceProvider.
+func Provider() terraform.ResourceProvider {
+	return &schema.Provider{
+		Schema: map[string]*schema.Schema{
+			"username": &schema.Schema{
+				Type:        schema.TypeString,
+				Required:    true,
+				DefaultFunc: schema.EnvDefaultFunc("STATUSCAKE_USERNAME", nil),
+				Description: "The username of the StatusCake account.",
+			},
+
+			"apikey": &schema.Schema{
+				Type:        schema.TypeString,
+				Required:    true,
+				DefaultFunc: schema.EnvDefaultFunc("STATUSCAKE_APIKEY", nil),
+				Description: "The API key of the StatusCake account.",
+			},
+		},
+
+		ResourcesMap: map[string]*schema.Resource{
+			"statuscake_check":         resourceStatusCakeCheck(),
+			"statuscake_contact_group": resourceStatusCakeContactGroup(),
+		},
+
+		ConfigureFunc: providerConfigure,
+	}
+}
+
+func providerConfigure(d *schema.ResourceData) (interface{}, error) {
+	config := Config{
+		Username: d.Get("username").(string),
+		APIKey:   d.Get("apikey").(string),
+	}
+
+	log.Println("[INFO] Initializing StatusCake client")
+	return config.Client()
+}
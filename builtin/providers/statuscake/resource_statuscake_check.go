@@ -0,0 +1,217 @@
+package statuscake
+
+import (
+	"fmt"
+	"log"
+	"strconv"
+
+	"github.com/hashicorp/terraform/helper/httpapi"
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func resourceStatusCakeCheck() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceStatusCakeCheckCreate,
+		Read:   resourceStatusCakeCheckRead,
+		Update: resourceStatusCakeCheckUpdate,
+		Delete: resourceStatusCakeCheckDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"website_name": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+			},
+
+			"website_url": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+			},
+
+			"test_type": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  "HTTP",
+			},
+
+			"check_rate": &schema.Schema{
+				Type:     schema.TypeInt,
+				Optional: true,
+				Default:  300,
+			},
+
+			"timeout": &schema.Schema{
+				Type:     schema.TypeInt,
+				Optional: true,
+				Computed: true,
+			},
+
+			"trigger_rate": &schema.Schema{
+				Type:     schema.TypeInt,
+				Optional: true,
+				Computed: true,
+			},
+
+			"port": &schema.Schema{
+				Type:     schema.TypeInt,
+				Optional: true,
+			},
+
+			"find_string": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"do_not_find": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+
+			"paused": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+
+			"contact_groups": &schema.Schema{
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Set:      schema.HashString,
+			},
+		},
+	}
+}
+
+func resourceStatusCakeCheckCreate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Client)
+
+	t, err := expandTest(d)
+	if err != nil {
+		return err
+	}
+
+	log.Printf("[DEBUG] Creating StatusCake check: %s", t.WebsiteName)
+	id, err := client.SaveTest(t)
+	if err != nil {
+		return fmt.Errorf(
+			"Error creating StatusCake check %s: %s", t.WebsiteName, err)
+	}
+
+	d.SetId(strconv.Itoa(id))
+	log.Printf("[INFO] StatusCake check created: %s", d.Id())
+
+	return resourceStatusCakeCheckRead(d, meta)
+}
+
+func resourceStatusCakeCheckRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Client)
+
+	id, err := parseId(d.Id())
+	if err != nil {
+		return err
+	}
+
+	t, err := client.Test(id)
+	if err != nil {
+		if httpapi.IsNotFound(err) {
+			d.SetId("")
+			return nil
+		}
+		return fmt.Errorf("Error reading StatusCake check %s: %s", d.Id(), err)
+	}
+
+	d.Set("website_name", t.WebsiteName)
+	d.Set("website_url", t.URI)
+	d.Set("test_type", t.TestType)
+	d.Set("check_rate", t.CheckRate)
+	d.Set("timeout", t.Timeout)
+	d.Set("trigger_rate", t.TriggerRate)
+	d.Set("find_string", t.FindString)
+	d.Set("do_not_find", t.DoNotFind)
+	d.Set("paused", t.Paused)
+	if err := d.Set("contact_groups", flattenContactGroups(t.ContactGroups)); err != nil {
+		return err
+	}
+
+	// The details of a test only have its port if it has one
+	if t.Port != 0 {
+		d.Set("port", t.Port)
+	}
+
+	return nil
+}
+
+func resourceStatusCakeCheckUpdate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Client)
+
+	t, err := expandTest(d)
+	if err != nil {
+		return err
+	}
+	if t.TestID, err = parseId(d.Id()); err != nil {
+		return err
+	}
+
+	log.Printf("[DEBUG] Updating StatusCake check: %s", d.Id())
+	if _, err := client.SaveTest(t); err != nil {
+		return fmt.Errorf("Error updating StatusCake check %s: %s", d.Id(), err)
+	}
+
+	return resourceStatusCakeCheckRead(d, meta)
+}
+
+func resourceStatusCakeCheckDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Client)
+
+	id, err := parseId(d.Id())
+	if err != nil {
+		return err
+	}
+
+	log.Printf("[INFO] Deleting StatusCake check: %s", d.Id())
+	if err := client.DeleteTest(id); err != nil {
+		if httpapi.IsNotFound(err) {
+			return nil
+		}
+		return fmt.Errorf("Error deleting StatusCake check %s: %s", d.Id(), err)
+	}
+
+	d.SetId("")
+	return nil
+}
+
+// expandTest returns the test of the resource, without its ID.
+func expandTest(d *schema.ResourceData) (*Test, error) {
+	typ := d.Get("test_type").(string)
+	if err := validateTestType(typ); err != nil {
+		return nil, err
+	}
+
+	port := d.Get("port").(int)
+	if typ == "TCP" && port == 0 {
+		return nil, fmt.Errorf("port must be set for TCP checks")
+	}
+
+	groups, err := expandContactGroups(d.Get("contact_groups").(*schema.Set))
+	if err != nil {
+		return nil, err
+	}
+
+	return &Test{
+		WebsiteName:   d.Get("website_name").(string),
+		URI:           d.Get("website_url").(string),
+		TestType:      typ,
+		CheckRate:     d.Get("check_rate").(int),
+		Timeout:       d.Get("timeout").(int),
+		TriggerRate:   d.Get("trigger_rate").(int),
+		Port:          port,
+		FindString:    d.Get("find_string").(string),
+		DoNotFind:     d.Get("do_not_find").(bool),
+		Paused:        d.Get("paused").(bool),
+		ContactGroups: groups,
+	}, nil
+}
@@ -0,0 +1,130 @@
+package statuscake
+
+import (
+	"fmt"
+	"strconv"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/httpapi"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestAccStatusCakeCheck_basic(t *testing.T) {
+	var test Test
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckStatusCakeCheckDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccStatusCakeCheckConfig_basic,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckStatusCakeCheckExists("statuscake_check.test", &test),
+					resource.TestCheckResourceAttr(
+						"statuscake_check.test", "check_rate", "300"),
+					resource.TestCheckResourceAttr(
+						"statuscake_check.test", "contact_groups.#", "1"),
+				),
+			},
+			resource.TestStep{
+				Config: testAccStatusCakeCheckConfig_update,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckStatusCakeCheckExists("statuscake_check.test", &test),
+					resource.TestCheckResourceAttr(
+						"statuscake_check.test", "check_rate", "600"),
+					resource.TestCheckResourceAttr(
+						"statuscake_check.test", "paused", "true"),
+					func(*terraform.State) error {
+						if test.FindString != "Example Domain" {
+							return fmt.Errorf("bad find string: %s", test.FindString)
+						}
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckStatusCakeCheckExists(n string, test *Test) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No check ID is set")
+		}
+
+		id, err := strconv.Atoi(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+
+		client := testAccProvider.Meta().(*Client)
+		result, err := client.Test(id)
+		if err != nil {
+			return err
+		}
+
+		*test = *result
+		return nil
+	}
+}
+
+func testAccCheckStatusCakeCheckDestroy(s *terraform.State) error {
+	client := testAccProvider.Meta().(*Client)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "statuscake_check" {
+			continue
+		}
+
+		id, err := strconv.Atoi(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+
+		_, err = client.Test(id)
+		if err == nil {
+			return fmt.Errorf("Check still exists: %s", rs.Primary.ID)
+		}
+		if !httpapi.IsNotFound(err) {
+			return err
+		}
+	}
+
+	return nil
+}
+
+const testAccStatusCakeCheckConfig_basic = `
+resource "statuscake_contact_group" "test" {
+	name = "terraform-acc-test"
+	emails = ["terraform-acc-test@example.com"]
+}
+
+resource "statuscake_check" "test" {
+	website_name = "terraform-acc-test"
+	website_url = "https://www.example.com"
+	contact_groups = ["${statuscake_contact_group.test.id}"]
+}
+`
+
+const testAccStatusCakeCheckConfig_update = `
+resource "statuscake_contact_group" "test" {
+	name = "terraform-acc-test"
+	emails = ["terraform-acc-test@example.com"]
+}
+
+resource "statuscake_check" "test" {
+	website_name = "terraform-acc-test"
+	website_url = "https://www.example.com"
+	check_rate = 600
+	find_string = "Example Domain"
+	paused = true
+	contact_groups = ["${statuscake_contact_group.test.id}"]
+}
+`
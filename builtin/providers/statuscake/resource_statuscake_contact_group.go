@@ -0,0 +1,146 @@
+package statuscake
+
+import (
+	"fmt"
+	"log"
+	"strconv"
+
+	"github.com/hashicorp/terraform/helper/httpapi"
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func resourceStatusCakeContactGroup() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceStatusCakeContactGroupCreate,
+		Read:   resourceStatusCakeContactGroupRead,
+		Update: resourceStatusCakeContactGroupUpdate,
+		Delete: resourceStatusCakeContactGroupDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"name": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+			},
+
+			"emails": &schema.Schema{
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Set:      schema.HashString,
+			},
+
+			"mobiles": &schema.Schema{
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Set:      schema.HashString,
+			},
+
+			"ping_url": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+		},
+	}
+}
+
+func resourceStatusCakeContactGroupCreate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Client)
+
+	g := expandContactGroup(d)
+	log.Printf("[DEBUG] Creating StatusCake contact group: %s", g.GroupName)
+	id, err := client.SaveContactGroup(g)
+	if err != nil {
+		return fmt.Errorf(
+			"Error creating StatusCake contact group %s: %s", g.GroupName, err)
+	}
+
+	d.SetId(strconv.Itoa(id))
+	log.Printf("[INFO] StatusCake contact group created: %s", d.Id())
+
+	return resourceStatusCakeContactGroupRead(d, meta)
+}
+
+func resourceStatusCakeContactGroupRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Client)
+
+	id, err := parseId(d.Id())
+	if err != nil {
+		return err
+	}
+
+	g, err := client.ContactGroup(id)
+	if err != nil {
+		if httpapi.IsNotFound(err) {
+			d.SetId("")
+			return nil
+		}
+		return fmt.Errorf(
+			"Error reading StatusCake contact group %s: %s", d.Id(), err)
+	}
+
+	d.Set("name", g.GroupName)
+	d.Set("ping_url", g.PingURL)
+	if err := d.Set("emails", g.Emails); err != nil {
+		return err
+	}
+	if err := d.Set("mobiles", g.Mobiles); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func resourceStatusCakeContactGroupUpdate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Client)
+
+	g := expandContactGroup(d)
+	id, err := parseId(d.Id())
+	if err != nil {
+		return err
+	}
+	g.ContactID = id
+
+	log.Printf("[DEBUG] Updating StatusCake contact group: %s", d.Id())
+	if _, err := client.SaveContactGroup(g); err != nil {
+		return fmt.Errorf(
+			"Error updating StatusCake contact group %s: %s", d.Id(), err)
+	}
+
+	return resourceStatusCakeContactGroupRead(d, meta)
+}
+
+func resourceStatusCakeContactGroupDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Client)
+
+	id, err := parseId(d.Id())
+	if err != nil {
+		return err
+	}
+
+	log.Printf("[INFO] Deleting StatusCake contact group: %s", d.Id())
+	if err := client.DeleteContactGroup(id); err != nil {
+		if httpapi.IsNotFound(err) {
+			return nil
+		}
+		return fmt.Errorf(
+			"Error deleting StatusCake contact group %s: %s", d.Id(), err)
+	}
+
+	d.SetId("")
+	return nil
+}
+
+// expandContactGroup returns the contact group of the resource, without
+// its ID.
+func expandContactGroup(d *schema.ResourceData) *ContactGroup {
+	return &ContactGroup{
+		GroupName: d.Get("name").(string),
+		Emails:    expandStringSet(d.Get("emails").(*schema.Set)),
+		Mobiles:   expandStringSet(d.Get("mobiles").(*schema.Set)),
+		PingURL:   d.Get("ping_url").(string),
+	}
+}
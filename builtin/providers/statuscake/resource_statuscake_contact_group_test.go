@@ -0,0 +1,117 @@
+package statuscake
+
+import (
+	"fmt"
+	"strconv"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/httpapi"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestAccStatusCakeContactGroup_basic(t *testing.T) {
+	var group ContactGroup
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckStatusCakeContactGroupDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccStatusCakeContactGroupConfig_basic,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckStatusCakeContactGroupExists(
+						"statuscake_contact_group.test", &group),
+					resource.TestCheckResourceAttr(
+						"statuscake_contact_group.test", "emails.#", "1"),
+				),
+			},
+			resource.TestStep{
+				Config: testAccStatusCakeContactGroupConfig_update,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckStatusCakeContactGroupExists(
+						"statuscake_contact_group.test", &group),
+					resource.TestCheckResourceAttr(
+						"statuscake_contact_group.test", "emails.#", "2"),
+					func(*terraform.State) error {
+						if group.PingURL != "https://hooks.example.com/statuscake" {
+							return fmt.Errorf("bad ping URL: %s", group.PingURL)
+						}
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckStatusCakeContactGroupExists(n string, group *ContactGroup) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No contact group ID is set")
+		}
+
+		id, err := strconv.Atoi(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+
+		client := testAccProvider.Meta().(*Client)
+		result, err := client.ContactGroup(id)
+		if err != nil {
+			return err
+		}
+
+		*group = *result
+		return nil
+	}
+}
+
+func testAccCheckStatusCakeContactGroupDestroy(s *terraform.State) error {
+	client := testAccProvider.Meta().(*Client)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "statuscake_contact_group" {
+			continue
+		}
+
+		id, err := strconv.Atoi(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+
+		_, err = client.ContactGroup(id)
+		if err == nil {
+			return fmt.Errorf("Contact group still exists: %s", rs.Primary.ID)
+		}
+		if !httpapi.IsNotFound(err) {
+			return err
+		}
+	}
+
+	return nil
+}
+
+const testAccStatusCakeContactGroupConfig_basic = `
+resource "statuscake_contact_group" "test" {
+	name = "terraform-acc-test"
+	emails = ["terraform-acc-test@example.com"]
+}
+`
+
+const testAccStatusCakeContactGroupConfig_update = `
+resource "statuscake_contact_group" "test" {
+	name = "terraform-acc-test"
+	emails = [
+		"terraform-acc-test@example.com",
+		"terraform-acc-test-2@example.com",
+	]
+	ping_url = "https://hooks.example.com/statuscake"
+}
+`
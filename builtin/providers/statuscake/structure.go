@@ -0,0 +1,113 @@
+package statuscake
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+// testTypes are the types of tests, which are the protocols that the
+// tests check the websites with.
+var testTypes = []string{"DNS", "HEAD", "HTTP", "PING", "SMTP", "SSH", "TCP"}
+
+// validateTestType returns an error unless the type is one of testTypes.
+func validateTestType(typ string) error {
+	for _, t := range testTypes {
+		if typ == t {
+			return nil
+		}
+	}
+
+	return fmt.Errorf(
+		"Invalid test type %q, expected one of %s",
+		typ, strings.Join(testTypes, ", "))
+}
+
+// expandContactGroups returns the contact groups of a test for the set
+// of their IDs in the configuration.
+func expandContactGroups(s *schema.Set) ([]TestContactGroup, error) {
+	result := make([]TestContactGroup, 0, s.Len())
+	for _, raw := range s.List() {
+		id, err := strconv.Atoi(raw.(string))
+		if err != nil {
+			return nil, fmt.Errorf("Invalid contact group ID %q", raw)
+		}
+		result = append(result, TestContactGroup{ID: id})
+	}
+
+	return result, nil
+}
+
+// flattenContactGroups returns the IDs of the contact groups of a test,
+// as they are in the configuration.
+func flattenContactGroups(groups []TestContactGroup) []string {
+	result := make([]string, 0, len(groups))
+	for _, g := range groups {
+		result = append(result, strconv.Itoa(g.ID))
+	}
+
+	return result
+}
+
+// expandStringSet returns the strings of the set, in order.
+func expandStringSet(s *schema.Set) []string {
+	result := make([]string, 0, s.Len())
+	for _, raw := range s.List() {
+		result = append(result, raw.(string))
+	}
+	sort.Strings(result)
+
+	return result
+}
+
+// formatFlag returns the value of a boolean parameter of the API.
+func formatFlag(b bool) string {
+	if b {
+		return "1"
+	}
+
+	return "0"
+}
+
+// formatIssues returns the problems with the parameters of a request, as
+// returned by the API, which are a message for each parameter, a list of
+// messages, or a single message.
+func formatIssues(issues interface{}) string {
+	switch v := issues.(type) {
+	case string:
+		return v
+	case []interface{}:
+		messages := make([]string, 0, len(v))
+		for _, m := range v {
+			messages = append(messages, fmt.Sprint(m))
+		}
+		return strings.Join(messages, "; ")
+	case map[string]interface{}:
+		keys := make([]string, 0, len(v))
+		for k := range v {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+
+		messages := make([]string, 0, len(v))
+		for _, k := range keys {
+			messages = append(messages, fmt.Sprintf("%s: %v", k, v[k]))
+		}
+		return strings.Join(messages, "; ")
+	default:
+		return ""
+	}
+}
+
+// parseId returns the numeric ID of a resource.
+func parseId(id string) (int, error) {
+	result, err := strconv.Atoi(id)
+	if err != nil {
+		return 0, fmt.Errorf("Unexpected ID %q, expected a number", id)
+	}
+
+	return result, nil
+}
@@ -0,0 +1,57 @@
+package statuscake
+
+import (
+	"testing"
+)
+
+func TestValidateTestType(t *testing.T) {
+	for _, typ := range []string{"HTTP", "TCP", "PING"} {
+		if err := validateTestType(typ); err != nil {
+			t.Fatalf("%s: err: %s", typ, err)
+		}
+	}
+
+	for _, typ := range []string{"", "http", "UDP"} {
+		if err := validateTestType(typ); err == nil {
+			t.Fatalf("%s: should error", typ)
+		}
+	}
+}
+
+func TestFormatIssues(t *testing.T) {
+	cases := []struct {
+		Issues   interface{}
+		Expected string
+	}{
+		{nil, ""},
+		{"CheckRate too low", "CheckRate too low"},
+		{[]interface{}{"a", "b"}, "a; b"},
+		{
+			map[string]interface{}{
+				"WebsiteURL": "Invalid URL",
+				"CheckRate":  "Too low",
+			},
+			"CheckRate: Too low; WebsiteURL: Invalid URL",
+		},
+	}
+
+	for _, tc := range cases {
+		if actual := formatIssues(tc.Issues); actual != tc.Expected {
+			t.Fatalf("%#v: bad: %q", tc.Issues, actual)
+		}
+	}
+}
+
+func TestParseId(t *testing.T) {
+	id, err := parseId("1234")
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if id != 1234 {
+		t.Fatalf("bad: %d", id)
+	}
+
+	if _, err := parseId("abc"); err == nil {
+		t.Fatal("should error")
+	}
+}
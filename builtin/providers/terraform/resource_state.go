@@ -1,9 +1,12 @@
 package terraform
 
 import (
+	"fmt"
 	"log"
+	"strings"
 	"time"
 
+	"github.com/hashicorp/terraform/config"
 	"github.com/hashicorp/terraform/helper/schema"
 	"github.com/hashicorp/terraform/state/remote"
 )
@@ -41,14 +44,14 @@ func resourceRemoteStateCreate(d *schema.ResourceData, meta interface{}) error {
 
 func resourceRemoteStateRead(d *schema.ResourceData, meta interface{}) error {
 	backend := d.Get("backend").(string)
-	config := make(map[string]string)
+	conf := make(map[string]string)
 	for k, v := range d.Get("config").(map[string]interface{}) {
-		config[k] = v.(string)
+		conf[k] = v.(string)
 	}
 
 	// Create the client to access our remote state
 	log.Printf("[DEBUG] Initializing remote state client: %s", backend)
-	client, err := remote.NewClient(backend, config)
+	client, err := remote.NewClient(backend, conf)
 	if err != nil {
 		return err
 	}
@@ -60,9 +63,27 @@ func resourceRemoteStateRead(d *schema.ResourceData, meta interface{}) error {
 		return err
 	}
 
-	var outputs map[string]string
+	// Flatten the outputs into strings. Lists are joined so that they
+	// can be expanded like splat variables, and map outputs are exposed
+	// as "output.name.key".
+	outputs := make(map[string]string)
 	if !state.State().Empty() {
-		outputs = state.State().RootModule().Outputs
+		for k, raw := range state.State().RootModule().Outputs {
+			switch v := raw.(type) {
+			case []interface{}:
+				parts := make([]string, len(v))
+				for i, e := range v {
+					parts[i] = fmt.Sprintf("%v", e)
+				}
+				outputs[k] = strings.Join(parts, config.InterpSplitDelim)
+			case map[string]interface{}:
+				for mk, mv := range v {
+					outputs[fmt.Sprintf("%s.%s", k, mk)] = fmt.Sprintf("%v", mv)
+				}
+			default:
+				outputs[k] = fmt.Sprintf("%v", v)
+			}
+		}
 	}
 
 	d.SetId(time.Now().UTC().String())
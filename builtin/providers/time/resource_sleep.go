@@ -0,0 +1,109 @@
+package time
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func resourceSleep() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceSleepCreate,
+		Read:   resourceSleepRead,
+		Delete: resourceSleepDelete,
+
+		Schema: map[string]*schema.Schema{
+			"create_duration": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+
+			"destroy_duration": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+
+			"triggers": &schema.Schema{
+				Type:     schema.TypeMap,
+				Optional: true,
+				ForceNew: true,
+			},
+		},
+	}
+}
+
+func resourceSleepCreate(d *schema.ResourceData, meta interface{}) error {
+	// Both durations are checked now, so that a bad destroy_duration
+	// doesn't stop the resource from being destroyed later.
+	create, err := sleepDuration(d, "create_duration")
+	if err != nil {
+		return err
+	}
+	if _, err := sleepDuration(d, "destroy_duration"); err != nil {
+		return err
+	}
+
+	if err := sleep(d, create); err != nil {
+		return err
+	}
+
+	d.SetId(time.Now().UTC().Format(time.RFC3339))
+	return nil
+}
+
+func resourceSleepRead(d *schema.ResourceData, meta interface{}) error {
+	// There is nothing to read, the resource only exists in the state
+	return nil
+}
+
+func resourceSleepDelete(d *schema.ResourceData, meta interface{}) error {
+	destroy, err := sleepDuration(d, "destroy_duration")
+	if err != nil {
+		return err
+	}
+
+	if err := sleep(d, destroy); err != nil {
+		return err
+	}
+
+	d.SetId("")
+	return nil
+}
+
+// sleepDuration returns the duration in the given attribute, which is
+// zero if it isn't set.
+func sleepDuration(d *schema.ResourceData, k string) (time.Duration, error) {
+	raw := d.Get(k).(string)
+	if raw == "" {
+		return 0, nil
+	}
+
+	result, err := time.ParseDuration(raw)
+	if err != nil {
+		return 0, fmt.Errorf("invalid %s %q: %s", k, raw, err)
+	}
+	if result < 0 {
+		return 0, fmt.Errorf("invalid %s %q: can't be negative", k, raw)
+	}
+
+	return result, nil
+}
+
+// sleep waits for the given duration, or until Terraform is interrupted.
+func sleep(d *schema.ResourceData, duration time.Duration) error {
+	if duration == 0 {
+		return nil
+	}
+
+	log.Printf("[DEBUG] Sleeping for %s", duration)
+	select {
+	case <-time.After(duration):
+		return nil
+	case <-d.StopCh():
+		return fmt.Errorf("interrupted while sleeping for %s", duration)
+	}
+}
@@ -0,0 +1,50 @@
+package time
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestResourceSleep(t *testing.T) {
+	r := resourceSleep()
+
+	start := time.Now()
+	s, err := schema.TestResourceApply(r, map[string]interface{}{
+		"create_duration":  "50ms",
+		"destroy_duration": "100ms",
+	})
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if elapsed := time.Since(start); elapsed < 50*time.Millisecond {
+		t.Fatalf("should sleep on create: %s", elapsed)
+	}
+	if _, err := time.Parse(time.RFC3339, s.ID); err != nil {
+		t.Fatalf("bad: %s", s.ID)
+	}
+
+	start = time.Now()
+	if _, err := r.Apply(s, &terraform.InstanceDiff{Destroy: true}, nil); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if elapsed := time.Since(start); elapsed < 100*time.Millisecond {
+		t.Fatalf("should sleep on destroy: %s", elapsed)
+	}
+}
+
+func TestResourceSleep_invalid(t *testing.T) {
+	cases := []map[string]interface{}{
+		{"create_duration": "nope"},
+		{"create_duration": "-1s"},
+		{"destroy_duration": "30"},
+	}
+
+	for i, raw := range cases {
+		if _, err := schema.TestResourceApply(resourceSleep(), raw); err == nil {
+			t.Fatalf("%d: should error", i)
+		}
+	}
+}
@@ -0,0 +1,263 @@
+package tls
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha1"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/hex"
+	"encoding/pem"
+	"fmt"
+	"net"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+// Provider returns a terraform.ResourceProvider.
+func Provider() terraform.ResourceProvider {
+	return &schema.Provider{
+		ResourcesMap: map[string]*schema.Resource{
+			"tls_private_key":         resourcePrivateKey(),
+			"tls_cert_request":        resourceCertRequest(),
+			"tls_self_signed_cert":    resourceSelfSignedCert(),
+			"tls_locally_signed_cert": resourceLocallySignedCert(),
+		},
+	}
+}
+
+// keyAlgos generates a private key of each algorithm, from the settings
+// of a tls_private_key.
+var keyAlgos = map[string]func(d *schema.ResourceData) (interface{}, error){
+	"RSA": func(d *schema.ResourceData) (interface{}, error) {
+		return rsa.GenerateKey(rand.Reader, d.Get("rsa_bits").(int))
+	},
+	"ECDSA": func(d *schema.ResourceData) (interface{}, error) {
+		var curve elliptic.Curve
+		switch name := d.Get("ecdsa_curve").(string); name {
+		case "P224":
+			curve = elliptic.P224()
+		case "P256":
+			curve = elliptic.P256()
+		case "P384":
+			curve = elliptic.P384()
+		case "P521":
+			curve = elliptic.P521()
+		default:
+			return nil, fmt.Errorf(
+				"invalid ecdsa_curve %q: must be P224, P256, P384 or P521", name)
+		}
+
+		return ecdsa.GenerateKey(curve, rand.Reader)
+	},
+}
+
+// keyParsers parses a PEM encoded private key of each algorithm.
+var keyParsers = map[string]func(der []byte) (interface{}, error){
+	"RSA": func(der []byte) (interface{}, error) {
+		return x509.ParsePKCS1PrivateKey(der)
+	},
+	"ECDSA": func(der []byte) (interface{}, error) {
+		return x509.ParseECPrivateKey(der)
+	},
+}
+
+// hashForState returns the SHA1 of a value, which is stored in the state
+// instead of the value itself for private keys and other large values.
+// Changes to the value still change the hash, so they are still diffed.
+func hashForState(v interface{}) string {
+	value, ok := v.(string)
+	if !ok || value == "" {
+		return ""
+	}
+
+	hash := sha1.Sum([]byte(strings.TrimSpace(value)))
+	return hex.EncodeToString(hash[:])
+}
+
+// decodePEM returns the bytes of the PEM block in the given attribute,
+// which must be of the given type.
+func decodePEM(d *schema.ResourceData, pemKey, pemType string) ([]byte, error) {
+	block, _ := pem.Decode([]byte(d.Get(pemKey).(string)))
+	if block == nil {
+		return nil, fmt.Errorf("no PEM block found in %s", pemKey)
+	}
+	if pemType != "" && block.Type != pemType {
+		return nil, fmt.Errorf(
+			"invalid PEM type in %s: %s (expected %s)", pemKey, block.Type, pemType)
+	}
+
+	return block.Bytes, nil
+}
+
+// parsePrivateKey parses the private key in the given attribute, using
+// the algorithm in the other given attribute.
+func parsePrivateKey(d *schema.ResourceData, pemKey, algoKey string) (interface{}, error) {
+	algoName := d.Get(algoKey).(string)
+	parser, ok := keyParsers[algoName]
+	if !ok {
+		return nil, fmt.Errorf(
+			"invalid %s %q: must be RSA or ECDSA", algoKey, algoName)
+	}
+
+	der, err := decodePEM(d, pemKey, "")
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := parser(der)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode %s: %s", pemKey, err)
+	}
+
+	return key, nil
+}
+
+// publicKey returns the public key of a private key.
+func publicKey(priv interface{}) interface{} {
+	switch k := priv.(type) {
+	case *rsa.PrivateKey:
+		return &k.PublicKey
+	case *ecdsa.PrivateKey:
+		return &k.PublicKey
+	default:
+		return nil
+	}
+}
+
+// subjectSchema is the schema of the subject of certificates and
+// certificate requests.
+func subjectSchema() *schema.Schema {
+	field := func() *schema.Schema {
+		return &schema.Schema{
+			Type:     schema.TypeString,
+			Optional: true,
+			ForceNew: true,
+		}
+	}
+
+	return &schema.Schema{
+		Type:     schema.TypeList,
+		Required: true,
+		ForceNew: true,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"common_name":         field(),
+				"organization":        field(),
+				"organizational_unit": field(),
+				"street_address": &schema.Schema{
+					Type:     schema.TypeList,
+					Optional: true,
+					ForceNew: true,
+					Elem:     &schema.Schema{Type: schema.TypeString},
+				},
+				"locality":      field(),
+				"province":      field(),
+				"country":       field(),
+				"postal_code":   field(),
+				"serial_number": field(),
+			},
+		},
+	}
+}
+
+// requestSchema returns the schema of the settings that certificate
+// requests and self-signed certificates share: the key, the subject and
+// the alternative names.
+func requestSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"key_algorithm": &schema.Schema{
+			Type:     schema.TypeString,
+			Required: true,
+			ForceNew: true,
+		},
+
+		"private_key_pem": &schema.Schema{
+			Type:      schema.TypeString,
+			Required:  true,
+			ForceNew:  true,
+			StateFunc: hashForState,
+		},
+
+		"subject": subjectSchema(),
+
+		"dns_names": &schema.Schema{
+			Type:     schema.TypeList,
+			Optional: true,
+			ForceNew: true,
+			Elem:     &schema.Schema{Type: schema.TypeString},
+		},
+
+		"ip_addresses": &schema.Schema{
+			Type:     schema.TypeList,
+			Optional: true,
+			ForceNew: true,
+			Elem:     &schema.Schema{Type: schema.TypeString},
+		},
+	}
+}
+
+// requestFromResourceData returns a certificate request with the subject
+// and alternative names of the resource.
+func requestFromResourceData(d *schema.ResourceData) (*x509.CertificateRequest, error) {
+	subjects := d.Get("subject").([]interface{})
+	if len(subjects) != 1 {
+		return nil, fmt.Errorf("exactly one subject must be given")
+	}
+	subject, ok := subjects[0].(map[string]interface{})
+	if !ok {
+		subject = make(map[string]interface{})
+	}
+
+	result := &x509.CertificateRequest{
+		Subject: nameFromMap(subject),
+	}
+	for _, v := range d.Get("dns_names").([]interface{}) {
+		result.DNSNames = append(result.DNSNames, v.(string))
+	}
+	for _, v := range d.Get("ip_addresses").([]interface{}) {
+		ip := net.ParseIP(v.(string))
+		if ip == nil {
+			return nil, fmt.Errorf("invalid IP address %q", v.(string))
+		}
+		result.IPAddresses = append(result.IPAddresses, ip)
+	}
+
+	return result, nil
+}
+
+// nameFromMap returns the name in a subject block.
+func nameFromMap(m map[string]interface{}) pkix.Name {
+	str := func(k string) string {
+		v, _ := m[k].(string)
+		return v
+	}
+	list := func(k string) []string {
+		if v := str(k); v != "" {
+			return []string{v}
+		}
+		return nil
+	}
+
+	result := pkix.Name{
+		CommonName:         str("common_name"),
+		SerialNumber:       str("serial_number"),
+		Organization:       list("organization"),
+		OrganizationalUnit: list("organizational_unit"),
+		Locality:           list("locality"),
+		Province:           list("province"),
+		Country:            list("country"),
+		PostalCode:         list("postal_code"),
+	}
+	if raw, ok := m["street_address"].([]interface{}); ok {
+		for _, v := range raw {
+			result.StreetAddress = append(result.StreetAddress, v.(string))
+		}
+	}
+
+	return result
+}
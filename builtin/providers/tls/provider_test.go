@@ -0,0 +1,40 @@
+package tls
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func TestProvider(t *testing.T) {
+	if err := Provider().(*schema.Provider).InternalValidate(); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+}
+
+func TestHashForState(t *testing.T) {
+	if v := hashForState(""); v != "" {
+		t.Fatalf("bad: %s", v)
+	}
+	if hashForState("foo\n") != hashForState("foo") {
+		t.Fatal("surrounding space should be ignored")
+	}
+	if hashForState("foo") == hashForState("bar") {
+		t.Fatal("different values should have different hashes")
+	}
+}
+
+// testPrivateKey creates a tls_private_key with the given algorithm,
+// returning its PEM.
+func testPrivateKey(t *testing.T, algo string) string {
+	s, err := schema.TestResourceApply(resourcePrivateKey(), map[string]interface{}{
+		"algorithm":   algo,
+		"rsa_bits":    1024,
+		"ecdsa_curve": "P256",
+	})
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	return s.Attributes["private_key_pem"]
+}
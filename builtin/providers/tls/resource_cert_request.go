@@ -0,0 +1,60 @@
+package tls
+
+import (
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func resourceCertRequest() *schema.Resource {
+	s := requestSchema()
+	s["cert_request_pem"] = &schema.Schema{
+		Type:     schema.TypeString,
+		Computed: true,
+	}
+
+	return &schema.Resource{
+		Create: resourceCertRequestCreate,
+		Read:   resourceCertRequestRead,
+		Delete: resourceCertRequestDelete,
+		Schema: s,
+	}
+}
+
+func resourceCertRequestCreate(d *schema.ResourceData, meta interface{}) error {
+	key, err := parsePrivateKey(d, "private_key_pem", "key_algorithm")
+	if err != nil {
+		return err
+	}
+
+	template, err := requestFromResourceData(d)
+	if err != nil {
+		return err
+	}
+
+	der, err := x509.CreateCertificateRequest(rand.Reader, template, key)
+	if err != nil {
+		return fmt.Errorf("error creating certificate request: %s", err)
+	}
+	block := &pem.Block{
+		Type:  "CERTIFICATE REQUEST",
+		Bytes: der,
+	}
+
+	d.SetId(hashForState(string(der)))
+	d.Set("cert_request_pem", string(pem.EncodeToMemory(block)))
+	return nil
+}
+
+func resourceCertRequestRead(d *schema.ResourceData, meta interface{}) error {
+	// The request only exists in the state
+	return nil
+}
+
+func resourceCertRequestDelete(d *schema.ResourceData, meta interface{}) error {
+	d.SetId("")
+	return nil
+}
@@ -0,0 +1,92 @@
+package tls
+
+import (
+	"crypto/x509"
+	"encoding/pem"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func TestResourceCertRequest(t *testing.T) {
+	key := testPrivateKey(t, "ECDSA")
+	s, err := schema.TestResourceApply(resourceCertRequest(), map[string]interface{}{
+		"key_algorithm":   "ECDSA",
+		"private_key_pem": key,
+		"subject": []interface{}{
+			map[string]interface{}{
+				"common_name":    "example.com",
+				"organization":   "Example, Inc",
+				"street_address": []interface{}{"1 Main St"},
+			},
+		},
+		"dns_names":    []interface{}{"example.com", "example.net"},
+		"ip_addresses": []interface{}{"127.0.0.1"},
+	})
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	// The key itself must not be stored
+	if s.Attributes["private_key_pem"] != hashForState(key) {
+		t.Fatalf("bad: %#v", s.Attributes)
+	}
+
+	block, _ := pem.Decode([]byte(s.Attributes["cert_request_pem"]))
+	if block == nil || block.Type != "CERTIFICATE REQUEST" {
+		t.Fatalf("bad: %#v", s.Attributes)
+	}
+	req, err := x509.ParseCertificateRequest(block.Bytes)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	if req.Subject.CommonName != "example.com" {
+		t.Fatalf("bad: %#v", req.Subject)
+	}
+	if !reflect.DeepEqual(req.Subject.Organization, []string{"Example, Inc"}) {
+		t.Fatalf("bad: %#v", req.Subject)
+	}
+	if !reflect.DeepEqual(req.Subject.StreetAddress, []string{"1 Main St"}) {
+		t.Fatalf("bad: %#v", req.Subject)
+	}
+	if !reflect.DeepEqual(req.DNSNames, []string{"example.com", "example.net"}) {
+		t.Fatalf("bad: %#v", req.DNSNames)
+	}
+	if len(req.IPAddresses) != 1 || req.IPAddresses[0].String() != "127.0.0.1" {
+		t.Fatalf("bad: %#v", req.IPAddresses)
+	}
+}
+
+func TestResourceCertRequest_invalid(t *testing.T) {
+	key := testPrivateKey(t, "RSA")
+	subject := []interface{}{
+		map[string]interface{}{"common_name": "example.com"},
+	}
+
+	cases := []map[string]interface{}{
+		{
+			"key_algorithm":   "ECDSA",
+			"private_key_pem": key,
+			"subject":         subject,
+		},
+		{
+			"key_algorithm":   "RSA",
+			"private_key_pem": "nope",
+			"subject":         subject,
+		},
+		{
+			"key_algorithm":   "RSA",
+			"private_key_pem": key,
+			"subject":         subject,
+			"ip_addresses":    []interface{}{"nope"},
+		},
+	}
+
+	for i, raw := range cases {
+		if _, err := schema.TestResourceApply(resourceCertRequest(), raw); err == nil {
+			t.Fatalf("%d: should error", i)
+		}
+	}
+}
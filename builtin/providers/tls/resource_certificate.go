@@ -0,0 +1,171 @@
+package tls
+
+import (
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+// keyUsages and extKeyUsages are the values of allowed_uses.
+var keyUsages = map[string]x509.KeyUsage{
+	"digital_signature":  x509.KeyUsageDigitalSignature,
+	"content_commitment": x509.KeyUsageContentCommitment,
+	"key_encipherment":   x509.KeyUsageKeyEncipherment,
+	"data_encipherment":  x509.KeyUsageDataEncipherment,
+	"key_agreement":      x509.KeyUsageKeyAgreement,
+	"cert_signing":       x509.KeyUsageCertSign,
+	"crl_signing":        x509.KeyUsageCRLSign,
+	"encipher_only":      x509.KeyUsageEncipherOnly,
+	"decipher_only":      x509.KeyUsageDecipherOnly,
+}
+
+var extKeyUsages = map[string]x509.ExtKeyUsage{
+	"any_extended":                  x509.ExtKeyUsageAny,
+	"server_auth":                   x509.ExtKeyUsageServerAuth,
+	"client_auth":                   x509.ExtKeyUsageClientAuth,
+	"code_signing":                  x509.ExtKeyUsageCodeSigning,
+	"email_protection":              x509.ExtKeyUsageEmailProtection,
+	"ipsec_end_system":              x509.ExtKeyUsageIPSECEndSystem,
+	"ipsec_tunnel":                  x509.ExtKeyUsageIPSECTunnel,
+	"ipsec_user":                    x509.ExtKeyUsageIPSECUser,
+	"timestamping":                  x509.ExtKeyUsageTimeStamping,
+	"ocsp_signing":                  x509.ExtKeyUsageOCSPSigning,
+	"microsoft_server_gated_crypto": x509.ExtKeyUsageMicrosoftServerGatedCrypto,
+	"netscape_server_gated_crypto":  x509.ExtKeyUsageNetscapeServerGatedCrypto,
+}
+
+// certificateSchema adds the settings and results that self-signed and
+// locally signed certificates share to the given schema.
+func certificateSchema(s map[string]*schema.Schema) map[string]*schema.Schema {
+	s["validity_period_hours"] = &schema.Schema{
+		Type:     schema.TypeInt,
+		Required: true,
+		ForceNew: true,
+	}
+
+	s["early_renewal_hours"] = &schema.Schema{
+		Type:     schema.TypeInt,
+		Optional: true,
+		ForceNew: true,
+		Default:  0,
+	}
+
+	s["is_ca_certificate"] = &schema.Schema{
+		Type:     schema.TypeBool,
+		Optional: true,
+		ForceNew: true,
+	}
+
+	s["allowed_uses"] = &schema.Schema{
+		Type:     schema.TypeList,
+		Required: true,
+		ForceNew: true,
+		Elem:     &schema.Schema{Type: schema.TypeString},
+	}
+
+	s["cert_pem"] = &schema.Schema{
+		Type:     schema.TypeString,
+		Computed: true,
+	}
+
+	s["validity_start_time"] = &schema.Schema{
+		Type:     schema.TypeString,
+		Computed: true,
+	}
+
+	s["validity_end_time"] = &schema.Schema{
+		Type:     schema.TypeString,
+		Computed: true,
+	}
+
+	return s
+}
+
+// createCertificate signs the certificate in the template with the given
+// parent and private key, completing the template with the validity and
+// uses of the resource, and sets the results of the resource.
+func createCertificate(
+	d *schema.ResourceData,
+	template, parent *x509.Certificate,
+	pub, priv interface{}) error {
+	serialLimit := new(big.Int).Lsh(big.NewInt(1), 128)
+	serial, err := rand.Int(rand.Reader, serialLimit)
+	if err != nil {
+		return fmt.Errorf("error generating serial number: %s", err)
+	}
+	template.SerialNumber = serial
+
+	hours := d.Get("validity_period_hours").(int)
+	if hours <= 0 {
+		return fmt.Errorf("validity_period_hours must be positive")
+	}
+	template.NotBefore = time.Now().UTC()
+	template.NotAfter = template.NotBefore.Add(time.Duration(hours) * time.Hour)
+
+	template.BasicConstraintsValid = true
+	template.IsCA = d.Get("is_ca_certificate").(bool)
+	for _, v := range d.Get("allowed_uses").([]interface{}) {
+		use := v.(string)
+		if usage, ok := keyUsages[use]; ok {
+			template.KeyUsage |= usage
+		} else if usage, ok := extKeyUsages[use]; ok {
+			template.ExtKeyUsage = append(template.ExtKeyUsage, usage)
+		} else {
+			return fmt.Errorf("invalid allowed_uses %q", use)
+		}
+	}
+
+	pubDer, err := x509.MarshalPKIXPublicKey(pub)
+	if err != nil {
+		return fmt.Errorf("error encoding public key: %s", err)
+	}
+	keyID := sha1.Sum(pubDer)
+	template.SubjectKeyId = keyID[:]
+
+	der, err := x509.CreateCertificate(rand.Reader, template, parent, pub, priv)
+	if err != nil {
+		return fmt.Errorf("error creating certificate: %s", err)
+	}
+	block := &pem.Block{
+		Type:  "CERTIFICATE",
+		Bytes: der,
+	}
+
+	d.SetId(serial.String())
+	d.Set("cert_pem", string(pem.EncodeToMemory(block)))
+	d.Set("validity_start_time", template.NotBefore.Format(time.RFC3339))
+	d.Set("validity_end_time", template.NotAfter.Format(time.RFC3339))
+	return nil
+}
+
+// resourceCertificateRead removes certificates that are due for renewal
+// from the state, so that the next plan creates a new one.
+func resourceCertificateRead(d *schema.ResourceData, meta interface{}) error {
+	raw := d.Get("validity_end_time").(string)
+	if raw == "" {
+		return nil
+	}
+
+	end, err := time.Parse(time.RFC3339, raw)
+	if err != nil {
+		return fmt.Errorf("invalid validity_end_time %q: %s", raw, err)
+	}
+
+	early := time.Duration(d.Get("early_renewal_hours").(int)) * time.Hour
+	if !time.Now().Before(end.Add(-early)) {
+		d.SetId("")
+	}
+
+	return nil
+}
+
+func resourceCertificateDelete(d *schema.ResourceData, meta interface{}) error {
+	d.SetId("")
+	return nil
+}
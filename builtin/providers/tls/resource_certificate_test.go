@@ -0,0 +1,199 @@
+package tls
+
+import (
+	"crypto/x509"
+	"encoding/pem"
+	"reflect"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestResourceSelfSignedCert(t *testing.T) {
+	key := testPrivateKey(t, "RSA")
+	s, err := schema.TestResourceApply(resourceSelfSignedCert(), map[string]interface{}{
+		"key_algorithm":   "RSA",
+		"private_key_pem": key,
+		"subject": []interface{}{
+			map[string]interface{}{"common_name": "example.com"},
+		},
+		"dns_names":             []interface{}{"example.com"},
+		"validity_period_hours": 24,
+		"is_ca_certificate":     true,
+		"allowed_uses": []interface{}{
+			"cert_signing",
+			"server_auth",
+		},
+	})
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	if s.Attributes["private_key_pem"] != hashForState(key) {
+		t.Fatalf("bad: %#v", s.Attributes)
+	}
+
+	cert := testCertificate(t, s.Attributes["cert_pem"])
+	if s.ID != cert.SerialNumber.String() {
+		t.Fatalf("bad: %s", s.ID)
+	}
+	if cert.Subject.CommonName != "example.com" {
+		t.Fatalf("bad: %#v", cert.Subject)
+	}
+	if cert.Issuer.CommonName != "example.com" {
+		t.Fatalf("bad: %#v", cert.Issuer)
+	}
+	if !reflect.DeepEqual(cert.DNSNames, []string{"example.com"}) {
+		t.Fatalf("bad: %#v", cert.DNSNames)
+	}
+	if !cert.IsCA {
+		t.Fatal("should be a CA")
+	}
+	if cert.KeyUsage != x509.KeyUsageCertSign {
+		t.Fatalf("bad: %#v", cert.KeyUsage)
+	}
+	if !reflect.DeepEqual(cert.ExtKeyUsage, []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth}) {
+		t.Fatalf("bad: %#v", cert.ExtKeyUsage)
+	}
+	if period := cert.NotAfter.Sub(cert.NotBefore); period != 24*time.Hour {
+		t.Fatalf("bad: %s", period)
+	}
+	if s.Attributes["validity_end_time"] != cert.NotAfter.Format(time.RFC3339) {
+		t.Fatalf("bad: %#v", s.Attributes)
+	}
+
+	if err := cert.CheckSignatureFrom(cert); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+}
+
+func TestResourceSelfSignedCert_invalidUse(t *testing.T) {
+	_, err := schema.TestResourceApply(resourceSelfSignedCert(), map[string]interface{}{
+		"key_algorithm":   "RSA",
+		"private_key_pem": testPrivateKey(t, "RSA"),
+		"subject": []interface{}{
+			map[string]interface{}{"common_name": "example.com"},
+		},
+		"validity_period_hours": 24,
+		"allowed_uses":          []interface{}{"nope"},
+	})
+	if err == nil {
+		t.Fatal("should error")
+	}
+}
+
+func TestResourceLocallySignedCert(t *testing.T) {
+	caKey := testPrivateKey(t, "ECDSA")
+	ca, err := schema.TestResourceApply(resourceSelfSignedCert(), map[string]interface{}{
+		"key_algorithm":   "ECDSA",
+		"private_key_pem": caKey,
+		"subject": []interface{}{
+			map[string]interface{}{"common_name": "Example CA"},
+		},
+		"validity_period_hours": 24,
+		"is_ca_certificate":     true,
+		"allowed_uses":          []interface{}{"cert_signing"},
+	})
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	req, err := schema.TestResourceApply(resourceCertRequest(), map[string]interface{}{
+		"key_algorithm":   "RSA",
+		"private_key_pem": testPrivateKey(t, "RSA"),
+		"subject": []interface{}{
+			map[string]interface{}{"common_name": "node1.example.com"},
+		},
+		"dns_names": []interface{}{"node1.example.com"},
+	})
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	s, err := schema.TestResourceApply(resourceLocallySignedCert(), map[string]interface{}{
+		"cert_request_pem":      req.Attributes["cert_request_pem"],
+		"ca_key_algorithm":      "ECDSA",
+		"ca_private_key_pem":    caKey,
+		"ca_cert_pem":           ca.Attributes["cert_pem"],
+		"validity_period_hours": 12,
+		"allowed_uses": []interface{}{
+			"key_encipherment",
+			"digital_signature",
+			"server_auth",
+			"client_auth",
+		},
+	})
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	if s.Attributes["ca_private_key_pem"] != hashForState(caKey) {
+		t.Fatalf("bad: %#v", s.Attributes)
+	}
+
+	cert := testCertificate(t, s.Attributes["cert_pem"])
+	caCert := testCertificate(t, ca.Attributes["cert_pem"])
+	if cert.Subject.CommonName != "node1.example.com" {
+		t.Fatalf("bad: %#v", cert.Subject)
+	}
+	if cert.Issuer.CommonName != "Example CA" {
+		t.Fatalf("bad: %#v", cert.Issuer)
+	}
+	if !reflect.DeepEqual(cert.DNSNames, []string{"node1.example.com"}) {
+		t.Fatalf("bad: %#v", cert.DNSNames)
+	}
+	if cert.IsCA {
+		t.Fatal("should not be a CA")
+	}
+	if err := cert.CheckSignatureFrom(caCert); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+}
+
+func TestResourceCertificate_renewal(t *testing.T) {
+	now := time.Now()
+	cases := []struct {
+		End   time.Time
+		Early int
+		Keep  bool
+	}{
+		{now.Add(48 * time.Hour), 0, true},
+		{now.Add(48 * time.Hour), 24, true},
+		{now.Add(12 * time.Hour), 24, false},
+		{now.Add(-time.Hour), 0, false},
+	}
+
+	for i, tc := range cases {
+		s := &terraform.InstanceState{
+			ID: "1",
+			Attributes: map[string]string{
+				"validity_end_time":   tc.End.Format(time.RFC3339),
+				"early_renewal_hours": strconv.Itoa(tc.Early),
+			},
+		}
+
+		actual, err := resourceSelfSignedCert().Refresh(s, nil)
+		if err != nil {
+			t.Fatalf("%d: err: %s", i, err)
+		}
+		if (actual != nil) != tc.Keep {
+			t.Fatalf("%d: bad: %#v", i, actual)
+		}
+	}
+}
+
+func testCertificate(t *testing.T, raw string) *x509.Certificate {
+	block, _ := pem.Decode([]byte(raw))
+	if block == nil || block.Type != "CERTIFICATE" {
+		t.Fatalf("bad: %#v", raw)
+	}
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	return cert
+}
@@ -0,0 +1,77 @@
+package tls
+
+import (
+	"crypto/x509"
+	"fmt"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func resourceLocallySignedCert() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceLocallySignedCertCreate,
+		Read:   resourceCertificateRead,
+		Delete: resourceCertificateDelete,
+
+		Schema: certificateSchema(map[string]*schema.Schema{
+			"cert_request_pem": &schema.Schema{
+				Type:      schema.TypeString,
+				Required:  true,
+				ForceNew:  true,
+				StateFunc: hashForState,
+			},
+
+			"ca_key_algorithm": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"ca_private_key_pem": &schema.Schema{
+				Type:      schema.TypeString,
+				Required:  true,
+				ForceNew:  true,
+				StateFunc: hashForState,
+			},
+
+			"ca_cert_pem": &schema.Schema{
+				Type:      schema.TypeString,
+				Required:  true,
+				ForceNew:  true,
+				StateFunc: hashForState,
+			},
+		}),
+	}
+}
+
+func resourceLocallySignedCertCreate(d *schema.ResourceData, meta interface{}) error {
+	der, err := decodePEM(d, "cert_request_pem", "CERTIFICATE REQUEST")
+	if err != nil {
+		return err
+	}
+	req, err := x509.ParseCertificateRequest(der)
+	if err != nil {
+		return fmt.Errorf("failed to decode cert_request_pem: %s", err)
+	}
+
+	caKey, err := parsePrivateKey(d, "ca_private_key_pem", "ca_key_algorithm")
+	if err != nil {
+		return err
+	}
+
+	der, err = decodePEM(d, "ca_cert_pem", "CERTIFICATE")
+	if err != nil {
+		return err
+	}
+	caCert, err := x509.ParseCertificate(der)
+	if err != nil {
+		return fmt.Errorf("failed to decode ca_cert_pem: %s", err)
+	}
+
+	template := &x509.Certificate{
+		Subject:     req.Subject,
+		DNSNames:    req.DNSNames,
+		IPAddresses: req.IPAddresses,
+	}
+	return createCertificate(d, template, caCert, req.PublicKey, caKey)
+}
@@ -0,0 +1,124 @@
+package tls
+
+import (
+	"crypto/ecdsa"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"golang.org/x/crypto/ssh"
+)
+
+func resourcePrivateKey() *schema.Resource {
+	return &schema.Resource{
+		Create: resourcePrivateKeyCreate,
+		Read:   resourcePrivateKeyRead,
+		Delete: resourcePrivateKeyDelete,
+
+		Schema: map[string]*schema.Schema{
+			"algorithm": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"rsa_bits": &schema.Schema{
+				Type:     schema.TypeInt,
+				Optional: true,
+				ForceNew: true,
+				Default:  2048,
+			},
+
+			"ecdsa_curve": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+				Default:  "P224",
+			},
+
+			"private_key_pem": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"public_key_pem": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"public_key_openssh": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourcePrivateKeyCreate(d *schema.ResourceData, meta interface{}) error {
+	algoName := d.Get("algorithm").(string)
+	generate, ok := keyAlgos[algoName]
+	if !ok {
+		return fmt.Errorf("invalid algorithm %q: must be RSA or ECDSA", algoName)
+	}
+
+	key, err := generate(d)
+	if err != nil {
+		return err
+	}
+
+	var keyBlock *pem.Block
+	switch k := key.(type) {
+	case *rsa.PrivateKey:
+		keyBlock = &pem.Block{
+			Type:  "RSA PRIVATE KEY",
+			Bytes: x509.MarshalPKCS1PrivateKey(k),
+		}
+	case *ecdsa.PrivateKey:
+		der, err := x509.MarshalECPrivateKey(k)
+		if err != nil {
+			return fmt.Errorf("error encoding key: %s", err)
+		}
+		keyBlock = &pem.Block{
+			Type:  "EC PRIVATE KEY",
+			Bytes: der,
+		}
+	}
+
+	pub := publicKey(key)
+	pubDer, err := x509.MarshalPKIXPublicKey(pub)
+	if err != nil {
+		return fmt.Errorf("error encoding public key: %s", err)
+	}
+	pubBlock := &pem.Block{
+		Type:  "PUBLIC KEY",
+		Bytes: pubDer,
+	}
+
+	// Not all keys can be used with OpenSSH, such as keys on the P224
+	// curve, so this is left empty for those.
+	var pubSSH string
+	if sshPub, err := ssh.NewPublicKey(pub); err == nil {
+		pubSSH = string(ssh.MarshalAuthorizedKey(sshPub))
+	} else {
+		log.Printf("[DEBUG] Key can't be used with OpenSSH: %s", err)
+	}
+
+	d.SetId(hashForState(string(pubDer)))
+	d.Set("private_key_pem", string(pem.EncodeToMemory(keyBlock)))
+	d.Set("public_key_pem", string(pem.EncodeToMemory(pubBlock)))
+	d.Set("public_key_openssh", pubSSH)
+	return nil
+}
+
+func resourcePrivateKeyRead(d *schema.ResourceData, meta interface{}) error {
+	// The key only exists in the state
+	return nil
+}
+
+func resourcePrivateKeyDelete(d *schema.ResourceData, meta interface{}) error {
+	d.SetId("")
+	return nil
+}
@@ -0,0 +1,101 @@
+package tls
+
+import (
+	"crypto/ecdsa"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func TestResourcePrivateKey(t *testing.T) {
+	cases := []struct {
+		Config  map[string]interface{}
+		PEMType string
+		OpenSSH string
+	}{
+		{
+			map[string]interface{}{"algorithm": "RSA"},
+			"RSA PRIVATE KEY",
+			"ssh-rsa ",
+		},
+		{
+			map[string]interface{}{"algorithm": "ECDSA", "ecdsa_curve": "P256"},
+			"EC PRIVATE KEY",
+			"ecdsa-sha2-nistp256 ",
+		},
+		{
+			map[string]interface{}{"algorithm": "ECDSA"},
+			"EC PRIVATE KEY",
+			"",
+		},
+	}
+
+	for i, tc := range cases {
+		s, err := schema.TestResourceApply(resourcePrivateKey(), tc.Config)
+		if err != nil {
+			t.Fatalf("%d: err: %s", i, err)
+		}
+		if s.ID == "" {
+			t.Fatalf("%d: should have an ID", i)
+		}
+
+		block, _ := pem.Decode([]byte(s.Attributes["private_key_pem"]))
+		if block == nil || block.Type != tc.PEMType {
+			t.Fatalf("%d: bad: %#v", i, s.Attributes)
+		}
+		switch block.Type {
+		case "RSA PRIVATE KEY":
+			key, err := x509.ParsePKCS1PrivateKey(block.Bytes)
+			if err != nil {
+				t.Fatalf("%d: err: %s", i, err)
+			}
+			if key.N.BitLen() != 2048 {
+				t.Fatalf("%d: bad: %d", i, key.N.BitLen())
+			}
+		case "EC PRIVATE KEY":
+			if _, err := x509.ParseECPrivateKey(block.Bytes); err != nil {
+				t.Fatalf("%d: err: %s", i, err)
+			}
+		}
+
+		block, _ = pem.Decode([]byte(s.Attributes["public_key_pem"]))
+		if block == nil || block.Type != "PUBLIC KEY" {
+			t.Fatalf("%d: bad: %#v", i, s.Attributes)
+		}
+		pub, err := x509.ParsePKIXPublicKey(block.Bytes)
+		if err != nil {
+			t.Fatalf("%d: err: %s", i, err)
+		}
+		switch pub.(type) {
+		case *rsa.PublicKey, *ecdsa.PublicKey:
+		default:
+			t.Fatalf("%d: bad: %#v", i, pub)
+		}
+
+		openssh := s.Attributes["public_key_openssh"]
+		if tc.OpenSSH == "" {
+			if openssh != "" {
+				t.Fatalf("%d: bad: %s", i, openssh)
+			}
+		} else if !strings.HasPrefix(openssh, tc.OpenSSH) {
+			t.Fatalf("%d: bad: %s", i, openssh)
+		}
+	}
+}
+
+func TestResourcePrivateKey_invalid(t *testing.T) {
+	cases := []map[string]interface{}{
+		{"algorithm": "DSA"},
+		{"algorithm": "ECDSA", "ecdsa_curve": "P100"},
+	}
+
+	for i, raw := range cases {
+		if _, err := schema.TestResourceApply(resourcePrivateKey(), raw); err == nil {
+			t.Fatalf("%d: should error", i)
+		}
+	}
+}
@@ -0,0 +1,35 @@
+package tls
+
+import (
+	"crypto/x509"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func resourceSelfSignedCert() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceSelfSignedCertCreate,
+		Read:   resourceCertificateRead,
+		Delete: resourceCertificateDelete,
+		Schema: certificateSchema(requestSchema()),
+	}
+}
+
+func resourceSelfSignedCertCreate(d *schema.ResourceData, meta interface{}) error {
+	key, err := parsePrivateKey(d, "private_key_pem", "key_algorithm")
+	if err != nil {
+		return err
+	}
+
+	req, err := requestFromResourceData(d)
+	if err != nil {
+		return err
+	}
+
+	template := &x509.Certificate{
+		Subject:     req.Subject,
+		DNSNames:    req.DNSNames,
+		IPAddresses: req.IPAddresses,
+	}
+	return createCertificate(d, template, template, publicKey(key), key)
+}
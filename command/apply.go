@@ -6,6 +6,7 @@ import (
 	"os"
 	"sort"
 	"strings"
+	"time"
 
 	"github.com/hashicorp/terraform/config/module"
 	"github.com/hashicorp/terraform/terraform"
@@ -25,7 +26,8 @@ type ApplyCommand struct {
 }
 
 func (c *ApplyCommand) Run(args []string) int {
-	var destroyForce, refresh bool
+	var destroyForce, refresh, autoApprove, stalePlan, jsonOutput bool
+	start := time.Now()
 	args = c.Meta.process(args, true)
 
 	cmdName := "apply"
@@ -38,13 +40,25 @@ func (c *ApplyCommand) Run(args []string) int {
 		cmdFlags.BoolVar(&destroyForce, "force", false, "force")
 	}
 	cmdFlags.BoolVar(&refresh, "refresh", true, "refresh")
+	if !c.Destroy {
+		cmdFlags.BoolVar(&autoApprove, "auto-approve", false, "skip approval")
+		cmdFlags.BoolVar(&stalePlan, "force", false, "force")
+		cmdFlags.Var((*FlagStringSlice)(&c.Meta.replace), "replace", "resource to replace")
+	}
 	cmdFlags.StringVar(&c.Meta.statePath, "state", DefaultStateFilename, "path")
 	cmdFlags.StringVar(&c.Meta.stateOutPath, "state-out", "", "path")
 	cmdFlags.StringVar(&c.Meta.backupPath, "backup", "", "path")
+	cmdFlags.DurationVar(&c.Meta.stateLockTimeout, "lock-timeout", 0, "duration")
+	cmdFlags.IntVar(&c.Meta.parallelism, "parallelism", 0, "parallelism")
+	cmdFlags.IntVar(&c.Meta.providerParallelism, "provider-parallelism", 0, "parallelism")
+	cmdFlags.BoolVar(&jsonOutput, "json", false, "json")
 	cmdFlags.Usage = func() { c.Ui.Error(c.Help()) }
 	if err := cmdFlags.Parse(args); err != nil {
 		return 1
 	}
+	if jsonOutput {
+		c.Meta.setJSONOutput()
+	}
 
 	pwd, err := os.Getwd()
 	if err != nil {
@@ -66,10 +80,11 @@ func (c *ApplyCommand) Run(args []string) int {
 		maybeInit = false
 	}
 
-	// Prepare the extra hooks to count resources
+	// Prepare the extra hooks to count resources and record metrics
 	countHook := new(CountHook)
 	stateHook := new(StateHook)
-	c.Meta.extraHooks = []terraform.Hook{countHook, stateHook}
+	metricsHook := new(MetricsHook)
+	c.Meta.extraHooks = []terraform.Hook{countHook, stateHook, metricsHook}
 
 	if !c.Destroy && maybeInit {
 		// Do a detect to determine if we need to do an init + apply.
@@ -92,21 +107,38 @@ func (c *ApplyCommand) Run(args []string) int {
 	}
 
 	// Build the context based on the arguments given
+	defer c.unlockState()
 	ctx, planned, err := c.Context(contextOpts{
 		Destroy:   c.Destroy,
 		Path:      configPath,
 		StatePath: c.Meta.statePath,
+		StalePlan: stalePlan,
+		Lock:      "apply",
 	})
 	if err != nil {
 		c.Ui.Error(err.Error())
 		return 1
 	}
+
 	if c.Destroy && planned {
 		c.Ui.Error(fmt.Sprintf(
 			"Destroy can't be called with a plan file."))
 		return 1
 	}
+	if len(c.Meta.replace) > 0 && planned {
+		c.Ui.Error(
+			"Resources to replace can't be given with a plan file. Use\n" +
+				"-replace when creating the plan instead.")
+		return 1
+	}
 	if !destroyForce && c.Destroy {
+		if jsonOutput {
+			c.Ui.Error(
+				"Destroy requires confirmation, which isn't possible with -json.\n" +
+					"Run with -force to destroy without confirmation.")
+			return 1
+		}
+
 		v, err := c.UIInput().Input(&terraform.InputOpts{
 			Id:    "destroy",
 			Query: "Do you really want to destroy?",
@@ -122,6 +154,12 @@ func (c *ApplyCommand) Run(args []string) int {
 			return 1
 		}
 	}
+	if !autoApprove && !planned && !c.Destroy && jsonOutput {
+		c.Ui.Error(
+			"Apply requires approval of the plan, which isn't possible with -json.\n" +
+				"Run with -auto-approve to apply without approval.")
+		return 1
+	}
 	if !planned {
 		if err := ctx.Input(c.InputMode()); err != nil {
 			c.Ui.Error(fmt.Sprintf("Error configuring: %s", err))
@@ -133,7 +171,12 @@ func (c *ApplyCommand) Run(args []string) int {
 	}
 
 	// Plan if we haven't already
-	if !planned {
+	plan := c.Meta.plan
+	if planned {
+		if !c.checkPolicy(plan) {
+			return 1
+		}
+	} else {
 		if refresh {
 			if _, err := ctx.Refresh(); err != nil {
 				c.Ui.Error(fmt.Sprintf("Error refreshing state: %s", err))
@@ -141,11 +184,29 @@ func (c *ApplyCommand) Run(args []string) int {
 			}
 		}
 
-		if _, err := ctx.Plan(); err != nil {
+		plan, err = ctx.Plan()
+		if err != nil {
 			c.Ui.Error(fmt.Sprintf(
 				"Error creating plan: %s", err))
 			return 1
 		}
+
+		if !c.checkPolicy(plan) {
+			return 1
+		}
+
+		// Show the plan and ask for approval before changing anything,
+		// unless this is a destroy, which was confirmed above.
+		if !c.Destroy && !autoApprove && !plan.Diff.Empty() {
+			if !c.approve(plan) {
+				return 1
+			}
+		}
+	}
+
+	// Tell the JSON events what is going to change
+	if jsonOutput {
+		c.jsonUi.Plan(plan)
 	}
 
 	// Setup the state hook for continous state updates
@@ -160,6 +221,8 @@ func (c *ApplyCommand) Run(args []string) int {
 		stateHook.State = state
 	}
 
+	c.Meta.fireWebhooks(webhookPlanEvent(WebhookEventApplyStart, cmdName, plan))
+
 	// Start the apply in a goroutine so that we can be interrupted.
 	var state *terraform.State
 	var applyErr error
@@ -176,21 +239,47 @@ func (c *ApplyCommand) Run(args []string) int {
 	case <-c.ShutdownCh:
 		c.Ui.Output("Interrupt received. Gracefully shutting down...")
 
-		// Stop execution
+		// Stop execution. This also asks the providers to abort their
+		// in-flight operations, so this shouldn't take long.
 		go ctx.Stop()
 
 		// Still get the result, since there is still one
 		select {
 		case <-c.ShutdownCh:
+			// Write the state as of the last resource that finished, so
+			// that the resources created so far aren't lost.
+			if err := stateHook.Persist(); err != nil {
+				c.Ui.Error(fmt.Sprintf(
+					"Two interrupts received. Exiting immediately. Failed to\n"+
+						"save the state, so data loss may have occurred: %s", err))
+				return 1
+			}
+
 			c.Ui.Error(
-				"Two interrupts received. Exiting immediately. Note that data\n" +
-					"loss may have occurred.")
+				"Two interrupts received. Exiting immediately. The state was\n" +
+					"saved, but may be missing the resources that were being\n" +
+					"changed when Terraform was interrupted.")
 			return 1
 		case <-doneCh:
 		}
 	case <-doneCh:
 	}
 
+	c.Meta.exportMetrics(metricsHook, cmdName, start, applyErr)
+
+	// Tell the webhooks how the apply went
+	{
+		e := webhookPlanEvent(WebhookEventApplySuccess, cmdName, plan)
+		e.Added = countHook.Added
+		e.Changed = countHook.Changed
+		e.Destroyed = countHook.Removed
+		if applyErr != nil {
+			e.Event = WebhookEventApplyFailure
+			e.Error = applyErr.Error()
+		}
+		c.Meta.fireWebhooks(e)
+	}
+
 	// Persist the state
 	if state != nil {
 		if err := c.Meta.PersistState(state); err != nil {
@@ -200,6 +289,10 @@ func (c *ApplyCommand) Run(args []string) int {
 	}
 
 	if applyErr != nil {
+		if jsonOutput {
+			c.jsonSummary(cmdName, countHook)
+		}
+
 		c.Ui.Error(fmt.Sprintf(
 			"Error applying plan:\n\n"+
 				"%s\n\n"+
@@ -211,6 +304,17 @@ func (c *ApplyCommand) Run(args []string) int {
 		return 1
 	}
 
+	if jsonOutput {
+		c.jsonSummary(cmdName, countHook)
+		if !c.Destroy && state != nil && len(state.RootModule().Outputs) > 0 {
+			c.jsonUi.Event(&JSONEvent{
+				Type:    JSONEventOutputs,
+				Outputs: state.RootModule().Outputs,
+			})
+		}
+		return 0
+	}
+
 	c.Ui.Output(c.Colorize().Color(fmt.Sprintf(
 		"[reset][bold][green]\n"+
 			"Apply complete! Resources: %d added, %d changed, %d destroyed.",
@@ -230,7 +334,7 @@ func (c *ApplyCommand) Run(args []string) int {
 	}
 
 	// If we have outputs, then output those at the end.
-	var outputs map[string]string
+	var outputs map[string]interface{}
 	if !c.Destroy && state != nil {
 		outputs = state.RootModule().Outputs
 	}
@@ -256,7 +360,7 @@ func (c *ApplyCommand) Run(args []string) int {
 				"  %s%s = %s\n",
 				k,
 				strings.Repeat(" ", keyLen-len(k)),
-				v))
+				formatOutputValue(v)))
 		}
 
 		c.Ui.Output(c.Colorize().Color(
@@ -266,6 +370,77 @@ func (c *ApplyCommand) Run(args []string) int {
 	return 0
 }
 
+// jsonSummary writes the change_summary event of the apply.
+func (c *ApplyCommand) jsonSummary(operation string, countHook *CountHook) {
+	c.jsonUi.Event(&JSONEvent{
+		Type: JSONEventChangeSummary,
+		Changes: &JSONChangeSummary{
+			Operation: operation,
+			Add:       countHook.Added,
+			Change:    countHook.Changed,
+			Destroy:   countHook.Removed,
+		},
+	})
+}
+
+// approve shows the plan and asks the user to approve it. It returns
+// false if the apply must not continue.
+func (c *ApplyCommand) approve(plan *terraform.Plan) bool {
+	if c.InputMode() == 0 {
+		c.Ui.Error(
+			"Apply requires approval of the plan, but input is disabled.\n" +
+				"Run with -auto-approve to apply without approval.")
+		return false
+	}
+
+	c.Ui.Output(strings.TrimSpace(applyApproveHeader) + "\n")
+	c.Ui.Output(FormatPlan(&FormatPlanOpts{
+		Plan:  plan,
+		Color: c.Colorize(),
+	}))
+
+	v, err := c.UIInput().Input(&terraform.InputOpts{
+		Id:    "approve",
+		Query: "Do you want to apply these changes?",
+		Description: "Terraform will perform the actions described above.\n" +
+			"Only 'yes' will be accepted to approve.",
+	})
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error asking for approval: %s", err))
+		return false
+	}
+	if v != "yes" {
+		c.Ui.Output("Apply cancelled.")
+		return false
+	}
+
+	return true
+}
+
+// checkPolicy runs the policy checks against the plan, and reports
+// whether the plan may be applied.
+func (c *ApplyCommand) checkPolicy(plan *terraform.Plan) bool {
+	violations, err := c.Policy.Check(plan)
+	if err != nil {
+		c.Ui.Error(err.Error())
+		return false
+	}
+	if len(violations) == 0 {
+		return true
+	}
+
+	buf := new(bytes.Buffer)
+	buf.WriteString("The plan was rejected by policy checks:\n")
+	for _, v := range violations {
+		buf.WriteString(fmt.Sprintf("\n%s:\n", v.Check))
+		for _, msg := range v.Messages {
+			buf.WriteString(fmt.Sprintf("  * %s\n", msg))
+		}
+	}
+	c.Ui.Error(strings.TrimSpace(buf.String()))
+	return false
+}
+
 func (c *ApplyCommand) Help() string {
 	if c.Destroy {
 		return c.helpDestroy()
@@ -295,19 +470,51 @@ Usage: terraform apply [options] [DIR]
   directory is empty of Terraform files. This is a shortcut for getting
   started.
 
+  Unless a plan file is given, the execution plan is shown first and
+  must be approved by typing "yes".
+
 Options:
 
+  -auto-approve          Apply without showing the plan and asking for
+                         approval. Required if input is disabled.
+
   -backup=path           Path to backup the existing state file before
                          modifying. Defaults to the "-state-out" path with
                          ".backup" extension. Set to "-" to disable backup.
 
+  -force                 Apply a plan file even if the configuration changed
+                         since the plan was created.
+
   -input=true            Ask for input for variables if not directly set.
 
+  -json                  Write the output as newline-delimited JSON events,
+                         such as the start and end of the changes of each
+                         resource. Input is disabled, so -auto-approve is
+                         required unless a plan file is given.
+
+  -lock-timeout=0s       How long to wait for the lock of the state if it is
+                         locked by another run, such as "5m". By default, the
+                         command fails right away.
+
   -no-color              If specified, output won't contain any color.
 
+  -parallelism=10        Limit the number of operations, such as
+                         refreshing a resource, that run at once.
+
+  -provider-parallelism=n
+                         Limit the number of operations of each provider
+                         that run at once, to stay below the rate limits
+                         of its API. Unlimited by default.
+
   -refresh=true          Update state prior to checking for differences. This
                          has no effect if a plan file is given to apply.
 
+  -replace=resource      Resource to replace. It will be destroyed and
+                         recreated, as if it were tainted. Resources in
+                         modules are given as "module.app.aws_instance.web".
+                         This flag can be used multiple times, but not with
+                         a plan file.
+
   -state=path            Path to read and save state (unless state-out
                          is specified). Defaults to "terraform.tfstate".
 
@@ -345,8 +552,24 @@ Options:
 
   -force                 Don't ask for input for destroy confirmation.
 
+  -json                  Write the output as newline-delimited JSON events,
+                         such as the start and end of the destruction of
+                         each resource. Requires -force.
+
+  -lock-timeout=0s       How long to wait for the lock of the state if it is
+                         locked by another run, such as "5m". By default, the
+                         command fails right away.
+
   -no-color              If specified, output won't contain any color.
 
+  -parallelism=10        Limit the number of operations, such as
+                         refreshing a resource, that run at once.
+
+  -provider-parallelism=n
+                         Limit the number of operations of each provider
+                         that run at once, to stay below the rate limits
+                         of its API. Unlimited by default.
+
   -refresh=true          Update state prior to checking for differences. This
                          has no effect if a plan file is given to apply.
 
@@ -372,3 +595,11 @@ Options:
 `
 	return strings.TrimSpace(helpText)
 }
+
+const applyApproveHeader = `
+Terraform will perform the actions in the execution plan below.
+Resources are shown in alphabetical order for quick scanning. Green resources
+will be created (or destroyed and then created if an existing resource
+exists), yellow resources are being changed in-place, and red resources
+will be destroyed.
+`
@@ -2,6 +2,7 @@ package command
 
 import (
 	"bytes"
+	"encoding/json"
 	"fmt"
 	"io/ioutil"
 	"net"
@@ -10,11 +11,13 @@ import (
 	"os"
 	"path/filepath"
 	"reflect"
+	"runtime"
 	"strings"
 	"sync"
 	"testing"
 	"time"
 
+	"github.com/hashicorp/terraform/config/module"
 	"github.com/hashicorp/terraform/terraform"
 	"github.com/mitchellh/cli"
 )
@@ -32,6 +35,7 @@ func TestApply(t *testing.T) {
 	}
 
 	args := []string{
+		"-auto-approve",
 		"-state", statePath,
 		testFixturePath("apply"),
 	}
@@ -69,6 +73,7 @@ func TestApply_configInvalid(t *testing.T) {
 	}
 
 	args := []string{
+		"-auto-approve",
 		"-state", testTempFile(t),
 		testFixturePath("apply-config-invalid"),
 	}
@@ -104,6 +109,7 @@ func TestApply_defaultState(t *testing.T) {
 	}
 
 	args := []string{
+		"-auto-approve",
 		testFixturePath("apply"),
 	}
 	if code := c.Run(args); code != 0 {
@@ -171,6 +177,7 @@ func TestApply_error(t *testing.T) {
 	}
 
 	args := []string{
+		"-auto-approve",
 		"-state", statePath,
 		testFixturePath("apply-error"),
 	}
@@ -237,6 +244,7 @@ func TestApply_init(t *testing.T) {
 	u.Path = "/header"
 
 	args := []string{
+		"-auto-approve",
 		"-state", statePath,
 		u.String(),
 	}
@@ -267,6 +275,216 @@ func TestApply_init(t *testing.T) {
 	}
 }
 
+func TestApply_approve(t *testing.T) {
+	cases := map[string]int{
+		"yes\n": 0,
+		"no\n":  1,
+	}
+
+	for answer, expected := range cases {
+		// Disable test mode so input would be asked
+		test = false
+
+		defaultInputReader = bytes.NewBufferString(answer)
+		defaultInputWriter = new(bytes.Buffer)
+
+		statePath := testTempFile(t)
+
+		p := testProvider()
+		ui := new(cli.MockUi)
+		c := &ApplyCommand{
+			Meta: Meta{
+				ContextOpts: testCtxConfig(p),
+				Ui:          ui,
+			},
+		}
+
+		args := []string{
+			"-state", statePath,
+			testFixturePath("apply"),
+		}
+		code := c.Run(args)
+		test = true
+		if code != expected {
+			t.Fatalf("%q: bad: %d\n\n%s", answer, code, ui.ErrorWriter.String())
+		}
+
+		// The plan is always shown, but only applied if approved
+		if !strings.Contains(ui.OutputWriter.String(), "test_instance.foo") {
+			t.Fatalf("%q: plan not shown:\n\n%s", answer, ui.OutputWriter.String())
+		}
+		if p.ApplyCalled != (expected == 0) {
+			t.Fatalf("%q: bad: %#v", answer, p.ApplyCalled)
+		}
+	}
+}
+
+func TestApply_approveNoInput(t *testing.T) {
+	statePath := testTempFile(t)
+
+	p := testProvider()
+	ui := new(cli.MockUi)
+	c := &ApplyCommand{
+		Meta: Meta{
+			ContextOpts: testCtxConfig(p),
+			Ui:          ui,
+		},
+	}
+
+	args := []string{
+		"-input=false",
+		"-state", statePath,
+		testFixturePath("apply"),
+	}
+	if code := c.Run(args); code != 1 {
+		t.Fatalf("bad: %d\n\n%s", code, ui.OutputWriter.String())
+	}
+	if p.ApplyCalled {
+		t.Fatal("apply should not be called")
+	}
+}
+
+func TestApply_policy(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("policy check scripts require a shell")
+	}
+
+	statePath := testTempFile(t)
+
+	p := testProvider()
+	ui := new(cli.MockUi)
+	c := &ApplyCommand{
+		Meta: Meta{
+			ContextOpts: testCtxConfig(p),
+			Policy: &Policy{
+				Checks: []string{testPolicyCheck(t, "echo 'not allowed'\nexit 1")},
+			},
+			Ui: ui,
+		},
+	}
+
+	args := []string{
+		"-auto-approve",
+		"-state", statePath,
+		testFixturePath("apply"),
+	}
+	if code := c.Run(args); code != 1 {
+		t.Fatalf("bad: %d\n\n%s", code, ui.OutputWriter.String())
+	}
+	if !strings.Contains(ui.ErrorWriter.String(), "not allowed") {
+		t.Fatalf("bad: %s", ui.ErrorWriter.String())
+	}
+	if p.ApplyCalled {
+		t.Fatal("apply should not be called")
+	}
+	if _, err := os.Stat(statePath); err == nil {
+		t.Fatal("state should not be written")
+	}
+}
+
+func TestApply_metricsFile(t *testing.T) {
+	metricsPath := testTempFile(t)
+	old := os.Getenv(MetricsFileEnvVar)
+	os.Setenv(MetricsFileEnvVar, metricsPath)
+	defer os.Setenv(MetricsFileEnvVar, old)
+
+	statePath := testStateFile(t, testState())
+
+	p := testProvider()
+	p.DiffReturn = &terraform.InstanceDiff{
+		Attributes: map[string]*terraform.ResourceAttrDiff{
+			"ami": &terraform.ResourceAttrDiff{
+				New: "bar",
+			},
+		},
+	}
+
+	ui := new(cli.MockUi)
+	c := &ApplyCommand{
+		Meta: Meta{
+			ContextOpts: testCtxConfig(p),
+			Ui:          ui,
+		},
+	}
+
+	args := []string{
+		"-auto-approve",
+		"-state", statePath,
+		testFixturePath("apply"),
+	}
+	if code := c.Run(args); code != 0 {
+		t.Fatalf("bad: %d\n\n%s", code, ui.ErrorWriter.String())
+	}
+
+	data, err := ioutil.ReadFile(metricsPath)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	var report metricsReport
+	if err := json.Unmarshal(data, &report); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	var ops []string
+	for _, r := range report.Resources {
+		ops = append(ops, r.Address+" "+r.Operation)
+	}
+	expected := []string{"test_instance.foo refresh", "test_instance.foo update"}
+	if !reflect.DeepEqual(ops, expected) {
+		t.Fatalf("bad: %#v\n\n%s", ops, data)
+	}
+	if report.Command != "apply" || report.Providers["test"].Operations != 2 {
+		t.Fatalf("bad: %s", data)
+	}
+}
+
+func TestApply_webhooks(t *testing.T) {
+	server, requests := testWebhookServer(t, http.StatusOK)
+	defer server.Close()
+
+	statePath := testTempFile(t)
+
+	p := testProvider()
+	ui := new(cli.MockUi)
+	c := &ApplyCommand{
+		Meta: Meta{
+			ContextOpts: testCtxConfig(p),
+			Webhooks: &Webhooks{
+				&Webhook{Name: "foo", URL: server.URL},
+			},
+			Ui: ui,
+		},
+	}
+
+	args := []string{
+		"-auto-approve",
+		"-state", statePath,
+		testFixturePath("apply"),
+	}
+	if code := c.Run(args); code != 0 {
+		t.Fatalf("bad: %d\n\n%s", code, ui.ErrorWriter.String())
+	}
+
+	var events []string
+	for _, r := range requests() {
+		var e WebhookEvent
+		if err := json.Unmarshal([]byte(r[1]), &e); err != nil {
+			t.Fatalf("err: %s", err)
+		}
+		if e.Command != "apply" {
+			t.Fatalf("bad: %#v", e)
+		}
+
+		events = append(events, e.Event)
+	}
+
+	expected := []string{WebhookEventApplyStart, WebhookEventApplySuccess}
+	if !reflect.DeepEqual(events, expected) {
+		t.Fatalf("bad: %#v", events)
+	}
+}
+
 func TestApply_input(t *testing.T) {
 	// Disable test mode so input would be asked
 	test = false
@@ -288,6 +506,7 @@ func TestApply_input(t *testing.T) {
 	}
 
 	args := []string{
+		"-auto-approve",
 		"-state", statePath,
 		testFixturePath("apply-input"),
 	}
@@ -322,6 +541,7 @@ func TestApply_noArgs(t *testing.T) {
 	}
 
 	args := []string{
+		"-auto-approve",
 		"-state", statePath,
 	}
 	if code := c.Run(args); code != 0 {
@@ -347,6 +567,34 @@ func TestApply_noArgs(t *testing.T) {
 	}
 }
 
+func TestApply_planReplace(t *testing.T) {
+	planPath := testPlanFile(t, &terraform.Plan{
+		Module: testModule(t, "apply"),
+	})
+	statePath := testTempFile(t)
+
+	p := testProvider()
+	ui := new(cli.MockUi)
+	c := &ApplyCommand{
+		Meta: Meta{
+			ContextOpts: testCtxConfig(p),
+			Ui:          ui,
+		},
+	}
+
+	args := []string{
+		"-state", statePath,
+		"-replace", "test_instance.foo",
+		planPath,
+	}
+	if code := c.Run(args); code != 1 {
+		t.Fatalf("bad: %d\n\n%s", code, ui.OutputWriter.String())
+	}
+	if p.ApplyCalled {
+		t.Fatal("apply should not be called")
+	}
+}
+
 func TestApply_plan(t *testing.T) {
 	// Disable test mode so input would be asked
 	test = false
@@ -401,6 +649,149 @@ func TestApply_plan(t *testing.T) {
 	}
 }
 
+func TestApply_planStale(t *testing.T) {
+	hash, err := configHash(&terraform.Plan{Module: testModule(t, "apply")})
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	cases := []struct {
+		Hash  string
+		Force bool
+		Code  int
+	}{
+		{hash, false, 0},
+		{"", false, 0},
+		{"stale", false, 1},
+		{"stale", true, 0},
+	}
+
+	for i, tc := range cases {
+		planPath := testPlanFile(t, &terraform.Plan{
+			Module:     testModule(t, "apply"),
+			ConfigHash: tc.Hash,
+		})
+		statePath := testTempFile(t)
+
+		p := testProvider()
+		ui := new(cli.MockUi)
+		c := &ApplyCommand{
+			Meta: Meta{
+				ContextOpts: testCtxConfig(p),
+				Ui:          ui,
+			},
+		}
+
+		args := []string{"-state", statePath}
+		if tc.Force {
+			args = append(args, "-force")
+		}
+		args = append(args, planPath)
+		if code := c.Run(args); code != tc.Code {
+			t.Fatalf("%d: bad: %d\n\n%s", i, code, ui.ErrorWriter.String())
+		}
+	}
+}
+
+func TestApply_planStaleVarFile(t *testing.T) {
+	varFilePath := filepath.Join(testTempDir(t), "terraform.tfvars")
+	if err := ioutil.WriteFile(varFilePath, []byte(applyVarFile), 0644); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	plan := &terraform.Plan{
+		Module:   testModule(t, "apply"),
+		VarFiles: []string{varFilePath},
+	}
+	hash, err := configHash(plan)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	plan.ConfigHash = hash
+	planPath := testPlanFile(t, plan)
+
+	// Changing a variable file makes the plan stale
+	if err := ioutil.WriteFile(varFilePath, []byte(`foo = "baz"`), 0644); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	p := testProvider()
+	ui := new(cli.MockUi)
+	c := &ApplyCommand{
+		Meta: Meta{
+			ContextOpts: testCtxConfig(p),
+			Ui:          ui,
+		},
+	}
+
+	args := []string{
+		"-state", testTempFile(t),
+		planPath,
+	}
+	if code := c.Run(args); code != 1 {
+		t.Fatalf("bad: %d\n\n%s", code, ui.ErrorWriter.String())
+	}
+	if p.ApplyCalled {
+		t.Fatal("apply should not be called")
+	}
+}
+
+func TestApply_planMissingConfig(t *testing.T) {
+	dir := testTempDir(t)
+	data, err := ioutil.ReadFile(filepath.Join(testFixturePath("apply"), "main.tf"))
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if err := ioutil.WriteFile(filepath.Join(dir, "main.tf"), data, 0644); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	mod, err := module.NewTreeModule("", dir)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	s := &module.FolderStorage{StorageDir: tempDir(t)}
+	if err := mod.Load(s, module.GetModeGet); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	plan := &terraform.Plan{Module: mod}
+	if plan.ConfigHash, err = configHash(plan); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	planPath := testPlanFile(t, plan)
+
+	// The configuration is gone, so the plan can't be checked
+	if err := os.RemoveAll(dir); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	for i, force := range []bool{false, true} {
+		p := testProvider()
+		ui := new(cli.MockUi)
+		c := &ApplyCommand{
+			Meta: Meta{
+				ContextOpts: testCtxConfig(p),
+				Ui:          ui,
+			},
+		}
+
+		args := []string{"-state", testTempFile(t)}
+		if force {
+			args = append(args, "-force")
+		}
+		args = append(args, planPath)
+
+		expected := 1
+		if force {
+			expected = 0
+		}
+		if code := c.Run(args); code != expected {
+			t.Fatalf("%d: bad: %d\n\n%s", i, code, ui.ErrorWriter.String())
+		}
+	}
+}
+
 func TestApply_plan_remoteState(t *testing.T) {
 	// Disable test mode so input would be asked
 	test = false
@@ -569,6 +960,7 @@ func TestApply_refresh(t *testing.T) {
 	}
 
 	args := []string{
+		"-auto-approve",
 		"-state", statePath,
 		testFixturePath("apply"),
 	}
@@ -679,6 +1071,7 @@ func TestApply_shutdown(t *testing.T) {
 	}()
 
 	args := []string{
+		"-auto-approve",
 		"-state", statePath,
 		testFixturePath("apply-shutdown"),
 	}
@@ -747,6 +1140,7 @@ func TestApply_state(t *testing.T) {
 
 	// Run the apply command pointing to our existing state
 	args := []string{
+		"-auto-approve",
 		"-state", statePath,
 		testFixturePath("apply"),
 	}
@@ -819,6 +1213,7 @@ func TestApply_stateNoExist(t *testing.T) {
 	}
 
 	args := []string{
+		"-auto-approve",
 		"idontexist.tfstate",
 		testFixturePath("apply"),
 	}
@@ -852,6 +1247,7 @@ func TestApply_vars(t *testing.T) {
 	}
 
 	args := []string{
+		"-auto-approve",
 		"-var", "foo=bar",
 		"-state", statePath,
 		testFixturePath("apply-vars"),
@@ -895,6 +1291,7 @@ func TestApply_varFile(t *testing.T) {
 	}
 
 	args := []string{
+		"-auto-approve",
 		"-var-file", varFilePath,
 		"-state", statePath,
 		testFixturePath("apply-vars"),
@@ -948,6 +1345,7 @@ func TestApply_varFileDefault(t *testing.T) {
 	}
 
 	args := []string{
+		"-auto-approve",
 		"-state", statePath,
 		testFixturePath("apply-vars"),
 	}
@@ -1000,6 +1398,7 @@ func TestApply_varFileDefaultJSON(t *testing.T) {
 	}
 
 	args := []string{
+		"-auto-approve",
 		"-state", statePath,
 		testFixturePath("apply-vars"),
 	}
@@ -1051,6 +1450,7 @@ func TestApply_backup(t *testing.T) {
 
 	// Run the apply command pointing to our existing state
 	args := []string{
+		"-auto-approve",
 		"-state", statePath,
 		"-backup", backupPath,
 		testFixturePath("apply"),
@@ -1120,6 +1520,7 @@ func TestApply_disableBackup(t *testing.T) {
 
 	// Run the apply command pointing to our existing state
 	args := []string{
+		"-auto-approve",
 		"-state", statePath,
 		"-backup", "-",
 		testFixturePath("apply"),
@@ -1221,3 +1622,92 @@ ID = bar
 const testApplyStateDiffStr = `
 ID = bar
 `
+
+func TestApply_json(t *testing.T) {
+	statePath := testTempFile(t)
+
+	p := testProvider()
+	ui := new(cli.MockUi)
+	c := &ApplyCommand{
+		Meta: Meta{
+			ContextOpts: testCtxConfig(p),
+			Ui:          ui,
+		},
+	}
+
+	args := []string{
+		"-json",
+		"-auto-approve",
+		"-state", statePath,
+		testFixturePath("apply"),
+	}
+	if code := c.Run(args); code != 0 {
+		t.Fatalf("bad: %d\n\n%s", code, ui.ErrorWriter.String())
+	}
+
+	// Every line of the output is an event
+	types := make(map[string]*JSONEvent)
+	for _, e := range testJSONEvents(t, ui.OutputWriter.String()) {
+		types[e.Type] = e
+	}
+
+	for _, typ := range []string{
+		JSONEventPlannedChange,
+		JSONEventApplyStart,
+		JSONEventApplyComplete,
+	} {
+		e, ok := types[typ]
+		if !ok {
+			t.Fatalf("no %s event:\n\n%s", typ, ui.OutputWriter.String())
+		}
+		if e.Address != "test_instance.foo" || e.Action != "create" {
+			t.Fatalf("bad: %#v", e)
+		}
+	}
+
+	summary := types[JSONEventChangeSummary]
+	if summary == nil || *summary.Changes != (JSONChangeSummary{
+		Operation: "apply", Add: 1,
+	}) {
+		t.Fatalf("bad: %#v", summary)
+	}
+}
+
+func TestApply_jsonApprove(t *testing.T) {
+	p := testProvider()
+	ui := new(cli.MockUi)
+	c := &ApplyCommand{
+		Meta: Meta{
+			ContextOpts: testCtxConfig(p),
+			Ui:          ui,
+		},
+	}
+
+	// Approval isn't possible with -json
+	args := []string{
+		"-json",
+		"-state", testTempFile(t),
+		testFixturePath("apply"),
+	}
+	if code := c.Run(args); code != 1 {
+		t.Fatalf("bad: %d", code)
+	}
+	if p.ApplyCalled {
+		t.Fatal("apply should not be called")
+	}
+	if p.DiffCalled {
+		t.Fatal("diff should not be called")
+	}
+
+	events := testJSONEvents(t, ui.OutputWriter.String())
+	for _, e := range events {
+		switch e.Type {
+		case JSONEventPlannedChange, JSONEventRefreshStart:
+			t.Fatalf("bad: %#v", e)
+		}
+	}
+	last := events[len(events)-1]
+	if last.Type != JSONEventDiagnostic || last.Severity != "error" {
+		t.Fatalf("bad: %#v", last)
+	}
+}
@@ -22,10 +22,24 @@ const DefaultVarsFilename = "terraform.tfvars"
 // DefaultBackupExtention is added to the state file to form the path
 const DefaultBackupExtention = ".backup"
 
+// BackupDirEnvVar is the environment variable with the directory to write
+// state backups to when no backup path is given. The backups in it are
+// named after the state file and the time, so they aren't overwritten.
+const BackupDirEnvVar = "TF_STATE_BACKUP_DIR"
+
 // DefaultDataDirectory is the directory where local state is stored
 // by default.
 const DefaultDataDirectory = ".terraform"
 
+// DefaultEnvDir is the directory the states of environments other than
+// the default one are stored in, each in a subdirectory named after the
+// environment.
+const DefaultEnvDir = "terraform.tfstate.d"
+
+// DefaultEnvFile is the file in the data directory that stores the name
+// of the selected environment.
+const DefaultEnvFile = "environment"
+
 func validateContext(ctx *terraform.Context, ui cli.Ui) bool {
 	if ws, es := ctx.Validate(); len(ws) > 0 || len(es) > 0 {
 		ui.Output(
@@ -254,6 +254,26 @@ func testCwd(t *testing.T) (string, string) {
 	return tmp, cwd
 }
 
+// testCwdRemoved changes into a temporary directory and removes it, so
+// that getting the working directory fails. The returned func changes
+// back to the original directory.
+func testCwdRemoved(t *testing.T) func() {
+	tmp, cwd := testCwd(t)
+	if err := os.Remove(tmp); err != nil {
+		t.Fatalf("err: %v", err)
+	}
+	if _, err := os.Getwd(); err == nil {
+		os.Chdir(cwd)
+		t.Skip("the working directory is known after it is removed")
+	}
+
+	return func() {
+		if err := os.Chdir(cwd); err != nil {
+			t.Fatalf("err: %v", err)
+		}
+	}
+}
+
 // testFixCwd is used to as a defer to testDir
 func testFixCwd(t *testing.T, tmp, cwd string) {
 	if err := os.Chdir(cwd); err != nil {
@@ -0,0 +1,366 @@
+package command
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform/terraform"
+	"github.com/mitchellh/cli"
+)
+
+// CompletionCommand is a cli.Command implementation that prints the shell
+// completion scripts, and the completions the scripts ask for.
+type CompletionCommand struct {
+	Meta
+
+	// Commands are the commands of the CLI, which are completed along
+	// with the flags from their help.
+	Commands map[string]cli.CommandFactory
+}
+
+func (c *CompletionCommand) Run(args []string) int {
+	var complete bool
+	cmdFlags := c.Meta.flagSet("completion")
+	cmdFlags.BoolVar(&complete, "complete", false, "complete")
+	cmdFlags.Usage = func() { c.Ui.Error(c.Help()) }
+	if err := cmdFlags.Parse(args); err != nil {
+		return 1
+	}
+
+	args = cmdFlags.Args()
+	if complete {
+		// The scripts ignore errors, so this always succeeds and
+		// prints nothing if there is nothing to complete.
+		for _, v := range c.complete(args) {
+			c.Ui.Output(v)
+		}
+		return 0
+	}
+
+	if len(args) != 1 {
+		c.Ui.Error("The completion command expects the name of a shell.")
+		cmdFlags.Usage()
+		return 1
+	}
+
+	switch args[0] {
+	case "bash":
+		c.Ui.Output(strings.TrimSpace(completionBash))
+	case "zsh":
+		c.Ui.Output(strings.TrimSpace(completionZsh))
+	default:
+		c.Ui.Error(fmt.Sprintf(
+			"Unsupported shell: %s. Must be \"bash\" or \"zsh\".", args[0]))
+		return 1
+	}
+
+	return 0
+}
+
+// complete returns the completions of the last of the given words, which
+// are the arguments to terraform up to the cursor.
+func (c *CompletionCommand) complete(words []string) []string {
+	if len(words) == 0 {
+		words = []string{""}
+	}
+	cur := words[len(words)-1]
+	args := words[1 : len(words)-1]
+
+	if len(words) == 1 {
+		names := make([]string, 0, len(c.Commands))
+		for k, _ := range c.Commands {
+			names = append(names, k)
+		}
+		return completionMatch(names, cur)
+	}
+
+	name := words[0]
+	f, ok := c.Commands[name]
+	if !ok {
+		return nil
+	}
+	cmd, err := f()
+	if err != nil {
+		return nil
+	}
+
+	// Commands that group others complete the name of the subcommand
+	// first, and then the flags of the subcommand.
+	if subs := c.subcommands(name); subs != nil {
+		if len(args) == 0 {
+			names := make([]string, 0, len(subs))
+			for k, _ := range subs {
+				names = append(names, k)
+			}
+			return completionMatch(names, cur)
+		}
+
+		cmd, ok = subs[args[0]]
+		if !ok {
+			return nil
+		}
+		name = name + " " + args[0]
+		args = args[1:]
+	}
+
+	flags := completionFlags(cmd.Help())
+
+	// The state the values come from may be set with -state
+	for i, arg := range args {
+		if strings.HasPrefix(arg, "-state=") {
+			c.Meta.statePath = arg[len("-state="):]
+		} else if arg == "-state" && i+1 < len(args) {
+			c.Meta.statePath = args[i+1]
+		}
+	}
+
+	if strings.HasPrefix(cur, "-") {
+		if i := strings.Index(cur, "="); i > 0 {
+			values := completionMatch(c.flagValues(cur[:i]), cur[i+1:])
+			for j, v := range values {
+				values[j] = cur[:i+1] + v
+			}
+			return values
+		}
+
+		return completionMatch(flags, cur)
+	}
+
+	// The value of a flag given as "-target aws_instance.foo"
+	if len(args) > 0 {
+		prev := args[len(args)-1]
+		for _, f := range flags {
+			if f == prev+"=" {
+				return completionMatch(c.flagValues(prev), cur)
+			}
+		}
+	}
+
+	// Only the first argument of taint and untaint is an address. The
+	// arguments are what's left after the flags and their values.
+	if name != "taint" && name != "untaint" {
+		return nil
+	}
+	for i := 0; i < len(args); i++ {
+		if !strings.HasPrefix(args[i], "-") {
+			return nil
+		}
+		for _, f := range flags {
+			if f == args[i]+"=" {
+				i++
+				break
+			}
+		}
+	}
+
+	return completionMatch(completionAddresses(c.completionState(), true), cur)
+}
+
+// subcommands returns the subcommands of the commands that group others,
+// by name, or nil if the command has none.
+func (c *CompletionCommand) subcommands(name string) map[string]cli.Command {
+	switch name {
+	case "env":
+		return map[string]cli.Command{
+			"delete": &EnvDeleteCommand{Meta: c.Meta},
+			"list":   &EnvListCommand{Meta: c.Meta},
+			"new":    &EnvNewCommand{Meta: c.Meta},
+			"select": &EnvSelectCommand{Meta: c.Meta},
+		}
+	case "remote":
+		return map[string]cli.Command{
+			"config": &RemoteConfigCommand{Meta: c.Meta},
+			"pull":   &RemotePullCommand{Meta: c.Meta},
+			"push":   &RemotePushCommand{Meta: c.Meta},
+		}
+	case "state":
+		return map[string]cli.Command{
+			"list":             &StateListCommand{Meta: c.Meta},
+			"pull":             &StatePullCommand{Meta: c.Meta},
+			"push":             &StatePushCommand{Meta: c.Meta},
+			"replace-provider": &StateReplaceProviderCommand{Meta: c.Meta},
+			"rm-deposed":       &StateRmDeposedCommand{Meta: c.Meta},
+		}
+	default:
+		return nil
+	}
+}
+
+// flagValues returns the values the given flag can be completed with.
+func (c *CompletionCommand) flagValues(flag string) []string {
+	switch flag {
+	case "-target", "-replace":
+		return completionAddresses(c.completionState(), false)
+	case "-only":
+		return PlanActions
+	default:
+		return nil
+	}
+}
+
+// completionState returns the current state, or nil if there is none or
+// it can't be read. The remote state isn't refreshed, since completion
+// must be fast, so the addresses come from the local copy of it.
+func (c *CompletionCommand) completionState() *terraform.State {
+	opts := c.StateOpts()
+	opts.RemoteRefresh = false
+	result, err := State(opts)
+	if err != nil {
+		return nil
+	}
+
+	return result.State.State()
+}
+
+// completionFlagRe matches the flags in the help of a command, which are
+// listed as "-name", or as "-name=value" or "-name 'value'" if they take
+// a value. The description is at least two spaces away.
+var completionFlagRe = regexp.MustCompile(`(?m)^\s+(-[a-z][a-z0-9-]*)(=| \S)?`)
+
+// completionFlags returns the flags listed in the help of a command,
+// sorted. Flags that take a value end with "=".
+func completionFlags(help string) []string {
+	seen := make(map[string]struct{})
+	for _, m := range completionFlagRe.FindAllStringSubmatch(help, -1) {
+		flag := m[1]
+		if m[2] != "" {
+			flag += "="
+		}
+		seen[flag] = struct{}{}
+	}
+
+	result := make([]string, 0, len(seen))
+	for k, _ := range seen {
+		result = append(result, k)
+	}
+	sort.Strings(result)
+	return result
+}
+
+// completionAddresses returns the addresses of the resources in the
+// state, sorted. With modules, these are the addresses taint takes, such
+// as "module.app.aws_instance.web.3". Without, these are the addresses of
+// the root module that -target takes, such as "aws_instance.web[3]" and
+// "aws_instance.web".
+func completionAddresses(s *terraform.State, modules bool) []string {
+	if s == nil {
+		return nil
+	}
+
+	seen := make(map[string]struct{})
+	for _, mod := range s.Modules {
+		prefix := ""
+		if modules {
+			for _, name := range mod.Path[1:] {
+				prefix += "module." + name + "."
+			}
+		} else if len(mod.Path) > 1 {
+			continue
+		}
+
+		for k, _ := range mod.Resources {
+			parts := strings.Split(k, ".")
+			if !modules && len(parts) == 3 {
+				seen[parts[0]+"."+parts[1]] = struct{}{}
+				k = fmt.Sprintf("%s.%s[%s]", parts[0], parts[1], parts[2])
+			}
+			seen[prefix+k] = struct{}{}
+		}
+	}
+
+	result := make([]string, 0, len(seen))
+	for k, _ := range seen {
+		result = append(result, k)
+	}
+	sort.Strings(result)
+	return result
+}
+
+// completionMatch returns the values that start with the given prefix,
+// sorted.
+func completionMatch(values []string, prefix string) []string {
+	result := make([]string, 0, len(values))
+	for _, v := range values {
+		if strings.HasPrefix(v, prefix) {
+			result = append(result, v)
+		}
+	}
+	sort.Strings(result)
+	return result
+}
+
+func (c *CompletionCommand) Help() string {
+	helpText := `
+Usage: terraform completion SHELL
+
+  Print the script that sets up the completion of Terraform commands for
+  the given shell, which is "bash" or "zsh". It completes the commands,
+  their flags, and the resource addresses in the state for taint,
+  untaint, -target and -replace.
+
+  To enable completion, load the script from your shell's startup file:
+
+      eval "$(terraform completion bash)"
+
+  The addresses come from the current state. Remote state isn't
+  refreshed for completion, so the addresses are the ones in the local
+  copy of it.
+
+`
+	return strings.TrimSpace(helpText)
+}
+
+func (c *CompletionCommand) Synopsis() string {
+	return "Print the shell completion script"
+}
+
+// completionBash is the completion script for bash. The words are split
+// on spaces rather than taken from COMP_WORDS, which bash also splits on
+// "=", and the part of "-flag=value" bash doesn't replace is removed
+// from the completions.
+const completionBash = `
+_terraform() {
+  local line="${COMP_LINE:0:COMP_POINT}"
+  local -a words=($line)
+  if [[ "$line" == *" " ]]; then
+    words+=("")
+  fi
+
+  local IFS=$'\n'
+  COMPREPLY=($(terraform completion -complete -- "${words[@]:1}" 2>/dev/null))
+
+  local cur="${words[${#words[@]}-1]}"
+  if [[ "$cur" == *=* && "$COMP_WORDBREAKS" == *=* ]]; then
+    COMPREPLY=("${COMPREPLY[@]#${cur%=*}=}")
+  fi
+  if [[ ${#COMPREPLY[@]} -eq 1 && "${COMPREPLY[0]}" == *= ]]; then
+    compopt -o nospace
+  fi
+}
+
+complete -o default -F _terraform terraform
+`
+
+// completionZsh is the completion script for zsh.
+const completionZsh = `
+#compdef terraform
+
+_terraform() {
+  local -a candidates flags others
+  candidates=("${(@f)$(terraform completion -complete -- "${(@)words[2,CURRENT]}" 2>/dev/null)}")
+  for c in $candidates; do
+    if [[ "$c" == *= ]]; then
+      flags+=("$c")
+    elif [[ -n "$c" ]]; then
+      others+=("$c")
+    fi
+  done
+
+  compadd -S '' -a flags
+  compadd -a others
+}
+
+compdef _terraform terraform
+`
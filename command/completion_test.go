@@ -0,0 +1,183 @@
+package command
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform/terraform"
+	"github.com/mitchellh/cli"
+)
+
+func TestCompletion(t *testing.T) {
+	statePath := testStateFile(t, &terraform.State{
+		Modules: []*terraform.ModuleState{
+			&terraform.ModuleState{
+				Path: []string{"root"},
+				Resources: map[string]*terraform.ResourceState{
+					"aws_instance.web.0": &terraform.ResourceState{
+						Type:    "aws_instance",
+						Primary: &terraform.InstanceState{ID: "a"},
+					},
+					"aws_instance.web.1": &terraform.ResourceState{
+						Type:    "aws_instance",
+						Primary: &terraform.InstanceState{ID: "b"},
+					},
+					"aws_vpc.main": &terraform.ResourceState{
+						Type:    "aws_vpc",
+						Primary: &terraform.InstanceState{ID: "c"},
+					},
+				},
+			},
+			&terraform.ModuleState{
+				Path: []string{"root", "app"},
+				Resources: map[string]*terraform.ResourceState{
+					"aws_instance.app": &terraform.ResourceState{
+						Type:    "aws_instance",
+						Primary: &terraform.InstanceState{ID: "d"},
+					},
+				},
+			},
+		},
+	})
+	state := "-state=" + statePath
+
+	cases := []struct {
+		Words    []string
+		Expected []string
+	}{
+		{
+			[]string{""},
+			[]string{"plan", "show", "state", "taint"},
+		},
+		{
+			[]string{"s"},
+			[]string{"show", "state"},
+		},
+		{
+			[]string{"plan", "-ta"},
+			[]string{"-target="},
+		},
+		{
+			[]string{"plan", "-var"},
+			[]string{"-var-file=", "-var="},
+		},
+		{
+			[]string{"plan", "-detailed"},
+			[]string{"-detailed-exitcode"},
+		},
+		{
+			[]string{"plan", "-only=c"},
+			[]string{"-only=create"},
+		},
+		{
+			[]string{"plan", state, "-target=aws_i"},
+			[]string{
+				"-target=aws_instance.web",
+				"-target=aws_instance.web[0]",
+				"-target=aws_instance.web[1]",
+			},
+		},
+		{
+			[]string{"plan", state, "-target", "aws_v"},
+			[]string{"aws_vpc.main"},
+		},
+		{
+			[]string{"taint", state, ""},
+			[]string{
+				"aws_instance.web.0",
+				"aws_instance.web.1",
+				"aws_vpc.main",
+				"module.app.aws_instance.app",
+			},
+		},
+		{
+			[]string{"taint", "-state", statePath, "-allow-missing", "mod"},
+			[]string{"module.app.aws_instance.app"},
+		},
+		{
+			[]string{"taint", state, "aws_vpc.main", ""},
+			nil,
+		},
+		{
+			[]string{"state", ""},
+			[]string{"list", "pull", "push", "replace-provider", "rm-deposed"},
+		},
+		{
+			[]string{"state", "push", "-f"},
+			[]string{"-force"},
+		},
+		{
+			[]string{"nope", ""},
+			nil,
+		},
+	}
+
+	for i, tc := range cases {
+		c := &CompletionCommand{
+			Meta: Meta{
+				Ui: new(cli.MockUi),
+			},
+			Commands: map[string]cli.CommandFactory{
+				"plan": func() (cli.Command, error) {
+					return &PlanCommand{}, nil
+				},
+				"show": func() (cli.Command, error) {
+					return &ShowCommand{}, nil
+				},
+				"state": func() (cli.Command, error) {
+					return &StateCommand{}, nil
+				},
+				"taint": func() (cli.Command, error) {
+					return &TaintCommand{}, nil
+				},
+			},
+		}
+
+		actual := c.complete(tc.Words)
+		if len(actual) == 0 && len(tc.Expected) == 0 {
+			continue
+		}
+		if !reflect.DeepEqual(actual, tc.Expected) {
+			t.Fatalf("%d: bad: %#v", i, actual)
+		}
+	}
+}
+
+func TestCompletion_run(t *testing.T) {
+	ui := new(cli.MockUi)
+	c := &CompletionCommand{
+		Meta: Meta{
+			Ui: ui,
+		},
+		Commands: map[string]cli.CommandFactory{
+			"plan": func() (cli.Command, error) {
+				return &PlanCommand{}, nil
+			},
+		},
+	}
+
+	if code := c.Run([]string{"-complete", "--", "plan", "-out"}); code != 0 {
+		t.Fatalf("bad: %d\n\n%s", code, ui.ErrorWriter.String())
+	}
+	if actual := ui.OutputWriter.String(); actual != "-out=\n" {
+		t.Fatalf("bad: %q", actual)
+	}
+
+	for _, shell := range []string{"bash", "zsh"} {
+		ui = new(cli.MockUi)
+		c.Ui = ui
+		if code := c.Run([]string{shell}); code != 0 {
+			t.Fatalf("%s: bad: %d\n\n%s", shell, code, ui.ErrorWriter.String())
+		}
+		if !strings.Contains(ui.OutputWriter.String(), "terraform completion -complete") {
+			t.Fatalf("%s: bad: %s", shell, ui.OutputWriter.String())
+		}
+	}
+
+	ui = new(cli.MockUi)
+	c.Ui = ui
+	if code := c.Run([]string{"fish"}); code != 1 {
+		t.Fatalf("bad: %d", code)
+	}
+}
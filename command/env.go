@@ -0,0 +1,204 @@
+package command
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform/state"
+	"github.com/hashicorp/terraform/state/remote"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+// EnvCommand is a Command implementation that manages the environments,
+// each of which has its own state.
+type EnvCommand struct {
+	Meta
+}
+
+func (c *EnvCommand) Run(argsRaw []string) int {
+	// Duplicate the args so we can munge them without affecting
+	// future subcommand invocations which will do the same.
+	args := make([]string, len(argsRaw))
+	copy(args, argsRaw)
+	args = c.Meta.process(args, false)
+
+	if len(args) == 0 {
+		c.Ui.Error(c.Help())
+		return 1
+	}
+
+	switch args[0] {
+	case "delete":
+		cmd := &EnvDeleteCommand{Meta: c.Meta}
+		return cmd.Run(args[1:])
+	case "list":
+		cmd := &EnvListCommand{Meta: c.Meta}
+		return cmd.Run(args[1:])
+	case "new":
+		cmd := &EnvNewCommand{Meta: c.Meta}
+		return cmd.Run(args[1:])
+	case "select":
+		cmd := &EnvSelectCommand{Meta: c.Meta}
+		return cmd.Run(args[1:])
+	default:
+		c.Ui.Error(c.Help())
+		return 1
+	}
+}
+
+func (c *EnvCommand) Help() string {
+	helpText := `
+Usage: terraform env <subcommand> [options]
+
+  Create, change and delete Terraform environments.
+
+  Each environment has its own state, so the same configuration can be
+  used for several deployments, such as staging and production. The
+  name of the selected environment is available in the configuration
+  as ${terraform.env}.
+
+Available subcommands:
+
+  delete      Delete an environment.
+  list        List environments.
+  new         Create a new environment.
+  select      Select an environment.
+
+`
+	return strings.TrimSpace(helpText)
+}
+
+func (c *EnvCommand) Synopsis() string {
+	return "Environment management"
+}
+
+// envNameRegexp matches the valid environment names.
+var envNameRegexp = regexp.MustCompile(`^[a-zA-Z0-9_.-]+$`)
+
+// validEnvName checks that the name can be used for an environment.
+func validEnvName(name string) error {
+	if !envNameRegexp.MatchString(name) || name == "." || name == ".." {
+		return fmt.Errorf(
+			"Invalid environment name %q. Names may only contain letters, "+
+				"digits, '_', '-' and '.'.", name)
+	}
+
+	return nil
+}
+
+// envStatePaths returns the local state path and the remote state cache
+// path of the given environment.
+func (m *Meta) envStatePaths(env string) (string, string) {
+	if env == terraform.DefaultEnv {
+		return DefaultStateFilename,
+			filepath.Join(m.DataDir(), DefaultStateFilename)
+	}
+
+	return filepath.Join(DefaultEnvDir, env, DefaultStateFilename),
+		filepath.Join(m.DataDir(), DefaultEnvDir, env, DefaultStateFilename)
+}
+
+// envs returns the sorted names of all the known environments.
+func (m *Meta) envs() ([]string, error) {
+	names := map[string]struct{}{terraform.DefaultEnv: struct{}{}}
+	dirs := []string{DefaultEnvDir, filepath.Join(m.DataDir(), DefaultEnvDir)}
+	for _, dir := range dirs {
+		fis, err := ioutil.ReadDir(dir)
+		if err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+
+			return nil, err
+		}
+
+		for _, fi := range fis {
+			if fi.IsDir() {
+				names[fi.Name()] = struct{}{}
+			}
+		}
+	}
+
+	result := make([]string, 0, len(names))
+	for name, _ := range names {
+		result = append(result, name)
+	}
+	sort.Strings(result)
+
+	return result, nil
+}
+
+// envExists returns true if the environment with the given name exists.
+func (m *Meta) envExists(env string) (bool, error) {
+	envs, err := m.envs()
+	if err != nil {
+		return false, err
+	}
+
+	for _, name := range envs {
+		if name == env {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// defaultRemoteState returns the remote state configuration of the
+// default environment, or nil if its state isn't remote.
+func (m *Meta) defaultRemoteState() (*terraform.RemoteState, error) {
+	_, remotePath := m.envStatePaths(terraform.DefaultEnv)
+	local := &state.LocalState{Path: remotePath}
+	if err := local.RefreshState(); err != nil {
+		return nil, err
+	}
+
+	s := local.State()
+	if !s.IsRemote() {
+		return nil, nil
+	}
+
+	return s.Remote, nil
+}
+
+// initEnvRemote sets up the remote state cache of the given environment
+// from the remote state configured for the default environment. It does
+// nothing if the default environment doesn't use remote state, or the
+// cache already exists.
+func (m *Meta) initEnvRemote(env string) error {
+	if env == terraform.DefaultEnv {
+		return nil
+	}
+
+	_, remotePath := m.envStatePaths(env)
+	if _, err := os.Stat(remotePath); err == nil {
+		return nil
+	}
+
+	r, err := m.defaultRemoteState()
+	if err != nil {
+		return err
+	}
+	if r == nil {
+		return nil
+	}
+
+	conf, err := remote.EnvConfig(strings.ToLower(r.Type), r.Config, env)
+	if err != nil {
+		return err
+	}
+
+	s := terraform.NewState()
+	s.Remote = &terraform.RemoteState{
+		Type:   r.Type,
+		Config: conf,
+	}
+
+	local := &state.LocalState{Path: remotePath}
+	return local.WriteState(s)
+}
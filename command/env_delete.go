@@ -0,0 +1,143 @@
+package command
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/hashicorp/terraform/state/remote"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+// EnvDeleteCommand is a Command implementation that deletes an
+// environment and its state.
+type EnvDeleteCommand struct {
+	Meta
+}
+
+func (c *EnvDeleteCommand) Run(args []string) int {
+	var force bool
+
+	args = c.Meta.process(args, false)
+	cmdFlags := flag.NewFlagSet("env delete", flag.ContinueOnError)
+	cmdFlags.BoolVar(&force, "force", false, "force")
+	cmdFlags.Usage = func() { c.Ui.Error(c.Help()) }
+	if err := cmdFlags.Parse(args); err != nil {
+		return 1
+	}
+
+	args = cmdFlags.Args()
+	if len(args) != 1 {
+		c.Ui.Error("Expected a single argument: NAME.\n")
+		c.Ui.Error(c.Help())
+		return 1
+	}
+	name := args[0]
+
+	if name == terraform.DefaultEnv {
+		c.Ui.Error("The default environment can't be deleted.")
+		return 1
+	}
+	if name == c.Env() {
+		c.Ui.Error(fmt.Sprintf(
+			"Environment %q is the selected environment.\n\n"+
+				"The selected environment can't be deleted. Select another\n"+
+				"environment with `terraform env select` and try again.", name))
+		return 1
+	}
+
+	exists, err := c.envExists(name)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error listing environments: %s", err))
+		return 1
+	}
+	if !exists {
+		c.Ui.Error(fmt.Sprintf("Environment %q doesn't exist!", name))
+		return 1
+	}
+
+	// Read the state of the environment to delete
+	localPath, remotePath := c.envStatePaths(name)
+	result, err := State(&StateOpts{
+		LocalPath:     localPath,
+		RemotePath:    remotePath,
+		RemoteRefresh: true,
+		BackupPath:    "-",
+	})
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error reading state: %s", err))
+		return 1
+	}
+
+	if !force && result.State != nil && stateHasResources(result.State.State()) {
+		c.Ui.Error(fmt.Sprintf(
+			"Environment %q still has resources in its state!\n\n"+
+				"Deleting it would leave these resources unmanaged by Terraform.\n"+
+				"Destroy them first, or use -force to delete the environment anyway.",
+			name))
+		return 1
+	}
+
+	// Delete the remote state, if any
+	if result.Remote != nil {
+		if durable, ok := result.Remote.Durable.(*remote.State); ok {
+			if err := durable.Client.Delete(); err != nil {
+				c.Ui.Error(fmt.Sprintf("Error deleting remote state: %s", err))
+				return 1
+			}
+		}
+	}
+
+	dirs := []string{
+		filepath.Join(DefaultEnvDir, name),
+		filepath.Join(c.DataDir(), DefaultEnvDir, name),
+	}
+	for _, dir := range dirs {
+		if err := os.RemoveAll(dir); err != nil {
+			c.Ui.Error(fmt.Sprintf("Error deleting environment: %s", err))
+			return 1
+		}
+	}
+
+	c.Ui.Output(c.Colorize().Color(fmt.Sprintf(
+		"[reset][green]Deleted environment %q!", name)))
+	return 0
+}
+
+// stateHasResources returns true if any module of the state has
+// resources.
+func stateHasResources(s *terraform.State) bool {
+	if s == nil {
+		return false
+	}
+
+	for _, m := range s.Modules {
+		if len(m.Resources) > 0 {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (c *EnvDeleteCommand) Help() string {
+	helpText := `
+Usage: terraform env delete [options] NAME
+
+  Delete an environment and its state. The default environment and the
+  selected environment can't be deleted.
+
+Options:
+
+  -force    Delete the environment even if its state still has
+            resources, leaving them unmanaged by Terraform.
+
+`
+	return strings.TrimSpace(helpText)
+}
+
+func (c *EnvDeleteCommand) Synopsis() string {
+	return "Delete an environment"
+}
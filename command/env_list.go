@@ -0,0 +1,57 @@
+package command
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"strings"
+)
+
+// EnvListCommand is a Command implementation that lists the environments.
+type EnvListCommand struct {
+	Meta
+}
+
+func (c *EnvListCommand) Run(args []string) int {
+	args = c.Meta.process(args, false)
+	cmdFlags := flag.NewFlagSet("env list", flag.ContinueOnError)
+	cmdFlags.Usage = func() { c.Ui.Error(c.Help()) }
+	if err := cmdFlags.Parse(args); err != nil {
+		return 1
+	}
+
+	envs, err := c.envs()
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error listing environments: %s", err))
+		return 1
+	}
+
+	current := c.Env()
+	var out bytes.Buffer
+	for _, env := range envs {
+		if env == current {
+			out.WriteString("* ")
+		} else {
+			out.WriteString("  ")
+		}
+		out.WriteString(env + "\n")
+	}
+
+	c.Ui.Output(strings.TrimRight(out.String(), "\n"))
+	return 0
+}
+
+func (c *EnvListCommand) Help() string {
+	helpText := `
+Usage: terraform env list
+
+  List the environments. The selected environment is marked with
+  an asterisk.
+
+`
+	return strings.TrimSpace(helpText)
+}
+
+func (c *EnvListCommand) Synopsis() string {
+	return "List environments"
+}
@@ -0,0 +1,94 @@
+package command
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// EnvNewCommand is a Command implementation that creates a new
+// environment and selects it.
+type EnvNewCommand struct {
+	Meta
+}
+
+func (c *EnvNewCommand) Run(args []string) int {
+	args = c.Meta.process(args, false)
+	cmdFlags := flag.NewFlagSet("env new", flag.ContinueOnError)
+	cmdFlags.Usage = func() { c.Ui.Error(c.Help()) }
+	if err := cmdFlags.Parse(args); err != nil {
+		return 1
+	}
+
+	args = cmdFlags.Args()
+	if len(args) != 1 {
+		c.Ui.Error("Expected a single argument: NAME.\n")
+		c.Ui.Error(c.Help())
+		return 1
+	}
+	name := args[0]
+
+	if err := validEnvName(name); err != nil {
+		c.Ui.Error(err.Error())
+		return 1
+	}
+
+	exists, err := c.envExists(name)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error listing environments: %s", err))
+		return 1
+	}
+	if exists {
+		c.Ui.Error(fmt.Sprintf("Environment %q already exists!", name))
+		return 1
+	}
+
+	// Environments with remote state get their remote state cache,
+	// otherwise they get a directory for their local state.
+	r, err := c.defaultRemoteState()
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error reading remote state: %s", err))
+		return 1
+	}
+	if r != nil {
+		err = c.initEnvRemote(name)
+	} else {
+		err = os.MkdirAll(filepath.Join(DefaultEnvDir, name), 0755)
+	}
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error creating environment: %s", err))
+		return 1
+	}
+
+	if err := c.SetEnv(name); err != nil {
+		c.Ui.Error(fmt.Sprintf("Error selecting environment: %s", err))
+		return 1
+	}
+
+	c.Ui.Output(c.Colorize().Color(fmt.Sprintf(
+		"[reset][green]Created and switched to environment %q!\n\n"+
+			"You're now on a new, empty environment. Environments isolate their state,\n"+
+			"so if you run \"terraform plan\" Terraform will not see any existing state\n"+
+			"for this configuration.", name)))
+	return 0
+}
+
+func (c *EnvNewCommand) Help() string {
+	helpText := `
+Usage: terraform env new NAME
+
+  Create a new environment with an empty state and select it.
+
+  If the default environment uses remote state, the state of the new
+  environment is stored with the same backend, next to the default
+  state.
+
+`
+	return strings.TrimSpace(helpText)
+}
+
+func (c *EnvNewCommand) Synopsis() string {
+	return "Create a new environment"
+}
@@ -0,0 +1,66 @@
+package command
+
+import (
+	"flag"
+	"fmt"
+	"strings"
+)
+
+// EnvSelectCommand is a Command implementation that selects the
+// environment to use.
+type EnvSelectCommand struct {
+	Meta
+}
+
+func (c *EnvSelectCommand) Run(args []string) int {
+	args = c.Meta.process(args, false)
+	cmdFlags := flag.NewFlagSet("env select", flag.ContinueOnError)
+	cmdFlags.Usage = func() { c.Ui.Error(c.Help()) }
+	if err := cmdFlags.Parse(args); err != nil {
+		return 1
+	}
+
+	args = cmdFlags.Args()
+	if len(args) != 1 {
+		c.Ui.Error("Expected a single argument: NAME.\n")
+		c.Ui.Error(c.Help())
+		return 1
+	}
+	name := args[0]
+
+	exists, err := c.envExists(name)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error listing environments: %s", err))
+		return 1
+	}
+	if !exists {
+		c.Ui.Error(fmt.Sprintf(
+			"Environment %q doesn't exist! You can create it with\n"+
+				"`terraform env new %s`.", name, name))
+		return 1
+	}
+
+	if err := c.SetEnv(name); err != nil {
+		c.Ui.Error(fmt.Sprintf("Error selecting environment: %s", err))
+		return 1
+	}
+
+	c.Ui.Output(c.Colorize().Color(fmt.Sprintf(
+		"[reset][green]Switched to environment %q!", name)))
+	return 0
+}
+
+func (c *EnvSelectCommand) Help() string {
+	helpText := `
+Usage: terraform env select NAME
+
+  Select the environment to use. Later commands use the state of this
+  environment.
+
+`
+	return strings.TrimSpace(helpText)
+}
+
+func (c *EnvSelectCommand) Synopsis() string {
+	return "Select an environment"
+}
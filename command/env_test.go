@@ -0,0 +1,173 @@
+package command
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform/state"
+	"github.com/hashicorp/terraform/terraform"
+	"github.com/mitchellh/cli"
+)
+
+func TestEnv_createSelectDelete(t *testing.T) {
+	tmp, cwd := testCwd(t)
+	defer testFixCwd(t, tmp, cwd)
+
+	// Create a new environment, which is selected
+	ui := new(cli.MockUi)
+	newCmd := &EnvNewCommand{Meta: Meta{Ui: ui}}
+	if code := newCmd.Run([]string{"dev"}); code != 0 {
+		t.Fatalf("bad: \n%s", ui.ErrorWriter.String())
+	}
+	if env := newCmd.Env(); env != "dev" {
+		t.Fatalf("bad: %s", env)
+	}
+
+	// It can't be created twice
+	ui = new(cli.MockUi)
+	newCmd = &EnvNewCommand{Meta: Meta{Ui: ui}}
+	if code := newCmd.Run([]string{"dev"}); code != 1 {
+		t.Fatalf("bad: \n%s", ui.OutputWriter.String())
+	}
+
+	// The state of the environment is stored separately
+	m := &Meta{}
+	opts := m.StateOpts()
+	expected := filepath.Join(DefaultEnvDir, "dev", DefaultStateFilename)
+	if opts.LocalPath != expected {
+		t.Fatalf("bad: %s", opts.LocalPath)
+	}
+
+	// Both environments are listed, with the selected one marked
+	ui = new(cli.MockUi)
+	listCmd := &EnvListCommand{Meta: Meta{Ui: ui}}
+	if code := listCmd.Run(nil); code != 0 {
+		t.Fatalf("bad: \n%s", ui.ErrorWriter.String())
+	}
+	actual := strings.TrimSpace(ui.OutputWriter.String())
+	if actual != "default\n* dev" {
+		t.Fatalf("bad: %q", actual)
+	}
+
+	// The selected environment can't be deleted
+	ui = new(cli.MockUi)
+	deleteCmd := &EnvDeleteCommand{Meta: Meta{Ui: ui}}
+	if code := deleteCmd.Run([]string{"dev"}); code != 1 {
+		t.Fatalf("bad: \n%s", ui.OutputWriter.String())
+	}
+
+	ui = new(cli.MockUi)
+	selectCmd := &EnvSelectCommand{Meta: Meta{Ui: ui}}
+	if code := selectCmd.Run([]string{"default"}); code != 0 {
+		t.Fatalf("bad: \n%s", ui.ErrorWriter.String())
+	}
+	if env := selectCmd.Env(); env != terraform.DefaultEnv {
+		t.Fatalf("bad: %s", env)
+	}
+
+	ui = new(cli.MockUi)
+	deleteCmd = &EnvDeleteCommand{Meta: Meta{Ui: ui}}
+	if code := deleteCmd.Run([]string{"dev"}); code != 0 {
+		t.Fatalf("bad: \n%s", ui.ErrorWriter.String())
+	}
+	if _, err := os.Stat(filepath.Join(DefaultEnvDir, "dev")); !os.IsNotExist(err) {
+		t.Fatalf("err: %s", err)
+	}
+}
+
+func TestEnv_deleteWithResources(t *testing.T) {
+	tmp, cwd := testCwd(t)
+	defer testFixCwd(t, tmp, cwd)
+
+	path := filepath.Join(DefaultEnvDir, "dev", DefaultStateFilename)
+	local := &state.LocalState{Path: path}
+	if err := local.WriteState(testState()); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	ui := new(cli.MockUi)
+	c := &EnvDeleteCommand{Meta: Meta{Ui: ui}}
+	if code := c.Run([]string{"dev"}); code != 1 {
+		t.Fatalf("bad: \n%s", ui.OutputWriter.String())
+	}
+	if _, err := os.Stat(path); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	ui = new(cli.MockUi)
+	c = &EnvDeleteCommand{Meta: Meta{Ui: ui}}
+	if code := c.Run([]string{"-force", "dev"}); code != 0 {
+		t.Fatalf("bad: \n%s", ui.ErrorWriter.String())
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Fatalf("err: %s", err)
+	}
+}
+
+func TestEnv_invalid(t *testing.T) {
+	tmp, cwd := testCwd(t)
+	defer testFixCwd(t, tmp, cwd)
+
+	ui := new(cli.MockUi)
+	newCmd := &EnvNewCommand{Meta: Meta{Ui: ui}}
+	if code := newCmd.Run([]string{"bad/name"}); code != 1 {
+		t.Fatalf("bad: \n%s", ui.OutputWriter.String())
+	}
+
+	ui = new(cli.MockUi)
+	selectCmd := &EnvSelectCommand{Meta: Meta{Ui: ui}}
+	if code := selectCmd.Run([]string{"missing"}); code != 1 {
+		t.Fatalf("bad: \n%s", ui.OutputWriter.String())
+	}
+
+	ui = new(cli.MockUi)
+	deleteCmd := &EnvDeleteCommand{Meta: Meta{Ui: ui}}
+	if code := deleteCmd.Run([]string{terraform.DefaultEnv}); code != 1 {
+		t.Fatalf("bad: \n%s", ui.OutputWriter.String())
+	}
+}
+
+func TestEnv_remote(t *testing.T) {
+	tmp, cwd := testCwd(t)
+	defer testFixCwd(t, tmp, cwd)
+
+	// Configure remote state for the default environment
+	s := terraform.NewState()
+	s.Remote = &terraform.RemoteState{
+		Type: "_local",
+		Config: map[string]string{
+			"path": filepath.Join(tmp, "remote.tfstate"),
+		},
+	}
+	local := &state.LocalState{
+		Path: filepath.Join(DefaultDataDir, DefaultStateFilename),
+	}
+	if err := local.WriteState(s); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	ui := new(cli.MockUi)
+	c := &EnvNewCommand{Meta: Meta{Ui: ui}}
+	if code := c.Run([]string{"dev"}); code != 0 {
+		t.Fatalf("bad: \n%s", ui.ErrorWriter.String())
+	}
+
+	// The environment uses the same backend, next to the default state
+	local = &state.LocalState{
+		Path: filepath.Join(DefaultDataDir, DefaultEnvDir, "dev", DefaultStateFilename),
+	}
+	if err := local.RefreshState(); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	actual := local.State().Remote
+	expected := filepath.Join(tmp, DefaultEnvDir, "dev", "remote.tfstate")
+	if actual == nil || actual.Type != "_local" || actual.Config["path"] != expected {
+		t.Fatalf("bad: %#v", actual)
+	}
+
+	if _, err := os.Stat(DefaultEnvDir); !os.IsNotExist(err) {
+		t.Fatalf("local environment directory should not exist: %s", err)
+	}
+}
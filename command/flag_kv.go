@@ -3,9 +3,11 @@ package command
 import (
 	"fmt"
 	"io/ioutil"
+	"path/filepath"
 	"strings"
 
 	"github.com/hashicorp/hcl"
+	"github.com/hashicorp/terraform/config"
 	"github.com/mitchellh/go-homedir"
 )
 
@@ -57,6 +59,37 @@ func (v *FlagKVFile) Set(raw string) error {
 	return nil
 }
 
+// FlagVarFile is a flag.Value implementation like FlagKVFile that also
+// records the absolute paths of the files it reads, in order, so that
+// they can be read again later.
+type FlagVarFile struct {
+	Vars  *map[string]string
+	Paths *[]string
+}
+
+func (v *FlagVarFile) String() string {
+	return ""
+}
+
+func (v *FlagVarFile) Set(raw string) error {
+	if err := (*FlagKVFile)(v.Vars).Set(raw); err != nil {
+		return err
+	}
+
+	path, err := homedir.Expand(raw)
+	if err != nil {
+		return fmt.Errorf(
+			"Error expanding path: %s", err)
+	}
+	if path, err = filepath.Abs(path); err != nil {
+		return fmt.Errorf(
+			"Error expanding path: %s", err)
+	}
+
+	*v.Paths = append(*v.Paths, path)
+	return nil
+}
+
 func loadKVFile(rawPath string) (map[string]string, error) {
 	path, err := homedir.Expand(rawPath)
 	if err != nil {
@@ -78,11 +111,25 @@ func loadKVFile(rawPath string) (map[string]string, error) {
 			"Error parsing %s: %s", path, err)
 	}
 
-	var result map[string]string
-	if err := hcl.DecodeObject(&result, obj); err != nil {
+	var raw map[string]interface{}
+	if err := hcl.DecodeObject(&raw, obj); err != nil {
 		return nil, err
 	}
 
+	// Maps, which can nest maps and lists, are flattened to the values
+	// of their keys, such as "envs.prod.cidr".
+	result := make(map[string]string)
+	for k, v := range raw {
+		vs, err := config.FlattenVariable(k, v)
+		if err != nil {
+			return nil, fmt.Errorf(
+				"Error loading %s: %s", path, err)
+		}
+		for fk, fv := range vs {
+			result[fk] = fv
+		}
+	}
+
 	return result, nil
 }
 
@@ -68,6 +68,15 @@ foo = "bar"
 	inputJson := `{
 		"foo": "bar"}`
 
+	inputMap := `
+envs {
+	prod {
+		cidr = "10.0.0.0/16"
+		azs = ["us-east-1a", "us-east-1b"]
+	}
+}
+`
+
 	cases := []struct {
 		Input  string
 		Output map[string]string
@@ -84,6 +93,17 @@ foo = "bar"
 			map[string]string{"foo": "bar"},
 			false,
 		},
+
+		{
+			inputMap,
+			map[string]string{
+				"envs.prod.cidr":  "10.0.0.0/16",
+				"envs.prod.azs.#": "2",
+				"envs.prod.azs.0": "us-east-1a",
+				"envs.prod.azs.1": "us-east-1b",
+			},
+			false,
+		},
 	}
 
 	path := testTempFile(t)
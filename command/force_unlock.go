@@ -0,0 +1,116 @@
+package command
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform/state"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+// ForceUnlockCommand is a cli.Command implementation that removes the
+// lock of the state, such as the lock left by a crashed run.
+type ForceUnlockCommand struct {
+	Meta
+}
+
+func (c *ForceUnlockCommand) Run(args []string) int {
+	var force bool
+	args = c.Meta.process(args, false)
+
+	cmdFlags := c.Meta.flagSet("force-unlock")
+	cmdFlags.BoolVar(&force, "force", false, "force")
+	cmdFlags.StringVar(&c.Meta.statePath, "state", DefaultStateFilename, "path")
+	cmdFlags.Usage = func() { c.Ui.Error(c.Help()) }
+	if err := cmdFlags.Parse(args); err != nil {
+		return 1
+	}
+
+	args = cmdFlags.Args()
+	if len(args) != 1 {
+		c.Ui.Error("The force-unlock command expects exactly one argument,\n" +
+			"the ID of the lock to remove.")
+		cmdFlags.Usage()
+		return 1
+	}
+	id := args[0]
+
+	s, err := c.State()
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Failed to load state: %s", err))
+		return 1
+	}
+
+	unlocker, ok := s.(state.ForceUnlocker)
+	if !ok {
+		c.Ui.Error(fmt.Sprintf("Failed to unlock state: %s",
+			state.ErrForceUnlockUnsupported))
+		return 1
+	}
+
+	if !force {
+		if c.InputMode() == 0 {
+			c.Ui.Error(
+				"force-unlock requires confirmation, but input is disabled.\n" +
+					"Run with -force to unlock without confirmation.")
+			return 1
+		}
+
+		v, err := c.UIInput().Input(&terraform.InputOpts{
+			Id:    "force-unlock",
+			Query: "Do you really want to force-unlock?",
+			Description: fmt.Sprintf(
+				"Terraform will remove the lock %s on the state. This lets other\n"+
+					"Terraform runs modify the state, even if the run that took the\n"+
+					"lock is still going. Only 'yes' will be accepted to confirm.", id),
+		})
+		if err != nil {
+			c.Ui.Error(fmt.Sprintf("Error asking for confirmation: %s", err))
+			return 1
+		}
+		if v != "yes" {
+			c.Ui.Output("force-unlock cancelled.")
+			return 1
+		}
+	}
+
+	if err := unlocker.ForceUnlock(id); err != nil {
+		c.Ui.Error(err.Error())
+		return 1
+	}
+
+	c.Ui.Output(c.Colorize().Color(
+		"[reset][bold][green]The state has been successfully unlocked!"))
+	return 0
+}
+
+func (c *ForceUnlockCommand) Help() string {
+	helpText := `
+Usage: terraform force-unlock [options] LOCK_ID
+
+  Manually unlock the state, removing the lock with the given ID.
+
+  This is for locks that were left behind, such as by a run that crashed
+  or was killed. The ID of the lock is shown in the error of the commands
+  that can't lock the state. Removing the lock of a run that is still
+  going lets other runs modify the state at the same time, so make sure
+  it has stopped.
+
+  This command doesn't modify the state itself.
+
+Options:
+
+  -force              Don't ask for confirmation.
+
+  -state=path         Path to the state file, if the state is local.
+                      The lock of a local state is released when the run
+                      holding it exits, so this is only useful with remote
+                      state. Defaults to "terraform.tfstate".
+
+`
+	return strings.TrimSpace(helpText)
+}
+
+func (c *ForceUnlockCommand) Synopsis() string {
+	return "Manually unlock the state"
+}
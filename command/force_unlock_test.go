@@ -0,0 +1,104 @@
+package command
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/hashicorp/terraform/state"
+	"github.com/mitchellh/cli"
+)
+
+func TestForceUnlock(t *testing.T) {
+	s := new(testForceUnlockState)
+	ui := new(cli.MockUi)
+	c := &ForceUnlockCommand{
+		Meta: Meta{
+			Ui:    ui,
+			state: s,
+		},
+	}
+
+	args := []string{"-force", "abc123"}
+	if code := c.Run(args); code != 0 {
+		t.Fatalf("bad: %d\n\n%s", code, ui.ErrorWriter.String())
+	}
+
+	if s.ID != "abc123" {
+		t.Fatalf("bad: %#v", s.ID)
+	}
+}
+
+func TestForceUnlock_confirm(t *testing.T) {
+	cases := map[string]int{
+		"yes\n": 0,
+		"no\n":  1,
+	}
+
+	for answer, expected := range cases {
+		// Disable test mode so input would be asked
+		test = false
+
+		defaultInputReader = bytes.NewBufferString(answer)
+		defaultInputWriter = new(bytes.Buffer)
+
+		s := new(testForceUnlockState)
+		ui := new(cli.MockUi)
+		c := &ForceUnlockCommand{
+			Meta: Meta{
+				Ui:    ui,
+				state: s,
+			},
+		}
+
+		code := c.Run([]string{"abc123"})
+		test = true
+		if code != expected {
+			t.Fatalf("%q: bad: %d\n\n%s", answer, code, ui.ErrorWriter.String())
+		}
+		if (s.ID != "") != (expected == 0) {
+			t.Fatalf("%q: bad: %#v", answer, s.ID)
+		}
+	}
+}
+
+func TestForceUnlock_unsupported(t *testing.T) {
+	ui := new(cli.MockUi)
+	c := &ForceUnlockCommand{
+		Meta: Meta{
+			Ui:    ui,
+			state: new(state.InmemState),
+		},
+	}
+
+	args := []string{"-force", "abc123"}
+	if code := c.Run(args); code != 1 {
+		t.Fatalf("bad: %d\n\n%s", code, ui.OutputWriter.String())
+	}
+}
+
+func TestForceUnlock_noArgs(t *testing.T) {
+	ui := new(cli.MockUi)
+	c := &ForceUnlockCommand{
+		Meta: Meta{
+			Ui:    ui,
+			state: new(testForceUnlockState),
+		},
+	}
+
+	if code := c.Run(nil); code != 1 {
+		t.Fatalf("bad: %d\n\n%s", code, ui.OutputWriter.String())
+	}
+}
+
+// testForceUnlockState is a state that records the ID of the lock it is
+// force unlocked with.
+type testForceUnlockState struct {
+	state.InmemState
+
+	ID string
+}
+
+func (s *testForceUnlockState) ForceUnlock(id string) error {
+	s.ID = id
+	return nil
+}
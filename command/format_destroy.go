@@ -0,0 +1,233 @@
+package command
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform/terraform"
+	"github.com/mitchellh/colorstring"
+)
+
+// FormatDestroyOrderOpts are the options for formatting the destroy
+// order of a plan.
+type FormatDestroyOrderOpts struct {
+	// Plan is the plan to format. This is required.
+	Plan *terraform.Plan
+
+	// Color is the colorizer. This is optional.
+	Color *colorstring.Colorize
+}
+
+// destroyNode is a resource that is destroyed by a plan.
+type destroyNode struct {
+	Addr string
+	Path []string
+	Key  string
+
+	// BlockedBy are the destroyed resources that depend on this one, and
+	// so must be destroyed first. Kept are the resources that depend on
+	// this one but aren't destroyed, which may make the destroy fail.
+	BlockedBy []string
+	Kept      []string
+}
+
+// FormatDestroyOrder takes a plan and returns the order in which the
+// resources it destroys will be destroyed, as determined by the
+// dependencies recorded in the state. Resources in the same step can be
+// destroyed in parallel.
+func FormatDestroyOrder(opts *FormatDestroyOrderOpts) string {
+	if opts.Color == nil {
+		opts.Color = &colorstring.Colorize{
+			Colors: colorstring.DefaultColors,
+			Reset:  false,
+		}
+	}
+
+	steps, cycle, nodes := destroyOrder(opts.Plan)
+	if len(nodes) == 0 {
+		return "This plan destroys nothing."
+	}
+
+	buf := new(bytes.Buffer)
+	for i, step := range steps {
+		buf.WriteString(opts.Color.Color(fmt.Sprintf(
+			"[reset][bold]Step %d:\n", i+1)))
+		for _, addr := range step {
+			n := nodes[addr]
+			buf.WriteString(opts.Color.Color(fmt.Sprintf(
+				"[red]  - %s\n", addr)))
+			if len(n.BlockedBy) > 0 {
+				buf.WriteString(fmt.Sprintf(
+					"      after: %s\n", strings.Join(n.BlockedBy, ", ")))
+			}
+		}
+		buf.WriteString("\n")
+	}
+
+	if len(cycle) > 0 {
+		buf.WriteString(opts.Color.Color(fmt.Sprintf(
+			"[reset][bold][red]These resources depend on each other and can't "+
+				"be ordered:\n[reset]  %s\n\n", strings.Join(cycle, "\n  "))))
+	}
+
+	// Warn about resources that stay but depend on destroyed ones
+	addrs := make([]string, 0, len(nodes))
+	for addr, _ := range nodes {
+		addrs = append(addrs, addr)
+	}
+	sort.Strings(addrs)
+	for _, addr := range addrs {
+		n := nodes[addr]
+		if len(n.Kept) == 0 {
+			continue
+		}
+
+		buf.WriteString(opts.Color.Color(fmt.Sprintf(
+			"[reset][yellow]Warning: %s is depended on by %s, which "+
+				"will not be destroyed.\n[reset]",
+			addr, strings.Join(n.Kept, ", "))))
+	}
+
+	return strings.TrimSpace(buf.String())
+}
+
+// destroyOrder returns the resources destroyed by the plan grouped in
+// the steps they will be destroyed in, the resources that can't be
+// ordered because they depend on each other, and all the destroyed
+// resources by address.
+func destroyOrder(p *terraform.Plan) ([][]string, []string, map[string]*destroyNode) {
+	nodes := make(map[string]*destroyNode)
+	if p.Diff == nil {
+		return nil, nil, nodes
+	}
+
+	for _, m := range p.Diff.Modules {
+		for key, rdiff := range m.Resources {
+			if !rdiff.Destroy {
+				continue
+			}
+
+			addr := stateResourceAddr(m.Path, key)
+			nodes[addr] = &destroyNode{Addr: addr, Path: m.Path, Key: key}
+		}
+	}
+
+	// Record the dependencies between the resources in the state
+	if p.State != nil {
+		for _, m := range p.State.Modules {
+			for key, rs := range m.Resources {
+				addr := stateResourceAddr(m.Path, key)
+				_, destroyed := nodes[addr]
+
+				for _, dep := range rs.Dependencies {
+					for _, n := range destroyDependencies(nodes, m.Path, dep) {
+						if n.Addr == addr {
+							continue
+						}
+
+						if destroyed {
+							n.BlockedBy = append(n.BlockedBy, addr)
+						} else {
+							n.Kept = append(n.Kept, addr)
+						}
+					}
+				}
+			}
+		}
+	}
+	for _, n := range nodes {
+		n.BlockedBy = uniqueSortedStrings(n.BlockedBy)
+		n.Kept = uniqueSortedStrings(n.Kept)
+	}
+
+	// Each step destroys the resources that are no longer blocked by
+	// resources that are yet to be destroyed.
+	done := make(map[string]bool)
+	var steps [][]string
+	for len(done) < len(nodes) {
+		var step []string
+		for addr, n := range nodes {
+			if done[addr] {
+				continue
+			}
+
+			ready := true
+			for _, b := range n.BlockedBy {
+				if !done[b] {
+					ready = false
+					break
+				}
+			}
+			if ready {
+				step = append(step, addr)
+			}
+		}
+
+		if len(step) == 0 {
+			break
+		}
+
+		sort.Strings(step)
+		for _, addr := range step {
+			done[addr] = true
+		}
+		steps = append(steps, step)
+	}
+
+	var cycle []string
+	for addr, _ := range nodes {
+		if !done[addr] {
+			cycle = append(cycle, addr)
+		}
+	}
+	sort.Strings(cycle)
+
+	return steps, cycle, nodes
+}
+
+// destroyDependencies returns the destroyed resources matching a
+// dependency of a resource in the module with the given path. A
+// dependency on a module matches all the resources in it.
+func destroyDependencies(
+	nodes map[string]*destroyNode, path []string, dep string) []*destroyNode {
+	var result []*destroyNode
+	if strings.HasPrefix(dep, "module.") {
+		child := append(append([]string{}, path...), strings.TrimPrefix(dep, "module."))
+		for _, n := range nodes {
+			if len(n.Path) >= len(child) &&
+				strings.Join(n.Path[:len(child)], ".") == strings.Join(child, ".") {
+				result = append(result, n)
+			}
+		}
+
+		return result
+	}
+
+	for _, n := range nodes {
+		if strings.Join(n.Path, ".") != strings.Join(path, ".") {
+			continue
+		}
+
+		if n.Key == dep || strings.HasPrefix(n.Key, dep+".") {
+			result = append(result, n)
+		}
+	}
+
+	return result
+}
+
+// uniqueSortedStrings returns the sorted strings without duplicates.
+func uniqueSortedStrings(s []string) []string {
+	sort.Strings(s)
+
+	var result []string
+	for _, v := range s {
+		if len(result) == 0 || v != result[len(result)-1] {
+			result = append(result, v)
+		}
+	}
+
+	return result
+}
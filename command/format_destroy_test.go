@@ -0,0 +1,149 @@
+package command
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestDestroyOrder(t *testing.T) {
+	plan := &terraform.Plan{
+		Diff: &terraform.Diff{
+			Modules: []*terraform.ModuleDiff{
+				&terraform.ModuleDiff{
+					Path: []string{"root"},
+					Resources: map[string]*terraform.InstanceDiff{
+						"aws_vpc.main":       &terraform.InstanceDiff{Destroy: true},
+						"aws_subnet.main":    &terraform.InstanceDiff{Destroy: true},
+						"aws_instance.web.0": &terraform.InstanceDiff{Destroy: true},
+						"aws_instance.web.1": &terraform.InstanceDiff{Destroy: true},
+						"aws_eip.web":        &terraform.InstanceDiff{Destroy: true},
+					},
+				},
+				&terraform.ModuleDiff{
+					Path: []string{"root", "db"},
+					Resources: map[string]*terraform.InstanceDiff{
+						"aws_db_instance.db": &terraform.InstanceDiff{Destroy: true},
+					},
+				},
+			},
+		},
+		State: &terraform.State{
+			Modules: []*terraform.ModuleState{
+				&terraform.ModuleState{
+					Path: []string{"root"},
+					Resources: map[string]*terraform.ResourceState{
+						"aws_vpc.main": &terraform.ResourceState{},
+						"aws_subnet.main": &terraform.ResourceState{
+							Dependencies: []string{"aws_vpc.main", "var.cidr"},
+						},
+						"aws_instance.web.0": &terraform.ResourceState{
+							Dependencies: []string{"aws_subnet.main", "module.db"},
+						},
+						"aws_instance.web.1": &terraform.ResourceState{
+							Dependencies: []string{"aws_subnet.main", "module.db"},
+						},
+						"aws_eip.web": &terraform.ResourceState{
+							Dependencies: []string{"aws_instance.web"},
+						},
+						"aws_network_interface.kept": &terraform.ResourceState{
+							Dependencies: []string{"aws_subnet.main"},
+						},
+					},
+				},
+				&terraform.ModuleState{
+					Path: []string{"root", "db"},
+					Resources: map[string]*terraform.ResourceState{
+						"aws_db_instance.db": &terraform.ResourceState{},
+					},
+				},
+			},
+		},
+	}
+
+	steps, cycle, nodes := destroyOrder(plan)
+	expected := [][]string{
+		[]string{"aws_eip.web"},
+		[]string{"aws_instance.web.0", "aws_instance.web.1"},
+		[]string{"aws_subnet.main", "module.db.aws_db_instance.db"},
+		[]string{"aws_vpc.main"},
+	}
+	if !reflect.DeepEqual(steps, expected) {
+		t.Fatalf("bad: %#v", steps)
+	}
+	if len(cycle) != 0 {
+		t.Fatalf("bad: %#v", cycle)
+	}
+
+	blockedBy := nodes["aws_subnet.main"].BlockedBy
+	if !reflect.DeepEqual(blockedBy, []string{"aws_instance.web.0", "aws_instance.web.1"}) {
+		t.Fatalf("bad: %#v", blockedBy)
+	}
+	kept := nodes["aws_subnet.main"].Kept
+	if !reflect.DeepEqual(kept, []string{"aws_network_interface.kept"}) {
+		t.Fatalf("bad: %#v", kept)
+	}
+
+	actual := FormatDestroyOrder(&FormatDestroyOrderOpts{Plan: plan})
+	for _, s := range []string{
+		"Step 4:",
+		"after: aws_instance.web.0, aws_instance.web.1",
+		"Warning: aws_subnet.main is depended on by aws_network_interface.kept",
+	} {
+		if !strings.Contains(actual, s) {
+			t.Fatalf("missing %q:\n\n%s", s, actual)
+		}
+	}
+}
+
+func TestDestroyOrder_cycle(t *testing.T) {
+	plan := &terraform.Plan{
+		Diff: &terraform.Diff{
+			Modules: []*terraform.ModuleDiff{
+				&terraform.ModuleDiff{
+					Path: []string{"root"},
+					Resources: map[string]*terraform.InstanceDiff{
+						"aws_instance.a": &terraform.InstanceDiff{Destroy: true},
+						"aws_instance.b": &terraform.InstanceDiff{Destroy: true},
+						"aws_instance.c": &terraform.InstanceDiff{Destroy: true},
+					},
+				},
+			},
+		},
+		State: &terraform.State{
+			Modules: []*terraform.ModuleState{
+				&terraform.ModuleState{
+					Path: []string{"root"},
+					Resources: map[string]*terraform.ResourceState{
+						"aws_instance.a": &terraform.ResourceState{
+							Dependencies: []string{"aws_instance.b"},
+						},
+						"aws_instance.b": &terraform.ResourceState{
+							Dependencies: []string{"aws_instance.a"},
+						},
+						"aws_instance.c": &terraform.ResourceState{},
+					},
+				},
+			},
+		},
+	}
+
+	steps, cycle, _ := destroyOrder(plan)
+	if !reflect.DeepEqual(steps, [][]string{[]string{"aws_instance.c"}}) {
+		t.Fatalf("bad: %#v", steps)
+	}
+	if !reflect.DeepEqual(cycle, []string{"aws_instance.a", "aws_instance.b"}) {
+		t.Fatalf("bad: %#v", cycle)
+	}
+}
+
+func TestFormatDestroyOrder_nothing(t *testing.T) {
+	actual := FormatDestroyOrder(&FormatDestroyOrderOpts{
+		Plan: &terraform.Plan{},
+	})
+	if actual != "This plan destroys nothing." {
+		t.Fatalf("bad: %s", actual)
+	}
+}
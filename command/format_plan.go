@@ -3,8 +3,10 @@ package command
 import (
 	"bytes"
 	"fmt"
+	"path"
 	"sort"
 	"strings"
+	"unicode/utf8"
 
 	"github.com/hashicorp/terraform/terraform"
 	"github.com/mitchellh/colorstring"
@@ -21,6 +23,98 @@ type FormatPlanOpts struct {
 	// ModuleDepth is the depth of the modules to expand. By default this
 	// is zero which will not expand modules at all.
 	ModuleDepth int
+
+	// FilterTypes, if set, limits the resources shown to the ones with a
+	// type matching one of the patterns, such as "aws_iam_*". The patterns
+	// are in the syntax of path.Match. This is optional.
+	FilterTypes []string
+
+	// Only, if set, limits the resources shown to the ones with one of
+	// the actions in PlanActions. Replaced resources are also shown for
+	// "create" and "destroy", since they are both. This is optional.
+	Only []string
+
+	// FullValues shows the values of the attributes in full. By default,
+	// long values are shortened to the part that changes.
+	FullValues bool
+}
+
+// PlanActions are the actions that FormatPlanOpts.Only can filter on.
+var PlanActions = []string{"create", "update", "destroy", "replace"}
+
+// ValidatePlanFilters checks the FilterTypes and Only of the options, so
+// that a typo doesn't silently hide the whole plan.
+func (opts *FormatPlanOpts) ValidatePlanFilters() error {
+	for _, pattern := range opts.FilterTypes {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return fmt.Errorf("Invalid type filter %q: %s", pattern, err)
+		}
+	}
+
+	for _, action := range opts.Only {
+		valid := false
+		for _, a := range PlanActions {
+			if action == a {
+				valid = true
+				break
+			}
+		}
+		if !valid {
+			return fmt.Errorf(
+				"Invalid action %q, must be one of: %s",
+				action, strings.Join(PlanActions, ", "))
+		}
+	}
+
+	return nil
+}
+
+// filtered returns true if the options have filters.
+func (opts *FormatPlanOpts) filtered() bool {
+	return len(opts.FilterTypes) > 0 || len(opts.Only) > 0
+}
+
+// show returns true if the diff of the resource with the given name, as
+// it is keyed in its module, passes the filters of the options.
+func (opts *FormatPlanOpts) show(name string, rdiff *terraform.InstanceDiff) bool {
+	if len(opts.FilterTypes) > 0 {
+		typ := strings.SplitN(name, ".", 2)[0]
+		match := false
+		for _, pattern := range opts.FilterTypes {
+			if ok, _ := path.Match(pattern, typ); ok {
+				match = true
+				break
+			}
+		}
+		if !match {
+			return false
+		}
+	}
+
+	if len(opts.Only) > 0 {
+		var actions []string
+		switch rdiff.ChangeType() {
+		case terraform.DiffCreate:
+			actions = []string{"create"}
+		case terraform.DiffUpdate:
+			actions = []string{"update"}
+		case terraform.DiffDestroy:
+			actions = []string{"destroy"}
+		case terraform.DiffDestroyCreate:
+			actions = []string{"replace", "create", "destroy"}
+		}
+
+		for _, action := range opts.Only {
+			for _, a := range actions {
+				if action == a {
+					return true
+				}
+			}
+		}
+		return false
+	}
+
+	return true
 }
 
 // FormatPlan takes a plan and returns a
@@ -46,6 +140,10 @@ func FormatPlan(opts *FormatPlanOpts) string {
 		}
 	}
 
+	if buf.Len() == 0 && opts.filtered() {
+		return "No changes in this plan match the filters."
+	}
+
 	return strings.TrimSpace(buf.String())
 }
 
@@ -74,7 +172,7 @@ func formatPlanModuleExpand(
 	// Go through each sorted name and start building the output
 	for _, name := range names {
 		rdiff := m.Resources[name]
-		if rdiff.Empty() {
+		if rdiff.Empty() || !opts.show(name, rdiff) {
 			continue
 		}
 
@@ -103,48 +201,306 @@ func formatPlanModuleExpand(
 			"[%s]%s %s\n",
 			color, symbol, name)))
 
-		// Get all the attributes that are changing, and sort them. Also
-		// determine the longest key so that we can align them all.
-		keyLen := 0
-		keys := make([]string, 0, len(rdiff.Attributes))
-		for key, _ := range rdiff.Attributes {
-			// Skip the ID since we do that specially
-			if key == "id" {
-				continue
-			}
+		formatPlanAttributes(buf, rdiff, opts)
 
-			keys = append(keys, key)
-			if len(key) > keyLen {
-				keyLen = len(key)
-			}
+		// Write the reset color so we don't overload the user's terminal
+		buf.WriteString(opts.Color.Color("[reset]\n"))
+	}
+}
+
+// formatPlanAttributes outputs the attributes of a resource diff. The
+// attributes of the elements of lists and sets are grouped by element, so
+// that the opaque hash codes of set elements aren't shown, and it is clear
+// which elements are added, removed or changed.
+func formatPlanAttributes(
+	buf *bytes.Buffer, rdiff *terraform.InstanceDiff, opts *FormatPlanOpts) {
+	attrs := make(map[string]*terraform.ResourceAttrDiff)
+	elems := make(map[string]*planElement)
+	for key, attrDiff := range rdiff.Attributes {
+		// Skip the ID since we do that specially
+		if key == "id" {
+			continue
 		}
-		sort.Strings(keys)
 
-		// Go through and output each attribute
-		for _, attrK := range keys {
-			attrDiff := rdiff.Attributes[attrK]
+		name, elemKey, field, ok := splitPlanElementKey(key)
+		if !ok {
+			attrs[key] = attrDiff
+			continue
+		}
 
-			v := attrDiff.New
-			if attrDiff.NewComputed {
-				v = "<computed>"
+		id := name + "." + elemKey
+		e, ok := elems[id]
+		if !ok {
+			e = &planElement{
+				Name:  name,
+				Key:   elemKey,
+				Attrs: make(map[string]*terraform.ResourceAttrDiff),
 			}
+			elems[id] = e
+		}
+		e.Attrs[field] = attrDiff
+	}
+
+	// Get all the attributes that are changing, and sort them. Also
+	// determine the longest key so that we can align them all.
+	keyLen := 0
+	keys := make([]string, 0, len(attrs))
+	for key, _ := range attrs {
+		keys = append(keys, key)
+		if len(key) > keyLen {
+			keyLen = len(key)
+		}
+	}
+	sort.Strings(keys)
+
+	// Go through and output each attribute
+	for _, attrK := range keys {
+		buf.WriteString(fmt.Sprintf(
+			"    %s:%s %s\n",
+			attrK,
+			strings.Repeat(" ", keyLen-len(attrK)),
+			formatPlanAttrDiff(attrs[attrK], rdiff, opts)))
+	}
 
-			newResource := ""
-			if attrDiff.RequiresNew && rdiff.Destroy {
-				newResource = " (forces new resource)"
+	// Then output the elements, sorted by their collection
+	ids := make([]string, 0, len(elems))
+	for id, _ := range elems {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	for _, id := range ids {
+		e := elems[id]
+		change := e.Change()
+
+		symbol := "~"
+		header := e.Name + "." + e.Key
+		switch change {
+		case planElementAdded:
+			symbol = "+"
+			header = e.Name
+		case planElementRemoved:
+			symbol = "-"
+			header = e.Name
+		}
+
+		// Elements of primitive values are a single line
+		if attrDiff, ok := e.Attrs[""]; ok && len(e.Attrs) == 1 {
+			buf.WriteString(fmt.Sprintf(
+				"    %s %s: %s\n",
+				symbol, header, formatPlanAttrDiff(attrDiff, rdiff, opts)))
+			continue
+		}
+
+		buf.WriteString(fmt.Sprintf("    %s %s\n", symbol, header))
+
+		fieldLen := 0
+		fields := make([]string, 0, len(e.Attrs))
+		for field, _ := range e.Attrs {
+			fields = append(fields, field)
+			if len(field) > fieldLen {
+				fieldLen = len(field)
 			}
+		}
+		sort.Strings(fields)
 
+		for _, field := range fields {
 			buf.WriteString(fmt.Sprintf(
-				"    %s:%s %#v => %#v%s\n",
-				attrK,
-				strings.Repeat(" ", keyLen-len(attrK)),
-				attrDiff.Old,
-				v,
-				newResource))
+				"        %s:%s %s\n",
+				field,
+				strings.Repeat(" ", fieldLen-len(field)),
+				formatPlanAttrDiff(e.Attrs[field], rdiff, opts)))
 		}
+	}
+}
 
-		// Write the reset color so we don't overload the user's terminal
-		buf.WriteString(opts.Color.Color("[reset]\n"))
+// formatPlanAttrDiff formats the old and new values of an attribute diff.
+// Unless FullValues is set, long values are shortened around the part
+// that changes.
+func formatPlanAttrDiff(
+	attrDiff *terraform.ResourceAttrDiff,
+	rdiff *terraform.InstanceDiff,
+	opts *FormatPlanOpts) string {
+	newResource := ""
+	if attrDiff.RequiresNew && rdiff.Destroy {
+		newResource = " (forces new resource)"
+	}
+
+	if attrDiff.NewComputed {
+		old, shortened := attrDiff.Old, false
+		if !opts.FullValues {
+			old, shortened = shortenPlanValue(old, 0, len(old))
+		}
+		return fmt.Sprintf("%#v => <computed>%s%s",
+			old, newResource, formatPlanShortened(shortened))
+	}
+
+	old, v := attrDiff.Old, attrDiff.New
+	shortened := false
+	if !opts.FullValues {
+		old, v, shortened = shortenPlanValues(old, v)
+	}
+
+	return fmt.Sprintf("%#v => %#v%s%s",
+		old, v, newResource, formatPlanShortened(shortened))
+}
+
+func formatPlanShortened(shortened bool) string {
+	if shortened {
+		return " (shortened, see -full-values)"
+	}
+
+	return ""
+}
+
+const (
+	// planValueMaxLen is the length above which values are shortened.
+	planValueMaxLen = 200
+
+	// planValueContext is the length of the unchanged text that is kept
+	// before and after the part of a shortened value that changes.
+	planValueContext = 40
+)
+
+// shortenPlanValues shortens the old and new values of an attribute if
+// either is longer than planValueMaxLen. The text that both values start
+// and end with is cut, except for some context, so that what is left is
+// the part that changes. The cut parts are replaced by "...".
+func shortenPlanValues(old, v string) (string, string, bool) {
+	if len(old) <= planValueMaxLen && len(v) <= planValueMaxLen {
+		return old, v, false
+	}
+
+	// Find the common prefix and suffix, without overlapping
+	prefix := 0
+	for prefix < len(old) && prefix < len(v) && old[prefix] == v[prefix] {
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(old)-prefix && suffix < len(v)-prefix &&
+		old[len(old)-suffix-1] == v[len(v)-suffix-1] {
+		suffix++
+	}
+
+	// The changed part starts and ends with whole UTF-8 characters
+	for prefix > 0 && prefix < len(old) && !utf8.RuneStart(old[prefix]) {
+		prefix--
+	}
+	for suffix > 0 && !utf8.RuneStart(old[len(old)-suffix]) {
+		suffix--
+	}
+
+	start := prefix - planValueContext
+	if start < 0 || old == "" || v == "" {
+		start = 0
+	}
+	endCut := suffix - planValueContext
+	if endCut < 0 || old == "" || v == "" {
+		endCut = 0
+	}
+
+	old, oldShort := shortenPlanValue(old, start, len(old)-endCut)
+	v, vShort := shortenPlanValue(v, start, len(v)-endCut)
+	return old, v, oldShort || vShort
+}
+
+// shortenPlanValue returns the part of the value from start to end, at
+// most planValueMaxLen long, with "..." in place of what is cut.
+func shortenPlanValue(v string, start, end int) (string, bool) {
+	if start == 0 && end == len(v) && len(v) <= planValueMaxLen {
+		return v, false
+	}
+	if end-start > planValueMaxLen {
+		end = start + planValueMaxLen
+	}
+
+	// Don't cut in the middle of a UTF-8 character
+	for start > 0 && !utf8.RuneStart(v[start]) {
+		start--
+	}
+	for end < len(v) && !utf8.RuneStart(v[end]) {
+		end++
+	}
+
+	result := v[start:end]
+	if start > 0 {
+		result = "..." + result
+	}
+	if end < len(v) {
+		result = result + "..."
+	}
+
+	return result, true
+}
+
+// splitPlanElementKey splits the key of an attribute of a list or set
+// element, such as "ebs_block_device.2557249741.volume_size", into the name
+// of the collection, the index or hash code of the element, and the rest
+// of the key, which is empty for elements that are primitive values. The
+// count of the collection, "ebs_block_device.#", isn't an element.
+func splitPlanElementKey(key string) (string, string, string, bool) {
+	parts := strings.SplitN(key, ".", 3)
+	if len(parts) < 2 || !isPlanElementKey(parts[1]) {
+		return "", "", "", false
+	}
+
+	field := ""
+	if len(parts) == 3 {
+		field = parts[2]
+	}
+
+	return parts[0], parts[1], field, true
+}
+
+func isPlanElementKey(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, c := range s {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+
+	return true
+}
+
+// planElementChange is how an element of a list or set changes.
+type planElementChange byte
+
+const (
+	planElementChanged planElementChange = iota
+	planElementAdded
+	planElementRemoved
+)
+
+// planElement is an element of a list or set in a resource diff, with the
+// diffs of its attributes keyed by the rest of their keys.
+type planElement struct {
+	Name  string
+	Key   string
+	Attrs map[string]*terraform.ResourceAttrDiff
+}
+
+// Change determines whether the element is added, removed or changed.
+func (e *planElement) Change() planElementChange {
+	added, removed := true, true
+	for _, attrDiff := range e.Attrs {
+		if !attrDiff.NewRemoved {
+			removed = false
+		}
+		if attrDiff.NewRemoved || attrDiff.Old != "" {
+			added = false
+		}
+	}
+
+	switch {
+	case removed:
+		return planElementRemoved
+	case added:
+		return planElementAdded
+	default:
+		return planElementChanged
 	}
 }
 
@@ -157,6 +513,21 @@ func formatPlanModuleSingle(
 		return
 	}
 
+	// Only count the resources that pass the filters, and leave the
+	// module out if there are none.
+	count := 0
+	for name, rdiff := range m.Resources {
+		if !rdiff.Empty() && opts.show(name, rdiff) {
+			count++
+		}
+	}
+	if count == 0 && opts.filtered() {
+		return
+	}
+	if !opts.filtered() {
+		count = len(m.Resources)
+	}
+
 	moduleName := fmt.Sprintf("module.%s", strings.Join(m.Path[1:], "."))
 
 	// Determine the color for the text (green for adding, yellow
@@ -178,6 +549,6 @@ func formatPlanModuleSingle(
 		color, symbol, moduleName)))
 	buf.WriteString(fmt.Sprintf(
 		"    %d resource(s)",
-		len(m.Resources)))
+		count))
 	buf.WriteString(opts.Color.Color("[reset]\n"))
 }
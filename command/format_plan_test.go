@@ -0,0 +1,337 @@
+package command
+
+import (
+	"reflect"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestFormatPlan_setElements(t *testing.T) {
+	plan := &terraform.Plan{
+		Diff: &terraform.Diff{
+			Modules: []*terraform.ModuleDiff{
+				&terraform.ModuleDiff{
+					Path: []string{"root"},
+					Resources: map[string]*terraform.InstanceDiff{
+						"aws_instance.web": &terraform.InstanceDiff{
+							Attributes: map[string]*terraform.ResourceAttrDiff{
+								"ami": &terraform.ResourceAttrDiff{
+									Old: "ami-1",
+									New: "ami-1",
+								},
+								"ebs_block_device.#": &terraform.ResourceAttrDiff{
+									Old: "1",
+									New: "1",
+								},
+								"ebs_block_device.2557249741.device_name": &terraform.ResourceAttrDiff{
+									Old:        "/dev/sdb",
+									NewRemoved: true,
+								},
+								"ebs_block_device.2557249741.volume_size": &terraform.ResourceAttrDiff{
+									Old:        "10",
+									NewRemoved: true,
+								},
+								"ebs_block_device.1234.device_name": &terraform.ResourceAttrDiff{
+									New: "/dev/sdb",
+								},
+								"ebs_block_device.1234.volume_size": &terraform.ResourceAttrDiff{
+									New: "20",
+								},
+								"security_groups.999": &terraform.ResourceAttrDiff{
+									New: "sg-1",
+								},
+								"route.0.cidr_block": &terraform.ResourceAttrDiff{
+									Old: "10.0.0.0/8",
+									New: "10.0.0.0/16",
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	actual := FormatPlan(&FormatPlanOpts{Plan: plan})
+	actual = strings.Replace(actual, "\x1b[0m", "", -1)
+	actual = strings.Replace(actual, "\x1b[33m", "", -1)
+	actual = strings.TrimSpace(actual)
+
+	expected := strings.TrimSpace(`
+~ aws_instance.web
+    ami:                "ami-1" => "ami-1"
+    ebs_block_device.#: "1" => "1"
+    + ebs_block_device
+        device_name: "" => "/dev/sdb"
+        volume_size: "" => "20"
+    - ebs_block_device
+        device_name: "/dev/sdb" => ""
+        volume_size: "10" => ""
+    ~ route.0
+        cidr_block: "10.0.0.0/8" => "10.0.0.0/16"
+    + security_groups: "" => "sg-1"
+`)
+	if actual != expected {
+		t.Fatalf("bad:\n\n%s\n\nexpected:\n\n%s", actual, expected)
+	}
+}
+
+func TestFormatPlan_filter(t *testing.T) {
+	plan := &terraform.Plan{
+		Diff: &terraform.Diff{
+			Modules: []*terraform.ModuleDiff{
+				&terraform.ModuleDiff{
+					Path: []string{"root"},
+					Resources: map[string]*terraform.InstanceDiff{
+						"aws_iam_role.foo": &terraform.InstanceDiff{
+							Attributes: map[string]*terraform.ResourceAttrDiff{
+								"id": &terraform.ResourceAttrDiff{
+									NewComputed: true,
+									RequiresNew: true,
+								},
+								"name": &terraform.ResourceAttrDiff{
+									New: "foo",
+								},
+							},
+						},
+						"aws_iam_policy.bar": &terraform.InstanceDiff{
+							Destroy: true,
+						},
+						"aws_iam_user.baz": &terraform.InstanceDiff{
+							Destroy: true,
+							Attributes: map[string]*terraform.ResourceAttrDiff{
+								"name": &terraform.ResourceAttrDiff{
+									Old:         "a",
+									New:         "b",
+									RequiresNew: true,
+								},
+							},
+						},
+						"aws_instance.web": &terraform.InstanceDiff{
+							Destroy: true,
+						},
+					},
+				},
+				&terraform.ModuleDiff{
+					Path: []string{"root", "child"},
+					Resources: map[string]*terraform.InstanceDiff{
+						"aws_instance.app": &terraform.InstanceDiff{
+							Destroy: true,
+						},
+						"aws_iam_role.app": &terraform.InstanceDiff{
+							Destroy: true,
+						},
+					},
+				},
+			},
+		},
+	}
+
+	cases := []struct {
+		Types    []string
+		Only     []string
+		Expected []string
+	}{
+		{
+			nil,
+			nil,
+			[]string{
+				"aws_iam_policy.bar", "aws_iam_role.foo", "aws_iam_user.baz",
+				"aws_instance.web", "module.child", "2 resource(s)",
+			},
+		},
+		{
+			[]string{"aws_iam_*"},
+			nil,
+			[]string{
+				"aws_iam_policy.bar", "aws_iam_role.foo", "aws_iam_user.baz",
+				"module.child", "1 resource(s)",
+			},
+		},
+		{
+			nil,
+			[]string{"destroy"},
+			[]string{
+				"aws_iam_policy.bar", "aws_iam_user.baz", "aws_instance.web",
+				"module.child", "2 resource(s)",
+			},
+		},
+		{
+			[]string{"aws_iam_*"},
+			[]string{"create"},
+			[]string{"aws_iam_role.foo", "aws_iam_user.baz"},
+		},
+		{
+			[]string{"aws_iam_role"},
+			[]string{"replace"},
+			[]string{"No changes in this plan match the filters."},
+		},
+	}
+
+	for i, tc := range cases {
+		opts := &FormatPlanOpts{
+			Plan:        plan,
+			FilterTypes: tc.Types,
+			Only:        tc.Only,
+		}
+		if err := opts.ValidatePlanFilters(); err != nil {
+			t.Fatalf("%d: err: %s", i, err)
+		}
+
+		// Keep the resource headers and the counts of collapsed modules
+		actual := FormatPlan(opts)
+		var lines []string
+		for _, line := range strings.Split(colorRe.ReplaceAllString(actual, ""), "\n") {
+			switch {
+			case strings.HasSuffix(line, "resource(s)"):
+				lines = append(lines, strings.TrimSpace(line))
+			case line == "" || strings.HasPrefix(line, " "):
+			case strings.HasPrefix(line, "No changes"):
+				lines = append(lines, line)
+			default:
+				lines = append(lines, strings.Fields(line)[1])
+			}
+		}
+		if !reflect.DeepEqual(lines, tc.Expected) {
+			t.Fatalf("%d: bad: %#v\n\n%s", i, lines, actual)
+		}
+	}
+}
+
+func TestFormatPlan_values(t *testing.T) {
+	long := strings.Repeat("a", 300)
+	plan := &terraform.Plan{
+		Diff: &terraform.Diff{
+			Modules: []*terraform.ModuleDiff{
+				&terraform.ModuleDiff{
+					Path: []string{"root"},
+					Resources: map[string]*terraform.InstanceDiff{
+						"aws_iam_policy.foo": &terraform.InstanceDiff{
+							Attributes: map[string]*terraform.ResourceAttrDiff{
+								"arn": &terraform.ResourceAttrDiff{
+									Old:         "arn:foo",
+									NewComputed: true,
+								},
+								"policy": &terraform.ResourceAttrDiff{
+									Old: long + "Allow" + long,
+									New: long + "Deny" + long,
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	actual := FormatPlan(&FormatPlanOpts{Plan: plan})
+	actual = colorRe.ReplaceAllString(actual, "")
+	context := strings.Repeat("a", planValueContext)
+	expected := strings.TrimSpace(`
+~ aws_iam_policy.foo
+    arn:    "arn:foo" => <computed>
+    policy: "...` + context + `Allow` + context + `..." => "...` + context + `Deny` + context + `..." (shortened, see -full-values)
+`)
+	if strings.TrimSpace(actual) != expected {
+		t.Fatalf("bad:\n\n%s\n\nexpected:\n\n%s", actual, expected)
+	}
+
+	actual = FormatPlan(&FormatPlanOpts{Plan: plan, FullValues: true})
+	if !strings.Contains(actual, long+"Deny"+long) {
+		t.Fatalf("bad:\n\n%s", actual)
+	}
+	if strings.Contains(actual, "shortened") {
+		t.Fatalf("bad:\n\n%s", actual)
+	}
+}
+
+func TestShortenPlanValues(t *testing.T) {
+	long := strings.Repeat("a", 300)
+	cases := []struct {
+		Old, New       string
+		OldOut, NewOut string
+		Shortened      bool
+	}{
+		{"foo", "bar", "foo", "bar", false},
+		{"", long, "", long[:planValueMaxLen] + "...", true},
+		{long, "", long[:planValueMaxLen] + "...", "", true},
+		{
+			long + "x",
+			long + "y",
+			"..." + long[:planValueContext] + "x",
+			"..." + long[:planValueContext] + "y",
+			true,
+		},
+		{
+			"x" + long,
+			"y" + long,
+			"x" + long[:planValueContext] + "...",
+			"y" + long[:planValueContext] + "...",
+			true,
+		},
+		{
+			long + "\u00e9" + long,
+			long + "\u00e8" + long,
+			"..." + long[:planValueContext] + "\u00e9" + long[:planValueContext] + "...",
+			"..." + long[:planValueContext] + "\u00e8" + long[:planValueContext] + "...",
+			true,
+		},
+	}
+
+	for i, tc := range cases {
+		old, v, shortened := shortenPlanValues(tc.Old, tc.New)
+		if old != tc.OldOut || v != tc.NewOut || shortened != tc.Shortened {
+			t.Fatalf("%d: bad: %q %q %v", i, old, v, shortened)
+		}
+	}
+}
+
+var colorRe = regexp.MustCompile("\x1b\\[[0-9;]*m")
+
+func TestFormatPlanOpts_validatePlanFilters(t *testing.T) {
+	cases := []struct {
+		Types []string
+		Only  []string
+		Err   bool
+	}{
+		{nil, nil, false},
+		{[]string{"aws_iam_*", "aws_s3_bucket"}, []string{"create", "replace"}, false},
+		{[]string{"aws_iam_["}, nil, true},
+		{nil, []string{"delete"}, true},
+	}
+
+	for i, tc := range cases {
+		opts := &FormatPlanOpts{FilterTypes: tc.Types, Only: tc.Only}
+		err := opts.ValidatePlanFilters()
+		if (err != nil) != tc.Err {
+			t.Fatalf("%d: err: %s", i, err)
+		}
+	}
+}
+
+func TestSplitPlanElementKey(t *testing.T) {
+	cases := []struct {
+		Key   string
+		Name  string
+		Elem  string
+		Field string
+		Ok    bool
+	}{
+		{"ami", "", "", "", false},
+		{"ebs_block_device.#", "", "", "", false},
+		{"tags.Name", "", "", "", false},
+		{"ebs_block_device.2557249741.volume_size", "ebs_block_device", "2557249741", "volume_size", true},
+		{"ingress.12.cidr_blocks.0", "ingress", "12", "cidr_blocks.0", true},
+		{"security_groups.999", "security_groups", "999", "", true},
+	}
+
+	for i, tc := range cases {
+		name, elem, field, ok := splitPlanElementKey(tc.Key)
+		if name != tc.Name || elem != tc.Elem || field != tc.Field || ok != tc.Ok {
+			t.Fatalf("%d: bad: %q %q %q %v", i, name, elem, field, ok)
+		}
+	}
+}
@@ -61,7 +61,7 @@ func FormatState(opts *FormatStateOpts) string {
 		// Output each output k/v pair
 		for _, k := range ks {
 			v := m.Outputs[k]
-			buf.WriteString(fmt.Sprintf("%s = %s\n", k, v))
+			buf.WriteString(fmt.Sprintf("%s = %s\n", k, formatOutputValue(v)))
 		}
 	}
 
@@ -104,6 +104,9 @@ func formatStateModuleExpand(
 		if len(rs.Tainted) > 0 {
 			taintStr = " (tainted)"
 		}
+		if len(rs.Deposed) > 0 {
+			taintStr += fmt.Sprintf(" (%d deposed)", len(rs.Deposed))
+		}
 
 		buf.WriteString(fmt.Sprintf("%s:%s\n", name, taintStr))
 		buf.WriteString(fmt.Sprintf("  id = %s\n", id))
@@ -127,6 +130,12 @@ func formatStateModuleExpand(
 				buf.WriteString(fmt.Sprintf("  %s = %s\n", ak, av))
 			}
 		}
+
+		// Deposed objects are listed by their index, which is how they
+		// are named in the output of apply and by "state rm-deposed".
+		for i, d := range rs.Deposed {
+			buf.WriteString(fmt.Sprintf("  deposed #%d id = %s\n", i, d.ID))
+		}
 	}
 
 	buf.WriteString("[reset]\n")
@@ -40,6 +40,7 @@ func (c *GetCommand) Run(args []string) int {
 		path, err = os.Getwd()
 		if err != nil {
 			c.Ui.Error(fmt.Sprintf("Error getting pwd: %s", err))
+			return 1
 		}
 	}
 
@@ -72,10 +73,14 @@ Usage: terraform get [options] PATH
   already downloaded, it will not be redownloaded or checked for updates
   unless the -update flag is specified.
 
+  The exact revisions downloaded are written to terraform-modules.lock
+  and are used on subsequent runs until -update is specified.
+
 Options:
 
   -update=false       If true, modules already downloaded will be checked
-                      for updates and updated if necessary.
+                      for updates and updated if necessary, ignoring the
+                      revisions in the module lock.
 
 `
 	return strings.TrimSpace(helpText)
@@ -112,3 +112,26 @@ func TestGet_update(t *testing.T) {
 		t.Fatalf("doesn't look like get: %s", output)
 	}
 }
+
+func TestGet_pwdError(t *testing.T) {
+	defer testCwdRemoved(t)()
+
+	ui := new(cli.MockUi)
+	c := &GetCommand{
+		Meta: Meta{
+			ContextOpts: testCtxConfig(testProvider()),
+			Ui:          ui,
+			dataDir:     tempDir(t),
+		},
+	}
+
+	if code := c.Run(nil); code != 1 {
+		t.Fatalf("bad: %d", code)
+	}
+
+	// Nothing else must be tried without a working directory
+	output := ui.ErrorWriter.String()
+	if !strings.HasPrefix(output, "Error getting pwd") || strings.Count(output, "\n") != 1 {
+		t.Fatalf("bad: %s", output)
+	}
+}
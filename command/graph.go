@@ -19,6 +19,7 @@ func (c *GraphCommand) Run(args []string) int {
 	var moduleDepth int
 	var verbose bool
 	var drawCycles bool
+	var graphTypeStr, format string
 
 	args = c.Meta.process(args, false)
 
@@ -26,6 +27,8 @@ func (c *GraphCommand) Run(args []string) int {
 	c.addModuleDepthFlag(cmdFlags, &moduleDepth)
 	cmdFlags.BoolVar(&verbose, "verbose", false, "verbose")
 	cmdFlags.BoolVar(&drawCycles, "draw-cycles", false, "draw-cycles")
+	cmdFlags.StringVar(&graphTypeStr, "type", "", "type")
+	cmdFlags.StringVar(&format, "format", "dot", "format")
 	cmdFlags.Usage = func() { c.Ui.Error(c.Help()) }
 	if err := cmdFlags.Parse(args); err != nil {
 		return 1
@@ -44,21 +47,53 @@ func (c *GraphCommand) Run(args []string) int {
 		path, err = os.Getwd()
 		if err != nil {
 			c.Ui.Error(fmt.Sprintf("Error getting pwd: %s", err))
+			return 1
 		}
 	}
 
-	ctx, _, err := c.Context(contextOpts{
+	if format != "dot" && format != "json" {
+		c.Ui.Error(fmt.Sprintf(
+			"Unknown graph format: %s. Must be \"dot\" or \"json\".", format))
+		return 1
+	}
+
+	var graphType terraform.GraphType
+	if graphTypeStr != "" {
+		var ok bool
+		graphType, ok = terraform.GraphTypeMap[graphTypeStr]
+		if !ok {
+			c.Ui.Error(fmt.Sprintf(
+				"Unknown graph type: %s. Must be one of: plan, plan-destroy, apply, refresh.",
+				graphTypeStr))
+			return 1
+		}
+	}
+
+	// The graph of a plan may be shown even if the configuration changed
+	// since it was created.
+	ctx, planned, err := c.Context(contextOpts{
 		Path:      path,
 		StatePath: "",
+		StalePlan: true,
 	})
 	if err != nil {
 		c.Ui.Error(fmt.Sprintf("Error loading Terraform: %s", err))
 		return 1
 	}
 
+	// Plans are shown as they will be applied, and configurations as they
+	// will be planned.
+	if graphType == terraform.GraphTypeInvalid {
+		graphType = terraform.GraphTypePlan
+		if planned {
+			graphType = terraform.GraphTypeApply
+		}
+	}
+
 	// Skip validation during graph generation - we want to see the graph even if
 	// it is invalid for some reason.
 	g, err := ctx.Graph(&terraform.ContextGraphOpts{
+		Type:     graphType,
 		Verbose:  verbose,
 		Validate: false,
 	})
@@ -67,11 +102,18 @@ func (c *GraphCommand) Run(args []string) int {
 		return 1
 	}
 
-	graphStr, err := terraform.GraphDot(g, &terraform.GraphDotOpts{
-		DrawCycles: drawCycles,
-		MaxDepth:   moduleDepth,
-		Verbose:    verbose,
-	})
+	var graphStr string
+	if format == "json" {
+		graphStr, err = terraform.GraphJSON(g, &terraform.GraphJSONOpts{
+			MaxDepth: moduleDepth,
+		})
+	} else {
+		graphStr, err = terraform.GraphDot(g, &terraform.GraphDotOpts{
+			DrawCycles: drawCycles,
+			MaxDepth:   moduleDepth,
+			Verbose:    verbose,
+		})
+	}
 	if err != nil {
 		c.Ui.Error(fmt.Sprintf("Error converting graph: %s", err))
 		return 1
@@ -84,23 +126,33 @@ func (c *GraphCommand) Run(args []string) int {
 
 func (c *GraphCommand) Help() string {
 	helpText := `
-Usage: terraform graph [options] [DIR]
+Usage: terraform graph [options] [DIR|PLAN]
 
   Outputs the visual dependency graph of Terraform resources according to
-  configuration files in DIR (or the current directory if omitted).
+  configuration files in DIR (or the current directory if omitted), or
+  of a saved plan.
 
   The graph is outputted in DOT format. The typical program that can
   read this format is GraphViz, but many web services are also available
-  to read this format.
+  to read this format. With -format=json, the nodes, edges and cycles
+  of the graph are outputted as JSON instead.
 
 Options:
 
   -draw-cycles         Highlight any cycles in the graph with colored edges.
                        This helps when diagnosing cycle errors.
 
+  -format=dot          The output format, "dot" or "json".
+
   -module-depth=n      The maximum depth to expand modules. By default this is
                        zero, which will not expand modules at all.
 
+  -type=plan           The operation to show the graph of: "plan",
+                       "plan-destroy", "apply" or "refresh". Defaults to
+                       "apply" for a saved plan, and to "plan" otherwise.
+                       The graph of apply has the changes of the plan, if
+                       any.
+
   -verbose             Generate a verbose, "worst-case" graph, with all nodes
                        for potential operations in place.
 `
@@ -1,6 +1,7 @@
 package command
 
 import (
+	"encoding/json"
 	"os"
 	"strings"
 	"testing"
@@ -49,6 +50,28 @@ func TestGraph_multipleArgs(t *testing.T) {
 	}
 }
 
+func TestGraph_pwdError(t *testing.T) {
+	defer testCwdRemoved(t)()
+
+	ui := new(cli.MockUi)
+	c := &GraphCommand{
+		Meta: Meta{
+			ContextOpts: testCtxConfig(testProvider()),
+			Ui:          ui,
+		},
+	}
+
+	if code := c.Run(nil); code != 1 {
+		t.Fatalf("bad: %d", code)
+	}
+
+	// Nothing else must be tried without a working directory
+	output := ui.ErrorWriter.String()
+	if !strings.HasPrefix(output, "Error getting pwd") || strings.Count(output, "\n") != 1 {
+		t.Fatalf("bad: %s", output)
+	}
+}
+
 func TestGraph_noArgs(t *testing.T) {
 	cwd, err := os.Getwd()
 	if err != nil {
@@ -103,3 +126,86 @@ func TestGraph_plan(t *testing.T) {
 		t.Fatalf("doesn't look like digraph: %s", output)
 	}
 }
+
+func TestGraph_json(t *testing.T) {
+	ui := new(cli.MockUi)
+	c := &GraphCommand{
+		Meta: Meta{
+			ContextOpts: testCtxConfig(testProvider()),
+			Ui:          ui,
+		},
+	}
+
+	args := []string{
+		"-format=json",
+		testFixturePath("graph"),
+	}
+	if code := c.Run(args); code != 0 {
+		t.Fatalf("bad: \n%s", ui.ErrorWriter.String())
+	}
+
+	var actual struct {
+		Nodes []struct {
+			ID string `json:"id"`
+		} `json:"nodes"`
+	}
+	output := ui.OutputWriter.String()
+	if err := json.Unmarshal([]byte(output), &actual); err != nil {
+		t.Fatalf("err: %s\n\n%s", err, output)
+	}
+
+	found := false
+	for _, n := range actual.Nodes {
+		if n.ID == "[root] provider.test" {
+			found = true
+		}
+	}
+	if !found {
+		t.Fatalf("bad: %s", output)
+	}
+}
+
+func TestGraph_typePlanDestroy(t *testing.T) {
+	ui := new(cli.MockUi)
+	c := &GraphCommand{
+		Meta: Meta{
+			ContextOpts: testCtxConfig(testProvider()),
+			Ui:          ui,
+		},
+	}
+
+	args := []string{
+		"-type=plan-destroy",
+		testFixturePath("graph"),
+	}
+	if code := c.Run(args); code != 0 {
+		t.Fatalf("bad: \n%s", ui.ErrorWriter.String())
+	}
+
+	output := ui.OutputWriter.String()
+	if !strings.Contains(output, "provider.test") {
+		t.Fatalf("doesn't look like digraph: %s", output)
+	}
+}
+
+func TestGraph_bad(t *testing.T) {
+	cases := [][]string{
+		[]string{"-type=destroy"},
+		[]string{"-format=svg"},
+	}
+
+	for i, args := range cases {
+		ui := new(cli.MockUi)
+		c := &GraphCommand{
+			Meta: Meta{
+				ContextOpts: testCtxConfig(testProvider()),
+				Ui:          ui,
+			},
+		}
+
+		args = append(args, testFixturePath("graph"))
+		if code := c.Run(args); code != 1 {
+			t.Fatalf("%d: bad: %d\n\n%s", i, code, ui.OutputWriter.String())
+		}
+	}
+}
@@ -0,0 +1,123 @@
+package command
+
+import (
+	"sync"
+
+	"github.com/hashicorp/terraform/terraform"
+)
+
+// JSONHook is the hook that reports the progress of the resources as
+// JSON events in the -json mode of the commands. It is used instead of
+// the UiHook.
+type JSONHook struct {
+	terraform.NilHook
+
+	Ui *JSONUi
+
+	l       sync.Mutex
+	actions map[string]string
+}
+
+func (h *JSONHook) PreApply(
+	n *terraform.InstanceInfo,
+	s *terraform.InstanceState,
+	d *terraform.InstanceDiff) (terraform.HookAction, error) {
+	action := "update"
+	if d.Destroy {
+		action = "destroy"
+	} else if s.ID == "" {
+		action = "create"
+	}
+
+	id := n.HumanId()
+	h.l.Lock()
+	if h.actions == nil {
+		h.actions = make(map[string]string)
+	}
+	h.actions[id] = action
+	h.l.Unlock()
+
+	h.Ui.Event(&JSONEvent{
+		Type:    JSONEventApplyStart,
+		Address: id,
+		Action:  action,
+		ID:      s.ID,
+	})
+	return terraform.HookActionContinue, nil
+}
+
+func (h *JSONHook) PostApply(
+	n *terraform.InstanceInfo,
+	s *terraform.InstanceState,
+	applyerr error) (terraform.HookAction, error) {
+	id := n.HumanId()
+	h.l.Lock()
+	action := h.actions[id]
+	delete(h.actions, id)
+	h.l.Unlock()
+
+	e := &JSONEvent{
+		Type:    JSONEventApplyComplete,
+		Address: id,
+		Action:  action,
+	}
+	if s != nil {
+		e.ID = s.ID
+	}
+	if applyerr != nil {
+		e.Type = JSONEventApplyErrored
+		e.Error = applyerr.Error()
+	}
+
+	h.Ui.Event(e)
+	return terraform.HookActionContinue, nil
+}
+
+func (h *JSONHook) PreProvision(
+	n *terraform.InstanceInfo,
+	provId string) (terraform.HookAction, error) {
+	h.Ui.Event(&JSONEvent{
+		Type:        JSONEventProvisionStart,
+		Address:     n.HumanId(),
+		Provisioner: provId,
+	})
+	return terraform.HookActionContinue, nil
+}
+
+func (h *JSONHook) ProvisionOutput(
+	n *terraform.InstanceInfo,
+	provId string,
+	msg string) {
+	h.Ui.Event(&JSONEvent{
+		Type:        JSONEventProvisionOutput,
+		Address:     n.HumanId(),
+		Provisioner: provId,
+		Message:     msg,
+	})
+}
+
+func (h *JSONHook) PreRefresh(
+	n *terraform.InstanceInfo,
+	s *terraform.InstanceState) (terraform.HookAction, error) {
+	h.Ui.Event(&JSONEvent{
+		Type:    JSONEventRefreshStart,
+		Address: n.HumanId(),
+		ID:      s.ID,
+	})
+	return terraform.HookActionContinue, nil
+}
+
+func (h *JSONHook) PostRefresh(
+	n *terraform.InstanceInfo,
+	s *terraform.InstanceState) (terraform.HookAction, error) {
+	e := &JSONEvent{
+		Type:    JSONEventRefreshComplete,
+		Address: n.HumanId(),
+	}
+	if s != nil {
+		e.ID = s.ID
+	}
+
+	h.Ui.Event(e)
+	return terraform.HookActionContinue, nil
+}
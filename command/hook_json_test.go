@@ -0,0 +1,68 @@
+package command
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform/terraform"
+	"github.com/mitchellh/cli"
+)
+
+func TestJSONHook_impl(t *testing.T) {
+	var _ terraform.Hook = new(JSONHook)
+}
+
+func TestJSONHook(t *testing.T) {
+	ui := new(cli.MockUi)
+	h := &JSONHook{Ui: &JSONUi{Ui: ui}}
+
+	foo := &terraform.InstanceInfo{Id: "aws_instance.foo"}
+	bar := &terraform.InstanceInfo{
+		Id:         "aws_instance.bar",
+		ModulePath: []string{"root", "app"},
+	}
+
+	h.PreApply(foo, &terraform.InstanceState{}, &terraform.InstanceDiff{})
+	h.PreApply(bar, &terraform.InstanceState{ID: "i-1"}, &terraform.InstanceDiff{
+		Destroy: true,
+	})
+	h.PostApply(bar, nil, errors.New("failed"))
+	h.PostApply(foo, &terraform.InstanceState{ID: "i-2"}, nil)
+
+	events := testJSONEvents(t, ui.OutputWriter.String())
+	expected := []JSONEvent{
+		JSONEvent{
+			Type:    JSONEventApplyStart,
+			Address: "aws_instance.foo",
+			Action:  "create",
+		},
+		JSONEvent{
+			Type:    JSONEventApplyStart,
+			Address: "module.app.aws_instance.bar",
+			Action:  "destroy",
+			ID:      "i-1",
+		},
+		JSONEvent{
+			Type:    JSONEventApplyErrored,
+			Address: "module.app.aws_instance.bar",
+			Action:  "destroy",
+			Error:   "failed",
+		},
+		JSONEvent{
+			Type:    JSONEventApplyComplete,
+			Address: "aws_instance.foo",
+			Action:  "create",
+			ID:      "i-2",
+		},
+	}
+	if len(events) != len(expected) {
+		t.Fatalf("bad: %s", ui.OutputWriter.String())
+	}
+	for i, e := range events {
+		e.Timestamp = expected[i].Timestamp
+		if !reflect.DeepEqual(*e, expected[i]) {
+			t.Fatalf("%d: bad: %#v", i, e)
+		}
+	}
+}
@@ -0,0 +1,126 @@
+package command
+
+import (
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform/terraform"
+)
+
+// MetricsHook is a hook that records how long each operation on a
+// resource takes, and whether it failed.
+type MetricsHook struct {
+	Operations []*MetricsOperation
+
+	pending map[string]*MetricsOperation
+
+	sync.Mutex
+	terraform.NilHook
+}
+
+// MetricsOperation is a single operation on a resource.
+type MetricsOperation struct {
+	Resource  string
+	Type      string
+	Provider  string
+	Operation string
+	Start     time.Time
+	Duration  time.Duration
+	Error     string
+}
+
+func (h *MetricsHook) PreApply(
+	n *terraform.InstanceInfo,
+	s *terraform.InstanceState,
+	d *terraform.InstanceDiff) (terraform.HookAction, error) {
+	op := "update"
+	if d.Destroy {
+		op = "destroy"
+	} else if s.ID == "" {
+		op = "create"
+	}
+
+	h.start(n, "apply", op)
+	return terraform.HookActionContinue, nil
+}
+
+func (h *MetricsHook) PostApply(
+	n *terraform.InstanceInfo,
+	s *terraform.InstanceState,
+	e error) (terraform.HookAction, error) {
+	h.stop(n, "apply", e)
+	return terraform.HookActionContinue, nil
+}
+
+func (h *MetricsHook) PreRefresh(
+	n *terraform.InstanceInfo,
+	s *terraform.InstanceState) (terraform.HookAction, error) {
+	h.start(n, "refresh", "refresh")
+	return terraform.HookActionContinue, nil
+}
+
+func (h *MetricsHook) PostRefresh(
+	n *terraform.InstanceInfo,
+	s *terraform.InstanceState) (terraform.HookAction, error) {
+	h.stop(n, "refresh", nil)
+	return terraform.HookActionContinue, nil
+}
+
+func (h *MetricsHook) PreProvisionResource(
+	n *terraform.InstanceInfo,
+	s *terraform.InstanceState) (terraform.HookAction, error) {
+	h.start(n, "provision", "provision")
+	return terraform.HookActionContinue, nil
+}
+
+func (h *MetricsHook) PostProvisionResource(
+	n *terraform.InstanceInfo,
+	s *terraform.InstanceState) (terraform.HookAction, error) {
+	h.stop(n, "provision", nil)
+	return terraform.HookActionContinue, nil
+}
+
+// start records the start of an operation. The kind is the hook the
+// operation is started by, so the end is matched to the right start.
+func (h *MetricsHook) start(n *terraform.InstanceInfo, kind, op string) {
+	h.Lock()
+	defer h.Unlock()
+
+	if h.pending == nil {
+		h.pending = make(map[string]*MetricsOperation)
+	}
+
+	provider := n.Type
+	if idx := strings.Index(provider, "_"); idx >= 0 {
+		provider = provider[:idx]
+	}
+
+	h.pending[kind+":"+n.HumanId()] = &MetricsOperation{
+		Resource:  n.HumanId(),
+		Type:      n.Type,
+		Provider:  provider,
+		Operation: op,
+		Start:     time.Now(),
+	}
+}
+
+// stop records the end of an operation that was started with start.
+func (h *MetricsHook) stop(n *terraform.InstanceInfo, kind string, e error) {
+	h.Lock()
+	defer h.Unlock()
+
+	key := kind + ":" + n.HumanId()
+	op, ok := h.pending[key]
+	if !ok {
+		return
+	}
+	delete(h.pending, key)
+
+	op.Duration = time.Now().Sub(op.Start)
+	if e != nil {
+		op.Error = e.Error()
+	}
+
+	h.Operations = append(h.Operations, op)
+}
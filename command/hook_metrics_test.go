@@ -0,0 +1,71 @@
+package command
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestMetricsHook_impl(t *testing.T) {
+	var _ terraform.Hook = new(MetricsHook)
+}
+
+func TestMetricsHook(t *testing.T) {
+	h := new(MetricsHook)
+
+	create := &terraform.InstanceInfo{Id: "aws_instance.web", Type: "aws_instance"}
+	destroy := &terraform.InstanceInfo{
+		Id:         "aws_eip.ip",
+		ModulePath: []string{"root", "child"},
+		Type:       "aws_eip",
+	}
+
+	h.PreRefresh(destroy, &terraform.InstanceState{ID: "foo"})
+	h.PostRefresh(destroy, &terraform.InstanceState{ID: "foo"})
+	h.PreApply(create, &terraform.InstanceState{}, &terraform.InstanceDiff{})
+	h.PreApply(destroy, &terraform.InstanceState{ID: "foo"},
+		&terraform.InstanceDiff{Destroy: true})
+	h.PostApply(destroy, nil, errors.New("throttled"))
+	h.PostApply(create, &terraform.InstanceState{ID: "bar"}, nil)
+
+	// Ends without a start are ignored
+	h.PostProvisionResource(create, nil)
+
+	expected := []MetricsOperation{
+		MetricsOperation{
+			Resource:  "module.child.aws_eip.ip",
+			Type:      "aws_eip",
+			Provider:  "aws",
+			Operation: "refresh",
+		},
+		MetricsOperation{
+			Resource:  "module.child.aws_eip.ip",
+			Type:      "aws_eip",
+			Provider:  "aws",
+			Operation: "destroy",
+			Error:     "throttled",
+		},
+		MetricsOperation{
+			Resource:  "aws_instance.web",
+			Type:      "aws_instance",
+			Provider:  "aws",
+			Operation: "create",
+		},
+	}
+	if len(h.Operations) != len(expected) {
+		t.Fatalf("bad: %#v", h.Operations)
+	}
+	for i, op := range h.Operations {
+		if op.Start.IsZero() || op.Duration < 0 {
+			t.Fatalf("%d: bad: %#v", i, op)
+		}
+
+		actual := *op
+		actual.Start = expected[i].Start
+		actual.Duration = 0
+		if actual != expected[i] {
+			t.Fatalf("%d: bad: %#v", i, actual)
+		}
+	}
+}
@@ -31,3 +31,16 @@ func (h *StateHook) PostStateUpdate(
 	// Continue forth
 	return terraform.HookActionContinue, nil
 }
+
+// Persist persists the last state that was written. It waits for a write
+// in progress, so it never persists a partially updated state.
+func (h *StateHook) Persist() error {
+	h.Lock()
+	defer h.Unlock()
+
+	if h.State == nil {
+		return nil
+	}
+
+	return h.State.PersistState()
+}
@@ -27,3 +27,37 @@ func TestStateHook(t *testing.T) {
 		t.Fatalf("bad state: %#v", is.State())
 	}
 }
+
+func TestStateHookPersist(t *testing.T) {
+	is := &testPersistCountState{}
+	hook := &StateHook{State: is}
+
+	s := state.TestStateInitial()
+	if _, err := hook.PostStateUpdate(s); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if err := hook.Persist(); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if is.persisted != 1 {
+		t.Fatalf("bad: %d", is.persisted)
+	}
+
+	// Nothing to persist without a state
+	if err := new(StateHook).Persist(); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+}
+
+// testPersistCountState is an in-memory state that counts how many times
+// it was persisted.
+type testPersistCountState struct {
+	state.InmemState
+
+	persisted int
+}
+
+func (s *testPersistCountState) PersistState() error {
+	s.persisted++
+	return nil
+}
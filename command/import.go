@@ -0,0 +1,137 @@
+package command
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"strings"
+
+	"github.com/hashicorp/terraform/terraform"
+)
+
+// ImportCommand is a cli.Command implementation that imports existing
+// resources into the state file.
+type ImportCommand struct {
+	Meta
+}
+
+func (c *ImportCommand) Run(args []string) int {
+	args = c.Meta.process(args, true)
+
+	var configPath string
+	cmdFlags := c.Meta.flagSet("import")
+	cmdFlags.StringVar(&configPath, "config", "", "path")
+	cmdFlags.StringVar(&c.Meta.statePath, "state", DefaultStateFilename, "path")
+	cmdFlags.StringVar(&c.Meta.stateOutPath, "state-out", "", "path")
+	cmdFlags.StringVar(&c.Meta.backupPath, "backup", "", "path")
+	cmdFlags.Usage = func() { c.Ui.Error(c.Help()) }
+	if err := cmdFlags.Parse(args); err != nil {
+		return 1
+	}
+
+	args = cmdFlags.Args()
+	if len(args) != 2 {
+		c.Ui.Error("The import command expects two arguments: ADDR and ID.")
+		cmdFlags.Usage()
+		return 1
+	}
+	target := &terraform.ImportTarget{Addr: args[0], ID: args[1]}
+
+	if configPath == "" {
+		var err error
+		configPath, err = os.Getwd()
+		if err != nil {
+			c.Ui.Error(fmt.Sprintf("Error getting pwd: %s", err))
+			return 1
+		}
+	}
+
+	// Build the context based on the arguments given
+	defer c.unlockState()
+	ctx, _, err := c.Context(contextOpts{
+		Path:      configPath,
+		StatePath: c.Meta.statePath,
+		Lock:      "import",
+	})
+	if err != nil {
+		c.Ui.Error(err.Error())
+		return 1
+	}
+
+	if !validateContext(ctx, c.Ui) {
+		return 1
+	}
+	if err := ctx.Input(c.InputMode()); err != nil {
+		c.Ui.Error(fmt.Sprintf("Error configuring: %s", err))
+		return 1
+	}
+
+	newState, err := ctx.Import([]*terraform.ImportTarget{target})
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error importing: %s", err))
+		return 1
+	}
+
+	log.Printf("[INFO] command: Writing state output to: %s", c.Meta.StateOutPath())
+	if err := c.Meta.PersistState(newState); err != nil {
+		c.Ui.Error(fmt.Sprintf("Error writing state file: %s", err))
+		return 1
+	}
+
+	c.Ui.Output(c.Colorize().Color(fmt.Sprintf(
+		"[reset][green]Imported %s as %s. It is now managed by Terraform.",
+		target.ID, target.Addr)))
+	return 0
+}
+
+func (c *ImportCommand) Help() string {
+	helpText := `
+Usage: terraform import [options] ADDR ID
+
+  Import an existing resource into the state file, so that Terraform
+  manages it from then on, without recreating it.
+
+  ADDR is the address of the resource in the configuration, such as
+  "aws_instance.web", which must be in the configuration of the root
+  module. ID is the ID of the existing resource, as known by its
+  provider, such as the ID of an EC2 instance. Only the resource types
+  that support it can be imported.
+
+  This will not modify your infrastructure. Review the configuration
+  of the resource with "terraform plan" after the import: differences
+  between the configuration and the imported resource are changes to
+  apply.
+
+Options:
+
+  -backup=path        Path to backup the existing state file before
+                      modifying. Defaults to the "-state-out" path with
+                      ".backup" extension. Set to "-" to disable backup.
+
+  -config=path        Path to the directory of the configuration. Defaults
+                      to the current directory.
+
+  -input=true         Ask for input for variables if not directly set.
+
+  -no-color           If specified, output won't contain any color.
+
+  -state=path         Path to read and save state (unless state-out
+                      is specified). Defaults to "terraform.tfstate".
+
+  -state-out=path     Path to write updated state file. By default, the
+                      "-state" path will be used.
+
+  -var 'foo=bar'      Set a variable in the Terraform configuration. This
+                      flag can be set multiple times.
+
+  -var-file=foo       Set variables in the Terraform configuration from
+                      a file. If "terraform.tfvars" is present, it will be
+                      automatically loaded if this flag is not specified.
+
+`
+	return strings.TrimSpace(helpText)
+}
+
+func (c *ImportCommand) Synopsis() string {
+	return "Import existing infrastructure into the state"
+}
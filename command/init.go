@@ -4,63 +4,132 @@ import (
 	"flag"
 	"fmt"
 	"os"
+	"sort"
 	"strings"
 
 	"github.com/hashicorp/terraform/config"
 	"github.com/hashicorp/terraform/config/module"
-	"github.com/hashicorp/terraform/terraform"
 )
 
-// InitCommand is a Command implementation that takes a Terraform
-// module and clones it to the working directory.
+// InitCommand is a Command implementation that prepares a working
+// directory for Terraform: it optionally copies a configuration from a
+// module source, downloads the modules, configures the remote state and
+// verifies the provider plugins.
 type InitCommand struct {
 	Meta
 }
 
 func (c *InitCommand) Run(args []string) int {
 	var remoteBackend string
+	var get, update, verifyPlugins bool
 	args = c.Meta.process(args, false)
 	remoteConfig := make(map[string]string)
 	cmdFlags := flag.NewFlagSet("init", flag.ContinueOnError)
 	cmdFlags.StringVar(&remoteBackend, "backend", "", "")
 	cmdFlags.Var((*FlagKV)(&remoteConfig), "backend-config", "config")
+	cmdFlags.BoolVar(&get, "get", true, "get")
+	cmdFlags.BoolVar(&update, "update", false, "update")
+	cmdFlags.BoolVar(&verifyPlugins, "verify-plugins", true, "verify-plugins")
 	cmdFlags.Usage = func() { c.Ui.Error(c.Help()) }
 	if err := cmdFlags.Parse(args); err != nil {
 		return 1
 	}
 
-	var path string
+	// Get our pwd since we need it
+	pwd, err := os.Getwd()
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf(
+			"Error reading working directory: %s", err))
+		return 1
+	}
+
+	var source string
+	path := pwd
 	args = cmdFlags.Args()
-	if len(args) > 2 {
+	switch len(args) {
+	case 0:
+	case 1:
+		source = args[0]
+	case 2:
+		source = args[0]
+		path = args[1]
+	default:
 		c.Ui.Error("The init command expects at most two arguments.\n")
 		cmdFlags.Usage()
 		return 1
-	} else if len(args) < 1 {
-		c.Ui.Error("The init command expects at least one arguments.\n")
-		cmdFlags.Usage()
-		return 1
 	}
 
-	if len(args) == 2 {
-		path = args[1]
-	} else {
-		var err error
-		path, err = os.Getwd()
-		if err != nil {
-			c.Ui.Error(fmt.Sprintf("Error getting pwd: %s", err))
+	// Copy the configuration from the source, if there is one
+	if source != "" {
+		if code := c.copySource(source, path, pwd); code != 0 {
+			return code
 		}
 	}
 
-	source := args[0]
-
-	// Get our pwd since we need it
-	pwd, err := os.Getwd()
+	empty, err := config.IsEmptyDir(path)
 	if err != nil {
 		c.Ui.Error(fmt.Sprintf(
-			"Error reading working directory: %s", err))
+			"Error checking on destination path: %s", err))
 		return 1
 	}
+	if empty {
+		c.Ui.Output(
+			"The directory has no Terraform configuration files, so there are\n" +
+				"no modules to download or provider plugins to verify.")
+	} else {
+		mode := module.GetModeNone
+		if get {
+			c.Ui.Output("Downloading modules...")
+			mode = module.GetModeGet
+			if update {
+				mode = module.GetModeUpdate
+			}
+		}
+
+		mod, err := c.loadModule(path, mode)
+		if err != nil {
+			c.Ui.Error(err.Error())
+			return 1
+		}
+
+		if verifyPlugins {
+			c.Ui.Output("Verifying provider plugins...")
+			if err := c.verifyPlugins(mod); err != nil {
+				c.Ui.Error(err.Error())
+				return 1
+			}
+		}
+	}
 
+	// Configure the remote state. This is done by the remote config
+	// command, which also migrates any existing state to the backend.
+	if remoteBackend != "" {
+		remoteArgs := []string{"-backend=" + remoteBackend}
+		keys := make([]string, 0, len(remoteConfig))
+		for k, _ := range remoteConfig {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			remoteArgs = append(remoteArgs,
+				fmt.Sprintf("-backend-config=%s=%s", k, remoteConfig[k]))
+		}
+
+		c.Ui.Output("Configuring remote state...")
+		remoteCmd := &RemoteConfigCommand{Meta: c.Meta}
+		if code := remoteCmd.Run(remoteArgs); code != 0 {
+			return code
+		}
+	}
+
+	c.Ui.Output(c.Colorize().Color(
+		"[reset][bold][green]Terraform has been successfully initialized!"))
+	return 0
+}
+
+// copySource copies the configuration from the module source into the
+// path, which must not have Terraform configuration files yet.
+func (c *InitCommand) copySource(source, path, pwd string) int {
 	// Verify the directory is empty
 	if empty, err := config.IsEmptyDir(path); err != nil {
 		c.Ui.Error(fmt.Sprintf(
@@ -69,13 +138,13 @@ func (c *InitCommand) Run(args []string) int {
 	} else if !empty {
 		c.Ui.Error(
 			"The destination path has Terraform configuration files. The\n" +
-				"init command can only be used on a directory without existing Terraform\n" +
-				"files.")
+				"init command can only copy a module into a directory without\n" +
+				"existing Terraform files.")
 		return 1
 	}
 
 	// Detect
-	source, err = module.Detect(source, pwd)
+	source, err := module.Detect(source, pwd)
 	if err != nil {
 		c.Ui.Error(fmt.Sprintf(
 			"Error with module source: %s", err))
@@ -83,76 +152,131 @@ func (c *InitCommand) Run(args []string) int {
 	}
 
 	// Get it!
+	c.Ui.Output(fmt.Sprintf("Copying configuration from %q...", source))
 	if err := module.GetCopy(path, source); err != nil {
 		c.Ui.Error(err.Error())
 		return 1
 	}
 
-	// Handle remote state if configured
-	if remoteBackend != "" {
-		var remoteConf terraform.RemoteState
-		remoteConf.Type = remoteBackend
-		remoteConf.Config = remoteConfig
+	return 0
+}
 
-		state, err := c.State()
-		if err != nil {
-			c.Ui.Error(fmt.Sprintf("Error checking for state: %s", err))
-			return 1
+// verifyPlugins checks that there is a plugin for each provider used by
+// the configuration, and that the plugins start.
+func (c *InitCommand) verifyPlugins(mod *module.Tree) error {
+	var missing, failed []string
+	for _, name := range initProviderNames(mod) {
+		factory, ok := c.ContextOpts.Providers[name]
+		if !ok {
+			missing = append(missing, name)
+			continue
 		}
-		if state != nil {
-			s := state.State()
-			if !s.Empty() {
-				c.Ui.Error(fmt.Sprintf(
-					"State file already exists and is not empty! Please remove this\n" +
-						"state file before initializing. Note that removing the state file\n" +
-						"may result in a loss of information since Terraform uses this\n" +
-						"to track your infrastructure."))
-				return 1
-			}
-			if s.IsRemote() {
-				c.Ui.Error(fmt.Sprintf(
-					"State file already exists with remote state enabled! Please remove this\n" +
-						"state file before initializing. Note that removing the state file\n" +
-						"may result in a loss of information since Terraform uses this\n" +
-						"to track your infrastructure."))
-				return 1
+
+		if _, err := factory(); err != nil {
+			failed = append(failed, fmt.Sprintf("  * %s: %s", name, err))
+		}
+	}
+
+	var errs []string
+	if len(missing) > 0 {
+		errs = append(errs, fmt.Sprintf(
+			"No plugins were found for the providers: %s. Install the\n"+
+				"plugins next to the terraform binary, or configure them\n"+
+				"in the \"providers\" block of ~/.terraformrc.",
+			strings.Join(missing, ", ")))
+	}
+	if len(failed) > 0 {
+		errs = append(errs, fmt.Sprintf(
+			"The plugins of some providers failed to start:\n\n%s",
+			strings.Join(failed, "\n")))
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("%s", strings.Join(errs, "\n\n"))
+	}
+
+	return nil
+}
+
+// initProviderNames returns the sorted names of the providers used by the
+// configurations of the module and its children: the ones configured, and
+// the ones of the resources.
+func initProviderNames(mod *module.Tree) []string {
+	names := make(map[string]struct{})
+	var walk func(*module.Tree)
+	walk = func(t *module.Tree) {
+		cfg := t.Config()
+		for _, pc := range cfg.ProviderConfigs {
+			names[pc.Name] = struct{}{}
+		}
+		for _, r := range cfg.Resources {
+			name := strings.SplitN(r.Provider, ".", 2)[0]
+			if name == "" {
+				name = strings.SplitN(r.Type, "_", 2)[0]
 			}
+			names[name] = struct{}{}
 		}
 
-		// Initialize a blank state file with remote enabled
-		remoteCmd := &RemoteConfigCommand{
-			Meta:       c.Meta,
-			remoteConf: remoteConf,
+		for _, child := range t.Children() {
+			walk(child)
 		}
-		return remoteCmd.initBlankState()
 	}
-	return 0
+	walk(mod)
+
+	result := make([]string, 0, len(names))
+	for name, _ := range names {
+		result = append(result, name)
+	}
+	sort.Strings(result)
+	return result
 }
 
 func (c *InitCommand) Help() string {
 	helpText := `
-Usage: terraform init [options] SOURCE [PATH]
+Usage: terraform init [options] [SOURCE] [PATH]
 
-  Downloads the module given by SOURCE into the PATH. The PATH defaults
-  to the working directory. PATH must be empty of any Terraform files.
-  Any conflicting non-Terraform files will be overwritten.
+  Prepares the directory at PATH for Terraform, in one step:
 
-  The module downloaded is a copy. If you're downloading a module from
-  Git, it will not preserve the Git history, it will only copy the
-  latest files.
+    * If SOURCE is given, the module at SOURCE is copied into PATH first.
+      PATH must be empty of any Terraform files. Any conflicting
+      non-Terraform files will be overwritten. The module is a copy: if
+      it is downloaded from Git, the Git history isn't kept.
+
+    * The modules of the configuration are downloaded, like with
+      "terraform get".
+
+    * The plugins of the providers used by the configuration are checked,
+      so that missing or broken plugins are found now instead of during
+      a plan.
+
+    * If -backend is given, remote state is configured like with
+      "terraform remote config". An existing local state is migrated to
+      the backend.
+
+  PATH defaults to the working directory. init can be run again at any
+  time, for example after adding modules.
 
 Options:
 
   -backend=atlas         Specifies the type of remote backend. If not
-                         specified, local storage will be used.
+                         specified, the state configuration isn't changed.
 
   -backend-config="k=v"  Specifies configuration for the remote storage
                          backend. This can be specified multiple times.
 
+  -get=true              Download the modules of the configuration.
+
+  -no-color              If specified, output won't contain any color.
+
+  -update=false          If true, modules already downloaded will be
+                         checked for updates and updated if necessary.
+
+  -verify-plugins=true   Check that the plugins of the providers used by
+                         the configuration exist and start.
+
 `
 	return strings.TrimSpace(helpText)
 }
 
 func (c *InitCommand) Synopsis() string {
-	return "Initializes Terraform configuration from a module"
+	return "Initializes a Terraform working directory"
 }
@@ -3,6 +3,8 @@ package command
 import (
 	"os"
 	"path/filepath"
+	"reflect"
+	"strings"
 	"testing"
 
 	"github.com/hashicorp/terraform/terraform"
@@ -88,6 +90,9 @@ func TestInit_multipleArgs(t *testing.T) {
 }
 
 func TestInit_noArgs(t *testing.T) {
+	tmp, cwd := testCwd(t)
+	defer testFixCwd(t, tmp, cwd)
+
 	ui := new(cli.MockUi)
 	c := &InitCommand{
 		Meta: Meta{
@@ -97,9 +102,104 @@ func TestInit_noArgs(t *testing.T) {
 	}
 
 	args := []string{}
+	if code := c.Run(args); code != 0 {
+		t.Fatalf("bad: \n%s", ui.ErrorWriter.String())
+	}
+
+	output := ui.OutputWriter.String()
+	if !strings.Contains(output, "no Terraform configuration files") {
+		t.Fatalf("bad: %s", output)
+	}
+}
+
+func TestInit_get(t *testing.T) {
+	tmp, cwd := testCwd(t)
+	defer testFixCwd(t, tmp, cwd)
+
+	ui := new(cli.MockUi)
+	c := &InitCommand{
+		Meta: Meta{
+			ContextOpts: testCtxConfig(testProvider()),
+			Ui:          ui,
+		},
+	}
+
+	args := []string{
+		testFixturePath("get"),
+		tmp,
+	}
+	if code := c.Run(args); code != 0 {
+		t.Fatalf("bad: \n%s", ui.ErrorWriter.String())
+	}
+
+	output := ui.OutputWriter.String()
+	if !strings.Contains(output, "Get: file://") {
+		t.Fatalf("doesn't look like get: %s", output)
+	}
+	if !strings.Contains(output, "successfully initialized") {
+		t.Fatalf("bad: %s", output)
+	}
+}
+
+func TestInit_pluginsMissing(t *testing.T) {
+	tmp, cwd := testCwd(t)
+	defer testFixCwd(t, tmp, cwd)
+
+	ui := new(cli.MockUi)
+	c := &InitCommand{
+		Meta: Meta{
+			ContextOpts: testCtxConfig(testProvider()),
+			Ui:          ui,
+		},
+	}
+
+	args := []string{
+		testFixturePath("init-plugins"),
+		tmp,
+	}
 	if code := c.Run(args); code != 1 {
 		t.Fatalf("bad: \n%s", ui.OutputWriter.String())
 	}
+
+	// The configuration was copied, but the aws plugin is missing
+	if _, err := os.Stat(filepath.Join(tmp, "main.tf")); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	errOutput := ui.ErrorWriter.String()
+	if !strings.Contains(errOutput, "providers: aws.") {
+		t.Fatalf("bad: %s", errOutput)
+	}
+}
+
+func TestInit_verifyPluginsDisabled(t *testing.T) {
+	tmp, cwd := testCwd(t)
+	defer testFixCwd(t, tmp, cwd)
+
+	ui := new(cli.MockUi)
+	c := &InitCommand{
+		Meta: Meta{
+			ContextOpts: testCtxConfig(testProvider()),
+			Ui:          ui,
+		},
+	}
+
+	args := []string{
+		"-verify-plugins=false",
+		testFixturePath("init-plugins"),
+		tmp,
+	}
+	if code := c.Run(args); code != 0 {
+		t.Fatalf("bad: \n%s", ui.ErrorWriter.String())
+	}
+}
+
+func TestInitProviderNames(t *testing.T) {
+	mod := testModule(t, "init-plugins")
+	actual := initProviderNames(mod)
+	expected := []string{"aws", "test"}
+	if !reflect.DeepEqual(actual, expected) {
+		t.Fatalf("bad: %#v", actual)
+	}
 }
 
 // https://github.com/hashicorp/terraform/issues/518
@@ -196,6 +296,9 @@ func TestInit_remoteStateWithLocal(t *testing.T) {
 		t.Fatalf("err: %s", err)
 	}
 
+	conf, srv := testRemoteState(t, testState(), 200)
+	defer srv.Close()
+
 	ui := new(cli.MockUi)
 	c := &InitCommand{
 		Meta: Meta{
@@ -206,11 +309,22 @@ func TestInit_remoteStateWithLocal(t *testing.T) {
 
 	args := []string{
 		"-backend", "http",
-		"-backend-config", "address=http://google.com",
+		"-backend-config", "address=" + conf.Config["address"],
 		testFixturePath("init"),
 	}
-	if code := c.Run(args); code == 0 {
-		t.Fatalf("should have failed: \n%s", ui.OutputWriter.String())
+	if code := c.Run(args); code != 0 {
+		t.Fatalf("bad: \n%s", ui.ErrorWriter.String())
+	}
+
+	// The local state was migrated to the remote state
+	if _, err := os.Stat(statePath); err == nil {
+		t.Fatal("local state should be removed")
+	}
+	if _, err := os.Stat(statePath + DefaultBackupExtention); err != nil {
+		t.Fatalf("missing backup: %s", err)
+	}
+	if _, err := os.Stat(filepath.Join(tmp, DefaultDataDir, DefaultStateFilename)); err != nil {
+		t.Fatalf("missing state: %s", err)
 	}
 }
 
@@ -0,0 +1,198 @@
+package command
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform/terraform"
+	"github.com/mitchellh/cli"
+)
+
+// These are the types of the events written with -json.
+const (
+	JSONEventMessage         = "message"
+	JSONEventDiagnostic      = "diagnostic"
+	JSONEventPlannedChange   = "planned_change"
+	JSONEventRefreshStart    = "refresh_start"
+	JSONEventRefreshComplete = "refresh_complete"
+	JSONEventApplyStart      = "apply_start"
+	JSONEventApplyComplete   = "apply_complete"
+	JSONEventApplyErrored    = "apply_errored"
+	JSONEventProvisionStart  = "provision_start"
+	JSONEventProvisionOutput = "provision_output"
+	JSONEventChangeSummary   = "change_summary"
+	JSONEventOutputs         = "outputs"
+)
+
+// JSONEvent is an event written with -json, as a single line of JSON.
+type JSONEvent struct {
+	Type      string    `json:"type"`
+	Timestamp time.Time `json:"timestamp"`
+
+	// Address is the resource the event is about, and Action is what
+	// is done to it: create, update, destroy, or replace for planned
+	// changes. ID is the ID of the resource, if it has one.
+	Address string `json:"address,omitempty"`
+	Action  string `json:"action,omitempty"`
+	ID      string `json:"id,omitempty"`
+
+	// Provisioner is the provisioner of provision events.
+	Provisioner string `json:"provisioner,omitempty"`
+
+	// Error is the error an apply of a resource failed with.
+	Error string `json:"error,omitempty"`
+
+	// Message is the text of messages, diagnostics and provisioner
+	// output. Diagnostics have a Severity of "error" or "warning".
+	Message  string `json:"message,omitempty"`
+	Severity string `json:"severity,omitempty"`
+
+	// Changes is the number of changes of a change_summary.
+	Changes *JSONChangeSummary `json:"changes,omitempty"`
+
+	// Outputs are the outputs after an apply.
+	Outputs map[string]interface{} `json:"outputs,omitempty"`
+}
+
+// JSONChangeSummary is the number of resources a plan will change, or
+// an apply or destroy changed.
+type JSONChangeSummary struct {
+	Operation string `json:"operation"`
+	Add       int    `json:"add"`
+	Change    int    `json:"change"`
+	Destroy   int    `json:"destroy"`
+}
+
+// JSONUi is a cli.Ui implementation that writes each message as a JSON
+// event on its own line, for the -json mode of the commands. Errors and
+// warnings become diagnostics.
+type JSONUi struct {
+	// Ui is the Ui the events are written to, with Output.
+	Ui cli.Ui
+
+	l sync.Mutex
+}
+
+// Event writes the event, setting its time if it isn't set.
+func (u *JSONUi) Event(e *JSONEvent) {
+	if e.Timestamp.IsZero() {
+		e.Timestamp = time.Now().UTC()
+	}
+
+	data, err := json.Marshal(e)
+	if err != nil {
+		// The events only have JSON types, but don't lose the message
+		data, _ = json.Marshal(&JSONEvent{
+			Type:      JSONEventDiagnostic,
+			Timestamp: e.Timestamp,
+			Severity:  "error",
+			Message:   fmt.Sprintf("Error encoding %s event: %s", e.Type, err),
+		})
+	}
+
+	u.l.Lock()
+	defer u.l.Unlock()
+	u.Ui.Output(string(data))
+}
+
+// Plan writes a planned_change event for each resource the plan changes,
+// sorted by address, followed by the change_summary of the plan.
+func (u *JSONUi) Plan(plan *terraform.Plan) {
+	summary := &JSONChangeSummary{Operation: "plan"}
+	if plan.Diff != nil {
+		var events []*JSONEvent
+		for _, m := range plan.Diff.Modules {
+			prefix := ""
+			if !m.IsRoot() {
+				prefix = fmt.Sprintf("module.%s.", strings.Join(m.Path[1:], "."))
+			}
+
+			for name, rdiff := range m.Resources {
+				var action string
+				switch rdiff.ChangeType() {
+				case terraform.DiffCreate:
+					action = "create"
+					summary.Add++
+				case terraform.DiffUpdate:
+					action = "update"
+					summary.Change++
+				case terraform.DiffDestroy:
+					action = "destroy"
+					summary.Destroy++
+				case terraform.DiffDestroyCreate:
+					action = "replace"
+					summary.Add++
+					summary.Destroy++
+				default:
+					continue
+				}
+
+				events = append(events, &JSONEvent{
+					Type:    JSONEventPlannedChange,
+					Address: prefix + name,
+					Action:  action,
+				})
+			}
+		}
+
+		sort.Sort(jsonEventsByAddress(events))
+		for _, e := range events {
+			u.Event(e)
+		}
+	}
+
+	u.Event(&JSONEvent{
+		Type:    JSONEventChangeSummary,
+		Changes: summary,
+	})
+}
+
+func (u *JSONUi) Ask(query string) (string, error) {
+	return "", errors.New("input isn't possible with -json")
+}
+
+func (u *JSONUi) AskSecret(query string) (string, error) {
+	return u.Ask(query)
+}
+
+// Output writes a message event. Blank lines, which only space out the
+// text of the commands, are left out.
+func (u *JSONUi) Output(message string) {
+	message = strings.TrimSpace(message)
+	if message == "" {
+		return
+	}
+
+	u.Event(&JSONEvent{Type: JSONEventMessage, Message: message})
+}
+
+func (u *JSONUi) Info(message string) {
+	u.Output(message)
+}
+
+func (u *JSONUi) Error(message string) {
+	u.Event(&JSONEvent{
+		Type:     JSONEventDiagnostic,
+		Severity: "error",
+		Message:  message,
+	})
+}
+
+func (u *JSONUi) Warn(message string) {
+	u.Event(&JSONEvent{
+		Type:     JSONEventDiagnostic,
+		Severity: "warning",
+		Message:  message,
+	})
+}
+
+type jsonEventsByAddress []*JSONEvent
+
+func (s jsonEventsByAddress) Len() int           { return len(s) }
+func (s jsonEventsByAddress) Swap(i, j int)      { s[i], s[j] = s[j], s[i] }
+func (s jsonEventsByAddress) Less(i, j int) bool { return s[i].Address < s[j].Address }
@@ -0,0 +1,135 @@
+package command
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform/terraform"
+	"github.com/mitchellh/cli"
+)
+
+func TestJSONUi_impl(t *testing.T) {
+	var _ cli.Ui = new(JSONUi)
+}
+
+func TestJSONUi(t *testing.T) {
+	ui := new(cli.MockUi)
+	u := &JSONUi{Ui: ui}
+
+	u.Output("hello")
+	u.Output("\n")
+	u.Warn("careful")
+	u.Error("bad")
+	if _, err := u.Ask("name"); err == nil {
+		t.Fatal("should error")
+	}
+
+	events := testJSONEvents(t, ui.OutputWriter.String())
+	if len(events) != 3 {
+		t.Fatalf("bad: %#v", events)
+	}
+
+	expected := []JSONEvent{
+		JSONEvent{Type: JSONEventMessage, Message: "hello"},
+		JSONEvent{Type: JSONEventDiagnostic, Severity: "warning", Message: "careful"},
+		JSONEvent{Type: JSONEventDiagnostic, Severity: "error", Message: "bad"},
+	}
+	for i, e := range events {
+		if e.Timestamp.IsZero() {
+			t.Fatalf("%d: no timestamp", i)
+		}
+
+		e.Timestamp = expected[i].Timestamp
+		if !reflect.DeepEqual(*e, expected[i]) {
+			t.Fatalf("%d: bad: %#v", i, e)
+		}
+	}
+}
+
+func TestJSONUi_plan(t *testing.T) {
+	plan := &terraform.Plan{
+		Diff: &terraform.Diff{
+			Modules: []*terraform.ModuleDiff{
+				&terraform.ModuleDiff{
+					Path: []string{"root"},
+					Resources: map[string]*terraform.InstanceDiff{
+						"aws_instance.web": &terraform.InstanceDiff{
+							Attributes: map[string]*terraform.ResourceAttrDiff{
+								"ami": &terraform.ResourceAttrDiff{
+									Old:         "foo",
+									New:         "bar",
+									RequiresNew: true,
+								},
+							},
+							Destroy: true,
+						},
+						"aws_instance.db": &terraform.InstanceDiff{
+							Destroy: true,
+						},
+					},
+				},
+				&terraform.ModuleDiff{
+					Path: []string{"root", "app"},
+					Resources: map[string]*terraform.InstanceDiff{
+						"aws_elb.lb": &terraform.InstanceDiff{
+							Attributes: map[string]*terraform.ResourceAttrDiff{
+								"name": &terraform.ResourceAttrDiff{
+									Old: "a",
+									New: "b",
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	ui := new(cli.MockUi)
+	u := &JSONUi{Ui: ui}
+	u.Plan(plan)
+
+	events := testJSONEvents(t, ui.OutputWriter.String())
+	actual := make([]string, len(events))
+	for i, e := range events {
+		actual[i] = e.Type + " " + e.Address + " " + e.Action
+	}
+
+	expected := []string{
+		"planned_change aws_instance.db destroy",
+		"planned_change aws_instance.web replace",
+		"planned_change module.app.aws_elb.lb update",
+		"change_summary  ",
+	}
+	if strings.Join(actual, "\n") != strings.Join(expected, "\n") {
+		t.Fatalf("bad:\n\n%s", strings.Join(actual, "\n"))
+	}
+
+	summary := events[len(events)-1].Changes
+	if summary == nil || *summary != (JSONChangeSummary{
+		Operation: "plan", Add: 1, Change: 1, Destroy: 2,
+	}) {
+		t.Fatalf("bad: %#v", summary)
+	}
+}
+
+// testJSONEvents parses the events written with -json, failing the test
+// if any line isn't an event.
+func testJSONEvents(t *testing.T, output string) []*JSONEvent {
+	var result []*JSONEvent
+	for _, line := range strings.Split(strings.TrimSpace(output), "\n") {
+		if line == "" {
+			continue
+		}
+
+		e := new(JSONEvent)
+		if err := json.Unmarshal([]byte(line), e); err != nil {
+			t.Fatalf("bad event %q: %s", line, err)
+		}
+		result = append(result, e)
+	}
+
+	return result
+}
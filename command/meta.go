@@ -2,13 +2,20 @@ package command
 
 import (
 	"bufio"
+	"crypto/sha256"
+	"encoding/hex"
 	"flag"
 	"fmt"
 	"io"
+	"io/ioutil"
 	"os"
 	"path/filepath"
+	"sort"
 	"strconv"
+	"strings"
+	"time"
 
+	"github.com/hashicorp/terraform/config"
 	"github.com/hashicorp/terraform/config/module"
 	"github.com/hashicorp/terraform/state"
 	"github.com/hashicorp/terraform/terraform"
@@ -20,6 +27,8 @@ import (
 type Meta struct {
 	Color       bool
 	ContextOpts *terraform.ContextOpts
+	Policy      *Policy
+	Webhooks    *Webhooks
 	Ui          cli.Ui
 
 	// State read when calling `Context`. This is available after calling
@@ -27,6 +36,13 @@ type Meta struct {
 	state       state.State
 	stateResult *StateResult
 
+	// Unlocks the state locked by `Context` for contextOpts.Lock. It is
+	// called by unlockState.
+	stateUnlock func()
+
+	// Plan read when calling `Context`, if it was given a plan file.
+	plan *terraform.Plan
+
 	// This can be set by the command itself to provide extra hooks.
 	extraHooks []terraform.Hook
 
@@ -38,13 +54,25 @@ type Meta struct {
 	autoVariables map[string]string
 	input         bool
 	variables     map[string]string
+	varFiles      []string
 
 	// Targets for this context (private)
 	targets []string
 
+	// Resources to replace in the plan (private)
+	replace []string
+
+	// Limits of the concurrent operations of the graph walks, in total
+	// and for each provider (private)
+	parallelism         int
+	providerParallelism int
+
 	color bool
 	oldUi cli.Ui
 
+	// jsonUi is set when the output is JSON events, with -json
+	jsonUi *JSONUi
+
 	// The fields below are expected to be set by the command via
 	// command line flags. See the Apply command for an example.
 	//
@@ -58,10 +86,16 @@ type Meta struct {
 	// be overriden.
 	//
 	// backupPath is used to backup the state file before writing a modified
-	// version. It defaults to stateOutPath + DefaultBackupExtention
-	statePath    string
-	stateOutPath string
-	backupPath   string
+	// version. It defaults to stateOutPath + DefaultBackupExtention, or a
+	// path in the directory set with BackupDirEnvVar. If it is "-", no
+	// backup is made.
+	//
+	// stateLockTimeout is how long to keep trying to lock the state if it
+	// is locked by another run, with -lock-timeout.
+	statePath        string
+	stateOutPath     string
+	backupPath       string
+	stateLockTimeout time.Duration
 }
 
 // initStatePaths is used to initialize the default values for
@@ -74,7 +108,7 @@ func (m *Meta) initStatePaths() {
 		m.stateOutPath = m.statePath
 	}
 	if m.backupPath == "" {
-		m.backupPath = m.stateOutPath + DefaultBackupExtention
+		m.backupPath = defaultBackupPath(m.stateOutPath)
 	}
 }
 
@@ -95,6 +129,11 @@ func (m *Meta) Colorize() *colorstring.Colorize {
 // Context returns a Terraform Context taking into account the context
 // options used to initialize this meta configuration.
 func (m *Meta) Context(copts contextOpts) (*terraform.Context, bool, error) {
+	if m.parallelism < 0 || m.providerParallelism < 0 {
+		return nil, false, fmt.Errorf(
+			"-parallelism and -provider-parallelism can't be negative")
+	}
+
 	opts := m.contextOpts()
 
 	// First try to just read the plan directly from the path given.
@@ -104,7 +143,10 @@ func (m *Meta) Context(copts contextOpts) (*terraform.Context, bool, error) {
 		f.Close()
 		if err == nil {
 			// Setup our state
-			state, statePath, err := StateFromPlan(m.statePath, plan)
+			stateOpts := m.StateOpts()
+			state, statePath, err := StateFromPlan(
+				stateOpts.LocalPath, stateOpts.RemotePath,
+				stateOpts.BackupPath, plan)
 			if err != nil {
 				return nil, false, fmt.Errorf("Error loading plan: %s", err)
 			}
@@ -112,6 +154,17 @@ func (m *Meta) Context(copts contextOpts) (*terraform.Context, bool, error) {
 			// Set our state
 			m.state = state
 			m.stateOutPath = statePath
+			m.plan = plan
+
+			// The state of the plan is applied rather than the current
+			// one, so it isn't read again once locked.
+			if copts.Lock != "" {
+				unlock, err := m.lockPlanState(copts.Lock)
+				if err != nil {
+					return nil, false, err
+				}
+				m.stateUnlock = unlock
+			}
 
 			if len(m.variables) > 0 {
 				return nil, false, fmt.Errorf(
@@ -121,6 +174,10 @@ func (m *Meta) Context(copts contextOpts) (*terraform.Context, bool, error) {
 						"variable values, create a new plan file.")
 			}
 
+			if err := m.checkPlanConfig(plan, copts.StalePlan); err != nil {
+				return nil, false, err
+			}
+
 			return plan.Context(opts), true, nil
 		}
 	}
@@ -139,15 +196,20 @@ func (m *Meta) Context(copts contextOpts) (*terraform.Context, bool, error) {
 		return nil, false, err
 	}
 
-	// Load the root module
-	mod, err := module.NewTreeModule("", copts.Path)
-	if err != nil {
-		return nil, false, fmt.Errorf("Error loading config: %s", err)
+	// Lock the state before the context reads it, so that it can't be
+	// changed by another run in between.
+	if copts.Lock != "" {
+		unlock, err := m.lockState(copts.Lock)
+		if err != nil {
+			return nil, false, err
+		}
+		m.stateUnlock = unlock
 	}
 
-	err = mod.Load(m.moduleStorage(m.DataDir()), copts.GetMode)
+	// Load the root module
+	mod, err := m.loadModule(copts.Path, copts.GetMode)
 	if err != nil {
-		return nil, false, fmt.Errorf("Error downloading modules: %s", err)
+		return nil, false, err
 	}
 
 	opts.Module = mod
@@ -156,6 +218,157 @@ func (m *Meta) Context(copts contextOpts) (*terraform.Context, bool, error) {
 	return ctx, false, nil
 }
 
+// loadModule loads the module tree of the configuration in the given
+// directory, downloading the modules with the given mode.
+func (m *Meta) loadModule(path string, mode module.GetMode) (*module.Tree, error) {
+	mod, err := module.NewTreeModule("", path)
+	if err != nil {
+		return nil, fmt.Errorf("Error loading config: %s", err)
+	}
+
+	// Load the module lock so that modules are pinned to the exact
+	// sources they were downloaded from before.
+	lockPath := filepath.Join(path, module.LockFile)
+	lock, err := module.ReadLock(lockPath)
+	if err != nil {
+		return nil, err
+	}
+	mod.SetLock(lock)
+
+	err = mod.Load(m.moduleStorage(m.DataDir()), mode)
+	if err != nil {
+		return nil, fmt.Errorf("Error downloading modules: %s", err)
+	}
+
+	// If we downloaded modules, then write out what we got
+	if mode > module.GetModeNone {
+		lock.Prune()
+		if len(lock.Modules) > 0 {
+			if err := lock.Write(lockPath); err != nil {
+				return nil, fmt.Errorf(
+					"Error writing module lock: %s", err)
+			}
+		} else if err := os.Remove(lockPath); err != nil && !os.IsNotExist(err) {
+			return nil, fmt.Errorf(
+				"Error removing module lock: %s", err)
+		}
+	}
+
+	return mod, nil
+}
+
+// checkPlanConfig verifies that the configuration hasn't changed since
+// the plan was created, by comparing the hash saved in the plan to the
+// hash of the modules and variable files the plan was created from. It
+// is an error if the directory of the configuration doesn't exist, such
+// as when the plan is applied on another machine.
+//
+// If stale is true, a changed or missing configuration is only a warning.
+func (m *Meta) checkPlanConfig(plan *terraform.Plan, stale bool) error {
+	if plan.ConfigHash == "" {
+		return nil
+	}
+
+	var dir string
+	if plan.Module != nil && plan.Module.Config() != nil {
+		dir = plan.Module.Config().Dir
+	}
+	if dir == "" {
+		return fmt.Errorf(
+			"The plan doesn't record the directory of its configuration,\n" +
+				"so it can't be checked for changes.")
+	}
+	if _, err := os.Stat(dir); err != nil {
+		if stale {
+			m.Ui.Warn(fmt.Sprintf(
+				"The configuration of the plan can't be checked: %s\n"+
+					"Applying the plan anyway, since -force was given.\n", err))
+			return nil
+		}
+
+		return fmt.Errorf(
+			"The configuration of the plan can't be checked for changes:\n"+
+				"%s\n\nUse -force to apply this plan anyway.", err)
+	}
+
+	hash, err := configHash(plan)
+	if err != nil {
+		return fmt.Errorf("Error hashing configuration: %s", err)
+	}
+	if hash == plan.ConfigHash {
+		return nil
+	}
+
+	if stale {
+		m.Ui.Warn(fmt.Sprintf(
+			"The configuration in %s has changed since the plan was created.\n"+
+				"Applying the plan anyway, since -force was given.\n", dir))
+		return nil
+	}
+
+	return fmt.Errorf(
+		"The configuration in %s has changed since the plan was created,\n"+
+			"so the plan may be stale. Create a new plan, or use -force to\n"+
+			"apply this plan anyway.", dir)
+}
+
+// configHash returns a hash of the configuration of the plan: the files
+// of every module in its module tree, the contents of the variable files
+// it was created with, and the values of its variables.
+func configHash(plan *terraform.Plan) (string, error) {
+	h := sha256.New()
+	if plan.Module != nil {
+		if err := moduleHash(h, plan.Module); err != nil {
+			return "", err
+		}
+	}
+
+	for _, path := range plan.VarFiles {
+		data, err := ioutil.ReadFile(path)
+		if err != nil {
+			return "", err
+		}
+
+		fmt.Fprintf(h, "%s\n%d\n", path, len(data))
+		h.Write(data)
+	}
+
+	keys := make([]string, 0, len(plan.Vars))
+	for k, _ := range plan.Vars {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		fmt.Fprintf(h, "%s=%q\n", k, plan.Vars[k])
+	}
+
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// moduleHash writes the hash of the configuration files of the module
+// and its children, by path, to w.
+func moduleHash(w io.Writer, t *module.Tree) error {
+	hash, err := config.DirHash(t.Config().Dir)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(w, "%s\n%s\n", strings.Join(t.Path(), "."), hash)
+
+	children := t.Children()
+	names := make([]string, 0, len(children))
+	for name, _ := range children {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if err := moduleHash(w, children[name]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 // DataDir returns the directory where local data will be stored.
 func (m *Meta) DataDir() string {
 	dataDir := DefaultDataDirectory
@@ -198,12 +411,49 @@ func (m *Meta) InputMode() terraform.InputMode {
 	return mode
 }
 
+// Env returns the name of the selected environment.
+func (m *Meta) Env() string {
+	data, err := ioutil.ReadFile(filepath.Join(m.DataDir(), DefaultEnvFile))
+	if err != nil {
+		return terraform.DefaultEnv
+	}
+
+	env := strings.TrimSpace(string(data))
+	if env == "" {
+		return terraform.DefaultEnv
+	}
+
+	return env
+}
+
+// SetEnv selects the environment with the given name.
+func (m *Meta) SetEnv(env string) error {
+	path := filepath.Join(m.DataDir(), DefaultEnvFile)
+	if env == terraform.DefaultEnv {
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+
+		return nil
+	}
+
+	if err := os.MkdirAll(m.DataDir(), 0755); err != nil {
+		return err
+	}
+
+	return ioutil.WriteFile(path, []byte(env+"\n"), 0644)
+}
+
 // State returns the state for this meta.
 func (m *Meta) State() (state.State, error) {
 	if m.state != nil {
 		return m.state, nil
 	}
 
+	if err := m.initEnvRemote(m.Env()); err != nil {
+		return nil, err
+	}
+
 	result, err := State(m.StateOpts())
 	if err != nil {
 		return nil, err
@@ -230,11 +480,14 @@ func (m *Meta) StateRaw(opts *StateOpts) (*StateResult, error) {
 
 // StateOpts returns the default state options
 func (m *Meta) StateOpts() *StateOpts {
+	envPath, remotePath := m.envStatePaths(m.Env())
+
+	// The default state path is replaced by the path of the selected
+	// environment's state.
 	localPath := m.statePath
-	if localPath == "" {
-		localPath = DefaultStateFilename
+	if localPath == "" || localPath == DefaultStateFilename {
+		localPath = envPath
 	}
-	remotePath := filepath.Join(m.DataDir(), DefaultStateFilename)
 
 	return &StateOpts{
 		LocalPath:     localPath,
@@ -262,6 +515,143 @@ func (m *Meta) PersistState(s *terraform.State) error {
 	return m.state.PersistState()
 }
 
+// stateLockRetryInterval is how long to wait between attempts to lock the
+// state when waiting for it with -lock-timeout.
+var stateLockRetryInterval = time.Second
+
+// lockState locks the state for the given operation if the state supports
+// locking. The returned function unlocks it again and should be deferred.
+//
+// If the state is locked by another run, it is tried again until the
+// -lock-timeout has passed. Since the state was read before locking it,
+// it is read again once locked, so that a change made by another run in
+// between isn't lost.
+func (m *Meta) lockState(operation string) (func(), error) {
+	if _, ok := m.state.(state.Locker); !ok {
+		return func() {}, nil
+	}
+
+	unlock, _, err := m.acquireStateLock(operation)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := m.state.RefreshState(); err != nil {
+		unlock()
+		return nil, fmt.Errorf("Error reloading state after locking it: %s", err)
+	}
+
+	return unlock, nil
+}
+
+// lockPlanState locks the state like lockState when applying a plan. The
+// state of the plan is applied rather than the current state, so the
+// state isn't read again, but it is an error if the run that held the
+// lock changed it.
+func (m *Meta) lockPlanState(operation string) (func(), error) {
+	if _, ok := m.state.(state.Locker); !ok {
+		return func() {}, nil
+	}
+
+	unlock, waited, err := m.acquireStateLock(operation)
+	if err != nil {
+		return nil, err
+	}
+
+	if waited {
+		old := m.state.State()
+		if err := m.state.RefreshState(); err != nil {
+			unlock()
+			return nil, fmt.Errorf("Error reloading state after locking it: %s", err)
+		}
+
+		current := m.state.State()
+		if !old.Equal(current) || (old != nil && current != nil && old.Serial != current.Serial) {
+			unlock()
+			return nil, fmt.Errorf(
+				"The state was modified by the run that held the lock.\n" +
+					"Create a new plan to use the new state.")
+		}
+	}
+
+	return unlock, nil
+}
+
+// acquireStateLock locks the state, which must be a state.Locker, and
+// returns the function to unlock it and whether it had to wait for the
+// lock of another run.
+func (m *Meta) acquireStateLock(operation string) (func(), bool, error) {
+	locker := m.state.(state.Locker)
+
+	deadline := time.Now().Add(m.stateLockTimeout)
+	waited := false
+	err := locker.Lock(operation)
+	for err != nil {
+		if _, ok := err.(*state.LockError); !ok || !time.Now().Before(deadline) {
+			break
+		}
+
+		if !waited {
+			m.Ui.Output(fmt.Sprintf(
+				"The state is locked by another run. Waiting up to %s for the lock...",
+				m.stateLockTimeout))
+			waited = true
+		}
+		time.Sleep(stateLockRetryInterval)
+		err = locker.Lock(operation)
+	}
+
+	if err != nil {
+		// The lock of a local state is released when the run holding it
+		// exits, so there is never a lock to remove by hand.
+		_, locked := err.(*state.LockError)
+		if locked && isLocalState(m.state) {
+			return nil, false, fmt.Errorf(
+				"Error locking state: %s\n\n"+
+					"Wait for the run holding the lock to finish, or use\n"+
+					"-lock-timeout to wait for it.", err)
+		}
+
+		if lockErr, ok := err.(*state.LockError); ok && lockErr.Info != nil {
+			return nil, false, fmt.Errorf(
+				"Error locking state: %s\n\n"+
+					"If the run holding the lock has stopped without unlocking\n"+
+					"the state, such as after a crash, remove the lock with:\n\n"+
+					"  terraform force-unlock %s",
+				err, lockErr.Info.ID)
+		}
+
+		return nil, false, fmt.Errorf("Error locking state: %s", err)
+	}
+
+	unlock := func() {
+		if err := locker.Unlock(); err != nil {
+			m.Ui.Error(fmt.Sprintf("Error unlocking state: %s", err))
+		}
+	}
+
+	return unlock, waited, nil
+}
+
+// unlockState unlocks the state locked by Context, if any.
+func (m *Meta) unlockState() {
+	if m.stateUnlock != nil {
+		m.stateUnlock()
+		m.stateUnlock = nil
+	}
+}
+
+// isLocalState returns whether the state is a local state file, possibly
+// backed up.
+func isLocalState(s state.State) bool {
+	if b, ok := s.(*state.BackupState); ok {
+		s = b.Real
+	}
+
+	_, ok := s.(*state.LocalState)
+	return ok
+}
+
 // Input returns true if we should ask for input for context.
 func (m *Meta) Input() bool {
 	return !test && m.input && len(m.variables) == 0
@@ -271,6 +661,7 @@ func (m *Meta) Input() bool {
 // context with the settings from this Meta.
 func (m *Meta) contextOpts() *terraform.ContextOpts {
 	var opts terraform.ContextOpts = *m.ContextOpts
+	opts.Env = m.Env()
 	opts.Hooks = make(
 		[]terraform.Hook,
 		len(m.ContextOpts.Hooks)+len(m.extraHooks)+1)
@@ -289,8 +680,15 @@ func (m *Meta) contextOpts() *terraform.ContextOpts {
 		vs[k] = v
 	}
 	opts.Variables = vs
+	opts.Replace = m.replace
 	opts.Targets = m.targets
 	opts.UIInput = m.UIInput()
+	if m.parallelism > 0 {
+		opts.Parallelism = m.parallelism
+	}
+	if m.providerParallelism > 0 {
+		opts.ProviderParallelism = m.providerParallelism
+	}
 
 	return &opts
 }
@@ -300,11 +698,17 @@ func (m *Meta) flagSet(n string) *flag.FlagSet {
 	f := flag.NewFlagSet(n, flag.ContinueOnError)
 	f.BoolVar(&m.input, "input", true, "input")
 	f.Var((*FlagKV)(&m.variables), "var", "variables")
-	f.Var((*FlagKVFile)(&m.variables), "var-file", "variable file")
+	f.Var(&FlagVarFile{
+		Vars:  &m.variables,
+		Paths: &m.varFiles,
+	}, "var-file", "variable file")
 	f.Var((*FlagStringSlice)(&m.targets), "target", "resource to target")
 
 	if m.autoKey != "" {
-		f.Var((*FlagKVFile)(&m.autoVariables), m.autoKey, "variable file")
+		f.Var(&FlagVarFile{
+			Vars:  &m.autoVariables,
+			Paths: &m.varFiles,
+		}, m.autoKey, "variable file")
 	}
 
 	// Create an io.Writer that writes to our Ui properly for errors.
@@ -391,8 +795,23 @@ func (m *Meta) process(args []string, vars bool) []string {
 	return args
 }
 
-// uiHook returns the UiHook to use with the context.
-func (m *Meta) uiHook() *UiHook {
+// setJSONOutput switches the output of the command to JSON events, for
+// the -json flag of the commands. It must be called after process. Input
+// isn't possible in the middle of the events, so it is disabled.
+func (m *Meta) setJSONOutput() {
+	m.jsonUi = &JSONUi{Ui: m.oldUi}
+	m.Ui = m.jsonUi
+	m.color = false
+	m.input = false
+}
+
+// uiHook returns the hook that shows the progress of the context: the
+// UiHook, or the JSONHook with -json.
+func (m *Meta) uiHook() terraform.Hook {
+	if m.jsonUi != nil {
+		return &JSONHook{Ui: m.jsonUi}
+	}
+
 	return &UiHook{
 		Colorize: m.Colorize(),
 		Ui:       m.Ui,
@@ -429,4 +848,12 @@ type contextOpts struct {
 
 	// Set to true when running a destroy plan/apply.
 	Destroy bool
+
+	// Set to true to load a plan even if the configuration changed since
+	// the plan was created.
+	StalePlan bool
+
+	// Lock is the operation to lock the state for, if any. The state is
+	// then unlocked by unlockState.
+	Lock string
 }
@@ -0,0 +1,190 @@
+package command
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"os"
+	"sort"
+	"time"
+)
+
+const (
+	// MetricsFileEnvVar is the environment variable that, if set, is the
+	// path of a file the metrics of each apply are written to as JSON.
+	MetricsFileEnvVar = "TF_METRICS_FILE"
+
+	// MetricsStatsdEnvVar is the environment variable that, if set, is the
+	// address of a statsd server the metrics of each apply are sent to.
+	MetricsStatsdEnvVar = "TF_METRICS_STATSD"
+
+	// MetricsPrefixEnvVar is the environment variable that, if set,
+	// replaces the default prefix of the metrics sent to statsd.
+	MetricsPrefixEnvVar = "TF_METRICS_PREFIX"
+)
+
+const defaultMetricsPrefix = "terraform"
+
+// metricsReport is the report of a run that is written to the metrics
+// file.
+type metricsReport struct {
+	Command    string                      `json:"command"`
+	Start      time.Time                   `json:"start"`
+	DurationMs int64                       `json:"duration_ms"`
+	Error      string                      `json:"error,omitempty"`
+	Resources  []*metricsResource          `json:"resources"`
+	Providers  map[string]*metricsProvider `json:"providers"`
+}
+
+type metricsResource struct {
+	Address    string `json:"address"`
+	Type       string `json:"type"`
+	Provider   string `json:"provider"`
+	Operation  string `json:"operation"`
+	DurationMs int64  `json:"duration_ms"`
+	Error      string `json:"error,omitempty"`
+}
+
+type metricsProvider struct {
+	Operations int   `json:"operations"`
+	Errors     int   `json:"errors"`
+	DurationMs int64 `json:"duration_ms"`
+}
+
+// exportMetrics writes the metrics recorded by the hook to the metrics
+// file and statsd, if they are configured. Failing to export metrics
+// doesn't fail the command, so errors are only reported.
+func (m *Meta) exportMetrics(
+	h *MetricsHook, command string, start time.Time, runErr error) {
+	path := os.Getenv(MetricsFileEnvVar)
+	addr := os.Getenv(MetricsStatsdEnvVar)
+	if path == "" && addr == "" {
+		return
+	}
+
+	report := newMetricsReport(h, command, start, runErr)
+	if path != "" {
+		if err := writeMetricsFile(path, report); err != nil {
+			m.Ui.Error(fmt.Sprintf("Error writing metrics: %s", err))
+		}
+	}
+	if addr != "" {
+		prefix := os.Getenv(MetricsPrefixEnvVar)
+		if prefix == "" {
+			prefix = defaultMetricsPrefix
+		}
+
+		if err := sendMetricsStatsd(addr, statsdLines(prefix, report)); err != nil {
+			m.Ui.Error(fmt.Sprintf("Error sending metrics to statsd: %s", err))
+		}
+	}
+}
+
+func newMetricsReport(
+	h *MetricsHook, command string, start time.Time, runErr error) *metricsReport {
+	result := &metricsReport{
+		Command:    command,
+		Start:      start,
+		DurationMs: metricsMs(time.Now().Sub(start)),
+		Resources:  make([]*metricsResource, 0),
+		Providers:  make(map[string]*metricsProvider),
+	}
+	if runErr != nil {
+		result.Error = runErr.Error()
+	}
+
+	h.Lock()
+	defer h.Unlock()
+	for _, op := range h.Operations {
+		result.Resources = append(result.Resources, &metricsResource{
+			Address:    op.Resource,
+			Type:       op.Type,
+			Provider:   op.Provider,
+			Operation:  op.Operation,
+			DurationMs: metricsMs(op.Duration),
+			Error:      op.Error,
+		})
+
+		p, ok := result.Providers[op.Provider]
+		if !ok {
+			p = new(metricsProvider)
+			result.Providers[op.Provider] = p
+		}
+		p.Operations++
+		p.DurationMs += metricsMs(op.Duration)
+		if op.Error != "" {
+			p.Errors++
+		}
+	}
+
+	return result
+}
+
+func writeMetricsFile(path string, r *metricsReport) error {
+	data, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return ioutil.WriteFile(path, append(data, '\n'), 0644)
+}
+
+// statsdLines returns the metrics of a report in the statsd format.
+// Resources are reported by type and operation, since their addresses
+// would make too many metrics.
+func statsdLines(prefix string, r *metricsReport) []string {
+	result := []string{
+		fmt.Sprintf("%s.%s.duration:%d|ms", prefix, r.Command, r.DurationMs),
+	}
+	if r.Error != "" {
+		result = append(result, fmt.Sprintf("%s.%s.errors:1|c", prefix, r.Command))
+	}
+
+	for _, res := range r.Resources {
+		name := fmt.Sprintf("%s.resource.%s.%s", prefix, res.Type, res.Operation)
+		result = append(result, fmt.Sprintf("%s.duration:%d|ms", name, res.DurationMs))
+		if res.Error != "" {
+			result = append(result, fmt.Sprintf("%s.errors:1|c", name))
+		}
+	}
+
+	providers := make([]string, 0, len(r.Providers))
+	for name, _ := range r.Providers {
+		providers = append(providers, name)
+	}
+	sort.Strings(providers)
+	for _, name := range providers {
+		p := r.Providers[name]
+		result = append(result, fmt.Sprintf(
+			"%s.provider.%s.operations:%d|c", prefix, name, p.Operations))
+		if p.Errors > 0 {
+			result = append(result, fmt.Sprintf(
+				"%s.provider.%s.errors:%d|c", prefix, name, p.Errors))
+		}
+	}
+
+	return result
+}
+
+// sendMetricsStatsd sends the metrics to statsd over UDP, one metric per
+// packet.
+func sendMetricsStatsd(addr string, lines []string) error {
+	conn, err := net.Dial("udp", addr)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	for _, line := range lines {
+		if _, err := conn.Write([]byte(line)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func metricsMs(d time.Duration) int64 {
+	return int64(d / time.Millisecond)
+}
@@ -0,0 +1,116 @@
+package command
+
+import (
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestNewMetricsReport(t *testing.T) {
+	h := &MetricsHook{
+		Operations: []*MetricsOperation{
+			&MetricsOperation{
+				Resource:  "aws_instance.web",
+				Type:      "aws_instance",
+				Provider:  "aws",
+				Operation: "create",
+				Duration:  1500 * time.Millisecond,
+			},
+			&MetricsOperation{
+				Resource:  "aws_eip.ip",
+				Type:      "aws_eip",
+				Provider:  "aws",
+				Operation: "update",
+				Duration:  500 * time.Millisecond,
+				Error:     "throttled",
+			},
+		},
+	}
+
+	r := newMetricsReport(h, "apply", time.Now(), errors.New("failed"))
+	if r.Command != "apply" || r.Error != "failed" || len(r.Resources) != 2 {
+		t.Fatalf("bad: %#v", r)
+	}
+	if r.Resources[0].DurationMs != 1500 {
+		t.Fatalf("bad: %#v", r.Resources[0])
+	}
+
+	expected := map[string]*metricsProvider{
+		"aws": &metricsProvider{
+			Operations: 2,
+			Errors:     1,
+			DurationMs: 2000,
+		},
+	}
+	if !reflect.DeepEqual(r.Providers, expected) {
+		t.Fatalf("bad: %#v", r.Providers)
+	}
+
+	r.DurationMs = 2100
+	actual := statsdLines("tf", r)
+	expectedLines := []string{
+		"tf.apply.duration:2100|ms",
+		"tf.apply.errors:1|c",
+		"tf.resource.aws_instance.create.duration:1500|ms",
+		"tf.resource.aws_eip.update.duration:500|ms",
+		"tf.resource.aws_eip.update.errors:1|c",
+		"tf.provider.aws.operations:2|c",
+		"tf.provider.aws.errors:1|c",
+	}
+	if !reflect.DeepEqual(actual, expectedLines) {
+		t.Fatalf("bad: %#v", actual)
+	}
+}
+
+func TestSendMetricsStatsd(t *testing.T) {
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	defer conn.Close()
+
+	lines := []string{"tf.apply.duration:10|ms", "tf.apply.errors:1|c"}
+	if err := sendMetricsStatsd(conn.LocalAddr().String(), lines); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+	buf := make([]byte, 512)
+	for _, expected := range lines {
+		n, _, err := conn.ReadFrom(buf)
+		if err != nil {
+			t.Fatalf("err: %s", err)
+		}
+		if actual := string(buf[:n]); actual != expected {
+			t.Fatalf("bad: %s", actual)
+		}
+	}
+}
+
+func TestWriteMetricsFile(t *testing.T) {
+	path := testTempFile(t)
+	r := newMetricsReport(new(MetricsHook), "destroy", time.Now(), nil)
+	if err := writeMetricsFile(path, r); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	var actual map[string]interface{}
+	if err := json.Unmarshal(data, &actual); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if actual["command"] != "destroy" {
+		t.Fatalf("bad: %s", data)
+	}
+	if _, ok := actual["error"]; ok {
+		t.Fatalf("bad: %s", data)
+	}
+}
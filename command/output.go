@@ -1,8 +1,10 @@
 package command
 
 import (
+	"encoding/json"
 	"flag"
 	"fmt"
+	"sort"
 	"strings"
 )
 
@@ -15,22 +17,37 @@ type OutputCommand struct {
 func (c *OutputCommand) Run(args []string) int {
 	args = c.Meta.process(args, false)
 
+	var format string
 	cmdFlags := flag.NewFlagSet("output", flag.ContinueOnError)
 	cmdFlags.StringVar(&c.Meta.statePath, "state", DefaultStateFilename, "path")
+	cmdFlags.StringVar(&format, "format", "", "format")
 	cmdFlags.Usage = func() { c.Ui.Error(c.Help()) }
 	if err := cmdFlags.Parse(args); err != nil {
 		return 1
 	}
 
+	switch format {
+	case "", "export", "json":
+	default:
+		c.Ui.Error(fmt.Sprintf(
+			"Unknown output format %q. Valid formats are \"export\" and \"json\".\n",
+			format))
+		cmdFlags.Usage()
+		return 1
+	}
+
+	// All the outputs are printed if no name is given, but only in the
+	// formats that keep the names.
 	args = cmdFlags.Args()
-	if len(args) != 1 || args[0] == "" {
+	if len(args) > 1 || (len(args) == 0 && format == "") ||
+		(len(args) == 1 && args[0] == "") {
 		c.Ui.Error(
-			"The output command expects exactly one argument with the name\n" +
-				"of an output variable.\n")
+			"The output command expects the name of an output variable as\n" +
+				"its only argument. The name can only be omitted with -format,\n" +
+				"which prints all the outputs.\n")
 		cmdFlags.Usage()
 		return 1
 	}
-	name := args[0]
 
 	stateStore, err := c.Meta.State()
 	if err != nil {
@@ -46,29 +63,165 @@ func (c *OutputCommand) Run(args []string) int {
 				"`terraform apply` for it to become available."))
 		return 1
 	}
-	v, ok := state.RootModule().Outputs[name]
-	if !ok {
-		c.Ui.Error(fmt.Sprintf(
-			"The output variable requested could not be found in the state\n" +
-				"file. If you recently added this to your configuration, be\n" +
-				"sure to run `terraform apply`, since the state won't be updated\n" +
-				"with new output variables until that command is run."))
+
+	outputs := state.RootModule().Outputs
+	if len(args) == 1 {
+		name := args[0]
+		v, ok := outputs[name]
+		if !ok {
+			c.Ui.Error(fmt.Sprintf(
+				"The output variable requested could not be found in the state\n" +
+					"file. If you recently added this to your configuration, be\n" +
+					"sure to run `terraform apply`, since the state won't be updated\n" +
+					"with new output variables until that command is run."))
+			return 1
+		}
+
+		switch format {
+		case "json":
+			return c.outputJSON(v)
+		case "export":
+			c.Ui.Output(formatOutputExport(name, v))
+			return 0
+		}
+
+		// Lists and maps are output one element per line so that they're
+		// easy to consume from shell scripts.
+		switch v := v.(type) {
+		case []interface{}:
+			for _, e := range v {
+				c.Ui.Output(fmt.Sprintf("%v", e))
+			}
+		case map[string]interface{}:
+			ks := make([]string, 0, len(v))
+			for k, _ := range v {
+				ks = append(ks, k)
+			}
+			sort.Strings(ks)
+
+			for _, k := range ks {
+				c.Ui.Output(fmt.Sprintf("%s = %v", k, v[k]))
+			}
+		default:
+			c.Ui.Output(fmt.Sprintf("%v", v))
+		}
+
+		return 0
+	}
+
+	if format == "json" {
+		return c.outputJSON(outputs)
+	}
+
+	ks := make([]string, 0, len(outputs))
+	for k, _ := range outputs {
+		ks = append(ks, k)
+	}
+	sort.Strings(ks)
+
+	for _, k := range ks {
+		c.Ui.Output(formatOutputExport(k, outputs[k]))
+	}
+
+	return 0
+}
+
+func (c *OutputCommand) outputJSON(v interface{}) int {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error encoding outputs as JSON: %s", err))
 		return 1
 	}
 
-	c.Ui.Output(v)
+	c.Ui.Output(string(data))
 	return 0
 }
 
+// formatOutputExport formats an output as a shell command that exports
+// it as an environment variable. Characters that can't be in the names of
+// variables are replaced with underscores, list elements are joined with
+// commas and maps are exported as JSON. The value is single quoted, so
+// the shell doesn't expand anything in it.
+func formatOutputExport(name string, raw interface{}) string {
+	key := []byte(name)
+	for i, c := range key {
+		valid := c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') ||
+			(i > 0 && c >= '0' && c <= '9')
+		if !valid {
+			key[i] = '_'
+		}
+	}
+
+	var value string
+	switch v := raw.(type) {
+	case []interface{}:
+		parts := make([]string, len(v))
+		for i, e := range v {
+			parts[i] = fmt.Sprintf("%v", e)
+		}
+		value = strings.Join(parts, ",")
+	case map[string]interface{}:
+		data, err := json.Marshal(v)
+		if err != nil {
+			value = formatOutputValue(v)
+		} else {
+			value = string(data)
+		}
+	default:
+		value = fmt.Sprintf("%v", v)
+	}
+
+	return fmt.Sprintf("export %s='%s'",
+		key, strings.Replace(value, "'", `'\''`, -1))
+}
+
+// formatOutputValue formats the value of an output on a single line for
+// human consumption.
+func formatOutputValue(raw interface{}) string {
+	switch v := raw.(type) {
+	case []interface{}:
+		parts := make([]string, len(v))
+		for i, e := range v {
+			parts[i] = fmt.Sprintf("%v", e)
+		}
+		return fmt.Sprintf("[%s]", strings.Join(parts, ", "))
+	case map[string]interface{}:
+		ks := make([]string, 0, len(v))
+		for k, _ := range v {
+			ks = append(ks, k)
+		}
+		sort.Strings(ks)
+
+		parts := make([]string, len(ks))
+		for i, k := range ks {
+			parts[i] = fmt.Sprintf("%s = %v", k, v[k])
+		}
+		return fmt.Sprintf("{%s}", strings.Join(parts, ", "))
+	default:
+		return fmt.Sprintf("%v", raw)
+	}
+}
+
 func (c *OutputCommand) Help() string {
 	helpText := `
-Usage: terraform output [options] NAME
+Usage: terraform output [options] [NAME]
 
   Reads an output variable from a Terraform state file and prints
-  the value.
+  the value. List outputs are printed one element per line and map
+  outputs are printed as one "key = value" pair per line.
+
+  NAME is required unless -format is given. With -format and no NAME,
+  all the outputs are printed.
 
 Options:
 
+  -format=export   Print "export NAME='value'" lines that can be
+                   evaluated by a shell. List elements are joined with
+                   commas and maps are printed as JSON.
+
+  -format=json     Print the value as JSON, or an object of all the
+                   outputs if NAME is omitted.
+
   -state=path      Path to the state file to read. Defaults to
                    "terraform.tfstate".
 
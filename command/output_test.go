@@ -1,9 +1,11 @@
 package command
 
 import (
+	"encoding/json"
 	"io/ioutil"
 	"os"
 	"path/filepath"
+	"reflect"
 	"strings"
 	"testing"
 
@@ -16,7 +18,7 @@ func TestOutput(t *testing.T) {
 		Modules: []*terraform.ModuleState{
 			&terraform.ModuleState{
 				Path: []string{"root"},
-				Outputs: map[string]string{
+				Outputs: map[string]interface{}{
 					"foo": "bar",
 				},
 			},
@@ -52,7 +54,7 @@ func TestOutput_badVar(t *testing.T) {
 		Modules: []*terraform.ModuleState{
 			&terraform.ModuleState{
 				Path: []string{"root"},
-				Outputs: map[string]string{
+				Outputs: map[string]interface{}{
 					"foo": "bar",
 				},
 			},
@@ -83,7 +85,7 @@ func TestOutput_blank(t *testing.T) {
 		Modules: []*terraform.ModuleState{
 			&terraform.ModuleState{
 				Path: []string{"root"},
-				Outputs: map[string]string{
+				Outputs: map[string]interface{}{
 					"foo": "bar",
 				},
 			},
@@ -140,6 +142,9 @@ func TestOutput_noArgs(t *testing.T) {
 	if code := c.Run(args); code != 1 {
 		t.Fatalf("bad: \n%s", ui.OutputWriter.String())
 	}
+	if !strings.Contains(ui.ErrorWriter.String(), "-format") {
+		t.Fatalf("bad: \n%s", ui.ErrorWriter.String())
+	}
 }
 
 func TestOutput_noState(t *testing.T) {
@@ -168,7 +173,7 @@ func TestOutput_noVars(t *testing.T) {
 		Modules: []*terraform.ModuleState{
 			&terraform.ModuleState{
 				Path:    []string{"root"},
-				Outputs: map[string]string{},
+				Outputs: map[string]interface{}{},
 			},
 		},
 	}
@@ -197,7 +202,7 @@ func TestOutput_stateDefault(t *testing.T) {
 		Modules: []*terraform.ModuleState{
 			&terraform.ModuleState{
 				Path: []string{"root"},
-				Outputs: map[string]string{
+				Outputs: map[string]interface{}{
 					"foo": "bar",
 				},
 			},
@@ -252,3 +257,180 @@ func TestOutput_stateDefault(t *testing.T) {
 		t.Fatalf("bad: %#v", actual)
 	}
 }
+
+func TestOutput_formatExport(t *testing.T) {
+	originalState := &terraform.State{
+		Modules: []*terraform.ModuleState{
+			&terraform.ModuleState{
+				Path: []string{"root"},
+				Outputs: map[string]interface{}{
+					"foo":     "bar",
+					"db-host": "it's here",
+				},
+			},
+		},
+	}
+
+	statePath := testStateFile(t, originalState)
+
+	ui := new(cli.MockUi)
+	c := &OutputCommand{
+		Meta: Meta{
+			ContextOpts: testCtxConfig(testProvider()),
+			Ui:          ui,
+		},
+	}
+
+	args := []string{
+		"-state", statePath,
+		"-format", "export",
+	}
+	if code := c.Run(args); code != 0 {
+		t.Fatalf("bad: \n%s", ui.ErrorWriter.String())
+	}
+
+	actual := strings.TrimSpace(ui.OutputWriter.String())
+	expected := "export db_host='it'\\''s here'\nexport foo='bar'"
+	if actual != expected {
+		t.Fatalf("bad: %#v", actual)
+	}
+}
+
+func TestOutput_formatJSON(t *testing.T) {
+	originalState := &terraform.State{
+		Modules: []*terraform.ModuleState{
+			&terraform.ModuleState{
+				Path: []string{"root"},
+				Outputs: map[string]interface{}{
+					"foo":   "bar",
+					"zones": []interface{}{"a", "b"},
+				},
+			},
+		},
+	}
+
+	statePath := testStateFile(t, originalState)
+
+	cases := []struct {
+		Args   []string
+		Output interface{}
+	}{
+		{
+			[]string{"zones"},
+			[]interface{}{"a", "b"},
+		},
+	}
+
+	for i, tc := range cases {
+		ui := new(cli.MockUi)
+		c := &OutputCommand{
+			Meta: Meta{
+				ContextOpts: testCtxConfig(testProvider()),
+				Ui:          ui,
+			},
+		}
+
+		args := append([]string{"-state", statePath, "-format", "json"}, tc.Args...)
+		if code := c.Run(args); code != 0 {
+			t.Fatalf("%d: bad: \n%s", i, ui.ErrorWriter.String())
+		}
+
+		var actual interface{}
+		if err := json.Unmarshal(ui.OutputWriter.Bytes(), &actual); err != nil {
+			t.Fatalf("%d: err: %s", i, err)
+		}
+		if !reflect.DeepEqual(actual, tc.Output) {
+			t.Fatalf("%d: bad: %#v", i, actual)
+		}
+	}
+}
+
+func TestOutput_formatJSONNoArgs(t *testing.T) {
+	originalState := &terraform.State{
+		Modules: []*terraform.ModuleState{
+			&terraform.ModuleState{
+				Path: []string{"root"},
+				Outputs: map[string]interface{}{
+					"foo":   "bar",
+					"zones": []interface{}{"a", "b"},
+				},
+			},
+		},
+	}
+
+	statePath := testStateFile(t, originalState)
+
+	ui := new(cli.MockUi)
+	c := &OutputCommand{
+		Meta: Meta{
+			ContextOpts: testCtxConfig(testProvider()),
+			Ui:          ui,
+		},
+	}
+
+	args := []string{
+		"-state", statePath,
+		"-format=json",
+	}
+	if code := c.Run(args); code != 0 {
+		t.Fatalf("bad: \n%s", ui.ErrorWriter.String())
+	}
+
+	var actual interface{}
+	if err := json.Unmarshal(ui.OutputWriter.Bytes(), &actual); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	expected := map[string]interface{}{
+		"foo":   "bar",
+		"zones": []interface{}{"a", "b"},
+	}
+	if !reflect.DeepEqual(actual, expected) {
+		t.Fatalf("bad: %#v", actual)
+	}
+}
+
+func TestOutput_badFormat(t *testing.T) {
+	ui := new(cli.MockUi)
+	c := &OutputCommand{
+		Meta: Meta{
+			ContextOpts: testCtxConfig(testProvider()),
+			Ui:          ui,
+		},
+	}
+
+	args := []string{
+		"-format", "yaml",
+		"foo",
+	}
+	if code := c.Run(args); code != 1 {
+		t.Fatalf("bad: \n%s", ui.OutputWriter.String())
+	}
+}
+
+func TestFormatOutputExport(t *testing.T) {
+	cases := []struct {
+		Name   string
+		Value  interface{}
+		Output string
+	}{
+		{"foo", "bar", "export foo='bar'"},
+		{"foo", "$HOME `id`", "export foo='$HOME `id`'"},
+		{"foo", "it's", `export foo='it'\''s'`},
+		{"db-host.1", "x", "export db_host_1='x'"},
+		{"1st", "x", "export _st='x'"},
+		{"zones", []interface{}{"a", "b"}, "export zones='a,b'"},
+		{
+			"tags",
+			map[string]interface{}{"Name": "web"},
+			`export tags='{"Name":"web"}'`,
+		},
+	}
+
+	for i, tc := range cases {
+		actual := formatOutputExport(tc.Name, tc.Value)
+		if actual != tc.Output {
+			t.Fatalf("%d: bad: %s", i, actual)
+		}
+	}
+}
@@ -16,9 +16,10 @@ type PlanCommand struct {
 }
 
 func (c *PlanCommand) Run(args []string) int {
-	var destroy, refresh, detailed bool
+	var destroy, refresh, detailed, verbose, fullValues, jsonOutput bool
 	var outPath string
 	var moduleDepth int
+	var filterTypes, only []string
 
 	args = c.Meta.process(args, true)
 
@@ -29,11 +30,23 @@ func (c *PlanCommand) Run(args []string) int {
 	cmdFlags.StringVar(&outPath, "out", "", "path")
 	cmdFlags.StringVar(&c.Meta.statePath, "state", DefaultStateFilename, "path")
 	cmdFlags.StringVar(&c.Meta.backupPath, "backup", "", "path")
+	cmdFlags.DurationVar(&c.Meta.stateLockTimeout, "lock-timeout", 0, "duration")
+	cmdFlags.IntVar(&c.Meta.parallelism, "parallelism", 0, "parallelism")
+	cmdFlags.IntVar(&c.Meta.providerParallelism, "provider-parallelism", 0, "parallelism")
 	cmdFlags.BoolVar(&detailed, "detailed-exitcode", false, "detailed-exitcode")
+	cmdFlags.Var((*FlagStringSlice)(&c.Meta.replace), "replace", "resource to replace")
+	cmdFlags.BoolVar(&verbose, "verbose", false, "verbose")
+	cmdFlags.BoolVar(&fullValues, "full-values", false, "full-values")
+	cmdFlags.Var((*FlagStringSlice)(&filterTypes), "filter-type", "type")
+	cmdFlags.Var((*FlagStringSlice)(&only), "only", "action")
+	cmdFlags.BoolVar(&jsonOutput, "json", false, "json")
 	cmdFlags.Usage = func() { c.Ui.Error(c.Help()) }
 	if err := cmdFlags.Parse(args); err != nil {
 		return 1
 	}
+	if jsonOutput {
+		c.Meta.setJSONOutput()
+	}
 
 	var path string
 	args = cmdFlags.Args()
@@ -50,18 +63,36 @@ func (c *PlanCommand) Run(args []string) int {
 		path, err = os.Getwd()
 		if err != nil {
 			c.Ui.Error(fmt.Sprintf("Error getting pwd: %s", err))
+			return 1
 		}
 	}
 
+	// The filters only change what is shown, but check them before the
+	// plan is made, which can take a while.
+	formatOpts := &FormatPlanOpts{
+		Color:       c.Colorize(),
+		ModuleDepth: moduleDepth,
+		FilterTypes: filterTypes,
+		Only:        splitPlanActions(only),
+		FullValues:  fullValues,
+	}
+	if err := formatOpts.ValidatePlanFilters(); err != nil {
+		c.Ui.Error(err.Error())
+		return 1
+	}
+
+	defer c.unlockState()
 	ctx, _, err := c.Context(contextOpts{
 		Destroy:   destroy,
 		Path:      path,
 		StatePath: c.Meta.statePath,
+		Lock:      "plan",
 	})
 	if err != nil {
 		c.Ui.Error(err.Error())
 		return 1
 	}
+
 	if !validateContext(ctx, c.Ui) {
 		return 1
 	}
@@ -80,7 +111,7 @@ func (c *PlanCommand) Run(args []string) int {
 		c.Ui.Output("")
 
 		if state != nil {
-			log.Printf("[INFO] Writing state output to: %s", c.Meta.StateOutPath())
+			log.Printf("[INFO] command: Writing state output to: %s", c.Meta.StateOutPath())
 			if err := c.Meta.PersistState(state); err != nil {
 				c.Ui.Error(fmt.Sprintf("Error writing state file: %s", err))
 				return 1
@@ -94,7 +125,14 @@ func (c *PlanCommand) Run(args []string) int {
 		return 1
 	}
 
+	c.Meta.fireWebhooks(webhookPlanEvent(WebhookEventPlan, "plan", plan))
+
 	if plan.Diff.Empty() {
+		if jsonOutput {
+			c.jsonUi.Plan(plan)
+			return 0
+		}
+
 		c.Ui.Output(
 			"No changes. Infrastructure is up-to-date. This means that Terraform\n" +
 				"could not detect any differences between your configuration and\n" +
@@ -104,7 +142,14 @@ func (c *PlanCommand) Run(args []string) int {
 	}
 
 	if outPath != "" {
-		log.Printf("[INFO] Writing plan output to: %s", outPath)
+		plan.VarFiles = c.Meta.varFiles
+		plan.ConfigHash, err = configHash(plan)
+		if err != nil {
+			c.Ui.Error(fmt.Sprintf("Error hashing configuration: %s", err))
+			return 1
+		}
+
+		log.Printf("[INFO] command: Writing plan output to: %s", outPath)
 		f, err := os.Create(outPath)
 		if err == nil {
 			defer f.Close()
@@ -116,6 +161,14 @@ func (c *PlanCommand) Run(args []string) int {
 		}
 	}
 
+	if jsonOutput {
+		c.jsonUi.Plan(plan)
+		if detailed {
+			return 2
+		}
+		return 0
+	}
+
 	if outPath == "" {
 		c.Ui.Output(strings.TrimSpace(planHeaderNoOutput) + "\n")
 	} else {
@@ -124,11 +177,17 @@ func (c *PlanCommand) Run(args []string) int {
 			outPath))
 	}
 
-	c.Ui.Output(FormatPlan(&FormatPlanOpts{
-		Plan:        plan,
-		Color:       c.Colorize(),
-		ModuleDepth: moduleDepth,
-	}))
+	formatOpts.Plan = plan
+	c.Ui.Output(FormatPlan(formatOpts))
+
+	if verbose {
+		c.Ui.Output(c.Colorize().Color(
+			"\n[reset][bold]Destroy order:[reset]\n"))
+		c.Ui.Output(FormatDestroyOrder(&FormatDestroyOrderOpts{
+			Plan:  plan,
+			Color: c.Colorize(),
+		}))
+	}
 
 	if detailed {
 		return 2
@@ -162,19 +221,56 @@ Options:
                       1 - Errored
                       2 - Succeeded, there is a diff
 
+  -filter-type=type   Only show the resources with a type matching the pattern,
+                      such as "aws_iam_*". This does not affect the plan
+                      itself, only the output shown. This flag can be used
+                      multiple times.
+
+  -full-values        Show the values of the attributes in full. By default,
+                      long values are shortened to the part that changes.
+
   -input=true         Ask for input for variables if not directly set.
 
+  -json               Write the output as newline-delimited JSON events:
+                      the progress of the refresh, a "planned_change" for
+                      each resource the plan changes and a "change_summary".
+                      Input is disabled.
+
+  -lock-timeout=0s    How long to wait for the lock of the state if it is
+                      locked by another run, such as "5m". By default, the
+                      command fails right away.
+
   -module-depth=n     Specifies the depth of modules to show in the output.
                       This does not affect the plan itself, only the output
                       shown. By default, this is zero. -1 will expand all.
 
   -no-color           If specified, output won't contain any color.
 
+  -only=action        Only show the resources with the action: create,
+                      update, destroy or replace. Replaced resources are
+                      also shown for create and destroy. This does not
+                      affect the plan itself, only the output shown. Can
+                      be a comma-separated list, or used multiple times.
+
   -out=path           Write a plan file to the given path. This can be used as
                       input to the "apply" command.
 
+  -parallelism=10     Limit the number of operations, such as
+                      refreshing a resource, that run at once.
+
+  -provider-parallelism=n
+                      Limit the number of operations of each provider
+                      that run at once, to stay below the rate limits
+                      of its API. Unlimited by default.
+
   -refresh=true       Update state prior to checking for differences.
 
+  -replace=resource   Resource to replace. The plan will destroy and recreate
+                      this resource, as if it were tainted, without changing
+                      the state. Resources in modules are given as
+                      "module.app.aws_instance.web". This flag can be used
+                      multiple times.
+
   -state=statefile    Path to a Terraform state file to use to look
                       up Terraform-managed resources. By default it will
                       use the state "terraform.tfstate" if it exists.
@@ -183,6 +279,10 @@ Options:
                       resource and its dependencies. This flag can be used
                       multiple times.
 
+  -verbose            Also show the order in which the resources will be
+                      destroyed, and which resources have to be destroyed
+                      before others. Useful with -destroy.
+
   -var 'foo=bar'      Set a variable in the Terraform configuration. This
                       flag can be set multiple times.
 
@@ -194,6 +294,21 @@ Options:
 	return strings.TrimSpace(helpText)
 }
 
+// splitPlanActions splits the comma-separated lists of actions given to
+// -only.
+func splitPlanActions(only []string) []string {
+	var result []string
+	for _, v := range only {
+		for _, action := range strings.Split(v, ",") {
+			if action = strings.TrimSpace(action); action != "" {
+				result = append(result, action)
+			}
+		}
+	}
+
+	return result
+}
+
 func (c *PlanCommand) Synopsis() string {
 	return "Generate and show an execution plan"
 }
@@ -6,7 +6,9 @@ import (
 	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 
+	"github.com/hashicorp/terraform/state"
 	"github.com/hashicorp/terraform/terraform"
 	"github.com/mitchellh/cli"
 )
@@ -240,6 +242,286 @@ func TestPlan_state(t *testing.T) {
 	}
 }
 
+func TestPlan_stateLocked(t *testing.T) {
+	statePath := testStateFile(t, testState())
+	defer os.Remove(statePath)
+
+	// Another run holds the lock
+	other := &state.LocalState{Path: statePath}
+	if err := other.Lock("apply"); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	defer other.Unlock()
+
+	p := testProvider()
+	ui := new(cli.MockUi)
+	c := &PlanCommand{
+		Meta: Meta{
+			ContextOpts: testCtxConfig(p),
+			Ui:          ui,
+		},
+	}
+
+	args := []string{
+		"-state", statePath,
+		testFixturePath("plan"),
+	}
+	if code := c.Run(args); code != 1 {
+		t.Fatalf("bad: %d\n\n%s", code, ui.OutputWriter.String())
+	}
+	if !strings.Contains(ui.ErrorWriter.String(), "state is locked") {
+		t.Fatalf("bad: %s", ui.ErrorWriter.String())
+	}
+	if p.DiffCalled {
+		t.Fatal("plan shouldn't be made without the lock")
+	}
+}
+
+func TestPlan_lockTimeout(t *testing.T) {
+	defer func(v time.Duration) { stateLockRetryInterval = v }(stateLockRetryInterval)
+	stateLockRetryInterval = 10 * time.Millisecond
+
+	statePath := testStateFile(t, testState())
+	defer os.Remove(statePath)
+
+	// Another run holds the lock for a little while
+	other := &state.LocalState{Path: statePath}
+	if err := other.Lock("apply"); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	go func() {
+		time.Sleep(50 * time.Millisecond)
+		other.Unlock()
+	}()
+
+	p := testProvider()
+	ui := new(cli.MockUi)
+	c := &PlanCommand{
+		Meta: Meta{
+			ContextOpts: testCtxConfig(p),
+			Ui:          ui,
+		},
+	}
+
+	args := []string{
+		"-lock-timeout", "5s",
+		"-state", statePath,
+		testFixturePath("plan"),
+	}
+	if code := c.Run(args); code != 0 {
+		t.Fatalf("bad: %d\n\n%s", code, ui.ErrorWriter.String())
+	}
+	if !strings.Contains(ui.OutputWriter.String(), "Waiting up to 5s") {
+		t.Fatalf("bad: %s", ui.OutputWriter.String())
+	}
+	if !p.DiffCalled {
+		t.Fatal("diff should be called")
+	}
+}
+
+func TestPlan_lockTimeoutStateChanged(t *testing.T) {
+	defer func(v time.Duration) { stateLockRetryInterval = v }(stateLockRetryInterval)
+	stateLockRetryInterval = 10 * time.Millisecond
+
+	statePath := testStateFile(t, testState())
+	defer os.Remove(statePath)
+
+	// Another run holds the lock, and changes the state before unlocking
+	other := &state.LocalState{Path: statePath}
+	if err := other.Lock("apply"); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if err := other.RefreshState(); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	go func() {
+		time.Sleep(50 * time.Millisecond)
+		s := other.State()
+		s.RootModule().Resources["test_instance.foo"].Primary.ID = "changed"
+		other.WriteState(s)
+		other.Unlock()
+	}()
+
+	p := testProvider()
+	ui := new(cli.MockUi)
+	c := &PlanCommand{
+		Meta: Meta{
+			ContextOpts: testCtxConfig(p),
+			Ui:          ui,
+		},
+	}
+
+	args := []string{
+		"-lock-timeout", "5s",
+		"-state", statePath,
+		testFixturePath("plan"),
+	}
+	if code := c.Run(args); code != 0 {
+		t.Fatalf("bad: %d\n\n%s", code, ui.ErrorWriter.String())
+	}
+
+	// The plan is made from the state written by the other run
+	if p.DiffState == nil || p.DiffState.ID != "changed" {
+		t.Fatalf("bad: %#v", p.DiffState)
+	}
+}
+
+func TestPlan_replace(t *testing.T) {
+	statePath := testStateFile(t, testState())
+	outPath := testTempFile(t)
+
+	p := testProvider()
+	ui := new(cli.MockUi)
+	c := &PlanCommand{
+		Meta: Meta{
+			ContextOpts: testCtxConfig(p),
+			Ui:          ui,
+		},
+	}
+
+	args := []string{
+		"-state", statePath,
+		"-out", outPath,
+		"-replace", "test_instance.foo",
+		testFixturePath("plan"),
+	}
+	if code := c.Run(args); code != 0 {
+		t.Fatalf("bad: %d\n\n%s", code, ui.ErrorWriter.String())
+	}
+
+	// The resource is tainted in the plan...
+	f, err := os.Open(outPath)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	defer f.Close()
+
+	plan, err := terraform.ReadPlan(f)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	rs := plan.State.RootModule().Resources["test_instance.foo"]
+	if rs.Primary != nil || len(rs.Tainted) != 1 {
+		t.Fatalf("bad: %#v", rs)
+	}
+
+	// ...but not in the state
+	testStateOutput(t, statePath, testPlanReplaceStateStr)
+}
+
+func TestPlan_configHash(t *testing.T) {
+	outPath := testTempFile(t)
+
+	p := testProvider()
+	ui := new(cli.MockUi)
+	c := &PlanCommand{
+		Meta: Meta{
+			ContextOpts: testCtxConfig(p),
+			Ui:          ui,
+		},
+	}
+
+	args := []string{
+		"-out", outPath,
+		testFixturePath("plan"),
+	}
+	if code := c.Run(args); code != 0 {
+		t.Fatalf("bad: %d\n\n%s", code, ui.ErrorWriter.String())
+	}
+
+	plan := testReadPlan(t, outPath)
+	expected, err := configHash(&terraform.Plan{
+		Module: testModule(t, "plan"),
+		Vars:   plan.Vars,
+	})
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	if plan.ConfigHash == "" || plan.ConfigHash != expected {
+		t.Fatalf("bad: %q", plan.ConfigHash)
+	}
+}
+
+func TestPlan_replaceMissing(t *testing.T) {
+	statePath := testStateFile(t, testState())
+
+	p := testProvider()
+	ui := new(cli.MockUi)
+	c := &PlanCommand{
+		Meta: Meta{
+			ContextOpts: testCtxConfig(p),
+			Ui:          ui,
+		},
+	}
+
+	args := []string{
+		"-state", statePath,
+		"-replace", "test_instance.nope",
+		testFixturePath("plan"),
+	}
+	if code := c.Run(args); code != 1 {
+		t.Fatalf("bad: %d\n\n%s", code, ui.OutputWriter.String())
+	}
+}
+
+func TestPlan_filter(t *testing.T) {
+	p := testProvider()
+	ui := new(cli.MockUi)
+	c := &PlanCommand{
+		Meta: Meta{
+			ContextOpts: testCtxConfig(p),
+			Ui:          ui,
+		},
+	}
+
+	p.DiffReturn = &terraform.InstanceDiff{
+		Attributes: map[string]*terraform.ResourceAttrDiff{
+			"ami": &terraform.ResourceAttrDiff{
+				New: "bar",
+			},
+		},
+	}
+
+	args := []string{
+		"-filter-type", "aws_*",
+		testFixturePath("plan"),
+	}
+	if code := c.Run(args); code != 0 {
+		t.Fatalf("bad: %d\n\n%s", code, ui.ErrorWriter.String())
+	}
+
+	output := ui.OutputWriter.String()
+	if strings.Contains(output, "test_instance.foo") {
+		t.Fatalf("bad: %s", output)
+	}
+	if !strings.Contains(output, "match the filters") {
+		t.Fatalf("bad: %s", output)
+	}
+}
+
+func TestPlan_filterBad(t *testing.T) {
+	p := testProvider()
+	ui := new(cli.MockUi)
+	c := &PlanCommand{
+		Meta: Meta{
+			ContextOpts: testCtxConfig(p),
+			Ui:          ui,
+		},
+	}
+
+	args := []string{
+		"-only", "create,delete",
+		testFixturePath("plan"),
+	}
+	if code := c.Run(args); code != 1 {
+		t.Fatalf("bad: %d\n\n%s", code, ui.OutputWriter.String())
+	}
+	if p.DiffCalled {
+		t.Fatal("diff should not be called")
+	}
+}
+
 func TestPlan_stateDefault(t *testing.T) {
 	originalState := testState()
 
@@ -617,10 +899,58 @@ func TestPlan_detailedExitcode_emptyDiff(t *testing.T) {
 	}
 }
 
+func TestPlan_detailedExitcode_error(t *testing.T) {
+	p := testProvider()
+	ui := new(cli.MockUi)
+	c := &PlanCommand{
+		Meta: Meta{
+			ContextOpts: testCtxConfig(p),
+			Ui:          ui,
+		},
+	}
+
+	args := []string{
+		"-detailed-exitcode",
+		testFixturePath("apply-config-invalid"),
+	}
+	if code := c.Run(args); code != 1 {
+		t.Fatalf("bad: %d\n\n%s", code, ui.OutputWriter.String())
+	}
+}
+
+func TestPlan_detailedExitcode_pwdError(t *testing.T) {
+	defer testCwdRemoved(t)()
+
+	p := testProvider()
+	ui := new(cli.MockUi)
+	c := &PlanCommand{
+		Meta: Meta{
+			ContextOpts: testCtxConfig(p),
+			Ui:          ui,
+		},
+	}
+
+	args := []string{"-detailed-exitcode"}
+	if code := c.Run(args); code != 1 {
+		t.Fatalf("bad: %d\n\n%s", code, ui.OutputWriter.String())
+	}
+
+	// Nothing else must be tried without a working directory
+	output := ui.ErrorWriter.String()
+	if !strings.HasPrefix(output, "Error getting pwd") || strings.Count(output, "\n") != 1 {
+		t.Fatalf("bad: %s", output)
+	}
+}
+
 const planVarFile = `
 foo = "bar"
 `
 
+const testPlanReplaceStateStr = `
+test_instance.foo:
+  ID = bar
+`
+
 const testPlanBackupStr = `
 ID = bar
 `
@@ -640,3 +970,40 @@ ID = bar
 const testPlanStateDefaultStr = `
 ID = bar
 `
+
+func TestPlan_json(t *testing.T) {
+	p := testProvider()
+	ui := new(cli.MockUi)
+	c := &PlanCommand{
+		Meta: Meta{
+			ContextOpts: testCtxConfig(p),
+			Ui:          ui,
+		},
+	}
+
+	args := []string{
+		"-json",
+		"-detailed-exitcode",
+		"-state", testTempFile(t),
+		testFixturePath("plan"),
+	}
+	if code := c.Run(args); code != 2 {
+		t.Fatalf("bad: %d\n\n%s", code, ui.ErrorWriter.String())
+	}
+
+	events := testJSONEvents(t, ui.OutputWriter.String())
+	if len(events) < 2 {
+		t.Fatalf("bad: %s", ui.OutputWriter.String())
+	}
+
+	change := events[len(events)-2]
+	if change.Type != JSONEventPlannedChange ||
+		change.Address != "test_instance.foo" || change.Action != "create" {
+		t.Fatalf("bad: %#v", change)
+	}
+
+	summary := events[len(events)-1]
+	if summary.Type != JSONEventChangeSummary || summary.Changes.Add != 1 {
+		t.Fatalf("bad: %#v", summary)
+	}
+}
@@ -0,0 +1,167 @@
+package command
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os/exec"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform/terraform"
+)
+
+// PolicyPlanVersion is the version of the plan format given to policy
+// checks. It is increased when the format changes incompatibly.
+const PolicyPlanVersion = 1
+
+// Policy are the policy checks that a plan must pass before it is
+// applied.
+//
+// Each check is an executable that is given the plan as JSON on stdin.
+// If it exits with a zero exit status the plan is allowed. Any other exit
+// status vetoes the apply, and the output of the check is shown to the
+// user as the reason.
+type Policy struct {
+	Checks []string
+}
+
+// PolicyViolation is a check that didn't allow a plan.
+type PolicyViolation struct {
+	Check    string
+	Messages []string
+}
+
+// policyPlan is the machine-readable plan given to the policy checks.
+type policyPlan struct {
+	Version   int               `json:"version"`
+	Resources []*policyResource `json:"resources"`
+}
+
+type policyResource struct {
+	Address    string                      `json:"address"`
+	Module     string                      `json:"module,omitempty"`
+	Type       string                      `json:"type"`
+	Name       string                      `json:"name"`
+	Action     string                      `json:"action"`
+	Attributes map[string]*policyAttribute `json:"attributes"`
+}
+
+type policyAttribute struct {
+	Old         string `json:"old"`
+	New         string `json:"new"`
+	Computed    bool   `json:"computed"`
+	Removed     bool   `json:"removed"`
+	RequiresNew bool   `json:"requires_new"`
+}
+
+// Check runs all the policy checks against the plan. It returns the
+// checks that didn't allow the plan, or an error if a check couldn't be
+// run at all.
+func (p *Policy) Check(plan *terraform.Plan) ([]*PolicyViolation, error) {
+	if p == nil || len(p.Checks) == 0 {
+		return nil, nil
+	}
+
+	input, err := json.MarshalIndent(policyPlanFromPlan(plan), "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("Error encoding plan for policy checks: %s", err)
+	}
+
+	var result []*PolicyViolation
+	for _, check := range p.Checks {
+		var output bytes.Buffer
+		cmd := exec.Command(check)
+		cmd.Stdin = bytes.NewReader(input)
+		cmd.Stdout = &output
+		cmd.Stderr = &output
+
+		err := cmd.Run()
+		if err == nil {
+			continue
+		}
+		if _, ok := err.(*exec.ExitError); !ok {
+			return nil, fmt.Errorf(
+				"Error running policy check %s: %s", check, err)
+		}
+
+		v := &PolicyViolation{Check: check}
+		for _, line := range strings.Split(output.String(), "\n") {
+			if line = strings.TrimSpace(line); line != "" {
+				v.Messages = append(v.Messages, line)
+			}
+		}
+		if len(v.Messages) == 0 {
+			v.Messages = []string{err.Error()}
+		}
+
+		result = append(result, v)
+	}
+
+	return result, nil
+}
+
+// policyPlanFromPlan converts a plan to the format given to the policy
+// checks. Resources are sorted by address so the output is stable.
+func policyPlanFromPlan(plan *terraform.Plan) *policyPlan {
+	result := &policyPlan{
+		Version:   PolicyPlanVersion,
+		Resources: make([]*policyResource, 0),
+	}
+	if plan == nil || plan.Diff == nil {
+		return result
+	}
+
+	for _, m := range plan.Diff.Modules {
+		for key, rdiff := range m.Resources {
+			var action string
+			switch rdiff.ChangeType() {
+			case terraform.DiffCreate:
+				action = "create"
+			case terraform.DiffUpdate:
+				action = "update"
+			case terraform.DiffDestroy:
+				action = "destroy"
+			case terraform.DiffDestroyCreate:
+				action = "replace"
+			default:
+				continue
+			}
+
+			r := &policyResource{
+				Address:    stateResourceAddr(m.Path, key),
+				Action:     action,
+				Attributes: make(map[string]*policyAttribute),
+			}
+			if len(m.Path) > 1 {
+				r.Module = "module." + strings.Join(m.Path[1:], ".")
+			}
+			parts := strings.SplitN(key, ".", 3)
+			r.Type = parts[0]
+			if len(parts) > 1 {
+				r.Name = parts[1]
+			}
+
+			for k, attr := range rdiff.Attributes {
+				r.Attributes[k] = &policyAttribute{
+					Old:         attr.Old,
+					New:         attr.New,
+					Computed:    attr.NewComputed,
+					Removed:     attr.NewRemoved,
+					RequiresNew: attr.RequiresNew,
+				}
+			}
+
+			result.Resources = append(result.Resources, r)
+		}
+	}
+
+	sort.Sort(policyResourceSlice(result.Resources))
+	return result
+}
+
+type policyResourceSlice []*policyResource
+
+func (s policyResourceSlice) Len() int           { return len(s) }
+func (s policyResourceSlice) Less(i, j int) bool { return s[i].Address < s[j].Address }
+func (s policyResourceSlice) Swap(i, j int)      { s[i], s[j] = s[j], s[i] }
@@ -0,0 +1,203 @@
+package command
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"path/filepath"
+	"reflect"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestPolicyCheck(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("policy check scripts require a shell")
+	}
+
+	allow := testPolicyCheck(t, "exit 0")
+	deny := testPolicyCheck(t, "echo 'no open ingress'\necho 'second' >&2\nexit 1")
+	silent := testPolicyCheck(t, "exit 2")
+
+	cases := []struct {
+		Checks   []string
+		Expected []*PolicyViolation
+	}{
+		{
+			nil,
+			nil,
+		},
+		{
+			[]string{allow},
+			nil,
+		},
+		{
+			[]string{allow, deny},
+			[]*PolicyViolation{
+				&PolicyViolation{
+					Check:    deny,
+					Messages: []string{"no open ingress", "second"},
+				},
+			},
+		},
+		{
+			[]string{silent},
+			[]*PolicyViolation{
+				&PolicyViolation{
+					Check:    silent,
+					Messages: []string{"exit status 2"},
+				},
+			},
+		},
+	}
+
+	for i, tc := range cases {
+		p := &Policy{Checks: tc.Checks}
+		actual, err := p.Check(&terraform.Plan{})
+		if err != nil {
+			t.Fatalf("%d: err: %s", i, err)
+		}
+		if !reflect.DeepEqual(actual, tc.Expected) {
+			t.Fatalf("%d: bad: %#v", i, actual)
+		}
+	}
+}
+
+func TestPolicyCheck_input(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("policy check scripts require a shell")
+	}
+
+	out := testTempFile(t)
+	check := testPolicyCheck(t, "cat > "+out)
+
+	plan := &terraform.Plan{
+		Diff: &terraform.Diff{
+			Modules: []*terraform.ModuleDiff{
+				&terraform.ModuleDiff{
+					Path: []string{"root"},
+					Resources: map[string]*terraform.InstanceDiff{
+						"aws_security_group.web": &terraform.InstanceDiff{
+							Attributes: map[string]*terraform.ResourceAttrDiff{
+								"ingress.0.cidr": &terraform.ResourceAttrDiff{
+									New: "0.0.0.0/0",
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	p := &Policy{Checks: []string{check}}
+	if _, err := p.Check(plan); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	data, err := ioutil.ReadFile(out)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	var actual policyPlan
+	if err := json.Unmarshal(data, &actual); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if !reflect.DeepEqual(&actual, policyPlanFromPlan(plan)) {
+		t.Fatalf("bad: %s", data)
+	}
+}
+
+func TestPolicyCheck_notFound(t *testing.T) {
+	p := &Policy{Checks: []string{filepath.Join(testTempDir(t), "nope")}}
+	if _, err := p.Check(&terraform.Plan{}); err == nil {
+		t.Fatal("should error")
+	}
+}
+
+func TestPolicyPlanFromPlan(t *testing.T) {
+	plan := &terraform.Plan{
+		Diff: &terraform.Diff{
+			Modules: []*terraform.ModuleDiff{
+				&terraform.ModuleDiff{
+					Path: []string{"root"},
+					Resources: map[string]*terraform.InstanceDiff{
+						"aws_instance.web.0": &terraform.InstanceDiff{
+							Attributes: map[string]*terraform.ResourceAttrDiff{
+								"ami": &terraform.ResourceAttrDiff{
+									Old:         "foo",
+									New:         "bar",
+									RequiresNew: true,
+								},
+							},
+							Destroy: true,
+						},
+						"aws_instance.db": &terraform.InstanceDiff{
+							Destroy: true,
+						},
+						"aws_instance.same": &terraform.InstanceDiff{},
+					},
+				},
+				&terraform.ModuleDiff{
+					Path: []string{"root", "child"},
+					Resources: map[string]*terraform.InstanceDiff{
+						"aws_eip.ip": &terraform.InstanceDiff{
+							Attributes: map[string]*terraform.ResourceAttrDiff{
+								"instance": &terraform.ResourceAttrDiff{
+									Old: "i-1",
+									New: "i-2",
+								},
+								"public_ip": &terraform.ResourceAttrDiff{
+									NewComputed: true,
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	actual := policyPlanFromPlan(plan)
+	if actual.Version != PolicyPlanVersion {
+		t.Fatalf("bad: %#v", actual)
+	}
+
+	var summary []string
+	for _, r := range actual.Resources {
+		summary = append(summary, strings.Join(
+			[]string{r.Address, r.Module, r.Type, r.Name, r.Action}, " "))
+	}
+	expected := []string{
+		"aws_instance.db  aws_instance db destroy",
+		"aws_instance.web.0  aws_instance web replace",
+		"module.child.aws_eip.ip module.child aws_eip ip update",
+	}
+	if !reflect.DeepEqual(summary, expected) {
+		t.Fatalf("bad: %#v", summary)
+	}
+
+	attr := actual.Resources[1].Attributes["ami"]
+	if attr.Old != "foo" || attr.New != "bar" || !attr.RequiresNew {
+		t.Fatalf("bad: %#v", attr)
+	}
+	attr = actual.Resources[2].Attributes["public_ip"]
+	if !attr.Computed {
+		t.Fatalf("bad: %#v", attr)
+	}
+}
+
+// testPolicyCheck writes a policy check script with the given body and
+// returns its path.
+func testPolicyCheck(t *testing.T, body string) string {
+	path := filepath.Join(testTempDir(t), "check")
+	script := "#!/bin/sh\n" + body + "\n"
+	if err := ioutil.WriteFile(path, []byte(script), 0755); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	return path
+}
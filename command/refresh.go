@@ -20,6 +20,9 @@ func (c *RefreshCommand) Run(args []string) int {
 	cmdFlags.StringVar(&c.Meta.statePath, "state", DefaultStateFilename, "path")
 	cmdFlags.StringVar(&c.Meta.stateOutPath, "state-out", "", "path")
 	cmdFlags.StringVar(&c.Meta.backupPath, "backup", "", "path")
+	cmdFlags.DurationVar(&c.Meta.stateLockTimeout, "lock-timeout", 0, "duration")
+	cmdFlags.IntVar(&c.Meta.parallelism, "parallelism", 0, "parallelism")
+	cmdFlags.IntVar(&c.Meta.providerParallelism, "provider-parallelism", 0, "parallelism")
 	cmdFlags.Usage = func() { c.Ui.Error(c.Help()) }
 	if err := cmdFlags.Parse(args); err != nil {
 		return 1
@@ -38,6 +41,7 @@ func (c *RefreshCommand) Run(args []string) int {
 		configPath, err = os.Getwd()
 		if err != nil {
 			c.Ui.Error(fmt.Sprintf("Error getting pwd: %s", err))
+			return 1
 		}
 	}
 
@@ -77,14 +81,17 @@ func (c *RefreshCommand) Run(args []string) int {
 	}
 
 	// Build the context based on the arguments given
+	defer c.unlockState()
 	ctx, _, err := c.Context(contextOpts{
 		Path:      configPath,
 		StatePath: c.Meta.statePath,
+		Lock:      "refresh",
 	})
 	if err != nil {
 		c.Ui.Error(err.Error())
 		return 1
 	}
+
 	if !validateContext(ctx, c.Ui) {
 		return 1
 	}
@@ -99,7 +106,7 @@ func (c *RefreshCommand) Run(args []string) int {
 		return 1
 	}
 
-	log.Printf("[INFO] Writing state output to: %s", c.Meta.StateOutPath())
+	log.Printf("[INFO] command: Writing state output to: %s", c.Meta.StateOutPath())
 	if err := c.Meta.PersistState(newState); err != nil {
 		c.Ui.Error(fmt.Sprintf("Error writing state file: %s", err))
 		return 1
@@ -127,8 +134,20 @@ Options:
 
   -input=true         Ask for input for variables if not directly set.
 
+  -lock-timeout=0s    How long to wait for the lock of the state if it is
+                      locked by another run, such as "5m". By default, the
+                      command fails right away.
+
   -no-color           If specified, output won't contain any color.
 
+  -parallelism=10     Limit the number of operations, such as
+                      refreshing a resource, that run at once.
+
+  -provider-parallelism=n
+                      Limit the number of operations of each provider
+                      that run at once, to stay below the rate limits
+                      of its API. Unlimited by default.
+
   -state=path         Path to read and save state (unless state-out
                       is specified). Defaults to "terraform.tfstate".
 
@@ -77,6 +77,86 @@ func TestRefresh_badState(t *testing.T) {
 	}
 }
 
+func TestRefresh_pwdError(t *testing.T) {
+	defer testCwdRemoved(t)()
+
+	ui := new(cli.MockUi)
+	c := &RefreshCommand{
+		Meta: Meta{
+			ContextOpts: testCtxConfig(testProvider()),
+			Ui:          ui,
+		},
+	}
+
+	if code := c.Run(nil); code != 1 {
+		t.Fatalf("bad: %d", code)
+	}
+
+	// Nothing else must be tried without a working directory
+	output := ui.ErrorWriter.String()
+	if !strings.HasPrefix(output, "Error getting pwd") || strings.Count(output, "\n") != 1 {
+		t.Fatalf("bad: %s", output)
+	}
+}
+
+func TestRefresh_parallelism(t *testing.T) {
+	state := testState()
+	statePath := testStateFile(t, state)
+
+	p := testProvider()
+	ui := new(cli.MockUi)
+	c := &RefreshCommand{
+		Meta: Meta{
+			ContextOpts: testCtxConfig(p),
+			Ui:          ui,
+		},
+	}
+
+	args := []string{
+		"-state", statePath,
+		"-parallelism", "2",
+		"-provider-parallelism", "1",
+		testFixturePath("refresh"),
+	}
+	if code := c.Run(args); code != 0 {
+		t.Fatalf("bad: %d\n\n%s", code, ui.ErrorWriter.String())
+	}
+	if !p.RefreshCalled {
+		t.Fatal("refresh should be called")
+	}
+
+	opts := c.contextOpts()
+	if opts.Parallelism != 2 || opts.ProviderParallelism != 1 {
+		t.Fatalf("bad: %d %d", opts.Parallelism, opts.ProviderParallelism)
+	}
+}
+
+func TestRefresh_parallelismNegative(t *testing.T) {
+	state := testState()
+	statePath := testStateFile(t, state)
+
+	p := testProvider()
+	ui := new(cli.MockUi)
+	c := &RefreshCommand{
+		Meta: Meta{
+			ContextOpts: testCtxConfig(p),
+			Ui:          ui,
+		},
+	}
+
+	args := []string{
+		"-state", statePath,
+		"-provider-parallelism", "-1",
+		testFixturePath("refresh"),
+	}
+	if code := c.Run(args); code != 1 {
+		t.Fatalf("bad: %d\n\n%s", code, ui.ErrorWriter.String())
+	}
+	if p.RefreshCalled {
+		t.Fatal("refresh should not be called")
+	}
+}
+
 func TestRefresh_cwd(t *testing.T) {
 	cwd, err := os.Getwd()
 	if err != nil {
@@ -507,6 +587,71 @@ func TestRefresh_backup(t *testing.T) {
 	}
 }
 
+func TestRefresh_backupDir(t *testing.T) {
+	state := testState()
+	statePath := testStateFile(t, state)
+
+	backupDir, err := ioutil.TempDir("", "tf")
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	defer os.RemoveAll(backupDir)
+
+	defer os.Setenv(BackupDirEnvVar, os.Getenv(BackupDirEnvVar))
+	os.Setenv(BackupDirEnvVar, backupDir)
+
+	p := testProvider()
+	ui := new(cli.MockUi)
+	c := &RefreshCommand{
+		Meta: Meta{
+			ContextOpts: testCtxConfig(p),
+			Ui:          ui,
+		},
+	}
+
+	p.RefreshFn = nil
+	p.RefreshReturn = &terraform.InstanceState{ID: "yes"}
+
+	args := []string{
+		"-state", statePath,
+		testFixturePath("refresh"),
+	}
+	if code := c.Run(args); code != 0 {
+		t.Fatalf("bad: %d\n\n%s", code, ui.ErrorWriter.String())
+	}
+
+	// The backup is in the directory, and not next to the state
+	if _, err := os.Stat(statePath + DefaultBackupExtention); err == nil {
+		t.Fatal("backup should not be next to the state")
+	}
+
+	matches, err := filepath.Glob(filepath.Join(
+		backupDir, filepath.Base(statePath)+".*"+DefaultBackupExtention))
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if len(matches) != 1 {
+		t.Fatalf("bad: %#v", matches)
+	}
+
+	f, err := os.Open(matches[0])
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	backupState, err := terraform.ReadState(f)
+	f.Close()
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	actualStr := strings.TrimSpace(backupState.String())
+	expectedStr := strings.TrimSpace(state.String())
+	if actualStr != expectedStr {
+		t.Fatalf("bad:\n\n%s\n\n%s", actualStr, expectedStr)
+	}
+}
+
 func TestRefresh_disableBackup(t *testing.T) {
 	state := testState()
 	statePath := testStateFile(t, state)
@@ -164,7 +164,7 @@ func (c *RemoteConfigCommand) disableRemoteState() int {
 
 	// Ensure we have the latest state before disabling
 	if c.conf.pullOnDisable {
-		log.Printf("[INFO] Refreshing local state from remote server")
+		log.Printf("[INFO] command: Refreshing local state from remote server")
 		if err := remote.RefreshState(); err != nil {
 			c.Ui.Error(fmt.Sprintf(
 				"Failed to refresh from remote state: %s", err))
@@ -176,7 +176,7 @@ func (c *RemoteConfigCommand) disableRemoteState() int {
 			c.Ui.Error(fmt.Sprintf("%s", change))
 			return 1
 		} else {
-			log.Printf("[INFO] %s", change)
+			log.Printf("[INFO] command: %s", change)
 		}
 	}
 
@@ -295,10 +295,10 @@ func (c *RemoteConfigCommand) enableRemoteState() int {
 	if backupPath != "-" {
 		// Provide default backup path if none provided
 		if backupPath == "" {
-			backupPath = c.conf.statePath + DefaultBackupExtention
+			backupPath = defaultBackupPath(c.conf.statePath)
 		}
 
-		log.Printf("[INFO] Writing backup state to: %s", backupPath)
+		log.Printf("[INFO] command: Writing backup state to: %s", backupPath)
 		backup := &state.LocalState{Path: backupPath}
 		if err := backup.WriteState(local.State()); err != nil {
 			c.Ui.Error(fmt.Sprintf("Error writing backup state file: %s", err))
@@ -324,7 +324,7 @@ func (c *RemoteConfigCommand) enableRemoteState() int {
 	}
 
 	// Remove the original, local state file
-	log.Printf("[INFO] Removing state file: %s", c.conf.statePath)
+	log.Printf("[INFO] command: Removing state file: %s", c.conf.statePath)
 	if err := os.Remove(c.conf.statePath); err != nil {
 		c.Ui.Error(fmt.Sprintf("Failed to remove state file '%s': %v",
 			c.conf.statePath, err))
@@ -18,11 +18,13 @@ type ShowCommand struct {
 
 func (c *ShowCommand) Run(args []string) int {
 	var moduleDepth int
+	var fullValues bool
 
 	args = c.Meta.process(args, false)
 
 	cmdFlags := flag.NewFlagSet("show", flag.ContinueOnError)
 	c.addModuleDepthFlag(cmdFlags, &moduleDepth)
+	cmdFlags.BoolVar(&fullValues, "full-values", false, "full-values")
 	cmdFlags.Usage = func() { c.Ui.Error(c.Help()) }
 	if err := cmdFlags.Parse(args); err != nil {
 		return 1
@@ -98,6 +100,7 @@ func (c *ShowCommand) Run(args []string) int {
 			Plan:        plan,
 			Color:       c.Colorize(),
 			ModuleDepth: moduleDepth,
+			FullValues:  fullValues,
 		}))
 		return 0
 	}
@@ -119,6 +122,10 @@ Usage: terraform show [options] [path]
 
 Options:
 
+  -full-values        Show the values of the attributes of a plan in full.
+                      By default, long values are shortened to the part
+                      that changes.
+
   -module-depth=n     Specifies the depth of modules to show in the output.
                       By default this is zero. -1 will expand all.
 
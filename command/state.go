@@ -5,6 +5,7 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/hashicorp/errwrap"
 	"github.com/hashicorp/terraform/state"
@@ -31,7 +32,8 @@ type StateOpts struct {
 
 	// BackupPath is the path where the backup will be placed. If not set,
 	// it is assumed to be the path where the state is stored locally
-	// plus the DefaultBackupExtension.
+	// plus the DefaultBackupExtension, or a path in the directory set
+	// with BackupDirEnvVar. If "-", no backup is made.
 	BackupPath string
 }
 
@@ -150,7 +152,7 @@ func State(opts *StateOpts) (*StateResult, error) {
 
 	// If we have a result, make sure to back it up
 	if result.State != nil {
-		backupPath := result.StatePath + DefaultBackupExtention
+		backupPath := defaultBackupPath(result.StatePath)
 		if opts.BackupPath != "" {
 			backupPath = opts.BackupPath
 		}
@@ -167,9 +169,12 @@ func State(opts *StateOpts) (*StateResult, error) {
 	return result, nil
 }
 
-// StateFromPlan gets our state from the plan.
+// StateFromPlan gets our state from the plan. remotePath is the path
+// where the remote state cache is stored if the plan has remote state.
+// backupPath is the path of the backup, as for StateOpts.
 func StateFromPlan(
-	localPath string, plan *terraform.Plan) (state.State, string, error) {
+	localPath, remotePath, backupPath string,
+	plan *terraform.Plan) (state.State, string, error) {
 	var result state.State
 	resultPath := localPath
 	if plan != nil && plan.State != nil &&
@@ -178,7 +183,7 @@ func StateFromPlan(
 
 		// It looks like we have a remote state in the plan, so
 		// we have to initialize that.
-		resultPath = filepath.Join(DefaultDataDir, DefaultStateFilename)
+		resultPath = remotePath
 		result, err = remoteState(plan.State, resultPath, false)
 		if err != nil {
 			return nil, "", err
@@ -192,14 +197,36 @@ func StateFromPlan(
 	}
 
 	// If we have a result, make sure to back it up
-	result = &state.BackupState{
-		Real: result,
-		Path: resultPath + DefaultBackupExtention,
+	if backupPath == "" {
+		backupPath = defaultBackupPath(resultPath)
+	}
+	if backupPath != "-" {
+		result = &state.BackupState{
+			Real: result,
+			Path: backupPath,
+		}
 	}
 
 	return result, resultPath, nil
 }
 
+// defaultBackupPath returns the path of the backup of the state at the
+// given path when no backup path is given: the state path plus the
+// DefaultBackupExtention, or a path in the directory set with
+// BackupDirEnvVar.
+func defaultBackupPath(statePath string) string {
+	dir := os.Getenv(BackupDirEnvVar)
+	if dir == "" {
+		return statePath + DefaultBackupExtention
+	}
+
+	return filepath.Join(dir, fmt.Sprintf(
+		"%s.%s%s",
+		filepath.Base(statePath),
+		time.Now().UTC().Format("20060102T150405Z"),
+		DefaultBackupExtention))
+}
+
 func remoteState(
 	local *terraform.State,
 	localPath string, refresh bool) (*state.CacheState, error) {
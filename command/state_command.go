@@ -0,0 +1,68 @@
+package command
+
+import (
+	"strings"
+)
+
+// StateCommand is a Command implementation that groups the commands
+// for working with the state directly.
+type StateCommand struct {
+	Meta
+}
+
+func (c *StateCommand) Run(argsRaw []string) int {
+	// Duplicate the args so we can munge them without affecting
+	// future subcommand invocations which will do the same.
+	args := make([]string, len(argsRaw))
+	copy(args, argsRaw)
+	args = c.Meta.process(args, false)
+
+	if len(args) == 0 {
+		c.Ui.Error(c.Help())
+		return 1
+	}
+
+	switch args[0] {
+	case "list":
+		cmd := &StateListCommand{Meta: c.Meta}
+		return cmd.Run(args[1:])
+	case "pull":
+		cmd := &StatePullCommand{Meta: c.Meta}
+		return cmd.Run(args[1:])
+	case "push":
+		cmd := &StatePushCommand{Meta: c.Meta}
+		return cmd.Run(args[1:])
+	case "replace-provider":
+		cmd := &StateReplaceProviderCommand{Meta: c.Meta}
+		return cmd.Run(args[1:])
+	case "rm-deposed":
+		cmd := &StateRmDeposedCommand{Meta: c.Meta}
+		return cmd.Run(args[1:])
+	default:
+		c.Ui.Error(c.Help())
+		return 1
+	}
+}
+
+func (c *StateCommand) Help() string {
+	helpText := `
+Usage: terraform state <subcommand> [options] [args]
+
+  Advanced commands for reading and writing the state directly, such as
+  to recover from a broken state or to migrate it to another backend.
+
+Available subcommands:
+
+  list                List the resources, or their deposed objects.
+  pull                Download the state and write it to stdout.
+  push                Upload a local state file, replacing the current state.
+  replace-provider    Move resources in the state to another provider.
+  rm-deposed          Destroy or forget a deposed object of a resource.
+
+`
+	return strings.TrimSpace(helpText)
+}
+
+func (c *StateCommand) Synopsis() string {
+	return "Advanced state management"
+}
@@ -0,0 +1,104 @@
+package command
+
+import (
+	"flag"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform/terraform"
+)
+
+// StateListCommand is a Command implementation that lists the resources
+// in the state.
+type StateListCommand struct {
+	Meta
+}
+
+func (c *StateListCommand) Run(args []string) int {
+	var deposed bool
+
+	args = c.Meta.process(args, false)
+	cmdFlags := flag.NewFlagSet("state list", flag.ContinueOnError)
+	cmdFlags.StringVar(&c.Meta.statePath, "state", DefaultStateFilename, "path")
+	cmdFlags.BoolVar(&deposed, "deposed", false, "deposed")
+	cmdFlags.Usage = func() { c.Ui.Error(c.Help()) }
+	if err := cmdFlags.Parse(args); err != nil {
+		return 1
+	}
+
+	s, err := c.State()
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Failed to load state: %s", err))
+		return 1
+	}
+
+	state := s.State()
+	if state == nil {
+		return 0
+	}
+
+	for _, line := range stateList(state, deposed) {
+		c.Ui.Output(line)
+	}
+
+	return 0
+}
+
+// stateList returns the addresses of the resources in the state, in
+// order. If deposed is true, it returns the deposed objects instead, as
+// "ADDRESS (deposed #INDEX): ID".
+func stateList(s *terraform.State, deposed bool) []string {
+	var result []string
+	for _, mod := range s.Modules {
+		keys := make([]string, 0, len(mod.Resources))
+		for k, _ := range mod.Resources {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+
+		for _, k := range keys {
+			addr := stateResourceAddr(mod.Path, k)
+			if !deposed {
+				result = append(result, addr)
+				continue
+			}
+
+			for i, is := range mod.Resources[k].Deposed {
+				result = append(result, fmt.Sprintf(
+					"%s (deposed #%d): %s", addr, i, is.ID))
+			}
+		}
+	}
+
+	return result
+}
+
+func (c *StateListCommand) Help() string {
+	helpText := `
+Usage: terraform state list [options]
+
+  List the resources in the state by their address, such as
+  "aws_instance.web" or "module.app.aws_instance.web.0".
+
+  With -deposed, list the deposed objects instead. An object is deposed
+  when a resource with create_before_destroy is replaced: the old object
+  is kept aside until its replacement is created, and then destroyed.
+  If the run fails in between, the deposed object is left in the state.
+  Deposed objects are listed with their index and ID, as used by
+  "terraform state rm-deposed".
+
+Options:
+
+  -deposed            List the deposed objects of the resources.
+
+  -state=path         Path to read the state from if remote state isn't
+                      enabled. Defaults to "terraform.tfstate".
+
+`
+	return strings.TrimSpace(helpText)
+}
+
+func (c *StateListCommand) Synopsis() string {
+	return "List the resources in the state"
+}
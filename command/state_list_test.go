@@ -0,0 +1,105 @@
+package command
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform/terraform"
+	"github.com/mitchellh/cli"
+)
+
+func TestStateList(t *testing.T) {
+	tmp, cwd := testCwd(t)
+	defer testFixCwd(t, tmp, cwd)
+
+	testStateFileDefault(t, testStateDeposed())
+
+	ui := new(cli.MockUi)
+	c := &StateListCommand{
+		Meta: Meta{
+			ContextOpts: testCtxConfig(testProvider()),
+			Ui:          ui,
+		},
+	}
+
+	if code := c.Run(nil); code != 0 {
+		t.Fatalf("bad: %d\n\n%s", code, ui.ErrorWriter.String())
+	}
+
+	actual := strings.TrimSpace(ui.OutputWriter.String())
+	expected := "test_instance.bar\ntest_instance.foo\nmodule.child.test_instance.baz"
+	if actual != expected {
+		t.Fatalf("bad:\n\n%s", actual)
+	}
+}
+
+func TestStateList_deposed(t *testing.T) {
+	tmp, cwd := testCwd(t)
+	defer testFixCwd(t, tmp, cwd)
+
+	testStateFileDefault(t, testStateDeposed())
+
+	ui := new(cli.MockUi)
+	c := &StateListCommand{
+		Meta: Meta{
+			ContextOpts: testCtxConfig(testProvider()),
+			Ui:          ui,
+		},
+	}
+
+	if code := c.Run([]string{"-deposed"}); code != 0 {
+		t.Fatalf("bad: %d\n\n%s", code, ui.ErrorWriter.String())
+	}
+
+	actual := strings.Split(strings.TrimSpace(ui.OutputWriter.String()), "\n")
+	expected := []string{
+		"test_instance.foo (deposed #0): old1",
+		"test_instance.foo (deposed #1): old2",
+		"module.child.test_instance.baz (deposed #0): old3",
+	}
+	if !reflect.DeepEqual(actual, expected) {
+		t.Fatalf("bad: %#v", actual)
+	}
+}
+
+// testStateDeposed returns a state with deposed objects in the root
+// module and in a child module.
+func testStateDeposed() *terraform.State {
+	return &terraform.State{
+		Modules: []*terraform.ModuleState{
+			&terraform.ModuleState{
+				Path: []string{"root"},
+				Resources: map[string]*terraform.ResourceState{
+					"test_instance.foo": &terraform.ResourceState{
+						Type: "test_instance",
+						Primary: &terraform.InstanceState{
+							ID: "bar",
+						},
+						Deposed: []*terraform.InstanceState{
+							&terraform.InstanceState{ID: "old1"},
+							&terraform.InstanceState{ID: "old2"},
+						},
+					},
+					"test_instance.bar": &terraform.ResourceState{
+						Type: "test_instance",
+						Primary: &terraform.InstanceState{
+							ID: "baz",
+						},
+					},
+				},
+			},
+			&terraform.ModuleState{
+				Path: []string{"root", "child"},
+				Resources: map[string]*terraform.ResourceState{
+					"test_instance.baz": &terraform.ResourceState{
+						Type: "test_instance",
+						Deposed: []*terraform.InstanceState{
+							&terraform.InstanceState{ID: "old3"},
+						},
+					},
+				},
+			},
+		},
+	}
+}
@@ -0,0 +1,67 @@
+package command
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform/terraform"
+)
+
+// StatePullCommand is a Command implementation that writes the current
+// state to stdout.
+type StatePullCommand struct {
+	Meta
+}
+
+func (c *StatePullCommand) Run(args []string) int {
+	args = c.Meta.process(args, false)
+	cmdFlags := flag.NewFlagSet("state pull", flag.ContinueOnError)
+	cmdFlags.StringVar(&c.Meta.statePath, "state", DefaultStateFilename, "path")
+	cmdFlags.Usage = func() { c.Ui.Error(c.Help()) }
+	if err := cmdFlags.Parse(args); err != nil {
+		return 1
+	}
+
+	// Loading the state refreshes it from the remote storage, if any
+	s, err := c.State()
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Failed to load state: %s", err))
+		return 1
+	}
+
+	state := s.State()
+	if state == nil {
+		return 0
+	}
+
+	var buf bytes.Buffer
+	if err := terraform.WriteState(state, &buf); err != nil {
+		c.Ui.Error(fmt.Sprintf("Failed to write state: %s", err))
+		return 1
+	}
+
+	c.Ui.Output(strings.TrimSuffix(buf.String(), "\n"))
+	return 0
+}
+
+func (c *StatePullCommand) Help() string {
+	helpText := `
+Usage: terraform state pull [options]
+
+  Download the latest state, from the remote storage if remote state is
+  enabled, and write it to stdout.
+
+Options:
+
+  -state=path         Path to read the state from if remote state isn't
+                      enabled. Defaults to "terraform.tfstate".
+
+`
+	return strings.TrimSpace(helpText)
+}
+
+func (c *StatePullCommand) Synopsis() string {
+	return "Download the state and write it to stdout"
+}
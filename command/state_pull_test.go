@@ -0,0 +1,61 @@
+package command
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform/terraform"
+	"github.com/mitchellh/cli"
+)
+
+func TestStatePull(t *testing.T) {
+	tmp, cwd := testCwd(t)
+	defer testFixCwd(t, tmp, cwd)
+
+	state := testState()
+	state.Lineage = "foo"
+	testStateFileDefault(t, state)
+
+	ui := new(cli.MockUi)
+	c := &StatePullCommand{
+		Meta: Meta{
+			ContextOpts: testCtxConfig(testProvider()),
+			Ui:          ui,
+		},
+	}
+
+	if code := c.Run(nil); code != 0 {
+		t.Fatalf("bad: %d\n\n%s", code, ui.ErrorWriter.String())
+	}
+
+	actual, err := terraform.ReadState(strings.NewReader(ui.OutputWriter.String()))
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if actual.Lineage != "foo" {
+		t.Fatalf("bad: %#v", actual)
+	}
+	if actual.String() != state.String() {
+		t.Fatalf("bad:\n\n%s", actual)
+	}
+}
+
+func TestStatePull_noState(t *testing.T) {
+	tmp, cwd := testCwd(t)
+	defer testFixCwd(t, tmp, cwd)
+
+	ui := new(cli.MockUi)
+	c := &StatePullCommand{
+		Meta: Meta{
+			ContextOpts: testCtxConfig(testProvider()),
+			Ui:          ui,
+		},
+	}
+
+	if code := c.Run(nil); code != 0 {
+		t.Fatalf("bad: %d\n\n%s", code, ui.ErrorWriter.String())
+	}
+	if ui.OutputWriter.Len() != 0 {
+		t.Fatalf("bad: %s", ui.OutputWriter.String())
+	}
+}
@@ -0,0 +1,146 @@
+package command
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/hashicorp/terraform/terraform"
+)
+
+// StatePushCommand is a Command implementation that replaces the current
+// state with a local state file.
+type StatePushCommand struct {
+	Meta
+}
+
+func (c *StatePushCommand) Run(args []string) int {
+	var force bool
+
+	args = c.Meta.process(args, false)
+	cmdFlags := flag.NewFlagSet("state push", flag.ContinueOnError)
+	cmdFlags.BoolVar(&force, "force", false, "force")
+	cmdFlags.StringVar(&c.Meta.statePath, "state", DefaultStateFilename, "path")
+	cmdFlags.Usage = func() { c.Ui.Error(c.Help()) }
+	if err := cmdFlags.Parse(args); err != nil {
+		return 1
+	}
+
+	args = cmdFlags.Args()
+	if len(args) != 1 {
+		c.Ui.Error("Expected a single argument: the path of the state to push.\n")
+		c.Ui.Error(c.Help())
+		return 1
+	}
+
+	// Read the state to push, "-" being stdin
+	var r io.Reader = os.Stdin
+	if args[0] != "-" {
+		f, err := os.Open(args[0])
+		if err != nil {
+			c.Ui.Error(fmt.Sprintf("Failed to open %s: %s", args[0], err))
+			return 1
+		}
+		defer f.Close()
+
+		r = f
+	}
+
+	src, err := terraform.ReadState(r)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Failed to read %s: %s", args[0], err))
+		return 1
+	}
+
+	// Load the current state, which refreshes it from the remote
+	// storage, if any.
+	s, err := c.State()
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Failed to load state: %s", err))
+		return 1
+	}
+
+	unlock, err := c.lockState("state push")
+	if err != nil {
+		c.Ui.Error(err.Error())
+		return 1
+	}
+	defer unlock()
+
+	dst := s.State()
+	if dst != nil && !force {
+		if err := statePushCheck(src, dst); err != nil {
+			c.Ui.Error(fmt.Sprintf(
+				"Failed to push state: %s\n\n"+
+					"Use -force to push the state anyway.", err))
+			return 1
+		}
+	}
+
+	// The pushed state replaces the state in the current storage, so
+	// it keeps the current remote state settings.
+	src.Remote = nil
+	if dst != nil {
+		src.Remote = dst.Remote
+	}
+
+	if err := s.WriteState(src); err != nil {
+		c.Ui.Error(fmt.Sprintf("Failed to write state: %s", err))
+		return 1
+	}
+	if err := s.PersistState(); err != nil {
+		c.Ui.Error(fmt.Sprintf("Failed to persist state: %s", err))
+		return 1
+	}
+
+	return 0
+}
+
+// statePushCheck checks that src can safely replace dst: it must be a
+// version of the same state, and not older than dst.
+func statePushCheck(src, dst *terraform.State) error {
+	if src.Lineage != "" && dst.Lineage != "" && src.Lineage != dst.Lineage {
+		return fmt.Errorf(
+			"the state has lineage %q, but the current state has lineage %q.\n"+
+				"They are not versions of the same state.",
+			src.Lineage, dst.Lineage)
+	}
+
+	if src.Serial < dst.Serial {
+		return fmt.Errorf(
+			"the state has serial %d, but the current state has the higher\n"+
+				"serial %d. Pushing it would overwrite newer changes.",
+			src.Serial, dst.Serial)
+	}
+
+	return nil
+}
+
+func (c *StatePushCommand) Help() string {
+	helpText := `
+Usage: terraform state push [options] PATH
+
+  Upload the local state file at PATH, replacing the current state.
+  If remote state is enabled, the state is uploaded to the remote
+  storage. If PATH is "-", the state is read from stdin.
+
+  The state is only pushed if it is a version of the current state, as
+  determined by its lineage, and its serial is not lower than that of
+  the current state, so that newer changes aren't overwritten.
+
+Options:
+
+  -force              Push the state even if the safety checks fail.
+
+  -state=path         Path of the state to replace if remote state isn't
+                      enabled. Defaults to "terraform.tfstate".
+
+`
+	return strings.TrimSpace(helpText)
+}
+
+func (c *StatePushCommand) Synopsis() string {
+	return "Upload a local state file, replacing the current state"
+}
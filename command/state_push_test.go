@@ -0,0 +1,123 @@
+package command
+
+import (
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform/terraform"
+	"github.com/mitchellh/cli"
+)
+
+func TestStatePush(t *testing.T) {
+	tmp, cwd := testCwd(t)
+	defer testFixCwd(t, tmp, cwd)
+
+	current := testState()
+	current.Serial = 2
+	current.Lineage = "foo"
+	testStateFileDefault(t, current)
+
+	// A newer version of the same state
+	pushed := testState()
+	pushed.Serial = 3
+	pushed.Lineage = "foo"
+	pushed.RootModule().Resources["test_instance.foo"].Primary.ID = "pushed"
+	path := testStateFile(t, pushed)
+
+	ui := new(cli.MockUi)
+	c := &StatePushCommand{
+		Meta: Meta{
+			ContextOpts: testCtxConfig(testProvider()),
+			Ui:          ui,
+		},
+	}
+
+	if code := c.Run([]string{path}); code != 0 {
+		t.Fatalf("bad: %d\n\n%s", code, ui.ErrorWriter.String())
+	}
+
+	actual := testStatePushRead(t)
+	if actual.RootModule().Resources["test_instance.foo"].Primary.ID != "pushed" {
+		t.Fatalf("bad:\n\n%s", actual)
+	}
+}
+
+func TestStatePush_checks(t *testing.T) {
+	cases := map[string]func(current, pushed *terraform.State){
+		"older serial": func(current, pushed *terraform.State) {
+			current.Serial = 5
+			current.Lineage = "foo"
+			pushed.Serial = 4
+			pushed.Lineage = "foo"
+		},
+		"different lineage": func(current, pushed *terraform.State) {
+			current.Serial = 1
+			current.Lineage = "current"
+			pushed.Serial = 2
+			pushed.Lineage = "other"
+		},
+	}
+
+	for name, setup := range cases {
+		tmp, cwd := testCwd(t)
+
+		current := testState()
+		pushed := testState()
+		setup(current, pushed)
+		pushed.RootModule().Resources["test_instance.foo"].Primary.ID = "pushed"
+		testStateFileDefault(t, current)
+		path := testStateFile(t, pushed)
+
+		// The push is refused...
+		ui := new(cli.MockUi)
+		c := &StatePushCommand{
+			Meta: Meta{
+				ContextOpts: testCtxConfig(testProvider()),
+				Ui:          ui,
+			},
+		}
+		if code := c.Run([]string{path}); code != 1 {
+			t.Fatalf("%s: bad: %d", name, code)
+		}
+		actual := testStatePushRead(t)
+		if actual.RootModule().Resources["test_instance.foo"].Primary.ID != "bar" {
+			t.Fatalf("%s: bad:\n\n%s", name, actual)
+		}
+
+		// ...unless it is forced
+		ui = new(cli.MockUi)
+		c = &StatePushCommand{
+			Meta: Meta{
+				ContextOpts: testCtxConfig(testProvider()),
+				Ui:          ui,
+			},
+		}
+		if code := c.Run([]string{"-force", path}); code != 0 {
+			t.Fatalf("%s: bad: %d\n\n%s", name, code, ui.ErrorWriter.String())
+		}
+		actual = testStatePushRead(t)
+		if actual.RootModule().Resources["test_instance.foo"].Primary.ID != "pushed" {
+			t.Fatalf("%s: bad:\n\n%s", name, actual)
+		}
+		if actual.Serial <= current.Serial {
+			t.Fatalf("%s: serial should be increased: %d", name, actual.Serial)
+		}
+
+		testFixCwd(t, tmp, cwd)
+	}
+}
+
+func testStatePushRead(t *testing.T) *terraform.State {
+	f, err := os.Open(DefaultStateFilename)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	defer f.Close()
+
+	s, err := terraform.ReadState(f)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	return s
+}
@@ -0,0 +1,242 @@
+package command
+
+import (
+	"fmt"
+	"log"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform/terraform"
+)
+
+// validProviderName matches a provider name, optionally with an alias,
+// such as "aws" or "aws.west".
+var validProviderName = regexp.MustCompile(`^[a-z0-9]+(\.[A-Za-z0-9_-]+)?$`)
+
+// StateReplaceProviderCommand is a Command implementation that moves
+// resources in the state from one provider to another.
+type StateReplaceProviderCommand struct {
+	Meta
+}
+
+func (c *StateReplaceProviderCommand) Run(args []string) int {
+	args = c.Meta.process(args, false)
+
+	cmdFlags := c.Meta.flagSet("state replace-provider")
+	cmdFlags.StringVar(&c.Meta.statePath, "state", DefaultStateFilename, "path")
+	cmdFlags.StringVar(&c.Meta.stateOutPath, "state-out", "", "path")
+	cmdFlags.StringVar(&c.Meta.backupPath, "backup", "", "path")
+	cmdFlags.Usage = func() { c.Ui.Error(c.Help()) }
+	if err := cmdFlags.Parse(args); err != nil {
+		return 1
+	}
+
+	args = cmdFlags.Args()
+	if len(args) != 2 {
+		c.Ui.Error("Expected two arguments: the provider to replace and its replacement.\n")
+		c.Ui.Error(c.Help())
+		return 1
+	}
+
+	from, to := args[0], args[1]
+	for _, p := range []string{from, to} {
+		if !validProviderName.MatchString(p) {
+			c.Ui.Error(fmt.Sprintf(
+				"Invalid provider %q. Providers are given as NAME or NAME.ALIAS.", p))
+			return 1
+		}
+	}
+
+	state, err := c.State()
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Failed to load state: %s", err))
+		return 1
+	}
+
+	unlock, err := c.lockState("state replace-provider")
+	if err != nil {
+		c.Ui.Error(err.Error())
+		return 1
+	}
+	defer unlock()
+
+	s := state.State()
+	if s.Empty() {
+		c.Ui.Error("The state is empty. There are no resources to move.")
+		return 1
+	}
+
+	result, moved, err := stateReplaceProvider(s, from, to)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Failed to replace provider: %s", err))
+		return 1
+	}
+	if len(moved) == 0 {
+		c.Ui.Output(fmt.Sprintf("No resources use the provider %s.", from))
+		return 0
+	}
+
+	log.Printf("[INFO] command: Writing state output to: %s", c.Meta.StateOutPath())
+	if err := c.Meta.PersistState(result); err != nil {
+		c.Ui.Error(fmt.Sprintf("Error writing state file: %s", err))
+		return 1
+	}
+
+	for _, m := range moved {
+		c.Ui.Output(m)
+	}
+	c.Ui.Output(fmt.Sprintf(
+		"\nMoved %d resource(s) from the provider %s to %s.", len(moved), from, to))
+	return 0
+}
+
+// stateReplaceProvider returns a copy of the state where every resource
+// connected to the provider from is connected to the provider to
+// instead, along with a description of each resource that was moved.
+//
+// If the provider name changes, and not only the alias, the resource
+// types are renamed as well: "oldcloud_instance" becomes
+// "newcloud_instance" when moving from "oldcloud" to "newcloud", and the
+// dependencies on the renamed resources are updated. The state is left
+// untouched if a renamed resource would collide with an existing one.
+func stateReplaceProvider(
+	s *terraform.State, from, to string) (*terraform.State, []string, error) {
+	fromName := strings.SplitN(from, ".", 2)[0]
+	toName := strings.SplitN(to, ".", 2)[0]
+
+	result := s.DeepCopy()
+	var moved []string
+	for _, mod := range result.Modules {
+		// renames maps the "TYPE.NAME" prefix of renamed resources
+		// to their new prefix, for updating dependencies.
+		renames := make(map[string]string)
+		resources := make(map[string]*terraform.ResourceState)
+
+		// Sort the keys so that collisions are reported consistently
+		keys := make([]string, 0, len(mod.Resources))
+		for k, _ := range mod.Resources {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+
+		for _, k := range keys {
+			rs := mod.Resources[k]
+			key := k
+
+			provider := rs.Provider
+			if provider == "" {
+				provider = strings.SplitN(rs.Type, "_", 2)[0]
+			}
+
+			if provider == from {
+				oldType := rs.Type
+				if fromName != toName {
+					if !strings.HasPrefix(rs.Type, fromName+"_") {
+						return nil, nil, fmt.Errorf(
+							"%s has the type %s, which doesn't belong to the "+
+								"provider %s, so it can't be renamed for %s",
+							stateResourceAddr(mod.Path, k), rs.Type, fromName, toName)
+					}
+
+					rs.Type = toName + strings.TrimPrefix(rs.Type, fromName)
+					key = rs.Type + strings.TrimPrefix(k, oldType)
+
+					parts := strings.SplitN(k, ".", 3)
+					renames[strings.Join(parts[:2], ".")] = strings.Join(
+						strings.SplitN(key, ".", 3)[:2], ".")
+				}
+
+				// Resources connected to the default provider for their
+				// type don't store it.
+				rs.Provider = to
+				if to == strings.SplitN(rs.Type, "_", 2)[0] {
+					rs.Provider = ""
+				}
+
+				moved = append(moved, fmt.Sprintf(
+					"%s: %s -> %s",
+					stateResourceAddr(mod.Path, k), provider, to))
+				if key != k {
+					moved[len(moved)-1] += fmt.Sprintf(
+						" (renamed to %s)", stateResourceAddr(mod.Path, key))
+				}
+			}
+
+			if _, ok := resources[key]; ok {
+				return nil, nil, fmt.Errorf(
+					"moving the resources would leave two resources "+
+						"with the address %s", stateResourceAddr(mod.Path, key))
+			}
+			resources[key] = rs
+		}
+		mod.Resources = resources
+
+		if len(renames) == 0 {
+			continue
+		}
+		for _, rs := range mod.Resources {
+			for i, dep := range rs.Dependencies {
+				for old, new := range renames {
+					if dep == old || strings.HasPrefix(dep, old+".") {
+						rs.Dependencies[i] = new + strings.TrimPrefix(dep, old)
+						break
+					}
+				}
+			}
+		}
+	}
+
+	return result, moved, nil
+}
+
+// stateResourceAddr returns the address of the resource with the given
+// key in the module with the given path for display, such as
+// "module.foo.aws_instance.bar".
+func stateResourceAddr(path []string, key string) string {
+	addr, err := terraform.ParseResourceAddress(key)
+	if err != nil {
+		// Not the key of a resource, so there is nothing better to show
+		return key
+	}
+	addr.Path = path[1:]
+
+	return addr.String()
+}
+
+func (c *StateReplaceProviderCommand) Help() string {
+	helpText := `
+Usage: terraform state replace-provider [options] FROM TO
+
+  Move the resources in the state that are connected to the provider
+  FROM to the provider TO. Providers are given as NAME or NAME.ALIAS,
+  such as "aws" or "aws.west".
+
+  If only the alias changes, the resources are simply connected to the
+  new provider configuration. If the provider name changes, the type of
+  each resource is renamed too, for example "oldcloud_instance" becomes
+  "newcloud_instance" when replacing "oldcloud" with "newcloud".
+
+  This command doesn't change the configuration, which must be updated
+  to match, and it will not modify your infrastructure. It can be undone
+  by reverting the state backup file that is created.
+
+Options:
+
+  -backup=path        Path to backup the existing state file before
+                      modifying. Defaults to the "-state-out" path with
+                      ".backup" extension. Set to "-" to disable backup.
+
+  -state=path         Path to read and save state (unless state-out
+                      is specified). Defaults to "terraform.tfstate".
+
+  -state-out=path     Path to write updated state file. By default, the
+                      "-state" path will be used.
+
+`
+	return strings.TrimSpace(helpText)
+}
+
+func (c *StateReplaceProviderCommand) Synopsis() string {
+	return "Move resources in the state to another provider"
+}
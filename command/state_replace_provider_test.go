@@ -0,0 +1,219 @@
+package command
+
+import (
+	"reflect"
+	"sort"
+	"testing"
+
+	"github.com/hashicorp/terraform/terraform"
+	"github.com/mitchellh/cli"
+)
+
+func TestStateReplaceProvider(t *testing.T) {
+	tmp, cwd := testCwd(t)
+	defer testFixCwd(t, tmp, cwd)
+
+	testStateFileDefault(t, testStateReplaceProviderState())
+
+	ui := new(cli.MockUi)
+	c := &StateReplaceProviderCommand{
+		Meta: Meta{
+			ContextOpts: testCtxConfig(testProvider()),
+			Ui:          ui,
+		},
+	}
+
+	if code := c.Run([]string{"aws", "aws.west"}); code != 0 {
+		t.Fatalf("bad: %d\n\n%s", code, ui.ErrorWriter.String())
+	}
+
+	actual := testStatePushRead(t)
+	resources := actual.RootModule().Resources
+	if resources["aws_instance.foo"].Provider != "aws.west" {
+		t.Fatalf("bad: %#v", resources["aws_instance.foo"])
+	}
+	if resources["aws_instance.bar"].Provider != "aws.west" {
+		t.Fatalf("bad: %#v", resources["aws_instance.bar"])
+	}
+	if resources["do_droplet.foo"].Provider != "" {
+		t.Fatalf("bad: %#v", resources["do_droplet.foo"])
+	}
+}
+
+func TestStateReplaceProvider_badArgs(t *testing.T) {
+	cases := [][]string{
+		nil,
+		[]string{"aws"},
+		[]string{"aws", "aws west"},
+		[]string{"aws", "aws.west", "extra"},
+	}
+
+	for i, args := range cases {
+		ui := new(cli.MockUi)
+		c := &StateReplaceProviderCommand{
+			Meta: Meta{
+				ContextOpts: testCtxConfig(testProvider()),
+				Ui:          ui,
+			},
+		}
+
+		if code := c.Run(args); code != 1 {
+			t.Fatalf("%d: bad: %d", i, code)
+		}
+	}
+}
+
+func TestStateReplaceProvider_func(t *testing.T) {
+	cases := []struct {
+		From, To  string
+		Resources map[string]string
+		Moved     int
+	}{
+		// Moving to an alias
+		{
+			"aws", "aws.west",
+			map[string]string{
+				"aws_instance.foo":   "aws.west",
+				"aws_instance.bar":   "aws.west",
+				"aws_instance.baz.0": "aws.east",
+				"do_droplet.foo":     "",
+			},
+			2,
+		},
+
+		// Moving back to the default provider
+		{
+			"aws.east", "aws",
+			map[string]string{
+				"aws_instance.foo":   "",
+				"aws_instance.bar":   "aws",
+				"aws_instance.baz.0": "",
+				"do_droplet.foo":     "",
+			},
+			1,
+		},
+
+		// Renaming the provider renames the types
+		{
+			"do", "digitalocean",
+			map[string]string{
+				"aws_instance.foo":         "",
+				"aws_instance.bar":         "aws",
+				"aws_instance.baz.0":       "aws.east",
+				"digitalocean_droplet.foo": "",
+			},
+			1,
+		},
+
+		// Nothing to move
+		{
+			"google", "google.west",
+			map[string]string{
+				"aws_instance.foo":   "",
+				"aws_instance.bar":   "aws",
+				"aws_instance.baz.0": "aws.east",
+				"do_droplet.foo":     "",
+			},
+			0,
+		},
+	}
+
+	for i, tc := range cases {
+		s := testStateReplaceProviderState()
+		actual, moved, err := stateReplaceProvider(s, tc.From, tc.To)
+		if err != nil {
+			t.Fatalf("%d: err: %s", i, err)
+		}
+
+		if len(moved) != tc.Moved {
+			t.Fatalf("%d: bad: %#v", i, moved)
+		}
+
+		resources := make(map[string]string)
+		for k, rs := range actual.RootModule().Resources {
+			resources[k] = rs.Provider
+		}
+		if !reflect.DeepEqual(resources, tc.Resources) {
+			t.Fatalf("%d: bad: %#v", i, resources)
+		}
+
+		// The original state is untouched
+		if !s.Equal(testStateReplaceProviderState()) {
+			t.Fatalf("%d: state was modified", i)
+		}
+	}
+}
+
+func TestStateReplaceProvider_dependencies(t *testing.T) {
+	s := testStateReplaceProviderState()
+	s.RootModule().Resources["aws_instance.foo"].Dependencies = []string{
+		"aws_instance.bar",
+		"do_droplet.foo",
+		"do_droplet.foo.0",
+		"do_droplet.foobar",
+	}
+
+	actual, _, err := stateReplaceProvider(s, "do", "digitalocean")
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	deps := actual.RootModule().Resources["aws_instance.foo"].Dependencies
+	sort.Strings(deps)
+	expected := []string{
+		"aws_instance.bar",
+		"digitalocean_droplet.foo",
+		"digitalocean_droplet.foo.0",
+		"do_droplet.foobar",
+	}
+	if !reflect.DeepEqual(deps, expected) {
+		t.Fatalf("bad: %#v", deps)
+	}
+
+	rs := actual.RootModule().Resources["digitalocean_droplet.foo"]
+	if rs == nil || rs.Type != "digitalocean_droplet" {
+		t.Fatalf("bad: %#v", rs)
+	}
+}
+
+func TestStateReplaceProvider_collision(t *testing.T) {
+	s := testStateReplaceProviderState()
+	s.RootModule().Resources["digitalocean_droplet.foo"] = &terraform.ResourceState{
+		Type:    "digitalocean_droplet",
+		Primary: &terraform.InstanceState{ID: "other"},
+	}
+
+	if _, _, err := stateReplaceProvider(s, "do", "digitalocean"); err == nil {
+		t.Fatal("should error")
+	}
+}
+
+func testStateReplaceProviderState() *terraform.State {
+	return &terraform.State{
+		Modules: []*terraform.ModuleState{
+			&terraform.ModuleState{
+				Path: []string{"root"},
+				Resources: map[string]*terraform.ResourceState{
+					"aws_instance.foo": &terraform.ResourceState{
+						Type:    "aws_instance",
+						Primary: &terraform.InstanceState{ID: "foo"},
+					},
+					"aws_instance.bar": &terraform.ResourceState{
+						Type:     "aws_instance",
+						Provider: "aws",
+						Primary:  &terraform.InstanceState{ID: "bar"},
+					},
+					"aws_instance.baz.0": &terraform.ResourceState{
+						Type:     "aws_instance",
+						Provider: "aws.east",
+						Primary:  &terraform.InstanceState{ID: "baz"},
+					},
+					"do_droplet.foo": &terraform.ResourceState{
+						Type:    "do_droplet",
+						Primary: &terraform.InstanceState{ID: "droplet"},
+					},
+				},
+			},
+		},
+	}
+}
@@ -0,0 +1,271 @@
+package command
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform/config/module"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+// StateRmDeposedCommand is a Command implementation that destroys or
+// forgets a deposed object of a resource in the state.
+type StateRmDeposedCommand struct {
+	Meta
+}
+
+func (c *StateRmDeposedCommand) Run(args []string) int {
+	var forget, force bool
+
+	args = c.Meta.process(args, true)
+	cmdFlags := c.Meta.flagSet("state rm-deposed")
+	cmdFlags.BoolVar(&forget, "forget", false, "forget")
+	cmdFlags.BoolVar(&force, "force", false, "force")
+	cmdFlags.StringVar(&c.Meta.statePath, "state", DefaultStateFilename, "path")
+	cmdFlags.StringVar(&c.Meta.stateOutPath, "state-out", "", "path")
+	cmdFlags.StringVar(&c.Meta.backupPath, "backup", "", "path")
+	cmdFlags.Usage = func() { c.Ui.Error(c.Help()) }
+	if err := cmdFlags.Parse(args); err != nil {
+		return 1
+	}
+
+	args = cmdFlags.Args()
+	if len(args) != 2 {
+		c.Ui.Error("Expected two arguments: the resource address and the index\n" +
+			"of the deposed object, as listed by \"terraform state list -deposed\".\n")
+		c.Ui.Error(c.Help())
+		return 1
+	}
+
+	addr := args[0]
+	parsed, err := terraform.ParseResourceAddress(addr)
+	if err == nil && parsed.InstanceType != terraform.TypePrimary {
+		err = fmt.Errorf("the address can't have an instance type")
+	}
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Invalid resource address %q: %s", addr, err))
+		return 1
+	}
+	index, err := strconv.Atoi(args[1])
+	if err != nil || index < 0 {
+		c.Ui.Error(fmt.Sprintf("Invalid index %q: must be a number.", args[1]))
+		return 1
+	}
+
+	state, err := c.State()
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Failed to load state: %s", err))
+		return 1
+	}
+
+	unlock, err := c.lockState("state rm-deposed")
+	if err != nil {
+		c.Ui.Error(err.Error())
+		return 1
+	}
+	defer unlock()
+
+	s := state.State()
+	if s == nil {
+		s = terraform.NewState()
+	}
+	path, key := parsed.ModulePath(), parsed.StateKey()
+	is := stateDeposed(s, path, key, index)
+	if is == nil {
+		c.Ui.Error(fmt.Sprintf(
+			"%s has no deposed object #%d. The deposed objects are listed\n"+
+				"by \"terraform state list -deposed\".", addr, index))
+		return 1
+	}
+
+	if !forget {
+		if !force {
+			v, err := c.UIInput().Input(&terraform.InputOpts{
+				Id: "destroy",
+				Query: fmt.Sprintf(
+					"Do you really want to destroy %s (deposed #%d): %s?",
+					addr, index, is.ID),
+				Description: "Terraform will delete the deposed object.\n" +
+					"There is no undo. Only 'yes' will be accepted to confirm.",
+			})
+			if err != nil {
+				c.Ui.Error(fmt.Sprintf("Error asking for confirmation: %s", err))
+				return 1
+			}
+			if v != "yes" {
+				c.Ui.Output("Destroy cancelled.")
+				return 1
+			}
+		}
+
+		if err := c.destroyDeposed(s, path, key, index); err != nil {
+			c.Ui.Error(err.Error())
+			return 1
+		}
+	}
+
+	result := s.DeepCopy()
+	stateRemoveDeposed(result, path, key, index)
+
+	log.Printf("[INFO] command: Writing state output to: %s", c.Meta.StateOutPath())
+	if err := c.Meta.PersistState(result); err != nil {
+		c.Ui.Error(fmt.Sprintf("Error writing state file: %s", err))
+		return 1
+	}
+
+	if forget {
+		c.Ui.Output(fmt.Sprintf(
+			"Removed %s (deposed #%d): %s from the state. The object itself\n"+
+				"still exists, and is no longer managed by Terraform.",
+			addr, index, is.ID))
+	} else {
+		c.Ui.Output(fmt.Sprintf(
+			"Destroyed %s (deposed #%d): %s.", addr, index, is.ID))
+	}
+
+	return 0
+}
+
+// destroyDeposed destroys the deposed object with the given index of the
+// resource with the given key in the module with the given path, using
+// the providers of the configuration in the working directory.
+//
+// This is an apply without any changes on a copy of the state in which
+// the deposed object is the only object to destroy. Apply always
+// destroys the deposed and tainted objects in the state, but doesn't
+// touch anything else unless there is a diff for it.
+func (c *StateRmDeposedCommand) destroyDeposed(
+	s *terraform.State, path []string, key string, index int) error {
+	pwd, err := os.Getwd()
+	if err != nil {
+		return fmt.Errorf("Error getting pwd: %s", err)
+	}
+
+	mod, err := c.loadModule(pwd, module.GetModeNone)
+	if err != nil {
+		return err
+	}
+
+	target := s.DeepCopy()
+	rs := target.ModuleByPath(path).Resources[key]
+	deposed := rs.Deposed[index : index+1]
+	for _, ms := range target.Modules {
+		for _, rs := range ms.Resources {
+			rs.Deposed = nil
+			rs.Tainted = nil
+		}
+	}
+	rs.Deposed = deposed
+
+	opts := c.contextOpts()
+	opts.Module = mod
+	opts.State = target
+	opts.Diff = new(terraform.Diff)
+	ctx := terraform.NewContext(opts)
+	if err := ctx.Input(c.InputMode()); err != nil {
+		return fmt.Errorf("Error configuring: %s", err)
+	}
+	if !validateContext(ctx, c.Ui) {
+		return fmt.Errorf("Error validating the configuration.")
+	}
+
+	result, err := ctx.Apply()
+	if err != nil {
+		return fmt.Errorf("Error destroying the deposed object: %s", err)
+	}
+	if stateDeposed(result, path, key, 0) != nil {
+		return fmt.Errorf(
+			"Error destroying the deposed object: it is still in the state")
+	}
+
+	return nil
+}
+
+// stateDeposed returns the deposed object with the given index of the
+// resource with the given key in the module with the given path, or nil
+// if there is none.
+func stateDeposed(
+	s *terraform.State, path []string, key string, index int) *terraform.InstanceState {
+	mod := s.ModuleByPath(path)
+	if mod == nil {
+		return nil
+	}
+
+	rs, ok := mod.Resources[key]
+	if !ok || index >= len(rs.Deposed) {
+		return nil
+	}
+
+	return rs.Deposed[index]
+}
+
+// stateRemoveDeposed removes the deposed object with the given index of
+// the resource with the given key in the module with the given path, and
+// the resource itself if nothing else is left of it.
+func stateRemoveDeposed(s *terraform.State, path []string, key string, index int) {
+	mod := s.ModuleByPath(path)
+	rs := mod.Resources[key]
+	rs.Deposed = append(rs.Deposed[:index], rs.Deposed[index+1:]...)
+	if len(rs.Deposed) == 0 {
+		rs.Deposed = nil
+	}
+
+	if rs.Primary == nil && len(rs.Tainted) == 0 && len(rs.Deposed) == 0 {
+		delete(mod.Resources, key)
+	}
+}
+
+func (c *StateRmDeposedCommand) Help() string {
+	helpText := `
+Usage: terraform state rm-deposed [options] ADDRESS INDEX
+
+  Destroy the deposed object with the given index of the resource with
+  the given address, and remove it from the state. The deposed objects
+  of the resources are listed by "terraform state list -deposed".
+
+  An object is deposed when a resource with create_before_destroy is
+  replaced. If creating its replacement fails, the deposed object is
+  left in the state, and destroyed by the next apply. This command
+  destroys it right away, without applying any other changes, using the
+  provider configuration in the current directory.
+
+  With -forget, the deposed object is only removed from the state, and
+  not destroyed. Use this when the object was already deleted outside of
+  Terraform, or should be kept and managed by hand.
+
+Options:
+
+  -backup=path        Path to backup the existing state file before
+                      modifying. Defaults to the "-state-out" path with
+                      ".backup" extension. Set to "-" to disable backup.
+
+  -force              Don't ask for confirmation before destroying.
+
+  -forget             Remove the deposed object from the state without
+                      destroying it.
+
+  -input=true         Ask for input for variables if not directly set.
+
+  -state=path         Path to read and save state (unless state-out
+                      is specified). Defaults to "terraform.tfstate".
+
+  -state-out=path     Path to write updated state file. By default, the
+                      "-state" path will be used.
+
+  -var 'foo=bar'      Set a variable in the Terraform configuration. This
+                      flag can be set multiple times.
+
+  -var-file=foo       Set variables in the Terraform configuration from
+                      a file. If "terraform.tfvars" is present, it will be
+                      automatically loaded if this flag is not specified.
+
+`
+	return strings.TrimSpace(helpText)
+}
+
+func (c *StateRmDeposedCommand) Synopsis() string {
+	return "Destroy or forget a deposed object of a resource"
+}
@@ -0,0 +1,117 @@
+package command
+
+import (
+	"io/ioutil"
+	"testing"
+
+	"github.com/mitchellh/cli"
+)
+
+func TestStateRmDeposed(t *testing.T) {
+	tmp, cwd := testCwd(t)
+	defer testFixCwd(t, tmp, cwd)
+
+	testStateFileDefault(t, testStateDeposed())
+	err := ioutil.WriteFile(
+		"main.tf", []byte(`resource "test_instance" "foo" {}`), 0644)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	p := testProvider()
+	ui := new(cli.MockUi)
+	c := &StateRmDeposedCommand{
+		Meta: Meta{
+			ContextOpts: testCtxConfig(p),
+			Ui:          ui,
+		},
+	}
+
+	args := []string{"-force", "test_instance.foo", "1"}
+	if code := c.Run(args); code != 0 {
+		t.Fatalf("bad: %d\n\n%s", code, ui.ErrorWriter.String())
+	}
+
+	if !p.ApplyCalled {
+		t.Fatal("apply should be called")
+	}
+	if p.ApplyState.ID != "old2" {
+		t.Fatalf("bad: %#v", p.ApplyState)
+	}
+	if !p.ApplyDiff.Destroy {
+		t.Fatalf("bad: %#v", p.ApplyDiff)
+	}
+
+	actual := testStatePushRead(t)
+	rs := actual.RootModule().Resources["test_instance.foo"]
+	if len(rs.Deposed) != 1 || rs.Deposed[0].ID != "old1" {
+		t.Fatalf("bad: %#v", rs.Deposed)
+	}
+}
+
+func TestStateRmDeposed_forget(t *testing.T) {
+	tmp, cwd := testCwd(t)
+	defer testFixCwd(t, tmp, cwd)
+
+	testStateFileDefault(t, testStateDeposed())
+
+	p := testProvider()
+	ui := new(cli.MockUi)
+	c := &StateRmDeposedCommand{
+		Meta: Meta{
+			ContextOpts: testCtxConfig(p),
+			Ui:          ui,
+		},
+	}
+
+	args := []string{"-forget", "module.child.test_instance.baz", "0"}
+	if code := c.Run(args); code != 0 {
+		t.Fatalf("bad: %d\n\n%s", code, ui.ErrorWriter.String())
+	}
+
+	if p.ApplyCalled {
+		t.Fatal("apply should not be called")
+	}
+
+	// The resource has nothing left and is removed
+	actual := testStatePushRead(t)
+	mod := actual.ModuleByPath([]string{"root", "child"})
+	if mod != nil && len(mod.Resources) > 0 {
+		t.Fatalf("bad: %#v", mod.Resources)
+	}
+	if len(actual.RootModule().Resources["test_instance.foo"].Deposed) != 2 {
+		t.Fatalf("bad: %#v", actual.RootModule())
+	}
+}
+
+func TestStateRmDeposed_badArgs(t *testing.T) {
+	tmp, cwd := testCwd(t)
+	defer testFixCwd(t, tmp, cwd)
+
+	testStateFileDefault(t, testStateDeposed())
+
+	cases := [][]string{
+		nil,
+		[]string{"test_instance.foo"},
+		[]string{"-forget", "test_instance.foo", "a"},
+		[]string{"-forget", "test_instance.foo", "2"},
+		[]string{"-forget", "test_instance.bar", "0"},
+		[]string{"-forget", "test_instance.foo", "0", "extra"},
+		[]string{"-forget", "test_instance.foo.deposed", "0"},
+		[]string{"-forget", "module.child", "0"},
+	}
+
+	for i, args := range cases {
+		ui := new(cli.MockUi)
+		c := &StateRmDeposedCommand{
+			Meta: Meta{
+				ContextOpts: testCtxConfig(testProvider()),
+				Ui:          ui,
+			},
+		}
+
+		if code := c.Run(args); code != 1 {
+			t.Fatalf("%d: bad: %d", i, code)
+		}
+	}
+}
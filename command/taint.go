@@ -3,7 +3,10 @@ package command
 import (
 	"fmt"
 	"log"
+	"sort"
 	"strings"
+
+	"github.com/hashicorp/terraform/terraform"
 )
 
 // TaintCommand is a cli.Command implementation that manually taints
@@ -36,12 +39,14 @@ func (c *TaintCommand) Run(args []string) int {
 		return 1
 	}
 
-	name := args[0]
-	if module == "" {
-		module = "root"
-	} else {
-		module = "root." + module
+	addr, err := parseTaintAddress(args[0], module)
+	if err != nil {
+		c.Ui.Error(err.Error())
+		return 1
 	}
+	modPath := addr.ModulePath()
+	name := addr.StateKey()
+	module = strings.Join(modPath, ".")
 
 	// Get the state that we'll be modifying
 	state, err := c.State()
@@ -66,7 +71,6 @@ func (c *TaintCommand) Run(args []string) int {
 	}
 
 	// Get the proper module we want to taint
-	modPath := strings.Split(module, ".")
 	mod := s.ModuleByPath(modPath)
 	if mod == nil {
 		if allowMissing {
@@ -92,8 +96,12 @@ func (c *TaintCommand) Run(args []string) int {
 	}
 
 	// Get the resource we're looking for
-	rs, ok := mod.Resources[name]
-	if !ok {
+	name, rs, err := findTaintResource(mod, addr)
+	if err != nil {
+		c.Ui.Error(err.Error())
+		return 1
+	}
+	if rs == nil {
 		if allowMissing {
 			return c.allowMissingExit(name, module)
 		}
@@ -104,11 +112,17 @@ func (c *TaintCommand) Run(args []string) int {
 			module))
 		return 1
 	}
+	if rs.Primary == nil {
+		c.Ui.Error(fmt.Sprintf(
+			"The resource %s in the module %s is already tainted.",
+			name, module))
+		return 1
+	}
 
 	// Taint the resource
 	rs.Taint()
 
-	log.Printf("[INFO] Writing state output to: %s", c.Meta.StateOutPath())
+	log.Printf("[INFO] command: Writing state output to: %s", c.Meta.StateOutPath())
 	if err := c.Meta.PersistState(s); err != nil {
 		c.Ui.Error(fmt.Sprintf("Error writing state file: %s", err))
 		return 1
@@ -122,11 +136,16 @@ func (c *TaintCommand) Run(args []string) int {
 
 func (c *TaintCommand) Help() string {
 	helpText := `
-Usage: terraform taint [options] name
+Usage: terraform taint [options] address
 
   Manually mark a resource as tainted, forcing a destroy and recreate
   on the next plan/apply.
 
+  The address is the name of the resource, optionally with the modules
+  it is in and the index of one of its instances if it has a count:
+  "module.app.aws_instance.web.3". The -module flag can still be used
+  for the modules instead.
+
   This will not modify your infrastructure. This command changes your
   state to mark a resource as tainted so that during the next plan or
   apply, that resource will be destroyed and recreated. This command on
@@ -145,6 +164,7 @@ Options:
   -module=path        The module path where the resource lives. By
                       default this will be root. Child modules can be specified
                       by names. Ex. "consul" or "consul.vpc" (nested modules).
+                      Can't be used with an address that has a module.
 
   -no-color           If specified, output won't contain any color.
 
@@ -169,3 +189,71 @@ func (c *TaintCommand) allowMissingExit(name, module string) int {
 		name, module))
 	return 0
 }
+
+// parseTaintAddress parses the address of a resource given to taint or
+// untaint, such as "module.app.aws_instance.web.3". The module can still
+// be given with the -module flag, in which case the address can't have
+// one.
+func parseTaintAddress(raw, module string) (*terraform.ResourceAddress, error) {
+	addr, err := terraform.ParseResourceAddress(raw)
+	if err != nil || addr.InstanceType != terraform.TypePrimary {
+		return nil, fmt.Errorf(
+			"Invalid resource address: %s. Addresses look like\n"+
+				"\"aws_instance.web\", \"aws_instance.web.3\" or\n"+
+				"\"module.app.aws_instance.web.3\".", raw)
+	}
+
+	if module != "" {
+		if len(addr.Path) > 0 {
+			return nil, fmt.Errorf(
+				"The address %s already has a module, -module can't be set too.",
+				raw)
+		}
+		addr.Path = strings.Split(module, ".")
+	}
+
+	return addr, nil
+}
+
+// findTaintResource finds the resource with the given address in a
+// module, and returns its key in the state, which differs from the key of
+// the address for resources with a count of one: they have no index in
+// the state. It returns a nil resource if there is none with the address,
+// and an error if the address is of a resource with a count but has no
+// index.
+func findTaintResource(
+	mod *terraform.ModuleState,
+	addr *terraform.ResourceAddress) (string, *terraform.ResourceState, error) {
+	name := addr.StateKey()
+	if rs, ok := mod.Resources[name]; ok {
+		return name, rs, nil
+	}
+
+	if addr.Index != -1 {
+		if addr.Index == 0 {
+			single := *addr
+			single.Index = -1
+			if rs, ok := mod.Resources[single.StateKey()]; ok {
+				return single.StateKey(), rs, nil
+			}
+		}
+
+		return name, nil, nil
+	}
+
+	var instances []string
+	for k, _ := range mod.Resources {
+		if strings.HasPrefix(k, name+".") {
+			instances = append(instances, k)
+		}
+	}
+	if len(instances) > 0 {
+		sort.Strings(instances)
+		return name, nil, fmt.Errorf(
+			"The resource %s has a count, so the address must have the index\n"+
+				"of one of its instances: %s",
+			name, strings.Join(instances, ", "))
+	}
+
+	return name, nil, nil
+}
@@ -0,0 +1,7 @@
+resource "test_instance" "foo" {
+    ami = "bar"
+}
+
+resource "aws_instance" "bar" {
+    ami = "baz"
+}
@@ -97,7 +97,7 @@ func (i *UIInput) Input(opts *terraform.InputOpts) (string, error) {
 	go func() {
 		var line string
 		if _, err := fmt.Fscanln(r, &line); err != nil {
-			log.Printf("[ERR] UIInput scan err: %s", err)
+			log.Printf("[ERR] command: UIInput scan err: %s", err)
 		}
 
 		result <- line
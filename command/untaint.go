@@ -0,0 +1,201 @@
+package command
+
+import (
+	"fmt"
+	"log"
+	"strings"
+)
+
+// UntaintCommand is a cli.Command implementation that manually untaints
+// a resource, so it isn't recreated.
+type UntaintCommand struct {
+	Meta
+}
+
+func (c *UntaintCommand) Run(args []string) int {
+	args = c.Meta.process(args, false)
+
+	var allowMissing bool
+	var index int
+	var module string
+	cmdFlags := c.Meta.flagSet("untaint")
+	cmdFlags.BoolVar(&allowMissing, "allow-missing", false, "module")
+	cmdFlags.IntVar(&index, "index", 0, "index")
+	cmdFlags.StringVar(&module, "module", "", "module")
+	cmdFlags.StringVar(&c.Meta.statePath, "state", DefaultStateFilename, "path")
+	cmdFlags.StringVar(&c.Meta.stateOutPath, "state-out", "", "path")
+	cmdFlags.StringVar(&c.Meta.backupPath, "backup", "", "path")
+	cmdFlags.Usage = func() { c.Ui.Error(c.Help()) }
+	if err := cmdFlags.Parse(args); err != nil {
+		return 1
+	}
+
+	// Require the one argument for the resource to untaint
+	args = cmdFlags.Args()
+	if len(args) != 1 {
+		c.Ui.Error("The untaint command expects exactly one argument.")
+		cmdFlags.Usage()
+		return 1
+	}
+
+	addr, err := parseTaintAddress(args[0], module)
+	if err != nil {
+		c.Ui.Error(err.Error())
+		return 1
+	}
+	modPath := addr.ModulePath()
+	name := addr.StateKey()
+	module = strings.Join(modPath, ".")
+
+	// Get the state that we'll be modifying
+	state, err := c.State()
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Failed to load state: %s", err))
+		return 1
+	}
+
+	// Get the actual state structure
+	s := state.State()
+	if s.Empty() {
+		if allowMissing {
+			return c.allowMissingExit(name, module)
+		}
+
+		c.Ui.Error(fmt.Sprintf(
+			"The state is empty. The most common reason for this is that\n" +
+				"an invalid state file path was given or Terraform has never\n " +
+				"been run for this infrastructure. Infrastructure must exist\n" +
+				"for it to be untainted."))
+		return 1
+	}
+
+	// Get the proper module we want to untaint
+	mod := s.ModuleByPath(modPath)
+	if mod == nil {
+		if allowMissing {
+			return c.allowMissingExit(name, module)
+		}
+
+		c.Ui.Error(fmt.Sprintf(
+			"The module %s could not be found. There is nothing to untaint.",
+			module))
+		return 1
+	}
+
+	// Get the resource we're looking for
+	name, rs, err := findTaintResource(mod, addr)
+	if err != nil {
+		c.Ui.Error(err.Error())
+		return 1
+	}
+	if rs == nil {
+		if allowMissing {
+			return c.allowMissingExit(name, module)
+		}
+
+		c.Ui.Error(fmt.Sprintf(
+			"The resource %s couldn't be found in the module %s.",
+			name,
+			module))
+		return 1
+	}
+
+	// Check that there is one instance to untaint, and which one
+	if len(rs.Tainted) == 0 || rs.Primary != nil {
+		c.Ui.Error(fmt.Sprintf(
+			"The resource %s in the module %s is not tainted.",
+			name, module))
+		return 1
+	}
+	if index == 0 {
+		if len(rs.Tainted) > 1 {
+			c.Ui.Error(fmt.Sprintf(
+				"The resource %s in the module %s has %d tainted instances.\n"+
+					"Choose the one to untaint with -index. The index is the\n"+
+					"number of its \"Tainted ID\" in the output of `terraform show`.",
+				name, module, len(rs.Tainted)))
+			return 1
+		}
+
+		index = 1
+	}
+	if index < 1 || index > len(rs.Tainted) {
+		c.Ui.Error(fmt.Sprintf(
+			"The resource %s in the module %s has no tainted instance %d.",
+			name, module, index))
+		return 1
+	}
+
+	// Untaint the resource
+	rs.Untaint(index - 1)
+
+	log.Printf("[INFO] command: Writing state output to: %s", c.Meta.StateOutPath())
+	if err := c.Meta.PersistState(s); err != nil {
+		c.Ui.Error(fmt.Sprintf("Error writing state file: %s", err))
+		return 1
+	}
+
+	c.Ui.Output(fmt.Sprintf(
+		"The resource %s in the module %s has been successfully untainted!",
+		name, module))
+	return 0
+}
+
+func (c *UntaintCommand) Help() string {
+	helpText := `
+Usage: terraform untaint [options] address
+
+  Manually unmark a resource as tainted, restoring it as the primary
+  instance in the state. This reverses either a manual 'terraform taint'
+  or the result of provisioners failing on a resource.
+
+  This will not modify your infrastructure. This command changes your
+  state to unmark a resource as tainted. This command can be undone by
+  reverting the state backup file that is created, or by running
+  'terraform taint' on the resource.
+
+  The address is the name of the resource, optionally with the modules
+  it is in and the index of one of its instances if it has a count:
+  "module.app.aws_instance.web.3".
+
+Options:
+
+  -allow-missing      If specified, the command will succeed (exit code 0)
+                      even if the resource is missing.
+
+  -backup=path        Path to backup the existing state file before
+                      modifying. Defaults to the "-state-out" path with
+                      ".backup" extension. Set to "-" to disable backup.
+
+  -index=n            The tainted instance to untaint, if the resource
+                      has more than one. This is the number of its
+                      "Tainted ID" in the output of 'terraform show'.
+
+  -module=path        The module path where the resource lives. By
+                      default this will be root. Child modules can be specified
+                      by names. Ex. "consul" or "consul.vpc" (nested modules).
+                      Can't be used with an address that has a module.
+
+  -no-color           If specified, output won't contain any color.
+
+  -state=path         Path to read and save state (unless state-out
+                      is specified). Defaults to "terraform.tfstate".
+
+  -state-out=path     Path to write updated state file. By default, the
+                      "-state" path will be used.
+
+`
+	return strings.TrimSpace(helpText)
+}
+
+func (c *UntaintCommand) Synopsis() string {
+	return "Manually unmark a resource as tainted"
+}
+
+func (c *UntaintCommand) allowMissingExit(name, module string) int {
+	c.Ui.Output(fmt.Sprintf(
+		"The resource %s in the module %s was not found, but\n"+
+			"-allow-missing is set, so we're exiting successfully.",
+		name, module))
+	return 0
+}
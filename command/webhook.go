@@ -0,0 +1,223 @@
+package command
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"text/template"
+	"time"
+
+	"github.com/hashicorp/terraform/terraform"
+)
+
+// These are the events that webhooks can be fired on.
+const (
+	WebhookEventPlan         = "plan"
+	WebhookEventApplyStart   = "apply_start"
+	WebhookEventApplySuccess = "apply_success"
+	WebhookEventApplyFailure = "apply_failure"
+)
+
+// WebhookEvents are all the events that webhooks can be fired on.
+var WebhookEvents = []string{
+	WebhookEventPlan,
+	WebhookEventApplyStart,
+	WebhookEventApplySuccess,
+	WebhookEventApplyFailure,
+}
+
+// webhookTimeout is how long a webhook may take before it is given up.
+const webhookTimeout = 10 * time.Second
+
+// Webhook is a URL that is sent a request when an event happens during
+// a run, such as an apply finishing.
+type Webhook struct {
+	Name string
+	URL  string
+
+	// Events are the events the webhook is fired on. If this is empty,
+	// it is fired on all events.
+	Events []string
+
+	// Payload is a text/template for the body of the request, which is
+	// executed with a WebhookEvent. If this is empty, the event is sent
+	// as JSON.
+	Payload string
+}
+
+// Webhooks are the webhooks that are fired by the commands.
+type Webhooks []*Webhook
+
+// WebhookEvent is the data sent to webhooks.
+type WebhookEvent struct {
+	Event   string    `json:"event"`
+	Command string    `json:"command"`
+	Dir     string    `json:"dir"`
+	Time    time.Time `json:"time"`
+
+	// Add, Change and Destroy are the number of resources the plan will
+	// add, change and destroy.
+	Add     int `json:"add"`
+	Change  int `json:"change"`
+	Destroy int `json:"destroy"`
+
+	// Added, Changed and Destroyed are the number of resources that were
+	// added, changed and destroyed by an apply.
+	Added     int `json:"added"`
+	Changed   int `json:"changed"`
+	Destroyed int `json:"destroyed"`
+
+	// Error is the error an apply failed with.
+	Error string `json:"error,omitempty"`
+}
+
+// Validate checks the webhook for errors.
+func (w *Webhook) Validate() error {
+	if w.URL == "" {
+		return fmt.Errorf("webhook %s: url must be set", w.Name)
+	}
+
+	for _, e := range w.Events {
+		valid := false
+		for _, v := range WebhookEvents {
+			if e == v {
+				valid = true
+				break
+			}
+		}
+		if !valid {
+			return fmt.Errorf("webhook %s: unknown event %q", w.Name, e)
+		}
+	}
+
+	if _, err := w.template(); err != nil {
+		return fmt.Errorf("webhook %s: invalid payload: %s", w.Name, err)
+	}
+
+	return nil
+}
+
+// Fire sends the event to the webhooks that are fired on it. Failing
+// webhooks don't fail the command, so errors are reported to the UI.
+func (ws Webhooks) Fire(m *Meta, e *WebhookEvent) {
+	if e.Time.IsZero() {
+		e.Time = time.Now()
+	}
+	if e.Dir == "" {
+		e.Dir, _ = os.Getwd()
+	}
+
+	for _, w := range ws {
+		if !w.firedOn(e.Event) {
+			continue
+		}
+
+		if err := w.send(e); err != nil {
+			m.Ui.Error(fmt.Sprintf(
+				"Error sending webhook %s: %s", w.Name, err))
+		}
+	}
+}
+
+func (w *Webhook) firedOn(event string) bool {
+	if len(w.Events) == 0 {
+		return true
+	}
+
+	for _, e := range w.Events {
+		if e == event {
+			return true
+		}
+	}
+
+	return false
+}
+
+// payload returns the body of the request for the event.
+func (w *Webhook) payload(e *WebhookEvent) ([]byte, error) {
+	tpl, err := w.template()
+	if err != nil {
+		return nil, err
+	}
+	if tpl == nil {
+		return json.Marshal(e)
+	}
+
+	var buf bytes.Buffer
+	if err := tpl.Execute(&buf, e); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+func (w *Webhook) template() (*template.Template, error) {
+	if w.Payload == "" {
+		return nil, nil
+	}
+
+	return template.New(w.Name).Funcs(template.FuncMap{
+		"json": func(v interface{}) (string, error) {
+			data, err := json.Marshal(v)
+			return string(data), err
+		},
+	}).Parse(w.Payload)
+}
+
+func (w *Webhook) send(e *WebhookEvent) error {
+	body, err := w.payload(e)
+	if err != nil {
+		return err
+	}
+
+	client := &http.Client{Timeout: webhookTimeout}
+	resp, err := client.Post(w.URL, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("unexpected status: %s", resp.Status)
+	}
+
+	return nil
+}
+
+// fireWebhooks sends the event to the configured webhooks, if any.
+func (m *Meta) fireWebhooks(e *WebhookEvent) {
+	if m.Webhooks == nil {
+		return
+	}
+
+	m.Webhooks.Fire(m, e)
+}
+
+// webhookPlanEvent returns the event for a plan, with the number of
+// resources it changes.
+func webhookPlanEvent(event, command string, plan *terraform.Plan) *WebhookEvent {
+	result := &WebhookEvent{Event: event, Command: command}
+	if plan == nil || plan.Diff == nil {
+		return result
+	}
+
+	for _, m := range plan.Diff.Modules {
+		for _, rdiff := range m.Resources {
+			switch rdiff.ChangeType() {
+			case terraform.DiffCreate:
+				result.Add++
+			case terraform.DiffUpdate:
+				result.Change++
+			case terraform.DiffDestroy:
+				result.Destroy++
+			case terraform.DiffDestroyCreate:
+				result.Add++
+				result.Destroy++
+			}
+		}
+	}
+
+	return result
+}
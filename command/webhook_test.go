@@ -0,0 +1,194 @@
+package command
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/hashicorp/terraform/terraform"
+	"github.com/mitchellh/cli"
+)
+
+func TestWebhookValidate(t *testing.T) {
+	cases := []struct {
+		Webhook *Webhook
+		Err     bool
+	}{
+		{
+			&Webhook{Name: "foo", URL: "http://example.com"},
+			false,
+		},
+		{
+			&Webhook{
+				Name:    "foo",
+				URL:     "http://example.com",
+				Events:  []string{"plan", "apply_failure"},
+				Payload: `{"text": {{json .Event}}}`,
+			},
+			false,
+		},
+		{
+			&Webhook{Name: "foo"},
+			true,
+		},
+		{
+			&Webhook{
+				Name:   "foo",
+				URL:    "http://example.com",
+				Events: []string{"apply_done"},
+			},
+			true,
+		},
+		{
+			&Webhook{
+				Name:    "foo",
+				URL:     "http://example.com",
+				Payload: "{{.Event",
+			},
+			true,
+		},
+	}
+
+	for i, tc := range cases {
+		err := tc.Webhook.Validate()
+		if (err != nil) != tc.Err {
+			t.Fatalf("%d: err: %s", i, err)
+		}
+	}
+}
+
+func TestWebhooksFire(t *testing.T) {
+	server, requests := testWebhookServer(t, http.StatusOK)
+	defer server.Close()
+
+	ws := Webhooks{
+		&Webhook{
+			Name: "all",
+			URL:  server.URL + "/all",
+		},
+		&Webhook{
+			Name:    "failures",
+			URL:     server.URL + "/failures",
+			Events:  []string{WebhookEventApplyFailure},
+			Payload: `{"text": {{json .Error}}, "added": {{.Added}}}`,
+		},
+	}
+
+	ui := new(cli.MockUi)
+	m := &Meta{Ui: ui}
+	ws.Fire(m, &WebhookEvent{Event: WebhookEventApplySuccess, Command: "apply"})
+	ws.Fire(m, &WebhookEvent{
+		Event:   WebhookEventApplyFailure,
+		Command: "apply",
+		Added:   2,
+		Error:   `bad "thing"`,
+	})
+	if ui.ErrorWriter.String() != "" {
+		t.Fatalf("bad: %s", ui.ErrorWriter.String())
+	}
+
+	actual := requests()
+	if len(actual) != 3 {
+		t.Fatalf("bad: %#v", actual)
+	}
+
+	var e WebhookEvent
+	if err := json.Unmarshal([]byte(actual[0][1]), &e); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if actual[0][0] != "/all" || e.Event != WebhookEventApplySuccess || e.Time.IsZero() {
+		t.Fatalf("bad: %#v", actual[0])
+	}
+
+	expected := []string{"/failures", `{"text": "bad \"thing\"", "added": 2}`}
+	if !reflect.DeepEqual(actual[2], expected) {
+		t.Fatalf("bad: %#v", actual[2])
+	}
+}
+
+func TestWebhooksFire_error(t *testing.T) {
+	server, _ := testWebhookServer(t, http.StatusInternalServerError)
+	defer server.Close()
+
+	ws := Webhooks{&Webhook{Name: "foo", URL: server.URL}}
+	ui := new(cli.MockUi)
+	ws.Fire(&Meta{Ui: ui}, &WebhookEvent{Event: WebhookEventPlan})
+	if !strings.Contains(ui.ErrorWriter.String(), "Error sending webhook foo") {
+		t.Fatalf("bad: %s", ui.ErrorWriter.String())
+	}
+}
+
+func TestWebhookPlanEvent(t *testing.T) {
+	plan := &terraform.Plan{
+		Diff: &terraform.Diff{
+			Modules: []*terraform.ModuleDiff{
+				&terraform.ModuleDiff{
+					Path: []string{"root"},
+					Resources: map[string]*terraform.InstanceDiff{
+						"aws_instance.new": &terraform.InstanceDiff{
+							Attributes: map[string]*terraform.ResourceAttrDiff{
+								"ami": &terraform.ResourceAttrDiff{
+									New:         "foo",
+									RequiresNew: true,
+								},
+							},
+						},
+						"aws_instance.replaced": &terraform.InstanceDiff{
+							Attributes: map[string]*terraform.ResourceAttrDiff{
+								"ami": &terraform.ResourceAttrDiff{
+									New:         "foo",
+									RequiresNew: true,
+								},
+							},
+							Destroy: true,
+						},
+						"aws_instance.changed": &terraform.InstanceDiff{
+							Attributes: map[string]*terraform.ResourceAttrDiff{
+								"tags": &terraform.ResourceAttrDiff{New: "foo"},
+							},
+						},
+						"aws_instance.gone": &terraform.InstanceDiff{
+							Destroy: true,
+						},
+					},
+				},
+			},
+		},
+	}
+
+	e := webhookPlanEvent(WebhookEventPlan, "plan", plan)
+	if e.Add != 2 || e.Change != 1 || e.Destroy != 2 {
+		t.Fatalf("bad: %#v", e)
+	}
+}
+
+// testWebhookServer starts a server that records the path and body of
+// the requests it is sent, and responds with the given status.
+func testWebhookServer(t *testing.T, status int) (*httptest.Server, func() [][]string) {
+	var l sync.Mutex
+	var requests [][]string
+	server := httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			body, err := ioutil.ReadAll(r.Body)
+			if err != nil {
+				t.Fatalf("err: %s", err)
+			}
+
+			l.Lock()
+			requests = append(requests, []string{r.URL.Path, string(body)})
+			l.Unlock()
+
+			w.WriteHeader(status)
+		}))
+
+	return server, func() [][]string {
+		l.Lock()
+		defer l.Unlock()
+		return requests
+	}
+}
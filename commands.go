@@ -31,6 +31,8 @@ func init() {
 	meta := command.Meta{
 		Color:       true,
 		ContextOpts: &ContextOpts,
+		Policy:      &Policy,
+		Webhooks:    &Webhooks,
 		Ui:          Ui,
 	}
 
@@ -42,6 +44,13 @@ func init() {
 			}, nil
 		},
 
+		"completion": func() (cli.Command, error) {
+			return &command.CompletionCommand{
+				Meta:     meta,
+				Commands: Commands,
+			}, nil
+		},
+
 		"destroy": func() (cli.Command, error) {
 			return &command.ApplyCommand{
 				Meta:       meta,
@@ -50,6 +59,18 @@ func init() {
 			}, nil
 		},
 
+		"env": func() (cli.Command, error) {
+			return &command.EnvCommand{
+				Meta: meta,
+			}, nil
+		},
+
+		"force-unlock": func() (cli.Command, error) {
+			return &command.ForceUnlockCommand{
+				Meta: meta,
+			}, nil
+		},
+
 		"get": func() (cli.Command, error) {
 			return &command.GetCommand{
 				Meta: meta,
@@ -62,6 +83,12 @@ func init() {
 			}, nil
 		},
 
+		"import": func() (cli.Command, error) {
+			return &command.ImportCommand{
+				Meta: meta,
+			}, nil
+		},
+
 		"init": func() (cli.Command, error) {
 			return &command.InitCommand{
 				Meta: meta,
@@ -104,12 +131,24 @@ func init() {
 			}, nil
 		},
 
+		"state": func() (cli.Command, error) {
+			return &command.StateCommand{
+				Meta: meta,
+			}, nil
+		},
+
 		"taint": func() (cli.Command, error) {
 			return &command.TaintCommand{
 				Meta: meta,
 			}, nil
 		},
 
+		"untaint": func() (cli.Command, error) {
+			return &command.UntaintCommand{
+				Meta: meta,
+			}, nil
+		},
+
 		"version": func() (cli.Command, error) {
 			return &command.VersionCommand{
 				Meta:              meta,
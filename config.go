@@ -7,9 +7,12 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
+	"runtime"
+	"sort"
 	"strings"
 
 	"github.com/hashicorp/hcl"
+	"github.com/hashicorp/terraform/command"
 	"github.com/hashicorp/terraform/plugin"
 	"github.com/hashicorp/terraform/terraform"
 	"github.com/mitchellh/osext"
@@ -23,10 +26,25 @@ type Config struct {
 	Providers    map[string]string
 	Provisioners map[string]string
 
+	// PolicyChecks are executables that check plans before they are
+	// applied. See command.Policy.
+	PolicyChecks []string `hcl:"policy_checks"`
+
+	// Webhooks are URLs that are sent a request on events during a run,
+	// by name. See command.Webhook.
+	Webhooks map[string]WebhookConfig `hcl:"webhook"`
+
 	DisableCheckpoint          bool `hcl:"disable_checkpoint"`
 	DisableCheckpointSignature bool `hcl:"disable_checkpoint_signature"`
 }
 
+// WebhookConfig is the configuration of a single webhook.
+type WebhookConfig struct {
+	URL     string   `hcl:"url"`
+	Events  []string `hcl:"events"`
+	Payload string   `hcl:"payload"`
+}
+
 // BuiltinConfig is the built-in defaults for the configuration. These
 // can be overridden by user configurations.
 var BuiltinConfig Config
@@ -34,6 +52,13 @@ var BuiltinConfig Config
 // ContextOpts are the global ContextOpts we use to initialize the CLI.
 var ContextOpts terraform.ContextOpts
 
+// Policy are the global policy checks that plans are checked against
+// before they are applied.
+var Policy command.Policy
+
+// Webhooks are the global webhooks that are fired by the commands.
+var Webhooks command.Webhooks
+
 // ConfigFile returns the default path to the configuration file.
 //
 // On Unix-like systems this is the ".terraformrc" file in the home directory.
@@ -73,39 +98,101 @@ func LoadConfig(path string) (*Config, error) {
 	return &result, nil
 }
 
+// CommandWebhooks returns the webhooks for the commands, sorted by name.
+func (c *Config) CommandWebhooks() (command.Webhooks, error) {
+	names := make([]string, 0, len(c.Webhooks))
+	for k, _ := range c.Webhooks {
+		names = append(names, k)
+	}
+	sort.Strings(names)
+
+	result := make(command.Webhooks, 0, len(names))
+	for _, name := range names {
+		v := c.Webhooks[name]
+		w := &command.Webhook{
+			Name:    name,
+			URL:     v.URL,
+			Events:  v.Events,
+			Payload: v.Payload,
+		}
+		if err := w.Validate(); err != nil {
+			return nil, err
+		}
+
+		result = append(result, w)
+	}
+
+	return result, nil
+}
+
+// PluginDir is the name of the directory that plugins are discovered in,
+// both within the configuration directory and within the current
+// directory for plugins that are local to a project.
+const PluginDir = "plugins"
+
+// ProjectPluginDir is the directory within the current directory that
+// plugins local to a project are discovered in.
+var ProjectPluginDir = filepath.Join("terraform.d", PluginDir)
+
 // Discover discovers plugins.
 //
-// This looks in the directory of the executable and the CWD, in that
-// order for priority.
+// Plugins are found by their file names, which must be
+// "terraform-provider-NAME" or "terraform-provisioner-NAME". This looks
+// in the following directories, with plugins found in later directories
+// overriding those with the same name found in earlier ones:
+//
+//   - The current directory
+//   - The plugins directory in the configuration directory, such as
+//     ~/.terraform.d/plugins
+//   - The terraform.d/plugins directory in the current directory
+//   - The directory of the executable
+//
+// For each of the plugins directories, the subdirectory for the current
+// OS and architecture, such as "linux_amd64", is also searched after the
+// directory itself.
 func (c *Config) Discover() error {
-	// Look in the cwd.
-	if err := c.discover("."); err != nil {
-		return err
+	for _, dir := range pluginDirs() {
+		if err := c.discover(dir); err != nil {
+			return err
+		}
 	}
 
+	return nil
+}
+
+// pluginDirs returns the directories that plugins are discovered in, in
+// order of priority from lowest to highest.
+func pluginDirs() []string {
+	osArch := runtime.GOOS + "_" + runtime.GOARCH
+
+	// Look in the cwd.
+	dirs := []string{"."}
+
 	// Look in the plugins directory. This will override any found
 	// in the current directory.
 	dir, err := ConfigDir()
 	if err != nil {
 		log.Printf("[ERR] Error loading config directory: %s", err)
 	} else {
-		if err := c.discover(filepath.Join(dir, "plugins")); err != nil {
-			return err
-		}
+		dir = filepath.Join(dir, PluginDir)
+		dirs = append(dirs, dir, filepath.Join(dir, osArch))
 	}
 
+	// Look in the plugins directory of the project, so that a project
+	// can bring its own plugins.
+	dirs = append(dirs,
+		ProjectPluginDir, filepath.Join(ProjectPluginDir, osArch))
+
 	// Next, look in the same directory as the executable. Any conflicts
 	// will overwrite those found in our current directory.
 	exePath, err := osext.Executable()
 	if err != nil {
 		log.Printf("[ERR] Error loading exe directory: %s", err)
 	} else {
-		if err := c.discover(filepath.Dir(exePath)); err != nil {
-			return err
-		}
+		dirs = append(dirs, filepath.Dir(exePath))
 	}
 
-	return nil
+	return dirs
 }
 
 // Merge merges two configurations and returns a third entirely
@@ -126,6 +213,17 @@ func (c1 *Config) Merge(c2 *Config) *Config {
 	for k, v := range c2.Provisioners {
 		result.Provisioners[k] = v
 	}
+	result.PolicyChecks = append(result.PolicyChecks, c1.PolicyChecks...)
+	result.PolicyChecks = append(result.PolicyChecks, c2.PolicyChecks...)
+	if len(c1.Webhooks) > 0 || len(c2.Webhooks) > 0 {
+		result.Webhooks = make(map[string]WebhookConfig)
+		for k, v := range c1.Webhooks {
+			result.Webhooks[k] = v
+		}
+		for k, v := range c2.Webhooks {
+			result.Webhooks[k] = v
+		}
+	}
 
 	return &result
 }
@@ -166,6 +264,10 @@ func (c *Config) discoverSingle(glob string, m *map[string]string) error {
 	}
 
 	for _, match := range matches {
+		if fi, err := os.Stat(match); err != nil || fi.IsDir() {
+			continue
+		}
+
 		file := filepath.Base(match)
 
 		// If the filename has a ".", trim up to there
@@ -42,6 +42,13 @@ func Append(c1, c2 *Config) (*Config, error) {
 		c.Modules = append(c.Modules, c2.Modules...)
 	}
 
+	if len(c1.Locals) > 0 || len(c2.Locals) > 0 {
+		c.Locals = make(
+			[]*Local, 0, len(c1.Locals)+len(c2.Locals))
+		c.Locals = append(c.Locals, c1.Locals...)
+		c.Locals = append(c.Locals, c2.Locals...)
+	}
+
 	if len(c1.Outputs) > 0 || len(c2.Outputs) > 0 {
 		c.Outputs = make(
 			[]*Output, 0, len(c1.Outputs)+len(c2.Outputs))
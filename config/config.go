@@ -8,6 +8,7 @@ import (
 	"strconv"
 	"strings"
 
+	"github.com/hashicorp/go-version"
 	"github.com/hashicorp/terraform/config/lang"
 	"github.com/hashicorp/terraform/config/lang/ast"
 	"github.com/hashicorp/terraform/flatmap"
@@ -33,6 +34,7 @@ type Config struct {
 	ProviderConfigs []*ProviderConfig
 	Resources       []*Resource
 	Variables       []*Variable
+	Locals          []*Local
 	Outputs         []*Output
 
 	// The fields below can be filled in by loaders for validation
@@ -54,6 +56,7 @@ type AtlasConfig struct {
 type Module struct {
 	Name      string
 	Source    string
+	Version   string
 	RawConfig *RawConfig
 }
 
@@ -62,15 +65,23 @@ type Module struct {
 // For example, Terraform needs to set the AWS access keys for the AWS
 // resource provider.
 type ProviderConfig struct {
-	Name      string
-	Alias     string
+	Name  string
+	Alias string
+
+	// Version is a constraint on the version of the provider plugin,
+	// such as "~> 1.2". It is checked when the provider is initialized.
+	Version   string
 	RawConfig *RawConfig
 }
 
 // A resource represents a single Terraform resource in the configuration.
 // A Terraform resource is something that represents some component that
 // can be created and managed, and has some properties associated with it.
+//
+// Data sources, declared with `data` blocks, are resources too: they are
+// only read, and their Mode is DataResourceMode.
 type Resource struct {
+	Mode         ResourceMode
 	Name         string
 	Type         string
 	RawCount     *RawConfig
@@ -79,8 +90,23 @@ type Resource struct {
 	Provider     string
 	DependsOn    []string
 	Lifecycle    ResourceLifecycle
+
+	// Pos is where the resource is declared, and VarPos where each
+	// variable of its configuration is first referenced, by the key of
+	// the variable. They are only used for error messages.
+	Pos    Pos
+	VarPos map[string]Pos
 }
 
+// ResourceMode is whether a resource is managed by Terraform, or is a
+// data source that Terraform only reads.
+type ResourceMode byte
+
+const (
+	ManagedResourceMode ResourceMode = iota
+	DataResourceMode
+)
+
 // ResourceLifecycle is used to store the lifecycle tuning parameters
 // to allow customized behavior
 type ResourceLifecycle struct {
@@ -102,6 +128,14 @@ type Variable struct {
 	Description string
 }
 
+// Local is a named value within a module that is computed from other
+// values and can be referenced as "${local.name}". The value is stored
+// under the "value" key of the RawConfig.
+type Local struct {
+	Name      string
+	RawConfig *RawConfig
+}
+
 // Output is an output defined within the configuration. An output is
 // resulting data that is highlighted by Terraform when finished.
 type Output struct {
@@ -149,8 +183,13 @@ func (r *Resource) Count() (int, error) {
 	return int(v), nil
 }
 
-// A unique identifier for this resource.
+// A unique identifier for this resource. The identifiers of data sources
+// start with "data.", like the variables that reference them.
 func (r *Resource) Id() string {
+	if r.Mode == DataResourceMode {
+		return fmt.Sprintf("data.%s.%s", r.Type, r.Name)
+	}
+
 	return fmt.Sprintf("%s.%s", r.Type, r.Name)
 }
 
@@ -228,6 +267,13 @@ func (c *Config) Validate() error {
 						source,
 						v.FullKey()))
 				}
+			case *EachVariable:
+				if v.Type == EachValueInvalid {
+					errs = append(errs, fmt.Errorf(
+						"%s: invalid each variable: %s",
+						source,
+						v.FullKey()))
+				}
 			case *PathVariable:
 				if v.Type == PathValueInvalid {
 					errs = append(errs, fmt.Errorf(
@@ -235,6 +281,13 @@ func (c *Config) Validate() error {
 						source,
 						v.FullKey()))
 				}
+			case *TerraformVariable:
+				if v.Type == TerraformValueInvalid {
+					errs = append(errs, fmt.Errorf(
+						"%s: invalid terraform variable: %s",
+						source,
+						v.FullKey()))
+				}
 			}
 		}
 	}
@@ -251,6 +304,15 @@ func (c *Config) Validate() error {
 		}
 
 		providerSet[name] = struct{}{}
+
+		// Check that the version constraint, if any, is valid
+		if p.Version != "" {
+			if _, err := version.NewConstraint(p.Version); err != nil {
+				errs = append(errs, fmt.Errorf(
+					"provider.%s: invalid version constraint %q: %s",
+					name, p.Version, err))
+			}
+		}
 	}
 
 	// Check that all references to modules are valid
@@ -287,6 +349,15 @@ func (c *Config) Validate() error {
 				m.Id()))
 		}
 
+		// Check that the version constraint, if any, is valid
+		if m.Version != "" {
+			if _, err := version.NewConstraint(m.Version); err != nil {
+				errs = append(errs, fmt.Errorf(
+					"%s: invalid version constraint %q: %s",
+					m.Id(), m.Version, err))
+			}
+		}
+
 		// Check that the name matches our regexp
 		if !NameRegexp.Match([]byte(m.Name)) {
 			errs = append(errs, fmt.Errorf(
@@ -295,16 +366,23 @@ func (c *Config) Validate() error {
 				m.Id()))
 		}
 
-		// Check that the configuration can all be strings
+		// Check that the configuration can all be strings, or maps for
+		// map variables
 		raw := make(map[string]interface{})
 		for k, v := range m.RawConfig.Raw {
 			var strVal string
-			if err := mapstructure.WeakDecode(v, &strVal); err != nil {
+			if err := mapstructure.WeakDecode(v, &strVal); err == nil {
+				raw[k] = strVal
+				continue
+			}
+
+			mapVal, err := NormalizeVariableMap(v)
+			if err != nil {
 				errs = append(errs, fmt.Errorf(
-					"%s: variable %s must be a string value",
+					"%s: variable %s must be a string or map value",
 					m.Id(), k))
 			}
-			raw[k] = strVal
+			raw[k] = mapVal
 		}
 
 		// Check for invalid count variables
@@ -380,7 +458,23 @@ func (c *Config) Validate() error {
 					"%s: resource count can't reference module variable: %s",
 					n,
 					v.FullKey()))
+			case *EachVariable:
+				errs = append(errs, fmt.Errorf(
+					"%s: resource count can't reference each variable: %s",
+					n,
+					v.FullKey()))
+			case *LocalVariable:
+				errs = append(errs, fmt.Errorf(
+					"%s: resource count can't reference local value: %s",
+					n,
+					v.FullKey()))
 			case *ResourceVariable:
+				// Data sources are read before the resources that
+				// reference them are planned, so they can be counted.
+				if v.(*ResourceVariable).Mode == DataResourceMode {
+					continue
+				}
+
 				errs = append(errs, fmt.Errorf(
 					"%s: resource count can't reference resource variable: %s",
 					n,
@@ -485,7 +579,7 @@ func (c *Config) Validate() error {
 				continue
 			}
 
-			id := fmt.Sprintf("%s.%s", rv.Type, rv.Name)
+			id := rv.ResourceId()
 			if _, ok := resources[id]; !ok {
 				errs = append(errs, fmt.Errorf(
 					"%s: unknown resource '%s' referenced in variable %s",
@@ -497,6 +591,55 @@ func (c *Config) Validate() error {
 		}
 	}
 
+	// Check that all locals are valid
+	locals := make(map[string]*Local)
+	for _, l := range c.Locals {
+		if _, ok := locals[l.Name]; ok {
+			errs = append(errs, fmt.Errorf(
+				"local.%s: local value repeated multiple times", l.Name))
+			continue
+		}
+
+		locals[l.Name] = l
+
+		if !NameRegexp.Match([]byte(l.Name)) {
+			errs = append(errs, fmt.Errorf(
+				"local.%s: local value name can only contain letters, "+
+					"numbers, dashes, and underscores", l.Name))
+		}
+
+		for _, v := range l.RawConfig.Variables {
+			switch v.(type) {
+			case *CountVariable:
+				errs = append(errs, fmt.Errorf(
+					"local.%s: count variables are only valid within resources",
+					l.Name))
+			case *SelfVariable:
+				errs = append(errs, fmt.Errorf(
+					"local.%s: self variables are only valid within resources",
+					l.Name))
+			}
+		}
+	}
+
+	// Check for references to local values that don't exist
+	for source, vs := range vars {
+		for _, v := range vs {
+			lv, ok := v.(*LocalVariable)
+			if !ok {
+				continue
+			}
+
+			if _, ok := locals[lv.Name]; !ok {
+				errs = append(errs, fmt.Errorf(
+					"%s: unknown local value referenced: '%s'. define it "+
+						"in a 'locals' block",
+					source,
+					lv.Name))
+			}
+		}
+	}
+
 	// Check that all outputs are valid
 	for _, o := range c.Outputs {
 		invalid := false
@@ -530,6 +673,27 @@ func (c *Config) Validate() error {
 		}
 	}
 
+	// Validate the dynamic blocks. Each variables are only valid within
+	// the content of a dynamic block.
+	for source, rc := range c.rawConfigs() {
+		raw, ok := rc.Raw[dynamicKey]
+		if ok {
+			if _, err := dynamicBlocks(raw); err != nil {
+				errs = append(errs, fmt.Errorf("%s: %s", source, err))
+			}
+
+			continue
+		}
+
+		for _, v := range rc.Variables {
+			if _, ok := v.(*EachVariable); ok {
+				errs = append(errs, fmt.Errorf(
+					"%s: each variables are only valid within dynamic blocks: %s",
+					source, v.FullKey()))
+			}
+		}
+	}
+
 	// Validate the self variable
 	for source, rc := range c.rawConfigs() {
 		// Ignore provisioners. This is a pretty brittle way to do this,
@@ -593,6 +757,11 @@ func (c *Config) rawConfigs() map[string]*RawConfig {
 		}
 	}
 
+	for _, l := range c.Locals {
+		source := fmt.Sprintf("local '%s'", l.Name)
+		result[source] = l.RawConfig
+	}
+
 	for _, o := range c.Outputs {
 		source := fmt.Sprintf("output '%s'", o.Name)
 		result[source] = o.RawConfig
@@ -665,6 +834,23 @@ func (m *Module) mergerMerge(other merger) merger {
 	if m2.Source != "" {
 		result.Source = m2.Source
 	}
+	if m2.Version != "" {
+		result.Version = m2.Version
+	}
+
+	return &result
+}
+
+func (l *Local) mergerName() string {
+	return l.Name
+}
+
+func (l *Local) mergerMerge(m merger) merger {
+	l2 := m.(*Local)
+
+	result := *l
+	result.Name = l2.Name
+	result.RawConfig = result.RawConfig.merge(l2.RawConfig)
 
 	return &result
 }
@@ -702,11 +888,15 @@ func (c *ProviderConfig) mergerMerge(m merger) merger {
 	result.Name = c2.Name
 	result.RawConfig = result.RawConfig.merge(c2.RawConfig)
 
+	if c2.Version != "" {
+		result.Version = c2.Version
+	}
+
 	return &result
 }
 
 func (r *Resource) mergerName() string {
-	return fmt.Sprintf("%s.%s", r.Type, r.Name)
+	return r.Id()
 }
 
 func (r *Resource) mergerMerge(m merger) merger {
@@ -725,6 +915,16 @@ func (r *Resource) mergerMerge(m merger) merger {
 		result.Provisioners = r2.Provisioners
 	}
 
+	if len(r2.VarPos) > 0 {
+		result.VarPos = make(map[string]Pos)
+		for k, v := range r.VarPos {
+			result.VarPos[k] = v
+		}
+		for k, v := range r2.VarPos {
+			result.VarPos[k] = v
+		}
+	}
+
 	return &result
 }
 
@@ -740,7 +940,7 @@ func (v *Variable) DefaultsMap() map[string]string {
 		return map[string]string{n: v.Default.(string)}
 	case VariableTypeMap:
 		result := flatmap.Flatten(map[string]interface{}{
-			n: v.Default.(map[string]string),
+			n: v.Default,
 		})
 		result[n] = v.Name
 
@@ -780,8 +980,7 @@ func (v *Variable) Type() VariableType {
 		return VariableTypeString
 	}
 
-	var m map[string]string
-	if err := mapstructure.WeakDecode(v.Default, &m); err == nil {
+	if m, err := NormalizeVariableMap(v.Default); err == nil {
 		v.Default = m
 		return VariableTypeMap
 	}
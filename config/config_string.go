@@ -41,6 +41,12 @@ func (c *Config) TestString() string {
 		buf.WriteString("\n\n")
 	}
 
+	if len(c.Locals) > 0 {
+		buf.WriteString("Locals:\n\n")
+		buf.WriteString(localsStr(c.Locals))
+		buf.WriteString("\n\n")
+	}
+
 	if len(c.Outputs) > 0 {
 		buf.WriteString("Outputs:\n\n")
 		buf.WriteString(outputsStr(c.Outputs))
@@ -76,6 +82,9 @@ func modulesStr(ms []*Module) string {
 		sort.Strings(ks)
 
 		result += fmt.Sprintf("  source = %s\n", m.Source)
+		if m.Version != "" {
+			result += fmt.Sprintf("  version = %s\n", m.Version)
+		}
 
 		for _, k := range ks {
 			result += fmt.Sprintf("  %s\n", k)
@@ -85,6 +94,44 @@ func modulesStr(ms []*Module) string {
 	return strings.TrimSpace(result)
 }
 
+func localsStr(ls []*Local) string {
+	ns := make([]string, 0, len(ls))
+	m := make(map[string]*Local)
+	for _, l := range ls {
+		ns = append(ns, l.Name)
+		m[l.Name] = l
+	}
+	sort.Strings(ns)
+
+	result := ""
+	for _, n := range ns {
+		l := m[n]
+
+		result += fmt.Sprintf("%s\n", n)
+
+		if len(l.RawConfig.Variables) > 0 {
+			result += fmt.Sprintf("  vars\n")
+			for _, rawV := range l.RawConfig.Variables {
+				kind := "unknown"
+				str := rawV.FullKey()
+
+				switch rawV.(type) {
+				case *LocalVariable:
+					kind = "local"
+				case *ResourceVariable:
+					kind = "resource"
+				case *UserVariable:
+					kind = "user"
+				}
+
+				result += fmt.Sprintf("    %s: %s\n", kind, str)
+			}
+		}
+	}
+
+	return strings.TrimSpace(result)
+}
+
 func outputsStr(os []*Output) string {
 	ns := make([]string, 0, len(os))
 	m := make(map[string]*Output)
@@ -138,6 +185,9 @@ func providerConfigsStr(pcs []*ProviderConfig) string {
 		pc := m[n]
 
 		result += fmt.Sprintf("%s\n", n)
+		if pc.Version != "" {
+			result += fmt.Sprintf("  version = %s\n", pc.Version)
+		}
 
 		keys := make([]string, 0, len(pc.RawConfig.Raw))
 		for k, _ := range pc.RawConfig.Raw {
@@ -178,7 +228,7 @@ func resourcesStr(rs []*Resource) string {
 	ks := make([]string, 0, len(rs))
 	mapping := make(map[string]int)
 	for i, r := range rs {
-		k := fmt.Sprintf("%s[%s]", r.Type, r.Name)
+		k := resourceStrKey(r)
 		ks = append(ks, k)
 		mapping[k] = i
 	}
@@ -190,9 +240,8 @@ func resourcesStr(rs []*Resource) string {
 	for _, i := range order {
 		r := rs[i]
 		result += fmt.Sprintf(
-			"%s[%s] (x%s)\n",
-			r.Type,
-			r.Name,
+			"%s (x%s)\n",
+			resourceStrKey(r),
 			r.RawCount.Value())
 
 		ks := make([]string, 0, len(r.RawConfig.Raw))
@@ -295,3 +344,13 @@ func variablesStr(vs []*Variable) string {
 
 	return strings.TrimSpace(result)
 }
+
+// resourceStrKey returns the key of the resource in resourcesStr, which
+// is prefixed with "data." for data sources.
+func resourceStrKey(r *Resource) string {
+	if r.Mode == DataResourceMode {
+		return fmt.Sprintf("data.%s[%s]", r.Type, r.Name)
+	}
+
+	return fmt.Sprintf("%s[%s]", r.Type, r.Name)
+}
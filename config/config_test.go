@@ -84,6 +84,13 @@ func TestConfigValidate_countCountVar(t *testing.T) {
 	}
 }
 
+func TestConfigValidate_countLocalVar(t *testing.T) {
+	c := testConfig(t, "validate-count-local-var")
+	if err := c.Validate(); err == nil {
+		t.Fatal("should not be valid")
+	}
+}
+
 func TestConfigValidate_countModuleVar(t *testing.T) {
 	c := testConfig(t, "validate-count-module-var")
 	if err := c.Validate(); err == nil {
@@ -98,6 +105,13 @@ func TestConfigValidate_countNotInt(t *testing.T) {
 	}
 }
 
+func TestConfigValidate_countDataSourceVar(t *testing.T) {
+	c := testConfig(t, "validate-count-data-source-var")
+	if err := c.Validate(); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+}
+
 func TestConfigValidate_countResourceVar(t *testing.T) {
 	c := testConfig(t, "validate-count-resource-var")
 	if err := c.Validate(); err == nil {
@@ -133,6 +147,20 @@ func TestConfigValidate_dependsOnVar(t *testing.T) {
 	}
 }
 
+func TestConfigValidate_dataSource(t *testing.T) {
+	c := testConfig(t, "validate-data-source")
+	if err := c.Validate(); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+}
+
+func TestConfigValidate_dataSourceRef(t *testing.T) {
+	c := testConfig(t, "validate-data-source-ref")
+	if err := c.Validate(); err == nil {
+		t.Fatal("should not be valid")
+	}
+}
+
 func TestConfigValidate_dupModule(t *testing.T) {
 	c := testConfig(t, "validate-dup-module")
 	if err := c.Validate(); err == nil {
@@ -147,6 +175,48 @@ func TestConfigValidate_dupResource(t *testing.T) {
 	}
 }
 
+func TestConfigValidate_dynamicBad(t *testing.T) {
+	c := testConfig(t, "validate-dynamic-bad")
+	if err := c.Validate(); err == nil {
+		t.Fatal("should not be valid")
+	}
+}
+
+func TestConfigValidate_dynamicGood(t *testing.T) {
+	c := testConfig(t, "validate-dynamic-good")
+	if err := c.Validate(); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+}
+
+func TestConfigValidate_eachVarContext(t *testing.T) {
+	c := testConfig(t, "validate-each-var-context")
+	if err := c.Validate(); err == nil {
+		t.Fatal("should not be valid")
+	}
+}
+
+func TestConfigValidate_localCountVar(t *testing.T) {
+	c := testConfig(t, "validate-local-count")
+	if err := c.Validate(); err == nil {
+		t.Fatal("should not be valid")
+	}
+}
+
+func TestConfigValidate_localGood(t *testing.T) {
+	c := testConfig(t, "validate-local-good")
+	if err := c.Validate(); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+}
+
+func TestConfigValidate_localUnknown(t *testing.T) {
+	c := testConfig(t, "validate-local-unknown")
+	if err := c.Validate(); err == nil {
+		t.Fatal("should not be valid")
+	}
+}
+
 func TestConfigValidate_moduleNameBad(t *testing.T) {
 	c := testConfig(t, "validate-module-name-bad")
 	if err := c.Validate(); err == nil {
@@ -175,6 +245,13 @@ func TestConfigValidate_moduleVarMap(t *testing.T) {
 	}
 }
 
+func TestConfigValidate_moduleVarNestedMap(t *testing.T) {
+	c := testConfig(t, "validate-module-var-nested-map")
+	if err := c.Validate(); err != nil {
+		t.Fatalf("should be valid: %s", err)
+	}
+}
+
 func TestConfigValidate_moduleVarSelf(t *testing.T) {
 	c := testConfig(t, "validate-module-var-self")
 	if err := c.Validate(); err == nil {
@@ -182,6 +259,27 @@ func TestConfigValidate_moduleVarSelf(t *testing.T) {
 	}
 }
 
+func TestConfigValidate_moduleVersionBad(t *testing.T) {
+	c := testConfig(t, "validate-module-version-bad")
+	if err := c.Validate(); err == nil {
+		t.Fatal("should be invalid")
+	}
+}
+
+func TestConfigValidate_moduleVersionGood(t *testing.T) {
+	c := testConfig(t, "validate-module-version-good")
+	if err := c.Validate(); err != nil {
+		t.Fatalf("should be valid: %s", err)
+	}
+}
+
+func TestConfigValidate_providerVersionBad(t *testing.T) {
+	c := testConfig(t, "validate-provider-version-bad")
+	if err := c.Validate(); err == nil {
+		t.Fatal("should be invalid")
+	}
+}
+
 func TestConfigValidate_nil(t *testing.T) {
 	var c Config
 	if err := c.Validate(); err != nil {
@@ -210,6 +308,20 @@ func TestConfigValidate_pathVarInvalid(t *testing.T) {
 	}
 }
 
+func TestConfigValidate_terraformVar(t *testing.T) {
+	c := testConfig(t, "validate-terraform-var")
+	if err := c.Validate(); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+}
+
+func TestConfigValidate_terraformVarInvalid(t *testing.T) {
+	c := testConfig(t, "validate-terraform-var-invalid")
+	if err := c.Validate(); err == nil {
+		t.Fatal("should not be valid")
+	}
+}
+
 func TestConfigValidate_providerMulti(t *testing.T) {
 	c := testConfig(t, "validate-provider-multi")
 	if err := c.Validate(); err == nil {
@@ -322,6 +434,13 @@ func TestConfigValidate_varDefault(t *testing.T) {
 	}
 }
 
+func TestConfigValidate_varDefaultNestedMap(t *testing.T) {
+	c := testConfig(t, "validate-var-default-nested-map")
+	if err := c.Validate(); err != nil {
+		t.Fatalf("should be valid: %s", err)
+	}
+}
+
 func TestConfigValidate_varDefaultBadType(t *testing.T) {
 	c := testConfig(t, "validate-var-default-bad-type")
 	if err := c.Validate(); err == nil {
@@ -438,6 +557,28 @@ func TestVariableDefaultsMap(t *testing.T) {
 				"var.foo.bar": "baz",
 			},
 		},
+
+		{
+			[]map[string]interface{}{
+				map[string]interface{}{
+					"prod": []map[string]interface{}{
+						map[string]interface{}{
+							"cidr": "10.0.0.0/16",
+							"azs":  []interface{}{"a", "b"},
+							"size": 3,
+						},
+					},
+				},
+			},
+			map[string]string{
+				"var.foo":            "foo",
+				"var.foo.prod.cidr":  "10.0.0.0/16",
+				"var.foo.prod.azs.#": "2",
+				"var.foo.prod.azs.0": "a",
+				"var.foo.prod.azs.1": "b",
+				"var.foo.prod.size":  "3",
+			},
+		},
 	}
 
 	for i, tc := range cases {
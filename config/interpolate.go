@@ -31,6 +31,32 @@ const (
 	CountValueIndex
 )
 
+// EachVariable is a variable for referencing the current element when
+// generating nested blocks with a dynamic block, such as "${each.value}".
+type EachVariable struct {
+	Type EachValueType
+	key  string
+}
+
+// EachValueType is the type of the each variable that is referenced.
+type EachValueType byte
+
+const (
+	EachValueInvalid EachValueType = iota
+	EachValueIndex
+	EachValueValue
+)
+
+// A LocalVariable is a variable that is referencing a local value
+// within the same module, such as "${local.foo}". Elem is set when
+// referencing a key of a map value: "${local.tags.Name}".
+type LocalVariable struct {
+	Name string
+	Elem string
+
+	key string
+}
+
 // A ModuleVariable is a variable that is referencing the output
 // of a module, such as "${module.foo.bar}"
 type ModuleVariable struct {
@@ -56,8 +82,10 @@ const (
 )
 
 // A ResourceVariable is a variable that is referencing the field
-// of a resource, such as "${aws_instance.foo.ami}"
+// of a resource, such as "${aws_instance.foo.ami}", or of a data source,
+// such as "${data.aws_ami.ubuntu.id}"
 type ResourceVariable struct {
+	Mode  ResourceMode
 	Type  string // Resource type, i.e. "aws_instance"
 	Name  string // Resource name
 	Field string // Resource field
@@ -76,6 +104,20 @@ type SelfVariable struct {
 	key string
 }
 
+// A TerraformVariable is a variable that references information about
+// the Terraform run itself, such as "${terraform.env}".
+type TerraformVariable struct {
+	Type TerraformValueType
+	key  string
+}
+
+type TerraformValueType byte
+
+const (
+	TerraformValueInvalid TerraformValueType = iota
+	TerraformValueEnv
+)
+
 // A UserVariable is a variable that is referencing a user variable
 // that is inputted from outside the configuration. This looks like
 // "${var.foo}"
@@ -89,12 +131,18 @@ type UserVariable struct {
 func NewInterpolatedVariable(v string) (InterpolatedVariable, error) {
 	if strings.HasPrefix(v, "count.") {
 		return NewCountVariable(v)
+	} else if strings.HasPrefix(v, "each.") {
+		return NewEachVariable(v)
 	} else if strings.HasPrefix(v, "path.") {
 		return NewPathVariable(v)
 	} else if strings.HasPrefix(v, "self.") {
 		return NewSelfVariable(v)
+	} else if strings.HasPrefix(v, "terraform.") {
+		return NewTerraformVariable(v)
 	} else if strings.HasPrefix(v, "var.") {
 		return NewUserVariable(v)
+	} else if strings.HasPrefix(v, "local.") {
+		return NewLocalVariable(v)
 	} else if strings.HasPrefix(v, "module.") {
 		return NewModuleVariable(v)
 	} else {
@@ -120,6 +168,45 @@ func (c *CountVariable) FullKey() string {
 	return c.key
 }
 
+func NewEachVariable(key string) (*EachVariable, error) {
+	var fieldType EachValueType
+	parts := strings.SplitN(key, ".", 2)
+	switch parts[1] {
+	case "index":
+		fieldType = EachValueIndex
+	case "value":
+		fieldType = EachValueValue
+	}
+
+	return &EachVariable{
+		Type: fieldType,
+		key:  key,
+	}, nil
+}
+
+func (v *EachVariable) FullKey() string {
+	return v.key
+}
+
+func NewLocalVariable(key string) (*LocalVariable, error) {
+	name := key[len("local."):]
+	elem := ""
+	if idx := strings.Index(name, "."); idx > -1 {
+		elem = name[idx+1:]
+		name = name[:idx]
+	}
+
+	return &LocalVariable{
+		Name: name,
+		Elem: elem,
+		key:  key,
+	}, nil
+}
+
+func (v *LocalVariable) FullKey() string {
+	return v.key
+}
+
 func NewModuleVariable(key string) (*ModuleVariable, error) {
 	parts := strings.SplitN(key, ".", 3)
 	if len(parts) < 3 {
@@ -161,8 +248,31 @@ func (v *PathVariable) FullKey() string {
 	return v.key
 }
 
+func NewTerraformVariable(key string) (*TerraformVariable, error) {
+	var fieldType TerraformValueType
+	parts := strings.SplitN(key, ".", 2)
+	switch parts[1] {
+	case "env":
+		fieldType = TerraformValueEnv
+	}
+
+	return &TerraformVariable{
+		Type: fieldType,
+		key:  key,
+	}, nil
+}
+
+func (v *TerraformVariable) FullKey() string {
+	return v.key
+}
+
 func NewResourceVariable(key string) (*ResourceVariable, error) {
+	mode := ManagedResourceMode
 	parts := strings.SplitN(key, ".", 3)
+	if strings.HasPrefix(key, "data.") {
+		mode = DataResourceMode
+		parts = strings.SplitN(key[len("data."):], ".", 3)
+	}
 	if len(parts) < 3 {
 		return nil, fmt.Errorf(
 			"%s: resource variables must be three parts: type.name.attr",
@@ -192,6 +302,7 @@ func NewResourceVariable(key string) (*ResourceVariable, error) {
 	}
 
 	return &ResourceVariable{
+		Mode:  mode,
 		Type:  parts[0],
 		Name:  parts[1],
 		Field: field,
@@ -202,6 +313,10 @@ func NewResourceVariable(key string) (*ResourceVariable, error) {
 }
 
 func (v *ResourceVariable) ResourceId() string {
+	if v.Mode == DataResourceMode {
+		return fmt.Sprintf("data.%s.%s", v.Type, v.Name)
+	}
+
 	return fmt.Sprintf("%s.%s", v.Type, v.Name)
 }
 
@@ -263,6 +378,19 @@ func DetectVariables(root ast.Node) ([]InterpolatedVariable, error) {
 			return n
 		}
 
+		// templatefile looks up relative paths in the directory of
+		// the module, so it depends on the path of the module.
+		if cn, ok := n.(*ast.Call); ok && cn.Func == "templatefile" {
+			v, err := NewPathVariable("path.module")
+			if err != nil {
+				resultErr = err
+				return n
+			}
+
+			result = append(result, v)
+			return n
+		}
+
 		vn, ok := n.(*ast.VariableAccess)
 		if !ok {
 			return n
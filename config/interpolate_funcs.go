@@ -2,12 +2,21 @@ package config
 
 import (
 	"bytes"
+	"crypto/md5"
+	"crypto/sha1"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
+	"hash"
+	"io"
 	"io/ioutil"
+	"os"
+	"path/filepath"
 	"regexp"
 	"strconv"
 	"strings"
 
+	"github.com/hashicorp/terraform/config/lang"
 	"github.com/hashicorp/terraform/config/lang/ast"
 	"github.com/mitchellh/go-homedir"
 )
@@ -17,13 +26,17 @@ var Funcs map[string]ast.Function
 
 func init() {
 	Funcs = map[string]ast.Function{
-		"file":    interpolationFuncFile(),
-		"format":  interpolationFuncFormat(),
-		"join":    interpolationFuncJoin(),
-		"element": interpolationFuncElement(),
-		"replace": interpolationFuncReplace(),
-		"split":   interpolationFuncSplit(),
-		"length":  interpolationFuncLength(),
+		"file":         interpolationFuncFile(),
+		"filemd5":      interpolationFuncFileHash(md5.New),
+		"filesha1":     interpolationFuncFileHash(sha1.New),
+		"filesha256":   interpolationFuncFileHash(sha256.New),
+		"format":       interpolationFuncFormat(),
+		"join":         interpolationFuncJoin(),
+		"element":      interpolationFuncElement(),
+		"replace":      interpolationFuncReplace(),
+		"split":        interpolationFuncSplit(),
+		"templatefile": interpolationFuncTemplateFile(""),
+		"length":       interpolationFuncLength(),
 
 		// Concat is a little useless now since we supported embeddded
 		// interpolations but we keep it around for backwards compat reasons.
@@ -73,6 +86,130 @@ func interpolationFuncFile() ast.Function {
 	}
 }
 
+// interpolationFuncFileHash implements the "filemd5", "filesha1" and
+// "filesha256" functions, which return the hex encoded hash of the
+// contents of a file, such as to name objects by their content or to
+// detect changes of the files uploaded by a resource.
+func interpolationFuncFileHash(newHash func() hash.Hash) ast.Function {
+	return ast.Function{
+		ArgTypes:   []ast.Type{ast.TypeString},
+		ReturnType: ast.TypeString,
+		Callback: func(args []interface{}) (interface{}, error) {
+			path, err := homedir.Expand(args[0].(string))
+			if err != nil {
+				return "", err
+			}
+			f, err := os.Open(path)
+			if err != nil {
+				return "", err
+			}
+			defer f.Close()
+
+			// The file is hashed as it is read, since it can be large,
+			// such as the archive of a Lambda function.
+			h := newHash()
+			if _, err := io.Copy(h, f); err != nil {
+				return "", err
+			}
+
+			return hex.EncodeToString(h.Sum(nil)), nil
+		},
+	}
+}
+
+// interpolationFuncTemplateFile implements the "templatefile" function
+// that renders a template file in place, like the template_file resource
+// does. The language has no maps, so the variables of the template are
+// given as pairs of a name and a value, such as
+// templatefile("init.tpl", "hostname", "web", "port", 8080).
+//
+// Relative paths are relative to dir, the directory of the module, if it
+// is given. Templates can call templatefile too, but can't render a
+// template that is already being rendered, since that never ends.
+func interpolationFuncTemplateFile(dir string) ast.Function {
+	return templateFileFunc(dir, nil)
+}
+
+// templateFileFunc returns the "templatefile" function for templates
+// called from the templates in stack, by their absolute paths.
+func templateFileFunc(dir string, stack []string) ast.Function {
+	return ast.Function{
+		ArgTypes:     []ast.Type{ast.TypeString},
+		Variadic:     true,
+		VariadicType: ast.TypeString,
+		ReturnType:   ast.TypeString,
+		Callback: func(args []interface{}) (interface{}, error) {
+			filename := args[0].(string)
+			pairs := args[1:]
+			if len(pairs)%2 != 0 {
+				return "", fmt.Errorf(
+					"templatefile: expected pairs of variable names and values, "+
+						"got %d arguments after the path", len(pairs))
+			}
+
+			vars := make(map[string]ast.Variable)
+			for i := 0; i < len(pairs); i += 2 {
+				vars[pairs[i].(string)] = ast.Variable{
+					Value: pairs[i+1].(string),
+					Type:  ast.TypeString,
+				}
+			}
+
+			path, err := homedir.Expand(filename)
+			if err != nil {
+				return "", err
+			}
+			if !filepath.IsAbs(path) && dir != "" {
+				path = filepath.Join(dir, path)
+			}
+			if path, err = filepath.Abs(path); err != nil {
+				return "", err
+			}
+			for _, p := range stack {
+				if p == path {
+					return "", fmt.Errorf(
+						"templatefile: %s renders itself", filename)
+				}
+			}
+
+			data, err := ioutil.ReadFile(path)
+			if err != nil {
+				return "", err
+			}
+
+			root, err := lang.Parse(string(data))
+			if err != nil {
+				return "", fmt.Errorf("failed to parse %s: %s", filename, err)
+			}
+
+			// Templates rendered by this one can't render it again
+			funcMap := make(map[string]ast.Function)
+			for k, v := range Funcs {
+				funcMap[k] = v
+			}
+			inner := make([]string, len(stack), len(stack)+1)
+			copy(inner, stack)
+			funcMap["templatefile"] = templateFileFunc(dir, append(inner, path))
+
+			out, typ, err := lang.Eval(root, &lang.EvalConfig{
+				GlobalScope: &ast.BasicScope{
+					VarMap:  vars,
+					FuncMap: funcMap,
+				},
+			})
+			if err != nil {
+				return "", fmt.Errorf("failed to render %s: %s", filename, err)
+			}
+			if typ != ast.TypeString {
+				return "", fmt.Errorf(
+					"failed to render %s: unexpected type %s", filename, typ)
+			}
+
+			return out.(string), nil
+		},
+	}
+}
+
 // interpolationFuncFormat implements the "replace" function that does
 // string replacement.
 func interpolationFuncFormat() ast.Function {
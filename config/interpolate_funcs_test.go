@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"io/ioutil"
 	"os"
+	"path/filepath"
 	"reflect"
 	"testing"
 
@@ -70,6 +71,148 @@ func TestInterpolateFuncFile(t *testing.T) {
 	})
 }
 
+func TestInterpolateFuncFileHash(t *testing.T) {
+	tf, err := ioutil.TempFile("", "tf")
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	path := tf.Name()
+	tf.Write([]byte("foo"))
+	tf.Close()
+	defer os.Remove(path)
+
+	testFunction(t, testFunctionConfig{
+		Cases: []testFunctionCase{
+			{
+				fmt.Sprintf(`${filemd5("%s")}`, path),
+				"acbd18db4cc2f85cedef654fccc4a4d8",
+				false,
+			},
+
+			{
+				fmt.Sprintf(`${filesha1("%s")}`, path),
+				"0beec7b5ea3f0fdbc95d0dd47f3c5bc275da8a33",
+				false,
+			},
+
+			{
+				fmt.Sprintf(`${filesha256("%s")}`, path),
+				"2c26b46b68ffc68ff99b453c1d30413413422d706483bfa0f98a5e886266e7ae",
+				false,
+			},
+
+			// Invalid path
+			{
+				`${filesha256("/i/dont/exist")}`,
+				nil,
+				true,
+			},
+
+			// Too many args
+			{
+				`${filemd5("foo", "bar")}`,
+				nil,
+				true,
+			},
+		},
+	})
+}
+
+func TestInterpolateFuncTemplateFile(t *testing.T) {
+	tf, err := ioutil.TempFile("", "tf")
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	path := tf.Name()
+	tf.Write([]byte(`hello ${name}:${port}, ${replace(name, "o", "0")}`))
+	tf.Close()
+	defer os.Remove(path)
+
+	testFunction(t, testFunctionConfig{
+		Cases: []testFunctionCase{
+			{
+				fmt.Sprintf(`${templatefile("%s", "name", "world", "port", 8080)}`, path),
+				"hello world:8080, w0rld",
+				false,
+			},
+
+			// Missing variable
+			{
+				fmt.Sprintf(`${templatefile("%s", "name", "world")}`, path),
+				nil,
+				true,
+			},
+
+			// Variable without a value
+			{
+				fmt.Sprintf(`${templatefile("%s", "name", "world", "port")}`, path),
+				nil,
+				true,
+			},
+
+			// Invalid path
+			{
+				`${templatefile("/i/dont/exist")}`,
+				nil,
+				true,
+			},
+		},
+	})
+}
+
+func TestInterpolateFuncTemplateFile_module(t *testing.T) {
+	dir, err := ioutil.TempDir("", "tf")
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	defer os.RemoveAll(dir)
+
+	files := map[string]string{
+		"hello.tpl": `hello ${templatefile("name.tpl", "name", name)}`,
+		"name.tpl":  `${name}`,
+		"self.tpl":  `${templatefile("self.tpl")}`,
+		"a.tpl":     `${templatefile("b.tpl")}`,
+		"b.tpl":     `${templatefile("a.tpl")}`,
+	}
+	for name, data := range files {
+		path := filepath.Join(dir, name)
+		if err := ioutil.WriteFile(path, []byte(data), 0644); err != nil {
+			t.Fatalf("err: %s", err)
+		}
+	}
+
+	testFunction(t, testFunctionConfig{
+		Cases: []testFunctionCase{
+			// Relative to the module, also from a template
+			{
+				`${templatefile("hello.tpl", "name", "world")}`,
+				"hello world",
+				false,
+			},
+
+			// Rendering itself
+			{
+				`${templatefile("self.tpl")}`,
+				nil,
+				true,
+			},
+
+			// Rendering itself through another template
+			{
+				`${templatefile("a.tpl")}`,
+				nil,
+				true,
+			},
+		},
+		Vars: map[string]ast.Variable{
+			"path.module": ast.Variable{
+				Value: dir,
+				Type:  ast.TypeString,
+			},
+		},
+	})
+}
+
 func TestInterpolateFuncFormat(t *testing.T) {
 	testFunction(t, testFunctionConfig{
 		Cases: []testFunctionCase{
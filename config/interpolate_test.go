@@ -21,6 +21,23 @@ func TestNewInterpolatedVariable(t *testing.T) {
 			},
 			false,
 		},
+		{
+			"local.foo",
+			&LocalVariable{
+				Name: "foo",
+				key:  "local.foo",
+			},
+			false,
+		},
+		{
+			"local.foo.bar",
+			&LocalVariable{
+				Name: "foo",
+				Elem: "bar",
+				key:  "local.foo.bar",
+			},
+			false,
+		},
 		{
 			"module.foo.bar",
 			&ModuleVariable{
@@ -46,6 +63,22 @@ func TestNewInterpolatedVariable(t *testing.T) {
 			},
 			false,
 		},
+		{
+			"each.value",
+			&EachVariable{
+				Type: EachValueValue,
+				key:  "each.value",
+			},
+			false,
+		},
+		{
+			"each.nope",
+			&EachVariable{
+				Type: EachValueInvalid,
+				key:  "each.nope",
+			},
+			false,
+		},
 		{
 			"path.module",
 			&PathVariable{
@@ -62,6 +95,22 @@ func TestNewInterpolatedVariable(t *testing.T) {
 			},
 			false,
 		},
+		{
+			"terraform.env",
+			&TerraformVariable{
+				Type: TerraformValueEnv,
+				key:  "terraform.env",
+			},
+			false,
+		},
+		{
+			"terraform.nope",
+			&TerraformVariable{
+				Type: TerraformValueInvalid,
+				key:  "terraform.nope",
+			},
+			false,
+		},
 	}
 
 	for i, tc := range cases {
@@ -99,6 +148,32 @@ func TestNewResourceVariable(t *testing.T) {
 	}
 }
 
+func TestNewResourceVariable_data(t *testing.T) {
+	v, err := NewResourceVariable("data.foo.bar.baz")
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	if v.Mode != DataResourceMode {
+		t.Fatalf("bad: %#v", v)
+	}
+	if v.Type != "foo" {
+		t.Fatalf("bad: %#v", v)
+	}
+	if v.Name != "bar" {
+		t.Fatalf("bad: %#v", v)
+	}
+	if v.Field != "baz" {
+		t.Fatalf("bad: %#v", v)
+	}
+	if v.ResourceId() != "data.foo.bar" {
+		t.Fatalf("bad: %#v", v)
+	}
+	if v.FullKey() != "data.foo.bar.baz" {
+		t.Fatalf("bad: %#v", v)
+	}
+}
+
 func TestNewUserVariable(t *testing.T) {
 	v, err := NewUserVariable("var.bar")
 	if err != nil {
@@ -219,6 +294,16 @@ func TestDetectVariables(t *testing.T) {
 				},
 			},
 		},
+
+		{
+			`${templatefile("foo.tpl")}`,
+			[]InterpolatedVariable{
+				&PathVariable{
+					Type: PathValueModule,
+					key:  "path.module",
+				},
+			},
+		},
 	}
 
 	for _, tc := range cases {
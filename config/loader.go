@@ -1,8 +1,11 @@
 package config
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
 	"io"
+	"io/ioutil"
 	"os"
 	"path/filepath"
 	"sort"
@@ -115,6 +118,34 @@ func IsEmptyDir(root string) (bool, error) {
 	return len(fs) == 0 && len(os) == 0, nil
 }
 
+// DirHash returns a hash of the Terraform configuration files in the
+// given directory, which changes whenever any of the files loaded by
+// LoadDir is added, removed or changed.
+func DirHash(root string) (string, error) {
+	files, overrides, err := dirFiles(root)
+	if err != nil {
+		return "", err
+	}
+
+	paths := append(files, overrides...)
+	sort.Strings(paths)
+
+	h := sha256.New()
+	for _, path := range paths {
+		data, err := ioutil.ReadFile(path)
+		if err != nil {
+			return "", err
+		}
+
+		// Include the name and length so that moving content between
+		// files changes the hash too.
+		fmt.Fprintf(h, "%s\n%d\n", filepath.Base(path), len(data))
+		h.Write(data)
+	}
+
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
 // Ext returns the Terraform configuration extension of the given
 // path, or a blank string if it is an invalid function.
 func ext(path string) string {
@@ -12,12 +12,15 @@ import (
 // how to turn HCL configuration into a *Config object.
 type hclConfigurable struct {
 	File   string
+	Source string
 	Object *hclobj.Object
 }
 
 func (t *hclConfigurable) Config() (*Config, error) {
 	validKeys := map[string]struct{}{
 		"atlas":    struct{}{},
+		"data":     struct{}{},
+		"locals":   struct{}{},
 		"module":   struct{}{},
 		"output":   struct{}{},
 		"provider": struct{}{},
@@ -101,7 +104,29 @@ func (t *hclConfigurable) Config() (*Config, error) {
 	// Build the resources
 	if resources := t.Object.Get("resource", false); resources != nil {
 		var err error
-		config.Resources, err = loadResourcesHcl(resources)
+		config.Resources, err = loadResourcesHcl(resources, ManagedResourceMode)
+		if err != nil {
+			return nil, err
+		}
+
+		setResourcePos(t.File, t.Source, config.Resources)
+	}
+
+	// Build the data sources, which are resources that are only read
+	if dataSources := t.Object.Get("data", false); dataSources != nil {
+		ds, err := loadResourcesHcl(dataSources, DataResourceMode)
+		if err != nil {
+			return nil, err
+		}
+
+		setResourcePos(t.File, t.Source, ds)
+		config.Resources = append(config.Resources, ds...)
+	}
+
+	// Build the local values
+	if locals := t.Object.Get("locals", false); locals != nil {
+		var err error
+		config.Locals, err = loadLocalsHcl(locals)
 		if err != nil {
 			return nil, err
 		}
@@ -151,6 +176,7 @@ func loadFileHcl(root string) (configurable, []string, error) {
 	// Start building the result
 	result := &hclConfigurable{
 		File:   root,
+		Source: string(d),
 		Object: obj,
 	}
 
@@ -248,6 +274,7 @@ func loadModulesHcl(os *hclobj.Object) ([]*Module, error) {
 
 		// Remove the fields we handle specially
 		delete(config, "source")
+		delete(config, "version")
 
 		rawConfig, err := NewRawConfig(config)
 		if err != nil {
@@ -269,9 +296,71 @@ func loadModulesHcl(os *hclobj.Object) ([]*Module, error) {
 			}
 		}
 
+		// If we have a version constraint, then read it
+		var version string
+		if o := obj.Get("version", false); o != nil {
+			err = hcl.DecodeObject(&version, o)
+			if err != nil {
+				return nil, fmt.Errorf(
+					"Error parsing version for %s: %s",
+					k,
+					err)
+			}
+		}
+
 		result = append(result, &Module{
 			Name:      k,
 			Source:    source,
+			Version:   version,
+			RawConfig: rawConfig,
+		})
+	}
+
+	return result, nil
+}
+
+// loadLocalsHcl recurses into the given HCL object and turns it into
+// a list of local values. Any number of "locals" blocks can be used.
+func loadLocalsHcl(os *hclobj.Object) ([]*Local, error) {
+	objects := make(map[string]*hclobj.Object)
+
+	// Iterate over all the "locals" blocks and get the keys along with
+	// their raw values. We'll parse those later.
+	for _, o1 := range os.Elem(false) {
+		for _, o2 := range o1.Elem(true) {
+			if _, ok := objects[o2.Key]; ok {
+				return nil, fmt.Errorf(
+					"local value %s: declared multiple times", o2.Key)
+			}
+
+			objects[o2.Key] = o2
+		}
+	}
+
+	if len(objects) == 0 {
+		return nil, nil
+	}
+
+	// Go through each object and turn it into an actual result.
+	result := make([]*Local, 0, len(objects))
+	for n, o := range objects {
+		var value interface{}
+		if err := hcl.DecodeObject(&value, o); err != nil {
+			return nil, err
+		}
+
+		rawConfig, err := NewRawConfig(map[string]interface{}{
+			"value": value,
+		})
+		if err != nil {
+			return nil, fmt.Errorf(
+				"Error reading config for local value %s: %s",
+				n,
+				err)
+		}
+
+		result = append(result, &Local{
+			Name:      n,
 			RawConfig: rawConfig,
 		})
 	}
@@ -349,6 +438,7 @@ func loadProvidersHcl(os *hclobj.Object) ([]*ProviderConfig, error) {
 		}
 
 		delete(config, "alias")
+		delete(config, "version")
 
 		rawConfig, err := NewRawConfig(config)
 		if err != nil {
@@ -370,9 +460,22 @@ func loadProvidersHcl(os *hclobj.Object) ([]*ProviderConfig, error) {
 			}
 		}
 
+		// If we have a version constraint, then read it
+		var version string
+		if v := o.Get("version", false); v != nil {
+			err := hcl.DecodeObject(&version, v)
+			if err != nil {
+				return nil, fmt.Errorf(
+					"Error reading version for provider[%s]: %s",
+					o.Key,
+					err)
+			}
+		}
+
 		result = append(result, &ProviderConfig{
 			Name:      o.Key,
 			Alias:     alias,
+			Version:   version,
 			RawConfig: rawConfig,
 		})
 	}
@@ -386,7 +489,10 @@ func loadProvidersHcl(os *hclobj.Object) ([]*ProviderConfig, error) {
 // The resulting resources may not be unique, but each resource
 // represents exactly one resource definition in the HCL configuration.
 // We leave it up to another pass to merge them together.
-func loadResourcesHcl(os *hclobj.Object) ([]*Resource, error) {
+// loadResourcesHcl loads the resource blocks of the given mode, which are
+// either `resource` or `data` blocks. Data sources don't support
+// provisioners or lifecycle settings, since they are only read.
+func loadResourcesHcl(os *hclobj.Object, mode ResourceMode) ([]*Resource, error) {
 	var allTypes []*hclobj.Object
 
 	// HCL object iteration is really nasty. Below is likely to make
@@ -427,6 +533,18 @@ func loadResourcesHcl(os *hclobj.Object) ([]*Resource, error) {
 					err)
 			}
 
+			if mode == DataResourceMode {
+				for _, key := range []string{"connection", "lifecycle", "provisioner"} {
+					if obj.Get(key, false) != nil {
+						return nil, fmt.Errorf(
+							"data.%s[%s]: data sources can't have a %s block",
+							t.Key,
+							k,
+							key)
+					}
+				}
+			}
+
 			// Remove the fields we handle specially
 			delete(config, "connection")
 			delete(config, "count")
@@ -532,6 +650,7 @@ func loadResourcesHcl(os *hclobj.Object) ([]*Resource, error) {
 			}
 
 			result = append(result, &Resource{
+				Mode:         mode,
 				Name:         k,
 				Type:         t.Key,
 				RawCount:     countConfig,
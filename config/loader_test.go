@@ -1,6 +1,8 @@
 package config
 
 import (
+	"io/ioutil"
+	"os"
 	"path/filepath"
 	"reflect"
 	"strings"
@@ -37,6 +39,60 @@ func TestIsEmptyDir_noConfigs(t *testing.T) {
 	}
 }
 
+func TestDirHash(t *testing.T) {
+	dir, err := ioutil.TempDir("", "tf")
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	defer os.RemoveAll(dir)
+
+	write := func(name, content string) {
+		path := filepath.Join(dir, name)
+		if err := ioutil.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatalf("err: %s", err)
+		}
+	}
+	hash := func() string {
+		h, err := DirHash(dir)
+		if err != nil {
+			t.Fatalf("err: %s", err)
+		}
+		return h
+	}
+
+	write("main.tf", `variable "foo" {}`)
+	original := hash()
+	if hash() != original {
+		t.Fatal("hash should be stable")
+	}
+
+	// Ignored files don't change the hash
+	write("main.tf~", `variable "bar" {}`)
+	write("notes.txt", "notes")
+	if hash() != original {
+		t.Fatal("ignored files should not change the hash")
+	}
+
+	// Changing a file does
+	write("main.tf", `variable "bar" {}`)
+	changed := hash()
+	if changed == original {
+		t.Fatal("hash should change")
+	}
+
+	// So does adding an override file
+	write("override.tf", `variable "bar" { default = "baz" }`)
+	if hash() == changed {
+		t.Fatal("hash should change")
+	}
+}
+
+func TestDirHash_noExist(t *testing.T) {
+	if _, err := DirHash(filepath.Join(fixtureDir, "nopenopenope")); err == nil {
+		t.Fatal("should error")
+	}
+}
+
 func TestLoad_badType(t *testing.T) {
 	_, err := Load(filepath.Join(fixtureDir, "bad_type.tf.nope"))
 	if err == nil {
@@ -184,6 +240,42 @@ func TestLoadBasic_modules(t *testing.T) {
 	}
 }
 
+func TestLoadBasic_modulesVersion(t *testing.T) {
+	c, err := Load(filepath.Join(fixtureDir, "modules-version.tf"))
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	actual := modulesStr(c.Modules)
+	if actual != strings.TrimSpace(modulesVersionModulesStr) {
+		t.Fatalf("bad:\n%s", actual)
+	}
+}
+
+func TestLoadBasic_providersVersion(t *testing.T) {
+	c, err := Load(filepath.Join(fixtureDir, "providers-version.tf"))
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	actual := providerConfigsStr(c.ProviderConfigs)
+	if actual != strings.TrimSpace(providersVersionStr) {
+		t.Fatalf("bad:\n%s", actual)
+	}
+}
+
+func TestLoad_locals(t *testing.T) {
+	c, err := Load(filepath.Join(fixtureDir, "locals.tf"))
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	actual := localsStr(c.Locals)
+	if actual != strings.TrimSpace(localsLocalsStr) {
+		t.Fatalf("bad:\n%s", actual)
+	}
+}
+
 func TestLoad_variables(t *testing.T) {
 	c, err := Load(filepath.Join(fixtureDir, "variables.tf"))
 	if err != nil {
@@ -319,6 +411,57 @@ func TestLoad_provisioners(t *testing.T) {
 	}
 }
 
+func TestLoad_dataSources(t *testing.T) {
+	c, err := Load(filepath.Join(fixtureDir, "data-sources.tf"))
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	actual := resourcesStr(c.Resources)
+	if actual != strings.TrimSpace(dataSourcesResourcesStr) {
+		t.Fatalf("bad:\n%s", actual)
+	}
+
+	for _, r := range c.Resources {
+		if r.Id() == "data.aws_ami.ubuntu" {
+			if r.Mode != DataResourceMode {
+				t.Fatalf("bad: %#v", r)
+			}
+			if r.Pos.Line != 1 {
+				t.Fatalf("bad: %#v", r.Pos)
+			}
+		}
+	}
+}
+
+func TestLoad_dataSourceProvisioner(t *testing.T) {
+	_, err := Load(filepath.Join(fixtureDir, "data-source-provisioner.tf"))
+	if err == nil {
+		t.Fatal("should error")
+	}
+}
+
+func TestLoad_resourcePos(t *testing.T) {
+	path := filepath.Join(fixtureDir, "provisioners.tf")
+	c, err := Load(path)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	r := c.Resources[0]
+	if r.Pos != (Pos{Filename: path, Line: 1}) {
+		t.Fatalf("bad: %#v", r.Pos)
+	}
+
+	expected := map[string]Pos{
+		"var.foo":                         Pos{Filename: path, Line: 2},
+		"aws_security_group.firewall.foo": Pos{Filename: path, Line: 5},
+	}
+	if !reflect.DeepEqual(r.VarPos, expected) {
+		t.Fatalf("bad: %#v", r.VarPos)
+	}
+}
+
 func TestLoad_connections(t *testing.T) {
 	c, err := Load(filepath.Join(fixtureDir, "connection.tf"))
 	if err != nil {
@@ -549,6 +692,42 @@ bar
   memory
 `
 
+const localsLocalsStr = `
+name
+  vars
+    local: local.prefix
+    resource: aws_instance.web.id
+prefix
+  vars
+    user: var.env
+tags
+  vars
+    local: local.prefix
+`
+
+const modulesVersionModulesStr = `
+bar
+  source = baz
+  version = ~> 1.2
+  memory
+`
+
+const providersVersionStr = `
+aws
+  version = ~> 1.2
+  region
+`
+
+const dataSourcesResourcesStr = `
+aws_instance[web] (x1)
+  ami
+  vars
+    resource: data.aws_ami.ubuntu.id
+data.aws_ami[ubuntu] (x1)
+  most_recent
+  owners
+`
+
 const provisionerResourcesStr = `
 aws_instance[web] (x1)
   ami
@@ -0,0 +1,40 @@
+package module
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+)
+
+// sshPattern matches SCP-style SSH addresses such as
+// "git@example.com:org/repo.git".
+var sshPattern = regexp.MustCompile(`^([A-Za-z0-9_.-]+)@([A-Za-z0-9_.-]+):([^?]+)(\?.*)?$`)
+
+// GitSSHDetector implements Detector to detect SCP-style SSH addresses
+// for any Git host and turn them into URLs that the Git Getter can
+// understand. Authentication is left to SSH itself, so keys loaded into
+// a running ssh-agent are used automatically.
+type GitSSHDetector struct{}
+
+func (d *GitSSHDetector) Detect(src, _ string) (string, bool, error) {
+	ms := sshPattern.FindStringSubmatch(src)
+	if ms == nil {
+		return "", false, nil
+	}
+
+	var u url.URL
+	u.Scheme = "ssh"
+	u.User = url.User(ms[1])
+	u.Host = ms[2]
+	u.Path = ms[3]
+	if ms[4] != "" {
+		q, err := url.ParseQuery(ms[4][1:])
+		if err != nil {
+			return "", true, fmt.Errorf("error parsing Git SSH URL: %s", err)
+		}
+
+		u.RawQuery = q.Encode()
+	}
+
+	return "git::" + u.String(), true, nil
+}
@@ -0,0 +1,60 @@
+package module
+
+import (
+	"testing"
+)
+
+func TestGitSSHDetector(t *testing.T) {
+	cases := []struct {
+		Input  string
+		Output string
+	}{
+		{
+			"git@example.com:org/modules.git",
+			"git::ssh://git@example.com/org/modules.git",
+		},
+		{
+			"deploy@git.example.com:modules.git",
+			"git::ssh://deploy@git.example.com/modules.git",
+		},
+		{
+			"git@example.com:org/modules.git?ref=v1.0&depth=1",
+			"git::ssh://git@example.com/org/modules.git?depth=1&ref=v1.0",
+		},
+	}
+
+	pwd := "/pwd"
+	f := new(GitSSHDetector)
+	for i, tc := range cases {
+		output, ok, err := f.Detect(tc.Input, pwd)
+		if err != nil {
+			t.Fatalf("err: %s", err)
+		}
+		if !ok {
+			t.Fatal("not ok")
+		}
+
+		if output != tc.Output {
+			t.Fatalf("%d: bad: %#v", i, output)
+		}
+	}
+}
+
+func TestGitSSHDetector_noMatch(t *testing.T) {
+	cases := []string{
+		"./foo",
+		"/foo/bar",
+		"github.com/hashicorp/foo",
+	}
+
+	f := new(GitSSHDetector)
+	for _, tc := range cases {
+		_, ok, err := f.Detect(tc, "/pwd")
+		if err != nil {
+			t.Fatalf("err: %s", err)
+		}
+		if ok {
+			t.Fatalf("%s: should not match", tc)
+		}
+	}
+}
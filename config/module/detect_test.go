@@ -37,6 +37,12 @@ func TestDetect(t *testing.T) {
 			"git::https://github.com/hashicorp/consul.git",
 			false,
 		},
+		{
+			"git@example.com:org/modules.git//vpc?ref=v1.0",
+			"",
+			"git::ssh://git@example.com/org/modules.git//vpc?ref=v1.0",
+			false,
+		},
 	}
 
 	for i, tc := range cases {
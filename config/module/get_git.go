@@ -1,14 +1,25 @@
 package module
 
 import (
+	"bufio"
+	"bytes"
 	"fmt"
 	"net/url"
 	"os"
 	"os/exec"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/go-version"
 )
 
 // GitGetter is a Getter implementation that will download a module from
 // a git repository.
+//
+// The "ref" query parameter selects a branch, tag, or commit to check out.
+// The "depth" query parameter makes a shallow clone with that many commits
+// of history, which is much faster for large repositories. A shallow clone
+// can only check out branches and tags, not arbitrary commits.
 type GitGetter struct{}
 
 func (g *GitGetter) Get(dst string, u *url.URL) error {
@@ -18,11 +29,21 @@ func (g *GitGetter) Get(dst string, u *url.URL) error {
 
 	// Extract some query parameters we use
 	var ref string
+	var depth int
 	q := u.Query()
 	if len(q) > 0 {
 		ref = q.Get("ref")
 		q.Del("ref")
 
+		if raw := q.Get("depth"); raw != "" {
+			var err error
+			depth, err = strconv.Atoi(raw)
+			if err != nil || depth < 1 {
+				return fmt.Errorf("depth must be a positive number: %s", raw)
+			}
+		}
+		q.Del("depth")
+
 		// Copy the URL
 		var newU url.URL = *u
 		u = &newU
@@ -35,16 +56,21 @@ func (g *GitGetter) Get(dst string, u *url.URL) error {
 		return err
 	}
 	if err == nil {
-		err = g.update(dst, u)
+		if depth > 0 {
+			err = g.fetchShallow(dst, ref, depth)
+		} else {
+			err = g.update(dst, u)
+		}
 	} else {
-		err = g.clone(dst, u)
+		err = g.clone(dst, u, ref, depth)
 	}
 	if err != nil {
 		return err
 	}
 
-	// Next: check out the proper tag/branch if it is specified, and checkout
-	if ref == "" {
+	// Next: check out the proper tag/branch if it is specified, and checkout.
+	// Shallow clones already have the ref checked out.
+	if ref == "" || depth > 0 {
 		return nil
 	}
 
@@ -57,8 +83,36 @@ func (g *GitGetter) checkout(dst string, ref string) error {
 	return getRunCommand(cmd)
 }
 
-func (g *GitGetter) clone(dst string, u *url.URL) error {
-	cmd := exec.Command("git", "clone", u.String(), dst)
+func (g *GitGetter) clone(dst string, u *url.URL, ref string, depth int) error {
+	args := []string{"clone"}
+	if depth > 0 {
+		args = append(args, "--depth", strconv.Itoa(depth))
+		if ref != "" {
+			args = append(args, "--branch", ref)
+		}
+	}
+	args = append(args, u.String(), dst)
+
+	cmd := exec.Command("git", args...)
+	return getRunCommand(cmd)
+}
+
+// fetchShallow updates a shallow clone by fetching only the ref we want
+// and checking it out.
+func (g *GitGetter) fetchShallow(dst string, ref string, depth int) error {
+	if ref == "" {
+		ref = "HEAD"
+	}
+
+	cmd := exec.Command(
+		"git", "fetch", "--depth", strconv.Itoa(depth), "origin", ref)
+	cmd.Dir = dst
+	if err := getRunCommand(cmd); err != nil {
+		return err
+	}
+
+	cmd = exec.Command("git", "checkout", "FETCH_HEAD")
+	cmd.Dir = dst
 	return getRunCommand(cmd)
 }
 
@@ -72,3 +126,92 @@ func (g *GitGetter) update(dst string, u *url.URL) error {
 	cmd.Dir = dst
 	return getRunCommand(cmd)
 }
+
+// Pin implements Pinner by pinning the ref to the commit that is
+// checked out. Since a shallow clone can't check out a commit, the
+// pinned URL always makes a full clone.
+func (g *GitGetter) Pin(dst string, u *url.URL) (*url.URL, error) {
+	var stderr bytes.Buffer
+	cmd := exec.Command("git", "rev-parse", "HEAD")
+	cmd.Dir = dst
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf(
+			"error reading commit: %s: %s", err, stderr.String())
+	}
+
+	var newU url.URL = *u
+	q := newU.Query()
+	q.Set("ref", strings.TrimSpace(string(out)))
+	q.Del("depth")
+	newU.RawQuery = q.Encode()
+	return &newU, nil
+}
+
+// Versions implements VersionLister. Every tag in the repository that
+// parses as a version, such as "v1.2.0" or "1.2.0", is a version of the
+// module.
+func (g *GitGetter) Versions(u *url.URL) ([]*ModuleVersion, error) {
+	if _, err := exec.LookPath("git"); err != nil {
+		return nil, fmt.Errorf("git must be available and on the PATH")
+	}
+
+	// Strip any ref from the URL since we'll be setting our own
+	var newU url.URL = *u
+	u = &newU
+	q := u.Query()
+	q.Del("ref")
+	u.RawQuery = q.Encode()
+
+	// Git itself doesn't understand the rest of our parameters either
+	var gitU url.URL = *u
+	gitQ := gitU.Query()
+	gitQ.Del("depth")
+	gitU.RawQuery = gitQ.Encode()
+
+	var stderr bytes.Buffer
+	cmd := exec.Command("git", "ls-remote", "--tags", gitU.String())
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf(
+			"error listing tags: %s: %s", err, stderr.String())
+	}
+
+	var result []*ModuleVersion
+	scanner := bufio.NewScanner(bytes.NewReader(out))
+	for scanner.Scan() {
+		parts := strings.Fields(scanner.Text())
+		if len(parts) != 2 {
+			continue
+		}
+
+		// Annotated tags are listed twice, once with a "^{}" suffix
+		// for the commit they point to. We only need them once.
+		tag := strings.TrimPrefix(parts[1], "refs/tags/")
+		if tag == parts[1] || strings.HasSuffix(tag, "^{}") {
+			continue
+		}
+
+		v, err := version.NewVersion(tag)
+		if err != nil {
+			// Not a version tag, ignore it
+			continue
+		}
+
+		var versionU url.URL = *u
+		q := versionU.Query()
+		q.Set("ref", tag)
+		versionU.RawQuery = q.Encode()
+		result = append(result, &ModuleVersion{
+			Version: v,
+			Source:  versionU.String(),
+		})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
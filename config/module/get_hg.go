@@ -1,11 +1,13 @@
 package module
 
 import (
+	"bytes"
 	"fmt"
 	"net/url"
 	"os"
 	"os/exec"
 	"runtime"
+	"strings"
 
 	urlhelper "github.com/hashicorp/terraform/helper/url"
 )
@@ -55,6 +57,26 @@ func (g *HgGetter) Get(dst string, u *url.URL) error {
 	return g.update(dst, newURL, rev)
 }
 
+// Pin implements Pinner by pinning the rev to the changeset that is
+// checked out.
+func (g *HgGetter) Pin(dst string, u *url.URL) (*url.URL, error) {
+	var stderr bytes.Buffer
+	cmd := exec.Command("hg", "log", "-r", ".", "--template", "{node}")
+	cmd.Dir = dst
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf(
+			"error reading changeset: %s: %s", err, stderr.String())
+	}
+
+	var newU url.URL = *u
+	q := newU.Query()
+	q.Set("rev", strings.TrimSpace(string(out)))
+	newU.RawQuery = q.Encode()
+	return &newU, nil
+}
+
 func (g *HgGetter) clone(dst string, u *url.URL) error {
 	cmd := exec.Command("hg", "clone", "-U", u.String(), dst)
 	return getRunCommand(cmd)
@@ -1,6 +1,7 @@
 package module
 
 import (
+	"encoding/json"
 	"encoding/xml"
 	"fmt"
 	"io"
@@ -10,6 +11,8 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+
+	"github.com/hashicorp/go-version"
 )
 
 // HttpGetter is a Getter implementation that will download a module from
@@ -29,6 +32,13 @@ import (
 // The source URL, whether from the header or meta tag, must be a fully
 // formed URL. The shorthand syntax of "github.com/foo/bar" or relative
 // paths are not allowed.
+//
+// HTTP endpoints can also act as a module registry so that modules can
+// be used with a version constraint. A GET request to the URL with
+// "/versions" appended must return a JSON document of the form
+// {"versions": ["1.0.0", "1.1.0"]}. A specific version is then downloaded
+// from the URL with "/<version>/download" appended, using the same
+// protocol as above.
 type HttpGetter struct{}
 
 func (g *HttpGetter) Get(dst string, u *url.URL) error {
@@ -76,6 +86,47 @@ func (g *HttpGetter) Get(dst string, u *url.URL) error {
 	return g.getSubdir(dst, source, subDir)
 }
 
+// Versions implements VersionLister.
+func (g *HttpGetter) Versions(u *url.URL) ([]*ModuleVersion, error) {
+	base := *u
+	base.Path = strings.TrimSuffix(base.Path, "/")
+
+	versionsU := base
+	versionsU.Path += "/versions"
+	resp, err := http.Get(versionsU.String())
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, fmt.Errorf("bad response code: %d", resp.StatusCode)
+	}
+
+	var index struct {
+		Versions []string `json:"versions"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&index); err != nil {
+		return nil, fmt.Errorf("error decoding version index: %s", err)
+	}
+
+	result := make([]*ModuleVersion, 0, len(index.Versions))
+	for _, raw := range index.Versions {
+		v, err := version.NewVersion(raw)
+		if err != nil {
+			return nil, fmt.Errorf("invalid version %q in index: %s", raw, err)
+		}
+
+		downloadU := base
+		downloadU.Path += fmt.Sprintf("/%s/download", raw)
+		result = append(result, &ModuleVersion{
+			Version: v,
+			Source:  downloadU.String(),
+		})
+	}
+
+	return result, nil
+}
+
 // getSubdir downloads the source into the destination, but with
 // the proper subdir.
 func (g *HttpGetter) getSubdir(dst, source, subDir string) error {
@@ -104,6 +104,31 @@ func TestHttpGetter_none(t *testing.T) {
 	}
 }
 
+func TestHttpGetter_versions(t *testing.T) {
+	ln := testHttpServer(t)
+	defer ln.Close()
+
+	g := new(HttpGetter)
+
+	var u url.URL
+	u.Scheme = "http"
+	u.Host = ln.Addr().String()
+	u.Path = "/registry"
+
+	vs, err := g.Versions(&u)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if len(vs) != 3 {
+		t.Fatalf("bad: %#v", vs)
+	}
+
+	expected := fmt.Sprintf("http://%s/registry/1.2.0/download", u.Host)
+	if vs[1].Source != expected {
+		t.Fatalf("bad: %s", vs[1].Source)
+	}
+}
+
 func testHttpServer(t *testing.T) net.Listener {
 	ln, err := net.Listen("tcp", "127.0.0.1:0")
 	if err != nil {
@@ -114,6 +139,8 @@ func testHttpServer(t *testing.T) net.Listener {
 	mux.HandleFunc("/header", testHttpHandlerHeader)
 	mux.HandleFunc("/meta", testHttpHandlerMeta)
 	mux.HandleFunc("/meta-subdir", testHttpHandlerMetaSubdir)
+	mux.HandleFunc("/registry/versions", testHttpHandlerVersions)
+	mux.HandleFunc("/registry/1.2.0/download", testHttpHandlerHeader)
 
 	var server http.Server
 	server.Handler = mux
@@ -135,6 +162,10 @@ func testHttpHandlerMetaSubdir(w http.ResponseWriter, r *http.Request) {
 	w.Write([]byte(fmt.Sprintf(testHttpMetaStr, testModuleURL("basic//subdir").String())))
 }
 
+func testHttpHandlerVersions(w http.ResponseWriter, r *http.Request) {
+	w.Write([]byte(`{"versions": ["1.0.0", "1.2.0", "2.0.0"]}`))
+}
+
 func testHttpHandlerNone(w http.ResponseWriter, r *http.Request) {
 	w.Write([]byte(testHttpNoneStr))
 }
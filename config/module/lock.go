@@ -0,0 +1,160 @@
+package module
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"os"
+
+	urlhelper "github.com/hashicorp/terraform/helper/url"
+)
+
+// LockFile is the name of the file, in the root module directory, that
+// pins the exact sources that modules were downloaded from.
+const LockFile = "terraform-modules.lock"
+
+// Pinner is an optional interface that a Getter can implement to report
+// the exact revision of a module that was downloaded, so that the same
+// revision can be downloaded again later.
+type Pinner interface {
+	// Pin returns a URL that will always download exactly what is
+	// in dst, which was downloaded from the given URL.
+	Pin(dst string, u *url.URL) (*url.URL, error)
+}
+
+// Lock records the exact sources that the modules of a tree were
+// downloaded from. When a tree is loaded with a lock, the locked sources
+// are used in place of resolving the configured sources again, so that
+// everyone working on the same configuration gets the same module code.
+type Lock struct {
+	Modules map[string]*LockedModule `json:"modules"`
+
+	used map[string]struct{}
+}
+
+// LockedModule is a single module pinned by a Lock.
+type LockedModule struct {
+	// Source and Version are the source and version constraint of the
+	// module as configured. If either changes, the lock no longer applies.
+	Source  string `json:"source"`
+	Version string `json:"version,omitempty"`
+
+	// Resolved is the exact source that the module was downloaded from.
+	Resolved string `json:"resolved"`
+}
+
+// ReadLock reads the lock at the given path. If the file doesn't exist,
+// an empty lock is returned.
+func ReadLock(path string) (*Lock, error) {
+	l := new(Lock)
+
+	f, err := os.Open(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return l, nil
+		}
+
+		return nil, err
+	}
+	defer f.Close()
+
+	if err := json.NewDecoder(f).Decode(l); err != nil {
+		return nil, fmt.Errorf("Error reading module lock %s: %s", path, err)
+	}
+
+	return l, nil
+}
+
+// Write writes the lock to the given path.
+func (l *Lock) Write(path string) error {
+	data, err := json.MarshalIndent(l, "", "    ")
+	if err != nil {
+		return err
+	}
+	data = append(data, '\n')
+
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	_, err = f.Write(data)
+	return err
+}
+
+// Prune removes the modules from the lock that weren't used since the
+// lock was read. This should be called after loading a tree so that
+// modules removed from the configuration are removed from the lock.
+func (l *Lock) Prune() {
+	for k, _ := range l.Modules {
+		if _, ok := l.used[k]; !ok {
+			delete(l.Modules, k)
+		}
+	}
+}
+
+// get returns the locked module for the given key if it still matches
+// the configured source and version.
+func (l *Lock) get(key, source, version string) *LockedModule {
+	m, ok := l.Modules[key]
+	if !ok || m.Source != source || m.Version != version {
+		return nil
+	}
+
+	l.markUsed(key)
+	return m
+}
+
+// set records the resolved source for the module with the given key.
+func (l *Lock) set(key, source, version, resolved string) {
+	if l.Modules == nil {
+		l.Modules = make(map[string]*LockedModule)
+	}
+
+	l.Modules[key] = &LockedModule{
+		Source:   source,
+		Version:  version,
+		Resolved: resolved,
+	}
+	l.markUsed(key)
+}
+
+func (l *Lock) markUsed(key string) {
+	if l.used == nil {
+		l.used = make(map[string]struct{})
+	}
+
+	l.used[key] = struct{}{}
+}
+
+// pinSource returns the source that will download exactly what was
+// downloaded into dst from src. The boolean result is false if the getter
+// for the source can't pin, such as for local file paths.
+func pinSource(dst, src string) (string, bool, error) {
+	force, getSrc := getForcedGetter(src)
+	u, err := urlhelper.Parse(getSrc)
+	if err != nil {
+		return "", false, err
+	}
+	if force == "" {
+		force = u.Scheme
+	}
+
+	p, ok := Getters[force].(Pinner)
+	if !ok {
+		return "", false, nil
+	}
+
+	pinned, err := p.Pin(dst, u)
+	if err != nil {
+		return "", false, fmt.Errorf("error pinning '%s': %s", src, err)
+	}
+
+	result := pinned.String()
+	if force != u.Scheme {
+		result = fmt.Sprintf("%s::%s", force, result)
+	}
+
+	return result, true, nil
+}
@@ -0,0 +1,139 @@
+package module
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform/config"
+)
+
+func TestReadLock_missing(t *testing.T) {
+	l, err := ReadLock(filepath.Join(tempDir(t), LockFile))
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if len(l.Modules) != 0 {
+		t.Fatalf("bad: %#v", l.Modules)
+	}
+}
+
+func TestLockWrite(t *testing.T) {
+	path := filepath.Join(tempDir(t), LockFile)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	l := new(Lock)
+	l.set("root.foo", "git::https://example.com/foo.git", "", "git::https://example.com/foo.git?ref=abc")
+	if err := l.Write(path); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	actual, err := ReadLock(path)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if !reflect.DeepEqual(actual.Modules, l.Modules) {
+		t.Fatalf("bad: %#v", actual.Modules)
+	}
+}
+
+func TestLockPrune(t *testing.T) {
+	l := &Lock{
+		Modules: map[string]*LockedModule{
+			"root.foo": &LockedModule{Source: "foo", Resolved: "foo"},
+			"root.bar": &LockedModule{Source: "bar", Resolved: "bar"},
+		},
+	}
+
+	if m := l.get("root.foo", "foo", ""); m == nil {
+		t.Fatal("should be locked")
+	}
+	l.Prune()
+
+	if _, ok := l.Modules["root.bar"]; ok {
+		t.Fatal("bar should be pruned")
+	}
+	if _, ok := l.Modules["root.foo"]; !ok {
+		t.Fatal("foo should not be pruned")
+	}
+}
+
+func TestLockGet_sourceChanged(t *testing.T) {
+	l := new(Lock)
+	l.set("root.foo", "foo", "", "foo?ref=abc")
+
+	if m := l.get("root.foo", "bar", ""); m != nil {
+		t.Fatalf("bad: %#v", m)
+	}
+	if m := l.get("root.foo", "foo", "~> 1.0"); m != nil {
+		t.Fatalf("bad: %#v", m)
+	}
+}
+
+func TestTreeLoad_lock(t *testing.T) {
+	ln := testHttpServer(t)
+	defer ln.Close()
+
+	src := fmt.Sprintf("http://%s/registry", ln.Addr().String())
+	lock := new(Lock)
+	tree := NewTree("", testVersionedConfig(src, ">= 1.0, < 2.0"))
+	tree.SetLock(lock)
+	if err := tree.Load(testStorage(t), GetModeGet); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	expected := map[string]*LockedModule{
+		"root.foo@>= 1.0, < 2.0": &LockedModule{
+			Source:   src,
+			Version:  ">= 1.0, < 2.0",
+			Resolved: src + "/1.2.0/download",
+		},
+	}
+	if !reflect.DeepEqual(lock.Modules, expected) {
+		t.Fatalf("bad: %#v", lock.Modules)
+	}
+}
+
+func TestTreeLoad_lockHonored(t *testing.T) {
+	ln := testHttpServer(t)
+	defer ln.Close()
+
+	// Lock the module to a source that isn't the newest version, which
+	// would be resolved if it weren't for the lock.
+	src := fmt.Sprintf("http://%s/registry", ln.Addr().String())
+	constraint := ">= 1.0"
+	key := "root.foo@" + constraint
+	lock := new(Lock)
+	lock.set(key, src, constraint, testModule("child"))
+
+	tree := NewTree("", testVersionedConfig(src, constraint))
+	tree.SetLock(lock)
+	if err := tree.Load(testStorage(t), GetModeGet); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	// Only the locked fixture has a nested child, so we know we got the
+	// locked source.
+	if c := tree.Child([]string{"foo", "foo", "bar"}); c == nil {
+		t.Fatalf("should've loaded the locked source:\n%s", tree)
+	}
+	if lock.Modules[key].Resolved != testModule("child") {
+		t.Fatalf("bad: %#v", lock.Modules[key])
+	}
+}
+
+func testVersionedConfig(src, constraint string) *config.Config {
+	return &config.Config{
+		Modules: []*config.Module{
+			&config.Module{
+				Name:    "foo",
+				Source:  src,
+				Version: constraint,
+			},
+		},
+	}
+}
@@ -2,6 +2,7 @@ package module
 
 // Module represents the metadata for a single module.
 type Module struct {
-	Name   string
-	Source string
+	Name    string
+	Source  string
+	Version string
 }
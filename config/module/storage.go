@@ -23,3 +23,30 @@ func getStorage(s Storage, key string, src string, mode GetMode) (string, bool,
 	// Get the directory where the module is.
 	return s.Dir(key)
 }
+
+// getStorageVersion resolves the version constraint for a module to the
+// source of a concrete version. The constraint is only resolved if the
+// module may actually be downloaded, otherwise src is returned unchanged.
+func getStorageVersion(
+	s Storage, key, src, constraint string, mode GetMode) (string, error) {
+	switch mode {
+	case GetModeNone:
+		return src, nil
+	case GetModeGet:
+		// If we already have it, we don't need to resolve anything
+		_, ok, err := s.Dir(key)
+		if err != nil {
+			return "", err
+		}
+		if ok {
+			return src, nil
+		}
+	}
+
+	v, err := ResolveVersion(src, constraint)
+	if err != nil {
+		return "", err
+	}
+
+	return v.Source, nil
+}
@@ -24,6 +24,7 @@ type Tree struct {
 	config   *config.Config
 	children map[string]*Tree
 	path     []string
+	modLock  *Lock
 	lock     sync.RWMutex
 }
 
@@ -63,6 +64,15 @@ func NewTreeModule(name, dir string) (*Tree, error) {
 	return NewTree(name, c), nil
 }
 
+// SetLock sets the module lock to use when loading the tree. Modules
+// pinned by the lock are downloaded from their locked sources, and any
+// modules downloaded are recorded in the lock.
+func (t *Tree) SetLock(l *Lock) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	t.modLock = l
+}
+
 // Config returns the configuration for this module.
 func (t *Tree) Config() *config.Config {
 	return t.config
@@ -107,8 +117,9 @@ func (t *Tree) Modules() []*Module {
 	result := make([]*Module, len(t.config.Modules))
 	for i, m := range t.config.Modules {
 		result[i] = &Module{
-			Name:   m.Name,
-			Source: m.Source,
+			Name:    m.Name,
+			Source:  m.Source,
+			Version: m.Version,
 		}
 	}
 
@@ -175,6 +186,28 @@ func (t *Tree) Load(s Storage, mode GetMode) error {
 		// Get the directory where this module is so we can load it
 		key := strings.Join(path, ".")
 		key = "root." + key
+		if m.Version != "" {
+			// The version constraint is part of the key so that
+			// changing the constraint gets the module again.
+			key += "@" + m.Version
+		}
+
+		// If the module is pinned by our lock, then we use exactly what
+		// was downloaded before. Otherwise, resolve the version.
+		detected := source
+		var locked *LockedModule
+		if t.modLock != nil && mode != GetModeUpdate {
+			locked = t.modLock.get(key, detected, m.Version)
+		}
+		if locked != nil {
+			source = locked.Resolved
+		} else if m.Version != "" {
+			source, err = getStorageVersion(s, key, source, m.Version, mode)
+			if err != nil {
+				return fmt.Errorf("module %s: %s", m.Name, err)
+			}
+		}
+
 		dir, ok, err := getStorage(s, key, source, mode)
 		if err != nil {
 			return err
@@ -184,6 +217,33 @@ func (t *Tree) Load(s Storage, mode GetMode) error {
 				"module %s: not found, may need to be downloaded", m.Name)
 		}
 
+		// Record what we downloaded in the lock. Sources that can't be
+		// pinned, such as local paths, are only recorded if a version
+		// was resolved for them.
+		if t.modLock != nil && mode > GetModeNone {
+			pinned, ok, err := pinSource(dir, source)
+			if err != nil {
+				return fmt.Errorf("module %s: %s", m.Name, err)
+			}
+			if !ok && m.Version != "" {
+				pinned, ok = source, true
+			}
+
+			// If what we have doesn't match the lock, then someone
+			// else updated the lock and we need to get it again.
+			if ok && locked != nil && pinned != locked.Resolved {
+				if err := s.Get(key, locked.Resolved, true); err != nil {
+					return err
+				}
+
+				pinned = locked.Resolved
+			}
+
+			if ok {
+				t.modLock.set(key, detected, m.Version, pinned)
+			}
+		}
+
 		// If we have a subdirectory, then merge that in
 		if subDir != "" {
 			dir = filepath.Join(dir, subDir)
@@ -198,6 +258,7 @@ func (t *Tree) Load(s Storage, mode GetMode) error {
 
 		// Set the path of this child
 		children[m.Name].path = path
+		children[m.Name].modLock = t.modLock
 	}
 
 	// Go through all the children and load them.
@@ -345,9 +406,15 @@ func (t *Tree) Validate() error {
 				panic("module not found in children: " + mv.Name)
 			}
 
+			// Map outputs are referenced by key as "output.key"
+			field := mv.Field
+			if idx := strings.Index(field, "."); idx >= 0 {
+				field = field[:idx]
+			}
+
 			found := false
 			for _, o := range tree.config.Outputs {
-				if o.Name == mv.Field {
+				if o.Name == field {
 					found = true
 					break
 				}
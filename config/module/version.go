@@ -0,0 +1,91 @@
+package module
+
+import (
+	"fmt"
+	"net/url"
+
+	"github.com/hashicorp/go-version"
+	urlhelper "github.com/hashicorp/terraform/helper/url"
+)
+
+// VersionLister is an optional interface that a Getter can implement if
+// the source it downloads from can publish multiple versions of a module.
+// This is what allows a version constraint to be set on a module.
+type VersionLister interface {
+	// Versions returns all the versions of the module that are available
+	// at the given URL.
+	Versions(*url.URL) ([]*ModuleVersion, error)
+}
+
+// ModuleVersion is a single version of a module that is available from
+// a source, along with the source URL that will download that exact
+// version.
+type ModuleVersion struct {
+	Version *version.Version
+	Source  string
+}
+
+// ResolveVersion takes a source URL and a version constraint such as
+// ">= 1.2, < 2.0" and returns the newest available version of the module
+// matching the constraint, along with the source URL that downloads it.
+//
+// The source must already have gone through Detect and must not contain
+// a subdirectory.
+func ResolveVersion(src, constraint string) (*ModuleVersion, error) {
+	cs, err := version.NewConstraint(constraint)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"invalid version constraint %q: %s", constraint, err)
+	}
+
+	force, getSrc := getForcedGetter(src)
+	u, err := urlhelper.Parse(getSrc)
+	if err != nil {
+		return nil, err
+	}
+	if force == "" {
+		force = u.Scheme
+	}
+
+	g, ok := Getters[force]
+	if !ok {
+		return nil, fmt.Errorf(
+			"module download not supported for scheme '%s'", force)
+	}
+	lister, ok := g.(VersionLister)
+	if !ok {
+		return nil, fmt.Errorf(
+			"version constraints are not supported for %s sources", force)
+	}
+
+	versions, err := lister.Versions(u)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"error listing versions for '%s': %s", src, err)
+	}
+
+	var result *ModuleVersion
+	for _, v := range versions {
+		if !cs.Check(v.Version) {
+			continue
+		}
+		if result == nil || v.Version.GreaterThan(result.Version) {
+			result = v
+		}
+	}
+	if result == nil {
+		return nil, fmt.Errorf(
+			"no version of '%s' matches the constraint %q", src, constraint)
+	}
+
+	// Keep the forced getter if we had one so the resolved source is
+	// fetched the same way as the original.
+	if force != u.Scheme {
+		result = &ModuleVersion{
+			Version: result.Version,
+			Source:  fmt.Sprintf("%s::%s", force, result.Source),
+		}
+	}
+
+	return result, nil
+}
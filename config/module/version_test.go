@@ -0,0 +1,58 @@
+package module
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestResolveVersion(t *testing.T) {
+	ln := testHttpServer(t)
+	defer ln.Close()
+
+	src := fmt.Sprintf("http://%s/registry", ln.Addr().String())
+	v, err := ResolveVersion(src, ">= 1.0, < 2.0")
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	if v.Version.String() != "1.2.0" {
+		t.Fatalf("bad: %s", v.Version)
+	}
+	if v.Source != src+"/1.2.0/download" {
+		t.Fatalf("bad: %s", v.Source)
+	}
+
+	// Make sure the resolved source can be fetched
+	dst := tempDir(t)
+	if err := Get(dst, v.Source); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if _, err := os.Stat(filepath.Join(dst, "main.tf")); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+}
+
+func TestResolveVersion_noMatch(t *testing.T) {
+	ln := testHttpServer(t)
+	defer ln.Close()
+
+	src := fmt.Sprintf("http://%s/registry", ln.Addr().String())
+	if _, err := ResolveVersion(src, ">= 3.0"); err == nil {
+		t.Fatal("should error")
+	}
+}
+
+func TestResolveVersion_badConstraint(t *testing.T) {
+	if _, err := ResolveVersion("http://example.com/foo", "nope"); err == nil {
+		t.Fatal("should error")
+	}
+}
+
+func TestResolveVersion_unsupported(t *testing.T) {
+	src := testModuleURL("basic").String()
+	if _, err := ResolveVersion(src, ">= 1.0"); err == nil {
+		t.Fatal("should error")
+	}
+}
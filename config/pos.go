@@ -0,0 +1,84 @@
+package config
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Pos is a position in a configuration file, used to point at the
+// source of a problem in error messages.
+type Pos struct {
+	Filename string
+	Line     int
+}
+
+func (p Pos) String() string {
+	if p.Line == 0 {
+		return p.Filename
+	}
+
+	return fmt.Sprintf("%s:%d", p.Filename, p.Line)
+}
+
+// setResourcePos sets the position of each resource, and of the first
+// reference to each variable in its configuration, by looking for them
+// in the source of the file the resources were loaded from.
+//
+// The HCL objects don't keep their positions, so the declaration of a
+// resource is found by its type and name, and a variable by the first
+// line after the declaration that contains its key. Only the file is
+// known for resources that aren't declared as `resource "TYPE" "NAME"`,
+// such as in JSON files.
+func setResourcePos(filename, src string, rs []*Resource) {
+	lines := strings.Split(src, "\n")
+	for _, r := range rs {
+		r.Pos = Pos{Filename: filename}
+
+		keyword := "resource"
+		if r.Mode == DataResourceMode {
+			keyword = "data"
+		}
+
+		re := regexp.MustCompile(fmt.Sprintf(
+			`^\s*%s\s+"?%s"?\s+"?%s"?`,
+			keyword, regexp.QuoteMeta(r.Type), regexp.QuoteMeta(r.Name)))
+		start := -1
+		for i, line := range lines {
+			if re.MatchString(line) {
+				start = i
+				break
+			}
+		}
+		if start == -1 {
+			continue
+		}
+		r.Pos.Line = start + 1
+
+		rcs := []*RawConfig{r.RawCount, r.RawConfig}
+		for _, p := range r.Provisioners {
+			rcs = append(rcs, p.ConnInfo, p.RawConfig)
+		}
+
+		r.VarPos = make(map[string]Pos)
+		for _, rc := range rcs {
+			if rc == nil {
+				continue
+			}
+
+			for k, _ := range rc.Variables {
+				if _, ok := r.VarPos[k]; ok {
+					continue
+				}
+
+				r.VarPos[k] = r.Pos
+				for i := start; i < len(lines); i++ {
+					if strings.Contains(lines[i], k) {
+						r.VarPos[k] = Pos{Filename: filename, Line: i + 1}
+						break
+					}
+				}
+			}
+		}
+	}
+}
@@ -102,38 +102,11 @@ func (r *RawConfig) Interpolate(vs map[string]ast.Variable) error {
 	r.lock.Lock()
 	defer r.lock.Unlock()
 
-	config := langEvalConfig(vs)
-	return r.interpolate(func(root ast.Node) (string, error) {
-		// We detect the variables again and check if the value of any
-		// of the variables is the computed value. If it is, then we
-		// treat this entire value as computed.
-		//
-		// We have to do this here before the `lang.Eval` because
-		// if any of the variables it depends on are computed, then
-		// the interpolation can fail at runtime for other reasons. Example:
-		// `${count.index+1}`: in a world where `count.index` is computed,
-		// this would fail a type check since the computed placeholder is
-		// a string, but realistically the whole value is just computed.
-		vars, err := DetectVariables(root)
-		if err != nil {
-			return "", err
-		}
-		for _, v := range vars {
-			varVal, ok := vs[v.FullKey()]
-			if ok && varVal.Value == UnknownVariableValue {
-				return UnknownVariableValue, nil
-			}
-		}
-
-		// None of the variables we need are computed, meaning we should
-		// be able to properly evaluate.
-		out, _, err := lang.Eval(root, config)
-		if err != nil {
-			return "", err
-		}
+	if err := r.interpolate(langInterpolateFunc(vs)); err != nil {
+		return err
+	}
 
-		return out.(string), nil
-	})
+	return r.interpolateDynamic(vs)
 }
 
 // Merge merges another RawConfig into this one (overriding any conflicting
@@ -222,6 +195,10 @@ func (r *RawConfig) interpolate(fn interpolationWalkerFunc) error {
 	}
 	r.config = config.(map[string]interface{})
 
+	// Dynamic blocks are interpolated separately once the rest of the
+	// configuration is done, see interpolateDynamic.
+	delete(r.config, dynamicKey)
+
 	w := &interpolationWalker{F: fn, Replace: true}
 	err = reflectwalk.Walk(r.config, w)
 	if err != nil {
@@ -297,6 +274,43 @@ type gobRawConfig struct {
 	Raw map[string]interface{}
 }
 
+// langInterpolateFunc returns the interpolationWalkerFunc that evaluates
+// interpolations using the given variable values.
+func langInterpolateFunc(vs map[string]ast.Variable) interpolationWalkerFunc {
+	config := langEvalConfig(vs)
+	return func(root ast.Node) (string, error) {
+		// We detect the variables again and check if the value of any
+		// of the variables is the computed value. If it is, then we
+		// treat this entire value as computed.
+		//
+		// We have to do this here before the `lang.Eval` because
+		// if any of the variables it depends on are computed, then
+		// the interpolation can fail at runtime for other reasons. Example:
+		// `${count.index+1}`: in a world where `count.index` is computed,
+		// this would fail a type check since the computed placeholder is
+		// a string, but realistically the whole value is just computed.
+		vars, err := DetectVariables(root)
+		if err != nil {
+			return "", err
+		}
+		for _, v := range vars {
+			varVal, ok := vs[v.FullKey()]
+			if ok && varVal.Value == UnknownVariableValue {
+				return UnknownVariableValue, nil
+			}
+		}
+
+		// None of the variables we need are computed, meaning we should
+		// be able to properly evaluate.
+		out, _, err := lang.Eval(root, config)
+		if err != nil {
+			return "", err
+		}
+
+		return out.(string), nil
+	}
+}
+
 // langEvalConfig returns the evaluation configuration we use to execute.
 func langEvalConfig(vs map[string]ast.Variable) *lang.EvalConfig {
 	funcMap := make(map[string]ast.Function)
@@ -305,6 +319,13 @@ func langEvalConfig(vs map[string]ast.Variable) *lang.EvalConfig {
 	}
 	funcMap["lookup"] = interpolationFuncLookup(vs)
 
+	// Templates are looked up relative to the module
+	if v, ok := vs["path.module"]; ok {
+		if dir, ok := v.Value.(string); ok {
+			funcMap["templatefile"] = interpolationFuncTemplateFile(dir)
+		}
+	}
+
 	return &lang.EvalConfig{
 		GlobalScope: &ast.BasicScope{
 			VarMap:  vs,
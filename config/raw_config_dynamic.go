@@ -0,0 +1,234 @@
+package config
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform/config/lang/ast"
+	"github.com/mitchellh/copystructure"
+	"github.com/mitchellh/reflectwalk"
+)
+
+// dynamicKey is the key within a configuration that holds dynamic
+// blocks. A dynamic block generates a nested block for every element
+// of a list:
+//
+//   dynamic "ingress" {
+//       for_each = ["22", "80"]
+//       content {
+//           from_port = "${each.value}"
+//       }
+//   }
+//
+// Within the content, "each.value" is the current element and
+// "each.index" is its index in the list.
+const dynamicKey = "dynamic"
+
+// dynamicBlock is a single parsed dynamic block.
+type dynamicBlock struct {
+	Name    string
+	ForEach interface{}
+	Content map[string]interface{}
+}
+
+// dynamicBlocks parses the raw value of the dynamic key into the
+// dynamic blocks, sorted by name.
+func dynamicBlocks(raw interface{}) ([]*dynamicBlock, error) {
+	ms := dynamicMaps(raw)
+	if len(ms) == 0 {
+		return nil, fmt.Errorf("dynamic: must be a block")
+	}
+
+	var result []*dynamicBlock
+	for _, m := range ms {
+		for name, v := range m {
+			bs := dynamicMaps(v)
+			if len(bs) == 0 {
+				return nil, fmt.Errorf("dynamic %s: must be a block", name)
+			}
+
+			for _, b := range bs {
+				block := &dynamicBlock{Name: name}
+				for k, v := range b {
+					switch k {
+					case "for_each":
+						block.ForEach = v
+					case "content":
+						cs := dynamicMaps(v)
+						if len(cs) != 1 {
+							return nil, fmt.Errorf(
+								"dynamic %s: exactly one content block is required",
+								name)
+						}
+
+						block.Content = cs[0]
+					default:
+						return nil, fmt.Errorf(
+							"dynamic %s: invalid key: %s", name, k)
+					}
+				}
+
+				if block.ForEach == nil {
+					return nil, fmt.Errorf(
+						"dynamic %s: for_each is required", name)
+				}
+				if block.Content == nil {
+					return nil, fmt.Errorf(
+						"dynamic %s: exactly one content block is required", name)
+				}
+
+				result = append(result, block)
+			}
+		}
+	}
+
+	sort.Stable(dynamicBlockSlice(result))
+	return result, nil
+}
+
+// dynamicMaps turns the various ways that HCL and JSON decode blocks
+// into a list of maps.
+func dynamicMaps(raw interface{}) []map[string]interface{} {
+	switch v := raw.(type) {
+	case map[string]interface{}:
+		return []map[string]interface{}{v}
+	case []map[string]interface{}:
+		return v
+	case []interface{}:
+		var result []map[string]interface{}
+		for _, e := range v {
+			result = append(result, dynamicMaps(e)...)
+		}
+
+		return result
+	default:
+		return nil
+	}
+}
+
+// items interpolates the for_each value of the block and returns the
+// list of elements to generate blocks for. If the list is computed,
+// nil is returned.
+func (b *dynamicBlock) items(vs map[string]ast.Variable) ([]string, error) {
+	raw, err := copystructure.Copy(map[string]interface{}{
+		"for_each": b.ForEach,
+	})
+	if err != nil {
+		return nil, err
+	}
+	m := raw.(map[string]interface{})
+
+	w := &interpolationWalker{F: langInterpolateFunc(vs), Replace: true}
+	if err := reflectwalk.Walk(m, w); err != nil {
+		return nil, err
+	}
+	if len(w.unknownKeys) > 0 {
+		return nil, nil
+	}
+
+	switch v := m["for_each"].(type) {
+	case string:
+		if v == "" {
+			return []string{}, nil
+		}
+
+		return strings.Split(v, InterpSplitDelim), nil
+	case []interface{}:
+		result := make([]string, len(v))
+		for i, e := range v {
+			s, ok := e.(string)
+			if !ok {
+				return nil, fmt.Errorf("for_each must be a list of strings")
+			}
+
+			result[i] = s
+		}
+
+		return result, nil
+	default:
+		return nil, fmt.Errorf("for_each must be a list of strings")
+	}
+}
+
+// interpolateDynamic generates the nested blocks for all the dynamic
+// blocks and adds them to the interpolated configuration. This must be
+// called after interpolate with the lock held.
+func (r *RawConfig) interpolateDynamic(vs map[string]ast.Variable) error {
+	raw, ok := r.Raw[dynamicKey]
+	if !ok {
+		return nil
+	}
+
+	blocks, err := dynamicBlocks(raw)
+	if err != nil {
+		return err
+	}
+
+	for _, b := range blocks {
+		items, err := b.items(vs)
+		if err != nil {
+			return fmt.Errorf("dynamic %s: %s", b.Name, err)
+		}
+
+		// If the list is computed, then all the blocks are computed
+		if items == nil {
+			r.unknownKeys = append(r.unknownKeys, b.Name)
+			continue
+		}
+
+		var result []map[string]interface{}
+		switch v := r.config[b.Name].(type) {
+		case nil:
+		case []map[string]interface{}:
+			result = v
+		default:
+			return fmt.Errorf(
+				"dynamic %s: conflicts with the %s attribute", b.Name, b.Name)
+		}
+
+		for i, item := range items {
+			each := make(map[string]ast.Variable, len(vs)+2)
+			for k, v := range vs {
+				each[k] = v
+			}
+			each["each.index"] = ast.Variable{
+				Value: i,
+				Type:  ast.TypeInt,
+			}
+			each["each.value"] = ast.Variable{
+				Value: item,
+				Type:  ast.TypeString,
+			}
+
+			raw, err := copystructure.Copy(b.Content)
+			if err != nil {
+				return err
+			}
+			content := raw.(map[string]interface{})
+
+			w := &interpolationWalker{F: langInterpolateFunc(each), Replace: true}
+			if err := reflectwalk.Walk(content, w); err != nil {
+				return fmt.Errorf("dynamic %s: %s", b.Name, err)
+			}
+			for _, k := range w.unknownKeys {
+				r.unknownKeys = append(r.unknownKeys, b.Name+"."+k)
+			}
+
+			result = append(result, content)
+		}
+
+		if len(result) > 0 {
+			r.config[b.Name] = result
+		}
+	}
+
+	return nil
+}
+
+// dynamicBlockSlice is a sort.Interface to sort dynamic blocks by name.
+type dynamicBlockSlice []*dynamicBlock
+
+func (s dynamicBlockSlice) Len() int           { return len(s) }
+func (s dynamicBlockSlice) Less(i, j int) bool { return s[i].Name < s[j].Name }
+func (s dynamicBlockSlice) Swap(i, j int)      { s[i], s[j] = s[j], s[i] }
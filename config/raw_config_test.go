@@ -195,6 +195,139 @@ func TestRawConfig_merge(t *testing.T) {
 	}
 }
 
+func TestRawConfig_dynamic(t *testing.T) {
+	raw := map[string]interface{}{
+		"name": "web",
+		"ingress": []map[string]interface{}{
+			map[string]interface{}{"port": "443"},
+		},
+		"dynamic": []map[string]interface{}{
+			map[string]interface{}{
+				"ingress": []map[string]interface{}{
+					map[string]interface{}{
+						"for_each": []interface{}{"${var.ports}"},
+						"content": []map[string]interface{}{
+							map[string]interface{}{
+								"port": "${each.value}",
+								"name": "${var.name}-${each.index}",
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	rc, err := NewRawConfig(raw)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	vars := map[string]ast.Variable{
+		"var.ports": ast.Variable{
+			Value: "22" + InterpSplitDelim + "80",
+			Type:  ast.TypeString,
+		},
+		"var.name": ast.Variable{
+			Value: "rule",
+			Type:  ast.TypeString,
+		},
+	}
+	if err := rc.Interpolate(vars); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	actual := rc.Config()
+	expected := map[string]interface{}{
+		"name": "web",
+		"ingress": []map[string]interface{}{
+			map[string]interface{}{"port": "443"},
+			map[string]interface{}{"port": "22", "name": "rule-0"},
+			map[string]interface{}{"port": "80", "name": "rule-1"},
+		},
+	}
+	if !reflect.DeepEqual(actual, expected) {
+		t.Fatalf("bad: %#v", actual)
+	}
+
+	// The raw configuration must be untouched
+	if _, ok := rc.Raw["dynamic"]; !ok {
+		t.Fatal("raw should still have dynamic blocks")
+	}
+}
+
+func TestRawConfig_dynamicComputed(t *testing.T) {
+	raw := map[string]interface{}{
+		"dynamic": []map[string]interface{}{
+			map[string]interface{}{
+				"ingress": []map[string]interface{}{
+					map[string]interface{}{
+						"for_each": "${var.ports}",
+						"content": []map[string]interface{}{
+							map[string]interface{}{
+								"port": "${each.value}",
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	rc, err := NewRawConfig(raw)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	vars := map[string]ast.Variable{
+		"var.ports": ast.Variable{
+			Value: UnknownVariableValue,
+			Type:  ast.TypeString,
+		},
+	}
+	if err := rc.Interpolate(vars); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	actual := rc.Config()
+	expected := map[string]interface{}{}
+	if !reflect.DeepEqual(actual, expected) {
+		t.Fatalf("bad: %#v", actual)
+	}
+
+	expectedKeys := []string{"ingress"}
+	if !reflect.DeepEqual(rc.UnknownKeys(), expectedKeys) {
+		t.Fatalf("bad: %#v", rc.UnknownKeys())
+	}
+}
+
+func TestRawConfig_dynamicNoForEach(t *testing.T) {
+	raw := map[string]interface{}{
+		"dynamic": []map[string]interface{}{
+			map[string]interface{}{
+				"ingress": []map[string]interface{}{
+					map[string]interface{}{
+						"content": []map[string]interface{}{
+							map[string]interface{}{
+								"port": "${each.value}",
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	rc, err := NewRawConfig(raw)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	if err := rc.Interpolate(nil); err == nil {
+		t.Fatal("should error")
+	}
+}
+
 func TestRawConfig_syntax(t *testing.T) {
 	raw := map[string]interface{}{
 		"foo": "${var",
@@ -0,0 +1,3 @@
+data "aws_ami" "ubuntu" {
+    provisioner "shell" {}
+}
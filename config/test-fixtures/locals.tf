@@ -0,0 +1,12 @@
+variable "env" {}
+
+locals {
+    prefix = "app-${var.env}"
+    tags {
+        Name = "${local.prefix}"
+    }
+}
+
+locals {
+    name = "${local.prefix}-${aws_instance.web.id}"
+}
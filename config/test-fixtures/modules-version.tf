@@ -0,0 +1,5 @@
+module "bar" {
+    memory = "1G"
+    source = "baz"
+    version = "~> 1.2"
+}
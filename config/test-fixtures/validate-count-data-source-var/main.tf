@@ -0,0 +1,5 @@
+data "aws_availability_zones" "available" {}
+
+resource "aws_instance" "web" {
+    count = "${length(data.aws_availability_zones.available.names)}"
+}
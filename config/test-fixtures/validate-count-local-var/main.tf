@@ -0,0 +1,7 @@
+locals {
+    num = "2"
+}
+
+resource "aws_instance" "foo" {
+    count = "${local.num}"
+}
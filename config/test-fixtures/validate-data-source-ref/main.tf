@@ -0,0 +1,5 @@
+resource "aws_ami" "ubuntu" {}
+
+resource "aws_instance" "web" {
+    ami = "${data.aws_ami.ubuntu.id}"
+}
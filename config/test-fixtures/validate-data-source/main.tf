@@ -0,0 +1,8 @@
+data "aws_ami" "ubuntu" {
+    most_recent = true
+    owners = ["099720109477"]
+}
+
+resource "aws_instance" "web" {
+    ami = "${data.aws_ami.ubuntu.id}"
+}
@@ -0,0 +1,7 @@
+resource "aws_security_group" "web" {
+    dynamic "ingress" {
+        content {
+            from_port = "${each.value}"
+        }
+    }
+}
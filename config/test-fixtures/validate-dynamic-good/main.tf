@@ -0,0 +1,13 @@
+variable "ports" {
+    default = "22,80"
+}
+
+resource "aws_security_group" "web" {
+    dynamic "ingress" {
+        for_each = ["${split(",", var.ports)}"]
+        content {
+            from_port = "${each.value}"
+            to_port = "${each.value}"
+        }
+    }
+}
@@ -0,0 +1,13 @@
+variable "env" {}
+
+locals {
+    prefix = "app-${var.env}"
+}
+
+locals {
+    name = "${local.prefix}-web"
+}
+
+resource "aws_instance" "web" {
+    name = "${local.name}"
+}
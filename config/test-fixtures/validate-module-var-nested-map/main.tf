@@ -0,0 +1,9 @@
+module "foo" {
+    source = "./foo"
+    envs {
+        prod {
+            cidr = "10.0.0.0/16"
+            azs = ["us-east-1a", "us-east-1b"]
+        }
+    }
+}
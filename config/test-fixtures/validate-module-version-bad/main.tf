@@ -0,0 +1,4 @@
+module "foo" {
+    source = "foo"
+    version = "not a version"
+}
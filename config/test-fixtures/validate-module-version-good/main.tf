@@ -0,0 +1,4 @@
+module "foo" {
+    source = "foo"
+    version = ">= 1.2, < 2.0"
+}
@@ -0,0 +1,12 @@
+variable "envs" {
+    default = {
+        prod = {
+            cidr = "10.0.0.0/16"
+            azs = ["us-east-1a", "us-east-1b"]
+        }
+    }
+}
+
+resource "aws_instance" "web" {
+    cidr = "${var.envs.prod.cidr}"
+}
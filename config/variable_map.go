@@ -0,0 +1,122 @@
+package config
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform/flatmap"
+	"github.com/mitchellh/mapstructure"
+)
+
+// NormalizeVariableMap returns the value of a map variable, or of a map
+// given to a module, with the nested values as strings, lists and maps.
+//
+// The values of the map can be nested lists and maps, such as
+// {prod => {cidr, azs => [...]}}. HCL decodes the mappings as lists of
+// maps, which are merged into a single map, and other values, such as
+// numbers, are turned into strings.
+func NormalizeVariableMap(raw interface{}) (map[string]interface{}, error) {
+	v, err := normalizeVariableValue(raw)
+	if err != nil {
+		return nil, err
+	}
+
+	result, ok := v.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("must be a mapping")
+	}
+
+	return result, nil
+}
+
+// FlattenVariable returns the value of the variable with the given name
+// as the flat values that variables are stored as: the value itself for
+// a string, or the values of a map under keys such as "amis.us-east-1",
+// "envs.prod.cidr" and "envs.prod.azs.0", along with "envs.prod.azs.#",
+// the number of elements of the list.
+func FlattenVariable(name string, raw interface{}) (map[string]string, error) {
+	var s string
+	if err := mapstructure.WeakDecode(raw, &s); err == nil {
+		return map[string]string{name: s}, nil
+	}
+
+	m, err := NormalizeVariableMap(raw)
+	if err != nil {
+		return nil, fmt.Errorf("%s: must be a string or a mapping", name)
+	}
+
+	return flatmap.Flatten(map[string]interface{}{name: m}), nil
+}
+
+func normalizeVariableValue(raw interface{}) (interface{}, error) {
+	switch v := raw.(type) {
+	case map[string]interface{}:
+		result := make(map[string]interface{}, len(v))
+		for k, e := range v {
+			n, err := normalizeVariableValue(e)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %s", k, err)
+			}
+			result[k] = n
+		}
+
+		return result, nil
+	case map[interface{}]interface{}:
+		m := make(map[string]interface{}, len(v))
+		for k, e := range v {
+			var ks string
+			if err := mapstructure.WeakDecode(k, &ks); err != nil {
+				return nil, fmt.Errorf("%v: keys must be strings", k)
+			}
+			m[ks] = e
+		}
+
+		return normalizeVariableValue(m)
+	case map[string]string:
+		result := make(map[string]interface{}, len(v))
+		for k, e := range v {
+			result[k] = e
+		}
+
+		return result, nil
+	case []map[string]interface{}:
+		// HCL decodes a mapping as a list of maps
+		merged := make(map[string]interface{})
+		for _, m := range v {
+			for k, e := range m {
+				merged[k] = e
+			}
+		}
+
+		return normalizeVariableValue(merged)
+	case []interface{}:
+		// A mapping can also end up as a list of maps that are
+		// interface values, but any other list is a list.
+		ms := make([]map[string]interface{}, 0, len(v))
+		for _, e := range v {
+			if m, ok := e.(map[string]interface{}); ok {
+				ms = append(ms, m)
+			}
+		}
+		if len(v) > 0 && len(ms) == len(v) {
+			return normalizeVariableValue(ms)
+		}
+
+		result := make([]interface{}, len(v))
+		for i, e := range v {
+			n, err := normalizeVariableValue(e)
+			if err != nil {
+				return nil, fmt.Errorf("%d: %s", i, err)
+			}
+			result[i] = n
+		}
+
+		return result, nil
+	default:
+		var s string
+		if err := mapstructure.WeakDecode(raw, &s); err != nil {
+			return nil, fmt.Errorf("must be a string, list, or mapping")
+		}
+
+		return s, nil
+	}
+}
@@ -1,8 +1,11 @@
 package main
 
 import (
+	"io/ioutil"
+	"os"
 	"path/filepath"
 	"reflect"
+	"runtime"
 	"testing"
 )
 
@@ -37,6 +40,7 @@ func TestConfig_Merge(t *testing.T) {
 			"local":  "local",
 			"remote": "bad",
 		},
+		PolicyChecks: []string{"foo"},
 	}
 
 	c2 := &Config{
@@ -47,6 +51,7 @@ func TestConfig_Merge(t *testing.T) {
 		Provisioners: map[string]string{
 			"remote": "remote",
 		},
+		PolicyChecks: []string{"bar"},
 	}
 
 	expected := &Config{
@@ -59,6 +64,7 @@ func TestConfig_Merge(t *testing.T) {
 			"local":  "local",
 			"remote": "remote",
 		},
+		PolicyChecks: []string{"foo", "bar"},
 	}
 
 	actual := c1.Merge(c2)
@@ -66,3 +72,157 @@ func TestConfig_Merge(t *testing.T) {
 		t.Fatalf("bad: %#v", actual)
 	}
 }
+
+func TestLoadConfig_webhook(t *testing.T) {
+	c, err := LoadConfig(filepath.Join(fixtureDir, "config-webhook"))
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	expected := map[string]WebhookConfig{
+		"chat": WebhookConfig{
+			URL:     "https://chat.example.com/hooks/terraform",
+			Events:  []string{"apply_success", "apply_failure"},
+			Payload: `{"text": "{{.Command}}: {{.Event}}"}`,
+		},
+	}
+	if !reflect.DeepEqual(c.Webhooks, expected) {
+		t.Fatalf("bad: %#v", c.Webhooks)
+	}
+}
+
+func TestConfig_CommandWebhooks(t *testing.T) {
+	c1 := &Config{
+		Webhooks: map[string]WebhookConfig{
+			"foo": WebhookConfig{URL: "http://foo"},
+			"bar": WebhookConfig{URL: "http://old"},
+		},
+	}
+	c2 := &Config{
+		Webhooks: map[string]WebhookConfig{
+			"bar": WebhookConfig{URL: "http://bar", Events: []string{"plan"}},
+		},
+	}
+
+	actual, err := c1.Merge(c2).CommandWebhooks()
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if len(actual) != 2 {
+		t.Fatalf("bad: %#v", actual)
+	}
+	if actual[0].Name != "bar" || actual[0].URL != "http://bar" {
+		t.Fatalf("bad: %#v", actual[0])
+	}
+	if actual[1].Name != "foo" || actual[1].URL != "http://foo" {
+		t.Fatalf("bad: %#v", actual[1])
+	}
+
+	c := &Config{
+		Webhooks: map[string]WebhookConfig{
+			"foo": WebhookConfig{},
+		},
+	}
+	if _, err := c.CommandWebhooks(); err == nil {
+		t.Fatal("should error")
+	}
+}
+
+func TestConfig_discover(t *testing.T) {
+	dir, err := filepath.Abs(filepath.Join(fixtureDir, "discover"))
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	var c Config
+	if err := c.discover(dir); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	expected := Config{
+		Providers: map[string]string{
+			"foo": filepath.Join(dir, "terraform-provider-foo"),
+		},
+		Provisioners: map[string]string{
+			"bar": filepath.Join(dir, "terraform-provisioner-bar.exe"),
+		},
+	}
+	if !reflect.DeepEqual(c, expected) {
+		t.Fatalf("bad: %#v", c)
+	}
+}
+
+func TestConfig_Discover(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("the config directory isn't in HOME on windows")
+	}
+
+	td, err := ioutil.TempDir("", "tf")
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	defer os.RemoveAll(td)
+
+	home := filepath.Join(td, "home")
+	project := filepath.Join(td, "project")
+	userDir := filepath.Join(home, ".terraform.d", PluginDir)
+	projectDir := filepath.Join(project, ProjectPluginDir)
+	osArch := runtime.GOOS + "_" + runtime.GOARCH
+	plugins := []string{
+		filepath.Join(userDir, "terraform-provider-user"),
+		filepath.Join(userDir, "terraform-provider-override"),
+		filepath.Join(userDir, osArch, "terraform-provisioner-arch"),
+		filepath.Join(projectDir, "terraform-provider-override"),
+		filepath.Join(projectDir, osArch, "terraform-provider-project"),
+	}
+	for _, p := range plugins {
+		if err := os.MkdirAll(filepath.Dir(p), 0755); err != nil {
+			t.Fatalf("err: %s", err)
+		}
+		if err := ioutil.WriteFile(p, nil, 0755); err != nil {
+			t.Fatalf("err: %s", err)
+		}
+	}
+
+	oldHome := os.Getenv("HOME")
+	defer os.Setenv("HOME", oldHome)
+	os.Setenv("HOME", home)
+
+	cwd, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	defer os.Chdir(cwd)
+	if err := os.Chdir(project); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	var c Config
+	if err := c.Discover(); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	// Resolve symlinks in the temporary directory, since the plugins in
+	// the project are found relative to the current directory.
+	project, err = filepath.EvalSymlinks(project)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	projectDir = filepath.Join(project, ProjectPluginDir)
+
+	expectedProviders := map[string]string{
+		"user":     filepath.Join(userDir, "terraform-provider-user"),
+		"override": filepath.Join(projectDir, "terraform-provider-override"),
+		"project":  filepath.Join(projectDir, osArch, "terraform-provider-project"),
+	}
+	for k, v := range expectedProviders {
+		if c.Providers[k] != v {
+			t.Fatalf("bad %s: %s", k, c.Providers[k])
+		}
+	}
+
+	expected := filepath.Join(userDir, osArch, "terraform-provisioner-arch")
+	if c.Provisioners["arch"] != expected {
+		t.Fatalf("bad: %s", c.Provisioners["arch"])
+	}
+}
@@ -0,0 +1,283 @@
+package main
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform/terraform"
+)
+
+// crashPlanMaxResources is the number of resources from a plan that are
+// included in a crash bundle.
+const crashPlanMaxResources = 100
+
+var (
+	// crashPluginPanicRe matches the relayed log output of a plugin that
+	// panicked.
+	crashPluginPanicRe = regexp.MustCompile(
+		`terraform-(?:provider|provisioner)-[\w-]+: panic: `)
+
+	// crashRedactRes match secrets in logs that are removed from crash
+	// bundles. The last group of each match is replaced.
+	crashRedactRes = []*regexp.Regexp{
+		regexp.MustCompile(
+			`(?s)(-----BEGIN [A-Z ]*PRIVATE KEY-----)(.*?-----END [A-Z ]*PRIVATE KEY-----)`),
+		regexp.MustCompile(
+			`(?i)((?:password|secret|token|private_key|access_key|secret_key|api_key)[\w.]*":\*terraform\.ResourceAttrDiff\{Old:")([^}]*)`),
+		regexp.MustCompile(
+			`(?i)((?:password|secret|token|private_key|access_key|secret_key|api_key)[\w.]*"?\s*(?:[:=]|=>)\s*"?)([^"\s,}*][^"\s,}]*)`),
+		regexp.MustCompile(`()(AKIA[0-9A-Z]{16})`),
+	}
+)
+
+// crashRedacted replaces secrets in crash bundles.
+const crashRedacted = "<redacted>"
+
+// crashBundle is the information about a crash that is written to a
+// crash bundle, to be attached to bug reports.
+type crashBundle struct {
+	// Log is the complete log of the run, including the panic.
+	Log []byte
+
+	// Args are the command line arguments of the run.
+	Args []string
+}
+
+// pluginCrashed returns whether the log of a run shows that a plugin
+// crashed.
+func pluginCrashed(log []byte) bool {
+	return crashPluginPanicRe.Match(log)
+}
+
+// Write writes the crash bundle as a gzipped tar file to the directory,
+// and returns its path. If the directory can't be written to, the bundle
+// is written to the temporary directory instead.
+func (b *crashBundle) Write(dir string) (string, error) {
+	name := fmt.Sprintf("crash-%s.tar.gz", time.Now().Format("20060102-150405"))
+	f, err := os.Create(filepath.Join(dir, name))
+	if err != nil {
+		f, err = os.Create(filepath.Join(os.TempDir(), name))
+		if err != nil {
+			return "", err
+		}
+	}
+	defer f.Close()
+
+	gzw := gzip.NewWriter(f)
+	tw := tar.NewWriter(gzw)
+	files := []struct {
+		Name string
+		Data []byte
+	}{
+		{"crash.log", crashRedact(b.Log)},
+		{"version.txt", []byte(b.version())},
+		{"graph.txt", []byte(crashGraph(b.Log))},
+		{"plan.txt", []byte(b.plan())},
+	}
+	for _, file := range files {
+		if len(file.Data) == 0 {
+			continue
+		}
+
+		hdr := &tar.Header{
+			Name:    file.Name,
+			Mode:    0644,
+			Size:    int64(len(file.Data)),
+			ModTime: time.Now(),
+		}
+		if err := tw.WriteHeader(hdr); err != nil {
+			return "", err
+		}
+		if _, err := tw.Write(file.Data); err != nil {
+			return "", err
+		}
+	}
+
+	if err := tw.Close(); err != nil {
+		return "", err
+	}
+	if err := gzw.Close(); err != nil {
+		return "", err
+	}
+
+	return f.Name(), nil
+}
+
+// version returns the versions of Terraform and its environment, and the
+// command that was run with the values of variables removed.
+func (b *crashBundle) version() string {
+	var buf bytes.Buffer
+	buf.WriteString(fmt.Sprintf("Terraform v%s", Version))
+	if VersionPrerelease != "" {
+		buf.WriteString(fmt.Sprintf("-%s", VersionPrerelease))
+	}
+	if GitCommit != "" {
+		buf.WriteString(fmt.Sprintf(" (%s)", GitCommit))
+	}
+	buf.WriteString("\n")
+	buf.WriteString(fmt.Sprintf("Go: %s\n", runtime.Version()))
+	buf.WriteString(fmt.Sprintf("OS: %s/%s\n", runtime.GOOS, runtime.GOARCH))
+	buf.WriteString(fmt.Sprintf(
+		"Command: terraform %s\n", strings.Join(crashArgs(b.Args), " ")))
+	return buf.String()
+}
+
+// plan returns the resources changed by the plan file given as an
+// argument, if there is one. Only the actions are included, since the
+// attributes may contain secrets.
+func (b *crashBundle) plan() string {
+	for _, arg := range b.Args {
+		if strings.HasPrefix(arg, "-") {
+			continue
+		}
+
+		f, err := os.Open(arg)
+		if err != nil {
+			continue
+		}
+		plan, err := terraform.ReadPlan(f)
+		f.Close()
+		if err != nil || plan.Diff == nil {
+			continue
+		}
+
+		return crashPlan(plan)
+	}
+
+	return ""
+}
+
+// crashPlan returns an excerpt of the plan without attribute values.
+func crashPlan(plan *terraform.Plan) string {
+	var buf bytes.Buffer
+	n := 0
+	for _, m := range plan.Diff.Modules {
+		prefix := ""
+		if len(m.Path) > 1 {
+			prefix = "module." + strings.Join(m.Path[1:], ".") + "."
+		}
+
+		for key, rdiff := range m.Resources {
+			var symbol string
+			switch rdiff.ChangeType() {
+			case terraform.DiffCreate:
+				symbol = "+"
+			case terraform.DiffUpdate:
+				symbol = "~"
+			case terraform.DiffDestroy:
+				symbol = "-"
+			case terraform.DiffDestroyCreate:
+				symbol = "-/+"
+			default:
+				continue
+			}
+
+			n++
+			if n > crashPlanMaxResources {
+				continue
+			}
+			buf.WriteString(fmt.Sprintf(
+				"%s %s%s (%d attributes)\n",
+				symbol, prefix, key, len(rdiff.Attributes)))
+		}
+	}
+	if n > crashPlanMaxResources {
+		buf.WriteString(fmt.Sprintf(
+			"... and %d more\n", n-crashPlanMaxResources))
+	}
+
+	return buf.String()
+}
+
+// crashArgs returns the command line arguments with the values of
+// variables removed.
+func crashArgs(args []string) []string {
+	result := make([]string, len(args))
+	redactNext := false
+	for i, arg := range args {
+		if redactNext {
+			arg = crashRedactVar(arg)
+			redactNext = false
+		} else if arg == "-var" || arg == "--var" {
+			redactNext = true
+		} else if strings.HasPrefix(arg, "-var=") || strings.HasPrefix(arg, "--var=") {
+			idx := strings.Index(arg, "=")
+			arg = arg[:idx+1] + crashRedactVar(arg[idx+1:])
+		}
+
+		result[i] = arg
+	}
+
+	return result
+}
+
+func crashRedactVar(v string) string {
+	if idx := strings.Index(v, "="); idx >= 0 {
+		return v[:idx+1] + crashRedacted
+	}
+
+	return crashRedacted
+}
+
+// crashRedact removes secrets from a log. This is best effort, so crash
+// bundles should still be checked before they are shared.
+func crashRedact(log []byte) []byte {
+	for _, re := range crashRedactRes {
+		log = re.ReplaceAll(log, []byte("${1}"+crashRedacted))
+	}
+
+	return log
+}
+
+// crashGraph returns the last graph that was logged, which is the graph
+// that was being walked when Terraform crashed.
+func crashGraph(log []byte) string {
+	const graphPrefix = "Graph after step "
+
+	var graph string
+	var record *logRecord
+	done := func() {
+		if record != nil && strings.HasPrefix(record.Message, graphPrefix) {
+			graph = record.Message
+		}
+	}
+
+	for _, line := range strings.Split(string(log), "\n") {
+		if r := parseLogRecord(line); r != nil {
+			done()
+			record = r
+		} else if record != nil {
+			record.Message += "\n" + line
+		}
+	}
+	done()
+
+	if graph == "" {
+		return ""
+	}
+
+	return strings.TrimSpace(graph) + "\n"
+}
+
+// readCrashLog reads the log of a run from the start.
+func readCrashLog(f *os.File) ([]byte, error) {
+	if _, err := f.Seek(0, 0); err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	if _, err := io.Copy(&buf, f); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
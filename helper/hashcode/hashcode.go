@@ -1,7 +1,10 @@
 package hashcode
 
 import (
+	"crypto/sha256"
+	"encoding/binary"
 	"hash/crc32"
+	"strconv"
 )
 
 // String hashes a string to a unique hashcode.
@@ -17,3 +20,24 @@ func String(s string) int {
 
 	return v
 }
+
+// Strings hashes a list of strings to a unique hashcode, such as the
+// fields of a set element.
+//
+// Set functions that join the fields with a separator and call String
+// give the same hashcode to ["a-b", "c"] and ["a", "b-c"], and CRC32
+// doesn't spread similar inputs well. Strings prefixes every string with
+// its length, so that different lists never hash the same input, and uses
+// SHA-256. The result fits in 31 bits, so that it is the same on every
+// platform.
+func Strings(strs ...string) int {
+	h := sha256.New()
+	for _, s := range strs {
+		h.Write([]byte(strconv.Itoa(len(s))))
+		h.Write([]byte{':'})
+		h.Write([]byte(s))
+	}
+
+	sum := h.Sum(nil)
+	return int(binary.BigEndian.Uint32(sum[:4]) >> 1)
+}
@@ -1,6 +1,7 @@
 package hashcode
 
 import (
+	"strconv"
 	"testing"
 )
 
@@ -23,3 +24,32 @@ func TestString_positiveIndex(t *testing.T) {
 		}
 	}
 }
+
+func TestStrings(t *testing.T) {
+	expected := Strings("foo", "bar")
+	for i := 0; i < 100; i++ {
+		if actual := Strings("foo", "bar"); actual != expected {
+			t.Fatalf("bad: %#v\n\t%#v", actual, expected)
+		}
+	}
+
+	cases := [][2][]string{
+		{{"a-b", "c"}, {"a", "b-c"}},
+		{{"ab", ""}, {"a", "b"}},
+		{{""}, {}},
+		{{"foo", "bar"}, {"bar", "foo"}},
+	}
+	for i, tc := range cases {
+		if Strings(tc[0]...) == Strings(tc[1]...) {
+			t.Fatalf("%d: %#v and %#v collide", i, tc[0], tc[1])
+		}
+	}
+}
+
+func TestStrings_positiveIndex(t *testing.T) {
+	for i := 0; i < 1000; i++ {
+		if index := Strings(strconv.Itoa(i)); index < 0 {
+			t.Fatalf("Bad Index %#v for %d", index, i)
+		}
+	}
+}
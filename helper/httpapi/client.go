@@ -0,0 +1,160 @@
+// Package httpapi is a small client for the HTTP APIs of providers that
+// don't have a Go library of their own. It sends the requests, decodes
+// the JSON answers and turns failed answers into errors.
+package httpapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"net/http"
+)
+
+// StatusError is the error returned for a request that the API failed,
+// with the HTTP status of the answer and the message of the error.
+type StatusError struct {
+	Code    int
+	Message string
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("%d: %s", e.Code, e.Message)
+}
+
+// IsNotFound returns whether the error is the API's answer for an object
+// that doesn't exist.
+func IsNotFound(err error) bool {
+	se, ok := err.(*StatusError)
+	return ok && se.Code == http.StatusNotFound
+}
+
+// Client sends requests to an HTTP API.
+//
+// The bodies of the requests and answers are never logged, since they
+// can hold passwords, keys and the data of secrets.
+type Client struct {
+	// BaseURL is the URL that the paths of the requests are relative to.
+	BaseURL string
+
+	// Name is the name of the API in the logs, such as "Grafana".
+	Name string
+
+	// HTTP is the client that sends the requests. If it is nil,
+	// http.DefaultClient is used.
+	HTTP *http.Client
+
+	// Header holds the headers to set on each request, such as the one
+	// with the API key.
+	Header http.Header
+
+	// Username and Password are sent with basic auth if Username is set.
+	Username string
+	Password string
+
+	// Sign, if set, is called with each request and its body just before
+	// it is sent, for APIs whose requests must be signed.
+	Sign func(req *http.Request, body []byte) error
+
+	// ErrorMessage returns the message of the error in the body of a
+	// failed answer. If it is nil, the message is the "message" field of
+	// a JSON body. If there is no message, it is the whole body.
+	ErrorMessage func(body []byte) string
+}
+
+// Do sends a request with in as its JSON body, unless it is nil, and
+// decodes the JSON body of the answer into out, unless it is nil or the
+// body is empty.
+func (c *Client) Do(method, path string, in, out interface{}) error {
+	var body []byte
+	var contentType string
+	if in != nil {
+		var err error
+		body, err = json.Marshal(in)
+		if err != nil {
+			return err
+		}
+		contentType = "application/json"
+	}
+
+	raw, _, err := c.Send(method, path, contentType, body)
+	if err != nil {
+		return err
+	}
+
+	if out == nil || len(raw) == 0 {
+		return nil
+	}
+	return json.Unmarshal(raw, out)
+}
+
+// Send sends a request with the given body of the given content type,
+// which are both empty for a request without a body, and returns the body
+// and the headers of the answer. A failed answer is a *StatusError.
+func (c *Client) Send(
+	method, path, contentType string, body []byte) ([]byte, http.Header, error) {
+	url := c.BaseURL + path
+	log.Printf("[DEBUG] %s request: %s %s", c.Name, method, url)
+	req, err := http.NewRequest(method, url, bytes.NewReader(body))
+	if err != nil {
+		return nil, nil, err
+	}
+	for k, v := range c.Header {
+		req.Header[k] = v
+	}
+	req.Header.Set("Accept", "application/json")
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	if c.Username != "" {
+		req.SetBasicAuth(c.Username, c.Password)
+	}
+	if c.Sign != nil {
+		if err := c.Sign(req, body); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	httpClient := c.HTTP
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer resp.Body.Close()
+
+	raw, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		errorMessage := c.ErrorMessage
+		if errorMessage == nil {
+			errorMessage = messageField
+		}
+		message := errorMessage(raw)
+		if message == "" {
+			message = string(raw)
+		}
+		return nil, nil, &StatusError{Code: resp.StatusCode, Message: message}
+	}
+
+	return raw, resp.Header, nil
+}
+
+// messageField returns the "message" field of a JSON body, which is where
+// most APIs put the message of their errors.
+func messageField(body []byte) string {
+	var status struct {
+		Message string `json:"message"`
+	}
+	if err := json.Unmarshal(body, &status); err != nil {
+		return ""
+	}
+
+	return status.Message
+}
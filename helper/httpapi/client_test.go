@@ -0,0 +1,135 @@
+package httpapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestClientDo(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			if r.Method != "POST" || r.URL.Path != "/things" {
+				t.Errorf("bad request: %s %s", r.Method, r.URL.Path)
+			}
+			if v := r.Header.Get("X-Api-Key"); v != "key" {
+				t.Errorf("bad key: %s", v)
+			}
+			if v := r.Header.Get("Content-Type"); v != "application/json" {
+				t.Errorf("bad content type: %s", v)
+			}
+			if user, pass, ok := r.BasicAuth(); !ok || user != "user" || pass != "pass" {
+				t.Errorf("bad basic auth: %s %s", user, pass)
+			}
+			if v := r.Header.Get("X-Signature"); v != `{"name":"foo"}` {
+				t.Errorf("bad signature: %s", v)
+			}
+
+			var in map[string]string
+			if err := json.NewDecoder(r.Body).Decode(&in); err != nil {
+				t.Errorf("err: %s", err)
+			}
+			fmt.Fprintf(w, `{"id":42,"name":%q}`, in["name"])
+		}))
+	defer server.Close()
+
+	c := &Client{
+		BaseURL:  server.URL,
+		Name:     "Test",
+		Header:   http.Header{"X-Api-Key": []string{"key"}},
+		Username: "user",
+		Password: "pass",
+		Sign: func(req *http.Request, body []byte) error {
+			req.Header.Set("X-Signature", string(body))
+			return nil
+		},
+	}
+
+	var out struct {
+		ID   int    `json:"id"`
+		Name string `json:"name"`
+	}
+	if err := c.Do("POST", "/things", map[string]string{"name": "foo"}, &out); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if out.ID != 42 || out.Name != "foo" {
+		t.Fatalf("bad: %#v", out)
+	}
+}
+
+func TestClientDo_emptyBody(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			if r.Header.Get("Content-Type") != "" {
+				t.Errorf("bad content type: %s", r.Header.Get("Content-Type"))
+			}
+			if body, _ := ioutil.ReadAll(r.Body); len(body) > 0 {
+				t.Errorf("bad body: %s", body)
+			}
+			w.WriteHeader(http.StatusNoContent)
+		}))
+	defer server.Close()
+
+	c := &Client{BaseURL: server.URL}
+	var out map[string]interface{}
+	if err := c.Do("DELETE", "/things/42", nil, &out); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if out != nil {
+		t.Fatalf("bad: %#v", out)
+	}
+}
+
+func TestClientSend_error(t *testing.T) {
+	cases := []struct {
+		Body         string
+		ErrorMessage func([]byte) string
+		Expected     string
+	}{
+		{`{"message":"Not found"}`, nil, "404: Not found"},
+		{`Not found`, nil, "404: Not found"},
+		{`{"error":"gone"}`, nil, `404: {"error":"gone"}`},
+		{
+			`{"error":"gone"}`,
+			func(body []byte) string {
+				var status struct {
+					Error string `json:"error"`
+				}
+				json.Unmarshal(body, &status)
+				return status.Error
+			},
+			"404: gone",
+		},
+	}
+
+	for i, tc := range cases {
+		server := httptest.NewServer(http.HandlerFunc(
+			func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusNotFound)
+				fmt.Fprint(w, tc.Body)
+			}))
+
+		c := &Client{BaseURL: server.URL, ErrorMessage: tc.ErrorMessage}
+		_, _, err := c.Send("GET", "/things/42", "", nil)
+		server.Close()
+
+		if !IsNotFound(err) {
+			t.Fatalf("%d: should be not found: %s", i, err)
+		}
+		if err.Error() != tc.Expected {
+			t.Fatalf("%d: bad: %s", i, err)
+		}
+	}
+}
+
+func TestIsNotFound(t *testing.T) {
+	if IsNotFound(fmt.Errorf("404: Not found")) {
+		t.Fatal("only a StatusError can be not found")
+	}
+	if IsNotFound(&StatusError{Code: http.StatusForbidden}) {
+		t.Fatal("a 403 isn't not found")
+	}
+}
@@ -15,7 +15,13 @@ const UniqueIdPrefix = `terraform-`
 // applied (base32, remove padding, downcase) to make visually distinguishing
 // identifiers easier.
 func UniqueId() string {
-	return fmt.Sprintf("%s%s", UniqueIdPrefix,
+	return PrefixedUniqueId(UniqueIdPrefix)
+}
+
+// Helper for a resource to generate a unique identifier with the given
+// prefix, such as one chosen by the user, instead of UniqueIdPrefix.
+func PrefixedUniqueId(prefix string) string {
+	return fmt.Sprintf("%s%s", prefix,
 		strings.ToLower(
 			strings.Replace(
 				base32.StdEncoding.EncodeToString(uuidV4()),
@@ -23,3 +23,17 @@ func TestUniqueId(t *testing.T) {
 		ids[id] = struct{}{}
 	}
 }
+
+func TestPrefixedUniqueId(t *testing.T) {
+	a := PrefixedUniqueId("foo-")
+	b := PrefixedUniqueId("foo-")
+	if a == b {
+		t.Fatalf("Got duplicated id! %s", a)
+	}
+
+	for _, id := range []string{a, b} {
+		if !strings.HasPrefix(id, "foo-") {
+			t.Fatalf("Prefixed unique ID didn't have foo- prefix! %s", id)
+		}
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"log"
 	"math"
+	"math/rand"
 	"time"
 )
 
@@ -29,6 +30,17 @@ type StateChangeConf struct {
 	Timeout        time.Duration    // The amount of time to wait before timeout
 	MinTimeout     time.Duration    // Smallest time to wait before refreshes
 	NotFoundChecks int              // Number of times to allow not found
+	Jitter         time.Duration    // Random extra wait of up to this long between refreshes
+
+	// ContinuousTargetOccurence is the number of times in a row the Target
+	// state has to be seen before the wait is over, for eventually
+	// consistent APIs that may briefly report the target state and then a
+	// pending or not found one. Defaults to 1.
+	ContinuousTargetOccurence int
+
+	// StopCh aborts the wait when it is closed, such as the channel of
+	// schema.ResourceData.StopCh when Terraform is interrupted.
+	StopCh <-chan struct{}
 }
 
 // WaitForState watches an object and waits for it to achieve the state
@@ -40,21 +52,31 @@ type StateChangeConf struct {
 // If the Refresh function returns a state other than the Target state or one
 // listed in Pending, return immediately with an error.
 //
+// If the Refresh function doesn't find the object more than NotFoundChecks
+// times in a row, return an error, unless we're waiting for its absence.
+//
 // If the Timeout is exceeded before reaching the Target state, return an
 // error.
 //
-// Otherwise, result the result of the first call to the Refresh function to
-// reach the target state.
+// Otherwise, result the result of the last call to the Refresh function,
+// once the target state was reached ContinuousTargetOccurence times in a
+// row.
 func (conf *StateChangeConf) WaitForState() (interface{}, error) {
 	log.Printf("[DEBUG] Waiting for state to become: %s", conf.Target)
 
 	notfoundTick := 0
+	targetOccurence := 0
 
 	// Set a default for times to check for not found
 	if conf.NotFoundChecks == 0 {
 		conf.NotFoundChecks = 20
 	}
 
+	// Set a default for the times the target has to be seen in a row
+	if conf.ContinuousTargetOccurence == 0 {
+		conf.ContinuousTargetOccurence = 1
+	}
+
 	var result interface{}
 	var resulterr error
 
@@ -76,6 +98,12 @@ func (conf *StateChangeConf) WaitForState() (interface{}, error) {
 				wait = 10 * time.Second
 			}
 
+			// Spread out the refreshes, so that many resources waiting at
+			// once don't all hit the API at the same time.
+			if conf.Jitter > 0 {
+				wait += time.Duration(rand.Int63n(int64(conf.Jitter)))
+			}
+
 			log.Printf("[TRACE] Waiting %s before next try", wait)
 			time.Sleep(wait)
 
@@ -86,43 +114,57 @@ func (conf *StateChangeConf) WaitForState() (interface{}, error) {
 				return
 			}
 
-			// If we're waiting for the absence of a thing, then return
+			// If we're waiting for the absence of a thing, then it counts
+			// as reaching the target
 			if result == nil && conf.Target == "" {
-				return
+				targetOccurence += 1
+				if targetOccurence >= conf.ContinuousTargetOccurence {
+					return
+				}
+				continue
 			}
 
 			if result == nil {
 				// If we didn't find the resource, check if we have been
 				// not finding it for awhile, and if so, report an error.
+				targetOccurence = 0
 				notfoundTick += 1
 				if notfoundTick > conf.NotFoundChecks {
 					resulterr = errors.New("couldn't find resource")
 					return
 				}
-			} else {
-				// Reset the counter for when a resource isn't found
-				notfoundTick = 0
+				continue
+			}
+
+			// Reset the counter for when a resource isn't found
+			notfoundTick = 0
 
-				if currentState == conf.Target {
+			if currentState == conf.Target {
+				targetOccurence += 1
+				if targetOccurence >= conf.ContinuousTargetOccurence {
 					return
 				}
+				continue
+			}
 
-				found := false
-				for _, allowed := range conf.Pending {
-					if currentState == allowed {
-						found = true
-						break
-					}
-				}
+			// Any other state starts the count of the target over
+			targetOccurence = 0
 
-				if !found {
-					resulterr = fmt.Errorf(
-						"unexpected state '%s', wanted target '%s'",
-						currentState,
-						conf.Target)
-					return
+			found := false
+			for _, allowed := range conf.Pending {
+				if currentState == allowed {
+					found = true
+					break
 				}
 			}
+
+			if !found {
+				resulterr = fmt.Errorf(
+					"unexpected state '%s', wanted target '%s'",
+					currentState,
+					conf.Target)
+				return
+			}
 		}
 	}()
 
@@ -133,5 +175,9 @@ func (conf *StateChangeConf) WaitForState() (interface{}, error) {
 		return nil, fmt.Errorf(
 			"timeout while waiting for state to become '%s'",
 			conf.Target)
+	case <-conf.StopCh:
+		return nil, fmt.Errorf(
+			"interrupted while waiting for state to become '%s'",
+			conf.Target)
 	}
 }
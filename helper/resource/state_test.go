@@ -45,6 +45,27 @@ func TestWaitForState_timeout(t *testing.T) {
 
 }
 
+func TestWaitForState_stop(t *testing.T) {
+	stopCh := make(chan struct{})
+	close(stopCh)
+
+	conf := &StateChangeConf{
+		Pending: []string{"pending", "incomplete"},
+		Target:  "running",
+		Refresh: TimeoutStateRefreshFunc(),
+		Timeout: 200 * time.Second,
+		StopCh:  stopCh,
+	}
+
+	obj, err := conf.WaitForState()
+	if err == nil || err.Error() != "interrupted while waiting for state to become 'running'" {
+		t.Fatalf("err: %s", err)
+	}
+	if obj != nil {
+		t.Fatalf("should not return obj")
+	}
+}
+
 func TestWaitForState_success(t *testing.T) {
 	conf := &StateChangeConf{
 		Pending: []string{"pending", "incomplete"},
@@ -97,3 +118,98 @@ func TestWaitForState_failure(t *testing.T) {
 		t.Fatalf("should not return obj")
 	}
 }
+
+func TestWaitForState_continuousTargetOccurence(t *testing.T) {
+	// The target is briefly reported before the object flaps back
+	states := []string{"running", "pending", "running", "running", "pending"}
+	refreshes := 0
+
+	conf := &StateChangeConf{
+		Pending: []string{"pending"},
+		Target:  "running",
+		Refresh: func() (interface{}, string, error) {
+			state := states[refreshes]
+			refreshes++
+			return refreshes, state, nil
+		},
+		Timeout:                   200 * time.Second,
+		ContinuousTargetOccurence: 2,
+	}
+
+	obj, err := conf.WaitForState()
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if obj.(int) != 4 {
+		t.Fatalf("bad: %#v", obj)
+	}
+}
+
+func TestWaitForState_continuousTargetOccurenceEmpty(t *testing.T) {
+	// The object disappears, comes back and disappears for good
+	found := []bool{false, true, false, false}
+	refreshes := 0
+
+	conf := &StateChangeConf{
+		Pending: []string{"deleting"},
+		Target:  "",
+		Refresh: func() (interface{}, string, error) {
+			f := found[refreshes]
+			refreshes++
+			if !f {
+				return nil, "", nil
+			}
+			return 42, "deleting", nil
+		},
+		Timeout:                   200 * time.Second,
+		ContinuousTargetOccurence: 2,
+	}
+
+	if _, err := conf.WaitForState(); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if refreshes != 4 {
+		t.Fatalf("bad: %d", refreshes)
+	}
+}
+
+func TestWaitForState_notFoundChecks(t *testing.T) {
+	refreshes := 0
+	conf := &StateChangeConf{
+		Pending: []string{"pending"},
+		Target:  "running",
+		Refresh: func() (interface{}, string, error) {
+			refreshes++
+			return nil, "", nil
+		},
+		Timeout:        200 * time.Second,
+		MinTimeout:     1 * time.Millisecond,
+		NotFoundChecks: 2,
+	}
+
+	_, err := conf.WaitForState()
+	if err == nil || err.Error() != "couldn't find resource" {
+		t.Fatalf("err: %s", err)
+	}
+	if refreshes != 3 {
+		t.Fatalf("bad: %d", refreshes)
+	}
+}
+
+func TestWaitForState_jitter(t *testing.T) {
+	conf := &StateChangeConf{
+		Pending: []string{"pending"},
+		Target:  "running",
+		Refresh: SuccessfulStateRefreshFunc(),
+		Timeout: 200 * time.Second,
+		Jitter:  50 * time.Millisecond,
+	}
+
+	obj, err := conf.WaitForState()
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if obj == nil {
+		t.Fatalf("should return obj")
+	}
+}
@@ -0,0 +1,209 @@
+package resource
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"sort"
+	"strings"
+	"testing"
+)
+
+// flagSweep is the comma-separated list of regions to run the sweepers
+// in. If it is set, TestMain runs the sweepers instead of the tests.
+var flagSweep = flag.String("sweep", "",
+	"comma-separated list of regions to sweep leaked acceptance test resources in")
+
+// flagSweepRun limits the sweepers that are run to the comma-separated
+// list of names. The dependencies of these sweepers are still run.
+var flagSweepRun = flag.String("sweep-run", "",
+	"comma-separated list of sweepers to run, defaults to all")
+
+// SweeperFunc is the function called by a sweeper to remove the resources
+// leaked by acceptance tests in a region.
+type SweeperFunc func(region string) error
+
+// Sweeper removes the resources that acceptance tests leaked, such as
+// when a test failed halfway through and couldn't destroy them.
+//
+// Sweepers must only remove resources that can be recognized as created
+// by acceptance tests, such as by a name prefix, since they're run
+// against a real account.
+type Sweeper struct {
+	// Name is the name of the sweeper, which is used to refer to it in
+	// Dependencies and the -sweep-run flag.
+	Name string
+
+	// Dependencies are the names of the sweepers that must run before
+	// this one, because their resources prevent this sweeper's resources
+	// from being removed. For example, instances must be removed before
+	// the VPC they are in.
+	Dependencies []string
+
+	// F is the function that removes the resources.
+	F SweeperFunc
+}
+
+var sweepers = make(map[string]*Sweeper)
+
+// AddTestSweepers registers a sweeper. This is usually called from an
+// init function in the tests of a provider. It panics if a sweeper with
+// the same name is already registered.
+func AddTestSweepers(name string, s *Sweeper) {
+	if _, ok := sweepers[name]; ok {
+		panic(fmt.Sprintf("sweeper already registered: %s", name))
+	}
+
+	if s.Name == "" {
+		s.Name = name
+	}
+	sweepers[name] = s
+}
+
+// TestMain is the entry point for the tests of packages that register
+// sweepers. It is called from a TestMain function in the package:
+//
+//	func TestMain(m *testing.M) {
+//		resource.TestMain(m)
+//	}
+//
+// If the -sweep flag is given, the sweepers are run in each of the
+// regions instead of the tests:
+//
+//	go test ./builtin/providers/aws -v -sweep=us-west-2,us-east-1
+func TestMain(m *testing.M) {
+	flag.Parse()
+	if *flagSweep == "" {
+		os.Exit(m.Run())
+	}
+
+	if err := runSweepers(
+		splitSweepFlag(*flagSweep), splitSweepFlag(*flagSweepRun)); err != nil {
+		fmt.Fprintf(os.Stderr, "%s\n", err)
+		os.Exit(1)
+	}
+
+	os.Exit(0)
+}
+
+// runSweepers runs the named sweepers, or all of them if names is
+// empty, in each of the regions. Every sweeper is run, even if others
+// fail, except for those that depend on a failed sweeper.
+func runSweepers(regions, names []string) error {
+	order, err := sweeperOrder(sweepers, names)
+	if err != nil {
+		return err
+	}
+
+	var errs []string
+	for _, region := range regions {
+		log.Printf("[INFO] Sweeping region: %s", region)
+
+		failed := make(map[string]bool)
+		for _, s := range order {
+			skip := false
+			for _, dep := range s.Dependencies {
+				if failed[dep] {
+					skip = true
+					break
+				}
+			}
+			if skip {
+				log.Printf(
+					"[WARN] Skipping sweeper %s in %s: a dependency failed",
+					s.Name, region)
+				failed[s.Name] = true
+				continue
+			}
+
+			log.Printf("[INFO] Running sweeper %s in %s", s.Name, region)
+			if err := s.F(region); err != nil {
+				failed[s.Name] = true
+				errs = append(errs, fmt.Sprintf(
+					"sweeper %s in %s: %s", s.Name, region, err))
+			}
+		}
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf(
+			"Error sweeping:\n\n%s", strings.Join(errs, "\n"))
+	}
+
+	return nil
+}
+
+// sweeperOrder returns the sweepers to run for the given names in the
+// order they must run, with dependencies before the sweepers that depend
+// on them. Sweepers that don't depend on each other are sorted by name.
+func sweeperOrder(all map[string]*Sweeper, names []string) ([]*Sweeper, error) {
+	if len(names) == 0 {
+		for name, _ := range all {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	var result []*Sweeper
+	done := make(map[string]bool)
+	visiting := make(map[string]bool)
+
+	var visit func(name string, path []string) error
+	visit = func(name string, path []string) error {
+		if done[name] {
+			return nil
+		}
+
+		path = append(path, name)
+		if visiting[name] {
+			return fmt.Errorf(
+				"sweeper dependency cycle: %s", strings.Join(path, " -> "))
+		}
+
+		s, ok := all[name]
+		if !ok {
+			if len(path) > 1 {
+				return fmt.Errorf(
+					"sweeper %s depends on unknown sweeper %s",
+					path[len(path)-2], name)
+			}
+
+			return fmt.Errorf("unknown sweeper: %s", name)
+		}
+
+		visiting[name] = true
+		deps := make([]string, len(s.Dependencies))
+		copy(deps, s.Dependencies)
+		sort.Strings(deps)
+		for _, dep := range deps {
+			if err := visit(dep, path); err != nil {
+				return err
+			}
+		}
+		visiting[name] = false
+
+		done[name] = true
+		result = append(result, s)
+		return nil
+	}
+
+	for _, name := range names {
+		if err := visit(name, nil); err != nil {
+			return nil, err
+		}
+	}
+
+	return result, nil
+}
+
+func splitSweepFlag(v string) []string {
+	var result []string
+	for _, part := range strings.Split(v, ",") {
+		if part = strings.TrimSpace(part); part != "" {
+			result = append(result, part)
+		}
+	}
+
+	return result
+}
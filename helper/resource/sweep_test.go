@@ -0,0 +1,146 @@
+package resource
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestSweeperOrder(t *testing.T) {
+	all := map[string]*Sweeper{
+		"instance": &Sweeper{Name: "instance"},
+		"elb":      &Sweeper{Name: "elb"},
+		"subnet": &Sweeper{
+			Name:         "subnet",
+			Dependencies: []string{"instance"},
+		},
+		"vpc": &Sweeper{
+			Name:         "vpc",
+			Dependencies: []string{"subnet", "elb"},
+		},
+	}
+
+	cases := []struct {
+		Names  []string
+		Result []string
+		Err    bool
+	}{
+		{
+			nil,
+			[]string{"elb", "instance", "subnet", "vpc"},
+			false,
+		},
+		{
+			[]string{"subnet"},
+			[]string{"instance", "subnet"},
+			false,
+		},
+		{
+			[]string{"vpc"},
+			[]string{"elb", "instance", "subnet", "vpc"},
+			false,
+		},
+		{
+			[]string{"nope"},
+			nil,
+			true,
+		},
+	}
+
+	for i, tc := range cases {
+		order, err := sweeperOrder(all, tc.Names)
+		if (err != nil) != tc.Err {
+			t.Fatalf("%d: err: %s", i, err)
+		}
+
+		var actual []string
+		for _, s := range order {
+			actual = append(actual, s.Name)
+		}
+		if !reflect.DeepEqual(actual, tc.Result) {
+			t.Fatalf("%d: bad: %#v", i, actual)
+		}
+	}
+}
+
+func TestSweeperOrder_cycle(t *testing.T) {
+	all := map[string]*Sweeper{
+		"a": &Sweeper{Name: "a", Dependencies: []string{"b"}},
+		"b": &Sweeper{Name: "b", Dependencies: []string{"a"}},
+	}
+
+	_, err := sweeperOrder(all, nil)
+	if err == nil || !strings.Contains(err.Error(), "cycle") {
+		t.Fatalf("bad: %s", err)
+	}
+}
+
+func TestSweeperOrder_unknownDependency(t *testing.T) {
+	all := map[string]*Sweeper{
+		"a": &Sweeper{Name: "a", Dependencies: []string{"b"}},
+	}
+
+	_, err := sweeperOrder(all, nil)
+	if err == nil || !strings.Contains(err.Error(), "unknown sweeper b") {
+		t.Fatalf("bad: %s", err)
+	}
+}
+
+func TestRunSweepers(t *testing.T) {
+	old := sweepers
+	defer func() { sweepers = old }()
+	sweepers = make(map[string]*Sweeper)
+
+	var calls []string
+	sweeper := func(name string, err error) SweeperFunc {
+		return func(region string) error {
+			calls = append(calls, name+":"+region)
+			return err
+		}
+	}
+
+	AddTestSweepers("instance", &Sweeper{
+		F: sweeper("instance", fmt.Errorf("failed")),
+	})
+	AddTestSweepers("elb", &Sweeper{
+		F: sweeper("elb", nil),
+	})
+	AddTestSweepers("vpc", &Sweeper{
+		Dependencies: []string{"instance"},
+		F:            sweeper("vpc", nil),
+	})
+
+	err := runSweepers([]string{"us-east-1", "us-west-2"}, nil)
+	if err == nil {
+		t.Fatal("should error")
+	}
+	if !strings.Contains(err.Error(), "sweeper instance in us-west-2: failed") {
+		t.Fatalf("bad: %s", err)
+	}
+
+	expected := []string{
+		"elb:us-east-1",
+		"instance:us-east-1",
+		"elb:us-west-2",
+		"instance:us-west-2",
+	}
+	if !reflect.DeepEqual(calls, expected) {
+		t.Fatalf("bad: %#v", calls)
+	}
+}
+
+func TestAddTestSweepers_duplicate(t *testing.T) {
+	old := sweepers
+	defer func() { sweepers = old }()
+	sweepers = make(map[string]*Sweeper)
+
+	AddTestSweepers("foo", &Sweeper{})
+
+	defer func() {
+		if recover() == nil {
+			t.Fatal("should panic")
+		}
+	}()
+	AddTestSweepers("foo", &Sweeper{})
+}
@@ -0,0 +1,5 @@
+variable "name" {}
+
+output "greeting" {
+    value = "hello ${var.name}"
+}
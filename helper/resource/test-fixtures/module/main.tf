@@ -0,0 +1,16 @@
+variable "name" {}
+
+resource "test_instance" "foo" {}
+
+module "child" {
+    source = "./child"
+    name = "${var.name}"
+}
+
+output "id" {
+    value = "${test_instance.foo.id}"
+}
+
+output "greeting" {
+    value = "${module.child.greeting}"
+}
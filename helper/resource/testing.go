@@ -63,6 +63,16 @@ type TestStep struct {
 	// Config a string of the configuration to give to Terraform.
 	Config string
 
+	// ConfigDir is the path of a directory with the configuration to
+	// give to Terraform, instead of Config. This tests a module: the
+	// directory is loaded as the root module, with the fixture variables
+	// in Vars, and the modules it uses are downloaded to a temporary
+	// directory.
+	ConfigDir string
+
+	// Vars are the values of the variables of the configuration.
+	Vars map[string]string
+
 	// Check is called after the Config is applied. Use this step to
 	// make your own API calls to check the status of things, and to
 	// inspect the format of the ResourceState itself.
@@ -141,10 +151,13 @@ func Test(t TestT, c TestCase) {
 
 	// If we have a state, then run the destroy
 	if state != nil {
+		lastStep := c.Steps[len(c.Steps)-1]
 		destroyStep := TestStep{
-			Config:  c.Steps[len(c.Steps)-1].Config,
-			Check:   c.CheckDestroy,
-			Destroy: true,
+			Config:    lastStep.Config,
+			ConfigDir: lastStep.ConfigDir,
+			Vars:      lastStep.Vars,
+			Check:     c.CheckDestroy,
+			Destroy:   true,
 		}
 
 		log.Printf("[WARN] Test: Executing destroy step")
@@ -173,22 +186,28 @@ func testStep(
 	}
 	defer os.RemoveAll(cfgPath)
 
-	// Write the configuration
-	cfgF, err := os.Create(filepath.Join(cfgPath, "main.tf"))
-	if err != nil {
-		return state, fmt.Errorf(
-			"Error creating temporary file for config: %s", err)
-	}
+	// Write the configuration, unless it is in a directory
+	modPath := step.ConfigDir
+	if modPath == "" {
+		modPath = cfgPath
+		cfgF, err := os.Create(filepath.Join(cfgPath, "main.tf"))
+		if err != nil {
+			return state, fmt.Errorf(
+				"Error creating temporary file for config: %s", err)
+		}
 
-	_, err = io.Copy(cfgF, strings.NewReader(step.Config))
-	cfgF.Close()
-	if err != nil {
-		return state, fmt.Errorf(
-			"Error creating temporary file for config: %s", err)
+		_, err = io.Copy(cfgF, strings.NewReader(step.Config))
+		cfgF.Close()
+		if err != nil {
+			return state, fmt.Errorf(
+				"Error creating temporary file for config: %s", err)
+		}
+	} else if step.Config != "" {
+		return state, fmt.Errorf("Only one of Config and ConfigDir can be set")
 	}
 
 	// Parse the configuration
-	mod, err := module.NewTreeModule("", cfgPath)
+	mod, err := module.NewTreeModule("", modPath)
 	if err != nil {
 		return state, fmt.Errorf(
 			"Error loading configuration: %s", err)
@@ -207,6 +226,7 @@ func testStep(
 	opts.Module = mod
 	opts.State = state
 	opts.Destroy = step.Destroy
+	opts.Variables = step.Vars
 	ctx := terraform.NewContext(&opts)
 	if ws, es := ctx.Validate(); len(ws) > 0 || len(es) > 0 {
 		estrs := make([]string, len(es))
@@ -294,8 +314,19 @@ func ComposeTestCheckFunc(fs ...TestCheckFunc) TestCheckFunc {
 }
 
 func TestCheckResourceAttr(name, key, value string) TestCheckFunc {
+	return TestCheckModuleResourceAttr(terraform.RootModulePath, name, key, value)
+}
+
+// TestCheckModuleResourceAttr is like TestCheckResourceAttr, for a
+// resource in the module with the given path, such as
+// []string{"root", "network"}.
+func TestCheckModuleResourceAttr(path []string, name, key, value string) TestCheckFunc {
 	return func(s *terraform.State) error {
-		ms := s.RootModule()
+		ms := s.ModuleByPath(path)
+		if ms == nil {
+			return fmt.Errorf("Module not found: %s", strings.Join(path, "."))
+		}
+
 		rs, ok := ms.Resources[name]
 		if !ok {
 			return fmt.Errorf("Not found: %s", name)
@@ -328,6 +359,28 @@ func TestCheckResourceAttrPtr(name string, key string, value *string) TestCheckF
 	}
 }
 
+// TestCheckOutput checks that the output of the root module with the
+// given name has the given value.
+func TestCheckOutput(name, value string) TestCheckFunc {
+	return func(s *terraform.State) error {
+		ms := s.RootModule()
+		v, ok := ms.Outputs[name]
+		if !ok {
+			return fmt.Errorf("Output not found: %s", name)
+		}
+
+		if v != value {
+			return fmt.Errorf(
+				"Output '%s' expected %#v, got %#v",
+				name,
+				value,
+				v)
+		}
+
+		return nil
+	}
+}
+
 // TestT is the interface used to handle the test lifecycle of a test.
 //
 // Users should just use a *testing.T object, which implements this.
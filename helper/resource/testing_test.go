@@ -3,12 +3,16 @@ package resource
 import (
 	"fmt"
 	"os"
+	"path/filepath"
 	"sync/atomic"
 	"testing"
 
 	"github.com/hashicorp/terraform/terraform"
 )
 
+// fixtureDir is the directory of the test fixtures.
+const fixtureDir = "./test-fixtures"
+
 func init() {
 	testTesting = true
 
@@ -175,6 +179,132 @@ func TestTest_stepError(t *testing.T) {
 	}
 }
 
+func TestTest_configDir(t *testing.T) {
+	mp := testProvider()
+	mp.DiffReturn = nil
+
+	mp.ApplyReturn = &terraform.InstanceState{
+		ID: "foo",
+	}
+	var refreshCount int32
+	mp.RefreshFn = func(*terraform.InstanceInfo, *terraform.InstanceState) (*terraform.InstanceState, error) {
+		if atomic.AddInt32(&refreshCount, 1) == 1 {
+			return &terraform.InstanceState{ID: "foo"}, nil
+		}
+		return nil, nil
+	}
+
+	mt := new(mockT)
+	Test(mt, TestCase{
+		Providers: map[string]terraform.ResourceProvider{
+			"test": mp,
+		},
+		Steps: []TestStep{
+			TestStep{
+				ConfigDir: filepath.Join(fixtureDir, "module"),
+				Vars: map[string]string{
+					"name": "world",
+				},
+				Check: ComposeTestCheckFunc(
+					TestCheckOutput("id", "foo"),
+					TestCheckOutput("greeting", "hello world"),
+					TestCheckResourceAttr("test_instance.foo", "id", "foo"),
+				),
+			},
+		},
+	})
+
+	if mt.failed() {
+		t.Fatalf("test failed: %s", mt.failMessage())
+	}
+	if !mp.ApplyCalled {
+		t.Fatal("apply should be called")
+	}
+}
+
+func TestTest_configAndConfigDir(t *testing.T) {
+	mt := new(mockT)
+	Test(mt, TestCase{
+		Providers: map[string]terraform.ResourceProvider{
+			"test": testProvider(),
+		},
+		Steps: []TestStep{
+			TestStep{
+				Config:    testConfigStr,
+				ConfigDir: filepath.Join(fixtureDir, "module"),
+			},
+		},
+	})
+
+	if !mt.failed() {
+		t.Fatal("test should've failed")
+	}
+}
+
+func TestTestCheckOutput(t *testing.T) {
+	s := &terraform.State{
+		Modules: []*terraform.ModuleState{
+			&terraform.ModuleState{
+				Path: terraform.RootModulePath,
+				Outputs: map[string]interface{}{
+					"address": "10.0.0.1",
+					"list":    []interface{}{"a"},
+				},
+			},
+		},
+	}
+
+	cases := []struct {
+		Name  string
+		Value string
+		Err   bool
+	}{
+		{"address", "10.0.0.1", false},
+		{"address", "10.0.0.2", true},
+		{"list", "a", true},
+		{"nope", "", true},
+	}
+
+	for i, tc := range cases {
+		err := TestCheckOutput(tc.Name, tc.Value)(s)
+		if (err != nil) != tc.Err {
+			t.Fatalf("%d: bad: %s", i, err)
+		}
+	}
+}
+
+func TestTestCheckModuleResourceAttr(t *testing.T) {
+	s := &terraform.State{
+		Modules: []*terraform.ModuleState{
+			&terraform.ModuleState{
+				Path: []string{"root", "network"},
+				Resources: map[string]*terraform.ResourceState{
+					"aws_vpc.main": &terraform.ResourceState{
+						Type: "aws_vpc",
+						Primary: &terraform.InstanceState{
+							ID: "vpc-1",
+							Attributes: map[string]string{
+								"cidr_block": "10.0.0.0/16",
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	path := []string{"root", "network"}
+	if err := TestCheckModuleResourceAttr(path, "aws_vpc.main", "cidr_block", "10.0.0.0/16")(s); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if err := TestCheckModuleResourceAttr(path, "aws_vpc.main", "cidr_block", "10.1.0.0/16")(s); err == nil {
+		t.Fatal("should error")
+	}
+	if err := TestCheckModuleResourceAttr([]string{"root", "nope"}, "aws_vpc.main", "cidr_block", "")(s); err == nil {
+		t.Fatal("should error")
+	}
+}
+
 func TestComposeTestCheckFunc(t *testing.T) {
 	cases := []struct {
 		F      []TestCheckFunc
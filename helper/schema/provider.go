@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 	"sort"
+	"sync"
 
 	"github.com/hashicorp/terraform/terraform"
 )
@@ -33,13 +34,27 @@ type Provider struct {
 	// Diff, etc. to the proper resource.
 	ResourcesMap map[string]*Resource
 
+	// DataSourcesMap is the list of available data sources that this
+	// provider can read, such as the images of a cloud, along with their
+	// Resource structure defining their own schemas and Read function.
+	// Data sources can be omitted.
+	DataSourcesMap map[string]*Resource
+
 	// ConfigureFunc is a function for configuring the provider. If the
 	// provider doesn't need to be configured, this can be omitted.
 	//
 	// See the ConfigureFunc documentation for more information.
 	ConfigureFunc ConfigureFunc
 
-	meta interface{}
+	// InputFunc is called after the values in the Schema were asked for,
+	// and can ask for values that are only needed depending on others,
+	// such as a one-time token for a configured device. It can be
+	// omitted.
+	InputFunc InputFunc
+
+	meta     interface{}
+	stopCh   chan struct{}
+	stopLock sync.Mutex
 }
 
 // ConfigureFunc is the function used to configure a Provider.
@@ -50,6 +65,12 @@ type Provider struct {
 // structure, etc.
 type ConfigureFunc func(*ResourceData) (interface{}, error)
 
+// InputFunc is the function used to ask for additional input for the
+// configuration of a Provider. It returns the configuration with the
+// values that were input set.
+type InputFunc func(
+	terraform.UIInput, *terraform.ResourceConfig) (*terraform.ResourceConfig, error)
+
 // InternalValidate should be called to validate the structure
 // of the provider.
 //
@@ -71,6 +92,12 @@ func (p *Provider) InternalValidate() error {
 		}
 	}
 
+	for k, r := range p.DataSourcesMap {
+		if err := r.InternalValidateDataSource(); err != nil {
+			return fmt.Errorf("data source %s: %s", k, err)
+		}
+	}
+
 	return nil
 }
 
@@ -91,7 +118,12 @@ func (p *Provider) SetMeta(v interface{}) {
 func (p *Provider) Input(
 	input terraform.UIInput,
 	c *terraform.ResourceConfig) (*terraform.ResourceConfig, error) {
-	return schemaMap(p.Schema).Input(input, c)
+	c, err := schemaMap(p.Schema).Input(input, c)
+	if err != nil || p.InputFunc == nil {
+		return c, err
+	}
+
+	return p.InputFunc(input, c)
 }
 
 // Validate implementation of terraform.ResourceProvider interface.
@@ -141,6 +173,40 @@ func (p *Provider) Configure(c *terraform.ResourceConfig) error {
 	return nil
 }
 
+// Stop implements terraform.ResourceProviderStopper. It closes the channel
+// returned by StopCh, and with it ResourceData.StopCh, so that resources
+// can abort long running operations. Once stopped, the provider stays
+// stopped.
+func (p *Provider) Stop() error {
+	p.stopLock.Lock()
+	defer p.stopLock.Unlock()
+
+	if p.stopCh == nil {
+		p.stopCh = make(chan struct{})
+	}
+
+	select {
+	case <-p.stopCh:
+		// Already stopped
+	default:
+		close(p.stopCh)
+	}
+
+	return nil
+}
+
+// StopCh returns a channel that is closed when the provider is stopped.
+func (p *Provider) StopCh() <-chan struct{} {
+	p.stopLock.Lock()
+	defer p.stopLock.Unlock()
+
+	if p.stopCh == nil {
+		p.stopCh = make(chan struct{})
+	}
+
+	return p.stopCh
+}
+
 // Apply implementation of terraform.ResourceProvider interface.
 func (p *Provider) Apply(
 	info *terraform.InstanceInfo,
@@ -151,7 +217,7 @@ func (p *Provider) Apply(
 		return nil, fmt.Errorf("unknown resource type: %s", info.Type)
 	}
 
-	return r.Apply(s, d, p.meta)
+	return r.apply(s, d, p.meta, p.StopCh())
 }
 
 // Diff implementation of terraform.ResourceProvider interface.
@@ -164,7 +230,7 @@ func (p *Provider) Diff(
 		return nil, fmt.Errorf("unknown resource type: %s", info.Type)
 	}
 
-	return r.Diff(s, c)
+	return r.diff(s, c, p.meta)
 }
 
 // Refresh implementation of terraform.ResourceProvider interface.
@@ -176,7 +242,20 @@ func (p *Provider) Refresh(
 		return nil, fmt.Errorf("unknown resource type: %s", info.Type)
 	}
 
-	return r.Refresh(s, p.meta)
+	return r.refresh(s, p.meta, p.StopCh())
+}
+
+// ImportState implementation of terraform.ResourceProviderImporter
+// interface.
+func (p *Provider) ImportState(
+	info *terraform.InstanceInfo,
+	id string) (*terraform.InstanceState, error) {
+	r, ok := p.ResourcesMap[info.Type]
+	if !ok {
+		return nil, fmt.Errorf("unknown resource type: %s", info.Type)
+	}
+
+	return r.importState(id, p.meta, p.StopCh())
 }
 
 // Resources implementation of terraform.ResourceProvider interface.
@@ -196,3 +275,48 @@ func (p *Provider) Resources() []terraform.ResourceType {
 
 	return result
 }
+
+// ValidateDataSource implementation of
+// terraform.ResourceProviderDataSourcer interface.
+func (p *Provider) ValidateDataSource(
+	t string, c *terraform.ResourceConfig) ([]string, []error) {
+	r, ok := p.DataSourcesMap[t]
+	if !ok {
+		return nil, []error{fmt.Errorf(
+			"Provider doesn't support data source: %s", t)}
+	}
+
+	return r.Validate(c)
+}
+
+// ReadDataSource implementation of terraform.ResourceProviderDataSourcer
+// interface.
+func (p *Provider) ReadDataSource(
+	info *terraform.InstanceInfo,
+	c *terraform.ResourceConfig) (*terraform.InstanceState, error) {
+	r, ok := p.DataSourcesMap[info.Type]
+	if !ok {
+		return nil, fmt.Errorf("unknown data source: %s", info.Type)
+	}
+
+	return r.readDataSource(c, p.meta, p.StopCh())
+}
+
+// DataSources implementation of terraform.ResourceProviderDataSourcer
+// interface.
+func (p *Provider) DataSources() []terraform.DataSource {
+	keys := make([]string, 0, len(p.DataSourcesMap))
+	for k := range p.DataSourcesMap {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	result := make([]terraform.DataSource, 0, len(keys))
+	for _, k := range keys {
+		result = append(result, terraform.DataSource{
+			Name: k,
+		})
+	}
+
+	return result
+}
@@ -9,6 +9,18 @@ import (
 	"github.com/hashicorp/terraform/terraform"
 )
 
+func TestProvider_stopperImpl(t *testing.T) {
+	var _ terraform.ResourceProviderStopper = new(Provider)
+}
+
+func TestProvider_importerImpl(t *testing.T) {
+	var _ terraform.ResourceProviderImporter = new(Provider)
+}
+
+func TestProvider_dataSourcerImpl(t *testing.T) {
+	var _ terraform.ResourceProviderDataSourcer = new(Provider)
+}
+
 func TestProvider_impl(t *testing.T) {
 	var _ terraform.ResourceProvider = new(Provider)
 }
@@ -85,6 +97,76 @@ func TestProviderConfigure(t *testing.T) {
 	}
 }
 
+func TestProviderInput(t *testing.T) {
+	p := &Provider{
+		Schema: map[string]*Schema{
+			"serial": &Schema{
+				Type:     TypeString,
+				Optional: true,
+				Default:  "",
+			},
+		},
+
+		InputFunc: func(
+			input terraform.UIInput,
+			c *terraform.ResourceConfig) (*terraform.ResourceConfig, error) {
+			if v, ok := c.Get("serial"); !ok || v == "" {
+				return c, nil
+			}
+
+			token, err := input.Input(&terraform.InputOpts{
+				Id:    "token",
+				Query: "token",
+			})
+			if err != nil {
+				return nil, err
+			}
+
+			c.Config["token"] = token
+			return c, nil
+		},
+	}
+
+	cases := []struct {
+		Config map[string]interface{}
+		Result map[string]interface{}
+	}{
+		{
+			map[string]interface{}{},
+			map[string]interface{}{},
+		},
+
+		{
+			map[string]interface{}{"serial": "foo"},
+			map[string]interface{}{
+				"serial": "foo",
+				"token":  "123456",
+			},
+		},
+	}
+
+	for i, tc := range cases {
+		c, err := config.NewRawConfig(tc.Config)
+		if err != nil {
+			t.Fatalf("err: %s", err)
+		}
+
+		input := new(terraform.MockUIInput)
+		input.InputReturnMap = map[string]string{
+			"token": "123456",
+		}
+
+		actual, err := p.Input(input, terraform.NewResourceConfig(c))
+		if err != nil {
+			t.Fatalf("%d: err: %s", i, err)
+		}
+
+		if !reflect.DeepEqual(actual.Config, tc.Result) {
+			t.Fatalf("%d: bad: %#v", i, actual.Config)
+		}
+	}
+}
+
 func TestProviderResources(t *testing.T) {
 	cases := []struct {
 		P      *Provider
@@ -117,6 +199,81 @@ func TestProviderResources(t *testing.T) {
 	}
 }
 
+func TestProviderDataSources(t *testing.T) {
+	cases := []struct {
+		P      *Provider
+		Result []terraform.DataSource
+	}{
+		{
+			P:      &Provider{},
+			Result: []terraform.DataSource{},
+		},
+
+		{
+			P: &Provider{
+				DataSourcesMap: map[string]*Resource{
+					"foo": nil,
+					"bar": nil,
+				},
+			},
+			Result: []terraform.DataSource{
+				terraform.DataSource{Name: "bar"},
+				terraform.DataSource{Name: "foo"},
+			},
+		},
+	}
+
+	for i, tc := range cases {
+		actual := tc.P.DataSources()
+		if !reflect.DeepEqual(actual, tc.Result) {
+			t.Fatalf("%d: %#v", i, actual)
+		}
+	}
+}
+
+func TestProviderValidateDataSource(t *testing.T) {
+	cases := []struct {
+		P      *Provider
+		Type   string
+		Config map[string]interface{}
+		Err    bool
+	}{
+		{
+			P: &Provider{
+				ResourcesMap: map[string]*Resource{
+					"foo": &Resource{},
+				},
+			},
+			Type:   "foo",
+			Config: nil,
+			Err:    true,
+		},
+
+		{
+			P: &Provider{
+				DataSourcesMap: map[string]*Resource{
+					"foo": &Resource{},
+				},
+			},
+			Type:   "foo",
+			Config: nil,
+			Err:    false,
+		},
+	}
+
+	for i, tc := range cases {
+		c, err := config.NewRawConfig(tc.Config)
+		if err != nil {
+			t.Fatalf("err: %s", err)
+		}
+
+		_, es := tc.P.ValidateDataSource(tc.Type, terraform.NewResourceConfig(c))
+		if (len(es) > 0) != tc.Err {
+			t.Fatalf("%d: %#v", i, es)
+		}
+	}
+}
+
 func TestProviderValidateResource(t *testing.T) {
 	cases := []struct {
 		P      *Provider
@@ -156,6 +313,71 @@ func TestProviderValidateResource(t *testing.T) {
 	}
 }
 
+func TestProviderDiff_diffConfig(t *testing.T) {
+	p := &Provider{
+		ResourcesMap: map[string]*Resource{
+			"foo": &Resource{
+				Schema: map[string]*Schema{
+					"foo": &Schema{
+						Type:     TypeString,
+						Optional: true,
+					},
+				},
+				DiffConfig: func(
+					c *terraform.ResourceConfig,
+					meta interface{}) (*terraform.ResourceConfig, error) {
+					if _, ok := c.Get("foo"); ok {
+						return c, nil
+					}
+
+					raw, err := config.NewRawConfig(map[string]interface{}{
+						"foo": meta,
+					})
+					if err != nil {
+						return nil, err
+					}
+					return terraform.NewResourceConfig(raw), nil
+				},
+			},
+		},
+	}
+	p.SetMeta("default")
+
+	cases := []struct {
+		Config map[string]interface{}
+		New    string
+	}{
+		{
+			nil,
+			"default",
+		},
+
+		{
+			map[string]interface{}{"foo": "bar"},
+			"bar",
+		},
+	}
+
+	for i, tc := range cases {
+		c, err := config.NewRawConfig(tc.Config)
+		if err != nil {
+			t.Fatalf("err: %s", err)
+		}
+
+		d, err := p.Diff(
+			&terraform.InstanceInfo{Type: "foo"},
+			nil,
+			terraform.NewResourceConfig(c))
+		if err != nil {
+			t.Fatalf("%d: err: %s", i, err)
+		}
+
+		if d.Attributes["foo"] == nil || d.Attributes["foo"].New != tc.New {
+			t.Fatalf("%d: bad: %#v", i, d)
+		}
+	}
+}
+
 func TestProviderMeta(t *testing.T) {
 	p := new(Provider)
 	if v := p.Meta(); v != nil {
@@ -168,3 +390,58 @@ func TestProviderMeta(t *testing.T) {
 		t.Fatalf("bad: %#v", v)
 	}
 }
+
+func TestProviderStop(t *testing.T) {
+	var stopCh <-chan struct{}
+	p := &Provider{
+		ResourcesMap: map[string]*Resource{
+			"foo": &Resource{
+				Schema: map[string]*Schema{
+					"foo": &Schema{
+						Type:     TypeString,
+						Optional: true,
+					},
+				},
+				Create: func(d *ResourceData, meta interface{}) error {
+					stopCh = d.StopCh()
+					d.SetId("foo")
+					return nil
+				},
+			},
+		},
+	}
+
+	_, err := p.Apply(
+		&terraform.InstanceInfo{Type: "foo"},
+		nil,
+		&terraform.InstanceDiff{
+			Attributes: map[string]*terraform.ResourceAttrDiff{
+				"foo": &terraform.ResourceAttrDiff{New: "bar"},
+			},
+		})
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if stopCh == nil {
+		t.Fatal("resource should get the stop channel")
+	}
+
+	select {
+	case <-stopCh:
+		t.Fatal("should not be stopped")
+	default:
+	}
+
+	// Stopping twice is fine
+	for i := 0; i < 2; i++ {
+		if err := p.Stop(); err != nil {
+			t.Fatalf("err: %s", err)
+		}
+	}
+
+	select {
+	case <-stopCh:
+	default:
+		t.Fatal("should be stopped")
+	}
+}
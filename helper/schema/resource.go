@@ -50,6 +50,26 @@ type Resource struct {
 	// needs to make any remote API calls.
 	MigrateState StateMigrateFunc
 
+	// StateUpgraders is an ordered chain of steps that each upgrade the
+	// state by one SchemaVersion, with typed access to the attributes. The
+	// last step must upgrade to the current SchemaVersion.
+	//
+	// States older than the first step are given to MigrateState first,
+	// which must return a state of the Version of the first step. This
+	// lets resources that already have a MigrateState add steps for
+	// later versions only.
+	//
+	// TestResourceStateUpgrade can be used to test the steps.
+	StateUpgraders []StateUpgrader
+
+	// DiffConfig, if set, is called with the configuration of the resource
+	// and the provider's meta before a diff is made, and returns the
+	// configuration to diff instead. Providers use it to fill in values
+	// that are configured once on the provider, such as default tags.
+	//
+	// The given configuration must not be modified.
+	DiffConfig DiffConfigFunc
+
 	// The functions below are the CRUD operations for this resource.
 	//
 	// The only optional operation is Update. If Update is not implemented,
@@ -73,11 +93,20 @@ type Resource struct {
 	// accordingly. If this function isn't set, it will not be called. It
 	// is highly recommended to set it. The *ResourceData passed to Exists
 	// should _not_ be modified.
+	//
+	// A Resource that is a data source, in the DataSourcesMap of its
+	// provider, only has Read, which is called with the configuration of
+	// the data source and must set the ID and the computed attributes.
 	Create CreateFunc
 	Read   ReadFunc
 	Update UpdateFunc
 	Delete DeleteFunc
 	Exists ExistsFunc
+
+	// Importer, if set, lets existing resources of this type be imported
+	// into the state by their ID with `terraform import`. If it isn't
+	// set, importing resources of this type is an error.
+	Importer *ResourceImporter
 }
 
 // See Resource documentation.
@@ -95,6 +124,10 @@ type DeleteFunc func(*ResourceData, interface{}) error
 // See Resource documentation.
 type ExistsFunc func(*ResourceData, interface{}) (bool, error)
 
+// See Resource documentation.
+type DiffConfigFunc func(
+	*terraform.ResourceConfig, interface{}) (*terraform.ResourceConfig, error)
+
 // See Resource documentation.
 type StateMigrateFunc func(
 	int, *terraform.InstanceState, interface{}) (*terraform.InstanceState, error)
@@ -104,10 +137,21 @@ func (r *Resource) Apply(
 	s *terraform.InstanceState,
 	d *terraform.InstanceDiff,
 	meta interface{}) (*terraform.InstanceState, error) {
+	return r.apply(s, d, meta, nil)
+}
+
+// apply is Apply with the channel that is closed when the provider is
+// stopped, which is made available to the resource as ResourceData.StopCh.
+func (r *Resource) apply(
+	s *terraform.InstanceState,
+	d *terraform.InstanceDiff,
+	meta interface{},
+	stopCh <-chan struct{}) (*terraform.InstanceState, error) {
 	data, err := schemaMap(r.Schema).Data(s, d)
 	if err != nil {
 		return s, err
 	}
+	data.stopCh = stopCh
 
 	if s == nil {
 		// The Terraform API dictates that this should never happen, but
@@ -137,6 +181,7 @@ func (r *Resource) Apply(
 		if err != nil {
 			return nil, err
 		}
+		data.stopCh = stopCh
 	}
 
 	err = nil
@@ -159,6 +204,22 @@ func (r *Resource) Apply(
 func (r *Resource) Diff(
 	s *terraform.InstanceState,
 	c *terraform.ResourceConfig) (*terraform.InstanceDiff, error) {
+	return r.diff(s, c, nil)
+}
+
+// diff is Diff with the provider's meta, which is given to DiffConfig.
+func (r *Resource) diff(
+	s *terraform.InstanceState,
+	c *terraform.ResourceConfig,
+	meta interface{}) (*terraform.InstanceDiff, error) {
+	if r.DiffConfig != nil {
+		var err error
+		c, err = r.DiffConfig(c, meta)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	return schemaMap(r.Schema).Diff(s, c)
 }
 
@@ -171,6 +232,15 @@ func (r *Resource) Validate(c *terraform.ResourceConfig) ([]string, []error) {
 func (r *Resource) Refresh(
 	s *terraform.InstanceState,
 	meta interface{}) (*terraform.InstanceState, error) {
+	return r.refresh(s, meta, nil)
+}
+
+// refresh is Refresh with the channel that is closed when the provider is
+// stopped. See apply.
+func (r *Resource) refresh(
+	s *terraform.InstanceState,
+	meta interface{},
+	stopCh <-chan struct{}) (*terraform.InstanceState, error) {
 	if r.Exists != nil {
 		// Make a copy of data so that if it is modified it doesn't
 		// affect our Read later.
@@ -178,6 +248,7 @@ func (r *Resource) Refresh(
 		if err != nil {
 			return s, err
 		}
+		data.stopCh = stopCh
 
 		exists, err := r.Exists(data, meta)
 		if err != nil {
@@ -189,8 +260,16 @@ func (r *Resource) Refresh(
 	}
 
 	needsMigration, stateSchemaVersion := r.checkSchemaVersion(s)
-	if needsMigration && r.MigrateState != nil {
-		s, err := r.MigrateState(stateSchemaVersion, s, meta)
+	if needsMigration {
+		var err error
+		s, err = r.migrateState(stateSchemaVersion, s, meta)
+		if err != nil {
+			return s, err
+		}
+
+		// The Set functions may have changed with the version, so the
+		// set elements are stored under new codes from now on.
+		s, err = r.MigrateSetHashes(s)
 		if err != nil {
 			return s, err
 		}
@@ -200,6 +279,7 @@ func (r *Resource) Refresh(
 	if err != nil {
 		return s, err
 	}
+	data.stopCh = stopCh
 
 	err = r.Read(data, meta)
 	state := data.State()
@@ -210,6 +290,153 @@ func (r *Resource) Refresh(
 	return r.recordCurrentSchemaVersion(state), err
 }
 
+// Import returns the state of the existing resource with the given ID,
+// which is read with Read once the Importer has prepared it. A nil state
+// is returned if the resource doesn't exist.
+func (r *Resource) Import(
+	id string,
+	meta interface{}) (*terraform.InstanceState, error) {
+	return r.importState(id, meta, nil)
+}
+
+// importState is Import with the channel that is closed when the provider
+// is stopped. See apply.
+func (r *Resource) importState(
+	id string,
+	meta interface{},
+	stopCh <-chan struct{}) (*terraform.InstanceState, error) {
+	if r.Importer == nil {
+		return nil, fmt.Errorf("doesn't support import")
+	}
+
+	data, err := schemaMap(r.Schema).Data(nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	data.stopCh = stopCh
+	data.SetId(id)
+
+	if r.Importer.State != nil {
+		if err := r.Importer.State(data, meta); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := r.Read(data, meta); err != nil {
+		return nil, err
+	}
+	state := data.State()
+	if state != nil && state.ID == "" {
+		state = nil
+	}
+
+	return r.recordCurrentSchemaVersion(state), nil
+}
+
+// ReadDataSource returns the state of the data source with the given
+// configuration, which is read with Read. It is an error for Read to not
+// set the ID of the data source.
+func (r *Resource) ReadDataSource(
+	c *terraform.ResourceConfig,
+	meta interface{}) (*terraform.InstanceState, error) {
+	return r.readDataSource(c, meta, nil)
+}
+
+// readDataSource is ReadDataSource with the channel that is closed when
+// the provider is stopped. See apply.
+func (r *Resource) readDataSource(
+	c *terraform.ResourceConfig,
+	meta interface{},
+	stopCh <-chan struct{}) (*terraform.InstanceState, error) {
+	// The configuration of the data source is given to Read as the diff
+	// of a new resource, like it is to Create.
+	diff, err := schemaMap(r.Schema).Diff(nil, c)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := schemaMap(r.Schema).Data(nil, diff)
+	if err != nil {
+		return nil, err
+	}
+	data.stopCh = stopCh
+
+	if err := r.Read(data, meta); err != nil {
+		return nil, err
+	}
+	state := data.State()
+	if state == nil || state.ID == "" {
+		return nil, fmt.Errorf("data source wasn't found")
+	}
+
+	return state, nil
+}
+
+// migrateState brings a state of an older SchemaVersion up to the current
+// one with MigrateState and the StateUpgraders.
+func (r *Resource) migrateState(
+	version int,
+	s *terraform.InstanceState,
+	meta interface{}) (*terraform.InstanceState, error) {
+	if len(r.StateUpgraders) == 0 {
+		if r.MigrateState == nil {
+			return s, nil
+		}
+
+		return r.MigrateState(version, s, meta)
+	}
+
+	if first := r.StateUpgraders[0].Version; version < first {
+		if r.MigrateState == nil {
+			return s, fmt.Errorf(
+				"no state upgrader for schema version %d", version)
+		}
+
+		var err error
+		s, err = r.MigrateState(version, s, meta)
+		if err != nil {
+			return s, err
+		}
+		version = first
+	}
+
+	return r.upgradeState(version, s, meta)
+}
+
+// MigrateSetHashes returns a copy of the state with the set elements stored
+// under the codes of the Set functions of the current schema.
+//
+// Changing a Set function changes the codes of the set elements, so the
+// elements in existing states would no longer match the ones in diffs. To
+// change a Set function, bump the SchemaVersion of the resource: Refresh
+// calls MigrateSetHashes for states of older versions, after MigrateState.
+func (r *Resource) MigrateSetHashes(
+	s *terraform.InstanceState) (*terraform.InstanceState, error) {
+	if s == nil || s.ID == "" {
+		return s, nil
+	}
+
+	data, err := schemaMap(r.Schema).Data(s, nil)
+	if err != nil {
+		return s, err
+	}
+
+	result := data.State()
+	if result == nil {
+		return s, nil
+	}
+
+	result.Ephemeral = s.Ephemeral
+	if s.Meta != nil {
+		result.Meta = make(map[string]string, len(s.Meta))
+		for k, v := range s.Meta {
+			result.Meta[k] = v
+		}
+	}
+
+	return result, nil
+}
+
 // InternalValidate should be called to validate the structure
 // of the resource.
 //
@@ -241,6 +468,28 @@ func (r *Resource) InternalValidate() error {
 		}
 	}
 
+	if err := r.validateStateUpgraders(); err != nil {
+		return err
+	}
+
+	return schemaMap(r.Schema).InternalValidate()
+}
+
+// InternalValidateDataSource is InternalValidate for a Resource that is a
+// data source, which must only have Read.
+func (r *Resource) InternalValidateDataSource() error {
+	if r == nil {
+		return errors.New("data source is nil")
+	}
+
+	if r.Read == nil {
+		return errors.New("Read must be defined")
+	}
+	if r.Create != nil || r.Update != nil || r.Delete != nil ||
+		r.Exists != nil || r.Importer != nil {
+		return errors.New("only Read may be defined for a data source")
+	}
+
 	return schemaMap(r.Schema).InternalValidate()
 }
 
@@ -30,6 +30,7 @@ type ResourceData struct {
 	partial     bool
 	partialMap  map[string]struct{}
 	once        sync.Once
+	stopCh      <-chan struct{}
 }
 
 // getResult is the internal structure that is generated when a Get
@@ -171,6 +172,14 @@ func (d *ResourceData) SetPartial(k string) {
 	}
 }
 
+// StopCh returns a channel that is closed when Terraform is interrupted,
+// so that long running operations, such as waiting for a resource to
+// become available, can be aborted. It is never closed when the resource
+// isn't used through a Provider.
+func (d *ResourceData) StopCh() <-chan struct{} {
+	return d.stopCh
+}
+
 // Id returns the ID of the resource.
 func (d *ResourceData) Id() string {
 	var result string
@@ -0,0 +1,21 @@
+package schema
+
+// ResourceImporter is how a resource is imported into the state with
+// `terraform import`. The resource is read with its Read function, with
+// the ID of the resource to import set on the ResourceData.
+type ResourceImporter struct {
+	// State, if set, is called before the resource is read, with the
+	// ResourceData that has the ID set. It can set the fields that Read
+	// needs besides the ID, such as those that are part of the ID, or
+	// return an error if the ID isn't valid for the resource.
+	State ImportStateFunc
+}
+
+// See ResourceImporter documentation.
+type ImportStateFunc func(*ResourceData, interface{}) error
+
+// ImportStatePassthrough is an ImportStateFunc for resources that Read
+// with nothing but their ID, which is most of them.
+func ImportStatePassthrough(d *ResourceData, meta interface{}) error {
+	return nil
+}
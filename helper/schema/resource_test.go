@@ -6,6 +6,7 @@ import (
 	"strconv"
 	"testing"
 
+	"github.com/hashicorp/terraform/config"
 	"github.com/hashicorp/terraform/terraform"
 )
 
@@ -341,6 +342,229 @@ func TestResourceInternalValidate(t *testing.T) {
 	}
 }
 
+func TestResourceImport(t *testing.T) {
+	r := &Resource{
+		SchemaVersion: 2,
+		Schema: map[string]*Schema{
+			"foo": &Schema{
+				Type:     TypeInt,
+				Optional: true,
+			},
+			"bar": &Schema{
+				Type:     TypeString,
+				Optional: true,
+			},
+		},
+		Importer: &ResourceImporter{
+			State: func(d *ResourceData, m interface{}) error {
+				return d.Set("bar", "baz")
+			},
+		},
+	}
+
+	r.Read = func(d *ResourceData, m interface{}) error {
+		if m != 42 {
+			return fmt.Errorf("meta not passed")
+		}
+		if d.Id() != "bar" {
+			return fmt.Errorf("bad id: %s", d.Id())
+		}
+		if v := d.Get("bar").(string); v != "baz" {
+			return fmt.Errorf("importer not called: %s", v)
+		}
+
+		return d.Set("foo", 13)
+	}
+
+	expected := &terraform.InstanceState{
+		ID: "bar",
+		Attributes: map[string]string{
+			"id":  "bar",
+			"foo": "13",
+			"bar": "baz",
+		},
+		Meta: map[string]string{
+			"schema_version": "2",
+		},
+	}
+
+	actual, err := r.Import("bar", 42)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	if !reflect.DeepEqual(actual, expected) {
+		t.Fatalf("bad: %#v", actual)
+	}
+}
+
+func TestResourceImport_notFound(t *testing.T) {
+	r := &Resource{
+		Schema: map[string]*Schema{
+			"foo": &Schema{
+				Type:     TypeInt,
+				Optional: true,
+			},
+		},
+		Importer: &ResourceImporter{
+			State: ImportStatePassthrough,
+		},
+	}
+
+	r.Read = func(d *ResourceData, m interface{}) error {
+		d.SetId("")
+		return nil
+	}
+
+	actual, err := r.Import("bar", 42)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	if actual != nil {
+		t.Fatalf("bad: %#v", actual)
+	}
+}
+
+func TestResourceImport_noImporter(t *testing.T) {
+	r := &Resource{
+		Schema: map[string]*Schema{
+			"foo": &Schema{
+				Type:     TypeInt,
+				Optional: true,
+			},
+		},
+	}
+
+	r.Read = func(d *ResourceData, m interface{}) error {
+		return nil
+	}
+
+	if _, err := r.Import("bar", 42); err == nil {
+		t.Fatal("should error")
+	}
+}
+
+func TestResourceInternalValidateDataSource(t *testing.T) {
+	read := func(d *ResourceData, m interface{}) error { return nil }
+	cases := []struct {
+		In  *Resource
+		Err bool
+	}{
+		{
+			nil,
+			true,
+		},
+
+		// No Read
+		{
+			&Resource{},
+			true,
+		},
+
+		// Only Read
+		{
+			&Resource{
+				Schema: map[string]*Schema{
+					"foo": &Schema{
+						Type:     TypeString,
+						Required: true,
+					},
+				},
+				Read: read,
+			},
+			false,
+		},
+
+		// Create
+		{
+			&Resource{
+				Create: read,
+				Read:   read,
+			},
+			true,
+		},
+	}
+
+	for i, tc := range cases {
+		err := tc.In.InternalValidateDataSource()
+		if (err != nil) != tc.Err {
+			t.Fatalf("%d: bad: %s", i, err)
+		}
+	}
+}
+
+func TestResourceReadDataSource(t *testing.T) {
+	r := &Resource{
+		Schema: map[string]*Schema{
+			"foo": &Schema{
+				Type:     TypeString,
+				Required: true,
+			},
+			"bar": &Schema{
+				Type:     TypeString,
+				Computed: true,
+			},
+		},
+	}
+
+	r.Read = func(d *ResourceData, m interface{}) error {
+		if m != 42 {
+			return fmt.Errorf("meta not passed")
+		}
+
+		d.SetId("baz")
+		return d.Set("bar", d.Get("foo").(string)+"-bar")
+	}
+
+	raw, err := config.NewRawConfig(map[string]interface{}{
+		"foo": "foo",
+	})
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	actual, err := r.ReadDataSource(terraform.NewResourceConfig(raw), 42)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	expected := &terraform.InstanceState{
+		ID: "baz",
+		Attributes: map[string]string{
+			"id":  "baz",
+			"foo": "foo",
+			"bar": "foo-bar",
+		},
+	}
+	if !reflect.DeepEqual(actual, expected) {
+		t.Fatalf("bad: %#v", actual)
+	}
+}
+
+func TestResourceReadDataSource_notFound(t *testing.T) {
+	r := &Resource{
+		Schema: map[string]*Schema{
+			"foo": &Schema{
+				Type:     TypeString,
+				Optional: true,
+			},
+		},
+		Read: func(d *ResourceData, m interface{}) error {
+			return nil
+		},
+	}
+
+	raw, err := config.NewRawConfig(map[string]interface{}{})
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	if _, err := r.ReadDataSource(terraform.NewResourceConfig(raw), nil); err == nil {
+		t.Fatal("should error")
+	}
+}
+
 func TestResourceRefresh(t *testing.T) {
 	r := &Resource{
 		SchemaVersion: 2,
@@ -702,3 +926,116 @@ func TestResourceRefresh_migrateStateErr(t *testing.T) {
 		t.Fatal("expected error, but got none!")
 	}
 }
+
+func TestResourceRefresh_migrateStateCopy(t *testing.T) {
+	r := &Resource{
+		SchemaVersion: 2,
+		Schema: map[string]*Schema{
+			"newfoo": &Schema{
+				Type:     TypeInt,
+				Optional: true,
+			},
+		},
+	}
+
+	r.Read = func(d *ResourceData, m interface{}) error {
+		if v := d.Get("newfoo").(int); v != 12 {
+			t.Fatalf("bad: %d", v)
+		}
+		return nil
+	}
+
+	// The migration returns a new state rather than changing the old one
+	r.MigrateState = func(
+		v int,
+		s *terraform.InstanceState,
+		meta interface{}) (*terraform.InstanceState, error) {
+		return &terraform.InstanceState{
+			ID: s.ID,
+			Attributes: map[string]string{
+				"newfoo": s.Attributes["oldfoo"],
+			},
+		}, nil
+	}
+
+	s := &terraform.InstanceState{
+		ID: "bar",
+		Attributes: map[string]string{
+			"oldfoo": "12",
+		},
+	}
+
+	actual, err := r.Refresh(s, nil)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if actual.Attributes["newfoo"] != "12" {
+		t.Fatalf("bad: %#v", actual)
+	}
+}
+
+func TestResourceRefresh_migrateSetHashes(t *testing.T) {
+	r := &Resource{
+		SchemaVersion: 1,
+		Schema: map[string]*Schema{
+			"ports": &Schema{
+				Type:     TypeSet,
+				Optional: true,
+				Elem:     &Schema{Type: TypeInt},
+				Set: func(v interface{}) int {
+					return v.(int) * 10
+				},
+			},
+		},
+	}
+
+	// The state has the codes of the old Set function, which was the
+	// value itself
+	var read map[string]string
+	r.Read = func(d *ResourceData, m interface{}) error {
+		read = d.State().Attributes
+		return nil
+	}
+
+	s := &terraform.InstanceState{
+		ID: "bar",
+		Attributes: map[string]string{
+			"ports.#":  "2",
+			"ports.80": "80",
+			"ports.22": "22",
+		},
+	}
+
+	actual, err := r.Refresh(s, nil)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	expected := map[string]string{
+		"id":        "bar",
+		"ports.#":   "2",
+		"ports.800": "80",
+		"ports.220": "22",
+	}
+	if !reflect.DeepEqual(read, expected) {
+		t.Fatalf("bad: %#v", read)
+	}
+	if !reflect.DeepEqual(actual.Attributes, expected) {
+		t.Fatalf("bad: %#v", actual.Attributes)
+	}
+	if actual.Meta["schema_version"] != "1" {
+		t.Fatalf("bad: %#v", actual.Meta)
+	}
+}
+
+func TestResourceMigrateSetHashes_noState(t *testing.T) {
+	r := &Resource{Schema: map[string]*Schema{}}
+
+	actual, err := r.MigrateSetHashes(nil)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if actual != nil {
+		t.Fatalf("bad: %#v", actual)
+	}
+}
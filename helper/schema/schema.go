@@ -84,13 +84,29 @@ type Schema struct {
 	// If ForceNew is true, then a change in this resource necessitates
 	// the creation of a new resource.
 	//
+	// ForceNewIf is used for values whose changes only need a new resource
+	// depending on the rest of the configuration, such as a setting that
+	// chooses between changing the value in place and replacing the
+	// resource. It is called with the data of the resource when the value
+	// changes. It can only be set on primitive fields, and not with
+	// ForceNew.
+	//
+	// ForceNewFunc is used for values that can only be changed in place
+	// in some ways, such as a disk that can be grown but not shrunk. It is
+	// called with the old and new value of a change and returns whether
+	// the change needs a new resource. Unlike ForceNewIf, it can be set on
+	// primitive fields nested in lists and sets. It can't be set with
+	// ForceNew.
+	//
 	// StateFunc is a function called to change the value of this before
 	// storing it in the state (and likewise before comparing for diffs).
 	// The use for this is for example with large strings, you may want
 	// to simply store the hash of it.
-	Computed  bool
-	ForceNew  bool
-	StateFunc SchemaStateFunc
+	Computed     bool
+	ForceNew     bool
+	ForceNewIf   SchemaForceNewIfFunc
+	ForceNewFunc SchemaForceNewFunc
+	StateFunc    SchemaStateFunc
 
 	// The following fields are only set for a TypeList or TypeSet Type.
 	//
@@ -103,7 +119,10 @@ type Schema struct {
 	// The following fields are only valid for a TypeSet type.
 	//
 	// Set defines a function to determine the unique ID of an item so that
-	// a proper set can be built.
+	// a proper set can be built. hashcode.Strings is a good basis for it.
+	// Changing the function changes the IDs of the items in existing
+	// states, so bump the SchemaVersion of the resource when doing so. See
+	// Resource.MigrateSetHashes.
 	Set SchemaSetFunc
 
 	// ComputedWhen is a set of queries on the configuration. Whenever any
@@ -123,6 +142,18 @@ type Schema struct {
 	// how to address the deprecation.
 	Deprecated string
 
+	// ValidateFunc checks the value of the field in the configuration,
+	// such as that a string is a valid CIDR block, so that bad values are
+	// reported when the configuration is validated, before any resource
+	// is changed. It is called with the value decoded to the Go type of
+	// the field (see Type) and the key of the field, and returns warnings
+	// and errors. It isn't called when the value is computed.
+	//
+	// ValidateFunc can only be set on primitive types. To validate the
+	// elements of a list or set, set it on the *Schema of its Elem. See
+	// the helper/validation package for common validators.
+	ValidateFunc SchemaValidateFunc
+
 	// When Removed is set, this attribute has been removed from the schema
 	//
 	// Removed attributes can be left in the Schema to generate informative error
@@ -168,10 +199,23 @@ func MultiEnvDefaultFunc(ks []string, dv interface{}) SchemaDefaultFunc {
 // element. This unique ID is used to store the element in a hash.
 type SchemaSetFunc func(interface{}) int
 
+// SchemaForceNewIfFunc is a function used to decide whether a change of
+// a field requires a new resource, given the data of the resource.
+type SchemaForceNewIfFunc func(*ResourceData) bool
+
+// SchemaForceNewFunc is a function used to decide whether a change from
+// the old to the new value of a field requires a new resource.
+type SchemaForceNewFunc func(old, new string) bool
+
 // SchemaStateFunc is a function used to convert some type to a string
 // to be stored in the state.
 type SchemaStateFunc func(interface{}) string
 
+// SchemaValidateFunc is a function used to validate the value of a field,
+// given the value and the key of the field. It returns warnings and
+// errors about the value.
+type SchemaValidateFunc func(interface{}, string) ([]string, []error)
+
 func (s *Schema) GoString() string {
 	return fmt.Sprintf("*%#v", *s)
 }
@@ -222,6 +266,11 @@ func (s *Schema) finalizeDiff(
 		d.RequiresNew = true
 	}
 
+	if s.ForceNewFunc != nil && !d.NewComputed && s.ForceNewFunc(d.Old, d.New) {
+		// This particular change can't be made in place
+		d.RequiresNew = true
+	}
+
 	return d
 }
 
@@ -262,6 +311,19 @@ func (m schemaMap) Diff(
 		}
 	}
 
+	// Changes of fields with ForceNewIf require a new resource depending
+	// on the rest of the configuration.
+	for k, schema := range m {
+		if schema.ForceNewIf == nil {
+			continue
+		}
+
+		attr, ok := result.Attributes[k]
+		if ok && attr != nil && !attr.NewComputed && schema.ForceNewIf(d) {
+			attr.RequiresNew = true
+		}
+	}
+
 	// If the diff requires a new resource, then we recompute the diff
 	// so we have the complete new resource diff, and preserve the
 	// RequiresNew fields where necessary so the user knows exactly what
@@ -383,6 +445,10 @@ func (m schemaMap) Input(
 			fallthrough
 		case TypeFloat:
 			fallthrough
+		case TypeList:
+			fallthrough
+		case TypeMap:
+			fallthrough
 		case TypeSet:
 			continue
 		case TypeString:
@@ -436,6 +502,35 @@ func (m schemaMap) InternalValidate() error {
 			return fmt.Errorf("%s: Default cannot be set with Required", k)
 		}
 
+		if v.ForceNewIf != nil {
+			if v.ForceNew {
+				return fmt.Errorf("%s: ForceNew and ForceNewIf cannot both be set", k)
+			}
+
+			switch v.Type {
+			case TypeList, TypeSet, TypeMap:
+				return fmt.Errorf("%s: ForceNewIf can only be set on primitive types", k)
+			}
+		}
+
+		if v.ForceNewFunc != nil {
+			if v.ForceNew {
+				return fmt.Errorf("%s: ForceNew and ForceNewFunc cannot both be set", k)
+			}
+
+			switch v.Type {
+			case TypeList, TypeSet, TypeMap:
+				return fmt.Errorf("%s: ForceNewFunc can only be set on primitive types", k)
+			}
+		}
+
+		if v.ValidateFunc != nil {
+			switch v.Type {
+			case TypeList, TypeSet, TypeMap:
+				return fmt.Errorf("%s: ValidateFunc can only be set on primitive types", k)
+			}
+		}
+
 		if len(v.ComputedWhen) > 0 && !v.Computed {
 			return fmt.Errorf("%s: ComputedWhen can only be set with Computed", k)
 		}
@@ -1105,6 +1200,7 @@ func (m schemaMap) validatePrimitive(
 		return nil, nil
 	}
 
+	var decoded interface{}
 	switch schema.Type {
 	case TypeBool:
 		// Verify that we can parse this as the correct type
@@ -1112,28 +1208,36 @@ func (m schemaMap) validatePrimitive(
 		if err := mapstructure.WeakDecode(raw, &n); err != nil {
 			return nil, []error{err}
 		}
+		decoded = n
 	case TypeInt:
 		// Verify that we can parse this as an int
 		var n int
 		if err := mapstructure.WeakDecode(raw, &n); err != nil {
 			return nil, []error{err}
 		}
+		decoded = n
 	case TypeFloat:
 		// Verify that we can parse this as an int
 		var n float64
 		if err := mapstructure.WeakDecode(raw, &n); err != nil {
 			return nil, []error{err}
 		}
+		decoded = n
 	case TypeString:
 		// Verify that we can parse this as a string
 		var n string
 		if err := mapstructure.WeakDecode(raw, &n); err != nil {
 			return nil, []error{err}
 		}
+		decoded = n
 	default:
 		panic(fmt.Sprintf("Unknown validation type: %#v", schema.Type))
 	}
 
+	if schema.ValidateFunc != nil {
+		return schema.ValidateFunc(decoded, k)
+	}
+
 	return nil, nil
 }
 
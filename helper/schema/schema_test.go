@@ -2348,6 +2348,329 @@ func TestSchemaMap_Diff(t *testing.T) {
 
 			Err: false,
 		},
+
+		// #60: ForceNewIf allowing the change
+		{
+			Schema: map[string]*Schema{
+				"size": &Schema{
+					Type:     TypeString,
+					Optional: true,
+					ForceNewIf: func(d *ResourceData) bool {
+						return d.Get("replace").(bool)
+					},
+				},
+				"replace": &Schema{
+					Type:     TypeBool,
+					Optional: true,
+				},
+			},
+
+			State: &terraform.InstanceState{
+				Attributes: map[string]string{
+					"size":    "2",
+					"replace": "false",
+				},
+			},
+
+			Config: map[string]interface{}{
+				"size":    "1",
+				"replace": false,
+			},
+
+			Diff: &terraform.InstanceDiff{
+				Attributes: map[string]*terraform.ResourceAttrDiff{
+					"size": &terraform.ResourceAttrDiff{
+						Old: "2",
+						New: "1",
+					},
+				},
+			},
+
+			Err: false,
+		},
+
+		// #61: ForceNewIf forcing a new resource
+		{
+			Schema: map[string]*Schema{
+				"size": &Schema{
+					Type:     TypeString,
+					Optional: true,
+					ForceNewIf: func(d *ResourceData) bool {
+						return d.Get("replace").(bool)
+					},
+				},
+				"replace": &Schema{
+					Type:     TypeBool,
+					Optional: true,
+				},
+			},
+
+			State: &terraform.InstanceState{
+				Attributes: map[string]string{
+					"size":    "2",
+					"replace": "true",
+				},
+			},
+
+			Config: map[string]interface{}{
+				"size":    "1",
+				"replace": true,
+			},
+
+			Diff: &terraform.InstanceDiff{
+				Attributes: map[string]*terraform.ResourceAttrDiff{
+					"size": &terraform.ResourceAttrDiff{
+						Old:         "2",
+						New:         "1",
+						RequiresNew: true,
+					},
+					"replace": &terraform.ResourceAttrDiff{
+						Old: "true",
+						New: "true",
+					},
+				},
+			},
+
+			Err: false,
+		},
+
+		// #62: ForceNewIf isn't called for a computed value
+		{
+			Schema: map[string]*Schema{
+				"size": &Schema{
+					Type:     TypeString,
+					Optional: true,
+					Computed: true,
+					ForceNewIf: func(*ResourceData) bool {
+						return true
+					},
+				},
+			},
+
+			State: nil,
+
+			Config: map[string]interface{}{},
+
+			Diff: &terraform.InstanceDiff{
+				Attributes: map[string]*terraform.ResourceAttrDiff{
+					"size": &terraform.ResourceAttrDiff{
+						Old:         "",
+						New:         "",
+						NewComputed: true,
+					},
+				},
+			},
+
+			Err: false,
+		},
+
+		// #63: ForceNewIf isn't called when its field doesn't change
+		{
+			Schema: map[string]*Schema{
+				"size": &Schema{
+					Type:     TypeString,
+					Optional: true,
+					ForceNewIf: func(*ResourceData) bool {
+						return true
+					},
+				},
+				"name": &Schema{
+					Type:     TypeString,
+					Optional: true,
+				},
+			},
+
+			State: &terraform.InstanceState{
+				Attributes: map[string]string{
+					"size": "2",
+					"name": "foo",
+				},
+			},
+
+			Config: map[string]interface{}{
+				"size": "2",
+				"name": "bar",
+			},
+
+			Diff: &terraform.InstanceDiff{
+				Attributes: map[string]*terraform.ResourceAttrDiff{
+					"name": &terraform.ResourceAttrDiff{
+						Old: "foo",
+						New: "bar",
+					},
+				},
+			},
+
+			Err: false,
+		},
+
+		// #64: ForceNewIf forcing a new resource recomputes the computed
+		// fields, like ForceNew
+		{
+			Schema: map[string]*Schema{
+				"size": &Schema{
+					Type:     TypeString,
+					Optional: true,
+					ForceNewIf: func(*ResourceData) bool {
+						return true
+					},
+				},
+				"address": &Schema{
+					Type:     TypeString,
+					Optional: true,
+					Computed: true,
+				},
+			},
+
+			State: &terraform.InstanceState{
+				Attributes: map[string]string{
+					"size":    "2",
+					"address": "foo",
+				},
+			},
+
+			Config: map[string]interface{}{
+				"size": "1",
+			},
+
+			Diff: &terraform.InstanceDiff{
+				Attributes: map[string]*terraform.ResourceAttrDiff{
+					"size": &terraform.ResourceAttrDiff{
+						Old:         "2",
+						New:         "1",
+						RequiresNew: true,
+					},
+					"address": &terraform.ResourceAttrDiff{
+						Old:         "foo",
+						New:         "",
+						NewComputed: true,
+					},
+				},
+			},
+
+			Err: false,
+		},
+
+		// #65: ForceNewFunc allowing the change
+		{
+			Schema: map[string]*Schema{
+				"size": &Schema{
+					Type:     TypeString,
+					Optional: true,
+					ForceNewFunc: func(o, n string) bool {
+						return n < o
+					},
+				},
+			},
+
+			State: &terraform.InstanceState{
+				Attributes: map[string]string{
+					"size": "2",
+				},
+			},
+
+			Config: map[string]interface{}{
+				"size": "3",
+			},
+
+			Diff: &terraform.InstanceDiff{
+				Attributes: map[string]*terraform.ResourceAttrDiff{
+					"size": &terraform.ResourceAttrDiff{
+						Old: "2",
+						New: "3",
+					},
+				},
+			},
+
+			Err: false,
+		},
+
+		// #66: ForceNewFunc forcing a new resource
+		{
+			Schema: map[string]*Schema{
+				"size": &Schema{
+					Type:     TypeString,
+					Optional: true,
+					ForceNewFunc: func(o, n string) bool {
+						return n < o
+					},
+				},
+			},
+
+			State: &terraform.InstanceState{
+				Attributes: map[string]string{
+					"size": "2",
+				},
+			},
+
+			Config: map[string]interface{}{
+				"size": "1",
+			},
+
+			Diff: &terraform.InstanceDiff{
+				Attributes: map[string]*terraform.ResourceAttrDiff{
+					"size": &terraform.ResourceAttrDiff{
+						Old:         "2",
+						New:         "1",
+						RequiresNew: true,
+					},
+				},
+			},
+
+			Err: false,
+		},
+
+		// #67: ForceNewFunc in a nested resource
+		{
+			Schema: map[string]*Schema{
+				"disk": &Schema{
+					Type:     TypeList,
+					Optional: true,
+					Elem: &Resource{
+						Schema: map[string]*Schema{
+							"size": &Schema{
+								Type:     TypeString,
+								Optional: true,
+								ForceNewFunc: func(o, n string) bool {
+									return n < o
+								},
+							},
+						},
+					},
+				},
+			},
+
+			State: &terraform.InstanceState{
+				Attributes: map[string]string{
+					"disk.#":      "1",
+					"disk.0.size": "2",
+				},
+			},
+
+			Config: map[string]interface{}{
+				"disk": []map[string]interface{}{
+					{
+						"size": "1",
+					},
+				},
+			},
+
+			Diff: &terraform.InstanceDiff{
+				Attributes: map[string]*terraform.ResourceAttrDiff{
+					"disk.#": &terraform.ResourceAttrDiff{
+						Old: "1",
+						New: "1",
+					},
+					"disk.0.size": &terraform.ResourceAttrDiff{
+						Old:         "2",
+						New:         "1",
+						RequiresNew: true,
+					},
+				},
+			},
+
+			Err: false,
+		},
 	}
 
 	for i, tc := range cases {
@@ -2508,6 +2831,37 @@ func TestSchemaMap_Input(t *testing.T) {
 
 			Err: false,
 		},
+
+		"input ignored for lists and maps": {
+			Schema: map[string]*Schema{
+				"ingress": &Schema{
+					Type:     TypeList,
+					Optional: true,
+					Elem: &Resource{
+						Schema: map[string]*Schema{
+							"port": &Schema{
+								Type:     TypeString,
+								Optional: true,
+							},
+						},
+					},
+				},
+
+				"tags": &Schema{
+					Type:     TypeMap,
+					Optional: true,
+				},
+			},
+
+			Input: map[string]string{
+				"ingress": "bar",
+				"tags":    "bar",
+			},
+
+			Result: map[string]interface{}{},
+
+			Err: false,
+		},
 	}
 
 	for i, tc := range cases {
@@ -2796,6 +3150,90 @@ func TestSchemaMap_InternalValidate(t *testing.T) {
 			},
 			false,
 		},
+
+		// ForceNew and ForceNewIf
+		{
+			map[string]*Schema{
+				"foo": &Schema{
+					Type:       TypeInt,
+					Optional:   true,
+					ForceNew:   true,
+					ForceNewIf: func(*ResourceData) bool { return true },
+				},
+			},
+			true,
+		},
+
+		// ForceNewIf on a list
+		{
+			map[string]*Schema{
+				"foo": &Schema{
+					Type:       TypeList,
+					Optional:   true,
+					Elem:       &Schema{Type: TypeString},
+					ForceNewIf: func(*ResourceData) bool { return true },
+				},
+			},
+			true,
+		},
+
+		// ForceNew and ForceNewFunc
+		{
+			map[string]*Schema{
+				"foo": &Schema{
+					Type:         TypeInt,
+					Optional:     true,
+					ForceNew:     true,
+					ForceNewFunc: func(o, n string) bool { return true },
+				},
+			},
+			true,
+		},
+
+		// ForceNewFunc on a list
+		{
+			map[string]*Schema{
+				"foo": &Schema{
+					Type:         TypeList,
+					Optional:     true,
+					Elem:         &Schema{Type: TypeString},
+					ForceNewFunc: func(o, n string) bool { return true },
+				},
+			},
+			true,
+		},
+
+		// ValidateFunc on a list
+		{
+			map[string]*Schema{
+				"foo": &Schema{
+					Type:     TypeList,
+					Optional: true,
+					Elem:     &Schema{Type: TypeString},
+					ValidateFunc: func(interface{}, string) ([]string, []error) {
+						return nil, nil
+					},
+				},
+			},
+			true,
+		},
+
+		// ValidateFunc on the elements of a list
+		{
+			map[string]*Schema{
+				"foo": &Schema{
+					Type:     TypeList,
+					Optional: true,
+					Elem: &Schema{
+						Type: TypeString,
+						ValidateFunc: func(interface{}, string) ([]string, []error) {
+							return nil, nil
+						},
+					},
+				},
+			},
+			false,
+		},
 	}
 
 	for i, tc := range cases {
@@ -3402,6 +3840,97 @@ func TestSchemaMap_Validate(t *testing.T) {
 				fmt.Errorf("\"optional_att\": conflicts with required_att (\"required-val\")"),
 			},
 		},
+
+		"ValidateFunc is called with the decoded value": {
+			Schema: map[string]*Schema{
+				"size": &Schema{
+					Type:     TypeInt,
+					Required: true,
+					ValidateFunc: func(v interface{}, k string) ([]string, []error) {
+						if v.(int) > 10 {
+							return nil, []error{fmt.Errorf("%q: too big: %d", k, v)}
+						}
+						return nil, nil
+					},
+				},
+			},
+
+			Config: map[string]interface{}{
+				"size": "42",
+			},
+
+			Err: true,
+			Errors: []error{
+				fmt.Errorf("\"size\": too big: 42"),
+			},
+		},
+
+		"ValidateFunc warnings": {
+			Schema: map[string]*Schema{
+				"name": &Schema{
+					Type:     TypeString,
+					Optional: true,
+					ValidateFunc: func(v interface{}, k string) ([]string, []error) {
+						return []string{fmt.Sprintf("%q: check %s", k, v)}, nil
+					},
+				},
+			},
+
+			Config: map[string]interface{}{
+				"name": "foo",
+			},
+
+			Warnings: []string{
+				"\"name\": check foo",
+			},
+		},
+
+		"ValidateFunc on the elements of a list": {
+			Schema: map[string]*Schema{
+				"ports": &Schema{
+					Type:     TypeList,
+					Optional: true,
+					Elem: &Schema{
+						Type: TypeInt,
+						ValidateFunc: func(v interface{}, k string) ([]string, []error) {
+							if v.(int) < 1 {
+								return nil, []error{fmt.Errorf("%q: bad port", k)}
+							}
+							return nil, nil
+						},
+					},
+				},
+			},
+
+			Config: map[string]interface{}{
+				"ports": []interface{}{80, 0},
+			},
+
+			Err: true,
+			Errors: []error{
+				fmt.Errorf("\"ports.1\": bad port"),
+			},
+		},
+
+		"ValidateFunc is not called for computed values": {
+			Schema: map[string]*Schema{
+				"name": &Schema{
+					Type:     TypeString,
+					Required: true,
+					ValidateFunc: func(v interface{}, k string) ([]string, []error) {
+						return nil, []error{fmt.Errorf("should not be called")}
+					},
+				},
+			},
+
+			Config: map[string]interface{}{
+				"name": "${var.foo}",
+			},
+
+			Vars: map[string]string{
+				"var.foo": config.UnknownVariableValue,
+			},
+		},
 	}
 
 	for tn, tc := range cases {
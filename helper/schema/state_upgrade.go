@@ -0,0 +1,175 @@
+package schema
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/hashicorp/terraform/terraform"
+)
+
+// StateUpgrader is a single step in the chain of upgrades that brings
+// the state of a resource from an older SchemaVersion to the current one.
+//
+// Unlike MigrateState, which works on the flattened attributes of the
+// state, an upgrade step works on the attributes read with the schema of
+// the version it upgrades from, so values come in as the same types that
+// ResourceData.Get returns. Sets are given as lists of their elements.
+type StateUpgrader struct {
+	// Version is the SchemaVersion of the states this step upgrades. The
+	// step returns a state of Version+1.
+	Version int
+
+	// Schema is the schema of the resource as it was at Version. It is
+	// used to read the state given to Upgrade, and the Schema of the next
+	// step (or of the resource, for the last step) is used to write the
+	// result.
+	Schema map[string]*Schema
+
+	// Upgrade changes the attributes of the state to the shape expected
+	// by Version+1. The map may be modified and returned.
+	Upgrade StateUpgradeFunc
+}
+
+// See StateUpgrader documentation.
+type StateUpgradeFunc func(
+	map[string]interface{}, interface{}) (map[string]interface{}, error)
+
+// upgradeState runs the StateUpgraders of the resource over a state of
+// the given version. States older than the first upgrader are expected to
+// have been migrated by MigrateState already.
+func (r *Resource) upgradeState(
+	version int,
+	s *terraform.InstanceState,
+	meta interface{}) (*terraform.InstanceState, error) {
+	for i, up := range r.StateUpgraders {
+		if up.Version < version {
+			continue
+		}
+		if up.Version != version {
+			return s, fmt.Errorf(
+				"no state upgrader for schema version %d", version)
+		}
+
+		next := r.Schema
+		if i+1 < len(r.StateUpgraders) {
+			next = r.StateUpgraders[i+1].Schema
+		}
+
+		var err error
+		s, err = up.upgrade(s, next, meta)
+		if err != nil {
+			return s, fmt.Errorf(
+				"error upgrading state from schema version %d: %s",
+				version, err)
+		}
+
+		version++
+	}
+
+	return s, nil
+}
+
+// upgrade runs a single step, writing the result with the given schema.
+func (up *StateUpgrader) upgrade(
+	s *terraform.InstanceState,
+	next map[string]*Schema,
+	meta interface{}) (*terraform.InstanceState, error) {
+	if s == nil || s.ID == "" {
+		return s, nil
+	}
+
+	data, err := schemaMap(up.Schema).Data(s, nil)
+	if err != nil {
+		return s, err
+	}
+
+	raw := make(map[string]interface{})
+	for k, _ := range up.Schema {
+		v := data.getRaw(k, getSourceSet)
+		if v.Exists && !v.Computed {
+			raw[k] = stateUpgradeValue(v.Value)
+		}
+	}
+
+	raw, err = up.Upgrade(raw, meta)
+	if err != nil {
+		return s, err
+	}
+
+	w := &MapFieldWriter{Schema: next}
+	if err := w.WriteField(nil, raw); err != nil {
+		return s, err
+	}
+
+	result := &terraform.InstanceState{
+		ID:         s.ID,
+		Attributes: w.Map(),
+		Ephemeral:  s.Ephemeral,
+		Meta:       make(map[string]string, len(s.Meta)+1),
+	}
+	result.Attributes["id"] = s.ID
+	for k, v := range s.Meta {
+		result.Meta[k] = v
+	}
+	result.Meta["schema_version"] = strconv.Itoa(up.Version + 1)
+
+	return result, nil
+}
+
+// stateUpgradeValue turns the sets in a value read from the state into
+// lists, so upgrade steps don't need the hash functions of the old schema.
+func stateUpgradeValue(v interface{}) interface{} {
+	switch v := v.(type) {
+	case *Set:
+		return stateUpgradeValue(v.List())
+	case []interface{}:
+		result := make([]interface{}, len(v))
+		for i, e := range v {
+			result[i] = stateUpgradeValue(e)
+		}
+		return result
+	case map[string]interface{}:
+		result := make(map[string]interface{}, len(v))
+		for k, e := range v {
+			result[k] = stateUpgradeValue(e)
+		}
+		return result
+	default:
+		return v
+	}
+}
+
+// validateStateUpgraders checks that the StateUpgraders of the resource
+// form a single chain ending at the current SchemaVersion.
+func (r *Resource) validateStateUpgraders() error {
+	if len(r.StateUpgraders) == 0 {
+		return nil
+	}
+
+	version := r.StateUpgraders[0].Version
+	for _, up := range r.StateUpgraders {
+		if up.Version != version {
+			return fmt.Errorf(
+				"StateUpgraders: expected version %d, got %d",
+				version, up.Version)
+		}
+		if up.Upgrade == nil {
+			return fmt.Errorf(
+				"StateUpgraders: version %d: Upgrade must be set", up.Version)
+		}
+		if err := schemaMap(up.Schema).InternalValidate(); err != nil {
+			return fmt.Errorf(
+				"StateUpgraders: version %d: %s", up.Version, err)
+		}
+
+		version++
+	}
+
+	if version != r.SchemaVersion {
+		return fmt.Errorf(
+			"StateUpgraders: last upgrader must be for version %d, got %d",
+			r.SchemaVersion-1, version-1)
+	}
+
+	return nil
+}
@@ -0,0 +1,288 @@
+package schema
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform/terraform"
+)
+
+// testStateUpgradeResource is a resource whose "port" string became an
+// int at version 1, and whose "ports" set became "ingress" blocks at
+// version 2. States of version 0 are migrated by MigrateState, which only
+// renames "name" to "label".
+func testStateUpgradeResource() *Resource {
+	v1 := map[string]*Schema{
+		"label": &Schema{Type: TypeString, Optional: true},
+		"port":  &Schema{Type: TypeString, Optional: true},
+		"ports": &Schema{
+			Type:     TypeSet,
+			Optional: true,
+			Elem:     &Schema{Type: TypeInt},
+			Set: func(v interface{}) int {
+				return v.(int)
+			},
+		},
+	}
+
+	v2 := map[string]*Schema{
+		"label": &Schema{Type: TypeString, Optional: true},
+		"port":  &Schema{Type: TypeInt, Optional: true},
+		"ports": v1["ports"],
+	}
+
+	return &Resource{
+		SchemaVersion: 3,
+		Schema: map[string]*Schema{
+			"label": &Schema{Type: TypeString, Optional: true},
+			"port":  &Schema{Type: TypeInt, Optional: true},
+			"ingress": &Schema{
+				Type:     TypeList,
+				Optional: true,
+				Elem: &Resource{
+					Schema: map[string]*Schema{
+						"from_port": &Schema{Type: TypeInt, Required: true},
+						"to_port":   &Schema{Type: TypeInt, Required: true},
+					},
+				},
+			},
+		},
+
+		MigrateState: func(
+			v int,
+			s *terraform.InstanceState,
+			meta interface{}) (*terraform.InstanceState, error) {
+			if v != 0 {
+				return s, fmt.Errorf("bad version: %d", v)
+			}
+
+			s.Attributes["label"] = s.Attributes["name"]
+			delete(s.Attributes, "name")
+			return s, nil
+		},
+
+		StateUpgraders: []StateUpgrader{
+			StateUpgrader{
+				Version: 1,
+				Schema:  v1,
+				Upgrade: func(
+					raw map[string]interface{},
+					meta interface{}) (map[string]interface{}, error) {
+					if v, ok := raw["port"]; ok {
+						port, err := strconv.Atoi(v.(string))
+						if err != nil {
+							return nil, err
+						}
+						raw["port"] = port
+					}
+					return raw, nil
+				},
+			},
+
+			StateUpgrader{
+				Version: 2,
+				Schema:  v2,
+				Upgrade: func(
+					raw map[string]interface{},
+					meta interface{}) (map[string]interface{}, error) {
+					if meta != 42 {
+						return nil, fmt.Errorf("meta not passed")
+					}
+
+					var ingress []interface{}
+					if v, ok := raw["ports"]; ok {
+						for _, p := range v.([]interface{}) {
+							ingress = append(ingress, map[string]interface{}{
+								"from_port": p,
+								"to_port":   p,
+							})
+						}
+					}
+					delete(raw, "ports")
+					raw["ingress"] = ingress
+					return raw, nil
+				},
+			},
+		},
+
+		Read: func(d *ResourceData, meta interface{}) error {
+			return nil
+		},
+	}
+}
+
+func TestResourceRefresh_stateUpgraders(t *testing.T) {
+	cases := []struct {
+		Version string
+		Attrs   map[string]string
+		Result  map[string]string
+		Err     string
+	}{
+		// Through MigrateState and both steps
+		{
+			"0",
+			map[string]string{
+				"name":     "web",
+				"port":     "80",
+				"ports.#":  "1",
+				"ports.22": "22",
+			},
+			map[string]string{
+				"id":                  "bar",
+				"label":               "web",
+				"port":                "80",
+				"ingress.#":           "1",
+				"ingress.0.from_port": "22",
+				"ingress.0.to_port":   "22",
+			},
+			"",
+		},
+
+		// Through the last step only
+		{
+			"2",
+			map[string]string{
+				"label":    "web",
+				"port":     "80",
+				"ports.#":  "2",
+				"ports.22": "22",
+				"ports.80": "80",
+			},
+			map[string]string{
+				"id":                  "bar",
+				"label":               "web",
+				"port":                "80",
+				"ingress.#":           "2",
+				"ingress.0.from_port": "22",
+				"ingress.0.to_port":   "22",
+				"ingress.1.from_port": "80",
+				"ingress.1.to_port":   "80",
+			},
+			"",
+		},
+
+		// Already current
+		{
+			"3",
+			map[string]string{
+				"label": "web",
+			},
+			map[string]string{
+				"id":    "bar",
+				"label": "web",
+			},
+			"",
+		},
+
+		// Step errors
+		{
+			"1",
+			map[string]string{
+				"port": "http",
+			},
+			nil,
+			"schema version 1",
+		},
+	}
+
+	for i, tc := range cases {
+		r := testStateUpgradeResource()
+		s := &terraform.InstanceState{
+			ID:         "bar",
+			Attributes: tc.Attrs,
+			Meta: map[string]string{
+				"schema_version": tc.Version,
+			},
+		}
+
+		actual, err := r.Refresh(s, 42)
+		if tc.Err != "" {
+			if err == nil || !strings.Contains(err.Error(), tc.Err) {
+				t.Fatalf("%d: bad: %s", i, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("%d: err: %s", i, err)
+		}
+
+		if !reflect.DeepEqual(actual.Attributes, tc.Result) {
+			t.Fatalf("%d: bad: %#v", i, actual.Attributes)
+		}
+		if actual.Meta["schema_version"] != "3" {
+			t.Fatalf("%d: bad: %#v", i, actual.Meta)
+		}
+	}
+}
+
+func TestResourceRefresh_stateUpgradersNoMigrateState(t *testing.T) {
+	r := testStateUpgradeResource()
+	r.MigrateState = nil
+
+	s := &terraform.InstanceState{
+		ID:         "bar",
+		Attributes: map[string]string{"name": "web"},
+	}
+
+	_, err := r.Refresh(s, 42)
+	if err == nil || !strings.Contains(err.Error(), "schema version 0") {
+		t.Fatalf("bad: %s", err)
+	}
+}
+
+func TestResourceInternalValidate_stateUpgraders(t *testing.T) {
+	cases := []struct {
+		In  func(*Resource)
+		Err bool
+	}{
+		{
+			func(r *Resource) {},
+			false,
+		},
+
+		// Gap in the chain
+		{
+			func(r *Resource) {
+				r.StateUpgraders[1].Version = 3
+			},
+			true,
+		},
+
+		// Doesn't reach the current version
+		{
+			func(r *Resource) {
+				r.SchemaVersion = 4
+			},
+			true,
+		},
+
+		// No Upgrade
+		{
+			func(r *Resource) {
+				r.StateUpgraders[1].Upgrade = nil
+			},
+			true,
+		},
+
+		// Invalid schema
+		{
+			func(r *Resource) {
+				r.StateUpgraders[0].Schema["label"] = &Schema{Type: TypeString}
+			},
+			true,
+		},
+	}
+
+	for i, tc := range cases {
+		r := testStateUpgradeResource()
+		tc.In(r)
+
+		err := r.InternalValidate()
+		if (err != nil) != tc.Err {
+			t.Fatalf("%d: bad: %s", i, err)
+		}
+	}
+}
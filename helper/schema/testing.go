@@ -0,0 +1,95 @@
+package schema
+
+import (
+	"reflect"
+	"strconv"
+
+	"github.com/hashicorp/terraform/config"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+// TestT is the interface used by the test helpers of this package.
+//
+// Users should just use a *testing.T object, which implements this.
+type TestT interface {
+	Fatalf(format string, args ...interface{})
+}
+
+// TestResourceStateUpgrade upgrades the attributes of a state of the given
+// SchemaVersion to the current SchemaVersion of the resource, and returns
+// the upgraded attributes.
+//
+// It fails the test if the upgrade errors, or if the upgraded attributes
+// don't round-trip through the current schema unchanged, which would
+// otherwise show up as a diff on the next plan.
+func TestResourceStateUpgrade(
+	t TestT,
+	r *Resource,
+	version int,
+	attrs map[string]string,
+	meta interface{}) map[string]string {
+	if err := r.InternalValidate(); err != nil {
+		t.Fatalf("invalid resource: %s", err)
+	}
+
+	s := &terraform.InstanceState{
+		ID:         "foo",
+		Attributes: make(map[string]string, len(attrs)),
+		Meta: map[string]string{
+			"schema_version": strconv.Itoa(version),
+		},
+	}
+	for k, v := range attrs {
+		s.Attributes[k] = v
+	}
+	if v, ok := s.Attributes["id"]; ok {
+		s.ID = v
+	}
+	s.Attributes["id"] = s.ID
+
+	s, err := r.migrateState(version, s, meta)
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+	if s == nil {
+		t.Fatalf("state upgrade from version %d returned no state", version)
+	}
+
+	data, err := schemaMap(r.Schema).Data(s, nil)
+	if err != nil {
+		t.Fatalf("error reading upgraded state: %s", err)
+	}
+	written := data.State()
+	if written == nil {
+		t.Fatalf("upgraded state can't be read with the current schema")
+	}
+
+	if !reflect.DeepEqual(written.Attributes, s.Attributes) {
+		t.Fatalf(
+			"upgraded state doesn't round-trip through the current schema\n\n"+
+				"upgraded: %#v\n\nwritten: %#v",
+			s.Attributes, written.Attributes)
+	}
+
+	return s.Attributes
+}
+
+// TestResourceApply creates the resource with the given raw configuration
+// as Terraform does, with a diff and then an apply, and returns its state.
+// The provider meta is nil, so this is for unit testing resources that
+// don't need a provider client, such as those that only compute values.
+func TestResourceApply(
+	r *Resource,
+	raw map[string]interface{}) (*terraform.InstanceState, error) {
+	rc, err := config.NewRawConfig(raw)
+	if err != nil {
+		return nil, err
+	}
+
+	d, err := r.Diff(nil, terraform.NewResourceConfig(rc))
+	if err != nil {
+		return nil, err
+	}
+
+	return r.Apply(nil, d, nil)
+}
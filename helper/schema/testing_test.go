@@ -0,0 +1,126 @@
+package schema
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform/terraform"
+)
+
+type testT struct {
+	failed bool
+	msg    string
+}
+
+func (t *testT) Fatalf(format string, args ...interface{}) {
+	if !t.failed {
+		t.failed = true
+		t.msg = fmt.Sprintf(format, args...)
+	}
+}
+
+func TestTestResourceStateUpgrade(t *testing.T) {
+	actual := TestResourceStateUpgrade(t, testStateUpgradeResource(), 1,
+		map[string]string{
+			"port":     "80",
+			"ports.#":  "1",
+			"ports.22": "22",
+		}, 42)
+
+	expected := map[string]string{
+		"id":                  "foo",
+		"port":                "80",
+		"ingress.#":           "1",
+		"ingress.0.from_port": "22",
+		"ingress.0.to_port":   "22",
+	}
+	if !reflect.DeepEqual(actual, expected) {
+		t.Fatalf("bad: %#v", actual)
+	}
+}
+
+func TestTestResourceStateUpgrade_roundTrip(t *testing.T) {
+	r := testStateUpgradeResource()
+
+	// MigrateState forgets to remove the old attribute, which the schema
+	// of the next version doesn't have
+	r.MigrateState = func(
+		v int,
+		s *terraform.InstanceState,
+		meta interface{}) (*terraform.InstanceState, error) {
+		s.Attributes["label"] = s.Attributes["name"]
+		return s, nil
+	}
+	r.StateUpgraders = nil
+	r.SchemaVersion = 1
+	r.Schema = map[string]*Schema{
+		"label": &Schema{Type: TypeString, Optional: true},
+	}
+
+	mock := new(testT)
+	TestResourceStateUpgrade(mock, r, 0, map[string]string{
+		"name": "web",
+	}, 42)
+	if !mock.failed || !strings.Contains(mock.msg, "round-trip") {
+		t.Fatalf("bad: %#v", mock)
+	}
+}
+
+func TestTestResourceStateUpgrade_error(t *testing.T) {
+	mock := new(testT)
+	TestResourceStateUpgrade(mock, testStateUpgradeResource(), 1,
+		map[string]string{"port": "http"}, 42)
+	if !mock.failed || !strings.Contains(mock.msg, "version 1") {
+		t.Fatalf("bad: %#v", mock)
+	}
+}
+
+func TestTestResourceApply(t *testing.T) {
+	r := &Resource{
+		Schema: map[string]*Schema{
+			"name": &Schema{
+				Type:     TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"greeting": &Schema{
+				Type:     TypeString,
+				Computed: true,
+			},
+		},
+
+		Create: func(d *ResourceData, meta interface{}) error {
+			if meta != nil {
+				return fmt.Errorf("meta should be nil, got: %#v", meta)
+			}
+			if d.Get("name").(string) == "" {
+				return fmt.Errorf("name can't be empty")
+			}
+
+			d.SetId(d.Get("name").(string))
+			d.Set("greeting", "hello "+d.Get("name").(string))
+			return nil
+		},
+		Read:   func(*ResourceData, interface{}) error { return nil },
+		Delete: func(*ResourceData, interface{}) error { return nil },
+	}
+
+	s, err := TestResourceApply(r, map[string]interface{}{
+		"name": "world",
+	})
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if s.ID != "world" || s.Attributes["greeting"] != "hello world" {
+		t.Fatalf("bad: %#v", s)
+	}
+
+	if _, err := TestResourceApply(r, map[string]interface{}{
+		"name": "",
+	}); err == nil {
+		t.Fatal("should error")
+	}
+}
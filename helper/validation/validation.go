@@ -0,0 +1,72 @@
+// validation provides validators for the ValidateFunc of the fields of a
+// helper/schema.Schema, which check the values of the configuration when
+// it is validated, before any resource is changed.
+package validation
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+// IntBetween returns a validator for a TypeInt field, which checks that
+// the value is between min and max, inclusive.
+func IntBetween(min, max int) schema.SchemaValidateFunc {
+	return func(i interface{}, k string) ([]string, []error) {
+		v, ok := i.(int)
+		if !ok {
+			return nil, []error{fmt.Errorf("%q: expected an int, got %T", k, i)}
+		}
+
+		if v < min || v > max {
+			return nil, []error{fmt.Errorf(
+				"%q: must be between %d and %d, got %d", k, min, max, v)}
+		}
+
+		return nil, nil
+	}
+}
+
+// StringLenBetween returns a validator for a TypeString field, which
+// checks that the length of the value is between min and max, inclusive.
+func StringLenBetween(min, max int) schema.SchemaValidateFunc {
+	return func(i interface{}, k string) ([]string, []error) {
+		v, ok := i.(string)
+		if !ok {
+			return nil, []error{fmt.Errorf("%q: expected a string, got %T", k, i)}
+		}
+
+		if len(v) < min || len(v) > max {
+			return nil, []error{fmt.Errorf(
+				"%q: must be between %d and %d characters long, got %d: %q",
+				k, min, max, len(v), v)}
+		}
+
+		return nil, nil
+	}
+}
+
+// StringMatch returns a validator for a TypeString field, which checks
+// that the value matches the regular expression. The message describes
+// the expected format in the error, such as "a lowercase name"; if it is
+// empty, the regular expression is shown instead.
+func StringMatch(r *regexp.Regexp, message string) schema.SchemaValidateFunc {
+	return func(i interface{}, k string) ([]string, []error) {
+		v, ok := i.(string)
+		if !ok {
+			return nil, []error{fmt.Errorf("%q: expected a string, got %T", k, i)}
+		}
+
+		if !r.MatchString(v) {
+			expected := message
+			if expected == "" {
+				expected = fmt.Sprintf("a value matching %q", r)
+			}
+			return nil, []error{fmt.Errorf(
+				"%q: must be %s, got %q", k, expected, v)}
+		}
+
+		return nil, nil
+	}
+}
@@ -0,0 +1,66 @@
+package validation
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+type validateCase struct {
+	Value interface{}
+	Err   bool
+}
+
+func testValidate(t *testing.T, f schema.SchemaValidateFunc, cases []validateCase) {
+	for i, tc := range cases {
+		ws, es := f(tc.Value, "foo")
+		if len(ws) > 0 {
+			t.Fatalf("%d: unexpected warnings: %#v", i, ws)
+		}
+		if (len(es) > 0) != tc.Err {
+			t.Fatalf("%d: %#v: bad errors: %#v", i, tc.Value, es)
+		}
+	}
+}
+
+func TestIntBetween(t *testing.T) {
+	testValidate(t, IntBetween(1, 65535), []validateCase{
+		{1, false},
+		{443, false},
+		{65535, false},
+		{0, true},
+		{65536, true},
+		{"80", true},
+	})
+}
+
+func TestStringLenBetween(t *testing.T) {
+	testValidate(t, StringLenBetween(1, 5), []validateCase{
+		{"a", false},
+		{"abcde", false},
+		{"", true},
+		{"abcdef", true},
+		{5, true},
+	})
+}
+
+func TestStringMatch(t *testing.T) {
+	f := StringMatch(regexp.MustCompile(`^[a-z][a-z0-9-]*$`), "a lowercase name")
+	testValidate(t, f, []validateCase{
+		{"web", false},
+		{"web-1", false},
+		{"Web", true},
+		{"1web", true},
+		{"", true},
+	})
+
+	_, es := f("Web", "name")
+	if len(es) != 1 {
+		t.Fatalf("bad: %#v", es)
+	}
+	expected := `"name": must be a lowercase name, got "Web"`
+	if es[0].Error() != expected {
+		t.Fatalf("bad: %s", es[0])
+	}
+}
@@ -1,29 +1,411 @@
 package main
 
 import (
+	"bytes"
+	"encoding/json"
+	"fmt"
 	"io"
 	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
 )
 
 // These are the environmental variables that determine if we log, and if
 // we log whether or not the log should go to a file.
-const EnvLog = "TF_LOG"          //Set to True
-const EnvLogFile = "TF_LOG_PATH" //Set to a file
+const EnvLog = "TF_LOG"                   //Set to a level, or levels per subsystem
+const EnvLogFile = "TF_LOG_PATH"          //Set to a file
+const EnvLogFormat = "TF_LOG_FORMAT"      //Set to "json" for JSON logs
+const EnvLogMaxSize = "TF_LOG_MAX_SIZE"   //Set to rotate TF_LOG_PATH at a size
+const EnvLogMaxFiles = "TF_LOG_MAX_FILES" //Set to the number of rotated files kept
+const defaultLogMaxFiles = 5
+
+// logLevels are the levels of log messages, from the most to the least
+// verbose. Setting a level to "OFF" hides all messages.
+var logLevels = []string{"TRACE", "DEBUG", "INFO", "WARN", "ERROR", "OFF"}
+
+// logLevelInfo is the level of messages that don't have a level.
+const logLevelInfo = 2
+
+var (
+	// logHeaderRe matches the start of a log record, as written by the
+	// standard logger with the date and time.
+	logHeaderRe = regexp.MustCompile(
+		`^(\d{4}/\d{2}/\d{2} \d{2}:\d{2}:\d{2}(?:\.\d+)?) (.*)$`)
+
+	// logPluginRe matches a log message relayed from a plugin.
+	logPluginRe = regexp.MustCompile(
+		`^terraform-(provider|provisioner)-([\w-]+): (.*)$`)
+
+	// logLevelRe matches the level of a log message.
+	logLevelRe = regexp.MustCompile(`\[(TRACE|DEBUG|INFO|WARN|ERR|ERROR)\] ?`)
+
+	// logSubsystemRe matches the subsystem that a message starts with,
+	// after its level, such as "[DEBUG] core: walking".
+	logSubsystemRe = regexp.MustCompile(`^(core|plugin|command|state): `)
+)
 
 // logOutput determines where we should send logs (if anywhere).
 func logOutput() (logOutput io.Writer, err error) {
 	logOutput = nil
-	if os.Getenv(EnvLog) != "" {
-		logOutput = os.Stderr
-
-		if logPath := os.Getenv(EnvLogFile); logPath != "" {
-			var err error
-			logOutput, err = os.Create(logPath)
-			if err != nil {
-				return nil, err
-			}
+	v := os.Getenv(EnvLog)
+	if v == "" {
+		return
+	}
+
+	levels, err := parseLogLevels(v)
+	if err != nil {
+		return nil, err
+	}
+
+	var jsonFormat bool
+	switch f := strings.ToLower(os.Getenv(EnvLogFormat)); f {
+	case "", "text":
+	case "json":
+		jsonFormat = true
+	default:
+		return nil, fmt.Errorf("Invalid %s: %q", EnvLogFormat, f)
+	}
+
+	var w io.Writer = os.Stderr
+	if logPath := os.Getenv(EnvLogFile); logPath != "" {
+		w, err = logFile(logPath)
+		if err != nil {
+			return nil, err
 		}
 	}
 
+	logOutput = &logFilter{
+		Levels: levels,
+		JSON:   jsonFormat,
+		Writer: w,
+	}
 	return
 }
+
+// logFile opens the file that logs are written to. If a maximum size is
+// set, the file is appended to and rotated once it is too large.
+// Otherwise it is truncated.
+func logFile(path string) (io.Writer, error) {
+	v := os.Getenv(EnvLogMaxSize)
+	if v == "" {
+		return os.Create(path)
+	}
+
+	maxSize, err := parseLogSize(v)
+	if err != nil {
+		return nil, fmt.Errorf("Invalid %s: %s", EnvLogMaxSize, err)
+	}
+
+	maxFiles := defaultLogMaxFiles
+	if v := os.Getenv(EnvLogMaxFiles); v != "" {
+		maxFiles, err = strconv.Atoi(v)
+		if err != nil || maxFiles < 0 {
+			return nil, fmt.Errorf("Invalid %s: %q", EnvLogMaxFiles, v)
+		}
+	}
+
+	return newRotatingFile(path, maxSize, maxFiles)
+}
+
+// logLevelSpec is the minimum level of the messages that are logged,
+// by default and for specific subsystems.
+type logLevelSpec struct {
+	Default    int
+	Subsystems map[string]int
+}
+
+// parseLogLevels parses the value of TF_LOG. This is a comma-separated
+// list of levels, such as "WARN,core=DEBUG,provider.aws=TRACE". A level
+// without a subsystem is the default level. For compatibility, any other
+// single value, such as "1", logs everything.
+func parseLogLevels(v string) (*logLevelSpec, error) {
+	result := &logLevelSpec{
+		Default:    0,
+		Subsystems: make(map[string]int),
+	}
+	if !strings.ContainsAny(v, ",=") {
+		if level, ok := logLevel(v); ok {
+			result.Default = level
+		}
+
+		return result, nil
+	}
+
+	for _, part := range strings.Split(v, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+
+		name := ""
+		if idx := strings.Index(part, "="); idx >= 0 {
+			name = strings.TrimSpace(part[:idx])
+			part = strings.TrimSpace(part[idx+1:])
+			if name == "" {
+				return nil, fmt.Errorf("Invalid %s: missing subsystem", EnvLog)
+			}
+		}
+
+		level, ok := logLevel(part)
+		if !ok {
+			return nil, fmt.Errorf(
+				"Invalid %s: unknown level %q. Valid levels are: %s",
+				EnvLog, part, strings.Join(logLevels, ", "))
+		}
+
+		if name == "" {
+			result.Default = level
+		} else {
+			result.Subsystems[strings.ToLower(name)] = level
+		}
+	}
+
+	return result, nil
+}
+
+// Allowed returns whether a message with the given level from the given
+// subsystem is logged. Levels for a subsystem also apply to subsystems
+// below it, so "provider" applies to "provider.aws".
+func (s *logLevelSpec) Allowed(subsystem string, level int) bool {
+	min := s.Default
+	for name := subsystem; name != ""; {
+		if l, ok := s.Subsystems[name]; ok {
+			min = l
+			break
+		}
+
+		idx := strings.LastIndex(name, ".")
+		if idx < 0 {
+			break
+		}
+		name = name[:idx]
+	}
+
+	return level >= min
+}
+
+// logLevel returns the index of a level in logLevels.
+func logLevel(v string) (int, bool) {
+	v = strings.ToUpper(strings.TrimSpace(v))
+	if v == "ERR" {
+		v = "ERROR"
+	}
+
+	for i, l := range logLevels {
+		if l == v {
+			return i, true
+		}
+	}
+
+	return 0, false
+}
+
+// parseLogSize parses a size in bytes, with an optional K, M or G
+// suffix.
+func parseLogSize(v string) (int64, error) {
+	v = strings.ToUpper(strings.TrimSpace(v))
+	mult := int64(1)
+	switch {
+	case strings.HasSuffix(v, "K"):
+		mult = 1024
+	case strings.HasSuffix(v, "M"):
+		mult = 1024 * 1024
+	case strings.HasSuffix(v, "G"):
+		mult = 1024 * 1024 * 1024
+	}
+	if mult > 1 {
+		v = v[:len(v)-1]
+	}
+
+	n, err := strconv.ParseInt(v, 10, 64)
+	if err != nil || n <= 0 {
+		return 0, fmt.Errorf("size must be a positive number of bytes, got %q", v)
+	}
+
+	return n * mult, nil
+}
+
+// logRecord is a single log message, which can span multiple lines.
+type logRecord struct {
+	Time      string
+	Subsystem string
+	Level     int
+	Message   string
+
+	// Text is the record as it is written in the text format, which is
+	// how it was logged.
+	Text string
+}
+
+// logFilter is an io.Writer that parses the log output of Terraform,
+// drops the messages below the configured levels and writes the rest as
+// text or JSON.
+//
+// Messages are logged with the standard logger in the format
+// "[LEVEL] subsystem: message", such as "[DEBUG] core: walking". Messages
+// without a known subsystem belong to "main", and those relayed from a
+// plugin belong to the plugin.
+type logFilter struct {
+	Levels *logLevelSpec
+	JSON   bool
+	Writer io.Writer
+
+	buf    []byte
+	record *logRecord
+}
+
+func (f *logFilter) Write(p []byte) (int, error) {
+	f.buf = append(f.buf, p...)
+	for {
+		idx := bytes.IndexByte(f.buf, '\n')
+		if idx < 0 {
+			break
+		}
+
+		line := string(f.buf[:idx])
+		f.buf = f.buf[idx+1:]
+		if err := f.line(strings.TrimRight(line, "\r")); err != nil {
+			return 0, err
+		}
+	}
+
+	return len(p), nil
+}
+
+// Close writes any remaining output and closes the underlying writer if
+// it can be closed.
+func (f *logFilter) Close() error {
+	if len(f.buf) > 0 {
+		line := string(f.buf)
+		f.buf = nil
+		if err := f.line(line); err != nil {
+			return err
+		}
+	}
+	if err := f.flush(); err != nil {
+		return err
+	}
+
+	if c, ok := f.Writer.(io.Closer); ok && f.Writer != os.Stderr {
+		return c.Close()
+	}
+
+	return nil
+}
+
+// line handles a single line of output. Lines that don't start a new
+// record belong to the previous one, so records are only written once
+// the next one starts.
+func (f *logFilter) line(line string) error {
+	r := parseLogRecord(line)
+	if r == nil {
+		if f.record != nil {
+			f.record.Message += "\n" + line
+			f.record.Text += "\n" + line
+			return nil
+		}
+
+		r = &logRecord{
+			Subsystem: "main",
+			Level:     logLevelInfo,
+			Message:   line,
+			Text:      line,
+		}
+	}
+
+	if err := f.flush(); err != nil {
+		return err
+	}
+
+	f.record = r
+	return nil
+}
+
+// flush writes the current record if its level is logged.
+func (f *logFilter) flush() error {
+	r := f.record
+	f.record = nil
+	if r == nil || !f.Levels.Allowed(r.Subsystem, r.Level) {
+		return nil
+	}
+
+	if !f.JSON {
+		_, err := io.WriteString(f.Writer, r.Text+"\n")
+		return err
+	}
+
+	m := map[string]string{
+		"@level":     strings.ToLower(logLevels[r.Level]),
+		"@subsystem": r.Subsystem,
+		"@message":   r.Message,
+	}
+	if r.Time != "" {
+		m["@timestamp"] = r.Time
+	}
+
+	data, err := json.Marshal(m)
+	if err != nil {
+		return err
+	}
+
+	_, err = f.Writer.Write(append(data, '\n'))
+	return err
+}
+
+// parseLogRecord parses the first line of a log record. It returns nil
+// if the line doesn't start a record.
+func parseLogRecord(line string) *logRecord {
+	match := logHeaderRe.FindStringSubmatch(line)
+	if match == nil {
+		return nil
+	}
+
+	r := &logRecord{
+		Time:      logTime(match[1]),
+		Subsystem: "main",
+		Message:   match[2],
+		Text:      line,
+	}
+
+	// Messages relayed from plugins belong to the plugin, and have the
+	// header of the plugin's own log.
+	plugin := false
+	if pm := logPluginRe.FindStringSubmatch(r.Message); pm != nil {
+		plugin = true
+		r.Subsystem = pm[1] + "." + pm[2]
+		r.Message = pm[3]
+		if inner := logHeaderRe.FindStringSubmatch(r.Message); inner != nil {
+			r.Time = logTime(inner[1])
+			r.Message = inner[2]
+		}
+	}
+
+	r.Level = logLevelInfo
+	if lm := logLevelRe.FindStringSubmatchIndex(r.Message); lm != nil {
+		r.Level, _ = logLevel(r.Message[lm[2]:lm[3]])
+		r.Message = r.Message[:lm[0]] + r.Message[lm[1]:]
+	}
+
+	if sm := logSubsystemRe.FindStringSubmatch(r.Message); sm != nil && !plugin {
+		r.Subsystem = sm[1]
+		r.Message = r.Message[len(sm[0]):]
+	}
+
+	return r
+}
+
+// logTime converts the time of a log record to RFC 3339. It returns the
+// time unchanged if it can't be parsed.
+func logTime(v string) string {
+	t, err := time.ParseInLocation("2006/01/02 15:04:05", v, time.Local)
+	if err != nil {
+		t, err = time.ParseInLocation("2006/01/02 15:04:05.000000", v, time.Local)
+	}
+	if err != nil {
+		return v
+	}
+
+	return t.Format(time.RFC3339Nano)
+}
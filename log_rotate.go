@@ -0,0 +1,95 @@
+package main
+
+import (
+	"fmt"
+	"os"
+)
+
+// rotatingFile is a log file that is rotated once it reaches a maximum
+// size. The rotated files are named after the file with the suffixes
+// ".1", ".2" and so on, with ".1" being the most recent.
+type rotatingFile struct {
+	Path     string
+	MaxSize  int64
+	MaxFiles int
+
+	f    *os.File
+	size int64
+}
+
+// newRotatingFile opens the log file at path for appending.
+func newRotatingFile(path string, maxSize int64, maxFiles int) (*rotatingFile, error) {
+	r := &rotatingFile{
+		Path:     path,
+		MaxSize:  maxSize,
+		MaxFiles: maxFiles,
+	}
+	if err := r.open(); err != nil {
+		return nil, err
+	}
+
+	return r, nil
+}
+
+func (r *rotatingFile) Write(p []byte) (int, error) {
+	if r.size > 0 && r.size+int64(len(p)) > r.MaxSize {
+		if err := r.rotate(); err != nil {
+			return 0, err
+		}
+	}
+
+	n, err := r.f.Write(p)
+	r.size += int64(n)
+	return n, err
+}
+
+func (r *rotatingFile) Close() error {
+	return r.f.Close()
+}
+
+func (r *rotatingFile) open() error {
+	f, err := os.OpenFile(r.Path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0644)
+	if err != nil {
+		return err
+	}
+
+	fi, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return err
+	}
+
+	r.f = f
+	r.size = fi.Size()
+	return nil
+}
+
+// rotate moves the current file to the first rotated file, dropping the
+// oldest one, and starts a new file.
+func (r *rotatingFile) rotate() error {
+	if err := r.f.Close(); err != nil {
+		return err
+	}
+
+	if r.MaxFiles == 0 {
+		if err := os.Remove(r.Path); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	} else {
+		for i := r.MaxFiles - 1; i > 0; i-- {
+			err := os.Rename(r.rotatedPath(i), r.rotatedPath(i+1))
+			if err != nil && !os.IsNotExist(err) {
+				return err
+			}
+		}
+		if err := os.Rename(r.Path, r.rotatedPath(1)); err != nil {
+			return err
+		}
+	}
+
+	return r.open()
+}
+
+func (r *rotatingFile) rotatedPath(i int) string {
+	return fmt.Sprintf("%s.%d", r.Path, i)
+}
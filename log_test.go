@@ -0,0 +1,349 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseLogLevels(t *testing.T) {
+	cases := []struct {
+		Input    string
+		Expected *logLevelSpec
+		Err      bool
+	}{
+		{
+			"1",
+			&logLevelSpec{Default: 0, Subsystems: map[string]int{}},
+			false,
+		},
+		{
+			"debug",
+			&logLevelSpec{Default: 1, Subsystems: map[string]int{}},
+			false,
+		},
+		{
+			"WARN,core=DEBUG, provider.aws=trace",
+			&logLevelSpec{
+				Default: 3,
+				Subsystems: map[string]int{
+					"core":         1,
+					"provider.aws": 0,
+				},
+			},
+			false,
+		},
+		{
+			"plugin=OFF",
+			&logLevelSpec{
+				Default:    0,
+				Subsystems: map[string]int{"plugin": 5},
+			},
+			false,
+		},
+		{
+			"WARN,core=LOUD",
+			nil,
+			true,
+		},
+		{
+			"=DEBUG",
+			nil,
+			true,
+		},
+	}
+
+	for i, tc := range cases {
+		actual, err := parseLogLevels(tc.Input)
+		if (err != nil) != tc.Err {
+			t.Fatalf("%d: err: %s", i, err)
+		}
+		if !reflect.DeepEqual(actual, tc.Expected) {
+			t.Fatalf("%d: bad: %#v", i, actual)
+		}
+	}
+}
+
+func TestLogLevelSpecAllowed(t *testing.T) {
+	spec, err := parseLogLevels("WARN,provider=DEBUG,provider.aws=ERROR")
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	cases := []struct {
+		Subsystem string
+		Level     string
+		Expected  bool
+	}{
+		{"core", "INFO", false},
+		{"core", "WARN", true},
+		{"provider.google", "DEBUG", true},
+		{"provider.google", "TRACE", false},
+		{"provider.aws", "WARN", false},
+		{"provider.aws", "ERROR", true},
+	}
+
+	for i, tc := range cases {
+		level, _ := logLevel(tc.Level)
+		if actual := spec.Allowed(tc.Subsystem, level); actual != tc.Expected {
+			t.Fatalf("%d: bad: %#v", i, actual)
+		}
+	}
+}
+
+func TestParseLogSize(t *testing.T) {
+	cases := []struct {
+		Input    string
+		Expected int64
+		Err      bool
+	}{
+		{"100", 100, false},
+		{"10k", 10 * 1024, false},
+		{"2M", 2 * 1024 * 1024, false},
+		{"1G", 1024 * 1024 * 1024, false},
+		{"0", 0, true},
+		{"M", 0, true},
+		{"ten", 0, true},
+	}
+
+	for i, tc := range cases {
+		actual, err := parseLogSize(tc.Input)
+		if (err != nil) != tc.Err {
+			t.Fatalf("%d: err: %s", i, err)
+		}
+		if actual != tc.Expected {
+			t.Fatalf("%d: bad: %d", i, actual)
+		}
+	}
+}
+
+func TestParseLogRecord(t *testing.T) {
+	cases := []struct {
+		Input     string
+		Subsystem string
+		Level     string
+		Message   string
+	}{
+		{
+			"2015/05/01 10:00:00 [DEBUG] core: vertex root",
+			"core",
+			"DEBUG",
+			"vertex root",
+		},
+		{
+			"2015/05/01 10:00:00 terraform-provider-aws: 2015/05/01 10:00:01 [WARN] throttled",
+			"provider.aws",
+			"WARN",
+			"throttled",
+		},
+		{
+			"2015/05/01 10:00:00 terraform-provider-aws: 2015/05/01 10:00:01 [ERR] plugin: lost connection",
+			"provider.aws",
+			"ERROR",
+			"plugin: lost connection",
+		},
+		{
+			"2015/05/01 10:00:00 [ERR] plugin: lost connection",
+			"plugin",
+			"ERROR",
+			"lost connection",
+		},
+		{
+			"2015/05/01 10:00:00 state: no level",
+			"state",
+			"INFO",
+			"no level",
+		},
+		{
+			"2015/05/01 10:00:00 [INFO] Terraform version",
+			"main",
+			"INFO",
+			"Terraform version",
+		},
+		{
+			"2015/05/01 10:00:00 [DEBUG] aws_instance.web: no subsystem",
+			"main",
+			"DEBUG",
+			"aws_instance.web: no subsystem",
+		},
+	}
+
+	for i, tc := range cases {
+		r := parseLogRecord(tc.Input)
+		if r == nil {
+			t.Fatalf("%d: should parse", i)
+		}
+
+		if r.Subsystem != tc.Subsystem ||
+			logLevels[r.Level] != tc.Level ||
+			r.Message != tc.Message ||
+			r.Text != tc.Input {
+			t.Fatalf("%d: bad: %#v", i, r)
+		}
+	}
+
+	if r := parseLogRecord("  continued"); r != nil {
+		t.Fatalf("bad: %#v", r)
+	}
+}
+
+func TestLogFilter(t *testing.T) {
+	spec, err := parseLogLevels("INFO,core=WARN")
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	out := new(bytes.Buffer)
+	f := &logFilter{Levels: spec, Writer: out}
+
+	// Write in pieces to make sure partial lines are handled
+	input := testLogInput
+	for len(input) > 0 {
+		n := 7
+		if n > len(input) {
+			n = len(input)
+		}
+		if _, err := f.Write([]byte(input[:n])); err != nil {
+			t.Fatalf("err: %s", err)
+		}
+		input = input[n:]
+	}
+	if err := f.Close(); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	expected := strings.TrimSpace(`
+2015/05/01 10:00:00 [INFO] Terraform version
+2015/05/01 10:00:02 [WARN] core: cycle:
+  aws_instance.a
+  aws_instance.b
+2015/05/01 10:00:03 terraform-provider-aws: 2015/05/01 10:00:03 [INFO] created
+`)
+	if actual := strings.TrimSpace(out.String()); actual != expected {
+		t.Fatalf("bad:\n\n%s", actual)
+	}
+}
+
+func TestLogFilter_json(t *testing.T) {
+	spec, err := parseLogLevels("INFO,core=WARN")
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	out := new(bytes.Buffer)
+	f := &logFilter{Levels: spec, JSON: true, Writer: out}
+	if _, err := f.Write([]byte(testLogInput)); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if err := f.Close(); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("bad: %#v", lines)
+	}
+
+	var actual []map[string]string
+	for _, line := range lines {
+		var m map[string]string
+		if err := json.Unmarshal([]byte(line), &m); err != nil {
+			t.Fatalf("err: %s", err)
+		}
+		delete(m, "@timestamp")
+		actual = append(actual, m)
+	}
+
+	expected := []map[string]string{
+		map[string]string{
+			"@level":     "info",
+			"@subsystem": "main",
+			"@message":   "Terraform version",
+		},
+		map[string]string{
+			"@level":     "warn",
+			"@subsystem": "core",
+			"@message":   "cycle:\n  aws_instance.a\n  aws_instance.b",
+		},
+		map[string]string{
+			"@level":     "info",
+			"@subsystem": "provider.aws",
+			"@message":   "created",
+		},
+	}
+	if !reflect.DeepEqual(actual, expected) {
+		t.Fatalf("bad: %#v", actual)
+	}
+}
+
+func TestRotatingFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "tf")
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "terraform.log")
+	f, err := newRotatingFile(path, 10, 2)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	for _, s := range []string{"aaaaaa\n", "bbbbbb\n", "cccccc\n", "dddddd\n"} {
+		if _, err := f.Write([]byte(s)); err != nil {
+			t.Fatalf("err: %s", err)
+		}
+	}
+	if err := f.Close(); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	expected := map[string]string{
+		path:        "dddddd\n",
+		path + ".1": "cccccc\n",
+		path + ".2": "bbbbbb\n",
+	}
+	for p, v := range expected {
+		data, err := ioutil.ReadFile(p)
+		if err != nil {
+			t.Fatalf("err: %s", err)
+		}
+		if string(data) != v {
+			t.Fatalf("bad %s: %q", p, data)
+		}
+	}
+	if _, err := os.Stat(path + ".3"); err == nil {
+		t.Fatal("should only keep two rotated files")
+	}
+
+	// Reopening appends to the existing file
+	f, err = newRotatingFile(path, 10, 2)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if _, err := f.Write([]byte("e\n")); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	f.Close()
+
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if string(data) != "dddddd\ne\n" {
+		t.Fatalf("bad: %q", data)
+	}
+}
+
+const testLogInput = `2015/05/01 10:00:00 [INFO] Terraform version
+2015/05/01 10:00:01 [DEBUG] core: walking
+  root
+2015/05/01 10:00:02 [WARN] core: cycle:
+  aws_instance.a
+  aws_instance.b
+2015/05/01 10:00:03 terraform-provider-aws: 2015/05/01 10:00:03 [INFO] created
+2015/05/01 10:00:04 terraform-provider-aws: 2015/05/01 10:00:04 [DEBUG] request`
@@ -70,6 +70,15 @@ func realMain() int {
 			// Wait for the output copying to finish
 			<-doneCh
 
+			// Write out the last of the logs
+			if c, ok := logWriter.(io.Closer); ok {
+				c.Close()
+			}
+
+			if exitStatus != 0 {
+				pluginCrashHandler(logTempFile)
+			}
+
 			return exitStatus
 		}
 
@@ -146,6 +155,12 @@ func wrappedMain() int {
 	// Initialize the TFConfig settings for the commands...
 	ContextOpts.Providers = config.ProviderFactories()
 	ContextOpts.Provisioners = config.ProvisionerFactories()
+	Policy.Checks = config.PolicyChecks
+	Webhooks, err = config.CommandWebhooks()
+	if err != nil {
+		Ui.Error(fmt.Sprintf("Error loading CLI configuration: \n\n%s", err))
+		return 1
+	}
 
 	exitCode, err := cli.Run()
 	if err != nil {
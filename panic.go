@@ -2,7 +2,6 @@ package main
 
 import (
 	"fmt"
-	"io"
 	"os"
 	"strings"
 
@@ -15,15 +14,35 @@ const panicOutput = `
 !!!!!!!!!!!!!!!!!!!!!!!!!!! TERRAFORM CRASH !!!!!!!!!!!!!!!!!!!!!!!!!!!!
 
 Terraform crashed! This is always indicative of a bug within Terraform.
-A crash log has been placed at "crash.log" relative to your current
-working directory. It would be immensely helpful if you could please
-report the crash with Terraform[1] so that we can fix this.
+A crash bundle with the log, versions and other details has been placed
+at the path below. It would be immensely helpful if you could please
+report the crash with Terraform[1] so that we can fix this, and attach
+the bundle. Secrets are removed from it as far as possible, but please
+check it before sharing it.
+
+Crash bundle: %s
 
 [1]: https://github.com/hashicorp/terraform/issues
 
 !!!!!!!!!!!!!!!!!!!!!!!!!!! TERRAFORM CRASH !!!!!!!!!!!!!!!!!!!!!!!!!!!!
 `
 
+// This output is shown if a plugin crashed.
+const pluginCrashOutput = `
+
+!!!!!!!!!!!!!!!!!!!!!!!!!!! PLUGIN CRASH !!!!!!!!!!!!!!!!!!!!!!!!!!!!!
+
+A plugin of Terraform crashed! This is always indicative of a bug within
+the plugin. A crash bundle with the log, versions and other details has
+been placed at the path below. Please report the crash to the authors of
+the plugin, and attach the bundle. Secrets are removed from it as far as
+possible, but please check it before sharing it.
+
+Crash bundle: %s
+
+!!!!!!!!!!!!!!!!!!!!!!!!!!! PLUGIN CRASH !!!!!!!!!!!!!!!!!!!!!!!!!!!!!
+`
+
 // panicHandler is what is called by panicwrap when a panic is encountered
 // within Terraform. It is guaranteed to run after the resulting process has
 // exited so we can take the log file, add in the panic, and store it
@@ -34,30 +53,48 @@ func panicHandler(logF *os.File) panicwrap.HandlerFunc {
 		// shown in case anything below fails.
 		fmt.Fprintf(os.Stderr, fmt.Sprintf("%s\n", m))
 
-		// Create the crash log file where we'll write the logs
-		f, err := os.Create("crash.log")
+		path, err := writeCrashBundle(logF)
 		if err != nil {
-			fmt.Fprintf(os.Stderr, "Failed to create crash log file: %s", err)
-			return
-		}
-		defer f.Close()
-
-		// Seek the log file back to the beginning
-		if _, err = logF.Seek(0, 0); err != nil {
-			fmt.Fprintf(os.Stderr, "Failed to seek log file for crash: %s", err)
-			return
-		}
-
-		// Copy the contents to the crash file. This will include
-		// the panic that just happened.
-		if _, err = io.Copy(f, logF); err != nil {
-			fmt.Fprintf(os.Stderr, "Failed to write crash log: %s", err)
+			fmt.Fprintf(os.Stderr, "Failed to write crash bundle: %s", err)
 			return
 		}
 
 		// Tell the user a crash occurred in some helpful way that
 		// they'll hopefully notice.
 		fmt.Printf("\n\n")
-		fmt.Println(strings.TrimSpace(panicOutput))
+		fmt.Println(strings.TrimSpace(fmt.Sprintf(panicOutput, path)))
+	}
+}
+
+// pluginCrashHandler is called after Terraform exited with an error, and
+// writes a crash bundle if the log shows that a plugin crashed.
+func pluginCrashHandler(logF *os.File) {
+	log, err := readCrashLog(logF)
+	if err != nil || !pluginCrashed(log) {
+		return
+	}
+
+	path, err := writeCrashBundle(logF)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to write crash bundle: %s", err)
+		return
+	}
+
+	fmt.Printf("\n\n")
+	fmt.Println(strings.TrimSpace(fmt.Sprintf(pluginCrashOutput, path)))
+}
+
+// writeCrashBundle writes a crash bundle with the log of the run to the
+// current working directory.
+func writeCrashBundle(logF *os.File) (string, error) {
+	log, err := readCrashLog(logF)
+	if err != nil {
+		return "", fmt.Errorf("Failed to read log file for crash: %s", err)
+	}
+
+	b := &crashBundle{
+		Log:  log,
+		Args: os.Args[1:],
 	}
+	return b.Write(".")
 }
@@ -36,6 +36,7 @@ type Client struct {
 	doneLogging chan struct{}
 	l           sync.Mutex
 	address     net.Addr
+	version     string
 	client      *tfrpc.Client
 }
 
@@ -88,7 +89,7 @@ func CleanupClients() {
 		}(client)
 	}
 
-	log.Println("waiting for all plugin processes to complete...")
+	log.Println("plugin: waiting for all plugin processes to complete...")
 	wg.Wait()
 }
 
@@ -141,10 +142,24 @@ func (c *Client) Client() (*tfrpc.Client, error) {
 	if err != nil {
 		return nil, err
 	}
+	c.client.PluginVersion = c.version
 
 	return c.client, nil
 }
 
+// Version returns the version that the plugin reported when it was
+// started, or an empty string if it didn't report one. The plugin is
+// started if it isn't running yet.
+func (c *Client) Version() (string, error) {
+	if _, err := c.Start(); err != nil {
+		return "", err
+	}
+
+	c.l.Lock()
+	defer c.l.Unlock()
+	return c.version, nil
+}
+
 // Tells whether or not the underlying process has exited.
 func (c *Client) Exited() bool {
 	c.l.Lock()
@@ -203,7 +218,7 @@ func (c *Client) Start() (addr net.Addr, err error) {
 	cmd.Stderr = stderr_w
 	cmd.Stdout = stdout_w
 
-	log.Printf("[DEBUG] Starting plugin: %s %#v", cmd.Path, cmd.Args)
+	log.Printf("[DEBUG] plugin: Starting %s %#v", cmd.Path, cmd.Args)
 	err = cmd.Start()
 	if err != nil {
 		return
@@ -234,7 +249,7 @@ func (c *Client) Start() (addr net.Addr, err error) {
 		cmd.Wait()
 
 		// Log and make sure to flush the logs write away
-		log.Printf("[DEBUG] %s: plugin process exited\n", cmd.Path)
+		log.Printf("[DEBUG] plugin: %s: process exited\n", cmd.Path)
 		os.Stderr.Sync()
 
 		// Mark that we exited
@@ -281,7 +296,7 @@ func (c *Client) Start() (addr net.Addr, err error) {
 	timeout := time.After(c.config.StartTimeout)
 
 	// Start looking for the address
-	log.Printf("[DEBUG] Waiting for RPC address for: %s", cmd.Path)
+	log.Printf("[DEBUG] plugin: Waiting for RPC address for: %s", cmd.Path)
 	select {
 	case <-timeout:
 		err = errors.New("timeout while waiting for plugin to start")
@@ -289,9 +304,9 @@ func (c *Client) Start() (addr net.Addr, err error) {
 		err = errors.New("plugin exited before we could connect")
 	case lineBytes := <-linesCh:
 		// Trim the line and split by "|" in order to get the parts of
-		// the output.
+		// the output. The version of the plugin is optional.
 		line := strings.TrimSpace(string(lineBytes))
-		parts := strings.SplitN(line, "|", 3)
+		parts := strings.SplitN(line, "|", 4)
 		if len(parts) < 3 {
 			err = fmt.Errorf("Unrecognized remote plugin message: %s", line)
 			return
@@ -312,6 +327,10 @@ func (c *Client) Start() (addr net.Addr, err error) {
 		default:
 			err = fmt.Errorf("Unknown address type: %s", parts[1])
 		}
+
+		if len(parts) > 3 {
+			c.version = parts[3]
+		}
 	}
 
 	c.address = addr
@@ -28,6 +28,11 @@ func TestClient(t *testing.T) {
 		t.Fatalf("bad: %#v", addr)
 	}
 
+	// The plugin doesn't report a version
+	if v, err := c.Version(); err != nil || v != "" {
+		t.Fatalf("bad: %s %s", v, err)
+	}
+
 	// Test that it exits properly if killed
 	c.Kill()
 
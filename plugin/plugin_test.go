@@ -59,6 +59,11 @@ func TestHelperProcess(*testing.T) {
 		Serve(&ServeOpts{
 			ProviderFunc: testProviderFixed(new(terraform.MockResourceProvider)),
 		})
+	case "resource-provider-version":
+		Serve(&ServeOpts{
+			ProviderFunc: testProviderFixed(new(terraform.MockResourceProvider)),
+			Version:      "1.2.3",
+		})
 	case "resource-provisioner":
 		Serve(&ServeOpts{
 			ProvisionerFunc: testProvisionerFixed(
@@ -2,6 +2,8 @@ package plugin
 
 import (
 	"testing"
+
+	"github.com/hashicorp/terraform/terraform"
 )
 
 func TestResourceProvider(t *testing.T) {
@@ -13,3 +15,32 @@ func TestResourceProvider(t *testing.T) {
 		t.Fatalf("should not have error: %s", err)
 	}
 }
+
+func TestResourceProvider_version(t *testing.T) {
+	c := NewClient(&ClientConfig{Cmd: helperProcess("resource-provider-version")})
+	defer c.Kill()
+
+	v, err := c.Version()
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if v != "1.2.3" {
+		t.Fatalf("bad: %s", v)
+	}
+
+	client, err := c.Client()
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	p, err := client.ResourceProvider()
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	pv, ok := p.(terraform.ResourceProviderVersioner)
+	if !ok {
+		t.Fatalf("bad: %#v", p)
+	}
+	if pv.ProviderVersion() != "1.2.3" {
+		t.Fatalf("bad: %s", pv.ProviderVersion())
+	}
+}
@@ -30,6 +30,11 @@ const MagicCookieValue = "d602bf8f470bc67ca7faa0386276bbdd4330efaf76d1a219cb4d69
 type ServeOpts struct {
 	ProviderFunc    tfrpc.ProviderFunc
 	ProvisionerFunc tfrpc.ProvisionerFunc
+
+	// Version is the version of the plugin, such as "1.2.0". It is
+	// reported to Terraform when the plugin starts, so that the version
+	// constraints in configurations can be checked.
+	Version string
 }
 
 // Serve serves the plugins given by ServeOpts.
@@ -49,7 +54,7 @@ func Serve(opts *ServeOpts) {
 	// Register a listener so we can accept a connection
 	listener, err := serverListener()
 	if err != nil {
-		log.Printf("[ERR] plugin init: %s", err)
+		log.Printf("[ERR] plugin: init: %s", err)
 		return
 	}
 	defer listener.Close()
@@ -61,13 +66,18 @@ func Serve(opts *ServeOpts) {
 	}
 
 	// Output the address and service name to stdout so that Terraform
-	// core can bring it up.
+	// core can bring it up. The version of the plugin is only added if
+	// there is one, since older versions of Terraform don't expect it.
 	log.Printf("Plugin address: %s %s\n",
 		listener.Addr().Network(), listener.Addr().String())
-	fmt.Printf("%s|%s|%s\n",
+	handshake := fmt.Sprintf("%s|%s|%s",
 		APIVersion,
 		listener.Addr().Network(),
 		listener.Addr().String())
+	if opts.Version != "" {
+		handshake += "|" + opts.Version
+	}
+	fmt.Println(handshake)
 	os.Stdout.Sync()
 
 	// Eat the interrupts
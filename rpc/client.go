@@ -12,6 +12,10 @@ import (
 // Client connects to a Server in order to request plugin implementations
 // for Terraform.
 type Client struct {
+	// PluginVersion is the version that the plugin reported when it was
+	// started, if any. It is passed on to the resource providers.
+	PluginVersion string
+
 	broker  *muxBroker
 	control *rpc.Client
 }
@@ -82,9 +86,10 @@ func (c *Client) ResourceProvider() (terraform.ResourceProvider, error) {
 	}
 
 	return &ResourceProvider{
-		Broker: c.broker,
-		Client: rpc.NewClient(conn),
-		Name:   "ResourceProvider",
+		Broker:  c.broker,
+		Client:  rpc.NewClient(conn),
+		Name:    "ResourceProvider",
+		Version: c.PluginVersion,
 	}, nil
 }
 
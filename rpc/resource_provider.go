@@ -1,6 +1,7 @@
 package rpc
 
 import (
+	"fmt"
 	"net/rpc"
 
 	"github.com/hashicorp/terraform/terraform"
@@ -12,6 +13,15 @@ type ResourceProvider struct {
 	Broker *muxBroker
 	Client *rpc.Client
 	Name   string
+
+	// Version is the version of the plugin that serves the provider, or
+	// an empty string if it is unknown.
+	Version string
+}
+
+// ProviderVersion implements terraform.ResourceProviderVersioner.
+func (p *ResourceProvider) ProviderVersion() string {
+	return p.Version
 }
 
 func (p *ResourceProvider) Input(
@@ -162,6 +172,103 @@ func (p *ResourceProvider) Refresh(
 	return resp.State, err
 }
 
+// ImportState implements terraform.ResourceProviderImporter. Plugins
+// whose provider can't import resources return an error.
+func (p *ResourceProvider) ImportState(
+	info *terraform.InstanceInfo,
+	id string) (*terraform.InstanceState, error) {
+	var resp ResourceProviderImportStateResponse
+	args := &ResourceProviderImportStateArgs{
+		Info: info,
+		ID:   id,
+	}
+
+	err := p.Client.Call(p.Name+".ImportState", args, &resp)
+	if err != nil {
+		return nil, err
+	}
+	if resp.Error != nil {
+		err = resp.Error
+	}
+
+	return resp.State, err
+}
+
+// ValidateDataSource implements terraform.ResourceProviderDataSourcer.
+func (p *ResourceProvider) ValidateDataSource(
+	t string, c *terraform.ResourceConfig) ([]string, []error) {
+	var resp ResourceProviderValidateResourceResponse
+	args := ResourceProviderValidateResourceArgs{
+		Config: c,
+		Type:   t,
+	}
+
+	err := p.Client.Call(p.Name+".ValidateDataSource", &args, &resp)
+	if err != nil {
+		return nil, []error{err}
+	}
+
+	var errs []error
+	if len(resp.Errors) > 0 {
+		errs = make([]error, len(resp.Errors))
+		for i, err := range resp.Errors {
+			errs[i] = err
+		}
+	}
+
+	return resp.Warnings, errs
+}
+
+// ReadDataSource implements terraform.ResourceProviderDataSourcer.
+// Plugins whose provider has no data sources return an error.
+func (p *ResourceProvider) ReadDataSource(
+	info *terraform.InstanceInfo,
+	c *terraform.ResourceConfig) (*terraform.InstanceState, error) {
+	var resp ResourceProviderReadDataSourceResponse
+	args := &ResourceProviderReadDataSourceArgs{
+		Info:   info,
+		Config: c,
+	}
+
+	err := p.Client.Call(p.Name+".ReadDataSource", args, &resp)
+	if err != nil {
+		return nil, err
+	}
+	if resp.Error != nil {
+		err = resp.Error
+	}
+
+	return resp.State, err
+}
+
+// DataSources implements terraform.ResourceProviderDataSourcer.
+func (p *ResourceProvider) DataSources() []terraform.DataSource {
+	var result []terraform.DataSource
+
+	err := p.Client.Call(p.Name+".DataSources", new(interface{}), &result)
+	if err != nil {
+		return nil
+	}
+
+	return result
+}
+
+// Stop implements terraform.ResourceProviderStopper. The plugin serves
+// calls concurrently, so this reaches the provider while it is still
+// applying.
+func (p *ResourceProvider) Stop() error {
+	var resp ResourceProviderStopResponse
+	err := p.Client.Call(p.Name+".Stop", new(interface{}), &resp)
+	if err != nil {
+		return err
+	}
+	if resp.Error != nil {
+		err = resp.Error
+	}
+
+	return err
+}
+
 func (p *ResourceProvider) Resources() []terraform.ResourceType {
 	var result []terraform.ResourceType
 
@@ -227,6 +334,30 @@ type ResourceProviderRefreshResponse struct {
 	Error *BasicError
 }
 
+type ResourceProviderImportStateArgs struct {
+	Info *terraform.InstanceInfo
+	ID   string
+}
+
+type ResourceProviderImportStateResponse struct {
+	State *terraform.InstanceState
+	Error *BasicError
+}
+
+type ResourceProviderReadDataSourceArgs struct {
+	Info   *terraform.InstanceInfo
+	Config *terraform.ResourceConfig
+}
+
+type ResourceProviderReadDataSourceResponse struct {
+	State *terraform.InstanceState
+	Error *BasicError
+}
+
+type ResourceProviderStopResponse struct {
+	Error *BasicError
+}
+
 type ResourceProviderValidateArgs struct {
 	Config *terraform.ResourceConfig
 }
@@ -346,9 +477,96 @@ func (s *ResourceProviderServer) Refresh(
 	return nil
 }
 
+func (s *ResourceProviderServer) ImportState(
+	args *ResourceProviderImportStateArgs,
+	result *ResourceProviderImportStateResponse) error {
+	importer, ok := s.Provider.(terraform.ResourceProviderImporter)
+	if !ok {
+		*result = ResourceProviderImportStateResponse{
+			Error: NewBasicError(fmt.Errorf(
+				"the provider doesn't support importing resources")),
+		}
+		return nil
+	}
+
+	state, err := importer.ImportState(args.Info, args.ID)
+	*result = ResourceProviderImportStateResponse{
+		State: state,
+		Error: NewBasicError(err),
+	}
+	return nil
+}
+
+func (s *ResourceProviderServer) ValidateDataSource(
+	args *ResourceProviderValidateResourceArgs,
+	reply *ResourceProviderValidateResourceResponse) error {
+	dataSourcer, ok := s.Provider.(terraform.ResourceProviderDataSourcer)
+	if !ok {
+		*reply = ResourceProviderValidateResourceResponse{
+			Errors: []*BasicError{NewBasicError(fmt.Errorf(
+				"the provider doesn't support data sources"))},
+		}
+		return nil
+	}
+
+	warns, errs := dataSourcer.ValidateDataSource(args.Type, args.Config)
+	berrs := make([]*BasicError, len(errs))
+	for i, err := range errs {
+		berrs[i] = NewBasicError(err)
+	}
+	*reply = ResourceProviderValidateResourceResponse{
+		Warnings: warns,
+		Errors:   berrs,
+	}
+	return nil
+}
+
+func (s *ResourceProviderServer) ReadDataSource(
+	args *ResourceProviderReadDataSourceArgs,
+	result *ResourceProviderReadDataSourceResponse) error {
+	dataSourcer, ok := s.Provider.(terraform.ResourceProviderDataSourcer)
+	if !ok {
+		*result = ResourceProviderReadDataSourceResponse{
+			Error: NewBasicError(fmt.Errorf(
+				"the provider doesn't support data sources")),
+		}
+		return nil
+	}
+
+	state, err := dataSourcer.ReadDataSource(args.Info, args.Config)
+	*result = ResourceProviderReadDataSourceResponse{
+		State: state,
+		Error: NewBasicError(err),
+	}
+	return nil
+}
+
+func (s *ResourceProviderServer) DataSources(
+	nothing interface{},
+	result *[]terraform.DataSource) error {
+	if dataSourcer, ok := s.Provider.(terraform.ResourceProviderDataSourcer); ok {
+		*result = dataSourcer.DataSources()
+	}
+	return nil
+}
+
 func (s *ResourceProviderServer) Resources(
 	nothing interface{},
 	result *[]terraform.ResourceType) error {
 	*result = s.Provider.Resources()
 	return nil
 }
+
+func (s *ResourceProviderServer) Stop(
+	nothing interface{},
+	reply *ResourceProviderStopResponse) error {
+	var err error
+	if stopper, ok := s.Provider.(terraform.ResourceProviderStopper); ok {
+		err = stopper.Stop()
+	}
+
+	*reply = ResourceProviderStopResponse{
+		Error: NewBasicError(err),
+	}
+	return nil
+}
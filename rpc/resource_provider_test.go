@@ -4,6 +4,7 @@ import (
 	"errors"
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/hashicorp/terraform/terraform"
 )
@@ -266,6 +267,101 @@ func TestResourceProvider_refresh(t *testing.T) {
 	}
 }
 
+func TestResourceProvider_importState(t *testing.T) {
+	p := new(terraform.MockResourceProvider)
+	client, server := testClientServer(t)
+	name, err := Register(server, p)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	provider := &ResourceProvider{Client: client, Name: name}
+
+	p.ImportStateReturn = &terraform.InstanceState{
+		ID: "bob",
+	}
+
+	// ImportState
+	info := &terraform.InstanceInfo{Type: "foo"}
+	state, err := provider.ImportState(info, "bob")
+	if !p.ImportStateCalled {
+		t.Fatal("import should be called")
+	}
+	if !reflect.DeepEqual(p.ImportStateInfo, info) {
+		t.Fatalf("bad: %#v", p.ImportStateInfo)
+	}
+	if p.ImportStateID != "bob" {
+		t.Fatalf("bad: %#v", p.ImportStateID)
+	}
+	if err != nil {
+		t.Fatalf("bad: %#v", err)
+	}
+	if !reflect.DeepEqual(p.ImportStateReturn, state) {
+		t.Fatalf("bad: %#v", state)
+	}
+}
+
+func TestResourceProvider_readDataSource(t *testing.T) {
+	p := new(terraform.MockResourceProvider)
+	client, server := testClientServer(t)
+	name, err := Register(server, p)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	provider := &ResourceProvider{Client: client, Name: name}
+
+	p.ReadDataSourceReturn = &terraform.InstanceState{
+		ID: "bob",
+	}
+
+	// ReadDataSource
+	info := &terraform.InstanceInfo{Type: "foo"}
+	config := &terraform.ResourceConfig{
+		Raw: map[string]interface{}{"foo": "bar"},
+	}
+	state, err := provider.ReadDataSource(info, config)
+	if !p.ReadDataSourceCalled {
+		t.Fatal("read data source should be called")
+	}
+	if !reflect.DeepEqual(p.ReadDataSourceInfo, info) {
+		t.Fatalf("bad: %#v", p.ReadDataSourceInfo)
+	}
+	if !reflect.DeepEqual(p.ReadDataSourceConfig, config) {
+		t.Fatalf("bad: %#v", p.ReadDataSourceConfig)
+	}
+	if err != nil {
+		t.Fatalf("bad: %#v", err)
+	}
+	if !reflect.DeepEqual(p.ReadDataSourceReturn, state) {
+		t.Fatalf("bad: %#v", state)
+	}
+}
+
+func TestResourceProvider_dataSources(t *testing.T) {
+	p := new(terraform.MockResourceProvider)
+	client, server := testClientServer(t)
+	name, err := Register(server, p)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	provider := &ResourceProvider{Client: client, Name: name}
+
+	expected := []terraform.DataSource{
+		{"foo"},
+		{"bar"},
+	}
+
+	p.DataSourcesReturn = expected
+
+	// DataSources
+	result := provider.DataSources()
+	if !p.DataSourcesCalled {
+		t.Fatal("data sources should be called")
+	}
+	if !reflect.DeepEqual(result, expected) {
+		t.Fatalf("bad: %#v", result)
+	}
+}
+
 func TestResourceProvider_resources(t *testing.T) {
 	p := new(terraform.MockResourceProvider)
 	client, server := testClientServer(t)
@@ -292,6 +388,68 @@ func TestResourceProvider_resources(t *testing.T) {
 	}
 }
 
+func TestResourceProvider_stop(t *testing.T) {
+	p := new(terraform.MockResourceProvider)
+	client, server := testClientServer(t)
+	name, err := Register(server, p)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	provider := &ResourceProvider{Client: client, Name: name}
+
+	// The apply blocks until the provider is stopped, which checks that
+	// Stop reaches the provider while another call is in flight.
+	stopCh := make(chan struct{})
+	p.StopFn = func() error {
+		close(stopCh)
+		return nil
+	}
+	p.ApplyFn = func(*terraform.InstanceInfo, *terraform.InstanceState, *terraform.InstanceDiff) (*terraform.InstanceState, error) {
+		<-stopCh
+		return nil, errors.New("interrupted")
+	}
+
+	applyErrCh := make(chan error)
+	go func() {
+		_, err := provider.Apply(
+			&terraform.InstanceInfo{}, nil, &terraform.InstanceDiff{})
+		applyErrCh <- err
+	}()
+
+	if err := provider.Stop(); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if !p.StopCalled {
+		t.Fatal("stop should be called")
+	}
+
+	select {
+	case err := <-applyErrCh:
+		if err == nil || err.Error() != "interrupted" {
+			t.Fatalf("bad: %#v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("apply wasn't interrupted")
+	}
+}
+
+func TestResourceProvider_stopError(t *testing.T) {
+	p := new(terraform.MockResourceProvider)
+	client, server := testClientServer(t)
+	name, err := Register(server, p)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	provider := &ResourceProvider{Client: client, Name: name}
+
+	p.StopReturnError = errors.New("foo")
+
+	err = provider.Stop()
+	if err == nil || err.Error() != "foo" {
+		t.Fatalf("bad: %#v", err)
+	}
+}
+
 func TestResourceProvider_validate(t *testing.T) {
 	p := new(terraform.MockResourceProvider)
 	client, server := testClientServer(t)
@@ -32,7 +32,7 @@ func (s *Server) Accept(lis net.Listener) {
 	for {
 		conn, err := lis.Accept()
 		if err != nil {
-			log.Printf("[ERR] plugin server: %s", err)
+			log.Printf("[ERR] plugin: server: %s", err)
 			return
 		}
 
@@ -92,7 +92,7 @@ func (d *dispenseServer) ResourceProvider(
 	go func() {
 		conn, err := d.broker.Accept(id)
 		if err != nil {
-			log.Printf("[ERR] Plugin dispense: %s", err)
+			log.Printf("[ERR] plugin: dispense: %s", err)
 			return
 		}
 
@@ -113,7 +113,7 @@ func (d *dispenseServer) ResourceProvisioner(
 	go func() {
 		conn, err := d.broker.Accept(id)
 		if err != nil {
-			log.Printf("[ERR] Plugin dispense: %s", err)
+			log.Printf("[ERR] plugin: dispense: %s", err)
 			return
 		}
 
@@ -129,7 +129,7 @@ func (d *dispenseServer) ResourceProvisioner(
 func acceptAndServe(mux *muxBroker, id uint32, n string, v interface{}) {
 	conn, err := mux.Accept(id)
 	if err != nil {
-		log.Printf("[ERR] Plugin acceptAndServe: %s", err)
+		log.Printf("[ERR] plugin: acceptAndServe: %s", err)
 		return
 	}
 
@@ -139,7 +139,7 @@ func acceptAndServe(mux *muxBroker, id uint32, n string, v interface{}) {
 func serve(conn io.ReadWriteCloser, name string, v interface{}) {
 	server := rpc.NewServer()
 	if err := server.RegisterName(name, v); err != nil {
-		log.Printf("[ERR] Plugin dispense: %s", err)
+		log.Printf("[ERR] plugin: dispense: %s", err)
 		return
 	}
 
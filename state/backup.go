@@ -43,6 +43,33 @@ func (s *BackupState) PersistState() error {
 	return s.Real.PersistState()
 }
 
+// Locker impl. The lock is passed through to the real state, if it
+// supports locking.
+func (s *BackupState) Lock(operation string) error {
+	if l, ok := s.Real.(Locker); ok {
+		return l.Lock(operation)
+	}
+
+	return nil
+}
+
+func (s *BackupState) Unlock() error {
+	if l, ok := s.Real.(Locker); ok {
+		return l.Unlock()
+	}
+
+	return nil
+}
+
+// ForceUnlocker impl. The state isn't backed up, since it isn't modified.
+func (s *BackupState) ForceUnlock(id string) error {
+	if l, ok := s.Real.(ForceUnlocker); ok {
+		return l.ForceUnlock(id)
+	}
+
+	return ErrForceUnlockUnsupported
+}
+
 func (s *BackupState) backup() error {
 	state := s.Real.State()
 	if state == nil {
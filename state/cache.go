@@ -131,6 +131,36 @@ func (s *CacheState) PersistState() error {
 	return s.Durable.PersistState()
 }
 
+// Lock locks the durable storage, if it supports locking. The cache is
+// local and doesn't need to be locked.
+//
+// Locker impl.
+func (s *CacheState) Lock(operation string) error {
+	if l, ok := s.Durable.(Locker); ok {
+		return l.Lock(operation)
+	}
+
+	return nil
+}
+
+// Locker impl.
+func (s *CacheState) Unlock() error {
+	if l, ok := s.Durable.(Locker); ok {
+		return l.Unlock()
+	}
+
+	return nil
+}
+
+// ForceUnlocker impl.
+func (s *CacheState) ForceUnlock(id string) error {
+	if l, ok := s.Durable.(ForceUnlocker); ok {
+		return l.ForceUnlock(id)
+	}
+
+	return ErrForceUnlockUnsupported
+}
+
 // CacheStateCache is the meta-interface that must be implemented for
 // the cache for the CacheState.
 type CacheStateCache interface {
@@ -1,6 +1,8 @@
 package state
 
 import (
+	"encoding/json"
+	"io/ioutil"
 	"os"
 	"path/filepath"
 
@@ -18,6 +20,9 @@ type LocalState struct {
 	state     *terraform.State
 	readState *terraform.State
 	written   bool
+
+	// lockFile is the open lock file while the state is locked.
+	lockFile *os.File
 }
 
 // SetState will force a specific state in-memory for this local state.
@@ -57,16 +62,10 @@ func (s *LocalState) WriteState(state *terraform.State) error {
 		return err
 	}
 
-	f, err := os.Create(path)
-	if err != nil {
-		return err
-	}
-	defer f.Close()
-
 	s.state.IncrementSerialMaybe(s.readState)
 	s.readState = s.state
 
-	if err := terraform.WriteState(s.state, f); err != nil {
+	if err := writeStateAtomic(path, s.state); err != nil {
 		return err
 	}
 
@@ -74,6 +73,60 @@ func (s *LocalState) WriteState(state *terraform.State) error {
 	return nil
 }
 
+// writeStateAtomic writes the state to a temporary file next to the path,
+// syncs it to disk and renames it over the path. The rename is atomic, so
+// a crash while writing leaves either the old or the new state at the
+// path, never a truncated one.
+func writeStateAtomic(path string, state *terraform.State) error {
+	// Replace the file a symlink points to, not the symlink
+	if real, err := filepath.EvalSymlinks(path); err == nil {
+		path = real
+	}
+
+	// Keep the permissions of the existing state
+	mode := os.FileMode(0644)
+	if fi, err := os.Stat(path); err == nil {
+		mode = fi.Mode().Perm()
+	}
+
+	dir, base := filepath.Split(path)
+	if dir == "" {
+		dir = "."
+	}
+	f, err := ioutil.TempFile(dir, "."+base+".tmp")
+	if err != nil {
+		return err
+	}
+	tmp := f.Name()
+
+	err = terraform.WriteState(state, f)
+	if err == nil {
+		err = f.Sync()
+	}
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Chmod(tmp, mode)
+	}
+	if err == nil {
+		err = os.Rename(tmp, path)
+	}
+	if err != nil {
+		os.Remove(tmp)
+		return err
+	}
+
+	// Sync the directory too, so the rename itself survives a crash. Not
+	// all platforms can sync directories, so this is best effort.
+	if d, err := os.Open(dir); err == nil {
+		d.Sync()
+		d.Close()
+	}
+
+	return nil
+}
+
 // PersistState for LocalState is a no-op since WriteState always persists.
 //
 // StatePersister impl.
@@ -113,3 +166,100 @@ func (s *LocalState) RefreshState() error {
 	s.readState = state
 	return nil
 }
+
+// Lock locks the state with an exclusive lock on a lock file next to it,
+// which is released by the operating system if the process exits without
+// unlocking, so a crashed run never leaves the state locked. The lock
+// information is written to the lock file for the runs that find the
+// state locked.
+//
+// Locker impl.
+func (s *LocalState) Lock(operation string) error {
+	if s.lockFile != nil {
+		return nil
+	}
+
+	path := s.lockPath()
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+
+	var f *os.File
+	for f == nil {
+		var err error
+		f, err = os.OpenFile(path, os.O_RDWR|os.O_CREATE, 0644)
+		if err != nil {
+			return err
+		}
+
+		if err := lockFile(f); err != nil {
+			lockErr := &LockError{Err: err}
+			info := new(LockInfo)
+			if err := json.NewDecoder(f).Decode(info); err == nil {
+				lockErr.Info = info
+			}
+			f.Close()
+			return lockErr
+		}
+
+		// The previous holder of the lock removes the lock file when
+		// unlocking, so the file that was locked may no longer be the
+		// one at the path. Try again with the new one then.
+		locked, err := f.Stat()
+		if err != nil {
+			unlockFile(f)
+			f.Close()
+			return err
+		}
+		if current, err := os.Stat(path); err != nil || !os.SameFile(locked, current) {
+			unlockFile(f)
+			f.Close()
+			f = nil
+		}
+	}
+
+	info, err := json.Marshal(NewLockInfo(operation))
+	if err == nil {
+		err = f.Truncate(0)
+	}
+	if err == nil {
+		_, err = f.WriteAt(info, 0)
+	}
+	if err != nil {
+		unlockFile(f)
+		f.Close()
+		return err
+	}
+
+	s.lockFile = f
+	return nil
+}
+
+// Locker impl.
+func (s *LocalState) Unlock() error {
+	if s.lockFile == nil {
+		return nil
+	}
+
+	f := s.lockFile
+	s.lockFile = nil
+
+	// The lock file is removed while it is still locked. Where open files
+	// can't be removed, it is emptied instead.
+	if err := os.Remove(f.Name()); err != nil {
+		f.Truncate(0)
+	}
+	if err := unlockFile(f); err != nil {
+		f.Close()
+		return err
+	}
+
+	return f.Close()
+}
+
+// lockPath returns the path of the lock file of the state, which is a
+// hidden file next to it.
+func (s *LocalState) lockPath() string {
+	dir, base := filepath.Split(s.Path)
+	return filepath.Join(dir, "."+base+".lock")
+}
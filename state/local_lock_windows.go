@@ -0,0 +1,53 @@
+// +build windows
+
+package state
+
+import (
+	"os"
+	"syscall"
+	"unsafe"
+)
+
+var (
+	kernel32     = syscall.MustLoadDLL("kernel32.dll")
+	lockFileEx   = kernel32.MustFindProc("LockFileEx")
+	unlockFileEx = kernel32.MustFindProc("UnlockFileEx")
+)
+
+const (
+	lockfileFailImmediately = 0x1
+	lockfileExclusiveLock   = 0x2
+)
+
+// lockRange returns the range of the file that is locked. Windows locks
+// are mandatory, so a byte far past the lock information is locked to
+// keep the information readable by others.
+func lockRange() *syscall.Overlapped {
+	return &syscall.Overlapped{Offset: ^uint32(0)}
+}
+
+// lockFile takes an exclusive lock on the file, without waiting if it is
+// already locked.
+func lockFile(f *os.File) error {
+	r, _, err := lockFileEx.Call(
+		f.Fd(),
+		lockfileExclusiveLock|lockfileFailImmediately,
+		0, 1, 0,
+		uintptr(unsafe.Pointer(lockRange())))
+	if r == 0 {
+		return err
+	}
+
+	return nil
+}
+
+// unlockFile releases the lock taken by lockFile.
+func unlockFile(f *os.File) error {
+	r, _, err := unlockFileEx.Call(
+		f.Fd(), 0, 1, 0, uintptr(unsafe.Pointer(lockRange())))
+	if r == 0 {
+		return err
+	}
+
+	return nil
+}
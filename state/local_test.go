@@ -3,6 +3,7 @@ package state
 import (
 	"io/ioutil"
 	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/hashicorp/terraform/terraform"
@@ -40,11 +41,112 @@ func TestLocalState_nonExist(t *testing.T) {
 	}
 }
 
+func TestLocalState_writeAtomic(t *testing.T) {
+	dir, err := ioutil.TempDir("", "tf")
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	defer os.RemoveAll(dir)
+
+	// The state is written through a symlink, with its permissions kept
+	path := filepath.Join(dir, "terraform.tfstate")
+	if err := ioutil.WriteFile(path, []byte("{}"), 0600); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	link := filepath.Join(dir, "link.tfstate")
+	if err := os.Symlink(path, link); err != nil {
+		t.Skipf("symlinks not supported: %s", err)
+	}
+
+	ls := &LocalState{Path: link}
+	if err := ls.WriteState(TestStateInitial()); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	fi, err := os.Lstat(link)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if fi.Mode()&os.ModeSymlink == 0 {
+		t.Fatalf("symlink was replaced: %s", fi.Mode())
+	}
+	fi, err = os.Stat(path)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if fi.Mode().Perm() != 0600 {
+		t.Fatalf("bad mode: %s", fi.Mode())
+	}
+
+	// No temporary files are left behind
+	entries, err := ioutil.ReadDir(dir)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if len(entries) != 2 {
+		t.Fatalf("bad: %d files", len(entries))
+	}
+
+	if err := ls.RefreshState(); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if !ls.State().Equal(TestStateInitial()) {
+		t.Fatalf("bad: %s", ls.State())
+	}
+}
+
+func TestLocalState_lock(t *testing.T) {
+	dir, err := ioutil.TempDir("", "tf")
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "terraform.tfstate")
+	a := &LocalState{Path: path}
+	b := &LocalState{Path: path}
+
+	if err := a.Lock("apply"); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	// The other state can't take the lock, and is told who holds it
+	err = b.Lock("plan")
+	lockErr, ok := err.(*LockError)
+	if !ok {
+		t.Fatalf("bad: %#v", err)
+	}
+	if lockErr.Info == nil || lockErr.Info.Operation != "apply" {
+		t.Fatalf("bad: %#v", lockErr.Info)
+	}
+
+	// The lock holder can still write the state
+	if err := a.WriteState(TestStateInitial()); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	if err := a.Unlock(); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if err := b.Lock("plan"); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if err := b.Unlock(); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	// The lock file is removed on unlock
+	if _, err := os.Stat(a.lockPath()); !os.IsNotExist(err) {
+		t.Fatalf("lock file left behind: %s", err)
+	}
+}
+
 func TestLocalState_impl(t *testing.T) {
 	var _ StateReader = new(LocalState)
 	var _ StateWriter = new(LocalState)
 	var _ StatePersister = new(LocalState)
 	var _ StateRefresher = new(LocalState)
+	var _ Locker = new(LocalState)
 }
 
 func testLocalState(t *testing.T) *LocalState {
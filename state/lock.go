@@ -0,0 +1,94 @@
+package state
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"os/user"
+	"time"
+)
+
+// Locker is implemented by states that can be locked, so that only one
+// Terraform run at a time can modify the state.
+type Locker interface {
+	// Lock locks the state for the given operation, such as "apply".
+	// If the state is already locked, a *LockError is returned.
+	Lock(operation string) error
+
+	// Unlock unlocks the state.
+	Unlock() error
+}
+
+// ErrForceUnlockUnsupported is returned by ForceUnlock when the storage of
+// the state doesn't support locking.
+var ErrForceUnlockUnsupported = errors.New(
+	"the state storage doesn't support locking, so there is no lock to remove")
+
+// ForceUnlocker is implemented by states whose lock can be removed by
+// anyone knowing the ID of the lock, such as the lock left by a crashed
+// run.
+type ForceUnlocker interface {
+	// ForceUnlock removes the lock with the given ID, even if it was
+	// taken by someone else.
+	ForceUnlock(id string) error
+}
+
+// LockInfo is the information that is stored with a lock so that anyone
+// finding the state locked knows who locked it and why.
+type LockInfo struct {
+	ID        string
+	Operation string
+	Who       string
+	Created   time.Time
+}
+
+// NewLockInfo returns the LockInfo for a new lock for the given
+// operation, with a random ID.
+func NewLockInfo(operation string) *LockInfo {
+	var id [16]byte
+	if _, err := rand.Read(id[:]); err != nil {
+		panic(fmt.Sprintf("failed to generate lock ID: %s", err))
+	}
+
+	who := "unknown"
+	if u, err := user.Current(); err == nil {
+		who = u.Username
+	}
+	if host, err := os.Hostname(); err == nil {
+		who = fmt.Sprintf("%s@%s", who, host)
+	}
+
+	return &LockInfo{
+		ID:        hex.EncodeToString(id[:]),
+		Operation: operation,
+		Who:       who,
+		Created:   time.Now().UTC(),
+	}
+}
+
+func (i *LockInfo) String() string {
+	return fmt.Sprintf(
+		"ID: %s\nOperation: %s\nWho: %s\nCreated: %s",
+		i.ID, i.Operation, i.Who, i.Created)
+}
+
+// LockError is the error returned when the state is already locked.
+// Info is the information of the existing lock, if it is known.
+type LockError struct {
+	Info *LockInfo
+	Err  error
+}
+
+func (e *LockError) Error() string {
+	msg := "state is locked"
+	if e.Err != nil {
+		msg = fmt.Sprintf("%s: %s", msg, e.Err)
+	}
+	if e.Info != nil {
+		msg = fmt.Sprintf("%s\n\nLock Info:\n%s", msg, e.Info)
+	}
+
+	return msg
+}
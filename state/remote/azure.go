@@ -0,0 +1,475 @@
+package remote
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/md5"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform/state"
+)
+
+const (
+	// azureVersion is the version of the Blob service REST API used.
+	azureVersion = "2015-04-05"
+
+	// azureLockMeta is the metadata key the lock information is stored
+	// under while the state blob is leased.
+	azureLockMeta = "x-ms-meta-terraformlock"
+)
+
+func azureFactory(conf map[string]string) (Client, error) {
+	account, ok := conf["storage_account_name"]
+	if !ok {
+		return nil, fmt.Errorf("missing 'storage_account_name' configuration")
+	}
+
+	container, ok := conf["container_name"]
+	if !ok {
+		return nil, fmt.Errorf("missing 'container_name' configuration")
+	}
+
+	key, ok := conf["key"]
+	if !ok {
+		return nil, fmt.Errorf("missing 'key' configuration")
+	}
+
+	client := &AzureClient{
+		AccountName: account,
+		Container:   container,
+		Key:         key,
+		SASToken:    strings.TrimPrefix(azureConf(conf, "sas_token", "ARM_SAS_TOKEN"), "?"),
+	}
+
+	if v := azureConf(conf, "access_key", "ARM_ACCESS_KEY"); v != "" {
+		accessKey, err := base64.StdEncoding.DecodeString(v)
+		if err != nil {
+			return nil, fmt.Errorf("invalid 'access_key': %s", err)
+		}
+
+		client.AccessKey = accessKey
+	}
+	if client.AccessKey == nil && client.SASToken == "" {
+		return nil, fmt.Errorf(
+			"either 'access_key' or 'sas_token' must be configured, or the " +
+				"ARM_ACCESS_KEY or ARM_SAS_TOKEN environment variable set")
+	}
+
+	endpoint := conf["endpoint"]
+	if endpoint == "" {
+		endpoint = fmt.Sprintf("https://%s.blob.core.windows.net", account)
+	}
+
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse 'endpoint': %s", err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return nil, fmt.Errorf("'endpoint' must be HTTP or HTTPS")
+	}
+	client.Endpoint = u
+
+	return client, nil
+}
+
+// azureConf returns the configuration value for the given key, falling
+// back to the given environment variable.
+func azureConf(conf map[string]string, key, env string) string {
+	if v, ok := conf[key]; ok && v != "" {
+		return v
+	}
+
+	return os.Getenv(env)
+}
+
+// AzureClient stores the state as a block blob in an Azure storage
+// account container. Requests are authorized with either the account
+// access key or a shared access signature (SAS) token.
+//
+// Locking is done by acquiring an infinite lease on the blob, using the
+// lock ID as the lease ID. The lock information is stored in the blob
+// metadata so that others can see who holds the lease.
+type AzureClient struct {
+	AccountName string
+	Container   string
+	Key         string
+
+	AccessKey []byte
+	SASToken  string
+
+	// Endpoint is the blob service endpoint. It defaults to the public
+	// Azure endpoint for the account.
+	Endpoint *url.URL
+
+	// HTTPClient is the HTTP client to use. If nil, http.DefaultClient
+	// is used.
+	HTTPClient *http.Client
+
+	leaseID      string
+	jsonLockInfo string
+}
+
+func (c *AzureClient) Get() (*Payload, error) {
+	resp, err := c.request("GET", nil, nil, nil)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to get state: %s", err)
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusNotFound:
+		return nil, nil
+	default:
+		return nil, azureError(resp)
+	}
+
+	data, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to read remote state: %s", err)
+	}
+
+	// If there was no data, then return nil. This is also the case for
+	// the empty blob created to lock a state that doesn't exist yet.
+	if len(data) == 0 {
+		return nil, nil
+	}
+
+	hash := md5.Sum(data)
+	return &Payload{
+		Data: data,
+		MD5:  hash[:],
+	}, nil
+}
+
+func (c *AzureClient) Put(data []byte) error {
+	hash := md5.Sum(data)
+	headers := map[string]string{
+		"x-ms-blob-type": "BlockBlob",
+		"Content-Type":   "application/json",
+		"Content-MD5":    base64.StdEncoding.EncodeToString(hash[:]),
+	}
+
+	// Writing the blob replaces its metadata, so keep our lock info
+	if c.leaseID != "" {
+		headers["x-ms-lease-id"] = c.leaseID
+		headers[azureLockMeta] = c.jsonLockInfo
+	}
+
+	resp, err := c.request("PUT", nil, headers, data)
+	if err != nil {
+		return fmt.Errorf("Failed to upload state: %s", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusCreated {
+		return fmt.Errorf("Failed to upload state: %s", azureError(resp))
+	}
+
+	return nil
+}
+
+func (c *AzureClient) Delete() error {
+	headers := make(map[string]string)
+	if c.leaseID != "" {
+		headers["x-ms-lease-id"] = c.leaseID
+	}
+
+	resp, err := c.request("DELETE", nil, headers, nil)
+	if err != nil {
+		return fmt.Errorf("Failed to delete state: %s", err)
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusAccepted, http.StatusNotFound:
+		// Deleting the blob also ends the lease
+		c.leaseID = ""
+		c.jsonLockInfo = ""
+		return nil
+	default:
+		return fmt.Errorf("Failed to delete state: %s", azureError(resp))
+	}
+}
+
+func (c *AzureClient) Lock(info *state.LockInfo) error {
+	raw, err := json.Marshal(info)
+	if err != nil {
+		return err
+	}
+	jsonLockInfo := base64.StdEncoding.EncodeToString(raw)
+
+	// A lease can only be taken on an existing blob, so create an empty
+	// one if there is no state yet. This fails harmlessly if it exists.
+	resp, err := c.request("PUT", nil, map[string]string{
+		"x-ms-blob-type": "BlockBlob",
+		"If-None-Match":  "*",
+	}, nil)
+	if err != nil {
+		return fmt.Errorf("Failed to lock state: %s", err)
+	}
+	resp.Body.Close()
+
+	leaseID := azureLeaseID(info.ID)
+	resp, err = c.request("PUT", url.Values{"comp": []string{"lease"}},
+		map[string]string{
+			"x-ms-lease-action":      "acquire",
+			"x-ms-lease-duration":    "-1",
+			"x-ms-proposed-lease-id": leaseID,
+		}, nil)
+	if err != nil {
+		return fmt.Errorf("Failed to lock state: %s", err)
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusCreated:
+	case http.StatusConflict:
+		lockErr := &state.LockError{
+			Err: fmt.Errorf("Azure blob %s/%s is already leased", c.Container, c.Key),
+		}
+		lockErr.Info, _ = c.lockInfo()
+		return lockErr
+	default:
+		return fmt.Errorf("Failed to lock state: %s", azureError(resp))
+	}
+
+	c.leaseID = leaseID
+	c.jsonLockInfo = jsonLockInfo
+
+	// Record who holds the lease
+	if err := c.setMetadata(jsonLockInfo); err != nil {
+		c.releaseLease()
+		return fmt.Errorf("Failed to lock state: %s", err)
+	}
+
+	return nil
+}
+
+func (c *AzureClient) Unlock() error {
+	if c.leaseID == "" {
+		return nil
+	}
+
+	if err := c.setMetadata(""); err != nil {
+		return fmt.Errorf("Failed to unlock state: %s", err)
+	}
+	if err := c.releaseLease(); err != nil {
+		return fmt.Errorf("Failed to unlock state: %s", err)
+	}
+
+	return nil
+}
+
+// ForceUnlock releases the lease of the lock with the given ID. The lease
+// ID is derived from the lock ID, so it can be released by anyone knowing
+// the lock ID.
+func (c *AzureClient) ForceUnlock(id string) error {
+	if info, err := c.lockInfo(); err == nil && info.ID != id {
+		return fmt.Errorf(
+			"Failed to unlock state: the lock ID doesn't match the lock "+
+				"of the state\n\nLock Info:\n%s", info)
+	}
+
+	c.leaseID = azureLeaseID(id)
+	if err := c.Unlock(); err != nil {
+		c.leaseID = ""
+		return err
+	}
+
+	return nil
+}
+
+func (c *AzureClient) releaseLease() error {
+	resp, err := c.request("PUT", url.Values{"comp": []string{"lease"}},
+		map[string]string{
+			"x-ms-lease-action": "release",
+			"x-ms-lease-id":     c.leaseID,
+		}, nil)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return azureError(resp)
+	}
+
+	c.leaseID = ""
+	c.jsonLockInfo = ""
+	return nil
+}
+
+// lockInfo reads the lock information stored in the blob metadata.
+func (c *AzureClient) lockInfo() (*state.LockInfo, error) {
+	resp, err := c.request("HEAD", url.Values{"comp": []string{"metadata"}}, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("HTTP error: %d", resp.StatusCode)
+	}
+
+	raw, err := base64.StdEncoding.DecodeString(resp.Header.Get(azureLockMeta))
+	if err != nil {
+		return nil, err
+	}
+
+	info := new(state.LockInfo)
+	if err := json.Unmarshal(raw, info); err != nil {
+		return nil, err
+	}
+
+	return info, nil
+}
+
+// setMetadata replaces the blob metadata with the given lock information,
+// or clears it if jsonLockInfo is empty.
+func (c *AzureClient) setMetadata(jsonLockInfo string) error {
+	headers := map[string]string{"x-ms-lease-id": c.leaseID}
+	if jsonLockInfo != "" {
+		headers[azureLockMeta] = jsonLockInfo
+	}
+
+	resp, err := c.request(
+		"PUT", url.Values{"comp": []string{"metadata"}}, headers, nil)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return azureError(resp)
+	}
+
+	return nil
+}
+
+// request makes an authorized request to the state blob.
+func (c *AzureClient) request(
+	method string, query url.Values,
+	headers map[string]string, data []byte) (*http.Response, error) {
+	u := *c.Endpoint
+	u.Path = fmt.Sprintf("%s/%s/%s", strings.TrimSuffix(u.Path, "/"), c.Container, c.Key)
+	u.RawQuery = query.Encode()
+	if c.AccessKey == nil {
+		if u.RawQuery != "" {
+			u.RawQuery += "&"
+		}
+		u.RawQuery += c.SASToken
+	}
+
+	var body io.Reader
+	if data != nil {
+		body = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequest(method, u.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+	req.Header.Set("x-ms-date", time.Now().UTC().Format(http.TimeFormat))
+	req.Header.Set("x-ms-version", azureVersion)
+
+	if c.AccessKey != nil {
+		req.Header.Set("Authorization", fmt.Sprintf(
+			"SharedKey %s:%s", c.AccountName, c.signature(req, query)))
+	}
+
+	client := c.HTTPClient
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	return client.Do(req)
+}
+
+// signature returns the Shared Key signature of the request.
+func (c *AzureClient) signature(req *http.Request, query url.Values) string {
+	contentLength := ""
+	if req.ContentLength > 0 {
+		contentLength = fmt.Sprintf("%d", req.ContentLength)
+	}
+
+	parts := []string{
+		req.Method,
+		req.Header.Get("Content-Encoding"),
+		req.Header.Get("Content-Language"),
+		contentLength,
+		req.Header.Get("Content-MD5"),
+		req.Header.Get("Content-Type"),
+		"",
+		req.Header.Get("If-Modified-Since"),
+		req.Header.Get("If-Match"),
+		req.Header.Get("If-None-Match"),
+		req.Header.Get("If-Unmodified-Since"),
+		req.Header.Get("Range"),
+	}
+
+	// Canonicalized headers are the x-ms- headers, lower cased and sorted
+	var names []string
+	for k, _ := range req.Header {
+		if k := strings.ToLower(k); strings.HasPrefix(k, "x-ms-") {
+			names = append(names, k)
+		}
+	}
+	sort.Strings(names)
+	for _, k := range names {
+		parts = append(parts, fmt.Sprintf("%s:%s", k, req.Header.Get(k)))
+	}
+
+	// Canonicalized resource is the account, path and sorted query
+	resource := fmt.Sprintf("/%s%s", c.AccountName, req.URL.Path)
+	var params []string
+	for k, _ := range query {
+		params = append(params, k)
+	}
+	sort.Strings(params)
+	for _, k := range params {
+		vs := append([]string(nil), query[k]...)
+		sort.Strings(vs)
+		resource += fmt.Sprintf("\n%s:%s", strings.ToLower(k), strings.Join(vs, ","))
+	}
+	parts = append(parts, resource)
+
+	mac := hmac.New(sha256.New, c.AccessKey)
+	mac.Write([]byte(strings.Join(parts, "\n")))
+	return base64.StdEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// azureLeaseID formats a lock ID as the GUID that Azure requires for
+// lease IDs.
+func azureLeaseID(id string) string {
+	if len(id) != 32 {
+		hash := md5.Sum([]byte(id))
+		id = fmt.Sprintf("%x", hash[:])
+	}
+
+	return fmt.Sprintf("%s-%s-%s-%s-%s", id[0:8], id[8:12], id[12:16], id[16:20], id[20:])
+}
+
+// azureError returns an error with the error code from a response.
+func azureError(resp *http.Response) error {
+	if code := resp.Header.Get("x-ms-error-code"); code != "" {
+		return fmt.Errorf("HTTP error %d: %s", resp.StatusCode, code)
+	}
+
+	return fmt.Errorf("HTTP error: %d", resp.StatusCode)
+}
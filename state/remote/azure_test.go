@@ -0,0 +1,311 @@
+package remote
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/hashicorp/terraform/state"
+)
+
+func TestAzureClient_impl(t *testing.T) {
+	var _ Client = new(AzureClient)
+	var _ ClientLocker = new(AzureClient)
+}
+
+func TestAzureFactory(t *testing.T) {
+	cases := []struct {
+		Config map[string]string
+		Err    bool
+	}{
+		{
+			map[string]string{
+				"storage_account_name": "account",
+				"container_name":       "tfstate",
+				"key":                  "prod.tfstate",
+				"access_key":           "c2VjcmV0",
+			},
+			false,
+		},
+		{
+			map[string]string{
+				"storage_account_name": "account",
+				"container_name":       "tfstate",
+				"key":                  "prod.tfstate",
+				"sas_token":            "?sv=2015-04-05&sig=abc",
+				"endpoint":             "http://127.0.0.1:10000/account",
+			},
+			false,
+		},
+		{
+			map[string]string{
+				"container_name": "tfstate",
+				"key":            "prod.tfstate",
+				"access_key":     "c2VjcmV0",
+			},
+			true,
+		},
+		{
+			map[string]string{
+				"storage_account_name": "account",
+				"container_name":       "tfstate",
+				"key":                  "prod.tfstate",
+				"access_key":           "not base64!",
+			},
+			true,
+		},
+		{
+			map[string]string{
+				"storage_account_name": "account",
+				"container_name":       "tfstate",
+				"key":                  "prod.tfstate",
+				"access_key":           "c2VjcmV0",
+				"endpoint":             "127.0.0.1:10000",
+			},
+			true,
+		},
+	}
+
+	for i, tc := range cases {
+		_, err := azureFactory(tc.Config)
+		if (err != nil) != tc.Err {
+			t.Fatalf("%d: bad: %s", i, err)
+		}
+	}
+}
+
+func TestAzureClient(t *testing.T) {
+	ts := httptest.NewServer(newTestAzureHandler())
+	defer ts.Close()
+
+	client, err := azureFactory(map[string]string{
+		"storage_account_name": "account",
+		"container_name":       "tfstate",
+		"key":                  "prod.tfstate",
+		"access_key":           "c2VjcmV0",
+		"endpoint":             ts.URL,
+	})
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	testClient(t, client)
+}
+
+func TestAzureClient_sas(t *testing.T) {
+	ts := httptest.NewServer(newTestAzureHandler())
+	defer ts.Close()
+
+	client, err := azureFactory(map[string]string{
+		"storage_account_name": "account",
+		"container_name":       "tfstate",
+		"key":                  "prod.tfstate",
+		"sas_token":            "?sv=2015-04-05&sig=abc",
+		"endpoint":             ts.URL,
+	})
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	testClient(t, client)
+}
+
+func TestAzureClient_lock(t *testing.T) {
+	ts := httptest.NewServer(newTestAzureHandler())
+	defer ts.Close()
+
+	conf := map[string]string{
+		"storage_account_name": "account",
+		"container_name":       "tfstate",
+		"key":                  "prod.tfstate",
+		"access_key":           "c2VjcmV0",
+		"endpoint":             ts.URL,
+	}
+
+	a, err := azureFactory(conf)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	b, err := azureFactory(conf)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	testClientLocks(t, a, b)
+
+	// A leased blob can't be written without the lease
+	if err := a.(ClientLocker).Lock(state.NewLockInfo("apply")); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if err := b.Put([]byte("other")); err == nil {
+		t.Fatal("should error")
+	}
+}
+
+func TestAzureClient_forceUnlock(t *testing.T) {
+	ts := httptest.NewServer(newTestAzureHandler())
+	defer ts.Close()
+
+	conf := map[string]string{
+		"storage_account_name": "account",
+		"container_name":       "tfstate",
+		"key":                  "prod.tfstate",
+		"access_key":           "c2VjcmV0",
+		"endpoint":             ts.URL,
+	}
+
+	a, err := azureFactory(conf)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	b, err := azureFactory(conf)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	testClientForceUnlock(t, a, b)
+}
+
+func TestAzureLeaseID(t *testing.T) {
+	actual := azureLeaseID("0123456789abcdef0123456789abcdef")
+	expected := "01234567-89ab-cdef-0123-456789abcdef"
+	if actual != expected {
+		t.Fatalf("bad: %s", actual)
+	}
+
+	if len(azureLeaseID("short")) != len(expected) {
+		t.Fatalf("bad: %s", azureLeaseID("short"))
+	}
+}
+
+// newTestAzureHandler returns a handler that fakes a single blob of the
+// Azure Blob service, including leases and metadata.
+func newTestAzureHandler() http.Handler {
+	var lock sync.Mutex
+	var data []byte
+	var exists bool
+	var meta, lease string
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		lock.Lock()
+		defer lock.Unlock()
+
+		auth := r.Header.Get("Authorization")
+		if !strings.HasPrefix(auth, "SharedKey account:") &&
+			r.URL.Query().Get("sig") == "" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		if r.Header.Get("x-ms-version") == "" || r.Header.Get("x-ms-date") == "" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		if r.URL.Path != "/tfstate/prod.tfstate" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		leased := func() bool {
+			if lease != "" && r.Header.Get("x-ms-lease-id") != lease {
+				w.Header().Set("x-ms-error-code", "LeaseIdMissing")
+				w.WriteHeader(http.StatusPreconditionFailed)
+				return false
+			}
+
+			return true
+		}
+
+		comp := r.URL.Query().Get("comp")
+		switch {
+		case r.Method == "GET" && comp == "":
+			if !exists {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+
+			w.Write(data)
+		case r.Method == "HEAD" && comp == "metadata":
+			if !exists {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+
+			w.Header().Set(azureLockMeta, meta)
+		case r.Method == "PUT" && comp == "lease":
+			if !exists {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+
+			switch r.Header.Get("x-ms-lease-action") {
+			case "acquire":
+				if lease != "" {
+					w.Header().Set("x-ms-error-code", "LeaseAlreadyPresent")
+					w.WriteHeader(http.StatusConflict)
+					return
+				}
+
+				lease = r.Header.Get("x-ms-proposed-lease-id")
+				w.WriteHeader(http.StatusCreated)
+			case "release":
+				if r.Header.Get("x-ms-lease-id") != lease {
+					w.WriteHeader(http.StatusConflict)
+					return
+				}
+
+				lease = ""
+			default:
+				w.WriteHeader(http.StatusBadRequest)
+			}
+		case r.Method == "PUT" && comp == "metadata":
+			if !exists {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			if !leased() {
+				return
+			}
+
+			meta = r.Header.Get(azureLockMeta)
+		case r.Method == "PUT" && comp == "":
+			if r.Header.Get("If-None-Match") == "*" && exists {
+				w.Header().Set("x-ms-error-code", "BlobAlreadyExists")
+				w.WriteHeader(http.StatusConflict)
+				return
+			}
+			if !leased() {
+				return
+			}
+
+			body, err := ioutil.ReadAll(r.Body)
+			if err != nil {
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+
+			data = body
+			meta = r.Header.Get(azureLockMeta)
+			exists = true
+			w.WriteHeader(http.StatusCreated)
+		case r.Method == "DELETE" && comp == "":
+			if !exists {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			if !leased() {
+				return
+			}
+
+			data = nil
+			meta = ""
+			lease = ""
+			exists = false
+			w.WriteHeader(http.StatusAccepted)
+		default:
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	})
+}
@@ -2,9 +2,11 @@ package remote
 
 import (
 	"crypto/md5"
+	"encoding/json"
 	"fmt"
 
 	consulapi "github.com/hashicorp/consul/api"
+	"github.com/hashicorp/terraform/state"
 )
 
 func consulFactory(conf map[string]string) (Client, error) {
@@ -36,9 +38,16 @@ func consulFactory(conf map[string]string) (Client, error) {
 }
 
 // ConsulClient is a remote client that stores data in Consul.
+//
+// Locking is done by acquiring the key of the path with ".lock" appended
+// with a Consul session, storing the lock information as its value. The
+// session deletes the key when it is destroyed, which happens on unlock
+// or when the Consul agent that created it fails.
 type ConsulClient struct {
 	Client *consulapi.Client
 	Path   string
+
+	sessionID string
 }
 
 func (c *ConsulClient) Get() (*Payload, error) {
@@ -71,3 +80,101 @@ func (c *ConsulClient) Delete() error {
 	_, err := kv.Delete(c.Path, nil)
 	return err
 }
+
+func (c *ConsulClient) Lock(info *state.LockInfo) error {
+	value, err := json.Marshal(info)
+	if err != nil {
+		return err
+	}
+
+	session, _, err := c.Client.Session().Create(&consulapi.SessionEntry{
+		Name:     fmt.Sprintf("terraform lock of %s", c.Path),
+		Behavior: consulapi.SessionBehaviorDelete,
+	}, nil)
+	if err != nil {
+		return fmt.Errorf("Failed to lock state: %s", err)
+	}
+
+	acquired, _, err := c.Client.KV().Acquire(&consulapi.KVPair{
+		Key:     c.lockPath(),
+		Value:   value,
+		Session: session,
+	}, nil)
+	if err != nil || !acquired {
+		c.Client.Session().Destroy(session, nil)
+	}
+	if err != nil {
+		return fmt.Errorf("Failed to lock state: %s", err)
+	}
+	if !acquired {
+		lockErr := &state.LockError{
+			Err: fmt.Errorf("Consul key %s is held by another session", c.lockPath()),
+		}
+		lockErr.Info, _ = c.lockInfo()
+		return lockErr
+	}
+
+	c.sessionID = session
+	return nil
+}
+
+func (c *ConsulClient) Unlock() error {
+	if c.sessionID == "" {
+		return nil
+	}
+
+	if _, err := c.Client.Session().Destroy(c.sessionID, nil); err != nil {
+		return fmt.Errorf("Failed to unlock state: %s", err)
+	}
+
+	c.sessionID = ""
+	return nil
+}
+
+// ForceUnlock destroys the session holding the lock with the given ID,
+// which deletes the lock.
+func (c *ConsulClient) ForceUnlock(id string) error {
+	pair, _, err := c.Client.KV().Get(c.lockPath(), nil)
+	if err != nil {
+		return fmt.Errorf("Failed to unlock state: %s", err)
+	}
+	if pair == nil || pair.Session == "" {
+		return fmt.Errorf("Failed to unlock state: the state isn't locked")
+	}
+
+	info := new(state.LockInfo)
+	if err := json.Unmarshal(pair.Value, info); err == nil && info.ID != id {
+		return fmt.Errorf(
+			"Failed to unlock state: the lock ID doesn't match the lock "+
+				"of the state\n\nLock Info:\n%s", info)
+	}
+
+	if _, err := c.Client.Session().Destroy(pair.Session, nil); err != nil {
+		return fmt.Errorf("Failed to unlock state: %s", err)
+	}
+
+	return nil
+}
+
+// lockInfo reads the lock information stored in the lock key.
+func (c *ConsulClient) lockInfo() (*state.LockInfo, error) {
+	pair, _, err := c.Client.KV().Get(c.lockPath(), nil)
+	if err != nil {
+		return nil, err
+	}
+	if pair == nil {
+		return nil, fmt.Errorf("lock key %s not found", c.lockPath())
+	}
+
+	info := new(state.LockInfo)
+	if err := json.Unmarshal(pair.Value, info); err != nil {
+		return nil, err
+	}
+
+	return info, nil
+}
+
+// lockPath returns the key of the lock of the state.
+func (c *ConsulClient) lockPath() string {
+	return c.Path + ".lock"
+}
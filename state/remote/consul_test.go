@@ -9,6 +9,8 @@ import (
 
 func TestConsulClient_impl(t *testing.T) {
 	var _ Client = new(ConsulClient)
+	var _ ClientLocker = new(ConsulClient)
+	var _ ClientForceUnlocker = new(ConsulClient)
 }
 
 func TestConsulClient(t *testing.T) {
@@ -26,3 +28,25 @@ func TestConsulClient(t *testing.T) {
 
 	testClient(t, client)
 }
+
+func TestConsulClient_locks(t *testing.T) {
+	if _, err := http.Get("http://google.com"); err != nil {
+		t.Skipf("skipping, internet seems to not be available: %s", err)
+	}
+
+	conf := map[string]string{
+		"address": "demo.consul.io:80",
+		"path":    fmt.Sprintf("tf-unit/%s", time.Now().String()),
+	}
+	a, err := consulFactory(conf)
+	if err != nil {
+		t.Fatalf("bad: %s", err)
+	}
+	b, err := consulFactory(conf)
+	if err != nil {
+		t.Fatalf("bad: %s", err)
+	}
+
+	testClientLocks(t, a, b)
+	testClientForceUnlock(t, a, b)
+}
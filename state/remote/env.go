@@ -0,0 +1,55 @@
+package remote
+
+import (
+	"fmt"
+	"path"
+	"path/filepath"
+)
+
+// envKeys are the configuration keys that name the state within each
+// backend. They are changed to keep a separate state per environment.
+var envKeys = map[string]string{
+	"azure":  "key",
+	"consul": "path",
+	"etcd":   "path",
+	"gcs":    "prefix",
+	"s3":     "key",
+	"swift":  "object",
+	"_local": "path",
+}
+
+// EnvConfig returns the configuration for the client of the given type
+// that stores the state of the named environment, given the configuration
+// of the default environment.
+//
+// The states of other environments are stored next to the default state,
+// under an "env:/NAME/" prefix.
+func EnvConfig(t string, conf map[string]string, env string) (map[string]string, error) {
+	key, ok := envKeys[t]
+	if !ok {
+		return nil, fmt.Errorf(
+			"remote state backend %q doesn't support environments", t)
+	}
+
+	result := make(map[string]string, len(conf))
+	for k, v := range conf {
+		result[k] = v
+	}
+
+	value := result[key]
+	switch t {
+	case "swift":
+		if value == "" {
+			value = swiftDefaultObject
+		}
+	case "_local":
+		// Local paths keep the environments in a directory alongside
+		// the state, since colons aren't valid in all file names.
+		result[key] = filepath.Join(
+			filepath.Dir(value), "terraform.tfstate.d", env, filepath.Base(value))
+		return result, nil
+	}
+
+	result[key] = path.Join("env:", env, value)
+	return result, nil
+}
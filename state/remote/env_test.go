@@ -0,0 +1,74 @@
+package remote
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestEnvConfig(t *testing.T) {
+	cases := []struct {
+		Type   string
+		Config map[string]string
+		Result map[string]string
+		Err    bool
+	}{
+		{
+			"s3",
+			map[string]string{"bucket": "tf", "key": "network/terraform.tfstate"},
+			map[string]string{"bucket": "tf", "key": "env:/dev/network/terraform.tfstate"},
+			false,
+		},
+		{
+			"consul",
+			map[string]string{"path": "tf/state"},
+			map[string]string{"path": "env:/dev/tf/state"},
+			false,
+		},
+		{
+			"gcs",
+			map[string]string{"bucket": "tf"},
+			map[string]string{"bucket": "tf", "prefix": "env:/dev"},
+			false,
+		},
+		{
+			"swift",
+			map[string]string{"container": "tf"},
+			map[string]string{"container": "tf", "object": "env:/dev/terraform.tfstate"},
+			false,
+		},
+		{
+			"_local",
+			map[string]string{"path": filepath.Join("foo", "state.tfstate")},
+			map[string]string{
+				"path": filepath.Join("foo", "terraform.tfstate.d", "dev", "state.tfstate"),
+			},
+			false,
+		},
+		{
+			"atlas",
+			map[string]string{"name": "hashicorp/tf"},
+			nil,
+			true,
+		},
+	}
+
+	for i, tc := range cases {
+		actual, err := EnvConfig(tc.Type, tc.Config, "dev")
+		if (err != nil) != tc.Err {
+			t.Fatalf("%d: bad: %s", i, err)
+		}
+		if !reflect.DeepEqual(actual, tc.Result) {
+			t.Fatalf("%d: bad: %#v", i, actual)
+		}
+	}
+
+	// The original configuration is untouched
+	conf := map[string]string{"path": "tf/state"}
+	if _, err := EnvConfig("consul", conf, "dev"); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if conf["path"] != "tf/state" {
+		t.Fatalf("bad: %#v", conf)
+	}
+}
@@ -0,0 +1,534 @@
+package remote
+
+import (
+	"bytes"
+	"crypto/md5"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+func etcdFactory(conf map[string]string) (Client, error) {
+	path, ok := conf["path"]
+	if !ok {
+		return nil, fmt.Errorf("missing 'path' configuration")
+	}
+
+	endpoints, ok := conf["endpoints"]
+	if !ok || endpoints == "" {
+		return nil, fmt.Errorf("missing 'endpoints' configuration")
+	}
+
+	client := &EtcdClient{
+		Path:     path,
+		Username: conf["username"],
+		Password: conf["password"],
+	}
+
+	for _, e := range strings.Split(endpoints, ",") {
+		e = strings.TrimSpace(e)
+		u, err := url.Parse(e)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse etcd endpoint %s: %s", e, err)
+		}
+		if u.Scheme != "http" && u.Scheme != "https" {
+			return nil, fmt.Errorf("etcd endpoint %s must be HTTP or HTTPS", e)
+		}
+
+		client.Endpoints = append(client.Endpoints, strings.TrimRight(e, "/"))
+	}
+
+	switch api := conf["api"]; api {
+	case "", "v2":
+		client.API = 2
+	case "v3":
+		client.API = 3
+	default:
+		return nil, fmt.Errorf("unknown etcd api %q, must be v2 or v3", api)
+	}
+
+	tlsConfig, err := etcdTLSConfig(conf)
+	if err != nil {
+		return nil, err
+	}
+	if tlsConfig != nil {
+		client.HTTPClient = &http.Client{
+			Transport: &http.Transport{TLSClientConfig: tlsConfig},
+		}
+	}
+
+	return client, nil
+}
+
+// etcdTLSConfig builds the TLS configuration for talking to etcd from
+// the cacert_path, cert_path and key_path configuration. If none of them
+// are set, nil is returned.
+func etcdTLSConfig(conf map[string]string) (*tls.Config, error) {
+	caPath := conf["cacert_path"]
+	certPath := conf["cert_path"]
+	keyPath := conf["key_path"]
+	if caPath == "" && certPath == "" && keyPath == "" {
+		return nil, nil
+	}
+
+	result := new(tls.Config)
+	if caPath != "" {
+		ca, err := ioutil.ReadFile(caPath)
+		if err != nil {
+			return nil, fmt.Errorf("error reading 'cacert_path': %s", err)
+		}
+
+		result.RootCAs = x509.NewCertPool()
+		if !result.RootCAs.AppendCertsFromPEM(ca) {
+			return nil, fmt.Errorf(
+				"no certificates found in 'cacert_path' %s", caPath)
+		}
+	}
+
+	if certPath != "" || keyPath != "" {
+		if certPath == "" || keyPath == "" {
+			return nil, fmt.Errorf(
+				"'cert_path' and 'key_path' must be set together")
+		}
+
+		cert, err := tls.LoadX509KeyPair(certPath, keyPath)
+		if err != nil {
+			return nil, fmt.Errorf("error loading client certificate: %s", err)
+		}
+
+		result.Certificates = []tls.Certificate{cert}
+	}
+
+	return result, nil
+}
+
+// EtcdClient is a remote client that stores data in etcd, using either
+// the v2 keys API or the v3 KV API.
+//
+// Writes are compare-and-swap: once the state has been read, it is only
+// written if the key hasn't been modified since. This prevents two
+// Terraform runs from silently overwriting each other's state.
+type EtcdClient struct {
+	Endpoints  []string
+	Path       string
+	API        int
+	Username   string
+	Password   string
+	HTTPClient *http.Client
+
+	// revision is the modification index (v2) or revision (v3) of the
+	// key the last time it was read or written, zero if the key didn't
+	// exist. It is only used if read is true.
+	revision int64
+	read     bool
+
+	// token is the v3 authentication token
+	token string
+}
+
+func (c *EtcdClient) Get() (*Payload, error) {
+	var data []byte
+	var revision int64
+	var err error
+	if c.API == 3 {
+		data, revision, err = c.getV3()
+	} else {
+		data, revision, err = c.getV2()
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	c.revision = revision
+	c.read = true
+	if data == nil {
+		return nil, nil
+	}
+
+	md5 := md5.Sum(data)
+	return &Payload{
+		Data: data,
+		MD5:  md5[:],
+	}, nil
+}
+
+func (c *EtcdClient) Put(data []byte) error {
+	var revision int64
+	var err error
+	if c.API == 3 {
+		revision, err = c.putV3(data)
+	} else {
+		revision, err = c.putV2(data)
+	}
+	if err != nil {
+		return err
+	}
+
+	c.revision = revision
+	c.read = true
+	return nil
+}
+
+func (c *EtcdClient) Delete() error {
+	var err error
+	if c.API == 3 {
+		err = c.deleteV3()
+	} else {
+		err = c.deleteV2()
+	}
+	if err != nil {
+		return err
+	}
+
+	c.revision = 0
+	c.read = true
+	return nil
+}
+
+// etcdV2Response is the response of the v2 keys API.
+type etcdV2Response struct {
+	ErrorCode int    `json:"errorCode"`
+	Message   string `json:"message"`
+	Node      struct {
+		Value         string `json:"value"`
+		ModifiedIndex int64  `json:"modifiedIndex"`
+	} `json:"node"`
+}
+
+const (
+	etcdV2ErrKeyNotFound = 100
+	etcdV2ErrTestFailed  = 101
+	etcdV2ErrNodeExist   = 105
+)
+
+func (c *EtcdClient) getV2() ([]byte, int64, error) {
+	var resp etcdV2Response
+	status, err := c.do("GET", c.v2Path(), nil, "", &resp)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	switch {
+	case status == http.StatusOK:
+		return []byte(resp.Node.Value), resp.Node.ModifiedIndex, nil
+	case resp.ErrorCode == etcdV2ErrKeyNotFound:
+		return nil, 0, nil
+	default:
+		return nil, 0, etcdV2Error(status, &resp)
+	}
+}
+
+func (c *EtcdClient) putV2(data []byte) (int64, error) {
+	form := url.Values{}
+	form.Set("value", string(data))
+	if c.read {
+		if c.revision == 0 {
+			form.Set("prevExist", "false")
+		} else {
+			form.Set("prevIndex", strconv.FormatInt(c.revision, 10))
+		}
+	}
+
+	var resp etcdV2Response
+	status, err := c.do(
+		"PUT", c.v2Path(), bytes.NewReader([]byte(form.Encode())),
+		"application/x-www-form-urlencoded", &resp)
+	if err != nil {
+		return 0, err
+	}
+
+	switch {
+	case status == http.StatusOK || status == http.StatusCreated:
+		return resp.Node.ModifiedIndex, nil
+	case resp.ErrorCode == etcdV2ErrTestFailed ||
+		resp.ErrorCode == etcdV2ErrNodeExist:
+		return 0, c.conflictError()
+	default:
+		return 0, etcdV2Error(status, &resp)
+	}
+}
+
+func (c *EtcdClient) deleteV2() error {
+	var resp etcdV2Response
+	status, err := c.do("DELETE", c.v2Path(), nil, "", &resp)
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case status == http.StatusOK:
+		return nil
+	case resp.ErrorCode == etcdV2ErrKeyNotFound:
+		return nil
+	default:
+		return etcdV2Error(status, &resp)
+	}
+}
+
+func (c *EtcdClient) v2Path() string {
+	return "/v2/keys/" + strings.TrimLeft(c.Path, "/")
+}
+
+func etcdV2Error(status int, resp *etcdV2Response) error {
+	if resp.Message != "" {
+		return fmt.Errorf("etcd error %d: %s", resp.ErrorCode, resp.Message)
+	}
+
+	return fmt.Errorf("unexpected etcd response code %d", status)
+}
+
+// etcdV3KV is a key/value pair of the v3 KV API. The gateway encodes
+// keys and values as base64 and int64 values as strings.
+type etcdV3KV struct {
+	Key         string `json:"key"`
+	Value       string `json:"value"`
+	ModRevision string `json:"mod_revision"`
+}
+
+type etcdV3Header struct {
+	Revision string `json:"revision"`
+}
+
+type etcdV3Error struct {
+	Error   string `json:"error"`
+	Message string `json:"message"`
+}
+
+func (c *EtcdClient) getV3() ([]byte, int64, error) {
+	var resp struct {
+		etcdV3Error
+		Kvs []etcdV3KV `json:"kvs"`
+	}
+	req := map[string]string{"key": c.v3Key()}
+	if err := c.doV3("/v3/kv/range", req, &resp, &resp.etcdV3Error); err != nil {
+		return nil, 0, err
+	}
+	if len(resp.Kvs) == 0 {
+		return nil, 0, nil
+	}
+
+	kv := resp.Kvs[0]
+	data, err := base64.StdEncoding.DecodeString(kv.Value)
+	if err != nil {
+		return nil, 0, fmt.Errorf("error decoding etcd value: %s", err)
+	}
+
+	revision, err := etcdV3Int(kv.ModRevision)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return data, revision, nil
+}
+
+func (c *EtcdClient) putV3(data []byte) (int64, error) {
+	put := map[string]interface{}{
+		"request_put": map[string]string{
+			"key":   c.v3Key(),
+			"value": base64.StdEncoding.EncodeToString(data),
+		},
+	}
+
+	// Only write the state if the key hasn't changed since we've last
+	// seen it. A zero create revision means the key doesn't exist.
+	var compare []interface{}
+	if c.read {
+		if c.revision == 0 {
+			compare = append(compare, map[string]string{
+				"key":             c.v3Key(),
+				"target":          "CREATE",
+				"result":          "EQUAL",
+				"create_revision": "0",
+			})
+		} else {
+			compare = append(compare, map[string]string{
+				"key":          c.v3Key(),
+				"target":       "MOD",
+				"result":       "EQUAL",
+				"mod_revision": strconv.FormatInt(c.revision, 10),
+			})
+		}
+	}
+
+	req := map[string]interface{}{
+		"compare": compare,
+		"success": []interface{}{put},
+	}
+
+	var resp struct {
+		etcdV3Error
+		Header    etcdV3Header `json:"header"`
+		Succeeded bool         `json:"succeeded"`
+	}
+	if err := c.doV3("/v3/kv/txn", req, &resp, &resp.etcdV3Error); err != nil {
+		return 0, err
+	}
+	if !resp.Succeeded {
+		return 0, c.conflictError()
+	}
+
+	return etcdV3Int(resp.Header.Revision)
+}
+
+func (c *EtcdClient) deleteV3() error {
+	var resp struct {
+		etcdV3Error
+	}
+	req := map[string]string{"key": c.v3Key()}
+	return c.doV3("/v3/kv/deleterange", req, &resp, &resp.etcdV3Error)
+}
+
+func (c *EtcdClient) v3Key() string {
+	return base64.StdEncoding.EncodeToString([]byte(c.Path))
+}
+
+// doV3 makes a request to the v3 JSON gateway, authenticating first if
+// a username is configured.
+func (c *EtcdClient) doV3(
+	path string, req interface{}, resp interface{}, respErr *etcdV3Error) error {
+	if c.Username != "" && c.token == "" {
+		if err := c.authenticateV3(); err != nil {
+			return err
+		}
+	}
+
+	body, err := json.Marshal(req)
+	if err != nil {
+		return err
+	}
+
+	status, err := c.do(
+		"POST", path, bytes.NewReader(body), "application/json", resp)
+	if err != nil {
+		return err
+	}
+	if status != http.StatusOK {
+		msg := respErr.Message
+		if msg == "" {
+			msg = respErr.Error
+		}
+		if msg == "" {
+			msg = http.StatusText(status)
+		}
+
+		return fmt.Errorf("etcd error %d: %s", status, msg)
+	}
+
+	return nil
+}
+
+func (c *EtcdClient) authenticateV3() error {
+	body, err := json.Marshal(map[string]string{
+		"name":     c.Username,
+		"password": c.Password,
+	})
+	if err != nil {
+		return err
+	}
+
+	var resp struct {
+		etcdV3Error
+		Token string `json:"token"`
+	}
+	status, err := c.do(
+		"POST", "/v3/auth/authenticate", bytes.NewReader(body),
+		"application/json", &resp)
+	if err != nil {
+		return err
+	}
+	if status != http.StatusOK || resp.Token == "" {
+		return fmt.Errorf("etcd authentication failed: %s", resp.Message)
+	}
+
+	c.token = resp.Token
+	return nil
+}
+
+// do makes a request to the first etcd endpoint that can be reached and
+// decodes the JSON response into resp. The HTTP status code is returned.
+func (c *EtcdClient) do(
+	method, path string, body *bytes.Reader, contentType string,
+	resp interface{}) (int, error) {
+	client := c.HTTPClient
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	var lastErr error
+	for _, e := range c.Endpoints {
+		if body != nil {
+			body.Seek(0, 0)
+		}
+
+		var req *http.Request
+		var err error
+		if body != nil {
+			req, err = http.NewRequest(method, e+path, body)
+		} else {
+			req, err = http.NewRequest(method, e+path, nil)
+		}
+		if err != nil {
+			return 0, fmt.Errorf("Failed to make etcd request: %s", err)
+		}
+
+		if contentType != "" {
+			req.Header.Set("Content-Type", contentType)
+		}
+		if c.token != "" {
+			req.Header.Set("Authorization", c.token)
+		} else if c.Username != "" && c.API != 3 {
+			req.SetBasicAuth(c.Username, c.Password)
+		}
+
+		httpResp, err := client.Do(req)
+		if err != nil {
+			// Try the next endpoint
+			lastErr = err
+			continue
+		}
+		defer httpResp.Body.Close()
+
+		switch httpResp.StatusCode {
+		case http.StatusUnauthorized:
+			return 0, fmt.Errorf("etcd requires authentication")
+		case http.StatusForbidden:
+			return 0, fmt.Errorf("etcd denied access to %s", c.Path)
+		}
+
+		if err := json.NewDecoder(httpResp.Body).Decode(resp); err != nil {
+			return 0, fmt.Errorf(
+				"Failed to decode etcd response (status %d): %s",
+				httpResp.StatusCode, err)
+		}
+
+		return httpResp.StatusCode, nil
+	}
+
+	return 0, fmt.Errorf("Failed to reach etcd: %s", lastErr)
+}
+
+func (c *EtcdClient) conflictError() error {
+	return fmt.Errorf(
+		"The state at %s was modified by someone else since it was "+
+			"last read. Refresh the state and try again.", c.Path)
+}
+
+func etcdV3Int(s string) (int64, error) {
+	if s == "" {
+		return 0, nil
+	}
+
+	v, err := strconv.ParseInt(s, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("error decoding etcd revision %q: %s", s, err)
+	}
+
+	return v, nil
+}
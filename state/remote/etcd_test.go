@@ -0,0 +1,360 @@
+package remote
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+)
+
+func TestEtcdClient_impl(t *testing.T) {
+	var _ Client = new(EtcdClient)
+}
+
+func TestEtcdFactory(t *testing.T) {
+	cases := []struct {
+		Config map[string]string
+		Err    bool
+	}{
+		{
+			map[string]string{
+				"path":      "tf/state",
+				"endpoints": "http://127.0.0.1:2379",
+			},
+			false,
+		},
+		{
+			map[string]string{
+				"path":      "tf/state",
+				"endpoints": "http://10.0.0.1:2379, http://10.0.0.2:2379",
+				"api":       "v3",
+			},
+			false,
+		},
+		{
+			map[string]string{
+				"endpoints": "http://127.0.0.1:2379",
+			},
+			true,
+		},
+		{
+			map[string]string{
+				"path": "tf/state",
+			},
+			true,
+		},
+		{
+			map[string]string{
+				"path":      "tf/state",
+				"endpoints": "127.0.0.1:2379",
+			},
+			true,
+		},
+		{
+			map[string]string{
+				"path":      "tf/state",
+				"endpoints": "http://127.0.0.1:2379",
+				"api":       "v4",
+			},
+			true,
+		},
+		{
+			map[string]string{
+				"path":      "tf/state",
+				"endpoints": "http://127.0.0.1:2379",
+				"cert_path": "cert.pem",
+			},
+			true,
+		},
+	}
+
+	for i, tc := range cases {
+		_, err := etcdFactory(tc.Config)
+		if (err != nil) != tc.Err {
+			t.Fatalf("%d: bad: %s", i, err)
+		}
+	}
+}
+
+func TestEtcdClient_v2(t *testing.T) {
+	ts := httptest.NewServer(newTestEtcdV2Handler())
+	defer ts.Close()
+
+	client, err := etcdFactory(map[string]string{
+		"path":      "tf/state",
+		"endpoints": ts.URL,
+	})
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	testClient(t, client)
+	testEtcdConflict(t, client, ts.URL, "v2")
+}
+
+func TestEtcdClient_v3(t *testing.T) {
+	ts := httptest.NewServer(newTestEtcdV3Handler())
+	defer ts.Close()
+
+	client, err := etcdFactory(map[string]string{
+		"path":      "tf/state",
+		"endpoints": ts.URL,
+		"api":       "v3",
+		"username":  "root",
+		"password":  "secret",
+	})
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	testClient(t, client)
+	testEtcdConflict(t, client, ts.URL, "v3")
+}
+
+func TestEtcdClient_endpointFailover(t *testing.T) {
+	ts := httptest.NewServer(newTestEtcdV2Handler())
+	defer ts.Close()
+
+	client, err := etcdFactory(map[string]string{
+		"path":      "tf/state",
+		"endpoints": "http://127.0.0.1:1," + ts.URL,
+	})
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	testClient(t, client)
+}
+
+// testEtcdConflict verifies that a write fails if another client has
+// written the state since it was last read.
+func testEtcdConflict(t *testing.T, c Client, endpoint, api string) {
+	other, err := etcdFactory(map[string]string{
+		"path":      "tf/state",
+		"endpoints": endpoint,
+		"api":       api,
+		"username":  "root",
+		"password":  "secret",
+	})
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	if _, err := c.Get(); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if _, err := other.Get(); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	if err := other.Put([]byte("other")); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if err := c.Put([]byte("mine")); err == nil {
+		t.Fatal("should conflict")
+	}
+
+	// After reading again the write succeeds
+	if _, err := c.Get(); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if err := c.Put([]byte("mine")); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+}
+
+// testEtcdStore is a tiny in-memory etcd keyspace with revisions.
+type testEtcdStore struct {
+	sync.Mutex
+
+	Revision int64
+	Values   map[string]string
+	Mods     map[string]int64
+}
+
+func newTestEtcdStore() *testEtcdStore {
+	return &testEtcdStore{
+		Values: make(map[string]string),
+		Mods:   make(map[string]int64),
+	}
+}
+
+func newTestEtcdV2Handler() http.Handler {
+	s := newTestEtcdStore()
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.Lock()
+		defer s.Unlock()
+
+		key := r.URL.Path[len("/v2/keys/"):]
+		notFound := func() {
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"errorCode":100,"message":"Key not found"}`))
+		}
+
+		switch r.Method {
+		case "GET":
+			v, ok := s.Values[key]
+			if !ok {
+				notFound()
+				return
+			}
+
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"node": map[string]interface{}{
+					"value":         v,
+					"modifiedIndex": s.Mods[key],
+				},
+			})
+		case "PUT":
+			_, exists := s.Values[key]
+			if r.FormValue("prevExist") == "false" && exists {
+				w.WriteHeader(http.StatusPreconditionFailed)
+				w.Write([]byte(`{"errorCode":105,"message":"Key already exists"}`))
+				return
+			}
+			if idx := r.FormValue("prevIndex"); idx != "" {
+				if idx != strconv.FormatInt(s.Mods[key], 10) {
+					w.WriteHeader(http.StatusPreconditionFailed)
+					w.Write([]byte(`{"errorCode":101,"message":"Compare failed"}`))
+					return
+				}
+			}
+
+			s.Revision++
+			s.Values[key] = r.FormValue("value")
+			s.Mods[key] = s.Revision
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"node": map[string]interface{}{
+					"value":         s.Values[key],
+					"modifiedIndex": s.Revision,
+				},
+			})
+		case "DELETE":
+			if _, ok := s.Values[key]; !ok {
+				notFound()
+				return
+			}
+
+			s.Revision++
+			delete(s.Values, key)
+			delete(s.Mods, key)
+			w.Write([]byte(`{}`))
+		default:
+			w.WriteHeader(http.StatusMethodNotAllowed)
+		}
+	})
+}
+
+func newTestEtcdV3Handler() http.Handler {
+	s := newTestEtcdStore()
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.Lock()
+		defer s.Unlock()
+
+		var req struct {
+			Key      string
+			Name     string
+			Password string
+			Compare  []map[string]string
+			Success  []map[string]map[string]string
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		if r.URL.Path == "/v3/auth/authenticate" {
+			if req.Name != "root" || req.Password != "secret" {
+				w.WriteHeader(http.StatusBadRequest)
+				w.Write([]byte(`{"error":"authentication failed"}`))
+				return
+			}
+
+			w.Write([]byte(`{"token":"token"}`))
+			return
+		}
+		if r.Header.Get("Authorization") != "token" {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"error":"user name is empty"}`))
+			return
+		}
+
+		decode := func(s string) string {
+			v, _ := base64.StdEncoding.DecodeString(s)
+			return string(v)
+		}
+		revision := strconv.FormatInt(s.Revision, 10)
+
+		switch r.URL.Path {
+		case "/v3/kv/range":
+			key := decode(req.Key)
+			var kvs []map[string]string
+			if v, ok := s.Values[key]; ok {
+				kvs = append(kvs, map[string]string{
+					"key":          req.Key,
+					"value":        base64.StdEncoding.EncodeToString([]byte(v)),
+					"mod_revision": strconv.FormatInt(s.Mods[key], 10),
+				})
+			}
+
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"header": map[string]string{"revision": revision},
+				"kvs":    kvs,
+			})
+		case "/v3/kv/txn":
+			for _, c := range req.Compare {
+				key := decode(c["key"])
+				var actual int64
+				var expected string
+				switch c["target"] {
+				case "CREATE":
+					if _, ok := s.Values[key]; ok {
+						actual = 1
+					}
+					expected = c["create_revision"]
+				case "MOD":
+					actual = s.Mods[key]
+					expected = c["mod_revision"]
+				}
+
+				if strconv.FormatInt(actual, 10) != expected {
+					json.NewEncoder(w).Encode(map[string]interface{}{
+						"header":    map[string]string{"revision": revision},
+						"succeeded": false,
+					})
+					return
+				}
+			}
+
+			s.Revision++
+			for _, op := range req.Success {
+				put := op["request_put"]
+				key := decode(put["key"])
+				s.Values[key] = decode(put["value"])
+				s.Mods[key] = s.Revision
+			}
+
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"header": map[string]string{
+					"revision": strconv.FormatInt(s.Revision, 10),
+				},
+				"succeeded": true,
+			})
+		case "/v3/kv/deleterange":
+			key := decode(req.Key)
+			if _, ok := s.Values[key]; ok {
+				s.Revision++
+				delete(s.Values, key)
+				delete(s.Mods, key)
+			}
+
+			w.Write([]byte(`{}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{}`))
+		}
+	})
+}
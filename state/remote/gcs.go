@@ -0,0 +1,298 @@
+package remote
+
+import (
+	"bytes"
+	"crypto/md5"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"strconv"
+	"strings"
+
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/google"
+	"golang.org/x/oauth2/jwt"
+)
+
+const (
+	gcsDefaultEndpoint = "https://www.googleapis.com"
+	gcsDefaultObject   = "terraform.tfstate"
+	gcsScope           = "https://www.googleapis.com/auth/devstorage.read_write"
+)
+
+func gcsFactory(conf map[string]string) (Client, error) {
+	bucket, ok := conf["bucket"]
+	if !ok {
+		return nil, fmt.Errorf("missing 'bucket' configuration")
+	}
+
+	client := &GCSClient{
+		Bucket: bucket,
+		Object: path.Join(conf["prefix"], gcsDefaultObject),
+	}
+
+	endpoint := conf["endpoint"]
+	if endpoint == "" {
+		endpoint = gcsDefaultEndpoint
+	}
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse 'endpoint': %s", err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return nil, fmt.Errorf("'endpoint' must be HTTP or HTTPS")
+	}
+	client.Endpoint = u
+
+	credentials := conf["credentials"]
+	if credentials == "" {
+		credentials = os.Getenv("GOOGLE_CREDENTIALS")
+	}
+	if credentials == "" {
+		credentials = os.Getenv("GOOGLE_ACCOUNT_FILE")
+	}
+
+	if credentials != "" {
+		account, err := gcsAccount(credentials)
+		if err != nil {
+			return nil, err
+		}
+
+		jwtConf := jwt.Config{
+			Email:      account.ClientEmail,
+			PrivateKey: []byte(account.PrivateKey),
+			Scopes:     []string{gcsScope},
+			TokenURL:   "https://accounts.google.com/o/oauth2/token",
+		}
+		client.HTTPClient = jwtConf.Client(oauth2.NoContext)
+	} else {
+		// Without credentials, use the service account of the Google
+		// Compute Engine instance we're running on.
+		client.HTTPClient = &http.Client{
+			Transport: &oauth2.Transport{
+				Source: google.ComputeTokenSource(""),
+			},
+		}
+	}
+
+	return client, nil
+}
+
+// gcsAccount loads service account credentials, which are either the
+// JSON key itself or the path to a file containing it.
+func gcsAccount(credentials string) (*gcsAccountFile, error) {
+	raw := []byte(credentials)
+	if !strings.HasPrefix(strings.TrimSpace(credentials), "{") {
+		var err error
+		raw, err = ioutil.ReadFile(credentials)
+		if err != nil {
+			return nil, fmt.Errorf(
+				"Error loading credentials file '%s': %s", credentials, err)
+		}
+	}
+
+	var account gcsAccountFile
+	if err := json.Unmarshal(raw, &account); err != nil {
+		return nil, fmt.Errorf("Error parsing credentials: %s", err)
+	}
+	if account.ClientEmail == "" || account.PrivateKey == "" {
+		return nil, fmt.Errorf(
+			"credentials must be a service account key with " +
+				"'client_email' and 'private_key'")
+	}
+
+	return &account, nil
+}
+
+// gcsAccountFile is the structure of a service account JSON key.
+type gcsAccountFile struct {
+	PrivateKey  string `json:"private_key"`
+	ClientEmail string `json:"client_email"`
+}
+
+// GCSClient stores the state as an object in a Google Cloud Storage
+// bucket, using the JSON API.
+//
+// Every write is made conditional on the generation of the object that
+// was last read, so a state that someone else has written since then
+// is never clobbered.
+type GCSClient struct {
+	Bucket string
+	Object string
+
+	// Endpoint is the base URL of the API.
+	Endpoint *url.URL
+
+	// HTTPClient is the authorized HTTP client used for requests.
+	HTTPClient *http.Client
+
+	// generation is the generation of the object when it was last read
+	// or written, or zero if it didn't exist. It is only used once the
+	// state has been read.
+	generation int64
+	read       bool
+}
+
+func (c *GCSClient) Get() (*Payload, error) {
+	resp, err := c.request("GET", c.objectURL(url.Values{"alt": []string{"media"}}), nil)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to get state: %s", err)
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusNotFound:
+		c.generation = 0
+		c.read = true
+		return nil, nil
+	default:
+		return nil, gcsError(resp)
+	}
+
+	data, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to read remote state: %s", err)
+	}
+
+	generation, err := strconv.ParseInt(resp.Header.Get("X-Goog-Generation"), 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to read state generation: %s", err)
+	}
+	c.generation = generation
+	c.read = true
+
+	// If there was no data, then return nil
+	if len(data) == 0 {
+		return nil, nil
+	}
+
+	hash := md5.Sum(data)
+	return &Payload{
+		Data: data,
+		MD5:  hash[:],
+	}, nil
+}
+
+func (c *GCSClient) Put(data []byte) error {
+	query := url.Values{
+		"uploadType": []string{"media"},
+		"name":       []string{c.Object},
+	}
+	if c.read {
+		// A generation of zero means the object must not exist yet
+		query.Set("ifGenerationMatch", strconv.FormatInt(c.generation, 10))
+	}
+
+	u := *c.Endpoint
+	u.Path = fmt.Sprintf("%s/upload/storage/v1/b/%s/o",
+		strings.TrimSuffix(u.Path, "/"), c.Bucket)
+	u.RawQuery = query.Encode()
+
+	resp, err := c.request("POST", &u, data)
+	if err != nil {
+		return fmt.Errorf("Failed to upload state: %s", err)
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusPreconditionFailed:
+		return fmt.Errorf(
+			"Failed to upload state: gs://%s/%s was modified since it was "+
+				"last read. Refresh the state and try again.", c.Bucket, c.Object)
+	default:
+		return fmt.Errorf("Failed to upload state: %s", gcsError(resp))
+	}
+
+	var object struct {
+		Generation string `json:"generation"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&object); err != nil {
+		return fmt.Errorf("Failed to decode upload response: %s", err)
+	}
+
+	c.generation, err = strconv.ParseInt(object.Generation, 10, 64)
+	if err != nil {
+		return fmt.Errorf("Failed to read state generation: %s", err)
+	}
+	c.read = true
+
+	return nil
+}
+
+func (c *GCSClient) Delete() error {
+	query := url.Values{}
+	if c.read && c.generation != 0 {
+		query.Set("ifGenerationMatch", strconv.FormatInt(c.generation, 10))
+	}
+
+	resp, err := c.request("DELETE", c.objectURL(query), nil)
+	if err != nil {
+		return fmt.Errorf("Failed to delete state: %s", err)
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusNoContent, http.StatusOK, http.StatusNotFound:
+		c.generation = 0
+		return nil
+	default:
+		return fmt.Errorf("Failed to delete state: %s", gcsError(resp))
+	}
+}
+
+// objectURL returns the API URL of the state object.
+func (c *GCSClient) objectURL(query url.Values) *url.URL {
+	u := *c.Endpoint
+	u.Opaque = fmt.Sprintf("//%s%s/storage/v1/b/%s/o/%s",
+		u.Host, strings.TrimSuffix(u.Path, "/"),
+		url.QueryEscape(c.Bucket), url.QueryEscape(c.Object))
+	u.RawQuery = query.Encode()
+	return &u
+}
+
+func (c *GCSClient) request(method string, u *url.URL, data []byte) (*http.Response, error) {
+	var body io.Reader
+	if data != nil {
+		body = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequest(method, u.String(), body)
+	if err != nil {
+		return nil, err
+	}
+	if data != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	// Keep the opaque URL so the escaped object name, which may contain
+	// slashes, is sent as is.
+	req.URL = u
+
+	client := c.HTTPClient
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	return client.Do(req)
+}
+
+// gcsError returns an error with the message from an error response.
+func gcsError(resp *http.Response) error {
+	var body struct {
+		Error struct {
+			Message string `json:"message"`
+		} `json:"error"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err == nil && body.Error.Message != "" {
+		return fmt.Errorf("HTTP error %d: %s", resp.StatusCode, body.Error.Message)
+	}
+
+	return fmt.Errorf("HTTP error: %d", resp.StatusCode)
+}
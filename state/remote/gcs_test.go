@@ -0,0 +1,220 @@
+package remote
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"testing"
+)
+
+func TestGCSClient_impl(t *testing.T) {
+	var _ Client = new(GCSClient)
+}
+
+func TestGCSFactory(t *testing.T) {
+	defer os.Setenv("GOOGLE_CREDENTIALS", os.Getenv("GOOGLE_CREDENTIALS"))
+	defer os.Setenv("GOOGLE_ACCOUNT_FILE", os.Getenv("GOOGLE_ACCOUNT_FILE"))
+	os.Setenv("GOOGLE_CREDENTIALS", "")
+	os.Setenv("GOOGLE_ACCOUNT_FILE", "")
+
+	td, err := ioutil.TempDir("", "tf")
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	defer os.RemoveAll(td)
+
+	key := `{"client_email":"tf@example.iam.gserviceaccount.com","private_key":"key"}`
+	keyPath := filepath.Join(td, "account.json")
+	if err := ioutil.WriteFile(keyPath, []byte(key), 0600); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	cases := []struct {
+		Config map[string]string
+		Object string
+		Err    bool
+	}{
+		{
+			map[string]string{"bucket": "tf"},
+			"terraform.tfstate",
+			false,
+		},
+		{
+			map[string]string{
+				"bucket":      "tf",
+				"prefix":      "prod/network",
+				"credentials": key,
+			},
+			"prod/network/terraform.tfstate",
+			false,
+		},
+		{
+			map[string]string{
+				"bucket":      "tf",
+				"credentials": keyPath,
+			},
+			"terraform.tfstate",
+			false,
+		},
+		{
+			map[string]string{"prefix": "prod"},
+			"",
+			true,
+		},
+		{
+			map[string]string{
+				"bucket":      "tf",
+				"credentials": filepath.Join(td, "missing.json"),
+			},
+			"",
+			true,
+		},
+		{
+			map[string]string{
+				"bucket":      "tf",
+				"credentials": `{"client_email":"tf@example.iam.gserviceaccount.com"}`,
+			},
+			"",
+			true,
+		},
+	}
+
+	for i, tc := range cases {
+		client, err := gcsFactory(tc.Config)
+		if (err != nil) != tc.Err {
+			t.Fatalf("%d: bad: %s", i, err)
+		}
+		if err != nil {
+			continue
+		}
+
+		if actual := client.(*GCSClient).Object; actual != tc.Object {
+			t.Fatalf("%d: bad: %s", i, actual)
+		}
+	}
+}
+
+func TestGCSClient(t *testing.T) {
+	ts := httptest.NewServer(newTestGCSHandler())
+	defer ts.Close()
+
+	testClient(t, testGCSClient(t, ts.URL))
+}
+
+func TestGCSClient_conflict(t *testing.T) {
+	ts := httptest.NewServer(newTestGCSHandler())
+	defer ts.Close()
+
+	a := testGCSClient(t, ts.URL)
+	b := testGCSClient(t, ts.URL)
+
+	if _, err := a.Get(); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if _, err := b.Get(); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	// Both clients saw no state, so only the first can create it
+	if err := b.Put([]byte("b")); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if err := a.Put([]byte("a")); err == nil {
+		t.Fatal("should conflict")
+	}
+
+	// After reading again the write succeeds, and further writes by
+	// the same client don't need another read.
+	if _, err := a.Get(); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if err := a.Put([]byte("a")); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if err := a.Put([]byte("a2")); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if err := b.Put([]byte("b2")); err == nil {
+		t.Fatal("should conflict")
+	}
+}
+
+func testGCSClient(t *testing.T, endpoint string) Client {
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	return &GCSClient{
+		Bucket:   "tf",
+		Object:   "prod/terraform.tfstate",
+		Endpoint: u,
+	}
+}
+
+// newTestGCSHandler returns a handler that fakes a single object of the
+// Google Cloud Storage JSON API, including generation preconditions.
+func newTestGCSHandler() http.Handler {
+	var lock sync.Mutex
+	var data []byte
+	var generation int64
+
+	const objectPath = "/storage/v1/b/tf/o/prod%2Fterraform.tfstate"
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		lock.Lock()
+		defer lock.Unlock()
+
+		// Check the generation precondition, if any
+		if v := r.URL.Query().Get("ifGenerationMatch"); v != "" {
+			if v != strconv.FormatInt(generation, 10) {
+				w.WriteHeader(http.StatusPreconditionFailed)
+				w.Write([]byte(`{"error":{"message":"Precondition Failed"}}`))
+				return
+			}
+		}
+
+		switch {
+		case r.Method == "GET" && r.URL.EscapedPath() == objectPath:
+			if generation == 0 {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+
+			w.Header().Set("X-Goog-Generation", strconv.FormatInt(generation, 10))
+			w.Write(data)
+		case r.Method == "POST" && r.URL.Path == "/upload/storage/v1/b/tf/o":
+			if r.URL.Query().Get("name") != "prod/terraform.tfstate" {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+
+			body, err := ioutil.ReadAll(r.Body)
+			if err != nil {
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+
+			data = body
+			generation++
+			fmt.Fprintf(w, `{"generation":"%d"}`, generation)
+		case r.Method == "DELETE" && r.URL.EscapedPath() == objectPath:
+			if generation == 0 {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+
+			data = nil
+			generation = 0
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"error":{"message":"Not Found"}}`))
+		}
+	})
+}
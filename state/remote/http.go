@@ -3,11 +3,16 @@ package remote
 import (
 	"bytes"
 	"crypto/md5"
+	"crypto/tls"
 	"encoding/base64"
+	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
 	"net/url"
+	"strconv"
+
+	"github.com/hashicorp/terraform/state"
 )
 
 func httpFactory(conf map[string]string) (Client, error) {
@@ -16,6 +21,67 @@ func httpFactory(conf map[string]string) (Client, error) {
 		return nil, fmt.Errorf("missing 'address' configuration")
 	}
 
+	url, err := httpURL(address)
+	if err != nil {
+		return nil, err
+	}
+
+	client := &HTTPClient{
+		URL:          url,
+		UpdateMethod: "POST",
+		LockMethod:   "LOCK",
+		UnlockMethod: "UNLOCK",
+		Username:     conf["username"],
+		Password:     conf["password"],
+	}
+
+	if v, ok := conf["update_method"]; ok && v != "" {
+		client.UpdateMethod = v
+	}
+	if v, ok := conf["lock_method"]; ok && v != "" {
+		client.LockMethod = v
+	}
+	if v, ok := conf["unlock_method"]; ok && v != "" {
+		client.UnlockMethod = v
+	}
+
+	// Locking is only enabled if a lock address is given. The unlock
+	// address defaults to the lock address.
+	if v, ok := conf["lock_address"]; ok && v != "" {
+		client.LockURL, err = httpURL(v)
+		if err != nil {
+			return nil, err
+		}
+
+		client.UnlockURL = client.LockURL
+	}
+	if v, ok := conf["unlock_address"]; ok && v != "" {
+		client.UnlockURL, err = httpURL(v)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if v, ok := conf["skip_cert_verification"]; ok && v != "" {
+		skip, err := strconv.ParseBool(v)
+		if err != nil {
+			return nil, fmt.Errorf(
+				"invalid 'skip_cert_verification' value: %s", err)
+		}
+
+		if skip {
+			client.Client = &http.Client{
+				Transport: &http.Transport{
+					TLSClientConfig: &tls.Config{InsecureSkipVerify: true},
+				},
+			}
+		}
+	}
+
+	return client, nil
+}
+
+func httpURL(address string) (*url.URL, error) {
 	url, err := url.Parse(address)
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse HTTP URL: %s", err)
@@ -24,18 +90,70 @@ func httpFactory(conf map[string]string) (Client, error) {
 		return nil, fmt.Errorf("address must be HTTP or HTTPS")
 	}
 
-	return &HTTPClient{
-		URL: url,
-	}, nil
+	return url, nil
 }
 
-// HTTPClient is a remote client that stores data in Consul.
+// HTTPClient is a remote client that stores data over a simple REST
+// protocol: the state is read with GET, written with UpdateMethod (POST
+// by default) and deleted with DELETE.
+//
+// If LockURL is set, the state is locked by sending the lock information
+// as JSON with LockMethod, and unlocked with UnlockMethod to UnlockURL.
+// The server responds with 409 Conflict or 423 Locked, optionally with
+// the existing lock information, if the state is already locked.
 type HTTPClient struct {
-	URL *url.URL
+	URL          *url.URL
+	UpdateMethod string
+
+	LockURL      *url.URL
+	LockMethod   string
+	UnlockURL    *url.URL
+	UnlockMethod string
+
+	Username string
+	Password string
+
+	// Client is the HTTP client to use. If nil, http.DefaultClient
+	// is used.
+	Client *http.Client
+
+	lockID       string
+	jsonLockInfo []byte
+}
+
+// request sends an HTTP request with the given body, if any. A body is
+// sent with the given content type and its MD5.
+func (c *HTTPClient) request(
+	method string, url *url.URL, contentType string, data []byte) (*http.Response, error) {
+	var body io.Reader
+	if data != nil {
+		body = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequest(method, url.String(), body)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to make HTTP request: %s", err)
+	}
+	if data != nil {
+		hash := md5.Sum(data)
+		req.Header.Set("Content-Type", contentType)
+		req.Header.Set("Content-MD5", base64.StdEncoding.EncodeToString(hash[:]))
+		req.ContentLength = int64(len(data))
+	}
+	if c.Username != "" {
+		req.SetBasicAuth(c.Username, c.Password)
+	}
+
+	client := c.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	return client.Do(req)
 }
 
 func (c *HTTPClient) Get() (*Payload, error) {
-	resp, err := http.Get(c.URL.String())
+	resp, err := c.request("GET", c.URL, "", nil)
 	if err != nil {
 		return nil, err
 	}
@@ -97,34 +215,34 @@ func (c *HTTPClient) Put(data []byte) error {
 	// Copy the target URL
 	base := *c.URL
 
-	// Generate the MD5
-	hash := md5.Sum(data)
-	b64 := base64.StdEncoding.EncodeToString(hash[:])
-
-	/*
-		// Set the force query parameter if needed
-		if force {
-			values := base.Query()
-			values.Set("force", "true")
-			base.RawQuery = values.Encode()
-		}
-	*/
+	// Tell the server which lock we hold, if any, so that it can
+	// reject writes from anyone else.
+	if c.lockID != "" {
+		values := base.Query()
+		values.Set("ID", c.lockID)
+		base.RawQuery = values.Encode()
+	}
 
-	// Make the HTTP client and request
-	req, err := http.NewRequest("POST", base.String(), bytes.NewReader(data))
+	// Make the request
+	resp, err := c.request(c.UpdateMethod, &base, "application/octet-stream", data)
 	if err != nil {
-		return fmt.Errorf("Failed to make HTTP request: %s", err)
+		return fmt.Errorf("Failed to upload state: %v", err)
 	}
+	defer resp.Body.Close()
 
-	// Prepare the request
-	req.Header.Set("Content-Type", "application/octet-stream")
-	req.Header.Set("Content-MD5", b64)
-	req.ContentLength = int64(len(data))
+	// Handle the error codes
+	switch resp.StatusCode {
+	case http.StatusOK, http.StatusCreated, http.StatusNoContent:
+		return nil
+	default:
+		return fmt.Errorf("HTTP error: %d", resp.StatusCode)
+	}
+}
 
-	// Make the request
-	resp, err := http.DefaultClient.Do(req)
+func (c *HTTPClient) Delete() error {
+	resp, err := c.request("DELETE", c.URL, "", nil)
 	if err != nil {
-		return fmt.Errorf("Failed to upload state: %v", err)
+		return fmt.Errorf("Failed to delete state: %s", err)
 	}
 	defer resp.Body.Close()
 
@@ -137,25 +255,89 @@ func (c *HTTPClient) Put(data []byte) error {
 	}
 }
 
-func (c *HTTPClient) Delete() error {
-	// Make the HTTP request
-	req, err := http.NewRequest("DELETE", c.URL.String(), nil)
+func (c *HTTPClient) Lock(info *state.LockInfo) error {
+	if c.LockURL == nil {
+		return nil
+	}
+
+	data, err := json.Marshal(info)
 	if err != nil {
-		return fmt.Errorf("Failed to make HTTP request: %s", err)
+		return err
 	}
 
-	// Make the request
-	resp, err := http.DefaultClient.Do(req)
+	resp, err := c.request(c.LockMethod, c.LockURL, "application/json", data)
 	if err != nil {
-		return fmt.Errorf("Failed to delete state: %s", err)
+		return fmt.Errorf("Failed to lock state: %s", err)
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		c.lockID = info.ID
+		c.jsonLockInfo = data
+		return nil
+	case http.StatusConflict, http.StatusLocked:
+		// The body optionally contains the existing lock
+		lockErr := &state.LockError{
+			Err: fmt.Errorf("HTTP remote state already locked"),
+		}
+
+		existing := new(state.LockInfo)
+		if err := json.NewDecoder(resp.Body).Decode(existing); err == nil {
+			lockErr.Info = existing
+		}
+
+		return lockErr
+	case http.StatusUnauthorized:
+		return fmt.Errorf("HTTP remote state endpoint requires auth")
+	case http.StatusForbidden:
+		return fmt.Errorf("HTTP remote state endpoint invalid auth")
+	default:
+		return fmt.Errorf("Unexpected HTTP response code %d", resp.StatusCode)
+	}
+}
+
+func (c *HTTPClient) Unlock() error {
+	if c.UnlockURL == nil || c.lockID == "" {
+		return nil
+	}
+
+	return c.unlock(c.jsonLockInfo)
+}
+
+// ForceUnlock sends an unlock request for the lock with the given ID. The
+// server only knows the lock by its ID, so the other lock information is
+// left out.
+func (c *HTTPClient) ForceUnlock(id string) error {
+	if c.UnlockURL == nil {
+		return fmt.Errorf(
+			"HTTP remote state has no unlock_address, so it can't be unlocked")
+	}
+
+	data, err := json.Marshal(&state.LockInfo{ID: id})
+	if err != nil {
+		return err
+	}
+
+	return c.unlock(data)
+}
+
+func (c *HTTPClient) unlock(data []byte) error {
+	resp, err := c.request(c.UnlockMethod, c.UnlockURL, "application/json", data)
+	if err != nil {
+		return fmt.Errorf("Failed to unlock state: %s", err)
 	}
 	defer resp.Body.Close()
 
-	// Handle the error codes
 	switch resp.StatusCode {
 	case http.StatusOK:
+		c.lockID = ""
+		c.jsonLockInfo = nil
 		return nil
+	case http.StatusConflict:
+		return fmt.Errorf(
+			"Failed to unlock state: the lock ID doesn't match the lock of the state")
 	default:
-		return fmt.Errorf("HTTP error: %d", resp.StatusCode)
+		return fmt.Errorf("Unexpected HTTP response code %d", resp.StatusCode)
 	}
 }
@@ -2,16 +2,77 @@ package remote
 
 import (
 	"bytes"
+	"crypto/md5"
+	"encoding/base64"
+	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
 	"testing"
+
+	"github.com/hashicorp/terraform/state"
 )
 
 func TestHTTPClient_impl(t *testing.T) {
 	var _ Client = new(HTTPClient)
+	var _ ClientLocker = new(HTTPClient)
+}
+
+func TestHTTPFactory(t *testing.T) {
+	cases := []struct {
+		Config map[string]string
+		Err    bool
+	}{
+		{
+			map[string]string{"address": "http://127.0.0.1:8080/state"},
+			false,
+		},
+		{
+			map[string]string{
+				"address":                "https://127.0.0.1:8080/state",
+				"update_method":          "PUT",
+				"lock_address":           "https://127.0.0.1:8080/lock",
+				"unlock_address":         "https://127.0.0.1:8080/unlock",
+				"lock_method":            "PUT",
+				"unlock_method":          "DELETE",
+				"username":               "user",
+				"password":               "pass",
+				"skip_cert_verification": "true",
+			},
+			false,
+		},
+		{
+			map[string]string{},
+			true,
+		},
+		{
+			map[string]string{"address": "ftp://127.0.0.1/state"},
+			true,
+		},
+		{
+			map[string]string{
+				"address":      "http://127.0.0.1:8080/state",
+				"lock_address": "127.0.0.1:8080/lock",
+			},
+			true,
+		},
+		{
+			map[string]string{
+				"address":                "http://127.0.0.1:8080/state",
+				"skip_cert_verification": "maybe",
+			},
+			true,
+		},
+	}
+
+	for i, tc := range cases {
+		_, err := httpFactory(tc.Config)
+		if (err != nil) != tc.Err {
+			t.Fatalf("%d: bad: %s", i, err)
+		}
+	}
 }
 
 func TestHTTPClient(t *testing.T) {
@@ -24,28 +85,175 @@ func TestHTTPClient(t *testing.T) {
 		t.Fatalf("err: %s", err)
 	}
 
-	client := &HTTPClient{URL: url}
+	client := &HTTPClient{URL: url, UpdateMethod: "POST"}
 	testClient(t, client)
 }
 
+func TestHTTPClient_lock(t *testing.T) {
+	handler := new(testHTTPHandler)
+	ts := httptest.NewServer(http.HandlerFunc(handler.Handle))
+	defer ts.Close()
+
+	conf := map[string]string{
+		"address":       ts.URL,
+		"update_method": "PUT",
+		"lock_address":  ts.URL,
+		"username":      "user",
+		"password":      "pass",
+	}
+
+	c, err := httpFactory(conf)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	client := c.(*HTTPClient)
+
+	other, err := httpFactory(conf)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	info := state.NewLockInfo("apply")
+	if err := client.Lock(info); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	// The state can be written while holding the lock
+	testClient(t, client)
+
+	// Another client can't take the lock, and is told who holds it
+	err = other.(ClientLocker).Lock(state.NewLockInfo("plan"))
+	lockErr, ok := err.(*state.LockError)
+	if !ok {
+		t.Fatalf("bad: %#v", err)
+	}
+	if lockErr.Info == nil || lockErr.Info.ID != info.ID {
+		t.Fatalf("bad: %#v", lockErr.Info)
+	}
+
+	// ...nor write the state
+	if err := other.Put([]byte("other")); err == nil {
+		t.Fatal("should error")
+	}
+
+	if err := client.Unlock(); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if err := other.(ClientLocker).Lock(state.NewLockInfo("plan")); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+}
+
+func TestHTTPClient_forceUnlock(t *testing.T) {
+	handler := new(testHTTPHandler)
+	ts := httptest.NewServer(http.HandlerFunc(handler.Handle))
+	defer ts.Close()
+
+	conf := map[string]string{
+		"address":      ts.URL,
+		"lock_address": ts.URL,
+	}
+
+	a, err := httpFactory(conf)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	b, err := httpFactory(conf)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	testClientForceUnlock(t, a, b)
+}
+
+func TestHTTPClient_lockDisabled(t *testing.T) {
+	handler := new(testHTTPHandler)
+	ts := httptest.NewServer(http.HandlerFunc(handler.Handle))
+	defer ts.Close()
+
+	client, err := httpFactory(map[string]string{"address": ts.URL})
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	// Without a lock address, locking is a no-op
+	locker := client.(ClientLocker)
+	if err := locker.Lock(state.NewLockInfo("apply")); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if err := locker.Unlock(); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if handler.Lock != nil {
+		t.Fatalf("bad: %#v", handler.Lock)
+	}
+}
+
 type testHTTPHandler struct {
 	Data []byte
+	Lock *state.LockInfo
 }
 
 func (h *testHTTPHandler) Handle(w http.ResponseWriter, r *http.Request) {
+	if user, pass, ok := r.BasicAuth(); ok {
+		if user != "user" || pass != "pass" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+	}
+
 	switch r.Method {
 	case "GET":
 		w.Write(h.Data)
-	case "POST":
+	case "POST", "PUT":
+		// Writes are rejected if someone else holds the lock
+		if h.Lock != nil && r.URL.Query().Get("ID") != h.Lock.ID {
+			w.WriteHeader(http.StatusConflict)
+			return
+		}
+
 		buf := new(bytes.Buffer)
 		if _, err := io.Copy(buf, r.Body); err != nil {
 			w.WriteHeader(500)
 		}
 
+		// The state is sent with its MD5
+		hash := md5.Sum(buf.Bytes())
+		if r.Header.Get("Content-MD5") != base64.StdEncoding.EncodeToString(hash[:]) {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
 		h.Data = buf.Bytes()
 	case "DELETE":
 		h.Data = nil
 		w.WriteHeader(200)
+	case "LOCK":
+		if h.Lock != nil {
+			w.WriteHeader(http.StatusLocked)
+			json.NewEncoder(w).Encode(h.Lock)
+			return
+		}
+
+		info := new(state.LockInfo)
+		if err := json.NewDecoder(r.Body).Decode(info); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		h.Lock = info
+	case "UNLOCK":
+		info := new(state.LockInfo)
+		if err := json.NewDecoder(r.Body).Decode(info); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		if h.Lock == nil || h.Lock.ID != info.ID {
+			w.WriteHeader(http.StatusConflict)
+			return
+		}
+
+		h.Lock = nil
 	default:
 		w.WriteHeader(500)
 		w.Write([]byte(fmt.Sprintf("Unknown method: %s", r.Method)))
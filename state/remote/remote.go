@@ -2,6 +2,8 @@ package remote
 
 import (
 	"fmt"
+
+	"github.com/hashicorp/terraform/state"
 )
 
 // Client is the interface that must be implemented for a remote state
@@ -13,6 +15,29 @@ type Client interface {
 	Delete() error
 }
 
+// ClientLocker is an optional interface that can be implemented by
+// clients that support locking the remote state.
+type ClientLocker interface {
+	Client
+
+	// Lock locks the state, storing the given lock information with
+	// the lock. If the state is already locked, a *state.LockError
+	// should be returned.
+	Lock(*state.LockInfo) error
+
+	// Unlock unlocks the state.
+	Unlock() error
+}
+
+// ClientForceUnlocker is an optional interface that can be implemented by
+// clients that support locking, to remove the lock with the given ID even
+// if it is held by someone else.
+type ClientForceUnlocker interface {
+	ClientLocker
+
+	ForceUnlock(id string) error
+}
+
 // Payload is the return value from the remote state storage.
 type Payload struct {
 	MD5  []byte
@@ -37,9 +62,13 @@ func NewClient(t string, conf map[string]string) (Client, error) {
 // NewClient.
 var BuiltinClients = map[string]Factory{
 	"atlas":  atlasFactory,
+	"azure":  azureFactory,
 	"consul": consulFactory,
+	"etcd":   etcdFactory,
+	"gcs":    gcsFactory,
 	"http":   httpFactory,
 	"s3":     s3Factory,
+	"swift":  swiftFactory,
 
 	// This is used for development purposes only.
 	"_local": fileFactory,
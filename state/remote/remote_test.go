@@ -41,3 +41,70 @@ func testClient(t *testing.T, c Client) {
 		t.Fatalf("bad: %#v", p)
 	}
 }
+
+// testClientLocks is a generic function to test the locking of any two
+// clients for the same state.
+func testClientLocks(t *testing.T, a, b Client) {
+	lockerA := a.(ClientLocker)
+	lockerB := b.(ClientLocker)
+
+	info := state.NewLockInfo("apply")
+	if err := lockerA.Lock(info); err != nil {
+		t.Fatalf("lock: %s", err)
+	}
+
+	// The lock holder can still write the state
+	if err := a.Put([]byte("locked")); err != nil {
+		t.Fatalf("put: %s", err)
+	}
+
+	// The other client can't take the lock, and is told who holds it
+	err := lockerB.Lock(state.NewLockInfo("plan"))
+	lockErr, ok := err.(*state.LockError)
+	if !ok {
+		t.Fatalf("bad: %#v", err)
+	}
+	if lockErr.Info == nil || lockErr.Info.ID != info.ID {
+		t.Fatalf("bad: %#v", lockErr.Info)
+	}
+
+	if err := lockerA.Unlock(); err != nil {
+		t.Fatalf("unlock: %s", err)
+	}
+
+	if err := lockerB.Lock(state.NewLockInfo("plan")); err != nil {
+		t.Fatalf("lock: %s", err)
+	}
+	if err := lockerB.Unlock(); err != nil {
+		t.Fatalf("unlock: %s", err)
+	}
+}
+
+func testClientForceUnlock(t *testing.T, a, b Client) {
+	lockerA := a.(ClientLocker)
+	unlockerB := b.(ClientForceUnlocker)
+
+	info := state.NewLockInfo("apply")
+	if err := lockerA.Lock(info); err != nil {
+		t.Fatalf("lock: %s", err)
+	}
+
+	// The lock isn't removed with the wrong ID
+	if err := unlockerB.ForceUnlock(state.NewLockInfo("plan").ID); err == nil {
+		t.Fatal("force unlock should fail with the wrong ID")
+	}
+	if err := unlockerB.Lock(state.NewLockInfo("plan")); err == nil {
+		t.Fatal("lock should fail")
+	}
+
+	// The other client can remove the lock with its ID, and take it
+	if err := unlockerB.ForceUnlock(info.ID); err != nil {
+		t.Fatalf("force unlock: %s", err)
+	}
+	if err := unlockerB.Lock(state.NewLockInfo("plan")); err != nil {
+		t.Fatalf("lock: %s", err)
+	}
+	if err := unlockerB.Unlock(); err != nil {
+		t.Fatalf("unlock: %s", err)
+	}
+}
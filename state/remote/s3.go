@@ -2,13 +2,18 @@ package remote
 
 import (
 	"bytes"
+	"encoding/json"
 	"fmt"
 	"io"
+	"log"
 	"os"
+	"strconv"
 
 	"github.com/awslabs/aws-sdk-go/aws"
 	"github.com/awslabs/aws-sdk-go/aws/credentials"
+	"github.com/awslabs/aws-sdk-go/service/dynamodb"
 	"github.com/awslabs/aws-sdk-go/service/s3"
+	"github.com/hashicorp/terraform/state"
 )
 
 func s3Factory(conf map[string]string) (Client, error) {
@@ -31,6 +36,23 @@ func s3Factory(conf map[string]string) (Client, error) {
 		}
 	}
 
+	serverSideEncryption := false
+	if raw, ok := conf["encrypt"]; ok && raw != "" {
+		v, err := strconv.ParseBool(raw)
+		if err != nil {
+			return nil, fmt.Errorf("invalid 'encrypt' value: %s", err)
+		}
+		serverSideEncryption = v
+	}
+
+	// A KMS key implies encryption, there's nothing else to use it for
+	kmsKeyID := conf["kms_key_id"]
+	if kmsKeyID != "" {
+		serverSideEncryption = true
+	}
+
+	acl := conf["acl"]
+
 	accessKeyId := conf["access_key"]
 	secretAccessKey := conf["secret_key"]
 
@@ -58,17 +80,47 @@ func s3Factory(conf map[string]string) (Client, error) {
 	}
 	nativeClient := s3.New(awsConfig)
 
-	return &S3Client{
-		nativeClient: nativeClient,
-		bucketName:   bucketName,
-		keyName:      keyName,
-	}, nil
+	client := &S3Client{
+		nativeClient:         nativeClient,
+		bucketName:           bucketName,
+		keyName:              keyName,
+		serverSideEncryption: serverSideEncryption,
+		kmsKeyID:             kmsKeyID,
+		acl:                  acl,
+	}
+
+	// Locking is only enabled if a DynamoDB table is given
+	if table := conf["lock_table"]; table != "" {
+		client.lockTable = table
+		client.lockClient = dynamodb.New(awsConfig)
+	}
+
+	return client, nil
 }
 
+// S3Client stores the state as an object in an S3 bucket.
+//
+// If serverSideEncryption is set, S3 encrypts the object at rest, with
+// the KMS key kmsKeyID if it is set, or with its own keys (SSE-S3)
+// otherwise. The object is always read without a version ID, so in a
+// bucket with versioning enabled the latest version is read while the
+// previous ones are kept.
+//
+// If lockTable is set, the state is locked by putting an item in that
+// DynamoDB table, whose hash key must be the string LockID. The item is
+// only put if there is none for the state yet, and it holds the lock
+// information.
 type S3Client struct {
-	nativeClient *s3.S3
-	bucketName   string
-	keyName      string
+	nativeClient         *s3.S3
+	bucketName           string
+	keyName              string
+	serverSideEncryption bool
+	kmsKeyID             string
+	acl                  string
+
+	lockClient   *dynamodb.DynamoDB
+	lockTable    string
+	jsonLockInfo string
 }
 
 func (c *S3Client) Get() (*Payload, error) {
@@ -91,6 +143,11 @@ func (c *S3Client) Get() (*Payload, error) {
 
 	defer output.Body.Close()
 
+	if output.VersionID != nil {
+		log.Printf("[DEBUG] state: Read version %s of s3://%s/%s",
+			*output.VersionID, c.bucketName, c.keyName)
+	}
+
 	buf := bytes.NewBuffer(nil)
 	if _, err := io.Copy(buf, output.Body); err != nil {
 		return nil, fmt.Errorf("Failed to read remote state: %s", err)
@@ -112,13 +169,28 @@ func (c *S3Client) Put(data []byte) error {
 	contentType := "application/octet-stream"
 	contentLength := int64(len(data))
 
-	_, err := c.nativeClient.PutObject(&s3.PutObjectInput{
+	i := &s3.PutObjectInput{
 		ContentType:   &contentType,
 		ContentLength: &contentLength,
 		Body:          bytes.NewReader(data),
 		Bucket:        &c.bucketName,
 		Key:           &c.keyName,
-	})
+	}
+
+	if c.serverSideEncryption {
+		if c.kmsKeyID != "" {
+			i.ServerSideEncryption = aws.String("aws:kms")
+			i.SSEKMSKeyID = aws.String(c.kmsKeyID)
+		} else {
+			i.ServerSideEncryption = aws.String("AES256")
+		}
+	}
+
+	if c.acl != "" {
+		i.ACL = aws.String(c.acl)
+	}
+
+	_, err := c.nativeClient.PutObject(i)
 
 	if err == nil {
 		return nil
@@ -135,3 +207,124 @@ func (c *S3Client) Delete() error {
 
 	return err
 }
+
+func (c *S3Client) Lock(info *state.LockInfo) error {
+	if c.lockClient == nil {
+		return nil
+	}
+
+	raw, err := json.Marshal(info)
+	if err != nil {
+		return err
+	}
+	jsonLockInfo := string(raw)
+
+	_, err = c.lockClient.PutItem(&dynamodb.PutItemInput{
+		TableName: aws.String(c.lockTable),
+		Item: map[string]*dynamodb.AttributeValue{
+			"LockID": &dynamodb.AttributeValue{S: aws.String(c.lockID())},
+			"Info":   &dynamodb.AttributeValue{S: aws.String(jsonLockInfo)},
+		},
+		ConditionExpression: aws.String("attribute_not_exists(LockID)"),
+	})
+	if err != nil {
+		if awserr := aws.Error(err); awserr != nil && awserr.Code == "ConditionalCheckFailedException" {
+			lockErr := &state.LockError{
+				Err: fmt.Errorf("DynamoDB table %s has a lock for %s", c.lockTable, c.lockID()),
+			}
+			lockErr.Info, _, _ = c.lockInfo()
+			return lockErr
+		}
+		return fmt.Errorf("Failed to lock state: %s", err)
+	}
+
+	c.jsonLockInfo = jsonLockInfo
+	return nil
+}
+
+func (c *S3Client) Unlock() error {
+	if c.jsonLockInfo == "" {
+		return nil
+	}
+
+	if err := c.deleteLock(c.jsonLockInfo); err != nil {
+		return fmt.Errorf("Failed to unlock state: %s", err)
+	}
+
+	c.jsonLockInfo = ""
+	return nil
+}
+
+// ForceUnlock deletes the lock item of the state if it has the given ID.
+func (c *S3Client) ForceUnlock(id string) error {
+	if c.lockClient == nil {
+		return state.ErrForceUnlockUnsupported
+	}
+
+	info, jsonLockInfo, err := c.lockInfo()
+	if err != nil {
+		return fmt.Errorf("Failed to unlock state: %s", err)
+	}
+	if info.ID != id {
+		return fmt.Errorf(
+			"Failed to unlock state: the lock ID doesn't match the lock "+
+				"of the state\n\nLock Info:\n%s", info)
+	}
+
+	if err := c.deleteLock(jsonLockInfo); err != nil {
+		return fmt.Errorf("Failed to unlock state: %s", err)
+	}
+
+	return nil
+}
+
+// deleteLock deletes the lock item of the state, if it still has the
+// given lock information, so a lock taken by someone else in the meantime
+// is left alone.
+func (c *S3Client) deleteLock(jsonLockInfo string) error {
+	_, err := c.lockClient.DeleteItem(&dynamodb.DeleteItemInput{
+		TableName: aws.String(c.lockTable),
+		Key: map[string]*dynamodb.AttributeValue{
+			"LockID": &dynamodb.AttributeValue{S: aws.String(c.lockID())},
+		},
+		ConditionExpression: aws.String("Info = :info"),
+		ExpressionAttributeValues: map[string]*dynamodb.AttributeValue{
+			":info": &dynamodb.AttributeValue{S: aws.String(jsonLockInfo)},
+		},
+	})
+
+	return err
+}
+
+// lockInfo reads the lock information of the lock item of the state,
+// returning it both parsed and as stored.
+func (c *S3Client) lockInfo() (*state.LockInfo, string, error) {
+	output, err := c.lockClient.GetItem(&dynamodb.GetItemInput{
+		TableName: aws.String(c.lockTable),
+		Key: map[string]*dynamodb.AttributeValue{
+			"LockID": &dynamodb.AttributeValue{S: aws.String(c.lockID())},
+		},
+		ConsistentRead: aws.Boolean(true),
+	})
+	if err != nil {
+		return nil, "", err
+	}
+
+	v, ok := output.Item["Info"]
+	if !ok || v.S == nil {
+		return nil, "", fmt.Errorf("no lock for %s", c.lockID())
+	}
+
+	info := new(state.LockInfo)
+	if err := json.Unmarshal([]byte(*v.S), info); err != nil {
+		return nil, "", err
+	}
+
+	return info, *v.S, nil
+}
+
+// lockID returns the hash key of the lock item of the state, which is
+// the bucket and key of the state.
+func (c *S3Client) lockID() string {
+	return fmt.Sprintf("%s/%s", c.bucketName, c.keyName)
+}
@@ -7,10 +7,13 @@ import (
 	"time"
 
 	"github.com/awslabs/aws-sdk-go/service/s3"
+	"github.com/hashicorp/terraform/state"
 )
 
 func TestS3Client_impl(t *testing.T) {
 	var _ Client = new(S3Client)
+	var _ ClientLocker = new(S3Client)
+	var _ ClientForceUnlocker = new(S3Client)
 }
 
 func TestS3Factory(t *testing.T) {
@@ -63,6 +66,91 @@ func TestS3Factory(t *testing.T) {
 	}
 }
 
+func TestS3Factory_lockTable(t *testing.T) {
+	config := map[string]string{
+		"region":     "us-west-1",
+		"bucket":     "foo",
+		"key":        "bar",
+		"access_key": "bazkey",
+		"secret_key": "bazsecret",
+	}
+
+	client, err := s3Factory(config)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if client.(*S3Client).lockClient != nil {
+		t.Fatal("locking should be disabled without a lock table")
+	}
+
+	// Without a lock table, locking is a no-op
+	if err := client.(ClientLocker).Lock(state.NewLockInfo("apply")); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	config["lock_table"] = "terraform-locks"
+	client, err = s3Factory(config)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	s3Client := client.(*S3Client)
+	if s3Client.lockClient == nil || s3Client.lockTable != "terraform-locks" {
+		t.Fatalf("bad: %#v", s3Client)
+	}
+	if id := s3Client.lockID(); id != "foo/bar" {
+		t.Fatalf("bad: %s", id)
+	}
+}
+
+func TestS3Factory_encryption(t *testing.T) {
+	config := map[string]string{
+		"region":     "us-west-1",
+		"bucket":     "foo",
+		"key":        "bar",
+		"access_key": "bazkey",
+		"secret_key": "bazsecret",
+	}
+
+	client, err := s3Factory(config)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if client.(*S3Client).serverSideEncryption {
+		t.Fatal("encryption should be disabled by default")
+	}
+
+	config["encrypt"] = "nope"
+	if _, err := s3Factory(config); err == nil {
+		t.Fatal("should error on an invalid encrypt value")
+	}
+
+	config["encrypt"] = "true"
+	config["acl"] = "bucket-owner-full-control"
+	client, err = s3Factory(config)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	s3Client := client.(*S3Client)
+	if !s3Client.serverSideEncryption || s3Client.kmsKeyID != "" {
+		t.Fatalf("bad: %#v", s3Client)
+	}
+	if s3Client.acl != "bucket-owner-full-control" {
+		t.Fatalf("bad: %s", s3Client.acl)
+	}
+
+	// A KMS key implies encryption
+	delete(config, "encrypt")
+	config["kms_key_id"] = "arn:aws:kms:us-west-1:123456789012:key/abc"
+	client, err = s3Factory(config)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	s3Client = client.(*S3Client)
+	if !s3Client.serverSideEncryption || s3Client.kmsKeyID != config["kms_key_id"] {
+		t.Fatalf("bad: %#v", s3Client)
+	}
+}
+
 func TestS3Client(t *testing.T) {
 	// This test creates a bucket in S3 and populates it.
 	// It may incur costs, so it will only run if AWS credential environment
@@ -85,6 +173,7 @@ func TestS3Client(t *testing.T) {
 	config["region"] = regionName
 	config["bucket"] = bucketName
 	config["key"] = keyName
+	config["encrypt"] = "true"
 
 	client, err := s3Factory(config)
 	if err != nil {
@@ -3,6 +3,7 @@ package remote
 import (
 	"bytes"
 
+	"github.com/hashicorp/terraform/state"
 	"github.com/hashicorp/terraform/terraform"
 )
 
@@ -58,3 +59,35 @@ func (s *State) PersistState() error {
 
 	return s.Client.Put(buf.Bytes())
 }
+
+// Lock locks the remote state if the client supports locking.
+//
+// state.Locker impl.
+func (s *State) Lock(operation string) error {
+	if l, ok := s.Client.(ClientLocker); ok {
+		return l.Lock(state.NewLockInfo(operation))
+	}
+
+	return nil
+}
+
+// state.Locker impl.
+func (s *State) Unlock() error {
+	if l, ok := s.Client.(ClientLocker); ok {
+		return l.Unlock()
+	}
+
+	return nil
+}
+
+// ForceUnlock removes the lock with the given ID, if the client supports
+// it.
+//
+// state.ForceUnlocker impl.
+func (s *State) ForceUnlock(id string) error {
+	if l, ok := s.Client.(ClientForceUnlocker); ok {
+		return l.ForceUnlock(id)
+	}
+
+	return state.ErrForceUnlockUnsupported
+}
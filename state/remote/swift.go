@@ -0,0 +1,186 @@
+package remote
+
+import (
+	"bytes"
+	"crypto/md5"
+	"crypto/tls"
+	"fmt"
+	"net/http"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/rackspace/gophercloud"
+	"github.com/rackspace/gophercloud/openstack"
+	"github.com/rackspace/gophercloud/openstack/objectstorage/v1/containers"
+	"github.com/rackspace/gophercloud/openstack/objectstorage/v1/objects"
+)
+
+const swiftDefaultObject = "terraform.tfstate"
+
+func swiftFactory(conf map[string]string) (Client, error) {
+	client := &SwiftClient{
+		Container: conf["container"],
+		Object:    conf["object"],
+		Region:    swiftConf(conf, "region", "OS_REGION_NAME"),
+	}
+	if client.Container == "" {
+		return nil, fmt.Errorf("missing 'container' configuration")
+	}
+	if client.Object == "" {
+		client.Object = swiftDefaultObject
+	}
+
+	if v, ok := conf["expire_after"]; ok && v != "" {
+		d, err := time.ParseDuration(v)
+		if err != nil {
+			return nil, fmt.Errorf("invalid 'expire_after' value: %s", err)
+		}
+		if d < time.Second {
+			return nil, fmt.Errorf("'expire_after' must be at least one second")
+		}
+
+		client.ExpireAfter = d
+	}
+
+	ao := gophercloud.AuthOptions{
+		IdentityEndpoint: swiftConf(conf, "auth_url", "OS_AUTH_URL"),
+		Username:         swiftConf(conf, "user_name", "OS_USERNAME"),
+		UserID:           swiftConf(conf, "user_id", "OS_USER_ID"),
+		Password:         swiftConf(conf, "password", "OS_PASSWORD"),
+		APIKey:           swiftConf(conf, "api_key", "OS_API_KEY"),
+		TenantID:         swiftConf(conf, "tenant_id", "OS_TENANT_ID"),
+		TenantName:       swiftConf(conf, "tenant_name", "OS_TENANT_NAME"),
+		DomainID:         swiftConf(conf, "domain_id", "OS_DOMAIN_ID"),
+		DomainName:       swiftConf(conf, "domain_name", "OS_DOMAIN_NAME"),
+	}
+	if ao.IdentityEndpoint == "" {
+		return nil, fmt.Errorf(
+			"missing 'auth_url' configuration or OS_AUTH_URL environment variable")
+	}
+
+	insecure := false
+	if v := swiftConf(conf, "insecure", "OS_INSECURE"); v != "" {
+		var err error
+		insecure, err = strconv.ParseBool(v)
+		if err != nil {
+			return nil, fmt.Errorf("invalid 'insecure' value: %s", err)
+		}
+	}
+
+	if err := client.connect(ao, insecure); err != nil {
+		return nil, err
+	}
+
+	return client, nil
+}
+
+// swiftConf returns the configuration value for the given key, falling
+// back to the given environment variable.
+func swiftConf(conf map[string]string, key, env string) string {
+	if v, ok := conf[key]; ok && v != "" {
+		return v
+	}
+
+	return os.Getenv(env)
+}
+
+// SwiftClient stores the state as an object in an OpenStack Swift
+// container, authenticating with Keystone.
+type SwiftClient struct {
+	Container string
+	Object    string
+	Region    string
+
+	// ExpireAfter, if non-zero, makes Swift delete the state object this
+	// long after it was last written.
+	ExpireAfter time.Duration
+
+	client *gophercloud.ServiceClient
+}
+
+func (c *SwiftClient) connect(ao gophercloud.AuthOptions, insecure bool) error {
+	provider, err := openstack.NewClient(ao.IdentityEndpoint)
+	if err != nil {
+		return fmt.Errorf("Error creating OpenStack client: %s", err)
+	}
+
+	if insecure {
+		provider.HTTPClient.Transport = &http.Transport{
+			TLSClientConfig: &tls.Config{InsecureSkipVerify: true},
+		}
+	}
+
+	if err := openstack.Authenticate(provider, ao); err != nil {
+		return fmt.Errorf("Error authenticating with OpenStack: %s", err)
+	}
+
+	c.client, err = openstack.NewObjectStorageV1(provider, gophercloud.EndpointOpts{
+		Region: c.Region,
+	})
+	if err != nil {
+		return fmt.Errorf("Error creating Swift client: %s", err)
+	}
+
+	return nil
+}
+
+func (c *SwiftClient) Get() (*Payload, error) {
+	result := objects.Download(c.client, c.Container, c.Object, nil)
+	data, err := result.ExtractContent()
+	if err != nil {
+		if swiftNotFound(err) {
+			return nil, nil
+		}
+
+		return nil, fmt.Errorf("Failed to download state: %s", err)
+	}
+
+	// If there was no data, then return nil
+	if len(data) == 0 {
+		return nil, nil
+	}
+
+	hash := md5.Sum(data)
+	return &Payload{
+		Data: data,
+		MD5:  hash[:],
+	}, nil
+}
+
+func (c *SwiftClient) Put(data []byte) error {
+	// Make sure the container exists. Creating an existing container
+	// is a no-op in Swift.
+	if err := containers.Create(c.client, c.Container, nil).Err; err != nil {
+		return fmt.Errorf("Failed to create container %q: %s", c.Container, err)
+	}
+
+	opts := objects.CreateOpts{
+		ContentType: "application/json",
+	}
+	if c.ExpireAfter > 0 {
+		opts.DeleteAfter = int(c.ExpireAfter / time.Second)
+	}
+
+	result := objects.Create(
+		c.client, c.Container, c.Object, bytes.NewReader(data), opts)
+	if result.Err != nil {
+		return fmt.Errorf("Failed to upload state: %s", result.Err)
+	}
+
+	return nil
+}
+
+func (c *SwiftClient) Delete() error {
+	err := objects.Delete(c.client, c.Container, c.Object, nil).Err
+	if err != nil && !swiftNotFound(err) {
+		return fmt.Errorf("Failed to delete state: %s", err)
+	}
+
+	return nil
+}
+
+func swiftNotFound(err error) bool {
+	e, ok := err.(*gophercloud.UnexpectedResponseCodeError)
+	return ok && e.Actual == http.StatusNotFound
+}
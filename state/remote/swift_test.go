@@ -0,0 +1,78 @@
+package remote
+
+import (
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/rackspace/gophercloud/openstack/objectstorage/v1/containers"
+)
+
+func TestSwiftClient_impl(t *testing.T) {
+	var _ Client = new(SwiftClient)
+}
+
+func TestSwiftFactory(t *testing.T) {
+	// These configurations are all invalid before any request is made,
+	// so this doesn't need an OpenStack account.
+	defer os.Setenv("OS_AUTH_URL", os.Getenv("OS_AUTH_URL"))
+	os.Setenv("OS_AUTH_URL", "")
+
+	cases := []map[string]string{
+		map[string]string{},
+		map[string]string{
+			"container": "terraform",
+		},
+		map[string]string{
+			"container":    "terraform",
+			"auth_url":     "http://127.0.0.1:5000/v2.0",
+			"expire_after": "tomorrow",
+		},
+		map[string]string{
+			"container":    "terraform",
+			"auth_url":     "http://127.0.0.1:5000/v2.0",
+			"expire_after": "10ms",
+		},
+		map[string]string{
+			"container": "terraform",
+			"auth_url":  "http://127.0.0.1:5000/v2.0",
+			"insecure":  "sometimes",
+		},
+	}
+
+	for i, conf := range cases {
+		if _, err := swiftFactory(conf); err == nil {
+			t.Fatalf("%d: should error", i)
+		}
+	}
+}
+
+func TestSwiftClient(t *testing.T) {
+	// This test creates a container in Swift and populates it, so it
+	// will only run if OpenStack credentials are present.
+	if os.Getenv("OS_AUTH_URL") == "" {
+		t.Skipf("skipping; OS_AUTH_URL must be set")
+	}
+
+	container := fmt.Sprintf("terraform-remote-swift-test-%x", time.Now().Unix())
+	client, err := swiftFactory(map[string]string{
+		"container":    container,
+		"expire_after": "1h",
+	})
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	// Be clear about what we're doing in case the user needs to clean
+	// this up later.
+	t.Logf("Using Swift container %s", container)
+	defer func() {
+		swift := client.(*SwiftClient)
+		if err := containers.Delete(swift.client, container).Err; err != nil {
+			t.Logf("WARNING: Failed to delete the test Swift container %s: %s", container, err)
+		}
+	}()
+
+	testClient(t, client)
+}
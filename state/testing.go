@@ -36,7 +36,7 @@ func TestState(t *testing.T, s interface{}) {
 	if ws, ok := s.(StateWriter); ok {
 		current.Modules = append(current.Modules, &terraform.ModuleState{
 			Path: []string{"root"},
-			Outputs: map[string]string{
+			Outputs: map[string]interface{}{
 				"bar": "baz",
 			},
 		})
@@ -94,7 +94,7 @@ func TestState(t *testing.T, s interface{}) {
 		current.Modules = []*terraform.ModuleState{
 			&terraform.ModuleState{
 				Path:    []string{"root", "somewhere"},
-				Outputs: map[string]string{"serialCheck": "true"},
+				Outputs: map[string]interface{}{"serialCheck": "true"},
 			},
 		}
 		if err := writer.WriteState(current); err != nil {
@@ -123,7 +123,7 @@ func TestStateInitial() *terraform.State {
 		Modules: []*terraform.ModuleState{
 			&terraform.ModuleState{
 				Path: []string{"root", "child"},
-				Outputs: map[string]string{
+				Outputs: map[string]interface{}{
 					"foo": "bar",
 				},
 			},
@@ -35,15 +35,22 @@ const (
 type ContextOpts struct {
 	Destroy      bool
 	Diff         *Diff
+	Env          string
 	Hooks        []Hook
 	Module       *module.Tree
 	Parallelism  int
 	State        *State
 	Providers    map[string]ResourceProviderFactory
 	Provisioners map[string]ResourceProvisionerFactory
+	Replace      []string
 	Targets      []string
 	Variables    map[string]string
 
+	// ProviderParallelism limits the number of nodes of the same provider
+	// that are evaluated at once, on top of Parallelism, to avoid the
+	// rate limits of its API. It is unlimited if zero.
+	ProviderParallelism int
+
 	UIInput UIInput
 }
 
@@ -54,10 +61,12 @@ type Context struct {
 	destroy      bool
 	diff         *Diff
 	diffLock     sync.RWMutex
+	env          string
 	hooks        []Hook
 	module       *module.Tree
 	providers    map[string]ResourceProviderFactory
 	provisioners map[string]ResourceProvisionerFactory
+	replace      []string
 	sh           *stopHook
 	state        *State
 	stateLock    sync.RWMutex
@@ -67,8 +76,10 @@ type Context struct {
 
 	l                   sync.Mutex // Lock acquired during any task
 	parallelSem         Semaphore
+	providerParallelism int
 	providerInputConfig map[string]map[string]interface{}
 	runCh               <-chan struct{}
+	walker              *ContextGraphWalker
 }
 
 // NewContext creates a new Context structure.
@@ -101,16 +112,19 @@ func NewContext(opts *ContextOpts) *Context {
 	return &Context{
 		destroy:      opts.Destroy,
 		diff:         opts.Diff,
+		env:          opts.Env,
 		hooks:        hooks,
 		module:       opts.Module,
 		providers:    opts.Providers,
 		provisioners: opts.Provisioners,
+		replace:      opts.Replace,
 		state:        state,
 		targets:      opts.Targets,
 		uiInput:      opts.UIInput,
 		variables:    opts.Variables,
 
 		parallelSem:         NewSemaphore(par),
+		providerParallelism: opts.ProviderParallelism,
 		providerInputConfig: make(map[string]map[string]interface{}),
 		sh:                  sh,
 	}
@@ -119,6 +133,11 @@ func NewContext(opts *ContextOpts) *Context {
 type ContextGraphOpts struct {
 	Validate bool
 	Verbose  bool
+
+	// Type is the operation to build the graph for. By default, the graph
+	// is built with the diff and destroy mode of the context, which is
+	// the same as GraphTypeApply.
+	Type GraphType
 }
 
 // Graph returns the graph for this config.
@@ -140,14 +159,24 @@ func (c *Context) graphBuilder(g *ContextGraphOpts) GraphBuilder {
 		provisioners = append(provisioners, k)
 	}
 
+	// Plans are built without a diff, which they create
+	diff, destroy := c.diff, c.destroy
+	switch g.Type {
+	case GraphTypePlan:
+		diff, destroy = nil, false
+	case GraphTypePlanDestroy:
+		diff, destroy = nil, true
+	}
+
 	return &BuiltinGraphBuilder{
 		Root:         c.module,
-		Diff:         c.diff,
+		Diff:         diff,
 		Providers:    providers,
 		Provisioners: provisioners,
 		State:        c.state,
 		Targets:      c.targets,
-		Destroy:      c.destroy,
+		Destroy:      destroy,
+		Refresh:      g.Type == GraphTypeRefresh,
 		Validate:     g.Validate,
 		Verbose:      g.Verbose,
 	}
@@ -286,6 +315,18 @@ func (c *Context) Plan() (*Plan, error) {
 	v := c.acquireRun()
 	defer c.releaseRun(v)
 
+	// Resources to replace are tainted in a copy of our state, so the
+	// plan replaces them and Apply, either with this context or from
+	// the plan, destroys the tainted instances.
+	if !c.destroy && len(c.replace) > 0 {
+		state := c.state.DeepCopy()
+		if err := state.taintResources(c.replace); err != nil {
+			return nil, err
+		}
+
+		c.state = state
+	}
+
 	p := &Plan{
 		Module: c.module,
 		Vars:   c.variables,
@@ -354,7 +395,10 @@ func (c *Context) Refresh() (*State, error) {
 	c.state = c.state.DeepCopy()
 
 	// Build the graph
-	graph, err := c.Graph(&ContextGraphOpts{Validate: true})
+	graph, err := c.Graph(&ContextGraphOpts{
+		Validate: true,
+		Type:     GraphTypeRefresh,
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -370,6 +414,55 @@ func (c *Context) Refresh() (*State, error) {
 	return c.state, nil
 }
 
+// Import imports the given existing resources into the state, so that
+// they are managed from then on, and returns the updated state. The
+// resources must be in the configuration of the root module, and must not
+// be in the state already.
+func (c *Context) Import(targets []*ImportTarget) (*State, error) {
+	v := c.acquireRun()
+	defer c.releaseRun(v)
+
+	// Copy our own state
+	c.state = c.state.DeepCopy()
+
+	// Check the targets before we call any provider
+	var errs error
+	mod := c.state.ModuleByPath(rootModulePath)
+	for _, t := range targets {
+		n, err := importStateNode(c.module, t)
+		if err != nil {
+			errs = multierror.Append(errs, err)
+			continue
+		}
+
+		id := n.resource.stateId()
+		if mod != nil && mod.Resources[id] != nil {
+			errs = multierror.Append(errs, fmt.Errorf(
+				"%s: resource is already managed by Terraform", id))
+		}
+	}
+	if errs != nil {
+		return nil, errs
+	}
+
+	// Build the graph. The targets of the context don't apply, as only
+	// the resources to import are changed.
+	builder := c.graphBuilder(&ContextGraphOpts{Validate: true}).(*BuiltinGraphBuilder)
+	builder.Imports = targets
+	builder.Targets = nil
+	graph, err := builder.Build(RootModulePath)
+	if err != nil {
+		return nil, err
+	}
+
+	// Do the walk
+	if _, err := c.walk(graph, walkImport); err != nil {
+		return nil, err
+	}
+
+	return c.state, nil
+}
+
 // Stop stops the running task.
 //
 // Stop will block until the task completes.
@@ -386,8 +479,15 @@ func (c *Context) Stop() {
 	// Tell the hook we want to stop
 	c.sh.Stop()
 
+	// Tell the providers to abort what they're doing, so we don't have
+	// to wait for long running operations to finish.
+	walker := c.walker
+
 	// Wait for us to stop
 	c.l.Unlock()
+	if walker != nil {
+		walker.stopProviders()
+	}
 	<-ch
 }
 
@@ -478,7 +578,18 @@ func (c *Context) releaseRun(ch chan<- struct{}) {
 func (c *Context) walk(
 	graph *Graph, operation walkOperation) (*ContextGraphWalker, error) {
 	// Walk the graph
-	log.Printf("[INFO] Starting graph walk: %s", operation.String())
+	log.Printf("[INFO] core: Starting graph walk: %s", operation.String())
 	walker := &ContextGraphWalker{Context: c, Operation: operation}
+
+	// Keep track of the walker so that Stop can reach its providers
+	c.l.Lock()
+	c.walker = walker
+	c.l.Unlock()
+	defer func() {
+		c.l.Lock()
+		c.walker = nil
+		c.l.Unlock()
+	}()
+
 	return walker, graph.Walk(walker)
 }
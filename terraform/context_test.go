@@ -423,6 +423,29 @@ func TestContext2Plan_moduleProviderDefaultsVar(t *testing.T) {
 	}
 }
 
+func TestContext2Plan_locals(t *testing.T) {
+	m := testModule(t, "plan-locals")
+	p := testProvider("aws")
+	p.DiffFn = testDiffFn
+	ctx := testContext2(t, &ContextOpts{
+		Module: m,
+		Providers: map[string]ResourceProviderFactory{
+			"aws": testProviderFuncFixed(p),
+		},
+	})
+
+	plan, err := ctx.Plan()
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	actual := strings.TrimSpace(plan.String())
+	expected := strings.TrimSpace(testTerraformPlanLocalsStr)
+	if actual != expected {
+		t.Fatalf("bad:\n%s", actual)
+	}
+}
+
 func TestContext2Plan_moduleVar(t *testing.T) {
 	m := testModule(t, "plan-module-var")
 	p := testProvider("aws")
@@ -670,6 +693,139 @@ func TestContext2Plan_computed(t *testing.T) {
 	}
 }
 
+func TestContext2Plan_dataSource(t *testing.T) {
+	m := testModule(t, "plan-data-source")
+	p := testProvider("aws")
+	p.DiffFn = testDiffFn
+	p.ReadDataSourceReturn = &InstanceState{
+		ID:         "foo",
+		Attributes: map[string]string{"foo": "bar"},
+	}
+	ctx := testContext2(t, &ContextOpts{
+		Module: m,
+		Providers: map[string]ResourceProviderFactory{
+			"aws": testProviderFuncFixed(p),
+		},
+	})
+
+	plan, err := ctx.Plan()
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if !p.ReadDataSourceCalled {
+		t.Fatal("read data source should be called")
+	}
+
+	rd := plan.Diff.RootModule().Resources["aws_instance.foo"]
+	if rd == nil || rd.Attributes["foo"] == nil {
+		t.Fatalf("bad: %#v", plan.Diff)
+	}
+	if rd.Attributes["foo"].New != "bar" {
+		t.Fatalf("bad: %#v", rd.Attributes["foo"])
+	}
+	if _, ok := plan.Diff.RootModule().Resources["data.aws_data_source.foo"]; ok {
+		t.Fatalf("data source shouldn't be in the diff: %#v", plan.Diff)
+	}
+}
+
+func TestContext2Plan_dataSourceCount(t *testing.T) {
+	m := testModule(t, "plan-data-source-count")
+	p := testProvider("aws")
+	p.DiffFn = testDiffFn
+	p.ReadDataSourceReturn = &InstanceState{
+		ID: "foo",
+		Attributes: map[string]string{
+			"names.#": "2",
+			"names.0": "a",
+			"names.1": "b",
+		},
+	}
+	ctx := testContext2(t, &ContextOpts{
+		Module: m,
+		Providers: map[string]ResourceProviderFactory{
+			"aws": testProviderFuncFixed(p),
+		},
+	})
+
+	if _, errs := ctx.Validate(); len(errs) > 0 {
+		t.Fatalf("bad: %#v", errs)
+	}
+
+	plan, err := ctx.Plan()
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	resources := plan.Diff.RootModule().Resources
+	for i, name := range []string{"a", "b"} {
+		rd := resources[fmt.Sprintf("aws_instance.foo.%d", i)]
+		if rd == nil || rd.Attributes["foo"] == nil {
+			t.Fatalf("bad: %#v", plan.Diff)
+		}
+		if rd.Attributes["foo"].New != name {
+			t.Fatalf("bad: %#v", rd.Attributes["foo"])
+		}
+	}
+	if len(resources) != 2 {
+		t.Fatalf("bad: %#v", plan.Diff)
+	}
+}
+
+func TestContext2Plan_dataSourceOrphan(t *testing.T) {
+	m := testModule(t, "plan-data-source-orphan")
+	p := testProvider("aws")
+	p.ApplyFn = func(
+		info *InstanceInfo,
+		s *InstanceState,
+		d *InstanceDiff) (*InstanceState, error) {
+		if info.Type == "aws_data_source" {
+			return nil, fmt.Errorf("data source shouldn't be applied")
+		}
+
+		return testApplyFn(info, s, d)
+	}
+	p.DiffFn = testDiffFn
+	ctx := testContext2(t, &ContextOpts{
+		Module: m,
+		Providers: map[string]ResourceProviderFactory{
+			"aws": testProviderFuncFixed(p),
+		},
+		State: &State{
+			Modules: []*ModuleState{
+				&ModuleState{
+					Path: rootModulePath,
+					Resources: map[string]*ResourceState{
+						"data.aws_data_source.foo": &ResourceState{
+							Type: "aws_data_source",
+							Primary: &InstanceState{
+								ID: "foo",
+							},
+						},
+					},
+				},
+			},
+		},
+	})
+
+	plan, err := ctx.Plan()
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	rd := plan.Diff.RootModule().Resources["data.aws_data_source.foo"]
+	if rd == nil || !rd.Destroy {
+		t.Fatalf("bad: %#v", plan.Diff)
+	}
+
+	state, err := ctx.Apply()
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if _, ok := state.RootModule().Resources["data.aws_data_source.foo"]; ok {
+		t.Fatalf("data source should be removed: %s", state)
+	}
+}
+
 func TestContext2Plan_computedList(t *testing.T) {
 	m := testModule(t, "plan-computed-list")
 	p := testProvider("aws")
@@ -1506,6 +1662,76 @@ func TestContext2Plan_multiple_taint(t *testing.T) {
 	}
 }
 
+func TestContext2Plan_replace(t *testing.T) {
+	m := testModule(t, "plan-taint")
+	p := testProvider("aws")
+	p.DiffFn = testDiffFn
+	s := &State{
+		Modules: []*ModuleState{
+			&ModuleState{
+				Path: rootModulePath,
+				Resources: map[string]*ResourceState{
+					"aws_instance.foo": &ResourceState{
+						Type: "aws_instance",
+						Primary: &InstanceState{
+							ID:         "bar",
+							Attributes: map[string]string{"num": "2"},
+						},
+					},
+					"aws_instance.bar": &ResourceState{
+						Type: "aws_instance",
+						Primary: &InstanceState{
+							ID: "baz",
+						},
+					},
+				},
+			},
+		},
+	}
+	ctx := testContext2(t, &ContextOpts{
+		Module: m,
+		Providers: map[string]ResourceProviderFactory{
+			"aws": testProviderFuncFixed(p),
+		},
+		Replace: []string{"aws_instance.bar"},
+		State:   s,
+	})
+
+	plan, err := ctx.Plan()
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	actual := strings.TrimSpace(plan.String())
+	expected := strings.TrimSpace(testTerraformPlanTaintStr)
+	if actual != expected {
+		t.Fatalf("bad:\n%s", actual)
+	}
+
+	// The given state isn't modified
+	rs := s.RootModule().Resources["aws_instance.bar"]
+	if rs.Primary == nil || len(rs.Tainted) != 0 {
+		t.Fatalf("bad: %#v", rs)
+	}
+}
+
+func TestContext2Plan_replaceMissing(t *testing.T) {
+	m := testModule(t, "plan-taint")
+	p := testProvider("aws")
+	p.DiffFn = testDiffFn
+	ctx := testContext2(t, &ContextOpts{
+		Module: m,
+		Providers: map[string]ResourceProviderFactory{
+			"aws": testProviderFuncFixed(p),
+		},
+		Replace: []string{"aws_instance.nope"},
+	})
+
+	if _, err := ctx.Plan(); err == nil {
+		t.Fatal("should error")
+	}
+}
+
 func TestContext2Plan_targeted(t *testing.T) {
 	m := testModule(t, "plan-targeted")
 	p := testProvider("aws")
@@ -1609,6 +1835,123 @@ func TestContext2Plan_varListErr(t *testing.T) {
 	}
 }
 
+func TestContext2Import(t *testing.T) {
+	p := testProvider("aws")
+	m := testModule(t, "import-basic")
+	ctx := testContext2(t, &ContextOpts{
+		Module: m,
+		Providers: map[string]ResourceProviderFactory{
+			"aws": testProviderFuncFixed(p),
+		},
+	})
+
+	p.ImportStateFn = func(
+		info *InstanceInfo, id string) (*InstanceState, error) {
+		return &InstanceState{
+			ID:         id,
+			Attributes: map[string]string{"id": id},
+		}, nil
+	}
+
+	s, err := ctx.Import([]*ImportTarget{
+		&ImportTarget{Addr: "aws_instance.foo", ID: "i-abc123"},
+		&ImportTarget{Addr: "aws_instance.bar[1]", ID: "i-def456"},
+	})
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if !p.ConfigureCalled {
+		t.Fatal("configure should be called")
+	}
+	if v, ok := p.ConfigureConfig.Get("foo"); !ok || v != "bar" {
+		t.Fatalf("bad: %#v", p.ConfigureConfig)
+	}
+
+	actual := strings.TrimSpace(s.String())
+	expected := strings.TrimSpace(testTerraformImportStr)
+	if actual != expected {
+		t.Fatalf("bad:\n\n%s", actual)
+	}
+}
+
+func TestContext2Import_notInConfig(t *testing.T) {
+	p := testProvider("aws")
+	m := testModule(t, "import-basic")
+	ctx := testContext2(t, &ContextOpts{
+		Module: m,
+		Providers: map[string]ResourceProviderFactory{
+			"aws": testProviderFuncFixed(p),
+		},
+	})
+
+	_, err := ctx.Import([]*ImportTarget{
+		&ImportTarget{Addr: "aws_instance.baz", ID: "i-abc123"},
+	})
+	if err == nil {
+		t.Fatal("should error")
+	}
+	if p.ImportStateCalled {
+		t.Fatal("import should not be called")
+	}
+}
+
+func TestContext2Import_alreadyManaged(t *testing.T) {
+	p := testProvider("aws")
+	m := testModule(t, "import-basic")
+	ctx := testContext2(t, &ContextOpts{
+		Module: m,
+		Providers: map[string]ResourceProviderFactory{
+			"aws": testProviderFuncFixed(p),
+		},
+		State: &State{
+			Modules: []*ModuleState{
+				&ModuleState{
+					Path: rootModulePath,
+					Resources: map[string]*ResourceState{
+						"aws_instance.foo": &ResourceState{
+							Type: "aws_instance",
+							Primary: &InstanceState{
+								ID: "i-abc123",
+							},
+						},
+					},
+				},
+			},
+		},
+	})
+
+	_, err := ctx.Import([]*ImportTarget{
+		&ImportTarget{Addr: "aws_instance.foo", ID: "i-def456"},
+	})
+	if err == nil {
+		t.Fatal("should error")
+	}
+	if p.ImportStateCalled {
+		t.Fatal("import should not be called")
+	}
+}
+
+func TestContext2Import_notFound(t *testing.T) {
+	p := testProvider("aws")
+	m := testModule(t, "import-basic")
+	ctx := testContext2(t, &ContextOpts{
+		Module: m,
+		Providers: map[string]ResourceProviderFactory{
+			"aws": testProviderFuncFixed(p),
+		},
+	})
+
+	_, err := ctx.Import([]*ImportTarget{
+		&ImportTarget{Addr: "aws_instance.foo", ID: "i-abc123"},
+	})
+	if err == nil {
+		t.Fatal("should error")
+	}
+	if !p.ImportStateCalled {
+		t.Fatal("import should be called")
+	}
+}
+
 func TestContext2Refresh(t *testing.T) {
 	p := testProvider("aws")
 	m := testModule(t, "refresh-basic")
@@ -1661,6 +2004,44 @@ func TestContext2Refresh(t *testing.T) {
 	}
 }
 
+func TestContext2Refresh_dataSource(t *testing.T) {
+	p := testProvider("aws")
+	m := testModule(t, "refresh-data-source")
+	ctx := testContext2(t, &ContextOpts{
+		Module: m,
+		Providers: map[string]ResourceProviderFactory{
+			"aws": testProviderFuncFixed(p),
+		},
+	})
+
+	p.ReadDataSourceReturn = &InstanceState{
+		ID:         "foo",
+		Attributes: map[string]string{"foo": "bar"},
+	}
+
+	s, err := ctx.Refresh()
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if !p.ReadDataSourceCalled {
+		t.Fatal("read data source should be called")
+	}
+	if p.ReadDataSourceInfo.Type != "aws_data_source" {
+		t.Fatalf("bad: %#v", p.ReadDataSourceInfo)
+	}
+	if v := p.ReadDataSourceConfig.Config["foo"]; v != "bar" {
+		t.Fatalf("bad: %#v", p.ReadDataSourceConfig)
+	}
+
+	rs := s.RootModule().Resources["data.aws_data_source.foo"]
+	if rs == nil || !reflect.DeepEqual(rs.Primary, p.ReadDataSourceReturn) {
+		t.Fatalf("bad: %s", s)
+	}
+	if rs.Type != "aws_data_source" {
+		t.Fatalf("bad: %#v", rs)
+	}
+}
+
 func TestContext2Refresh_targeted(t *testing.T) {
 	p := testProvider("aws")
 	m := testModule(t, "refresh-targeted")
@@ -1898,6 +2279,120 @@ func TestContext2Refresh_hook(t *testing.T) {
 	}
 }
 
+func TestContext2Refresh_parallel(t *testing.T) {
+	m := testModule(t, "refresh-parallel")
+
+	// Each refresh waits for the others, which only finishes in time if
+	// the resources are refreshed at once, despite their dependencies.
+	var inflight, max int32
+	p := &testConcurrentRefreshProvider{
+		MockResourceProvider: testProvider("aws"),
+		refreshFn: func(info *InstanceInfo, s *InstanceState) (*InstanceState, error) {
+			n := atomic.AddInt32(&inflight, 1)
+			defer atomic.AddInt32(&inflight, -1)
+			testRecordMax(&max, n)
+
+			deadline := time.Now().Add(2 * time.Second)
+			for atomic.LoadInt32(&inflight) < 3 && time.Now().Before(deadline) {
+				time.Sleep(10 * time.Millisecond)
+			}
+			testRecordMax(&max, atomic.LoadInt32(&inflight))
+
+			return s, nil
+		},
+	}
+
+	ctx := testContext2(t, &ContextOpts{
+		Module: m,
+		Providers: map[string]ResourceProviderFactory{
+			"aws": testProviderFuncFixed(p),
+		},
+		State: testRefreshParallelState(),
+	})
+
+	if _, err := ctx.Refresh(); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if max != 3 {
+		t.Fatalf("expected 3 concurrent refreshes, got %d", max)
+	}
+}
+
+func TestContext2Refresh_providerParallelism(t *testing.T) {
+	m := testModule(t, "refresh-parallel")
+
+	var inflight, max int32
+	p := &testConcurrentRefreshProvider{
+		MockResourceProvider: testProvider("aws"),
+		refreshFn: func(info *InstanceInfo, s *InstanceState) (*InstanceState, error) {
+			n := atomic.AddInt32(&inflight, 1)
+			defer atomic.AddInt32(&inflight, -1)
+			testRecordMax(&max, n)
+
+			time.Sleep(20 * time.Millisecond)
+			return s, nil
+		},
+	}
+
+	ctx := testContext2(t, &ContextOpts{
+		Module: m,
+		Providers: map[string]ResourceProviderFactory{
+			"aws": testProviderFuncFixed(p),
+		},
+		State:               testRefreshParallelState(),
+		ProviderParallelism: 1,
+	})
+
+	if _, err := ctx.Refresh(); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if max != 1 {
+		t.Fatalf("expected 1 refresh at a time, got %d", max)
+	}
+}
+
+// testConcurrentRefreshProvider is a mock provider whose refreshes can
+// run concurrently, unlike those of MockResourceProvider, which holds its
+// lock while refreshing.
+type testConcurrentRefreshProvider struct {
+	*MockResourceProvider
+
+	refreshFn func(*InstanceInfo, *InstanceState) (*InstanceState, error)
+}
+
+func (p *testConcurrentRefreshProvider) Refresh(
+	info *InstanceInfo, s *InstanceState) (*InstanceState, error) {
+	return p.refreshFn(info, s)
+}
+
+func testRecordMax(max *int32, n int32) {
+	for {
+		old := atomic.LoadInt32(max)
+		if n <= old || atomic.CompareAndSwapInt32(max, old, n) {
+			return
+		}
+	}
+}
+
+func testRefreshParallelState() *State {
+	resources := make(map[string]*ResourceState)
+	for _, name := range []string{"A", "B", "C"} {
+		resources["aws_instance."+name] = &ResourceState{
+			Type:    "aws_instance",
+			Primary: &InstanceState{ID: name},
+		}
+	}
+
+	return &State{
+		Modules: []*ModuleState{
+			&ModuleState{
+				Path:      rootModulePath,
+				Resources: resources,
+			},
+		},
+	}
+}
+
 func TestContext2Refresh_modules(t *testing.T) {
 	p := testProvider("aws")
 	m := testModule(t, "refresh-modules")
@@ -2036,7 +2531,7 @@ func TestContext2Refresh_output(t *testing.T) {
 						},
 					},
 
-					Outputs: map[string]string{
+					Outputs: map[string]interface{}{
 						"foo": "foo",
 					},
 				},
@@ -3453,6 +3948,39 @@ func TestContext2Apply(t *testing.T) {
 	}
 }
 
+// Apply must keep the dependencies that the refresh graph drops, so that
+// resources are created after the ones they interpolate.
+func TestContext2Apply_dependencyOrder(t *testing.T) {
+	m := testModule(t, "refresh-parallel")
+	p := testProvider("aws")
+	p.DiffFn = testDiffFn
+
+	var order []string
+	p.ApplyFn = func(info *InstanceInfo, s *InstanceState, d *InstanceDiff) (*InstanceState, error) {
+		order = append(order, info.Id)
+		return testApplyFn(info, s, d)
+	}
+
+	ctx := testContext2(t, &ContextOpts{
+		Module: m,
+		Providers: map[string]ResourceProviderFactory{
+			"aws": testProviderFuncFixed(p),
+		},
+	})
+
+	if _, err := ctx.Plan(); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if _, err := ctx.Apply(); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	expected := []string{"aws_instance.A", "aws_instance.B", "aws_instance.C"}
+	if !reflect.DeepEqual(order, expected) {
+		t.Fatalf("bad order: %#v", order)
+	}
+}
+
 func TestContext2Apply_providerAlias(t *testing.T) {
 	m := testModule(t, "apply-provider-alias")
 	p := testProvider("aws")
@@ -3750,6 +4278,52 @@ func TestContext2Apply_cancel(t *testing.T) {
 	}
 }
 
+func TestContext2Apply_cancelProvider(t *testing.T) {
+	m := testModule(t, "apply-cancel")
+	p := testProvider("aws")
+	ctx := testContext2(t, &ContextOpts{
+		Module: m,
+		Providers: map[string]ResourceProviderFactory{
+			"aws": testProviderFuncFixed(p),
+		},
+	})
+
+	// The apply of the first resource blocks until the provider is
+	// stopped, like a provider waiting on a resource would.
+	stopCh := make(chan struct{})
+	p.StopFn = func() error {
+		close(stopCh)
+		return nil
+	}
+	applied := false
+	p.ApplyFn = func(*InstanceInfo, *InstanceState, *InstanceDiff) (*InstanceState, error) {
+		if !applied {
+			applied = true
+			go ctx.Stop()
+		}
+
+		select {
+		case <-stopCh:
+			return nil, fmt.Errorf("interrupted")
+		case <-time.After(5 * time.Second):
+			return nil, fmt.Errorf("provider wasn't stopped")
+		}
+	}
+	p.DiffFn = testDiffFn
+
+	if _, err := ctx.Plan(); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	_, err := ctx.Apply()
+	if err == nil || !strings.Contains(err.Error(), "interrupted") {
+		t.Fatalf("bad: %s", err)
+	}
+	if !p.StopCalled {
+		t.Fatal("provider should be stopped")
+	}
+}
+
 func TestContext2Apply_compute(t *testing.T) {
 	m := testModule(t, "apply-compute")
 	p := testProvider("aws")
@@ -4119,7 +4693,7 @@ func TestContext2Apply_moduleDestroyOrder(t *testing.T) {
 						},
 					},
 				},
-				Outputs: map[string]string{
+				Outputs: map[string]interface{}{
 					"a_output": "a",
 				},
 			},
@@ -4304,7 +4878,7 @@ func TestContext2Apply_outputOrphan(t *testing.T) {
 		Modules: []*ModuleState{
 			&ModuleState{
 				Path: rootModulePath,
-				Outputs: map[string]string{
+				Outputs: map[string]interface{}{
 					"foo": "bar",
 					"bar": "baz",
 				},
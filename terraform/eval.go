@@ -49,10 +49,10 @@ func EvalRaw(n EvalNode, ctx EvalContext) (interface{}, error) {
 		path = strings.Join(ctx.Path(), ".")
 	}
 
-	log.Printf("[DEBUG] %s: eval: %T", path, n)
+	log.Printf("[DEBUG] core: %s: eval: %T", path, n)
 	output, err := n.Eval(ctx)
 	if err != nil {
-		log.Printf("[ERROR] %s: eval: %T, err: %s", path, n, err)
+		log.Printf("[ERROR] core: %s: eval: %T, err: %s", path, n, err)
 	}
 
 	return output, err
@@ -30,7 +30,7 @@ func (n *EvalApply) Eval(ctx EvalContext) (interface{}, error) {
 	// If we have no diff, we have nothing to do!
 	if diff.Empty() {
 		log.Printf(
-			"[DEBUG] apply: %s: diff is empty, doing nothing.", n.Info.Id)
+			"[DEBUG] core: apply: %s: diff is empty, doing nothing.", n.Info.Id)
 		return nil, nil
 	}
 
@@ -63,7 +63,7 @@ func (n *EvalApply) Eval(ctx EvalContext) (interface{}, error) {
 	}
 
 	// With the completed diff, apply!
-	log.Printf("[DEBUG] apply: %s: executing Apply", n.Info.Id)
+	log.Printf("[DEBUG] core: apply: %s: executing Apply", n.Info.Id)
 	state, err := provider.Apply(n.Info, state, diff)
 	if state == nil {
 		state = new(InstanceState)
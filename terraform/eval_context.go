@@ -58,6 +58,12 @@ type EvalContext interface {
 	// that is currently being acted upon.
 	Interpolate(*config.RawConfig, *Resource) (*ResourceConfig, error)
 
+	// VariableMap returns the values of the keys of the map variable
+	// with the given name in the module of this context, such as
+	// "prod.cidr" for "${var.envs.prod.cidr}". It is empty if the
+	// variable isn't a map.
+	VariableMap(string) (map[string]string, error)
+
 	// SetVariables sets the variables for the module within
 	// this context with the name n. This function call is additive:
 	// the second parameter is merged with any previous call.
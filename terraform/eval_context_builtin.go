@@ -56,7 +56,7 @@ func (ctx *BuiltinEvalContext) Hook(fn func(Hook) (HookAction, error)) error {
 			continue
 		case HookActionHalt:
 			// Return an early exit error to trigger an early exit
-			log.Printf("[WARN] Early exit triggered by hook: %T", h)
+			log.Printf("[WARN] core: Early exit triggered by hook: %T", h)
 			return EvalEarlyExitError{}
 		}
 	}
@@ -245,6 +245,31 @@ func (ctx *BuiltinEvalContext) Interpolate(
 	return result, nil
 }
 
+func (ctx *BuiltinEvalContext) VariableMap(n string) (map[string]string, error) {
+	key := "var." + n
+	v, err := config.NewUserVariable(key)
+	if err != nil {
+		return nil, err
+	}
+
+	scope := &InterpolationScope{Path: ctx.Path()}
+	vs, err := ctx.Interpolater.Values(
+		scope, map[string]config.InterpolatedVariable{key: v})
+	if err != nil {
+		return nil, err
+	}
+
+	prefix := key + "."
+	result := make(map[string]string)
+	for k, v := range vs {
+		if strings.HasPrefix(k, prefix) {
+			result[k[len(prefix):]] = v.Value.(string)
+		}
+	}
+
+	return result, nil
+}
+
 func (ctx *BuiltinEvalContext) Path() []string {
 	return ctx.PathValue
 }
@@ -61,6 +61,11 @@ type MockEvalContext struct {
 	InterpolateConfigResult *ResourceConfig
 	InterpolateError        error
 
+	VariableMapCalled bool
+	VariableMapName   string
+	VariableMapResult map[string]string
+	VariableMapError  error
+
 	PathCalled bool
 	PathPath   []string
 
@@ -158,6 +163,12 @@ func (c *MockEvalContext) Interpolate(
 	return c.InterpolateConfigResult, c.InterpolateError
 }
 
+func (c *MockEvalContext) VariableMap(n string) (map[string]string, error) {
+	c.VariableMapCalled = true
+	c.VariableMapName = n
+	return c.VariableMapResult, c.VariableMapError
+}
+
 func (c *MockEvalContext) Path() []string {
 	c.PathCalled = true
 	return c.PathPath
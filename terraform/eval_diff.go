@@ -39,10 +39,10 @@ func (n *EvalCompareDiff) Eval(ctx EvalContext) (interface{}, error) {
 	}()
 
 	if same, reason := one.Same(two); !same {
-		log.Printf("[ERROR] %s: diffs didn't match", n.Info.Id)
-		log.Printf("[ERROR] %s: reason: %s", n.Info.Id, reason)
-		log.Printf("[ERROR] %s: diff one: %#v", n.Info.Id, one)
-		log.Printf("[ERROR] %s: diff two: %#v", n.Info.Id, two)
+		log.Printf("[ERROR] core: %s: diffs didn't match", n.Info.Id)
+		log.Printf("[ERROR] core: %s: reason: %s", n.Info.Id, reason)
+		log.Printf("[ERROR] core: %s: diff one: %#v", n.Info.Id, one)
+		log.Printf("[ERROR] core: %s: diff two: %#v", n.Info.Id, two)
 		return nil, fmt.Errorf(
 			"%s: diffs didn't match during apply. This is a bug with "+
 				"Terraform and should be reported.", n.Info.Id)
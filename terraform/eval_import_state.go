@@ -0,0 +1,54 @@
+package terraform
+
+import (
+	"fmt"
+)
+
+// EvalImportState is an EvalNode implementation that imports the
+// resource with the given ID into the state, through its provider.
+type EvalImportState struct {
+	Provider *ResourceProvider
+	Info     *InstanceInfo
+	Id       string
+	Output   **InstanceState
+}
+
+func (n *EvalImportState) Eval(ctx EvalContext) (interface{}, error) {
+	importer, ok := (*n.Provider).(ResourceProviderImporter)
+	if !ok {
+		return nil, fmt.Errorf(
+			"%s: the provider doesn't support importing resources", n.Info.Id)
+	}
+
+	// The import is reported as a refresh of the resource, which it is
+	// from the point of view of the UI
+	state := &InstanceState{ID: n.Id}
+	err := ctx.Hook(func(h Hook) (HookAction, error) {
+		return h.PreRefresh(n.Info, state)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	state, err = importer.ImportState(n.Info, n.Id)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %s", n.Info.Id, err)
+	}
+	if state == nil || state.ID == "" {
+		return nil, fmt.Errorf(
+			"%s: no %s with the ID %q exists", n.Info.Id, n.Info.Type, n.Id)
+	}
+
+	err = ctx.Hook(func(h Hook) (HookAction, error) {
+		return h.PostRefresh(n.Info, state)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if n.Output != nil {
+		*n.Output = state
+	}
+
+	return nil, nil
+}
@@ -0,0 +1,58 @@
+package terraform
+
+import (
+	"testing"
+)
+
+func TestEvalImportState(t *testing.T) {
+	p := new(MockResourceProvider)
+	p.ImportStateReturn = &InstanceState{
+		ID:         "i-abc123",
+		Attributes: map[string]string{"ami": "ami-1234"},
+	}
+	provider := ResourceProvider(p)
+
+	var output *InstanceState
+	node := &EvalImportState{
+		Provider: &provider,
+		Info:     &InstanceInfo{Id: "aws_instance.foo", Type: "aws_instance"},
+		Id:       "i-abc123",
+		Output:   &output,
+	}
+
+	ctx := new(MockEvalContext)
+	if _, err := node.Eval(ctx); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	if !p.ImportStateCalled {
+		t.Fatal("should call ImportState")
+	}
+	if p.ImportStateID != "i-abc123" {
+		t.Fatalf("bad: %s", p.ImportStateID)
+	}
+	if output != p.ImportStateReturn {
+		t.Fatalf("bad: %#v", output)
+	}
+}
+
+func TestEvalImportState_notFound(t *testing.T) {
+	p := new(MockResourceProvider)
+	provider := ResourceProvider(p)
+
+	var output *InstanceState
+	node := &EvalImportState{
+		Provider: &provider,
+		Info:     &InstanceInfo{Id: "aws_instance.foo", Type: "aws_instance"},
+		Id:       "i-abc123",
+		Output:   &output,
+	}
+
+	ctx := new(MockEvalContext)
+	if _, err := node.Eval(ctx); err == nil {
+		t.Fatal("should error")
+	}
+	if output != nil {
+		t.Fatalf("bad: %#v", output)
+	}
+}
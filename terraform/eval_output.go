@@ -76,16 +76,63 @@ func (n *EvalWriteOutput) Eval(ctx EvalContext) (interface{}, error) {
 		}
 	}
 
-	// If it is a list of values, get the first one
-	if list, ok := valueRaw.([]interface{}); ok {
-		valueRaw = list[0]
-	}
-	if _, ok := valueRaw.(string); !ok {
-		return nil, fmt.Errorf("output %s is not a string", n.Name)
+	value, err := outputValue(valueRaw)
+	if err != nil {
+		return nil, fmt.Errorf("output %s: %s", n.Name, err)
 	}
 
 	// Write the output
-	mod.Outputs[n.Name] = valueRaw.(string)
+	mod.Outputs[n.Name] = value
 
 	return nil, nil
 }
+
+// outputValue turns the raw interpolated value of an output into the
+// value that is stored in the state: a string, a list of strings, or a
+// map of strings.
+func outputValue(raw interface{}) (interface{}, error) {
+	switch v := raw.(type) {
+	case string:
+		return v, nil
+	case []map[string]interface{}:
+		// HCL decodes an object into a list of maps
+		return outputMapValue(v)
+	case map[string]interface{}:
+		return outputMapValue([]map[string]interface{}{v})
+	case []interface{}:
+		// An object can also end up as a single-element list of maps
+		if len(v) == 1 {
+			if m, ok := v[0].(map[string]interface{}); ok {
+				return outputMapValue([]map[string]interface{}{m})
+			}
+		}
+
+		result := make([]interface{}, len(v))
+		for i, e := range v {
+			s, ok := e.(string)
+			if !ok {
+				return nil, fmt.Errorf("list elements must be strings")
+			}
+			result[i] = s
+		}
+
+		return result, nil
+	default:
+		return nil, fmt.Errorf("must be a string, list, or map")
+	}
+}
+
+func outputMapValue(ms []map[string]interface{}) (interface{}, error) {
+	result := make(map[string]interface{})
+	for _, m := range ms {
+		for k, v := range m {
+			s, ok := v.(string)
+			if !ok {
+				return nil, fmt.Errorf("map values must be strings")
+			}
+			result[k] = s
+		}
+	}
+
+	return result, nil
+}
@@ -0,0 +1,47 @@
+package terraform
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestOutputValue(t *testing.T) {
+	cases := []struct {
+		Input  interface{}
+		Output interface{}
+		Err    bool
+	}{
+		{"foo", "foo", false},
+		{
+			[]interface{}{"a", "b"},
+			[]interface{}{"a", "b"},
+			false,
+		},
+		{
+			[]map[string]interface{}{
+				map[string]interface{}{"a": "b"},
+			},
+			map[string]interface{}{"a": "b"},
+			false,
+		},
+		{
+			[]interface{}{
+				map[string]interface{}{"a": "b"},
+			},
+			map[string]interface{}{"a": "b"},
+			false,
+		},
+		{[]interface{}{"a", 42}, nil, true},
+		{42, nil, true},
+	}
+
+	for i, tc := range cases {
+		actual, err := outputValue(tc.Input)
+		if (err != nil) != tc.Err {
+			t.Fatalf("%d: bad err: %s", i, err)
+		}
+		if !reflect.DeepEqual(actual, tc.Output) {
+			t.Fatalf("%d: bad: %#v", i, actual)
+		}
+	}
+}
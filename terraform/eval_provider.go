@@ -3,6 +3,7 @@ package terraform
 import (
 	"fmt"
 
+	"github.com/hashicorp/go-version"
 	"github.com/hashicorp/terraform/config"
 )
 
@@ -71,6 +72,58 @@ func (n *EvalInitProvider) Eval(ctx EvalContext) (interface{}, error) {
 	return ctx.InitProvider(n.Name)
 }
 
+// EvalCheckProviderVersion is an EvalNode implementation that checks that
+// an initialized provider matches the version constraint of its
+// configuration, so that configurations aren't used with a provider that
+// may behave differently than expected.
+type EvalCheckProviderVersion struct {
+	Name       string
+	Constraint string
+}
+
+func (n *EvalCheckProviderVersion) Eval(ctx EvalContext) (interface{}, error) {
+	if n.Constraint == "" {
+		return nil, nil
+	}
+
+	cs, err := version.NewConstraint(n.Constraint)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"provider.%s: invalid version constraint %q: %s",
+			n.Name, n.Constraint, err)
+	}
+
+	p := ctx.Provider(n.Name)
+	if p == nil {
+		return nil, fmt.Errorf("provider %s not initialized", n.Name)
+	}
+
+	var raw string
+	if pv, ok := p.(ResourceProviderVersioner); ok {
+		raw = pv.ProviderVersion()
+	}
+	if raw == "" {
+		return nil, fmt.Errorf(
+			"provider.%s: the provider doesn't report its version, so the "+
+				"version constraint %q can't be checked",
+			n.Name, n.Constraint)
+	}
+
+	v, err := version.NewVersion(raw)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"provider.%s: invalid provider version %q: %s",
+			n.Name, raw, err)
+	}
+	if !cs.Check(v) {
+		return nil, fmt.Errorf(
+			"provider.%s: version %s doesn't match the constraint %q",
+			n.Name, raw, n.Constraint)
+	}
+
+	return nil, nil
+}
+
 // EvalGetProvider is an EvalNode implementation that retrieves an already
 // initialized provider instance for the given name.
 type EvalGetProvider struct {
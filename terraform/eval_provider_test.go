@@ -112,6 +112,37 @@ func TestEvalInitProvider(t *testing.T) {
 	}
 }
 
+func TestEvalCheckProviderVersion_impl(t *testing.T) {
+	var _ EvalNode = new(EvalCheckProviderVersion)
+}
+
+func TestEvalCheckProviderVersion(t *testing.T) {
+	cases := []struct {
+		Version    string
+		Constraint string
+		Err        bool
+	}{
+		{"1.2.3", "", false},
+		{"", "", false},
+		{"1.2.3", "~> 1.2", false},
+		{"1.1.0", "~> 1.2", true},
+		{"2.0.0", ">= 1.0, < 2.0", true},
+		{"", "~> 1.2", true},
+		{"bad", "~> 1.2", true},
+		{"1.2.3", "bad", true},
+	}
+
+	for i, tc := range cases {
+		n := &EvalCheckProviderVersion{Name: "foo", Constraint: tc.Constraint}
+		provider := &MockResourceProvider{ProviderVersionReturn: tc.Version}
+		ctx := &MockEvalContext{ProviderProvider: provider}
+		_, err := n.Eval(ctx)
+		if (err != nil) != tc.Err {
+			t.Fatalf("%d: err: %s", i, err)
+		}
+	}
+}
+
 func TestEvalGetProvider_impl(t *testing.T) {
 	var _ EvalNode = new(EvalGetProvider)
 }
@@ -0,0 +1,63 @@
+package terraform
+
+import (
+	"fmt"
+	"log"
+)
+
+// EvalReadDataSource is an EvalNode implementation that reads a data
+// source through its provider, with its interpolated configuration.
+//
+// A data source whose configuration depends on values that aren't known
+// yet, such as the attributes of a resource that will be created, isn't
+// read: its state is left alone until the values are known.
+type EvalReadDataSource struct {
+	Provider *ResourceProvider
+	Config   **ResourceConfig
+	Info     *InstanceInfo
+	Output   **InstanceState
+}
+
+func (n *EvalReadDataSource) Eval(ctx EvalContext) (interface{}, error) {
+	dataSourcer, ok := (*n.Provider).(ResourceProviderDataSourcer)
+	if !ok {
+		return nil, fmt.Errorf(
+			"%s: the provider doesn't support data sources", n.Info.Id)
+	}
+
+	cfg := *n.Config
+	if len(cfg.ComputedKeys) > 0 {
+		log.Printf(
+			"[DEBUG] core: read data: %s: configuration is computed, not reading",
+			n.Info.Id)
+		return nil, EvalEarlyExitError{}
+	}
+
+	// The read is reported as a refresh of the data source, which it is
+	// from the point of view of the UI
+	state := &InstanceState{}
+	err := ctx.Hook(func(h Hook) (HookAction, error) {
+		return h.PreRefresh(n.Info, state)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	state, err = dataSourcer.ReadDataSource(n.Info, cfg)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %s", n.Info.Id, err)
+	}
+
+	err = ctx.Hook(func(h Hook) (HookAction, error) {
+		return h.PostRefresh(n.Info, state)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if n.Output != nil {
+		*n.Output = state
+	}
+
+	return nil, nil
+}
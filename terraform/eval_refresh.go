@@ -20,7 +20,7 @@ func (n *EvalRefresh) Eval(ctx EvalContext) (interface{}, error) {
 
 	// If we have no state, we don't do any refreshing
 	if state == nil {
-		log.Printf("[DEBUG] refresh: %s: no state, not refreshing", n.Info.Id)
+		log.Printf("[DEBUG] core: refresh: %s: no state, not refreshing", n.Info.Id)
 		return nil, nil
 	}
 
@@ -132,3 +132,40 @@ func (n *EvalValidateResource) Eval(ctx EvalContext) (interface{}, error) {
 		Errors:   errs,
 	}
 }
+
+// EvalValidateDataSource is an EvalNode implementation that validates
+// the configuration of a data source.
+type EvalValidateDataSource struct {
+	Provider     *ResourceProvider
+	Config       **ResourceConfig
+	ResourceName string
+	ResourceType string
+}
+
+func (n *EvalValidateDataSource) Eval(ctx EvalContext) (interface{}, error) {
+	dataSourcer, ok := (*n.Provider).(ResourceProviderDataSourcer)
+	if !ok {
+		return nil, &EvalValidateError{
+			Errors: []error{fmt.Errorf(
+				"data.%s.%s: the provider doesn't support data sources",
+				n.ResourceType, n.ResourceName)},
+		}
+	}
+
+	warns, errs := dataSourcer.ValidateDataSource(n.ResourceType, *n.Config)
+	if !config.NameRegexp.Match([]byte(n.ResourceName)) {
+		errs = append(errs, fmt.Errorf(
+			"data.%s.%s: data source name can only contain letters, "+
+				"numbers, dashes, and underscores.",
+			n.ResourceType, n.ResourceName))
+	}
+
+	if len(warns) == 0 && len(errs) == 0 {
+		return nil, nil
+	}
+
+	return nil, &EvalValidateError{
+		Warnings: warns,
+		Errors:   errs,
+	}
+}
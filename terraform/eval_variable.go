@@ -2,10 +2,10 @@ package terraform
 
 import (
 	"fmt"
+	"regexp"
 
 	"github.com/hashicorp/errwrap"
 	"github.com/hashicorp/terraform/config"
-	"github.com/mitchellh/mapstructure"
 )
 
 // EvalSetVariables is an EvalNode implementation that sets the variables
@@ -29,7 +29,10 @@ type EvalVariableBlock struct {
 	Variables map[string]string
 }
 
-// TODO: test
+// varMapRegexp matches a value that is only a map variable of the
+// module the block is in, such as "${var.envs}".
+var varMapRegexp = regexp.MustCompile(`^\$\{var\.([^.}]+)\}$`)
+
 func (n *EvalVariableBlock) Eval(ctx EvalContext) (interface{}, error) {
 	// Clear out the existing mapping
 	for k, _ := range n.Variables {
@@ -38,17 +41,40 @@ func (n *EvalVariableBlock) Eval(ctx EvalContext) (interface{}, error) {
 
 	// Get our configuration
 	rc := *n.Config
+	set := make(map[string]struct{})
 	for k, v := range rc.Config {
-		var vStr string
-		if err := mapstructure.WeakDecode(v, &vStr); err != nil {
+		// A map variable is interpolated as its name, so the values
+		// of its keys are looked up and set as the keys of this one.
+		if raw, ok := rc.Raw[k].(string); ok {
+			if match := varMapRegexp.FindStringSubmatch(raw); match != nil {
+				vs, err := ctx.VariableMap(match[1])
+				if err != nil {
+					return nil, errwrap.Wrapf(fmt.Sprintf(
+						"%s: error reading value: {{err}}", k), err)
+				}
+				if len(vs) > 0 {
+					for mk, mv := range vs {
+						n.Variables[k+"."+mk] = mv
+					}
+					set[k] = struct{}{}
+					continue
+				}
+			}
+		}
+
+		vs, err := config.FlattenVariable(k, v)
+		if err != nil {
 			return nil, errwrap.Wrapf(fmt.Sprintf(
 				"%s: error reading value: {{err}}", k), err)
 		}
 
-		n.Variables[k] = vStr
+		for fk, fv := range vs {
+			n.Variables[fk] = fv
+		}
+		set[k] = struct{}{}
 	}
 	for k, _ := range rc.Raw {
-		if _, ok := n.Variables[k]; !ok {
+		if _, ok := set[k]; !ok {
 			n.Variables[k] = config.UnknownVariableValue
 		}
 	}
@@ -0,0 +1,97 @@
+package terraform
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform/config"
+)
+
+func TestEvalVariableBlock(t *testing.T) {
+	cases := []struct {
+		Raw       map[string]interface{}
+		Config    map[string]interface{}
+		VarMap    map[string]string
+		Variables map[string]string
+	}{
+		// Strings
+		{
+			map[string]interface{}{"foo": "bar"},
+			map[string]interface{}{"foo": "bar"},
+			nil,
+			map[string]string{"foo": "bar"},
+		},
+
+		// Computed values
+		{
+			map[string]interface{}{"foo": "${aws_instance.foo.id}"},
+			map[string]interface{}{},
+			nil,
+			map[string]string{"foo": config.UnknownVariableValue},
+		},
+
+		// Nested maps
+		{
+			map[string]interface{}{"envs": nil},
+			map[string]interface{}{
+				"envs": []map[string]interface{}{
+					map[string]interface{}{
+						"prod": []map[string]interface{}{
+							map[string]interface{}{
+								"cidr": "10.0.0.0/16",
+								"azs":  []interface{}{"a", "b"},
+							},
+						},
+					},
+				},
+			},
+			nil,
+			map[string]string{
+				"envs.prod.cidr":  "10.0.0.0/16",
+				"envs.prod.azs.#": "2",
+				"envs.prod.azs.0": "a",
+				"envs.prod.azs.1": "b",
+			},
+		},
+
+		// Map variables
+		{
+			map[string]interface{}{"envs": "${var.envs}"},
+			map[string]interface{}{"envs": "envs"},
+			map[string]string{
+				"prod.cidr": "10.0.0.0/16",
+			},
+			map[string]string{
+				"envs.prod.cidr": "10.0.0.0/16",
+			},
+		},
+
+		// String variables
+		{
+			map[string]interface{}{"foo": "${var.foo}"},
+			map[string]interface{}{"foo": "bar"},
+			map[string]string{},
+			map[string]string{"foo": "bar"},
+		},
+	}
+
+	for i, tc := range cases {
+		rc := &ResourceConfig{
+			Raw:    tc.Raw,
+			Config: tc.Config,
+		}
+		ctx := &MockEvalContext{VariableMapResult: tc.VarMap}
+		vs := make(map[string]string)
+		n := &EvalVariableBlock{
+			Config:    &rc,
+			Variables: vs,
+		}
+		if _, err := n.Eval(ctx); err != nil {
+			t.Fatalf("%d: err: %s", i, err)
+		}
+
+		if !reflect.DeepEqual(vs, tc.Variables) {
+			t.Fatalf("%d: bad: %#v", i, vs)
+		}
+	}
+}
@@ -5,13 +5,20 @@ import (
 )
 
 // ProviderEvalTree returns the evaluation tree for initializing and
-// configuring providers.
-func ProviderEvalTree(n string, config *config.RawConfig) EvalNode {
+// configuring providers. If version isn't empty, it is the version
+// constraint that the provider is checked against once it is initialized.
+func ProviderEvalTree(n string, config *config.RawConfig, version string) EvalNode {
 	var provider ResourceProvider
 	var resourceConfig *ResourceConfig
 
 	seq := make([]EvalNode, 0, 5)
 	seq = append(seq, &EvalInitProvider{Name: n})
+	if version != "" {
+		seq = append(seq, &EvalCheckProviderVersion{
+			Name:       n,
+			Constraint: version,
+		})
+	}
 
 	// Input stuff
 	seq = append(seq, &EvalOpFilter{
@@ -42,7 +49,7 @@ func ProviderEvalTree(n string, config *config.RawConfig) EvalNode {
 
 	// Apply stuff
 	seq = append(seq, &EvalOpFilter{
-		Ops: []walkOperation{walkValidate, walkRefresh, walkPlan, walkApply},
+		Ops: []walkOperation{walkValidate, walkRefresh, walkPlan, walkApply, walkImport},
 		Node: &EvalSequence{
 			Nodes: []EvalNode{
 				&EvalGetProvider{
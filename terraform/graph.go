@@ -165,7 +165,7 @@ func (g *Graph) walk(walker GraphWalker) error {
 	// Walk the graph.
 	var walkFn dag.WalkFunc
 	walkFn = func(v dag.Vertex) (rerr error) {
-		log.Printf("[DEBUG] vertex %s.%s: walking", path, dag.VertexName(v))
+		log.Printf("[DEBUG] core: vertex %s.%s: walking", path, dag.VertexName(v))
 
 		walker.EnterVertex(v)
 		defer func() { walker.ExitVertex(v, rerr) }()
@@ -189,7 +189,7 @@ func (g *Graph) walk(walker GraphWalker) error {
 
 			// Allow the walker to change our tree if needed. Eval,
 			// then callback with the output.
-			log.Printf("[DEBUG] vertex %s.%s: evaluating", path, dag.VertexName(v))
+			log.Printf("[DEBUG] core: vertex %s.%s: evaluating", path, dag.VertexName(v))
 			tree = walker.EnterEvalTree(v, tree)
 			output, err := Eval(tree, vertexCtx)
 			if rerr = walker.ExitEvalTree(v, output, err); rerr != nil {
@@ -200,7 +200,7 @@ func (g *Graph) walk(walker GraphWalker) error {
 		// If the node is dynamically expanded, then expand it
 		if ev, ok := v.(GraphNodeDynamicExpandable); ok {
 			log.Printf(
-				"[DEBUG] vertex %s.%s: expanding/walking dynamic subgraph",
+				"[DEBUG] core: vertex %s.%s: expanding/walking dynamic subgraph",
 				path,
 				dag.VertexName(v))
 			g, err := ev.DynamicExpand(vertexCtx)
@@ -218,7 +218,7 @@ func (g *Graph) walk(walker GraphWalker) error {
 		// If the node has a subgraph, then walk the subgraph
 		if sn, ok := v.(GraphNodeSubgraph); ok {
 			log.Printf(
-				"[DEBUG] vertex %s.%s: walking subgraph",
+				"[DEBUG] core: vertex %s.%s: walking subgraph",
 				path,
 				dag.VertexName(v))
 
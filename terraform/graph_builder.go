@@ -31,14 +31,14 @@ func (b *BasicGraphBuilder) Build(path []string) (*Graph, error) {
 		}
 
 		log.Printf(
-			"[TRACE] Graph after step %T:\n\n%s",
+			"[TRACE] core: Graph after step %T:\n\n%s",
 			step, g.String())
 	}
 
 	// Validate the graph structure
 	if b.Validate {
 		if err := g.Validate(); err != nil {
-			log.Printf("[ERROR] Graph validation failed. Graph:\n\n%s", g.String())
+			log.Printf("[ERROR] core: Graph validation failed. Graph:\n\n%s", g.String())
 			return nil, err
 		}
 	}
@@ -73,10 +73,17 @@ type BuiltinGraphBuilder struct {
 	// Targets is the user-specified list of resources to target.
 	Targets []string
 
+	// Imports is the list of resources to import into the state.
+	Imports []*ImportTarget
+
 	// Destroy is set to true when we're in a `terraform destroy` or a
 	// `terraform plan -destroy`
 	Destroy bool
 
+	// Refresh is set to true when the graph is for refreshing the state,
+	// which doesn't need the resources to wait for each other.
+	Refresh bool
+
 	// Determines whether the GraphBuilder should perform graph validation before
 	// returning the Graph. Generally you want this to be done, except when you'd
 	// like to inspect a problematic graph.
@@ -113,6 +120,9 @@ func (b *BuiltinGraphBuilder) Steps(path []string) []GraphTransformer {
 		// Output-related transformations
 		&AddOutputOrphanTransformer{State: b.State},
 
+		// Add the resources to import, if any
+		&ImportStateTransformer{Targets: b.Imports, Module: b.Root},
+
 		// Provider-related transformations
 		&MissingProviderTransformer{Providers: b.Providers},
 		&ProviderTransformer{},
@@ -160,6 +170,13 @@ func (b *BuiltinGraphBuilder) Steps(path []string) []GraphTransformer {
 				Then: &PruneDestroyTransformer{Diff: b.Diff, State: b.State},
 			}),
 
+			// Refreshing a resource only reads its own state, so the
+			// resources are refreshed concurrently instead of in order.
+			b.conditional(&conditionalOpts{
+				If:   func() bool { return b.Refresh && !b.Verbose },
+				Then: &RefreshTransformer{},
+			}),
+
 			// Make sure we have a single root after the above changes.
 			// This is the 2nd root transformer. In practice this shouldn't
 			// actually matter as the RootTransformer is idempotent.
@@ -171,14 +188,15 @@ func (b *BuiltinGraphBuilder) Steps(path []string) []GraphTransformer {
 		)
 	}
 
-	// Remove nils
-	for i, s := range steps {
-		if s == nil {
-			steps = append(steps[:i], steps[i+1:]...)
+	// Remove nils, which may be next to each other
+	result := make([]GraphTransformer, 0, len(steps))
+	for _, s := range steps {
+		if s != nil {
+			result = append(result, s)
 		}
 	}
 
-	return steps
+	return result
 }
 
 type conditionalOpts struct {
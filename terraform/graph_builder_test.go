@@ -109,6 +109,32 @@ func TestBuiltinGraphBuilder_Verbose(t *testing.T) {
 	}
 }
 
+func TestBuiltinGraphBuilder_cycleRefs(t *testing.T) {
+	b := &BuiltinGraphBuilder{
+		Root:     testModule(t, "graph-builder-cycle-refs"),
+		Validate: true,
+	}
+
+	_, err := b.Build(RootModulePath)
+	if err == nil {
+		t.Fatal("should error")
+	}
+
+	expected := []string{
+		"aws_security_group.a -> aws_security_group.b:\n" +
+			"    ingress.0.security_groups.0 = ${aws_security_group.b.id} (",
+		"main.tf:3)",
+		"aws_security_group.b -> aws_security_group.a:\n" +
+			"    ingress.0.security_groups.0 = ${aws_security_group.a.id} (",
+		"main.tf:9)",
+	}
+	for _, e := range expected {
+		if !strings.Contains(err.Error(), e) {
+			t.Fatalf("bad: %s", err)
+		}
+	}
+}
+
 // This tests a cycle we got when a CBD resource depends on a non-CBD
 // resource. This cycle shouldn't happen in the general case anymore.
 func TestBuiltinGraphBuilder_cbdDepNonCbd(t *testing.T) {
@@ -41,7 +41,8 @@ func (n *GraphNodeConfigProvider) DependentOn() []string {
 
 // GraphNodeEvalable impl.
 func (n *GraphNodeConfigProvider) EvalTree() EvalNode {
-	return ProviderEvalTree(n.ProviderName(), n.Provider.RawConfig)
+	return ProviderEvalTree(
+		n.ProviderName(), n.Provider.RawConfig, n.Provider.Version)
 }
 
 // GraphNodeProvider implementation
@@ -13,4 +13,5 @@ const (
 	GraphNodeConfigTypeModule
 	GraphNodeConfigTypeOutput
 	GraphNodeConfigTypeVariable
+	GraphNodeConfigTypeLocal
 )
@@ -0,0 +1,213 @@
+package terraform
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/go-multierror"
+	"github.com/hashicorp/terraform/config"
+	"github.com/hashicorp/terraform/dag"
+)
+
+// Validate validates the graph like dag.AcyclicGraph.Validate, but the
+// error of each cycle also lists the edges between the resources of the
+// cycle, along with the references in the configuration that form them
+// and where they are, so that the cycle can be found and broken.
+func (g *Graph) Validate() error {
+	if _, err := g.Root(); err != nil {
+		return err
+	}
+
+	var err error
+	for _, cycle := range g.Cycles() {
+		err = multierror.Append(err, g.cycleError(cycle))
+	}
+
+	for _, e := range g.Edges() {
+		if e.Source() == e.Target() {
+			err = multierror.Append(err, fmt.Errorf(
+				"Self reference: %s", dag.VertexName(e.Source())))
+		}
+	}
+
+	return err
+}
+
+// cycleError returns the error for a cycle of the graph.
+func (g *Graph) cycleError(cycle []dag.Vertex) error {
+	names := make([]string, len(cycle))
+	for i, v := range cycle {
+		names[i] = dag.VertexName(v)
+	}
+	msg := fmt.Sprintf("Cycle: %s", strings.Join(names, ", "))
+
+	var edges []string
+	for _, source := range cycle {
+		r, prefix := graphNodeConfigResource(source)
+		if r == nil {
+			continue
+		}
+
+		for _, target := range cycle {
+			if !g.DownEdges(source).Include(target) {
+				continue
+			}
+
+			dv, ok := target.(GraphNodeDependable)
+			if !ok {
+				continue
+			}
+
+			refs := resourceRefs(r, prefix, dv.DependableName())
+			if len(refs) == 0 {
+				continue
+			}
+
+			edges = append(edges, fmt.Sprintf(
+				"%s -> %s:\n    %s",
+				dag.VertexName(source), dag.VertexName(target),
+				strings.Join(refs, "\n    ")))
+		}
+	}
+	if len(edges) == 0 {
+		return fmt.Errorf("%s", msg)
+	}
+
+	sort.Strings(edges)
+	return fmt.Errorf("%s\n\n  %s", msg, strings.Join(edges, "\n  "))
+}
+
+// graphNodeConfigResource returns the configuration of the resource of
+// the given vertex, if it is a resource, along with the prefix of the
+// names of its dependencies in the graph, such as "module.foo" for the
+// resources of a flattened module.
+func graphNodeConfigResource(v dag.Vertex) (*config.Resource, string) {
+	switch n := v.(type) {
+	case *GraphNodeConfigResource:
+		return n.Resource, ""
+	case *GraphNodeConfigResourceFlat:
+		return n.Resource, modulePrefixStr(n.PathValue)
+	default:
+		return nil, ""
+	}
+}
+
+// resourceRefs returns a description of each reference in the
+// configuration of the resource to the dependencies with the given
+// names, such as "ingress.0.security_groups.0 =
+// ${aws_security_group.b.id} (main.tf:12)".
+func resourceRefs(r *config.Resource, prefix string, names []string) []string {
+	targets := make(map[string]struct{})
+	for _, n := range names {
+		targets[n] = struct{}{}
+	}
+	isTarget := func(n string) bool {
+		if prefix != "" {
+			n = prefix + "." + n
+		}
+		_, ok := targets[n]
+		return ok
+	}
+
+	var result []string
+	for _, d := range r.DependsOn {
+		if isTarget(d) {
+			result = append(result, fmt.Sprintf(
+				"depends_on = %s (%s)", d, r.Pos))
+		}
+	}
+
+	rcs := []*config.RawConfig{r.RawCount, r.RawConfig}
+	attrPrefixes := []string{"count", ""}
+	for i, p := range r.Provisioners {
+		rcs = append(rcs, p.ConnInfo, p.RawConfig)
+		attrPrefixes = append(attrPrefixes,
+			fmt.Sprintf("provisioner.%d.connection", i),
+			fmt.Sprintf("provisioner.%d", i))
+	}
+
+	for i, rc := range rcs {
+		if rc == nil {
+			continue
+		}
+
+		keys := make([]string, 0, len(rc.Variables))
+		for k, v := range rc.Variables {
+			if isTarget(varNameForVar(v)) {
+				keys = append(keys, k)
+			}
+		}
+		sort.Strings(keys)
+
+		for _, k := range keys {
+			attr := attrPrefixes[i]
+			if a, ok := rawConfigAttr(rc.Raw, k); ok && a != "" && attr != "count" {
+				if attr != "" {
+					a = attr + "." + a
+				}
+				attr = a
+			}
+			if attr == "" {
+				attr = "config"
+			}
+
+			pos, ok := r.VarPos[k]
+			if !ok {
+				pos = r.Pos
+			}
+
+			ref := fmt.Sprintf("%s = ${%s}", attr, k)
+			if pos.Filename != "" {
+				ref += fmt.Sprintf(" (%s)", pos)
+			}
+			result = append(result, ref)
+		}
+	}
+
+	return result
+}
+
+// rawConfigAttr returns the flattened key, such as
+// "ingress.0.security_groups.0", of the first value of the raw
+// configuration in which the variable with the given key is
+// interpolated, and whether there is one.
+func rawConfigAttr(raw interface{}, key string) (string, bool) {
+	join := func(k, attr string) string {
+		if attr == "" {
+			return k
+		}
+		return k + "." + attr
+	}
+
+	switch v := raw.(type) {
+	case string:
+		return "", strings.Contains(v, "${") && strings.Contains(v, key)
+	case map[string]interface{}:
+		ks := make([]string, 0, len(v))
+		for k, _ := range v {
+			ks = append(ks, k)
+		}
+		sort.Strings(ks)
+
+		for _, k := range ks {
+			if attr, ok := rawConfigAttr(v[k], key); ok {
+				return join(k, attr), true
+			}
+		}
+	case []map[string]interface{}:
+		for i, m := range v {
+			if attr, ok := rawConfigAttr(m, key); ok {
+				return join(fmt.Sprintf("%d", i), attr), true
+			}
+		}
+	case []interface{}:
+		for i, e := range v {
+			if attr, ok := rawConfigAttr(e, key); ok {
+				return join(fmt.Sprintf("%d", i), attr), true
+			}
+		}
+	}
+
+	return "", false
+}
@@ -0,0 +1,129 @@
+package terraform
+
+import (
+	"encoding/json"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform/dag"
+)
+
+// GraphJSONOpts are the options for generating a JSON formatted Graph.
+type GraphJSONOpts struct {
+	// How many levels to expand modules, as with GraphDotOpts
+	MaxDepth int
+}
+
+// graphJSON is the JSON representation of a graph. Nodes are identified
+// by the same names as in the dot graph: "[root] aws_instance.foo".
+type graphJSON struct {
+	Nodes  []*graphJSONNode `json:"nodes"`
+	Edges  []*graphJSONEdge `json:"edges"`
+	Cycles [][]string       `json:"cycles"`
+}
+
+type graphJSONNode struct {
+	ID     string `json:"id"`
+	Name   string `json:"name"`
+	Module string `json:"module"`
+
+	// Subgraph is the module of the nodes this node expands to, if any
+	Subgraph string `json:"subgraph,omitempty"`
+}
+
+// graphJSONEdge is an edge from a node to a node it depends on.
+type graphJSONEdge struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+// GraphJSON returns the JSON formatting of the given Terraform graph, for
+// tools that can't read dot. Unlike GraphDot, it has all the nodes of the
+// graph, and always lists the cycles.
+func GraphJSON(g *Graph, opts *GraphJSONOpts) (string, error) {
+	result := &graphJSON{
+		Nodes:  make([]*graphJSONNode, 0),
+		Edges:  make([]*graphJSONEdge, 0),
+		Cycles: make([][]string, 0),
+	}
+	graphJSONSubgraph(result, "root", g, opts, 0)
+
+	sort.Sort(graphJSONNodes(result.Nodes))
+	sort.Sort(graphJSONEdges(result.Edges))
+	sort.Sort(graphJSONCycles(result.Cycles))
+
+	data, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return "", err
+	}
+
+	return string(data), nil
+}
+
+func graphJSONSubgraph(
+	result *graphJSON, modName string, g *Graph, opts *GraphJSONOpts, modDepth int) {
+	// Respect user-specified module depth
+	if opts.MaxDepth >= 0 && modDepth > opts.MaxDepth {
+		return
+	}
+
+	for _, v := range g.Vertices() {
+		node := &graphJSONNode{
+			ID:     graphDotNodeName(modName, v),
+			Name:   dag.VertexName(v),
+			Module: modName,
+		}
+
+		if sn, ok := v.(GraphNodeSubgraph); ok {
+			if sg := sn.Subgraph(); sg != nil {
+				if opts.MaxDepth < 0 || modDepth < opts.MaxDepth {
+					node.Subgraph = node.Name
+				}
+				graphJSONSubgraph(result, node.Name, sg, opts, modDepth+1)
+			}
+		}
+
+		result.Nodes = append(result.Nodes, node)
+	}
+
+	for _, e := range g.Edges() {
+		result.Edges = append(result.Edges, &graphJSONEdge{
+			From: graphDotNodeName(modName, e.Source()),
+			To:   graphDotNodeName(modName, e.Target()),
+		})
+	}
+
+	for _, cycle := range g.Cycles() {
+		ids := make([]string, len(cycle))
+		for i, v := range cycle {
+			ids[i] = graphDotNodeName(modName, v)
+		}
+		sort.Strings(ids)
+		result.Cycles = append(result.Cycles, ids)
+	}
+}
+
+type graphJSONNodes []*graphJSONNode
+
+func (s graphJSONNodes) Len() int           { return len(s) }
+func (s graphJSONNodes) Swap(i, j int)      { s[i], s[j] = s[j], s[i] }
+func (s graphJSONNodes) Less(i, j int) bool { return s[i].ID < s[j].ID }
+
+type graphJSONEdges []*graphJSONEdge
+
+func (s graphJSONEdges) Len() int      { return len(s) }
+func (s graphJSONEdges) Swap(i, j int) { s[i], s[j] = s[j], s[i] }
+func (s graphJSONEdges) Less(i, j int) bool {
+	if s[i].From != s[j].From {
+		return s[i].From < s[j].From
+	}
+	return s[i].To < s[j].To
+}
+
+type graphJSONCycles [][]string
+
+func (s graphJSONCycles) Len() int      { return len(s) }
+func (s graphJSONCycles) Swap(i, j int) { s[i], s[j] = s[j], s[i] }
+func (s graphJSONCycles) Less(i, j int) bool {
+	return strings.Join(s[i], " ") < strings.Join(s[j], " ")
+}
@@ -0,0 +1,117 @@
+package terraform
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestGraphJSON(t *testing.T) {
+	cases := map[string]struct {
+		Graph  testGraphFunc
+		Opts   GraphJSONOpts
+		Expect *graphJSON
+	}{
+		"cycle": {
+			Graph: func() *Graph {
+				var g Graph
+				g.Add(&testDrawableOrigin{"root"})
+				g.Add(&testDrawable{
+					VertexName:      "A",
+					DependentOnMock: []string{"root", "C"},
+				})
+				g.Add(&testDrawable{
+					VertexName:      "B",
+					DependentOnMock: []string{"A"},
+				})
+				g.Add(&testDrawable{
+					VertexName:      "C",
+					DependentOnMock: []string{"B"},
+				})
+
+				g.ConnectDependents()
+				return &g
+			},
+			Expect: &graphJSON{
+				Nodes: []*graphJSONNode{
+					&graphJSONNode{ID: "[root] A", Name: "A", Module: "root"},
+					&graphJSONNode{ID: "[root] B", Name: "B", Module: "root"},
+					&graphJSONNode{ID: "[root] C", Name: "C", Module: "root"},
+					&graphJSONNode{ID: "[root] root", Name: "root", Module: "root"},
+				},
+				Edges: []*graphJSONEdge{
+					&graphJSONEdge{From: "[root] A", To: "[root] C"},
+					&graphJSONEdge{From: "[root] A", To: "[root] root"},
+					&graphJSONEdge{From: "[root] B", To: "[root] A"},
+					&graphJSONEdge{From: "[root] C", To: "[root] B"},
+				},
+				Cycles: [][]string{
+					[]string{"[root] A", "[root] B", "[root] C"},
+				},
+			},
+		},
+
+		"subgraphs, depth 1": {
+			Opts: GraphJSONOpts{
+				MaxDepth: 1,
+			},
+			Graph: func() *Graph {
+				var g Graph
+				g.Add(&testDrawableOrigin{"root"})
+
+				var sub Graph
+				sub.Add(&testDrawableOrigin{"sub_root"})
+
+				var subsub Graph
+				subsub.Add(&testDrawableOrigin{"subsub_root"})
+				sub.Add(&testDrawableSubgraph{
+					VertexName:      "subsub",
+					SubgraphMock:    &subsub,
+					DependentOnMock: []string{"sub_root"},
+				})
+				g.Add(&testDrawableSubgraph{
+					VertexName:      "sub",
+					SubgraphMock:    &sub,
+					DependentOnMock: []string{"root"},
+				})
+
+				g.ConnectDependents()
+				sub.ConnectDependents()
+				return &g
+			},
+			Expect: &graphJSON{
+				Nodes: []*graphJSONNode{
+					&graphJSONNode{ID: "[root] root", Name: "root", Module: "root"},
+					&graphJSONNode{
+						ID:       "[root] sub",
+						Name:     "sub",
+						Module:   "root",
+						Subgraph: "sub",
+					},
+					&graphJSONNode{ID: "[sub] sub_root", Name: "sub_root", Module: "sub"},
+					&graphJSONNode{ID: "[sub] subsub", Name: "subsub", Module: "sub"},
+				},
+				Edges: []*graphJSONEdge{
+					&graphJSONEdge{From: "[root] sub", To: "[root] root"},
+					&graphJSONEdge{From: "[sub] subsub", To: "[sub] sub_root"},
+				},
+				Cycles: [][]string{},
+			},
+		},
+	}
+
+	for tn, tc := range cases {
+		raw, err := GraphJSON(tc.Graph(), &tc.Opts)
+		if err != nil {
+			t.Fatalf("%s: err: %s", tn, err)
+		}
+
+		actual := new(graphJSON)
+		if err := json.Unmarshal([]byte(raw), actual); err != nil {
+			t.Fatalf("%s: err: %s\n\n%s", tn, err, raw)
+		}
+		if !reflect.DeepEqual(actual, tc.Expect) {
+			t.Fatalf("%s: bad:\n\n%s", tn, raw)
+		}
+	}
+}
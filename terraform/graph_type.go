@@ -0,0 +1,25 @@
+package terraform
+
+//go:generate stringer -type=GraphType graph_type.go
+
+// GraphType is the operation a graph is built for, which decides the
+// diff and destroy mode the graph is built with, and for refresh, which
+// dependencies are kept.
+type GraphType byte
+
+const (
+	GraphTypeInvalid GraphType = iota
+	GraphTypePlan
+	GraphTypePlanDestroy
+	GraphTypeApply
+	GraphTypeRefresh
+)
+
+// GraphTypeMap maps the names of the graph types, as used on the command
+// line, to the GraphType.
+var GraphTypeMap = map[string]GraphType{
+	"apply":        GraphTypeApply,
+	"plan":         GraphTypePlan,
+	"plan-destroy": GraphTypePlanDestroy,
+	"refresh":      GraphTypeRefresh,
+}
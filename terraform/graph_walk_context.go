@@ -2,6 +2,8 @@ package terraform
 
 import (
 	"fmt"
+	"log"
+	"strings"
 	"sync"
 
 	"github.com/hashicorp/errwrap"
@@ -33,6 +35,8 @@ type ContextGraphWalker struct {
 	providerLock        sync.Mutex
 	provisionerCache    map[string]ResourceProvisioner
 	provisionerLock     sync.Mutex
+	providerSems        map[string]Semaphore
+	providerSemLock     sync.Mutex
 }
 
 func (w *ContextGraphWalker) EnterPath(path []string) EvalContext {
@@ -81,6 +85,7 @@ func (w *ContextGraphWalker) EnterPath(path []string) EvalContext {
 		StateLock:           &w.Context.stateLock,
 		Interpolater: &Interpolater{
 			Operation: w.Operation,
+			Env:       w.Context.env,
 			Module:    w.Context.module,
 			State:     w.Context.state,
 			StateLock: &w.Context.stateLock,
@@ -95,6 +100,13 @@ func (w *ContextGraphWalker) EnterPath(path []string) EvalContext {
 }
 
 func (w *ContextGraphWalker) EnterEvalTree(v dag.Vertex, n EvalNode) EvalNode {
+	// Acquire a lock on the semaphore of the provider first, so that
+	// the nodes waiting for a busy provider don't take the places of the
+	// nodes of other providers.
+	if sem := w.providerSem(v); sem != nil {
+		sem.Acquire()
+	}
+
 	// Acquire a lock on the semaphore
 	w.Context.parallelSem.Acquire()
 
@@ -105,8 +117,11 @@ func (w *ContextGraphWalker) EnterEvalTree(v dag.Vertex, n EvalNode) EvalNode {
 
 func (w *ContextGraphWalker) ExitEvalTree(
 	v dag.Vertex, output interface{}, err error) error {
-	// Release the semaphore
+	// Release the semaphores
 	w.Context.parallelSem.Release()
+	if sem := w.providerSem(v); sem != nil {
+		sem.Release()
+	}
 
 	if err == nil {
 		return nil
@@ -143,4 +158,71 @@ func (w *ContextGraphWalker) init() {
 	w.providerConfigCache = make(map[string]*ResourceConfig, 5)
 	w.provisionerCache = make(map[string]ResourceProvisioner, 5)
 	w.interpolaterVars = make(map[string]map[string]string, 5)
+	w.providerSems = make(map[string]Semaphore, 5)
+}
+
+// providerSem returns the semaphore that limits the number of nodes of
+// the provider of the vertex that are evaluated at once, or nil if the
+// vertex doesn't use a provider or there is no limit. The limit applies
+// to all the instances of a provider type, such as the aliases of the
+// provider and its configurations in modules, since they usually share
+// the same API.
+func (w *ContextGraphWalker) providerSem(v dag.Vertex) Semaphore {
+	if w.Context.providerParallelism <= 0 {
+		return nil
+	}
+
+	pc, ok := v.(GraphNodeProviderConsumer)
+	if !ok {
+		return nil
+	}
+	names := pc.ProvidedBy()
+	if len(names) == 0 {
+		return nil
+	}
+
+	// The name may have the module prefix of a flattened node, such as
+	// "module.foo.aws.east", as well as the alias of the provider.
+	name := names[0]
+	for strings.HasPrefix(name, "module.") {
+		parts := strings.SplitN(name, ".", 3)
+		if len(parts) < 3 {
+			break
+		}
+		name = parts[2]
+	}
+	name = strings.SplitN(name, ".", 2)[0]
+
+	w.once.Do(w.init)
+	w.providerSemLock.Lock()
+	defer w.providerSemLock.Unlock()
+
+	sem, ok := w.providerSems[name]
+	if !ok {
+		sem = NewSemaphore(w.Context.providerParallelism)
+		w.providerSems[name] = sem
+	}
+
+	return sem
+}
+
+// stopProviders asks the providers that were started during the walk to
+// abort their in-flight operations.
+func (w *ContextGraphWalker) stopProviders() {
+	w.once.Do(w.init)
+
+	w.providerLock.Lock()
+	defer w.providerLock.Unlock()
+
+	for k, p := range w.providerCache {
+		s, ok := p.(ResourceProviderStopper)
+		if !ok {
+			continue
+		}
+
+		log.Printf("[INFO] core: Stopping provider: %s", k)
+		if err := s.Stop(); err != nil {
+			log.Printf("[WARN] core: Error stopping provider %s: %s", k, err)
+		}
+	}
 }
@@ -3,6 +3,7 @@ package terraform
 import (
 	"fmt"
 	"os"
+	"strconv"
 	"strings"
 	"sync"
 
@@ -15,12 +16,17 @@ const (
 	// VarEnvPrefix is the prefix of variables that are read from
 	// the environment to set variables here.
 	VarEnvPrefix = "TF_VAR_"
+
+	// DefaultEnv is the name of the environment that is used if
+	// none is selected.
+	DefaultEnv = "default"
 )
 
 // Interpolater is the structure responsible for determining the values
 // for interpolations such as `aws_instance.foo.bar`.
 type Interpolater struct {
 	Operation walkOperation
+	Env       string
 	Module    *module.Tree
 	State     *State
 	StateLock *sync.RWMutex
@@ -67,6 +73,12 @@ func (i *Interpolater) Values(
 		switch v := rawV.(type) {
 		case *config.CountVariable:
 			err = i.valueCountVar(scope, n, v, result)
+		case *config.EachVariable:
+			// Each variables are set by the RawConfig itself when it
+			// generates the dynamic blocks.
+			continue
+		case *config.LocalVariable:
+			err = i.valueLocalVar(scope, n, v, result)
 		case *config.ModuleVariable:
 			err = i.valueModuleVar(scope, n, v, result)
 		case *config.PathVariable:
@@ -75,6 +87,8 @@ func (i *Interpolater) Values(
 			err = i.valueResourceVar(scope, n, v, result)
 		case *config.SelfVariable:
 			err = i.valueSelfVar(scope, n, v, result)
+		case *config.TerraformVariable:
+			err = i.valueTerraformVar(scope, n, v, result)
 		case *config.UserVariable:
 			err = i.valueUserVar(scope, n, v, result)
 		default:
@@ -109,6 +123,62 @@ func (i *Interpolater) valueCountVar(
 	}
 }
 
+func (i *Interpolater) valueLocalVar(
+	scope *InterpolationScope,
+	n string,
+	v *config.LocalVariable,
+	result map[string]ast.Variable) error {
+	mod := i.Module
+	if len(scope.Path) > 1 {
+		mod = i.Module.Child(scope.Path[1:])
+	}
+
+	var local *config.Local
+	if mod != nil {
+		for _, l := range mod.Config().Locals {
+			if l.Name == v.Name {
+				local = l
+				break
+			}
+		}
+	}
+	if local == nil {
+		return fmt.Errorf("%s: unknown local value %s", n, v.Name)
+	}
+
+	// Local values are interpolated every time they're referenced. We
+	// copy the configuration since it can be referenced concurrently.
+	// Local values can't reference count or self, so the resource is
+	// left out of the scope.
+	rc := local.RawConfig.Copy()
+	vs, err := i.Values(&InterpolationScope{Path: scope.Path}, rc.Variables)
+	if err != nil {
+		return fmt.Errorf("%s: %s", n, err)
+	}
+	if err := rc.Interpolate(vs); err != nil {
+		return fmt.Errorf("%s: %s", n, err)
+	}
+
+	var raw interface{} = config.UnknownVariableValue
+	if len(rc.UnknownKeys()) == 0 {
+		raw, err = outputValue(rc.Config()["value"])
+		if err != nil {
+			return fmt.Errorf("%s: %s", n, err)
+		}
+	}
+
+	value, err := moduleOutputString(raw, v.Elem)
+	if err != nil {
+		return fmt.Errorf("%s: %s", n, err)
+	}
+
+	result[n] = ast.Variable{
+		Value: value,
+		Type:  ast.TypeString,
+	}
+	return nil
+}
+
 func (i *Interpolater) valueModuleVar(
 	scope *InterpolationScope,
 	n string,
@@ -146,12 +216,23 @@ func (i *Interpolater) valueModuleVar(
 		// point otherwise it really is a panic.
 		value = config.UnknownVariableValue
 	} else {
-		// Get the value from the outputs
-		var ok bool
-		value, ok = mod.Outputs[v.Field]
+		// Get the value from the outputs. A map output is referenced by
+		// key as "module.name.output.key".
+		name, key := v.Field, ""
+		if idx := strings.Index(name, "."); idx >= 0 {
+			name, key = name[:idx], name[idx+1:]
+		}
+
+		raw, ok := mod.Outputs[name]
 		if !ok {
 			// Same reasons as the comment above.
-			value = config.UnknownVariableValue
+			raw = config.UnknownVariableValue
+		}
+
+		var err error
+		value, err = moduleOutputString(raw, key)
+		if err != nil {
+			return fmt.Errorf("%s: %s", n, err)
 		}
 	}
 
@@ -162,6 +243,46 @@ func (i *Interpolater) valueModuleVar(
 	return nil
 }
 
+// moduleOutputString turns the value of a module output or local value
+// into the string that is used for interpolation. Lists are joined so
+// that they're expanded in the same way as splat variables, and maps must
+// be referenced by key.
+func moduleOutputString(raw interface{}, key string) (string, error) {
+	switch v := raw.(type) {
+	case string:
+		if v == config.UnknownVariableValue {
+			return v, nil
+		}
+		if key != "" {
+			return "", fmt.Errorf("value is not a map")
+		}
+		return v, nil
+	case []interface{}:
+		if key != "" {
+			return "", fmt.Errorf("value is not a map")
+		}
+
+		parts := make([]string, len(v))
+		for i, e := range v {
+			parts[i] = fmt.Sprintf("%v", e)
+		}
+		return strings.Join(parts, config.InterpSplitDelim), nil
+	case map[string]interface{}:
+		if key == "" {
+			return "", fmt.Errorf(
+				"value is a map, reference a key with name.key")
+		}
+
+		value, ok := v[key]
+		if !ok {
+			return "", fmt.Errorf("key %q not found in map", key)
+		}
+		return fmt.Sprintf("%v", value), nil
+	default:
+		return "", fmt.Errorf("unknown output type: %T", raw)
+	}
+}
+
 func (i *Interpolater) valuePathVar(
 	scope *InterpolationScope,
 	n string,
@@ -251,6 +372,29 @@ func (i *Interpolater) valueSelfVar(
 	return i.valueResourceVar(scope, n, rv, result)
 }
 
+func (i *Interpolater) valueTerraformVar(
+	scope *InterpolationScope,
+	n string,
+	v *config.TerraformVariable,
+	result map[string]ast.Variable) error {
+	switch v.Type {
+	case config.TerraformValueEnv:
+		env := i.Env
+		if env == "" {
+			env = DefaultEnv
+		}
+
+		result[n] = ast.Variable{
+			Value: env,
+			Type:  ast.TypeString,
+		}
+	default:
+		return fmt.Errorf("%s: unknown terraform variable: %s", n, v.FullKey())
+	}
+
+	return nil
+}
+
 func (i *Interpolater) valueUserVar(
 	scope *InterpolationScope,
 	n string,
@@ -275,8 +419,10 @@ func (i *Interpolater) valueUserVar(
 
 	// Look up if we have any variables with this prefix because
 	// those are map overrides. Include those.
+	found := false
 	for k, val := range i.Variables {
 		if strings.HasPrefix(k, v.Name+".") {
+			found = true
 			result["var."+k] = ast.Variable{
 				Value: val,
 				Type:  ast.TypeString,
@@ -284,6 +430,15 @@ func (i *Interpolater) valueUserVar(
 		}
 	}
 
+	// A map that is only set by its keys has the same value as a map
+	// with a default, which is its name.
+	if _, ok := result[n]; found && !ok && v.Elem == "" {
+		result[n] = ast.Variable{
+			Value: v.Name,
+			Type:  ast.TypeString,
+		}
+	}
+
 	return nil
 }
 
@@ -321,6 +476,12 @@ func (i *Interpolater) computeResourceVariable(
 		r = nil
 	}
 	if r == nil {
+		// A data source isn't read until its configuration is known, so
+		// its attributes are unknown until then, except when applying.
+		if v.Mode == config.DataResourceMode && i.Operation != walkApply {
+			return config.UnknownVariableValue, nil
+		}
+
 		return "", fmt.Errorf(
 			"Resource '%s' not found for variable '%s'",
 			id,
@@ -335,6 +496,13 @@ func (i *Interpolater) computeResourceVariable(
 		return attr, nil
 	}
 
+	// A list attribute is interpolated as a whole by joining its
+	// elements, like the outputs of modules, so that it can be used
+	// with functions such as element and length.
+	if attr, ok := resourceListAttribute(r.Primary.Attributes, v.Field); ok {
+		return attr, nil
+	}
+
 	// At apply time, we can't do the "maybe has it" check below
 	// that we need for plans since parent elements might be computed.
 	// Therefore, it is an error and we're missing the key.
@@ -370,8 +538,9 @@ MISSING:
 	// If the operation is refresh, it isn't an error for a value to
 	// be unknown. Instead, we return that the value is computed so
 	// that the graph can continue to refresh other nodes. It doesn't
-	// matter because the config isn't interpolated anyways.
-	if i.Operation == walkRefresh {
+	// matter because the config isn't interpolated anyways. The same
+	// goes for imports, which don't use the config of the resources.
+	if i.Operation == walkRefresh || i.Operation == walkImport {
 		return config.UnknownVariableValue, nil
 	}
 
@@ -383,6 +552,36 @@ MISSING:
 		v.FullKey())
 }
 
+// resourceListAttribute returns the elements of a list attribute of a
+// resource joined by config.InterpSplitDelim. It returns false if the
+// attribute isn't a list, such as when it is a set.
+func resourceListAttribute(attrs map[string]string, field string) (string, bool) {
+	count, ok := attrs[field+".#"]
+	if !ok {
+		return "", false
+	}
+	if count == config.UnknownVariableValue {
+		return count, true
+	}
+
+	n, err := strconv.Atoi(count)
+	if err != nil {
+		return "", false
+	}
+
+	values := make([]string, n)
+	for idx := 0; idx < n; idx++ {
+		value, ok := attrs[fmt.Sprintf("%s.%d", field, idx)]
+		if !ok {
+			return "", false
+		}
+
+		values[idx] = value
+	}
+
+	return strings.Join(values, config.InterpSplitDelim), true
+}
+
 func (i *Interpolater) computeResourceMultiVariable(
 	scope *InterpolationScope,
 	v *config.ResourceVariable) (string, error) {
@@ -438,6 +637,10 @@ func (i *Interpolater) computeResourceMultiVariable(
 	}
 
 	if len(values) == 0 {
+		if v.Mode == config.DataResourceMode && i.Operation != walkApply {
+			return config.UnknownVariableValue, nil
+		}
+
 		return "", fmt.Errorf(
 			"Resource '%s' does not have attribute '%s' "+
 				"for variable '%s'",
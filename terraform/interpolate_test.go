@@ -50,6 +50,26 @@ func TestInterpolater_countIndexInWrongContext(t *testing.T) {
 	}
 }
 
+func TestInterpolater_localVariable(t *testing.T) {
+	i := &Interpolater{
+		Module: testModule(t, "interpolate-local"),
+	}
+
+	scope := &InterpolationScope{
+		Path: rootModulePath,
+	}
+
+	testInterpolate(t, i, scope, "local.prefix", ast.Variable{
+		Value: "app-prod",
+		Type:  ast.TypeString,
+	})
+
+	testInterpolate(t, i, scope, "local.tags.Name", ast.Variable{
+		Value: "app-prod",
+		Type:  ast.TypeString,
+	})
+}
+
 func TestInterpolater_moduleVariable(t *testing.T) {
 	lock := new(sync.RWMutex)
 	state := &State{
@@ -67,7 +87,7 @@ func TestInterpolater_moduleVariable(t *testing.T) {
 			},
 			&ModuleState{
 				Path: []string{RootModuleName, "child"},
-				Outputs: map[string]string{
+				Outputs: map[string]interface{}{
 					"foo": "bar",
 				},
 			},
@@ -89,6 +109,123 @@ func TestInterpolater_moduleVariable(t *testing.T) {
 	})
 }
 
+func TestInterpolater_moduleVariableList(t *testing.T) {
+	lock := new(sync.RWMutex)
+	state := &State{
+		Modules: []*ModuleState{
+			&ModuleState{
+				Path: rootModulePath,
+			},
+			&ModuleState{
+				Path: []string{RootModuleName, "child"},
+				Outputs: map[string]interface{}{
+					"ids": []interface{}{"i-1", "i-2"},
+				},
+			},
+		},
+	}
+
+	i := &Interpolater{
+		State:     state,
+		StateLock: lock,
+	}
+
+	scope := &InterpolationScope{
+		Path: rootModulePath,
+	}
+
+	testInterpolate(t, i, scope, "module.child.ids", ast.Variable{
+		Value: "i-1" + config.InterpSplitDelim + "i-2",
+		Type:  ast.TypeString,
+	})
+}
+
+func TestInterpolater_resourceVariableList(t *testing.T) {
+	lock := new(sync.RWMutex)
+	state := &State{
+		Modules: []*ModuleState{
+			&ModuleState{
+				Path: rootModulePath,
+				Resources: map[string]*ResourceState{
+					"aws_availability_zones.available": &ResourceState{
+						Type: "aws_availability_zones",
+						Primary: &InstanceState{
+							ID: "us-west-2",
+							Attributes: map[string]string{
+								"names.#": "2",
+								"names.0": "us-west-2a",
+								"names.1": "us-west-2b",
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	i := &Interpolater{
+		Module:    testModule(t, "interpolate-resource-variable-list"),
+		State:     state,
+		StateLock: lock,
+	}
+
+	scope := &InterpolationScope{
+		Path: rootModulePath,
+	}
+
+	testInterpolate(t, i, scope, "aws_availability_zones.available.names", ast.Variable{
+		Value: "us-west-2a" + config.InterpSplitDelim + "us-west-2b",
+		Type:  ast.TypeString,
+	})
+	testInterpolate(t, i, scope, "aws_availability_zones.available.names.1", ast.Variable{
+		Value: "us-west-2b",
+		Type:  ast.TypeString,
+	})
+}
+
+func TestInterpolater_moduleVariableMap(t *testing.T) {
+	lock := new(sync.RWMutex)
+	state := &State{
+		Modules: []*ModuleState{
+			&ModuleState{
+				Path: rootModulePath,
+			},
+			&ModuleState{
+				Path: []string{RootModuleName, "child"},
+				Outputs: map[string]interface{}{
+					"tags": map[string]interface{}{"Name": "web"},
+				},
+			},
+		},
+	}
+
+	i := &Interpolater{
+		State:     state,
+		StateLock: lock,
+	}
+
+	scope := &InterpolationScope{
+		Path: rootModulePath,
+	}
+
+	testInterpolate(t, i, scope, "module.child.tags.Name", ast.Variable{
+		Value: "web",
+		Type:  ast.TypeString,
+	})
+
+	// Referencing the whole map is an error
+	v, err := config.NewInterpolatedVariable("module.child.tags")
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	_, err = i.Values(scope, map[string]config.InterpolatedVariable{
+		"foo": v,
+	})
+	if err == nil {
+		t.Fatal("should error")
+	}
+}
+
 func TestInterpolater_pathCwd(t *testing.T) {
 	i := &Interpolater{}
 	scope := &InterpolationScope{}
@@ -136,6 +273,26 @@ func TestInterpolater_pathRoot(t *testing.T) {
 	})
 }
 
+func TestInterpolater_terraformEnv(t *testing.T) {
+	i := &Interpolater{Env: "staging"}
+	scope := &InterpolationScope{}
+
+	testInterpolate(t, i, scope, "terraform.env", ast.Variable{
+		Value: "staging",
+		Type:  ast.TypeString,
+	})
+}
+
+func TestInterpolater_terraformEnvDefault(t *testing.T) {
+	i := &Interpolater{}
+	scope := &InterpolationScope{}
+
+	testInterpolate(t, i, scope, "terraform.env", ast.Variable{
+		Value: DefaultEnv,
+		Type:  ast.TypeString,
+	})
+}
+
 func testInterpolate(
 	t *testing.T, i *Interpolater,
 	scope *InterpolationScope,
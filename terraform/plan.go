@@ -26,6 +26,16 @@ type Plan struct {
 	State  *State
 	Vars   map[string]string
 
+	// ConfigHash is a hash of the configuration the plan was created
+	// from, used to detect that the configuration has changed since.
+	// It is empty for plans that weren't saved with a hash.
+	ConfigHash string
+
+	// VarFiles are the paths of the variable files the plan was created
+	// with, in the order they were read. Their contents are part of
+	// ConfigHash.
+	VarFiles []string
+
 	once sync.Once
 }
 
@@ -4,11 +4,19 @@ import (
 	"fmt"
 	"regexp"
 	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform/config"
 )
 
 // ResourceAddress is a way of identifying an individual resource (or,
 // eventually, a subset of resources) within the state. It is used for Targets.
 type ResourceAddress struct {
+	// Path is the path of the module of the resource, without the root
+	// module. It is empty for the resources of the root module.
+	Path []string
+
+	Mode         config.ResourceMode
 	Index        int
 	InstanceType InstanceType
 	Name         string
@@ -20,10 +28,27 @@ func ParseResourceAddress(s string) (*ResourceAddress, error) {
 	if err != nil {
 		return nil, err
 	}
+	if matches["type"] == "module" {
+		return nil, fmt.Errorf("Address is of a module, not a resource: %q", s)
+	}
+	var path []string
+	if matches["path"] != "" {
+		parts := strings.Split(strings.TrimSuffix(matches["path"], "."), ".")
+		for i := 1; i < len(parts); i += 2 {
+			path = append(path, parts[i])
+		}
+	}
 	resourceIndex := -1
-	if matches["index"] != "" {
+	if matches["key_index"] != "" && matches["index"] != "" {
+		return nil, fmt.Errorf("Address can't have two indexes: %q", s)
+	}
+	for _, raw := range []string{matches["key_index"], matches["index"]} {
+		if raw == "" {
+			continue
+		}
+
 		var err error
-		if resourceIndex, err = strconv.Atoi(matches["index"]); err != nil {
+		if resourceIndex, err = strconv.Atoi(raw); err != nil {
 			return nil, err
 		}
 	}
@@ -35,7 +60,14 @@ func ParseResourceAddress(s string) (*ResourceAddress, error) {
 		}
 	}
 
+	mode := config.ManagedResourceMode
+	if matches["data_prefix"] != "" {
+		mode = config.DataResourceMode
+	}
+
 	return &ResourceAddress{
+		Path:         path,
+		Mode:         mode,
 		Index:        resourceIndex,
 		InstanceType: instanceType,
 		Name:         matches["name"],
@@ -54,11 +86,53 @@ func (addr *ResourceAddress) Equals(raw interface{}) bool {
 		addr.Index == other.Index)
 
 	return (indexMatch &&
+		strings.Join(addr.Path, ".") == strings.Join(other.Path, ".") &&
+		addr.Mode == other.Mode &&
 		addr.InstanceType == other.InstanceType &&
 		addr.Name == other.Name &&
 		addr.Type == other.Type)
 }
 
+// ModulePath returns the path of the module of the resource in the state,
+// which starts with the root module.
+func (addr *ResourceAddress) ModulePath() []string {
+	return append(append([]string{}, rootModulePath...), addr.Path...)
+}
+
+// StateKey returns the key of the resource in the resources of its module
+// in the state, such as "aws_instance.web.3". The key has no index if the
+// address doesn't.
+func (addr *ResourceAddress) StateKey() string {
+	key := addr.Type + "." + addr.Name
+	if addr.Mode == config.DataResourceMode {
+		key = "data." + key
+	}
+	if addr.Index != -1 {
+		key += "." + strconv.Itoa(addr.Index)
+	}
+
+	return key
+}
+
+// String returns the address in the format of the state and the plan,
+// such as "module.app.aws_instance.web.3", which can be parsed again.
+func (addr *ResourceAddress) String() string {
+	parts := make([]string, 0, len(addr.Path)*2+2)
+	for _, p := range addr.Path {
+		parts = append(parts, "module", p)
+	}
+	parts = append(parts, addr.StateKey())
+
+	switch addr.InstanceType {
+	case TypeTainted:
+		parts = append(parts, "tainted")
+	case TypeDeposed:
+		parts = append(parts, "deposed")
+	}
+
+	return strings.Join(parts, ".")
+}
+
 func ParseInstanceType(s string) (InstanceType, error) {
 	switch s {
 	case "primary":
@@ -74,12 +148,18 @@ func ParseInstanceType(s string) (InstanceType, error) {
 
 func tokenizeResourceAddress(s string) (map[string]string, error) {
 	// Example of portions of the regexp below using the
-	// string "aws_instance.web.tainted[1]"
+	// string "module.app.aws_instance.web.tainted[1]"
 	re := regexp.MustCompile(`\A` +
+		// "module.app." (optional, for a resource in a module)
+		`(?P<path>(?:module\.[^.[]+\.)*)` +
+		// "data" (optional, for the address of a data source)
+		`(?:(?P<data_prefix>data)\.)?` +
 		// "aws_instance"
 		`(?P<type>[^.]+)\.` +
 		// "web"
 		`(?P<name>[^.[]+)` +
+		// "1" (optional, the index as it is in the keys of the state)
+		`(?:\.(?P<key_index>\d+))?` +
 		// "tainted" (optional, omission implies: "primary")
 		`(?:\.(?P<instance_type>\w+))?` +
 		// "1" (optional, omission implies: "0")
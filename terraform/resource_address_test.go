@@ -3,6 +3,8 @@ package terraform
 import (
 	"reflect"
 	"testing"
+
+	"github.com/hashicorp/terraform/config"
 )
 
 func TestParseResourceAddress(t *testing.T) {
@@ -55,6 +57,16 @@ func TestParseResourceAddress(t *testing.T) {
 				Index:        -1,
 			},
 		},
+		"data source": {
+			Input: "data.aws_ami.foo",
+			Expected: &ResourceAddress{
+				Mode:         config.DataResourceMode,
+				Type:         "aws_ami",
+				Name:         "foo",
+				InstanceType: TypePrimary,
+				Index:        -1,
+			},
+		},
 		"with a hyphen": {
 			Input: "aws_instance.foo-bar",
 			Expected: &ResourceAddress{
@@ -64,6 +76,36 @@ func TestParseResourceAddress(t *testing.T) {
 				Index:        -1,
 			},
 		},
+		"index as in the state": {
+			Input: "aws_instance.foo.2",
+			Expected: &ResourceAddress{
+				Type:         "aws_instance",
+				Name:         "foo",
+				InstanceType: TypePrimary,
+				Index:        2,
+			},
+		},
+		"in a module": {
+			Input: "module.a.module.b.aws_instance.foo.tainted[2]",
+			Expected: &ResourceAddress{
+				Path:         []string{"a", "b"},
+				Type:         "aws_instance",
+				Name:         "foo",
+				InstanceType: TypeTainted,
+				Index:        2,
+			},
+		},
+		"data source in a module": {
+			Input: "module.a.data.aws_ami.foo",
+			Expected: &ResourceAddress{
+				Path:         []string{"a"},
+				Mode:         config.DataResourceMode,
+				Type:         "aws_ami",
+				Name:         "foo",
+				InstanceType: TypePrimary,
+				Index:        -1,
+			},
+		},
 	}
 
 	for tn, tc := range cases {
@@ -78,6 +120,82 @@ func TestParseResourceAddress(t *testing.T) {
 	}
 }
 
+func TestParseResourceAddress_invalid(t *testing.T) {
+	cases := []string{
+		"aws_instance",
+		"aws_instance.foo.bar",
+		"aws_instance.foo.2[3]",
+		"module.a",
+		"module.a.aws_instance",
+	}
+
+	for _, tc := range cases {
+		if _, err := ParseResourceAddress(tc); err == nil {
+			t.Fatalf("%s: should error", tc)
+		}
+	}
+}
+
+func TestResourceAddressString(t *testing.T) {
+	cases := []struct {
+		Input    string
+		Path     []string
+		StateKey string
+		String   string
+	}{
+		{
+			"aws_instance.foo",
+			[]string{"root"},
+			"aws_instance.foo",
+			"aws_instance.foo",
+		},
+		{
+			"aws_instance.foo[1]",
+			[]string{"root"},
+			"aws_instance.foo.1",
+			"aws_instance.foo.1",
+		},
+		{
+			"module.a.module.b.data.aws_ami.foo",
+			[]string{"root", "a", "b"},
+			"data.aws_ami.foo",
+			"module.a.module.b.data.aws_ami.foo",
+		},
+		{
+			"module.a.aws_instance.foo.deposed[0]",
+			[]string{"root", "a"},
+			"aws_instance.foo.0",
+			"module.a.aws_instance.foo.0.deposed",
+		},
+	}
+
+	for _, tc := range cases {
+		addr, err := ParseResourceAddress(tc.Input)
+		if err != nil {
+			t.Fatalf("%s: err: %s", tc.Input, err)
+		}
+
+		if path := addr.ModulePath(); !reflect.DeepEqual(path, tc.Path) {
+			t.Fatalf("%s: bad path: %#v", tc.Input, path)
+		}
+		if key := addr.StateKey(); key != tc.StateKey {
+			t.Fatalf("%s: bad key: %s", tc.Input, key)
+		}
+		if s := addr.String(); s != tc.String {
+			t.Fatalf("%s: bad string: %s", tc.Input, s)
+		}
+
+		// The string must be parsed to the same address
+		again, err := ParseResourceAddress(addr.String())
+		if err != nil {
+			t.Fatalf("%s: err: %s", tc.Input, err)
+		}
+		if !reflect.DeepEqual(again, addr) {
+			t.Fatalf("%s: bad: %#v", tc.Input, again)
+		}
+	}
+}
+
 func TestResourceAddressEquals(t *testing.T) {
 	cases := map[string]struct {
 		Address *ResourceAddress
@@ -174,6 +292,22 @@ func TestResourceAddressEquals(t *testing.T) {
 			},
 			Expect: false,
 		},
+		"different mode": {
+			Address: &ResourceAddress{
+				Mode:         config.DataResourceMode,
+				Type:         "aws_ami",
+				Name:         "foo",
+				InstanceType: TypePrimary,
+				Index:        -1,
+			},
+			Other: &ResourceAddress{
+				Type:         "aws_ami",
+				Name:         "foo",
+				InstanceType: TypePrimary,
+				Index:        -1,
+			},
+			Expect: false,
+		},
 		"different instance type": {
 			Address: &ResourceAddress{
 				Type:         "aws_instance",
@@ -189,6 +323,22 @@ func TestResourceAddressEquals(t *testing.T) {
 			},
 			Expect: false,
 		},
+		"different module": {
+			Address: &ResourceAddress{
+				Path:         []string{"a"},
+				Type:         "aws_instance",
+				Name:         "foo",
+				InstanceType: TypePrimary,
+				Index:        -1,
+			},
+			Other: &ResourceAddress{
+				Type:         "aws_instance",
+				Name:         "foo",
+				InstanceType: TypePrimary,
+				Index:        -1,
+			},
+			Expect: false,
+		},
 		"different index": {
 			Address: &ResourceAddress{
 				Type:         "aws_instance",
@@ -71,11 +71,62 @@ type ResourceProvider interface {
 	Refresh(*InstanceInfo, *InstanceState) (*InstanceState, error)
 }
 
+// ResourceProviderVersioner is an optional interface for resource
+// providers that know their version, such as plugins that report it when
+// they are started. It is used to check the version constraints of
+// provider configurations.
+type ResourceProviderVersioner interface {
+	// ProviderVersion returns the version of the provider, or an empty
+	// string if it is unknown.
+	ProviderVersion() string
+}
+
+// ResourceProviderStopper is an optional interface for resource providers
+// that can abort their in-flight operations, such as waiting for a resource
+// to become available, when Terraform is interrupted. Stop may be called
+// concurrently with any other method and must return promptly.
+type ResourceProviderStopper interface {
+	Stop() error
+}
+
+// ResourceProviderImporter is an optional interface for resource providers
+// that can import existing resources into the state, so that Terraform
+// manages them from then on.
+type ResourceProviderImporter interface {
+	// ImportState returns the state of the resource of the given type
+	// that has the given ID, as known by the provider. It returns a nil
+	// state if the resource doesn't exist.
+	ImportState(*InstanceInfo, string) (*InstanceState, error)
+}
+
+// ResourceProviderDataSourcer is an optional interface for resource
+// providers that have data sources: types of resources that Terraform only
+// reads, such as the AMIs of a cloud, so that their attributes can be used
+// in the configuration of other resources.
+type ResourceProviderDataSourcer interface {
+	// ValidateDataSource is called once at the beginning with the raw
+	// configuration of a data source, just like ValidateResource.
+	ValidateDataSource(string, *ResourceConfig) ([]string, []error)
+
+	// ReadDataSource returns the state of the data source of the given
+	// type for the given configuration, which is fully interpolated.
+	ReadDataSource(*InstanceInfo, *ResourceConfig) (*InstanceState, error)
+
+	// DataSources returns all the available data source types that this
+	// provider knows how to read.
+	DataSources() []DataSource
+}
+
 // ResourceType is a type of resource that a resource provider can manage.
 type ResourceType struct {
 	Name string
 }
 
+// DataSource is a type of data source that a resource provider can read.
+type DataSource struct {
+	Name string
+}
+
 // ResourceProviderFactory is a function type that creates a new instance
 // of a resource provider.
 type ResourceProviderFactory func() (ResourceProvider, error)
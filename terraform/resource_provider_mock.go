@@ -10,49 +10,74 @@ type MockResourceProvider struct {
 	// Anything you want, in case you need to store extra data with the mock.
 	Meta interface{}
 
-	InputCalled                  bool
-	InputInput                   UIInput
-	InputConfig                  *ResourceConfig
-	InputReturnConfig            *ResourceConfig
-	InputReturnError             error
-	InputFn                      func(UIInput, *ResourceConfig) (*ResourceConfig, error)
-	ApplyCalled                  bool
-	ApplyInfo                    *InstanceInfo
-	ApplyState                   *InstanceState
-	ApplyDiff                    *InstanceDiff
-	ApplyFn                      func(*InstanceInfo, *InstanceState, *InstanceDiff) (*InstanceState, error)
-	ApplyReturn                  *InstanceState
-	ApplyReturnError             error
-	ConfigureCalled              bool
-	ConfigureConfig              *ResourceConfig
-	ConfigureFn                  func(*ResourceConfig) error
-	ConfigureReturnError         error
-	DiffCalled                   bool
-	DiffInfo                     *InstanceInfo
-	DiffState                    *InstanceState
-	DiffDesired                  *ResourceConfig
-	DiffFn                       func(*InstanceInfo, *InstanceState, *ResourceConfig) (*InstanceDiff, error)
-	DiffReturn                   *InstanceDiff
-	DiffReturnError              error
-	RefreshCalled                bool
-	RefreshInfo                  *InstanceInfo
-	RefreshState                 *InstanceState
-	RefreshFn                    func(*InstanceInfo, *InstanceState) (*InstanceState, error)
-	RefreshReturn                *InstanceState
-	RefreshReturnError           error
-	ResourcesCalled              bool
-	ResourcesReturn              []ResourceType
-	ValidateCalled               bool
-	ValidateConfig               *ResourceConfig
-	ValidateFn                   func(*ResourceConfig) ([]string, []error)
-	ValidateReturnWarns          []string
-	ValidateReturnErrors         []error
-	ValidateResourceFn           func(string, *ResourceConfig) ([]string, []error)
-	ValidateResourceCalled       bool
-	ValidateResourceType         string
-	ValidateResourceConfig       *ResourceConfig
-	ValidateResourceReturnWarns  []string
-	ValidateResourceReturnErrors []error
+	InputCalled                    bool
+	InputInput                     UIInput
+	InputConfig                    *ResourceConfig
+	InputReturnConfig              *ResourceConfig
+	InputReturnError               error
+	InputFn                        func(UIInput, *ResourceConfig) (*ResourceConfig, error)
+	ApplyCalled                    bool
+	ApplyInfo                      *InstanceInfo
+	ApplyState                     *InstanceState
+	ApplyDiff                      *InstanceDiff
+	ApplyFn                        func(*InstanceInfo, *InstanceState, *InstanceDiff) (*InstanceState, error)
+	ApplyReturn                    *InstanceState
+	ApplyReturnError               error
+	ConfigureCalled                bool
+	ConfigureConfig                *ResourceConfig
+	ConfigureFn                    func(*ResourceConfig) error
+	ConfigureReturnError           error
+	DataSourcesCalled              bool
+	DataSourcesReturn              []DataSource
+	DiffCalled                     bool
+	DiffInfo                       *InstanceInfo
+	DiffState                      *InstanceState
+	DiffDesired                    *ResourceConfig
+	DiffFn                         func(*InstanceInfo, *InstanceState, *ResourceConfig) (*InstanceDiff, error)
+	DiffReturn                     *InstanceDiff
+	DiffReturnError                error
+	ImportStateCalled              bool
+	ImportStateInfo                *InstanceInfo
+	ImportStateID                  string
+	ImportStateFn                  func(*InstanceInfo, string) (*InstanceState, error)
+	ImportStateReturn              *InstanceState
+	ImportStateReturnError         error
+	ProviderVersionCalled          bool
+	ProviderVersionReturn          string
+	ReadDataSourceCalled           bool
+	ReadDataSourceInfo             *InstanceInfo
+	ReadDataSourceConfig           *ResourceConfig
+	ReadDataSourceFn               func(*InstanceInfo, *ResourceConfig) (*InstanceState, error)
+	ReadDataSourceReturn           *InstanceState
+	ReadDataSourceReturnError      error
+	RefreshCalled                  bool
+	RefreshInfo                    *InstanceInfo
+	RefreshState                   *InstanceState
+	RefreshFn                      func(*InstanceInfo, *InstanceState) (*InstanceState, error)
+	RefreshReturn                  *InstanceState
+	RefreshReturnError             error
+	ResourcesCalled                bool
+	ResourcesReturn                []ResourceType
+	StopCalled                     bool
+	StopFn                         func() error
+	StopReturnError                error
+	ValidateCalled                 bool
+	ValidateConfig                 *ResourceConfig
+	ValidateFn                     func(*ResourceConfig) ([]string, []error)
+	ValidateReturnWarns            []string
+	ValidateReturnErrors           []error
+	ValidateDataSourceFn           func(string, *ResourceConfig) ([]string, []error)
+	ValidateDataSourceCalled       bool
+	ValidateDataSourceType         string
+	ValidateDataSourceConfig       *ResourceConfig
+	ValidateDataSourceReturnWarns  []string
+	ValidateDataSourceReturnErrors []error
+	ValidateResourceFn             func(string, *ResourceConfig) ([]string, []error)
+	ValidateResourceCalled         bool
+	ValidateResourceType           string
+	ValidateResourceConfig         *ResourceConfig
+	ValidateResourceReturnWarns    []string
+	ValidateResourceReturnErrors   []error
 }
 
 func (p *MockResourceProvider) Input(
@@ -93,6 +118,22 @@ func (p *MockResourceProvider) ValidateResource(t string, c *ResourceConfig) ([]
 	return p.ValidateResourceReturnWarns, p.ValidateResourceReturnErrors
 }
 
+func (p *MockResourceProvider) ValidateDataSource(
+	t string, c *ResourceConfig) ([]string, []error) {
+	p.Lock()
+	defer p.Unlock()
+
+	p.ValidateDataSourceCalled = true
+	p.ValidateDataSourceType = t
+	p.ValidateDataSourceConfig = c
+
+	if p.ValidateDataSourceFn != nil {
+		return p.ValidateDataSourceFn(t, c)
+	}
+
+	return p.ValidateDataSourceReturnWarns, p.ValidateDataSourceReturnErrors
+}
+
 func (p *MockResourceProvider) Configure(c *ResourceConfig) error {
 	p.Lock()
 	defer p.Unlock()
@@ -160,6 +201,56 @@ func (p *MockResourceProvider) Refresh(
 	return p.RefreshReturn, p.RefreshReturnError
 }
 
+func (p *MockResourceProvider) ImportState(
+	info *InstanceInfo,
+	id string) (*InstanceState, error) {
+	p.Lock()
+	defer p.Unlock()
+
+	p.ImportStateCalled = true
+	p.ImportStateInfo = info
+	p.ImportStateID = id
+
+	if p.ImportStateFn != nil {
+		return p.ImportStateFn(info, id)
+	}
+
+	return p.ImportStateReturn, p.ImportStateReturnError
+}
+
+func (p *MockResourceProvider) ReadDataSource(
+	info *InstanceInfo,
+	c *ResourceConfig) (*InstanceState, error) {
+	p.Lock()
+	defer p.Unlock()
+
+	p.ReadDataSourceCalled = true
+	p.ReadDataSourceInfo = info
+	p.ReadDataSourceConfig = c
+
+	if p.ReadDataSourceFn != nil {
+		return p.ReadDataSourceFn(info, c)
+	}
+
+	return p.ReadDataSourceReturn, p.ReadDataSourceReturnError
+}
+
+func (p *MockResourceProvider) DataSources() []DataSource {
+	p.Lock()
+	defer p.Unlock()
+
+	p.DataSourcesCalled = true
+	return p.DataSourcesReturn
+}
+
+func (p *MockResourceProvider) ProviderVersion() string {
+	p.Lock()
+	defer p.Unlock()
+
+	p.ProviderVersionCalled = true
+	return p.ProviderVersionReturn
+}
+
 func (p *MockResourceProvider) Resources() []ResourceType {
 	p.Lock()
 	defer p.Unlock()
@@ -167,3 +258,14 @@ func (p *MockResourceProvider) Resources() []ResourceType {
 	p.ResourcesCalled = true
 	return p.ResourcesReturn
 }
+
+// Stop doesn't lock the mock, since it is called while the other methods
+// are running.
+func (p *MockResourceProvider) Stop() error {
+	p.StopCalled = true
+	if p.StopFn != nil {
+		return p.StopFn()
+	}
+
+	return p.StopReturnError
+}
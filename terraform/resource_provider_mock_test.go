@@ -7,3 +7,11 @@ import (
 func TestMockResourceProvider_impl(t *testing.T) {
 	var _ ResourceProvider = new(MockResourceProvider)
 }
+
+func TestMockResourceProvider_importer(t *testing.T) {
+	var _ ResourceProviderImporter = new(MockResourceProvider)
+}
+
+func TestMockResourceProvider_dataSourcer(t *testing.T) {
+	var _ ResourceProviderDataSourcer = new(MockResourceProvider)
+}
@@ -2,6 +2,7 @@ package terraform
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/hashicorp/go-multierror"
 	"github.com/hashicorp/terraform/config"
@@ -86,6 +87,12 @@ func smcUserVariables(c *config.Config, vs map[string]string) []error {
 	}
 	for k, _ := range vs {
 		delete(required, k)
+
+		// Maps are set as the values of their keys, such as
+		// "envs.prod.cidr" for the variable "envs".
+		if idx := strings.Index(k, "."); idx != -1 {
+			delete(required, k[:idx])
+		}
 	}
 	if len(required) > 0 {
 		for k, _ := range required {
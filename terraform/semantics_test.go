@@ -37,4 +37,11 @@ func TestSMCUserVariables(t *testing.T) {
 		t.Fatal("should have errors")
 	}
 
+	// Required variable set as a mapping
+	errs = smcUserVariables(c, map[string]string{
+		"foo.prod.cidr": "10.0.0.0/16",
+	})
+	if len(errs) != 0 {
+		t.Fatalf("err: %#v", errs)
+	}
 }
@@ -3,6 +3,8 @@ package terraform
 import (
 	"bufio"
 	"bytes"
+	"crypto/rand"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -15,8 +17,10 @@ import (
 )
 
 const (
-	// StateVersion is the current version for our state file
-	StateVersion = 1
+	// StateVersion is the current version for our state file. Version 2
+	// allows the outputs of modules to be lists and maps, where version 1
+	// only had strings.
+	StateVersion = 2
 )
 
 // rootModulePath is the path of the root module
@@ -26,7 +30,7 @@ var rootModulePath = []string{"root"}
 // can use to keep track of what real world resources it is actually
 // managing. This is the latest format as of Terraform 0.3
 type State struct {
-	// Version is the protocol version. Currently only "1".
+	// Version is the protocol version. Currently "2".
 	Version int `json:"version"`
 
 	// Serial is incremented on any operation that modifies
@@ -34,6 +38,12 @@ type State struct {
 	// updates.
 	Serial int64 `json:"serial"`
 
+	// Lineage is set when a new state is created and never changes, so
+	// that two states with the same lineage are known to be versions of
+	// the same state. States written by older versions have no lineage
+	// until they are written again.
+	Lineage string `json:"lineage,omitempty"`
+
 	// Remote is used to track the metadata required to
 	// pull and push state files from a remote storage endpoint.
 	Remote *RemoteState `json:"remote,omitempty"`
@@ -44,7 +54,7 @@ type State struct {
 
 // NewState is used to initialize a blank state
 func NewState() *State {
-	s := &State{}
+	s := &State{Lineage: newLineage()}
 	s.init()
 	return s
 }
@@ -200,6 +210,7 @@ func (s *State) DeepCopy() *State {
 	n := &State{
 		Version: s.Version,
 		Serial:  s.Serial,
+		Lineage: s.Lineage,
 		Modules: make([]*ModuleState, 0, len(s.Modules)),
 	}
 	for _, mod := range s.Modules {
@@ -232,6 +243,59 @@ func (s *State) IncrementSerialMaybe(other *State) {
 	}
 }
 
+// taintResources taints the resources that match the given addresses,
+// in the module of each address, so that they are replaced by the next
+// plan. It is an error if an address doesn't match any resource.
+func (s *State) taintResources(addrs []string) error {
+	for _, raw := range addrs {
+		addr, err := ParseResourceAddress(raw)
+		if err != nil {
+			return err
+		}
+		if addr.InstanceType != TypePrimary {
+			return fmt.Errorf(
+				"Only primary instances can be replaced: %s", raw)
+		}
+
+		var resources map[string]*ResourceState
+		if mod := s.ModuleByPath(addr.ModulePath()); mod != nil {
+			resources = mod.Resources
+		}
+
+		found := false
+		for k, rs := range resources {
+			if !resourceKeyMatches(k, addr) {
+				continue
+			}
+
+			found = true
+			rs.Taint()
+		}
+		if !found {
+			return fmt.Errorf(
+				"Resource to replace not found in the state: %s", raw)
+		}
+	}
+
+	return nil
+}
+
+// resourceKeyMatches returns true if the resource with the given key in
+// the resources of the module of the address matches the address. A key
+// without an index is the only instance of the resource, with the index 0.
+func resourceKeyMatches(key string, addr *ResourceAddress) bool {
+	keyAddr, err := ParseResourceAddress(key)
+	if err != nil {
+		return false
+	}
+	if keyAddr.Index == -1 {
+		keyAddr.Index = 0
+	}
+	keyAddr.Path = addr.Path
+
+	return addr.Equals(keyAddr)
+}
+
 func (s *State) init() {
 	if s.Version == 0 {
 		s.Version = StateVersion
@@ -359,7 +423,10 @@ type ModuleState struct {
 	// Outputs declared by the module and maintained for each module
 	// even though only the root module technically needs to be kept.
 	// This allows operators to inspect values at the boundaries.
-	Outputs map[string]string `json:"outputs"`
+	//
+	// Each value is either a string, a list of strings ([]interface{}),
+	// or a map of strings (map[string]interface{}).
+	Outputs map[string]interface{} `json:"outputs"`
 
 	// Resources is a mapping of the logically named resource to
 	// the state of the resource. Each resource may actually have
@@ -394,7 +461,7 @@ func (m *ModuleState) Equal(other *ModuleState) bool {
 		return false
 	}
 	for k, v := range m.Outputs {
-		if other.Outputs[k] != v {
+		if !reflect.DeepEqual(other.Outputs[k], v) {
 			return false
 		}
 	}
@@ -484,7 +551,7 @@ func (m *ModuleState) View(id string) *ModuleState {
 
 func (m *ModuleState) init() {
 	if m.Outputs == nil {
-		m.Outputs = make(map[string]string)
+		m.Outputs = make(map[string]interface{})
 	}
 	if m.Resources == nil {
 		m.Resources = make(map[string]*ResourceState)
@@ -497,12 +564,12 @@ func (m *ModuleState) deepcopy() *ModuleState {
 	}
 	n := &ModuleState{
 		Path:      make([]string, len(m.Path)),
-		Outputs:   make(map[string]string, len(m.Outputs)),
+		Outputs:   make(map[string]interface{}, len(m.Outputs)),
 		Resources: make(map[string]*ResourceState, len(m.Resources)),
 	}
 	copy(n.Path, m.Path)
 	for k, v := range m.Outputs {
-		n.Outputs[k] = v
+		n.Outputs[k] = copyOutputValue(v)
 	}
 	for k, v := range m.Resources {
 		n.Resources[k] = v.deepcopy()
@@ -622,13 +689,59 @@ func (m *ModuleState) String() string {
 
 		for _, k := range ks {
 			v := m.Outputs[k]
-			buf.WriteString(fmt.Sprintf("%s = %s\n", k, v))
+			buf.WriteString(fmt.Sprintf("%s = %s\n", k, outputValueString(v)))
 		}
 	}
 
 	return buf.String()
 }
 
+// copyOutputValue returns a copy of an output value so that lists and
+// maps aren't shared between copies of a state.
+func copyOutputValue(v interface{}) interface{} {
+	switch v := v.(type) {
+	case []interface{}:
+		result := make([]interface{}, len(v))
+		copy(result, v)
+		return result
+	case map[string]interface{}:
+		result := make(map[string]interface{}, len(v))
+		for k, v := range v {
+			result[k] = v
+		}
+		return result
+	default:
+		return v
+	}
+}
+
+// outputValueString returns a single-line representation of an output
+// value for the string representation of the state.
+func outputValueString(v interface{}) string {
+	switch v := v.(type) {
+	case []interface{}:
+		parts := make([]string, len(v))
+		for i, e := range v {
+			parts[i] = fmt.Sprintf("%v", e)
+		}
+		return fmt.Sprintf("[%s]", strings.Join(parts, ", "))
+	case map[string]interface{}:
+		ks := make([]string, 0, len(v))
+		for k, _ := range v {
+			ks = append(ks, k)
+		}
+		sort.Strings(ks)
+
+		parts := make([]string, len(ks))
+		for i, k := range ks {
+			parts[i] = fmt.Sprintf("%s = %v", k, v[k])
+		}
+		return fmt.Sprintf("{%s}", strings.Join(parts, ", "))
+	default:
+		return fmt.Sprintf("%v", v)
+	}
+}
+
 // ResourceState holds the state of a resource that is used so that
 // a provider can find and manage an existing resource as well as for
 // storing attributes that are used to populate variables of child
@@ -765,6 +878,21 @@ func (r *ResourceState) Taint() {
 	r.Primary = nil
 }
 
+// Untaint makes the tainted instance with the given index the primary
+// instance again. It does nothing if there is a primary instance already
+// or there is no tainted instance with the index.
+func (r *ResourceState) Untaint(idx int) {
+	if r.Primary != nil || idx < 0 || idx >= len(r.Tainted) {
+		return
+	}
+
+	r.Primary = r.Tainted[idx]
+	r.Tainted = append(r.Tainted[:idx], r.Tainted[idx+1:]...)
+	if len(r.Tainted) == 0 {
+		r.Tainted = nil
+	}
+}
+
 func (r *ResourceState) init() {
 	if r.Primary == nil {
 		r.Primary = &InstanceState{}
@@ -1067,6 +1195,13 @@ func ReadState(src io.Reader) (*State, error) {
 			state.Version)
 	}
 
+	// Upgrade the states written before lists and maps were outputs
+	if state.Version < 2 {
+		if err := upgradeV1Outputs(state); err != nil {
+			return nil, err
+		}
+	}
+
 	// Sort it
 	state.sort()
 
@@ -1078,8 +1213,11 @@ func WriteState(d *State, dst io.Writer) error {
 	// Make sure it is sorted
 	d.sort()
 
-	// Ensure the version is set
+	// Ensure the version and lineage are set
 	d.Version = StateVersion
+	if d.Lineage == "" {
+		d.Lineage = newLineage()
+	}
 
 	// Encode the data in a human-friendly way
 	data, err := json.MarshalIndent(d, "", "    ")
@@ -1098,6 +1236,16 @@ func WriteState(d *State, dst io.Writer) error {
 	return nil
 }
 
+// newLineage returns a new random lineage for a state.
+func newLineage() string {
+	var id [16]byte
+	if _, err := rand.Read(id[:]); err != nil {
+		panic(fmt.Sprintf("failed to generate state lineage: %s", err))
+	}
+
+	return hex.EncodeToString(id[:])
+}
+
 // upgradeV1State is used to upgrade a V1 state representation
 // into a proper State representation.
 func upgradeV1State(old *StateV1) (*State, error) {
@@ -1109,7 +1257,9 @@ func upgradeV1State(old *StateV1) (*State, error) {
 	root := s.RootModule()
 
 	// Copy the outputs
-	root.Outputs = old.Outputs
+	for k, v := range old.Outputs {
+		root.Outputs[k] = v
+	}
 
 	// Upgrade the resources
 	for id, rs := range old.Resources {
@@ -1135,7 +1285,7 @@ func upgradeV1State(old *StateV1) (*State, error) {
 		// no upgrade path for this! Now totally deprecated.
 		if len(rs.Extra) > 0 {
 			log.Printf(
-				"[WARN] Resource %s uses deprecated attribute "+
+				"[WARN] core: Resource %s uses deprecated attribute "+
 					"storage, state file upgrade may be incomplete.",
 				rs.ID,
 			)
@@ -1144,6 +1294,25 @@ func upgradeV1State(old *StateV1) (*State, error) {
 	return s, nil
 }
 
+// upgradeV1Outputs upgrades a version 1 state, whose outputs were all
+// strings, to the current version. The strings decode as they are, so
+// this only checks that there is nothing else among them.
+func upgradeV1Outputs(s *State) error {
+	for _, m := range s.Modules {
+		for k, v := range m.Outputs {
+			if _, ok := v.(string); !ok {
+				return fmt.Errorf(
+					"State version %d can only have string outputs, "+
+						"output %q in module %s is a %T",
+					s.Version, k, strings.Join(m.Path, "."), v)
+			}
+		}
+	}
+
+	s.Version = StateVersion
+	return nil
+}
+
 // moduleStateSort implements sort.Interface to sort module states
 type moduleStateSort []*ModuleState
 
@@ -366,6 +366,89 @@ func TestResourceStateTaint(t *testing.T) {
 	}
 }
 
+func TestResourceStateUntaint(t *testing.T) {
+	cases := map[string]struct {
+		Input  *ResourceState
+		Index  int
+		Output *ResourceState
+	}{
+		"no tainted": {
+			&ResourceState{},
+			0,
+			&ResourceState{},
+		},
+
+		"primary": {
+			&ResourceState{
+				Primary: &InstanceState{ID: "foo"},
+				Tainted: []*InstanceState{
+					&InstanceState{ID: "bar"},
+				},
+			},
+			0,
+			&ResourceState{
+				Primary: &InstanceState{ID: "foo"},
+				Tainted: []*InstanceState{
+					&InstanceState{ID: "bar"},
+				},
+			},
+		},
+
+		"one tainted": {
+			&ResourceState{
+				Tainted: []*InstanceState{
+					&InstanceState{ID: "foo"},
+				},
+			},
+			0,
+			&ResourceState{
+				Primary: &InstanceState{ID: "foo"},
+			},
+		},
+
+		"many tainted": {
+			&ResourceState{
+				Tainted: []*InstanceState{
+					&InstanceState{ID: "foo"},
+					&InstanceState{ID: "bar"},
+					&InstanceState{ID: "baz"},
+				},
+			},
+			1,
+			&ResourceState{
+				Primary: &InstanceState{ID: "bar"},
+				Tainted: []*InstanceState{
+					&InstanceState{ID: "foo"},
+					&InstanceState{ID: "baz"},
+				},
+			},
+		},
+
+		"bad index": {
+			&ResourceState{
+				Tainted: []*InstanceState{
+					&InstanceState{ID: "foo"},
+				},
+			},
+			1,
+			&ResourceState{
+				Tainted: []*InstanceState{
+					&InstanceState{ID: "foo"},
+				},
+			},
+		},
+	}
+
+	for k, tc := range cases {
+		tc.Input.Untaint(tc.Index)
+		if !reflect.DeepEqual(tc.Input, tc.Output) {
+			t.Fatalf(
+				"Failure: %s\n\nExpected: %#v\n\nGot: %#v",
+				k, tc.Output, tc.Input)
+		}
+	}
+}
+
 func TestInstanceStateEmpty(t *testing.T) {
 	cases := map[string]struct {
 		In     *InstanceState
@@ -692,6 +775,36 @@ func TestReadWriteState(t *testing.T) {
 	}
 }
 
+func TestWriteState_lineage(t *testing.T) {
+	// New states have their own lineage
+	a, b := NewState(), NewState()
+	if a.Lineage == "" || a.Lineage == b.Lineage {
+		t.Fatalf("bad: %q %q", a.Lineage, b.Lineage)
+	}
+
+	// States without a lineage get one when they're written, and it
+	// is kept when they're read back.
+	state := &State{}
+	buf := new(bytes.Buffer)
+	if err := WriteState(state, buf); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if state.Lineage == "" {
+		t.Fatal("lineage should be set")
+	}
+
+	actual, err := ReadState(buf)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if actual.Lineage != state.Lineage {
+		t.Fatalf("bad: %q", actual.Lineage)
+	}
+	if actual.DeepCopy().Lineage != state.Lineage {
+		t.Fatal("copy should keep the lineage")
+	}
+}
+
 func TestReadStateNewVersion(t *testing.T) {
 	type out struct {
 		Version int
@@ -711,6 +824,49 @@ func TestReadStateNewVersion(t *testing.T) {
 	}
 }
 
+func TestReadState_version1Outputs(t *testing.T) {
+	src := `{
+    "version": 1,
+    "serial": 3,
+    "modules": [
+        {
+            "path": ["root"],
+            "outputs": {
+                "ip": "127.0.0.1",
+                "ids": "i-abc,i-def"
+            },
+            "resources": {}
+        }
+    ]
+}`
+
+	s, err := ReadState(strings.NewReader(src))
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if s.Version != StateVersion {
+		t.Fatalf("bad version number: %d", s.Version)
+	}
+
+	expected := map[string]interface{}{
+		"ip":  "127.0.0.1",
+		"ids": "i-abc,i-def",
+	}
+	if !reflect.DeepEqual(s.RootModule().Outputs, expected) {
+		t.Fatalf("bad: %#v", s.RootModule().Outputs)
+	}
+
+	// Only the current version can have lists and maps
+	src = strings.Replace(src, `"i-abc,i-def"`, `["i-abc", "i-def"]`, 1)
+	if _, err := ReadState(strings.NewReader(src)); err == nil {
+		t.Fatal("should error")
+	}
+	src = strings.Replace(src, `"version": 1`, `"version": 2`, 1)
+	if _, err := ReadState(strings.NewReader(src)); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+}
+
 func TestUpgradeV1State(t *testing.T) {
 	old := &StateV1{
 		Outputs: map[string]string{
@@ -784,3 +940,84 @@ func TestUpgradeV1State(t *testing.T) {
 		t.Fatalf("bad: %#v", bt)
 	}
 }
+
+func TestStateTaintResources(t *testing.T) {
+	state := &State{
+		Modules: []*ModuleState{
+			&ModuleState{
+				Path: rootModulePath,
+				Resources: map[string]*ResourceState{
+					"aws_instance.web": &ResourceState{
+						Type:    "aws_instance",
+						Primary: &InstanceState{ID: "root"},
+					},
+				},
+			},
+			&ModuleState{
+				Path: []string{"root", "child"},
+				Resources: map[string]*ResourceState{
+					"aws_instance.web.0": &ResourceState{
+						Type:    "aws_instance",
+						Primary: &InstanceState{ID: "child0"},
+					},
+					"aws_instance.web.1": &ResourceState{
+						Type:    "aws_instance",
+						Primary: &InstanceState{ID: "child1"},
+					},
+				},
+			},
+		},
+	}
+
+	if err := state.taintResources([]string{"module.child.aws_instance.web[1]"}); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	child := state.ModuleByPath([]string{"root", "child"})
+	if rs := child.Resources["aws_instance.web.1"]; rs.Primary != nil || len(rs.Tainted) != 1 {
+		t.Fatalf("bad: %#v", rs)
+	}
+	if rs := child.Resources["aws_instance.web.0"]; rs.Primary == nil {
+		t.Fatalf("bad: %#v", rs)
+	}
+	if rs := state.RootModule().Resources["aws_instance.web"]; rs.Primary == nil {
+		t.Fatalf("bad: %#v", rs)
+	}
+
+	for _, addr := range []string{"module.nope.aws_instance.web", "module.child.aws_eip.web"} {
+		if err := state.taintResources([]string{addr}); err == nil {
+			t.Fatalf("%s: should error", addr)
+		}
+	}
+}
+
+func TestResourceKeyMatches(t *testing.T) {
+	cases := []struct {
+		Key      string
+		Addr     string
+		Expected bool
+	}{
+		{"aws_instance.web", "aws_instance.web", true},
+		{"aws_instance.web.2", "aws_instance.web", true},
+		{"aws_instance.web", "aws_instance.web[0]", true},
+		{"aws_instance.web", "aws_instance.web[1]", false},
+		{"aws_instance.web.1", "aws_instance.web[1]", true},
+		{"aws_instance.web.1", "aws_instance.web[2]", false},
+		{"aws_instance.webs", "aws_instance.web", false},
+		{"aws_eip.web", "aws_instance.web", false},
+		{"data.aws_ami.web", "data.aws_ami.web", true},
+		{"data.aws_ami.web", "aws_ami.web", false},
+		{"aws_instance.web", "module.child.aws_instance.web", true},
+	}
+
+	for i, tc := range cases {
+		addr, err := ParseResourceAddress(tc.Addr)
+		if err != nil {
+			t.Fatalf("%d: err: %s", i, err)
+		}
+
+		if actual := resourceKeyMatches(tc.Key, addr); actual != tc.Expected {
+			t.Fatalf("%d: bad: %#v", i, actual)
+		}
+	}
+}
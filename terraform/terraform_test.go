@@ -1104,6 +1104,18 @@ module.child:
     ID = baz
 `
 
+const testTerraformPlanLocalsStr = `
+DIFF:
+
+CREATE: aws_instance.foo
+  foo:  "" => "app-prod-web"
+  type: "" => "aws_instance"
+
+STATE:
+
+<no state>
+`
+
 const testTerraformPlanModuleVarStr = `
 DIFF:
 
@@ -1244,3 +1256,10 @@ STATE:
 
 <no state>
 `
+
+const testTerraformImportStr = `
+aws_instance.bar.1:
+  ID = i-def456
+aws_instance.foo:
+  ID = i-abc123
+`
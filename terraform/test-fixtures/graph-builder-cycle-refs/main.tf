@@ -0,0 +1,11 @@
+resource "aws_security_group" "a" {
+    ingress {
+        security_groups = ["${aws_security_group.b.id}"]
+    }
+}
+
+resource "aws_security_group" "b" {
+    ingress {
+        security_groups = ["${aws_security_group.a.id}"]
+    }
+}
@@ -0,0 +1,9 @@
+provider "aws" {
+    foo = "bar"
+}
+
+resource "aws_instance" "foo" {}
+
+resource "aws_instance" "bar" {
+    count = 2
+}
@@ -0,0 +1,10 @@
+variable "env" {
+    default = "prod"
+}
+
+locals {
+    prefix = "app-${var.env}"
+    tags {
+        Name = "${local.prefix}"
+    }
+}
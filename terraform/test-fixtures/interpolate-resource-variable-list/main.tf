@@ -0,0 +1 @@
+resource "aws_availability_zones" "available" {}
@@ -0,0 +1,8 @@
+data "aws_data_source" "foo" {
+    foo = "bar"
+}
+
+resource "aws_instance" "foo" {
+    count = "${length(data.aws_data_source.foo.names)}"
+    foo = "${element(data.aws_data_source.foo.names, count.index)}"
+}
@@ -0,0 +1,7 @@
+data "aws_data_source" "foo" {
+    foo = "bar"
+}
+
+resource "aws_instance" "foo" {
+    foo = "${data.aws_data_source.foo.foo}"
+}
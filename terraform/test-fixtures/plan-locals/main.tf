@@ -0,0 +1,15 @@
+variable "env" {
+    default = "prod"
+}
+
+locals {
+    prefix = "app-${var.env}"
+}
+
+locals {
+    name = "${local.prefix}-web"
+}
+
+resource "aws_instance" "foo" {
+    foo = "${local.name}"
+}
@@ -0,0 +1,3 @@
+data "aws_data_source" "foo" {
+    foo = "bar"
+}
@@ -0,0 +1,9 @@
+resource "aws_instance" "A" {}
+
+resource "aws_instance" "B" {
+    foo = "${aws_instance.A.id}"
+}
+
+resource "aws_instance" "C" {
+    foo = "${aws_instance.B.id}"
+}
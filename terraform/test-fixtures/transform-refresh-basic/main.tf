@@ -0,0 +1,21 @@
+variable "count" { default = 2 }
+
+resource "aws_instance" "A" {
+    count = "${var.count}"
+}
+
+resource "aws_instance" "B" {
+    A = "${aws_instance.A.0.id}"
+}
+
+data "aws_ami" "D" {
+    B = "${aws_instance.B.id}"
+}
+
+resource "aws_instance" "C" {
+    ami = "${data.aws_ami.D.id}"
+}
+
+resource "aws_instance" "E" {
+    count = "${length(data.aws_ami.D.ids)}"
+}
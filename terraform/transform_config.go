@@ -3,6 +3,7 @@ package terraform
 import (
 	"errors"
 	"fmt"
+	"strings"
 
 	"github.com/hashicorp/go-multierror"
 	"github.com/hashicorp/terraform/config"
@@ -41,6 +42,7 @@ func (t *ConfigTransformer) Transform(g *Graph) error {
 			len(config.ProviderConfigs)+
 			len(config.Modules)+
 			len(config.Resources)+
+			len(config.Locals)+
 			len(config.Outputs))*2)
 
 	// Write all the variables out
@@ -72,6 +74,11 @@ func (t *ConfigTransformer) Transform(g *Graph) error {
 		})
 	}
 
+	// Write all the local values out
+	for _, l := range config.Locals {
+		nodes = append(nodes, &GraphNodeConfigLocal{Local: l})
+	}
+
 	// Write all the outputs out
 	for _, o := range config.Outputs {
 		nodes = append(nodes, &GraphNodeConfigOutput{Output: o})
@@ -104,8 +111,16 @@ func (t *ConfigTransformer) Transform(g *Graph) error {
 // graph to build the graph edges.
 func varNameForVar(raw config.InterpolatedVariable) string {
 	switch v := raw.(type) {
+	case *config.LocalVariable:
+		return fmt.Sprintf("local.%s", v.Name)
 	case *config.ModuleVariable:
-		return fmt.Sprintf("module.%s.output.%s", v.Name, v.Field)
+		// Elements of map outputs depend on the whole output
+		field := v.Field
+		if idx := strings.Index(field, "."); idx >= 0 {
+			field = field[:idx]
+		}
+
+		return fmt.Sprintf("module.%s.output.%s", v.Name, field)
 	case *config.ResourceVariable:
 		return v.ResourceId()
 	case *config.UserVariable:
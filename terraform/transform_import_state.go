@@ -0,0 +1,142 @@
+package terraform
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform/config"
+	"github.com/hashicorp/terraform/config/module"
+)
+
+// ImportTarget is a resource to import into the state: the address of the
+// resource in the configuration, and the ID of the existing resource, as
+// known by its provider.
+type ImportTarget struct {
+	Addr string
+	ID   string
+}
+
+// ImportStateTransformer is a GraphTransformer that adds a node to the
+// graph for each resource to import. Only resources of the root module
+// can be imported.
+type ImportStateTransformer struct {
+	Targets []*ImportTarget
+	Module  *module.Tree
+}
+
+func (t *ImportStateTransformer) Transform(g *Graph) error {
+	if len(t.Targets) == 0 || len(g.Path) > 1 {
+		return nil
+	}
+
+	for _, target := range t.Targets {
+		n, err := importStateNode(t.Module, target)
+		if err != nil {
+			return err
+		}
+
+		g.Add(n)
+	}
+
+	return nil
+}
+
+// importStateNode returns the node that imports the given target, which
+// must be a resource of the configuration of the given module.
+func importStateNode(
+	m *module.Tree, target *ImportTarget) (*graphNodeImportState, error) {
+	addr, err := ParseResourceAddress(target.Addr)
+	if err != nil {
+		return nil, err
+	}
+	if len(addr.Path) > 0 {
+		return nil, fmt.Errorf(
+			"%s: resources in modules can't be imported", target.Addr)
+	}
+	if addr.Mode == config.DataResourceMode {
+		return nil, fmt.Errorf(
+			"%s: data sources can't be imported", target.Addr)
+	}
+	if addr.InstanceType != TypePrimary {
+		return nil, fmt.Errorf(
+			"%s: only primary instances can be imported", target.Addr)
+	}
+
+	var resource *config.Resource
+	if m != nil && m.Config() != nil {
+		for _, r := range m.Config().Resources {
+			if r.Mode == addr.Mode && r.Type == addr.Type && r.Name == addr.Name {
+				resource = r
+				break
+			}
+		}
+	}
+	if resource == nil {
+		return nil, fmt.Errorf(
+			"%s: resource not found in the configuration. Add it to the "+
+				"configuration before importing it.", target.Addr)
+	}
+
+	// A resource with a count of one is known by its name alone in the
+	// state, just like it is when the count is expanded.
+	index := addr.Index
+	if count, err := resource.Count(); err == nil && count == 1 {
+		index = -1
+	}
+
+	return &graphNodeImportState{
+		ID: target.ID,
+		resource: &graphNodeExpandedResource{
+			Index:    index,
+			Resource: resource,
+		},
+	}, nil
+}
+
+// graphNodeImportState is the node that imports a resource into the state.
+type graphNodeImportState struct {
+	ID string
+
+	resource *graphNodeExpandedResource
+}
+
+func (n *graphNodeImportState) Name() string {
+	return fmt.Sprintf("%s (import %s)", n.resource.Name(), n.ID)
+}
+
+// GraphNodeProviderConsumer impl.
+func (n *graphNodeImportState) ProvidedBy() []string {
+	return n.resource.ProvidedBy()
+}
+
+// GraphNodeEvalable impl.
+func (n *graphNodeImportState) EvalTree() EvalNode {
+	var provider ResourceProvider
+	var state *InstanceState
+
+	info := n.resource.instanceInfo()
+	return &EvalOpFilter{
+		Ops: []walkOperation{walkImport},
+		Node: &EvalSequence{
+			Nodes: []EvalNode{
+				&EvalInstanceInfo{Info: info},
+				&EvalGetProvider{
+					Name:   n.ProvidedBy()[0],
+					Output: &provider,
+				},
+				&EvalImportState{
+					Provider: &provider,
+					Info:     info,
+					Id:       n.ID,
+					Output:   &state,
+				},
+				&EvalWriteState{
+					Name:         n.resource.stateId(),
+					ResourceType: n.resource.Resource.Type,
+					Provider:     n.resource.Resource.Provider,
+					Dependencies: n.resource.StateDependencies(),
+					State:        &state,
+				},
+			},
+		},
+	}
+}
@@ -3,6 +3,7 @@ package terraform
 import (
 	"fmt"
 	"log"
+	"strings"
 
 	"github.com/hashicorp/terraform/config"
 	"github.com/hashicorp/terraform/config/module"
@@ -42,7 +43,7 @@ func (t *OrphanTransformer) Transform(g *Graph) error {
 	}
 
 	if t.Targeting {
-		log.Printf("Skipping orphan transformer because we have targets.")
+		log.Printf("core: Skipping orphan transformer because we have targets.")
 		// If we are in a run where we are targeting nodes, we won't process
 		// orphans for this run.
 		return nil
@@ -181,11 +182,19 @@ func (n *graphNodeOrphanResource) Name() string {
 }
 
 func (n *graphNodeOrphanResource) ProvidedBy() []string {
+	if n.isDataSource() {
+		return []string{resourceProvider(n.ResourceType, n.Provider)}
+	}
+
 	return []string{resourceProvider(n.ResourceName, n.Provider)}
 }
 
 // GraphNodeEvalable impl.
 func (n *graphNodeOrphanResource) EvalTree() EvalNode {
+	if n.isDataSource() {
+		return n.dataSourceEvalTree()
+	}
+
 	var provider ResourceProvider
 	var state *InstanceState
 
@@ -287,6 +296,75 @@ func (n *graphNodeOrphanResource) EvalTree() EvalNode {
 	return seq
 }
 
+// dataSourceEvalTree is the EvalTree of an orphan data source, which is
+// only removed from the state, without calling its provider.
+func (n *graphNodeOrphanResource) dataSourceEvalTree() EvalNode {
+	var diff *InstanceDiff
+	var state *InstanceState
+
+	info := &InstanceInfo{Id: n.ResourceName, Type: n.ResourceType}
+	return &EvalSequence{
+		Nodes: []EvalNode{
+			&EvalInstanceInfo{Info: info},
+			&EvalOpFilter{
+				Ops: []walkOperation{walkPlan, walkPlanDestroy},
+				Node: &EvalSequence{
+					Nodes: []EvalNode{
+						&EvalReadState{
+							Name:   n.ResourceName,
+							Output: &state,
+						},
+						&EvalDiffDestroy{
+							Info:   info,
+							State:  &state,
+							Output: &diff,
+						},
+						&EvalWriteDiff{
+							Name: n.ResourceName,
+							Diff: &diff,
+						},
+					},
+				},
+			},
+			&EvalOpFilter{
+				Ops: []walkOperation{walkApply},
+				Node: &EvalSequence{
+					Nodes: []EvalNode{
+						&EvalReadDiff{
+							Name: n.ResourceName,
+							Diff: &diff,
+						},
+						&EvalIf{
+							If: func(ctx EvalContext) (bool, error) {
+								if diff != nil && diff.Destroy {
+									return true, nil
+								}
+
+								return true, EvalEarlyExitError{}
+							},
+							Then: EvalNoop{},
+						},
+						&EvalWriteState{
+							Name:         n.ResourceName,
+							ResourceType: n.ResourceType,
+							Provider:     n.Provider,
+							Dependencies: n.DependentOn(),
+							State:        &state,
+						},
+						&EvalUpdateStateHook{},
+					},
+				},
+			},
+		},
+	}
+}
+
 func (n *graphNodeOrphanResource) dependableName() string {
 	return n.ResourceName
 }
+
+// isDataSource returns whether the orphan is a data source, whose state
+// is kept under a name that starts with "data.".
+func (n *graphNodeOrphanResource) isDataSource() bool {
+	return strings.HasPrefix(n.ResourceName, "data.")
+}
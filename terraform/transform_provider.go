@@ -152,7 +152,7 @@ func (n *graphNodeDisabledProvider) EvalTree() EvalNode {
 	var resourceConfig *ResourceConfig
 
 	return &EvalOpFilter{
-		Ops: []walkOperation{walkInput, walkValidate, walkRefresh, walkPlan, walkApply},
+		Ops: []walkOperation{walkInput, walkValidate, walkRefresh, walkPlan, walkApply, walkImport},
 		Node: &EvalSequence{
 			Nodes: []EvalNode{
 				&EvalInterpolate{
@@ -215,7 +215,7 @@ func (n *graphNodeMissingProvider) Name() string {
 
 // GraphNodeEvalable impl.
 func (n *graphNodeMissingProvider) EvalTree() EvalNode {
-	return ProviderEvalTree(n.ProviderNameValue, nil)
+	return ProviderEvalTree(n.ProviderNameValue, nil, "")
 }
 
 // GraphNodeDependable impl.
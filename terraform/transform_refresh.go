@@ -0,0 +1,93 @@
+package terraform
+
+import (
+	"github.com/hashicorp/terraform/config"
+	"github.com/hashicorp/terraform/dag"
+)
+
+// RefreshTransformer is a GraphTransformer that removes the dependencies
+// of managed resources on other resources, so that they are refreshed
+// concurrently: refreshing a managed resource only reads its own state
+// from its provider, whatever the state of the resources it references.
+//
+// Data sources keep their dependencies, since they are read with their
+// interpolated configuration, as do the dependencies on anything that
+// isn't a resource, such as the count of a resource on a variable, and
+// the dependencies of the count of a resource on data sources.
+type RefreshTransformer struct{}
+
+func (t *RefreshTransformer) Transform(g *Graph) error {
+	for _, e := range g.Edges() {
+		managed, ok := refreshResourceNode(e.Source())
+		if !ok || !managed {
+			continue
+		}
+		if _, ok := refreshResourceNode(e.Target()); !ok {
+			continue
+		}
+		if refreshCountDependsOn(e.Source(), e.Target()) {
+			continue
+		}
+
+		g.RemoveEdge(e)
+	}
+
+	return nil
+}
+
+// refreshResourceNode returns whether the vertex is the node of a managed
+// resource, and whether it is the node of a resource at all, as opposed
+// to the destroy node of a resource, a provider, a variable and such.
+func refreshResourceNode(v dag.Vertex) (bool, bool) {
+	switch n := v.(type) {
+	case *GraphNodeConfigResource:
+		if n.DestroyMode != DestroyNone {
+			return false, false
+		}
+		return n.Resource.Mode == config.ManagedResourceMode, true
+	case *GraphNodeConfigResourceFlat:
+		return refreshResourceNode(n.GraphNodeConfigResource)
+	case *graphNodeOrphanResource:
+		return !n.isDataSource(), true
+	default:
+		return false, false
+	}
+}
+
+// refreshCountDependsOn returns whether the count of the resource of the
+// source vertex references the target vertex, which then has to be read
+// first to expand the count.
+func refreshCountDependsOn(source, target dag.Vertex) bool {
+	dependable, ok := target.(GraphNodeDependable)
+	if !ok {
+		return false
+	}
+
+	var prefix string
+	var n *GraphNodeConfigResource
+	switch v := source.(type) {
+	case *GraphNodeConfigResource:
+		n = v
+	case *GraphNodeConfigResourceFlat:
+		n = v.GraphNodeConfigResource
+		prefix = modulePrefixStr(v.PathValue)
+	default:
+		return false
+	}
+
+	names := make([]string, 0, len(n.Resource.RawCount.Variables))
+	for _, v := range n.Resource.RawCount.Variables {
+		if vn := varNameForVar(v); vn != "" {
+			names = append(names, vn)
+		}
+	}
+	for _, name := range modulePrefixList(names, prefix) {
+		for _, dn := range dependable.DependableName() {
+			if name == dn {
+				return true
+			}
+		}
+	}
+
+	return false
+}
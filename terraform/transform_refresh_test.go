@@ -0,0 +1,43 @@
+package terraform
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRefreshTransformer(t *testing.T) {
+	mod := testModule(t, "transform-refresh-basic")
+
+	g := Graph{Path: RootModulePath}
+	{
+		tf := &ConfigTransformer{Module: mod}
+		if err := tf.Transform(&g); err != nil {
+			t.Fatalf("err: %s", err)
+		}
+	}
+
+	{
+		tf := &RefreshTransformer{}
+		if err := tf.Transform(&g); err != nil {
+			t.Fatalf("err: %s", err)
+		}
+	}
+
+	actual := strings.TrimSpace(g.String())
+	expected := strings.TrimSpace(testTransformRefreshBasicStr)
+	if actual != expected {
+		t.Fatalf("bad:\n\n%s", actual)
+	}
+}
+
+const testTransformRefreshBasicStr = `
+aws_instance.A
+  var.count
+aws_instance.B
+aws_instance.C
+aws_instance.E
+  data.aws_ami.D
+data.aws_ami.D
+  aws_instance.B
+var.count
+`
@@ -112,6 +112,7 @@ func (n *graphNodeExpandedResource) ResourceAddress() *ResourceAddress {
 		index = 0
 	}
 	return &ResourceAddress{
+		Mode:  n.Resource.Mode,
 		Index: index,
 		// TODO: kjkjkj
 		InstanceType: TypePrimary,
@@ -193,6 +194,10 @@ func (n *graphNodeExpandedResource) StateDependencies() []string {
 
 // GraphNodeEvalable impl.
 func (n *graphNodeExpandedResource) EvalTree() EvalNode {
+	if n.Resource.Mode == config.DataResourceMode {
+		return n.dataSourceEvalTree()
+	}
+
 	var diff *InstanceDiff
 	var provider ResourceProvider
 	var resourceConfig *ResourceConfig
@@ -546,6 +551,133 @@ func (n *graphNodeExpandedResource) EvalTree() EvalNode {
 	return seq
 }
 
+// dataSourceEvalTree is the EvalTree of a data source. Data sources
+// aren't diffed and applied: they are read whenever the graph is
+// refreshed, planned or applied, as soon as their configuration is known,
+// and their state is just removed when they are destroyed.
+func (n *graphNodeExpandedResource) dataSourceEvalTree() EvalNode {
+	var diff *InstanceDiff
+	var provider ResourceProvider
+	var resourceConfig *ResourceConfig
+	var state *InstanceState
+
+	index := n.Index
+	if index < 0 {
+		index = 0
+	}
+	resource := &Resource{
+		Name:       n.Resource.Name,
+		Type:       n.Resource.Type,
+		CountIndex: index,
+	}
+
+	info := n.instanceInfo()
+	return &EvalSequence{
+		Nodes: []EvalNode{
+			// Validate the data source
+			&EvalOpFilter{
+				Ops: []walkOperation{walkValidate},
+				Node: &EvalSequence{
+					Nodes: []EvalNode{
+						&EvalGetProvider{
+							Name:   n.ProvidedBy()[0],
+							Output: &provider,
+						},
+						&EvalInterpolate{
+							Config:   n.Resource.RawConfig.Copy(),
+							Resource: resource,
+							Output:   &resourceConfig,
+						},
+						&EvalValidateDataSource{
+							Provider:     &provider,
+							Config:       &resourceConfig,
+							ResourceName: n.Resource.Name,
+							ResourceType: n.Resource.Type,
+						},
+					},
+				},
+			},
+
+			&EvalInstanceInfo{Info: info},
+
+			// Read the data source
+			&EvalOpFilter{
+				Ops: []walkOperation{walkRefresh, walkPlan, walkApply},
+				Node: &EvalSequence{
+					Nodes: []EvalNode{
+						// Don't read it again if it is being destroyed
+						&EvalOpFilter{
+							Ops: []walkOperation{walkApply},
+							Node: &EvalSequence{
+								Nodes: []EvalNode{
+									&EvalReadDiff{
+										Name: n.stateId(),
+										Diff: &diff,
+									},
+									&EvalIf{
+										If: func(ctx EvalContext) (bool, error) {
+											if diff != nil && diff.Destroy {
+												return true, EvalEarlyExitError{}
+											}
+
+											return true, nil
+										},
+										Then: EvalNoop{},
+									},
+								},
+							},
+						},
+						&EvalInterpolate{
+							Config:   n.Resource.RawConfig.Copy(),
+							Resource: resource,
+							Output:   &resourceConfig,
+						},
+						&EvalGetProvider{
+							Name:   n.ProvidedBy()[0],
+							Output: &provider,
+						},
+						&EvalReadDataSource{
+							Provider: &provider,
+							Config:   &resourceConfig,
+							Info:     info,
+							Output:   &state,
+						},
+						&EvalWriteState{
+							Name:         n.stateId(),
+							ResourceType: n.Resource.Type,
+							Provider:     n.Resource.Provider,
+							Dependencies: n.StateDependencies(),
+							State:        &state,
+						},
+					},
+				},
+			},
+
+			// Plan the removal of the data source from the state
+			&EvalOpFilter{
+				Ops: []walkOperation{walkPlanDestroy},
+				Node: &EvalSequence{
+					Nodes: []EvalNode{
+						&EvalReadState{
+							Name:   n.stateId(),
+							Output: &state,
+						},
+						&EvalDiffDestroy{
+							Info:   info,
+							State:  &state,
+							Output: &diff,
+						},
+						&EvalWriteDiff{
+							Name: n.stateId(),
+							Diff: &diff,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
 // instanceInfo is used for EvalTree.
 func (n *graphNodeExpandedResource) instanceInfo() *InstanceInfo {
 	return &InstanceInfo{Id: n.stateId(), Type: n.Resource.Type}
@@ -582,6 +714,10 @@ func (n *graphNodeExpandedResourceDestroy) ConfigType() GraphNodeConfigType {
 
 // GraphNodeEvalable impl.
 func (n *graphNodeExpandedResourceDestroy) EvalTree() EvalNode {
+	if n.Resource.Mode == config.DataResourceMode {
+		return n.dataSourceEvalTree()
+	}
+
 	info := n.instanceInfo()
 
 	var diffApply *InstanceDiff
@@ -652,3 +788,43 @@ func (n *graphNodeExpandedResourceDestroy) EvalTree() EvalNode {
 		},
 	}
 }
+
+// dataSourceEvalTree is the EvalTree that destroys a data source, which
+// is only removed from the state: there is nothing to destroy.
+func (n *graphNodeExpandedResourceDestroy) dataSourceEvalTree() EvalNode {
+	var diffApply *InstanceDiff
+	var state *InstanceState
+	return &EvalOpFilter{
+		Ops: []walkOperation{walkApply},
+		Node: &EvalSequence{
+			Nodes: []EvalNode{
+				&EvalReadDiff{
+					Name: n.stateId(),
+					Diff: &diffApply,
+				},
+				&EvalFilterDiff{
+					Diff:    &diffApply,
+					Output:  &diffApply,
+					Destroy: true,
+				},
+				&EvalIf{
+					If: func(ctx EvalContext) (bool, error) {
+						if diffApply != nil && diffApply.Destroy {
+							return true, nil
+						}
+
+						return true, EvalEarlyExitError{}
+					},
+					Then: EvalNoop{},
+				},
+				&EvalWriteState{
+					Name:         n.stateId(),
+					ResourceType: n.Resource.Type,
+					Provider:     n.Resource.Provider,
+					Dependencies: n.StateDependencies(),
+					State:        &state,
+				},
+			},
+		},
+	}
+}
@@ -1,6 +1,10 @@
 package terraform
 
-import "github.com/hashicorp/terraform/dag"
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform/dag"
+)
 
 // TargetsTransformer is a GraphTransformer that, when the user specifies a
 // list of resources to target, limits the graph to only those resources and
@@ -45,6 +49,10 @@ func (t *TargetsTransformer) parseTargetAddresses() ([]ResourceAddress, error) {
 		if err != nil {
 			return nil, err
 		}
+		if len(ta.Path) > 0 {
+			return nil, fmt.Errorf(
+				"Resources in modules can't be targeted: %s", target)
+		}
 		addrs[i] = *ta
 	}
 	return addrs, nil
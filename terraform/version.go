@@ -0,0 +1,22 @@
+package terraform
+
+import "fmt"
+
+// The main version number that is being run at the moment. This is also
+// the version of the plugins that are built into Terraform.
+const Version = "0.5.0"
+
+// A pre-release marker for the version. If this is "" (empty string)
+// then it means that it is a final release. Otherwise, this is a pre-release
+// such as "dev" (in development), "beta", "rc1", etc.
+const VersionPrerelease = "dev"
+
+// VersionString returns the complete version, including the pre-release
+// marker if there is one, such as "0.5.0-dev".
+func VersionString() string {
+	if VersionPrerelease != "" {
+		return fmt.Sprintf("%s-%s", Version, VersionPrerelease)
+	}
+
+	return Version
+}
@@ -4,9 +4,9 @@ package terraform
 
 import "fmt"
 
-const _walkOperation_name = "walkInvalidwalkInputwalkApplywalkPlanwalkPlanDestroywalkRefreshwalkValidate"
+const _walkOperation_name = "walkInvalidwalkInputwalkApplywalkPlanwalkPlanDestroywalkRefreshwalkValidatewalkImport"
 
-var _walkOperation_index = [...]uint8{0, 11, 20, 29, 37, 52, 63, 75}
+var _walkOperation_index = [...]uint8{0, 11, 20, 29, 37, 52, 63, 75, 85}
 
 func (i walkOperation) String() string {
 	if i+1 >= walkOperation(len(_walkOperation_index)) {
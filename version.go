@@ -1,12 +1,15 @@
 package main
 
+import "github.com/hashicorp/terraform/terraform"
+
 // The git commit that was compiled. This will be filled in by the compiler.
 var GitCommit string
 
-// The main version number that is being run at the moment.
-const Version = "0.5.0"
+// The main version number that is being run at the moment. This is kept
+// in the terraform package so that the builtin plugins can report it.
+const Version = terraform.Version
 
 // A pre-release marker for the version. If this is "" (empty string)
 // then it means that it is a final release. Otherwise, this is a pre-release
 // such as "dev" (in development), "beta", "rc1", etc.
-const VersionPrerelease = "dev"
+const VersionPrerelease = terraform.VersionPrerelease